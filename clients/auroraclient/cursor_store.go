@@ -0,0 +1,202 @@
+package auroraclient
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/shantanu-hashcash/go/support/db"
+	"github.com/shantanu-hashcash/go/support/errors"
+)
+
+// CursorStore persists the paging token of the last event processed by a
+// ResilientStream so that streaming can resume from the same position after
+// a reconnect or a process restart. Keys identify independent streams.
+type CursorStore interface {
+	// Load returns the stored cursor for key, or an empty string if no cursor
+	// has been saved yet.
+	Load(ctx context.Context, key string) (string, error)
+	// Save stores cursor as the latest processed position for key.
+	Save(ctx context.Context, key, cursor string) error
+}
+
+// TxCursorStore is a CursorStore that can save a cursor in the database
+// transaction an event is handled in. It is required by the Stream*Tx methods
+// of ResilientStream.
+type TxCursorStore interface {
+	CursorStore
+	// InTx calls fn in a new transaction, saves cursor for key in the same
+	// transaction and commits it. Nothing is committed if fn fails. An empty
+	// cursor is not saved.
+	InTx(ctx context.Context, key, cursor string, fn func(session db.SessionInterface) error) error
+}
+
+// MemoryCursorStore is a CursorStore that keeps cursors in memory. It
+// survives reconnects but not process restarts.
+type MemoryCursorStore struct {
+	mutex   sync.Mutex
+	cursors map[string]string
+}
+
+// NewMemoryCursorStore returns an empty MemoryCursorStore.
+func NewMemoryCursorStore() *MemoryCursorStore {
+	return &MemoryCursorStore{cursors: map[string]string{}}
+}
+
+// Load implements CursorStore.
+func (s *MemoryCursorStore) Load(ctx context.Context, key string) (string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.cursors[key], nil
+}
+
+// Save implements CursorStore.
+func (s *MemoryCursorStore) Save(ctx context.Context, key, cursor string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.cursors == nil {
+		s.cursors = map[string]string{}
+	}
+	s.cursors[key] = cursor
+	return nil
+}
+
+// FileCursorStore is a CursorStore that keeps one file per key in Dir.
+// Cursors are written to a temporary file first and then renamed so a crash
+// never leaves a partially written cursor behind.
+type FileCursorStore struct {
+	Dir string
+}
+
+var invalidCursorKeyChars = regexp.MustCompile(`[^a-zA-Z0-9_.-]`)
+
+func (s FileCursorStore) path(key string) (string, error) {
+	if key == "" {
+		return "", errors.New("cursor key cannot be empty")
+	}
+	return filepath.Join(s.Dir, invalidCursorKeyChars.ReplaceAllString(key, "_")+".cursor"), nil
+}
+
+// Load implements CursorStore.
+func (s FileCursorStore) Load(ctx context.Context, key string) (string, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return "", err
+	}
+	contents, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	} else if err != nil {
+		return "", errors.Wrapf(err, "error reading cursor file %s", path)
+	}
+	return strings.TrimSpace(string(contents)), nil
+}
+
+// Save implements CursorStore.
+func (s FileCursorStore) Save(ctx context.Context, key, cursor string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(s.Dir, 0o755); err != nil {
+		return errors.Wrapf(err, "error creating cursor directory %s", s.Dir)
+	}
+
+	tmp, err := os.CreateTemp(s.Dir, filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return errors.Wrap(err, "error creating temporary cursor file")
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.WriteString(cursor); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "error writing cursor")
+	}
+	if err = tmp.Sync(); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "error syncing cursor file")
+	}
+	if err = tmp.Close(); err != nil {
+		return errors.Wrap(err, "error closing cursor file")
+	}
+	return errors.Wrap(os.Rename(tmp.Name(), path), "error replacing cursor file")
+}
+
+// SQLCursorStore is a CursorStore backed by a Postgres table. The table must
+// have the following shape (the name is configurable with Table):
+//
+//	CREATE TABLE stream_cursors (
+//	    key text PRIMARY KEY,
+//	    cursor text NOT NULL
+//	);
+//
+// When events are written to the same database, the Stream*Tx methods of
+// ResilientStream use InTx to persist the cursor atomically with the
+// processed data. SaveTx does the same for transactions managed by the
+// caller.
+type SQLCursorStore struct {
+	Session db.SessionInterface
+	// Table defaults to "stream_cursors".
+	Table string
+}
+
+func (s SQLCursorStore) table() string {
+	if s.Table == "" {
+		return "stream_cursors"
+	}
+	return s.Table
+}
+
+// Load implements CursorStore.
+func (s SQLCursorStore) Load(ctx context.Context, key string) (string, error) {
+	var cursor string
+	err := s.Session.GetRaw(ctx, &cursor, fmt.Sprintf("SELECT cursor FROM %s WHERE key = ?", s.table()), key)
+	if s.Session.NoRows(err) {
+		return "", nil
+	}
+	return cursor, errors.Wrap(err, "error loading cursor")
+}
+
+// Save implements CursorStore.
+func (s SQLCursorStore) Save(ctx context.Context, key, cursor string) error {
+	return s.SaveTx(ctx, s.Session, key, cursor)
+}
+
+// SaveTx stores cursor for key using session, which is usually in the
+// transaction writing the processed data.
+func (s SQLCursorStore) SaveTx(ctx context.Context, session db.SessionInterface, key, cursor string) error {
+	_, err := session.ExecRaw(ctx, s.upsertQuery(), key, cursor)
+	return errors.Wrap(err, "error saving cursor")
+}
+
+// InTx implements TxCursorStore. The transaction is started on a clone of
+// Session.
+func (s SQLCursorStore) InTx(ctx context.Context, key, cursor string, fn func(session db.SessionInterface) error) error {
+	session := s.Session.Clone()
+	if err := session.Begin(ctx); err != nil {
+		return errors.Wrap(err, "error starting transaction")
+	}
+	defer session.Rollback()
+
+	if err := fn(session); err != nil {
+		return err
+	}
+	if cursor != "" {
+		if err := s.SaveTx(ctx, session, key, cursor); err != nil {
+			return err
+		}
+	}
+	return errors.Wrap(session.Commit(), "error committing transaction")
+}
+
+func (s SQLCursorStore) upsertQuery() string {
+	return fmt.Sprintf(
+		"INSERT INTO %s (key, cursor) VALUES (?, ?) "+
+			"ON CONFLICT (key) DO UPDATE SET cursor = EXCLUDED.cursor",
+		s.table(),
+	)
+}
@@ -0,0 +1,373 @@
+package auroraclient
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+
+	hProtocol "github.com/shantanu-hashcash/go/protocols/aurora"
+	"github.com/shantanu-hashcash/go/protocols/aurora/effects"
+	"github.com/shantanu-hashcash/go/protocols/aurora/operations"
+	"github.com/shantanu-hashcash/go/support/db"
+	"github.com/shantanu-hashcash/go/support/errors"
+)
+
+const (
+	defaultStreamMinBackoff = time.Second
+	defaultStreamMaxBackoff = time.Minute
+)
+
+// ResilientStream wraps the streaming functions of Client. Unlike the plain
+// Stream* methods, which return as soon as the connection fails, a
+// ResilientStream reconnects with jittered exponential backoff and resumes
+// from the paging token of the last event delivered to the handler.
+//
+// When Store is set, the paging token is persisted under Key after every
+// handled event and loaded again on start, so a restarted process resumes
+// after the last saved event. Events at or before the cursor are never
+// delivered. The Stream* methods save the cursor after the handler returns,
+// so an event is handled again if the process dies in between. The Stream*Tx
+// methods require a TxCursorStore and run the handler in the transaction the
+// cursor is saved in, so every event is processed exactly once.
+type ResilientStream struct {
+	Client *Client
+	// Store persists cursors between restarts. Defaults to an in-memory
+	// store, which only survives reconnects.
+	Store CursorStore
+	// Key identifies this stream in Store.
+	Key string
+	// MinBackoff and MaxBackoff bound the delay between reconnect attempts.
+	// They default to 1 second and 1 minute respectively.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// MaxRetries limits consecutive failed connection attempts. Zero means
+	// retry until the context is canceled.
+	MaxRetries uint64
+	// OnError, when set, is called with every error that triggers a
+	// reconnect.
+	OnError func(err error)
+
+	once sync.Once
+}
+
+func (s *ResilientStream) init() {
+	s.once.Do(func() {
+		if s.Store == nil {
+			s.Store = NewMemoryCursorStore()
+		}
+		if s.MinBackoff == 0 {
+			s.MinBackoff = defaultStreamMinBackoff
+		}
+		if s.MaxBackoff == 0 {
+			s.MaxBackoff = defaultStreamMaxBackoff
+		}
+	})
+}
+
+func (s *ResilientStream) newBackOff() backoff.BackOff {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = s.MinBackoff
+	b.MaxInterval = s.MaxBackoff
+	b.MaxElapsedTime = 0
+	b.Reset()
+	if s.MaxRetries > 0 {
+		return backoff.WithMaxRetries(b, s.MaxRetries)
+	}
+	return b
+}
+
+// handleFunc forwards an event to the user handler. session is the
+// transaction the cursor is saved in, or nil for non transactional handlers.
+type handleFunc func(session db.SessionInterface) error
+
+// streamFunc opens a single stream starting at cursor. It must call deliver
+// for every received event with the event's paging token and the function
+// forwarding the event to the user handler.
+type streamFunc func(ctx context.Context, cursor string, deliver func(token string, handle handleFunc)) error
+
+// run drives open until ctx is canceled, reconnecting on failure and keeping
+// the cursor in s.Store up to date. When transactional is set, events are
+// handled with TxCursorStore.InTx.
+func (s *ResilientStream) run(ctx context.Context, initialCursor string, transactional bool, open streamFunc) error {
+	if s.Client == nil {
+		return errors.New("client cannot be nil")
+	}
+	s.init()
+
+	var txStore TxCursorStore
+	if transactional {
+		var ok bool
+		if txStore, ok = s.Store.(TxCursorStore); !ok {
+			return errors.New("store does not support transactions")
+		}
+	}
+
+	cursor, err := s.Store.Load(ctx, s.Key)
+	if err != nil {
+		return errors.Wrap(err, "unable to load cursor")
+	}
+	if cursor == "" {
+		cursor = initialCursor
+	}
+
+	b := s.newBackOff()
+	for {
+		streamCtx, cancel := context.WithCancel(ctx)
+		var handleErr error
+		received := false
+
+		err = open(streamCtx, cursor, func(token string, handle handleFunc) {
+			if streamCtx.Err() != nil || (token != "" && !cursorAfter(token, cursor)) {
+				// Either handling a previous event failed or the event was
+				// already handled before the last reconnect or restart.
+				return
+			}
+			// The handler may cancel ctx to stop streaming, the cursor of
+			// the event it just handled must still be saved.
+			saveCtx := context.WithoutCancel(ctx)
+			if txStore != nil {
+				handleErr = errors.Wrap(txStore.InTx(saveCtx, s.Key, token, handle), "unable to handle event")
+			} else if handleErr = handle(nil); handleErr == nil && token != "" {
+				handleErr = errors.Wrap(s.Store.Save(saveCtx, s.Key, token), "unable to save cursor")
+			}
+			if handleErr != nil {
+				cancel()
+				return
+			}
+			received = true
+			if token != "" {
+				cursor = token
+			}
+		})
+		cancel()
+
+		if handleErr != nil {
+			return handleErr
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err == nil {
+			err = errors.New("stream closed unexpectedly")
+		}
+		if received {
+			b.Reset()
+		}
+		if s.OnError != nil {
+			s.OnError(err)
+		}
+
+		wait := b.NextBackOff()
+		if wait == backoff.Stop {
+			return errors.Wrap(err, "maximum number of retries reached")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(wait):
+		}
+	}
+}
+
+// cursorAfter reports whether the paging token is a later position than
+// cursor. Paging tokens are decimal numbers, followed by "-" and an order for
+// effects and trades, so they are compared numerically part by part. A cursor
+// that is not a paging token, like "now", is before every token.
+func cursorAfter(token, cursor string) bool {
+	t, ok := parsePagingToken(token)
+	if !ok {
+		return true
+	}
+	c, ok := parsePagingToken(cursor)
+	if !ok {
+		return true
+	}
+	if t[0] != c[0] {
+		return t[0] > c[0]
+	}
+	return t[1] > c[1]
+}
+
+func parsePagingToken(token string) ([2]uint64, bool) {
+	var parsed [2]uint64
+	id, order, hasOrder := strings.Cut(token, "-")
+	var err error
+	if parsed[0], err = strconv.ParseUint(id, 10, 64); err != nil {
+		return parsed, false
+	}
+	if hasOrder {
+		if parsed[1], err = strconv.ParseUint(order, 10, 64); err != nil {
+			return parsed, false
+		}
+	}
+	return parsed, true
+}
+
+// StreamTransactions is the resilient equivalent of Client.StreamTransactions.
+// request.Cursor is only used when no cursor has been stored yet.
+func (s *ResilientStream) StreamTransactions(ctx context.Context, request TransactionRequest, handler TransactionHandler) error {
+	return s.run(ctx, request.Cursor, false, func(ctx context.Context, cursor string, deliver func(string, handleFunc)) error {
+		request.Cursor = cursor
+		return s.Client.StreamTransactions(ctx, request, func(tx hProtocol.Transaction) {
+			deliver(tx.PagingToken(), func(db.SessionInterface) error {
+				handler(tx)
+				return nil
+			})
+		})
+	})
+}
+
+// StreamOperations is the resilient equivalent of Client.StreamOperations.
+// request.Cursor is only used when no cursor has been stored yet.
+func (s *ResilientStream) StreamOperations(ctx context.Context, request OperationRequest, handler OperationHandler) error {
+	return s.run(ctx, request.Cursor, false, func(ctx context.Context, cursor string, deliver func(string, handleFunc)) error {
+		request.Cursor = cursor
+		return s.Client.StreamOperations(ctx, request, func(op operations.Operation) {
+			deliver(op.PagingToken(), func(db.SessionInterface) error {
+				handler(op)
+				return nil
+			})
+		})
+	})
+}
+
+// StreamPayments is the resilient equivalent of Client.StreamPayments.
+// request.Cursor is only used when no cursor has been stored yet.
+func (s *ResilientStream) StreamPayments(ctx context.Context, request OperationRequest, handler OperationHandler) error {
+	return s.run(ctx, request.Cursor, false, func(ctx context.Context, cursor string, deliver func(string, handleFunc)) error {
+		request.Cursor = cursor
+		return s.Client.StreamPayments(ctx, request, func(op operations.Operation) {
+			deliver(op.PagingToken(), func(db.SessionInterface) error {
+				handler(op)
+				return nil
+			})
+		})
+	})
+}
+
+// StreamEffects is the resilient equivalent of Client.StreamEffects.
+// request.Cursor is only used when no cursor has been stored yet.
+func (s *ResilientStream) StreamEffects(ctx context.Context, request EffectRequest, handler EffectHandler) error {
+	return s.run(ctx, request.Cursor, false, func(ctx context.Context, cursor string, deliver func(string, handleFunc)) error {
+		request.Cursor = cursor
+		return s.Client.StreamEffects(ctx, request, func(effect effects.Effect) {
+			deliver(effect.PagingToken(), func(db.SessionInterface) error {
+				handler(effect)
+				return nil
+			})
+		})
+	})
+}
+
+// StreamLedgers is the resilient equivalent of Client.StreamLedgers.
+// request.Cursor is only used when no cursor has been stored yet.
+func (s *ResilientStream) StreamLedgers(ctx context.Context, request LedgerRequest, handler LedgerHandler) error {
+	return s.run(ctx, request.Cursor, false, func(ctx context.Context, cursor string, deliver func(string, handleFunc)) error {
+		request.Cursor = cursor
+		return s.Client.StreamLedgers(ctx, request, func(ledger hProtocol.Ledger) {
+			deliver(ledger.PagingToken(), func(db.SessionInterface) error {
+				handler(ledger)
+				return nil
+			})
+		})
+	})
+}
+
+// StreamTrades is the resilient equivalent of Client.StreamTrades.
+// request.Cursor is only used when no cursor has been stored yet.
+func (s *ResilientStream) StreamTrades(ctx context.Context, request TradeRequest, handler TradeHandler) error {
+	return s.run(ctx, request.Cursor, false, func(ctx context.Context, cursor string, deliver func(string, handleFunc)) error {
+		request.Cursor = cursor
+		return s.Client.StreamTrades(ctx, request, func(trade hProtocol.Trade) {
+			deliver(trade.PagingToken(), func(db.SessionInterface) error {
+				handler(trade)
+				return nil
+			})
+		})
+	})
+}
+
+// StreamTransactionsTx is like StreamTransactions but calls handler with the database
+// transaction the cursor of every transaction is saved in, so the writes of
+// handler and the cursor are committed together. s.Store must implement
+// TxCursorStore. An error returned by handler rolls the transaction back and
+// stops streaming.
+func (s *ResilientStream) StreamTransactionsTx(ctx context.Context, request TransactionRequest, handler func(session db.SessionInterface, tx hProtocol.Transaction) error) error {
+	return s.run(ctx, request.Cursor, true, func(ctx context.Context, cursor string, deliver func(string, handleFunc)) error {
+		request.Cursor = cursor
+		return s.Client.StreamTransactions(ctx, request, func(tx hProtocol.Transaction) {
+			deliver(tx.PagingToken(), func(session db.SessionInterface) error {
+				return handler(session, tx)
+			})
+		})
+	})
+}
+
+// StreamOperationsTx is the transactional equivalent of StreamOperations, see
+// StreamTransactionsTx.
+func (s *ResilientStream) StreamOperationsTx(ctx context.Context, request OperationRequest, handler func(session db.SessionInterface, op operations.Operation) error) error {
+	return s.run(ctx, request.Cursor, true, func(ctx context.Context, cursor string, deliver func(string, handleFunc)) error {
+		request.Cursor = cursor
+		return s.Client.StreamOperations(ctx, request, func(op operations.Operation) {
+			deliver(op.PagingToken(), func(session db.SessionInterface) error {
+				return handler(session, op)
+			})
+		})
+	})
+}
+
+// StreamPaymentsTx is the transactional equivalent of StreamPayments, see
+// StreamTransactionsTx.
+func (s *ResilientStream) StreamPaymentsTx(ctx context.Context, request OperationRequest, handler func(session db.SessionInterface, op operations.Operation) error) error {
+	return s.run(ctx, request.Cursor, true, func(ctx context.Context, cursor string, deliver func(string, handleFunc)) error {
+		request.Cursor = cursor
+		return s.Client.StreamPayments(ctx, request, func(op operations.Operation) {
+			deliver(op.PagingToken(), func(session db.SessionInterface) error {
+				return handler(session, op)
+			})
+		})
+	})
+}
+
+// StreamEffectsTx is the transactional equivalent of StreamEffects, see
+// StreamTransactionsTx.
+func (s *ResilientStream) StreamEffectsTx(ctx context.Context, request EffectRequest, handler func(session db.SessionInterface, effect effects.Effect) error) error {
+	return s.run(ctx, request.Cursor, true, func(ctx context.Context, cursor string, deliver func(string, handleFunc)) error {
+		request.Cursor = cursor
+		return s.Client.StreamEffects(ctx, request, func(effect effects.Effect) {
+			deliver(effect.PagingToken(), func(session db.SessionInterface) error {
+				return handler(session, effect)
+			})
+		})
+	})
+}
+
+// StreamLedgersTx is the transactional equivalent of StreamLedgers, see
+// StreamTransactionsTx.
+func (s *ResilientStream) StreamLedgersTx(ctx context.Context, request LedgerRequest, handler func(session db.SessionInterface, ledger hProtocol.Ledger) error) error {
+	return s.run(ctx, request.Cursor, true, func(ctx context.Context, cursor string, deliver func(string, handleFunc)) error {
+		request.Cursor = cursor
+		return s.Client.StreamLedgers(ctx, request, func(ledger hProtocol.Ledger) {
+			deliver(ledger.PagingToken(), func(session db.SessionInterface) error {
+				return handler(session, ledger)
+			})
+		})
+	})
+}
+
+// StreamTradesTx is the transactional equivalent of StreamTrades, see
+// StreamTransactionsTx.
+func (s *ResilientStream) StreamTradesTx(ctx context.Context, request TradeRequest, handler func(session db.SessionInterface, trade hProtocol.Trade) error) error {
+	return s.run(ctx, request.Cursor, true, func(ctx context.Context, cursor string, deliver func(string, handleFunc)) error {
+		request.Cursor = cursor
+		return s.Client.StreamTrades(ctx, request, func(trade hProtocol.Trade) {
+			deliver(trade.PagingToken(), func(session db.SessionInterface) error {
+				return handler(session, trade)
+			})
+		})
+	})
+}
@@ -0,0 +1,287 @@
+package auroraclient
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	hProtocol "github.com/shantanu-hashcash/go/protocols/aurora"
+	"github.com/shantanu-hashcash/go/support/db"
+	"github.com/shantanu-hashcash/go/support/errors"
+)
+
+func TestResilientStreamReconnectsAndPersistsCursor(t *testing.T) {
+	var mutex sync.Mutex
+	var cursors []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		cursors = append(cursors, r.URL.Query().Get("cursor"))
+		attempt := len(cursors)
+		mutex.Unlock()
+
+		if attempt == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, txStreamResponse)
+	}))
+	defer server.Close()
+
+	store := FileCursorStore{Dir: t.TempDir()}
+	var errs []error
+	stream := &ResilientStream{
+		Client:     &Client{AuroraURL: server.URL},
+		Store:      store,
+		Key:        "transactions",
+		MinBackoff: time.Millisecond,
+		MaxBackoff: time.Millisecond,
+		OnError:    func(err error) { errs = append(errs, err) },
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var received []hProtocol.Transaction
+	err := stream.StreamTransactions(ctx, TransactionRequest{}, func(tx hProtocol.Transaction) {
+		received = append(received, tx)
+		cancel()
+	})
+	require.NoError(t, err)
+	require.Len(t, received, 1)
+	assert.Equal(t, "2608707301036032", received[0].PagingToken())
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "got bad HTTP status code 503")
+
+	cursor, err := store.Load(context.Background(), "transactions")
+	require.NoError(t, err)
+	assert.Equal(t, "2608707301036032", cursor)
+
+	// A new stream sharing the store resumes from the persisted cursor and
+	// does not deliver the already handled transaction again.
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	restarted := &ResilientStream{
+		Client:     &Client{AuroraURL: server.URL},
+		Store:      store,
+		Key:        "transactions",
+		MinBackoff: time.Millisecond,
+		MaxBackoff: time.Millisecond,
+	}
+	err = restarted.StreamTransactions(ctx, TransactionRequest{Cursor: "now"}, func(tx hProtocol.Transaction) {
+		t.Fatalf("transaction %s delivered twice", tx.Hash)
+	})
+	require.NoError(t, err)
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	assert.Equal(t, []string{"now", "now"}, cursors[:2])
+	assert.Equal(t, "2608707301036032", cursors[len(cursors)-1])
+}
+
+func TestResilientStreamMaxRetries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	attempts := 0
+	stream := &ResilientStream{
+		Client:     &Client{AuroraURL: server.URL},
+		MinBackoff: time.Millisecond,
+		MaxBackoff: time.Millisecond,
+		MaxRetries: 2,
+		OnError:    func(error) { attempts++ },
+	}
+	err := stream.StreamLedgers(context.Background(), LedgerRequest{}, func(hProtocol.Ledger) {})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "maximum number of retries reached")
+	}
+	assert.Equal(t, 3, attempts)
+}
+
+func TestMemoryCursorStore(t *testing.T) {
+	store := NewMemoryCursorStore()
+	cursor, err := store.Load(context.Background(), "a")
+	require.NoError(t, err)
+	assert.Equal(t, "", cursor)
+
+	require.NoError(t, store.Save(context.Background(), "a", "123"))
+	cursor, err = store.Load(context.Background(), "a")
+	require.NoError(t, err)
+	assert.Equal(t, "123", cursor)
+}
+
+// cancelAwareCursorStore fails to save when ctx is done, like a database.
+type cancelAwareCursorStore struct {
+	*MemoryCursorStore
+}
+
+func (s cancelAwareCursorStore) Save(ctx context.Context, key, cursor string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return s.MemoryCursorStore.Save(ctx, key, cursor)
+}
+
+func TestResilientStreamSavesCursorWhenHandlerCancels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, txStreamResponse)
+	}))
+	defer server.Close()
+
+	store := cancelAwareCursorStore{NewMemoryCursorStore()}
+	stream := &ResilientStream{
+		Client: &Client{AuroraURL: server.URL},
+		Store:  store,
+		Key:    "transactions",
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	err := stream.StreamTransactions(ctx, TransactionRequest{}, func(tx hProtocol.Transaction) {
+		cancel()
+	})
+	require.NoError(t, err)
+
+	cursor, err := store.Load(context.Background(), "transactions")
+	require.NoError(t, err)
+	assert.Equal(t, "2608707301036032", cursor)
+}
+
+func TestCursorAfter(t *testing.T) {
+	for _, tc := range []struct {
+		token, cursor string
+		after         bool
+	}{
+		{"2608707301036032", "now", true},
+		{"2608707301036032", "", true},
+		{"2608707301036032", "2608707301036031", true},
+		{"2608707301036032", "2608707301036032", false},
+		{"2608707301036032", "2608707301036033", false},
+		{"9", "10", false},
+		{"10", "9", true},
+		{"2608707301036033-2", "2608707301036033-1", true},
+		{"2608707301036033-2", "2608707301036033-2", false},
+		{"2608707301036033-10", "2608707301036033-9", true},
+		{"2608707301036032-10", "2608707301036033-1", false},
+	} {
+		assert.Equal(t, tc.after, cursorAfter(tc.token, tc.cursor), "%s after %s", tc.token, tc.cursor)
+	}
+}
+
+func TestResilientStreamSkipsEventsAtOrBeforeCursor(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, txStreamResponse)
+	}))
+	defer server.Close()
+
+	for _, cursor := range []string{"2608707301036032", "2608707301036033"} {
+		store := NewMemoryCursorStore()
+		require.NoError(t, store.Save(context.Background(), "transactions", cursor))
+		stream := &ResilientStream{
+			Client:     &Client{AuroraURL: server.URL},
+			Store:      store,
+			Key:        "transactions",
+			MinBackoff: time.Millisecond,
+			MaxBackoff: time.Millisecond,
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		err := stream.StreamTransactions(ctx, TransactionRequest{}, func(tx hProtocol.Transaction) {
+			t.Fatalf("transaction %s delivered with cursor %s", tx.PagingToken(), cursor)
+		})
+		cancel()
+		require.NoError(t, err)
+
+		stored, err := store.Load(context.Background(), "transactions")
+		require.NoError(t, err)
+		assert.Equal(t, cursor, stored)
+	}
+}
+
+func newMockCursorSession(tx *db.MockSession) *db.MockSession {
+	session := &db.MockSession{}
+	session.On("GetRaw", mock.Anything, mock.Anything, "SELECT cursor FROM stream_cursors WHERE key = ?", []interface{}{"transactions"}).
+		Return(sql.ErrNoRows)
+	session.On("NoRows", sql.ErrNoRows).Return(true)
+	session.On("Clone").Return(tx)
+	return session
+}
+
+func TestResilientStreamTxSavesCursorInHandlerTransaction(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, txStreamResponse)
+	}))
+	defer server.Close()
+
+	var calls []string
+	record := func(call string) func(mock.Arguments) {
+		return func(mock.Arguments) { calls = append(calls, call) }
+	}
+	tx := &db.MockSession{}
+	tx.On("Begin", mock.Anything).Return(nil).Run(record("begin")).Once()
+	tx.On("ExecRaw", mock.Anything, "INSERT INTO processed (hash) VALUES (?)", mock.Anything).
+		Return(driver.RowsAffected(1), nil).Run(record("handler")).Once()
+	tx.On("ExecRaw", mock.Anything, SQLCursorStore{}.upsertQuery(), []interface{}{"transactions", "2608707301036032"}).
+		Return(driver.RowsAffected(1), nil).Run(record("save cursor")).Once()
+	tx.On("Commit").Return(nil).Run(record("commit")).Once()
+	tx.On("Rollback").Return(errors.New("not in transaction")).Maybe()
+	defer tx.AssertExpectations(t)
+
+	stream := &ResilientStream{
+		Client: &Client{AuroraURL: server.URL},
+		Store:  SQLCursorStore{Session: newMockCursorSession(tx)},
+		Key:    "transactions",
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	err := stream.StreamTransactionsTx(ctx, TransactionRequest{}, func(session db.SessionInterface, transaction hProtocol.Transaction) error {
+		assert.Same(t, tx, session)
+		cancel()
+		_, err := session.ExecRaw(ctx, "INSERT INTO processed (hash) VALUES (?)", transaction.Hash)
+		return err
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"begin", "handler", "save cursor", "commit"}, calls)
+}
+
+func TestResilientStreamTxRollsBackWhenHandlerFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, txStreamResponse)
+	}))
+	defer server.Close()
+
+	tx := &db.MockSession{}
+	tx.On("Begin", mock.Anything).Return(nil).Once()
+	tx.On("Rollback").Return(nil).Once()
+	defer tx.AssertExpectations(t)
+
+	stream := &ResilientStream{
+		Client: &Client{AuroraURL: server.URL},
+		Store:  SQLCursorStore{Session: newMockCursorSession(tx)},
+		Key:    "transactions",
+	}
+	err := stream.StreamTransactionsTx(context.Background(), TransactionRequest{}, func(db.SessionInterface, hProtocol.Transaction) error {
+		return errors.New("handler failed")
+	})
+	if assert.Error(t, err) {
+		assert.EqualError(t, err, "unable to handle event: handler failed")
+	}
+}
+
+func TestResilientStreamTxRequiresTxCursorStore(t *testing.T) {
+	stream := &ResilientStream{Client: &Client{AuroraURL: "http://localhost"}}
+	err := stream.StreamLedgersTx(context.Background(), LedgerRequest{}, func(db.SessionInterface, hProtocol.Ledger) error {
+		return nil
+	})
+	assert.EqualError(t, err, "store does not support transactions")
+}
@@ -1,3 +1,6 @@
 // Package derivation provides functions for ed25519 key derivation as described in:
 // https://github.com/satoshilabs/slips/blob/master/slip-0010.md
+//
+// Deprecated: use github.com/shantanu-hashcash/go/keypair/hdwallet, which
+// also handles mnemonics and account discovery.
 package derivation
@@ -2,9 +2,11 @@ package actions
 
 import (
 	"errors"
+	"math"
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/shantanu-hashcash/go/support/log"
 	"github.com/shantanu-hashcash/go/xdr"
@@ -12,6 +14,7 @@ import (
 	"github.com/shantanu-hashcash/go/exp/lightaurora/adapters"
 	"github.com/shantanu-hashcash/go/exp/lightaurora/services"
 	hProtocol "github.com/shantanu-hashcash/go/protocols/aurora"
+	"github.com/shantanu-hashcash/go/protocols/aurora/effects"
 	"github.com/shantanu-hashcash/go/protocols/aurora/operations"
 	"github.com/shantanu-hashcash/go/support/render/hal"
 	supportProblem "github.com/shantanu-hashcash/go/support/render/problem"
@@ -46,6 +49,69 @@ func accountRequestParams(w http.ResponseWriter, r *http.Request) (string, pagin
 	return accountId, paginate, nil
 }
 
+// effectsRequestParams is like accountRequestParams, but for the effects
+// paging token, "<operation id>-<order>", which is returned with the order
+// part separately.
+func effectsRequestParams(w http.ResponseWriter, r *http.Request) (string, pagination, int32, *supportProblem.P) {
+	accountId, ok := getURLParam(r, urlAccountId)
+	if !ok {
+		return "", pagination{}, 0, supportProblem.MakeInvalidFieldProblem(urlAccountId,
+			errors.New("unable to find account_id in url path"))
+	}
+
+	paginate := pagination{
+		Order: orderAsc,
+	}
+	var cursorOrder int32
+	cursorRequested, err := requestUnaryParam(r, "cursor")
+	if err != nil {
+		return "", pagination{}, 0, supportProblem.MakeInvalidFieldProblem("cursor", err)
+	} else if cursorRequested != "" {
+		paginate.Cursor, cursorOrder, err = parseEffectsCursor(cursorRequested)
+		if err != nil {
+			return "", pagination{}, 0, supportProblem.MakeInvalidFieldProblem("cursor", err)
+		}
+	}
+
+	paginate, err = pagingLimitAndOrder(r, paginate)
+	if err != nil {
+		return "", pagination{}, 0, supportProblem.MakeInvalidFieldProblem("limit", err)
+	}
+
+	if paginate.Cursor < 1 {
+		paginate.Cursor = toid.New(1, 1, 1).ToInt64()
+	}
+
+	if paginate.Limit == 0 {
+		paginate.Limit = 10
+	}
+
+	return accountId, paginate, cursorOrder, nil
+}
+
+// parseEffectsCursor parses an effects paging token into the operation id
+// and the order of the effect within the operation. An operation id on its
+// own is also accepted and refers to the start of the operation.
+func parseEffectsCursor(cursor string) (int64, int32, error) {
+	opPart, orderPart, hasOrder := strings.Cut(cursor, "-")
+	opID, err := strconv.ParseInt(opPart, 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	if !hasOrder {
+		return opID, 0, nil
+	}
+
+	order, err := strconv.ParseInt(orderPart, 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	if order > math.MaxInt32 {
+		order = math.MaxInt32
+	}
+	return opID, int32(order), nil
+}
+
 func NewTXByAccountHandler(lightAurora services.LightAurora) func(http.ResponseWriter, *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
@@ -140,3 +206,90 @@ func NewOpsByAccountHandler(lightAurora services.LightAurora) func(http.Response
 		sendPageResponse(r.Context(), w, page)
 	}
 }
+
+func NewPaymentsByAccountHandler(lightAurora services.LightAurora) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		var accountId string
+		var paginate pagination
+		var err error
+
+		if accountId, paginate, err = accountRequestParams(w, r); err != nil {
+			errorMsg := supportProblem.MakeInvalidFieldProblem("account_id", err)
+			sendErrorResponse(r.Context(), w, *errorMsg)
+			return
+		}
+
+		page := hal.Page{
+			Cursor: strconv.FormatInt(paginate.Cursor, 10),
+			Order:  string(paginate.Order),
+			Limit:  uint64(paginate.Limit),
+		}
+		page.Init()
+		page.FullURL = r.URL
+
+		ops, err := lightAurora.Operations.GetPaymentsByAccount(ctx, paginate.Cursor, paginate.Limit, accountId)
+		if err != nil {
+			log.Error(err)
+			sendErrorResponse(r.Context(), w, supportProblem.ServerError)
+			return
+		}
+
+		for _, op := range ops {
+			var response operations.Operation
+			response, err = adapters.PopulateOperation(r, &op)
+			if err != nil {
+				log.Error(err)
+				sendErrorResponse(r.Context(), w, supportProblem.ServerError)
+				return
+			}
+
+			page.Add(response)
+		}
+
+		page.PopulateLinks()
+		sendPageResponse(r.Context(), w, page)
+	}
+}
+
+func NewEffectsByAccountHandler(lightAurora services.LightAurora) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		accountId, paginate, cursorOrder, problem := effectsRequestParams(w, r)
+		if problem != nil {
+			sendErrorResponse(r.Context(), w, *problem)
+			return
+		}
+
+		page := hal.Page{
+			Cursor: strconv.FormatInt(paginate.Cursor, 10),
+			Order:  string(paginate.Order),
+			Limit:  uint64(paginate.Limit),
+		}
+		page.Init()
+		page.FullURL = r.URL
+
+		effs, err := lightAurora.Effects.GetEffectsByAccount(ctx, paginate.Cursor, cursorOrder, paginate.Limit, accountId)
+		if err != nil {
+			log.Error(err)
+			sendErrorResponse(r.Context(), w, supportProblem.ServerError)
+			return
+		}
+
+		for _, effect := range effs {
+			var response effects.Effect
+			response, err = adapters.PopulateEffect(r, &effect)
+			if err != nil {
+				log.Error(err)
+				sendErrorResponse(r.Context(), w, supportProblem.ServerError)
+				return
+			}
+
+			page.Add(response)
+		}
+
+		page.PopulateLinks()
+		sendPageResponse(r.Context(), w, page)
+	}
+}
@@ -168,6 +168,80 @@ func TestOpsByAccountServerError(t *testing.T) {
 	assert.Equal(t, "server_error", problem.Type)
 }
 
+func TestPaymentsByAccountServerError(t *testing.T) {
+	setupTest()
+	recorder := httptest.NewRecorder()
+	pathParams := make(map[string]string)
+	pathParams["account_id"] = "G1234"
+	request := buildHttpRequest(
+		t,
+		map[string]string{},
+		pathParams,
+	)
+
+	mockOperationService := &services.MockOperationService{}
+	mockOperationService.On("GetPaymentsByAccount", mock.Anything, mock.Anything, mock.Anything, "G1234").Return([]common.Operation{}, errors.New("not good"))
+
+	lh := services.LightAurora{
+		Operations: mockOperationService,
+	}
+
+	handler := NewPaymentsByAccountHandler(lh)
+	handler(recorder, request)
+
+	resp := recorder.Result()
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+}
+
+func TestEffectsByAccountCursor(t *testing.T) {
+	setupTest()
+	pathParams := make(map[string]string)
+	pathParams["account_id"] = "G1234"
+
+	for _, testCase := range []struct {
+		cursor      string
+		wantCursor  int64
+		wantOrder   int32
+		wantProblem bool
+	}{
+		{"", 4294971393, 0, false},
+		{"6606621773926401", 6606621773926401, 0, false},
+		{"6606621773926401-3", 6606621773926401, 3, false},
+		{"6606621773926401-x", 0, 0, true},
+		{"abc", 0, 0, true},
+	} {
+		t.Run(testCase.cursor, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			query := map[string]string{}
+			if testCase.cursor != "" {
+				query["cursor"] = testCase.cursor
+			}
+			request := buildHttpRequest(t, query, pathParams)
+
+			mockEffectService := &services.MockEffectService{}
+			mockEffectService.
+				On("GetEffectsByAccount", mock.Anything, testCase.wantCursor, testCase.wantOrder, uint64(10), "G1234").
+				Return([]common.Effect{}, nil)
+
+			handler := NewEffectsByAccountHandler(services.LightAurora{Effects: mockEffectService})
+			handler(recorder, request)
+
+			resp := recorder.Result()
+			if testCase.wantProblem {
+				assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+				var problem problem.P
+				require.NoError(t, json.NewDecoder(resp.Body).Decode(&problem))
+				assert.Equal(t, "cursor", problem.Extras["invalid_field"])
+				mockEffectService.AssertNotCalled(t, "GetEffectsByAccount")
+				return
+			}
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			mockEffectService.AssertExpectations(t)
+		})
+	}
+}
+
 func buildHttpRequest(
 	t *testing.T,
 	queryParams map[string]string,
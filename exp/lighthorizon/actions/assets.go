@@ -0,0 +1,151 @@
+package actions
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/shantanu-hashcash/go/exp/lightaurora/adapters"
+	"github.com/shantanu-hashcash/go/exp/lightaurora/common"
+	"github.com/shantanu-hashcash/go/exp/lightaurora/index"
+	"github.com/shantanu-hashcash/go/exp/lightaurora/services"
+	"github.com/shantanu-hashcash/go/protocols/aurora/operations"
+	"github.com/shantanu-hashcash/go/strkey"
+	"github.com/shantanu-hashcash/go/support/log"
+	"github.com/shantanu-hashcash/go/support/render/hal"
+	supportProblem "github.com/shantanu-hashcash/go/support/render/problem"
+	"github.com/shantanu-hashcash/go/toid"
+)
+
+const (
+	urlAsset           = "asset"
+	urlLiquidityPoolId = "liquidity_pool_id"
+	urlContractId      = "contract_id"
+)
+
+// opsByKeyFunc is a service method that finds the operations relevant to an
+// index key, e.g. services.OperationService.GetOperationsByAsset.
+type opsByKeyFunc func(ctx context.Context, cursor int64, limit uint64, key string) ([]common.Operation, error)
+
+// NewOpsByAssetHandler serves the operations that reference an asset, given
+// in its canonical CODE:ISSUER form.
+func NewOpsByAssetHandler(lightAurora services.LightAurora) func(http.ResponseWriter, *http.Request) {
+	return newOpsByKeyHandler(urlAsset, parseAssetKey, lightAurora.Operations.GetOperationsByAsset)
+}
+
+// NewPaymentsByAssetHandler serves the payments that transfer an asset, given
+// in its canonical CODE:ISSUER form.
+func NewPaymentsByAssetHandler(lightAurora services.LightAurora) func(http.ResponseWriter, *http.Request) {
+	return newOpsByKeyHandler(urlAsset, parseAssetKey, lightAurora.Operations.GetPaymentsByAsset)
+}
+
+func NewOpsByLiquidityPoolHandler(lightAurora services.LightAurora) func(http.ResponseWriter, *http.Request) {
+	return newOpsByKeyHandler(urlLiquidityPoolId, parseLiquidityPoolKey, lightAurora.Operations.GetOperationsByLiquidityPool)
+}
+
+func NewOpsByContractHandler(lightAurora services.LightAurora) func(http.ResponseWriter, *http.Request) {
+	return newOpsByKeyHandler(urlContractId, parseContractKey, lightAurora.Operations.GetOperationsByContract)
+}
+
+func newOpsByKeyHandler(
+	urlParam string,
+	parseKey func(string) (string, error),
+	getOps opsByKeyFunc,
+) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		key, paginate, problem := keyRequestParams(r, urlParam, parseKey)
+		if problem != nil {
+			sendErrorResponse(ctx, w, *problem)
+			return
+		}
+
+		page := hal.Page{
+			Cursor: strconv.FormatInt(paginate.Cursor, 10),
+			Order:  string(paginate.Order),
+			Limit:  uint64(paginate.Limit),
+		}
+		page.Init()
+		page.FullURL = r.URL
+
+		ops, err := getOps(ctx, paginate.Cursor, paginate.Limit, key)
+		if err != nil {
+			log.Error(err)
+			sendErrorResponse(ctx, w, supportProblem.ServerError)
+			return
+		}
+
+		for _, op := range ops {
+			var response operations.Operation
+			response, err = adapters.PopulateOperation(r, &op)
+			if err != nil {
+				log.Error(err)
+				sendErrorResponse(ctx, w, supportProblem.ServerError)
+				return
+			}
+
+			page.Add(response)
+		}
+
+		page.PopulateLinks()
+		sendPageResponse(ctx, w, page)
+	}
+}
+
+// keyRequestParams is like accountRequestParams, but for endpoints keyed by
+// something other than an account, which parseKey validates and normalizes.
+func keyRequestParams(r *http.Request, urlParam string, parseKey func(string) (string, error)) (string, pagination, *supportProblem.P) {
+	raw, ok := getURLParam(r, urlParam)
+	if !ok {
+		return "", pagination{}, supportProblem.MakeInvalidFieldProblem(urlParam,
+			fmt.Errorf("unable to find %s in url path", urlParam))
+	}
+
+	key, err := parseKey(raw)
+	if err != nil {
+		return "", pagination{}, supportProblem.MakeInvalidFieldProblem(urlParam, err)
+	}
+
+	paginate, err := paging(r)
+	if err != nil {
+		return "", pagination{}, supportProblem.MakeInvalidFieldProblem("cursor", err)
+	}
+
+	if paginate.Cursor < 1 {
+		paginate.Cursor = toid.New(1, 1, 1).ToInt64()
+	}
+
+	if paginate.Limit == 0 {
+		paginate.Limit = 10
+	}
+
+	return key, paginate, nil
+}
+
+func parseAssetKey(raw string) (string, error) {
+	asset, err := index.ParseAssetKey(raw)
+	if err != nil {
+		return "", err
+	}
+	key, _ := index.AssetKey(asset)
+	return key, nil
+}
+
+func parseLiquidityPoolKey(raw string) (string, error) {
+	id, err := hex.DecodeString(raw)
+	if err != nil || len(id) != 32 {
+		return "", fmt.Errorf("%s is not a valid liquidity pool id", raw)
+	}
+	return strings.ToLower(raw), nil
+}
+
+func parseContractKey(raw string) (string, error) {
+	if _, err := strkey.Decode(strkey.VersionByteContract, raw); err != nil {
+		return "", fmt.Errorf("%s is not a valid contract id", raw)
+	}
+	return raw, nil
+}
@@ -0,0 +1,96 @@
+package actions
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shantanu-hashcash/go/exp/lightaurora/common"
+	"github.com/shantanu-hashcash/go/exp/lightaurora/services"
+	"github.com/shantanu-hashcash/go/support/render/problem"
+)
+
+const (
+	testAsset           = "USD:GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU"
+	testLiquidityPoolId = "67260c4c1807b262ff851b0a3fe141194936bb0215b2f77447f1df11998eabb9"
+	testContractId      = "CAAQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAABDQF"
+)
+
+func TestOpsByKeyHandlers(t *testing.T) {
+	for _, testCase := range []struct {
+		name       string
+		urlParam   string
+		value      string
+		method     string
+		wantKey    string
+		newHandler func(services.LightAurora) func(http.ResponseWriter, *http.Request)
+	}{
+		{"asset operations", urlAsset, testAsset, "GetOperationsByAsset", testAsset, NewOpsByAssetHandler},
+		{"asset payments", urlAsset, testAsset, "GetPaymentsByAsset", testAsset, NewPaymentsByAssetHandler},
+		{"pool operations", urlLiquidityPoolId, "67260C4C1807B262FF851B0A3FE141194936BB0215B2F77447F1DF11998EABB9",
+			"GetOperationsByLiquidityPool", testLiquidityPoolId, NewOpsByLiquidityPoolHandler},
+		{"contract operations", urlContractId, testContractId, "GetOperationsByContract", testContractId, NewOpsByContractHandler},
+	} {
+		t.Run(testCase.name, func(tt *testing.T) {
+			setupTest()
+			recorder := httptest.NewRecorder()
+			request := buildHttpRequest(
+				tt,
+				map[string]string{"cursor": "6606621773926401", "limit": "5"},
+				map[string]string{testCase.urlParam: testCase.value},
+			)
+
+			mockOperationService := &services.MockOperationService{}
+			mockOperationService.On(testCase.method, mock.Anything, int64(6606621773926401), uint64(5), testCase.wantKey).
+				Return([]common.Operation{}, nil)
+
+			handler := testCase.newHandler(services.LightAurora{Operations: mockOperationService})
+			handler(recorder, request)
+
+			resp := recorder.Result()
+			assert.Equal(tt, http.StatusOK, resp.StatusCode)
+			mockOperationService.AssertExpectations(tt)
+		})
+	}
+}
+
+func TestOpsByKeyInvalidKey(t *testing.T) {
+	for _, testCase := range []struct {
+		urlParam   string
+		value      string
+		newHandler func(services.LightAurora) func(http.ResponseWriter, *http.Request)
+	}{
+		{urlAsset, "native", NewPaymentsByAssetHandler},
+		{urlAsset, "USD", NewOpsByAssetHandler},
+		{urlAsset, "USD:G1234", NewOpsByAssetHandler},
+		{urlLiquidityPoolId, "abcd", NewOpsByLiquidityPoolHandler},
+		{urlContractId, "GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU", NewOpsByContractHandler},
+	} {
+		t.Run(testCase.value, func(tt *testing.T) {
+			setupTest()
+			recorder := httptest.NewRecorder()
+			request := buildHttpRequest(
+				tt,
+				map[string]string{},
+				map[string]string{testCase.urlParam: testCase.value},
+			)
+
+			mockOperationService := &services.MockOperationService{}
+			handler := testCase.newHandler(services.LightAurora{Operations: mockOperationService})
+			handler(recorder, request)
+
+			resp := recorder.Result()
+			assert.Equal(tt, http.StatusBadRequest, resp.StatusCode)
+
+			var problem problem.P
+			require.NoError(tt, json.NewDecoder(resp.Body).Decode(&problem))
+			assert.Equal(tt, testCase.urlParam, problem.Extras["invalid_field"])
+			assert.Empty(tt, mockOperationService.Calls)
+		})
+	}
+}
@@ -59,6 +59,17 @@ func sendPageResponse(ctx context.Context, w http.ResponseWriter, page hal.Page)
 	}
 }
 
+func sendResourceResponse(ctx context.Context, w http.ResponseWriter, resource interface{}) {
+	w.Header().Set("Content-Type", "application/hal+json; charset=utf-8")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err := encoder.Encode(resource)
+	if err != nil {
+		log.Error(err)
+		sendErrorResponse(ctx, w, supportProblem.ServerError)
+	}
+}
+
 func sendErrorResponse(ctx context.Context, w http.ResponseWriter, problem supportProblem.P) {
 	supportProblem.Render(ctx, w, problem)
 }
@@ -85,6 +96,12 @@ func paging(r *http.Request) (pagination, error) {
 		}
 	}
 
+	return pagingLimitAndOrder(r, paginate)
+}
+
+// pagingLimitAndOrder reads the limit and order of the page into paginate,
+// for endpoints whose cursor is not a single id.
+func pagingLimitAndOrder(r *http.Request, paginate pagination) (pagination, error) {
 	if limitRequested, err := requestUnaryParam(r, "limit"); err != nil {
 		return pagination{}, err
 	} else if limitRequested != "" {
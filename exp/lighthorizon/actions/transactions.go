@@ -0,0 +1,57 @@
+package actions
+
+import (
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"os"
+
+	"github.com/shantanu-hashcash/go/exp/lightaurora/adapters"
+	"github.com/shantanu-hashcash/go/exp/lightaurora/services"
+	"github.com/shantanu-hashcash/go/support/log"
+	supportProblem "github.com/shantanu-hashcash/go/support/render/problem"
+	"github.com/shantanu-hashcash/go/xdr"
+)
+
+const (
+	urlTxHash = "tx_hash"
+)
+
+func NewTXByHashHandler(lightAurora services.LightAurora) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		hash, ok := getURLParam(r, urlTxHash)
+		if !ok {
+			errorMsg := supportProblem.MakeInvalidFieldProblem(urlTxHash,
+				errors.New("unable to find tx_hash in url path"))
+			sendErrorResponse(ctx, w, *errorMsg)
+			return
+		}
+		if decoded, err := hex.DecodeString(hash); err != nil || len(decoded) != 32 {
+			errorMsg := supportProblem.MakeInvalidFieldProblem(urlTxHash,
+				errors.New("transaction hash must be 64 hexadecimal characters"))
+			sendErrorResponse(ctx, w, *errorMsg)
+			return
+		}
+
+		txn, err := lightAurora.Transactions.GetTransactionByHash(ctx, hash)
+		if os.IsNotExist(err) {
+			sendErrorResponse(ctx, w, supportProblem.NotFound)
+			return
+		} else if err != nil {
+			log.Error(err)
+			sendErrorResponse(ctx, w, supportProblem.ServerError)
+			return
+		}
+
+		response, err := adapters.PopulateTransaction(r.URL, &txn, xdr.NewEncodingBuffer())
+		if err != nil {
+			log.Error(err)
+			sendErrorResponse(ctx, w, supportProblem.ServerError)
+			return
+		}
+
+		sendResourceResponse(ctx, w, response)
+	}
+}
@@ -0,0 +1,80 @@
+package actions
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shantanu-hashcash/go/exp/lightaurora/common"
+	"github.com/shantanu-hashcash/go/exp/lightaurora/services"
+	"github.com/shantanu-hashcash/go/support/render/problem"
+)
+
+const testTxHash = "55d8aa3693489ffc1d70b8ba33b8b5c012ec098f6f104383e3f090048488febd"
+
+func TestTxByHashInvalidHash(t *testing.T) {
+	setupTest()
+	recorder := httptest.NewRecorder()
+	request := buildHttpRequest(
+		t,
+		map[string]string{},
+		map[string]string{"tx_hash": "not-a-hash"},
+	)
+
+	mockTransactionService := &services.MockTransactionService{}
+	handler := NewTXByHashHandler(services.LightAurora{Transactions: mockTransactionService})
+	handler(recorder, request)
+
+	resp := recorder.Result()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	var problem problem.P
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&problem))
+	assert.Equal(t, "tx_hash", problem.Extras["invalid_field"])
+	mockTransactionService.AssertNotCalled(t, "GetTransactionByHash")
+}
+
+func TestTxByHashNotFound(t *testing.T) {
+	setupTest()
+	recorder := httptest.NewRecorder()
+	request := buildHttpRequest(
+		t,
+		map[string]string{},
+		map[string]string{"tx_hash": testTxHash},
+	)
+
+	mockTransactionService := &services.MockTransactionService{}
+	mockTransactionService.On("GetTransactionByHash", mock.Anything, testTxHash).Return(common.Transaction{}, os.ErrNotExist)
+
+	handler := NewTXByHashHandler(services.LightAurora{Transactions: mockTransactionService})
+	handler(recorder, request)
+
+	resp := recorder.Result()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestTxByHashServerError(t *testing.T) {
+	setupTest()
+	recorder := httptest.NewRecorder()
+	request := buildHttpRequest(
+		t,
+		map[string]string{},
+		map[string]string{"tx_hash": testTxHash},
+	)
+
+	mockTransactionService := &services.MockTransactionService{}
+	mockTransactionService.On("GetTransactionByHash", mock.Anything, testTxHash).Return(common.Transaction{}, errors.New("not good"))
+
+	handler := NewTXByHashHandler(services.LightAurora{Transactions: mockTransactionService})
+	handler(recorder, request)
+
+	resp := recorder.Result()
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+}
@@ -0,0 +1,287 @@
+package adapters
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/shantanu-hashcash/go/exp/lightaurora/common"
+	"github.com/shantanu-hashcash/go/protocols/aurora/base"
+	"github.com/shantanu-hashcash/go/protocols/aurora/effects"
+	"github.com/shantanu-hashcash/go/support/render/hal"
+	"github.com/shantanu-hashcash/go/xdr"
+)
+
+func PopulateEffect(r *http.Request, effect *common.Effect) (effects.Effect, error) {
+	baseEffect := effects.Base{
+		ID:              effect.ID(),
+		PT:              effect.PagingToken(),
+		Account:         effect.Account,
+		AccountMuxed:    effect.AccountMuxed,
+		Type:            effects.EffectTypeNames[effect.Type],
+		TypeI:           int32(effect.Type),
+		LedgerCloseTime: time.Unix(int64(effect.Operation.LedgerHeader.ScpValue.CloseTime), 0).UTC(),
+	}
+
+	if effect.AccountMuxed != "" {
+		baseEffect.AccountMuxedID = uint64(xdr.MustMuxedAddress(effect.AccountMuxed).Med25519.Id)
+	}
+
+	lb := hal.LinkBuilder{Base: r.URL}
+	baseEffect.Links.Operation = lb.Linkf("/operations/%d", effect.Operation.TOID())
+	baseEffect.Links.Succeeds = lb.Linkf("/effects?order=desc&cursor=%s", baseEffect.PT)
+	baseEffect.Links.Precedes = lb.Linkf("/effects?order=asc&cursor=%s", baseEffect.PT)
+
+	var (
+		result effects.Effect
+		err    error
+	)
+	switch effect.Type {
+	case effects.EffectAccountCreated:
+		e := effects.AccountCreated{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectAccountCredited:
+		e := effects.AccountCredited{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectAccountDebited:
+		e := effects.AccountDebited{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectAccountThresholdsUpdated:
+		e := effects.AccountThresholdsUpdated{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectAccountHomeDomainUpdated:
+		e := effects.AccountHomeDomainUpdated{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectAccountFlagsUpdated:
+		e := effects.AccountFlagsUpdated{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectSignerCreated:
+		e := effects.SignerCreated{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectSignerUpdated:
+		e := effects.SignerUpdated{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectSignerRemoved:
+		e := effects.SignerRemoved{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectTrustlineCreated:
+		e := effects.TrustlineCreated{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectTrustlineUpdated:
+		e := effects.TrustlineUpdated{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectTrustlineRemoved:
+		e := effects.TrustlineRemoved{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectTrustlineAuthorized:
+		e := effects.TrustlineAuthorized{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectTrustlineAuthorizedToMaintainLiabilities:
+		e := effects.TrustlineAuthorizedToMaintainLiabilities{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectTrustlineDeauthorized:
+		e := effects.TrustlineDeauthorized{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectTrustlineFlagsUpdated:
+		e := effects.TrustlineFlagsUpdated{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectTrade:
+		e := effects.Trade{Base: baseEffect}
+		details := tradeDetails{}
+		err = effect.UnmarshalDetails(&details)
+		if err == nil {
+			e.Seller = details.Seller
+			e.SellerMuxed = details.SellerMuxed
+			e.SellerMuxedID = details.SellerMuxedID
+			e.OfferID = details.OfferID
+			e.SoldAmount = details.SoldAmount
+			e.SoldAssetType = details.SoldAssetType
+			e.SoldAssetCode = details.SoldAssetCode
+			e.SoldAssetIssuer = details.SoldAssetIssuer
+			e.BoughtAmount = details.BoughtAmount
+			e.BoughtAssetType = details.BoughtAssetType
+			e.BoughtAssetCode = details.BoughtAssetCode
+			e.BoughtAssetIssuer = details.BoughtAssetIssuer
+		}
+		result = e
+	case effects.EffectDataCreated:
+		e := effects.DataCreated{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectDataUpdated:
+		e := effects.DataUpdated{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectDataRemoved:
+		e := effects.DataRemoved{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectSequenceBumped:
+		e := effects.SequenceBumped{Base: baseEffect}
+		details := sequenceBumpedDetails{}
+		err = effect.UnmarshalDetails(&details)
+		if err == nil {
+			e.NewSeq = details.NewSeq
+		}
+		result = e
+	case effects.EffectClaimableBalanceCreated:
+		e := effects.ClaimableBalanceCreated{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectClaimableBalanceClaimed:
+		e := effects.ClaimableBalanceClaimed{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectClaimableBalanceClaimantCreated:
+		e := effects.ClaimableBalanceClaimantCreated{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectAccountSponsorshipCreated:
+		e := effects.AccountSponsorshipCreated{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectAccountSponsorshipUpdated:
+		e := effects.AccountSponsorshipUpdated{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectAccountSponsorshipRemoved:
+		e := effects.AccountSponsorshipRemoved{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectTrustlineSponsorshipCreated:
+		e := effects.TrustlineSponsorshipCreated{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectTrustlineSponsorshipUpdated:
+		e := effects.TrustlineSponsorshipUpdated{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectTrustlineSponsorshipRemoved:
+		e := effects.TrustlineSponsorshipRemoved{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectDataSponsorshipCreated:
+		e := effects.DataSponsorshipCreated{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectDataSponsorshipUpdated:
+		e := effects.DataSponsorshipUpdated{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectDataSponsorshipRemoved:
+		e := effects.DataSponsorshipRemoved{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectClaimableBalanceSponsorshipCreated:
+		e := effects.ClaimableBalanceSponsorshipCreated{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectClaimableBalanceSponsorshipUpdated:
+		e := effects.ClaimableBalanceSponsorshipUpdated{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectClaimableBalanceSponsorshipRemoved:
+		e := effects.ClaimableBalanceSponsorshipRemoved{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectSignerSponsorshipCreated:
+		e := effects.SignerSponsorshipCreated{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectSignerSponsorshipUpdated:
+		e := effects.SignerSponsorshipUpdated{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectSignerSponsorshipRemoved:
+		e := effects.SignerSponsorshipRemoved{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectClaimableBalanceClawedBack:
+		e := effects.ClaimableBalanceClawedBack{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectLiquidityPoolDeposited:
+		e := effects.LiquidityPoolDeposited{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectLiquidityPoolWithdrew:
+		e := effects.LiquidityPoolWithdrew{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectLiquidityPoolTrade:
+		e := effects.LiquidityPoolTrade{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectLiquidityPoolCreated:
+		e := effects.LiquidityPoolCreated{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectLiquidityPoolRemoved:
+		e := effects.LiquidityPoolRemoved{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectLiquidityPoolRevoked:
+		e := effects.LiquidityPoolRevoked{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectContractCredited:
+		e := effects.ContractCredited{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectContractDebited:
+		e := effects.ContractDebited{Base: baseEffect}
+		err = effect.UnmarshalDetails(&e)
+		result = e
+	case effects.EffectAccountRemoved:
+		// there is no explicit data structure for account removed
+		fallthrough
+	default:
+		result = baseEffect
+	}
+
+	if err != nil {
+		return result, err
+	}
+	if rh, ok := result.(base.Rehydratable); ok {
+		err = rh.Rehydrate()
+	}
+	return result, err
+}
+
+// tradeDetails are the details of a trade effect, whose offer id and muxed
+// seller id are not encoded as strings like in the effect resource.
+type tradeDetails struct {
+	Seller            string `json:"seller"`
+	SellerMuxed       string `json:"seller_muxed,omitempty"`
+	SellerMuxedID     uint64 `json:"seller_muxed_id,omitempty"`
+	OfferID           int64  `json:"offer_id"`
+	SoldAmount        string `json:"sold_amount"`
+	SoldAssetType     string `json:"sold_asset_type"`
+	SoldAssetCode     string `json:"sold_asset_code,omitempty"`
+	SoldAssetIssuer   string `json:"sold_asset_issuer,omitempty"`
+	BoughtAmount      string `json:"bought_amount"`
+	BoughtAssetType   string `json:"bought_asset_type"`
+	BoughtAssetCode   string `json:"bought_asset_code,omitempty"`
+	BoughtAssetIssuer string `json:"bought_asset_issuer,omitempty"`
+}
+
+// sequenceBumpedDetails are the details of a sequence bumped effect, whose
+// new sequence is not encoded as a string like in the effect resource.
+type sequenceBumpedDetails struct {
+	NewSeq int64 `json:"new_seq"`
+}
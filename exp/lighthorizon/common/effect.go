@@ -0,0 +1,42 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/shantanu-hashcash/go/protocols/aurora/effects"
+)
+
+// Effect is a change to an account made by an operation. Light Aurora does
+// not store effects, they are computed from the operation when requested.
+type Effect struct {
+	Operation *Operation
+	// Order is the 1-based position of the effect among the effects of the
+	// operation.
+	Order        int32
+	Type         effects.EffectType
+	Account      string
+	AccountMuxed string
+	Details      map[string]interface{}
+}
+
+// ID returns a lexically ordered id for the effect, in the same format as
+// Aurora's.
+func (e *Effect) ID() string {
+	return fmt.Sprintf("%019d-%010d", e.Operation.TOID(), e.Order)
+}
+
+// PagingToken returns a cursor for the effect, in the same format as
+// Aurora's.
+func (e *Effect) PagingToken() string {
+	return fmt.Sprintf("%d-%d", e.Operation.TOID(), e.Order)
+}
+
+// UnmarshalDetails unmarshals the details of the effect into dest.
+func (e *Effect) UnmarshalDetails(dest interface{}) error {
+	raw, err := json.Marshal(e.Details)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, dest)
+}
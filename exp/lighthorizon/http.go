@@ -60,8 +60,20 @@ func lightAuroraHTTPHandler(registry *prometheus.Registry, lightAurora services.
 	router.Route("/accounts/{account_id}", func(r chi.Router) {
 		r.MethodFunc(http.MethodGet, "/transactions", actions.NewTXByAccountHandler(lightAurora))
 		r.MethodFunc(http.MethodGet, "/operations", actions.NewOpsByAccountHandler(lightAurora))
+		r.MethodFunc(http.MethodGet, "/payments", actions.NewPaymentsByAccountHandler(lightAurora))
+		r.MethodFunc(http.MethodGet, "/effects", actions.NewEffectsByAccountHandler(lightAurora))
 	})
 
+	router.MethodFunc(http.MethodGet, "/transactions/{tx_hash}", actions.NewTXByHashHandler(lightAurora))
+
+	router.Route("/assets/{asset}", func(r chi.Router) {
+		r.MethodFunc(http.MethodGet, "/operations", actions.NewOpsByAssetHandler(lightAurora))
+		r.MethodFunc(http.MethodGet, "/payments", actions.NewPaymentsByAssetHandler(lightAurora))
+	})
+
+	router.MethodFunc(http.MethodGet, "/liquidity_pools/{liquidity_pool_id}/operations", actions.NewOpsByLiquidityPoolHandler(lightAurora))
+	router.MethodFunc(http.MethodGet, "/contracts/{contract_id}/operations", actions.NewOpsByContractHandler(lightAurora))
+
 	router.MethodFunc(http.MethodGet, "/", actions.Root(actions.RootResponse{
 		Version: AuroraLiteVersion,
 		// by default, no other fields are known yet
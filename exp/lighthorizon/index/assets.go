@@ -0,0 +1,279 @@
+package index
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/shantanu-hashcash/go/ingest"
+	"github.com/shantanu-hashcash/go/xdr"
+)
+
+// AssetKey returns the key under which an asset's indices are stored, which is
+// its canonical CODE:ISSUER form. The native asset has no key: every
+// transaction touches it, so indexing it would be pointless.
+func AssetKey(asset xdr.Asset) (string, bool) {
+	if asset.Type == xdr.AssetTypeAssetTypeNative {
+		return "", false
+	}
+	return asset.StringCanonical(), true
+}
+
+// LiquidityPoolKey returns the key under which a liquidity pool's indices are
+// stored, which is its hex-encoded ID (as Aurora renders it).
+func LiquidityPoolKey(id xdr.PoolId) string {
+	return hex.EncodeToString(id[:])
+}
+
+func GetPaymentAssets(transaction ingest.LedgerTransaction) ([]string, error) {
+	return assetsForOperations(transaction, true)
+}
+
+func GetTransactionAssets(transaction ingest.LedgerTransaction) ([]string, error) {
+	return assetsForOperations(transaction, false)
+}
+
+// transaction - the ledger transaction
+// operation   - the operation within this transaction
+// opIndex     - the 0 based index of the operation within the transaction
+func GetOperationAssets(transaction ingest.LedgerTransaction, operation xdr.Operation, opIndex int) ([]string, error) {
+	return assetsForOperation(transaction, operation, opIndex, false)
+}
+
+// GetOperationPaymentAssets returns the assets that the operation transfers,
+// which is empty unless it's a payment operation.
+func GetOperationPaymentAssets(transaction ingest.LedgerTransaction, operation xdr.Operation, opIndex int) ([]string, error) {
+	return assetsForOperation(transaction, operation, opIndex, true)
+}
+
+func GetTransactionLiquidityPools(transaction ingest.LedgerTransaction) ([]string, error) {
+	var pools []string
+	for opIndex, operation := range transaction.Envelope.Operations() {
+		opPools, err := GetOperationLiquidityPools(transaction, operation, opIndex)
+		if err != nil {
+			return []string{}, err
+		}
+		pools = append(pools, opPools...)
+	}
+	return pools, nil
+}
+
+func assetsForOperations(transaction ingest.LedgerTransaction, onlyPayments bool) ([]string, error) {
+	var assets []string
+
+	for opIndex, operation := range transaction.Envelope.Operations() {
+		opAssets, err := assetsForOperation(transaction, operation, opIndex, onlyPayments)
+		if err != nil {
+			return []string{}, err
+		}
+		assets = append(assets, opAssets...)
+	}
+
+	return assets, nil
+}
+
+// assetsForOperation returns the keys of the (non-native) assets referenced by
+// an operation. When onlyPayments is set, only the assets moved between
+// accounts by a payment operation are returned, i.e. the source and
+// destination assets but not the intermediate hops of a path payment.
+func assetsForOperation(transaction ingest.LedgerTransaction, operation xdr.Operation, opIndex int, onlyPayments bool) ([]string, error) {
+	assets := []xdr.Asset{}
+
+	switch operation.Body.Type {
+	case xdr.OperationTypePayment:
+		assets = append(assets, operation.Body.MustPaymentOp().Asset)
+
+	case xdr.OperationTypePathPaymentStrictReceive:
+		op := operation.Body.MustPathPaymentStrictReceiveOp()
+		assets = append(assets, op.SendAsset, op.DestAsset)
+		if !onlyPayments {
+			assets = append(assets, op.Path...)
+		}
+
+	case xdr.OperationTypePathPaymentStrictSend:
+		op := operation.Body.MustPathPaymentStrictSendOp()
+		assets = append(assets, op.SendAsset, op.DestAsset)
+		if !onlyPayments {
+			assets = append(assets, op.Path...)
+		}
+
+	default:
+		if onlyPayments {
+			return []string{}, nil
+		}
+	}
+
+	switch operation.Body.Type {
+	case xdr.OperationTypeManageSellOffer:
+		op := operation.Body.MustManageSellOfferOp()
+		assets = append(assets, op.Selling, op.Buying)
+
+	case xdr.OperationTypeManageBuyOffer:
+		op := operation.Body.MustManageBuyOfferOp()
+		assets = append(assets, op.Selling, op.Buying)
+
+	case xdr.OperationTypeCreatePassiveSellOffer:
+		op := operation.Body.MustCreatePassiveSellOfferOp()
+		assets = append(assets, op.Selling, op.Buying)
+
+	case xdr.OperationTypeChangeTrust:
+		line := operation.Body.MustChangeTrustOp().Line
+		if line.Type == xdr.AssetTypeAssetTypePoolShare {
+			params := line.MustLiquidityPool().MustConstantProduct()
+			assets = append(assets, params.AssetA, params.AssetB)
+		} else {
+			assets = append(assets, line.ToAsset())
+		}
+
+	case xdr.OperationTypeAllowTrust:
+		// The issuer of the asset is the source of the operation.
+		issuer := operationSource(transaction, operation).ToAccountId()
+		assets = append(assets, operation.Body.MustAllowTrustOp().Asset.ToAsset(issuer))
+
+	case xdr.OperationTypeSetTrustLineFlags:
+		assets = append(assets, operation.Body.MustSetTrustLineFlagsOp().Asset)
+
+	case xdr.OperationTypeClawback:
+		assets = append(assets, operation.Body.MustClawbackOp().Asset)
+
+	case xdr.OperationTypeCreateClaimableBalance:
+		assets = append(assets, operation.Body.MustCreateClaimableBalanceOp().Asset)
+
+	case xdr.OperationTypeClaimClaimableBalance,
+		xdr.OperationTypeClawbackClaimableBalance,
+		xdr.OperationTypeLiquidityPoolDeposit,
+		xdr.OperationTypeLiquidityPoolWithdraw:
+		// The operation only references the balance or pool by ID, so the
+		// assets have to come from the entries it changed.
+		changes, err := transaction.GetOperationChanges(uint32(opIndex))
+		if err != nil {
+			return nil, err
+		}
+		for _, change := range changes {
+			assets = append(assets, changeAssets(change)...)
+		}
+	}
+
+	return assetKeys(assets), nil
+}
+
+// GetOperationLiquidityPools returns the keys of the liquidity pools an
+// operation interacts with, whether by ID (deposits and withdrawals), by
+// parameters (pool share trustlines) or by trading through them (path
+// payments and offers, which only show up in the meta).
+func GetOperationLiquidityPools(transaction ingest.LedgerTransaction, operation xdr.Operation, opIndex int) ([]string, error) {
+	pools := []string{}
+
+	switch operation.Body.Type {
+	case xdr.OperationTypeLiquidityPoolDeposit:
+		pools = append(pools, LiquidityPoolKey(operation.Body.MustLiquidityPoolDepositOp().LiquidityPoolId))
+
+	case xdr.OperationTypeLiquidityPoolWithdraw:
+		pools = append(pools, LiquidityPoolKey(operation.Body.MustLiquidityPoolWithdrawOp().LiquidityPoolId))
+
+	case xdr.OperationTypeChangeTrust:
+		line := operation.Body.MustChangeTrustOp().Line
+		if line.Type == xdr.AssetTypeAssetTypePoolShare {
+			params := line.MustLiquidityPool().MustConstantProduct()
+			id, err := xdr.NewPoolId(params.AssetA, params.AssetB, params.Fee)
+			if err != nil {
+				return nil, err
+			}
+			pools = append(pools, LiquidityPoolKey(id))
+		}
+	}
+
+	// Liquidity pools predate the first meta version with per-operation
+	// changes, so there's nothing else to find in older ledgers.
+	if transaction.UnsafeMeta.V == 0 {
+		return dedupe(pools), nil
+	}
+
+	changes, err := transaction.GetOperationChanges(uint32(opIndex))
+	if err != nil {
+		return nil, err
+	}
+	for _, change := range changes {
+		entry := change.Post
+		if entry == nil {
+			entry = change.Pre
+		}
+
+		switch change.Type {
+		case xdr.LedgerEntryTypeLiquidityPool:
+			pools = append(pools, LiquidityPoolKey(entry.Data.MustLiquidityPool().LiquidityPoolId))
+		case xdr.LedgerEntryTypeTrustline:
+			asset := entry.Data.MustTrustLine().Asset
+			if asset.Type == xdr.AssetTypeAssetTypePoolShare {
+				pools = append(pools, LiquidityPoolKey(*asset.LiquidityPoolId))
+			}
+		}
+	}
+
+	return dedupe(pools), nil
+}
+
+// changeAssets returns the assets held by the entry of a ledger change.
+func changeAssets(change ingest.Change) []xdr.Asset {
+	entry := change.Post
+	if entry == nil {
+		entry = change.Pre
+	}
+	if entry == nil {
+		return nil
+	}
+
+	switch change.Type {
+	case xdr.LedgerEntryTypeClaimableBalance:
+		return []xdr.Asset{entry.Data.MustClaimableBalance().Asset}
+	case xdr.LedgerEntryTypeLiquidityPool:
+		params := entry.Data.MustLiquidityPool().Body.MustConstantProduct().Params
+		return []xdr.Asset{params.AssetA, params.AssetB}
+	}
+	return nil
+}
+
+func assetKeys(assets []xdr.Asset) []string {
+	keys := make([]string, 0, len(assets))
+	for _, asset := range assets {
+		if key, ok := AssetKey(asset); ok {
+			keys = append(keys, key)
+		}
+	}
+	return dedupe(keys)
+}
+
+func operationSource(transaction ingest.LedgerTransaction, operation xdr.Operation) xdr.MuxedAccount {
+	if operation.SourceAccount != nil {
+		return *operation.SourceAccount
+	}
+	return transaction.Envelope.SourceAccount()
+}
+
+func dedupe(keys []string) []string {
+	seen := make(map[string]struct{}, len(keys))
+	result := keys[:0]
+	for _, key := range keys {
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		result = append(result, key)
+	}
+	return result
+}
+
+// ParseAssetKey is the inverse of AssetKey, and is useful for validating user
+// input before it's used as an index key.
+func ParseAssetKey(key string) (xdr.Asset, error) {
+	assets, err := xdr.BuildAssets(key)
+	if err != nil {
+		return xdr.Asset{}, err
+	}
+	if len(assets) != 1 {
+		return xdr.Asset{}, fmt.Errorf("%s is not a single asset", key)
+	}
+	if assets[0].Type == xdr.AssetTypeAssetTypeNative {
+		return xdr.Asset{}, fmt.Errorf("the native asset is not indexed")
+	}
+	return assets[0], nil
+}
@@ -0,0 +1,227 @@
+package index
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shantanu-hashcash/go/ingest"
+	"github.com/shantanu-hashcash/go/xdr"
+)
+
+const (
+	testSource = "GDMQQNJM4UL7QIA66P7R2PZHMQINWZBM77BEBMHLFXD5JEUAHGJ7R4JZ"
+	testIssuer = "GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU"
+)
+
+var (
+	usd = xdr.MustNewCreditAsset("USD", testIssuer)
+	eur = xdr.MustNewCreditAsset("EUR", testIssuer)
+	btc = xdr.MustNewCreditAsset("BTC", testIssuer)
+)
+
+func TestAssetsForPayments(t *testing.T) {
+	destination := xdr.MustMuxedAddress(testIssuer)
+	tx := testTransaction(nil,
+		xdr.OperationBody{
+			Type: xdr.OperationTypePayment,
+			PaymentOp: &xdr.PaymentOp{
+				Destination: destination,
+				Asset:       usd,
+				Amount:      1,
+			},
+		},
+		xdr.OperationBody{
+			Type: xdr.OperationTypePathPaymentStrictSend,
+			PathPaymentStrictSendOp: &xdr.PathPaymentStrictSendOp{
+				SendAsset:   eur,
+				SendAmount:  1,
+				Destination: destination,
+				DestAsset:   xdr.MustNewNativeAsset(),
+				DestMin:     1,
+				Path:        []xdr.Asset{btc},
+			},
+		},
+		xdr.OperationBody{
+			Type: xdr.OperationTypeManageSellOffer,
+			ManageSellOfferOp: &xdr.ManageSellOfferOp{
+				Selling: btc,
+				Buying:  usd,
+				Price:   xdr.Price{N: 1, D: 1},
+			},
+		},
+	)
+
+	all, err := GetTransactionAssets(tx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		usd.StringCanonical(),
+		eur.StringCanonical(), btc.StringCanonical(),
+		btc.StringCanonical(), usd.StringCanonical(),
+	}, all)
+
+	payments, err := GetPaymentAssets(tx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{usd.StringCanonical(), eur.StringCanonical()}, payments)
+}
+
+func TestAssetsAndPoolsFromMeta(t *testing.T) {
+	poolId, err := xdr.NewPoolId(eur, usd, xdr.LiquidityPoolFeeV18)
+	require.NoError(t, err)
+	pool := xdr.LedgerEntry{
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeLiquidityPool,
+			LiquidityPool: &xdr.LiquidityPoolEntry{
+				LiquidityPoolId: poolId,
+				Body: xdr.LiquidityPoolEntryBody{
+					Type: xdr.LiquidityPoolTypeLiquidityPoolConstantProduct,
+					ConstantProduct: &xdr.LiquidityPoolEntryConstantProduct{
+						Params: xdr.LiquidityPoolConstantProductParameters{
+							AssetA: eur,
+							AssetB: usd,
+							Fee:    xdr.LiquidityPoolFeeV18,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tx := testTransaction(
+		[]xdr.LedgerEntryChanges{{
+			{Type: xdr.LedgerEntryChangeTypeLedgerEntryState, State: &pool},
+			{Type: xdr.LedgerEntryChangeTypeLedgerEntryUpdated, Updated: &pool},
+		}},
+		xdr.OperationBody{
+			Type: xdr.OperationTypeLiquidityPoolDeposit,
+			LiquidityPoolDepositOp: &xdr.LiquidityPoolDepositOp{
+				LiquidityPoolId: poolId,
+				MaxAmountA:      1,
+				MaxAmountB:      1,
+				MinPrice:        xdr.Price{N: 1, D: 1},
+				MaxPrice:        xdr.Price{N: 1, D: 1},
+			},
+		},
+	)
+
+	assets, err := GetTransactionAssets(tx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{eur.StringCanonical(), usd.StringCanonical()}, assets)
+
+	pools, err := GetTransactionLiquidityPools(tx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{LiquidityPoolKey(poolId)}, pools)
+
+	payments, err := GetPaymentAssets(tx)
+	require.NoError(t, err)
+	assert.Empty(t, payments)
+}
+
+func TestContractsForInvocation(t *testing.T) {
+	invoked, other := xdr.Hash{1}, xdr.Hash{2}
+	tx := testTransaction(
+		[]xdr.LedgerEntryChanges{{
+			{
+				Type: xdr.LedgerEntryChangeTypeLedgerEntryCreated,
+				Created: &xdr.LedgerEntry{
+					Data: xdr.LedgerEntryData{
+						Type: xdr.LedgerEntryTypeContractData,
+						ContractData: &xdr.ContractDataEntry{
+							Contract: xdr.ScAddress{
+								Type:       xdr.ScAddressTypeScAddressTypeContract,
+								ContractId: &other,
+							},
+							Key: xdr.ScVal{Type: xdr.ScValTypeScvVoid},
+							Val: xdr.ScVal{Type: xdr.ScValTypeScvVoid},
+						},
+					},
+				},
+			},
+		}},
+		xdr.OperationBody{
+			Type: xdr.OperationTypeInvokeHostFunction,
+			InvokeHostFunctionOp: &xdr.InvokeHostFunctionOp{
+				HostFunction: xdr.HostFunction{
+					Type: xdr.HostFunctionTypeHostFunctionTypeInvokeContract,
+					InvokeContract: &xdr.InvokeContractArgs{
+						ContractAddress: xdr.ScAddress{
+							Type:       xdr.ScAddressTypeScAddressTypeContract,
+							ContractId: &invoked,
+						},
+						FunctionName: "transfer",
+					},
+				},
+			},
+		},
+	)
+	tx.UnsafeMeta.V3.SorobanMeta = &xdr.SorobanTransactionMeta{
+		Events: []xdr.ContractEvent{{ContractId: &invoked}},
+	}
+
+	invokedKey, err := ContractKey(invoked)
+	require.NoError(t, err)
+	otherKey, err := ContractKey(other)
+	require.NoError(t, err)
+
+	contracts, err := GetTransactionContracts(tx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{invokedKey, otherKey}, contracts)
+
+	// Soroban operations have no account participants other than the source,
+	// which shouldn't stop the account indices from being built.
+	participants, err := GetTransactionParticipants(tx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{testSource}, participants)
+}
+
+func TestParseAssetKey(t *testing.T) {
+	asset, err := ParseAssetKey(usd.StringCanonical())
+	require.NoError(t, err)
+	assert.True(t, asset.Equals(usd))
+
+	_, err = ParseAssetKey("native")
+	assert.Error(t, err)
+	_, err = ParseAssetKey("USD")
+	assert.Error(t, err)
+	_, err = ParseAssetKey(usd.StringCanonical() + "," + eur.StringCanonical())
+	assert.Error(t, err)
+}
+
+// testTransaction builds a successful transaction from the source account
+// with the given operations and, optionally, the changes made by each.
+func testTransaction(changes []xdr.LedgerEntryChanges, bodies ...xdr.OperationBody) ingest.LedgerTransaction {
+	ops := make([]xdr.Operation, len(bodies))
+	opsMeta := make([]xdr.OperationMeta, len(bodies))
+	for i, body := range bodies {
+		ops[i] = xdr.Operation{Body: body}
+		if i < len(changes) {
+			opsMeta[i].Changes = changes[i]
+		}
+	}
+
+	return ingest.LedgerTransaction{
+		Index: 1,
+		Envelope: xdr.TransactionEnvelope{
+			Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+			V1: &xdr.TransactionV1Envelope{
+				Tx: xdr.Transaction{
+					SourceAccount: xdr.MustMuxedAddress(testSource),
+					Operations:    ops,
+				},
+			},
+		},
+		Result: xdr.TransactionResultPair{
+			Result: xdr.TransactionResult{
+				Result: xdr.TransactionResultResult{
+					Code:    xdr.TransactionResultCodeTxSuccess,
+					Results: &[]xdr.OperationResult{},
+				},
+			},
+		},
+		UnsafeMeta: xdr.TransactionMeta{
+			V:  3,
+			V3: &xdr.TransactionMetaV3{Operations: opsMeta},
+		},
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/shantanu-hashcash/go/support/collections/set"
 	"github.com/shantanu-hashcash/go/support/errors"
 	"github.com/shantanu-hashcash/go/support/log"
 
@@ -44,10 +45,28 @@ func NewS3Backend(awsConfig *aws.Config, bucket string, pathPrefix string, paral
 	}, nil
 }
 
+// FlushAccounts adds the accounts to the list of accounts in the bucket. Like
+// the file backend, it appends rather than replaces, since we might flush a
+// batch of accounts at a time.
 func (s *S3Backend) FlushAccounts(accounts []string) error {
+	existing, err := s.ReadAccounts()
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	all := set.NewSet[string](len(existing) + len(accounts))
+	merged := make([]string, 0, len(existing)+len(accounts))
+	for _, account := range append(existing, accounts...) {
+		if account == "" || all.Contains(account) {
+			continue
+		}
+		all.Add(account)
+		merged = append(merged, account)
+	}
+
 	var buf bytes.Buffer
-	accountsString := strings.Join(accounts, "\n")
-	_, err := buf.WriteString(accountsString)
+	accountsString := strings.Join(merged, "\n")
+	_, err = buf.WriteString(accountsString)
 	if err != nil {
 		return err
 	}
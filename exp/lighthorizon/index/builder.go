@@ -99,6 +99,21 @@ func BuildIndices(
 		case "accounts_by_ledger_unbacked":
 			indexBuilder.RegisterModule(ProcessAccountsByLedgerWithoutBackend)
 			indexStore.ClearMemory(false)
+		case "assets":
+			indexBuilder.RegisterModule(ProcessAssets)
+		case "assets_unbacked":
+			indexBuilder.RegisterModule(ProcessAssetsWithoutBackend)
+			indexStore.ClearMemory(false)
+		case "liquidity_pools":
+			indexBuilder.RegisterModule(ProcessLiquidityPools)
+		case "liquidity_pools_unbacked":
+			indexBuilder.RegisterModule(ProcessLiquidityPoolsWithoutBackend)
+			indexStore.ClearMemory(false)
+		case "contracts":
+			indexBuilder.RegisterModule(ProcessContracts)
+		case "contracts_unbacked":
+			indexBuilder.RegisterModule(ProcessContractsWithoutBackend)
+			indexStore.ClearMemory(false)
 		default:
 			return indexBuilder, fmt.Errorf("unknown module '%s'", part)
 		}
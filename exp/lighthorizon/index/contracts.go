@@ -0,0 +1,111 @@
+package index
+
+import (
+	"github.com/shantanu-hashcash/go/ingest"
+	"github.com/shantanu-hashcash/go/strkey"
+	"github.com/shantanu-hashcash/go/xdr"
+)
+
+// ContractKey returns the key under which a contract's indices are stored,
+// which is its strkey-encoded (C...) address.
+func ContractKey(id xdr.Hash) (string, error) {
+	return strkey.Encode(strkey.VersionByteContract, id[:])
+}
+
+func GetTransactionContracts(transaction ingest.LedgerTransaction) ([]string, error) {
+	var contracts []string
+	for opIndex, operation := range transaction.Envelope.Operations() {
+		opContracts, err := GetOperationContracts(transaction, operation, opIndex)
+		if err != nil {
+			return []string{}, err
+		}
+		contracts = append(contracts, opContracts...)
+	}
+	return dedupe(contracts), nil
+}
+
+// GetOperationContracts returns the keys of the Soroban contracts an operation
+// interacts with: the contract it invokes, the contracts whose data it reads or
+// writes (which includes contracts invoked by other contracts and newly
+// created ones) and the contracts that emitted events during it.
+func GetOperationContracts(transaction ingest.LedgerTransaction, operation xdr.Operation, opIndex int) ([]string, error) {
+	ids := []xdr.Hash{}
+
+	switch operation.Body.Type {
+	case xdr.OperationTypeInvokeHostFunction,
+		xdr.OperationTypeExtendFootprintTtl,
+		xdr.OperationTypeRestoreFootprint:
+	default:
+		return []string{}, nil
+	}
+
+	if op, ok := operation.Body.GetInvokeHostFunctionOp(); ok {
+		if args, ok := op.HostFunction.GetInvokeContract(); ok {
+			if id, ok := args.ContractAddress.GetContractId(); ok {
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	// The footprint is the only place in which contracts are referenced
+	// by failed transactions and by TTL extensions.
+	if data, ok := sorobanData(transaction.Envelope); ok {
+		footprint := data.Resources.Footprint
+		for _, key := range append(footprint.ReadOnly, footprint.ReadWrite...) {
+			if contractData, ok := key.GetContractData(); ok {
+				if id, ok := contractData.Contract.GetContractId(); ok {
+					ids = append(ids, id)
+				}
+			}
+		}
+	}
+
+	if transaction.UnsafeMeta.V >= 3 {
+		changes, err := transaction.GetOperationChanges(uint32(opIndex))
+		if err != nil {
+			return nil, err
+		}
+		for _, change := range changes {
+			if change.Type != xdr.LedgerEntryTypeContractData {
+				continue
+			}
+			entry := change.Post
+			if entry == nil {
+				entry = change.Pre
+			}
+			if id, ok := entry.Data.MustContractData().Contract.GetContractId(); ok {
+				ids = append(ids, id)
+			}
+		}
+
+		// Soroban transactions have exactly one operation, so all of the
+		// events belong to it.
+		if sorobanMeta := transaction.UnsafeMeta.MustV3().SorobanMeta; sorobanMeta != nil {
+			for _, event := range sorobanMeta.Events {
+				if event.ContractId != nil {
+					ids = append(ids, *event.ContractId)
+				}
+			}
+		}
+	}
+
+	contracts := make([]string, 0, len(ids))
+	for _, id := range ids {
+		key, err := ContractKey(id)
+		if err != nil {
+			return nil, err
+		}
+		contracts = append(contracts, key)
+	}
+	return dedupe(contracts), nil
+}
+
+func sorobanData(envelope xdr.TransactionEnvelope) (xdr.SorobanTransactionData, bool) {
+	switch envelope.Type {
+	case xdr.EnvelopeTypeEnvelopeTypeTx:
+		return envelope.V1.Tx.Ext.GetSorobanData()
+	case xdr.EnvelopeTypeEnvelopeTypeTxFeeBump:
+		return envelope.FeeBump.Tx.InnerTx.V1.Tx.Ext.GetSorobanData()
+	}
+	return xdr.SorobanTransactionData{}, false
+}
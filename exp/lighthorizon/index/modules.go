@@ -132,6 +132,103 @@ func ProcessAccountsWithoutBackend(
 	return nil
 }
 
+// ProcessAssets indexes the checkpoints in which each (non-native) asset is
+// referenced, using the same "all/all" and "all/payments" index names as the
+// account indices but keyed by the asset's CODE:ISSUER. Since these keys can
+// never collide with account addresses, they live alongside the account
+// indices and are merged by the same reduce job.
+func ProcessAssets(
+	indexStore Store,
+	ledger xdr.LedgerCloseMeta,
+	tx ingest.LedgerTransaction,
+) error {
+	return processAssets(ledger, tx, indexStore.AddParticipantsToIndexes)
+}
+
+func ProcessAssetsWithoutBackend(
+	indexStore Store,
+	ledger xdr.LedgerCloseMeta,
+	tx ingest.LedgerTransaction,
+) error {
+	return processAssets(ledger, tx, indexStore.AddParticipantsToIndexesNoBackend)
+}
+
+func processAssets(
+	ledger xdr.LedgerCloseMeta,
+	tx ingest.LedgerTransaction,
+	addToIndexes addParticipantsFunc,
+) error {
+	index := getIndex(ledger, ByCheckpoint)
+
+	allAssets, err := GetTransactionAssets(tx)
+	if err != nil {
+		return err
+	}
+
+	err = addToIndexes(index, "all/all", allAssets)
+	if err != nil {
+		return err
+	}
+
+	paymentAssets, err := GetPaymentAssets(tx)
+	if err != nil {
+		return err
+	}
+
+	return addToIndexes(index, "all/payments", paymentAssets)
+}
+
+// ProcessLiquidityPools indexes the checkpoints in which each liquidity pool
+// is used, keyed by the hex-encoded pool ID.
+func ProcessLiquidityPools(
+	indexStore Store,
+	ledger xdr.LedgerCloseMeta,
+	tx ingest.LedgerTransaction,
+) error {
+	return processKeys(ledger, tx, GetTransactionLiquidityPools, indexStore.AddParticipantsToIndexes)
+}
+
+func ProcessLiquidityPoolsWithoutBackend(
+	indexStore Store,
+	ledger xdr.LedgerCloseMeta,
+	tx ingest.LedgerTransaction,
+) error {
+	return processKeys(ledger, tx, GetTransactionLiquidityPools, indexStore.AddParticipantsToIndexesNoBackend)
+}
+
+// ProcessContracts indexes the checkpoints in which each Soroban contract is
+// used, keyed by the contract's C... address.
+func ProcessContracts(
+	indexStore Store,
+	ledger xdr.LedgerCloseMeta,
+	tx ingest.LedgerTransaction,
+) error {
+	return processKeys(ledger, tx, GetTransactionContracts, indexStore.AddParticipantsToIndexes)
+}
+
+func ProcessContractsWithoutBackend(
+	indexStore Store,
+	ledger xdr.LedgerCloseMeta,
+	tx ingest.LedgerTransaction,
+) error {
+	return processKeys(ledger, tx, GetTransactionContracts, indexStore.AddParticipantsToIndexesNoBackend)
+}
+
+type addParticipantsFunc func(checkpoint uint32, index string, participants []string) error
+
+func processKeys(
+	ledger xdr.LedgerCloseMeta,
+	tx ingest.LedgerTransaction,
+	getKeys func(ingest.LedgerTransaction) ([]string, error),
+	addToIndexes addParticipantsFunc,
+) error {
+	keys, err := getKeys(tx)
+	if err != nil {
+		return err
+	}
+	return addToIndexes(getIndex(ledger, ByCheckpoint), "all/all", keys)
+}
+
 // GetCheckpointNumber returns the next checkpoint NUMBER (NOT the checkpoint
 // ledger sequence) corresponding to a given ledger sequence.
 func GetCheckpointNumber(ledger uint32) uint32 {
@@ -275,6 +372,9 @@ func participantsForOperation(transaction ingest.LedgerTransaction, operation xd
 	case xdr.OperationTypeClawbackClaimableBalance:
 	case xdr.OperationTypeLiquidityPoolDeposit:
 	case xdr.OperationTypeLiquidityPoolWithdraw:
+	case xdr.OperationTypeInvokeHostFunction:
+	case xdr.OperationTypeExtendFootprintTtl:
+	case xdr.OperationTypeRestoreFootprint:
 
 	default:
 		return nil, fmt.Errorf("unknown operation type: %s", operation.Body.Type)
@@ -201,7 +201,6 @@ func (i *BitmapIndex) setInactive(index uint32) error {
 	return nil
 }
 
-//lint:ignore U1000 Ignore unused function temporarily
 func (i *BitmapIndex) isActive(index uint32) bool {
 	if index >= i.firstBit && index <= i.lastBit {
 		b := bitShiftLeft(index)
@@ -254,6 +253,26 @@ func (i *BitmapIndex) Merge(other *BitmapIndex) error {
 	return err
 }
 
+// Compact returns a copy of the index that only spans the range between its
+// first and last active bits, i.e. without any empty bytes at either end of
+// the bitmap. It's equivalent to setting every active bit on an empty index.
+func (i *BitmapIndex) Compact() (*BitmapIndex, error) {
+	compacted := &BitmapIndex{}
+
+	var err error
+	iterErr := i.iterate(func(index uint32) {
+		if err != nil || !i.isActive(index) {
+			return
+		}
+		err = compacted.setActive(index)
+	})
+	if iterErr != nil {
+		return nil, iterErr
+	}
+
+	return compacted, err
+}
+
 // NextActiveBit returns the next bit position (inclusive) where this index is
 // active. "Inclusive" means that if it's already active at `position`, this
 // returns `position`.
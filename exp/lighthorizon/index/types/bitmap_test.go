@@ -380,3 +380,33 @@ func TestMerge(t *testing.T) {
 
 	assert.Equal(t, []uint32{9, 129, 900, 1000}, checkpoints)
 }
+
+func TestCompact(t *testing.T) {
+	// An index for just checkpoint 12 that's padded with empty bytes on both
+	// sides, like one whose bits were unset by hand.
+	padded := &BitmapIndex{
+		bitmap:   []byte{0, 0b0001_0000, 0},
+		firstBit: 1,
+		lastBit:  24,
+	}
+
+	compacted, err := padded.Compact()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0b0001_0000}, compacted.bitmap)
+	assert.EqualValues(t, 12, compacted.firstBit)
+	assert.EqualValues(t, 12, compacted.lastBit)
+
+	index := &BitmapIndex{}
+	for _, checkpoint := range []uint32{3, 70, 1000} {
+		require.NoError(t, index.SetActive(checkpoint))
+	}
+	compacted, err = index.Compact()
+	require.NoError(t, err)
+	assert.Equal(t, index.bitmap, compacted.bitmap)
+	assert.Equal(t, index.firstBit, compacted.firstBit)
+	assert.Equal(t, index.lastBit, compacted.lastBit)
+
+	compacted, err = (&BitmapIndex{}).Compact()
+	require.NoError(t, err)
+	assert.Zero(t, compacted.Size())
+}
@@ -146,6 +146,9 @@ break down accounts by active ledgers.`,
 				Operations: &services.OperationRepository{
 					Config: Config,
 				},
+				Effects: &services.EffectRepository{
+					Config: Config,
+				},
 			}
 
 			// Inject our config into the root response.
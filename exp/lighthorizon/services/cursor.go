@@ -16,11 +16,19 @@ type AccountActivityCursorManager struct {
 	AccountId string
 
 	store      index.Store
+	index      string
 	lastCursor *toid.ID
 }
 
 func NewCursorManagerForAccountActivity(store index.Store, accountId string) *AccountActivityCursorManager {
-	return &AccountActivityCursorManager{AccountId: accountId, store: store}
+	return NewCursorManagerForAccountIndex(store, accountId, allTransactionsIndex)
+}
+
+// NewCursorManagerForAccountIndex returns a cursor manager that advances
+// through the checkpoints in which the account is active in the named index,
+// e.g. only those with payments.
+func NewCursorManagerForAccountIndex(store index.Store, accountId, indexName string) *AccountActivityCursorManager {
+	return &AccountActivityCursorManager{AccountId: accountId, store: store, index: indexName}
 }
 
 func (c *AccountActivityCursorManager) Begin(cursor int64) (int64, error) {
@@ -36,7 +44,7 @@ func (c *AccountActivityCursorManager) Begin(cursor int64) (int64, error) {
 	//
 	// For example, someone might say ?cursor=0 but the first active checkpoint
 	// is actually 40M ledgers in.
-	firstCheckpoint, err := c.store.NextActive(c.AccountId, allTransactionsIndex, lastCheckpoint)
+	firstCheckpoint, err := c.store.NextActive(c.AccountId, c.index, lastCheckpoint)
 	if err != nil {
 		return cursor, err
 	}
@@ -76,7 +84,7 @@ func (c *AccountActivityCursorManager) Advance(times uint) (int64, error) {
 			// is "inclusive" so if the parameter is an active checkpoint it
 			// will return itself.
 			checkpoint := index.GetCheckpointNumber(uint32(c.lastCursor.LedgerSequence))
-			checkpoint, err := c.store.NextActive(c.AccountId, allTransactionsIndex, checkpoint+1)
+			checkpoint, err := c.store.NextActive(c.AccountId, c.index, checkpoint+1)
 			if err != nil {
 				return c.lastCursor.ToInt64(), err
 			}
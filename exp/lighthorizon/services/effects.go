@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/shantanu-hashcash/go/exp/lightaurora/common"
+	"github.com/shantanu-hashcash/go/exp/lightaurora/ingester"
+	"github.com/shantanu-hashcash/go/ingest/processors"
+	"github.com/shantanu-hashcash/go/support/errors"
+	"github.com/shantanu-hashcash/go/support/log"
+	"github.com/shantanu-hashcash/go/toid"
+	"github.com/shantanu-hashcash/go/xdr"
+)
+
+type EffectService interface {
+	GetEffectsByAccount(ctx context.Context,
+		cursor int64, cursorOrder int32, limit uint64,
+		accountId string,
+	) ([]common.Effect, error)
+}
+
+type EffectRepository struct {
+	EffectService
+	Config Config
+}
+
+// GetEffectsByAccount returns the effects on the account of the operations
+// the account participates in. Like Aurora's effects paging tokens, the cursor
+// is an operation id and the order of an effect within it, and the effects
+// up to and including it are skipped.
+//
+// Effects are computed from each operation the same way Aurora ingests them.
+func (er *EffectRepository) GetEffectsByAccount(ctx context.Context,
+	cursor int64, cursorOrder int32, limit uint64,
+	accountId string,
+) ([]common.Effect, error) {
+	effs := []common.Effect{}
+
+	effectsCallback := func(tx ingester.LedgerTransaction, ledgerHeader *xdr.LedgerHeader) (bool, error) {
+		txEffects, err := transactionEffects(tx, ledgerHeader, er.Config.Passphrase)
+		if err != nil {
+			return false, err
+		}
+
+		for _, effect := range txEffects {
+			if effect.Account != accountId || effect.Operation.TOID() < cursor ||
+				(effect.Operation.TOID() == cursor && effect.Order <= cursorOrder) {
+				continue
+			}
+
+			effs = append(effs, effect)
+			if uint64(len(effs)) == limit {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	}
+
+	err := searchAccountTransactions(ctx, cursor, accountId, er.Config, effectsCallback)
+	if age := effectsResponseAgeSeconds(effs); age >= 0 {
+		er.Config.Metrics.ResponseAgeHistogram.With(prometheus.Labels{
+			"request":    "GetEffectsByAccount",
+			"successful": strconv.FormatBool(err == nil),
+		}).Observe(age)
+	}
+
+	return effs, err
+}
+
+// transactionEffects computes the effects of the operations of a transaction
+// with Aurora's effects processor, in operation order. Failed transactions
+// have no effects.
+func transactionEffects(tx ingester.LedgerTransaction, ledgerHeader *xdr.LedgerHeader, passphrase string) ([]common.Effect, error) {
+	// The processor only reads the ledger sequence from the ledger meta.
+	lcm := xdr.LedgerCloseMeta{
+		V: 0,
+		V0: &xdr.LedgerCloseMetaV0{
+			LedgerHeader: xdr.LedgerHeaderHistoryEntry{Header: *ledgerHeader},
+		},
+	}
+	txEffects, err := processors.TransactionEffects(lcm, *tx.LedgerTransaction, passphrase)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compute effects of transaction %d", tx.Index)
+	}
+
+	operations := map[int64]*common.Operation{}
+	effs := make([]common.Effect, 0, len(txEffects))
+	for _, effect := range txEffects {
+		op, ok := operations[effect.OperationID]
+		if !ok {
+			op = &common.Operation{
+				TransactionEnvelope: &tx.Envelope,
+				TransactionResult:   &tx.Result.Result,
+				LedgerHeader:        ledgerHeader,
+				TxIndex:             int32(tx.Index),
+				OpIndex:             toid.Parse(effect.OperationID).OperationOrder - 1,
+			}
+			operations[effect.OperationID] = op
+		}
+
+		effs = append(effs, common.Effect{
+			Operation:    op,
+			Order:        int32(effect.Order),
+			Type:         effect.Type,
+			Account:      effect.Address,
+			AccountMuxed: effect.AddressMuxed,
+			Details:      effect.Details,
+		})
+	}
+	return effs, nil
+}
+
+func effectsResponseAgeSeconds(effs []common.Effect) float64 {
+	if len(effs) == 0 {
+		return -1
+	}
+
+	oldest := effs[0].Operation.LedgerHeader.ScpValue.CloseTime
+	for i := 1; i < len(effs); i++ {
+		if closeTime := effs[i].Operation.LedgerHeader.ScpValue.CloseTime; closeTime < oldest {
+			oldest = closeTime
+		}
+	}
+
+	lastCloseTime := time.Unix(int64(oldest), 0).UTC()
+	now := time.Now().UTC()
+	if now.Before(lastCloseTime) {
+		log.Errorf("current time %v is before oldest effect close time %v", now, lastCloseTime)
+		return -1
+	}
+	return now.Sub(lastCloseTime).Seconds()
+}
+
+var _ EffectService = (*EffectRepository)(nil) // ensure conformity to the interface
@@ -58,6 +58,7 @@ func NewMetrics(registry *prometheus.Registry) Metrics {
 type LightAurora struct {
 	Operations   OperationService
 	Transactions TransactionService
+	Effects      EffectService
 }
 
 type Metrics struct {
@@ -82,7 +83,45 @@ func searchAccountTransactions(ctx context.Context,
 	config Config,
 	callback searchCallback,
 ) error {
-	cursorMgr := NewCursorManagerForAccountActivity(config.IndexStore, accountId)
+	return searchAccountIndex(ctx, cursor, accountId, allTransactionsIndex, config, callback)
+}
+
+// searchAccountIndex is like searchAccountTransactions but only visits the
+// ledgers in which the account is active in the named index.
+func searchAccountIndex(ctx context.Context,
+	cursor int64,
+	accountId string,
+	indexName string,
+	config Config,
+	callback searchCallback,
+) error {
+	// Note: If we move to ledger-based indices, we don't need this filter,
+	// since we have a guarantee that the transaction will contain the
+	// account as a participant.
+	isParticipant := func(tx ingester.LedgerTransaction) (bool, error) {
+		participants, err := ingester.GetTransactionParticipants(tx)
+		if err != nil {
+			return false, err
+		}
+		_, found := participants[accountId]
+		return found, nil
+	}
+
+	return searchIndex(ctx, cursor, accountId, indexName, config, isParticipant, callback)
+}
+
+// searchIndex visits the transactions in the ledgers in which the key (an
+// account, asset, liquidity pool or contract) is active in the named index,
+// passing the ones accepted by the filter to the callback.
+func searchIndex(ctx context.Context,
+	cursor int64,
+	key string,
+	indexName string,
+	config Config,
+	filter func(ingester.LedgerTransaction) (bool, error),
+	callback searchCallback,
+) error {
+	cursorMgr := NewCursorManagerForAccountIndex(config.IndexStore, key, indexName)
 	cursor, err := cursorMgr.Begin(cursor)
 	if err == io.EOF {
 		return nil
@@ -92,8 +131,8 @@ func searchAccountTransactions(ctx context.Context,
 	nextLedger := getLedgerFromCursor(cursor)
 
 	log.WithField("cursor", cursor).
-		Debugf("Searching %s for account %s starting at ledger %d",
-			allTransactionsIndex, accountId, nextLedger)
+		Debugf("Searching %s for %s starting at ledger %d",
+			indexName, key, nextLedger)
 
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
@@ -113,7 +152,7 @@ func searchAccountTransactions(ctx context.Context,
 			WithField("avg-ledger-process", getAverageDuration(processDuration, count)).
 			WithField("avg-index-fetch", getAverageDuration(indexFetchDuration, count)).
 			WithField("total", time.Since(fullStart)).
-			Infof("Fulfilled request for account %s at cursor %d", accountId, cursor)
+			Infof("Fulfilled request for %s at cursor %d", key, cursor)
 	}()
 
 	checkpointMgr := historyarchive.NewCheckpointManager(0)
@@ -169,15 +208,12 @@ func searchAccountTransactions(ctx context.Context,
 				return readErr
 			}
 
-			// Note: If we move to ledger-based indices, we don't need this,
-			// since we have a guarantee that the transaction will contain
-			// the account as a participant.
-			participants, participantErr := ingester.GetTransactionParticipants(tx)
-			if participantErr != nil {
-				return participantErr
+			found, filterErr := filter(tx)
+			if filterErr != nil {
+				return filterErr
 			}
 
-			if _, found := participants[accountId]; found {
+			if found {
 				finished, callBackErr := callback(tx, &ledger.V0.V0.LedgerHeader.Header)
 				if callBackErr != nil {
 					return callBackErr
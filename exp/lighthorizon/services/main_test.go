@@ -2,7 +2,9 @@ package services
 
 import (
 	"context"
+	"encoding/hex"
 	"io"
+	"os"
 	"testing"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -10,6 +12,7 @@ import (
 	"github.com/shantanu-hashcash/go/exp/lightaurora/index"
 	"github.com/shantanu-hashcash/go/exp/lightaurora/ingester"
 	"github.com/shantanu-hashcash/go/ingest"
+	"github.com/shantanu-hashcash/go/network"
 	"github.com/shantanu-hashcash/go/toid"
 	"github.com/shantanu-hashcash/go/xdr"
 	"github.com/stretchr/testify/mock"
@@ -95,6 +98,59 @@ func TestItGetsOperationsByAccount(t *testing.T) {
 	})
 }
 
+func TestItGetsPaymentsByAccount(t *testing.T) {
+	ctx := context.Background()
+
+	ledgerSeq := checkpointMgr.PrevCheckpoint(uint32(startLedgerSeq))
+	cursor := toid.New(int32(ledgerSeq), 1, 1).ToInt64()
+
+	// the account only bumps its sequence, which is not a payment
+	opsService := newOperationService(ctx)
+	ops, err := opsService.GetPaymentsByAccount(ctx, cursor, 5, accountId)
+	require.NoError(t, err)
+	require.Empty(t, ops)
+}
+
+func TestItGetsTransactionByHash(t *testing.T) {
+	ctx := context.Background()
+
+	archive, store := mockArchiveAndIndex(ctx)
+	txService := &TransactionRepository{
+		Config: Config{
+			Ingester:   archive,
+			IndexStore: store,
+			Passphrase: passphrase,
+			Metrics:    NewMetrics(prometheus.NewRegistry()),
+		},
+	}
+
+	expected := testLedgerTx(xdr.MustAddress(accountId), 2, 34)
+	hash, err := network.HashTransactionInEnvelope(expected.Envelope, passphrase)
+	require.NoError(t, err)
+	missing := [32]byte{1}
+
+	store.(*index.MockStore).
+		On("TransactionTOID", [32]byte(hash)).Return(toid.New(int32(startLedgerSeq+1), 2, 0).ToInt64(), nil).
+		On("TransactionTOID", missing).Return(int64(0), io.EOF)
+
+	t.Run("found", func(tt *testing.T) {
+		tx, err := txService.GetTransactionByHash(ctx, hex.EncodeToString(hash[:]))
+		require.NoError(tt, err)
+		require.Equal(tt, xdr.Uint32(1586113), tx.LedgerHeader.LedgerSeq)
+		require.EqualValues(tt, 2, tx.TxIndex)
+	})
+
+	t.Run("not found", func(tt *testing.T) {
+		_, err := txService.GetTransactionByHash(ctx, hex.EncodeToString(missing[:]))
+		require.True(tt, os.IsNotExist(err))
+	})
+
+	t.Run("invalid hash", func(tt *testing.T) {
+		_, err := txService.GetTransactionByHash(ctx, "abc")
+		require.Error(tt, err)
+	})
+}
+
 func mockArchiveAndIndex(ctx context.Context) (ingester.Ingester, index.Store) {
 	mockArchive := &ingester.MockIngester{}
 	mockReaderLedger1 := &ingester.MockLedgerTransactionReader{}
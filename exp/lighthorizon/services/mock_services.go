@@ -30,3 +30,60 @@ func (m *MockOperationService) GetOperationsByAccount(ctx context.Context,
 	args := m.Called(ctx, cursor, limit, accountId)
 	return args.Get(0).([]common.Operation), args.Error(1)
 }
+
+func (m *MockTransactionService) GetTransactionByHash(ctx context.Context, hash string) (common.Transaction, error) {
+	args := m.Called(ctx, hash)
+	return args.Get(0).(common.Transaction), args.Error(1)
+}
+
+func (m *MockOperationService) GetPaymentsByAccount(ctx context.Context,
+	cursor int64, limit uint64,
+	accountId string,
+) ([]common.Operation, error) {
+	args := m.Called(ctx, cursor, limit, accountId)
+	return args.Get(0).([]common.Operation), args.Error(1)
+}
+
+func (m *MockOperationService) GetOperationsByAsset(ctx context.Context,
+	cursor int64, limit uint64,
+	asset string,
+) ([]common.Operation, error) {
+	args := m.Called(ctx, cursor, limit, asset)
+	return args.Get(0).([]common.Operation), args.Error(1)
+}
+
+func (m *MockOperationService) GetPaymentsByAsset(ctx context.Context,
+	cursor int64, limit uint64,
+	asset string,
+) ([]common.Operation, error) {
+	args := m.Called(ctx, cursor, limit, asset)
+	return args.Get(0).([]common.Operation), args.Error(1)
+}
+
+func (m *MockOperationService) GetOperationsByLiquidityPool(ctx context.Context,
+	cursor int64, limit uint64,
+	liquidityPoolId string,
+) ([]common.Operation, error) {
+	args := m.Called(ctx, cursor, limit, liquidityPoolId)
+	return args.Get(0).([]common.Operation), args.Error(1)
+}
+
+func (m *MockOperationService) GetOperationsByContract(ctx context.Context,
+	cursor int64, limit uint64,
+	contractId string,
+) ([]common.Operation, error) {
+	args := m.Called(ctx, cursor, limit, contractId)
+	return args.Get(0).([]common.Operation), args.Error(1)
+}
+
+type MockEffectService struct {
+	mock.Mock
+}
+
+func (m *MockEffectService) GetEffectsByAccount(ctx context.Context,
+	cursor int64, cursorOrder int32, limit uint64,
+	accountId string,
+) ([]common.Effect, error) {
+	args := m.Called(ctx, cursor, cursorOrder, limit, accountId)
+	return args.Get(0).([]common.Effect), args.Error(1)
+}
@@ -7,7 +7,9 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/shantanu-hashcash/go/exp/lightaurora/common"
+	"github.com/shantanu-hashcash/go/exp/lightaurora/index"
 	"github.com/shantanu-hashcash/go/exp/lightaurora/ingester"
+	"github.com/shantanu-hashcash/go/ingest"
 	"github.com/shantanu-hashcash/go/support/log"
 	"github.com/shantanu-hashcash/go/xdr"
 )
@@ -17,6 +19,26 @@ type OperationService interface {
 		cursor int64, limit uint64,
 		accountId string,
 	) ([]common.Operation, error)
+	GetPaymentsByAccount(ctx context.Context,
+		cursor int64, limit uint64,
+		accountId string,
+	) ([]common.Operation, error)
+	GetOperationsByAsset(ctx context.Context,
+		cursor int64, limit uint64,
+		asset string,
+	) ([]common.Operation, error)
+	GetPaymentsByAsset(ctx context.Context,
+		cursor int64, limit uint64,
+		asset string,
+	) ([]common.Operation, error)
+	GetOperationsByLiquidityPool(ctx context.Context,
+		cursor int64, limit uint64,
+		liquidityPoolId string,
+	) ([]common.Operation, error)
+	GetOperationsByContract(ctx context.Context,
+		cursor int64, limit uint64,
+		contractId string,
+	) ([]common.Operation, error)
 }
 
 type OperationRepository struct {
@@ -66,6 +88,174 @@ func (or *OperationRepository) GetOperationsByAccount(ctx context.Context,
 	return ops, err
 }
 
+// GetPaymentsByAccount returns the payment operations the account
+// participates in, i.e. the same operations as Aurora's /payments endpoints:
+// account creations, payments, path payments and account merges.
+func (or *OperationRepository) GetPaymentsByAccount(ctx context.Context,
+	cursor int64, limit uint64,
+	accountId string,
+) ([]common.Operation, error) {
+	ops := []common.Operation{}
+
+	opsCallback := func(tx ingester.LedgerTransaction, ledgerHeader *xdr.LedgerHeader) (bool, error) {
+		for operationOrder, op := range tx.Envelope.Operations() {
+			if !isPaymentOperation(op.Body.Type) {
+				continue
+			}
+
+			opParticipants, err := ingester.GetOperationParticipants(tx, op, operationOrder)
+			if err != nil {
+				return false, err
+			}
+
+			if _, foundInOp := opParticipants[accountId]; foundInOp {
+				ops = append(ops, common.Operation{
+					TransactionEnvelope: &tx.Envelope,
+					TransactionResult:   &tx.Result.Result,
+					LedgerHeader:        ledgerHeader,
+					TxIndex:             int32(tx.Index),
+					OpIndex:             int32(operationOrder),
+				})
+
+				if uint64(len(ops)) == limit {
+					return true, nil
+				}
+			}
+		}
+
+		return false, nil
+	}
+
+	err := searchAccountIndex(ctx, cursor, accountId, allPaymentsIndex, or.Config, opsCallback)
+	if age := operationsResponseAgeSeconds(ops); age >= 0 {
+		or.Config.Metrics.ResponseAgeHistogram.With(prometheus.Labels{
+			"request":    "GetPaymentsByAccount",
+			"successful": strconv.FormatBool(err == nil),
+		}).Observe(age)
+	}
+
+	return ops, err
+}
+
+// GetOperationsByAsset returns the operations that reference the asset, given
+// in its canonical CODE:ISSUER form.
+func (or *OperationRepository) GetOperationsByAsset(ctx context.Context,
+	cursor int64, limit uint64,
+	asset string,
+) ([]common.Operation, error) {
+	return or.getOperationsByKey(ctx, cursor, limit, asset,
+		allTransactionsIndex, index.GetOperationAssets, "GetOperationsByAsset")
+}
+
+// GetPaymentsByAsset returns the payment operations that transfer the asset,
+// given in its canonical CODE:ISSUER form.
+func (or *OperationRepository) GetPaymentsByAsset(ctx context.Context,
+	cursor int64, limit uint64,
+	asset string,
+) ([]common.Operation, error) {
+	return or.getOperationsByKey(ctx, cursor, limit, asset,
+		allPaymentsIndex, index.GetOperationPaymentAssets, "GetPaymentsByAsset")
+}
+
+// GetOperationsByLiquidityPool returns the operations that use the liquidity
+// pool, given by its hex-encoded ID.
+func (or *OperationRepository) GetOperationsByLiquidityPool(ctx context.Context,
+	cursor int64, limit uint64,
+	liquidityPoolId string,
+) ([]common.Operation, error) {
+	return or.getOperationsByKey(ctx, cursor, limit, liquidityPoolId,
+		allTransactionsIndex, index.GetOperationLiquidityPools, "GetOperationsByLiquidityPool")
+}
+
+// GetOperationsByContract returns the operations that use the Soroban
+// contract, given by its C... address.
+func (or *OperationRepository) GetOperationsByContract(ctx context.Context,
+	cursor int64, limit uint64,
+	contractId string,
+) ([]common.Operation, error) {
+	return or.getOperationsByKey(ctx, cursor, limit, contractId,
+		allTransactionsIndex, index.GetOperationContracts, "GetOperationsByContract")
+}
+
+// operationKeysFunc returns the index keys that an operation is relevant to,
+// given the transaction, the operation and its 0-based index.
+type operationKeysFunc func(ingest.LedgerTransaction, xdr.Operation, int) ([]string, error)
+
+// getOperationsByKey searches the named index of the key and returns the
+// operations for which opKeys includes the key.
+func (or *OperationRepository) getOperationsByKey(ctx context.Context,
+	cursor int64, limit uint64,
+	key, indexName string,
+	opKeys operationKeysFunc,
+	request string,
+) ([]common.Operation, error) {
+	ops := []common.Operation{}
+
+	// The operations are filtered individually below, so every transaction
+	// in an active ledger has to be looked at.
+	anyTransaction := func(ingester.LedgerTransaction) (bool, error) {
+		return true, nil
+	}
+
+	opsCallback := func(tx ingester.LedgerTransaction, ledgerHeader *xdr.LedgerHeader) (bool, error) {
+		for operationOrder, op := range tx.Envelope.Operations() {
+			keys, err := opKeys(*tx.LedgerTransaction, op, operationOrder)
+			if err != nil {
+				return false, err
+			}
+
+			if !containsKey(keys, key) {
+				continue
+			}
+
+			ops = append(ops, common.Operation{
+				TransactionEnvelope: &tx.Envelope,
+				TransactionResult:   &tx.Result.Result,
+				LedgerHeader:        ledgerHeader,
+				TxIndex:             int32(tx.Index),
+				OpIndex:             int32(operationOrder),
+			})
+
+			if uint64(len(ops)) == limit {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	}
+
+	err := searchIndex(ctx, cursor, key, indexName, or.Config, anyTransaction, opsCallback)
+	if age := operationsResponseAgeSeconds(ops); age >= 0 {
+		or.Config.Metrics.ResponseAgeHistogram.With(prometheus.Labels{
+			"request":    request,
+			"successful": strconv.FormatBool(err == nil),
+		}).Observe(age)
+	}
+
+	return ops, err
+}
+
+func containsKey(keys []string, key string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+func isPaymentOperation(opType xdr.OperationType) bool {
+	switch opType {
+	case xdr.OperationTypeCreateAccount,
+		xdr.OperationTypePayment,
+		xdr.OperationTypePathPaymentStrictReceive,
+		xdr.OperationTypePathPaymentStrictSend,
+		xdr.OperationTypeAccountMerge:
+		return true
+	}
+	return false
+}
+
 func operationsResponseAgeSeconds(ops []common.Operation) float64 {
 	if len(ops) == 0 {
 		return -1
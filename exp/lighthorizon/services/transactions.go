@@ -2,13 +2,18 @@ package services
 
 import (
 	"context"
+	"encoding/hex"
+	"io"
+	"os"
 	"strconv"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/shantanu-hashcash/go/exp/lightaurora/common"
 	"github.com/shantanu-hashcash/go/exp/lightaurora/ingester"
+	"github.com/shantanu-hashcash/go/support/errors"
 	"github.com/shantanu-hashcash/go/support/log"
+	"github.com/shantanu-hashcash/go/toid"
 	"github.com/shantanu-hashcash/go/xdr"
 )
 
@@ -22,6 +27,7 @@ type TransactionService interface {
 		cursor int64, limit uint64,
 		accountId string,
 	) ([]common.Transaction, error)
+	GetTransactionByHash(ctx context.Context, hash string) (common.Transaction, error)
 }
 
 func (tr *TransactionRepository) GetTransactionsByAccount(ctx context.Context,
@@ -52,6 +58,73 @@ func (tr *TransactionRepository) GetTransactionsByAccount(ctx context.Context,
 	return txs, err
 }
 
+// GetTransactionByHash finds the transaction in the transaction index and
+// reads it from its ledger. It returns os.ErrNotExist if the transaction is
+// not in the index.
+func (tr *TransactionRepository) GetTransactionByHash(ctx context.Context, hash string) (common.Transaction, error) {
+	var txHash [32]byte
+	decoded, err := hex.DecodeString(hash)
+	if err != nil || len(decoded) != len(txHash) {
+		return common.Transaction{}, errors.Errorf("invalid transaction hash %q", hash)
+	}
+	copy(txHash[:], decoded)
+	hash = hex.EncodeToString(decoded)
+
+	txTOID, err := tr.Config.IndexStore.TransactionTOID(txHash)
+	if err == io.EOF {
+		return common.Transaction{}, os.ErrNotExist
+	} else if err != nil {
+		return common.Transaction{}, errors.Wrap(err, "failed to look up transaction in index")
+	}
+
+	id := toid.Parse(txTOID)
+	ledgerSeq := uint32(id.LedgerSequence)
+	ledger, err := tr.Config.Ingester.GetLedger(ctx, ledgerSeq)
+	if err != nil {
+		return common.Transaction{}, errors.Wrapf(err,
+			"failed to retrieve ledger %d from archive", ledgerSeq)
+	}
+
+	reader, err := tr.Config.Ingester.NewLedgerTransactionReader(ledger)
+	if err != nil {
+		return common.Transaction{}, errors.Wrapf(err,
+			"failed to read ledger %d", ledgerSeq)
+	}
+
+	for {
+		tx, readErr := reader.Read()
+		if readErr == io.EOF {
+			break
+		} else if readErr != nil {
+			return common.Transaction{}, readErr
+		}
+
+		// The index stores the 1-based position of the transaction in the
+		// ledger, the same as the ingest library.
+		if tx.Index != uint32(id.TransactionOrder) {
+			continue
+		}
+
+		result := common.Transaction{
+			LedgerTransaction: &tx,
+			LedgerHeader:      &ledger.V0.V0.LedgerHeader.Header,
+			TxIndex:           int32(tx.Index),
+			NetworkPassphrase: tr.Config.Passphrase,
+		}
+		if found, hashErr := result.TransactionHash(); hashErr != nil {
+			return common.Transaction{}, hashErr
+		} else if found != hash {
+			return common.Transaction{}, errors.Errorf(
+				"index points transaction %s at ledger %d, found %s instead",
+				hash, ledgerSeq, found)
+		}
+		return result, nil
+	}
+
+	return common.Transaction{}, errors.Errorf(
+		"index points transaction %s at ledger %d, but it is not there", hash, ledgerSeq)
+}
+
 func transactionsResponseAgeSeconds(txs []common.Transaction) float64 {
 	if len(txs) == 0 {
 		return -1
@@ -2,9 +2,11 @@ package tools
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"os"
 	"os/signal"
+	"runtime"
 	"strconv"
 	"strings"
 	"syscall"
@@ -14,6 +16,7 @@ import (
 
 	"github.com/shantanu-hashcash/go/exp/lightaurora/index"
 	"github.com/shantanu-hashcash/go/historyarchive"
+	"github.com/shantanu-hashcash/go/network"
 	"github.com/shantanu-hashcash/go/strkey"
 	"github.com/shantanu-hashcash/go/support/collections/maps"
 	"github.com/shantanu-hashcash/go/support/collections/set"
@@ -32,7 +35,9 @@ func AddIndexCommands(parent *cobra.Command) *cobra.Command {
 		Example: `
 index view file:///tmp/indices
 index view file:///tmp/indices GAGJZWQ5QT34VK3U6W6YKRYFIK6YSAXQC6BHIIYLG6X3CE5QW2KAYNJR
-index stats file:///tmp/indices`,
+index stats file:///tmp/indices
+index coverage file:///tmp/indices
+index merge file:///tmp/indices-a file:///tmp/indices-b file:///tmp/indices`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// require a subcommand - this is just a "category"
 			return cmd.Help()
@@ -191,9 +196,162 @@ view gcs://indices --limit=10 GAXLQGKIUAIIUHAX4GJO3J7HFGLBCNF6ZCZSTLJE7EKO5IUHGL
 		},
 	}
 
+	merge := &cobra.Command{
+		Use: "merge <index path> <index path> <target index path>",
+		Long: "Merges the indices from two independent builds (e.g. of " +
+			"different ledger ranges) into the target. Indices already at " +
+			"the target are replaced, so to extend an index in place, pass " +
+			"it as one of the sources as well as the target.",
+		Example: `merge file:///tmp/indices-a file:///tmp/indices-b s3://indices
+merge s3://indices file:///tmp/indices-new s3://indices`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 3 {
+				return cmd.Usage()
+			}
+
+			log.Infof("Merging indices from %s and %s into %s.", args[0], args[1], args[2])
+			return mergeIndices(args[:2], args[2])
+		},
+	}
+
+	compact := &cobra.Command{
+		Use: "compact <index path>",
+		Long: "Rewrites the indices so that each bitmap only spans its " +
+			"active checkpoints, dropping indices that have none (e.g. " +
+			"after a purge).",
+		Example: `compact s3://indices`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return cmd.Usage()
+			}
+
+			stats, err := compactIndex(args[0])
+			if err != nil {
+				return err
+			}
+
+			log.Infof("Scanned %d accounts, rewrote %d.", stats.AccountsScanned, stats.Accounts)
+			log.Infof("Dropped %d empty indices; %d accounts have no activity left.",
+				stats.EmptyIndices, stats.EmptyAccounts)
+			log.Infof("Bitmaps went from %d to %d bytes.", stats.BytesBefore, stats.BytesAfter)
+			return nil
+		},
+	}
+
+	verify := &cobra.Command{
+		Use: "verify <index path> <txmeta source> <start ledger> <end ledger> [accounts?]",
+		Long: "Rebuilds the indices for the given ledger range from txmeta " +
+			"and compares them to the stored indices, either for the given " +
+			"accounts or a random sample of the ones active in the range. " +
+			"Exits with an error if any checkpoint is missing or unexpected.",
+		Example: `verify s3://indices file:///tmp/txmeta 1410048 1410367
+verify file:///tmp/indices gcs://txmeta 1410048 1410367 --sample=1000 --seed=42
+verify file:///tmp/indices gcs://txmeta 1410048 1410367 GAXLQGKIUAIIUHAX4GJO3J7HFGLBCNF6ZCZSTLJE7EKO5IUHGLQLMXZO`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 4 || len(args) > 5 {
+				return cmd.Usage()
+			}
+
+			start, err := strconv.ParseUint(args[2], 10, 32)
+			if err != nil {
+				return cmd.Usage()
+			}
+			end, err := strconv.ParseUint(args[3], 10, 32)
+			if err != nil {
+				return cmd.Usage()
+			}
+
+			config := verifyConfig{
+				IndexPath:    args[0],
+				TxMetaSource: args[1],
+				LedgerRange:  historyarchive.Range{Low: uint32(start), High: uint32(end)},
+			}
+			if len(args) == 5 {
+				config.Accounts = strings.Split(args[4], ",")
+			}
+
+			flags := cmd.Flags()
+			if config.NetworkPassphrase, err = flags.GetString("network-passphrase"); err != nil {
+				return cmd.Usage()
+			}
+			modules, err := flags.GetString("modules")
+			if err != nil {
+				return cmd.Usage()
+			}
+			config.Modules = strings.Split(modules, ",")
+			if config.SampleSize, err = flags.GetInt("sample"); err != nil {
+				return cmd.Usage()
+			}
+			if config.Seed, err = flags.GetInt64("seed"); err != nil {
+				return cmd.Usage()
+			}
+			if config.Workers, err = flags.GetInt("workers"); err != nil {
+				return cmd.Usage()
+			}
+
+			checked, mismatches, err := verifyIndex(cmd.Context(), config)
+			if err != nil {
+				return err
+			}
+
+			for _, mismatch := range mismatches {
+				log.Warn(mismatch.String())
+			}
+			log.Infof("Checked %d accounts over ledgers [%d, %d]: %d mismatches.",
+				len(checked), config.LedgerRange.Low, config.LedgerRange.High, len(mismatches))
+			if len(mismatches) > 0 {
+				return fmt.Errorf("indices at %s don't match txmeta", config.IndexPath)
+			}
+			return nil
+		},
+	}
+
+	coverage := &cobra.Command{
+		Use: "coverage <index path>",
+		Long: "Reports the ranges of ledgers covered by the indices. Gaps " +
+			"between the ranges are ledgers that were never indexed. Note " +
+			"that, like stats, this reads every index.",
+		Example: `coverage s3://indices
+coverage file:///tmp/indices --index-name=all/payments`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return cmd.Usage()
+			}
+
+			indexName, err := cmd.Flags().GetString("index-name")
+			if err != nil {
+				return cmd.Usage()
+			}
+
+			ranges, err := indexCoverage(args[0], indexName)
+			if err != nil {
+				return err
+			}
+
+			for i, r := range ranges {
+				ledgers := r.Ledgers()
+				log.Infof("  - checkpoints [%d, %d], ledgers [%d, %d]",
+					r.First, r.Last, ledgers.Low, ledgers.High)
+				if i+1 < len(ranges) {
+					next := ranges[i+1].Ledgers()
+					log.Warnf("  - gap: ledgers [%d, %d] are not indexed",
+						ledgers.High+1, next.Low-1)
+				}
+			}
+			log.Infof("Coverage: %d ranges", len(ranges))
+			return nil
+		},
+	}
+
 	view.Flags().Uint("limit", 10, "a maximum number of accounts or checkpoints to show")
 	view.Flags().String("index-name", "", "filter for a particular index")
-	cmd.AddCommand(stats, view, purge)
+	verify.Flags().String("network-passphrase", network.TestNetworkPassphrase, "network passphrase of the txmeta")
+	verify.Flags().String("modules", "accounts", "comma-separated list of modules to rebuild and compare")
+	verify.Flags().Int("sample", 100, "how many of the active accounts to check, if none are given (0 for all)")
+	verify.Flags().Int64("seed", time.Now().UnixNano(), "seed for sampling the accounts")
+	verify.Flags().Int("workers", runtime.NumCPU(), "number of workers to rebuild the indices with")
+	coverage.Flags().String("index-name", "", "only consider a particular index")
+	cmd.AddCommand(stats, view, purge, merge, compact, verify, coverage)
 
 	if parent == nil {
 		return cmd
@@ -0,0 +1,441 @@
+package tools
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"sort"
+
+	"github.com/shantanu-hashcash/go/exp/lightaurora/index"
+	types "github.com/shantanu-hashcash/go/exp/lightaurora/index/types"
+	"github.com/shantanu-hashcash/go/historyarchive"
+	"github.com/shantanu-hashcash/go/support/collections/set"
+	"github.com/shantanu-hashcash/go/support/errors"
+	"github.com/shantanu-hashcash/go/support/log"
+)
+
+const (
+	// How many accounts to hold in memory before writing them out when
+	// rewriting an index.
+	maintenanceFlushFrequency = 1000
+)
+
+// mergeIndices merges the account and transaction indices from each of the
+// sources (e.g. two independent builds over different ledger ranges) into the
+// target. Any account indices already at the target are replaced, so to extend
+// an index in place, pass it as both a source and the target.
+func mergeIndices(sources []string, target string) error {
+	stores := make([]index.Store, 0, len(sources))
+	accounts := set.Set[string]{}
+	for _, path := range sources {
+		store, err := index.Connect(path)
+		if err != nil {
+			return errors.Wrapf(err, "failed to connect to index store at %s", path)
+		}
+		stores = append(stores, store)
+
+		sourceAccounts, err := store.ReadAccounts()
+		if os.IsNotExist(err) {
+			log.Warnf("No accounts found in index store at %s", path)
+			continue
+		} else if err != nil {
+			return errors.Wrapf(err, "failed to read accounts from %s", path)
+		}
+		accounts.AddSlice(sourceAccounts)
+	}
+
+	targetStore, err := index.Connect(target)
+	if err != nil {
+		return errors.Wrapf(err, "failed to connect to index store at %s", target)
+	}
+	targetStore.ClearMemory(true)
+
+	merged := 0
+	for _, account := range sortedAccounts(accounts) {
+		indices := types.NamedIndices{}
+		for i, store := range stores {
+			found, err := store.Read(account)
+			if os.IsNotExist(err) {
+				continue
+			} else if err != nil {
+				return errors.Wrapf(err, "failed to read indices for %s from %s",
+					account, sources[i])
+			}
+
+			if err := mergeNamedIndices(indices, found); err != nil {
+				return errors.Wrapf(err, "failed to merge indices for %s", account)
+			}
+		}
+
+		targetStore.AddParticipantToIndexesNoBackend(account, indices)
+		merged++
+		if merged%maintenanceFlushFrequency == 0 {
+			log.Infof("Merged %d/%d accounts", merged, len(accounts))
+			if err := targetStore.Flush(); err != nil {
+				return errors.Wrap(err, "flushing merged indices failed")
+			}
+		}
+	}
+
+	// Transaction indices are split by the first byte of the hash.
+	for b := 0; b <= 0xff; b++ {
+		prefix := hex.EncodeToString([]byte{byte(b)})
+		for i, store := range stores {
+			trie, err := store.ReadTransactions(prefix)
+			if err != nil {
+				return errors.Wrapf(err, "failed to read transactions with prefix %s from %s",
+					prefix, sources[i])
+			}
+			if err := targetStore.MergeTransactions(prefix, trie); err != nil {
+				return errors.Wrapf(err, "failed to merge transactions with prefix %s", prefix)
+			}
+		}
+	}
+
+	if err := targetStore.Flush(); err != nil {
+		return errors.Wrap(err, "flushing merged indices failed")
+	}
+
+	log.Infof("Merged the indices of %d accounts from %d sources into %s.",
+		merged, len(sources), target)
+	return nil
+}
+
+func mergeNamedIndices(into, from types.NamedIndices) error {
+	for name, idx := range from {
+		existing, ok := into[name]
+		if !ok {
+			into[name] = idx
+			continue
+		}
+		if err := existing.Merge(idx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type compactionStats struct {
+	Accounts        int // accounts whose indices were rewritten
+	EmptyIndices    int // indices dropped because they had no active bits
+	EmptyAccounts   int // accounts without any active bits, left untouched
+	BytesBefore     int
+	BytesAfter      int
+	AccountsScanned int
+}
+
+// compactIndex rewrites every account index at the path so that its bitmaps
+// only span their active checkpoints, and drops indices that are empty (e.g.
+// after a purge).
+func compactIndex(path string) (compactionStats, error) {
+	stats := compactionStats{}
+
+	store, err := index.Connect(path)
+	if err != nil {
+		return stats, errors.Wrapf(err, "failed to connect to index store at %s", path)
+	}
+	store.ClearMemory(true)
+
+	accounts, err := store.ReadAccounts()
+	if err != nil {
+		return stats, errors.Wrapf(err, "failed to read accounts from %s", path)
+	}
+
+	pending := 0
+	for _, account := range accounts {
+		stats.AccountsScanned++
+
+		indices, err := store.Read(account)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return stats, errors.Wrapf(err, "failed to read indices for %s", account)
+		}
+
+		compacted := types.NamedIndices{}
+		changed := false
+		for name, idx := range indices {
+			stats.BytesBefore += idx.Size()
+
+			c, err := idx.Compact()
+			if err != nil {
+				return stats, errors.Wrapf(err, "failed to compact index %s of %s", name, account)
+			}
+			if c.Size() == 0 {
+				stats.EmptyIndices++
+				changed = true
+				continue
+			}
+
+			stats.BytesAfter += c.Size()
+			changed = changed || c.Size() != idx.Size()
+			compacted[name] = c
+		}
+
+		if len(compacted) == 0 {
+			// The backends can't delete an account, and writing it out with
+			// no indices would be a no-op, so we leave it be.
+			stats.EmptyAccounts++
+			continue
+		} else if !changed {
+			continue
+		}
+
+		store.AddParticipantToIndexesNoBackend(account, compacted)
+		stats.Accounts++
+		pending++
+		if pending%maintenanceFlushFrequency == 0 {
+			if err := store.Flush(); err != nil {
+				return stats, errors.Wrap(err, "flushing compacted indices failed")
+			}
+		}
+	}
+
+	if err := store.Flush(); err != nil {
+		return stats, errors.Wrap(err, "flushing compacted indices failed")
+	}
+
+	return stats, nil
+}
+
+// indexMismatch is a checkpoint in which an account's stored index disagrees
+// with the index built from ledger meta.
+type indexMismatch struct {
+	Account    string
+	Index      string
+	Checkpoint uint32
+	// Missing is whether the checkpoint is active in the expected index but
+	// not in the stored one; otherwise, it's the other way around.
+	Missing bool
+}
+
+func (m indexMismatch) String() string {
+	if m.Missing {
+		return fmt.Sprintf("%s: checkpoint %d missing from %s", m.Account, m.Checkpoint, m.Index)
+	}
+	return fmt.Sprintf("%s: unexpected checkpoint %d in %s", m.Account, m.Checkpoint, m.Index)
+}
+
+type verifyConfig struct {
+	IndexPath         string
+	TxMetaSource      string
+	NetworkPassphrase string
+	LedgerRange       historyarchive.Range
+	Modules           []string
+	// Accounts to check. If empty, a sample of SampleSize accounts that are
+	// active in the ledger range is checked.
+	Accounts   []string
+	SampleSize int
+	Seed       int64
+	Workers    int
+}
+
+// verifyIndex rebuilds the indices for the ledger range from ledger meta and
+// compares them against the stored indices for a sample of accounts. Stored
+// checkpoints that only partially overlap the range are allowed to have more
+// activity than the rebuilt ones, since the rest of the checkpoint wasn't
+// replayed.
+func verifyIndex(ctx context.Context, config verifyConfig) (checked []string, mismatches []indexMismatch, err error) {
+	tmpDir, err := os.MkdirTemp("", "index-verify")
+	if err != nil {
+		return nil, nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+	expectedPath := "file://" + tmpDir
+
+	log.Infof("Rebuilding %v indices for ledgers [%d, %d] from %s",
+		config.Modules, config.LedgerRange.Low, config.LedgerRange.High, config.TxMetaSource)
+	_, err = index.BuildIndices(ctx,
+		config.TxMetaSource, expectedPath,
+		config.NetworkPassphrase,
+		config.LedgerRange,
+		config.Modules,
+		config.Workers)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to rebuild indices")
+	}
+
+	expected, err := index.Connect(expectedPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	stored, err := index.Connect(config.IndexPath)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to connect to index store at %s", config.IndexPath)
+	}
+
+	checked = config.Accounts
+	if len(checked) == 0 {
+		active, err := expected.ReadAccounts()
+		if os.IsNotExist(err) {
+			return nil, nil, nil // nothing happened in the range
+		} else if err != nil {
+			return nil, nil, err
+		}
+
+		sort.Strings(active)
+		rand.New(rand.NewSource(config.Seed)).Shuffle(len(active), func(i, j int) {
+			active[i], active[j] = active[j], active[i]
+		})
+		if config.SampleSize > 0 && len(active) > config.SampleSize {
+			active = active[:config.SampleSize]
+		}
+		checked = active
+	}
+
+	freq := checkpointMgr.GetCheckpointFrequency()
+	first := index.GetCheckpointNumber(config.LedgerRange.Low)
+	last := index.GetCheckpointNumber(config.LedgerRange.High)
+	isFullyCovered := func(checkpoint uint32) bool {
+		return (checkpoint-1)*freq >= config.LedgerRange.Low &&
+			checkpoint*freq-1 <= config.LedgerRange.High
+	}
+
+	for _, account := range checked {
+		want, err := readIndicesOrEmpty(expected, account)
+		if err != nil {
+			return nil, nil, err
+		}
+		got, err := readIndicesOrEmpty(stored, account)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to read indices for %s", account)
+		}
+
+		for name, wantIndex := range want {
+			gotIndex, ok := got[name]
+			if !ok {
+				gotIndex = &types.BitmapIndex{}
+			}
+
+			wantCheckpoints, err := activeCheckpoints(wantIndex, first, last)
+			if err != nil {
+				return nil, nil, err
+			}
+			gotCheckpoints, err := activeCheckpoints(gotIndex, first, last)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			for checkpoint := range wantCheckpoints {
+				if !gotCheckpoints.Contains(checkpoint) {
+					mismatches = append(mismatches, indexMismatch{account, name, checkpoint, true})
+				}
+			}
+			for checkpoint := range gotCheckpoints {
+				if !wantCheckpoints.Contains(checkpoint) && isFullyCovered(checkpoint) {
+					mismatches = append(mismatches, indexMismatch{account, name, checkpoint, false})
+				}
+			}
+		}
+	}
+
+	sort.Slice(mismatches, func(i, j int) bool {
+		a, b := mismatches[i], mismatches[j]
+		if a.Account != b.Account {
+			return a.Account < b.Account
+		} else if a.Index != b.Index {
+			return a.Index < b.Index
+		}
+		return a.Checkpoint < b.Checkpoint
+	})
+	return checked, mismatches, nil
+}
+
+func readIndicesOrEmpty(store index.Store, account string) (types.NamedIndices, error) {
+	indices, err := store.Read(account)
+	if os.IsNotExist(err) {
+		return types.NamedIndices{}, nil
+	}
+	return indices, err
+}
+
+// activeCheckpoints returns the active checkpoints of the index within
+// [first, last].
+func activeCheckpoints(idx *types.BitmapIndex, first, last uint32) (set.Set[uint32], error) {
+	active := set.Set[uint32]{}
+	checkpoint, err := idx.NextActiveBit(first)
+	for err == nil && checkpoint <= last {
+		active.Add(checkpoint)
+		checkpoint, err = idx.NextActiveBit(checkpoint + 1)
+	}
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	return active, nil
+}
+
+// checkpointRange is an inclusive range of checkpoint numbers (as used by the
+// indices, see index.GetCheckpointNumber).
+type checkpointRange struct {
+	First, Last uint32
+}
+
+// Ledgers returns the range of ledgers covered by the checkpoints.
+func (r checkpointRange) Ledgers() historyarchive.Range {
+	freq := checkpointMgr.GetCheckpointFrequency()
+	return historyarchive.Range{
+		Low:  (r.First - 1) * freq,
+		High: r.Last*freq - 1,
+	}
+}
+
+// indexCoverage returns the contiguous ranges of checkpoints in which at least
+// one account is active in the index (or the named index, if given). Since
+// every checkpoint on a live network has some activity, gaps between the
+// ranges are ledgers that were never indexed.
+func indexCoverage(path, indexName string) ([]checkpointRange, error) {
+	store, err := index.Connect(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to connect to index store at %s", path)
+	}
+
+	accounts, err := store.ReadAccounts()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read accounts from %s", path)
+	}
+
+	covered := set.Set[uint32]{}
+	for _, account := range accounts {
+		indices, err := readIndicesOrEmpty(store, account)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read indices for %s", account)
+		}
+
+		for name, idx := range indices {
+			if indexName != "" && name != indexName {
+				continue
+			}
+
+			checkpoint, err := idx.NextActiveBit(0)
+			for err == nil {
+				covered.Add(checkpoint)
+				checkpoint, err = idx.NextActiveBit(checkpoint + 1)
+			}
+			if err != io.EOF {
+				return nil, errors.Wrapf(err, "failed to iterate over index %s of %s", name, account)
+			}
+		}
+	}
+
+	checkpoints := covered.Slice()
+	sort.Slice(checkpoints, func(i, j int) bool { return checkpoints[i] < checkpoints[j] })
+
+	ranges := []checkpointRange{}
+	for _, checkpoint := range checkpoints {
+		if n := len(ranges); n > 0 && ranges[n-1].Last+1 == checkpoint {
+			ranges[n-1].Last = checkpoint
+			continue
+		}
+		ranges = append(ranges, checkpointRange{First: checkpoint, Last: checkpoint})
+	}
+	return ranges, nil
+}
+
+func sortedAccounts(accounts set.Set[string]) []string {
+	sorted := accounts.Slice()
+	sort.Strings(sorted)
+	return sorted
+}
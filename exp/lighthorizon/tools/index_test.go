@@ -1,12 +1,14 @@
 package tools
 
 import (
+	"context"
 	"path/filepath"
 	"testing"
 
 	"github.com/shantanu-hashcash/go/exp/lightaurora/index"
 	"github.com/shantanu-hashcash/go/historyarchive"
 	"github.com/shantanu-hashcash/go/keypair"
+	"github.com/shantanu-hashcash/go/network"
 	"github.com/shantanu-hashcash/go/support/log"
 	"github.com/stretchr/testify/require"
 )
@@ -56,3 +58,134 @@ func TestIndexPurge(t *testing.T) {
 	require.NoError(t, err)
 	require.EqualValues(t, 123, i)
 }
+
+func TestIndexMerge(t *testing.T) {
+	pathA := "file://" + filepath.Join(t.TempDir(), "index-a")
+	pathB := "file://" + filepath.Join(t.TempDir(), "index-b")
+	target := "file://" + filepath.Join(t.TempDir(), "index-merged")
+	shared, onlyB := keypair.MustRandom().Address(), keypair.MustRandom().Address()
+
+	a, err := index.Connect(pathA)
+	require.NoError(t, err)
+	require.NoError(t, a.AddParticipantsToIndexes(10, "test", []string{shared}))
+	require.NoError(t, a.AddTransactionToIndexes(1234, [32]byte{0xab}))
+	require.NoError(t, a.Flush())
+
+	b, err := index.Connect(pathB)
+	require.NoError(t, err)
+	require.NoError(t, b.AddParticipantsToIndexes(20, "test", []string{shared, onlyB}))
+	require.NoError(t, b.AddParticipantsToIndexes(20, "other", []string{shared}))
+	require.NoError(t, b.AddTransactionToIndexes(5678, [32]byte{0xcd}))
+	require.NoError(t, b.Flush())
+
+	require.NoError(t, mergeIndices([]string{pathA, pathB}, target))
+
+	merged, err := index.Connect(target)
+	require.NoError(t, err)
+
+	accounts, err := merged.ReadAccounts()
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{shared, onlyB}, accounts)
+
+	indices, err := merged.Read(shared)
+	require.NoError(t, err)
+	require.Contains(t, indices, "other")
+	active, err := activeCheckpoints(indices["test"], 0, 100)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []uint32{10, 20}, active.Slice())
+
+	toid, err := merged.TransactionTOID([32]byte{0xab})
+	require.NoError(t, err)
+	require.EqualValues(t, 1234, toid)
+	toid, err = merged.TransactionTOID([32]byte{0xcd})
+	require.NoError(t, err)
+	require.EqualValues(t, 5678, toid)
+}
+
+func TestIndexCompact(t *testing.T) {
+	path := "file://" + filepath.Join(t.TempDir(), "index-store")
+	account := keypair.MustRandom().Address()
+
+	idx, err := index.Connect(path)
+	require.NoError(t, err)
+	require.NoError(t, idx.AddParticipantsToIndexes(14, "kept", []string{account}))
+	require.NoError(t, idx.AddParticipantsToIndexes(1000, "purged", []string{account}))
+	require.NoError(t, idx.Flush())
+
+	// Empty out one of the indices.
+	require.NoError(t, purgeIndex(path, historyarchive.Range{Low: 999 * freq, High: 1001 * freq}))
+
+	stats, err := compactIndex(path)
+	require.NoError(t, err)
+	require.Equal(t, 1, stats.EmptyIndices)
+	require.Equal(t, 1, stats.Accounts)
+
+	idx, err = index.Connect(path)
+	require.NoError(t, err)
+	indices, err := idx.Read(account)
+	require.NoError(t, err)
+	require.NotContains(t, indices, "purged")
+	i, err := indices["kept"].NextActiveBit(0)
+	require.NoError(t, err)
+	require.EqualValues(t, 14, i)
+}
+
+func TestIndexCoverage(t *testing.T) {
+	path := "file://" + filepath.Join(t.TempDir(), "index-store")
+	accounts := []string{keypair.MustRandom().Address(), keypair.MustRandom().Address()}
+
+	idx, err := index.Connect(path)
+	require.NoError(t, err)
+	for _, chk := range []uint32{3, 4, 5, 9} {
+		require.NoError(t, idx.AddParticipantsToIndexes(chk, "test", accounts[:1]))
+	}
+	require.NoError(t, idx.AddParticipantsToIndexes(6, "test", accounts[1:]))
+	require.NoError(t, idx.AddParticipantsToIndexes(20, "other", accounts[1:]))
+	require.NoError(t, idx.Flush())
+
+	ranges, err := indexCoverage(path, "")
+	require.NoError(t, err)
+	require.Equal(t, []checkpointRange{{3, 6}, {9, 9}, {20, 20}}, ranges)
+	require.Equal(t, historyarchive.Range{Low: 2 * freq, High: 6*freq - 1}, ranges[0].Ledgers())
+
+	ranges, err = indexCoverage(path, "test")
+	require.NoError(t, err)
+	require.Equal(t, []checkpointRange{{3, 6}, {9, 9}}, ranges)
+}
+
+func TestIndexVerify(t *testing.T) {
+	const txmetaSource = "file://../index/cmd/testdata/"
+	ledgerRange := historyarchive.Range{Low: 1410048, High: 1410175} // two checkpoints
+	path := "file://" + filepath.Join(t.TempDir(), "index-store")
+
+	ctx := context.Background()
+	_, err := index.BuildIndices(ctx, txmetaSource, path, network.TestNetworkPassphrase,
+		ledgerRange, []string{"accounts"}, 1)
+	require.NoError(t, err)
+
+	config := verifyConfig{
+		IndexPath:         path,
+		TxMetaSource:      txmetaSource,
+		NetworkPassphrase: network.TestNetworkPassphrase,
+		LedgerRange:       ledgerRange,
+		Modules:           []string{"accounts"},
+		SampleSize:        0, // all of them
+		Workers:           1,
+	}
+	checked, mismatches, err := verifyIndex(ctx, config)
+	require.NoError(t, err)
+	require.NotEmpty(t, checked)
+	require.Empty(t, mismatches)
+
+	// Losing a checkpoint should be noticed for every account active in it.
+	lost := index.GetCheckpointNumber(ledgerRange.Low)
+	require.NoError(t, purgeIndex(path, historyarchive.Range{Low: lost * freq, High: lost * freq}))
+
+	_, mismatches, err = verifyIndex(ctx, config)
+	require.NoError(t, err)
+	require.NotEmpty(t, mismatches)
+	for _, mismatch := range mismatches {
+		require.True(t, mismatch.Missing)
+		require.Equal(t, lost, mismatch.Checkpoint)
+	}
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"go/types"
+	"time"
+
+	"github.com/shantanu-hashcash/go/exp/services/cosigner/internal/serve"
+	"github.com/shantanu-hashcash/go/network"
+	"github.com/shantanu-hashcash/go/support/config"
+	supportlog "github.com/shantanu-hashcash/go/support/log"
+	"github.com/spf13/cobra"
+)
+
+type ServeCommand struct {
+	Logger *supportlog.Entry
+}
+
+func (c *ServeCommand) Command() *cobra.Command {
+	opts := serve.Options{
+		Logger: c.Logger,
+	}
+	challengeExpiresIn := 0
+	configOpts := config.ConfigOptions{
+		{
+			Name:        "port",
+			Usage:       "Port to listen and serve on",
+			OptType:     types.Int,
+			ConfigKey:   &opts.Port,
+			FlagDefault: 8000,
+			Required:    true,
+		},
+		{
+			Name:        "db-url",
+			Usage:       "Database URL",
+			OptType:     types.String,
+			ConfigKey:   &opts.DatabaseURL,
+			FlagDefault: "postgres://localhost:5432/?sslmode=disable",
+			Required:    false,
+		},
+		{
+			Name:        "db-max-open-conns",
+			Usage:       "Database max open connections",
+			OptType:     types.Int,
+			ConfigKey:   &opts.DatabaseMaxOpenConns,
+			FlagDefault: 20,
+			Required:    false,
+		},
+		{
+			Name:        "network-passphrase",
+			Usage:       "Network passphrase of the Hcnet network transactions are coordinated for",
+			OptType:     types.String,
+			ConfigKey:   &opts.NetworkPassphrase,
+			FlagDefault: network.TestNetworkPassphrase,
+			Required:    true,
+		},
+		{
+			Name:        "aurora-url",
+			Usage:       "Aurora URL used to load source account signers and thresholds, and to submit transactions",
+			OptType:     types.String,
+			ConfigKey:   &opts.AuroraURL,
+			FlagDefault: "https://aurora-testnet.hcnet.org/",
+			Required:    true,
+		},
+		{
+			Name:      "signing-key",
+			Usage:     "Hcnet signing key used to sign challenge transactions that clients authenticate with",
+			OptType:   types.String,
+			ConfigKey: &opts.SigningKey,
+			Required:  true,
+		},
+		{
+			Name:        "domain",
+			Usage:       "Domain that this service is hosted at, included in challenge transactions",
+			OptType:     types.String,
+			ConfigKey:   &opts.Domain,
+			FlagDefault: "localhost:8000",
+			Required:    true,
+		},
+		{
+			Name:        "challenge-expires-in",
+			Usage:       "The time period in seconds after which a challenge transaction expires and can no longer be used to authenticate",
+			OptType:     types.Int,
+			ConfigKey:   &challengeExpiresIn,
+			FlagDefault: 900,
+			Required:    true,
+		},
+	}
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Run the multisig cosigner server",
+		Run: func(_ *cobra.Command, _ []string) {
+			configOpts.Require()
+			configOpts.SetValues()
+			opts.ChallengeExpiresIn = time.Duration(challengeExpiresIn) * time.Second
+			c.Run(opts)
+		},
+	}
+	configOpts.Init(cmd)
+	return cmd
+}
+
+func (c *ServeCommand) Run(opts serve.Options) {
+	serve.Serve(opts)
+}
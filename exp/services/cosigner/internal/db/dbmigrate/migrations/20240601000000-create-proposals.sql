@@ -0,0 +1,28 @@
+-- +migrate Up
+
+CREATE TABLE proposals (
+  hash TEXT NOT NULL PRIMARY KEY,
+
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMP WITH TIME ZONE,
+
+  proposer TEXT NOT NULL,
+  transaction_xdr TEXT NOT NULL,
+  requirements JSONB NOT NULL,
+  status TEXT NOT NULL,
+  result_xdr TEXT,
+  error TEXT
+);
+
+CREATE TABLE proposal_signers (
+  proposal_hash TEXT NOT NULL REFERENCES proposals (hash) ON DELETE CASCADE,
+  signer TEXT NOT NULL,
+  PRIMARY KEY (proposal_hash, signer)
+);
+
+CREATE INDEX ON proposal_signers (signer);
+
+-- +migrate Down
+
+DROP TABLE proposal_signers;
+DROP TABLE proposals;
@@ -0,0 +1,154 @@
+package proposal
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+
+	supportdb "github.com/shantanu-hashcash/go/support/db"
+	"github.com/shantanu-hashcash/go/support/errors"
+)
+
+type DBStore struct {
+	DB *supportdb.Session
+}
+
+type proposalRow struct {
+	Hash           string         `db:"hash"`
+	Proposer       string         `db:"proposer"`
+	TransactionXDR string         `db:"transaction_xdr"`
+	Requirements   []byte         `db:"requirements"`
+	Status         string         `db:"status"`
+	ResultXDR      sql.NullString `db:"result_xdr"`
+	Error          sql.NullString `db:"error"`
+	CreatedAt      time.Time      `db:"created_at"`
+}
+
+func (r proposalRow) proposal() (Proposal, error) {
+	p := Proposal{
+		Hash:           r.Hash,
+		Proposer:       r.Proposer,
+		TransactionXDR: r.TransactionXDR,
+		Status:         Status(r.Status),
+		ResultXDR:      r.ResultXDR.String,
+		Error:          r.Error.String,
+		CreatedAt:      r.CreatedAt,
+	}
+	err := json.Unmarshal(r.Requirements, &p.Requirements)
+	if err != nil {
+		return Proposal{}, errors.Wrap(err, "decoding requirements")
+	}
+	return p, nil
+}
+
+const selectProposals = `SELECT hash, proposer, transaction_xdr, requirements, status, result_xdr, error, created_at FROM proposals`
+
+func (s *DBStore) Add(ctx context.Context, p Proposal) error {
+	requirements, err := json.Marshal(p.Requirements)
+	if err != nil {
+		return errors.Wrap(err, "encoding requirements")
+	}
+
+	session := s.DB.Clone()
+	err = session.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer session.Rollback()
+
+	_, err = session.ExecRaw(ctx,
+		`INSERT INTO proposals (hash, proposer, transaction_xdr, requirements, status)
+		VALUES ($1, $2, $3, $4, $5)`,
+		p.Hash, p.Proposer, p.TransactionXDR, requirements, string(p.Status),
+	)
+	if pqErr, ok := errors.Cause(err).(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+		return ErrAlreadyExists
+	} else if err != nil {
+		return err
+	}
+
+	for _, signer := range p.Signers() {
+		_, err = session.ExecRaw(ctx,
+			`INSERT INTO proposal_signers (proposal_hash, signer) VALUES ($1, $2)`,
+			p.Hash, signer,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return session.Commit()
+}
+
+func (s *DBStore) Get(ctx context.Context, hash string) (Proposal, error) {
+	row := proposalRow{}
+	err := s.DB.GetRaw(ctx, &row, selectProposals+` WHERE hash = $1`, hash)
+	if s.DB.NoRows(err) {
+		return Proposal{}, ErrNotFound
+	} else if err != nil {
+		return Proposal{}, err
+	}
+	return row.proposal()
+}
+
+func (s *DBStore) FindWithSigner(ctx context.Context, signer string) ([]Proposal, error) {
+	rows := []proposalRow{}
+	err := s.DB.SelectRaw(ctx, &rows,
+		selectProposals+` WHERE hash IN (SELECT proposal_hash FROM proposal_signers WHERE signer = $1) ORDER BY created_at DESC, hash`,
+		signer,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	proposals := make([]Proposal, 0, len(rows))
+	for _, row := range rows {
+		p, err := row.proposal()
+		if err != nil {
+			return nil, err
+		}
+		proposals = append(proposals, p)
+	}
+	return proposals, nil
+}
+
+func (s *DBStore) Update(ctx context.Context, hash string, fn func(p *Proposal) error) error {
+	session := s.DB.Clone()
+	err := session.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer session.Rollback()
+
+	row := proposalRow{}
+	err = session.GetRaw(ctx, &row, selectProposals+` WHERE hash = $1 FOR UPDATE`, hash)
+	if session.NoRows(err) {
+		return ErrNotFound
+	} else if err != nil {
+		return err
+	}
+	p, err := row.proposal()
+	if err != nil {
+		return err
+	}
+
+	err = fn(&p)
+	if err != nil {
+		return err
+	}
+
+	_, err = session.ExecRaw(ctx,
+		`UPDATE proposals
+		SET transaction_xdr = $2, status = $3, result_xdr = NULLIF($4, ''), error = NULLIF($5, ''), updated_at = NOW()
+		WHERE hash = $1`,
+		hash, p.TransactionXDR, string(p.Status), p.ResultXDR, p.Error,
+	)
+	if err != nil {
+		return err
+	}
+
+	return session.Commit()
+}
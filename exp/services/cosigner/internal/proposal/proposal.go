@@ -0,0 +1,301 @@
+package proposal
+
+import (
+	"bytes"
+	"sort"
+	"time"
+
+	"github.com/shantanu-hashcash/go/keypair"
+	hProtocol "github.com/shantanu-hashcash/go/protocols/aurora"
+	"github.com/shantanu-hashcash/go/strkey"
+	"github.com/shantanu-hashcash/go/support/errors"
+	"github.com/shantanu-hashcash/go/txnbuild"
+	"github.com/shantanu-hashcash/go/xdr"
+)
+
+// Status is the state of a proposal.
+type Status string
+
+const (
+	// StatusPending proposals are still collecting signatures.
+	StatusPending Status = "pending"
+	// StatusSubmitting proposals met all thresholds and are being submitted
+	// to Aurora, or their submission failed without a definitive result,
+	// for example because it timed out, and can be submitted again.
+	StatusSubmitting Status = "submitting"
+	// StatusSubmitted proposals met all thresholds and were accepted by
+	// Aurora.
+	StatusSubmitted Status = "submitted"
+	// StatusFailed proposals met all thresholds but were rejected by Aurora.
+	StatusFailed Status = "failed"
+)
+
+// Proposal is a transaction uploaded by a proposer that co-signers add
+// signatures to until the thresholds of every source account are met.
+type Proposal struct {
+	Hash           string
+	Proposer       string
+	TransactionXDR string
+	Requirements   []Requirement
+	Status         Status
+	ResultXDR      string
+	Error          string
+	CreatedAt      time.Time
+}
+
+// Requirement is the total signature weight a source account of the
+// transaction must contribute, and the weight of each of its signers.
+type Requirement struct {
+	Account   string                 `json:"account"`
+	Threshold int32                  `json:"threshold"`
+	Signers   txnbuild.SignerSummary `json:"signers"`
+}
+
+// Progress is how far a source account is from meeting its requirement.
+type Progress struct {
+	Account   string
+	Threshold int32
+	Weight    int32
+	Signers   []string
+}
+
+// Met reports whether the signatures collected meet the threshold.
+func (p Progress) Met() bool {
+	return p.Weight >= p.Threshold
+}
+
+// Signers returns every signer that can contribute to the proposal, sorted.
+func (p Proposal) Signers() []string {
+	seen := map[string]bool{}
+	signers := []string{}
+	for _, r := range p.Requirements {
+		for signer, weight := range r.Signers {
+			if weight > 0 && !seen[signer] {
+				seen[signer] = true
+				signers = append(signers, signer)
+			}
+		}
+	}
+	sort.Strings(signers)
+	return signers
+}
+
+// IsSigner reports whether address can contribute a signature to the
+// proposal.
+func (p Proposal) IsSigner(address string) bool {
+	for _, r := range p.Requirements {
+		if r.Signers[address] > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Transaction decodes the transaction of the proposal.
+func (p Proposal) Transaction() (*txnbuild.Transaction, error) {
+	return ParseTransaction(p.TransactionXDR)
+}
+
+// ParseTransaction decodes a transaction envelope that can be coordinated.
+// Fee bump transactions are not supported.
+func ParseTransaction(transactionXDR string) (*txnbuild.Transaction, error) {
+	genericTx, err := txnbuild.TransactionFromXDR(transactionXDR)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing transaction")
+	}
+	tx, ok := genericTx.Transaction()
+	if !ok {
+		return nil, errors.New("fee bump transactions are not supported")
+	}
+	return tx, nil
+}
+
+// SourceAccounts returns the unmuxed source accounts of the transaction and
+// its operations.
+func SourceAccounts(tx *txnbuild.Transaction) []string {
+	seen := map[string]bool{}
+	accounts := []string{}
+	add := func(address string) {
+		if muxed, err := xdr.AddressToMuxedAccount(address); err == nil {
+			address = muxed.ToAccountId().Address()
+		}
+		if !seen[address] {
+			seen[address] = true
+			accounts = append(accounts, address)
+		}
+	}
+	add(tx.SourceAccount().AccountID)
+	for _, op := range tx.Operations() {
+		if source := op.GetSourceAccount(); source != "" {
+			add(source)
+		}
+	}
+	return accounts
+}
+
+// Requirements works out, for each source account of the transaction, the
+// signature weight needed according to the current state of the accounts.
+// Each account must be present in accounts.
+func Requirements(tx *txnbuild.Transaction, accounts map[string]hProtocol.Account) ([]Requirement, error) {
+	source, err := normalizeAddress(tx.SourceAccount().AccountID)
+	if err != nil {
+		return nil, err
+	}
+	levels := map[string]txnbuild.ThresholdCategory{source: txnbuild.ThresholdCategoryLow}
+	for _, op := range tx.Operations() {
+		opSource := source
+		if op.GetSourceAccount() != "" {
+			opSource, err = normalizeAddress(op.GetSourceAccount())
+			if err != nil {
+				return nil, err
+			}
+		}
+		if level := txnbuild.OperationThresholdCategory(op); level > levels[opSource] {
+			levels[opSource] = level
+		}
+	}
+
+	requirements := []Requirement{}
+	for _, address := range SourceAccounts(tx) {
+		account, ok := accounts[address]
+		if !ok {
+			return nil, errors.Errorf("account %s not found", address)
+		}
+		var t int32
+		switch levels[address] {
+		case txnbuild.ThresholdCategoryLow:
+			t = int32(account.Thresholds.LowThreshold)
+		case txnbuild.ThresholdCategoryHigh:
+			t = int32(account.Thresholds.HighThreshold)
+		default:
+			t = int32(account.Thresholds.MedThreshold)
+		}
+		// A threshold of zero still requires a signature from a signer with
+		// a non-zero weight.
+		if t == 0 {
+			t = 1
+		}
+		requirements = append(requirements, Requirement{
+			Account:   address,
+			Threshold: t,
+			Signers:   txnbuild.SignerSummary(account.SignerSummary()),
+		})
+	}
+	return requirements, nil
+}
+
+func normalizeAddress(address string) (string, error) {
+	muxed, err := xdr.AddressToMuxedAccount(address)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid address %s", address)
+	}
+	return muxed.ToAccountId().Address(), nil
+}
+
+// verifiedSigner returns the ed25519 signer among candidates that produced
+// signature over hash.
+func verifiedSigner(hash [32]byte, signature xdr.DecoratedSignature, candidates []string) (string, bool) {
+	for _, candidate := range candidates {
+		if !strkey.IsValidEd25519PublicKey(candidate) {
+			continue
+		}
+		kp, err := keypair.ParseAddress(candidate)
+		if err != nil || kp.Hint() != signature.Hint {
+			continue
+		}
+		if kp.Verify(hash[:], signature.Signature) == nil {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// CalculateProgress returns the progress of every requirement given the
+// signatures currently on tx.
+func CalculateProgress(tx *txnbuild.Transaction, networkPassphrase string, requirements []Requirement) ([]Progress, error) {
+	hash, err := tx.Hash(networkPassphrase)
+	if err != nil {
+		return nil, errors.Wrap(err, "hashing transaction")
+	}
+
+	signers := Proposal{Requirements: requirements}.Signers()
+	signed := map[string]bool{}
+	for _, signature := range tx.Signatures() {
+		if signer, ok := verifiedSigner(hash, signature, signers); ok {
+			signed[signer] = true
+		}
+	}
+
+	progress := make([]Progress, 0, len(requirements))
+	for _, r := range requirements {
+		p := Progress{Account: r.Account, Threshold: r.Threshold, Signers: []string{}}
+		for signer, weight := range r.Signers {
+			if signed[signer] && weight > 0 {
+				p.Weight += weight
+				p.Signers = append(p.Signers, signer)
+			}
+		}
+		sort.Strings(p.Signers)
+		progress = append(progress, p)
+	}
+	return progress, nil
+}
+
+// ThresholdsMet reports whether every requirement has been met.
+func ThresholdsMet(progress []Progress) bool {
+	for _, p := range progress {
+		if !p.Met() {
+			return false
+		}
+	}
+	return true
+}
+
+// ErrNoValidSignatures is returned by AddSignatures when none of the
+// signatures provided are new valid signatures from a signer of the
+// proposal.
+var ErrNoValidSignatures = errors.New("no new valid signatures")
+
+// AddSignatures adds to tx every signature in signatures that was produced by
+// a signer of the requirements and that is not already present. Signatures
+// from other keys are ignored.
+func AddSignatures(tx *txnbuild.Transaction, networkPassphrase string, requirements []Requirement, signatures []xdr.DecoratedSignature) (*txnbuild.Transaction, []string, error) {
+	hash, err := tx.Hash(networkPassphrase)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "hashing transaction")
+	}
+
+	existing := tx.Signatures()
+	signers := Proposal{Requirements: requirements}.Signers()
+	added := []string{}
+	newSignatures := []xdr.DecoratedSignature{}
+	for _, signature := range signatures {
+		if containsSignature(existing, signature) || containsSignature(newSignatures, signature) {
+			continue
+		}
+		signer, ok := verifiedSigner(hash, signature, signers)
+		if !ok {
+			continue
+		}
+		added = append(added, signer)
+		newSignatures = append(newSignatures, signature)
+	}
+	if len(newSignatures) == 0 {
+		return nil, nil, ErrNoValidSignatures
+	}
+
+	tx, err = tx.AddSignatureDecorated(newSignatures...)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "adding signatures")
+	}
+	return tx, added, nil
+}
+
+func containsSignature(signatures []xdr.DecoratedSignature, signature xdr.DecoratedSignature) bool {
+	for _, s := range signatures {
+		if s.Hint == signature.Hint && bytes.Equal(s.Signature, signature.Signature) {
+			return true
+		}
+	}
+	return false
+}
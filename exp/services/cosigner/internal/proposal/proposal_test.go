@@ -0,0 +1,130 @@
+package proposal
+
+import (
+	"testing"
+
+	"github.com/shantanu-hashcash/go/keypair"
+	"github.com/shantanu-hashcash/go/network"
+	hProtocol "github.com/shantanu-hashcash/go/protocols/aurora"
+	"github.com/shantanu-hashcash/go/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func treasuryTx(t *testing.T, source string, ops ...txnbuild.Operation) *txnbuild.Transaction {
+	tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount:        &txnbuild.SimpleAccount{AccountID: source, Sequence: 1},
+		IncrementSequenceNum: true,
+		Operations:           ops,
+		BaseFee:              txnbuild.MinBaseFee,
+		Preconditions:        txnbuild.Preconditions{TimeBounds: txnbuild.NewInfiniteTimeout()},
+	})
+	require.NoError(t, err)
+	return tx
+}
+
+func TestRequirements(t *testing.T) {
+	treasury := keypair.MustRandom()
+	other := keypair.MustRandom()
+	signer := keypair.MustRandom()
+
+	tx := treasuryTx(t, treasury.Address(),
+		&txnbuild.Payment{Destination: other.Address(), Amount: "10", Asset: txnbuild.NativeAsset{}},
+		&txnbuild.BumpSequence{BumpTo: 10, SourceAccount: other.Address()},
+	)
+	accounts := map[string]hProtocol.Account{
+		treasury.Address(): {
+			AccountID:  treasury.Address(),
+			Thresholds: hProtocol.AccountThresholds{LowThreshold: 1, MedThreshold: 3, HighThreshold: 5},
+			Signers: []hProtocol.Signer{
+				{Key: treasury.Address(), Weight: 0},
+				{Key: signer.Address(), Weight: 1},
+			},
+		},
+		other.Address(): {
+			AccountID: other.Address(),
+			Signers:   []hProtocol.Signer{{Key: other.Address(), Weight: 1}},
+		},
+	}
+
+	requirements, err := Requirements(tx, accounts)
+	require.NoError(t, err)
+	assert.Equal(t, []Requirement{
+		{
+			Account:   treasury.Address(),
+			Threshold: 3,
+			Signers:   txnbuild.SignerSummary{treasury.Address(): 0, signer.Address(): 1},
+		},
+		{
+			Account:   other.Address(),
+			Threshold: 1,
+			Signers:   txnbuild.SignerSummary{other.Address(): 1},
+		},
+	}, requirements)
+
+	p := Proposal{Requirements: requirements}
+	assert.True(t, p.IsSigner(signer.Address()))
+	assert.True(t, p.IsSigner(other.Address()))
+	assert.False(t, p.IsSigner(treasury.Address()))
+
+	_, err = Requirements(tx, map[string]hProtocol.Account{treasury.Address(): accounts[treasury.Address()]})
+	assert.EqualError(t, err, "account "+other.Address()+" not found")
+}
+
+func TestAddSignaturesAndProgress(t *testing.T) {
+	treasury := keypair.MustRandom()
+	signers := []*keypair.Full{keypair.MustRandom(), keypair.MustRandom(), keypair.MustRandom()}
+	stranger := keypair.MustRandom()
+
+	tx := treasuryTx(t, treasury.Address(),
+		&txnbuild.Payment{Destination: stranger.Address(), Amount: "10", Asset: txnbuild.NativeAsset{}},
+	)
+	requirements := []Requirement{{
+		Account:   treasury.Address(),
+		Threshold: 2,
+		Signers: txnbuild.SignerSummary{
+			signers[0].Address(): 1,
+			signers[1].Address(): 1,
+			signers[2].Address(): 1,
+		},
+	}}
+
+	progress, err := CalculateProgress(tx, network.TestNetworkPassphrase, requirements)
+	require.NoError(t, err)
+	assert.Equal(t, []Progress{{Account: treasury.Address(), Threshold: 2, Weight: 0, Signers: []string{}}}, progress)
+	assert.False(t, ThresholdsMet(progress))
+
+	// Signatures from keys that are not signers are rejected.
+	signed, err := tx.Sign(network.TestNetworkPassphrase, stranger)
+	require.NoError(t, err)
+	_, _, err = AddSignatures(tx, network.TestNetworkPassphrase, requirements, signed.Signatures())
+	assert.Equal(t, ErrNoValidSignatures, err)
+
+	signed, err = tx.Sign(network.TestNetworkPassphrase, signers[0], stranger)
+	require.NoError(t, err)
+	tx, added, err := AddSignatures(tx, network.TestNetworkPassphrase, requirements, signed.Signatures())
+	require.NoError(t, err)
+	assert.Equal(t, []string{signers[0].Address()}, added)
+	assert.Len(t, tx.Signatures(), 1)
+
+	// Adding the same signature twice does nothing.
+	_, _, err = AddSignatures(tx, network.TestNetworkPassphrase, requirements, signed.Signatures())
+	assert.Equal(t, ErrNoValidSignatures, err)
+
+	progress, err = CalculateProgress(tx, network.TestNetworkPassphrase, requirements)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), progress[0].Weight)
+	assert.False(t, ThresholdsMet(progress))
+
+	signed, err = tx.Sign(network.TestNetworkPassphrase, signers[2])
+	require.NoError(t, err)
+	tx, added, err = AddSignatures(tx, network.TestNetworkPassphrase, requirements, signed.Signatures())
+	require.NoError(t, err)
+	assert.Equal(t, []string{signers[2].Address()}, added)
+
+	progress, err = CalculateProgress(tx, network.TestNetworkPassphrase, requirements)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), progress[0].Weight)
+	assert.ElementsMatch(t, []string{signers[0].Address(), signers[2].Address()}, progress[0].Signers)
+	assert.True(t, ThresholdsMet(progress))
+}
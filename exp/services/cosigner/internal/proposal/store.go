@@ -0,0 +1,18 @@
+package proposal
+
+import (
+	"context"
+	"errors"
+)
+
+type Store interface {
+	Add(ctx context.Context, p Proposal) error
+	Get(ctx context.Context, hash string) (Proposal, error)
+	FindWithSigner(ctx context.Context, signer string) ([]Proposal, error)
+	// Update locks the proposal, calls fn with it and stores the changes fn
+	// made if it returns no error.
+	Update(ctx context.Context, hash string, fn func(p *Proposal) error) error
+}
+
+var ErrNotFound = errors.New("proposal not found")
+var ErrAlreadyExists = errors.New("proposal already exists")
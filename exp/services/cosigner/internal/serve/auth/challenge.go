@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/shantanu-hashcash/go/support/http/httpauthz"
+	"github.com/shantanu-hashcash/go/support/log"
+	"github.com/shantanu-hashcash/go/txnbuild"
+	"github.com/shantanu-hashcash/go/xdr"
+)
+
+// ChallengeMiddleware provides middleware that authenticates a client with a
+// SEP-10 style challenge transaction passed as a bearer token. The challenge
+// must have been issued by serverAccount for domain, must not have expired,
+// and must be signed by the key of the client account it was issued for. The
+// client account is the authenticated address.
+func ChallengeMiddleware(serverAccount, networkPassphrase, domain string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if address, ok := addressFromChallenge(r, serverAccount, networkPassphrase, domain); ok {
+				ctx := r.Context()
+				auth, _ := FromContext(ctx)
+				auth.Address = address
+
+				log.Ctx(ctx).
+					WithField("address", address).
+					Info("Challenge transaction verified.")
+
+				ctx = NewContext(ctx, auth)
+				r = r.WithContext(ctx)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func addressFromChallenge(r *http.Request, serverAccount, networkPassphrase, domain string) (string, bool) {
+	challenge := httpauthz.ParseBearerToken(r.Header.Get("Authorization"))
+	if challenge == "" {
+		return "", false
+	}
+
+	homeDomains := []string{domain}
+	_, clientAccountID, _, _, err := txnbuild.ReadChallengeTx(challenge, serverAccount, networkPassphrase, domain, homeDomains)
+	if err != nil {
+		return "", false
+	}
+	muxedAccount, err := xdr.AddressToMuxedAccount(clientAccountID)
+	if err != nil {
+		return "", false
+	}
+	address := muxedAccount.ToAccountId().Address()
+
+	_, err = txnbuild.VerifyChallengeTxSigners(challenge, serverAccount, networkPassphrase, domain, homeDomains, address)
+	if err != nil {
+		return "", false
+	}
+	return address, true
+}
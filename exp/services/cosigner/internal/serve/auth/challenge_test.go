@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shantanu-hashcash/go/keypair"
+	"github.com/shantanu-hashcash/go/network"
+	"github.com/shantanu-hashcash/go/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChallengeMiddleware(t *testing.T) {
+	serverKey := keypair.MustRandom()
+	clientKey := keypair.MustRandom()
+	domain := "cosigner.example.com"
+
+	challenge, err := txnbuild.BuildChallengeTx(serverKey.Seed(), clientKey.Address(), domain, domain, network.TestNetworkPassphrase, time.Minute, nil)
+	require.NoError(t, err)
+	unsigned, err := challenge.Base64()
+	require.NoError(t, err)
+	challenge, err = challenge.Sign(network.TestNetworkPassphrase, clientKey)
+	require.NoError(t, err)
+	signed, err := challenge.Base64()
+	require.NoError(t, err)
+
+	var gotAuth Auth
+	var gotOK bool
+	handler := ChallengeMiddleware(serverKey.Address(), network.TestNetworkPassphrase, domain)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotAuth, gotOK = FromContext(r.Context())
+		}),
+	)
+
+	testCases := []struct {
+		name          string
+		authorization string
+		wantAddress   string
+	}{
+		{"signed", "Bearer " + signed, clientKey.Address()},
+		{"unsigned", "Bearer " + unsigned, ""},
+		{"missing", "", ""},
+		{"garbage", "Bearer AAAA", ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gotAuth, gotOK = Auth{}, false
+			r := httptest.NewRequest("GET", "/", nil)
+			if tc.authorization != "" {
+				r.Header.Set("Authorization", tc.authorization)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), r)
+			assert.Equal(t, tc.wantAddress != "", gotOK)
+			assert.Equal(t, tc.wantAddress, gotAuth.Address)
+		})
+	}
+}
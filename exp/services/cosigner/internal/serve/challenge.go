@@ -0,0 +1,69 @@
+package serve
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/shantanu-hashcash/go/keypair"
+	"github.com/shantanu-hashcash/go/strkey"
+	supportlog "github.com/shantanu-hashcash/go/support/log"
+	"github.com/shantanu-hashcash/go/support/render/httpjson"
+	"github.com/shantanu-hashcash/go/txnbuild"
+)
+
+// challengeHandler issues challenge transactions that clients sign and pass
+// back as a bearer token to authenticate.
+type challengeHandler struct {
+	Logger             *supportlog.Entry
+	NetworkPassphrase  string
+	SigningKey         *keypair.Full
+	ChallengeExpiresIn time.Duration
+	Domain             string
+}
+
+type challengeResponse struct {
+	Transaction       string `json:"transaction"`
+	NetworkPassphrase string `json:"network_passphrase"`
+}
+
+func (h challengeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	account := r.URL.Query().Get("account")
+	if !strkey.IsValidEd25519PublicKey(account) {
+		badRequest.Render(w)
+		return
+	}
+
+	tx, err := txnbuild.BuildChallengeTx(
+		h.SigningKey.Seed(),
+		account,
+		h.Domain,
+		h.Domain,
+		h.NetworkPassphrase,
+		h.ChallengeExpiresIn,
+		nil,
+	)
+	if err != nil {
+		h.Logger.Ctx(ctx).WithStack(err).Error(err)
+		serverError.Render(w)
+		return
+	}
+
+	txeBase64, err := tx.Base64()
+	if err != nil {
+		h.Logger.Ctx(ctx).WithStack(err).Error(err)
+		serverError.Render(w)
+		return
+	}
+
+	h.Logger.Ctx(ctx).
+		WithField("account", account).
+		Info("Generated challenge transaction for account.")
+
+	res := challengeResponse{
+		Transaction:       txeBase64,
+		NetworkPassphrase: h.NetworkPassphrase,
+	}
+	httpjson.Render(w, res, httpjson.JSON)
+}
@@ -0,0 +1,71 @@
+package serve
+
+import (
+	"net/http"
+
+	"github.com/shantanu-hashcash/go/exp/services/cosigner/internal/proposal"
+	"github.com/shantanu-hashcash/go/exp/services/cosigner/internal/serve/auth"
+	"github.com/shantanu-hashcash/go/support/http/httpdecode"
+	supportlog "github.com/shantanu-hashcash/go/support/log"
+	"github.com/shantanu-hashcash/go/support/render/httpjson"
+)
+
+type proposalGetHandler struct {
+	Logger            *supportlog.Entry
+	NetworkPassphrase string
+	ProposalStore     proposal.Store
+}
+
+type proposalGetRequest struct {
+	Hash string `path:"hash"`
+}
+
+func (h proposalGetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	claims, _ := auth.FromContext(ctx)
+	if claims.Address == "" {
+		unauthorized.Render(w)
+		return
+	}
+
+	req := proposalGetRequest{}
+	err := httpdecode.Decode(r, &req)
+	if err != nil || req.Hash == "" {
+		badRequest.Render(w)
+		return
+	}
+
+	l := h.Logger.Ctx(ctx).
+		WithField("signer", claims.Address).
+		WithField("tx", req.Hash)
+
+	l.Info("Request to get proposal.")
+
+	p, err := h.ProposalStore.Get(ctx, req.Hash)
+	if err == proposal.ErrNotFound {
+		l.Info("Proposal not found.")
+		notFound.Render(w)
+		return
+	} else if err != nil {
+		l.Error(err)
+		serverError.Render(w)
+		return
+	}
+
+	// Proposals are only visible to their signers. Respond the same way as
+	// for an unknown proposal so that hashes cannot be probed.
+	if !p.IsSigner(claims.Address) {
+		l.Info("Not a signer of the proposal.")
+		notFound.Render(w)
+		return
+	}
+
+	resp, err := newProposalResponse(p, h.NetworkPassphrase)
+	if err != nil {
+		l.Error(err)
+		serverError.Render(w)
+		return
+	}
+	httpjson.Render(w, resp, httpjson.JSON)
+}
@@ -0,0 +1,57 @@
+package serve
+
+import (
+	"net/http"
+
+	"github.com/shantanu-hashcash/go/exp/services/cosigner/internal/proposal"
+	"github.com/shantanu-hashcash/go/exp/services/cosigner/internal/serve/auth"
+	supportlog "github.com/shantanu-hashcash/go/support/log"
+	"github.com/shantanu-hashcash/go/support/render/httpjson"
+)
+
+type proposalListHandler struct {
+	Logger            *supportlog.Entry
+	NetworkPassphrase string
+	ProposalStore     proposal.Store
+}
+
+type proposalListResponse struct {
+	Proposals []proposalResponse `json:"proposals"`
+}
+
+func (h proposalListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	claims, _ := auth.FromContext(ctx)
+	if claims.Address == "" {
+		unauthorized.Render(w)
+		return
+	}
+
+	l := h.Logger.Ctx(ctx).
+		WithField("signer", claims.Address)
+
+	l.Info("Request to list proposals.")
+
+	proposals, err := h.ProposalStore.FindWithSigner(ctx, claims.Address)
+	if err != nil {
+		l.Error(err)
+		serverError.Render(w)
+		return
+	}
+
+	resp := proposalListResponse{Proposals: []proposalResponse{}}
+	for _, p := range proposals {
+		pResp, err := newProposalResponse(p, h.NetworkPassphrase)
+		if err != nil {
+			l.Error(err)
+			serverError.Render(w)
+			return
+		}
+		resp.Proposals = append(resp.Proposals, pResp)
+	}
+
+	l.Infof("Found %d proposals.", len(resp.Proposals))
+
+	httpjson.Render(w, resp, httpjson.JSON)
+}
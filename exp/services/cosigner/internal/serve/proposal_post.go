@@ -0,0 +1,161 @@
+package serve
+
+import (
+	"net/http"
+
+	"github.com/shantanu-hashcash/go/clients/auroraclient"
+	"github.com/shantanu-hashcash/go/exp/services/cosigner/internal/proposal"
+	"github.com/shantanu-hashcash/go/exp/services/cosigner/internal/serve/auth"
+	hProtocol "github.com/shantanu-hashcash/go/protocols/aurora"
+	"github.com/shantanu-hashcash/go/support/http/httpdecode"
+	supportlog "github.com/shantanu-hashcash/go/support/log"
+	"github.com/shantanu-hashcash/go/support/render/httpjson"
+)
+
+type proposalPostHandler struct {
+	Logger            *supportlog.Entry
+	NetworkPassphrase string
+	AuroraClient      auroraclient.ClientInterface
+	ProposalStore     proposal.Store
+}
+
+type proposalPostRequest struct {
+	Transaction string `json:"transaction" form:"transaction"`
+}
+
+func (h proposalPostHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	claims, _ := auth.FromContext(ctx)
+	if claims.Address == "" {
+		unauthorized.Render(w)
+		return
+	}
+
+	req := proposalPostRequest{}
+	err := httpdecode.Decode(r, &req)
+	if err != nil || req.Transaction == "" {
+		badRequest.Render(w)
+		return
+	}
+
+	tx, err := proposal.ParseTransaction(req.Transaction)
+	if err != nil {
+		badRequest.Render(w)
+		return
+	}
+	hash, err := tx.HashHex(h.NetworkPassphrase)
+	if err != nil {
+		badRequest.Render(w)
+		return
+	}
+
+	l := h.Logger.Ctx(ctx).
+		WithField("proposer", claims.Address).
+		WithField("tx", hash)
+
+	l.Info("Request to propose transaction.")
+
+	accounts := map[string]hProtocol.Account{}
+	for _, address := range proposal.SourceAccounts(tx) {
+		account, err := h.AuroraClient.AccountDetail(auroraclient.AccountRequest{AccountID: address})
+		if auroraclient.IsNotFoundError(err) {
+			l.WithField("account", address).Info("Source account does not exist.")
+			badRequest.Render(w)
+			return
+		} else if err != nil {
+			l.Error(err)
+			serverError.Render(w)
+			return
+		}
+		accounts[address] = account
+	}
+
+	requirements, err := proposal.Requirements(tx, accounts)
+	if err != nil {
+		l.Error(err)
+		serverError.Render(w)
+		return
+	}
+
+	p := proposal.Proposal{
+		Hash:         hash,
+		Proposer:     claims.Address,
+		Requirements: requirements,
+		Status:       proposal.StatusPending,
+	}
+	if !p.IsSigner(claims.Address) {
+		l.Info("Proposer is not a signer of any source account.")
+		forbidden.Render(w)
+		return
+	}
+
+	// Keep only the signatures that come from signers of the source
+	// accounts.
+	signatures := tx.Signatures()
+	tx, err = tx.ClearSignatures()
+	if err != nil {
+		l.Error(err)
+		serverError.Render(w)
+		return
+	}
+	if len(signatures) > 0 {
+		signedTx, added, err := proposal.AddSignatures(tx, h.NetworkPassphrase, requirements, signatures)
+		if err == nil {
+			tx = signedTx
+			l.Infof("Proposal includes signatures from %v.", added)
+		} else if err != proposal.ErrNoValidSignatures {
+			l.Error(err)
+			serverError.Render(w)
+			return
+		}
+	}
+	p.TransactionXDR, err = tx.Base64()
+	if err != nil {
+		l.Error(err)
+		serverError.Render(w)
+		return
+	}
+
+	err = h.ProposalStore.Add(ctx, p)
+	if err == proposal.ErrAlreadyExists {
+		l.Info("Proposal already exists.")
+		conflict.Render(w)
+		return
+	} else if err != nil {
+		l.Error(err)
+		serverError.Render(w)
+		return
+	}
+
+	// A proposal that arrives with enough signatures is submitted straight
+	// away.
+	ready := false
+	err = h.ProposalStore.Update(ctx, hash, func(p *proposal.Proposal) error {
+		var markErr error
+		ready, markErr = markIfReady(l, h.NetworkPassphrase, p, tx)
+		return markErr
+	})
+	if err == nil && ready {
+		err = submit(ctx, l, h.AuroraClient, h.ProposalStore, hash)
+	}
+	if err != nil {
+		l.Error(err)
+		serverError.Render(w)
+		return
+	}
+
+	p, err = h.ProposalStore.Get(ctx, hash)
+	if err != nil {
+		l.Error(err)
+		serverError.Render(w)
+		return
+	}
+	resp, err := newProposalResponse(p, h.NetworkPassphrase)
+	if err != nil {
+		l.Error(err)
+		serverError.Render(w)
+		return
+	}
+	httpjson.RenderStatus(w, http.StatusCreated, resp, httpjson.JSON)
+}
@@ -0,0 +1,60 @@
+package serve
+
+import (
+	"time"
+
+	"github.com/shantanu-hashcash/go/exp/services/cosigner/internal/proposal"
+)
+
+type proposalResponse struct {
+	Hash        string                     `json:"hash"`
+	Proposer    string                     `json:"proposer"`
+	Status      proposal.Status            `json:"status"`
+	Transaction string                     `json:"transaction"`
+	ResultXDR   string                     `json:"result_xdr,omitempty"`
+	Error       string                     `json:"error,omitempty"`
+	Progress    []proposalProgressResponse `json:"progress"`
+	Signers     []string                   `json:"signers"`
+	CreatedAt   time.Time                  `json:"created_at"`
+}
+
+type proposalProgressResponse struct {
+	Account   string   `json:"account"`
+	Threshold int32    `json:"threshold"`
+	Weight    int32    `json:"weight"`
+	Signers   []string `json:"signers"`
+	Met       bool     `json:"met"`
+}
+
+func newProposalResponse(p proposal.Proposal, networkPassphrase string) (proposalResponse, error) {
+	tx, err := p.Transaction()
+	if err != nil {
+		return proposalResponse{}, err
+	}
+	progress, err := proposal.CalculateProgress(tx, networkPassphrase, p.Requirements)
+	if err != nil {
+		return proposalResponse{}, err
+	}
+
+	resp := proposalResponse{
+		Hash:        p.Hash,
+		Proposer:    p.Proposer,
+		Status:      p.Status,
+		Transaction: p.TransactionXDR,
+		ResultXDR:   p.ResultXDR,
+		Error:       p.Error,
+		Progress:    []proposalProgressResponse{},
+		Signers:     p.Signers(),
+		CreatedAt:   p.CreatedAt,
+	}
+	for _, ap := range progress {
+		resp.Progress = append(resp.Progress, proposalProgressResponse{
+			Account:   ap.Account,
+			Threshold: ap.Threshold,
+			Weight:    ap.Weight,
+			Signers:   ap.Signers,
+			Met:       ap.Met(),
+		})
+	}
+	return resp, nil
+}
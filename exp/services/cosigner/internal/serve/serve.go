@@ -0,0 +1,146 @@
+package serve
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/shantanu-hashcash/go/clients/auroraclient"
+	"github.com/shantanu-hashcash/go/exp/services/cosigner/internal/db"
+	"github.com/shantanu-hashcash/go/exp/services/cosigner/internal/proposal"
+	"github.com/shantanu-hashcash/go/exp/services/cosigner/internal/serve/auth"
+	"github.com/shantanu-hashcash/go/keypair"
+	"github.com/shantanu-hashcash/go/support/errors"
+	supporthttp "github.com/shantanu-hashcash/go/support/http"
+	supportlog "github.com/shantanu-hashcash/go/support/log"
+	"github.com/shantanu-hashcash/go/support/render/health"
+)
+
+type Options struct {
+	Logger               *supportlog.Entry
+	DatabaseURL          string
+	DatabaseMaxOpenConns int
+	Port                 int
+	NetworkPassphrase    string
+	AuroraURL            string
+	SigningKey           string
+	Domain               string
+	ChallengeExpiresIn   time.Duration
+}
+
+func Serve(opts Options) {
+	deps, err := getHandlerDeps(opts)
+	if err != nil {
+		opts.Logger.Fatalf("Error: %v", err)
+		return
+	}
+
+	handler := handler(deps)
+
+	addr := fmt.Sprintf(":%d", opts.Port)
+	supporthttp.Run(supporthttp.Config{
+		ListenAddr: addr,
+		Handler:    handler,
+		OnStarting: func() {
+			deps.Logger.Infof("Starting cosigner server on %s", addr)
+		},
+	})
+}
+
+type handlerDeps struct {
+	Logger             *supportlog.Entry
+	NetworkPassphrase  string
+	SigningKey         *keypair.Full
+	Domain             string
+	ChallengeExpiresIn time.Duration
+	AuroraClient       auroraclient.ClientInterface
+	ProposalStore      proposal.Store
+}
+
+func getHandlerDeps(opts Options) (handlerDeps, error) {
+	signingKey, err := keypair.ParseFull(opts.SigningKey)
+	if err != nil {
+		return handlerDeps{}, errors.Wrap(err, "parsing signing key seed")
+	}
+	opts.Logger.Info("Signing key: ", signingKey.Address())
+
+	session, err := db.Open(opts.DatabaseURL)
+	if err != nil {
+		return handlerDeps{}, errors.Wrap(err, "error parsing database url")
+	}
+	session.DB.SetMaxOpenConns(opts.DatabaseMaxOpenConns)
+
+	err = session.DB.Ping()
+	if err != nil {
+		opts.Logger.Warn("Error pinging to Database: ", err)
+	}
+
+	auroraClient := &auroraclient.Client{
+		AuroraURL: opts.AuroraURL,
+		HTTP:      &http.Client{Timeout: 30 * time.Second},
+	}
+
+	deps := handlerDeps{
+		Logger:             opts.Logger,
+		NetworkPassphrase:  opts.NetworkPassphrase,
+		SigningKey:         signingKey,
+		Domain:             opts.Domain,
+		ChallengeExpiresIn: opts.ChallengeExpiresIn,
+		AuroraClient:       auroraClient,
+		ProposalStore:      &proposal.DBStore{DB: session},
+	}
+
+	return deps, nil
+}
+
+func handler(deps handlerDeps) http.Handler {
+	mux := supporthttp.NewAPIMux(deps.Logger)
+
+	mux.NotFound(errorHandler{Error: notFound}.ServeHTTP)
+	mux.MethodNotAllowed(errorHandler{Error: methodNotAllowed}.ServeHTTP)
+
+	mux.Get("/health", health.PassHandler{}.ServeHTTP)
+	mux.Get("/challenge", challengeHandler{
+		Logger:             deps.Logger,
+		NetworkPassphrase:  deps.NetworkPassphrase,
+		SigningKey:         deps.SigningKey,
+		ChallengeExpiresIn: deps.ChallengeExpiresIn,
+		Domain:             deps.Domain,
+	}.ServeHTTP)
+	mux.Route("/transactions", func(mux chi.Router) {
+		mux.Use(auth.ChallengeMiddleware(deps.SigningKey.Address(), deps.NetworkPassphrase, deps.Domain))
+		mux.Get("/", proposalListHandler{
+			Logger:            deps.Logger,
+			NetworkPassphrase: deps.NetworkPassphrase,
+			ProposalStore:     deps.ProposalStore,
+		}.ServeHTTP)
+		mux.Post("/", proposalPostHandler{
+			Logger:            deps.Logger,
+			NetworkPassphrase: deps.NetworkPassphrase,
+			AuroraClient:      deps.AuroraClient,
+			ProposalStore:     deps.ProposalStore,
+		}.ServeHTTP)
+		mux.Route("/{hash}", func(mux chi.Router) {
+			mux.Get("/", proposalGetHandler{
+				Logger:            deps.Logger,
+				NetworkPassphrase: deps.NetworkPassphrase,
+				ProposalStore:     deps.ProposalStore,
+			}.ServeHTTP)
+			mux.Post("/signatures", signaturePostHandler{
+				Logger:            deps.Logger,
+				NetworkPassphrase: deps.NetworkPassphrase,
+				AuroraClient:      deps.AuroraClient,
+				ProposalStore:     deps.ProposalStore,
+			}.ServeHTTP)
+			mux.Post("/submission", submissionPostHandler{
+				Logger:            deps.Logger,
+				NetworkPassphrase: deps.NetworkPassphrase,
+				AuroraClient:      deps.AuroraClient,
+				ProposalStore:     deps.ProposalStore,
+			}.ServeHTTP)
+		})
+	})
+
+	return mux
+}
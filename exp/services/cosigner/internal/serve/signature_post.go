@@ -0,0 +1,135 @@
+package serve
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/shantanu-hashcash/go/clients/auroraclient"
+	"github.com/shantanu-hashcash/go/exp/services/cosigner/internal/proposal"
+	"github.com/shantanu-hashcash/go/exp/services/cosigner/internal/serve/auth"
+	"github.com/shantanu-hashcash/go/support/http/httpdecode"
+	supportlog "github.com/shantanu-hashcash/go/support/log"
+	"github.com/shantanu-hashcash/go/support/render/httpjson"
+)
+
+type signaturePostHandler struct {
+	Logger            *supportlog.Entry
+	NetworkPassphrase string
+	AuroraClient      auroraclient.ClientInterface
+	ProposalStore     proposal.Store
+}
+
+var errNotPending = errors.New("proposal is not pending")
+
+type signaturePostRequest struct {
+	Hash string `path:"hash"`
+	// Transaction is the proposed transaction with one or more signatures
+	// added by the co-signer.
+	Transaction string `json:"transaction" form:"transaction"`
+}
+
+func (h signaturePostHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	claims, _ := auth.FromContext(ctx)
+	if claims.Address == "" {
+		unauthorized.Render(w)
+		return
+	}
+
+	req := signaturePostRequest{}
+	err := httpdecode.Decode(r, &req)
+	if err != nil || req.Hash == "" || req.Transaction == "" {
+		badRequest.Render(w)
+		return
+	}
+
+	l := h.Logger.Ctx(ctx).
+		WithField("signer", claims.Address).
+		WithField("tx", req.Hash)
+
+	l.Info("Request to add signatures.")
+
+	signedTx, err := proposal.ParseTransaction(req.Transaction)
+	if err != nil {
+		badRequest.Render(w)
+		return
+	}
+	signedHash, err := signedTx.HashHex(h.NetworkPassphrase)
+	if err != nil || signedHash != req.Hash {
+		l.Info("Signed transaction does not match the proposal.")
+		badRequest.Render(w)
+		return
+	}
+
+	var errResponse *errorResponse
+	ready := false
+	err = h.ProposalStore.Update(ctx, req.Hash, func(p *proposal.Proposal) error {
+		if !p.IsSigner(claims.Address) {
+			l.Info("Not a signer of the proposal.")
+			errResponse = &notFound
+			return proposal.ErrNotFound
+		}
+		if p.Status != proposal.StatusPending {
+			l.Infof("Proposal is %s.", p.Status)
+			errResponse = &notPending
+			return errNotPending
+		}
+
+		tx, err := p.Transaction()
+		if err != nil {
+			return err
+		}
+		tx, added, err := proposal.AddSignatures(tx, h.NetworkPassphrase, p.Requirements, signedTx.Signatures())
+		if err == proposal.ErrNoValidSignatures {
+			l.Info("No new valid signatures.")
+			errResponse = &badRequest
+			return err
+		} else if err != nil {
+			return err
+		}
+		l.Infof("Added signatures from %v.", added)
+
+		p.TransactionXDR, err = tx.Base64()
+		if err != nil {
+			return err
+		}
+		ready, err = markIfReady(l, h.NetworkPassphrase, p, tx)
+		return err
+	})
+	if errResponse != nil {
+		errResponse.Render(w)
+		return
+	} else if err == proposal.ErrNotFound {
+		l.Info("Proposal not found.")
+		notFound.Render(w)
+		return
+	} else if err != nil {
+		l.Error(err)
+		serverError.Render(w)
+		return
+	}
+
+	if ready {
+		err = submit(ctx, l, h.AuroraClient, h.ProposalStore, req.Hash)
+		if err != nil {
+			l.Error(err)
+			serverError.Render(w)
+			return
+		}
+	}
+
+	p, err := h.ProposalStore.Get(ctx, req.Hash)
+	if err != nil {
+		l.Error(err)
+		serverError.Render(w)
+		return
+	}
+	resp, err := newProposalResponse(p, h.NetworkPassphrase)
+	if err != nil {
+		l.Error(err)
+		serverError.Render(w)
+		return
+	}
+	httpjson.Render(w, resp, httpjson.JSON)
+}
@@ -0,0 +1,125 @@
+package serve
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/shantanu-hashcash/go/clients/auroraclient"
+	"github.com/shantanu-hashcash/go/exp/services/cosigner/internal/db/dbtest"
+	"github.com/shantanu-hashcash/go/exp/services/cosigner/internal/proposal"
+	"github.com/shantanu-hashcash/go/exp/services/cosigner/internal/serve/auth"
+	"github.com/shantanu-hashcash/go/keypair"
+	"github.com/shantanu-hashcash/go/network"
+	hProtocol "github.com/shantanu-hashcash/go/protocols/aurora"
+	supportdb "github.com/shantanu-hashcash/go/support/db"
+	supportlog "github.com/shantanu-hashcash/go/support/log"
+	"github.com/shantanu-hashcash/go/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test that signatures are collected from co-signers and that the transaction
+// is submitted once the threshold is met.
+func TestSignaturePost_submitsWhenThresholdMet(t *testing.T) {
+	db := dbtest.Open(t)
+	session := &supportdb.Session{DB: db.Open()}
+	defer session.Close()
+	store := &proposal.DBStore{DB: session}
+
+	treasury := keypair.MustRandom()
+	signers := []*keypair.Full{keypair.MustRandom(), keypair.MustRandom(), keypair.MustRandom()}
+	tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount:        &txnbuild.SimpleAccount{AccountID: treasury.Address(), Sequence: 1},
+		IncrementSequenceNum: true,
+		Operations:           []txnbuild.Operation{&txnbuild.Payment{Destination: signers[0].Address(), Amount: "1", Asset: txnbuild.NativeAsset{}}},
+		BaseFee:              txnbuild.MinBaseFee,
+		Preconditions:        txnbuild.Preconditions{TimeBounds: txnbuild.NewInfiniteTimeout()},
+	})
+	require.NoError(t, err)
+	hash, err := tx.HashHex(network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	txXDR, err := tx.Base64()
+	require.NoError(t, err)
+
+	err = store.Add(context.Background(), proposal.Proposal{
+		Hash:           hash,
+		Proposer:       signers[0].Address(),
+		TransactionXDR: txXDR,
+		Status:         proposal.StatusPending,
+		Requirements: []proposal.Requirement{{
+			Account:   treasury.Address(),
+			Threshold: 2,
+			Signers: txnbuild.SignerSummary{
+				signers[0].Address(): 1,
+				signers[1].Address(): 1,
+				signers[2].Address(): 1,
+			},
+		}},
+	})
+	require.NoError(t, err)
+
+	auroraMock := &auroraclient.MockClient{}
+	h := signaturePostHandler{
+		Logger:            supportlog.DefaultLogger,
+		NetworkPassphrase: network.TestNetworkPassphrase,
+		AuroraClient:      auroraMock,
+		ProposalStore:     store,
+	}
+	m := chi.NewMux()
+	m.Post("/{hash}/signatures", h.ServeHTTP)
+
+	sign := func(signer *keypair.Full) *http.Response {
+		signed, err := tx.Sign(network.TestNetworkPassphrase, signer)
+		require.NoError(t, err)
+		signedXDR, err := signed.Base64()
+		require.NoError(t, err)
+
+		ctx := auth.NewContext(context.Background(), auth.Auth{Address: signer.Address()})
+		r := httptest.NewRequest("POST", "/"+hash+"/signatures", strings.NewReader(`{"transaction":"`+signedXDR+`"}`))
+		r.Header.Set("Content-Type", "application/json")
+		r = r.WithContext(ctx)
+		w := httptest.NewRecorder()
+		m.ServeHTTP(w, r)
+		return w.Result()
+	}
+
+	resp := sign(signers[0])
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body := proposalResponse{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, proposal.StatusPending, body.Status)
+	assert.Equal(t, int32(1), body.Progress[0].Weight)
+
+	// A key that is not a signer cannot add signatures.
+	resp = sign(keypair.MustRandom())
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	signed, err := tx.Sign(network.TestNetworkPassphrase, signers[0], signers[2])
+	require.NoError(t, err)
+	signedXDR, err := signed.Base64()
+	require.NoError(t, err)
+	auroraMock.On("TransactionDetail", hash).
+		Return(hProtocol.Transaction{}, notFoundErr).
+		Once()
+	auroraMock.On("SubmitTransactionXDR", signedXDR).
+		Return(hProtocol.Transaction{Hash: hash, ResultXdr: "AAAAAAAAAGQAAAAAAAAAAQAAAAAAAAABAAAAAAAAAAA="}, nil).
+		Once()
+
+	resp = sign(signers[2])
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body = proposalResponse{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, proposal.StatusSubmitted, body.Status)
+	assert.Equal(t, "AAAAAAAAAGQAAAAAAAAAAQAAAAAAAAABAAAAAAAAAAA=", body.ResultXDR)
+	assert.True(t, body.Progress[0].Met)
+	auroraMock.AssertExpectations(t)
+
+	// Once submitted no more signatures are accepted.
+	resp = sign(signers[1])
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+}
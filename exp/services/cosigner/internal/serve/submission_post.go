@@ -0,0 +1,90 @@
+package serve
+
+import (
+	"net/http"
+
+	"github.com/shantanu-hashcash/go/clients/auroraclient"
+	"github.com/shantanu-hashcash/go/exp/services/cosigner/internal/proposal"
+	"github.com/shantanu-hashcash/go/exp/services/cosigner/internal/serve/auth"
+	"github.com/shantanu-hashcash/go/support/http/httpdecode"
+	supportlog "github.com/shantanu-hashcash/go/support/log"
+	"github.com/shantanu-hashcash/go/support/render/httpjson"
+)
+
+// submissionPostHandler submits again a proposal whose previous submission
+// did not return a definitive result.
+type submissionPostHandler struct {
+	Logger            *supportlog.Entry
+	NetworkPassphrase string
+	AuroraClient      auroraclient.ClientInterface
+	ProposalStore     proposal.Store
+}
+
+type submissionPostRequest struct {
+	Hash string `path:"hash"`
+}
+
+func (h submissionPostHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	claims, _ := auth.FromContext(ctx)
+	if claims.Address == "" {
+		unauthorized.Render(w)
+		return
+	}
+
+	req := submissionPostRequest{}
+	err := httpdecode.Decode(r, &req)
+	if err != nil || req.Hash == "" {
+		badRequest.Render(w)
+		return
+	}
+
+	l := h.Logger.Ctx(ctx).
+		WithField("signer", claims.Address).
+		WithField("tx", req.Hash)
+
+	l.Info("Request to submit proposal.")
+
+	p, err := h.ProposalStore.Get(ctx, req.Hash)
+	if err == proposal.ErrNotFound {
+		l.Info("Proposal not found.")
+		notFound.Render(w)
+		return
+	} else if err != nil {
+		l.Error(err)
+		serverError.Render(w)
+		return
+	}
+	if !p.IsSigner(claims.Address) {
+		l.Info("Not a signer of the proposal.")
+		notFound.Render(w)
+		return
+	}
+	if p.Status != proposal.StatusSubmitting {
+		l.Infof("Proposal is %s.", p.Status)
+		notSubmitting.Render(w)
+		return
+	}
+
+	err = submit(ctx, l, h.AuroraClient, h.ProposalStore, req.Hash)
+	if err != nil {
+		l.Error(err)
+		serverError.Render(w)
+		return
+	}
+
+	p, err = h.ProposalStore.Get(ctx, req.Hash)
+	if err != nil {
+		l.Error(err)
+		serverError.Render(w)
+		return
+	}
+	resp, err := newProposalResponse(p, h.NetworkPassphrase)
+	if err != nil {
+		l.Error(err)
+		serverError.Render(w)
+		return
+	}
+	httpjson.Render(w, resp, httpjson.JSON)
+}
@@ -0,0 +1,89 @@
+package serve
+
+import (
+	"context"
+
+	"github.com/shantanu-hashcash/go/clients/auroraclient"
+	"github.com/shantanu-hashcash/go/exp/services/cosigner/internal/proposal"
+	supportlog "github.com/shantanu-hashcash/go/support/log"
+	"github.com/shantanu-hashcash/go/txnbuild"
+)
+
+// markIfReady moves p to StatusSubmitting if p is pending and the signatures
+// on tx meet the thresholds of every source account. It reports whether p
+// must now be submitted with submit, which must happen after the change is
+// stored so that no row lock is held during the call to Aurora.
+func markIfReady(l *supportlog.Entry, networkPassphrase string, p *proposal.Proposal, tx *txnbuild.Transaction) (bool, error) {
+	if p.Status != proposal.StatusPending {
+		return false, nil
+	}
+
+	progress, err := proposal.CalculateProgress(tx, networkPassphrase, p.Requirements)
+	if err != nil {
+		return false, err
+	}
+	if !proposal.ThresholdsMet(progress) {
+		l.Info("Thresholds not met yet.")
+		return false, nil
+	}
+
+	l.Info("Thresholds met, transaction ready to submit.")
+	p.Status = proposal.StatusSubmitting
+	return true, nil
+}
+
+// submit submits the transaction of the proposal with the given hash to
+// Aurora, if the proposal is submitting, and records the outcome.
+//
+// The proposal only becomes failed when Aurora returns result codes for the
+// transaction. After a timeout or any other error the transaction may still
+// be included in a ledger, so the proposal stays submitting and the error is
+// recorded. Calling submit again first looks the transaction up by hash, so
+// a transaction that made it into a ledger is not submitted twice.
+func submit(ctx context.Context, l *supportlog.Entry, client auroraclient.ClientInterface, store proposal.Store, hash string) error {
+	p, err := store.Get(ctx, hash)
+	if err != nil {
+		return err
+	}
+	if p.Status != proposal.StatusSubmitting {
+		return nil
+	}
+
+	status, resultXDR, submitErr := proposal.StatusSubmitting, "", ""
+	if tx, err := client.TransactionDetail(hash); err == nil {
+		l.Info("Transaction found in Aurora.")
+		status, resultXDR = proposal.StatusSubmitted, tx.ResultXdr
+	} else if !auroraclient.IsNotFoundError(err) {
+		l.WithField("error", err.Error()).Info("Transaction lookup failed, proposal stays submitting.")
+		submitErr = err.Error()
+	} else if tx, err := client.SubmitTransactionXDR(p.TransactionXDR); err == nil {
+		l.Info("Transaction submitted.")
+		status, resultXDR = proposal.StatusSubmitted, tx.ResultXdr
+	} else if auroraErr := auroraclient.GetError(err); auroraErr != nil && rejected(auroraErr) {
+		l.WithField("error", err.Error()).Info("Transaction rejected.")
+		status, submitErr = proposal.StatusFailed, err.Error()
+		resultXDR, _ = auroraErr.ResultString()
+	} else {
+		l.WithField("error", err.Error()).Info("Transaction submission failed, proposal stays submitting.")
+		submitErr = err.Error()
+	}
+
+	return store.Update(ctx, hash, func(p *proposal.Proposal) error {
+		if p.Status != proposal.StatusSubmitting {
+			// Another request recorded the outcome first.
+			return nil
+		}
+		p.Status = status
+		p.ResultXDR = resultXDR
+		p.Error = submitErr
+		return nil
+	})
+}
+
+// rejected reports whether Aurora definitively rejected the transaction,
+// which is when it returns transaction result codes such as tx_failed or
+// tx_bad_seq. Timeouts are returned without result codes.
+func rejected(auroraErr *auroraclient.Error) bool {
+	codes, err := auroraErr.ResultCodes()
+	return err == nil && codes.TransactionCode != ""
+}
@@ -0,0 +1,127 @@
+package serve
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shantanu-hashcash/go/clients/auroraclient"
+	"github.com/shantanu-hashcash/go/exp/services/cosigner/internal/proposal"
+	hProtocol "github.com/shantanu-hashcash/go/protocols/aurora"
+	"github.com/shantanu-hashcash/go/support/errors"
+	supportlog "github.com/shantanu-hashcash/go/support/log"
+	"github.com/shantanu-hashcash/go/support/render/problem"
+)
+
+var notFoundErr = &auroraclient.Error{
+	Problem: problem.P{Type: "https://hcnet.org/aurora-errors/not_found", Status: http.StatusNotFound},
+}
+
+// memoryStore is a proposal.Store for tests that do not need a database.
+type memoryStore map[string]proposal.Proposal
+
+func (s memoryStore) Add(ctx context.Context, p proposal.Proposal) error {
+	s[p.Hash] = p
+	return nil
+}
+
+func (s memoryStore) Get(ctx context.Context, hash string) (proposal.Proposal, error) {
+	p, ok := s[hash]
+	if !ok {
+		return p, proposal.ErrNotFound
+	}
+	return p, nil
+}
+
+func (s memoryStore) FindWithSigner(ctx context.Context, signer string) ([]proposal.Proposal, error) {
+	return nil, nil
+}
+
+func (s memoryStore) Update(ctx context.Context, hash string, fn func(p *proposal.Proposal) error) error {
+	p, err := s.Get(ctx, hash)
+	if err != nil {
+		return err
+	}
+	if err = fn(&p); err != nil {
+		return err
+	}
+	s[hash] = p
+	return nil
+}
+
+func TestSubmit_timeoutKeepsProposalSubmitting(t *testing.T) {
+	ctx := context.Background()
+	store := memoryStore{}
+	require.NoError(t, store.Add(ctx, proposal.Proposal{Hash: "abc", TransactionXDR: "xdr", Status: proposal.StatusSubmitting}))
+
+	auroraMock := &auroraclient.MockClient{}
+	auroraMock.On("TransactionDetail", "abc").Return(hProtocol.Transaction{}, notFoundErr).Twice()
+	auroraMock.On("SubmitTransactionXDR", "xdr").
+		Return(hProtocol.Transaction{}, &auroraclient.Error{Problem: problem.P{Status: http.StatusGatewayTimeout, Title: "Timeout"}}).
+		Once()
+	require.NoError(t, submit(ctx, supportlog.DefaultLogger, auroraMock, store, "abc"))
+
+	p, err := store.Get(ctx, "abc")
+	require.NoError(t, err)
+	assert.Equal(t, proposal.StatusSubmitting, p.Status)
+	assert.NotEmpty(t, p.Error)
+
+	// Transport errors are not definitive either.
+	auroraMock.On("SubmitTransactionXDR", "xdr").
+		Return(hProtocol.Transaction{}, errors.New("connection reset")).
+		Once()
+	require.NoError(t, submit(ctx, supportlog.DefaultLogger, auroraMock, store, "abc"))
+	p, err = store.Get(ctx, "abc")
+	require.NoError(t, err)
+	assert.Equal(t, proposal.StatusSubmitting, p.Status)
+	assert.Equal(t, "connection reset", p.Error)
+
+	// The transaction landed despite the errors, it is found by hash and
+	// not submitted again.
+	auroraMock.On("TransactionDetail", "abc").Return(hProtocol.Transaction{ResultXdr: "result"}, nil).Once()
+	require.NoError(t, submit(ctx, supportlog.DefaultLogger, auroraMock, store, "abc"))
+	p, err = store.Get(ctx, "abc")
+	require.NoError(t, err)
+	assert.Equal(t, proposal.StatusSubmitted, p.Status)
+	assert.Equal(t, "result", p.ResultXDR)
+	assert.Empty(t, p.Error)
+	auroraMock.AssertExpectations(t)
+}
+
+func TestSubmit_resultCodesFailProposal(t *testing.T) {
+	ctx := context.Background()
+	store := memoryStore{}
+	require.NoError(t, store.Add(ctx, proposal.Proposal{Hash: "abc", TransactionXDR: "xdr", Status: proposal.StatusSubmitting}))
+
+	auroraMock := &auroraclient.MockClient{}
+	auroraMock.On("TransactionDetail", "abc").Return(hProtocol.Transaction{}, notFoundErr).Once()
+	auroraMock.On("SubmitTransactionXDR", "xdr").
+		Return(hProtocol.Transaction{}, &auroraclient.Error{Problem: problem.P{
+			Status: http.StatusBadRequest,
+			Title:  "Transaction Failed",
+			Extras: map[string]interface{}{
+				"result_codes": map[string]interface{}{"transaction": "tx_bad_seq"},
+				"result_xdr":   "AAAAAAAAAGT////7AAAAAA==",
+			},
+		}}).
+		Once()
+	require.NoError(t, submit(ctx, supportlog.DefaultLogger, auroraMock, store, "abc"))
+
+	p, err := store.Get(ctx, "abc")
+	require.NoError(t, err)
+	assert.Equal(t, proposal.StatusFailed, p.Status)
+	assert.Equal(t, "AAAAAAAAAGT////7AAAAAA==", p.ResultXDR)
+	auroraMock.AssertExpectations(t)
+}
+
+func TestSubmit_ignoresProposalsNotSubmitting(t *testing.T) {
+	ctx := context.Background()
+	store := memoryStore{}
+	require.NoError(t, store.Add(ctx, proposal.Proposal{Hash: "abc", Status: proposal.StatusPending}))
+	auroraMock := &auroraclient.MockClient{}
+	require.NoError(t, submit(ctx, supportlog.DefaultLogger, auroraMock, store, "abc"))
+	auroraMock.AssertExpectations(t)
+}
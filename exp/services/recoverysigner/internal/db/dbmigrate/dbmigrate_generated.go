@@ -8,6 +8,7 @@
 // migrations/20200320000000-create-accounts-audit.sql (1.23kB)
 // migrations/20200320000001-create-identities-audit.sql (1.166kB)
 // migrations/20200320000002-create-auth-methods-audit.sql (1.192kB)
+// migrations/20240722000000-create-sign-requests.sql (880B)
 
 package dbmigrate
 
@@ -236,6 +237,26 @@ func migrations20200320000002CreateAuthMethodsAuditSql() (*asset, error) {
 	return a, nil
 }
 
+var _migrations20240722000000CreateSignRequestsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x95\x53\xc1\x72\x82\x30\x10\xbd\xe7\x2b\xf6\xa6\x4e\xf1\x0b\x3c\x45\x58\x2d\x53\x08\x0c\x84\x51\x7a\x61\x22\x64\x94\x19\x8a\x96\x40\x3b\xfd\xfb\x26\x96\x2a\xad\xd6\xb6\x87\xcc\x64\x67\xf7\xbd\xb7\x79\xbb\x99\x4e\xe1\xee\xa9\xdc\x36\xa2\x95\x90\x1c\x08\xb1\x23\xa4\x1c\x81\xa7\x21\x82\x2a\xb7\x75\xd6\xc8\xe7\x4e\xaa\x36\x53\xad\x68\x3b\x05\x34\x06\x64\x89\x0f\x63\x02\x30\x3a\xc8\xba\x28\xeb\xed\xc8\x32\x41\x2e\xea\x5c\x56\x95\x2c\x3e\x42\x03\xd6\x77\x32\x99\x9d\x49\xe9\xdc\xfb\xca\xaa\x8e\x3c\x22\xcf\xf7\x5d\xdd\x66\x65\x01\x73\x77\xe9\x32\x0e\x2c\xd0\x27\xf1\x3c\x88\x70\x81\x11\x32\x1b\xe3\xcf\x2a\x0d\x29\x8b\x09\x04\x0c\x1c\xf4\x50\xb3\xda\x34\xb6\xa9\x83\x46\xf4\x0a\x41\x18\xb9\x3e\x8d\x52\x78\xc0\x14\x96\xc8\x30\xd2\x8d\x38\x40\xbd\x15\x4d\x63\xf3\x18\xd7\x41\xc6\x5d\x9e\x5a\x44\xe3\xf3\x46\x6a\x1f\x8a\x4c\xb4\xc0\x5d\x1f\x63\x4e\xfd\x10\x56\x2e\xbf\x3f\x86\xf0\x18\x30\x3c\x33\x3b\xb8\xa0\x89\x67\xa4\x56\xe3\x89\x51\xef\x0e\xc5\x6f\xe8\xa3\x8a\x31\x40\xbb\x96\x89\xa2\x68\xa4\x52\xc0\x71\x7d\x6e\xd8\x10\xb5\x8d\xa8\x95\xc8\xdb\x72\x5f\x67\x3b\xa1\x76\x37\x2b\x2e\x93\xbd\xb7\xba\x93\xcd\xdb\x65\xb6\x9f\xe3\xb5\xd9\x0e\xcb\xc4\x8b\x28\x2b\xb1\xa9\xe4\x9f\xcc\x30\x88\xd3\xfc\x6f\x1b\x30\x2c\xec\xfb\xb3\x7a\x53\x6e\x43\x87\x9b\xe4\x32\x07\xd7\x66\x07\xbe\x2d\xd3\x79\x93\xac\x0b\x17\x35\xfc\x1f\xe8\x53\x3f\x46\x75\x3a\xf8\x24\xce\xfe\xb5\x26\xc4\x89\x82\xf0\xda\x3e\xcf\xfa\xcc\x0f\xdf\x67\x46\xde\x01\x94\xfd\x44\x70\x70\x03\x00\x00")
+
+func migrations20240722000000CreateSignRequestsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations20240722000000CreateSignRequestsSql,
+		"migrations/20240722000000-create-sign-requests.sql",
+	)
+}
+
+func migrations20240722000000CreateSignRequestsSql() (*asset, error) {
+	bytes, err := migrations20240722000000CreateSignRequestsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/20240722000000-create-sign-requests.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x27, 0xb9, 0xc3, 0x30, 0x30, 0xfe, 0x1c, 0x9d, 0xb3, 0xa8, 0x4e, 0x7b, 0x1e, 0x66, 0x49, 0xd8, 0x82, 0xa1, 0x6e, 0x59, 0xe9, 0xf4, 0x34, 0xa6, 0xda, 0xd6, 0x26, 0x47, 0x93, 0x5e, 0xc9, 0xf7}}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -335,6 +356,7 @@ var _bindata = map[string]func() (*asset, error){
 	"migrations/20200320000000-create-accounts-audit.sql":     migrations20200320000000CreateAccountsAuditSql,
 	"migrations/20200320000001-create-identities-audit.sql":   migrations20200320000001CreateIdentitiesAuditSql,
 	"migrations/20200320000002-create-auth-methods-audit.sql": migrations20200320000002CreateAuthMethodsAuditSql,
+	"migrations/20240722000000-create-sign-requests.sql":      migrations20240722000000CreateSignRequestsSql,
 }
 
 // AssetDir returns the file names below a certain
@@ -389,6 +411,7 @@ var _bintree = &bintree{nil, map[string]*bintree{
 		"20200320000000-create-accounts-audit.sql":     {migrations20200320000000CreateAccountsAuditSql, map[string]*bintree{}},
 		"20200320000001-create-identities-audit.sql":   {migrations20200320000001CreateIdentitiesAuditSql, map[string]*bintree{}},
 		"20200320000002-create-auth-methods-audit.sql": {migrations20200320000002CreateAuthMethodsAuditSql, map[string]*bintree{}},
+		"20240722000000-create-sign-requests.sql":      {migrations20240722000000CreateSignRequestsSql, map[string]*bintree{}},
 	}},
 }}
 
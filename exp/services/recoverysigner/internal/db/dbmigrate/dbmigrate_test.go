@@ -157,6 +157,7 @@ func TestMigrate_upApplyAll(t *testing.T) {
 		"20200320000000-create-accounts-audit.sql",
 		"20200320000001-create-identities-audit.sql",
 		"20200320000002-create-auth-methods-audit.sql",
+		"20240722000000-create-sign-requests.sql",
 	}
 	assert.Equal(t, wantIDs, ids)
 }
@@ -186,6 +187,7 @@ func TestMigrate_upApplyNone(t *testing.T) {
 		"20200320000000-create-accounts-audit.sql",
 		"20200320000001-create-identities-audit.sql",
 		"20200320000002-create-auth-methods-audit.sql",
+		"20240722000000-create-sign-requests.sql",
 	}
 	assert.Equal(t, wantIDs, ids)
 }
@@ -0,0 +1,33 @@
+-- +migrate Up
+
+CREATE TYPE sign_request_status AS ENUM (
+  'pending',
+  'cancelled',
+  'signed'
+);
+
+CREATE TABLE sign_requests (
+  account_id BIGINT NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+  id BIGINT NOT NULL PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMP WITH TIME ZONE,
+
+  signing_address TEXT NOT NULL,
+  transaction_hash TEXT NOT NULL,
+  transaction TEXT NOT NULL,
+  requested_by TEXT NOT NULL,
+  status sign_request_status NOT NULL,
+  available_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  cancelled_at TIMESTAMP WITH TIME ZONE,
+  cancelled_by TEXT,
+  signed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX ON sign_requests (account_id, transaction_hash);
+CREATE INDEX ON sign_requests (account_id, signed_at);
+
+-- +migrate Down
+
+DROP TABLE sign_requests;
+DROP TYPE sign_request_status;
@@ -0,0 +1,129 @@
+// Package notify notifies the identities of an account of changes, such as
+// a transaction being requested to be signed, so that they can react to
+// activity they did not initiate.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/shantanu-hashcash/go/support/errors"
+	supportlog "github.com/shantanu-hashcash/go/support/log"
+)
+
+type EventType string
+
+const (
+	// EventSignRequested is a request to sign a transaction that is
+	// time-locked and may be cancelled until it becomes available.
+	EventSignRequested EventType = "sign_requested"
+	// EventSignRequestCancelled is a request to sign a transaction that has
+	// been cancelled.
+	EventSignRequestCancelled EventType = "sign_request_cancelled"
+	// EventSigned is a transaction that has been signed.
+	EventSigned EventType = "signed"
+	// EventSignRejected is a request to sign a transaction that has been
+	// rejected by the signing policy.
+	EventSignRejected EventType = "sign_rejected"
+)
+
+// Event is a change to an account.
+type Event struct {
+	Type            EventType       `json:"type"`
+	Address         string          `json:"address"`
+	Identities      []EventIdentity `json:"identities"`
+	SigningAddress  string          `json:"signing_address,omitempty"`
+	TransactionHash string          `json:"transaction_hash,omitempty"`
+	RequestID       int64           `json:"request_id,omitempty"`
+	AvailableAt     *time.Time      `json:"available_at,omitempty"`
+	// By describes who caused the event, e.g. the roles of the identities
+	// the client was authenticated as.
+	By     string    `json:"by,omitempty"`
+	Reason string    `json:"reason,omitempty"`
+	At     time.Time `json:"at"`
+}
+
+// EventIdentity is an identity of the account, with the auth methods that
+// can be used to contact it.
+type EventIdentity struct {
+	Role        string                    `json:"role"`
+	AuthMethods []EventIdentityAuthMethod `json:"auth_methods"`
+}
+
+type EventIdentityAuthMethod struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// Notifier notifies of an event.
+type Notifier interface {
+	Notify(ctx context.Context, e Event) error
+}
+
+// NotifierFunc is an adapter to allow the use of an ordinary function as a
+// Notifier.
+type NotifierFunc func(ctx context.Context, e Event) error
+
+func (f NotifierFunc) Notify(ctx context.Context, e Event) error {
+	return f(ctx, e)
+}
+
+// LogNotifier logs events.
+type LogNotifier struct {
+	Logger *supportlog.Entry
+}
+
+func (n LogNotifier) Notify(ctx context.Context, e Event) error {
+	n.Logger.Ctx(ctx).
+		WithField("event", string(e.Type)).
+		WithField("account", e.Address).
+		WithField("request_id", e.RequestID).
+		WithField("transaction_hash", e.TransactionHash).
+		Info("Notification.")
+	return nil
+}
+
+// WebhookNotifier notifies of events by posting them as JSON to a webhook.
+type WebhookNotifier struct {
+	URL    string
+	Client *http.Client
+}
+
+func (n WebhookNotifier) Notify(ctx context.Context, e Event) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return errors.Wrap(err, "encoding event")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.URL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "building webhook request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.Client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "sending webhook request")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.Errorf("unexpected status code %d from webhook", resp.StatusCode)
+	}
+	return nil
+}
+
+// Notifiers notifies all of the notifiers of an event, returning the first
+// error that occurs.
+type Notifiers []Notifier
+
+func (ns Notifiers) Notify(ctx context.Context, e Event) error {
+	var firstErr error
+	for _, n := range ns {
+		err := n.Notify(ctx, e)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
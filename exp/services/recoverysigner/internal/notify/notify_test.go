@@ -0,0 +1,78 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookNotifier(t *testing.T) {
+	got := map[string]interface{}{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+	}))
+	defer server.Close()
+
+	n := WebhookNotifier{URL: server.URL, Client: server.Client()}
+	at := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	err := n.Notify(context.Background(), Event{
+		Type:    EventSignRequested,
+		Address: "GCLLT3VG4F6EZAHZEBKWBWV5JGVPCVIKUCGTY3QEOAIZU5IJGMWCT2TT",
+		Identities: []EventIdentity{
+			{Role: "owner", AuthMethods: []EventIdentityAuthMethod{{Type: "email", Value: "user@example.com"}}},
+		},
+		RequestID:   1,
+		AvailableAt: &at,
+		By:          "owner",
+		At:          at,
+	})
+	require.NoError(t, err)
+
+	wantBody := `{
+		"type": "sign_requested",
+		"address": "GCLLT3VG4F6EZAHZEBKWBWV5JGVPCVIKUCGTY3QEOAIZU5IJGMWCT2TT",
+		"identities": [{"role": "owner", "auth_methods": [{"type": "email", "value": "user@example.com"}]}],
+		"request_id": 1,
+		"available_at": "2020-01-01T00:00:00Z",
+		"by": "owner",
+		"at": "2020-01-01T00:00:00Z"
+	}`
+	gotBody, err := json.Marshal(got)
+	require.NoError(t, err)
+	assert.JSONEq(t, wantBody, string(gotBody))
+}
+
+func TestWebhookNotifier_failure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	n := WebhookNotifier{URL: server.URL, Client: server.Client()}
+	err := n.Notify(context.Background(), Event{Type: EventSigned})
+	assert.EqualError(t, err, "unexpected status code 500 from webhook")
+}
+
+func TestNotifiers(t *testing.T) {
+	calls := 0
+	ok := NotifierFunc(func(ctx context.Context, e Event) error {
+		calls++
+		return nil
+	})
+	failing := NotifierFunc(func(ctx context.Context, e Event) error {
+		calls++
+		return errors.New("failed")
+	})
+
+	err := Notifiers{failing, ok}.Notify(context.Background(), Event{Type: EventSigned})
+	assert.EqualError(t, err, "failed")
+	assert.Equal(t, 2, calls)
+}
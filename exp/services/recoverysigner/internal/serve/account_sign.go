@@ -4,7 +4,9 @@ import (
 	"net/http"
 
 	"github.com/shantanu-hashcash/go/exp/services/recoverysigner/internal/account"
+	"github.com/shantanu-hashcash/go/exp/services/recoverysigner/internal/notify"
 	"github.com/shantanu-hashcash/go/exp/services/recoverysigner/internal/serve/auth"
+	"github.com/shantanu-hashcash/go/exp/services/recoverysigner/internal/signrequest"
 	"github.com/shantanu-hashcash/go/keypair"
 	"github.com/shantanu-hashcash/go/support/http/httpdecode"
 	supportlog "github.com/shantanu-hashcash/go/support/log"
@@ -18,6 +20,10 @@ type accountSignHandler struct {
 	NetworkPassphrase     string
 	AccountStore          account.Store
 	AllowedSourceAccounts []*keypair.FromAddress
+	HighValuePolicy       highValuePolicy
+	SignPolicy            signPolicy
+	SignRequestStore      signrequest.Store
+	Notifier              notify.Notifier
 }
 
 type accountSignRequest struct {
@@ -87,16 +93,10 @@ func (h accountSignHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	l.Infof("Authorized with self: %v.", authorized)
 
 	// Authorized if authenticated as an identity registered with the account.
-	for _, i := range acc.Identities {
-		for _, m := range i.AuthMethods {
-			if m.Value != "" && ((m.Type == account.AuthMethodTypeAddress && m.Value == claims.Address) ||
-				(m.Type == account.AuthMethodTypePhoneNumber && m.Value == claims.PhoneNumber) ||
-				(m.Type == account.AuthMethodTypeEmail && m.Value == claims.Email)) {
-				authorized = true
-				l.Infof("Authorized with %s.", m.Type)
-				break
-			}
-		}
+	identities := authorizedIdentities(acc, claims)
+	if len(identities) > 0 {
+		authorized = true
+		l.Infof("Authorized with identities: %v.", identities)
 	}
 
 	l.Infof("Authorized: %v.", authorized)
@@ -160,6 +160,45 @@ func (h accountSignHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Check that a high-value transaction is authorized by enough
+	// identities, unless authenticated as the account itself.
+	if claims.Address != req.Address.Address() && h.HighValuePolicy.isHighValue(tx) &&
+		len(identities) < h.HighValuePolicy.Identities {
+		l.Infof("High-value transaction authorized with %d identities but %d required.", len(identities), h.HighValuePolicy.Identities)
+		insufficientIdentities.Render(w)
+		return
+	}
+
+	// Check that the transaction only contains operations that are allowed.
+	requestedBy := describeAuthorization(claims.Address == req.Address.Address(), identities)
+	err = h.SignPolicy.checkOperations(tx)
+	if err != nil {
+		l.Infof("Transaction not allowed: %v.", err)
+		h.notify(ctx, l, acc, notify.Event{
+			Type:            notify.EventSignRejected,
+			SigningAddress:  signingKey.Address(),
+			TransactionHash: hashHex,
+			By:              requestedBy,
+			Reason:          err.Error(),
+		})
+		operationNotAllowed.Render(w)
+		return
+	}
+
+	// Check that the transaction is allowed to be signed now.
+	if h.SignPolicy.recorded() {
+		signRequest := signrequest.Request{
+			Address:         acc.Address,
+			SigningAddress:  signingKey.Address(),
+			TransactionHash: hashHex,
+			Transaction:     req.Transaction,
+			RequestedBy:     requestedBy,
+		}
+		if !h.requestSignature(w, r, l, acc, signRequest) {
+			return
+		}
+	}
+
 	// Sign the transaction.
 	hash, err := tx.Hash(h.NetworkPassphrase)
 	if err != nil {
@@ -176,6 +215,13 @@ func (h accountSignHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	l.Info("Transaction signed.")
 
+	h.notify(ctx, l, acc, notify.Event{
+		Type:            notify.EventSigned,
+		SigningAddress:  signingKey.Address(),
+		TransactionHash: hashHex,
+		By:              requestedBy,
+	})
+
 	resp := accountSignResponse{
 		Signature:         sig,
 		NetworkPassphrase: h.NetworkPassphrase,
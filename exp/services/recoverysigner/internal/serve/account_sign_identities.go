@@ -0,0 +1,97 @@
+package serve
+
+import (
+	"github.com/shantanu-hashcash/go/amount"
+	"github.com/shantanu-hashcash/go/exp/services/recoverysigner/internal/account"
+	"github.com/shantanu-hashcash/go/exp/services/recoverysigner/internal/serve/auth"
+	"github.com/shantanu-hashcash/go/txnbuild"
+)
+
+// authorizedIdentities returns the roles of the identities of the account
+// that the client is authenticated as.
+func authorizedIdentities(acc account.Account, claims auth.Auth) []string {
+	roles := []string{}
+	for _, i := range acc.Identities {
+		for _, m := range i.AuthMethods {
+			if m.Value != "" && ((m.Type == account.AuthMethodTypeAddress && m.Value == claims.Address) ||
+				(m.Type == account.AuthMethodTypePhoneNumber && m.Value == claims.PhoneNumber) ||
+				(m.Type == account.AuthMethodTypeEmail && m.Value == claims.Email)) {
+				roles = append(roles, i.Role)
+				break
+			}
+		}
+	}
+	return roles
+}
+
+// highValuePolicy defines the transactions that are high-value and must be
+// authorized by more than one identity of the account.
+type highValuePolicy struct {
+	// Identities is the number of identities that must authorize a
+	// high-value transaction. Transactions are not high-value if it is one
+	// or less.
+	Identities int
+	// Amount is the amount in stroops, of any asset, that a transaction
+	// moving it in a single operation is high-value. Amounts are not
+	// considered if it is zero.
+	Amount int64
+}
+
+// isHighValue returns true if the transaction has any operation that is not
+// low-risk.
+func (p highValuePolicy) isHighValue(tx *txnbuild.Transaction) bool {
+	if p.Identities <= 1 {
+		return false
+	}
+	for _, op := range tx.Operations() {
+		if !p.isLowRisk(op) {
+			return true
+		}
+	}
+	return false
+}
+
+// isLowRisk returns true if the operation neither changes who controls the
+// account nor can move the high-value amount. Operations are high-value
+// unless listed here, offers for instance can give away any amount at a
+// dust price.
+func (p highValuePolicy) isLowRisk(op txnbuild.Operation) bool {
+	switch op := op.(type) {
+	case *txnbuild.Payment:
+		return !p.exceedsAmount(op.Amount)
+	case *txnbuild.CreateAccount:
+		return !p.exceedsAmount(op.Amount)
+	case *txnbuild.PathPaymentStrictSend:
+		return !p.exceedsAmount(op.SendAmount)
+	case *txnbuild.PathPaymentStrictReceive:
+		return !p.exceedsAmount(op.SendMax)
+	case *txnbuild.SetOptions:
+		// Only the home domain and the inflation destination.
+		return op.Signer == nil && op.MasterWeight == nil && op.LowThreshold == nil &&
+			op.MediumThreshold == nil && op.HighThreshold == nil &&
+			len(op.SetFlags) == 0 && len(op.ClearFlags) == 0
+	case *txnbuild.ManageSellOffer:
+		// Deleting an offer.
+		return isZeroAmount(op.Amount)
+	case *txnbuild.ManageBuyOffer:
+		return isZeroAmount(op.Amount)
+	case *txnbuild.ChangeTrust, *txnbuild.ManageData:
+		return true
+	default:
+		return false
+	}
+}
+
+func isZeroAmount(a string) bool {
+	stroops, err := amount.ParseInt64(a)
+	return err == nil && stroops == 0
+}
+
+func (p highValuePolicy) exceedsAmount(a string) bool {
+	if p.Amount == 0 {
+		return false
+	}
+	stroops, err := amount.ParseInt64(a)
+	// An amount that cannot be parsed is treated as high-value.
+	return err != nil || stroops >= p.Amount
+}
@@ -0,0 +1,94 @@
+package serve
+
+import (
+	"testing"
+
+	"github.com/shantanu-hashcash/go/exp/services/recoverysigner/internal/account"
+	"github.com/shantanu-hashcash/go/exp/services/recoverysigner/internal/serve/auth"
+	"github.com/shantanu-hashcash/go/txnbuild"
+	"github.com/shantanu-hashcash/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthorizedIdentities(t *testing.T) {
+	acc := account.Account{
+		Address: "GA6HNE7O2N2IXIOBZNZ4IPTS2P6DSAJJF5GD5PDLH5GYOZ6WMPSKCXD4",
+		Identities: []account.Identity{
+			{
+				Role: "owner",
+				AuthMethods: []account.AuthMethod{
+					{Type: account.AuthMethodTypeEmail, Value: "owner@example.com"},
+					{Type: account.AuthMethodTypePhoneNumber, Value: "+10000000000"},
+				},
+			},
+			{
+				Role: "sender",
+				AuthMethods: []account.AuthMethod{
+					{Type: account.AuthMethodTypeAddress, Value: "GBLOP46WEVXWO5N75TDX7GXLYFQE3XLDT5NQ2VYIBEWWEMSZWR3AUISZ"},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name   string
+		claims auth.Auth
+		want   []string
+	}{
+		{"none", auth.Auth{Email: "other@example.com"}, []string{}},
+		{"one identity by one auth method", auth.Auth{Email: "owner@example.com"}, []string{"owner"}},
+		{"one identity by two auth methods", auth.Auth{Email: "owner@example.com", PhoneNumber: "+10000000000"}, []string{"owner"}},
+		{"two identities", auth.Auth{Address: "GBLOP46WEVXWO5N75TDX7GXLYFQE3XLDT5NQ2VYIBEWWEMSZWR3AUISZ", PhoneNumber: "+10000000000"}, []string{"owner", "sender"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, authorizedIdentities(acc, tc.claims))
+		})
+	}
+}
+
+func TestHighValuePolicy_isHighValue(t *testing.T) {
+	const address = "GA6HNE7O2N2IXIOBZNZ4IPTS2P6DSAJJF5GD5PDLH5GYOZ6WMPSKCXD4"
+	const other = "GBLOP46WEVXWO5N75TDX7GXLYFQE3XLDT5NQ2VYIBEWWEMSZWR3AUISZ"
+	policy := highValuePolicy{Identities: 2, Amount: 1000_0000000}
+	sponsored := other
+
+	testCases := []struct {
+		name   string
+		policy highValuePolicy
+		op     txnbuild.Operation
+		want   bool
+	}{
+		{"add signer", policy, &txnbuild.SetOptions{Signer: &txnbuild.Signer{Address: other, Weight: 1}}, true},
+		{"set thresholds", policy, &txnbuild.SetOptions{HighThreshold: txnbuild.NewThreshold(2)}, true},
+		{"set home domain", policy, &txnbuild.SetOptions{HomeDomain: txnbuild.NewHomeDomain("example.com")}, false},
+		{"merge", policy, &txnbuild.AccountMerge{Destination: other}, true},
+		{"small payment", policy, &txnbuild.Payment{Destination: other, Amount: "999.9999999", Asset: txnbuild.NativeAsset{}}, false},
+		{"large payment", policy, &txnbuild.Payment{Destination: other, Amount: "1000", Asset: txnbuild.NativeAsset{}}, true},
+		{"large create account", policy, &txnbuild.CreateAccount{Destination: other, Amount: "5000"}, true},
+		{"amounts not considered", highValuePolicy{Identities: 2}, &txnbuild.Payment{Destination: other, Amount: "5000", Asset: txnbuild.NativeAsset{}}, false},
+		{"set flags", policy, &txnbuild.SetOptions{SetFlags: []txnbuild.AccountFlag{txnbuild.AuthClawbackEnabled}}, true},
+		{"change trust", policy, &txnbuild.ChangeTrust{Line: txnbuild.CreditAsset{Code: "USD", Issuer: other}.MustToChangeTrustAsset()}, false},
+		{"offer at a dust price", policy, &txnbuild.ManageSellOffer{Selling: txnbuild.NativeAsset{}, Buying: txnbuild.CreditAsset{Code: "USD", Issuer: other}, Amount: "1", Price: xdr.Price{N: 1, D: 1000000}}, true},
+		{"delete offer", policy, &txnbuild.ManageSellOffer{Selling: txnbuild.NativeAsset{}, Buying: txnbuild.CreditAsset{Code: "USD", Issuer: other}, Amount: "0", Price: xdr.Price{N: 1, D: 1}, OfferID: 1}, false},
+		{"passive offer", policy, &txnbuild.CreatePassiveSellOffer{Selling: txnbuild.NativeAsset{}, Buying: txnbuild.CreditAsset{Code: "USD", Issuer: other}, Amount: "1", Price: xdr.Price{N: 1, D: 1}}, true},
+		{"claimable balance", policy, &txnbuild.CreateClaimableBalance{Amount: "1", Asset: txnbuild.NativeAsset{}, Destinations: []txnbuild.Claimant{txnbuild.NewClaimant(other, nil)}}, true},
+		{"revoke sponsorship", policy, &txnbuild.RevokeSponsorship{SponsorshipType: txnbuild.RevokeSponsorshipTypeAccount, Account: &sponsored}, true},
+		{"bump sequence", policy, &txnbuild.BumpSequence{BumpTo: 100}, true},
+		{"one identity required", highValuePolicy{Identities: 1}, &txnbuild.AccountMerge{Destination: other}, false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+				SourceAccount:        &txnbuild.SimpleAccount{AccountID: address, Sequence: 1},
+				IncrementSequenceNum: true,
+				Operations:           []txnbuild.Operation{tc.op},
+				BaseFee:              txnbuild.MinBaseFee,
+				Preconditions:        txnbuild.Preconditions{TimeBounds: txnbuild.NewInfiniteTimeout()},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, tc.policy.isHighValue(tx))
+		})
+	}
+}
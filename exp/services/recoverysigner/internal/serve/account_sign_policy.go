@@ -0,0 +1,109 @@
+package serve
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shantanu-hashcash/go/exp/services/recoverysigner/internal/signrequest"
+	"github.com/shantanu-hashcash/go/protocols/aurora/operations"
+	"github.com/shantanu-hashcash/go/support/errors"
+	"github.com/shantanu-hashcash/go/txnbuild"
+)
+
+// operationSetOptionsAddSigner is the name of a set_options operation that
+// only adds or updates a signer, which is all that is required to recover an
+// account.
+const operationSetOptionsAddSigner = "set_options_add_signer"
+
+// signPolicy defines the checks made before a transaction is signed.
+type signPolicy struct {
+	// AllowedOperations are the names of the operations, as named by Aurora,
+	// that transactions may contain. All operations are allowed if empty.
+	AllowedOperations map[string]bool
+	// MaxSignatures is the maximum number of transactions signed for an
+	// account within SignaturesWindow. There is no maximum if it is zero.
+	MaxSignatures    int
+	SignaturesWindow time.Duration
+	// Timelock is the time a request to sign a transaction must wait, during
+	// which it can be cancelled by any identity of the account. Transactions
+	// are signed immediately if it is zero.
+	Timelock time.Duration
+}
+
+// parseAllowedOperations parses a comma separated list of operation names.
+func parseAllowedOperations(s string) (map[string]bool, error) {
+	if s == "" {
+		return nil, nil
+	}
+	names := map[string]bool{operationSetOptionsAddSigner: true}
+	for _, name := range operations.TypeNames {
+		names[name] = true
+	}
+	allowed := map[string]bool{}
+	for _, name := range strings.Split(s, ",") {
+		name = strings.TrimSpace(name)
+		if !names[name] {
+			return nil, errors.Errorf("operation %q unrecognized", name)
+		}
+		allowed[name] = true
+	}
+	return allowed, nil
+}
+
+// checkOperations returns an error if the transaction contains an operation
+// that is not allowed.
+func (p signPolicy) checkOperations(tx *txnbuild.Transaction) error {
+	if len(p.AllowedOperations) == 0 {
+		return nil
+	}
+	for i, op := range tx.Operations() {
+		xdrOp, err := op.BuildXDR()
+		if err != nil {
+			return errors.Wrapf(err, "operation %d invalid", i)
+		}
+		name := operations.TypeNames[xdrOp.Body.Type]
+		if p.AllowedOperations[name] {
+			continue
+		}
+		if setOptions, ok := op.(*txnbuild.SetOptions); ok && p.AllowedOperations[operationSetOptionsAddSigner] && isAddSigner(setOptions) {
+			continue
+		}
+		return errors.Errorf("operation %d %s not allowed", i, name)
+	}
+	return nil
+}
+
+// isAddSigner returns true if the set_options operation only adds or updates
+// a signer.
+func isAddSigner(op *txnbuild.SetOptions) bool {
+	return op.Signer != nil && op.Signer.Weight > 0 &&
+		op.InflationDestination == nil && len(op.SetFlags) == 0 && len(op.ClearFlags) == 0 &&
+		op.MasterWeight == nil && op.LowThreshold == nil && op.MediumThreshold == nil &&
+		op.HighThreshold == nil && op.HomeDomain == nil
+}
+
+// recorded returns true if the policy requires requests to sign to be
+// recorded.
+func (p signPolicy) recorded() bool {
+	return p.MaxSignatures > 0 || p.Timelock > 0
+}
+
+// limit returns the limit on the number of transactions signed at the time.
+func (p signPolicy) limit(now time.Time) signrequest.Limit {
+	return signrequest.Limit{
+		Max:   p.MaxSignatures,
+		Since: now.Add(-p.SignaturesWindow),
+	}
+}
+
+// describeAuthorization describes who a client is authorized as, to record
+// who requested or cancelled a request to sign.
+func describeAuthorization(self bool, roles []string) string {
+	if self {
+		return "account"
+	}
+	roles = append([]string(nil), roles...)
+	sort.Strings(roles)
+	return strings.Join(roles, ",")
+}
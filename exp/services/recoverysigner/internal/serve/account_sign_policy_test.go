@@ -0,0 +1,111 @@
+package serve
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shantanu-hashcash/go/exp/services/recoverysigner/internal/signrequest"
+	"github.com/shantanu-hashcash/go/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAllowedOperations(t *testing.T) {
+	allowed, err := parseAllowedOperations("")
+	require.NoError(t, err)
+	assert.Nil(t, allowed)
+
+	allowed, err = parseAllowedOperations("set_options_add_signer, bump_sequence")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"set_options_add_signer": true, "bump_sequence": true}, allowed)
+
+	_, err = parseAllowedOperations("set_options,unknown")
+	assert.EqualError(t, err, `operation "unknown" unrecognized`)
+}
+
+func TestSignPolicy_checkOperations(t *testing.T) {
+	const address = "GA6HNE7O2N2IXIOBZNZ4IPTS2P6DSAJJF5GD5PDLH5GYOZ6WMPSKCXD4"
+	const other = "GBLOP46WEVXWO5N75TDX7GXLYFQE3XLDT5NQ2VYIBEWWEMSZWR3AUISZ"
+	addSignerOnly := signPolicy{AllowedOperations: map[string]bool{"set_options_add_signer": true}}
+
+	testCases := []struct {
+		name    string
+		policy  signPolicy
+		ops     []txnbuild.Operation
+		wantErr string
+	}{
+		{
+			name:   "all allowed",
+			policy: signPolicy{},
+			ops:    []txnbuild.Operation{&txnbuild.AccountMerge{Destination: other}},
+		},
+		{
+			name:   "add signer",
+			policy: addSignerOnly,
+			ops:    []txnbuild.Operation{&txnbuild.SetOptions{Signer: &txnbuild.Signer{Address: other, Weight: 10}}},
+		},
+		{
+			name:    "remove signer",
+			policy:  addSignerOnly,
+			ops:     []txnbuild.Operation{&txnbuild.SetOptions{Signer: &txnbuild.Signer{Address: other, Weight: 0}}},
+			wantErr: "operation 0 set_options not allowed",
+		},
+		{
+			name:   "add signer and set master weight",
+			policy: addSignerOnly,
+			ops: []txnbuild.Operation{&txnbuild.SetOptions{
+				Signer:       &txnbuild.Signer{Address: other, Weight: 10},
+				MasterWeight: txnbuild.NewThreshold(0),
+			}},
+			wantErr: "operation 0 set_options not allowed",
+		},
+		{
+			name:   "payment after add signer",
+			policy: addSignerOnly,
+			ops: []txnbuild.Operation{
+				&txnbuild.SetOptions{Signer: &txnbuild.Signer{Address: other, Weight: 10}},
+				&txnbuild.Payment{Destination: other, Amount: "1", Asset: txnbuild.NativeAsset{}},
+			},
+			wantErr: "operation 1 payment not allowed",
+		},
+		{
+			name:   "set options allowed",
+			policy: signPolicy{AllowedOperations: map[string]bool{"set_options": true}},
+			ops:    []txnbuild.Operation{&txnbuild.SetOptions{MasterWeight: txnbuild.NewThreshold(0)}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+				SourceAccount:        &txnbuild.SimpleAccount{AccountID: address, Sequence: 1},
+				IncrementSequenceNum: true,
+				Operations:           tc.ops,
+				BaseFee:              txnbuild.MinBaseFee,
+				Preconditions:        txnbuild.Preconditions{TimeBounds: txnbuild.NewInfiniteTimeout()},
+			})
+			require.NoError(t, err)
+
+			err = tc.policy.checkOperations(tx)
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestSignPolicy_limit(t *testing.T) {
+	now := time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)
+	p := signPolicy{MaxSignatures: 3, SignaturesWindow: 24 * time.Hour}
+	assert.True(t, p.recorded())
+	assert.Equal(t, signrequest.Limit{Max: 3, Since: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}, p.limit(now))
+
+	assert.False(t, signPolicy{}.recorded())
+	assert.True(t, signPolicy{Timelock: time.Hour}.recorded())
+}
+
+func TestDescribeAuthorization(t *testing.T) {
+	assert.Equal(t, "account", describeAuthorization(true, []string{"owner"}))
+	assert.Equal(t, "owner,sender", describeAuthorization(false, []string{"sender", "owner"}))
+}
@@ -0,0 +1,157 @@
+package serve
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/shantanu-hashcash/go/exp/services/recoverysigner/internal/account"
+	"github.com/shantanu-hashcash/go/exp/services/recoverysigner/internal/notify"
+	"github.com/shantanu-hashcash/go/exp/services/recoverysigner/internal/signrequest"
+	supportlog "github.com/shantanu-hashcash/go/support/log"
+	"github.com/shantanu-hashcash/go/support/render/httpjson"
+)
+
+type accountSignPendingResponse struct {
+	Status      string    `json:"status"`
+	ID          int64     `json:"id"`
+	AvailableAt time.Time `json:"available_at"`
+}
+
+// requestSignature records the request to sign the transaction and returns
+// true if the transaction can be signed now. If it cannot it renders the
+// response and returns false.
+//
+// Without a timelock the transaction can be signed immediately, unless the
+// maximum number of signatures has been reached. With a timelock the first
+// request to sign the transaction is recorded as pending, and the
+// transaction can be signed when it is requested again after the timelock,
+// unless the request has been cancelled in the meantime.
+func (h accountSignHandler) requestSignature(w http.ResponseWriter, r *http.Request, l *supportlog.Entry, acc account.Account, signRequest signrequest.Request) bool {
+	ctx := r.Context()
+	now := time.Now()
+	limit := h.SignPolicy.limit(now)
+
+	if h.SignPolicy.Timelock == 0 {
+		signRequest.Status = signrequest.StatusSigned
+		signRequest.AvailableAt = now
+		_, err := h.SignRequestStore.Add(signRequest, limit)
+		return h.handleSignError(w, r, l, acc, signRequest, err)
+	}
+
+	existing, err := h.SignRequestStore.FindWithTransactionHash(acc.Address, signRequest.SigningAddress, signRequest.TransactionHash)
+	if err == signrequest.ErrNotFound {
+		signRequest.Status = signrequest.StatusPending
+		signRequest.AvailableAt = now.Add(h.SignPolicy.Timelock)
+		added, err := h.SignRequestStore.Add(signRequest, limit)
+		if err != nil {
+			l.Error(err)
+			serverError.Render(w)
+			return false
+		}
+		l.WithField("sign_request_id", added.ID).
+			Infof("Request to sign time-locked until %s.", added.AvailableAt)
+		h.notify(ctx, l, acc, notify.Event{
+			Type:            notify.EventSignRequested,
+			SigningAddress:  added.SigningAddress,
+			TransactionHash: added.TransactionHash,
+			RequestID:       added.ID,
+			AvailableAt:     &added.AvailableAt,
+			By:              added.RequestedBy,
+		})
+		renderSignPending(w, added)
+		return false
+	} else if err != nil {
+		l.Error(err)
+		serverError.Render(w)
+		return false
+	}
+
+	l = l.WithField("sign_request_id", existing.ID)
+	switch existing.Status {
+	case signrequest.StatusSigned:
+		l.Info("Request to sign already signed.")
+		return true
+	case signrequest.StatusCancelled:
+		l.Info("Request to sign cancelled.")
+		signRequestCancelled.Render(w)
+		return false
+	}
+
+	if now.Before(existing.AvailableAt) {
+		l.Infof("Request to sign time-locked until %s.", existing.AvailableAt)
+		renderSignPending(w, existing)
+		return false
+	}
+
+	_, err = h.SignRequestStore.Sign(acc.Address, existing.ID, limit)
+	if err == signrequest.ErrNotPending {
+		// The request was cancelled or signed concurrently.
+		existing, err = h.SignRequestStore.Get(acc.Address, existing.ID)
+		if err == nil && existing.Status != signrequest.StatusSigned {
+			l.Info("Request to sign cancelled.")
+			signRequestCancelled.Render(w)
+			return false
+		}
+	}
+	return h.handleSignError(w, r, l, acc, signRequest, err)
+}
+
+func (h accountSignHandler) handleSignError(w http.ResponseWriter, r *http.Request, l *supportlog.Entry, acc account.Account, signRequest signrequest.Request, err error) bool {
+	switch err {
+	case nil:
+		return true
+	case signrequest.ErrLimitReached:
+		l.Info("Maximum number of signatures reached.")
+		h.notify(r.Context(), l, acc, notify.Event{
+			Type:            notify.EventSignRejected,
+			SigningAddress:  signRequest.SigningAddress,
+			TransactionHash: signRequest.TransactionHash,
+			By:              signRequest.RequestedBy,
+			Reason:          err.Error(),
+		})
+		signLimitReached.Render(w)
+	default:
+		l.Error(err)
+		serverError.Render(w)
+	}
+	return false
+}
+
+// notify notifies of the event, filling in the details of the account. An
+// error notifying is logged and otherwise ignored.
+func (h accountSignHandler) notify(ctx context.Context, l *supportlog.Entry, acc account.Account, e notify.Event) {
+	notifyAccountEvent(ctx, h.Notifier, l, acc, e)
+}
+
+func notifyAccountEvent(ctx context.Context, n notify.Notifier, l *supportlog.Entry, acc account.Account, e notify.Event) {
+	if n == nil {
+		return
+	}
+	e.Address = acc.Address
+	e.At = time.Now()
+	for _, i := range acc.Identities {
+		ei := notify.EventIdentity{Role: i.Role}
+		for _, m := range i.AuthMethods {
+			ei.AuthMethods = append(ei.AuthMethods, notify.EventIdentityAuthMethod{
+				Type:  string(m.Type),
+				Value: m.Value,
+			})
+		}
+		e.Identities = append(e.Identities, ei)
+	}
+	err := n.Notify(ctx, e)
+	if err != nil {
+		l.WithField("event", string(e.Type)).
+			Warn("Error notifying: ", err)
+	}
+}
+
+func renderSignPending(w http.ResponseWriter, r signrequest.Request) {
+	resp := accountSignPendingResponse{
+		Status:      string(r.Status),
+		ID:          r.ID,
+		AvailableAt: r.AvailableAt,
+	}
+	httpjson.RenderStatus(w, http.StatusAccepted, resp, httpjson.JSON)
+}
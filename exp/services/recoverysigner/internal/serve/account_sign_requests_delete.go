@@ -0,0 +1,101 @@
+package serve
+
+import (
+	"net/http"
+
+	"github.com/shantanu-hashcash/go/exp/services/recoverysigner/internal/account"
+	"github.com/shantanu-hashcash/go/exp/services/recoverysigner/internal/notify"
+	"github.com/shantanu-hashcash/go/exp/services/recoverysigner/internal/serve/auth"
+	"github.com/shantanu-hashcash/go/exp/services/recoverysigner/internal/signrequest"
+	"github.com/shantanu-hashcash/go/keypair"
+	"github.com/shantanu-hashcash/go/support/http/httpdecode"
+	supportlog "github.com/shantanu-hashcash/go/support/log"
+	"github.com/shantanu-hashcash/go/support/render/httpjson"
+)
+
+// accountSignRequestsDeleteHandler cancels a pending request to sign a
+// transaction. Any identity of the account can cancel a request, so that a
+// request made by someone who has gained access to one identity can be
+// stopped by the others during the timelock.
+type accountSignRequestsDeleteHandler struct {
+	Logger           *supportlog.Entry
+	AccountStore     account.Store
+	SignRequestStore signrequest.Store
+	Notifier         notify.Notifier
+}
+
+type accountSignRequestsDeleteRequest struct {
+	Address *keypair.FromAddress `path:"address"`
+	ID      int64                `path:"id"`
+}
+
+func (h accountSignRequestsDeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	claims, _ := auth.FromContext(ctx)
+	if claims.Address == "" && claims.PhoneNumber == "" && claims.Email == "" {
+		unauthorized.Render(w)
+		return
+	}
+
+	req := accountSignRequestsDeleteRequest{}
+	err := httpdecode.Decode(r, &req)
+	if err != nil || req.Address == nil || req.ID == 0 {
+		badRequest.Render(w)
+		return
+	}
+
+	l := h.Logger.Ctx(ctx).
+		WithField("account", req.Address.Address()).
+		WithField("sign_request_id", req.ID)
+
+	l.Info("Request to cancel sign request.")
+
+	acc, err := h.AccountStore.Get(req.Address.Address())
+	if err == account.ErrNotFound {
+		l.Info("Account not found.")
+		notFound.Render(w)
+		return
+	} else if err != nil {
+		l.Error(err)
+		serverError.Render(w)
+		return
+	}
+
+	self := claims.Address == req.Address.Address()
+	identities := authorizedIdentities(acc, claims)
+	authorized := self || len(identities) > 0
+	l.Infof("Authorized: %v.", authorized)
+	if !authorized {
+		notFound.Render(w)
+		return
+	}
+
+	cancelledBy := describeAuthorization(self, identities)
+	cancelled, err := h.SignRequestStore.Cancel(acc.Address, req.ID, cancelledBy)
+	if err == signrequest.ErrNotFound {
+		l.Info("Sign request not found.")
+		notFound.Render(w)
+		return
+	} else if err == signrequest.ErrNotPending {
+		l.Info("Sign request not pending.")
+		conflict.Render(w)
+		return
+	} else if err != nil {
+		l.Error(err)
+		serverError.Render(w)
+		return
+	}
+
+	l.Info("Sign request cancelled.")
+
+	notifyAccountEvent(ctx, h.Notifier, l, acc, notify.Event{
+		Type:            notify.EventSignRequestCancelled,
+		SigningAddress:  cancelled.SigningAddress,
+		TransactionHash: cancelled.TransactionHash,
+		RequestID:       cancelled.ID,
+		By:              cancelledBy,
+	})
+
+	httpjson.Render(w, newSignRequestResponse(cancelled), httpjson.JSON)
+}
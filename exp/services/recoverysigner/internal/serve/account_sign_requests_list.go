@@ -0,0 +1,115 @@
+package serve
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/shantanu-hashcash/go/exp/services/recoverysigner/internal/account"
+	"github.com/shantanu-hashcash/go/exp/services/recoverysigner/internal/serve/auth"
+	"github.com/shantanu-hashcash/go/exp/services/recoverysigner/internal/signrequest"
+	"github.com/shantanu-hashcash/go/keypair"
+	"github.com/shantanu-hashcash/go/support/http/httpdecode"
+	supportlog "github.com/shantanu-hashcash/go/support/log"
+	"github.com/shantanu-hashcash/go/support/render/httpjson"
+)
+
+type accountSignRequestsListHandler struct {
+	Logger           *supportlog.Entry
+	AccountStore     account.Store
+	SignRequestStore signrequest.Store
+}
+
+type accountSignRequestsListRequest struct {
+	Address *keypair.FromAddress `path:"address"`
+}
+
+type signRequestResponse struct {
+	ID              int64      `json:"id"`
+	Status          string     `json:"status"`
+	SigningAddress  string     `json:"signing_address"`
+	TransactionHash string     `json:"transaction_hash"`
+	Transaction     string     `json:"transaction"`
+	RequestedBy     string     `json:"requested_by"`
+	CreatedAt       time.Time  `json:"created_at"`
+	AvailableAt     time.Time  `json:"available_at"`
+	CancelledAt     *time.Time `json:"cancelled_at,omitempty"`
+	CancelledBy     string     `json:"cancelled_by,omitempty"`
+	SignedAt        *time.Time `json:"signed_at,omitempty"`
+}
+
+func newSignRequestResponse(r signrequest.Request) signRequestResponse {
+	return signRequestResponse{
+		ID:              r.ID,
+		Status:          string(r.Status),
+		SigningAddress:  r.SigningAddress,
+		TransactionHash: r.TransactionHash,
+		Transaction:     r.Transaction,
+		RequestedBy:     r.RequestedBy,
+		CreatedAt:       r.CreatedAt,
+		AvailableAt:     r.AvailableAt,
+		CancelledAt:     r.CancelledAt,
+		CancelledBy:     r.CancelledBy,
+		SignedAt:        r.SignedAt,
+	}
+}
+
+type accountSignRequestsListResponse struct {
+	SignRequests []signRequestResponse `json:"sign_requests"`
+}
+
+func (h accountSignRequestsListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	claims, _ := auth.FromContext(ctx)
+	if claims.Address == "" && claims.PhoneNumber == "" && claims.Email == "" {
+		unauthorized.Render(w)
+		return
+	}
+
+	req := accountSignRequestsListRequest{}
+	err := httpdecode.Decode(r, &req)
+	if err != nil || req.Address == nil {
+		badRequest.Render(w)
+		return
+	}
+
+	l := h.Logger.Ctx(ctx).
+		WithField("account", req.Address.Address())
+
+	l.Info("Request to list pending sign requests.")
+
+	acc, err := h.AccountStore.Get(req.Address.Address())
+	if err == account.ErrNotFound {
+		l.Info("Account not found.")
+		notFound.Render(w)
+		return
+	} else if err != nil {
+		l.Error(err)
+		serverError.Render(w)
+		return
+	}
+
+	// Authorized if authenticated as the account or as an identity
+	// registered with the account.
+	authorized := claims.Address == req.Address.Address() || len(authorizedIdentities(acc, claims)) > 0
+	l.Infof("Authorized: %v.", authorized)
+	if !authorized {
+		notFound.Render(w)
+		return
+	}
+
+	requests, err := h.SignRequestStore.ListPending(acc.Address)
+	if err != nil {
+		l.Error(err)
+		serverError.Render(w)
+		return
+	}
+
+	resp := accountSignRequestsListResponse{
+		SignRequests: []signRequestResponse{},
+	}
+	for _, sr := range requests {
+		resp.SignRequests = append(resp.SignRequests, newSignRequestResponse(sr))
+	}
+	httpjson.Render(w, resp, httpjson.JSON)
+}
@@ -0,0 +1,226 @@
+package serve
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/shantanu-hashcash/go/exp/services/recoverysigner/internal/account"
+	"github.com/shantanu-hashcash/go/exp/services/recoverysigner/internal/db/dbtest"
+	"github.com/shantanu-hashcash/go/exp/services/recoverysigner/internal/notify"
+	"github.com/shantanu-hashcash/go/exp/services/recoverysigner/internal/serve/auth"
+	"github.com/shantanu-hashcash/go/exp/services/recoverysigner/internal/signrequest"
+	"github.com/shantanu-hashcash/go/keypair"
+	"github.com/shantanu-hashcash/go/network"
+	supportlog "github.com/shantanu-hashcash/go/support/log"
+	"github.com/shantanu-hashcash/go/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type signPolicyTest struct {
+	AccountStore     *account.DBStore
+	SignRequestStore *signrequest.DBStore
+	Handler          http.Handler
+	Events           []notify.Event
+}
+
+func newSignPolicyTest(t *testing.T, policy signPolicy) *signPolicyTest {
+	session := dbtest.Open(t).Open()
+	test := &signPolicyTest{
+		AccountStore:     &account.DBStore{DB: session},
+		SignRequestStore: &signrequest.DBStore{DB: session},
+	}
+	err := test.AccountStore.Add(account.Account{
+		Address: "GA6HNE7O2N2IXIOBZNZ4IPTS2P6DSAJJF5GD5PDLH5GYOZ6WMPSKCXD4",
+		Identities: []account.Identity{
+			{
+				Role: "sender",
+				AuthMethods: []account.AuthMethod{
+					{Type: account.AuthMethodTypeEmail, Value: "sender@example.com"},
+				},
+			},
+			{
+				Role: "receiver",
+				AuthMethods: []account.AuthMethod{
+					{Type: account.AuthMethodTypeEmail, Value: "receiver@example.com"},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	notifier := notify.NotifierFunc(func(ctx context.Context, e notify.Event) error {
+		test.Events = append(test.Events, e)
+		return nil
+	})
+	m := chi.NewMux()
+	m.Post("/{address}/sign/{signing-address}", accountSignHandler{
+		Logger:       supportlog.DefaultLogger,
+		AccountStore: test.AccountStore,
+		SigningKeys: []*keypair.Full{
+			keypair.MustParseFull("SBIB72S6JMTGJRC6LMKLC5XMHZ2IOHZSZH4SASTN47LECEEJ7QEB6EYK"), // GBOG4KF66M4AFRBUHOTJQJRO7BGGFCSGIICTI5BHXHKXCWV2C67QRN5H
+		},
+		NetworkPassphrase: network.TestNetworkPassphrase,
+		SignPolicy:        policy,
+		SignRequestStore:  test.SignRequestStore,
+		Notifier:          notifier,
+	}.ServeHTTP)
+	m.Get("/{address}/sign-requests", accountSignRequestsListHandler{
+		Logger:           supportlog.DefaultLogger,
+		AccountStore:     test.AccountStore,
+		SignRequestStore: test.SignRequestStore,
+	}.ServeHTTP)
+	m.Delete("/{address}/sign-requests/{id}", accountSignRequestsDeleteHandler{
+		Logger:           supportlog.DefaultLogger,
+		AccountStore:     test.AccountStore,
+		SignRequestStore: test.SignRequestStore,
+		Notifier:         notifier,
+	}.ServeHTTP)
+	test.Handler = m
+	return test
+}
+
+func (test *signPolicyTest) Do(method, path, body, email string) *http.Response {
+	ctx := auth.NewContext(context.Background(), auth.Auth{Email: email})
+	r := httptest.NewRequest(method, path, strings.NewReader(body))
+	r = r.WithContext(ctx)
+	w := httptest.NewRecorder()
+	test.Handler.ServeHTTP(w, r)
+	return w.Result()
+}
+
+func (test *signPolicyTest) Sign(t *testing.T, ops ...txnbuild.Operation) *http.Response {
+	tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount:        &txnbuild.SimpleAccount{AccountID: "GA6HNE7O2N2IXIOBZNZ4IPTS2P6DSAJJF5GD5PDLH5GYOZ6WMPSKCXD4", Sequence: 1},
+		IncrementSequenceNum: true,
+		Operations:           ops,
+		BaseFee:              txnbuild.MinBaseFee,
+		Preconditions:        txnbuild.Preconditions{TimeBounds: txnbuild.NewInfiniteTimeout()},
+	})
+	require.NoError(t, err)
+	txEnc, err := tx.Base64()
+	require.NoError(t, err)
+	return test.Do("POST", "/GA6HNE7O2N2IXIOBZNZ4IPTS2P6DSAJJF5GD5PDLH5GYOZ6WMPSKCXD4/sign/GBOG4KF66M4AFRBUHOTJQJRO7BGGFCSGIICTI5BHXHKXCWV2C67QRN5H", `{"transaction": "`+txEnc+`"}`, "sender@example.com")
+}
+
+var addSignerOp = &txnbuild.SetOptions{
+	Signer: &txnbuild.Signer{Address: "GBLOP46WEVXWO5N75TDX7GXLYFQE3XLDT5NQ2VYIBEWWEMSZWR3AUISZ", Weight: 10},
+}
+
+// Test that a transaction containing an operation that is not allowed is not
+// signed.
+func TestAccountSign_operationNotAllowed(t *testing.T) {
+	test := newSignPolicyTest(t, signPolicy{AllowedOperations: map[string]bool{"set_options_add_signer": true}})
+
+	resp := test.Sign(t, &txnbuild.BumpSequence{BumpTo: 2})
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	require.Len(t, test.Events, 1)
+	assert.Equal(t, notify.EventSignRejected, test.Events[0].Type)
+	assert.Equal(t, "operation 0 bump_sequence not allowed", test.Events[0].Reason)
+
+	resp = test.Sign(t, addSignerOp)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+// Test that no more than the maximum number of transactions are signed.
+func TestAccountSign_maxSignatures(t *testing.T) {
+	test := newSignPolicyTest(t, signPolicy{MaxSignatures: 1, SignaturesWindow: time.Hour})
+
+	resp := test.Sign(t, addSignerOp)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = test.Sign(t, &txnbuild.BumpSequence{BumpTo: 2})
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+
+	require.Len(t, test.Events, 2)
+	assert.Equal(t, notify.EventSigned, test.Events[0].Type)
+	assert.Equal(t, notify.EventSignRejected, test.Events[1].Type)
+}
+
+// Test that a time-locked transaction is signed only once the timelock has
+// passed.
+func TestAccountSign_timelock(t *testing.T) {
+	test := newSignPolicyTest(t, signPolicy{Timelock: time.Hour})
+
+	resp := test.Sign(t, addSignerOp)
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	pending := accountSignPendingResponse{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&pending))
+	assert.Equal(t, "pending", pending.Status)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), pending.AvailableAt, time.Minute)
+
+	require.Len(t, test.Events, 1)
+	assert.Equal(t, notify.EventSignRequested, test.Events[0].Type)
+	assert.Equal(t, pending.ID, test.Events[0].RequestID)
+	assert.Equal(t, "sender", test.Events[0].By)
+	assert.Len(t, test.Events[0].Identities, 2)
+
+	// Requesting again before the timelock has passed is still pending.
+	resp = test.Sign(t, addSignerOp)
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	_, err := test.SignRequestStore.DB.Exec(`UPDATE sign_requests SET available_at = NOW() - INTERVAL '1 second'`)
+	require.NoError(t, err)
+
+	resp = test.Sign(t, addSignerOp)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	signed := accountSignResponse{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&signed))
+	assert.NotEmpty(t, signed.Signature)
+
+	r, err := test.SignRequestStore.Get("GA6HNE7O2N2IXIOBZNZ4IPTS2P6DSAJJF5GD5PDLH5GYOZ6WMPSKCXD4", pending.ID)
+	require.NoError(t, err)
+	assert.Equal(t, signrequest.StatusSigned, r.Status)
+}
+
+// Test that a time-locked request cancelled by another identity is not
+// signed.
+func TestAccountSign_timelockCancelled(t *testing.T) {
+	test := newSignPolicyTest(t, signPolicy{Timelock: time.Hour})
+
+	resp := test.Sign(t, addSignerOp)
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	pending := accountSignPendingResponse{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&pending))
+
+	resp = test.Do("GET", "/GA6HNE7O2N2IXIOBZNZ4IPTS2P6DSAJJF5GD5PDLH5GYOZ6WMPSKCXD4/sign-requests", "", "receiver@example.com")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	list := accountSignRequestsListResponse{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+	require.Len(t, list.SignRequests, 1)
+	assert.Equal(t, pending.ID, list.SignRequests[0].ID)
+	assert.Equal(t, "sender", list.SignRequests[0].RequestedBy)
+
+	// Not cancellable by someone who is not an identity of the account.
+	resp = test.Do("DELETE", "/GA6HNE7O2N2IXIOBZNZ4IPTS2P6DSAJJF5GD5PDLH5GYOZ6WMPSKCXD4/sign-requests/"+jsonInt(pending.ID), "", "other@example.com")
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp = test.Do("DELETE", "/GA6HNE7O2N2IXIOBZNZ4IPTS2P6DSAJJF5GD5PDLH5GYOZ6WMPSKCXD4/sign-requests/"+jsonInt(pending.ID), "", "receiver@example.com")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	cancelled := signRequestResponse{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&cancelled))
+	assert.Equal(t, "cancelled", cancelled.Status)
+	assert.Equal(t, "receiver", cancelled.CancelledBy)
+
+	_, err := test.SignRequestStore.DB.Exec(`UPDATE sign_requests SET available_at = NOW() - INTERVAL '1 second'`)
+	require.NoError(t, err)
+
+	resp = test.Sign(t, addSignerOp)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	require.Len(t, test.Events, 2)
+	assert.Equal(t, notify.EventSignRequested, test.Events[0].Type)
+	assert.Equal(t, notify.EventSignRequestCancelled, test.Events[1].Type)
+	assert.Equal(t, "receiver", test.Events[1].By)
+}
+
+func jsonInt(i int64) string {
+	b, _ := json.Marshal(i)
+	return string(b)
+}
@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/shantanu-hashcash/go/support/http/httpauthz"
+	"github.com/shantanu-hashcash/go/support/log"
+)
+
+// IdentityVerifier verifies a bearer token proving an identity, such as a
+// Hcnet address, a phone number or an email address.
+type IdentityVerifier interface {
+	// VerifyIdentity returns the auth details proven by the token, or false
+	// if the token is not one the verifier accepts or is invalid.
+	VerifyIdentity(ctx context.Context, token string) (Auth, bool)
+}
+
+// IdentityVerifierFunc is an adapter to allow the use of an ordinary function
+// as an IdentityVerifier.
+type IdentityVerifierFunc func(ctx context.Context, token string) (Auth, bool)
+
+func (f IdentityVerifierFunc) VerifyIdentity(ctx context.Context, token string) (Auth, bool) {
+	return f(ctx, token)
+}
+
+// IdentityMiddleware provides middleware for authenticating a request with
+// the given identity verifiers.
+//
+// A request may contain multiple Authorization headers each with a bearer
+// token proving a different identity, e.g. a phone number and an email
+// address, so that a client can prove multiple identities in one request.
+// The first identity proven for each of the auth details is kept.
+func IdentityMiddleware(verifiers ...IdentityVerifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			auth, _ := FromContext(ctx)
+			authTypes := []string{}
+			for _, authHeader := range r.Header.Values("Authorization") {
+				token := httpauthz.ParseBearerToken(authHeader)
+				if token == "" {
+					continue
+				}
+				for _, v := range verifiers {
+					verified, ok := v.VerifyIdentity(ctx, token)
+					if !ok {
+						continue
+					}
+					authTypes = append(authTypes, auth.merge(verified)...)
+					break
+				}
+			}
+			if len(authTypes) > 0 {
+				log.Ctx(ctx).
+					WithField("auth_types", strings.Join(authTypes, ", ")).
+					Info("Identity verified.")
+				r = r.WithContext(NewContext(ctx, auth))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// merge sets the auth details of o that are not already set and returns the
+// types of the details set.
+func (a *Auth) merge(o Auth) []string {
+	authTypes := []string{}
+	if a.Address == "" && o.Address != "" {
+		a.Address = o.Address
+		authTypes = append(authTypes, "hcnet_address")
+	}
+	if a.PhoneNumber == "" && o.PhoneNumber != "" {
+		a.PhoneNumber = o.PhoneNumber
+		authTypes = append(authTypes, "phone_number")
+	}
+	if a.Email == "" && o.Email != "" {
+		a.Email = o.Email
+		authTypes = append(authTypes, "email")
+	}
+	return authTypes
+}
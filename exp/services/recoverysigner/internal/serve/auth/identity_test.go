@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdentityMiddleware_mergesIdentitiesFromMultipleTokens(t *testing.T) {
+	verifier := IdentityVerifierFunc(func(ctx context.Context, token string) (Auth, bool) {
+		switch token {
+		case "email-token":
+			return Auth{Email: "user@example.com"}, true
+		case "phone-token":
+			return Auth{PhoneNumber: "+10000000000"}, true
+		case "other-email-token":
+			return Auth{Email: "other@example.com"}, true
+		}
+		return Auth{}, false
+	})
+
+	ctx := context.Context(nil)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx = r.Context()
+	})
+	handler := IdentityMiddleware(verifier)(next)
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Add("Authorization", "Bearer email-token")
+	r.Header.Add("Authorization", "Bearer invalid-token")
+	r.Header.Add("Authorization", "Bearer phone-token")
+	r.Header.Add("Authorization", "Bearer other-email-token")
+	handler.ServeHTTP(nil, r)
+
+	claims, ok := FromContext(ctx)
+	assert.True(t, ok)
+	wantClaims := Auth{
+		PhoneNumber: "+10000000000",
+		Email:       "user@example.com",
+	}
+	assert.Equal(t, wantClaims, claims)
+}
+
+func TestIdentityMiddleware_keepsExistingAuth(t *testing.T) {
+	verifier := IdentityVerifierFunc(func(ctx context.Context, token string) (Auth, bool) {
+		return Auth{Email: "user@example.com", PhoneNumber: "+10000000000"}, true
+	})
+
+	ctx := context.Context(nil)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx = r.Context()
+	})
+	handler := IdentityMiddleware(verifier)(next)
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r = r.WithContext(NewContext(r.Context(), Auth{Email: "firebase@example.com"}))
+	r.Header.Set("Authorization", "Bearer token")
+	handler.ServeHTTP(nil, r)
+
+	claims, _ := FromContext(ctx)
+	wantClaims := Auth{
+		PhoneNumber: "+10000000000",
+		Email:       "firebase@example.com",
+	}
+	assert.Equal(t, wantClaims, claims)
+}
+
+func TestIdentityMiddleware_noAuthIfNoTokenVerified(t *testing.T) {
+	verifier := IdentityVerifierFunc(func(ctx context.Context, token string) (Auth, bool) {
+		return Auth{}, false
+	})
+
+	ctx := context.Context(nil)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx = r.Context()
+	})
+	handler := IdentityMiddleware(verifier)(next)
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Authorization", "Bearer token")
+	handler.ServeHTTP(nil, r)
+
+	_, ok := FromContext(ctx)
+	assert.False(t, ok)
+}
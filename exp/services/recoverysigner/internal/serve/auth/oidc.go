@@ -0,0 +1,125 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/shantanu-hashcash/go/support/errors"
+	"github.com/shantanu-hashcash/go/support/log"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+// OIDCVerifier is an IdentityVerifier that verifies OpenID Connect ID tokens
+// issued by any OpenID Connect provider. Only the email address and phone
+// number claims that the provider has verified are used.
+type OIDCVerifier struct {
+	// Issuer is the issuer URL of the provider that must be in the iss
+	// claim.
+	Issuer string
+	// ClientID is the client ID registered with the provider for this
+	// server that must be in the aud claim.
+	ClientID string
+	// KeySet contains the keys the provider signs ID tokens with.
+	KeySet jose.JSONWebKeySet
+	// RemoteKeySet, if set, contains keys that are used in addition to the
+	// keys in KeySet, such as the keys published at the provider's jwks_uri.
+	RemoteKeySet *RemoteKeySet
+}
+
+type oidcJWTClaims struct {
+	jwt.Claims
+	Email               string `json:"email"`
+	EmailVerified       bool   `json:"email_verified"`
+	PhoneNumber         string `json:"phone_number"`
+	PhoneNumberVerified bool   `json:"phone_number_verified"`
+}
+
+func (v OIDCVerifier) VerifyIdentity(ctx context.Context, tokenEncoded string) (Auth, bool) {
+	token, err := jwt.ParseSigned(tokenEncoded)
+	if err != nil {
+		return Auth{}, false
+	}
+	keyID := ""
+	if len(token.Headers) > 0 {
+		keyID = token.Headers[0].KeyID
+	}
+	claims := oidcJWTClaims{}
+	verified := false
+	for _, k := range mergeKeySets(ctx, v.KeySet, v.RemoteKeySet, keyID).Keys {
+		if token.Claims(k, &claims) == nil {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return Auth{}, false
+	}
+	if claims.Expiry == nil {
+		return Auth{}, false
+	}
+	expectedClaims := jwt.Expected{
+		Issuer:   v.Issuer,
+		Audience: jwt.Audience{v.ClientID},
+		Time:     time.Now(),
+	}
+	if claims.Validate(expectedClaims) != nil {
+		return Auth{}, false
+	}
+
+	auth := Auth{}
+	if claims.EmailVerified {
+		auth.Email = claims.Email
+	}
+	if claims.PhoneNumberVerified {
+		auth.PhoneNumber = claims.PhoneNumber
+	}
+	if auth == (Auth{}) {
+		return Auth{}, false
+	}
+	log.Ctx(ctx).
+		WithField("iss", claims.Issuer).
+		WithField("sub", claims.Subject).
+		Info("OIDC ID token verified.")
+	return auth, true
+}
+
+// FetchOIDCJWKSURI fetches the URL of the JSON Web Key Set an OpenID Connect
+// provider signs ID tokens with, from the provider's discovery document.
+func FetchOIDCJWKSURI(ctx context.Context, client *http.Client, issuer string) (string, error) {
+	discoveryURL := strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration"
+	discovery := struct {
+		Issuer  string `json:"issuer"`
+		JWKSURI string `json:"jwks_uri"`
+	}{}
+	err := getJSON(ctx, client, discoveryURL, &discovery)
+	if err != nil {
+		return "", errors.Wrap(err, "fetching OIDC discovery document")
+	}
+	if discovery.Issuer != issuer {
+		return "", errors.Errorf("OIDC discovery document issuer %q does not match %q", discovery.Issuer, issuer)
+	}
+	if discovery.JWKSURI == "" {
+		return "", errors.New("OIDC discovery document has no jwks_uri")
+	}
+	return discovery.JWKSURI, nil
+}
+
+func getJSON(ctx context.Context, client *http.Client, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return errors.Wrap(err, "building request")
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "sending request")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("unexpected status code %d from %s", resp.StatusCode, url)
+	}
+	return errors.Wrap(json.NewDecoder(resp.Body).Decode(v), "decoding response")
+}
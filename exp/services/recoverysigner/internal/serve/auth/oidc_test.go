@@ -0,0 +1,189 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/square/go-jose.v2"
+)
+
+func TestOIDCVerifier_verifiedClaims(t *testing.T) {
+	k, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	v := OIDCVerifier{
+		Issuer:   "https://accounts.example.com",
+		ClientID: "recoverysigner",
+		KeySet:   jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: &k.PublicKey}}},
+	}
+
+	testCases := []struct {
+		name     string
+		claims   jwt.MapClaims
+		wantAuth Auth
+		wantOK   bool
+	}{
+		{
+			name: "email and phone number verified",
+			claims: jwt.MapClaims{
+				"email":                 "user@example.com",
+				"email_verified":        true,
+				"phone_number":          "+10000000000",
+				"phone_number_verified": true,
+			},
+			wantAuth: Auth{Email: "user@example.com", PhoneNumber: "+10000000000"},
+			wantOK:   true,
+		},
+		{
+			name: "phone number not verified",
+			claims: jwt.MapClaims{
+				"email":          "user@example.com",
+				"email_verified": true,
+				"phone_number":   "+10000000000",
+			},
+			wantAuth: Auth{Email: "user@example.com"},
+			wantOK:   true,
+		},
+		{
+			name: "nothing verified",
+			claims: jwt.MapClaims{
+				"email": "user@example.com",
+			},
+		},
+		{
+			name: "other audience",
+			claims: jwt.MapClaims{
+				"aud":            "other",
+				"email":          "user@example.com",
+				"email_verified": true,
+			},
+		},
+		{
+			name: "other issuer",
+			claims: jwt.MapClaims{
+				"iss":            "https://other.example.com",
+				"email":          "user@example.com",
+				"email_verified": true,
+			},
+		},
+		{
+			name: "expired",
+			claims: jwt.MapClaims{
+				"exp":            time.Now().Add(-time.Minute).Unix(),
+				"email":          "user@example.com",
+				"email_verified": true,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			claims := jwt.MapClaims{
+				"iss": "https://accounts.example.com",
+				"aud": "recoverysigner",
+				"sub": "1234",
+				"iat": time.Now().Unix(),
+				"exp": time.Now().Add(time.Hour).Unix(),
+			}
+			for k, v := range tc.claims {
+				claims[k] = v
+			}
+			token, err := jwt.NewWithClaims(jwt.SigningMethodES256, claims).SignedString(k)
+			require.NoError(t, err)
+
+			auth, ok := v.VerifyIdentity(context.Background(), token)
+			assert.Equal(t, tc.wantOK, ok)
+			assert.Equal(t, tc.wantAuth, auth)
+		})
+	}
+}
+
+func TestOIDCVerifier_otherKey(t *testing.T) {
+	k1, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	k2, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	v := OIDCVerifier{
+		Issuer:   "https://accounts.example.com",
+		ClientID: "recoverysigner",
+		KeySet:   jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: &k1.PublicKey}}},
+	}
+
+	claims := jwt.MapClaims{
+		"iss":            "https://accounts.example.com",
+		"aud":            "recoverysigner",
+		"iat":            time.Now().Unix(),
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"email":          "user@example.com",
+		"email_verified": true,
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodES256, claims).SignedString(k2)
+	require.NoError(t, err)
+
+	_, ok := v.VerifyIdentity(context.Background(), token)
+	assert.False(t, ok)
+}
+
+func TestFetchOIDCJWKSURI(t *testing.T) {
+	k, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ks := jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: &k.PublicKey, KeyID: "key1", Algorithm: "ES256"}}}
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   server.URL,
+			"jwks_uri": server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(ks)
+	})
+
+	got, err := FetchOIDCJWKSURI(context.Background(), server.Client(), server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/jwks", got)
+
+	_, err = FetchOIDCJWKSURI(context.Background(), server.Client(), server.URL+"/")
+	assert.Error(t, err)
+
+	// ID tokens signed with the keys published at the jwks_uri are
+	// verified, keys the provider rotates to are fetched again.
+	v := OIDCVerifier{
+		Issuer:       "https://accounts.example.com",
+		ClientID:     "recoverysigner",
+		RemoteKeySet: &RemoteKeySet{URL: got, Client: server.Client(), RefreshInterval: time.Hour},
+	}
+	sign := func(k *ecdsa.PrivateKey, keyID string) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+			"iss":            "https://accounts.example.com",
+			"aud":            "recoverysigner",
+			"exp":            time.Now().Add(time.Hour).Unix(),
+			"email":          "user@example.com",
+			"email_verified": true,
+		})
+		token.Header["kid"] = keyID
+		signed, err := token.SignedString(k)
+		require.NoError(t, err)
+		return signed
+	}
+	_, ok := v.VerifyIdentity(context.Background(), sign(k, "key1"))
+	assert.True(t, ok)
+
+	k2, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ks.Keys = append(ks.Keys, jose.JSONWebKey{Key: &k2.PublicKey, KeyID: "key2", Algorithm: "ES256"})
+	v.RemoteKeySet.fetchedAt = time.Now().Add(-remoteKeySetMinRefetchInterval)
+	_, ok = v.VerifyIdentity(context.Background(), sign(k2, "key2"))
+	assert.True(t, ok)
+}
@@ -0,0 +1,252 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shantanu-hashcash/go/support/errors"
+	"github.com/shantanu-hashcash/go/support/log"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+// OTPType is the type of identity a one-time password is sent to.
+type OTPType string
+
+const (
+	OTPTypeEmail       OTPType = "email"
+	OTPTypePhoneNumber OTPType = "phone_number"
+)
+
+const (
+	otpDigits         = 6
+	otpResendInterval = 30 * time.Second
+)
+
+var (
+	ErrOTPInvalidRecipient = errors.New("invalid one-time password recipient")
+	ErrOTPTooSoon          = errors.New("one-time password requested too soon after the last")
+	ErrOTPInvalid          = errors.New("invalid or expired one-time password")
+)
+
+var phoneNumberRegexp = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+// OTPAuthenticator authenticates a client with a one-time password sent to
+// an email address or phone number. A client that submits the one-time
+// password receives a JWT, signed by this server, that proves the identity
+// and is verified by the authenticator as an IdentityVerifier.
+//
+// Pending one-time passwords are kept in memory, so a client must submit the
+// one-time password to the server instance that sent it.
+type OTPAuthenticator struct {
+	Type   OTPType
+	Sender OTPSender
+	// Issuer is the issuer of the JWTs issued.
+	Issuer string
+	// TokenKey is the key the JWTs issued are signed with using HS256.
+	TokenKey []byte
+	// TokenTTL is how long the JWTs issued are valid for.
+	TokenTTL time.Duration
+	// CodeTTL is how long a one-time password is valid for.
+	CodeTTL time.Duration
+	// MaxAttempts is the number of attempts a client has to submit a
+	// one-time password.
+	MaxAttempts int
+	// Now returns the current time, defaulting to time.Now.
+	Now func() time.Time
+
+	mu      sync.Mutex
+	pending map[string]pendingOTP
+}
+
+type pendingOTP struct {
+	codeHash  [sha256.Size]byte
+	sentAt    time.Time
+	expiresAt time.Time
+	attempts  int
+}
+
+type otpJWTClaims struct {
+	jwt.Claims
+	Type OTPType `json:"otp_type"`
+}
+
+// Start sends a new one-time password to the recipient, replacing any
+// pending one-time password.
+func (a *OTPAuthenticator) Start(ctx context.Context, to string) error {
+	to, err := a.normalize(to)
+	if err != nil {
+		return err
+	}
+	code, err := newOTPCode()
+	if err != nil {
+		return err
+	}
+
+	now := a.now()
+	a.mu.Lock()
+	if a.pending == nil {
+		a.pending = map[string]pendingOTP{}
+	}
+	a.prune(now)
+	if p, ok := a.pending[to]; ok && now.Sub(p.sentAt) < otpResendInterval {
+		a.mu.Unlock()
+		return ErrOTPTooSoon
+	}
+	a.pending[to] = pendingOTP{
+		codeHash:  sha256.Sum256([]byte(code)),
+		sentAt:    now,
+		expiresAt: now.Add(a.CodeTTL),
+	}
+	a.mu.Unlock()
+
+	err = a.Sender.SendOTP(ctx, to, code)
+	if err != nil {
+		a.mu.Lock()
+		delete(a.pending, to)
+		a.mu.Unlock()
+		return errors.Wrap(err, "sending one-time password")
+	}
+	return nil
+}
+
+// Verify checks the one-time password sent to the recipient and returns a
+// JWT proving the identity of the recipient.
+func (a *OTPAuthenticator) Verify(ctx context.Context, to, code string) (string, error) {
+	to, err := a.normalize(to)
+	if err != nil {
+		return "", err
+	}
+
+	now := a.now()
+	a.mu.Lock()
+	p, ok := a.pending[to]
+	if !ok || !now.Before(p.expiresAt) {
+		delete(a.pending, to)
+		a.mu.Unlock()
+		return "", ErrOTPInvalid
+	}
+	codeHash := sha256.Sum256([]byte(code))
+	if subtle.ConstantTimeCompare(codeHash[:], p.codeHash[:]) != 1 {
+		p.attempts++
+		if p.attempts >= a.MaxAttempts {
+			delete(a.pending, to)
+		} else {
+			a.pending[to] = p
+		}
+		a.mu.Unlock()
+		return "", ErrOTPInvalid
+	}
+	delete(a.pending, to)
+	a.mu.Unlock()
+
+	log.Ctx(ctx).
+		WithField("otp_type", string(a.Type)).
+		Info("One-time password verified.")
+	return a.issueToken(to, now)
+}
+
+// VerifyIdentity verifies a JWT issued by the authenticator.
+func (a *OTPAuthenticator) VerifyIdentity(ctx context.Context, tokenEncoded string) (Auth, bool) {
+	token, err := jwt.ParseSigned(tokenEncoded)
+	if err != nil {
+		return Auth{}, false
+	}
+	claims := otpJWTClaims{}
+	if token.Claims(a.TokenKey, &claims) != nil {
+		return Auth{}, false
+	}
+	if claims.Type != a.Type || claims.Subject == "" || claims.Expiry == nil {
+		return Auth{}, false
+	}
+	expectedClaims := jwt.Expected{
+		Issuer: a.Issuer,
+		Time:   a.now(),
+	}
+	if claims.Validate(expectedClaims) != nil {
+		return Auth{}, false
+	}
+
+	switch a.Type {
+	case OTPTypeEmail:
+		return Auth{Email: claims.Subject}, true
+	case OTPTypePhoneNumber:
+		return Auth{PhoneNumber: claims.Subject}, true
+	}
+	return Auth{}, false
+}
+
+func (a *OTPAuthenticator) issueToken(to string, now time.Time) (string, error) {
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.HS256, Key: a.TokenKey}, nil)
+	if err != nil {
+		return "", errors.Wrap(err, "creating JWT signer")
+	}
+	claims := otpJWTClaims{
+		Claims: jwt.Claims{
+			Issuer:   a.Issuer,
+			Subject:  to,
+			IssuedAt: jwt.NewNumericDate(now),
+			Expiry:   jwt.NewNumericDate(now.Add(a.TokenTTL)),
+		},
+		Type: a.Type,
+	}
+	token, err := jwt.Signed(signer).Claims(claims).CompactSerialize()
+	return token, errors.Wrap(err, "signing JWT")
+}
+
+// normalize validates the recipient and returns it in the form stored with
+// the identities of accounts.
+func (a *OTPAuthenticator) normalize(to string) (string, error) {
+	to = strings.TrimSpace(to)
+	switch a.Type {
+	case OTPTypeEmail:
+		at := strings.LastIndex(to, "@")
+		if at < 1 || at == len(to)-1 || strings.ContainsAny(to, " \r\n") {
+			return "", ErrOTPInvalidRecipient
+		}
+		return to, nil
+	case OTPTypePhoneNumber:
+		if !phoneNumberRegexp.MatchString(to) {
+			return "", ErrOTPInvalidRecipient
+		}
+		return to, nil
+	}
+	return "", errors.Errorf("unrecognized one-time password type %q", a.Type)
+}
+
+// prune removes expired one-time passwords. It must be called with the lock
+// held.
+func (a *OTPAuthenticator) prune(now time.Time) {
+	for to, p := range a.pending {
+		if !now.Before(p.expiresAt) {
+			delete(a.pending, to)
+		}
+	}
+}
+
+func (a *OTPAuthenticator) now() time.Time {
+	if a.Now != nil {
+		return a.Now()
+	}
+	return time.Now()
+}
+
+func newOTPCode() (string, error) {
+	max := big.NewInt(1)
+	for i := 0; i < otpDigits; i++ {
+		max.Mul(max, big.NewInt(10))
+	}
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", errors.Wrap(err, "generating one-time password")
+	}
+	return fmt.Sprintf("%0*d", otpDigits, n), nil
+}
@@ -0,0 +1,155 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryOTPSender is a stand-in sender that keeps the one-time passwords
+// sent in memory.
+type memoryOTPSender map[string]string
+
+func (s memoryOTPSender) SendOTP(ctx context.Context, to, code string) error {
+	s[to] = code
+	return nil
+}
+
+func newTestOTPAuthenticator(typ OTPType, sender OTPSender, now *time.Time) *OTPAuthenticator {
+	return &OTPAuthenticator{
+		Type:        typ,
+		Sender:      sender,
+		Issuer:      "test",
+		TokenKey:    []byte("0123456789abcdef0123456789abcdef"),
+		TokenTTL:    time.Hour,
+		CodeTTL:     10 * time.Minute,
+		MaxAttempts: 3,
+		Now:         func() time.Time { return *now },
+	}
+}
+
+func TestOTPAuthenticator_email(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	sender := memoryOTPSender{}
+	a := newTestOTPAuthenticator(OTPTypeEmail, sender, &now)
+
+	err := a.Start(ctx, "user@example.com")
+	require.NoError(t, err)
+	code := sender["user@example.com"]
+	assert.Len(t, code, 6)
+
+	token, err := a.Verify(ctx, "user@example.com", code)
+	require.NoError(t, err)
+
+	auth, ok := a.VerifyIdentity(ctx, token)
+	assert.True(t, ok)
+	assert.Equal(t, Auth{Email: "user@example.com"}, auth)
+
+	// The one-time password can only be used once.
+	_, err = a.Verify(ctx, "user@example.com", code)
+	assert.Equal(t, ErrOTPInvalid, err)
+
+	// The token expires.
+	now = now.Add(2 * time.Hour)
+	_, ok = a.VerifyIdentity(ctx, token)
+	assert.False(t, ok)
+}
+
+func TestOTPAuthenticator_phoneNumber(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	sender := memoryOTPSender{}
+	a := newTestOTPAuthenticator(OTPTypePhoneNumber, sender, &now)
+
+	err := a.Start(ctx, "10000000000")
+	assert.Equal(t, ErrOTPInvalidRecipient, err)
+
+	err = a.Start(ctx, "+10000000000")
+	require.NoError(t, err)
+
+	token, err := a.Verify(ctx, "+10000000000", sender["+10000000000"])
+	require.NoError(t, err)
+
+	auth, ok := a.VerifyIdentity(ctx, token)
+	assert.True(t, ok)
+	assert.Equal(t, Auth{PhoneNumber: "+10000000000"}, auth)
+
+	// Tokens for another type of identity are not accepted.
+	email := newTestOTPAuthenticator(OTPTypeEmail, sender, &now)
+	_, ok = email.VerifyIdentity(ctx, token)
+	assert.False(t, ok)
+}
+
+func TestOTPAuthenticator_maxAttempts(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	sender := memoryOTPSender{}
+	a := newTestOTPAuthenticator(OTPTypeEmail, sender, &now)
+
+	err := a.Start(ctx, "user@example.com")
+	require.NoError(t, err)
+	code := sender["user@example.com"]
+	wrong := "000000"
+	if code == wrong {
+		wrong = "111111"
+	}
+
+	for i := 0; i < 3; i++ {
+		_, err = a.Verify(ctx, "user@example.com", wrong)
+		assert.Equal(t, ErrOTPInvalid, err)
+	}
+	_, err = a.Verify(ctx, "user@example.com", code)
+	assert.Equal(t, ErrOTPInvalid, err)
+}
+
+func TestOTPAuthenticator_expired(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	sender := memoryOTPSender{}
+	a := newTestOTPAuthenticator(OTPTypeEmail, sender, &now)
+
+	err := a.Start(ctx, "user@example.com")
+	require.NoError(t, err)
+
+	now = now.Add(11 * time.Minute)
+	_, err = a.Verify(ctx, "user@example.com", sender["user@example.com"])
+	assert.Equal(t, ErrOTPInvalid, err)
+}
+
+func TestOTPAuthenticator_resendInterval(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	sender := memoryOTPSender{}
+	a := newTestOTPAuthenticator(OTPTypeEmail, sender, &now)
+
+	err := a.Start(ctx, "user@example.com")
+	require.NoError(t, err)
+	err = a.Start(ctx, "user@example.com")
+	assert.Equal(t, ErrOTPTooSoon, err)
+
+	now = now.Add(otpResendInterval)
+	err = a.Start(ctx, "user@example.com")
+	assert.NoError(t, err)
+}
+
+func TestOTPAuthenticator_sendFailure(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	sender := OTPSenderFunc(func(ctx context.Context, to, code string) error {
+		return errors.New("unavailable")
+	})
+	a := newTestOTPAuthenticator(OTPTypeEmail, sender, &now)
+
+	err := a.Start(ctx, "user@example.com")
+	assert.EqualError(t, err, "sending one-time password: unavailable")
+
+	// A failed send does not prevent a retry.
+	a.Sender = memoryOTPSender{}
+	err = a.Start(ctx, "user@example.com")
+	assert.NoError(t, err)
+}
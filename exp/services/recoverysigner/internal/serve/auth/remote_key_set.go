@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/shantanu-hashcash/go/support/errors"
+	"github.com/shantanu-hashcash/go/support/log"
+	"gopkg.in/square/go-jose.v2"
+)
+
+// remoteKeySetMinRefetchInterval is the minimum time between fetches of a
+// RemoteKeySet caused by JWTs signed with keys that are not in the set.
+const remoteKeySetMinRefetchInterval = 10 * time.Second
+
+// RemoteKeySet is a JSON Web Key Set that is fetched from a URL, such as the
+// /.well-known/jwks.json endpoint of a SEP-10 server, and cached. The set is
+// fetched again when the refresh interval has passed, or when a JWT is signed
+// with a key ID that is not in the set, so that keys the server rotates to
+// are picked up without restarting.
+type RemoteKeySet struct {
+	URL             string
+	Client          *http.Client
+	RefreshInterval time.Duration
+
+	mu        sync.Mutex
+	keySet    jose.JSONWebKeySet
+	fetchedAt time.Time
+}
+
+// KeySet returns the cached set, fetching it first if it is stale or does not
+// contain the key ID. If fetching fails the cached set is returned.
+func (s *RemoteKeySet) KeySet(ctx context.Context, keyID string) jose.JSONWebKeySet {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	stale := s.fetchedAt.IsZero() || now.Sub(s.fetchedAt) >= s.RefreshInterval
+	unknownKeyID := keyID != "" && len(s.keySet.Key(keyID)) == 0 &&
+		now.Sub(s.fetchedAt) >= remoteKeySetMinRefetchInterval
+	if stale || unknownKeyID {
+		err := s.fetch(ctx, now)
+		if err != nil {
+			log.Ctx(ctx).
+				WithField("url", s.URL).
+				Warn("Error fetching JSON Web Key Set: ", err)
+		}
+	}
+	return s.keySet
+}
+
+// mergeKeySets returns the keys of ks followed by the keys of remote, if it
+// is not nil, fetched for a JWT signed with keyID.
+func mergeKeySets(ctx context.Context, ks jose.JSONWebKeySet, remote *RemoteKeySet, keyID string) jose.JSONWebKeySet {
+	if remote == nil {
+		return ks
+	}
+	merged := jose.JSONWebKeySet{}
+	merged.Keys = append(merged.Keys, ks.Keys...)
+	merged.Keys = append(merged.Keys, remote.KeySet(ctx, keyID).Keys...)
+	return merged
+}
+
+// fetch fetches the set. It must be called with the lock held.
+func (s *RemoteKeySet) fetch(ctx context.Context, now time.Time) error {
+	// Fetches are attempted no more often than the minimum interval even if
+	// they fail.
+	s.fetchedAt = now
+	ks := jose.JSONWebKeySet{}
+	err := getJSON(ctx, s.Client, s.URL, &ks)
+	if err != nil {
+		return err
+	}
+	if len(ks.Keys) == 0 {
+		return errors.New("no keys included in JSON Web Key Set")
+	}
+	s.keySet = ks
+	return nil
+}
@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/square/go-jose.v2"
+)
+
+func TestSEP10Verifier_remoteKeySetRotation(t *testing.T) {
+	k1, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	k2, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	fetches := 0
+	jwks := jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: &k1.PublicKey, KeyID: "1"}}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		_ = json.NewEncoder(w).Encode(jwks)
+	}))
+	defer server.Close()
+
+	v := SEP10Verifier{
+		Issuer: "https://webauth.example.com",
+		RemoteKeySet: &RemoteKeySet{
+			URL:             server.URL,
+			Client:          server.Client(),
+			RefreshInterval: time.Hour,
+		},
+	}
+
+	sign := func(k *ecdsa.PrivateKey, kid string) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+			"iss": "https://webauth.example.com",
+			"sub": "GDKABHI4LTLG7UCE6O7Y4D6REHJVS4DLXTVVXTE3BPRRLXPASHSOKG2D",
+			"iat": time.Now().Unix(),
+			"exp": time.Now().Add(time.Hour).Unix(),
+		})
+		token.Header["kid"] = kid
+		s, err := token.SignedString(k)
+		require.NoError(t, err)
+		return s
+	}
+
+	ctx := context.Background()
+	a, ok := v.VerifyIdentity(ctx, sign(k1, "1"))
+	assert.True(t, ok)
+	assert.Equal(t, Auth{Address: "GDKABHI4LTLG7UCE6O7Y4D6REHJVS4DLXTVVXTE3BPRRLXPASHSOKG2D"}, a)
+	_, ok = v.VerifyIdentity(ctx, sign(k1, "1"))
+	assert.True(t, ok)
+	assert.Equal(t, 1, fetches)
+
+	// The server rotates to a new key, which is fetched when a JWT signed
+	// with it is verified.
+	jwks = jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+		{Key: &k2.PublicKey, KeyID: "2"},
+		{Key: &k1.PublicKey, KeyID: "1"},
+	}}
+	v.RemoteKeySet.fetchedAt = time.Now().Add(-remoteKeySetMinRefetchInterval)
+	_, ok = v.VerifyIdentity(ctx, sign(k2, "2"))
+	assert.True(t, ok)
+	_, ok = v.VerifyIdentity(ctx, sign(k1, "1"))
+	assert.True(t, ok)
+	assert.Equal(t, 2, fetches)
+
+	// An unknown key does not cause a fetch within the minimum interval.
+	_, ok = v.VerifyIdentity(ctx, sign(k1, "3"))
+	assert.True(t, ok)
+	assert.Equal(t, 2, fetches)
+}
+
+func TestRemoteKeySet_fetchErrorKeepsCachedKeys(t *testing.T) {
+	k1, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	fail := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: &k1.PublicKey, KeyID: "1"}}})
+	}))
+	defer server.Close()
+
+	s := &RemoteKeySet{URL: server.URL, Client: server.Client()}
+	ks := s.KeySet(context.Background(), "")
+	require.Len(t, ks.Keys, 1)
+
+	fail = true
+	ks = s.KeySet(context.Background(), "")
+	require.Len(t, ks.Keys, 1)
+	assert.Equal(t, "1", ks.Keys[0].KeyID)
+}
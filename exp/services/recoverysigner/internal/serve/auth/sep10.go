@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"time"
@@ -52,12 +53,49 @@ func (c sep10JWTClaims) Validate(issuer string) error {
 	return c.Claims.Validate(expectedClaims)
 }
 
+// SEP10Verifier is an IdentityVerifier that verifies SEP-10 JWTs proving
+// a Hcnet address.
+type SEP10Verifier struct {
+	Issuer string
+	KeySet jose.JSONWebKeySet
+	// RemoteKeySet, if set, contains keys that are used in addition to the
+	// keys in KeySet.
+	RemoteKeySet *RemoteKeySet
+}
+
+func (v SEP10Verifier) VerifyIdentity(ctx context.Context, token string) (Auth, bool) {
+	address, k, ok := sep10ClaimsFromToken(token, v.Issuer, v.keySet(ctx, token))
+	if !ok {
+		return Auth{}, false
+	}
+	log.Ctx(ctx).
+		WithField("jwkkid", k.KeyID).
+		WithField("address", address).
+		Info("SEP-10 JWT verified.")
+	return Auth{Address: address}, true
+}
+
+func (v SEP10Verifier) keySet(ctx context.Context, tokenEncoded string) jose.JSONWebKeySet {
+	if v.RemoteKeySet == nil {
+		return v.KeySet
+	}
+	keyID := ""
+	if token, err := jwt.ParseSigned(tokenEncoded); err == nil && len(token.Headers) > 0 {
+		keyID = token.Headers[0].KeyID
+	}
+	return mergeKeySets(ctx, v.KeySet, v.RemoteKeySet, keyID)
+}
+
 func sep10ClaimsFromRequest(r *http.Request, issuer string, ks jose.JSONWebKeySet) (address string, k jose.JSONWebKey, ok bool) {
 	authHeader := r.Header.Get("Authorization")
 	tokenEncoded := httpauthz.ParseBearerToken(authHeader)
 	if tokenEncoded == "" {
 		return "", jose.JSONWebKey{}, false
 	}
+	return sep10ClaimsFromToken(tokenEncoded, issuer, ks)
+}
+
+func sep10ClaimsFromToken(tokenEncoded string, issuer string, ks jose.JSONWebKeySet) (address string, k jose.JSONWebKey, ok bool) {
 	token, err := jwt.ParseSigned(tokenEncoded)
 	if err != nil {
 		return "", jose.JSONWebKey{}, false
@@ -0,0 +1,64 @@
+package serve
+
+import (
+	"net/http"
+
+	"github.com/shantanu-hashcash/go/exp/services/recoverysigner/internal/serve/auth"
+	"github.com/shantanu-hashcash/go/support/errors"
+	"github.com/shantanu-hashcash/go/support/http/httpdecode"
+	supportlog "github.com/shantanu-hashcash/go/support/log"
+	"github.com/shantanu-hashcash/go/support/render/httpjson"
+)
+
+type authOTPStartHandler struct {
+	Logger *supportlog.Entry
+	OTP    *auth.OTPAuthenticator
+}
+
+type authOTPStartRequest struct {
+	To string `json:"to" form:"to"`
+}
+
+type authOTPStartResponse struct {
+	To string `json:"to"`
+}
+
+func (h authOTPStartHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := authOTPStartRequest{}
+	err := httpdecode.Decode(r, &req)
+	if err != nil || req.To == "" {
+		badRequest.Render(w)
+		return
+	}
+
+	l := h.Logger.Ctx(ctx).
+		WithField("otp_type", string(h.OTP.Type))
+
+	l.Info("Request to send one-time password.")
+
+	err = h.OTP.Start(ctx, req.To)
+	switch errors.Cause(err) {
+	case nil:
+	case auth.ErrOTPInvalidRecipient:
+		l.Info("One-time password recipient invalid.")
+		badRequest.Render(w)
+		return
+	case auth.ErrOTPTooSoon:
+		l.Info("One-time password requested too soon.")
+		tooManyRequests.Render(w)
+		return
+	default:
+		l.Error(err)
+		serverError.Render(w)
+		return
+	}
+
+	l.Info("One-time password sent.")
+
+	resp := authOTPStartResponse{
+		To: req.To,
+	}
+	httpjson.Render(w, resp, httpjson.JSON)
+}
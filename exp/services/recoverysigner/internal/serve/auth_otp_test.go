@@ -0,0 +1,86 @@
+package serve
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shantanu-hashcash/go/exp/services/recoverysigner/internal/serve/auth"
+	supportlog "github.com/shantanu-hashcash/go/support/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestOTPAuthenticator(sent map[string]string) *auth.OTPAuthenticator {
+	return &auth.OTPAuthenticator{
+		Type: auth.OTPTypeEmail,
+		Sender: auth.OTPSenderFunc(func(ctx context.Context, to, code string) error {
+			sent[to] = code
+			return nil
+		}),
+		Issuer:      "test",
+		TokenKey:    []byte("0123456789abcdef0123456789abcdef"),
+		TokenTTL:    time.Hour,
+		CodeTTL:     10 * time.Minute,
+		MaxAttempts: 3,
+	}
+}
+
+func TestAuthOTP_startAndVerify(t *testing.T) {
+	sent := map[string]string{}
+	otp := newTestOTPAuthenticator(sent)
+
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`{"to": "user@example.com"}`))
+	w := httptest.NewRecorder()
+	authOTPStartHandler{Logger: supportlog.DefaultLogger, OTP: otp}.ServeHTTP(w, r)
+	resp := w.Result()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"to": "user@example.com"}`, string(body))
+
+	// Requesting again immediately is rate limited.
+	r = httptest.NewRequest("POST", "/", strings.NewReader(`{"to": "user@example.com"}`))
+	w = httptest.NewRecorder()
+	authOTPStartHandler{Logger: supportlog.DefaultLogger, OTP: otp}.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusTooManyRequests, w.Result().StatusCode)
+
+	r = httptest.NewRequest("POST", "/", strings.NewReader(`{"to": "user@example.com", "code": "`+sent["user@example.com"]+`"}`))
+	w = httptest.NewRecorder()
+	authOTPVerifyHandler{Logger: supportlog.DefaultLogger, OTP: otp}.ServeHTTP(w, r)
+	resp = w.Result()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	respBody := authOTPVerifyResponse{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&respBody))
+
+	claims, ok := otp.VerifyIdentity(context.Background(), respBody.Token)
+	assert.True(t, ok)
+	assert.Equal(t, auth.Auth{Email: "user@example.com"}, claims)
+}
+
+func TestAuthOTP_startInvalidRecipient(t *testing.T) {
+	otp := newTestOTPAuthenticator(map[string]string{})
+
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`{"to": "not-an-email"}`))
+	w := httptest.NewRecorder()
+	authOTPStartHandler{Logger: supportlog.DefaultLogger, OTP: otp}.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+}
+
+func TestAuthOTP_verifyInvalidCode(t *testing.T) {
+	otp := newTestOTPAuthenticator(map[string]string{})
+
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`{"to": "user@example.com", "code": "123456"}`))
+	w := httptest.NewRecorder()
+	authOTPVerifyHandler{Logger: supportlog.DefaultLogger, OTP: otp}.ServeHTTP(w, r)
+	resp := w.Result()
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"error": "The request could not be authenticated."}`, string(body))
+}
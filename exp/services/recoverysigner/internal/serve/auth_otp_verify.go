@@ -0,0 +1,65 @@
+package serve
+
+import (
+	"net/http"
+
+	"github.com/shantanu-hashcash/go/exp/services/recoverysigner/internal/serve/auth"
+	"github.com/shantanu-hashcash/go/support/errors"
+	"github.com/shantanu-hashcash/go/support/http/httpdecode"
+	supportlog "github.com/shantanu-hashcash/go/support/log"
+	"github.com/shantanu-hashcash/go/support/render/httpjson"
+)
+
+type authOTPVerifyHandler struct {
+	Logger *supportlog.Entry
+	OTP    *auth.OTPAuthenticator
+}
+
+type authOTPVerifyRequest struct {
+	To   string `json:"to" form:"to"`
+	Code string `json:"code" form:"code"`
+}
+
+type authOTPVerifyResponse struct {
+	Token string `json:"token"`
+}
+
+func (h authOTPVerifyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := authOTPVerifyRequest{}
+	err := httpdecode.Decode(r, &req)
+	if err != nil || req.To == "" || req.Code == "" {
+		badRequest.Render(w)
+		return
+	}
+
+	l := h.Logger.Ctx(ctx).
+		WithField("otp_type", string(h.OTP.Type))
+
+	l.Info("Request to verify one-time password.")
+
+	token, err := h.OTP.Verify(ctx, req.To, req.Code)
+	switch errors.Cause(err) {
+	case nil:
+	case auth.ErrOTPInvalidRecipient:
+		l.Info("One-time password recipient invalid.")
+		badRequest.Render(w)
+		return
+	case auth.ErrOTPInvalid:
+		l.Info("One-time password invalid.")
+		unauthorized.Render(w)
+		return
+	default:
+		l.Error(err)
+		serverError.Render(w)
+		return
+	}
+
+	l.Info("One-time password verified.")
+
+	resp := authOTPVerifyResponse{
+		Token: token,
+	}
+	httpjson.Render(w, resp, httpjson.JSON)
+}
@@ -30,6 +30,26 @@ var unauthorized = errorResponse{
 	Status: http.StatusUnauthorized,
 	Error:  "The request could not be authenticated.",
 }
+var insufficientIdentities = errorResponse{
+	Status: http.StatusForbidden,
+	Error:  "The request must be authenticated with more identities of the account.",
+}
+var tooManyRequests = errorResponse{
+	Status: http.StatusTooManyRequests,
+	Error:  "Too many requests have been made, try again later.",
+}
+var operationNotAllowed = errorResponse{
+	Status: http.StatusForbidden,
+	Error:  "The transaction contains an operation that is not allowed to be signed.",
+}
+var signLimitReached = errorResponse{
+	Status: http.StatusTooManyRequests,
+	Error:  "The maximum number of transactions signed for the account has been reached, try again later.",
+}
+var signRequestCancelled = errorResponse{
+	Status: http.StatusConflict,
+	Error:  "The request to sign the transaction has been cancelled.",
+}
 
 type errorResponse struct {
 	Status int    `json:"-"`
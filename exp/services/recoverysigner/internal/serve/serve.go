@@ -1,17 +1,23 @@
 package serve
 
 import (
+	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	firebaseauth "firebase.google.com/go/auth"
 	"github.com/go-chi/chi"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/shantanu-hashcash/go/amount"
 	"github.com/shantanu-hashcash/go/exp/services/recoverysigner/internal/account"
 	"github.com/shantanu-hashcash/go/exp/services/recoverysigner/internal/db"
+	"github.com/shantanu-hashcash/go/exp/services/recoverysigner/internal/notify"
 	"github.com/shantanu-hashcash/go/exp/services/recoverysigner/internal/serve/auth"
+	"github.com/shantanu-hashcash/go/exp/services/recoverysigner/internal/signrequest"
 	"github.com/shantanu-hashcash/go/keypair"
 	"github.com/shantanu-hashcash/go/support/errors"
 	supporthttp "github.com/shantanu-hashcash/go/support/http"
@@ -28,9 +34,27 @@ type Options struct {
 	NetworkPassphrase    string
 	SigningKeys          string
 	SEP10JWKS            string
+	SEP10JWKSURL         string
 	SEP10JWTIssuer       string
 	FirebaseProjectID    string
 
+	OIDCIssuer   string
+	OIDCClientID string
+	OIDCJWKS     string
+
+	OTPTokenKey          string
+	OTPEmailSender       string
+	OTPPhoneNumberSender string
+
+	SignHighValueIdentities int
+	SignHighValueAmount     string
+
+	SignAllowedOperations string
+	SignMaxSignatures     int
+	SignSignaturesWindow  time.Duration
+	SignTimelock          time.Duration
+	NotifyWebhook         string
+
 	AdminPort        int
 	MetricsNamespace string
 
@@ -64,17 +88,35 @@ func Serve(opts Options) {
 	})
 }
 
+const (
+	otpIssuer      = "recoverysigner"
+	otpCodeTTL     = 10 * time.Minute
+	otpTokenTTL    = time.Hour
+	otpMaxAttempts = 5
+
+	notifyWebhookTimeout = 10 * time.Second
+
+	sep10JWKSFetchTimeout    = 10 * time.Second
+	sep10JWKSRefreshInterval = time.Hour
+)
+
 type handlerDeps struct {
 	Logger                *supportlog.Entry
 	NetworkPassphrase     string
 	SigningKeys           []*keypair.Full
 	SigningAddresses      []*keypair.FromAddress
 	AccountStore          account.Store
+	SignRequestStore      signrequest.Store
 	SEP10JWKS             jose.JSONWebKeySet
 	SEP10JWTIssuer        string
 	FirebaseAuthClient    *firebaseauth.Client
+	IdentityVerifiers     []auth.IdentityVerifier
+	OTPAuthenticators     []*auth.OTPAuthenticator
 	MetricsRegistry       *prometheus.Registry
 	AllowedSourceAccounts []*keypair.FromAddress
+	HighValuePolicy       highValuePolicy
+	SignPolicy            signPolicy
+	Notifier              notify.Notifier
 }
 
 func getHandlerDeps(opts Options) (handlerDeps, error) {
@@ -93,15 +135,10 @@ func getHandlerDeps(opts Options) (handlerDeps, error) {
 		opts.Logger.Info("Signing key ", i, ": ", signingKey.Address())
 	}
 
-	sep10JWKS := jose.JSONWebKeySet{}
-	err := json.Unmarshal([]byte(opts.SEP10JWKS), &sep10JWKS)
+	sep10JWKS, sep10RemoteJWKS, err := getSEP10JWKS(opts)
 	if err != nil {
-		return handlerDeps{}, errors.Wrap(err, "parsing SEP-10 JSON Web Key (JWK) Set")
+		return handlerDeps{}, err
 	}
-	if len(sep10JWKS.Keys) == 0 {
-		return handlerDeps{}, errors.New("no keys included in SEP-10 JSON Web Key (JWK) Set")
-	}
-	opts.Logger.Infof("SEP10 JWKS contains %d keys", len(sep10JWKS.Keys))
 
 	db, err := db.Open(opts.DatabaseURL)
 	if err != nil {
@@ -114,10 +151,48 @@ func getHandlerDeps(opts Options) (handlerDeps, error) {
 		opts.Logger.Warn("Error pinging to Database: ", err)
 	}
 	accountStore := &account.DBStore{DB: db}
+	signRequestStore := &signrequest.DBStore{DB: db}
 
-	firebaseAuthClient, err := auth.NewFirebaseAuthClient(opts.FirebaseProjectID)
+	identityVerifiers := []auth.IdentityVerifier{
+		auth.SEP10Verifier{Issuer: opts.SEP10JWTIssuer, KeySet: sep10JWKS, RemoteKeySet: sep10RemoteJWKS},
+	}
+
+	var firebaseAuthClient *firebaseauth.Client
+	if opts.FirebaseProjectID != "" {
+		firebaseAuthClient, err = auth.NewFirebaseAuthClient(opts.FirebaseProjectID)
+		if err != nil {
+			return handlerDeps{}, errors.Wrap(err, "error setting up firebase auth client")
+		}
+	}
+
+	if opts.OIDCIssuer != "" {
+		oidcVerifier, err := getOIDCVerifier(opts)
+		if err != nil {
+			return handlerDeps{}, err
+		}
+		identityVerifiers = append(identityVerifiers, oidcVerifier)
+		opts.Logger.Infof("OIDC JWKS contains %d keys", len(oidcVerifier.KeySet.Keys))
+	}
+
+	otpAuthenticators, err := getOTPAuthenticators(opts)
 	if err != nil {
-		return handlerDeps{}, errors.Wrap(err, "error setting up firebase auth client")
+		return handlerDeps{}, err
+	}
+	for _, a := range otpAuthenticators {
+		identityVerifiers = append(identityVerifiers, a)
+		opts.Logger.Infof("OTP authentication enabled for %s", a.Type)
+	}
+
+	if opts.FirebaseProjectID == "" && opts.OIDCIssuer == "" && len(otpAuthenticators) == 0 {
+		opts.Logger.Warn("No phone number or email authentication configured, only SEP-10 authentication is available")
+	}
+
+	highValuePolicy := highValuePolicy{Identities: opts.SignHighValueIdentities}
+	if opts.SignHighValueAmount != "" {
+		highValuePolicy.Amount, err = amount.ParseInt64(opts.SignHighValueAmount)
+		if err != nil {
+			return handlerDeps{}, errors.Wrap(err, "parsing sign high-value amount")
+		}
 	}
 
 	metricsRegistry := prometheus.NewRegistry()
@@ -155,22 +230,167 @@ func getHandlerDeps(opts Options) (handlerDeps, error) {
 		}
 	}
 
+	allowedOperations, err := parseAllowedOperations(opts.SignAllowedOperations)
+	if err != nil {
+		return handlerDeps{}, errors.Wrap(err, "parsing sign allowed operations")
+	}
+	signPolicy := signPolicy{
+		AllowedOperations: allowedOperations,
+		MaxSignatures:     opts.SignMaxSignatures,
+		SignaturesWindow:  opts.SignSignaturesWindow,
+		Timelock:          opts.SignTimelock,
+	}
+	if signPolicy.MaxSignatures > 0 && signPolicy.SignaturesWindow <= 0 {
+		return handlerDeps{}, errors.New("sign signatures window is required when sign max signatures is set")
+	}
+
+	notifiers := notify.Notifiers{notify.LogNotifier{Logger: opts.Logger}}
+	if opts.NotifyWebhook != "" {
+		notifiers = append(notifiers, notify.WebhookNotifier{
+			URL:    opts.NotifyWebhook,
+			Client: &http.Client{Timeout: notifyWebhookTimeout},
+		})
+	}
+
 	deps := handlerDeps{
 		Logger:                opts.Logger,
 		NetworkPassphrase:     opts.NetworkPassphrase,
 		SigningKeys:           signingKeys,
 		SigningAddresses:      signingAddresses,
 		AccountStore:          accountStore,
+		SignRequestStore:      signRequestStore,
 		SEP10JWKS:             sep10JWKS,
 		SEP10JWTIssuer:        opts.SEP10JWTIssuer,
 		FirebaseAuthClient:    firebaseAuthClient,
+		IdentityVerifiers:     identityVerifiers,
+		OTPAuthenticators:     otpAuthenticators,
 		MetricsRegistry:       metricsRegistry,
 		AllowedSourceAccounts: allowedSourceAccounts,
+		HighValuePolicy:       highValuePolicy,
+		SignPolicy:            signPolicy,
+		Notifier:              notifiers,
 	}
 
 	return deps, nil
 }
 
+func getSEP10JWKS(opts Options) (jose.JSONWebKeySet, *auth.RemoteKeySet, error) {
+	if opts.SEP10JWKS == "" && opts.SEP10JWKSURL == "" {
+		return jose.JSONWebKeySet{}, nil, errors.New("SEP-10 JSON Web Key (JWK) Set or its URL is required")
+	}
+
+	sep10JWKS := jose.JSONWebKeySet{}
+	if opts.SEP10JWKS != "" {
+		err := json.Unmarshal([]byte(opts.SEP10JWKS), &sep10JWKS)
+		if err != nil {
+			return jose.JSONWebKeySet{}, nil, errors.Wrap(err, "parsing SEP-10 JSON Web Key (JWK) Set")
+		}
+		if len(sep10JWKS.Keys) == 0 {
+			return jose.JSONWebKeySet{}, nil, errors.New("no keys included in SEP-10 JSON Web Key (JWK) Set")
+		}
+		opts.Logger.Infof("SEP10 JWKS contains %d keys", len(sep10JWKS.Keys))
+	}
+
+	var sep10RemoteJWKS *auth.RemoteKeySet
+	if opts.SEP10JWKSURL != "" {
+		sep10RemoteJWKS = &auth.RemoteKeySet{
+			URL:             opts.SEP10JWKSURL,
+			Client:          &http.Client{Timeout: sep10JWKSFetchTimeout},
+			RefreshInterval: sep10JWKSRefreshInterval,
+		}
+		ks := sep10RemoteJWKS.KeySet(context.Background(), "")
+		opts.Logger.Infof("SEP10 JWKS fetched from %s contains %d keys", opts.SEP10JWKSURL, len(ks.Keys))
+	}
+
+	return sep10JWKS, sep10RemoteJWKS, nil
+}
+
+func getOIDCVerifier(opts Options) (auth.OIDCVerifier, error) {
+	if opts.OIDCClientID == "" {
+		return auth.OIDCVerifier{}, errors.New("OIDC client ID is required when an OIDC issuer is configured")
+	}
+	oidcJWKS := jose.JSONWebKeySet{}
+	if opts.OIDCJWKS != "" {
+		err := json.Unmarshal([]byte(opts.OIDCJWKS), &oidcJWKS)
+		if err != nil {
+			return auth.OIDCVerifier{}, errors.Wrap(err, "parsing OIDC JSON Web Key (JWK) Set")
+		}
+		if len(oidcJWKS.Keys) == 0 {
+			return auth.OIDCVerifier{}, errors.New("no keys included in OIDC JSON Web Key (JWK) Set")
+		}
+		return auth.OIDCVerifier{
+			Issuer:   opts.OIDCIssuer,
+			ClientID: opts.OIDCClientID,
+			KeySet:   oidcJWKS,
+		}, nil
+	}
+
+	client := &http.Client{Timeout: sep10JWKSFetchTimeout}
+	jwksURI, err := auth.FetchOIDCJWKSURI(context.Background(), client, opts.OIDCIssuer)
+	if err != nil {
+		return auth.OIDCVerifier{}, errors.Wrap(err, "fetching OIDC JSON Web Key (JWK) Set URL")
+	}
+	// The provider rotates its keys, they are fetched again periodically
+	// and when an ID token is signed with an unknown key.
+	oidcRemoteJWKS := &auth.RemoteKeySet{
+		URL:             jwksURI,
+		Client:          client,
+		RefreshInterval: sep10JWKSRefreshInterval,
+	}
+	ks := oidcRemoteJWKS.KeySet(context.Background(), "")
+	if len(ks.Keys) == 0 {
+		return auth.OIDCVerifier{}, errors.Errorf("no keys fetched from OIDC JSON Web Key (JWK) Set %s", jwksURI)
+	}
+	opts.Logger.Infof("OIDC JWKS fetched from %s contains %d keys", jwksURI, len(ks.Keys))
+	return auth.OIDCVerifier{
+		Issuer:       opts.OIDCIssuer,
+		ClientID:     opts.OIDCClientID,
+		RemoteKeySet: oidcRemoteJWKS,
+	}, nil
+}
+
+func getOTPAuthenticators(opts Options) ([]*auth.OTPAuthenticator, error) {
+	senders := []struct {
+		Type   auth.OTPType
+		Sender string
+	}{
+		{auth.OTPTypeEmail, opts.OTPEmailSender},
+		{auth.OTPTypePhoneNumber, opts.OTPPhoneNumberSender},
+	}
+
+	authenticators := []*auth.OTPAuthenticator{}
+	var tokenKey []byte
+	for _, s := range senders {
+		if s.Sender == "" {
+			continue
+		}
+		if tokenKey == nil {
+			var err error
+			tokenKey, err = base64.StdEncoding.DecodeString(opts.OTPTokenKey)
+			if err != nil {
+				return nil, errors.Wrap(err, "parsing OTP token key")
+			}
+			if len(tokenKey) < 32 {
+				return nil, errors.New("OTP token key must be at least 32 bytes when an OTP sender is configured")
+			}
+		}
+		sender, err := auth.NewOTPSender(opts.Logger, s.Sender)
+		if err != nil {
+			return nil, errors.Wrapf(err, "setting up %s OTP sender", s.Type)
+		}
+		authenticators = append(authenticators, &auth.OTPAuthenticator{
+			Type:        s.Type,
+			Sender:      sender,
+			Issuer:      otpIssuer,
+			TokenKey:    tokenKey,
+			TokenTTL:    otpTokenTTL,
+			CodeTTL:     otpCodeTTL,
+			MaxAttempts: otpMaxAttempts,
+		})
+	}
+	return authenticators, nil
+}
+
 func handler(deps handlerDeps) http.Handler {
 	mux := supporthttp.NewAPIMux(deps.Logger)
 
@@ -178,9 +398,23 @@ func handler(deps handlerDeps) http.Handler {
 	mux.MethodNotAllowed(errorHandler{Error: methodNotAllowed}.ServeHTTP)
 
 	mux.Get("/health", health.PassHandler{}.ServeHTTP)
+	mux.Route("/auth/otp", func(mux chi.Router) {
+		for _, a := range deps.OTPAuthenticators {
+			mux.Post("/"+string(a.Type), authOTPStartHandler{
+				Logger: deps.Logger,
+				OTP:    a,
+			}.ServeHTTP)
+			mux.Post("/"+string(a.Type)+"/verify", authOTPVerifyHandler{
+				Logger: deps.Logger,
+				OTP:    a,
+			}.ServeHTTP)
+		}
+	})
 	mux.Route("/accounts", func(mux chi.Router) {
-		mux.Use(auth.SEP10Middleware(deps.SEP10JWTIssuer, deps.SEP10JWKS))
-		mux.Use(auth.FirebaseMiddleware(auth.FirebaseTokenVerifierLive{AuthClient: deps.FirebaseAuthClient}))
+		if deps.FirebaseAuthClient != nil {
+			mux.Use(auth.FirebaseMiddleware(auth.FirebaseTokenVerifierLive{AuthClient: deps.FirebaseAuthClient}))
+		}
+		mux.Use(auth.IdentityMiddleware(deps.IdentityVerifiers...))
 		mux.Get("/", accountListHandler{
 			Logger:           deps.Logger,
 			SigningAddresses: deps.SigningAddresses,
@@ -213,9 +447,24 @@ func handler(deps handlerDeps) http.Handler {
 				NetworkPassphrase:     deps.NetworkPassphrase,
 				AccountStore:          deps.AccountStore,
 				AllowedSourceAccounts: deps.AllowedSourceAccounts,
+				HighValuePolicy:       deps.HighValuePolicy,
+				SignPolicy:            deps.SignPolicy,
+				SignRequestStore:      deps.SignRequestStore,
+				Notifier:              deps.Notifier,
 			}
 			mux.Post("/sign", signHandler.ServeHTTP)
 			mux.Post("/sign/{signing-address}", signHandler.ServeHTTP)
+			mux.Get("/sign-requests", accountSignRequestsListHandler{
+				Logger:           deps.Logger,
+				AccountStore:     deps.AccountStore,
+				SignRequestStore: deps.SignRequestStore,
+			}.ServeHTTP)
+			mux.Delete("/sign-requests/{id}", accountSignRequestsDeleteHandler{
+				Logger:           deps.Logger,
+				AccountStore:     deps.AccountStore,
+				SignRequestStore: deps.SignRequestStore,
+				Notifier:         deps.Notifier,
+			}.ServeHTTP)
 		})
 	})
 
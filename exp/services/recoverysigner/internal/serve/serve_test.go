@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/shantanu-hashcash/go/exp/services/recoverysigner/internal/serve/auth"
 	"github.com/shantanu-hashcash/go/keypair"
 	supportlog "github.com/shantanu-hashcash/go/support/log"
 	"github.com/stretchr/testify/assert"
@@ -33,3 +34,52 @@ func TestGetHandlerDeps(t *testing.T) {
 	assert.Equal(t, sep10JWKS, got.SEP10JWKS)
 	assert.Equal(t, []*keypair.FromAddress{}, got.AllowedSourceAccounts)
 }
+
+func TestGetHandlerDeps_identityVerifiers(t *testing.T) {
+	opts := Options{
+		Logger:                  supportlog.DefaultLogger,
+		SigningKeys:             "SBWLXUTJR2CGVPGCZDIGGLQDPX7ZGGBHBFXBJ555MNIQ2PZCCLM643Z3",
+		SEP10JWKS:               `{"keys":[{"kty":"EC","crv":"P-256","alg":"ES256","x":"i8chX_7Slm4VQ_Y6XBWVBnxIO5-XSWH1GJsXWNkal3E","y":"G22r0OgrcQnkfCAqsS6wvtHgR0SbfvXNJy6-jJfvc94"}]}`,
+		OIDCIssuer:              "https://accounts.example.com",
+		OIDCClientID:            "recoverysigner",
+		OIDCJWKS:                `{"keys":[{"kty":"EC","crv":"P-256","alg":"ES256","x":"i8chX_7Slm4VQ_Y6XBWVBnxIO5-XSWH1GJsXWNkal3E","y":"G22r0OgrcQnkfCAqsS6wvtHgR0SbfvXNJy6-jJfvc94"}]}`,
+		OTPTokenKey:             "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY=",
+		OTPEmailSender:          "log:",
+		SignHighValueIdentities: 2,
+		SignHighValueAmount:     "1000",
+	}
+
+	got, err := getHandlerDeps(opts)
+	require.NoError(t, err)
+
+	assert.Nil(t, got.FirebaseAuthClient)
+	require.Len(t, got.IdentityVerifiers, 3)
+	assert.IsType(t, auth.SEP10Verifier{}, got.IdentityVerifiers[0])
+	assert.IsType(t, auth.OIDCVerifier{}, got.IdentityVerifiers[1])
+	require.Len(t, got.OTPAuthenticators, 1)
+	assert.Equal(t, auth.OTPTypeEmail, got.OTPAuthenticators[0].Type)
+	assert.Equal(t, highValuePolicy{Identities: 2, Amount: 1000_0000000}, got.HighValuePolicy)
+}
+
+func TestGetHandlerDeps_otpTokenKeyTooShort(t *testing.T) {
+	opts := Options{
+		Logger:         supportlog.DefaultLogger,
+		SigningKeys:    "SBWLXUTJR2CGVPGCZDIGGLQDPX7ZGGBHBFXBJ555MNIQ2PZCCLM643Z3",
+		SEP10JWKS:      `{"keys":[{"kty":"EC","crv":"P-256","alg":"ES256","x":"i8chX_7Slm4VQ_Y6XBWVBnxIO5-XSWH1GJsXWNkal3E","y":"G22r0OgrcQnkfCAqsS6wvtHgR0SbfvXNJy6-jJfvc94"}]}`,
+		OTPTokenKey:    "c2hvcnQ=",
+		OTPEmailSender: "log:",
+	}
+
+	_, err := getHandlerDeps(opts)
+	assert.EqualError(t, err, "OTP token key must be at least 32 bytes when an OTP sender is configured")
+}
+
+func TestGetHandlerDeps_noSEP10JWKS(t *testing.T) {
+	opts := Options{
+		Logger:      supportlog.DefaultLogger,
+		SigningKeys: "SBIB72S6JMTGJRC6LMKLC5XMHZ2IOHZSZH4SASTN47LECEEJ7QEB6EYK",
+	}
+
+	_, err := getHandlerDeps(opts)
+	assert.EqualError(t, err, "SEP-10 JSON Web Key (JWK) Set or its URL is required")
+}
@@ -0,0 +1,154 @@
+package signrequest
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type DBStore struct {
+	DB *sqlx.DB
+}
+
+const selectRequests = `SELECT
+		sign_requests.id,
+		accounts.address,
+		sign_requests.signing_address,
+		sign_requests.transaction_hash,
+		sign_requests.transaction,
+		sign_requests.requested_by,
+		sign_requests.status,
+		sign_requests.created_at,
+		sign_requests.available_at,
+		sign_requests.cancelled_at,
+		COALESCE(sign_requests.cancelled_by, '') AS cancelled_by,
+		sign_requests.signed_at
+	FROM sign_requests
+	JOIN accounts ON accounts.id = sign_requests.account_id`
+
+type requestRow struct {
+	ID              int64      `db:"id"`
+	Address         string     `db:"address"`
+	SigningAddress  string     `db:"signing_address"`
+	TransactionHash string     `db:"transaction_hash"`
+	Transaction     string     `db:"transaction"`
+	RequestedBy     string     `db:"requested_by"`
+	Status          string     `db:"status"`
+	CreatedAt       time.Time  `db:"created_at"`
+	AvailableAt     time.Time  `db:"available_at"`
+	CancelledAt     *time.Time `db:"cancelled_at"`
+	CancelledBy     string     `db:"cancelled_by"`
+	SignedAt        *time.Time `db:"signed_at"`
+}
+
+func (r requestRow) request() Request {
+	return Request{
+		ID:              r.ID,
+		Address:         r.Address,
+		SigningAddress:  r.SigningAddress,
+		TransactionHash: r.TransactionHash,
+		Transaction:     r.Transaction,
+		RequestedBy:     r.RequestedBy,
+		Status:          Status(r.Status),
+		CreatedAt:       r.CreatedAt,
+		AvailableAt:     r.AvailableAt,
+		CancelledAt:     r.CancelledAt,
+		CancelledBy:     r.CancelledBy,
+		SignedAt:        r.SignedAt,
+	}
+}
+
+func (s *DBStore) getRequest(q sqlx.Queryer, where string, args ...interface{}) (Request, error) {
+	rows := []requestRow{}
+	err := sqlx.Select(q, &rows, selectRequests+`
+		WHERE `+where+`
+		ORDER BY sign_requests.id DESC
+		LIMIT 1`, args...)
+	if err != nil {
+		return Request{}, err
+	}
+	if len(rows) == 0 {
+		return Request{}, ErrNotFound
+	}
+	return rows[0].request(), nil
+}
+
+// lockAccount locks the account until the end of the transaction, so that
+// the requests signed for it are counted consistently, and returns its id.
+func lockAccount(tx *sqlx.Tx, address string) (int64, error) {
+	accountID := int64(0)
+	err := tx.Get(&accountID, `
+		SELECT id
+		FROM accounts
+		WHERE address = $1
+		FOR UPDATE
+	`, address)
+	if err != nil {
+		return 0, err
+	}
+	return accountID, nil
+}
+
+// checkLimit returns ErrLimitReached if the limit of requests signed for the
+// account has been reached.
+func checkLimit(tx *sqlx.Tx, accountID int64, limit Limit) error {
+	if limit.Max == 0 {
+		return nil
+	}
+	count := 0
+	err := tx.Get(&count, `
+		SELECT COUNT(*)
+		FROM sign_requests
+		WHERE account_id = $1 AND status = 'signed' AND signed_at >= $2
+	`, accountID, limit.Since)
+	if err != nil {
+		return err
+	}
+	if count >= limit.Max {
+		return ErrLimitReached
+	}
+	return nil
+}
+
+// updatePending updates a pending request of the account with the account
+// locked, and returns the updated request.
+func (s *DBStore) updatePending(address string, id int64, update func(tx *sqlx.Tx, accountID int64) error) (Request, error) {
+	tx, err := s.DB.Beginx()
+	if err != nil {
+		return Request{}, err
+	}
+	defer tx.Rollback()
+
+	accountID, err := lockAccount(tx, address)
+	if err == sql.ErrNoRows {
+		return Request{}, ErrNotFound
+	} else if err != nil {
+		return Request{}, err
+	}
+
+	r, err := s.getRequest(tx, "sign_requests.account_id = $1 AND sign_requests.id = $2", accountID, id)
+	if err != nil {
+		return Request{}, err
+	}
+	if r.Status != StatusPending {
+		return Request{}, ErrNotPending
+	}
+
+	err = update(tx, accountID)
+	if err != nil {
+		return Request{}, err
+	}
+
+	r, err = s.getRequest(tx, "sign_requests.id = $1", id)
+	if err != nil {
+		return Request{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return Request{}, err
+	}
+
+	return r, nil
+}
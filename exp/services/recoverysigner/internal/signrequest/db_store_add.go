@@ -0,0 +1,49 @@
+package signrequest
+
+import (
+	"database/sql"
+)
+
+func (s *DBStore) Add(r Request, limit Limit) (Request, error) {
+	tx, err := s.DB.Beginx()
+	if err != nil {
+		return Request{}, err
+	}
+	defer tx.Rollback()
+
+	accountID, err := lockAccount(tx, r.Address)
+	if err == sql.ErrNoRows {
+		return Request{}, ErrNotFound
+	} else if err != nil {
+		return Request{}, err
+	}
+
+	if r.Status == StatusSigned {
+		err = checkLimit(tx, accountID, limit)
+		if err != nil {
+			return Request{}, err
+		}
+	}
+
+	id := int64(0)
+	err = tx.Get(&id, `
+		INSERT INTO sign_requests (account_id, signing_address, transaction_hash, transaction, requested_by, status, available_at, signed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, CASE WHEN $8 THEN NOW() END)
+		RETURNING id
+	`, accountID, r.SigningAddress, r.TransactionHash, r.Transaction, r.RequestedBy, r.Status, r.AvailableAt, r.Status == StatusSigned)
+	if err != nil {
+		return Request{}, err
+	}
+
+	added, err := s.getRequest(tx, "sign_requests.id = $1", id)
+	if err != nil {
+		return Request{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return Request{}, err
+	}
+
+	return added, nil
+}
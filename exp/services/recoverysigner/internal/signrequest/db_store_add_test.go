@@ -0,0 +1,95 @@
+package signrequest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shantanu-hashcash/go/exp/services/recoverysigner/internal/account"
+	"github.com/shantanu-hashcash/go/exp/services/recoverysigner/internal/db/dbtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAddress = "GCLLT3VG4F6EZAHZEBKWBWV5JGVPCVIKUCGTY3QEOAIZU5IJGMWCT2TT"
+const testSigningAddress = "GBOG4KF66M4AFRBUHOTJQJRO7BGGFCSGIICTI5BHXHKXCWV2C67QRN5H"
+
+func newTestStore(t *testing.T) *DBStore {
+	session := dbtest.Open(t).Open()
+	accountStore := account.DBStore{DB: session}
+	err := accountStore.Add(account.Account{Address: testAddress})
+	require.NoError(t, err)
+	return &DBStore{DB: session}
+}
+
+func TestAdd(t *testing.T) {
+	store := newTestStore(t)
+
+	availableAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	r, err := store.Add(Request{
+		Address:         testAddress,
+		SigningAddress:  testSigningAddress,
+		TransactionHash: "hash1",
+		Transaction:     "tx1",
+		RequestedBy:     "owner",
+		Status:          StatusPending,
+		AvailableAt:     availableAt,
+	}, Limit{})
+	require.NoError(t, err)
+
+	assert.NotZero(t, r.ID)
+	assert.Equal(t, testAddress, r.Address)
+	assert.Equal(t, testSigningAddress, r.SigningAddress)
+	assert.Equal(t, "hash1", r.TransactionHash)
+	assert.Equal(t, "tx1", r.Transaction)
+	assert.Equal(t, "owner", r.RequestedBy)
+	assert.Equal(t, StatusPending, r.Status)
+	assert.True(t, availableAt.Equal(r.AvailableAt))
+	assert.Nil(t, r.SignedAt)
+	assert.Nil(t, r.CancelledAt)
+}
+
+func TestAdd_signedWithinLimit(t *testing.T) {
+	store := newTestStore(t)
+
+	limit := Limit{Max: 2, Since: time.Now().Add(-time.Hour)}
+	for i := 0; i < 2; i++ {
+		r, err := store.Add(Request{
+			Address:        testAddress,
+			SigningAddress: testSigningAddress,
+			Status:         StatusSigned,
+			AvailableAt:    time.Now(),
+		}, limit)
+		require.NoError(t, err)
+		assert.Equal(t, StatusSigned, r.Status)
+		assert.NotNil(t, r.SignedAt)
+	}
+
+	_, err := store.Add(Request{
+		Address:        testAddress,
+		SigningAddress: testSigningAddress,
+		Status:         StatusSigned,
+		AvailableAt:    time.Now(),
+	}, limit)
+	assert.Equal(t, ErrLimitReached, err)
+
+	// Signatures before the limit's window are not counted.
+	_, err = store.Add(Request{
+		Address:        testAddress,
+		SigningAddress: testSigningAddress,
+		Status:         StatusSigned,
+		AvailableAt:    time.Now(),
+	}, Limit{Max: 2, Since: time.Now().Add(time.Hour)})
+	assert.NoError(t, err)
+}
+
+func TestAdd_accountNotFound(t *testing.T) {
+	store := newTestStore(t)
+
+	_, err := store.Add(Request{
+		Address:        "GDU2CH4BBE7HM5WJW7AFTJZT4P6QKU3QRFGN7Q4LRP5LZAEZS6YSFCAO",
+		SigningAddress: testSigningAddress,
+		Status:         StatusPending,
+		AvailableAt:    time.Now(),
+	}, Limit{})
+	assert.Equal(t, ErrNotFound, err)
+}
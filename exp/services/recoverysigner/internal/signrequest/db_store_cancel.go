@@ -0,0 +1,14 @@
+package signrequest
+
+import "github.com/jmoiron/sqlx"
+
+func (s *DBStore) Cancel(address string, id int64, cancelledBy string) (Request, error) {
+	return s.updatePending(address, id, func(tx *sqlx.Tx, accountID int64) error {
+		_, err := tx.Exec(`
+			UPDATE sign_requests
+			SET status = 'cancelled', cancelled_at = NOW(), cancelled_by = $2, updated_at = NOW()
+			WHERE id = $1
+		`, id, cancelledBy)
+		return err
+	})
+}
@@ -0,0 +1,33 @@
+package signrequest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCancel(t *testing.T) {
+	store := newTestStore(t)
+
+	added, err := store.Add(Request{
+		Address:        testAddress,
+		SigningAddress: testSigningAddress,
+		Status:         StatusPending,
+		AvailableAt:    time.Now(),
+	}, Limit{})
+	require.NoError(t, err)
+
+	r, err := store.Cancel(testAddress, added.ID, "receiver")
+	require.NoError(t, err)
+	assert.Equal(t, StatusCancelled, r.Status)
+	assert.Equal(t, "receiver", r.CancelledBy)
+	assert.NotNil(t, r.CancelledAt)
+
+	_, err = store.Cancel(testAddress, added.ID, "receiver")
+	assert.Equal(t, ErrNotPending, err)
+
+	_, err = store.Cancel(testAddress, added.ID+1, "receiver")
+	assert.Equal(t, ErrNotFound, err)
+}
@@ -0,0 +1,9 @@
+package signrequest
+
+func (s *DBStore) Get(address string, id int64) (Request, error) {
+	return s.getRequest(s.DB, "accounts.address = $1 AND sign_requests.id = $2", address, id)
+}
+
+func (s *DBStore) FindWithTransactionHash(address, signingAddress, transactionHash string) (Request, error) {
+	return s.getRequest(s.DB, "accounts.address = $1 AND sign_requests.signing_address = $2 AND sign_requests.transaction_hash = $3", address, signingAddress, transactionHash)
+}
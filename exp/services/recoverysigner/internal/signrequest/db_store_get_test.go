@@ -0,0 +1,62 @@
+package signrequest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGet(t *testing.T) {
+	store := newTestStore(t)
+
+	added, err := store.Add(Request{
+		Address:         testAddress,
+		SigningAddress:  testSigningAddress,
+		TransactionHash: "hash1",
+		Status:          StatusPending,
+		AvailableAt:     time.Now(),
+	}, Limit{})
+	require.NoError(t, err)
+
+	r, err := store.Get(testAddress, added.ID)
+	require.NoError(t, err)
+	assert.Equal(t, added, r)
+
+	_, err = store.Get("GDU2CH4BBE7HM5WJW7AFTJZT4P6QKU3QRFGN7Q4LRP5LZAEZS6YSFCAO", added.ID)
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestFindWithTransactionHash(t *testing.T) {
+	store := newTestStore(t)
+
+	_, err := store.FindWithTransactionHash(testAddress, testSigningAddress, "hash1")
+	assert.Equal(t, ErrNotFound, err)
+
+	first, err := store.Add(Request{
+		Address:         testAddress,
+		SigningAddress:  testSigningAddress,
+		TransactionHash: "hash1",
+		Status:          StatusPending,
+		AvailableAt:     time.Now(),
+	}, Limit{})
+	require.NoError(t, err)
+	_, err = store.Cancel(testAddress, first.ID, "owner")
+	require.NoError(t, err)
+	latest, err := store.Add(Request{
+		Address:         testAddress,
+		SigningAddress:  testSigningAddress,
+		TransactionHash: "hash1",
+		Status:          StatusPending,
+		AvailableAt:     time.Now(),
+	}, Limit{})
+	require.NoError(t, err)
+
+	r, err := store.FindWithTransactionHash(testAddress, testSigningAddress, "hash1")
+	require.NoError(t, err)
+	assert.Equal(t, latest.ID, r.ID)
+
+	_, err = store.FindWithTransactionHash(testAddress, "GAPE22DOMALCH42VOR4S3HN6KIZZ643G7D3GNTYF4YOWWXP6UVRAF5JS", "hash1")
+	assert.Equal(t, ErrNotFound, err)
+}
@@ -0,0 +1,18 @@
+package signrequest
+
+func (s *DBStore) ListPending(address string) ([]Request, error) {
+	rows := []requestRow{}
+	err := s.DB.Select(&rows, selectRequests+`
+		WHERE accounts.address = $1 AND sign_requests.status = 'pending'
+		ORDER BY sign_requests.id
+	`, address)
+	if err != nil {
+		return nil, err
+	}
+
+	requests := []Request{}
+	for _, r := range rows {
+		requests = append(requests, r.request())
+	}
+	return requests, nil
+}
@@ -0,0 +1,36 @@
+package signrequest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListPending(t *testing.T) {
+	store := newTestStore(t)
+
+	requests, err := store.ListPending(testAddress)
+	require.NoError(t, err)
+	assert.Empty(t, requests)
+
+	pending, err := store.Add(Request{
+		Address:        testAddress,
+		SigningAddress: testSigningAddress,
+		Status:         StatusPending,
+		AvailableAt:    time.Now(),
+	}, Limit{})
+	require.NoError(t, err)
+	_, err = store.Add(Request{
+		Address:        testAddress,
+		SigningAddress: testSigningAddress,
+		Status:         StatusSigned,
+		AvailableAt:    time.Now(),
+	}, Limit{})
+	require.NoError(t, err)
+
+	requests, err = store.ListPending(testAddress)
+	require.NoError(t, err)
+	assert.Equal(t, []Request{pending}, requests)
+}
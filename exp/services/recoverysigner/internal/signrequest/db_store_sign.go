@@ -0,0 +1,18 @@
+package signrequest
+
+import "github.com/jmoiron/sqlx"
+
+func (s *DBStore) Sign(address string, id int64, limit Limit) (Request, error) {
+	return s.updatePending(address, id, func(tx *sqlx.Tx, accountID int64) error {
+		err := checkLimit(tx, accountID, limit)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(`
+			UPDATE sign_requests
+			SET status = 'signed', signed_at = NOW(), updated_at = NOW()
+			WHERE id = $1
+		`, id)
+		return err
+	})
+}
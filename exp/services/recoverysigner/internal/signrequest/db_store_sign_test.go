@@ -0,0 +1,55 @@
+package signrequest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSign(t *testing.T) {
+	store := newTestStore(t)
+
+	added, err := store.Add(Request{
+		Address:        testAddress,
+		SigningAddress: testSigningAddress,
+		Status:         StatusPending,
+		AvailableAt:    time.Now(),
+	}, Limit{})
+	require.NoError(t, err)
+
+	r, err := store.Sign(testAddress, added.ID, Limit{Max: 1, Since: time.Now().Add(-time.Hour)})
+	require.NoError(t, err)
+	assert.Equal(t, StatusSigned, r.Status)
+	assert.NotNil(t, r.SignedAt)
+
+	_, err = store.Sign(testAddress, added.ID, Limit{})
+	assert.Equal(t, ErrNotPending, err)
+}
+
+func TestSign_limitReached(t *testing.T) {
+	store := newTestStore(t)
+
+	_, err := store.Add(Request{
+		Address:        testAddress,
+		SigningAddress: testSigningAddress,
+		Status:         StatusSigned,
+		AvailableAt:    time.Now(),
+	}, Limit{})
+	require.NoError(t, err)
+	added, err := store.Add(Request{
+		Address:        testAddress,
+		SigningAddress: testSigningAddress,
+		Status:         StatusPending,
+		AvailableAt:    time.Now(),
+	}, Limit{})
+	require.NoError(t, err)
+
+	_, err = store.Sign(testAddress, added.ID, Limit{Max: 1, Since: time.Now().Add(-time.Hour)})
+	assert.Equal(t, ErrLimitReached, err)
+
+	r, err := store.Get(testAddress, added.ID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusPending, r.Status)
+}
@@ -0,0 +1,36 @@
+package signrequest
+
+import "time"
+
+// Request is a request to sign a transaction for an account. Requests are
+// recorded for every transaction signed, and, when signing is time-locked,
+// are pending until they become available or are cancelled.
+type Request struct {
+	ID              int64
+	Address         string
+	SigningAddress  string
+	TransactionHash string
+	Transaction     string
+	RequestedBy     string
+	Status          Status
+	CreatedAt       time.Time
+	AvailableAt     time.Time
+	CancelledAt     *time.Time
+	CancelledBy     string
+	SignedAt        *time.Time
+}
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusCancelled Status = "cancelled"
+	StatusSigned    Status = "signed"
+)
+
+// Limit is a limit on the number of transactions signed for an account
+// since a point in time. There is no limit if Max is zero.
+type Limit struct {
+	Max   int
+	Since time.Time
+}
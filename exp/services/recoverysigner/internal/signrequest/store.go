@@ -0,0 +1,22 @@
+package signrequest
+
+import "errors"
+
+type Store interface {
+	// Add adds a request. A request added as signed counts towards the
+	// limit, and is not added if the limit has been reached.
+	Add(r Request, limit Limit) (Request, error)
+	Get(address string, id int64) (Request, error)
+	// FindWithTransactionHash returns the latest request to sign the
+	// transaction with the signing address.
+	FindWithTransactionHash(address, signingAddress, transactionHash string) (Request, error)
+	ListPending(address string) ([]Request, error)
+	Cancel(address string, id int64, cancelledBy string) (Request, error)
+	// Sign marks a pending request signed, unless the limit has been
+	// reached.
+	Sign(address string, id int64, limit Limit) (Request, error)
+}
+
+var ErrNotFound = errors.New("sign request not found")
+var ErrNotPending = errors.New("sign request not pending")
+var ErrLimitReached = errors.New("sign limit reached")
@@ -53,7 +53,7 @@ func (c *ServeCommand) Command() *cobra.Command {
 		},
 		{
 			Name:      "domain",
-			Usage:     "Domain that this this service is hosted at",
+			Usage:     "Domain that this service is hosted at",
 			OptType:   types.String,
 			ConfigKey: &opts.Domain,
 			Required:  true,
@@ -76,11 +76,27 @@ func (c *ServeCommand) Command() *cobra.Command {
 		},
 		{
 			Name:      "jwk",
-			Usage:     "JSON Web Key (JWK) used for signing JWTs (if the key is an asymmetric key that has separate public and private key, the JWK must contain the private key)",
+			Usage:     "JSON Web Key (JWK) used for signing JWTs (if the key is an asymmetric key that has separate public and private key, the JWK must contain the private key) (a JSON Web Key Set (JWKS) may be provided instead to rotate keys, the first key is used for signing, others are previous keys published for validating JWTs they signed)",
 			OptType:   types.String,
 			ConfigKey: &opts.JWK,
 			Required:  true,
 		},
+		{
+			Name:      "jwk-rotated-at",
+			Usage:     "The time in RFC 3339 format at which the JWK used for signing replaced the previous keys (previous keys are published indefinitely if empty)",
+			OptType:   types.String,
+			ConfigKey: &opts.JWKRotatedAt,
+			Required:  false,
+		},
+		{
+			Name:           "jwk-rotation-grace-period",
+			Usage:          "The time period in seconds after the JWK rotated at time that previous keys are published (defaults to the JWT expires in if zero)",
+			OptType:        types.Int,
+			CustomSetValue: config.SetDuration,
+			ConfigKey:      &opts.JWKRotationGracePeriod,
+			FlagDefault:    0,
+			Required:       false,
+		},
 		{
 			Name:      "jwt-issuer",
 			Usage:     "The issuer to set in the JWT iss claim",
@@ -97,6 +113,31 @@ func (c *ServeCommand) Command() *cobra.Command {
 			FlagDefault:    300,
 			Required:       true,
 		},
+		{
+			Name:           "refresh-token-expires-in",
+			Usage:          "The time period in seconds after which a refresh token expires if not used (refresh tokens are not issued if zero)",
+			OptType:        types.Int,
+			CustomSetValue: config.SetDuration,
+			ConfigKey:      &opts.RefreshTokenExpiresIn,
+			FlagDefault:    0,
+			Required:       false,
+		},
+		{
+			Name:        "db-url",
+			Usage:       "Database URL used for storing refresh tokens (only used if refresh tokens are issued)",
+			OptType:     types.String,
+			ConfigKey:   &opts.DatabaseURL,
+			FlagDefault: "postgres://localhost:5432/?sslmode=disable",
+			Required:    false,
+		},
+		{
+			Name:        "db-max-open-conns",
+			Usage:       "Database max open connections",
+			OptType:     types.Int,
+			ConfigKey:   &opts.DatabaseMaxOpenConns,
+			FlagDefault: 20,
+			Required:    false,
+		},
 		{
 			Name:        "allow-accounts-that-do-not-exist",
 			Usage:       "Allow accounts that do not exist",
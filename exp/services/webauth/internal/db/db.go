@@ -0,0 +1,11 @@
+package db
+
+import (
+	_ "github.com/lib/pq"
+
+	supportdb "github.com/shantanu-hashcash/go/support/db"
+)
+
+func Open(dataSourceName string) (*supportdb.Session, error) {
+	return supportdb.Open("postgres", dataSourceName)
+}
@@ -0,0 +1,38 @@
+package dbmigrate
+
+import (
+	"embed"
+
+	migrate "github.com/rubenv/sql-migrate"
+
+	supportdb "github.com/shantanu-hashcash/go/support/db"
+)
+
+//go:embed migrations/*.sql
+var migrationFS embed.FS
+
+var migrationSource = &migrate.EmbedFileSystemMigrationSource{
+	FileSystem: migrationFS,
+	Root:       "migrations",
+}
+
+// PlanMigration finds the migrations that would be applied if Migrate was to
+// be run now.
+func PlanMigration(session *supportdb.Session, dir migrate.MigrationDirection, count int) ([]string, error) {
+	migrations, _, err := migrate.PlanMigration(session.DB.DB, session.Dialect(), migrationSource, dir, count)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(migrations))
+	for _, m := range migrations {
+		ids = append(ids, m.Id)
+	}
+	return ids, nil
+}
+
+// Migrate runs all the migrations to get the database to the state described
+// by the migration files in the direction specified. Count is the maximum
+// number of migrations to apply or rollback.
+func Migrate(session *supportdb.Session, dir migrate.MigrationDirection, count int) (int, error) {
+	return migrate.ExecMax(session.DB.DB, session.Dialect(), migrationSource, dir, count)
+}
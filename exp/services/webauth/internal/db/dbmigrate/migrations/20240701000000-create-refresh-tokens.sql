@@ -0,0 +1,23 @@
+-- +migrate Up
+
+CREATE TABLE refresh_tokens (
+    hash text PRIMARY KEY,
+    family text NOT NULL,
+    subject text NOT NULL,
+    expires_at timestamp with time zone NOT NULL,
+    used boolean NOT NULL DEFAULT false
+);
+
+CREATE INDEX refresh_tokens_family_idx ON refresh_tokens (family);
+CREATE INDEX refresh_tokens_expires_at_idx ON refresh_tokens (expires_at);
+
+CREATE TABLE refresh_token_revoked_families (
+    family text PRIMARY KEY,
+    expires_at timestamp with time zone NOT NULL
+);
+
+-- +migrate Down
+
+DROP TABLE refresh_token_revoked_families;
+
+DROP TABLE refresh_tokens;
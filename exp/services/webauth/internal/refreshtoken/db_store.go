@@ -0,0 +1,169 @@
+package refreshtoken
+
+import (
+	"context"
+	"time"
+
+	supportdb "github.com/shantanu-hashcash/go/support/db"
+)
+
+// DBStore is a Store that keeps refresh tokens in Postgres, so that they
+// survive restarts and are shared between instances.
+type DBStore struct {
+	DB *supportdb.Session
+	// Now returns the current time, defaulting to time.Now.
+	Now func() time.Time
+}
+
+var _ Store = (*DBStore)(nil)
+
+type tokenRow struct {
+	Hash      string    `db:"hash"`
+	Family    string    `db:"family"`
+	Subject   string    `db:"subject"`
+	ExpiresAt time.Time `db:"expires_at"`
+	Used      bool      `db:"used"`
+	Revoked   bool      `db:"revoked"`
+}
+
+func (s *DBStore) Add(ctx context.Context, t Token) error {
+	session := s.DB.Clone()
+	err := session.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer session.Rollback()
+
+	err = prune(ctx, session, s.now())
+	if err != nil {
+		return err
+	}
+	_, err = session.ExecRaw(ctx,
+		`INSERT INTO refresh_tokens (hash, family, subject, expires_at) VALUES ($1, $2, $3, $4)`,
+		t.Hash, t.Family, t.Subject, t.ExpiresAt,
+	)
+	if err != nil {
+		return err
+	}
+	return session.Commit()
+}
+
+func (s *DBStore) Rotate(ctx context.Context, hash string, next Token) (Token, error) {
+	session := s.DB.Clone()
+	err := session.Begin(ctx)
+	if err != nil {
+		return Token{}, err
+	}
+	defer session.Rollback()
+
+	now := s.now()
+	err = prune(ctx, session, now)
+	if err != nil {
+		return Token{}, err
+	}
+
+	row := tokenRow{}
+	err = session.GetRaw(ctx, &row,
+		`SELECT t.hash, t.family, t.subject, t.expires_at, t.used, r.family IS NOT NULL AS revoked
+		FROM refresh_tokens t
+		LEFT JOIN refresh_token_revoked_families r ON r.family = t.family
+		WHERE t.hash = $1
+		FOR UPDATE OF t`,
+		hash,
+	)
+	if session.NoRows(err) {
+		return Token{}, ErrNotFound
+	} else if err != nil {
+		return Token{}, err
+	}
+	if row.Revoked {
+		return Token{}, ErrRevoked
+	}
+	if row.Used {
+		err = revoke(ctx, session, row.Family)
+		if err != nil {
+			return Token{}, err
+		}
+		err = session.Commit()
+		if err != nil {
+			return Token{}, err
+		}
+		return Token{}, ErrReused
+	}
+
+	_, err = session.ExecRaw(ctx, `UPDATE refresh_tokens SET used = true WHERE hash = $1`, hash)
+	if err != nil {
+		return Token{}, err
+	}
+	next.Family = row.Family
+	next.Subject = row.Subject
+	_, err = session.ExecRaw(ctx,
+		`INSERT INTO refresh_tokens (hash, family, subject, expires_at) VALUES ($1, $2, $3, $4)`,
+		next.Hash, next.Family, next.Subject, next.ExpiresAt,
+	)
+	if err != nil {
+		return Token{}, err
+	}
+	err = session.Commit()
+	if err != nil {
+		return Token{}, err
+	}
+	return next, nil
+}
+
+func (s *DBStore) Revoke(ctx context.Context, hash string) error {
+	session := s.DB.Clone()
+	err := session.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer session.Rollback()
+
+	err = prune(ctx, session, s.now())
+	if err != nil {
+		return err
+	}
+
+	var family string
+	err = session.GetRaw(ctx, &family, `SELECT family FROM refresh_tokens WHERE hash = $1`, hash)
+	if session.NoRows(err) {
+		return ErrNotFound
+	} else if err != nil {
+		return err
+	}
+	err = revoke(ctx, session, family)
+	if err != nil {
+		return err
+	}
+	return session.Commit()
+}
+
+// revoke adds the family to the revocation list until the last of its refresh
+// tokens expires.
+func revoke(ctx context.Context, session supportdb.SessionInterface, family string) error {
+	_, err := session.ExecRaw(ctx,
+		`INSERT INTO refresh_token_revoked_families (family, expires_at)
+		SELECT family, MAX(expires_at) FROM refresh_tokens WHERE family = $1 GROUP BY family
+		ON CONFLICT (family) DO UPDATE SET expires_at = GREATEST(refresh_token_revoked_families.expires_at, EXCLUDED.expires_at)`,
+		family,
+	)
+	return err
+}
+
+// prune removes expired refresh tokens, and revoked families that no longer
+// have refresh tokens that have not expired.
+func prune(ctx context.Context, session supportdb.SessionInterface, now time.Time) error {
+	_, err := session.ExecRaw(ctx, `DELETE FROM refresh_tokens WHERE expires_at <= $1`, now)
+	if err != nil {
+		return err
+	}
+	_, err = session.ExecRaw(ctx, `DELETE FROM refresh_token_revoked_families WHERE expires_at <= $1`, now)
+	return err
+}
+
+func (s *DBStore) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
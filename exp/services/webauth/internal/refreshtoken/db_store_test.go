@@ -0,0 +1,101 @@
+package refreshtoken
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shantanu-hashcash/go/exp/services/webauth/internal/db/dbtest"
+	supportdb "github.com/shantanu-hashcash/go/support/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDBStore(t *testing.T, now *time.Time) *DBStore {
+	db := dbtest.Open(t)
+	t.Cleanup(db.Close)
+	session := &supportdb.Session{DB: db.Open()}
+	t.Cleanup(func() { session.Close() })
+	return &DBStore{DB: session, Now: func() time.Time { return *now }}
+}
+
+func TestDBStore_rotate(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := newDBStore(t, &now)
+
+	err := s.Add(ctx, Token{Hash: "a", Family: "f", Subject: "GA", ExpiresAt: now.Add(time.Hour)})
+	require.NoError(t, err)
+
+	next, err := s.Rotate(ctx, "a", Token{Hash: "b", ExpiresAt: now.Add(2 * time.Hour)})
+	require.NoError(t, err)
+	assert.Equal(t, Token{Hash: "b", Family: "f", Subject: "GA", ExpiresAt: now.Add(2 * time.Hour)}, next)
+
+	next, err = s.Rotate(ctx, "b", Token{Hash: "c", ExpiresAt: now.Add(2 * time.Hour)})
+	require.NoError(t, err)
+	assert.Equal(t, "GA", next.Subject)
+
+	_, err = s.Rotate(ctx, "unknown", Token{Hash: "d", ExpiresAt: now.Add(2 * time.Hour)})
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestDBStore_rotateReused(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := newDBStore(t, &now)
+
+	err := s.Add(ctx, Token{Hash: "a", Family: "f", Subject: "GA", ExpiresAt: now.Add(time.Hour)})
+	require.NoError(t, err)
+	_, err = s.Rotate(ctx, "a", Token{Hash: "b", ExpiresAt: now.Add(time.Hour)})
+	require.NoError(t, err)
+
+	// Reusing the first token revokes the family, including the token it
+	// was rotated for.
+	_, err = s.Rotate(ctx, "a", Token{Hash: "c", ExpiresAt: now.Add(time.Hour)})
+	assert.Equal(t, ErrReused, err)
+	_, err = s.Rotate(ctx, "b", Token{Hash: "c", ExpiresAt: now.Add(time.Hour)})
+	assert.Equal(t, ErrRevoked, err)
+}
+
+func TestDBStore_revoke(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := newDBStore(t, &now)
+
+	err := s.Add(ctx, Token{Hash: "a", Family: "f", Subject: "GA", ExpiresAt: now.Add(time.Hour)})
+	require.NoError(t, err)
+	err = s.Add(ctx, Token{Hash: "x", Family: "g", Subject: "GB", ExpiresAt: now.Add(time.Hour)})
+	require.NoError(t, err)
+
+	err = s.Revoke(ctx, "a")
+	require.NoError(t, err)
+	_, err = s.Rotate(ctx, "a", Token{Hash: "b", ExpiresAt: now.Add(time.Hour)})
+	assert.Equal(t, ErrRevoked, err)
+
+	// Other families are unaffected.
+	_, err = s.Rotate(ctx, "x", Token{Hash: "y", ExpiresAt: now.Add(time.Hour)})
+	assert.NoError(t, err)
+
+	err = s.Revoke(ctx, "unknown")
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestDBStore_expired(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := newDBStore(t, &now)
+
+	err := s.Add(ctx, Token{Hash: "a", Family: "f", Subject: "GA", ExpiresAt: now.Add(time.Hour)})
+	require.NoError(t, err)
+	err = s.Revoke(ctx, "a")
+	require.NoError(t, err)
+
+	now = now.Add(time.Hour)
+	_, err = s.Rotate(ctx, "a", Token{Hash: "b", ExpiresAt: now.Add(time.Hour)})
+	assert.Equal(t, ErrNotFound, err)
+
+	var count int
+	err = s.DB.GetRaw(ctx, &count, `SELECT (SELECT COUNT(*) FROM refresh_tokens) + (SELECT COUNT(*) FROM refresh_token_revoked_families)`)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
@@ -0,0 +1,62 @@
+// Package refreshtoken provides refresh tokens that are exchanged for new
+// JWTs without completing another SEP-10 challenge.
+//
+// Refresh tokens are opaque random strings. Only the hash of a refresh token
+// is stored. Every refresh token is used once: refreshing rotates it for a new
+// refresh token in the same family. If a refresh token that has already been
+// used is presented again the whole family is revoked, since either the
+// client or someone who has stolen the token is replaying it.
+package refreshtoken
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/shantanu-hashcash/go/support/errors"
+)
+
+const tokenBytes = 32
+
+// Token is the stored record of a refresh token.
+type Token struct {
+	// Hash is the hash of the refresh token, as returned by Hash.
+	Hash string
+	// Family identifies the refresh tokens that were rotated from the same
+	// original refresh token.
+	Family string
+	// Subject is the sub claim of the JWTs issued for the refresh token.
+	Subject   string
+	ExpiresAt time.Time
+}
+
+// Generate returns a new random refresh token and its hash.
+func Generate() (token string, hash string, err error) {
+	token, err = random()
+	if err != nil {
+		return "", "", err
+	}
+	return token, Hash(token), nil
+}
+
+// NewFamily returns a new random family identifier.
+func NewFamily() (string, error) {
+	return random()
+}
+
+// Hash returns the hash of the refresh token that it is stored with.
+func Hash(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
+
+func random() (string, error) {
+	b := make([]byte, tokenBytes)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", errors.Wrap(err, "generating random bytes")
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
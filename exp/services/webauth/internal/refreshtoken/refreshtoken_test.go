@@ -0,0 +1,20 @@
+package refreshtoken
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	token, hash, err := Generate()
+	require.NoError(t, err)
+	assert.Len(t, token, 43)
+	assert.Equal(t, Hash(token), hash)
+	assert.NotEqual(t, token, hash)
+
+	token2, _, err := Generate()
+	require.NoError(t, err)
+	assert.NotEqual(t, token, token2)
+}
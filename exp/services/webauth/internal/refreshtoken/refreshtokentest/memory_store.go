@@ -0,0 +1,115 @@
+// Package refreshtokentest provides an in-memory refresh token store for tests.
+package refreshtokentest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/shantanu-hashcash/go/exp/services/webauth/internal/refreshtoken"
+)
+
+// MemoryStore is a refreshtoken.Store that keeps refresh tokens in memory. It
+// is intended for tests; refresh tokens are lost when the process exits and are
+// not shared between instances.
+type MemoryStore struct {
+	// Now returns the current time, defaulting to time.Now.
+	Now func() time.Time
+
+	mu      sync.Mutex
+	tokens  map[string]memoryToken
+	revoked map[string]time.Time
+}
+
+type memoryToken struct {
+	refreshtoken.Token
+	Used bool
+}
+
+var _ refreshtoken.Store = (*MemoryStore)(nil)
+
+func (s *MemoryStore) Add(ctx context.Context, t refreshtoken.Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prune(s.now())
+	s.tokens[t.Hash] = memoryToken{Token: t}
+	return nil
+}
+
+func (s *MemoryStore) Rotate(ctx context.Context, hash string, next refreshtoken.Token) (refreshtoken.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	s.prune(now)
+
+	t, ok := s.tokens[hash]
+	if !ok {
+		return refreshtoken.Token{}, refreshtoken.ErrNotFound
+	}
+	if _, ok := s.revoked[t.Family]; ok {
+		return refreshtoken.Token{}, refreshtoken.ErrRevoked
+	}
+	if t.Used {
+		s.revoke(t.Family)
+		return refreshtoken.Token{}, refreshtoken.ErrReused
+	}
+
+	t.Used = true
+	s.tokens[hash] = t
+	next.Family = t.Family
+	next.Subject = t.Subject
+	s.tokens[next.Hash] = memoryToken{Token: next}
+	return next, nil
+}
+
+func (s *MemoryStore) Revoke(ctx context.Context, hash string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prune(s.now())
+
+	t, ok := s.tokens[hash]
+	if !ok {
+		return refreshtoken.ErrNotFound
+	}
+	s.revoke(t.Family)
+	return nil
+}
+
+// revoke adds the family to the revocation list until the last of its refresh
+// tokens expires. It must be called with the lock held.
+func (s *MemoryStore) revoke(family string) {
+	var expiresAt time.Time
+	for _, t := range s.tokens {
+		if t.Family == family && t.ExpiresAt.After(expiresAt) {
+			expiresAt = t.ExpiresAt
+		}
+	}
+	s.revoked[family] = expiresAt
+}
+
+// prune removes expired refresh tokens, and revoked families that no longer
+// have refresh tokens that have not expired. It must be called with the lock
+// held.
+func (s *MemoryStore) prune(now time.Time) {
+	if s.tokens == nil {
+		s.tokens = map[string]memoryToken{}
+		s.revoked = map[string]time.Time{}
+	}
+	for hash, t := range s.tokens {
+		if !now.Before(t.ExpiresAt) {
+			delete(s.tokens, hash)
+		}
+	}
+	for family, expiresAt := range s.revoked {
+		if !now.Before(expiresAt) {
+			delete(s.revoked, family)
+		}
+	}
+}
+
+func (s *MemoryStore) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,89 @@
+package refreshtokentest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shantanu-hashcash/go/exp/services/webauth/internal/refreshtoken"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_rotate(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := &MemoryStore{Now: func() time.Time { return now }}
+
+	err := s.Add(ctx, refreshtoken.Token{Hash: "a", Family: "f", Subject: "GA", ExpiresAt: now.Add(time.Hour)})
+	require.NoError(t, err)
+
+	next, err := s.Rotate(ctx, "a", refreshtoken.Token{Hash: "b", ExpiresAt: now.Add(2 * time.Hour)})
+	require.NoError(t, err)
+	assert.Equal(t, refreshtoken.Token{Hash: "b", Family: "f", Subject: "GA", ExpiresAt: now.Add(2 * time.Hour)}, next)
+
+	next, err = s.Rotate(ctx, "b", refreshtoken.Token{Hash: "c", ExpiresAt: now.Add(2 * time.Hour)})
+	require.NoError(t, err)
+	assert.Equal(t, "GA", next.Subject)
+
+	_, err = s.Rotate(ctx, "unknown", refreshtoken.Token{Hash: "d", ExpiresAt: now.Add(2 * time.Hour)})
+	assert.Equal(t, refreshtoken.ErrNotFound, err)
+}
+
+func TestMemoryStore_rotateReused(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := &MemoryStore{Now: func() time.Time { return now }}
+
+	err := s.Add(ctx, refreshtoken.Token{Hash: "a", Family: "f", Subject: "GA", ExpiresAt: now.Add(time.Hour)})
+	require.NoError(t, err)
+	_, err = s.Rotate(ctx, "a", refreshtoken.Token{Hash: "b", ExpiresAt: now.Add(time.Hour)})
+	require.NoError(t, err)
+
+	// Reusing the first token revokes the family, including the token it
+	// was rotated for.
+	_, err = s.Rotate(ctx, "a", refreshtoken.Token{Hash: "c", ExpiresAt: now.Add(time.Hour)})
+	assert.Equal(t, refreshtoken.ErrReused, err)
+	_, err = s.Rotate(ctx, "b", refreshtoken.Token{Hash: "c", ExpiresAt: now.Add(time.Hour)})
+	assert.Equal(t, refreshtoken.ErrRevoked, err)
+}
+
+func TestMemoryStore_revoke(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := &MemoryStore{Now: func() time.Time { return now }}
+
+	err := s.Add(ctx, refreshtoken.Token{Hash: "a", Family: "f", Subject: "GA", ExpiresAt: now.Add(time.Hour)})
+	require.NoError(t, err)
+	err = s.Add(ctx, refreshtoken.Token{Hash: "x", Family: "g", Subject: "GB", ExpiresAt: now.Add(time.Hour)})
+	require.NoError(t, err)
+
+	err = s.Revoke(ctx, "a")
+	require.NoError(t, err)
+	_, err = s.Rotate(ctx, "a", refreshtoken.Token{Hash: "b", ExpiresAt: now.Add(time.Hour)})
+	assert.Equal(t, refreshtoken.ErrRevoked, err)
+
+	// Other families are unaffected.
+	_, err = s.Rotate(ctx, "x", refreshtoken.Token{Hash: "y", ExpiresAt: now.Add(time.Hour)})
+	assert.NoError(t, err)
+
+	err = s.Revoke(ctx, "unknown")
+	assert.Equal(t, refreshtoken.ErrNotFound, err)
+}
+
+func TestMemoryStore_expired(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := &MemoryStore{Now: func() time.Time { return now }}
+
+	err := s.Add(ctx, refreshtoken.Token{Hash: "a", Family: "f", Subject: "GA", ExpiresAt: now.Add(time.Hour)})
+	require.NoError(t, err)
+	err = s.Revoke(ctx, "a")
+	require.NoError(t, err)
+
+	now = now.Add(time.Hour)
+	_, err = s.Rotate(ctx, "a", refreshtoken.Token{Hash: "b", ExpiresAt: now.Add(time.Hour)})
+	assert.Equal(t, refreshtoken.ErrNotFound, err)
+	assert.Empty(t, s.tokens)
+	assert.Empty(t, s.revoked)
+}
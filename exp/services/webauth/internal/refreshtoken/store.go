@@ -0,0 +1,29 @@
+package refreshtoken
+
+import (
+	"context"
+
+	"github.com/shantanu-hashcash/go/support/errors"
+)
+
+var (
+	// ErrNotFound is returned when a refresh token is unknown or expired.
+	ErrNotFound = errors.New("refresh token not found")
+	// ErrRevoked is returned when the family of a refresh token is revoked.
+	ErrRevoked = errors.New("refresh token revoked")
+	// ErrReused is returned when a refresh token that has already been
+	// rotated is rotated again. Its family is revoked.
+	ErrReused = errors.New("refresh token reused")
+)
+
+// Store stores refresh tokens and the list of revoked refresh token families.
+type Store interface {
+	// Add stores a new refresh token.
+	Add(ctx context.Context, t Token) error
+	// Rotate uses the refresh token with the hash and stores the next
+	// refresh token in its family. The family and subject of next are set
+	// from the refresh token used, and next is returned.
+	Rotate(ctx context.Context, hash string, next Token) (Token, error)
+	// Revoke revokes the family of the refresh token with the hash.
+	Revoke(ctx context.Context, hash string) error
+}
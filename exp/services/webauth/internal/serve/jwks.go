@@ -0,0 +1,99 @@
+package serve
+
+import (
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/shantanu-hashcash/go/support/errors"
+	"github.com/shantanu-hashcash/go/support/render/httpjson"
+	"gopkg.in/square/go-jose.v2"
+)
+
+// jwtKeys are the keys of the server for JWTs. JWTs are signed with the
+// signing key. The previous keys are keys that JWTs were signed with before
+// the signing key was rotated, and they are published until the JWTs they
+// signed have expired so that the JWTs continue to be valid.
+type jwtKeys struct {
+	Signing  jose.JSONWebKey
+	Previous []jose.JSONWebKey
+	// PreviousExpireAt is when the previous keys stop being published. The
+	// previous keys are published indefinitely if it is zero.
+	PreviousExpireAt time.Time
+}
+
+// parseJWTKeys parses a JWK, or a JWKS where the first key is the signing key
+// and any other keys are previous keys. Keys without a key ID are given their
+// thumbprint as their key ID.
+func parseJWTKeys(s string) (jwtKeys, error) {
+	jwks := jose.JSONWebKeySet{}
+	err := json.Unmarshal([]byte(s), &jwks)
+	if err != nil || len(jwks.Keys) == 0 {
+		jwk := jose.JSONWebKey{}
+		err = json.Unmarshal([]byte(s), &jwk)
+		if err != nil {
+			return jwtKeys{}, errors.Wrap(err, "parsing JSON Web Key (JWK)")
+		}
+		jwks.Keys = []jose.JSONWebKey{jwk}
+	}
+
+	for i := range jwks.Keys {
+		k := &jwks.Keys[i]
+		if k.Algorithm == "" {
+			return jwtKeys{}, errors.New("algorithm (alg) field must be set")
+		}
+		if k.KeyID == "" {
+			thumbprint, err := k.Thumbprint(crypto.SHA256)
+			if err != nil {
+				return jwtKeys{}, errors.Wrap(err, "computing JSON Web Key (JWK) thumbprint")
+			}
+			k.KeyID = base64.RawURLEncoding.EncodeToString(thumbprint)
+		}
+	}
+	if jwks.Keys[0].IsPublic() {
+		return jwtKeys{}, errors.New("JSON Web Key (JWK) used for signing must contain the private key")
+	}
+
+	return jwtKeys{
+		Signing:  jwks.Keys[0],
+		Previous: jwks.Keys[1:],
+	}, nil
+}
+
+// publicKeySet returns the public keys of the signing key and of the
+// previous keys that have not expired. Symmetric keys are never published.
+func (k jwtKeys) publicKeySet(now time.Time) jose.JSONWebKeySet {
+	keys := []jose.JSONWebKey{k.Signing}
+	if k.PreviousExpireAt.IsZero() || now.Before(k.PreviousExpireAt) {
+		keys = append(keys, k.Previous...)
+	}
+
+	ks := jose.JSONWebKeySet{Keys: []jose.JSONWebKey{}}
+	for _, key := range keys {
+		public := key.Public()
+		if !public.Valid() {
+			continue
+		}
+		public.Use = "sig"
+		ks.Keys = append(ks.Keys, public)
+	}
+	return ks
+}
+
+// jwksHandler serves the JSON Web Key Set containing the public keys that
+// JWTs issued by the server are validated with.
+type jwksHandler struct {
+	Keys jwtKeys
+	Now  func() time.Time
+}
+
+func (h jwksHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	now := time.Now
+	if h.Now != nil {
+		now = h.Now
+	}
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	httpjson.Render(w, h.Keys.publicKeySet(now()), httpjson.JSON)
+}
@@ -0,0 +1,124 @@
+package serve
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shantanu-hashcash/go/exp/support/jwtkey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/square/go-jose.v2"
+)
+
+func TestParseJWTKeys_jwk(t *testing.T) {
+	k, err := jwtkey.GenerateKey()
+	require.NoError(t, err)
+	jwkJSON, err := json.Marshal(jose.JSONWebKey{Key: k, Algorithm: string(jose.ES256)})
+	require.NoError(t, err)
+
+	keys, err := parseJWTKeys(string(jwkJSON))
+	require.NoError(t, err)
+	assert.Equal(t, k, keys.Signing.Key)
+	assert.NotEmpty(t, keys.Signing.KeyID)
+	assert.Empty(t, keys.Previous)
+}
+
+func TestParseJWTKeys_jwks(t *testing.T) {
+	k1, err := jwtkey.GenerateKey()
+	require.NoError(t, err)
+	k2, err := jwtkey.GenerateKey()
+	require.NoError(t, err)
+	jwksJSON, err := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+		{Key: k1, Algorithm: string(jose.ES256), KeyID: "2"},
+		{Key: &k2.PublicKey, Algorithm: string(jose.ES256), KeyID: "1"},
+	}})
+	require.NoError(t, err)
+
+	keys, err := parseJWTKeys(string(jwksJSON))
+	require.NoError(t, err)
+	assert.Equal(t, "2", keys.Signing.KeyID)
+	require.Len(t, keys.Previous, 1)
+	assert.Equal(t, "1", keys.Previous[0].KeyID)
+}
+
+func TestParseJWTKeys_invalid(t *testing.T) {
+	k, err := jwtkey.GenerateKey()
+	require.NoError(t, err)
+
+	_, err = parseJWTKeys(`not json`)
+	assert.EqualError(t, err, "parsing JSON Web Key (JWK): invalid character 'o' in literal null (expecting 'u')")
+
+	jwkJSON, err := json.Marshal(jose.JSONWebKey{Key: k})
+	require.NoError(t, err)
+	_, err = parseJWTKeys(string(jwkJSON))
+	assert.EqualError(t, err, "algorithm (alg) field must be set")
+
+	jwkJSON, err = json.Marshal(jose.JSONWebKey{Key: &k.PublicKey, Algorithm: string(jose.ES256)})
+	require.NoError(t, err)
+	_, err = parseJWTKeys(string(jwkJSON))
+	assert.EqualError(t, err, "JSON Web Key (JWK) used for signing must contain the private key")
+}
+
+func TestJWKS_rotationGracePeriod(t *testing.T) {
+	k1, err := jwtkey.GenerateKey()
+	require.NoError(t, err)
+	k2, err := jwtkey.GenerateKey()
+	require.NoError(t, err)
+
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	h := jwksHandler{
+		Keys: jwtKeys{
+			Signing:          jose.JSONWebKey{Key: k2, Algorithm: string(jose.ES256), KeyID: "2"},
+			Previous:         []jose.JSONWebKey{{Key: k1, Algorithm: string(jose.ES256), KeyID: "1"}},
+			PreviousExpireAt: now.Add(time.Minute),
+		},
+		Now: func() time.Time { return now },
+	}
+
+	get := func() jose.JSONWebKeySet {
+		r := httptest.NewRequest("GET", "/.well-known/jwks.json", nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		resp := w.Result()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json; charset=utf-8", resp.Header.Get("Content-Type"))
+		ks := jose.JSONWebKeySet{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&ks))
+		return ks
+	}
+
+	// Within the grace period previous keys are published, and only the
+	// public keys are published.
+	ks := get()
+	require.Len(t, ks.Keys, 2)
+	assert.Equal(t, "2", ks.Keys[0].KeyID)
+	assert.Equal(t, &k2.PublicKey, ks.Keys[0].Key)
+	assert.Equal(t, "sig", ks.Keys[0].Use)
+	assert.Equal(t, "1", ks.Keys[1].KeyID)
+	assert.Equal(t, &k1.PublicKey, ks.Keys[1].Key)
+
+	// After the grace period only the signing key is published.
+	now = now.Add(time.Minute)
+	ks = get()
+	require.Len(t, ks.Keys, 1)
+	assert.Equal(t, "2", ks.Keys[0].KeyID)
+}
+
+func TestJWKS_symmetricKeysNotPublished(t *testing.T) {
+	h := jwksHandler{
+		Keys: jwtKeys{
+			Signing: jose.JSONWebKey{Key: []byte("secretsecretsecretsecretsecretse"), Algorithm: string(jose.HS256), KeyID: "1"},
+		},
+	}
+	r := httptest.NewRequest("GET", "/.well-known/jwks.json", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	resp := w.Result()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	ks := jose.JSONWebKeySet{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&ks))
+	assert.Empty(t, ks.Keys)
+}
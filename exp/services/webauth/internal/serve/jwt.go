@@ -0,0 +1,36 @@
+package serve
+
+import (
+	"time"
+
+	"github.com/shantanu-hashcash/go/support/errors"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+// issueJWT returns a JWT for the subject signed with the JWK. The key ID of
+// the JWK, if any, is set in the JWT kid header so that services validating
+// the JWT can select the key from the JWKS.
+func issueJWT(jwk jose.JSONWebKey, issuer, subject string, issuedAt time.Time, expiresIn time.Duration) (string, error) {
+	jwsOptions := &jose.SignerOptions{}
+	jwsOptions.WithType("JWT")
+	if jwk.KeyID != "" {
+		jwsOptions.WithHeader("kid", jwk.KeyID)
+	}
+	jws, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.SignatureAlgorithm(jwk.Algorithm), Key: jwk.Key}, jwsOptions)
+	if err != nil {
+		return "", errors.Wrap(err, "creating JWT signer")
+	}
+
+	claims := jwt.Claims{
+		Issuer:   issuer,
+		Subject:  subject,
+		IssuedAt: jwt.NewNumericDate(issuedAt),
+		Expiry:   jwt.NewNumericDate(issuedAt.Add(expiresIn)),
+	}
+	tokenStr, err := jwt.Signed(jws).Claims(claims).CompactSerialize()
+	if err != nil {
+		return "", errors.Wrap(err, "signing JWT")
+	}
+	return tokenStr, nil
+}
@@ -0,0 +1,88 @@
+package serve
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/shantanu-hashcash/go/exp/services/webauth/internal/refreshtoken"
+	"github.com/shantanu-hashcash/go/support/http/httpdecode"
+	supportlog "github.com/shantanu-hashcash/go/support/log"
+	"github.com/shantanu-hashcash/go/support/render/httpjson"
+	"gopkg.in/square/go-jose.v2"
+)
+
+// refreshHandler exchanges a refresh token for a new JWT and a new refresh
+// token, without the client completing another challenge.
+type refreshHandler struct {
+	Logger                *supportlog.Entry
+	JWK                   jose.JSONWebKey
+	JWTIssuer             string
+	JWTExpiresIn          time.Duration
+	RefreshTokenStore     refreshtoken.Store
+	RefreshTokenExpiresIn time.Duration
+}
+
+type refreshRequest struct {
+	RefreshToken string `json:"refresh_token" form:"refresh_token"`
+}
+
+func (h refreshHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := refreshRequest{}
+	err := httpdecode.Decode(r, &req)
+	if err != nil || req.RefreshToken == "" {
+		badRequest.Render(w)
+		return
+	}
+
+	token, hash, err := refreshtoken.Generate()
+	if err != nil {
+		h.Logger.Ctx(ctx).WithStack(err).Error(err)
+		serverError.Render(w)
+		return
+	}
+
+	now := time.Now()
+	next, err := h.RefreshTokenStore.Rotate(ctx, refreshtoken.Hash(req.RefreshToken), refreshtoken.Token{
+		Hash:      hash,
+		ExpiresAt: now.Add(h.RefreshTokenExpiresIn),
+	})
+	switch err {
+	case nil:
+	case refreshtoken.ErrNotFound:
+		h.Logger.Ctx(ctx).Info("Refresh token not found.")
+		unauthorized.Render(w)
+		return
+	case refreshtoken.ErrRevoked:
+		h.Logger.Ctx(ctx).Info("Refresh token revoked.")
+		unauthorized.Render(w)
+		return
+	case refreshtoken.ErrReused:
+		h.Logger.Ctx(ctx).Warn("Refresh token reused, revoked all refresh tokens in its family.")
+		unauthorized.Render(w)
+		return
+	default:
+		h.Logger.Ctx(ctx).WithStack(err).Error(err)
+		serverError.Render(w)
+		return
+	}
+
+	l := h.Logger.Ctx(ctx).
+		WithField("sub", next.Subject)
+
+	tokenStr, err := issueJWT(h.JWK, h.JWTIssuer, next.Subject, now, h.JWTExpiresIn)
+	if err != nil {
+		l.WithStack(err).Error(err)
+		serverError.Render(w)
+		return
+	}
+
+	l.Info("Refreshed token.")
+
+	res := tokenResponse{
+		Token:        tokenStr,
+		RefreshToken: token,
+	}
+	httpjson.Render(w, res, httpjson.JSON)
+}
@@ -0,0 +1,133 @@
+package serve
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shantanu-hashcash/go/exp/services/webauth/internal/refreshtoken/refreshtokentest"
+	"github.com/shantanu-hashcash/go/exp/support/jwtkey"
+	"github.com/shantanu-hashcash/go/keypair"
+	supportlog "github.com/shantanu-hashcash/go/support/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+func postRefreshToken(h http.Handler, refreshToken string) *http.Response {
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`{"refresh_token":"`+refreshToken+`"}`))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w.Result()
+}
+
+func TestRefresh(t *testing.T) {
+	jwtPrivateKey, err := jwtkey.GenerateKey()
+	require.NoError(t, err)
+	jwk := jose.JSONWebKey{Key: jwtPrivateKey, Algorithm: string(jose.ES256), KeyID: "1"}
+
+	account := keypair.MustRandom()
+	store := &refreshtokentest.MemoryStore{}
+	refreshToken, err := tokenHandler{
+		RefreshTokenStore:     store,
+		RefreshTokenExpiresIn: time.Hour,
+	}.issueRefreshToken(context.Background(), account.Address())
+	require.NoError(t, err)
+
+	h := refreshHandler{
+		Logger:                supportlog.DefaultLogger,
+		JWK:                   jwk,
+		JWTIssuer:             "https://example.com",
+		JWTExpiresIn:          time.Minute,
+		RefreshTokenStore:     store,
+		RefreshTokenExpiresIn: time.Hour,
+	}
+
+	resp := postRefreshToken(h, refreshToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	res := tokenResponse{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+	assert.NotEmpty(t, res.RefreshToken)
+	assert.NotEqual(t, refreshToken, res.RefreshToken)
+
+	token, err := jwt.ParseSigned(res.Token)
+	require.NoError(t, err)
+	require.Len(t, token.Headers, 1)
+	assert.Equal(t, "1", token.Headers[0].KeyID)
+	claims := jwt.Claims{}
+	require.NoError(t, token.Claims(&jwtPrivateKey.PublicKey, &claims))
+	assert.Equal(t, "https://example.com", claims.Issuer)
+	assert.Equal(t, account.Address(), claims.Subject)
+	assert.Equal(t, time.Minute, claims.Expiry.Time().Sub(claims.IssuedAt.Time()))
+
+	// The new refresh token can be used.
+	resp = postRefreshToken(h, res.RefreshToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	res2 := tokenResponse{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&res2))
+
+	// The first refresh token cannot be used again, and reusing it revokes
+	// the latest refresh token too.
+	resp = postRefreshToken(h, refreshToken)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	resp = postRefreshToken(h, res2.RefreshToken)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestRefresh_invalid(t *testing.T) {
+	h := refreshHandler{
+		Logger:            supportlog.DefaultLogger,
+		RefreshTokenStore: &refreshtokentest.MemoryStore{},
+	}
+
+	resp := postRefreshToken(h, "")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = postRefreshToken(h, "unknown")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestRevoke(t *testing.T) {
+	jwtPrivateKey, err := jwtkey.GenerateKey()
+	require.NoError(t, err)
+	jwk := jose.JSONWebKey{Key: jwtPrivateKey, Algorithm: string(jose.ES256)}
+
+	store := &refreshtokentest.MemoryStore{}
+	refreshToken, err := tokenHandler{
+		RefreshTokenStore:     store,
+		RefreshTokenExpiresIn: time.Hour,
+	}.issueRefreshToken(context.Background(), keypair.MustRandom().Address())
+	require.NoError(t, err)
+
+	refresh := refreshHandler{
+		Logger:                supportlog.DefaultLogger,
+		JWK:                   jwk,
+		JWTExpiresIn:          time.Minute,
+		RefreshTokenStore:     store,
+		RefreshTokenExpiresIn: time.Hour,
+	}
+	resp := postRefreshToken(refresh, refreshToken)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	res := tokenResponse{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+
+	revoke := revokeHandler{
+		Logger:            supportlog.DefaultLogger,
+		RefreshTokenStore: store,
+	}
+	resp = postRefreshToken(revoke, res.RefreshToken)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = postRefreshToken(refresh, res.RefreshToken)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	// Revoking an unknown refresh token is not an error.
+	resp = postRefreshToken(revoke, "unknown")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
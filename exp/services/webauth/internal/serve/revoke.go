@@ -0,0 +1,46 @@
+package serve
+
+import (
+	"net/http"
+
+	"github.com/shantanu-hashcash/go/exp/services/webauth/internal/refreshtoken"
+	"github.com/shantanu-hashcash/go/support/http/httpdecode"
+	supportlog "github.com/shantanu-hashcash/go/support/log"
+	"github.com/shantanu-hashcash/go/support/render/httpjson"
+)
+
+// revokeHandler revokes a refresh token and every refresh token rotated from
+// the same original refresh token, e.g. when a client logs out. As in RFC
+// 7009, revoking an unknown refresh token is not an error. JWTs already issued
+// are not revoked and remain valid until they expire.
+type revokeHandler struct {
+	Logger            *supportlog.Entry
+	RefreshTokenStore refreshtoken.Store
+}
+
+type revokeResponse struct{}
+
+func (h revokeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := refreshRequest{}
+	err := httpdecode.Decode(r, &req)
+	if err != nil || req.RefreshToken == "" {
+		badRequest.Render(w)
+		return
+	}
+
+	err = h.RefreshTokenStore.Revoke(ctx, refreshtoken.Hash(req.RefreshToken))
+	switch err {
+	case nil:
+		h.Logger.Ctx(ctx).Info("Refresh token revoked.")
+	case refreshtoken.ErrNotFound:
+		h.Logger.Ctx(ctx).Info("Refresh token to revoke not found.")
+	default:
+		h.Logger.Ctx(ctx).WithStack(err).Error(err)
+		serverError.Render(w)
+		return
+	}
+
+	httpjson.Render(w, revokeResponse{}, httpjson.JSON)
+}
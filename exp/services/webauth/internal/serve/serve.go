@@ -1,19 +1,19 @@
 package serve
 
 import (
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/shantanu-hashcash/go/clients/auroraclient"
+	"github.com/shantanu-hashcash/go/exp/services/webauth/internal/db"
+	"github.com/shantanu-hashcash/go/exp/services/webauth/internal/refreshtoken"
 	"github.com/shantanu-hashcash/go/keypair"
 	"github.com/shantanu-hashcash/go/support/errors"
 	supporthttp "github.com/shantanu-hashcash/go/support/http"
 	supportlog "github.com/shantanu-hashcash/go/support/log"
 	"github.com/shantanu-hashcash/go/support/render/health"
-	"gopkg.in/square/go-jose.v2"
 )
 
 type Options struct {
@@ -26,8 +26,13 @@ type Options struct {
 	AuthHomeDomains             string
 	ChallengeExpiresIn          time.Duration
 	JWK                         string
+	JWKRotatedAt                string
+	JWKRotationGracePeriod      time.Duration
 	JWTIssuer                   string
 	JWTExpiresIn                time.Duration
+	RefreshTokenExpiresIn       time.Duration
+	DatabaseURL                 string
+	DatabaseMaxOpenConns        int
 	AllowAccountsThatDoNotExist bool
 }
 
@@ -71,13 +76,32 @@ func handler(opts Options) (http.Handler, error) {
 		trimmedHomeDomains = append(trimmedHomeDomains, strings.TrimSuffix(homeDomain, "."))
 	}
 
-	jwk := jose.JSONWebKey{}
-	err := json.Unmarshal([]byte(opts.JWK), &jwk)
+	jwtKeys, err := getJWTKeys(opts)
 	if err != nil {
-		return nil, errors.Wrap(err, "parsing JSON Web Key (JWK)")
+		return nil, err
 	}
-	if jwk.Algorithm == "" {
-		return nil, errors.New("algorithm (alg) field must be set")
+	opts.Logger.Info("JWT signing key: ", jwtKeys.Signing.KeyID)
+	for i, k := range jwtKeys.Previous {
+		opts.Logger.Info("JWT previous key ", i, ": ", k.KeyID)
+	}
+	if len(jwtKeys.Previous) > 0 && !jwtKeys.PreviousExpireAt.IsZero() {
+		opts.Logger.Info("JWT previous keys expire at ", jwtKeys.PreviousExpireAt.Format(time.RFC3339))
+	}
+
+	var refreshTokenStore refreshtoken.Store
+	if opts.RefreshTokenExpiresIn > 0 {
+		session, err := db.Open(opts.DatabaseURL)
+		if err != nil {
+			return nil, errors.Wrap(err, "error parsing database url")
+		}
+		session.DB.SetMaxOpenConns(opts.DatabaseMaxOpenConns)
+
+		err = session.DB.Ping()
+		if err != nil {
+			opts.Logger.Warn("Error pinging to Database: ", err)
+		}
+
+		refreshTokenStore = &refreshtoken.DBStore{DB: session}
 	}
 
 	auroraTimeout := auroraclient.AuroraTimeout
@@ -96,6 +120,9 @@ func handler(opts Options) (http.Handler, error) {
 	mux.MethodNotAllowed(errorHandler{Error: methodNotAllowed}.ServeHTTP)
 
 	mux.Get("/health", health.PassHandler{}.ServeHTTP)
+	mux.Get("/.well-known/jwks.json", jwksHandler{
+		Keys: jwtKeys,
+	}.ServeHTTP)
 	mux.Get("/", challengeHandler{
 		Logger:             opts.Logger,
 		NetworkPassphrase:  opts.NetworkPassphrase,
@@ -109,13 +136,48 @@ func handler(opts Options) (http.Handler, error) {
 		AuroraClient:               auroraClient,
 		NetworkPassphrase:           opts.NetworkPassphrase,
 		SigningAddresses:            signingAddresses,
-		JWK:                         jwk,
+		JWK:                         jwtKeys.Signing,
 		JWTIssuer:                   opts.JWTIssuer,
 		JWTExpiresIn:                opts.JWTExpiresIn,
 		AllowAccountsThatDoNotExist: opts.AllowAccountsThatDoNotExist,
 		Domain:                      opts.Domain,
 		HomeDomains:                 trimmedHomeDomains,
+		RefreshTokenStore:           refreshTokenStore,
+		RefreshTokenExpiresIn:       opts.RefreshTokenExpiresIn,
 	}.ServeHTTP)
+	if refreshTokenStore != nil {
+		mux.Post("/refresh", refreshHandler{
+			Logger:                opts.Logger,
+			JWK:                   jwtKeys.Signing,
+			JWTIssuer:             opts.JWTIssuer,
+			JWTExpiresIn:          opts.JWTExpiresIn,
+			RefreshTokenStore:     refreshTokenStore,
+			RefreshTokenExpiresIn: opts.RefreshTokenExpiresIn,
+		}.ServeHTTP)
+		mux.Post("/revoke", revokeHandler{
+			Logger:            opts.Logger,
+			RefreshTokenStore: refreshTokenStore,
+		}.ServeHTTP)
+	}
 
 	return mux, nil
 }
+
+func getJWTKeys(opts Options) (jwtKeys, error) {
+	keys, err := parseJWTKeys(opts.JWK)
+	if err != nil {
+		return jwtKeys{}, err
+	}
+	if opts.JWKRotatedAt != "" {
+		rotatedAt, err := time.Parse(time.RFC3339, opts.JWKRotatedAt)
+		if err != nil {
+			return jwtKeys{}, errors.Wrap(err, "parsing JSON Web Key (JWK) rotated at time")
+		}
+		gracePeriod := opts.JWKRotationGracePeriod
+		if gracePeriod <= 0 {
+			gracePeriod = opts.JWTExpiresIn
+		}
+		keys.PreviousExpireAt = rotatedAt.Add(gracePeriod)
+	}
+	return keys, nil
+}
@@ -1,20 +1,22 @@
 package serve
 
 import (
+	"context"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/shantanu-hashcash/go/clients/auroraclient"
+	"github.com/shantanu-hashcash/go/exp/services/webauth/internal/refreshtoken"
 	"github.com/shantanu-hashcash/go/keypair"
+	"github.com/shantanu-hashcash/go/support/errors"
 	"github.com/shantanu-hashcash/go/support/http/httpdecode"
 	supportlog "github.com/shantanu-hashcash/go/support/log"
 	"github.com/shantanu-hashcash/go/support/render/httpjson"
 	"github.com/shantanu-hashcash/go/txnbuild"
 	"github.com/shantanu-hashcash/go/xdr"
 	"gopkg.in/square/go-jose.v2"
-	"gopkg.in/square/go-jose.v2/jwt"
 )
 
 type tokenHandler struct {
@@ -28,6 +30,8 @@ type tokenHandler struct {
 	AllowAccountsThatDoNotExist bool
 	Domain                      string
 	HomeDomains                 []string
+	RefreshTokenStore           refreshtoken.Store
+	RefreshTokenExpiresIn       time.Duration
 }
 
 type tokenRequest struct {
@@ -35,7 +39,8 @@ type tokenRequest struct {
 }
 
 type tokenResponse struct {
-	Token string `json:"token"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
 }
 
 func (h tokenHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -144,15 +149,6 @@ func (h tokenHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		WithField("signers", strings.Join(signersVerified, ",")).
 		Infof("Successfully verified challenge transaction.")
 
-	jwsOptions := &jose.SignerOptions{}
-	jwsOptions.WithType("JWT")
-	jws, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.SignatureAlgorithm(h.JWK.Algorithm), Key: h.JWK.Key}, jwsOptions)
-	if err != nil {
-		l.WithStack(err).Error(err)
-		serverError.Render(w)
-		return
-	}
-
 	var sub string
 	if muxedAccount.Type == xdr.CryptoKeyTypeKeyTypeEd25519 {
 		sub = clientAccountID
@@ -164,13 +160,7 @@ func (h tokenHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	issuedAt := time.Unix(tx.Timebounds().MinTime, 0)
-	claims := jwt.Claims{
-		Issuer:   h.JWTIssuer,
-		Subject:  sub,
-		IssuedAt: jwt.NewNumericDate(issuedAt),
-		Expiry:   jwt.NewNumericDate(issuedAt.Add(h.JWTExpiresIn)),
-	}
-	tokenStr, err := jwt.Signed(jws).Claims(claims).CompactSerialize()
+	tokenStr, err := issueJWT(h.JWK, h.JWTIssuer, sub, issuedAt, h.JWTExpiresIn)
 	if err != nil {
 		l.WithStack(err).Error(err)
 		serverError.Render(w)
@@ -180,5 +170,36 @@ func (h tokenHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	res := tokenResponse{
 		Token: tokenStr,
 	}
+	if h.RefreshTokenStore != nil {
+		res.RefreshToken, err = h.issueRefreshToken(ctx, sub)
+		if err != nil {
+			l.WithStack(err).Error(err)
+			serverError.Render(w)
+			return
+		}
+	}
 	httpjson.Render(w, res, httpjson.JSON)
 }
+
+// issueRefreshToken starts a new family of refresh tokens for the subject and
+// returns its first refresh token.
+func (h tokenHandler) issueRefreshToken(ctx context.Context, sub string) (string, error) {
+	family, err := refreshtoken.NewFamily()
+	if err != nil {
+		return "", err
+	}
+	token, hash, err := refreshtoken.Generate()
+	if err != nil {
+		return "", err
+	}
+	err = h.RefreshTokenStore.Add(ctx, refreshtoken.Token{
+		Hash:      hash,
+		Family:    family,
+		Subject:   sub,
+		ExpiresAt: time.Now().Add(h.RefreshTokenExpiresIn),
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "storing refresh token")
+	}
+	return token, nil
+}
@@ -1,2 +1,34 @@
-// TODO explain here how to write wrappers to use without casting from `interface{}`.
+/*
+Package pipeline runs typed processing graphs, for building ETL jobs on top of
+the readers of the ingest package.
+
+A Pipeline is a directed acyclic graph of nodes connected by typed streams.
+Sources feed the graph, processing nodes consume a stream and produce a new
+one, and sinks consume a stream without producing one. A stream consumed by
+several nodes delivers every item to each of them:
+
+	p := pipeline.New("accounts")
+	changes := pipeline.From(p, "changes", pipeline.ChangeSource(reader))
+	accounts := pipeline.Filter(p, "accounts", changes,
+		func(ctx context.Context, change ingest.Change) (bool, error) {
+			return change.Type == xdr.LedgerEntryTypeAccount, nil
+		},
+	)
+	pipeline.Sink(p, "print", accounts,
+		func(ctx context.Context, change ingest.Change) error {
+			fmt.Println(change.Post)
+			return nil
+		},
+	)
+	err := p.Run(ctx)
+
+Each node reads its input from a bounded buffer (see Buffer), so a slow node
+slows down the nodes writing to it instead of queueing items without limit.
+Nodes run the given function on a single goroutine unless more are requested
+with Concurrency, in which case items are processed and emitted out of order.
+
+The first error returned by a node cancels the context passed to all the
+nodes and is returned by Run as a *NodeError naming the node. Canceling the
+context passed to Run stops the pipeline in the same way.
+*/
 package pipeline
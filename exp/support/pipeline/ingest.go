@@ -0,0 +1,93 @@
+package pipeline
+
+import (
+	"context"
+	"io"
+
+	"github.com/shantanu-hashcash/go/ingest"
+	"github.com/shantanu-hashcash/go/ingest/ledgerbackend"
+	"github.com/shantanu-hashcash/go/support/errors"
+)
+
+// ChangeSource returns a source reading the changes of reader, for example
+// a checkpoint or ledger change reader.
+func ChangeSource(reader ingest.ChangeReader) Source[ingest.Change] {
+	return reader
+}
+
+// TransactionSource returns a source reading the transactions of a ledger.
+func TransactionSource(reader *ingest.LedgerTransactionReader) Source[ingest.LedgerTransaction] {
+	return reader
+}
+
+// LedgerRangeTransactionSource returns a source reading the transactions of
+// the ledgers in [from, to] from backend, which must have prepared the range.
+func LedgerRangeTransactionSource(
+	ctx context.Context,
+	backend ledgerbackend.LedgerBackend,
+	networkPassphrase string,
+	from, to uint32,
+) Source[ingest.LedgerTransaction] {
+	return &ledgerRangeTransactionSource{
+		ctx:               ctx,
+		backend:           backend,
+		networkPassphrase: networkPassphrase,
+		next:              from,
+		to:                to,
+	}
+}
+
+type ledgerRangeTransactionSource struct {
+	ctx               context.Context
+	backend           ledgerbackend.LedgerBackend
+	networkPassphrase string
+	next, to          uint32
+	reader            *ingest.LedgerTransactionReader
+}
+
+func (s *ledgerRangeTransactionSource) Read() (ingest.LedgerTransaction, error) {
+	for {
+		if s.reader != nil {
+			tx, err := s.reader.Read()
+			if err != io.EOF {
+				return tx, err
+			}
+			s.reader = nil
+		}
+		// next wraps around after reading the last possible ledger
+		if s.next > s.to || s.next == 0 {
+			return ingest.LedgerTransaction{}, io.EOF
+		}
+
+		reader, err := ingest.NewLedgerTransactionReader(s.ctx, s.backend, s.networkPassphrase, s.next)
+		if err != nil {
+			return ingest.LedgerTransaction{}, errors.Wrapf(err, "could not read ledger %d", s.next)
+		}
+		s.reader = reader
+		s.next++
+	}
+}
+
+func (s *ledgerRangeTransactionSource) Close() error {
+	return nil
+}
+
+// TransactionChanges is a ProcessFunc writing the fee changes followed by
+// the operation changes of a transaction.
+func TransactionChanges(ctx context.Context, tx ingest.LedgerTransaction, emit func(ingest.Change) error) error {
+	for _, change := range tx.GetFeeChanges() {
+		if err := emit(change); err != nil {
+			return err
+		}
+	}
+	changes, err := tx.GetChanges()
+	if err != nil {
+		return errors.Wrapf(err, "could not get changes of transaction %d", tx.Index)
+	}
+	for _, change := range changes {
+		if err := emit(change); err != nil {
+			return err
+		}
+	}
+	return nil
+}
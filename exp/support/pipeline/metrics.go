@@ -0,0 +1,107 @@
+package pipeline
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics collects the metrics of the nodes of pipelines, labeled by
+// pipeline and node name. It implements prometheus.Collector and can be
+// shared by several pipelines.
+type Metrics struct {
+	read     *prometheus.CounterVec
+	written  *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+	queued   *prometheus.GaugeVec
+	duration *prometheus.SummaryVec
+}
+
+// NewMetrics creates the metrics of pipelines in the given namespace.
+func NewMetrics(namespace string) *Metrics {
+	labels := []string{"pipeline", "node"}
+	return &Metrics{
+		read: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace, Subsystem: "pipeline", Name: "items_read_total",
+				Help: "number of items read by a node",
+			},
+			labels,
+		),
+		written: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace, Subsystem: "pipeline", Name: "items_written_total",
+				Help: "number of items written by a node",
+			},
+			labels,
+		),
+		errors: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace, Subsystem: "pipeline", Name: "errors_total",
+				Help: "number of runs failed by a node",
+			},
+			labels,
+		),
+		queued: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace, Subsystem: "pipeline", Name: "queued_items",
+				Help: "number of items waiting at the input of a node, sampled when the node reads an item",
+			},
+			labels,
+		),
+		duration: prometheus.NewSummaryVec(
+			prometheus.SummaryOpts{
+				Namespace: namespace, Subsystem: "pipeline", Name: "item_duration_seconds",
+				Help:       "duration of processing an item by a node, including the time blocked writing to the next nodes, sliding window = 10m",
+				Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+			},
+			labels,
+		),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	m.read.Describe(ch)
+	m.written.Describe(ch)
+	m.errors.Describe(ch)
+	m.queued.Describe(ch)
+	m.duration.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	m.read.Collect(ch)
+	m.written.Collect(ch)
+	m.errors.Collect(ch)
+	m.queued.Collect(ch)
+	m.duration.Collect(ch)
+}
+
+type nodeMetrics struct {
+	read     prometheus.Counter
+	written  prometheus.Counter
+	errors   prometheus.Counter
+	queued   prometheus.Gauge
+	duration prometheus.Observer
+}
+
+// forNode returns the metrics of a node. Nodes of pipelines without metrics
+// get unregistered ones.
+func (m *Metrics) forNode(pipeline, node string) nodeMetrics {
+	if m == nil {
+		return nodeMetrics{
+			read:     prometheus.NewCounter(prometheus.CounterOpts{Name: "read"}),
+			written:  prometheus.NewCounter(prometheus.CounterOpts{Name: "written"}),
+			errors:   prometheus.NewCounter(prometheus.CounterOpts{Name: "errors"}),
+			queued:   prometheus.NewGauge(prometheus.GaugeOpts{Name: "queued"}),
+			duration: prometheus.NewSummary(prometheus.SummaryOpts{Name: "duration"}),
+		}
+	}
+	labels := prometheus.Labels{"pipeline": pipeline, "node": node}
+	return nodeMetrics{
+		read:     m.read.With(labels),
+		written:  m.written.With(labels),
+		errors:   m.errors.With(labels),
+		queued:   m.queued.With(labels),
+		duration: m.duration.With(labels),
+	}
+}
@@ -3,267 +3,251 @@ package pipeline
 import (
 	"context"
 	"fmt"
-	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/shantanu-hashcash/go/support/errors"
 )
 
-func New(rootProcessor *PipelineNode) *Pipeline {
-	return &Pipeline{root: rootProcessor}
-}
+// DefaultBuffer is the default number of items queued at the input of a node
+// before the nodes writing to it block.
+const DefaultBuffer = 100
 
-func Node(processor Processor) *PipelineNode {
-	return &PipelineNode{
-		Processor: processor,
-	}
-}
+// ErrAlreadyRun is returned by Run when the pipeline has already been run.
+// Streams are consumed by a run so a new Pipeline must be built for every
+// run.
+var ErrAlreadyRun = errors.New("pipeline has already been run")
 
-func (p *Pipeline) PrintStatus() {
-	p.printNodeStatus(p.root, 0)
+// NodeError is returned by Run when a node fails.
+type NodeError struct {
+	Node string
+	Err  error
 }
 
-// AddPreProcessingHook adds post-processing hook. Context will be a main
-// reader context.
-func (p *Pipeline) AddPreProcessingHook(hook func(context.Context) (context.Context, error)) {
-	p.preProcessingHooks = append(p.preProcessingHooks, hook)
+func (e *NodeError) Error() string {
+	return fmt.Sprintf("node %s: %v", e.Node, e.Err)
 }
 
-// AddPostProcessingHook adds post-processing hook. Context will be a main
-// reader context and error will be nil, if processing was successful,
-// ErrShutdown when pipeline was shutdown and non nil otherwise.
-func (p *Pipeline) AddPostProcessingHook(hook func(context.Context, error) error) {
-	p.postProcessingHooks = append(p.postProcessingHooks, hook)
+func (e *NodeError) Unwrap() error {
+	return e.Err
 }
 
-func (p *Pipeline) printNodeStatus(node *PipelineNode, level int) {
-	fmt.Print(strings.Repeat("  ", level))
-
-	var wrRatio = float32(0)
-	if node.readEntries > 0 {
-		wrRatio = float32(node.wroteEntries) / float32(node.readEntries)
-	}
-
-	icon := ""
-	if node.queuedEntries > bufferSize/10*9 {
-		icon = "⚠️ "
-	}
-
-	fmt.Printf(
-		"└ %s%s read=%d (queued=%d rps=%d) wrote=%d (w/r ratio = %1.5f)\n",
-		icon,
-		node.Processor.Name(),
-		node.readEntries,
-		node.queuedEntries,
-		node.readsPerSecond,
-		node.wroteEntries,
-		wrRatio,
-	)
-
-	for _, child := range node.Children {
-		p.printNodeStatus(child, level+1)
-	}
+// NodeStats are the statistics of a node.
+type NodeStats struct {
+	Name string
+	// Read is the number of items read by the node.
+	Read int64
+	// Written is the number of items written by the node.
+	Written int64
+	// Queued is the number of items waiting at the input of the node.
+	Queued int
+	// Failed is true if the node returned an error.
+	Failed bool
 }
 
-func (p *Pipeline) SetRoot(rootProcessor *PipelineNode) {
-	p.root = rootProcessor
+// Option configures a node.
+type Option func(*nodeConfig)
+
+type nodeConfig struct {
+	concurrency int
+	buffer      int
 }
 
-// setRunning protects from processing more than once at a time.
-func (p *Pipeline) setRunning(setRunning bool) error {
-	if setRunning {
-		if p.running {
-			panic("Cannot start processing, pipeline is running...")
+// Concurrency sets the number of goroutines processing the items of a node,
+// 1 by default. Items are processed and emitted out of order when it's
+// greater than 1.
+func Concurrency(n int) Option {
+	return func(c *nodeConfig) {
+		if n < 1 {
+			n = 1
 		}
+		c.concurrency = n
+	}
+}
 
-		if p.shutDown {
-			return ErrShutdown
+// Buffer sets the number of items queued at the input of a node before the
+// nodes writing to it block, DefaultBuffer by default.
+func Buffer(n int) Option {
+	return func(c *nodeConfig) {
+		if n < 0 {
+			n = 0
 		}
+		c.buffer = n
 	}
-
-	p.running = setRunning
-	return nil
 }
 
-// IsRunning returns true if pipeline is running
-func (p *Pipeline) IsRunning() bool {
-	// Protects internal fields
-	p.mutex.Lock()
-	defer p.mutex.Unlock()
-	return p.running
+func newNodeConfig(opts []Option) nodeConfig {
+	config := nodeConfig{concurrency: 1, buffer: DefaultBuffer}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return config
+}
+
+type node struct {
+	name        string
+	concurrency int
+	// work is run by each of the node's goroutines.
+	work func(ctx context.Context) error
+	// done is called once all the node's goroutines returned.
+	done func()
+	// queued returns the number of items waiting at the input of the node.
+	queued func() int
+
+	read    int64
+	written int64
+	failed  int32
+	metrics nodeMetrics
+}
+
+func (n *node) received() {
+	atomic.AddInt64(&n.read, 1)
+	n.metrics.read.Inc()
+	if n.queued != nil {
+		n.metrics.queued.Set(float64(n.queued()))
+	}
 }
 
-// reset resets internal state of the pipeline and all the nodes and processors.
-func (p *Pipeline) reset() {
-	p.canceled = false
-	p.resetNode(p.root)
+func (n *node) wrote() {
+	atomic.AddInt64(&n.written, 1)
+	n.metrics.written.Inc()
 }
 
-func (p *Pipeline) sendPreProcessingHooks(ctx context.Context) (context.Context, error) {
-	var err error
+func (n *node) processed(duration time.Duration) {
+	n.metrics.duration.Observe(duration.Seconds())
+}
 
-	for _, hook := range p.preProcessingHooks {
-		ctx, err = hook(ctx)
-		if err != nil {
-			return ctx, err
-		}
-	}
+// Pipeline is a graph of nodes connected by typed streams. Nodes are added
+// with From, Process, Map, Filter, Batch and Sink.
+type Pipeline struct {
+	name    string
+	metrics *Metrics
 
-	return ctx, nil
+	mutex   sync.Mutex
+	nodes   []*node
+	started bool
 }
 
-func (p *Pipeline) sendPostProcessingHooks(ctx context.Context, processingError error) error {
-	for _, hook := range p.postProcessingHooks {
-		err := hook(ctx, processingError)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
+// New returns an empty pipeline. The name identifies the pipeline in
+// metrics.
+func New(name string) *Pipeline {
+	return &Pipeline{name: name}
 }
 
-// resetNode resets internal state of the pipeline node and internal processor and
-// calls itself recursively on all of the children.
-func (p *Pipeline) resetNode(node *PipelineNode) {
-	node.reset()
-	for _, child := range node.Children {
-		p.resetNode(child)
-	}
+// SetMetrics makes the pipeline report the metrics of its nodes to m. It must
+// be called before Run.
+func (p *Pipeline) SetMetrics(m *Metrics) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.metrics = m
 }
 
-// Process starts pipeline. Return channel will return if an error occurred in
-// any of the processors or any of the pipeline hooks. Will return ErrShutdown
-// if the pipeline was shutdown.
-func (p *Pipeline) Process(reader Reader) <-chan error {
-	// Protects internal fields
+func (p *Pipeline) addNode(name string, concurrency int) *node {
 	p.mutex.Lock()
 	defer p.mutex.Unlock()
 
-	errorChan := make(chan error, 1)
-	err := p.setRunning(true)
-	if err != nil {
-		errorChan <- err
-		return errorChan
+	if p.started {
+		panic("cannot add node " + name + ", pipeline has been run")
 	}
-	p.reset()
-
-	ctx, err := p.sendPreProcessingHooks(reader.GetContext())
-	if err != nil {
-		p.setRunning(false)
-		errorChan <- errors.Wrap(err, "Error running pre-hook")
-		return errorChan
+	for _, n := range p.nodes {
+		if n.name == name {
+			panic("duplicate node " + name)
+		}
 	}
 
-	ctx, p.cancelFunc = context.WithCancel(ctx)
-	return p.processStateNode(ctx, &Store{}, p.root, reader)
+	n := &node{name: name, concurrency: concurrency}
+	p.nodes = append(p.nodes, n)
+	return n
 }
 
-func (p *Pipeline) processStateNode(ctx context.Context, store *Store, node *PipelineNode, reader Reader) <-chan error {
-	outputs := make([]Writer, len(node.Children))
+// Stats returns the statistics of the nodes in the order they were added.
+func (p *Pipeline) Stats() []NodeStats {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
 
-	for i := range outputs {
-		outputs[i] = &BufferedReadWriter{
-			context: reader.GetContext(),
+	stats := make([]NodeStats, 0, len(p.nodes))
+	for _, n := range p.nodes {
+		s := NodeStats{
+			Name:    n.name,
+			Read:    atomic.LoadInt64(&n.read),
+			Written: atomic.LoadInt64(&n.written),
+			Failed:  atomic.LoadInt32(&n.failed) == 1,
 		}
+		if n.queued != nil {
+			s.Queued = n.queued()
+		}
+		stats = append(stats, s)
 	}
+	return stats
+}
 
-	var wg sync.WaitGroup
-
-	writer := &multiWriter{
-		writers:    outputs,
-		closeAfter: 1,
+// Run runs the pipeline until all the sources are exhausted and all the
+// items are processed, a node fails or ctx is canceled. It returns a
+// *NodeError wrapping the first error returned by a node, or ctx.Err() if ctx
+// was canceled. A pipeline can only be run once.
+func (p *Pipeline) Run(ctx context.Context) error {
+	p.mutex.Lock()
+	if p.started {
+		p.mutex.Unlock()
+		return ErrAlreadyRun
 	}
+	p.started = true
+	nodes := p.nodes
+	for _, n := range nodes {
+		n.metrics = p.metrics.forNode(p.name, n.name)
+	}
+	p.mutex.Unlock()
 
-	var processingError error
-
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-
-		err := node.Processor.Process(ctx, store, reader, writer)
-		if err != nil {
-			// Protects from canceling twice and sending multiple errors to err channel
-			p.mutex.Lock()
-			defer p.mutex.Unlock()
-
-			if p.canceled {
-				return
-			}
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
-			wrappedErr := errors.Wrap(err, fmt.Sprintf("Processor %s errored", node.Processor.Name()))
+	var (
+		errMutex sync.Mutex
+		firstErr error
+	)
+	fail := func(n *node, err error) {
+		errMutex.Lock()
+		defer errMutex.Unlock()
+		// Errors returned after the pipeline was canceled are caused by the
+		// cancellation.
+		if firstErr != nil || runCtx.Err() != nil {
+			return
+		}
+		atomic.StoreInt32(&n.failed, 1)
+		n.metrics.errors.Inc()
+		firstErr = &NodeError{Node: n.name, Err: err}
+		cancel()
+	}
 
-			p.canceled = true
-			p.cancelFunc()
-			processingError = wrappedErr
+	var wg sync.WaitGroup
+	for _, n := range nodes {
+		var workers sync.WaitGroup
+		for i := 0; i < n.concurrency; i++ {
+			workers.Add(1)
+			wg.Add(1)
+			go func(n *node) {
+				defer wg.Done()
+				defer workers.Done()
+				if err := n.work(runCtx); err != nil {
+					fail(n, err)
+				}
+			}(n)
 		}
-	}()
 
-	for i, child := range node.Children {
 		wg.Add(1)
-		go func(i int, child *PipelineNode) {
+		go func(n *node) {
 			defer wg.Done()
-			err := <-p.processStateNode(ctx, store, child, outputs[i].(*BufferedReadWriter))
-			if err != nil {
-				processingError = err
+			workers.Wait()
+			if n.done != nil {
+				n.done()
 			}
-		}(i, child)
+		}(n)
 	}
+	wg.Wait()
 
-	errorChan := make(chan error, 1)
-
-	go func() {
-		wg.Wait()
-		if node == p.root {
-			// If pipeline processing is finished run post-hooks and send error
-			// if not already sent.
-			var returnError error
-			var hookError error
-
-			hookError = processingError
-			if hookError == nil && p.shutDown {
-				hookError = ErrShutdown
-			}
-
-			err := p.sendPostProcessingHooks(reader.GetContext(), hookError)
-			if err != nil {
-				returnError = errors.Wrap(err, "Error running post-hook")
-			} else {
-				returnError = processingError
-			}
-
-			if returnError == nil && p.shutDown {
-				returnError = ErrShutdown
-			}
-
-			p.mutex.Lock()
-			p.setRunning(false)
-			p.mutex.Unlock()
-
-			errorChan <- returnError
-		} else {
-			// For non-root node just send an error
-			errorChan <- processingError
-		}
-	}()
-
-	return errorChan
-}
-
-// Shutdown stops the processing. Please note that post-processing hooks will
-// receive ErrShutdown when Shutdown() is called.
-func (p *Pipeline) Shutdown() {
-	// Protects internal fields
-	p.mutex.Lock()
-	defer p.mutex.Unlock()
-
-	if p.canceled {
-		return
-	}
-	p.shutDown = true
-	p.canceled = true
-	// It's possible that Shutdown will be called before first run.
-	if p.cancelFunc != nil {
-		p.cancelFunc()
+	if firstErr != nil {
+		return firstErr
 	}
+	return ctx.Err()
 }
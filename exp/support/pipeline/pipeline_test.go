@@ -2,229 +2,300 @@ package pipeline_test
 
 import (
 	"context"
+	"fmt"
 	"io"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"github.com/shantanu-hashcash/go/exp/support/pipeline"
+	"github.com/shantanu-hashcash/go/ingest"
 	"github.com/shantanu-hashcash/go/support/errors"
-	"github.com/stretchr/testify/assert"
+	"github.com/shantanu-hashcash/go/xdr"
 )
 
-func TestPipelineCanBeProcessedAgain(t *testing.T) {
-	p := pipeline.New(
-		pipeline.Node(&NoOpProcessor{}),
-	)
-
-	assert.NoError(t, <-p.Process(&SimpleReader{CountObject: 10}))
-	assert.NoError(t, <-p.Process(&SimpleReader{CountObject: 20}))
-}
-
-func TestCannotRunProcessOnRunningPipeline(t *testing.T) {
-	p := pipeline.New(
-		pipeline.Node(&NoOpProcessor{}),
-	)
-
-	go p.Process(&SimpleReader{})
-	defer p.Shutdown()
-	time.Sleep(100 * time.Millisecond)
-	assert.Panics(t, func() {
-		p.Process(&SimpleReader{})
-	})
+// countSource reads the numbers from 1 to count.
+type countSource struct {
+	count  int
+	read   int64
+	closed bool
 }
 
-func TestNoErrorsOnSuccess(t *testing.T) {
-	p := pipeline.New(
-		pipeline.Node(&NoOpProcessor{}),
-	)
-
-	assert.NoError(t, <-p.Process(&SimpleReader{CountObject: 10}))
+func (s *countSource) Read() (int, error) {
+	n := atomic.AddInt64(&s.read, 1)
+	if int(n) > s.count {
+		return 0, io.EOF
+	}
+	return int(n), nil
 }
 
-func TestErrorsOnFailure(t *testing.T) {
-	p := pipeline.New(
-		pipeline.Node(&NoOpProcessor{ReturnError: true}),
-	)
-
-	err := <-p.Process(&SimpleReader{CountObject: 10})
-	assert.Error(t, err)
-	assert.Equal(t, "Processor NoOpProcessor errored: Test error", err.Error())
+func (s *countSource) Close() error {
+	s.closed = true
+	return nil
 }
 
-func TestHooksCalled(t *testing.T) {
-	p := pipeline.New(
-		pipeline.Node(&NoOpProcessor{}),
+// collect adds a sink storing the items of in.
+func collect[T any](p *pipeline.Pipeline, name string, in *pipeline.Stream[T]) *[]T {
+	var (
+		mutex sync.Mutex
+		items []T
 	)
-
-	preHookCalled := false
-	p.AddPreProcessingHook(func(ctx context.Context) (context.Context, error) {
-		preHookCalled = true
-		return ctx, nil
-	})
-
-	postHookCalled := false
-	p.AddPostProcessingHook(func(ctx context.Context, err error) error {
-		postHookCalled = true
+	pipeline.Sink(p, name, in, func(ctx context.Context, item T) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		items = append(items, item)
 		return nil
 	})
-
-	err := <-p.Process(&SimpleReader{CountObject: 10})
-	assert.NoError(t, err)
-	assert.True(t, preHookCalled, "pre-hook not called")
-	assert.True(t, postHookCalled, "post-hook not called")
+	return &items
 }
 
-func TestPostHooksCalledWithError(t *testing.T) {
-	p := pipeline.New(
-		pipeline.Node(&NoOpProcessor{ReturnError: true}),
-	)
-
-	errChan := make(chan error, 1)
-
-	p.AddPostProcessingHook(func(ctx context.Context, err error) error {
-		errChan <- err
-		return nil
+func TestPipeline(t *testing.T) {
+	p := pipeline.New("test")
+	source := &countSource{count: 10}
+	numbers := pipeline.From[int](p, "numbers", source)
+	even := pipeline.Filter(p, "even", numbers, func(ctx context.Context, n int) (bool, error) {
+		return n%2 == 0, nil
+	})
+	labels := pipeline.Map(p, "labels", even, func(ctx context.Context, n int) (string, error) {
+		return fmt.Sprintf("#%d", n), nil
+	})
+	pairs := pipeline.Process(p, "pairs", numbers, func(ctx context.Context, n int, emit func(int) error) error {
+		if err := emit(n); err != nil {
+			return err
+		}
+		return emit(-n)
 	})
 
-	err := <-p.Process(&SimpleReader{CountObject: 10})
-	assert.Error(t, err)
-	assert.Equal(t, "Processor NoOpProcessor errored: Test error", err.Error())
-
-	hookErr := <-errChan
-	assert.Error(t, hookErr)
-	assert.Equal(t, "Processor NoOpProcessor errored: Test error", hookErr.Error())
+	gotLabels := collect(p, "collect-labels", labels)
+	gotPairs := collect(p, "collect-pairs", pairs)
+
+	require.NoError(t, p.Run(context.Background()))
+	assert.True(t, source.closed)
+	assert.Equal(t, []string{"#2", "#4", "#6", "#8", "#10"}, *gotLabels)
+	assert.Len(t, *gotPairs, 20)
+	assert.Equal(t, []int{1, -1, 2, -2}, (*gotPairs)[:4])
+
+	assert.Equal(t, []pipeline.NodeStats{
+		{Name: "numbers", Read: 0, Written: 10},
+		{Name: "even", Read: 10, Written: 5},
+		{Name: "labels", Read: 5, Written: 5},
+		{Name: "pairs", Read: 10, Written: 20},
+		{Name: "collect-labels", Read: 5},
+		{Name: "collect-pairs", Read: 20},
+	}, p.Stats())
+
+	assert.Equal(t, pipeline.ErrAlreadyRun, p.Run(context.Background()))
 }
 
-func TestProcessReturnsErrorWhenPostHooksErrors(t *testing.T) {
-	p := pipeline.New(
-		pipeline.Node(&NoOpProcessor{}),
-	)
+func TestConcurrency(t *testing.T) {
+	p := pipeline.New("test")
+	numbers := pipeline.From[int](p, "numbers", &countSource{count: 100})
+
+	var running, maxRunning int64
+	squares := pipeline.Map(p, "squares", numbers, func(ctx context.Context, n int) (int, error) {
+		current := atomic.AddInt64(&running, 1)
+		defer atomic.AddInt64(&running, -1)
+		for {
+			max := atomic.LoadInt64(&maxRunning)
+			if current <= max || atomic.CompareAndSwapInt64(&maxRunning, max, current) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		return n * n, nil
+	}, pipeline.Concurrency(4))
+	got := collect(p, "collect", squares)
+
+	require.NoError(t, p.Run(context.Background()))
+	assert.Greater(t, maxRunning, int64(1))
+	assert.LessOrEqual(t, maxRunning, int64(4))
+
+	sort.Ints(*got)
+	require.Len(t, *got, 100)
+	for i, square := range *got {
+		assert.Equal(t, (i+1)*(i+1), square)
+	}
+}
 
-	p.AddPostProcessingHook(func(ctx context.Context, err error) error {
-		return errors.New("post-hook error")
-	})
+func TestBatch(t *testing.T) {
+	p := pipeline.New("test")
+	numbers := pipeline.From[int](p, "numbers", &countSource{count: 7})
+	batches := pipeline.Batch(p, "batches", numbers, 3)
+	got := collect(p, "collect", batches)
 
-	err := <-p.Process(&SimpleReader{CountObject: 10})
-	assert.Error(t, err)
-	assert.Equal(t, "Error running post-hook: post-hook error", err.Error())
+	require.NoError(t, p.Run(context.Background()))
+	assert.Equal(t, [][]int{{1, 2, 3}, {4, 5, 6}, {7}}, *got)
 }
 
-func TestPostHookWhenShutDown(t *testing.T) {
-	done := make(chan bool)
-	p := pipeline.New(
-		pipeline.Node(&NoOpProcessor{}),
-	)
+func TestBackpressure(t *testing.T) {
+	p := pipeline.New("test")
+	source := &countSource{count: 1000}
+	numbers := pipeline.From[int](p, "numbers", source)
 
-	p.AddPostProcessingHook(func(ctx context.Context, err error) error {
-		assert.Equal(t, pipeline.ErrShutdown, err)
-		done <- true
+	release := make(chan struct{})
+	pipeline.Sink(p, "slow", numbers, func(ctx context.Context, n int) error {
+		<-release
 		return nil
-	})
-
-	go p.Process(&SimpleReader{})
-	time.Sleep(100 * time.Millisecond)
-	p.Shutdown()
-	<-done
-}
-
-func TestProcessShutdown(t *testing.T) {
-	done := make(chan bool)
-	p := pipeline.New(
-		pipeline.Node(&WaitForShutDownProcessor{}),
-	)
+	}, pipeline.Buffer(2))
 
+	done := make(chan error)
 	go func() {
-		err := <-p.Process(&SimpleReader{})
-		assert.Equal(t, pipeline.ErrShutdown, err)
-		done <- true
+		done <- p.Run(context.Background())
 	}()
-	time.Sleep(100 * time.Millisecond)
-	p.Shutdown()
-	<-done
-
-	// Calling it again should also return error (different code path)
-	err := <-p.Process(&SimpleReader{})
-	assert.Equal(t, pipeline.ErrShutdown, err)
-}
-
-// SimpleReader sends CountObject objects. If CountObject = 0 it
-// streams infinite number of objects.
-type SimpleReader struct {
-	sync.Mutex
-	CountObject int
 
-	sent int
-}
+	// one item being processed, two buffered and one blocked in the source
+	time.Sleep(50 * time.Millisecond)
+	assert.LessOrEqual(t, atomic.LoadInt64(&source.read), int64(4))
+	assert.Equal(t, 2, p.Stats()[1].Queued)
 
-func (r *SimpleReader) GetContext() context.Context {
-	return context.Background()
+	close(release)
+	require.NoError(t, <-done)
+	assert.Equal(t, int64(1000), p.Stats()[1].Read)
 }
 
-func (r *SimpleReader) Read() (interface{}, error) {
-	r.Lock()
-	defer r.Unlock()
-
-	if r.CountObject != 0 && r.sent == r.CountObject {
-		return nil, io.EOF
+func TestNodeError(t *testing.T) {
+	p := pipeline.New("test")
+	source := &countSource{count: 1000000}
+	numbers := pipeline.From[int](p, "numbers", source)
+	failure := errors.New("cannot process 5")
+	checked := pipeline.Map(p, "check", numbers, func(ctx context.Context, n int) (int, error) {
+		if n == 5 {
+			return 0, failure
+		}
+		return n, nil
+	})
+	collect(p, "collect", checked)
+
+	err := p.Run(context.Background())
+	require.Error(t, err)
+	assert.EqualError(t, err, "node check: cannot process 5")
+	var nodeErr *pipeline.NodeError
+	require.ErrorAs(t, err, &nodeErr)
+	assert.Equal(t, "check", nodeErr.Node)
+	assert.ErrorIs(t, err, failure)
+
+	assert.True(t, source.closed)
+	assert.Less(t, atomic.LoadInt64(&source.read), int64(1000000))
+	for _, stats := range p.Stats() {
+		assert.Equal(t, stats.Name == "check", stats.Failed, stats.Name)
 	}
-
-	r.sent++
-	return "test", nil
 }
 
-func (r *SimpleReader) Close() error {
-	return nil
-}
+func TestCancel(t *testing.T) {
+	p := pipeline.New("test")
+	numbers := pipeline.From[int](p, "numbers", &countSource{count: 1000000})
+	started := make(chan struct{})
+	var once sync.Once
+	pipeline.Sink(p, "block", numbers, func(ctx context.Context, n int) error {
+		once.Do(func() { close(started) })
+		<-ctx.Done()
+		return ctx.Err()
+	})
 
-type NoOpProcessor struct {
-	ReturnError bool
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+	assert.Equal(t, context.Canceled, p.Run(ctx))
+	for _, stats := range p.Stats() {
+		assert.False(t, stats.Failed, stats.Name)
+	}
 }
 
-func (p *NoOpProcessor) Process(ctx context.Context, store *pipeline.Store, r pipeline.Reader, w pipeline.Writer) error {
-	defer r.Close()
-	defer w.Close()
-
-	for {
-		_, err := r.Read()
-		if err != nil {
-			if err == io.EOF {
-				break
-			} else {
-				return errors.Wrap(err, "Error reading from Reader")
-			}
-		}
-
-		if p.ReturnError {
-			return errors.New("Test error")
-		}
+func TestMetrics(t *testing.T) {
+	metrics := pipeline.NewMetrics("test")
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(metrics)
+
+	for _, name := range []string{"first", "second"} {
+		p := pipeline.New(name)
+		p.SetMetrics(metrics)
+		numbers := pipeline.From[int](p, "numbers", &countSource{count: 3})
+		collect(p, "collect", numbers)
+		require.NoError(t, p.Run(context.Background()))
+	}
 
-		select {
-		case <-ctx.Done():
-			return nil
-		default:
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	values := map[string]float64{}
+	for _, family := range families {
+		if family.GetName() != "test_pipeline_items_read_total" {
 			continue
 		}
+		for _, metric := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			values[labels["pipeline"]+"/"+labels["node"]] = metric.GetCounter().GetValue()
+		}
 	}
-
-	return nil
-}
-
-func (p *NoOpProcessor) Name() string {
-	return "NoOpProcessor"
+	assert.Equal(t, map[string]float64{
+		"first/numbers":  0,
+		"first/collect":  3,
+		"second/numbers": 0,
+		"second/collect": 3,
+	}, values)
+	assert.Equal(t, 20, testutil.CollectAndCount(metrics))
 }
 
-func (p *NoOpProcessor) Reset() {}
+func TestChangeSource(t *testing.T) {
+	change := ingest.Change{Type: xdr.LedgerEntryTypeAccount}
+	reader := &ingest.MockChangeReader{}
+	reader.On("Read").Return(change, nil).Twice()
+	reader.On("Read").Return(ingest.Change{}, io.EOF).Once()
+	reader.On("Close").Return(nil).Once()
 
-type WaitForShutDownProcessor struct{}
+	p := pipeline.New("test")
+	changes := pipeline.From(p, "changes", pipeline.ChangeSource(reader))
+	got := collect(p, "collect", changes)
 
-func (p *WaitForShutDownProcessor) Process(ctx context.Context, store *pipeline.Store, r pipeline.Reader, w pipeline.Writer) error {
-	<-ctx.Done()
-	return nil
+	require.NoError(t, p.Run(context.Background()))
+	assert.Equal(t, []ingest.Change{change, change}, *got)
+	reader.AssertExpectations(t)
 }
 
-func (p *WaitForShutDownProcessor) Name() string {
-	return "WaitForShutDownProcessor"
-}
+func TestTransactionChanges(t *testing.T) {
+	account := xdr.MustAddress("GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7")
+	tx := ingest.LedgerTransaction{
+		FeeChanges: xdr.LedgerEntryChanges{
+			{
+				Type: xdr.LedgerEntryChangeTypeLedgerEntryState,
+				State: &xdr.LedgerEntry{
+					Data: xdr.LedgerEntryData{
+						Type:    xdr.LedgerEntryTypeAccount,
+						Account: &xdr.AccountEntry{AccountId: account, Balance: 200},
+					},
+				},
+			},
+			{
+				Type: xdr.LedgerEntryChangeTypeLedgerEntryUpdated,
+				Updated: &xdr.LedgerEntry{
+					Data: xdr.LedgerEntryData{
+						Type:    xdr.LedgerEntryTypeAccount,
+						Account: &xdr.AccountEntry{AccountId: account, Balance: 100},
+					},
+				},
+			},
+		},
+		UnsafeMeta: xdr.TransactionMeta{V: 1, V1: &xdr.TransactionMetaV1{}},
+	}
 
-func (p *WaitForShutDownProcessor) Reset() {}
+	var changes []ingest.Change
+	err := pipeline.TransactionChanges(context.Background(), tx, func(change ingest.Change) error {
+		changes = append(changes, change)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, xdr.Int64(200), changes[0].Pre.Data.Account.Balance)
+	assert.Equal(t, xdr.Int64(100), changes[0].Post.Data.Account.Balance)
+}
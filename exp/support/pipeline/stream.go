@@ -0,0 +1,199 @@
+package pipeline
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/shantanu-hashcash/go/support/errors"
+)
+
+// Source feeds items into a pipeline. ingest.ChangeReader and
+// *ingest.LedgerTransactionReader are sources.
+type Source[T any] interface {
+	// Read returns the next item, or io.EOF when there are no more items.
+	Read() (T, error)
+	// Close is called when the pipeline stops reading from the source.
+	Close() error
+}
+
+// ProcessFunc processes an item, writing any number of items to the next
+// nodes with emit. emit blocks while the next nodes' buffers are full and
+// returns an error when the pipeline is stopped.
+type ProcessFunc[In, Out any] func(ctx context.Context, item In, emit func(Out) error) error
+
+// Stream is the output of a node. Every node consuming a stream receives all
+// of its items.
+type Stream[T any] struct {
+	pipeline *Pipeline
+	node     *node
+	outputs  []chan T
+}
+
+func newStream[T any](p *Pipeline, n *node) *Stream[T] {
+	s := &Stream[T]{pipeline: p, node: n}
+	n.done = s.close
+	return s
+}
+
+func (s *Stream[T]) subscribe(p *Pipeline, buffer int) chan T {
+	if s.pipeline != p {
+		panic("stream of node " + s.node.name + " belongs to another pipeline")
+	}
+	ch := make(chan T, buffer)
+	s.outputs = append(s.outputs, ch)
+	return ch
+}
+
+func (s *Stream[T]) write(ctx context.Context, item T) error {
+	for _, out := range s.outputs {
+		select {
+		case out <- item:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	s.node.wrote()
+	return nil
+}
+
+func (s *Stream[T]) close() {
+	for _, out := range s.outputs {
+		close(out)
+	}
+}
+
+// From adds a node reading the items of source. The source is read by a
+// single goroutine and closed when the node stops.
+func From[T any](p *Pipeline, name string, source Source[T]) *Stream[T] {
+	n := p.addNode(name, 1)
+	out := newStream[T](p, n)
+	n.work = func(ctx context.Context) (err error) {
+		defer func() {
+			if closeErr := source.Close(); closeErr != nil && err == nil {
+				err = errors.Wrap(closeErr, "could not close source")
+			}
+		}()
+
+		for ctx.Err() == nil {
+			item, err := source.Read()
+			if err == io.EOF {
+				return nil
+			} else if err != nil {
+				return err
+			}
+			if err := out.write(ctx, item); err != nil {
+				return err
+			}
+		}
+		return ctx.Err()
+	}
+	return out
+}
+
+// consume adds a node calling handle with every item of in and flush once
+// in has been exhausted.
+func consume[In any](
+	p *Pipeline,
+	name string,
+	in *Stream[In],
+	config nodeConfig,
+	handle func(ctx context.Context, item In) error,
+	flush func(ctx context.Context) error,
+) *node {
+	n := p.addNode(name, config.concurrency)
+	input := in.subscribe(p, config.buffer)
+	n.queued = func() int { return len(input) }
+	n.work = func(ctx context.Context) error {
+		for {
+			select {
+			case item, ok := <-input:
+				if !ok {
+					if flush != nil {
+						return flush(ctx)
+					}
+					return nil
+				}
+				n.received()
+				start := time.Now()
+				err := handle(ctx, item)
+				n.processed(time.Since(start))
+				if err != nil {
+					return err
+				}
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+	return n
+}
+
+// Process adds a node running fn with every item of in.
+func Process[In, Out any](p *Pipeline, name string, in *Stream[In], fn ProcessFunc[In, Out], opts ...Option) *Stream[Out] {
+	var out *Stream[Out]
+	n := consume(p, name, in, newNodeConfig(opts), func(ctx context.Context, item In) error {
+		return fn(ctx, item, func(result Out) error {
+			return out.write(ctx, result)
+		})
+	}, nil)
+	out = newStream[Out](p, n)
+	return out
+}
+
+// Map adds a node writing the result of fn for every item of in.
+func Map[In, Out any](p *Pipeline, name string, in *Stream[In], fn func(ctx context.Context, item In) (Out, error), opts ...Option) *Stream[Out] {
+	return Process(p, name, in, func(ctx context.Context, item In, emit func(Out) error) error {
+		result, err := fn(ctx, item)
+		if err != nil {
+			return err
+		}
+		return emit(result)
+	}, opts...)
+}
+
+// Filter adds a node writing the items of in for which fn returns true.
+func Filter[T any](p *Pipeline, name string, in *Stream[T], fn func(ctx context.Context, item T) (bool, error), opts ...Option) *Stream[T] {
+	return Process(p, name, in, func(ctx context.Context, item T, emit func(T) error) error {
+		keep, err := fn(ctx, item)
+		if err != nil || !keep {
+			return err
+		}
+		return emit(item)
+	}, opts...)
+}
+
+// Batch adds a node grouping the items of in into slices of size items. The
+// last batch holds the remaining items and may be shorter. Batches are built
+// by a single goroutine, the Concurrency option is ignored.
+func Batch[T any](p *Pipeline, name string, in *Stream[T], size int, opts ...Option) *Stream[[]T] {
+	if size < 1 {
+		panic("batch size must be positive")
+	}
+	config := newNodeConfig(opts)
+	config.concurrency = 1
+
+	var out *Stream[[]T]
+	batch := make([]T, 0, size)
+	n := consume(p, name, in, config, func(ctx context.Context, item T) error {
+		batch = append(batch, item)
+		if len(batch) < size {
+			return nil
+		}
+		full := batch
+		batch = make([]T, 0, size)
+		return out.write(ctx, full)
+	}, func(ctx context.Context) error {
+		if len(batch) == 0 {
+			return nil
+		}
+		return out.write(ctx, batch)
+	})
+	out = newStream[[]T](p, n)
+	return out
+}
+
+// Sink adds a node running fn with every item of in.
+func Sink[T any](p *Pipeline, name string, in *Stream[T], fn func(ctx context.Context, item T) error, opts ...Option) {
+	consume(p, name, in, newNodeConfig(opts), fn, nil)
+}
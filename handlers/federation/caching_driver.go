@@ -0,0 +1,117 @@
+package federation
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type cachedRecord struct {
+	record    *Record
+	expiresAt time.Time
+}
+
+type cachedReverseRecord struct {
+	record    *ReverseRecord
+	expiresAt time.Time
+}
+
+// LookupRecord implements `Driver` by returning the cached result of
+// `drv.Driver`, looking it up if it is not cached or has expired
+func (drv *CachingDriver) LookupRecord(ctx context.Context, name, domain string) (*Record, error) {
+	key := name + "*" + domain
+	now := time.Now()
+
+	drv.mu.Lock()
+	cached, ok := drv.records[key]
+	drv.mu.Unlock()
+	if ok && now.Before(cached.expiresAt) {
+		return cached.record, nil
+	}
+
+	rec, err := drv.Driver.LookupRecord(ctx, name, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	drv.mu.Lock()
+	drv.prune(now)
+	drv.records[key] = cachedRecord{record: rec, expiresAt: now.Add(drv.TTL)}
+	drv.mu.Unlock()
+	return rec, nil
+}
+
+// LookupReverseRecord implements `ReverseDriver` by returning the cached
+// result of `drv.Driver`, looking it up if it is not cached or has expired
+func (drv *CachingDriver) LookupReverseRecord(ctx context.Context, accountid string) (*ReverseRecord, error) {
+	rd, ok := drv.Driver.(ReverseDriver)
+	if !ok {
+		return nil, ErrorResponse{
+			StatusCode: http.StatusNotImplemented,
+			Code:       "not_implemented",
+			Message:    "id type queries are not supported",
+		}
+	}
+
+	now := time.Now()
+
+	drv.mu.Lock()
+	cached, ok := drv.reverse[accountid]
+	drv.mu.Unlock()
+	if ok && now.Before(cached.expiresAt) {
+		return cached.record, nil
+	}
+
+	rec, err := rd.LookupReverseRecord(ctx, accountid)
+	if err != nil {
+		return nil, err
+	}
+
+	drv.mu.Lock()
+	drv.prune(now)
+	drv.reverse[accountid] = cachedReverseRecord{record: rec, expiresAt: now.Add(drv.TTL)}
+	drv.mu.Unlock()
+	return rec, nil
+}
+
+// LookupForwardingRecord implements `ForwardDriver` by passing the query to
+// `drv.Driver` without caching
+func (drv *CachingDriver) LookupForwardingRecord(query url.Values) (*Record, error) {
+	fd, ok := drv.Driver.(ForwardDriver)
+	if !ok {
+		return nil, ErrorResponse{
+			StatusCode: http.StatusNotImplemented,
+			Code:       "not_implemented",
+			Message:    "forward type queries are not supported",
+		}
+	}
+	return fd.LookupForwardingRecord(query)
+}
+
+var _ Driver = &CachingDriver{}
+var _ ReverseDriver = &CachingDriver{}
+var _ ForwardDriver = &CachingDriver{}
+
+// prune removes expired results, at most once per TTL. It must be called with
+// the lock held.
+func (drv *CachingDriver) prune(now time.Time) {
+	if drv.records == nil {
+		drv.records = map[string]cachedRecord{}
+		drv.reverse = map[string]cachedReverseRecord{}
+	}
+	if now.Sub(drv.pruned) < drv.TTL {
+		return
+	}
+	drv.pruned = now
+	for k, c := range drv.records {
+		if !now.Before(c.expiresAt) {
+			delete(drv.records, k)
+		}
+	}
+	for k, c := range drv.reverse {
+		if !now.Before(c.expiresAt) {
+			delete(drv.reverse, k)
+		}
+	}
+}
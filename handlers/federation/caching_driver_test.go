@@ -0,0 +1,86 @@
+package federation
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/shantanu-hashcash/go/support/http/httptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingDriver struct {
+	MemoryDriver
+	lookups        int
+	reverseLookups int
+}
+
+func (drv *countingDriver) LookupRecord(ctx context.Context, name, domain string) (*Record, error) {
+	drv.lookups++
+	return drv.MemoryDriver.LookupRecord(ctx, name, domain)
+}
+
+func (drv *countingDriver) LookupReverseRecord(ctx context.Context, accountid string) (*ReverseRecord, error) {
+	drv.reverseLookups++
+	return drv.MemoryDriver.LookupReverseRecord(ctx, accountid)
+}
+
+func TestCachingDriver(t *testing.T) {
+	ctx := context.Background()
+	mem, err := NewMemoryDriver(map[string]MemoryDomain{
+		"hcnet.org": {Names: map[string]MemoryRecord{
+			"scott": {AccountID: "GD2GJPL3UOK5LX7TWXOACK2ZPWPFSLBNKL3GTGH6BLBNISK4BGWMFBBG"},
+		}},
+	})
+	require.NoError(t, err)
+	counting := &countingDriver{MemoryDriver: *mem}
+	drv := &CachingDriver{Driver: counting, TTL: time.Hour}
+
+	for i := 0; i < 2; i++ {
+		rec, err := drv.LookupRecord(ctx, "scott", "hcnet.org")
+		require.NoError(t, err)
+		assert.Equal(t, "GD2GJPL3UOK5LX7TWXOACK2ZPWPFSLBNKL3GTGH6BLBNISK4BGWMFBBG", rec.AccountID)
+
+		// Records not found are cached too.
+		rec, err = drv.LookupRecord(ctx, "jed", "hcnet.org")
+		require.NoError(t, err)
+		assert.Nil(t, rec)
+
+		rev, err := drv.LookupReverseRecord(ctx, "GD2GJPL3UOK5LX7TWXOACK2ZPWPFSLBNKL3GTGH6BLBNISK4BGWMFBBG")
+		require.NoError(t, err)
+		assert.Equal(t, &ReverseRecord{Name: "scott", Domain: "hcnet.org"}, rev)
+	}
+	assert.Equal(t, 2, counting.lookups)
+	assert.Equal(t, 1, counting.reverseLookups)
+
+	// Expired results are looked up again.
+	drv.records["scott*hcnet.org"] = cachedRecord{expiresAt: time.Now().Add(-time.Second)}
+	rec, err := drv.LookupRecord(ctx, "scott", "hcnet.org")
+	require.NoError(t, err)
+	assert.NotNil(t, rec)
+	assert.Equal(t, 3, counting.lookups)
+}
+
+func TestCachingDriver_notImplemented(t *testing.T) {
+	handler := &Handler{&CachingDriver{Driver: &HTTPDriver{}, TTL: time.Hour}}
+	server := httptest.NewServer(t, handler)
+	defer server.Close()
+
+	server.GET("/federation").
+		WithQuery("type", "id").
+		WithQuery("q", "GA3R753JKGXU6ETHNY3U6PYIY7D6UUCXXDYBRF4XURNAGXW3CVGQH2ZA").
+		Expect().
+		Status(http.StatusNotImplemented).
+		JSON().Object().
+		ValueEqual("code", "not_implemented")
+
+	server.GET("/federation").
+		WithQuery("type", "forward").
+		WithQuery("acct", "1234").
+		Expect().
+		Status(http.StatusNotImplemented).
+		JSON().Object().
+		ValueEqual("code", "not_implemented")
+}
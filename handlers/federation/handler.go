@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 
 	"github.com/pkg/errors"
 	"github.com/shantanu-hashcash/go/address"
 	proto "github.com/shantanu-hashcash/go/protocols/federation"
+	"github.com/shantanu-hashcash/go/strkey"
 	"github.com/shantanu-hashcash/go/support/log"
 )
 
@@ -63,7 +65,13 @@ func (h *Handler) lookupByID(w http.ResponseWriter, r *http.Request, q string) {
 		return
 	}
 
-	// TODO: validate that `q` is a strkey encoded address
+	if !strkey.IsValidEd25519PublicKey(q) && !strkey.IsValidMuxedAccountEd25519PublicKey(q) {
+		h.writeJSON(w, ErrorResponse{
+			Code:    "invalid_query",
+			Message: "Please use an account ID or a muxed account address",
+		}, http.StatusBadRequest)
+		return
+	}
 
 	rec, err := rd.LookupReverseRecord(r.Context(), q)
 	if err != nil {
@@ -101,11 +109,7 @@ func (h *Handler) lookupByName(w http.ResponseWriter, r *http.Request, q string)
 		return
 	}
 
-	h.writeJSON(w, proto.NameResponse{
-		AccountID: rec.AccountID,
-		Memo:      proto.Memo{rec.Memo},
-		MemoType:  rec.MemoType,
-	}, http.StatusOK)
+	h.writeRecord(w, rec)
 }
 
 func (h *Handler) lookupByForward(w http.ResponseWriter, query url.Values) {
@@ -126,11 +130,39 @@ func (h *Handler) lookupByForward(w http.ResponseWriter, query url.Values) {
 		return
 	}
 
-	h.writeJSON(w, proto.NameResponse{
+	h.writeRecord(w, rec)
+}
+
+// writeRecord writes the name response for the record. A record with a muxed
+// account address is written as the account ID of the muxed account with its
+// ID as an id memo.
+func (h *Handler) writeRecord(w http.ResponseWriter, rec *Record) {
+	resp := proto.NameResponse{
 		AccountID: rec.AccountID,
-		Memo:      proto.Memo{rec.Memo},
+		Memo:      proto.Memo{Value: rec.Memo},
 		MemoType:  rec.MemoType,
-	}, http.StatusOK)
+	}
+
+	if strkey.IsValidMuxedAccountEd25519PublicKey(rec.AccountID) {
+		if rec.MemoType != "" || rec.Memo != "" {
+			h.writeError(w, errors.New("record has a muxed account address and a memo"))
+			return
+		}
+		muxed, err := strkey.DecodeMuxedAccount(rec.AccountID)
+		if err != nil {
+			h.writeError(w, errors.Wrap(err, "decode muxed account"))
+			return
+		}
+		resp.AccountID, err = muxed.AccountID()
+		if err != nil {
+			h.writeError(w, errors.Wrap(err, "muxed account id"))
+			return
+		}
+		resp.MemoType = "id"
+		resp.Memo = proto.Memo{Value: strconv.FormatUint(muxed.ID(), 10)}
+	}
+
+	h.writeJSON(w, resp, http.StatusOK)
 }
 
 func (h *Handler) writeJSON(
@@ -0,0 +1,77 @@
+package federation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	proto "github.com/shantanu-hashcash/go/protocols/federation"
+	"github.com/shantanu-hashcash/go/support/errors"
+)
+
+// LookupRecord implements `Driver` by calling `drv.LookupRecordURL` with the
+// provided name and domain
+func (drv *HTTPDriver) LookupRecord(ctx context.Context, name, domain string) (*Record, error) {
+	var resp proto.NameResponse
+
+	found, err := drv.get(ctx, drv.LookupRecordURL, url.Values{
+		"name":   []string{name},
+		"domain": []string{domain},
+	}, &resp)
+	if err != nil {
+		return nil, errors.Wrap(err, "http get")
+	}
+	if !found {
+		return nil, nil
+	}
+
+	return &Record{
+		AccountID: resp.AccountID,
+		MemoType:  resp.MemoType,
+		Memo:      resp.Memo.Value,
+	}, nil
+}
+
+var _ Driver = &HTTPDriver{}
+
+// get calls the service at the URL with the query parameters added and
+// decodes the response into dest. It returns false if the service responds
+// that it has no record.
+func (drv *HTTPDriver) get(ctx context.Context, rawURL string, query url.Values, dest interface{}) (bool, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false, errors.Wrap(err, "parse url")
+	}
+	q := u.Query()
+	for k, v := range query {
+		q[k] = v
+	}
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return false, errors.Wrap(err, "new request")
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := drv.HTTP.Do(req)
+	if err != nil {
+		return false, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, errors.Errorf("http request failed with non-200 status code: %d", resp.StatusCode)
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(dest)
+	if err != nil {
+		return false, errors.Wrap(err, "json decode")
+	}
+	return true, nil
+}
@@ -0,0 +1,61 @@
+package federation
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/shantanu-hashcash/go/support/http/httptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPDriver(t *testing.T) {
+	ctx := context.Background()
+	h := httptest.NewClient()
+	drv := &ReverseHTTPDriver{
+		HTTPDriver: HTTPDriver{
+			HTTP:            h,
+			LookupRecordURL: "https://users.example.com/federation?key=1",
+		},
+		LookupReverseRecordURL: "https://users.example.com/reverse-federation",
+	}
+
+	h.
+		On("GET", "https://users.example.com/federation?domain=hcnet.org&key=1&name=scott").
+		ReturnJSON(http.StatusOK, map[string]interface{}{
+			"account_id": "GD6WU64OEP5C4LRBH6NK3MHYIA2ADN6K6II6EXPNVUR3ERBXT4AN4ACD",
+			"memo_type":  "id",
+			"memo":       1,
+		})
+	rec, err := drv.LookupRecord(ctx, "scott", "hcnet.org")
+	require.NoError(t, err)
+	assert.Equal(t, &Record{AccountID: "GD6WU64OEP5C4LRBH6NK3MHYIA2ADN6K6II6EXPNVUR3ERBXT4AN4ACD", MemoType: "id", Memo: "1"}, rec)
+
+	h.
+		On("GET", "https://users.example.com/federation?domain=hcnet.org&key=1&name=jed").
+		ReturnNotFound()
+	rec, err = drv.LookupRecord(ctx, "jed", "hcnet.org")
+	require.NoError(t, err)
+	assert.Nil(t, rec)
+
+	h.
+		On("GET", "https://users.example.com/federation?domain=hcnet.org&key=1&name=error").
+		ReturnString(http.StatusInternalServerError, "error")
+	_, err = drv.LookupRecord(ctx, "error", "hcnet.org")
+	assert.EqualError(t, err, "http get: http request failed with non-200 status code: 500")
+
+	h.
+		On("GET", "https://users.example.com/reverse-federation?account_id=GD2GJPL3UOK5LX7TWXOACK2ZPWPFSLBNKL3GTGH6BLBNISK4BGWMFBBG").
+		ReturnJSON(http.StatusOK, map[string]string{"name": "scott", "domain": "hcnet.org"})
+	rev, err := drv.LookupReverseRecord(ctx, "GD2GJPL3UOK5LX7TWXOACK2ZPWPFSLBNKL3GTGH6BLBNISK4BGWMFBBG")
+	require.NoError(t, err)
+	assert.Equal(t, &ReverseRecord{Name: "scott", Domain: "hcnet.org"}, rev)
+
+	h.
+		On("GET", "https://users.example.com/reverse-federation?account_id=GA3R753JKGXU6ETHNY3U6PYIY7D6UUCXXDYBRF4XURNAGXW3CVGQH2ZA").
+		ReturnNotFound()
+	rev, err = drv.LookupReverseRecord(ctx, "GA3R753JKGXU6ETHNY3U6PYIY7D6UUCXXDYBRF4XURNAGXW3CVGQH2ZA")
+	require.NoError(t, err)
+	assert.Nil(t, rev)
+}
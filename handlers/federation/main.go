@@ -8,13 +8,23 @@
 //
 // A pre-baked implementation of `Driver` and `ReverseDriver` that provides
 // simple access to SQL systems is included. See `SQLDriver` for more details.
+// Implementations that call out to an HTTP service (see `HTTPDriver`) and that
+// serve records from memory (see `MemoryDriver`) are also included, and any
+// driver can be wrapped in a `CachingDriver` to cache its results.
+//
+// Records may use a muxed account address (M...) as their account ID. The
+// handler responds to name requests for such records with the underlying
+// account ID and an id memo, so that many federation names can map to a shared
+// account, and accepts muxed account addresses in reverse (id) requests.
 package federation
 
 import (
 	"context"
 	"database/sql"
+	"net/http"
 	"net/url"
 	"sync"
+	"time"
 
 	"github.com/shantanu-hashcash/go/support/db"
 )
@@ -122,3 +132,82 @@ type SQLDriver struct {
 	init sync.Once
 	db   *db.Session
 }
+
+// HTTP represents the http client that an `HTTPDriver` uses to make http
+// requests.
+type HTTP interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// HTTPDriver represents an implementation of `Driver` that looks up records by
+// calling out to an HTTP service, allowing a federation server to be backed
+// by any existing service.
+type HTTPDriver struct {
+	// HTTP is the http client used to call the service.
+	HTTP HTTP
+
+	// LookupRecordURL is the URL the service is called at for "forward"
+	// federation queries. The name and domain are added to the URL as the
+	// `name` and `domain` query parameters. The service should respond with a
+	// federation name response, a JSON object with the `account_id` and
+	// optional `memo_type` and `memo` fields, or a 404 status code if no record
+	// is found.
+	LookupRecordURL string
+}
+
+// ReverseHTTPDriver provides a `ReverseDriver` implementation based upon an
+// HTTP service.  See `HTTPDriver`, the forward only version, for more details.
+type ReverseHTTPDriver struct {
+	HTTPDriver
+
+	// LookupReverseRecordURL is the URL the service is called at for
+	// "reverse" federation queries. The account ID is added to the URL as the
+	// `account_id` query parameter. The service should respond with a JSON
+	// object with the `name` and `domain` fields, or a 404 status code if no
+	// record is found.
+	LookupReverseRecordURL string
+}
+
+// MemoryDriver represents an implementation of `Driver` and `ReverseDriver`
+// that serves records held in memory, organized as a directory of domains
+// that contain names.  A domain can define an account ID that is inherited by
+// the names within it that do not define their own, so that names can map to a
+// shared account with a memo.  Names and domains are matched case
+// insensitively.  Use `NewMemoryDriver` or `ReadMemoryDriver` to create one.
+type MemoryDriver struct {
+	records map[string]Record
+	reverse map[string]ReverseRecord
+}
+
+// MemoryDomain is a domain of a `MemoryDriver`.
+type MemoryDomain struct {
+	// AccountID is the account ID inherited by names in the domain that do
+	// not define their own.
+	AccountID string `yaml:"account_id"`
+	// Names are the records of the names in the domain.
+	Names map[string]MemoryRecord `yaml:"names"`
+}
+
+// MemoryRecord is the record of a name in a `MemoryDomain`.
+type MemoryRecord struct {
+	AccountID string `yaml:"account_id"`
+	MemoType  string `yaml:"memo_type"`
+	Memo      string `yaml:"memo"`
+}
+
+// CachingDriver represents an implementation of `Driver` and `ReverseDriver`
+// that caches the results of another driver, including lookups that found no
+// record, for a period of time.  Reverse queries respond as not implemented if
+// the driver is not a `ReverseDriver`.  Errors are not cached.
+type CachingDriver struct {
+	// Driver is the driver whose results are cached.
+	Driver Driver
+
+	// TTL is the period of time results are cached for.
+	TTL time.Duration
+
+	mu      sync.Mutex
+	records map[string]cachedRecord
+	reverse map[string]cachedReverseRecord
+	pruned  time.Time
+}
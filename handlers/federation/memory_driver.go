@@ -0,0 +1,160 @@
+package federation
+
+import (
+	"context"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/shantanu-hashcash/go/strkey"
+	"github.com/shantanu-hashcash/go/support/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// NewMemoryDriver returns a `MemoryDriver` serving the records of the
+// domains. An error is returned if a record has an invalid account ID or memo.
+//
+// Reverse queries are answered for records that resolve to an account ID
+// without a memo, or to an account ID with an id memo, which is looked up by
+// its muxed account address. If more than one record resolves to the same
+// address the first name in alphabetical order is the answer.
+func NewMemoryDriver(domains map[string]MemoryDomain) (*MemoryDriver, error) {
+	drv := &MemoryDriver{
+		records: map[string]Record{},
+		reverse: map[string]ReverseRecord{},
+	}
+
+	domainNames := make([]string, 0, len(domains))
+	for domain := range domains {
+		domainNames = append(domainNames, domain)
+	}
+	sort.Strings(domainNames)
+
+	for _, domain := range domainNames {
+		d := domains[domain]
+		names := make([]string, 0, len(d.Names))
+		for name := range d.Names {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			r := d.Names[name]
+			rec := Record{
+				AccountID: r.AccountID,
+				MemoType:  r.MemoType,
+				Memo:      r.Memo,
+			}
+			if rec.AccountID == "" {
+				rec.AccountID = d.AccountID
+			}
+			address, err := reverseAddress(rec)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid record for %s*%s", name, domain)
+			}
+
+			key := memoryKey(name, domain)
+			if _, ok := drv.records[key]; ok {
+				return nil, errors.Errorf("duplicate record for %s*%s", name, domain)
+			}
+			drv.records[key] = rec
+
+			if _, ok := drv.reverse[address]; address != "" && !ok {
+				drv.reverse[address] = ReverseRecord{
+					Name:   strings.ToLower(name),
+					Domain: strings.ToLower(domain),
+				}
+			}
+		}
+	}
+
+	return drv, nil
+}
+
+// ReadMemoryDriver returns a `MemoryDriver` serving the records read from a
+// YAML document of the form:
+//
+//	example.com:
+//	  account_id: GD6WU64OEP5C4LRBH6NK3MHYIA2ADN6K6II6EXPNVUR3ERBXT4AN4ACD
+//	  names:
+//	    alice:
+//	      memo_type: id
+//	      memo: "1"
+//	    bob:
+//	      account_id: GA3R753JKGXU6ETHNY3U6PYIY7D6UUCXXDYBRF4XURNAGXW3CVGQH2ZA
+func ReadMemoryDriver(r io.Reader) (*MemoryDriver, error) {
+	domains := map[string]MemoryDomain{}
+	err := yaml.NewDecoder(r).Decode(&domains)
+	if err != nil && err != io.EOF {
+		return nil, errors.Wrap(err, "yaml decode")
+	}
+	return NewMemoryDriver(domains)
+}
+
+// LookupRecord implements `Driver` by looking up the record of the name in
+// the domain
+func (drv *MemoryDriver) LookupRecord(ctx context.Context, name, domain string) (*Record, error) {
+	rec, ok := drv.records[memoryKey(name, domain)]
+	if !ok {
+		return nil, nil
+	}
+	return &rec, nil
+}
+
+// LookupReverseRecord implements `ReverseDriver` by looking up the name that
+// resolves to the account id or muxed account address
+func (drv *MemoryDriver) LookupReverseRecord(ctx context.Context, accountid string) (*ReverseRecord, error) {
+	rec, ok := drv.reverse[accountid]
+	if !ok {
+		return nil, nil
+	}
+	return &rec, nil
+}
+
+var _ Driver = &MemoryDriver{}
+var _ ReverseDriver = &MemoryDriver{}
+
+func memoryKey(name, domain string) string {
+	return strings.ToLower(name) + "*" + strings.ToLower(domain)
+}
+
+// reverseAddress validates the record and returns the address that reverse
+// queries for it are made with, or an empty string if it cannot be reverse
+// queried.
+func reverseAddress(rec Record) (string, error) {
+	switch {
+	case strkey.IsValidMuxedAccountEd25519PublicKey(rec.AccountID):
+		if rec.MemoType != "" || rec.Memo != "" {
+			return "", errors.New("muxed account address with memo")
+		}
+		return rec.AccountID, nil
+	case strkey.IsValidEd25519PublicKey(rec.AccountID):
+	default:
+		return "", errors.New("invalid account id")
+	}
+
+	switch rec.MemoType {
+	case "":
+		if rec.Memo != "" {
+			return "", errors.New("memo without memo type")
+		}
+		return rec.AccountID, nil
+	case "id":
+		id, err := strconv.ParseUint(rec.Memo, 10, 64)
+		if err != nil {
+			return "", errors.New("invalid id memo")
+		}
+		muxed := strkey.MuxedAccount{}
+		err = muxed.SetAccountID(rec.AccountID)
+		if err != nil {
+			return "", err
+		}
+		muxed.SetID(id)
+		return muxed.Address()
+	case "text", "hash":
+		return "", nil
+	default:
+		return "", errors.Errorf("invalid memo type: %s", rec.MemoType)
+	}
+}
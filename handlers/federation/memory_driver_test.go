@@ -0,0 +1,140 @@
+package federation
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/shantanu-hashcash/go/support/http/httptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const memoryDriverYAML = `
+hcnet.org:
+  names:
+    scott:
+      account_id: GD2GJPL3UOK5LX7TWXOACK2ZPWPFSLBNKL3GTGH6BLBNISK4BGWMFBBG
+    Bartek:
+      account_id: MCYMGWPZ6NC2U7SO6SMXOP5ZLXOEC5SYPKITDMVEONLCHFSCCQR2IAAAAAAAAAAD5ABLS
+exchange.com:
+  account_id: GD6WU64OEP5C4LRBH6NK3MHYIA2ADN6K6II6EXPNVUR3ERBXT4AN4ACD
+  names:
+    alice:
+      memo_type: id
+      memo: "1"
+    bob:
+      memo_type: text
+      memo: bob
+`
+
+func TestMemoryDriver(t *testing.T) {
+	ctx := context.Background()
+	drv, err := ReadMemoryDriver(strings.NewReader(memoryDriverYAML))
+	require.NoError(t, err)
+
+	rec, err := drv.LookupRecord(ctx, "scott", "hcnet.org")
+	require.NoError(t, err)
+	assert.Equal(t, &Record{AccountID: "GD2GJPL3UOK5LX7TWXOACK2ZPWPFSLBNKL3GTGH6BLBNISK4BGWMFBBG"}, rec)
+
+	// Names and domains are matched case insensitively.
+	rec, err = drv.LookupRecord(ctx, "bartek", "Hcnet.org")
+	require.NoError(t, err)
+	assert.Equal(t, &Record{AccountID: "MCYMGWPZ6NC2U7SO6SMXOP5ZLXOEC5SYPKITDMVEONLCHFSCCQR2IAAAAAAAAAAD5ABLS"}, rec)
+
+	// Names inherit the account ID of their domain.
+	rec, err = drv.LookupRecord(ctx, "alice", "exchange.com")
+	require.NoError(t, err)
+	assert.Equal(t, &Record{AccountID: "GD6WU64OEP5C4LRBH6NK3MHYIA2ADN6K6II6EXPNVUR3ERBXT4AN4ACD", MemoType: "id", Memo: "1"}, rec)
+
+	rec, err = drv.LookupRecord(ctx, "jed", "hcnet.org")
+	require.NoError(t, err)
+	assert.Nil(t, rec)
+
+	rev, err := drv.LookupReverseRecord(ctx, "GD2GJPL3UOK5LX7TWXOACK2ZPWPFSLBNKL3GTGH6BLBNISK4BGWMFBBG")
+	require.NoError(t, err)
+	assert.Equal(t, &ReverseRecord{Name: "scott", Domain: "hcnet.org"}, rev)
+
+	rev, err = drv.LookupReverseRecord(ctx, "MCYMGWPZ6NC2U7SO6SMXOP5ZLXOEC5SYPKITDMVEONLCHFSCCQR2IAAAAAAAAAAD5ABLS")
+	require.NoError(t, err)
+	assert.Equal(t, &ReverseRecord{Name: "bartek", Domain: "hcnet.org"}, rev)
+
+	// An account ID with an id memo is reverse looked up by its muxed
+	// account address.
+	rev, err = drv.LookupReverseRecord(ctx, "MD6WU64OEP5C4LRBH6NK3MHYIA2ADN6K6II6EXPNVUR3ERBXT4AN4AAAAAAAAAAAAFB6Y")
+	require.NoError(t, err)
+	assert.Equal(t, &ReverseRecord{Name: "alice", Domain: "exchange.com"}, rev)
+
+	// The shared account itself is not the address of any one name.
+	rev, err = drv.LookupReverseRecord(ctx, "GD6WU64OEP5C4LRBH6NK3MHYIA2ADN6K6II6EXPNVUR3ERBXT4AN4ACD")
+	require.NoError(t, err)
+	assert.Nil(t, rev)
+}
+
+func TestNewMemoryDriver_invalid(t *testing.T) {
+	testCases := []struct {
+		record  MemoryRecord
+		wantErr string
+	}{
+		{MemoryRecord{}, "invalid record for scott*hcnet.org: invalid account id"},
+		{MemoryRecord{AccountID: "GD2GJPL3UOK5LX7TWXOACK2ZPWPFSLBNKL3GTGH6BLBNISK4BGWMFBBG", MemoType: "id", Memo: "x"}, "invalid record for scott*hcnet.org: invalid id memo"},
+		{MemoryRecord{AccountID: "GD2GJPL3UOK5LX7TWXOACK2ZPWPFSLBNKL3GTGH6BLBNISK4BGWMFBBG", MemoType: "bogus", Memo: "x"}, "invalid record for scott*hcnet.org: invalid memo type: bogus"},
+		{MemoryRecord{AccountID: "GD2GJPL3UOK5LX7TWXOACK2ZPWPFSLBNKL3GTGH6BLBNISK4BGWMFBBG", Memo: "x"}, "invalid record for scott*hcnet.org: memo without memo type"},
+		{MemoryRecord{AccountID: "MCYMGWPZ6NC2U7SO6SMXOP5ZLXOEC5SYPKITDMVEONLCHFSCCQR2IAAAAAAAAAAD5ABLS", MemoType: "id", Memo: "1"}, "invalid record for scott*hcnet.org: muxed account address with memo"},
+	}
+	for _, tc := range testCases {
+		_, err := NewMemoryDriver(map[string]MemoryDomain{
+			"hcnet.org": {Names: map[string]MemoryRecord{"scott": tc.record}},
+		})
+		assert.EqualError(t, err, tc.wantErr)
+	}
+}
+
+func TestMemoryHandler_muxed(t *testing.T) {
+	drv, err := ReadMemoryDriver(strings.NewReader(memoryDriverYAML))
+	require.NoError(t, err)
+
+	handler := &Handler{drv}
+	server := httptest.NewServer(t, handler)
+	defer server.Close()
+
+	// A muxed account is responded with as its account and an id memo
+	server.GET("/federation").
+		WithQuery("type", "name").
+		WithQuery("q", "bartek*hcnet.org").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().
+		ValueEqual("account_id", "GCYMGWPZ6NC2U7SO6SMXOP5ZLXOEC5SYPKITDMVEONLCHFSCCQR2J4S3").
+		ValueEqual("memo_type", "id").
+		ValueEqual("memo", "1000")
+
+	server.GET("/federation").
+		WithQuery("type", "name").
+		WithQuery("q", "alice*exchange.com").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().
+		ValueEqual("account_id", "GD6WU64OEP5C4LRBH6NK3MHYIA2ADN6K6II6EXPNVUR3ERBXT4AN4ACD").
+		ValueEqual("memo_type", "id").
+		ValueEqual("memo", "1")
+
+	// Reverse request with a muxed account address
+	server.GET("/federation").
+		WithQuery("type", "id").
+		WithQuery("q", "MD6WU64OEP5C4LRBH6NK3MHYIA2ADN6K6II6EXPNVUR3ERBXT4AN4AAAAAAAAAAAAFB6Y").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().
+		ValueEqual("hcnet_address", "alice*exchange.com")
+
+	// Reverse request with an invalid address
+	server.GET("/federation").
+		WithQuery("type", "id").
+		WithQuery("q", "scott").
+		Expect().
+		Status(http.StatusBadRequest).
+		JSON().Object().
+		ValueEqual("code", "invalid_query")
+}
@@ -0,0 +1,37 @@
+package federation
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/shantanu-hashcash/go/support/errors"
+)
+
+// LookupReverseRecord implements `ReverseDriver` by calling
+// `drv.LookupReverseRecordURL` with the provided account id
+func (drv *ReverseHTTPDriver) LookupReverseRecord(
+	ctx context.Context,
+	accountid string,
+) (*ReverseRecord, error) {
+	var resp struct {
+		Name   string `json:"name"`
+		Domain string `json:"domain"`
+	}
+
+	found, err := drv.get(ctx, drv.LookupReverseRecordURL, url.Values{
+		"account_id": []string{accountid},
+	}, &resp)
+	if err != nil {
+		return nil, errors.Wrap(err, "http get")
+	}
+	if !found {
+		return nil, nil
+	}
+
+	return &ReverseRecord{
+		Name:   resp.Name,
+		Domain: resp.Domain,
+	}, nil
+}
+
+var _ ReverseDriver = &ReverseHTTPDriver{}
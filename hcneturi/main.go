@@ -0,0 +1,500 @@
+// Package hcneturi builds, parses, signs and verifies SEP-7 URIs, which
+// delegate signing of a transaction or a payment to a wallet. See
+// https://github.com/shantanu-hashcash/hcnet-protocol/blob/master/ecosystem/sep-0007.md
+//
+// Two operations are supported:
+//
+//	web+hcnet:tx?xdr=...
+//	web+hcnet:pay?destination=...&amount=...
+package hcneturi
+
+import (
+	"bytes"
+	"encoding/base64"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/shantanu-hashcash/go/amount"
+	"github.com/shantanu-hashcash/go/clients/hcnettoml"
+	"github.com/shantanu-hashcash/go/keypair"
+	"github.com/shantanu-hashcash/go/strkey"
+	"github.com/shantanu-hashcash/go/support/errors"
+	"github.com/shantanu-hashcash/go/txnbuild"
+)
+
+// Scheme is the URI scheme of SEP-7 URIs.
+const Scheme = "web+hcnet"
+
+// MaxMessageLength is the maximum number of characters of the msg parameter.
+const MaxMessageLength = 300
+
+// Operation is the operation of a SEP-7 URI.
+type Operation string
+
+const (
+	// OperationTx requests signing a transaction.
+	OperationTx Operation = "tx"
+	// OperationPay requests a payment.
+	OperationPay Operation = "pay"
+)
+
+// MemoType is the type of the memo of a pay request.
+type MemoType string
+
+const (
+	MemoTypeText   MemoType = "MEMO_TEXT"
+	MemoTypeID     MemoType = "MEMO_ID"
+	MemoTypeHash   MemoType = "MEMO_HASH"
+	MemoTypeReturn MemoType = "MEMO_RETURN"
+)
+
+// signaturePayloadPrefix precedes the URI when signing, see "Request Signing"
+// in SEP-7. It is 35 zero bytes followed by the byte 4.
+var signaturePayloadPrefix = append(make([]byte, 35), 4)
+
+const signaturePayloadTag = "hcnet.sep.7 - URI Scheme"
+
+// ErrMissingSignature is returned when verifying a URI without a signature.
+var ErrMissingSignature = errors.New("uri has no signature")
+
+// ErrInvalidSignature is returned when a URI signature does not verify.
+var ErrInvalidSignature = errors.New("uri signature is invalid")
+
+// Request is a parsed SEP-7 URI, either a *TransactionRequest or a
+// *PayRequest.
+type Request interface {
+	Operation() Operation
+	// String returns the URI, including the signature if it is set.
+	String() string
+	// Validate checks the parameters of the request.
+	Validate() error
+}
+
+// Common holds the parameters shared by all operations.
+type Common struct {
+	// Callback is the URL the signed transaction should be posted to instead
+	// of being submitted to the network. It is encoded with the "url:"
+	// prefix.
+	Callback          string
+	Message           string
+	NetworkPassphrase string
+	OriginDomain      string
+	Signature         string
+}
+
+func (c Common) validate() error {
+	if len([]rune(c.Message)) > MaxMessageLength {
+		return errors.Errorf("msg cannot be longer than %d characters", MaxMessageLength)
+	}
+	if c.Callback != "" {
+		if _, err := url.ParseRequestURI(c.Callback); err != nil {
+			return errors.Wrap(err, "invalid callback")
+		}
+	}
+	if c.Signature != "" && c.OriginDomain == "" {
+		return errors.New("signature requires origin_domain")
+	}
+	return nil
+}
+
+func (c Common) params(p *params) {
+	if c.Callback != "" {
+		p.add("callback", "url:"+c.Callback)
+	}
+	p.add("msg", c.Message)
+	p.add("network_passphrase", c.NetworkPassphrase)
+	p.add("origin_domain", c.OriginDomain)
+}
+
+func (c *Common) parse(values url.Values) error {
+	if callback := values.Get("callback"); callback != "" {
+		if !strings.HasPrefix(callback, "url:") {
+			return errors.New("callback must start with url:")
+		}
+		c.Callback = strings.TrimPrefix(callback, "url:")
+	}
+	c.Message = values.Get("msg")
+	c.NetworkPassphrase = values.Get("network_passphrase")
+	c.OriginDomain = values.Get("origin_domain")
+	c.Signature = values.Get("signature")
+	return nil
+}
+
+// TransactionRequest is a `web+hcnet:tx` request to sign a transaction.
+type TransactionRequest struct {
+	// XDR is the base64 encoded transaction envelope.
+	XDR string
+	// Replace is a SEP-11 style list of fields of the transaction that the
+	// wallet should let the user replace.
+	Replace string
+	// PubKey is the public key that should sign the transaction.
+	PubKey string
+	// Chain is a nested SEP-7 URI that this request was derived from.
+	Chain string
+	Common
+}
+
+// NewTransactionRequest returns a request to sign tx.
+func NewTransactionRequest(tx *txnbuild.Transaction) (*TransactionRequest, error) {
+	xdr, err := tx.Base64()
+	if err != nil {
+		return nil, errors.Wrap(err, "encoding transaction")
+	}
+	return &TransactionRequest{XDR: xdr}, nil
+}
+
+// Operation implements Request.
+func (r *TransactionRequest) Operation() Operation {
+	return OperationTx
+}
+
+// Transaction decodes the transaction of the request.
+func (r *TransactionRequest) Transaction() (*txnbuild.GenericTransaction, error) {
+	tx, err := txnbuild.TransactionFromXDR(r.XDR)
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding xdr")
+	}
+	return tx, nil
+}
+
+// Validate implements Request.
+func (r *TransactionRequest) Validate() error {
+	if r.XDR == "" {
+		return errors.New("xdr is required")
+	}
+	if _, err := r.Transaction(); err != nil {
+		return err
+	}
+	if r.PubKey != "" && !strkey.IsValidEd25519PublicKey(r.PubKey) {
+		return errors.New("pubkey is not a valid account id")
+	}
+	if r.Chain != "" {
+		if _, err := Parse(r.Chain); err != nil {
+			return errors.Wrap(err, "invalid chain")
+		}
+	}
+	return r.Common.validate()
+}
+
+func (r *TransactionRequest) unsigned() string {
+	p := params{}
+	p.add("xdr", r.XDR)
+	p.add("replace", r.Replace)
+	p.add("pubkey", r.PubKey)
+	p.add("chain", r.Chain)
+	r.Common.params(&p)
+	return p.uri(OperationTx)
+}
+
+// String implements Request.
+func (r *TransactionRequest) String() string {
+	return withSignature(r.unsigned(), r.Signature)
+}
+
+// Sign sets the signature of the request to the signature of kp, whose
+// address must be the URI_REQUEST_SIGNING_KEY of OriginDomain.
+func (r *TransactionRequest) Sign(kp *keypair.Full) error {
+	signature, err := sign(r.unsigned(), kp)
+	if err != nil {
+		return err
+	}
+	r.Signature = signature
+	return nil
+}
+
+// PayRequest is a `web+hcnet:pay` request for a payment.
+type PayRequest struct {
+	Destination string
+	// Amount is optional, the wallet asks the user for it when empty.
+	Amount string
+	// AssetCode and AssetIssuer are empty for the native asset.
+	AssetCode   string
+	AssetIssuer string
+	Memo        string
+	MemoType    MemoType
+	Common
+}
+
+// Operation implements Request.
+func (r *PayRequest) Operation() Operation {
+	return OperationPay
+}
+
+// Asset returns the asset requested.
+func (r *PayRequest) Asset() txnbuild.Asset {
+	if r.AssetCode == "" {
+		return txnbuild.NativeAsset{}
+	}
+	return txnbuild.CreditAsset{Code: r.AssetCode, Issuer: r.AssetIssuer}
+}
+
+// Validate implements Request.
+func (r *PayRequest) Validate() error {
+	if r.Destination == "" {
+		return errors.New("destination is required")
+	}
+	if !strkey.IsValidEd25519PublicKey(r.Destination) &&
+		!strkey.IsValidMuxedAccountEd25519PublicKey(r.Destination) {
+		return errors.New("destination is not a valid address")
+	}
+	if r.Amount != "" {
+		if _, err := amount.ParseInt64(r.Amount); err != nil {
+			return errors.Wrap(err, "invalid amount")
+		}
+	}
+	if (r.AssetCode == "") != (r.AssetIssuer == "") {
+		return errors.New("asset_code and asset_issuer must be set together")
+	}
+	if r.AssetCode != "" {
+		if _, err := r.Asset().ToXDR(); err != nil {
+			return errors.Wrap(err, "invalid asset")
+		}
+	}
+	if _, err := r.TxnbuildMemo(); err != nil {
+		return err
+	}
+	return r.Common.validate()
+}
+
+// TxnbuildMemo converts the memo of the request, returning nil if there is
+// no memo.
+func (r *PayRequest) TxnbuildMemo() (txnbuild.Memo, error) {
+	if r.Memo == "" {
+		if r.MemoType != "" {
+			return nil, errors.New("memo_type requires memo")
+		}
+		return nil, nil
+	}
+	switch r.MemoType {
+	case "", MemoTypeText:
+		if len(r.Memo) > 28 {
+			return nil, errors.New("text memo cannot be longer than 28 bytes")
+		}
+		return txnbuild.MemoText(r.Memo), nil
+	case MemoTypeID:
+		id, err := strconv.ParseUint(r.Memo, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid id memo")
+		}
+		return txnbuild.MemoID(id), nil
+	case MemoTypeHash, MemoTypeReturn:
+		decoded, err := base64.StdEncoding.DecodeString(r.Memo)
+		if err != nil || len(decoded) != 32 {
+			return nil, errors.New("hash memo must be 32 base64 encoded bytes")
+		}
+		var hash [32]byte
+		copy(hash[:], decoded)
+		if r.MemoType == MemoTypeHash {
+			return txnbuild.MemoHash(hash), nil
+		}
+		return txnbuild.MemoReturn(hash), nil
+	default:
+		return nil, errors.Errorf("unknown memo_type %s", r.MemoType)
+	}
+}
+
+// PaymentOperation returns the payment operation requested.
+func (r *PayRequest) PaymentOperation() (*txnbuild.Payment, error) {
+	if r.Amount == "" {
+		return nil, errors.New("amount is not set")
+	}
+	return &txnbuild.Payment{
+		Destination: r.Destination,
+		Amount:      r.Amount,
+		Asset:       r.Asset(),
+	}, nil
+}
+
+func (r *PayRequest) unsigned() string {
+	p := params{}
+	p.add("destination", r.Destination)
+	p.add("amount", r.Amount)
+	p.add("asset_code", r.AssetCode)
+	p.add("asset_issuer", r.AssetIssuer)
+	p.add("memo", r.Memo)
+	p.add("memo_type", string(r.MemoType))
+	r.Common.params(&p)
+	return p.uri(OperationPay)
+}
+
+// String implements Request.
+func (r *PayRequest) String() string {
+	return withSignature(r.unsigned(), r.Signature)
+}
+
+// Sign sets the signature of the request to the signature of kp, whose
+// address must be the URI_REQUEST_SIGNING_KEY of OriginDomain.
+func (r *PayRequest) Sign(kp *keypair.Full) error {
+	signature, err := sign(r.unsigned(), kp)
+	if err != nil {
+		return err
+	}
+	r.Signature = signature
+	return nil
+}
+
+// Parse parses and validates a SEP-7 URI.
+func Parse(uri string) (Request, error) {
+	rest := strings.TrimPrefix(uri, Scheme+":")
+	if rest == uri {
+		return nil, errors.Errorf("uri must start with %s:", Scheme)
+	}
+	operation, rawQuery, _ := strings.Cut(rest, "?")
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing query")
+	}
+
+	var request Request
+	switch Operation(operation) {
+	case OperationTx:
+		r := &TransactionRequest{
+			XDR:     values.Get("xdr"),
+			Replace: values.Get("replace"),
+			PubKey:  values.Get("pubkey"),
+			Chain:   values.Get("chain"),
+		}
+		err = r.Common.parse(values)
+		request = r
+	case OperationPay:
+		r := &PayRequest{
+			Destination: values.Get("destination"),
+			Amount:      values.Get("amount"),
+			AssetCode:   values.Get("asset_code"),
+			AssetIssuer: values.Get("asset_issuer"),
+			Memo:        values.Get("memo"),
+			MemoType:    MemoType(values.Get("memo_type")),
+		}
+		err = r.Common.parse(values)
+		request = r
+	default:
+		return nil, errors.Errorf("unsupported operation %q", operation)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = request.Validate(); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// VerifySignature verifies the signature of uri against signingKey. The
+// signature covers the URI exactly as given, up to the signature parameter,
+// so URIs must be verified before being re-encoded.
+func VerifySignature(uri, signingKey string) error {
+	unsigned, signature, ok := splitSignature(uri)
+	if !ok {
+		return ErrMissingSignature
+	}
+	decoded, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	kp, err := keypair.ParseAddress(signingKey)
+	if err != nil {
+		return errors.Wrap(err, "invalid signing key")
+	}
+	if err = kp.Verify(signaturePayload(unsigned), decoded); err != nil {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// VerifyOrigin verifies that uri was signed by the URI_REQUEST_SIGNING_KEY
+// published in the hcnet.toml of its origin_domain.
+func VerifyOrigin(uri string, client hcnettoml.ClientInterface) error {
+	request, err := Parse(uri)
+	if err != nil {
+		return err
+	}
+	var originDomain string
+	switch r := request.(type) {
+	case *TransactionRequest:
+		originDomain = r.OriginDomain
+	case *PayRequest:
+		originDomain = r.OriginDomain
+	}
+	if originDomain == "" {
+		return errors.New("uri has no origin_domain")
+	}
+
+	toml, err := client.GetHcnetToml(originDomain)
+	if err != nil {
+		return errors.Wrap(err, "fetching hcnet.toml of origin domain")
+	}
+	if toml.UriRequestSigningKey == "" {
+		return errors.Errorf("hcnet.toml of %s has no URI_REQUEST_SIGNING_KEY", originDomain)
+	}
+	return VerifySignature(uri, toml.UriRequestSigningKey)
+}
+
+func signaturePayload(unsigned string) []byte {
+	var payload bytes.Buffer
+	payload.Write(signaturePayloadPrefix)
+	payload.WriteString(signaturePayloadTag)
+	payload.WriteString(unsigned)
+	return payload.Bytes()
+}
+
+func sign(unsigned string, kp *keypair.Full) (string, error) {
+	signature, err := kp.Sign(signaturePayload(unsigned))
+	if err != nil {
+		return "", errors.Wrap(err, "signing uri")
+	}
+	return base64.StdEncoding.EncodeToString(signature), nil
+}
+
+func withSignature(unsigned, signature string) string {
+	if signature == "" {
+		return unsigned
+	}
+	return unsigned + "&signature=" + escape(signature)
+}
+
+// splitSignature separates the trailing signature parameter from uri.
+func splitSignature(uri string) (unsigned, signature string, ok bool) {
+	i := strings.LastIndex(uri, "&signature=")
+	if i < 0 {
+		return "", "", false
+	}
+	signature, err := url.QueryUnescape(uri[i+len("&signature="):])
+	if err != nil || signature == "" {
+		return "", "", false
+	}
+	return uri[:i], signature, true
+}
+
+// params keeps query parameters in the order they are added so that URIs,
+// and therefore their signatures, are stable.
+type params []string
+
+func (p *params) add(key, value string) {
+	if value != "" {
+		*p = append(*p, key+"="+escape(value))
+	}
+}
+
+func (p params) uri(operation Operation) string {
+	return Scheme + ":" + string(operation) + "?" + strings.Join(p, "&")
+}
+
+// encodeURIComponentReplacer turns the output of url.QueryEscape into the one
+// of encodeURIComponent: spaces are encoded as %20 and !'()* are not
+// encoded. A literal + is already encoded as %2B by url.QueryEscape.
+var encodeURIComponentReplacer = strings.NewReplacer(
+	"+", "%20",
+	"%21", "!",
+	"%27", "'",
+	"%28", "(",
+	"%29", ")",
+	"%2A", "*",
+)
+
+// escape encodes s the same way as JavaScript's encodeURIComponent, which is
+// what most SEP-7 implementations use, so that signatures over URIs built in
+// Go and in JavaScript match.
+func escape(s string) string {
+	return encodeURIComponentReplacer.Replace(url.QueryEscape(s))
+}
@@ -0,0 +1,170 @@
+package hcneturi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shantanu-hashcash/go/clients/hcnettoml"
+	"github.com/shantanu-hashcash/go/keypair"
+	"github.com/shantanu-hashcash/go/network"
+	"github.com/shantanu-hashcash/go/txnbuild"
+)
+
+func buildTransaction(t *testing.T) *txnbuild.Transaction {
+	source := keypair.MustRandom()
+	tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount:        &txnbuild.SimpleAccount{AccountID: source.Address(), Sequence: 1},
+		IncrementSequenceNum: true,
+		Operations: []txnbuild.Operation{
+			&txnbuild.BumpSequence{BumpTo: 10},
+		},
+		BaseFee:       txnbuild.MinBaseFee,
+		Preconditions: txnbuild.Preconditions{TimeBounds: txnbuild.NewInfiniteTimeout()},
+	})
+	require.NoError(t, err)
+	return tx
+}
+
+func TestTransactionRequestRoundTrip(t *testing.T) {
+	tx := buildTransaction(t)
+	request, err := NewTransactionRequest(tx)
+	require.NoError(t, err)
+	request.Callback = "https://example.com/callback?a=b"
+	request.Message = "order #24"
+	request.NetworkPassphrase = network.TestNetworkPassphrase
+
+	uri := request.String()
+	assert.Contains(t, uri, "web+hcnet:tx?xdr=")
+	assert.Contains(t, uri, "&callback=url%3Ahttps%3A%2F%2Fexample.com%2Fcallback%3Fa%3Db")
+	assert.Contains(t, uri, "&msg=order%20%2324")
+
+	parsed, err := Parse(uri)
+	require.NoError(t, err)
+	assert.Equal(t, request, parsed)
+	assert.Equal(t, uri, parsed.String())
+
+	parsedTx, err := parsed.(*TransactionRequest).Transaction()
+	require.NoError(t, err)
+	hash, err := parsedTx.HashHex(network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	expectedHash, err := tx.HashHex(network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	assert.Equal(t, expectedHash, hash)
+}
+
+func TestPayRequest(t *testing.T) {
+	destination := keypair.MustRandom().Address()
+	issuer := keypair.MustRandom().Address()
+	request := &PayRequest{
+		Destination: destination,
+		Amount:      "120.1234567",
+		AssetCode:   "USD",
+		AssetIssuer: issuer,
+		Memo:        "skdjfasf",
+		MemoType:    MemoTypeText,
+	}
+	require.NoError(t, request.Validate())
+
+	parsed, err := Parse(request.String())
+	require.NoError(t, err)
+	assert.Equal(t, request, parsed)
+
+	op, err := parsed.(*PayRequest).PaymentOperation()
+	require.NoError(t, err)
+	assert.Equal(t, &txnbuild.Payment{
+		Destination: destination,
+		Amount:      "120.1234567",
+		Asset:       txnbuild.CreditAsset{Code: "USD", Issuer: issuer},
+	}, op)
+
+	memo, err := parsed.(*PayRequest).TxnbuildMemo()
+	require.NoError(t, err)
+	assert.Equal(t, txnbuild.MemoText("skdjfasf"), memo)
+
+	request.MemoType = MemoTypeID
+	request.Memo = "1234"
+	memo, err = request.TxnbuildMemo()
+	require.NoError(t, err)
+	assert.Equal(t, txnbuild.MemoID(1234), memo)
+}
+
+func TestParseErrors(t *testing.T) {
+	destination := keypair.MustRandom().Address()
+	testCases := []struct {
+		uri string
+		err string
+	}{
+		{"https://example.com", "uri must start with web+hcnet:"},
+		{"web+hcnet:sign?xdr=AAAA", `unsupported operation "sign"`},
+		{"web+hcnet:tx", "xdr is required"},
+		{"web+hcnet:tx?xdr=AAAA", "decoding xdr"},
+		{"web+hcnet:pay?destination=GABC", "destination is not a valid address"},
+		{"web+hcnet:pay?destination=" + destination + "&amount=abc", "invalid amount"},
+		{"web+hcnet:pay?destination=" + destination + "&asset_code=USD", "asset_code and asset_issuer must be set together"},
+		{"web+hcnet:pay?destination=" + destination + "&memo=abc&memo_type=MEMO_ID", "invalid id memo"},
+		{"web+hcnet:pay?destination=" + destination + "&callback=https%3A%2F%2Fexample.com", "callback must start with url:"},
+		{"web+hcnet:pay?destination=" + destination + "&signature=abc", "signature requires origin_domain"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.uri, func(t *testing.T) {
+			_, err := Parse(tc.uri)
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.err)
+			}
+		})
+	}
+}
+
+func TestSignAndVerify(t *testing.T) {
+	signingKey := keypair.MustRandom()
+	request := &PayRequest{
+		Destination: keypair.MustRandom().Address(),
+		Amount:      "10",
+		Common: Common{
+			Message:      "pay me with lumens",
+			OriginDomain: "someDomain.com",
+		},
+	}
+	require.NoError(t, request.Sign(signingKey))
+	uri := request.String()
+	assert.Regexp(t, "&signature=[^&]+$", uri)
+
+	require.NoError(t, VerifySignature(uri, signingKey.Address()))
+	assert.Equal(t, ErrInvalidSignature, VerifySignature(uri, keypair.MustRandom().Address()))
+	assert.Equal(t, ErrMissingSignature, VerifySignature(request.unsigned(), signingKey.Address()))
+
+	// Any change to the signed parameters invalidates the signature.
+	tampered := *request
+	tampered.Amount = "1000"
+	assert.Equal(t, ErrInvalidSignature, VerifySignature(tampered.String(), signingKey.Address()))
+
+	client := &hcnettoml.MockClient{}
+	client.On("GetHcnetToml", "someDomain.com").
+		Return(&hcnettoml.Response{UriRequestSigningKey: signingKey.Address()}, nil)
+	require.NoError(t, VerifyOrigin(uri, client))
+	assert.Equal(t, ErrInvalidSignature, VerifyOrigin(tampered.String(), client))
+
+	client = &hcnettoml.MockClient{}
+	client.On("GetHcnetToml", "someDomain.com").Return(&hcnettoml.Response{}, nil)
+	err := VerifyOrigin(uri, client)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "has no URI_REQUEST_SIGNING_KEY")
+	}
+}
+
+func TestEscapeMatchesEncodeURIComponent(t *testing.T) {
+	// encodeURIComponent("a b!'()*~-_.+/&=?%é")
+	assert.Equal(t, "a%20b!'()*~-_.%2B%2F%26%3D%3F%25%C3%A9", escape("a b!'()*~-_.+/&=?%é"))
+
+	request := &PayRequest{
+		Destination: keypair.MustRandom().Address(),
+		Common:      Common{Message: "Don't (really) pay *me*!"},
+	}
+	uri := request.String()
+	assert.Contains(t, uri, "msg=Don't%20(really)%20pay%20*me*!")
+	parsed, err := Parse(uri)
+	require.NoError(t, err)
+	assert.Equal(t, request, parsed)
+}
@@ -0,0 +1,111 @@
+package ledgerfixture
+
+import (
+	"context"
+	"sync"
+
+	"github.com/shantanu-hashcash/go/ingest/ledgerbackend"
+	"github.com/shantanu-hashcash/go/support/errors"
+	"github.com/shantanu-hashcash/go/xdr"
+)
+
+// Ensure Backend implements LedgerBackend
+var _ ledgerbackend.LedgerBackend = (*Backend)(nil)
+
+// Backend is a LedgerBackend serving generated ledgers. Like captive core,
+// GetLedger blocks until the requested ledger is added, so a test can
+// ingest ledgers while a scenario is still closing them.
+type Backend struct {
+	mutex   sync.Mutex
+	ledgers map[uint32]xdr.LedgerCloseMeta
+	latest  uint32
+	added   chan struct{}
+	closed  bool
+}
+
+// NewBackend returns a Backend serving the given ledgers.
+func NewBackend(ledgers ...xdr.LedgerCloseMeta) *Backend {
+	b := &Backend{
+		ledgers: map[uint32]xdr.LedgerCloseMeta{},
+		added:   make(chan struct{}),
+	}
+	b.AddLedgers(ledgers...)
+	return b
+}
+
+// AddLedgers adds ledgers to the backend, waking up pending GetLedger
+// calls. Ledgers added after Close are ignored.
+func (b *Backend) AddLedgers(ledgers ...xdr.LedgerCloseMeta) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.closed {
+		return
+	}
+	for _, ledger := range ledgers {
+		sequence := ledger.LedgerSequence()
+		b.ledgers[sequence] = ledger
+		if sequence > b.latest {
+			b.latest = sequence
+		}
+	}
+	close(b.added)
+	b.added = make(chan struct{})
+}
+
+// GetLatestLedgerSequence returns the sequence of the latest ledger added.
+func (b *Backend) GetLatestLedgerSequence(ctx context.Context) (uint32, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.closed {
+		return 0, errors.New("backend is closed")
+	}
+	if b.latest == 0 {
+		return 0, errors.New("backend has no ledgers")
+	}
+	return b.latest, nil
+}
+
+// PrepareRange is a noop, all the ledgers added are available.
+func (b *Backend) PrepareRange(ctx context.Context, ledgerRange ledgerbackend.Range) error {
+	return nil
+}
+
+// IsPrepared returns true, all the ledgers added are available.
+func (b *Backend) IsPrepared(ctx context.Context, ledgerRange ledgerbackend.Range) (bool, error) {
+	return true, nil
+}
+
+// GetLedger returns the ledger with the given sequence, blocking until it is
+// added or ctx is done.
+func (b *Backend) GetLedger(ctx context.Context, sequence uint32) (xdr.LedgerCloseMeta, error) {
+	for {
+		b.mutex.Lock()
+		if b.closed {
+			b.mutex.Unlock()
+			return xdr.LedgerCloseMeta{}, errors.New("backend is closed")
+		}
+		ledger, ok := b.ledgers[sequence]
+		added := b.added
+		b.mutex.Unlock()
+		if ok {
+			return ledger, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return xdr.LedgerCloseMeta{}, ctx.Err()
+		case <-added:
+		}
+	}
+}
+
+// Close closes the backend, pending GetLedger calls return an error.
+func (b *Backend) Close() error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if !b.closed {
+		b.closed = true
+		close(b.added)
+	}
+	return nil
+}
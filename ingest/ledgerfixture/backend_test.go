@@ -0,0 +1,79 @@
+package ledgerfixture
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shantanu-hashcash/go/xdr"
+)
+
+func TestBackendGetLedgerWaitsForLedger(t *testing.T) {
+	s := runScenario(t)
+	ledgers := s.Ledgers()
+	backend := NewBackend(ledgers[:2]...)
+	ctx := context.Background()
+
+	latest, err := backend.GetLatestLedgerSequence(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(3), latest)
+
+	ledger, err := backend.GetLedger(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, ledgers[0], ledger)
+
+	done := make(chan xdr.LedgerCloseMeta)
+	go func() {
+		ledger, err := backend.GetLedger(ctx, 4)
+		assert.NoError(t, err)
+		done <- ledger
+	}()
+	select {
+	case <-done:
+		t.Fatal("ledger 4 was returned before being added")
+	case <-time.After(50 * time.Millisecond):
+	}
+	backend.AddLedgers(ledgers[2:]...)
+	assert.Equal(t, ledgers[2], <-done)
+
+	latest, err = backend.GetLatestLedgerSequence(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(7), latest)
+}
+
+func TestBackendGetLedgerCancelled(t *testing.T) {
+	backend := NewBackend()
+	_, err := backend.GetLatestLedgerSequence(context.Background())
+	assert.EqualError(t, err, "backend has no ledgers")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = backend.GetLedger(ctx, 2)
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		assert.NoError(t, backend.Close())
+	}()
+	_, err = backend.GetLedger(context.Background(), 2)
+	assert.EqualError(t, err, "backend is closed")
+}
+
+func TestBackendAddLedgersAfterClose(t *testing.T) {
+	backend := NewBackend()
+	require.NoError(t, backend.Close())
+
+	backend.AddLedgers(xdr.LedgerCloseMeta{
+		V0: &xdr.LedgerCloseMetaV0{
+			LedgerHeader: xdr.LedgerHeaderHistoryEntry{
+				Header: xdr.LedgerHeader{LedgerSeq: 2},
+			},
+		},
+	})
+	_, err := backend.GetLedger(context.Background(), 2)
+	assert.EqualError(t, err, "backend is closed")
+	assert.NoError(t, backend.Close())
+}
@@ -0,0 +1,37 @@
+/*
+Package ledgerfixture generates deterministic ledger close meta for
+integration tests, from a scenario scripted with txnbuild operations.
+
+A Scenario starts from the genesis ledger of a network and closes one ledger
+per call to CloseLedger, applying the transactions to its ledger entries. The
+ledger entry changes of the generated meta are consistent with each other and
+with the genesis ledger, and each header links to the hash of the previous
+one, so the ledgers can be ingested like the ones of a real network:
+
+	s, err := ledgerfixture.NewScenario(network.TestNetworkPassphrase)
+	if err != nil {
+		return err
+	}
+	alice := s.Account("alice")
+	_, err = s.CloseLedger(ledgerfixture.Transaction{
+		Source: s.Root(),
+		Operations: []txnbuild.Operation{
+			&txnbuild.CreateAccount{Destination: alice.Address(), Amount: "1000"},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	...
+	backend := ledgerfixture.NewBackend(s.Ledgers()...)
+	reader, err := ingest.NewLedgerTransactionReader(ctx, backend, network.TestNetworkPassphrase, 2)
+
+Transactions invoking a host function do not run any contract: their contract
+events and return value are set by the scenario.
+
+The generated ledgers are not a replica of what hcnet-core would produce.
+Signatures, reserves and liabilities are not checked, offer crossings may be
+rounded differently and the bucket list hash is a hash of all the ledger
+entries.
+*/
+package ledgerfixture
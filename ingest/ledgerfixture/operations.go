@@ -0,0 +1,594 @@
+package ledgerfixture
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/shantanu-hashcash/go/support/errors"
+	"github.com/shantanu-hashcash/go/xdr"
+)
+
+// applyOperation applies a successful operation to the entries of changes.
+// Reserves, liabilities and signatures are not checked.
+func (l *ledgerCloser) applyOperation(changes *changeSet, source xdr.AccountId, op xdr.Operation) (xdr.OperationResultTr, error) {
+	if _, err := changes.loadAccount(source); err != nil {
+		return xdr.OperationResultTr{}, err
+	}
+
+	switch op.Body.Type {
+	case xdr.OperationTypeCreateAccount:
+		return l.createAccount(changes, source, op.Body.MustCreateAccountOp())
+	case xdr.OperationTypePayment:
+		return l.payment(changes, source, op.Body.MustPaymentOp())
+	case xdr.OperationTypeChangeTrust:
+		return l.changeTrust(changes, source, op.Body.MustChangeTrustOp())
+	case xdr.OperationTypeManageSellOffer:
+		return l.manageSellOffer(changes, source, op.Body.MustManageSellOfferOp())
+	case xdr.OperationTypeLiquidityPoolDeposit:
+		return l.liquidityPoolDeposit(changes, source, op.Body.MustLiquidityPoolDepositOp())
+	case xdr.OperationTypeInvokeHostFunction:
+		// The contract events and return value of the transaction are set
+		// by the scenario, the invocation does not change any entry.
+		return xdr.OperationResultTr{
+			Type: xdr.OperationTypeInvokeHostFunction,
+			InvokeHostFunctionResult: &xdr.InvokeHostFunctionResult{
+				Code: xdr.InvokeHostFunctionResultCodeInvokeHostFunctionSuccess,
+			},
+		}, nil
+	default:
+		return xdr.OperationResultTr{}, errors.Errorf("%s operations are not supported", op.Body.Type)
+	}
+}
+
+func (l *ledgerCloser) createAccount(changes *changeSet, source xdr.AccountId, op xdr.CreateAccountOp) (xdr.OperationResultTr, error) {
+	if op.StartingBalance < 0 {
+		return xdr.OperationResultTr{}, errors.New("starting balance cannot be negative")
+	}
+	if err := changes.addBalance(source, xdr.MustNewNativeAsset(), -int64(op.StartingBalance)); err != nil {
+		return xdr.OperationResultTr{}, err
+	}
+	err := changes.create(xdr.LedgerEntry{
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeAccount,
+			Account: &xdr.AccountEntry{
+				AccountId: op.Destination,
+				Balance:   op.StartingBalance,
+				// Accounts start with the sequence number of the ledger
+				// they are created in, shifted to the high 32 bits.
+				SeqNum:     xdr.SequenceNumber(int64(l.sequence) << 32),
+				Thresholds: xdr.Thresholds{1, 0, 0, 0},
+			},
+		},
+	})
+	if err != nil {
+		return xdr.OperationResultTr{}, errors.Wrapf(err, "could not create account %s", op.Destination.Address())
+	}
+
+	return xdr.OperationResultTr{
+		Type: xdr.OperationTypeCreateAccount,
+		CreateAccountResult: &xdr.CreateAccountResult{
+			Code: xdr.CreateAccountResultCodeCreateAccountSuccess,
+		},
+	}, nil
+}
+
+func (l *ledgerCloser) payment(changes *changeSet, source xdr.AccountId, op xdr.PaymentOp) (xdr.OperationResultTr, error) {
+	destination := op.Destination.ToAccountId()
+	if _, err := changes.loadAccount(destination); err != nil {
+		return xdr.OperationResultTr{}, err
+	}
+	if op.Amount <= 0 {
+		return xdr.OperationResultTr{}, errors.New("payment amount must be positive")
+	}
+	if err := changes.addBalance(source, op.Asset, -int64(op.Amount)); err != nil {
+		return xdr.OperationResultTr{}, err
+	}
+	if err := changes.addBalance(destination, op.Asset, int64(op.Amount)); err != nil {
+		return xdr.OperationResultTr{}, err
+	}
+
+	return xdr.OperationResultTr{
+		Type: xdr.OperationTypePayment,
+		PaymentResult: &xdr.PaymentResult{
+			Code: xdr.PaymentResultCodePaymentSuccess,
+		},
+	}, nil
+}
+
+func (l *ledgerCloser) changeTrust(changes *changeSet, source xdr.AccountId, op xdr.ChangeTrustOp) (xdr.OperationResultTr, error) {
+	result := xdr.OperationResultTr{
+		Type: xdr.OperationTypeChangeTrust,
+		ChangeTrustResult: &xdr.ChangeTrustResult{
+			Code: xdr.ChangeTrustResultCodeChangeTrustSuccess,
+		},
+	}
+
+	var (
+		asset      xdr.TrustLineAsset
+		params     *xdr.LiquidityPoolConstantProductParameters
+		subEntries xdr.Uint32 = 1
+		flags      xdr.Uint32
+	)
+	switch op.Line.Type {
+	case xdr.AssetTypeAssetTypeNative:
+		return result, errors.New("cannot change the trust line of the native asset")
+	case xdr.AssetTypeAssetTypePoolShare:
+		constantProduct := op.Line.LiquidityPool.MustConstantProduct()
+		params = &constantProduct
+		poolID, err := xdr.NewPoolId(params.AssetA, params.AssetB, params.Fee)
+		if err != nil {
+			return result, err
+		}
+		asset = xdr.TrustLineAsset{Type: xdr.AssetTypeAssetTypePoolShare, LiquidityPoolId: &poolID}
+		// pool share trust lines count twice towards the subentries
+		subEntries = 2
+	default:
+		line := op.Line.ToAsset()
+		if line.GetIssuer() == source.Address() {
+			return result, errors.New("issuers cannot trust their own assets")
+		}
+		asset = line.ToTrustLineAsset()
+		flags = xdr.Uint32(xdr.TrustLineFlagsAuthorizedFlag)
+	}
+
+	account, err := changes.loadAccount(source)
+	if err != nil {
+		return result, err
+	}
+	trustLine, err := changes.loadTrustLine(source, asset)
+	if err != nil {
+		return result, err
+	}
+
+	if op.Limit == 0 {
+		if trustLine == nil {
+			return result, errors.New("cannot remove a trust line which does not exist")
+		}
+		if trustLine.Balance != 0 {
+			return result, errors.New("cannot remove a trust line with a balance")
+		}
+		var key xdr.LedgerKey
+		if err = key.SetTrustline(source, asset); err != nil {
+			return result, err
+		}
+		if err = changes.remove(key); err != nil {
+			return result, err
+		}
+		account.NumSubEntries -= subEntries
+		if params != nil {
+			return result, l.removePoolShareTrustLine(changes, *asset.LiquidityPoolId)
+		}
+		return result, nil
+	}
+
+	if trustLine != nil {
+		if op.Limit < trustLine.Balance {
+			return result, errors.New("trust line limit cannot be lower than its balance")
+		}
+		trustLine.Limit = op.Limit
+		return result, nil
+	}
+
+	if params != nil {
+		if err = l.addPoolShareTrustLine(changes, source, *asset.LiquidityPoolId, *params); err != nil {
+			return result, err
+		}
+	}
+	err = changes.create(xdr.LedgerEntry{
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeTrustline,
+			TrustLine: &xdr.TrustLineEntry{
+				AccountId: source,
+				Asset:     asset,
+				Limit:     op.Limit,
+				Flags:     flags,
+			},
+		},
+	})
+	if err != nil {
+		return result, err
+	}
+	account.NumSubEntries += subEntries
+	return result, nil
+}
+
+// addPoolShareTrustLine creates the liquidity pool if needed and counts the
+// new trust line, which requires trust lines for both assets of the pool.
+func (l *ledgerCloser) addPoolShareTrustLine(
+	changes *changeSet,
+	source xdr.AccountId,
+	poolID xdr.PoolId,
+	params xdr.LiquidityPoolConstantProductParameters,
+) error {
+	for _, asset := range []xdr.Asset{params.AssetA, params.AssetB} {
+		if asset.Type == xdr.AssetTypeAssetTypeNative || asset.GetIssuer() == source.Address() {
+			continue
+		}
+		trustLine, err := changes.loadTrustLine(source, asset.ToTrustLineAsset())
+		if err != nil {
+			return err
+		}
+		if trustLine == nil {
+			return errors.Errorf("account %s has no trust line for %s", source.Address(), asset.StringCanonical())
+		}
+	}
+
+	pool, err := changes.loadLiquidityPool(poolID)
+	if err != nil {
+		return err
+	}
+	if pool != nil {
+		pool.Body.ConstantProduct.PoolSharesTrustLineCount++
+		return nil
+	}
+	return changes.create(xdr.LedgerEntry{
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeLiquidityPool,
+			LiquidityPool: &xdr.LiquidityPoolEntry{
+				LiquidityPoolId: poolID,
+				Body: xdr.LiquidityPoolEntryBody{
+					Type: xdr.LiquidityPoolTypeLiquidityPoolConstantProduct,
+					ConstantProduct: &xdr.LiquidityPoolEntryConstantProduct{
+						Params:                   params,
+						PoolSharesTrustLineCount: 1,
+					},
+				},
+			},
+		},
+	})
+}
+
+// removePoolShareTrustLine uncounts a removed trust line and removes the
+// liquidity pool once it has no trust lines left.
+func (l *ledgerCloser) removePoolShareTrustLine(changes *changeSet, poolID xdr.PoolId) error {
+	pool, err := changes.loadLiquidityPool(poolID)
+	if err != nil {
+		return err
+	}
+	if pool == nil {
+		return errors.New("liquidity pool does not exist")
+	}
+	pool.Body.ConstantProduct.PoolSharesTrustLineCount--
+	if pool.Body.ConstantProduct.PoolSharesTrustLineCount > 0 {
+		return nil
+	}
+	var key xdr.LedgerKey
+	if err = key.SetLiquidityPool(poolID); err != nil {
+		return err
+	}
+	return changes.remove(key)
+}
+
+// balance returns the amount of asset held by account, the maximum amount
+// for issuers.
+func (l *ledgerCloser) balance(changes *changeSet, id xdr.AccountId, asset xdr.Asset) (int64, error) {
+	if asset.Type == xdr.AssetTypeAssetTypeNative {
+		account, err := changes.loadAccount(id)
+		if err != nil {
+			return 0, err
+		}
+		return int64(account.Balance), nil
+	}
+	if asset.GetIssuer() == id.Address() {
+		return maxAmount, nil
+	}
+	trustLine, err := changes.loadTrustLine(id, asset.ToTrustLineAsset())
+	if err != nil {
+		return 0, err
+	}
+	if trustLine == nil {
+		return 0, errors.Errorf("account %s has no trust line for %s", id.Address(), asset.StringCanonical())
+	}
+	return int64(trustLine.Balance), nil
+}
+
+func (l *ledgerCloser) manageSellOffer(changes *changeSet, source xdr.AccountId, op xdr.ManageSellOfferOp) (xdr.OperationResultTr, error) {
+	success := &xdr.ManageOfferSuccessResult{
+		Offer: xdr.ManageOfferSuccessResultOffer{Effect: xdr.ManageOfferEffectManageOfferDeleted},
+	}
+	result := xdr.OperationResultTr{
+		Type: xdr.OperationTypeManageSellOffer,
+		ManageSellOfferResult: &xdr.ManageSellOfferResult{
+			Code:    xdr.ManageSellOfferResultCodeManageSellOfferSuccess,
+			Success: success,
+		},
+	}
+
+	account, err := changes.loadAccount(source)
+	if err != nil {
+		return result, err
+	}
+
+	if op.OfferId != 0 {
+		if op.Amount != 0 {
+			return result, errors.New("updating offers is not supported, only deleting them")
+		}
+		var key xdr.LedgerKey
+		if err = key.SetOffer(source, uint64(op.OfferId)); err != nil {
+			return result, err
+		}
+		if err = changes.remove(key); err != nil {
+			return result, errors.Wrapf(err, "could not delete offer %d", op.OfferId)
+		}
+		account.NumSubEntries--
+		return result, nil
+	}
+
+	if op.Amount <= 0 {
+		return result, errors.New("offer amount must be positive")
+	}
+	if op.Price.N <= 0 || op.Price.D <= 0 {
+		return result, errors.New("offer price must be positive")
+	}
+	if op.Selling.Equals(op.Buying) {
+		return result, errors.New("offer cannot sell and buy the same asset")
+	}
+	selling, err := l.balance(changes, source, op.Selling)
+	if err != nil {
+		return result, err
+	}
+	if selling < int64(op.Amount) {
+		return result, errors.Errorf("account %s has an insufficient balance of %s", source.Address(), op.Selling.StringCanonical())
+	}
+	if _, err = l.balance(changes, source, op.Buying); err != nil {
+		return result, err
+	}
+
+	remaining := int64(op.Amount)
+	crossed, err := l.crossingOffers(changes, op)
+	if err != nil {
+		return result, err
+	}
+	for _, offer := range crossed {
+		if offer.SellerId.Equals(source) {
+			return result, errors.Errorf("offer would cross offer %d of the same account", offer.OfferId)
+		}
+		sold, bought, ok := exchange(int64(offer.Amount), offer.Price, remaining)
+		if !ok {
+			return result, errors.New("offer amounts overflow")
+		}
+		if sold == 0 {
+			break
+		}
+
+		// the seller of the crossed offer sells `sold` and buys `bought`
+		if err = changes.addBalance(offer.SellerId, offer.Selling, -sold); err != nil {
+			return result, err
+		}
+		if err = changes.addBalance(offer.SellerId, offer.Buying, bought); err != nil {
+			return result, err
+		}
+		if err = changes.addBalance(source, op.Buying, sold); err != nil {
+			return result, err
+		}
+		if err = changes.addBalance(source, op.Selling, -bought); err != nil {
+			return result, err
+		}
+
+		success.OffersClaimed = append(success.OffersClaimed, xdr.ClaimAtom{
+			Type: xdr.ClaimAtomTypeClaimAtomTypeOrderBook,
+			OrderBook: &xdr.ClaimOfferAtom{
+				SellerId:     offer.SellerId,
+				OfferId:      offer.OfferId,
+				AssetSold:    offer.Selling,
+				AmountSold:   xdr.Int64(sold),
+				AssetBought:  offer.Buying,
+				AmountBought: xdr.Int64(bought),
+			},
+		})
+
+		remaining -= bought
+		offer.Amount -= xdr.Int64(sold)
+		if offer.Amount == 0 {
+			if err = l.removeOffer(changes, offer); err != nil {
+				return result, err
+			}
+		}
+		if remaining == 0 {
+			break
+		}
+	}
+
+	if remaining == 0 {
+		return result, nil
+	}
+
+	l.idPool++
+	offer := xdr.OfferEntry{
+		SellerId: source,
+		OfferId:  xdr.Int64(l.idPool),
+		Selling:  op.Selling,
+		Buying:   op.Buying,
+		Amount:   xdr.Int64(remaining),
+		Price:    op.Price,
+	}
+	err = changes.create(xdr.LedgerEntry{
+		Data: xdr.LedgerEntryData{
+			Type:  xdr.LedgerEntryTypeOffer,
+			Offer: &offer,
+		},
+	})
+	if err != nil {
+		return result, err
+	}
+	account.NumSubEntries++
+	success.Offer = xdr.ManageOfferSuccessResultOffer{
+		Effect: xdr.ManageOfferEffectManageOfferCreated,
+		Offer:  &offer,
+	}
+	return result, nil
+}
+
+// crossingOffers returns the offers crossed by op, best price first.
+func (l *ledgerCloser) crossingOffers(changes *changeSet, op xdr.ManageSellOfferOp) ([]*xdr.OfferEntry, error) {
+	var offers []xdr.OfferEntry
+	for _, entry := range changes.state {
+		offer, ok := entry.Data.GetOffer()
+		if !ok || !offer.Selling.Equals(op.Buying) || !offer.Buying.Equals(op.Selling) {
+			continue
+		}
+		// the offer crosses if its price is at most the inverse of the
+		// price of op
+		if big.NewInt(0).Mul(big.NewInt(int64(offer.Price.N)), big.NewInt(int64(op.Price.N))).Cmp(
+			big.NewInt(0).Mul(big.NewInt(int64(offer.Price.D)), big.NewInt(int64(op.Price.D))),
+		) > 0 {
+			continue
+		}
+		offers = append(offers, offer)
+	}
+	sort.Slice(offers, func(i, j int) bool {
+		if offers[i].Price.Equal(offers[j].Price) {
+			return offers[i].OfferId < offers[j].OfferId
+		}
+		return offers[i].Price.Cheaper(offers[j].Price)
+	})
+
+	crossed := make([]*xdr.OfferEntry, 0, len(offers))
+	for _, offer := range offers {
+		var key xdr.LedgerKey
+		if err := key.SetOffer(offer.SellerId, uint64(offer.OfferId)); err != nil {
+			return nil, err
+		}
+		entry, err := changes.load(key)
+		if err != nil {
+			return nil, err
+		}
+		crossed = append(crossed, entry.Data.Offer)
+	}
+	return crossed, nil
+}
+
+func (l *ledgerCloser) removeOffer(changes *changeSet, offer *xdr.OfferEntry) error {
+	seller, err := changes.loadAccount(offer.SellerId)
+	if err != nil {
+		return err
+	}
+	var key xdr.LedgerKey
+	if err = key.SetOffer(offer.SellerId, uint64(offer.OfferId)); err != nil {
+		return err
+	}
+	if err = changes.remove(key); err != nil {
+		return err
+	}
+	seller.NumSubEntries--
+	return nil
+}
+
+// exchange returns the amount sold by an offer of the given amount and price
+// to a taker selling at most maxBought, and the amount the offer buys. The
+// amount bought is rounded up in favor of the offer. hcnet-core rounds with
+// more care, so crossings can differ by a stroop from a real network.
+func exchange(amount int64, price xdr.Price, maxBought int64) (sold, bought int64, ok bool) {
+	n, d := int64(price.N), int64(price.D)
+	if cost, ok := mulDiv(amount, n, d, true); ok && cost <= maxBought {
+		return amount, cost, true
+	}
+	sold, ok = mulDiv(maxBought, d, n, false)
+	if !ok {
+		return 0, 0, false
+	}
+	if sold > amount {
+		sold = amount
+	}
+	bought, ok = mulDiv(sold, n, d, true)
+	return sold, bought, ok
+}
+
+func (l *ledgerCloser) liquidityPoolDeposit(changes *changeSet, source xdr.AccountId, op xdr.LiquidityPoolDepositOp) (xdr.OperationResultTr, error) {
+	result := xdr.OperationResultTr{
+		Type: xdr.OperationTypeLiquidityPoolDeposit,
+		LiquidityPoolDepositResult: &xdr.LiquidityPoolDepositResult{
+			Code: xdr.LiquidityPoolDepositResultCodeLiquidityPoolDepositSuccess,
+		},
+	}
+
+	pool, err := changes.loadLiquidityPool(op.LiquidityPoolId)
+	if err != nil {
+		return result, err
+	}
+	if pool == nil {
+		return result, errors.New("liquidity pool does not exist")
+	}
+	shareTrustLine, err := changes.loadTrustLine(source, xdr.TrustLineAsset{
+		Type:            xdr.AssetTypeAssetTypePoolShare,
+		LiquidityPoolId: &op.LiquidityPoolId,
+	})
+	if err != nil {
+		return result, err
+	}
+	if shareTrustLine == nil {
+		return result, errors.Errorf("account %s has no trust line for the liquidity pool", source.Address())
+	}
+	if op.MaxAmountA <= 0 || op.MaxAmountB <= 0 {
+		return result, errors.New("deposit amounts must be positive")
+	}
+
+	cp := pool.Body.ConstantProduct
+	var depositA, depositB, shares int64
+	ok := true
+	if cp.TotalPoolShares == 0 {
+		depositA, depositB = int64(op.MaxAmountA), int64(op.MaxAmountB)
+		product := big.NewInt(0).Mul(big.NewInt(depositA), big.NewInt(depositB))
+		shares = product.Sqrt(product).Int64()
+	} else {
+		total := int64(cp.TotalPoolShares)
+		sharesA, okA := mulDiv(int64(op.MaxAmountA), total, int64(cp.ReserveA), false)
+		sharesB, okB := mulDiv(int64(op.MaxAmountB), total, int64(cp.ReserveB), false)
+		ok = okA && okB
+		shares = sharesA
+		if sharesB < shares {
+			shares = sharesB
+		}
+		var okDepositA, okDepositB bool
+		depositA, okDepositA = mulDiv(shares, int64(cp.ReserveA), total, true)
+		depositB, okDepositB = mulDiv(shares, int64(cp.ReserveB), total, true)
+		ok = ok && okDepositA && okDepositB
+	}
+	if !ok {
+		return result, errors.New("deposit amounts overflow")
+	}
+	if shares == 0 || depositA == 0 || depositB == 0 {
+		return result, errors.New("deposit is too small")
+	}
+
+	// MinPrice <= depositA/depositB <= MaxPrice
+	a, b := big.NewInt(depositA), big.NewInt(depositB)
+	if big.NewInt(0).Mul(a, big.NewInt(int64(op.MinPrice.D))).Cmp(big.NewInt(0).Mul(b, big.NewInt(int64(op.MinPrice.N)))) < 0 ||
+		big.NewInt(0).Mul(a, big.NewInt(int64(op.MaxPrice.D))).Cmp(big.NewInt(0).Mul(b, big.NewInt(int64(op.MaxPrice.N)))) > 0 {
+		return result, errors.New("deposit price is out of bounds")
+	}
+
+	if err = changes.addBalance(source, cp.Params.AssetA, -depositA); err != nil {
+		return result, err
+	}
+	if err = changes.addBalance(source, cp.Params.AssetB, -depositB); err != nil {
+		return result, err
+	}
+	reserveA, okA := addAmounts(int64(cp.ReserveA), depositA)
+	reserveB, okB := addAmounts(int64(cp.ReserveB), depositB)
+	totalShares, okShares := addAmounts(int64(cp.TotalPoolShares), shares)
+	balance, okBalance := addAmounts(int64(shareTrustLine.Balance), shares)
+	if !okA || !okB || !okShares || !okBalance || balance > int64(shareTrustLine.Limit) {
+		return result, errors.New("liquidity pool is full")
+	}
+	cp.ReserveA = xdr.Int64(reserveA)
+	cp.ReserveB = xdr.Int64(reserveB)
+	cp.TotalPoolShares = xdr.Int64(totalShares)
+	shareTrustLine.Balance = xdr.Int64(balance)
+	return result, nil
+}
+
+// mulDiv returns a*b/c rounded down, or up if roundUp is set, and false if
+// the result does not fit in an int64.
+func mulDiv(a, b, c int64, roundUp bool) (int64, bool) {
+	numerator := big.NewInt(0).Mul(big.NewInt(a), big.NewInt(b))
+	divisor := big.NewInt(c)
+	quotient, remainder := big.NewInt(0).QuoRem(numerator, divisor, big.NewInt(0))
+	if roundUp && remainder.Sign() > 0 {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+	if !quotient.IsInt64() {
+		return 0, false
+	}
+	return quotient.Int64(), true
+}
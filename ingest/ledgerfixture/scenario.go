@@ -0,0 +1,448 @@
+package ledgerfixture
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"math"
+
+	"github.com/shantanu-hashcash/go/ingest"
+	"github.com/shantanu-hashcash/go/keypair"
+	"github.com/shantanu-hashcash/go/support/errors"
+	"github.com/shantanu-hashcash/go/txnbuild"
+	"github.com/shantanu-hashcash/go/xdr"
+)
+
+const (
+	// ProtocolVersion is the protocol version of the generated ledgers.
+	ProtocolVersion = 20
+	// BaseFee is the fee charged per operation, in stroops.
+	BaseFee = 100
+	// BaseReserve is the base reserve of the generated ledgers, in stroops.
+	BaseReserve = 5000000
+	// GenesisCloseTime is the close time of the genesis ledger, as a unix
+	// timestamp.
+	GenesisCloseTime = 1700000000
+	// CloseTimeInterval is the number of seconds between two ledgers.
+	CloseTimeInterval = 5
+
+	maxAmount = math.MaxInt64
+)
+
+// Transaction is a transaction to include in a ledger. The sequence number
+// and fee of the transaction are set by the scenario, which also signs it
+// with Source.
+type Transaction struct {
+	Source     *keypair.Full
+	Operations []txnbuild.Operation
+	Memo       txnbuild.Memo
+	// ContractEvents and ReturnValue are the result of a transaction with a
+	// single InvokeHostFunction operation.
+	ContractEvents []xdr.ContractEvent
+	ReturnValue    *xdr.ScVal
+}
+
+func (tx Transaction) isSoroban() bool {
+	for _, op := range tx.Operations {
+		if _, ok := op.(*txnbuild.InvokeHostFunction); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Scenario generates a sequence of ledgers starting from the genesis ledger
+// of a network. Each ledger applies scripted transactions to the ledger
+// entries of the scenario, so the ledger entry changes of the generated meta
+// are consistent with each other, and the header of each ledger links to the
+// hash of the previous one.
+//
+// Scenarios are deterministic: the same transactions always produce the same
+// ledgers, hashes included.
+type Scenario struct {
+	networkPassphrase string
+	root              *keypair.Full
+	state             ledgerState
+	header            xdr.LedgerHeaderHistoryEntry
+	ledgers           []xdr.LedgerCloseMeta
+}
+
+// NewScenario returns a Scenario whose only ledger entry is the root account
+// of the network, as in its genesis ledger.
+func NewScenario(networkPassphrase string) (*Scenario, error) {
+	genesis := ingest.GenesisChange(networkPassphrase)
+	key, err := genesis.Post.LedgerKey()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get ledger key of root account")
+	}
+	keyString, err := ledgerKeyString(key)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Scenario{
+		networkPassphrase: networkPassphrase,
+		root:              keypair.Root(networkPassphrase),
+		state:             ledgerState{keyString: *genesis.Post},
+	}
+
+	bucketListHash, err := s.state.hash()
+	if err != nil {
+		return nil, err
+	}
+	header := xdr.LedgerHeader{
+		LedgerVersion: ProtocolVersion,
+		ScpValue: xdr.HcnetValue{
+			CloseTime: GenesisCloseTime,
+		},
+		BucketListHash: bucketListHash,
+		LedgerSeq:      1,
+		TotalCoins:     genesis.Post.Data.Account.Balance,
+		BaseFee:        BaseFee,
+		BaseReserve:    BaseReserve,
+		MaxTxSetSize:   1000,
+	}
+	if s.header, err = headerHistoryEntry(header); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Root returns the root account of the network, which holds all the lumens
+// at genesis.
+func (s *Scenario) Root() *keypair.Full {
+	return s.root
+}
+
+// Account returns the keypair of a named account. The same name always
+// returns the same keypair. The account exists once it has been created by a
+// CreateAccount operation.
+func (s *Scenario) Account(name string) *keypair.Full {
+	kp, err := keypair.FromRawSeed(sha256.Sum256([]byte("ledgerfixture account " + name)))
+	if err != nil {
+		panic(err)
+	}
+	return kp
+}
+
+// Header returns the header of the last closed ledger.
+func (s *Scenario) Header() xdr.LedgerHeaderHistoryEntry {
+	return s.header
+}
+
+// Ledgers returns the ledgers closed so far, starting at ledger 2.
+func (s *Scenario) Ledgers() []xdr.LedgerCloseMeta {
+	return append([]xdr.LedgerCloseMeta(nil), s.ledgers...)
+}
+
+// Entries returns the current ledger entries, in a deterministic order.
+func (s *Scenario) Entries() []xdr.LedgerEntry {
+	entries := make([]xdr.LedgerEntry, 0, len(s.state))
+	for _, key := range s.state.sortedKeys() {
+		entries = append(entries, s.state[key])
+	}
+	return entries
+}
+
+// ledgerCloser holds the state of the ledger being closed.
+type ledgerCloser struct {
+	sequence uint32
+	idPool   uint64
+	state    ledgerState
+}
+
+// CloseLedger closes the next ledger with the given transactions, applied in
+// order, and returns its meta. All the operations must succeed: an error is
+// returned, and the scenario is left unchanged, if an operation is invalid or
+// not supported.
+//
+// The supported operations are CreateAccount, Payment, ChangeTrust (of
+// assets and liquidity pool shares), ManageSellOffer (new offers, which
+// cross existing ones, and deleted offers), LiquidityPoolDeposit and
+// InvokeHostFunction.
+func (s *Scenario) CloseLedger(txs ...Transaction) (xdr.LedgerCloseMeta, error) {
+	previous := s.header.Header
+	l := &ledgerCloser{
+		sequence: uint32(previous.LedgerSeq) + 1,
+		idPool:   uint64(previous.IdPool),
+		state:    s.state.snapshot(),
+	}
+
+	envelopes := make([]xdr.TransactionEnvelope, len(txs))
+	hashes := make([]xdr.Hash, len(txs))
+	sequences := map[string]int64{}
+	for i, tx := range txs {
+		if tx.Source == nil {
+			return xdr.LedgerCloseMeta{}, errors.Errorf("transaction %d has no source", i)
+		}
+		var err error
+		envelopes[i], hashes[i], err = s.buildTransaction(l, tx, sequences)
+		if err != nil {
+			return xdr.LedgerCloseMeta{}, errors.Wrapf(err, "could not build transaction %d", i)
+		}
+	}
+
+	// Fees of all the transactions are charged before applying them.
+	processing := make([]xdr.TransactionResultMeta, len(txs))
+	var fees int64
+	for i, tx := range txs {
+		fee := int64(BaseFee * len(tx.Operations))
+		changes := newChangeSet(l.state, l.sequence)
+		if err := changes.addBalance(xdr.MustAddress(tx.Source.Address()), xdr.MustNewNativeAsset(), -fee); err != nil {
+			return xdr.LedgerCloseMeta{}, errors.Wrapf(err, "could not charge fee of transaction %d", i)
+		}
+		feeChanges, err := changes.commit()
+		if err != nil {
+			return xdr.LedgerCloseMeta{}, err
+		}
+		fees += fee
+		processing[i] = xdr.TransactionResultMeta{
+			Result: xdr.TransactionResultPair{
+				TransactionHash: hashes[i],
+				Result:          xdr.TransactionResult{FeeCharged: xdr.Int64(fee)},
+			},
+			FeeProcessing: feeChanges,
+		}
+	}
+
+	for i, tx := range txs {
+		if err := s.applyTransaction(l, tx, envelopes[i], &processing[i]); err != nil {
+			return xdr.LedgerCloseMeta{}, errors.Wrapf(err, "could not apply transaction %d", i)
+		}
+	}
+
+	lcm, err := s.closeLedger(l, previous, envelopes, txs, processing, fees)
+	if err != nil {
+		return xdr.LedgerCloseMeta{}, err
+	}
+	s.state = l.state
+	s.header = lcm.V1.LedgerHeader
+	s.ledgers = append(s.ledgers, lcm)
+	return lcm, nil
+}
+
+// buildTransaction returns the signed envelope and hash of tx, using the next
+// sequence number of its source.
+func (s *Scenario) buildTransaction(
+	l *ledgerCloser,
+	tx Transaction,
+	sequences map[string]int64,
+) (xdr.TransactionEnvelope, xdr.Hash, error) {
+	source := tx.Source.Address()
+	sequence, ok := sequences[source]
+	if !ok {
+		changes := newChangeSet(l.state, l.sequence)
+		account, err := changes.loadAccount(xdr.MustAddress(source))
+		if err != nil {
+			return xdr.TransactionEnvelope{}, xdr.Hash{}, err
+		}
+		sequence = int64(account.SeqNum)
+	}
+
+	built, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount:        &txnbuild.SimpleAccount{AccountID: source, Sequence: sequence},
+		IncrementSequenceNum: true,
+		Operations:           tx.Operations,
+		BaseFee:              BaseFee,
+		Memo:                 tx.Memo,
+		Preconditions:        txnbuild.Preconditions{TimeBounds: txnbuild.NewInfiniteTimeout()},
+	})
+	if err != nil {
+		return xdr.TransactionEnvelope{}, xdr.Hash{}, err
+	}
+	if built, err = built.Sign(s.networkPassphrase, tx.Source); err != nil {
+		return xdr.TransactionEnvelope{}, xdr.Hash{}, err
+	}
+	hash, err := built.Hash(s.networkPassphrase)
+	if err != nil {
+		return xdr.TransactionEnvelope{}, xdr.Hash{}, err
+	}
+	sequences[source] = sequence + 1
+	return built.ToXDR(), hash, nil
+}
+
+// applyTransaction bumps the sequence number of the source of tx and applies
+// its operations, adding the results and meta to processing.
+func (s *Scenario) applyTransaction(
+	l *ledgerCloser,
+	tx Transaction,
+	envelope xdr.TransactionEnvelope,
+	processing *xdr.TransactionResultMeta,
+) error {
+	source := envelope.SourceAccount().ToAccountId()
+	changes := newChangeSet(l.state, l.sequence)
+	account, err := changes.loadAccount(source)
+	if err != nil {
+		return err
+	}
+	account.SeqNum = xdr.SequenceNumber(envelope.SeqNum())
+	changesBefore, err := changes.commit()
+	if err != nil {
+		return err
+	}
+
+	operations := envelope.Operations()
+	results := make([]xdr.OperationResult, len(operations))
+	operationsMeta := make([]xdr.OperationMeta, len(operations))
+	for i, op := range operations {
+		opSource := source
+		if op.SourceAccount != nil {
+			opSource = op.SourceAccount.ToAccountId()
+		}
+		changes := newChangeSet(l.state, l.sequence)
+		result, err := l.applyOperation(changes, opSource, op)
+		if err != nil {
+			return errors.Wrapf(err, "operation %d (%s) failed", i, op.Body.Type)
+		}
+		if operationsMeta[i].Changes, err = changes.commit(); err != nil {
+			return err
+		}
+		results[i] = xdr.OperationResult{Code: xdr.OperationResultCodeOpInner, Tr: &result}
+	}
+
+	meta := xdr.TransactionMetaV3{
+		TxChangesBefore: changesBefore,
+		Operations:      operationsMeta,
+	}
+	if tx.isSoroban() {
+		if len(operations) != 1 {
+			return errors.New("transactions invoking a host function must have a single operation")
+		}
+		returnValue := xdr.ScVal{Type: xdr.ScValTypeScvVoid}
+		if tx.ReturnValue != nil {
+			returnValue = *tx.ReturnValue
+		}
+		events := tx.ContractEvents
+		if events == nil {
+			events = []xdr.ContractEvent{}
+		}
+		meta.SorobanMeta = &xdr.SorobanTransactionMeta{
+			Events:      events,
+			ReturnValue: returnValue,
+		}
+		preImageHash, err := hashXDR(xdr.InvokeHostFunctionSuccessPreImage{
+			ReturnValue: returnValue,
+			Events:      events,
+		})
+		if err != nil {
+			return err
+		}
+		results[0].Tr.InvokeHostFunctionResult.Success = &preImageHash
+	} else if len(tx.ContractEvents) > 0 || tx.ReturnValue != nil {
+		return errors.New("only transactions invoking a host function can have contract events or a return value")
+	}
+
+	processing.Result.Result.Result = xdr.TransactionResultResult{
+		Code:    xdr.TransactionResultCodeTxSuccess,
+		Results: &results,
+	}
+	processing.TxApplyProcessing = xdr.TransactionMeta{V: 3, V3: &meta}
+	return nil
+}
+
+// closeLedger builds the transaction set and header of the ledger.
+func (s *Scenario) closeLedger(
+	l *ledgerCloser,
+	previous xdr.LedgerHeader,
+	envelopes []xdr.TransactionEnvelope,
+	txs []Transaction,
+	processing []xdr.TransactionResultMeta,
+	fees int64,
+) (xdr.LedgerCloseMeta, error) {
+	// Classic and Soroban transactions are in separate phases.
+	classic, soroban := []xdr.TransactionEnvelope{}, []xdr.TransactionEnvelope{}
+	for i, tx := range txs {
+		if tx.isSoroban() {
+			soroban = append(soroban, envelopes[i])
+		} else {
+			classic = append(classic, envelopes[i])
+		}
+	}
+	previousHash := s.header.Hash
+	txSet := xdr.GeneralizedTransactionSet{
+		V: 1,
+		V1TxSet: &xdr.TransactionSetV1{
+			PreviousLedgerHash: previousHash,
+			Phases:             []xdr.TransactionPhase{txSetPhase(classic), txSetPhase(soroban)},
+		},
+	}
+	txSetHash, err := hashXDR(txSet)
+	if err != nil {
+		return xdr.LedgerCloseMeta{}, err
+	}
+
+	results := make([]xdr.TransactionResultPair, len(processing))
+	for i := range processing {
+		results[i] = processing[i].Result
+	}
+	resultsHash, err := hashXDR(xdr.TransactionResultSet{Results: results})
+	if err != nil {
+		return xdr.LedgerCloseMeta{}, err
+	}
+
+	bucketListHash, err := l.state.hash()
+	if err != nil {
+		return xdr.LedgerCloseMeta{}, err
+	}
+
+	header, err := headerHistoryEntry(xdr.LedgerHeader{
+		LedgerVersion:      previous.LedgerVersion,
+		PreviousLedgerHash: previousHash,
+		ScpValue: xdr.HcnetValue{
+			TxSetHash: txSetHash,
+			CloseTime: previous.ScpValue.CloseTime + CloseTimeInterval,
+		},
+		TxSetResultHash: resultsHash,
+		BucketListHash:  bucketListHash,
+		LedgerSeq:       xdr.Uint32(l.sequence),
+		TotalCoins:      previous.TotalCoins,
+		FeePool:         previous.FeePool + xdr.Int64(fees),
+		InflationSeq:    previous.InflationSeq,
+		IdPool:          xdr.Uint64(l.idPool),
+		BaseFee:         previous.BaseFee,
+		BaseReserve:     previous.BaseReserve,
+		MaxTxSetSize:    previous.MaxTxSetSize,
+	})
+	if err != nil {
+		return xdr.LedgerCloseMeta{}, err
+	}
+
+	return xdr.LedgerCloseMeta{
+		V: 1,
+		V1: &xdr.LedgerCloseMetaV1{
+			LedgerHeader: header,
+			TxSet:        txSet,
+			TxProcessing: processing,
+		},
+	}, nil
+}
+
+func txSetPhase(envelopes []xdr.TransactionEnvelope) xdr.TransactionPhase {
+	baseFee := xdr.Int64(BaseFee)
+	components := []xdr.TxSetComponent{}
+	if len(envelopes) > 0 {
+		components = append(components, xdr.TxSetComponent{
+			Type: xdr.TxSetComponentTypeTxsetCompTxsMaybeDiscountedFee,
+			TxsMaybeDiscountedFee: &xdr.TxSetComponentTxsMaybeDiscountedFee{
+				BaseFee: &baseFee,
+				Txs:     envelopes,
+			},
+		})
+	}
+	return xdr.TransactionPhase{V: 0, V0Components: &components}
+}
+
+func headerHistoryEntry(header xdr.LedgerHeader) (xdr.LedgerHeaderHistoryEntry, error) {
+	hash, err := hashXDR(header)
+	if err != nil {
+		return xdr.LedgerHeaderHistoryEntry{}, err
+	}
+	return xdr.LedgerHeaderHistoryEntry{Hash: hash, Header: header}, nil
+}
+
+func hashXDR(v interface{}) (xdr.Hash, error) {
+	var buf bytes.Buffer
+	if _, err := xdr.Marshal(&buf, v); err != nil {
+		return xdr.Hash{}, errors.Wrapf(err, "could not marshal %T", v)
+	}
+	return sha256.Sum256(buf.Bytes()), nil
+}
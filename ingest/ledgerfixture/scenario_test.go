@@ -0,0 +1,281 @@
+package ledgerfixture
+
+import (
+	"context"
+	"io"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shantanu-hashcash/go/ingest"
+	"github.com/shantanu-hashcash/go/ingest/processors"
+	"github.com/shantanu-hashcash/go/network"
+	"github.com/shantanu-hashcash/go/support/contractevents"
+	"github.com/shantanu-hashcash/go/txnbuild"
+	"github.com/shantanu-hashcash/go/xdr"
+)
+
+// runScenario closes ledgers 2 to 7, which create accounts and a trust line,
+// cross offers, deposit into a liquidity pool and emit a contract event.
+func runScenario(t *testing.T) *Scenario {
+	s, err := NewScenario(network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	issuer, alice, bob := s.Account("issuer"), s.Account("alice"), s.Account("bob")
+	usd := txnbuild.CreditAsset{Code: "USD", Issuer: issuer.Address()}
+	poolShare := txnbuild.LiquidityPoolShareChangeTrustAsset{
+		LiquidityPoolParameters: txnbuild.LiquidityPoolParameters{
+			AssetA: txnbuild.NativeAsset{},
+			AssetB: usd,
+			Fee:    txnbuild.LiquidityPoolFeeV18,
+		},
+	}
+	deposit, err := txnbuild.NewLiquidityPoolDeposit(
+		"",
+		txnbuild.AssetAmount{Asset: txnbuild.NativeAsset{}, Amount: "100"},
+		txnbuild.AssetAmount{Asset: usd, Amount: "100"},
+		xdr.Price{N: 1, D: 2},
+		xdr.Price{N: 2, D: 1},
+	)
+	require.NoError(t, err)
+	xdrUSD, err := usd.ToXDR()
+	require.NoError(t, err)
+	contractID, err := xdrUSD.ContractID(network.TestNetworkPassphrase)
+	require.NoError(t, err)
+
+	ledgers := [][]Transaction{
+		{
+			{Source: s.Root(), Operations: []txnbuild.Operation{
+				&txnbuild.CreateAccount{Destination: issuer.Address(), Amount: "100"},
+				&txnbuild.CreateAccount{Destination: alice.Address(), Amount: "1000"},
+				&txnbuild.CreateAccount{Destination: bob.Address(), Amount: "1000"},
+			}},
+		},
+		{
+			{Source: alice, Operations: []txnbuild.Operation{&txnbuild.ChangeTrust{Line: usd.MustToChangeTrustAsset()}}},
+			{Source: bob, Operations: []txnbuild.Operation{&txnbuild.ChangeTrust{Line: usd.MustToChangeTrustAsset()}}},
+		},
+		{
+			{Source: issuer, Operations: []txnbuild.Operation{
+				&txnbuild.Payment{Destination: alice.Address(), Amount: "1000", Asset: usd},
+			}, Memo: txnbuild.MemoText("funding")},
+		},
+		{
+			{Source: alice, Operations: []txnbuild.Operation{&txnbuild.ManageSellOffer{
+				Selling: usd, Buying: txnbuild.NativeAsset{}, Amount: "100", Price: xdr.Price{N: 2, D: 1},
+			}}},
+			{Source: bob, Operations: []txnbuild.Operation{&txnbuild.ManageSellOffer{
+				Selling: txnbuild.NativeAsset{}, Buying: usd, Amount: "50", Price: xdr.Price{N: 1, D: 2},
+			}}},
+		},
+		{
+			{Source: alice, Operations: []txnbuild.Operation{
+				&txnbuild.ChangeTrust{Line: poolShare},
+				&deposit,
+			}},
+		},
+		{
+			{
+				Source: bob,
+				Operations: []txnbuild.Operation{&txnbuild.InvokeHostFunction{
+					HostFunction: xdr.HostFunction{
+						Type: xdr.HostFunctionTypeHostFunctionTypeInvokeContract,
+						InvokeContract: &xdr.InvokeContractArgs{
+							ContractAddress: xdr.ScAddress{
+								Type:       xdr.ScAddressTypeScAddressTypeContract,
+								ContractId: (*xdr.Hash)(&contractID),
+							},
+							FunctionName: "transfer",
+							Args:         []xdr.ScVal{},
+						},
+					},
+				}},
+				ContractEvents: []xdr.ContractEvent{contractevents.GenerateEvent(
+					contractevents.EventTypeTransfer,
+					bob.Address(), alice.Address(), "",
+					xdrUSD, big.NewInt(10000000),
+					network.TestNetworkPassphrase,
+				)},
+			},
+		},
+	}
+	for _, txs := range ledgers {
+		_, err := s.CloseLedger(txs...)
+		require.NoError(t, err)
+	}
+	return s
+}
+
+func TestScenarioHashChain(t *testing.T) {
+	s := runScenario(t)
+	ledgers := s.Ledgers()
+	require.Len(t, ledgers, 6)
+
+	genesis, err := NewScenario(network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	previous := genesis.Header()
+	for i, ledger := range ledgers {
+		header := ledger.LedgerHeaderHistoryEntry()
+		assert.Equal(t, uint32(i+2), ledger.LedgerSequence())
+		assert.Equal(t, previous.Hash, header.Header.PreviousLedgerHash)
+		assert.Equal(t, previous.Header.ScpValue.CloseTime+CloseTimeInterval, header.Header.ScpValue.CloseTime)
+
+		hash, err := hashXDR(header.Header)
+		require.NoError(t, err)
+		assert.Equal(t, hash, header.Hash)
+		txSetHash, err := hashXDR(ledger.V1.TxSet)
+		require.NoError(t, err)
+		assert.Equal(t, txSetHash, header.Header.ScpValue.TxSetHash)
+		previous = header
+	}
+	assert.Equal(t, previous, s.Header())
+}
+
+func TestScenarioIsDeterministic(t *testing.T) {
+	a, b := runScenario(t), runScenario(t)
+	assert.Equal(t, a.Header().Hash, b.Header().Hash)
+	assert.Equal(t, a.Ledgers(), b.Ledgers())
+}
+
+func TestScenarioChangesReplayToEntries(t *testing.T) {
+	s := runScenario(t)
+	backend := NewBackend(s.Ledgers()...)
+	ctx := context.Background()
+
+	state := map[string]xdr.LedgerEntry{}
+	apply := func(change ingest.Change) {
+		entry := change.Post
+		if entry == nil {
+			entry = change.Pre
+		}
+		key, err := entry.LedgerKey()
+		require.NoError(t, err)
+		keyString, err := ledgerKeyString(key)
+		require.NoError(t, err)
+
+		current, ok := state[keyString]
+		if change.Pre == nil {
+			assert.False(t, ok, "created entry already exists")
+		} else {
+			require.True(t, ok, "changed entry does not exist")
+			assert.Equal(t, current, *change.Pre)
+		}
+		if change.Post == nil {
+			delete(state, keyString)
+		} else {
+			state[keyString] = *change.Post
+		}
+	}
+
+	apply(ingest.GenesisChange(network.TestNetworkPassphrase))
+	for _, ledger := range s.Ledgers() {
+		reader, err := ingest.NewLedgerChangeReader(ctx, backend, network.TestNetworkPassphrase, ledger.LedgerSequence())
+		require.NoError(t, err)
+		for {
+			change, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			apply(change)
+		}
+		require.NoError(t, reader.Close())
+	}
+
+	var entries []xdr.LedgerEntry
+	for _, key := range ledgerState(state).sortedKeys() {
+		entries = append(entries, state[key])
+	}
+	assert.Equal(t, s.Entries(), entries)
+
+	stats := processors.NewAssetStatsProcessor()
+	for i := range entries {
+		require.NoError(t, stats.ProcessChange(ingest.Change{
+			Type: entries[i].Data.Type,
+			Post: &entries[i],
+		}))
+	}
+	require.Len(t, stats.Stats(), 1)
+	stat := stats.Stats()[0]
+	assert.Equal(t, "USD", stat.AssetCode)
+	assert.Equal(t, s.Account("issuer").Address(), stat.AssetIssuer)
+	assert.Equal(t, int32(2), stat.Accounts.Authorized)
+	assert.Equal(t, int32(1), stat.Accounts.LiquidityPools)
+	assert.Equal(t, "9000000000", stat.Balances.Authorized.String())
+	assert.Equal(t, "1000000000", stat.Balances.LiquidityPools.String())
+}
+
+func TestScenarioProcessors(t *testing.T) {
+	s := runScenario(t)
+	backend := NewBackend(s.Ledgers()...)
+	ctx := context.Background()
+
+	var trades []processors.Trade
+	var events []processors.ContractEvent
+	var transactions int
+	for _, ledger := range s.Ledgers() {
+		reader, err := ingest.NewLedgerTransactionReader(ctx, backend, network.TestNetworkPassphrase, ledger.LedgerSequence())
+		require.NoError(t, err)
+		for {
+			tx, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			transactions++
+			assert.True(t, tx.Result.Successful())
+
+			_, err = processors.TransactionOperations(ledger, tx, network.TestNetworkPassphrase)
+			require.NoError(t, err)
+			_, err = processors.TransactionEffects(ledger, tx, network.TestNetworkPassphrase)
+			require.NoError(t, err)
+			txTrades, err := processors.TransactionTrades(ledger, tx)
+			require.NoError(t, err)
+			trades = append(trades, txTrades...)
+			if tx.UnsafeMeta.V == 3 && tx.UnsafeMeta.V3.SorobanMeta != nil {
+				txEvents, err := processors.TransactionContractEvents(tx, network.TestNetworkPassphrase)
+				require.NoError(t, err)
+				events = append(events, txEvents...)
+			}
+		}
+		require.NoError(t, reader.Close())
+	}
+	assert.Equal(t, 8, transactions)
+
+	require.Len(t, trades, 1)
+	assert.Equal(t, processors.OrderbookTradeType, trades[0].Type)
+	assert.Equal(t, s.Account("alice").Address(), trades[0].SellerAccount)
+	assert.Equal(t, int64(250000000), trades[0].SoldAmount)
+	assert.Equal(t, int64(500000000), trades[0].BoughtAmount)
+
+	require.Len(t, events, 1)
+	require.NotNil(t, events[0].AssetEvent)
+	assert.Equal(t, contractevents.EventTypeTransfer, events[0].AssetEvent.GetType())
+}
+
+func TestCloseLedgerErrorLeavesScenarioUnchanged(t *testing.T) {
+	s := runScenario(t)
+	header, entries := s.Header(), s.Entries()
+
+	_, err := s.CloseLedger(
+		Transaction{Source: s.Account("bob"), Operations: []txnbuild.Operation{
+			&txnbuild.Payment{Destination: s.Account("alice").Address(), Amount: "1", Asset: txnbuild.NativeAsset{}},
+		}},
+		Transaction{Source: s.Account("carol"), Operations: []txnbuild.Operation{
+			&txnbuild.Payment{Destination: s.Account("alice").Address(), Amount: "1", Asset: txnbuild.NativeAsset{}},
+		}},
+	)
+	assert.EqualError(t, err, "could not build transaction 1: account "+s.Account("carol").Address()+" does not exist")
+
+	_, err = s.CloseLedger(Transaction{Source: s.Account("bob"), Operations: []txnbuild.Operation{
+		&txnbuild.Payment{Destination: s.Account("alice").Address(), Amount: "1", Asset: txnbuild.NativeAsset{}},
+		&txnbuild.Payment{Destination: s.Account("alice").Address(), Amount: "100000", Asset: txnbuild.NativeAsset{}},
+	}})
+	assert.EqualError(t, err, "could not apply transaction 0: operation 1 (OperationTypePayment) failed: account "+
+		s.Account("bob").Address()+" has an insufficient balance")
+
+	assert.Equal(t, header, s.Header())
+	assert.Equal(t, entries, s.Entries())
+	assert.Len(t, s.Ledgers(), 6)
+}
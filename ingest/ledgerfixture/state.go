@@ -0,0 +1,303 @@
+package ledgerfixture
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"math"
+	"sort"
+
+	"github.com/shantanu-hashcash/go/support/errors"
+	"github.com/shantanu-hashcash/go/xdr"
+)
+
+// ledgerState holds the ledger entries by their marshalled ledger key.
+// Entries are never modified in place so the map can be copied to take a
+// snapshot.
+type ledgerState map[string]xdr.LedgerEntry
+
+func ledgerKeyString(key xdr.LedgerKey) (string, error) {
+	raw, err := key.MarshalBinary()
+	if err != nil {
+		return "", errors.Wrap(err, "could not marshal ledger key")
+	}
+	return string(raw), nil
+}
+
+func cloneEntry(entry xdr.LedgerEntry) (xdr.LedgerEntry, error) {
+	var clone xdr.LedgerEntry
+	raw, err := entry.MarshalBinary()
+	if err != nil {
+		return clone, errors.Wrap(err, "could not marshal ledger entry")
+	}
+	if err = clone.UnmarshalBinary(raw); err != nil {
+		return clone, errors.Wrap(err, "could not unmarshal ledger entry")
+	}
+	return clone, nil
+}
+
+func (s ledgerState) snapshot() ledgerState {
+	snapshot := make(ledgerState, len(s))
+	for key, entry := range s {
+		snapshot[key] = entry
+	}
+	return snapshot
+}
+
+// sortedKeys returns the keys of the entries in a deterministic order.
+func (s ledgerState) sortedKeys() []string {
+	keys := make([]string, 0, len(s))
+	for key := range s {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// hash returns the hash of all the entries. It stands in for the bucket list
+// hash of hcnet-core, which cannot be computed without the bucket list.
+func (s ledgerState) hash() (xdr.Hash, error) {
+	var buf bytes.Buffer
+	for _, key := range s.sortedKeys() {
+		entry := s[key]
+		if _, err := xdr.Marshal(&buf, entry); err != nil {
+			return xdr.Hash{}, errors.Wrap(err, "could not marshal ledger entry")
+		}
+	}
+	return sha256.Sum256(buf.Bytes()), nil
+}
+
+// changeSet tracks the entries modified by a step of the ledger close, such
+// as charging a fee or applying an operation, and records them as the
+// ledger entry changes of that step.
+type changeSet struct {
+	state  ledgerState
+	ledger uint32
+	keys   []string
+	pre    map[string]*xdr.LedgerEntry
+	post   map[string]*xdr.LedgerEntry
+}
+
+func newChangeSet(state ledgerState, ledger uint32) *changeSet {
+	return &changeSet{
+		state:  state,
+		ledger: ledger,
+		pre:    map[string]*xdr.LedgerEntry{},
+		post:   map[string]*xdr.LedgerEntry{},
+	}
+}
+
+func (c *changeSet) track(key string) error {
+	if _, ok := c.pre[key]; ok {
+		return nil
+	}
+	c.keys = append(c.keys, key)
+	entry, ok := c.state[key]
+	if !ok {
+		c.pre[key] = nil
+		c.post[key] = nil
+		return nil
+	}
+	pre, err := cloneEntry(entry)
+	if err != nil {
+		return err
+	}
+	post, err := cloneEntry(entry)
+	if err != nil {
+		return err
+	}
+	c.pre[key] = &pre
+	c.post[key] = &post
+	return nil
+}
+
+// load returns the entry with the given key, which can be modified until
+// the change set is committed, or nil if it does not exist.
+func (c *changeSet) load(key xdr.LedgerKey) (*xdr.LedgerEntry, error) {
+	keyString, err := ledgerKeyString(key)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.track(keyString); err != nil {
+		return nil, err
+	}
+	return c.post[keyString], nil
+}
+
+func (c *changeSet) create(entry xdr.LedgerEntry) error {
+	key, err := entry.LedgerKey()
+	if err != nil {
+		return errors.Wrap(err, "could not get ledger key")
+	}
+	existing, err := c.load(key)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return errors.Errorf("%s entry already exists", entry.Data.Type)
+	}
+	keyString, err := ledgerKeyString(key)
+	if err != nil {
+		return err
+	}
+	c.post[keyString] = &entry
+	return nil
+}
+
+func (c *changeSet) remove(key xdr.LedgerKey) error {
+	existing, err := c.load(key)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return errors.Errorf("%s entry does not exist", key.Type)
+	}
+	keyString, err := ledgerKeyString(key)
+	if err != nil {
+		return err
+	}
+	c.post[keyString] = nil
+	return nil
+}
+
+// commit writes the modified entries to the ledger state and returns their
+// changes in the order they were first loaded.
+func (c *changeSet) commit() (xdr.LedgerEntryChanges, error) {
+	var changes xdr.LedgerEntryChanges
+	for _, key := range c.keys {
+		pre, post := c.pre[key], c.post[key]
+		switch {
+		case pre == nil && post == nil:
+		case pre == nil:
+			post.LastModifiedLedgerSeq = xdr.Uint32(c.ledger)
+			c.state[key] = *post
+			changes = append(changes, xdr.LedgerEntryChange{
+				Type:    xdr.LedgerEntryChangeTypeLedgerEntryCreated,
+				Created: post,
+			})
+		case post == nil:
+			ledgerKey, err := pre.LedgerKey()
+			if err != nil {
+				return nil, errors.Wrap(err, "could not get ledger key")
+			}
+			delete(c.state, key)
+			changes = append(changes,
+				xdr.LedgerEntryChange{
+					Type:  xdr.LedgerEntryChangeTypeLedgerEntryState,
+					State: pre,
+				},
+				xdr.LedgerEntryChange{
+					Type:    xdr.LedgerEntryChangeTypeLedgerEntryRemoved,
+					Removed: &ledgerKey,
+				},
+			)
+		default:
+			preRaw, err := pre.MarshalBinary()
+			if err != nil {
+				return nil, errors.Wrap(err, "could not marshal ledger entry")
+			}
+			postRaw, err := post.MarshalBinary()
+			if err != nil {
+				return nil, errors.Wrap(err, "could not marshal ledger entry")
+			}
+			if bytes.Equal(preRaw, postRaw) {
+				continue
+			}
+			post.LastModifiedLedgerSeq = xdr.Uint32(c.ledger)
+			c.state[key] = *post
+			changes = append(changes,
+				xdr.LedgerEntryChange{
+					Type:  xdr.LedgerEntryChangeTypeLedgerEntryState,
+					State: pre,
+				},
+				xdr.LedgerEntryChange{
+					Type:    xdr.LedgerEntryChangeTypeLedgerEntryUpdated,
+					Updated: post,
+				},
+			)
+		}
+	}
+	return changes, nil
+}
+
+func (c *changeSet) loadAccount(id xdr.AccountId) (*xdr.AccountEntry, error) {
+	var key xdr.LedgerKey
+	if err := key.SetAccount(id); err != nil {
+		return nil, err
+	}
+	entry, err := c.load(key)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, errors.Errorf("account %s does not exist", id.Address())
+	}
+	return entry.Data.Account, nil
+}
+
+func (c *changeSet) loadTrustLine(id xdr.AccountId, asset xdr.TrustLineAsset) (*xdr.TrustLineEntry, error) {
+	var key xdr.LedgerKey
+	if err := key.SetTrustline(id, asset); err != nil {
+		return nil, err
+	}
+	entry, err := c.load(key)
+	if err != nil || entry == nil {
+		return nil, err
+	}
+	return entry.Data.TrustLine, nil
+}
+
+func (c *changeSet) loadLiquidityPool(id xdr.PoolId) (*xdr.LiquidityPoolEntry, error) {
+	var key xdr.LedgerKey
+	if err := key.SetLiquidityPool(id); err != nil {
+		return nil, err
+	}
+	entry, err := c.load(key)
+	if err != nil || entry == nil {
+		return nil, err
+	}
+	return entry.Data.LiquidityPool, nil
+}
+
+// addBalance adds amount, which can be negative, to the balance of asset
+// held by account. The balances of issuers are unlimited.
+func (c *changeSet) addBalance(id xdr.AccountId, asset xdr.Asset, amount int64) error {
+	if asset.Type == xdr.AssetTypeAssetTypeNative {
+		account, err := c.loadAccount(id)
+		if err != nil {
+			return err
+		}
+		balance, ok := addAmounts(int64(account.Balance), amount)
+		if !ok || balance < 0 {
+			return errors.Errorf("account %s has an insufficient balance", id.Address())
+		}
+		account.Balance = xdr.Int64(balance)
+		return nil
+	}
+
+	if asset.GetIssuer() == id.Address() {
+		return nil
+	}
+	trustLine, err := c.loadTrustLine(id, asset.ToTrustLineAsset())
+	if err != nil {
+		return err
+	}
+	if trustLine == nil {
+		return errors.Errorf("account %s has no trust line for %s", id.Address(), asset.StringCanonical())
+	}
+	if !xdr.TrustLineFlags(trustLine.Flags).IsAuthorized() {
+		return errors.Errorf("trust line of account %s for %s is not authorized", id.Address(), asset.StringCanonical())
+	}
+	balance, ok := addAmounts(int64(trustLine.Balance), amount)
+	if !ok || balance < 0 || balance > int64(trustLine.Limit) {
+		return errors.Errorf("trust line of account %s for %s has an insufficient balance or limit", id.Address(), asset.StringCanonical())
+	}
+	trustLine.Balance = xdr.Int64(balance)
+	return nil
+}
+
+func addAmounts(a, b int64) (int64, bool) {
+	if (b > 0 && a > math.MaxInt64-b) || (b < 0 && a < math.MinInt64-b) {
+		return 0, false
+	}
+	return a + b, true
+}
@@ -0,0 +1,326 @@
+package processors
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/shantanu-hashcash/go/ingest"
+	"github.com/shantanu-hashcash/go/support/errors"
+	"github.com/shantanu-hashcash/go/xdr"
+)
+
+// AssetStatAccounts counts the holders of an asset.
+type AssetStatAccounts struct {
+	Authorized                      int32
+	AuthorizedToMaintainLiabilities int32
+	ClaimableBalances               int32
+	LiquidityPools                  int32
+	Unauthorized                    int32
+}
+
+// IsZero returns true if all the counts are zero.
+func (a AssetStatAccounts) IsZero() bool {
+	return a == AssetStatAccounts{}
+}
+
+// AssetStatBalances sums the holdings of an asset.
+type AssetStatBalances struct {
+	Authorized                      *big.Int
+	AuthorizedToMaintainLiabilities *big.Int
+	ClaimableBalances               *big.Int
+	LiquidityPools                  *big.Int
+	Unauthorized                    *big.Int
+}
+
+func newAssetStatBalances() AssetStatBalances {
+	return AssetStatBalances{
+		Authorized:                      big.NewInt(0),
+		AuthorizedToMaintainLiabilities: big.NewInt(0),
+		ClaimableBalances:               big.NewInt(0),
+		LiquidityPools:                  big.NewInt(0),
+		Unauthorized:                    big.NewInt(0),
+	}
+}
+
+// IsZero returns true if all the sums are zero.
+func (a AssetStatBalances) IsZero() bool {
+	return a.Authorized.Sign() == 0 &&
+		a.AuthorizedToMaintainLiabilities.Sign() == 0 &&
+		a.ClaimableBalances.Sign() == 0 &&
+		a.LiquidityPools.Sign() == 0 &&
+		a.Unauthorized.Sign() == 0
+}
+
+// AssetStat is the change in the number of holders and the holdings of a
+// non-native classic asset, held in trust lines, claimable balances and
+// liquidity pools.
+type AssetStat struct {
+	AssetType   xdr.AssetType
+	AssetCode   string
+	AssetIssuer string
+	Accounts    AssetStatAccounts
+	Balances    AssetStatBalances
+}
+
+type assetStatKey struct {
+	assetType   xdr.AssetType
+	assetCode   string
+	assetIssuer string
+}
+
+// AssetStatsProcessor aggregates the changes of trust lines, claimable
+// balances and liquidity pools into per-asset stats. Processing the changes
+// of a history archive checkpoint yields the stats of all assets at that
+// ledger, and processing the changes of ledgers yields the deltas to apply
+// to them.
+type AssetStatsProcessor struct {
+	stats map[assetStatKey]*AssetStat
+}
+
+// NewAssetStatsProcessor returns an empty AssetStatsProcessor.
+func NewAssetStatsProcessor() *AssetStatsProcessor {
+	return &AssetStatsProcessor{stats: map[assetStatKey]*AssetStat{}}
+}
+
+type delta struct {
+	Authorized                      int64
+	AuthorizedToMaintainLiabilities int64
+	Unauthorized                    int64
+	ClaimableBalances               int64
+	LiquidityPools                  int64
+}
+
+func (d *delta) addByFlags(flags xdr.Uint32, amount int64) {
+	f := xdr.TrustLineFlags(flags)
+	if f.IsAuthorized() {
+		d.Authorized += amount
+	} else if f.IsAuthorizedToMaintainLiabilitiesFlag() {
+		d.AuthorizedToMaintainLiabilities += amount
+	} else {
+		d.Unauthorized += amount
+	}
+}
+
+func (d delta) isEmpty() bool {
+	return d == delta{}
+}
+
+// ProcessChange adds a change to the stats. Changes of ledger entries other
+// than trust lines, claimable balances and liquidity pools are ignored.
+func (p *AssetStatsProcessor) ProcessChange(change ingest.Change) error {
+	switch change.Type {
+	case xdr.LedgerEntryTypeTrustline:
+		return p.addTrustline(change)
+	case xdr.LedgerEntryTypeClaimableBalance:
+		return p.addClaimableBalance(change)
+	case xdr.LedgerEntryTypeLiquidityPool:
+		return p.addLiquidityPool(change)
+	default:
+		return nil
+	}
+}
+
+// Stats returns the non-empty stats, ordered by asset type, code and issuer.
+func (p *AssetStatsProcessor) Stats() []AssetStat {
+	stats := make([]AssetStat, 0, len(p.stats))
+	for _, stat := range p.stats {
+		stats = append(stats, *stat)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].AssetType != stats[j].AssetType {
+			return stats[i].AssetType < stats[j].AssetType
+		}
+		if stats[i].AssetCode != stats[j].AssetCode {
+			return stats[i].AssetCode < stats[j].AssetCode
+		}
+		return stats[i].AssetIssuer < stats[j].AssetIssuer
+	})
+	return stats
+}
+
+// addDelta adds a delta balance and delta accounts to a given asset.
+func (p *AssetStatsProcessor) addDelta(asset xdr.Asset, deltaBalances, deltaAccounts delta) error {
+	if deltaBalances.isEmpty() && deltaAccounts.isEmpty() {
+		return nil
+	}
+
+	var key assetStatKey
+	if err := asset.Extract(&key.assetType, &key.assetCode, &key.assetIssuer); err != nil {
+		return errors.Wrap(err, "could not extract asset info from trustline")
+	}
+
+	current, ok := p.stats[key]
+	if !ok {
+		current = &AssetStat{
+			AssetType:   key.assetType,
+			AssetCode:   key.assetCode,
+			AssetIssuer: key.assetIssuer,
+			Balances:    newAssetStatBalances(),
+		}
+		p.stats[key] = current
+	}
+
+	current.Accounts.Authorized += int32(deltaAccounts.Authorized)
+	current.Accounts.AuthorizedToMaintainLiabilities += int32(deltaAccounts.AuthorizedToMaintainLiabilities)
+	current.Accounts.ClaimableBalances += int32(deltaAccounts.ClaimableBalances)
+	current.Accounts.LiquidityPools += int32(deltaAccounts.LiquidityPools)
+	current.Accounts.Unauthorized += int32(deltaAccounts.Unauthorized)
+
+	current.Balances.Authorized.Add(current.Balances.Authorized, big.NewInt(deltaBalances.Authorized))
+	current.Balances.AuthorizedToMaintainLiabilities.Add(current.Balances.AuthorizedToMaintainLiabilities, big.NewInt(deltaBalances.AuthorizedToMaintainLiabilities))
+	current.Balances.ClaimableBalances.Add(current.Balances.ClaimableBalances, big.NewInt(deltaBalances.ClaimableBalances))
+	current.Balances.LiquidityPools.Add(current.Balances.LiquidityPools, big.NewInt(deltaBalances.LiquidityPools))
+	current.Balances.Unauthorized.Add(current.Balances.Unauthorized, big.NewInt(deltaBalances.Unauthorized))
+
+	// Note: it's possible that after operations above:
+	// numAccounts != 0 && amount == 0 (ex. two accounts send some of their assets to third account)
+	//  OR
+	// numAccounts == 0 && amount != 0 (ex. issuer issued an asset)
+	if current.Balances.IsZero() && current.Accounts.IsZero() {
+		delete(p.stats, key)
+	}
+
+	return nil
+}
+
+func (p *AssetStatsProcessor) addTrustline(change ingest.Change) error {
+	var pre, post *xdr.TrustLineEntry
+	if change.Pre != nil {
+		pre = change.Pre.Data.TrustLine
+	}
+	if change.Post != nil {
+		post = change.Post.Data.TrustLine
+	}
+
+	deltaAccounts := delta{}
+	deltaBalances := delta{}
+
+	if pre == nil && post == nil {
+		return ingest.NewStateError(errors.New("both pre and post trustlines cannot be nil"))
+	}
+
+	var asset xdr.TrustLineAsset
+	if pre != nil {
+		asset = pre.Asset
+		deltaAccounts.addByFlags(pre.Flags, -1)
+		deltaBalances.addByFlags(pre.Flags, -int64(pre.Balance))
+	}
+	if post != nil {
+		asset = post.Asset
+		deltaAccounts.addByFlags(post.Flags, 1)
+		deltaBalances.addByFlags(post.Flags, int64(post.Balance))
+	}
+	if asset.Type == xdr.AssetTypeAssetTypePoolShare || asset.Type == xdr.AssetTypeAssetTypeNative {
+		return nil
+	}
+
+	err := p.addDelta(asset.ToAsset(), deltaBalances, deltaAccounts)
+	if err != nil {
+		return errors.Wrap(err, "error running AssetStatsProcessor.addDelta")
+	}
+	return nil
+}
+
+func (p *AssetStatsProcessor) addLiquidityPool(change ingest.Change) error {
+	var pre, post *xdr.LiquidityPoolEntry
+	if change.Pre != nil {
+		pre = change.Pre.Data.LiquidityPool
+	}
+	if change.Post != nil {
+		post = change.Post.Data.LiquidityPool
+	}
+
+	assetAdeltaNum := delta{}
+	assetAdeltaBalances := delta{}
+	assetBdeltaNum := delta{}
+	assetBdeltaBalances := delta{}
+
+	if pre == nil && post == nil {
+		return ingest.NewStateError(errors.New("both pre and post liquidity pools cannot be nil"))
+	}
+
+	lpType, err := change.GetLiquidityPoolType()
+	if err != nil {
+		return ingest.NewStateError(err)
+	}
+
+	var assetA, assetB xdr.Asset
+	switch lpType {
+	case xdr.LiquidityPoolTypeLiquidityPoolConstantProduct:
+		if pre != nil {
+			assetA = pre.Body.ConstantProduct.Params.AssetA
+			assetAdeltaNum.LiquidityPools--
+			assetAdeltaBalances.LiquidityPools -= int64(pre.Body.ConstantProduct.ReserveA)
+
+			assetB = pre.Body.ConstantProduct.Params.AssetB
+			assetBdeltaNum.LiquidityPools--
+			assetBdeltaBalances.LiquidityPools -= int64(pre.Body.ConstantProduct.ReserveB)
+		}
+		if post != nil {
+			assetA = post.Body.ConstantProduct.Params.AssetA
+			assetAdeltaNum.LiquidityPools++
+			assetAdeltaBalances.LiquidityPools += int64(post.Body.ConstantProduct.ReserveA)
+
+			assetB = post.Body.ConstantProduct.Params.AssetB
+			assetBdeltaNum.LiquidityPools++
+			assetBdeltaBalances.LiquidityPools += int64(post.Body.ConstantProduct.ReserveB)
+		}
+	default:
+		return errors.Errorf("Unknown liquidity pool type=%d", lpType)
+	}
+
+	if assetA.Type != xdr.AssetTypeAssetTypeNative {
+		err := p.addDelta(assetA, assetAdeltaBalances, assetAdeltaNum)
+		if err != nil {
+			return errors.Wrap(err, "error running AssetStatsProcessor.addDelta using AssetA")
+		}
+	}
+
+	if assetB.Type != xdr.AssetTypeAssetTypeNative {
+		err := p.addDelta(assetB, assetBdeltaBalances, assetBdeltaNum)
+		if err != nil {
+			return errors.Wrap(err, "error running AssetStatsProcessor.addDelta using AssetB")
+		}
+	}
+
+	return nil
+}
+
+func (p *AssetStatsProcessor) addClaimableBalance(change ingest.Change) error {
+	var pre, post *xdr.ClaimableBalanceEntry
+	if change.Pre != nil {
+		pre = change.Pre.Data.ClaimableBalance
+	}
+	if change.Post != nil {
+		post = change.Post.Data.ClaimableBalance
+	}
+
+	deltaAccounts := delta{}
+	deltaBalances := delta{}
+
+	if pre == nil && post == nil {
+		return ingest.NewStateError(errors.New("both pre and post claimable balances cannot be nil"))
+	}
+
+	var asset xdr.Asset
+	if pre != nil {
+		asset = pre.Asset
+		deltaAccounts.ClaimableBalances--
+		deltaBalances.ClaimableBalances -= int64(pre.Amount)
+	}
+	if post != nil {
+		asset = post.Asset
+		deltaAccounts.ClaimableBalances++
+		deltaBalances.ClaimableBalances += int64(post.Amount)
+	}
+
+	if asset.Type == xdr.AssetTypeAssetTypeNative {
+		return nil
+	}
+
+	err := p.addDelta(asset, deltaBalances, deltaAccounts)
+	if err != nil {
+		return errors.Wrap(err, "error running AssetStatsProcessor.addDelta")
+	}
+	return nil
+}
@@ -0,0 +1,288 @@
+package processors
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shantanu-hashcash/go/ingest"
+	"github.com/shantanu-hashcash/go/xdr"
+)
+
+var trustLineIssuer = xdr.MustAddress("GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H")
+
+// expectedStat is an AssetStat with balances as strings, in the order
+// authorized, authorized to maintain liabilities, claimable balances,
+// liquidity pools and unauthorized.
+type expectedStat struct {
+	assetType xdr.AssetType
+	assetCode string
+	accounts  AssetStatAccounts
+	balances  [5]string
+}
+
+func assertStats(t *testing.T, p *AssetStatsProcessor, expected []expectedStat) {
+	stats := p.Stats()
+	require.Len(t, stats, len(expected))
+	for i, stat := range stats {
+		assert.Equal(t, expected[i].assetType, stat.AssetType)
+		assert.Equal(t, expected[i].assetCode, stat.AssetCode)
+		assert.Equal(t, trustLineIssuer.Address(), stat.AssetIssuer)
+		assert.Equal(t, expected[i].accounts, stat.Accounts)
+		assert.Equal(t, expected[i].balances, [5]string{
+			stat.Balances.Authorized.String(),
+			stat.Balances.AuthorizedToMaintainLiabilities.String(),
+			stat.Balances.ClaimableBalances.String(),
+			stat.Balances.LiquidityPools.String(),
+			stat.Balances.Unauthorized.String(),
+		})
+	}
+}
+
+func trustlineChange(pre, post *xdr.TrustLineEntry) ingest.Change {
+	c := ingest.Change{Type: xdr.LedgerEntryTypeTrustline}
+	if pre != nil {
+		c.Pre = &xdr.LedgerEntry{
+			Data: xdr.LedgerEntryData{
+				Type:      xdr.LedgerEntryTypeTrustline,
+				TrustLine: pre,
+			},
+		}
+	}
+	if post != nil {
+		c.Post = &xdr.LedgerEntry{
+			Data: xdr.LedgerEntryData{
+				Type:      xdr.LedgerEntryTypeTrustline,
+				TrustLine: post,
+			},
+		}
+	}
+	return c
+}
+
+func TestEmptyAssetStats(t *testing.T) {
+	assert.Empty(t, NewAssetStatsProcessor().Stats())
+}
+
+func TestAddNativeClaimableBalance(t *testing.T) {
+	p := NewAssetStatsProcessor()
+	claimableBalance := xdr.ClaimableBalanceEntry{
+		BalanceId: xdr.ClaimableBalanceId{},
+		Claimants: nil,
+		Asset:     xdr.MustNewNativeAsset(),
+		Amount:    100,
+	}
+	assert.NoError(t, p.ProcessChange(
+		ingest.Change{
+			Type: xdr.LedgerEntryTypeClaimableBalance,
+			Post: &xdr.LedgerEntry{
+				Data: xdr.LedgerEntryData{
+					Type:             xdr.LedgerEntryTypeClaimableBalance,
+					ClaimableBalance: &claimableBalance,
+				},
+			},
+		},
+	))
+	assert.Empty(t, p.Stats())
+}
+
+func TestAddPoolShareTrustline(t *testing.T) {
+	p := NewAssetStatsProcessor()
+	assert.NoError(
+		t,
+		p.ProcessChange(trustlineChange(nil, &xdr.TrustLineEntry{
+			AccountId: xdr.MustAddress("GAOQJGUAB7NI7K7I62ORBXMN3J4SSWQUQ7FOEPSDJ322W2HMCNWPHXFB"),
+			Asset: xdr.TrustLineAsset{
+				Type:            xdr.AssetTypeAssetTypePoolShare,
+				LiquidityPoolId: &xdr.PoolId{1, 2, 3},
+			},
+			Balance: 1,
+			Flags:   xdr.Uint32(xdr.TrustLineFlagsAuthorizedFlag),
+		},
+		)),
+	)
+	assert.Empty(t, p.Stats())
+}
+
+func TestAddAssetStats(t *testing.T) {
+	p := NewAssetStatsProcessor()
+	eur := "EUR"
+	eurAssetStat := expectedStat{
+		assetType: xdr.AssetTypeAssetTypeCreditAlphanum4,
+		assetCode: eur,
+		accounts:  AssetStatAccounts{Authorized: 1},
+		balances:  [5]string{"1", "0", "0", "0", "0"},
+	}
+
+	assert.NoError(
+		t,
+		p.ProcessChange(trustlineChange(nil, &xdr.TrustLineEntry{
+			AccountId: xdr.MustAddress("GAOQJGUAB7NI7K7I62ORBXMN3J4SSWQUQ7FOEPSDJ322W2HMCNWPHXFB"),
+			Asset:     xdr.MustNewCreditAsset(eur, trustLineIssuer.Address()).ToTrustLineAsset(),
+			Balance:   1,
+			Flags:     xdr.Uint32(xdr.TrustLineFlagsAuthorizedFlag),
+		},
+		)),
+	)
+	assertStats(t, p, []expectedStat{eurAssetStat})
+
+	eurAssetStat.accounts.ClaimableBalances++
+	eurAssetStat.balances[2] = "23"
+	eurAsset := xdr.MustNewCreditAsset(eur, trustLineIssuer.Address())
+	assert.NoError(
+		t,
+		p.addDelta(
+			eurAsset,
+			delta{ClaimableBalances: 23},
+			delta{ClaimableBalances: 1},
+		),
+	)
+	assertStats(t, p, []expectedStat{eurAssetStat})
+
+	assert.NoError(
+		t,
+		p.ProcessChange(trustlineChange(nil, &xdr.TrustLineEntry{
+			AccountId: xdr.MustAddress("GAOQJGUAB7NI7K7I62ORBXMN3J4SSWQUQ7FOEPSDJ322W2HMCNWPHXFB"),
+			Asset:     xdr.MustNewCreditAsset(eur, trustLineIssuer.Address()).ToTrustLineAsset(),
+			Balance:   24,
+			Flags:     xdr.Uint32(xdr.TrustLineFlagsAuthorizedFlag | xdr.TrustLineFlagsTrustlineClawbackEnabledFlag),
+		})),
+	)
+	eurAssetStat.balances[0] = "25"
+	eurAssetStat.accounts.Authorized++
+	assertStats(t, p, []expectedStat{eurAssetStat})
+
+	usd := "USD"
+	assert.NoError(
+		t,
+		p.ProcessChange(trustlineChange(nil, &xdr.TrustLineEntry{
+			AccountId: xdr.MustAddress("GC3C4AKRBQLHOJ45U4XG35ESVWRDECWO5XLDGYADO6DPR3L7KIDVUMML"),
+			Asset:     xdr.MustNewCreditAsset(usd, trustLineIssuer.Address()).ToTrustLineAsset(),
+			Balance:   10,
+			Flags:     xdr.Uint32(xdr.TrustLineFlagsAuthorizedFlag),
+		})),
+	)
+
+	ether := "ETHER"
+	assert.NoError(
+		t,
+		p.ProcessChange(trustlineChange(nil, &xdr.TrustLineEntry{
+			AccountId: xdr.MustAddress("GC3C4AKRBQLHOJ45U4XG35ESVWRDECWO5XLDGYADO6DPR3L7KIDVUMML"),
+			Asset:     xdr.MustNewCreditAsset(ether, trustLineIssuer.Address()).ToTrustLineAsset(),
+			Balance:   3,
+			Flags:     xdr.Uint32(xdr.TrustLineFlagsAuthorizedFlag),
+		})),
+	)
+
+	// add an authorized_to_maintain_liabilities trust line
+	assert.NoError(
+		t,
+		p.ProcessChange(trustlineChange(nil, &xdr.TrustLineEntry{
+			AccountId: xdr.MustAddress("GAOQJGUAB7NI7K7I62ORBXMN3J4SSWQUQ7FOEPSDJ322W2HMCNWPHXFB"),
+			Asset:     xdr.MustNewCreditAsset(ether, trustLineIssuer.Address()).ToTrustLineAsset(),
+			Balance:   4,
+			Flags:     xdr.Uint32(xdr.TrustLineFlagsAuthorizedToMaintainLiabilitiesFlag),
+		})),
+	)
+
+	// add an unauthorized trust line
+	assert.NoError(
+		t,
+		p.ProcessChange(trustlineChange(nil, &xdr.TrustLineEntry{
+			AccountId: xdr.MustAddress("GAOQJGUAB7NI7K7I62ORBXMN3J4SSWQUQ7FOEPSDJ322W2HMCNWPHXFB"),
+			Asset:     xdr.MustNewCreditAsset(ether, trustLineIssuer.Address()).ToTrustLineAsset(),
+			Balance:   5,
+		})),
+	)
+	assertStats(t, p, []expectedStat{
+		eurAssetStat,
+		{
+			assetType: xdr.AssetTypeAssetTypeCreditAlphanum4,
+			assetCode: usd,
+			accounts:  AssetStatAccounts{Authorized: 1},
+			balances:  [5]string{"10", "0", "0", "0", "0"},
+		},
+		{
+			assetType: xdr.AssetTypeAssetTypeCreditAlphanum12,
+			assetCode: ether,
+			accounts: AssetStatAccounts{
+				Authorized:                      1,
+				AuthorizedToMaintainLiabilities: 1,
+				Unauthorized:                    1,
+			},
+			balances: [5]string{"3", "4", "0", "0", "5"},
+		},
+	})
+
+	// removing the trust lines removes the stat
+	assert.NoError(
+		t,
+		p.ProcessChange(trustlineChange(&xdr.TrustLineEntry{
+			AccountId: xdr.MustAddress("GC3C4AKRBQLHOJ45U4XG35ESVWRDECWO5XLDGYADO6DPR3L7KIDVUMML"),
+			Asset:     xdr.MustNewCreditAsset(usd, trustLineIssuer.Address()).ToTrustLineAsset(),
+			Balance:   10,
+			Flags:     xdr.Uint32(xdr.TrustLineFlagsAuthorizedFlag),
+		}, nil)),
+	)
+	assert.Len(t, p.Stats(), 2)
+}
+
+func TestAddLiquidityPool(t *testing.T) {
+	p := NewAssetStatsProcessor()
+	usd := xdr.MustNewCreditAsset("USD", trustLineIssuer.Address())
+	pool := &xdr.LiquidityPoolEntry{
+		LiquidityPoolId: xdr.PoolId{1, 2, 3},
+		Body: xdr.LiquidityPoolEntryBody{
+			Type: xdr.LiquidityPoolTypeLiquidityPoolConstantProduct,
+			ConstantProduct: &xdr.LiquidityPoolEntryConstantProduct{
+				Params: xdr.LiquidityPoolConstantProductParameters{
+					AssetA: xdr.MustNewNativeAsset(),
+					AssetB: usd,
+					Fee:    30,
+				},
+				ReserveA: 100,
+				ReserveB: 200,
+			},
+		},
+	}
+	assert.NoError(t, p.ProcessChange(ingest.Change{
+		Type: xdr.LedgerEntryTypeLiquidityPool,
+		Post: &xdr.LedgerEntry{
+			Data: xdr.LedgerEntryData{
+				Type:          xdr.LedgerEntryTypeLiquidityPool,
+				LiquidityPool: pool,
+			},
+		},
+	}))
+	assertStats(t, p, []expectedStat{
+		{
+			assetType: xdr.AssetTypeAssetTypeCreditAlphanum4,
+			assetCode: "USD",
+			accounts:  AssetStatAccounts{LiquidityPools: 1},
+			balances:  [5]string{"0", "0", "0", "200", "0"},
+		},
+	})
+}
+
+func TestOverflowAssetStats(t *testing.T) {
+	p := NewAssetStatsProcessor()
+	eur := "EUR"
+	for i := 0; i < 2; i++ {
+		require.NoError(t, p.ProcessChange(trustlineChange(nil, &xdr.TrustLineEntry{
+			AccountId: xdr.MustAddress("GAOQJGUAB7NI7K7I62ORBXMN3J4SSWQUQ7FOEPSDJ322W2HMCNWPHXFB"),
+			Asset:     xdr.MustNewCreditAsset(eur, trustLineIssuer.Address()).ToTrustLineAsset(),
+			Balance:   math.MaxInt64,
+			Flags:     xdr.Uint32(xdr.TrustLineFlagsAuthorizedFlag),
+		})))
+	}
+	assertStats(t, p, []expectedStat{
+		{
+			assetType: xdr.AssetTypeAssetTypeCreditAlphanum4,
+			assetCode: eur,
+			accounts:  AssetStatAccounts{Authorized: 2},
+			balances:  [5]string{"18446744073709551614", "0", "0", "0", "0"},
+		},
+	})
+}
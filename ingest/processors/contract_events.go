@@ -0,0 +1,68 @@
+package processors
+
+import (
+	"github.com/shantanu-hashcash/go/ingest"
+	"github.com/shantanu-hashcash/go/strkey"
+	"github.com/shantanu-hashcash/go/support/contractevents"
+	"github.com/shantanu-hashcash/go/support/errors"
+	"github.com/shantanu-hashcash/go/xdr"
+)
+
+// ContractEvent is an event emitted by a Soroban transaction.
+type ContractEvent struct {
+	TransactionHash string
+	// Order is the position of the event within the transaction's events,
+	// starting at 1.
+	Order uint32
+	Type  xdr.ContractEventType
+	// ContractID is the strkey encoded ID of the contract which emitted the
+	// event, empty for events not emitted by a contract.
+	ContractID string
+	Topics     []xdr.ScVal
+	Data       xdr.ScVal
+	// InSuccessfulContractCall is false for diagnostic events of failed
+	// contract calls.
+	InSuccessfulContractCall bool
+	// AssetEvent is set when the event is a transfer, mint, clawback or burn
+	// emitted by a Hcnet Asset Contract.
+	AssetEvent contractevents.HcnetAssetContractEvent
+}
+
+// TransactionContractEvents returns the contract, system and diagnostic
+// events of a transaction. Events of transactions submitted with diagnostic
+// events disabled only include the events of successful contract calls.
+func TransactionContractEvents(transaction ingest.LedgerTransaction, network string) ([]ContractEvent, error) {
+	diagnosticEvents, err := transaction.GetDiagnosticEvents()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get transaction events")
+	}
+
+	hash := transaction.Result.TransactionHash.HexString()
+	result := make([]ContractEvent, 0, len(diagnosticEvents))
+	for i, diagnosticEvent := range diagnosticEvents {
+		event := diagnosticEvent.Event
+		row := ContractEvent{
+			TransactionHash:          hash,
+			Order:                    uint32(i + 1),
+			Type:                     event.Type,
+			InSuccessfulContractCall: diagnosticEvent.InSuccessfulContractCall,
+		}
+		if event.ContractId != nil {
+			row.ContractID, err = strkey.Encode(strkey.VersionByteContract, event.ContractId[:])
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not encode contract id of event %d", i)
+			}
+		}
+		if body, ok := event.Body.GetV0(); ok {
+			row.Topics = body.Topics
+			row.Data = body.Data
+		}
+		if diagnosticEvent.InSuccessfulContractCall {
+			if assetEvent, err := contractevents.NewHcnetAssetContractEvent(&event, network); err == nil {
+				row.AssetEvent = assetEvent
+			}
+		}
+		result = append(result, row)
+	}
+	return result, nil
+}
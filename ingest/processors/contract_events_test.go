@@ -0,0 +1,84 @@
+package processors
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shantanu-hashcash/go/ingest"
+	"github.com/shantanu-hashcash/go/strkey"
+	"github.com/shantanu-hashcash/go/support/contractevents"
+	"github.com/shantanu-hashcash/go/xdr"
+)
+
+func TestTransactionContractEvents(t *testing.T) {
+	from := "GDRW375MAYR46ODGF2WGANQC2RRZL7O246DYHHCGWTV2RE7IHE2QUQLD"
+	to := "GACAR2AEYEKITE2LKI5RMXF5MIVZ6Q7XILROGDT22O7JX4DSWFS7FDDP"
+	asset := xdr.MustNewCreditAsset("USD", to)
+	transfer := contractevents.GenerateEvent(
+		contractevents.EventTypeTransfer, from, to, "", asset, big.NewInt(100), networkPassphrase,
+	)
+
+	contractID := xdr.Hash{1, 2, 3}
+	custom := xdr.ContractEvent{
+		ContractId: &contractID,
+		Type:       xdr.ContractEventTypeContract,
+		Body: xdr.ContractEventBody{
+			V: 0,
+			V0: &xdr.ContractEventV0{
+				Topics: []xdr.ScVal{{Type: xdr.ScValTypeScvBool}},
+				Data:   xdr.ScVal{Type: xdr.ScValTypeScvVoid},
+			},
+		},
+	}
+
+	transaction := ingest.LedgerTransaction{
+		Result: xdr.TransactionResultPair{TransactionHash: xdr.Hash{0xff}},
+		UnsafeMeta: xdr.TransactionMeta{
+			V: 3,
+			V3: &xdr.TransactionMetaV3{
+				SorobanMeta: &xdr.SorobanTransactionMeta{
+					Events: []xdr.ContractEvent{transfer, custom},
+				},
+			},
+		},
+	}
+
+	events, err := TransactionContractEvents(transaction, networkPassphrase)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	transferEvent := events[0]
+	assert.Equal(t, xdr.Hash{0xff}.HexString(), transferEvent.TransactionHash)
+	assert.Equal(t, uint32(1), transferEvent.Order)
+	assert.Equal(t, xdr.ContractEventTypeContract, transferEvent.Type)
+	assert.Equal(t, transfer.Body.V0.Topics, transferEvent.Topics)
+	assert.True(t, transferEvent.InSuccessfulContractCall)
+	require.NotNil(t, transferEvent.AssetEvent)
+	assert.Equal(t, contractevents.EventTypeTransfer, transferEvent.AssetEvent.GetType())
+	assert.True(t, asset.Equals(transferEvent.AssetEvent.GetAsset()))
+	parsed, ok := transferEvent.AssetEvent.(*contractevents.TransferEvent)
+	require.True(t, ok)
+	assert.Equal(t, from, parsed.From)
+	assert.Equal(t, to, parsed.To)
+
+	customEvent := events[1]
+	assert.Equal(t, uint32(2), customEvent.Order)
+	expectedID, err := strkey.Encode(strkey.VersionByteContract, contractID[:])
+	require.NoError(t, err)
+	assert.Equal(t, expectedID, customEvent.ContractID)
+	assert.Equal(t, custom.Body.V0.Topics, customEvent.Topics)
+	assert.Equal(t, custom.Body.V0.Data, customEvent.Data)
+	assert.Nil(t, customEvent.AssetEvent)
+}
+
+func TestTransactionContractEventsWithoutSorobanMeta(t *testing.T) {
+	transaction := ingest.LedgerTransaction{
+		UnsafeMeta: xdr.TransactionMeta{V: 2, V2: &xdr.TransactionMetaV2{}},
+	}
+	events, err := TransactionContractEvents(transaction, networkPassphrase)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
@@ -0,0 +1,35 @@
+/*
+Package processors extracts the history of the Hcnet network from the
+transactions and changes read with the ingest package: operations, effects,
+trades, contract events and asset stats.
+
+The results are plain structs which do not depend on a database, so they can
+be stored by any ingestion pipeline. For example, to read the operations and
+effects of the transactions of a ledger:
+
+	reader, err := ingest.NewLedgerTransactionReaderFromLedgerCloseMeta(passphrase, lcm)
+	if err != nil {
+		return err
+	}
+	for {
+		tx, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		operations, err := processors.TransactionOperations(lcm, tx, passphrase)
+		if err != nil {
+			return err
+		}
+		effects, err := processors.TransactionEffects(lcm, tx, passphrase)
+		if err != nil {
+			return err
+		}
+		...
+	}
+
+Aurora ingests its history from this package, so the operation details and
+effect types match the ones it serves.
+*/
+package processors
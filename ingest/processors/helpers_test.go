@@ -0,0 +1,139 @@
+package processors
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shantanu-hashcash/go/ingest"
+	"github.com/shantanu-hashcash/go/xdr"
+)
+
+const (
+	networkPassphrase = "Arbitrary Testing Passphrase"
+)
+
+// TestTransaction holds the base64 XDR of a transaction.
+type TestTransaction struct {
+	Index         uint32
+	EnvelopeXDR   string
+	ResultXDR     string
+	FeeChangesXDR string
+	MetaXDR       string
+	Hash          string
+}
+
+// BuildLedgerTransaction builds a ledger transaction from its XDR.
+func BuildLedgerTransaction(t *testing.T, tx TestTransaction) ingest.LedgerTransaction {
+	transaction := ingest.LedgerTransaction{
+		Index:      tx.Index,
+		Envelope:   xdr.TransactionEnvelope{},
+		Result:     xdr.TransactionResultPair{},
+		FeeChanges: xdr.LedgerEntryChanges{},
+		UnsafeMeta: xdr.TransactionMeta{},
+	}
+
+	tt := assert.New(t)
+
+	err := xdr.SafeUnmarshalBase64(tx.EnvelopeXDR, &transaction.Envelope)
+	tt.NoError(err)
+	err = xdr.SafeUnmarshalBase64(tx.ResultXDR, &transaction.Result.Result)
+	tt.NoError(err)
+	err = xdr.SafeUnmarshalBase64(tx.MetaXDR, &transaction.UnsafeMeta)
+	tt.NoError(err)
+	err = xdr.SafeUnmarshalBase64(tx.FeeChangesXDR, &transaction.FeeChanges)
+	tt.NoError(err)
+
+	_, err = hex.Decode(transaction.Result.TransactionHash[:], []byte(tx.Hash))
+	tt.NoError(err)
+
+	return transaction
+}
+
+func createTransactionMeta(opMeta []xdr.OperationMeta) xdr.TransactionMeta {
+	return xdr.TransactionMeta{
+		V: 1,
+		V1: &xdr.TransactionMetaV1{
+			Operations: opMeta,
+		},
+	}
+}
+
+func createTransaction(successful bool, numOps int, metaVer int32) ingest.LedgerTransaction {
+	code := xdr.TransactionResultCodeTxSuccess
+	if !successful {
+		code = xdr.TransactionResultCodeTxFailed
+	}
+
+	operations := []xdr.Operation{}
+	op := xdr.BumpSequenceOp{BumpTo: 30000}
+	for i := 0; i < numOps; i++ {
+		operations = append(operations, xdr.Operation{
+			Body: xdr.OperationBody{
+				Type:           xdr.OperationTypeBumpSequence,
+				BumpSequenceOp: &op,
+			},
+		})
+	}
+	sourceAID := xdr.MustAddress("GAUJETIZVEP2NRYLUESJ3LS66NVCEGMON4UDCBCSBEVPIID773P2W6AY")
+
+	var txMeta xdr.TransactionMeta
+	switch metaVer {
+	case 3:
+		txMeta = xdr.TransactionMeta{
+			V: 3,
+			V3: &xdr.TransactionMetaV3{
+				Operations: make([]xdr.OperationMeta, numOps, numOps),
+			},
+		}
+	case 2:
+		txMeta = xdr.TransactionMeta{
+			V: 2,
+			V2: &xdr.TransactionMetaV2{
+				Operations: make([]xdr.OperationMeta, numOps, numOps),
+			},
+		}
+	case 1:
+		txMeta = xdr.TransactionMeta{
+			V: 1,
+			V1: &xdr.TransactionMetaV1{
+				Operations: make([]xdr.OperationMeta, numOps, numOps),
+			},
+		}
+	}
+
+	return ingest.LedgerTransaction{
+		Result: xdr.TransactionResultPair{
+			TransactionHash: xdr.Hash{},
+			Result: xdr.TransactionResult{
+				Result: xdr.TransactionResultResult{
+					Code:            code,
+					InnerResultPair: &xdr.InnerTransactionResultPair{},
+					Results:         &[]xdr.OperationResult{},
+				},
+			},
+		},
+		Envelope: xdr.TransactionEnvelope{
+			Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+			V1: &xdr.TransactionV1Envelope{
+				Tx: xdr.Transaction{
+					SourceAccount: sourceAID.ToMuxedAccount(),
+					Operations:    operations,
+				},
+			},
+		},
+		UnsafeMeta: txMeta,
+	}
+}
+
+func ledgerWithSequence(sequence uint32) xdr.LedgerCloseMeta {
+	return xdr.LedgerCloseMeta{
+		V: 0,
+		V0: &xdr.LedgerCloseMetaV0{
+			LedgerHeader: xdr.LedgerHeaderHistoryEntry{
+				Header: xdr.LedgerHeader{LedgerSeq: xdr.Uint32(sequence)},
+			},
+		},
+	}
+}
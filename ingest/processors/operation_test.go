@@ -3,16 +3,19 @@
 package processors
 
 import (
+	"encoding/base64"
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 
-	"github.com/shantanu-hashcash/go/protocols/aurora/base"
-
 	"github.com/shantanu-hashcash/go/ingest"
-	"github.com/shantanu-hashcash/go/services/aurora/internal/db2/history"
-	. "github.com/shantanu-hashcash/go/services/aurora/internal/test/transactions"
+	"github.com/shantanu-hashcash/go/keypair"
+	protocol "github.com/shantanu-hashcash/go/protocols/aurora"
+	"github.com/shantanu-hashcash/go/protocols/aurora/base"
+	"github.com/shantanu-hashcash/go/strkey"
+	"github.com/shantanu-hashcash/go/support/contractevents"
 	"github.com/shantanu-hashcash/go/xdr"
 )
 
@@ -1100,17 +1103,21 @@ func TestOperationParticipants(t *testing.T) {
 		},
 	)
 
-	expectedParticipants := []xdr.AccountId{
-		xdr.MustAddress("GDRW375MAYR46ODGF2WGANQC2RRZL7O246DYHHCGWTV2RE7IHE2QUQLD"),
-		xdr.MustAddress("GACAR2AEYEKITE2LKI5RMXF5MIVZ6Q7XILROGDT22O7JX4DSWFS7FDDP"),
+	expectedParticipants := []string{
+		"GDRW375MAYR46ODGF2WGANQC2RRZL7O246DYHHCGWTV2RE7IHE2QUQLD",
+		"GACAR2AEYEKITE2LKI5RMXF5MIVZ6Q7XILROGDT22O7JX4DSWFS7FDDP",
 	}
-	participantsMap, err := operationsParticipants(transaction, sequence)
+	operations, err := TransactionOperations(ledgerWithSequence(sequence), transaction, networkPassphrase)
 	tt.NoError(err)
-	tt.Len(participantsMap, 1)
-	for k, v := range participantsMap {
-		tt.Equal(int64(240518172673), k)
-		tt.ElementsMatch(expectedParticipants, v)
-	}
+	tt.Len(operations, 1)
+	tt.Equal(int64(240518172673), operations[0].ID)
+	tt.Equal(int64(240518172672), operations[0].TransactionID)
+	tt.Equal(uint32(1), operations[0].ApplicationOrder)
+	tt.Equal(xdr.OperationTypePathPaymentStrictReceive, operations[0].Type)
+	tt.Equal("GDRW375MAYR46ODGF2WGANQC2RRZL7O246DYHHCGWTV2RE7IHE2QUQLD", operations[0].SourceAccount)
+	tt.Empty(operations[0].SourceAccountMuxed)
+	tt.True(operations[0].IsPayment)
+	tt.ElementsMatch(expectedParticipants, operations[0].Participants)
 }
 
 func TestTransactionOperationAllowTrustDetails(t *testing.T) {
@@ -1292,8 +1299,8 @@ func (s *CreateClaimableBalanceOpTestSuite) TestDetails() {
 			expected: map[string]interface{}{
 				"asset":  "native",
 				"amount": "10.0000000",
-				"claimants": history.Claimants{
-					history.Claimant{
+				"claimants": []protocol.Claimant{
+					protocol.Claimant{
 						Destination: "GD5OVB6FKDV7P7SOJ5UB2BPLBL4XGSHPYHINR5355SY3RSXLT2BZWAKY",
 						Predicate: xdr.ClaimPredicate{
 							Type: xdr.ClaimPredicateTypeClaimPredicateUnconditional,
@@ -1308,14 +1315,14 @@ func (s *CreateClaimableBalanceOpTestSuite) TestDetails() {
 			expected: map[string]interface{}{
 				"asset":  "USD:GDRW375MAYR46ODGF2WGANQC2RRZL7O246DYHHCGWTV2RE7IHE2QUQLD",
 				"amount": "20.0000000",
-				"claimants": history.Claimants{
-					history.Claimant{
+				"claimants": []protocol.Claimant{
+					protocol.Claimant{
 						Destination: "GDMQUXK7ZUCWM5472ZU3YLDP4BMJLQQ76DEMNYDEY2ODEEGGRKLEWGW2",
 						Predicate: xdr.ClaimPredicate{
 							Type: xdr.ClaimPredicateTypeClaimPredicateUnconditional,
 						},
 					},
-					history.Claimant{
+					protocol.Claimant{
 						Destination: "GDQNY3PBOJOKYZSRMK2S7LHHGWZIUISD4QORETLMXEWXBI7KFZZMKTL3",
 						Predicate: xdr.ClaimPredicate{
 							Type: xdr.ClaimPredicateTypeClaimPredicateUnconditional,
@@ -2288,3 +2295,301 @@ func TestDetailsCoversAllOperationTypes(t *testing.T) {
 	}
 	assert.PanicsWithError(t, "unknown operation type: ", f)
 }
+
+func TestOperationTypeInvokeHostFunctionDetails(t *testing.T) {
+	tt := assert.New(t)
+	sourceAddress := "GAUJETIZVEP2NRYLUESJ3LS66NVCEGMON4UDCBCSBEVPIID773P2W6AY"
+	source := xdr.MustMuxedAddress(sourceAddress)
+
+	contractParamVal0 := xdr.ScAddress{
+		Type:       xdr.ScAddressTypeScAddressTypeContract,
+		ContractId: &xdr.Hash{0x1, 0x2},
+	}
+	contractParamVal1 := xdr.ScSymbol("func1")
+	contractParamVal2 := xdr.Int32(-5)
+	contractParamVal3 := xdr.Uint32(6)
+	contractParamVal4 := xdr.Uint64(3)
+	contractParamVal5 := xdr.ScBytes([]byte{0, 1, 2})
+	contractParamVal6 := true
+
+	accountId := xdr.MustAddress("GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H")
+	wasm := []byte("Some contract code")
+
+	tx := ingest.LedgerTransaction{
+		UnsafeMeta: xdr.TransactionMeta{
+			V:  2,
+			V2: &xdr.TransactionMetaV2{},
+		},
+	}
+
+	t.Run("InvokeContract", func(t *testing.T) {
+		wrapper := transactionOperationWrapper{
+			transaction: tx,
+			operation: xdr.Operation{
+				SourceAccount: &source,
+				Body: xdr.OperationBody{
+					Type: xdr.OperationTypeInvokeHostFunction,
+					InvokeHostFunctionOp: &xdr.InvokeHostFunctionOp{
+						HostFunction: xdr.HostFunction{
+							Type: xdr.HostFunctionTypeHostFunctionTypeInvokeContract,
+							InvokeContract: &xdr.InvokeContractArgs{
+								ContractAddress: contractParamVal0,
+								FunctionName:    contractParamVal1,
+								Args: xdr.ScVec{
+									{
+										Type: xdr.ScValTypeScvI32,
+										I32:  &contractParamVal2,
+									},
+									{
+										Type: xdr.ScValTypeScvU32,
+										U32:  &contractParamVal3,
+									},
+									{
+										Type: xdr.ScValTypeScvU64,
+										U64:  &contractParamVal4,
+									},
+									{
+										Type:  xdr.ScValTypeScvBytes,
+										Bytes: &contractParamVal5,
+									},
+									{
+										Type: xdr.ScValTypeScvBool,
+										B:    &contractParamVal6,
+									},
+									{
+										// invalid ScVal
+										Type: 5555,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		details, err := wrapper.Details()
+		tt.NoError(err)
+
+		args := []xdr.ScVal{
+			{
+				Type:    xdr.ScValTypeScvAddress,
+				Address: &contractParamVal0,
+			},
+			{
+				Type: xdr.ScValTypeScvSymbol,
+				Sym:  &contractParamVal1,
+			},
+		}
+		args = append(args, wrapper.operation.Body.InvokeHostFunctionOp.HostFunction.InvokeContract.Args...)
+		detailsFunctionParams := details["parameters"].([]map[string]string)
+		tt.Equal(details["function"], "HostFunctionTypeHostFunctionTypeInvokeContract")
+		assertInvokeHostFunctionParameter(tt, detailsFunctionParams, 0, "Address", args[0])
+		assertInvokeHostFunctionParameter(tt, detailsFunctionParams, 1, "Sym", args[1])
+		assertInvokeHostFunctionParameter(tt, detailsFunctionParams, 2, "I32", args[2])
+		assertInvokeHostFunctionParameter(tt, detailsFunctionParams, 3, "U32", args[3])
+		assertInvokeHostFunctionParameter(tt, detailsFunctionParams, 4, "U64", args[4])
+		assertInvokeHostFunctionParameter(tt, detailsFunctionParams, 5, "Bytes", args[5])
+		assertInvokeHostFunctionParameter(tt, detailsFunctionParams, 6, "B", args[6])
+		assertInvokeHostFunctionParameter(tt, detailsFunctionParams, 7, "n/a", args[7])
+	})
+
+	t.Run("CreateContractFromAsset", func(t *testing.T) {
+		wrapper := transactionOperationWrapper{
+			transaction: tx,
+			operation: xdr.Operation{
+				SourceAccount: &source,
+				Body: xdr.OperationBody{
+					Type: xdr.OperationTypeInvokeHostFunction,
+					InvokeHostFunctionOp: &xdr.InvokeHostFunctionOp{
+						HostFunction: xdr.HostFunction{
+							Type: xdr.HostFunctionTypeHostFunctionTypeCreateContract,
+							CreateContract: &xdr.CreateContractArgs{
+								ContractIdPreimage: xdr.ContractIdPreimage{
+									Type: xdr.ContractIdPreimageTypeContractIdPreimageFromAsset,
+									FromAsset: &xdr.Asset{
+										Type: 1,
+										AlphaNum4: &xdr.AlphaNum4{
+											AssetCode: xdr.AssetCode4{65, 82, 83, 0},
+											Issuer:    xdr.MustAddress("GCXI6Q73J7F6EUSBZTPW4G4OUGVDHABPYF2U4KO7MVEX52OH5VMVUCRF"),
+										},
+									},
+								},
+								Executable: xdr.ContractExecutable{
+									Type: xdr.ContractExecutableTypeContractExecutableHcnetAsset,
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		details, err := wrapper.Details()
+		tt.NoError(err)
+
+		tt.Equal(details["function"], "HostFunctionTypeHostFunctionTypeCreateContract")
+		tt.Equal(details["from"], "asset")
+		tt.Equal(details["asset"], "ARS:GCXI6Q73J7F6EUSBZTPW4G4OUGVDHABPYF2U4KO7MVEX52OH5VMVUCRF")
+	})
+
+	t.Run("CreateContractFromAddress", func(t *testing.T) {
+		wrapper := transactionOperationWrapper{
+			transaction: tx,
+			operation: xdr.Operation{
+				SourceAccount: &source,
+				Body: xdr.OperationBody{
+					Type: xdr.OperationTypeInvokeHostFunction,
+					InvokeHostFunctionOp: &xdr.InvokeHostFunctionOp{
+						HostFunction: xdr.HostFunction{
+							Type: xdr.HostFunctionTypeHostFunctionTypeCreateContract,
+							CreateContract: &xdr.CreateContractArgs{
+								ContractIdPreimage: xdr.ContractIdPreimage{
+									Type: xdr.ContractIdPreimageTypeContractIdPreimageFromAddress,
+									FromAddress: &xdr.ContractIdPreimageFromAddress{
+										Address: xdr.ScAddress{
+											Type:      xdr.ScAddressTypeScAddressTypeAccount,
+											AccountId: &accountId,
+										},
+										Salt: xdr.Uint256{1},
+									},
+								},
+								Executable: xdr.ContractExecutable{},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		details, err := wrapper.Details()
+		tt.NoError(err)
+
+		tt.Equal(details["function"], "HostFunctionTypeHostFunctionTypeCreateContract")
+		tt.Equal(details["from"], "address")
+		tt.Equal(details["address"], "GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H")
+		tt.Equal(details["salt"], xdr.Uint256{1}.String())
+	})
+
+	t.Run("UploadContractWasm", func(t *testing.T) {
+		wrapper := transactionOperationWrapper{
+			transaction: tx,
+			operation: xdr.Operation{
+				SourceAccount: &source,
+				Body: xdr.OperationBody{
+					Type: xdr.OperationTypeInvokeHostFunction,
+					InvokeHostFunctionOp: &xdr.InvokeHostFunctionOp{
+						HostFunction: xdr.HostFunction{
+							Type: xdr.HostFunctionTypeHostFunctionTypeUploadContractWasm,
+							Wasm: &wasm,
+						},
+					},
+				},
+			},
+		}
+
+		details, err := wrapper.Details()
+		tt.NoError(err)
+		tt.Equal(details["function"], "HostFunctionTypeHostFunctionTypeUploadContractWasm")
+	})
+
+	t.Run("InvokeContractWithSACEventsInDetails", func(t *testing.T) {
+		randomIssuer := keypair.MustRandom()
+		randomAsset := xdr.MustNewCreditAsset("TESTING", randomIssuer.Address())
+		passphrase := "passphrase"
+		randomAccount := keypair.MustRandom().Address()
+		contractId := [32]byte{}
+		zeroContractStrKey, err := strkey.Encode(strkey.VersionByteContract, contractId[:])
+		tt.NoError(err)
+
+		transferContractEvent := contractevents.GenerateEvent(contractevents.EventTypeTransfer, randomAccount, zeroContractStrKey, "", randomAsset, big.NewInt(10000000), passphrase)
+		burnContractEvent := contractevents.GenerateEvent(contractevents.EventTypeBurn, zeroContractStrKey, "", "", randomAsset, big.NewInt(10000000), passphrase)
+		mintContractEvent := contractevents.GenerateEvent(contractevents.EventTypeMint, "", zeroContractStrKey, randomAccount, randomAsset, big.NewInt(10000000), passphrase)
+		clawbackContractEvent := contractevents.GenerateEvent(contractevents.EventTypeClawback, zeroContractStrKey, "", randomAccount, randomAsset, big.NewInt(10000000), passphrase)
+
+		tx = ingest.LedgerTransaction{
+			UnsafeMeta: xdr.TransactionMeta{
+				V: 3,
+				V3: &xdr.TransactionMetaV3{
+					SorobanMeta: &xdr.SorobanTransactionMeta{
+						Events: []xdr.ContractEvent{
+							transferContractEvent,
+							burnContractEvent,
+							mintContractEvent,
+							clawbackContractEvent,
+						},
+					},
+				},
+			},
+		}
+		wrapper := transactionOperationWrapper{
+			transaction: tx,
+			operation: xdr.Operation{
+				SourceAccount: &source,
+				Body: xdr.OperationBody{
+					Type: xdr.OperationTypeInvokeHostFunction,
+					InvokeHostFunctionOp: &xdr.InvokeHostFunctionOp{
+						HostFunction: xdr.HostFunction{
+							Type: xdr.HostFunctionTypeHostFunctionTypeInvokeContract,
+							InvokeContract: &xdr.InvokeContractArgs{
+								ContractAddress: xdr.ScAddress{
+									Type:       xdr.ScAddressTypeScAddressTypeContract,
+									ContractId: &xdr.Hash{0x1, 0x2},
+								},
+								FunctionName: "foo",
+								Args:         xdr.ScVec{},
+							},
+						},
+					},
+				},
+			},
+			network: passphrase,
+		}
+
+		details, err := wrapper.Details()
+		tt.NoError(err)
+		tt.Len(details["asset_balance_changes"], 4)
+
+		found := 0
+		for _, assetBalanceChanged := range details["asset_balance_changes"].([]map[string]interface{}) {
+			if assetBalanceChanged["type"] == "transfer" {
+				tt.Equal(assetBalanceChanged["from"], randomAccount)
+				tt.Equal(assetBalanceChanged["to"], zeroContractStrKey)
+				tt.Equal(assetBalanceChanged["amount"], "1.0000000")
+				found++
+			}
+
+			if assetBalanceChanged["type"] == "burn" {
+				tt.Equal(assetBalanceChanged["from"], zeroContractStrKey)
+				tt.NotContains(assetBalanceChanged, "to")
+				tt.Equal(assetBalanceChanged["amount"], "1.0000000")
+				found++
+			}
+
+			if assetBalanceChanged["type"] == "mint" {
+				tt.NotContains(assetBalanceChanged, "from")
+				tt.Equal(assetBalanceChanged["to"], zeroContractStrKey)
+				tt.Equal(assetBalanceChanged["amount"], "1.0000000")
+				found++
+			}
+
+			if assetBalanceChanged["type"] == "clawback" {
+				tt.Equal(assetBalanceChanged["from"], zeroContractStrKey)
+				tt.NotContains(assetBalanceChanged, "to")
+				tt.Equal(assetBalanceChanged["amount"], "1.0000000")
+				found++
+			}
+		}
+		tt.Equal(found, 4, "should have one balance changed record for each of mint, burn, clawback, transfer")
+	})
+}
+
+func assertInvokeHostFunctionParameter(tt *assert.Assertions, parameters []map[string]string, paramPosition int, expectedType string, expectedVal xdr.ScVal) {
+	serializedParam := parameters[paramPosition]
+	tt.Equal(serializedParam["type"], expectedType)
+	if expectedSerializedXdr, err := expectedVal.MarshalBinary(); err == nil {
+		tt.Equal(serializedParam["value"], base64.StdEncoding.EncodeToString(expectedSerializedXdr))
+	} else {
+		tt.Equal(serializedParam["value"], "n/a")
+	}
+}
@@ -0,0 +1,367 @@
+package processors
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/shantanu-hashcash/go/exp/orderbook"
+	"github.com/shantanu-hashcash/go/ingest"
+	"github.com/shantanu-hashcash/go/support/errors"
+	"github.com/shantanu-hashcash/go/toid"
+	"github.com/shantanu-hashcash/go/xdr"
+)
+
+// TradeType is the type of the seller side of a trade.
+type TradeType int16
+
+const (
+	// OrderbookTradeType is a trade against an offer of the order book.
+	OrderbookTradeType TradeType = 1
+	// LiquidityPoolTradeType is a trade against a liquidity pool.
+	LiquidityPoolTradeType TradeType = 2
+)
+
+// Trade is a trade executed by an operation. The seller is the offer or
+// liquidity pool which was crossed, the buyer is the source account of the
+// operation.
+type Trade struct {
+	OperationID int64
+	// Order is the position of the trade within the operation's trades.
+	Order           int32
+	LedgerCloseTime time.Time
+	Type            TradeType
+
+	// SellerAccount and SellerOfferID are set for order book trades.
+	SellerAccount string
+	SellerOfferID int64
+	// LiquidityPoolID and LiquidityPoolFee are set for liquidity pool
+	// trades.
+	LiquidityPoolID  string
+	LiquidityPoolFee uint32
+	SoldAsset        xdr.Asset
+	SoldAmount       int64
+
+	BuyerAccount string
+	// BuyerOfferID is the ID of the offer created by the operation, or a
+	// synthetic ID derived from the operation ID (see EncodeOfferId) if the
+	// offer was filled immediately or the operation is a path payment.
+	BuyerOfferID int64
+	BoughtAsset  xdr.Asset
+	BoughtAmount int64
+
+	// PriceN and PriceD are the price of the sold asset in terms of the
+	// bought asset.
+	PriceN int64
+	PriceD int64
+	// SellerIsExact is set for path payments: the sold amount is exact for
+	// strict receive payments and the bought amount for strict send ones.
+	SellerIsExact *bool
+	// RoundingSlippage is the slippage in basis points caused by rounding of
+	// path payments crossing liquidity pools.
+	RoundingSlippage *int64
+}
+
+// TransactionTrades returns the trades executed by the operations of a
+// transaction of the given ledger. Failed transactions have no trades.
+func TransactionTrades(lcm xdr.LedgerCloseMeta, transaction ingest.LedgerTransaction) ([]Trade, error) {
+	if !transaction.Result.Successful() {
+		return nil, nil
+	}
+
+	var result []Trade
+	ledger := lcm.LedgerHeaderHistoryEntry()
+	closeTime := time.Unix(int64(ledger.Header.ScpValue.CloseTime), 0).UTC()
+
+	opResults, ok := transaction.Result.OperationResults()
+	if !ok {
+		return result, errors.New("transaction has no operation results")
+	}
+	for opidx, op := range transaction.Envelope.Operations() {
+		var trades []xdr.ClaimAtom
+		var buyOfferExists bool
+		var buyOffer xdr.OfferEntry
+
+		switch op.Body.Type {
+		case xdr.OperationTypePathPaymentStrictReceive:
+			trades = opResults[opidx].MustTr().MustPathPaymentStrictReceiveResult().
+				MustSuccess().
+				Offers
+
+		case xdr.OperationTypePathPaymentStrictSend:
+			trades = opResults[opidx].MustTr().
+				MustPathPaymentStrictSendResult().
+				MustSuccess().
+				Offers
+
+		case xdr.OperationTypeManageBuyOffer:
+			manageOfferResult := opResults[opidx].MustTr().MustManageBuyOfferResult().
+				MustSuccess()
+			trades = manageOfferResult.OffersClaimed
+			buyOffer, buyOfferExists = manageOfferResult.Offer.GetOffer()
+
+		case xdr.OperationTypeManageSellOffer:
+			manageOfferResult := opResults[opidx].MustTr().MustManageSellOfferResult().
+				MustSuccess()
+			trades = manageOfferResult.OffersClaimed
+			buyOffer, buyOfferExists = manageOfferResult.Offer.GetOffer()
+
+		case xdr.OperationTypeCreatePassiveSellOffer:
+			result := opResults[opidx].MustTr()
+
+			// KNOWN ISSUE:  hcnet-core creates results for CreatePassiveOffer operations
+			// with the wrong result arm set.
+			if result.Type == xdr.OperationTypeManageSellOffer {
+				manageOfferResult := result.MustManageSellOfferResult().MustSuccess()
+				trades = manageOfferResult.OffersClaimed
+				buyOffer, buyOfferExists = manageOfferResult.Offer.GetOffer()
+			} else {
+				passiveOfferResult := result.MustCreatePassiveSellOfferResult().MustSuccess()
+				trades = passiveOfferResult.OffersClaimed
+				buyOffer, buyOfferExists = passiveOfferResult.Offer.GetOffer()
+			}
+		}
+
+		opID := toid.New(
+			int32(ledger.Header.LedgerSeq), int32(transaction.Index), int32(opidx+1),
+		).ToInt64()
+		for order, trade := range trades {
+			// hcnet-core will opportunistically garbage collect invalid offers (in the
+			// event that a trader spends down their balance).  These garbage collected
+			// offers get emitted in the result with the amount values set to zero.
+			//
+			// These zeroed ClaimOfferAtom values do not represent trades, and so we
+			// skip them.
+			if trade.AmountBought() == 0 && trade.AmountSold() == 0 {
+				continue
+			}
+
+			sellPriceN, sellPriceD, err := findTradeSellPrice(transaction, opidx, trade)
+			if err != nil {
+				return result, err
+			}
+
+			row := Trade{
+				OperationID:     opID,
+				Order:           int32(order),
+				LedgerCloseTime: closeTime,
+				SoldAsset:       trade.AssetSold(),
+				SoldAmount:      int64(trade.AmountSold()),
+				BoughtAsset:     trade.AssetBought(),
+				BoughtAmount:    int64(trade.AmountBought()),
+				PriceN:          sellPriceN,
+				PriceD:          sellPriceD,
+			}
+
+			switch op.Body.Type {
+			case xdr.OperationTypePathPaymentStrictSend:
+				exact := false
+				row.SellerIsExact = &exact
+			case xdr.OperationTypePathPaymentStrictReceive:
+				exact := true
+				row.SellerIsExact = &exact
+			}
+
+			if trade.Type == xdr.ClaimAtomTypeClaimAtomTypeLiquidityPool {
+				id := trade.MustLiquidityPool().LiquidityPoolId
+				row.LiquidityPoolID = PoolIDToString(id)
+				if row.LiquidityPoolFee, err = findPoolFee(transaction, opidx, id); err != nil {
+					return nil, err
+				}
+				row.Type = LiquidityPoolTradeType
+
+				change, err := liquidityPoolChange(transaction, opidx, trade)
+				if err != nil {
+					return nil, err
+				}
+				if change != nil {
+					row.RoundingSlippage, err = roundingSlippage(transaction, opidx, trade, change)
+					if err != nil {
+						return nil, err
+					}
+				}
+			} else {
+				row.SellerOfferID = int64(trade.OfferId())
+				row.SellerAccount = trade.SellerId().Address()
+				row.Type = OrderbookTradeType
+			}
+
+			if buyOfferExists {
+				row.BuyerOfferID = EncodeOfferId(uint64(buyOffer.OfferId), CoreOfferIDType)
+			} else {
+				row.BuyerOfferID = EncodeOfferId(uint64(opID), TOIDType)
+			}
+
+			if buyer := op.SourceAccount; buyer != nil {
+				accid := buyer.ToAccountId()
+				row.BuyerAccount = accid.Address()
+			} else {
+				sa := transaction.Envelope.SourceAccount().ToAccountId()
+				row.BuyerAccount = sa.Address()
+			}
+
+			result = append(result, row)
+		}
+	}
+
+	return result, nil
+}
+
+// PoolIDToString returns the hex encoding of a liquidity pool ID.
+func PoolIDToString(id xdr.PoolId) string {
+	return xdr.Hash(id).HexString()
+}
+
+func findTradeSellPrice(
+	transaction ingest.LedgerTransaction,
+	opidx int,
+	trade xdr.ClaimAtom,
+) (int64, int64, error) {
+	if trade.Type == xdr.ClaimAtomTypeClaimAtomTypeLiquidityPool {
+		return int64(trade.AmountBought()), int64(trade.AmountSold()), nil
+	}
+
+	key := xdr.LedgerKey{}
+	if err := key.SetOffer(trade.SellerId(), uint64(trade.OfferId())); err != nil {
+		return 0, 0, errors.Wrap(err, "Could not create offer ledger key")
+	}
+
+	change, err := findOperationChange(transaction, opidx, key)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "could not find change for trade offer")
+	}
+
+	return int64(change.Pre.Data.Offer.Price.N), int64(change.Pre.Data.Offer.Price.D), nil
+}
+
+func findOperationChange(tx ingest.LedgerTransaction, opidx int, key xdr.LedgerKey) (ingest.Change, error) {
+	changes, err := tx.GetOperationChanges(uint32(opidx))
+	if err != nil {
+		return ingest.Change{}, errors.Wrap(err, "could not determine changes for operation")
+	}
+
+	var change ingest.Change
+	for i := len(changes) - 1; i >= 0; i-- {
+		change = changes[i]
+		if change.Pre != nil {
+			preKey, err := change.Pre.LedgerKey()
+			if err != nil {
+				return ingest.Change{}, errors.Wrap(err, "could not determine ledger key for change")
+			}
+			if key.Equals(preKey) {
+				return change, nil
+			}
+		}
+	}
+	return ingest.Change{}, errors.Errorf("could not find operation for key %v", key)
+}
+
+func liquidityPoolChange(
+	transaction ingest.LedgerTransaction,
+	opidx int,
+	trade xdr.ClaimAtom,
+) (*ingest.Change, error) {
+	if trade.Type != xdr.ClaimAtomTypeClaimAtomTypeLiquidityPool {
+		return nil, nil
+	}
+
+	poolID := trade.LiquidityPool.LiquidityPoolId
+
+	key := xdr.LedgerKey{}
+	if err := key.SetLiquidityPool(poolID); err != nil {
+		return nil, errors.Wrap(err, "Could not create liquidity pool ledger key")
+	}
+
+	change, err := findOperationChange(transaction, opidx, key)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not find change for liquidity pool")
+	}
+	return &change, nil
+}
+
+func liquidityPoolReserves(trade xdr.ClaimAtom, change *ingest.Change) (int64, int64) {
+	pre := change.Pre.Data.MustLiquidityPool().Body.ConstantProduct
+	a := int64(pre.ReserveA)
+	b := int64(pre.ReserveB)
+	if !trade.AssetSold().Equals(pre.Params.AssetA) {
+		a, b = b, a
+	}
+	return a, b
+}
+
+func roundingSlippage(
+	transaction ingest.LedgerTransaction,
+	opidx int,
+	trade xdr.ClaimAtom,
+	change *ingest.Change,
+) (*int64, error) {
+	disbursedReserves, depositedReserves := liquidityPoolReserves(trade, change)
+
+	pre := change.Pre.Data.MustLiquidityPool().Body.ConstantProduct
+
+	op, found := transaction.GetOperation(uint32(opidx))
+	if !found {
+		return nil, errors.New("could not find operation")
+	}
+
+	amountDeposited := trade.AmountBought()
+	amountDisbursed := trade.AmountSold()
+
+	switch op.Body.Type {
+	case xdr.OperationTypePathPaymentStrictReceive:
+		// User specified the disbursed amount
+		_, roundingSlippageBips, ok := orderbook.CalculatePoolExpectation(
+			xdr.Int64(depositedReserves),
+			xdr.Int64(disbursedReserves),
+			amountDisbursed,
+			pre.Params.Fee,
+			true,
+		)
+		if !ok {
+			// Temporary workaround for https://github.com/shantanu-hashcash/go/issues/4203
+			// Given strict receives that would underflow here, set maximum
+			// slippage so they get excluded.
+			roundingSlippageBips = xdr.Int64(math.MaxInt64)
+		}
+		slippage := int64(roundingSlippageBips)
+		return &slippage, nil
+	case xdr.OperationTypePathPaymentStrictSend:
+		// User specified the disbursed amount
+		_, roundingSlippageBips, ok := orderbook.CalculatePoolPayout(
+			xdr.Int64(depositedReserves),
+			xdr.Int64(disbursedReserves),
+			amountDeposited,
+			pre.Params.Fee,
+			true,
+		)
+		if !ok {
+			// Temporary workaround for https://github.com/shantanu-hashcash/go/issues/4203
+			// Given strict sends that would overflow here, set maximum slippage
+			// so they get excluded.
+			roundingSlippageBips = xdr.Int64(math.MaxInt64)
+		}
+		slippage := int64(roundingSlippageBips)
+		return &slippage, nil
+	default:
+		return nil, fmt.Errorf("unexpected trade operation type: %v", op.Body.Type)
+	}
+}
+
+func findPoolFee(
+	transaction ingest.LedgerTransaction,
+	opidx int,
+	poolID xdr.PoolId,
+) (uint32, error) {
+	key := xdr.LedgerKey{}
+	if err := key.SetLiquidityPool(poolID); err != nil {
+		return 0, errors.Wrap(err, "Could not create liquidity pool ledger key")
+
+	}
+
+	change, err := findOperationChange(transaction, opidx, key)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not find change for liquidity pool")
+	}
+
+	return uint32(change.Pre.Data.MustLiquidityPool().Body.MustConstantProduct().Params.Fee), nil
+}
@@ -0,0 +1,420 @@
+package processors
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shantanu-hashcash/go/exp/orderbook"
+	"github.com/shantanu-hashcash/go/ingest"
+	"github.com/shantanu-hashcash/go/toid"
+	"github.com/shantanu-hashcash/go/xdr"
+)
+
+var (
+	tradeBuyer  = xdr.MustAddress("GDRW375MAYR46ODGF2WGANQC2RRZL7O246DYHHCGWTV2RE7IHE2QUQLD")
+	tradeSeller = xdr.MustAddress("GACAR2AEYEKITE2LKI5RMXF5MIVZ6Q7XILROGDT22O7JX4DSWFS7FDDP")
+	tradeUSD    = xdr.MustNewCreditAsset("USD", tradeSeller.Address())
+)
+
+func tradeTransaction(op xdr.Operation, result xdr.OperationResult, changes xdr.LedgerEntryChanges) ingest.LedgerTransaction {
+	return ingest.LedgerTransaction{
+		Index: 1,
+		Envelope: xdr.TransactionEnvelope{
+			Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+			V1: &xdr.TransactionV1Envelope{
+				Tx: xdr.Transaction{
+					SourceAccount: tradeBuyer.ToMuxedAccount(),
+					Operations:    []xdr.Operation{op},
+				},
+			},
+		},
+		Result: xdr.TransactionResultPair{
+			Result: xdr.TransactionResult{
+				Result: xdr.TransactionResultResult{
+					Code:    xdr.TransactionResultCodeTxSuccess,
+					Results: &[]xdr.OperationResult{result},
+				},
+			},
+		},
+		UnsafeMeta: xdr.TransactionMeta{
+			V: 2,
+			V2: &xdr.TransactionMetaV2{
+				Operations: []xdr.OperationMeta{{Changes: changes}},
+			},
+		},
+	}
+}
+
+func tradeLedger() xdr.LedgerCloseMeta {
+	lcm := ledgerWithSequence(100)
+	lcm.V0.LedgerHeader.Header.ScpValue.CloseTime = 1600000000
+	return lcm
+}
+
+func TestOrderbookTrade(t *testing.T) {
+	offer := xdr.OfferEntry{
+		SellerId: tradeSeller,
+		OfferId:  7,
+		Selling:  tradeUSD,
+		Buying:   xdr.MustNewNativeAsset(),
+		Amount:   500,
+		Price:    xdr.Price{N: 2, D: 1},
+	}
+	offerKey := xdr.LedgerKey{}
+	require.NoError(t, offerKey.SetOffer(tradeSeller, 7))
+
+	transaction := tradeTransaction(
+		xdr.Operation{
+			Body: xdr.OperationBody{
+				Type: xdr.OperationTypeManageSellOffer,
+				ManageSellOfferOp: &xdr.ManageSellOfferOp{
+					Selling: xdr.MustNewNativeAsset(),
+					Buying:  tradeUSD,
+					Amount:  200,
+					Price:   xdr.Price{N: 1, D: 2},
+				},
+			},
+		},
+		xdr.OperationResult{
+			Code: xdr.OperationResultCodeOpInner,
+			Tr: &xdr.OperationResultTr{
+				Type: xdr.OperationTypeManageSellOffer,
+				ManageSellOfferResult: &xdr.ManageSellOfferResult{
+					Code: xdr.ManageSellOfferResultCodeManageSellOfferSuccess,
+					Success: &xdr.ManageOfferSuccessResult{
+						OffersClaimed: []xdr.ClaimAtom{
+							{
+								Type: xdr.ClaimAtomTypeClaimAtomTypeOrderBook,
+								OrderBook: &xdr.ClaimOfferAtom{
+									SellerId:     tradeSeller,
+									OfferId:      7,
+									AssetSold:    tradeUSD,
+									AmountSold:   100,
+									AssetBought:  xdr.MustNewNativeAsset(),
+									AmountBought: 200,
+								},
+							},
+							// offers removed by core without trading are skipped
+							{
+								Type: xdr.ClaimAtomTypeClaimAtomTypeOrderBook,
+								OrderBook: &xdr.ClaimOfferAtom{
+									SellerId:    tradeSeller,
+									OfferId:     8,
+									AssetSold:   tradeUSD,
+									AssetBought: xdr.MustNewNativeAsset(),
+								},
+							},
+						},
+						Offer: xdr.ManageOfferSuccessResultOffer{
+							Effect: xdr.ManageOfferEffectManageOfferDeleted,
+						},
+					},
+				},
+			},
+		},
+		xdr.LedgerEntryChanges{
+			{
+				Type: xdr.LedgerEntryChangeTypeLedgerEntryState,
+				State: &xdr.LedgerEntry{
+					Data: xdr.LedgerEntryData{Type: xdr.LedgerEntryTypeOffer, Offer: &offer},
+				},
+			},
+			{
+				Type:    xdr.LedgerEntryChangeTypeLedgerEntryRemoved,
+				Removed: &offerKey,
+			},
+		},
+	)
+
+	trades, err := TransactionTrades(tradeLedger(), transaction)
+	require.NoError(t, err)
+	require.Len(t, trades, 1)
+
+	opID := toid.New(100, 1, 1).ToInt64()
+	assert.Equal(t, Trade{
+		OperationID:     opID,
+		Order:           0,
+		LedgerCloseTime: time.Unix(1600000000, 0).UTC(),
+		Type:            OrderbookTradeType,
+		SellerAccount:   tradeSeller.Address(),
+		SellerOfferID:   7,
+		SoldAsset:       tradeUSD,
+		SoldAmount:      100,
+		BuyerAccount:    tradeBuyer.Address(),
+		BuyerOfferID:    EncodeOfferId(uint64(opID), TOIDType),
+		BoughtAsset:     xdr.MustNewNativeAsset(),
+		BoughtAmount:    200,
+		PriceN:          2,
+		PriceD:          1,
+	}, trades[0])
+}
+
+func TestLiquidityPoolTrade(t *testing.T) {
+	poolID := xdr.PoolId{1, 2, 3}
+	pool := func(reserveA, reserveB xdr.Int64) *xdr.LedgerEntry {
+		return &xdr.LedgerEntry{
+			Data: xdr.LedgerEntryData{
+				Type: xdr.LedgerEntryTypeLiquidityPool,
+				LiquidityPool: &xdr.LiquidityPoolEntry{
+					LiquidityPoolId: poolID,
+					Body: xdr.LiquidityPoolEntryBody{
+						Type: xdr.LiquidityPoolTypeLiquidityPoolConstantProduct,
+						ConstantProduct: &xdr.LiquidityPoolEntryConstantProduct{
+							Params: xdr.LiquidityPoolConstantProductParameters{
+								AssetA: xdr.MustNewNativeAsset(),
+								AssetB: tradeUSD,
+								Fee:    30,
+							},
+							ReserveA: reserveA,
+							ReserveB: reserveB,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	transaction := tradeTransaction(
+		xdr.Operation{
+			Body: xdr.OperationBody{
+				Type: xdr.OperationTypePathPaymentStrictSend,
+				PathPaymentStrictSendOp: &xdr.PathPaymentStrictSendOp{
+					SendAsset:   xdr.MustNewNativeAsset(),
+					SendAmount:  100,
+					Destination: tradeSeller.ToMuxedAccount(),
+					DestAsset:   tradeUSD,
+					DestMin:     1,
+				},
+			},
+		},
+		xdr.OperationResult{
+			Code: xdr.OperationResultCodeOpInner,
+			Tr: &xdr.OperationResultTr{
+				Type: xdr.OperationTypePathPaymentStrictSend,
+				PathPaymentStrictSendResult: &xdr.PathPaymentStrictSendResult{
+					Code: xdr.PathPaymentStrictSendResultCodePathPaymentStrictSendSuccess,
+					Success: &xdr.PathPaymentStrictSendResultSuccess{
+						Offers: []xdr.ClaimAtom{
+							{
+								Type: xdr.ClaimAtomTypeClaimAtomTypeLiquidityPool,
+								LiquidityPool: &xdr.ClaimLiquidityAtom{
+									LiquidityPoolId: poolID,
+									AssetSold:       tradeUSD,
+									AmountSold:      99,
+									AssetBought:     xdr.MustNewNativeAsset(),
+									AmountBought:    100,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		xdr.LedgerEntryChanges{
+			{Type: xdr.LedgerEntryChangeTypeLedgerEntryState, State: pool(1000000, 1000000)},
+			{Type: xdr.LedgerEntryChangeTypeLedgerEntryUpdated, Updated: pool(1000100, 999901)},
+		},
+	)
+
+	trades, err := TransactionTrades(tradeLedger(), transaction)
+	require.NoError(t, err)
+	require.Len(t, trades, 1)
+
+	_, expectedSlippage, ok := orderbook.CalculatePoolPayout(1000000, 1000000, 100, 30, true)
+	require.True(t, ok)
+	slippage := int64(expectedSlippage)
+	exact := false
+	opID := toid.New(100, 1, 1).ToInt64()
+	assert.Equal(t, Trade{
+		OperationID:      opID,
+		Order:            0,
+		LedgerCloseTime:  time.Unix(1600000000, 0).UTC(),
+		Type:             LiquidityPoolTradeType,
+		LiquidityPoolID:  PoolIDToString(poolID),
+		LiquidityPoolFee: 30,
+		SoldAsset:        tradeUSD,
+		SoldAmount:       99,
+		BuyerAccount:     tradeBuyer.Address(),
+		BuyerOfferID:     EncodeOfferId(uint64(opID), TOIDType),
+		BoughtAsset:      xdr.MustNewNativeAsset(),
+		BoughtAmount:     100,
+		PriceN:           100,
+		PriceD:           99,
+		SellerIsExact:    &exact,
+		RoundingSlippage: &slippage,
+	}, trades[0])
+}
+
+func TestFailedTransactionTrades(t *testing.T) {
+	transaction := ingest.LedgerTransaction{
+		Result: xdr.TransactionResultPair{
+			Result: xdr.TransactionResult{
+				Result: xdr.TransactionResultResult{Code: xdr.TransactionResultCodeTxFailed},
+			},
+		},
+	}
+	trades, err := TransactionTrades(tradeLedger(), transaction)
+	require.NoError(t, err)
+	assert.Empty(t, trades)
+}
+
+func TestRoundingSlippageBig(t *testing.T) {
+	assetDeposited := xdr.MustNewCreditAsset("MAD", tradeSeller.Address())
+	assetDisbursed := xdr.MustNewCreditAsset("GRE", tradeSeller.Address())
+	poolId, err := xdr.NewPoolId(assetDisbursed, assetDeposited, xdr.LiquidityPoolFeeV18)
+	require.NoError(t, err)
+	trade := xdr.ClaimAtom{
+		Type: xdr.ClaimAtomTypeClaimAtomTypeLiquidityPool,
+		LiquidityPool: &xdr.ClaimLiquidityAtom{
+			LiquidityPoolId: poolId,
+			AssetBought:     assetDeposited,
+			AmountBought:    1,
+			AssetSold:       assetDisbursed,
+			AmountSold:      1,
+		},
+	}
+	tx := createTransactionForTrade(trade, 3740000000, 162020000000)
+	opIdx := 0
+	change, err := liquidityPoolChange(tx, opIdx, trade)
+	require.NoError(t, err)
+
+	result, err := roundingSlippage(tx, opIdx, trade, change)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, int64(4229), *result)
+}
+
+func TestRoundingSlippageSmall(t *testing.T) {
+	assetDeposited := xdr.MustNewCreditAsset("MAD", tradeSeller.Address())
+	assetDisbursed := xdr.MustNewCreditAsset("GRE", tradeSeller.Address())
+	poolId, err := xdr.NewPoolId(assetDisbursed, assetDeposited, xdr.LiquidityPoolFeeV18)
+	require.NoError(t, err)
+	trade := xdr.ClaimAtom{
+		Type: xdr.ClaimAtomTypeClaimAtomTypeLiquidityPool,
+		LiquidityPool: &xdr.ClaimLiquidityAtom{
+			LiquidityPoolId: poolId,
+			AssetBought:     assetDeposited,
+			AmountBought:    11,
+			AssetSold:       assetDisbursed,
+			AmountSold:      20,
+		},
+	}
+	tx := createTransactionForTrade(trade, 200, 400)
+	opIdx := 0
+	change, err := liquidityPoolChange(tx, opIdx, trade)
+	require.NoError(t, err)
+
+	result, err := roundingSlippage(tx, opIdx, trade, change)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, int64(4), *result)
+}
+
+func createTransactionForTrade(trade xdr.ClaimAtom, reserveA, reserveB int64) ingest.LedgerTransaction {
+	source := tradeBuyer.ToMuxedAccount()
+	destination := source
+
+	pool := makePool(trade.AssetBought(), trade.AssetSold(), reserveA, reserveB)
+
+	poolLedgerEntry := func(reserveA, reserveB xdr.Int64) *xdr.LedgerEntry {
+		return &xdr.LedgerEntry{
+			Data: xdr.LedgerEntryData{
+				Type:          xdr.LedgerEntryTypeLiquidityPool,
+				LiquidityPool: &pool,
+			},
+		}
+	}
+
+	return ingest.LedgerTransaction{
+		Result: xdr.TransactionResultPair{
+			TransactionHash: xdr.Hash{},
+			Result: xdr.TransactionResult{
+				Result: xdr.TransactionResultResult{
+					Code:            xdr.TransactionResultCodeTxSuccess,
+					InnerResultPair: &xdr.InnerTransactionResultPair{},
+					Results:         &[]xdr.OperationResult{},
+				},
+			},
+		},
+		Envelope: xdr.TransactionEnvelope{
+			Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+			V1: &xdr.TransactionV1Envelope{
+				Tx: xdr.Transaction{
+					SourceAccount: source,
+					Operations: []xdr.Operation{
+						{
+							Body: xdr.OperationBody{
+								Type: xdr.OperationTypePathPaymentStrictReceive,
+								PathPaymentStrictReceiveOp: &xdr.PathPaymentStrictReceiveOp{
+									SendAsset:   trade.AssetBought(),
+									SendMax:     trade.AmountBought(),
+									Destination: destination,
+									DestAsset:   trade.AssetSold(),
+									DestAmount:  trade.AmountSold(),
+									Path: []xdr.Asset{
+										trade.AssetBought(),
+										trade.AssetSold(),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		UnsafeMeta: xdr.TransactionMeta{
+			V: 2,
+			V2: &xdr.TransactionMetaV2{
+				Operations: []xdr.OperationMeta{
+					{
+						Changes: xdr.LedgerEntryChanges{
+							{
+								Type: xdr.LedgerEntryChangeTypeLedgerEntryState,
+								State: poolLedgerEntry(
+									xdr.Int64(reserveA),
+									xdr.Int64(reserveB),
+								),
+							},
+							{
+								Type: xdr.LedgerEntryChangeTypeLedgerEntryUpdated,
+								Updated: poolLedgerEntry(
+									xdr.Int64(reserveA)+trade.AmountBought(),
+									xdr.Int64(reserveB)-trade.AmountSold(),
+								),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func makePool(A, B xdr.Asset, a, b int64) xdr.LiquidityPoolEntry {
+	if !A.LessThan(B) {
+		B, A = A, B
+		b, a = a, b
+	}
+
+	poolId, _ := xdr.NewPoolId(A, B, xdr.LiquidityPoolFeeV18)
+	return xdr.LiquidityPoolEntry{
+		LiquidityPoolId: poolId,
+		Body: xdr.LiquidityPoolEntryBody{
+			Type: xdr.LiquidityPoolTypeLiquidityPoolConstantProduct,
+			ConstantProduct: &xdr.LiquidityPoolEntryConstantProduct{
+				Params: xdr.LiquidityPoolConstantProductParameters{
+					AssetA: A,
+					AssetB: B,
+					Fee:    xdr.LiquidityPoolFeeV18,
+				},
+				ReserveA:                 xdr.Int64(a),
+				ReserveB:                 xdr.Int64(b),
+				TotalPoolShares:          123,
+				PoolSharesTrustLineCount: 456,
+			},
+		},
+	}
+}
@@ -0,0 +1,53 @@
+package hdwallet
+
+import (
+	"github.com/shantanu-hashcash/go/clients/auroraclient"
+	"github.com/shantanu-hashcash/go/keypair"
+	hProtocol "github.com/shantanu-hashcash/go/protocols/aurora"
+	"github.com/shantanu-hashcash/go/support/errors"
+)
+
+// DefaultGapLimit is the number of consecutive unfunded accounts after which
+// Discover stops, as recommended by BIP-44.
+const DefaultGapLimit = 20
+
+// DiscoveredAccount is a derived account that exists on the network.
+type DiscoveredAccount struct {
+	Index   uint32
+	Keypair *keypair.Full
+	Account hProtocol.Account
+}
+
+// Discover derives accounts in order and looks each one up in Aurora until
+// gapLimit consecutive accounts are not found, returning the accounts that
+// exist. A gapLimit of 0 uses DefaultGapLimit.
+func Discover(w *Wallet, client auroraclient.ClientInterface, gapLimit uint32) ([]DiscoveredAccount, error) {
+	if gapLimit == 0 {
+		gapLimit = DefaultGapLimit
+	}
+
+	discovered := []DiscoveredAccount{}
+	gap := uint32(0)
+	for index := uint32(0); gap < gapLimit; index++ {
+		kp, err := w.Account(index)
+		if err != nil {
+			return nil, err
+		}
+
+		account, err := client.AccountDetail(auroraclient.AccountRequest{AccountID: kp.Address()})
+		if auroraclient.IsNotFoundError(err) {
+			gap++
+			continue
+		} else if err != nil {
+			return nil, errors.Wrapf(err, "loading account %s", kp.Address())
+		}
+
+		gap = 0
+		discovered = append(discovered, DiscoveredAccount{
+			Index:   index,
+			Keypair: kp,
+			Account: account,
+		})
+	}
+	return discovered, nil
+}
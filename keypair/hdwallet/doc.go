@@ -0,0 +1,5 @@
+// Package hdwallet implements hierarchical deterministic wallets for Hcnet
+// as described in SEP-0005: BIP-39 mnemonics, SLIP-10 ed25519 key derivation
+// of accounts at m/44'/148'/n', and discovery of the derived accounts that
+// exist on the network.
+package hdwallet
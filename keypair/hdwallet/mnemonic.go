@@ -0,0 +1,235 @@
+package hdwallet
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"strings"
+	"sync"
+
+	"github.com/tyler-smith/go-bip39/wordlists"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/shantanu-hashcash/go/support/errors"
+)
+
+// Language is the language of the BIP-39 wordlist a mnemonic is written in.
+type Language string
+
+const (
+	English            Language = "english"
+	ChineseSimplified  Language = "chinese_simplified"
+	ChineseTraditional Language = "chinese_traditional"
+	Czech              Language = "czech"
+	French             Language = "french"
+	Italian            Language = "italian"
+	Japanese           Language = "japanese"
+	Korean             Language = "korean"
+	Spanish            Language = "spanish"
+)
+
+// Languages lists every supported language. DetectLanguage tries them in
+// this order.
+var Languages = []Language{
+	English,
+	ChineseSimplified,
+	ChineseTraditional,
+	Czech,
+	French,
+	Italian,
+	Japanese,
+	Korean,
+	Spanish,
+}
+
+// DefaultEntropySize is the recommended entropy, in bits, to pass to
+// NewMnemonic. It results in 24 words.
+const DefaultEntropySize = 256
+
+var (
+	// ErrInvalidEntropySize is returned when entropy is not 128, 160, 192, 224
+	// or 256 bits long.
+	ErrInvalidEntropySize = errors.New("entropy must be 128, 160, 192, 224 or 256 bits long")
+	// ErrInvalidMnemonic is returned when a mnemonic does not have a valid
+	// number of words or contains a word that is not in the wordlist.
+	ErrInvalidMnemonic = errors.New("invalid mnemonic")
+	// ErrInvalidChecksum is returned when the checksum of a mnemonic does not
+	// match its entropy.
+	ErrInvalidChecksum = errors.New("invalid mnemonic checksum")
+	// ErrUnknownLanguage is returned for languages that are not supported and
+	// for mnemonics whose language cannot be detected.
+	ErrUnknownLanguage = errors.New("unknown mnemonic language")
+)
+
+type wordlist struct {
+	words   []string
+	indexes map[string]int
+}
+
+var (
+	wordlistsOnce sync.Once
+	wordlistsMap  map[Language]*wordlist
+)
+
+func getWordlist(language Language) (*wordlist, error) {
+	wordlistsOnce.Do(func() {
+		sources := map[Language][]string{
+			English:            wordlists.English,
+			ChineseSimplified:  wordlists.ChineseSimplified,
+			ChineseTraditional: wordlists.ChineseTraditional,
+			Czech:              wordlists.Czech,
+			French:             wordlists.French,
+			Italian:            wordlists.Italian,
+			Japanese:           wordlists.Japanese,
+			Korean:             wordlists.Korean,
+			Spanish:            wordlists.Spanish,
+		}
+		wordlistsMap = make(map[Language]*wordlist, len(sources))
+		for language, words := range sources {
+			list := &wordlist{words: words, indexes: make(map[string]int, len(words))}
+			for i, word := range words {
+				list.indexes[norm.NFKD.String(word)] = i
+			}
+			wordlistsMap[language] = list
+		}
+	})
+
+	list, ok := wordlistsMap[language]
+	if !ok {
+		return nil, ErrUnknownLanguage
+	}
+	return list, nil
+}
+
+// separator returns the string words are joined with. BIP-39 recommends the
+// ideographic space for Japanese.
+func (l Language) separator() string {
+	if l == Japanese {
+		return "　"
+	}
+	return " "
+}
+
+// NewEntropy returns bitSize bits of random entropy.
+func NewEntropy(bitSize int) ([]byte, error) {
+	if !validEntropySize(bitSize/8) || bitSize%8 != 0 {
+		return nil, ErrInvalidEntropySize
+	}
+	entropy := make([]byte, bitSize/8)
+	if _, err := rand.Read(entropy); err != nil {
+		return nil, errors.Wrap(err, "reading random entropy")
+	}
+	return entropy, nil
+}
+
+// NewMnemonic generates a random mnemonic with bitSize bits of entropy in
+// language.
+func NewMnemonic(bitSize int, language Language) (string, error) {
+	entropy, err := NewEntropy(bitSize)
+	if err != nil {
+		return "", err
+	}
+	return MnemonicFromEntropy(entropy, language)
+}
+
+// MnemonicFromEntropy encodes entropy as a mnemonic in language.
+func MnemonicFromEntropy(entropy []byte, language Language) (string, error) {
+	if !validEntropySize(len(entropy)) {
+		return "", ErrInvalidEntropySize
+	}
+	list, err := getWordlist(language)
+	if err != nil {
+		return "", err
+	}
+
+	// The entropy is followed by one checksum bit for every 32 bits of
+	// entropy, the first bits of its SHA-256 hash. The result is split in
+	// groups of 11 bits, each the index of a word.
+	checksum := sha256.Sum256(entropy)
+	data := append(append([]byte{}, entropy...), checksum[0])
+	wordCount := (len(entropy)*8 + len(entropy)/4) / 11
+
+	words := make([]string, wordCount)
+	for i := range words {
+		index := 0
+		for bit := i * 11; bit < (i+1)*11; bit++ {
+			index = index<<1 | int(data[bit/8]>>(7-bit%8)&1)
+		}
+		words[i] = list.words[index]
+	}
+	return strings.Join(words, language.separator()), nil
+}
+
+// MnemonicToEntropy decodes a mnemonic in language, verifying its checksum.
+func MnemonicToEntropy(mnemonic string, language Language) ([]byte, error) {
+	list, err := getWordlist(language)
+	if err != nil {
+		return nil, err
+	}
+
+	words := strings.Fields(norm.NFKD.String(mnemonic))
+	if len(words)%3 != 0 || !validEntropySize(len(words)*4/3) {
+		return nil, ErrInvalidMnemonic
+	}
+
+	entropyLength := len(words) * 4 / 3
+	data := make([]byte, entropyLength+1)
+	for i, word := range words {
+		index, ok := list.indexes[word]
+		if !ok {
+			return nil, errors.Wrapf(ErrInvalidMnemonic, "unknown word %q", word)
+		}
+		for j := 0; j < 11; j++ {
+			if index>>(10-j)&1 == 1 {
+				bit := i*11 + j
+				data[bit/8] |= 1 << (7 - bit%8)
+			}
+		}
+	}
+
+	entropy := data[:entropyLength]
+	checksumBits := uint(entropyLength / 4)
+	checksum := sha256.Sum256(entropy)
+	mask := byte(0xff) << (8 - checksumBits)
+	if checksum[0]&mask != data[entropyLength] {
+		return nil, ErrInvalidChecksum
+	}
+	return entropy, nil
+}
+
+// ValidateMnemonic checks that mnemonic is a valid mnemonic in language.
+func ValidateMnemonic(mnemonic string, language Language) error {
+	_, err := MnemonicToEntropy(mnemonic, language)
+	return err
+}
+
+// DetectLanguage returns the first language, in the order of Languages, in
+// which mnemonic is valid. A few words are shared between wordlists, so
+// callers that know the language should pass it explicitly instead.
+func DetectLanguage(mnemonic string) (Language, error) {
+	for _, language := range Languages {
+		if ValidateMnemonic(mnemonic, language) == nil {
+			return language, nil
+		}
+	}
+	return "", ErrUnknownLanguage
+}
+
+// NewSeed validates mnemonic and returns the 64 byte BIP-39 seed derived from
+// it and the optional passphrase.
+func NewSeed(mnemonic, passphrase string, language Language) ([]byte, error) {
+	if err := ValidateMnemonic(mnemonic, language); err != nil {
+		return nil, err
+	}
+	// Whitespace is normalized so that the same words always produce the
+	// same seed.
+	words := strings.Fields(norm.NFKD.String(mnemonic))
+	normalized := norm.NFKD.String(strings.Join(words, language.separator()))
+	salt := norm.NFKD.String("mnemonic" + passphrase)
+	return pbkdf2.Key([]byte(normalized), []byte(salt), 2048, 64, sha512.New), nil
+}
+
+func validEntropySize(bytes int) bool {
+	return bytes >= 16 && bytes <= 32 && bytes%4 == 0
+}
@@ -0,0 +1,68 @@
+package hdwallet
+
+import (
+	"fmt"
+
+	"github.com/shantanu-hashcash/go/keypair"
+	"github.com/shantanu-hashcash/go/support/errors"
+)
+
+// Wallet derives Hcnet accounts at m/44'/148'/n' from a BIP-39 seed, as
+// described in SEP-0005.
+type Wallet struct {
+	// master is the key at HcnetAccountPrefix, account keys are its
+	// children.
+	master *Key
+}
+
+// NewWallet returns the wallet of a BIP-39 seed.
+func NewWallet(seed []byte) (*Wallet, error) {
+	master, err := DeriveForPath(HcnetAccountPrefix, seed)
+	if err != nil {
+		return nil, errors.Wrap(err, "deriving master key")
+	}
+	return &Wallet{master: master}, nil
+}
+
+// FromMnemonic returns the wallet of a mnemonic and its optional passphrase.
+func FromMnemonic(mnemonic, passphrase string, language Language) (*Wallet, error) {
+	seed, err := NewSeed(mnemonic, passphrase, language)
+	if err != nil {
+		return nil, err
+	}
+	return NewWallet(seed)
+}
+
+// AccountPath returns the derivation path of the account with index.
+func AccountPath(index uint32) string {
+	return fmt.Sprintf(HcnetAccountPathFormat, index)
+}
+
+// Account derives the account at m/44'/148'/index'.
+func (w *Wallet) Account(index uint32) (*keypair.Full, error) {
+	if index >= FirstHardenedIndex {
+		return nil, errors.Errorf("account index %d is too large", index)
+	}
+	key, err := w.master.Derive(FirstHardenedIndex + index)
+	if err != nil {
+		return nil, errors.Wrap(err, "deriving account key")
+	}
+	kp, err := keypair.FromRawSeed(key.RawSeed())
+	if err != nil {
+		return nil, errors.Wrap(err, "creating key pair")
+	}
+	return kp, nil
+}
+
+// Accounts derives count accounts starting at index start.
+func (w *Wallet) Accounts(start, count uint32) ([]*keypair.Full, error) {
+	accounts := make([]*keypair.Full, 0, count)
+	for i := start; i < start+count; i++ {
+		kp, err := w.Account(i)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, kp)
+	}
+	return accounts, nil
+}
@@ -0,0 +1,161 @@
+package hdwallet
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shantanu-hashcash/go/clients/auroraclient"
+	hProtocol "github.com/shantanu-hashcash/go/protocols/aurora"
+	"github.com/shantanu-hashcash/go/support/render/problem"
+)
+
+func TestMnemonicFromEntropy(t *testing.T) {
+	// Vectors from https://github.com/trezor/python-mnemonic/blob/master/vectors.json
+	testCases := []struct {
+		entropy  string
+		mnemonic string
+		seed     string
+	}{
+		{
+			entropy:  "00000000000000000000000000000000",
+			mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			seed:     "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		},
+		{
+			entropy:  "7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+			mnemonic: "legal winner thank year wave sausage worth useful legal winner thank yellow",
+			seed:     "2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607",
+		},
+		{
+			entropy:  "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+			mnemonic: "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo vote",
+			seed:     "dd48c104698c30cfe2b6142103248622fb7bb0ff692eebb00089b32d22484e1613912f0a5b694407be899ffd31ed3992c456cdf60f5d4564b8ba3f05a69890ad",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.mnemonic, func(t *testing.T) {
+			entropy, err := hex.DecodeString(tc.entropy)
+			require.NoError(t, err)
+
+			mnemonic, err := MnemonicFromEntropy(entropy, English)
+			require.NoError(t, err)
+			assert.Equal(t, tc.mnemonic, mnemonic)
+
+			decoded, err := MnemonicToEntropy(mnemonic, English)
+			require.NoError(t, err)
+			assert.Equal(t, entropy, decoded)
+
+			seed, err := NewSeed(mnemonic, "TREZOR", English)
+			require.NoError(t, err)
+			assert.Equal(t, tc.seed, hex.EncodeToString(seed))
+		})
+	}
+}
+
+func TestMnemonicErrors(t *testing.T) {
+	_, err := MnemonicFromEntropy(make([]byte, 15), English)
+	assert.Equal(t, ErrInvalidEntropySize, err)
+	_, err = MnemonicFromEntropy(make([]byte, 16), Language("klingon"))
+	assert.Equal(t, ErrUnknownLanguage, err)
+
+	assert.Equal(t, ErrInvalidChecksum, ValidateMnemonic(
+		"illness spike retreat truth genius clock brain pass fit cave bargain illness", English))
+	assert.Equal(t, ErrInvalidMnemonic, ValidateMnemonic("abandon abandon", English))
+	err = ValidateMnemonic(strings.Repeat("abandon ", 11)+"lumens", English)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `unknown word "lumens"`)
+	}
+}
+
+func TestMnemonicLanguages(t *testing.T) {
+	for _, language := range Languages {
+		t.Run(string(language), func(t *testing.T) {
+			mnemonic, err := NewMnemonic(DefaultEntropySize, language)
+			require.NoError(t, err)
+			assert.Len(t, strings.Fields(mnemonic), 24)
+			require.NoError(t, ValidateMnemonic(mnemonic, language))
+
+			_, err = NewSeed(mnemonic, "passphrase", language)
+			require.NoError(t, err)
+		})
+	}
+
+	mnemonic, err := NewMnemonic(128, Japanese)
+	require.NoError(t, err)
+	assert.Len(t, strings.Split(mnemonic, "　"), 12)
+
+	mnemonic, err = MnemonicFromEntropy(make([]byte, 16), Spanish)
+	require.NoError(t, err)
+	language, err := DetectLanguage(mnemonic)
+	require.NoError(t, err)
+	assert.Equal(t, Spanish, language)
+
+	_, err = DetectLanguage("not a mnemonic")
+	assert.Equal(t, ErrUnknownLanguage, err)
+}
+
+func TestWalletAccounts(t *testing.T) {
+	// Vectors from SEP-0005.
+	w, err := FromMnemonic("illness spike retreat truth genius clock brain pass fit cave bargain toe", "", English)
+	require.NoError(t, err)
+	accounts, err := w.Accounts(0, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "GDRXE2BQUC3AZNPVFSCEZ76NJ3WWL25FYFK6RGZGIEKWE4SOOHSUJUJ6", accounts[0].Address())
+	assert.Equal(t, "SBGWSG6BTNCKCOB3DIFBGCVMUPQFYPA2G4O34RMTB343OYPXU5DJDVMN", accounts[0].Seed())
+	assert.Equal(t, "GBAW5XGWORWVFE2XTJYDTLDHXTY2Q2MO73HYCGB3XMFMQ562Q2W2GJQX", accounts[1].Address())
+	assert.Equal(t, "m/44'/148'/1'", AccountPath(1))
+
+	w, err = FromMnemonic(
+		"cable spray genius state float twenty onion head street palace net private method loan turn phrase state blanket interest dry amazing dress blast tube",
+		"p4ssphr4se",
+		English,
+	)
+	require.NoError(t, err)
+	kp, err := w.Account(9)
+	require.NoError(t, err)
+	assert.Equal(t, "GBOSMFQYKWFDHJWCMCZSMGUMWCZOM4KFMXXS64INDHVCJ2A2JAABCYRR", kp.Address())
+
+	_, err = w.Account(FirstHardenedIndex)
+	assert.Error(t, err)
+}
+
+func TestDiscover(t *testing.T) {
+	w, err := FromMnemonic("illness spike retreat truth genius clock brain pass fit cave bargain toe", "", English)
+	require.NoError(t, err)
+	accounts, err := w.Accounts(0, 6)
+	require.NoError(t, err)
+
+	notFound := auroraclient.Error{Problem: problem.P{
+		Type:   "https://hcnet.org/aurora-errors/not_found",
+		Status: 404,
+	}}
+	client := &auroraclient.MockClient{}
+	// Accounts 0 and 2 are funded, with a gap limit of 3 discovery stops
+	// after account 5.
+	for i, kp := range accounts {
+		request := auroraclient.AccountRequest{AccountID: kp.Address()}
+		if i == 0 || i == 2 {
+			client.On("AccountDetail", request).Return(hProtocol.Account{AccountID: kp.Address()}, nil).Once()
+		} else {
+			client.On("AccountDetail", request).Return(hProtocol.Account{}, notFound).Once()
+		}
+	}
+
+	discovered, err := Discover(w, client, 3)
+	require.NoError(t, err)
+	require.Len(t, discovered, 2)
+	assert.Equal(t, uint32(0), discovered[0].Index)
+	assert.Equal(t, uint32(2), discovered[1].Index)
+	assert.Equal(t, accounts[2].Address(), discovered[1].Account.AccountID)
+	client.AssertExpectations(t)
+
+	client = &auroraclient.MockClient{}
+	client.On("AccountDetail", mock.Anything).Return(hProtocol.Account{}, problem.ServerError).Once()
+	_, err = Discover(w, client, 3)
+	assert.Error(t, err)
+}
@@ -9,6 +9,8 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -16,11 +18,14 @@ import (
 
 	aurora "github.com/shantanu-hashcash/go/services/aurora/internal"
 	"github.com/shantanu-hashcash/go/services/aurora/internal/db2/schema"
+	"github.com/shantanu-hashcash/go/services/aurora/internal/db2/schema/online"
+	"github.com/shantanu-hashcash/go/services/aurora/internal/export"
 	"github.com/shantanu-hashcash/go/services/aurora/internal/ingest"
 	support "github.com/shantanu-hashcash/go/support/config"
 	"github.com/shantanu-hashcash/go/support/db"
 	"github.com/shantanu-hashcash/go/support/errors"
 	hlog "github.com/shantanu-hashcash/go/support/log"
+	"github.com/shantanu-hashcash/go/support/storage"
 )
 
 var dbCmd = &cobra.Command{
@@ -215,6 +220,130 @@ var dbMigrateUpCmd = &cobra.Command{
 	},
 }
 
+var (
+	onlineBatchSize          uint
+	onlineLockTimeoutSeconds uint
+)
+
+var dbMigrateOnlineCmdOpts = support.ConfigOptions{
+	{
+		Name:        "batch-size",
+		ConfigKey:   &onlineBatchSize,
+		OptType:     types.Uint,
+		Required:    false,
+		FlagDefault: uint(online.DefaultBatchSize),
+		Usage:       "[optional] number of rows copied per transaction by backfill",
+	},
+	{
+		Name:        "lock-timeout-seconds",
+		ConfigKey:   &onlineLockTimeoutSeconds,
+		OptType:     types.Uint,
+		Required:    false,
+		FlagDefault: uint(online.DefaultLockTimeout / time.Second),
+		Usage:       "[optional] time start, swap and abort wait for a lock on the migrated table before failing",
+	},
+}
+
+var dbMigrateOnlineCmd = &cobra.Command{
+	Use:   "online [command]",
+	Short: "commands to run online schema migrations on aurora's postgres db",
+	Long: "online migrations rewrite large history tables in the background while aurora keeps ingesting. " +
+		"A migration is started, backfilled, swapped and cleaned up by the respective subcommands, " +
+		"backfill can be interrupted and resumed.",
+}
+
+// onlineMigrationCmd returns a command which runs a step of an online
+// migration given by id.
+func onlineMigrationCmd(use, short string, step func(*online.Migrator, context.Context, online.Migration) error) *cobra.Command {
+	return &cobra.Command{
+		Use:   use + " ID",
+		Short: short,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := requireAndSetFlags(aurora.DatabaseURLFlagName); err != nil {
+				return err
+			}
+			if err := dbMigrateOnlineCmdOpts.SetValues(); err != nil {
+				return err
+			}
+			if len(args) != 1 {
+				return ErrUsage{cmd}
+			}
+
+			migration, ok := online.Find(args[0])
+			if !ok {
+				return fmt.Errorf("unknown online migration %s", args[0])
+			}
+
+			dbConn, err := db.Open("postgres", globalConfig.DatabaseURL)
+			if err != nil {
+				return err
+			}
+			defer dbConn.Close()
+
+			migrator := &online.Migrator{
+				Session:     dbConn,
+				BatchSize:   int(onlineBatchSize),
+				LockTimeout: time.Duration(onlineLockTimeoutSeconds) * time.Second,
+			}
+			if err := step(migrator, context.Background(), migration); err != nil {
+				return err
+			}
+			hlog.Infof("Online migration %s: %s completed", migration.ID, use)
+			return nil
+		},
+	}
+}
+
+var dbMigrateOnlineStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "print the progress of online migrations",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireAndSetFlags(aurora.DatabaseURLFlagName); err != nil {
+			return err
+		}
+		if len(args) != 0 {
+			return ErrUsage{cmd}
+		}
+
+		dbConn, err := db.Open("postgres", globalConfig.DatabaseURL)
+		if err != nil {
+			return err
+		}
+		defer dbConn.Close()
+
+		migrator := &online.Migrator{Session: dbConn}
+		statuses, err := migrator.Status(context.Background(), online.Migrations)
+		if err != nil {
+			return err
+		}
+
+		table := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(table, "Migration\tTable\tState\tProgress\tRows copied\tUpdated")
+		for _, status := range statuses {
+			updated := ""
+			if !status.UpdatedAt.IsZero() {
+				updated = status.UpdatedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(table, "%s\t%s\t%s\t%.2f%%\t%d\t%s\n",
+				status.ID, status.Table, status.State, 100*status.Progress(), status.RowsCopied, updated)
+		}
+		return table.Flush()
+	},
+}
+
+var (
+	dbMigrateOnlineStartCmd = onlineMigrationCmd("start",
+		"create the new table and start double-writing into it", (*online.Migrator).Start)
+	dbMigrateOnlineBackfillCmd = onlineMigrationCmd("backfill",
+		"copy existing rows into the new table and build its indexes, resuming any previous backfill", (*online.Migrator).Backfill)
+	dbMigrateOnlineSwapCmd = onlineMigrationCmd("swap",
+		"replace the old table with the backfilled new table", (*online.Migrator).Swap)
+	dbMigrateOnlineCleanupCmd = onlineMigrationCmd("cleanup",
+		"drop the old table of a swapped migration", (*online.Migrator).Cleanup)
+	dbMigrateOnlineAbortCmd = onlineMigrationCmd("abort",
+		"stop a migration which hasn't been swapped and drop its new table", (*online.Migrator).Abort)
+)
+
 var dbReapCmd = &cobra.Command{
 	Use:   "reap",
 	Short: "reaps (i.e. removes) any reapable history data",
@@ -491,6 +620,106 @@ var dbDetectGapsCmd = &cobra.Command{
 	},
 }
 
+var (
+	exportDestination   string
+	exportTables        string
+	exportPartitionSize uint32
+)
+
+var dbExportCmdOpts = support.ConfigOptions{
+	{
+		Name:      "destination",
+		ConfigKey: &exportDestination,
+		OptType:   types.String,
+		Required:  true,
+		Usage:     "storage URL the Parquet files are written to, e.g. s3://bucket/prefix, gcs://bucket/prefix or file:///path",
+	},
+	{
+		Name:        "tables",
+		ConfigKey:   &exportTables,
+		OptType:     types.String,
+		Required:    false,
+		FlagDefault: "",
+		Usage:       "[optional] comma-separated list of tables to export, all tables by default",
+	},
+	{
+		Name:        "partition-size",
+		ConfigKey:   &exportPartitionSize,
+		OptType:     types.Uint32,
+		Required:    false,
+		FlagDefault: uint32(export.DefaultPartitionSize),
+		Usage:       "[optional] number of ledgers per file of the history tables, S3 uploads buffer a whole file in memory",
+	},
+}
+
+var dbExportCmd = &cobra.Command{
+	Use:   "export [Start sequence number] [End sequence number]",
+	Short: "exports history and state tables to Parquet files",
+	Long: "exports the rows of the history tables and the state tables for the ledgers between X and Y sequence number " +
+		"(closed intervals) to Parquet files, partitioned by ledger range. State tables (accounts, trust_lines, offers, " +
+		"claimable_balances, liquidity_pools) are only exported incrementally: the entries whose current version was last " +
+		"modified between X and Y are exported, from the same snapshot as the last partition of the history tables. " +
+		"Entries modified again after Y are not included, and removed entries (including offers and liquidity pools " +
+		"marked as deleted) are never exported, so state exports don't capture deletions.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireAndSetFlags(aurora.DatabaseURLFlagName); err != nil {
+			return err
+		}
+		if err := dbExportCmdOpts.RequireE(); err != nil {
+			return err
+		}
+		if err := dbExportCmdOpts.SetValues(); err != nil {
+			return err
+		}
+
+		if len(args) != 2 {
+			return ErrUsage{cmd}
+		}
+		ledgers := make([]uint32, 2)
+		for i, arg := range args {
+			seq, err := strconv.ParseUint(arg, 10, 32)
+			if err != nil {
+				cmd.Usage()
+				return fmt.Errorf(`invalid sequence number "%s"`, arg)
+			}
+			ledgers[i] = uint32(seq)
+		}
+
+		var names []string
+		if exportTables != "" {
+			names = strings.Split(exportTables, ",")
+		}
+		tables, err := export.FindTables(names)
+		if err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		destination, err := storage.ConnectBackend(exportDestination, storage.ConnectOptions{Context: ctx})
+		if err != nil {
+			return errors.Wrap(err, "could not connect to destination")
+		}
+		defer destination.Close()
+
+		dbConn, err := db.Open("postgres", globalConfig.DatabaseURL)
+		if err != nil {
+			return err
+		}
+		defer dbConn.Close()
+
+		exporter := &export.Exporter{
+			Session:       dbConn,
+			Storage:       destination,
+			PartitionSize: exportPartitionSize,
+		}
+		if err := exporter.Export(ctx, tables, ledgers[0], ledgers[1]); err != nil {
+			return err
+		}
+		hlog.Info("Export completed successfully!")
+		return nil
+	},
+}
+
 func runDBDetectGaps(config aurora.Config) ([]history.LedgerRange, error) {
 	auroraSession, err := db.Open("postgres", config.DatabaseURL)
 	if err != nil {
@@ -518,9 +747,17 @@ func init() {
 	if err := dbFillGapsCmdOpts.Init(dbFillGapsCmd); err != nil {
 		log.Fatal(err.Error())
 	}
+	if err := dbMigrateOnlineCmdOpts.Init(dbMigrateOnlineCmd); err != nil {
+		log.Fatal(err.Error())
+	}
+	if err := dbExportCmdOpts.Init(dbExportCmd); err != nil {
+		log.Fatal(err.Error())
+	}
 
 	viper.BindPFlags(dbReingestRangeCmd.PersistentFlags())
 	viper.BindPFlags(dbFillGapsCmd.PersistentFlags())
+	viper.BindPFlags(dbMigrateOnlineCmd.PersistentFlags())
+	viper.BindPFlags(dbExportCmd.PersistentFlags())
 
 	RootCmd.AddCommand(dbCmd)
 	dbCmd.AddCommand(
@@ -530,12 +767,22 @@ func init() {
 		dbReingestCmd,
 		dbDetectGapsCmd,
 		dbFillGapsCmd,
+		dbExportCmd,
 	)
 	dbMigrateCmd.AddCommand(
 		dbMigrateDownCmd,
 		dbMigrateRedoCmd,
 		dbMigrateStatusCmd,
 		dbMigrateUpCmd,
+		dbMigrateOnlineCmd,
+	)
+	dbMigrateOnlineCmd.AddCommand(
+		dbMigrateOnlineStatusCmd,
+		dbMigrateOnlineStartCmd,
+		dbMigrateOnlineBackfillCmd,
+		dbMigrateOnlineSwapCmd,
+		dbMigrateOnlineCleanupCmd,
+		dbMigrateOnlineAbortCmd,
 	)
 	dbReingestCmd.AddCommand(dbReingestRangeCmd)
 }
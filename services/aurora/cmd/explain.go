@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"encoding/json"
+	"go/types"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	hProtocol "github.com/shantanu-hashcash/go/protocols/aurora"
+	"github.com/shantanu-hashcash/go/services/aurora/internal/explain"
+	support "github.com/shantanu-hashcash/go/support/config"
+	"github.com/shantanu-hashcash/go/support/errors"
+	"github.com/shantanu-hashcash/go/support/log"
+)
+
+var (
+	explainKind              string
+	explainNetworkPassphrase string
+	explainAccountFiles      string
+)
+
+var explainCmdOpts = support.ConfigOptions{
+	{
+		Name:        "xdr-type",
+		ConfigKey:   &explainKind,
+		OptType:     types.String,
+		Required:    false,
+		FlagDefault: "",
+		Usage:       "[optional] type of the XDR: envelope, result or meta. Detected automatically when empty",
+	},
+	{
+		Name:        "explain-network-passphrase",
+		ConfigKey:   &explainNetworkPassphrase,
+		OptType:     types.String,
+		Required:    false,
+		FlagDefault: "",
+		Usage:       "[optional] network passphrase used to hash envelopes and verify their signatures",
+	},
+	{
+		Name:        "accounts",
+		ConfigKey:   &explainAccountFiles,
+		OptType:     types.String,
+		Required:    false,
+		FlagDefault: "",
+		Usage:       "[optional] comma separated list of files containing accounts as returned by /accounts/{id}, used to list required signers",
+	},
+}
+
+var explainCmd = &cobra.Command{
+	Use:   "explain [base64 XDR]",
+	Short: "prints a human-readable breakdown of transaction XDR",
+	Long: "Decodes a transaction envelope (including fee bump envelopes), transaction result or transaction " +
+		"meta and prints it in a human-readable form. Nothing is sent over the network. The XDR is read " +
+		"from standard input when it is not passed as an argument.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := explainCmdOpts.SetValues(); err != nil {
+			return err
+		}
+
+		var input string
+		switch len(args) {
+		case 0:
+			contents, err := io.ReadAll(os.Stdin)
+			if err != nil {
+				return errors.Wrap(err, "error reading standard input")
+			}
+			input = string(contents)
+		case 1:
+			input = args[0]
+		default:
+			return ErrUsage{cmd}
+		}
+
+		opts := explain.Options{
+			Kind:              explain.Kind(explainKind),
+			NetworkPassphrase: explainNetworkPassphrase,
+		}
+		for _, path := range strings.Split(explainAccountFiles, ",") {
+			if path = strings.TrimSpace(path); path == "" {
+				continue
+			}
+			contents, err := os.ReadFile(path)
+			if err != nil {
+				return errors.Wrapf(err, "error reading account file %s", path)
+			}
+			var account hProtocol.Account
+			if err = json.Unmarshal(contents, &account); err != nil {
+				return errors.Wrapf(err, "error decoding account file %s", path)
+			}
+			opts.Accounts = append(opts.Accounts, account)
+		}
+
+		return explain.Explain(cmd.OutOrStdout(), input, opts)
+	},
+}
+
+func init() {
+	if err := explainCmdOpts.Init(explainCmd); err != nil {
+		log.Fatal(err.Error())
+	}
+	viper.BindPFlags(explainCmd.PersistentFlags())
+	RootCmd.AddCommand(explainCmd)
+}
@@ -38,7 +38,6 @@ type App struct {
 	config          Config
 	webServer       *httpx.Server
 	historyQ        *history.Q
-	primaryHistoryQ *history.Q
 	ctx             context.Context
 	cancel          func()
 	auroraVersion  string
@@ -557,8 +556,8 @@ func (a *App) init() error {
 		SkipTxMeta: a.config.SkipTxmeta,
 	}
 
-	if a.primaryHistoryQ != nil {
-		routerConfig.PrimaryDBSession = a.primaryHistoryQ.SessionInterface
+	if replicaSession, ok := a.historyQ.SessionInterface.(*db.ReplicaSession); ok {
+		routerConfig.PrimaryDBSession = replicaSession.Primary
 	}
 
 	var err error
@@ -19,6 +19,10 @@ type Config struct {
 	Port               uint
 	AdminPort          uint
 
+	// RoDatabaseMaxStaleness is the largest replication lag at which the
+	// read replica still serves reads
+	RoDatabaseMaxStaleness time.Duration
+
 	EnableIngestionFiltering    bool
 	CaptiveCoreBinaryPath       string
 	CaptiveCoreConfigPath       string
@@ -9,7 +9,7 @@
 // migrations/16_ingest_failed_transactions.sql (509B)
 // migrations/17_transaction_fee_paid.sql (287B)
 // migrations/18_account_for_signers.sql (481B)
-// migrations/19_offers.sql (1.064kB)
+// migrations/19_offers.sql (1.063kB)
 // migrations/1_initial_schema.sql (9.977kB)
 // migrations/20_account_for_signer_index.sql (140B)
 // migrations/21_trades_remove_zero_amount_constraints.sql (765B)
@@ -61,9 +61,10 @@
 // migrations/62_claimable_balance_claimants.sql (1.428kB)
 // migrations/63_add_contract_id_to_asset_stats.sql (153B)
 // migrations/64_add_payment_flag_history_ops.sql (145B)
-// migrations/65_drop_payment_index.sql (260B)
+// migrations/65_drop_payment_index.sql (270B)
 // migrations/66_contract_asset_stats.sql (583B)
 // migrations/67_remove_unused_indexes.sql (2.897kB)
+// migrations/68_online_migrations.sql (640B)
 // migrations/6_create_assets_table.sql (366B)
 // migrations/7_modify_trades_table.sql (2.303kB)
 // migrations/8_add_aggregators.sql (907B)
@@ -137,7 +138,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _migrations10_add_trades_priceSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc4\x53\x51\x8b\xda\x4c\x14\x7d\x9f\x5f\x71\x1e\xf2\xa0\x7c\xfa\x15\x7d\xb5\x5b\x18\x93\xdb\x34\x10\xa3\x9d\x99\xd0\x16\x11\x99\x35\x63\x76\xc0\x4c\x64\x92\xc5\xdd\x7f\x5f\xb2\xba\xae\x2d\xec\x62\x5b\x68\xdf\x4e\x6e\xce\x5c\xce\xb9\x87\x33\x1c\xe2\xbf\xca\x96\x5e\xb7\x06\xf9\x9e\xb1\xe1\x10\xbc\x28\xe0\x75\x6b\x6b\xa7\x77\xd8\x7b\xbb\x31\x68\x6b\xb4\x5e\x17\xa6\x41\xab\x6f\x77\x86\xf1\x54\x91\x80\xe2\xd3\x94\x70\x67\x9b\xb6\xf6\x8f\xeb\x13\x81\x47\xd1\xf1\xd1\xda\x61\x9a\xc4\x49\xa6\x26\xd7\xd1\x8b\x33\xbd\x13\xa1\xcb\xd2\x9b\xb2\x53\xb5\xbd\x77\x9b\x4e\x0c\xb6\xb5\xc7\xd6\xba\xc2\xba\x12\x95\x75\xb6\x3a\xcb\x3b\xdc\x19\x77\x82\xb6\x81\x37\x7b\x6f\x1a\xe3\x5a\x53\x40\x37\xd0\x0e\xda\x7b\xfd\x88\x7a\x8b\xf6\x50\xc3\xec\x4c\x65\x5c\xdb\x60\xe9\x06\xc5\x8a\x85\x82\xb8\x22\xcc\x05\x04\x2d\x52\x1e\x12\x3e\xe6\x59\xa8\x92\x79\x86\xfd\xfd\xed\xce\x6e\xfe\xaf\xac\x5b\x1f\x25\xea\xb2\x44\x0f\x59\x3e\x23\x91\x84\xcb\xd5\xe0\x05\xf6\x19\x20\x48\xe5\x22\x93\x2f\x43\xa4\x3c\x8b\x73\x1e\x13\xe4\xe7\x14\xc9\x6c\x96\x1f\x4f\x20\x95\x48\x42\x05\x2e\x11\x04\x90\x94\x52\xa8\xd0\x63\x40\xc8\x25\xe1\xcb\x27\xca\x10\x8c\x96\xa3\xd5\xbb\x60\xb4\x1c\xaf\xde\x07\xe3\x27\x3c\x5e\x8e\x57\x50\xc7\x9f\xa0\x54\x12\x82\x31\x28\x8b\xfa\x08\x82\x09\x7b\xb6\xc1\xe3\x58\x50\xdc\xa1\x93\xf8\x59\x92\xad\x17\x22\x09\x09\x3d\x26\x3b\x67\xb8\xc1\x22\x9f\xa6\x49\xf8\xa3\xaf\x01\x9b\x72\x49\xea\xdb\x82\x70\x73\xe1\x90\xc9\x9f\x47\xac\x7f\x5d\x40\xfa\xe1\xef\x05\xa4\x1f\xfe\x61\x40\x1f\xfe\x30\x20\xfe\xf5\xd5\x80\x2e\x7d\xfd\x72\x40\xe7\x5a\x47\xf5\xc1\xbd\x55\xc1\x48\xcc\x17\xcf\x95\x7d\xb3\xab\x17\xc4\x62\xc2\xd8\xd3\xe7\xef\x94\x05\xfd\xee\x92\x21\x8f\x68\xf2\xca\x92\x2b\x02\xbd\x58\xf2\x3d\x00\x00\xff\xff\x19\x48\x96\x1e\xc4\x04\x00\x00")
+var _migrations10_add_trades_priceSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc4\x53\x51\x8b\xda\x40\x18\x7c\xdf\x5f\x31\x0f\x79\x38\xa9\xb6\xe8\x6b\x7a\x85\x75\xf3\x35\x0d\xc4\x68\xb3\x1b\xda\x22\x22\x7b\x66\xcd\x2d\xe8\x46\x36\x39\xf4\xfe\x7d\x89\x7a\x4a\x0b\x57\xa4\x07\xed\xdb\xf0\x65\xbe\x8f\x99\xcc\xce\x60\x80\x77\x5b\x5b\x79\xdd\x1a\x14\x3b\xc6\x06\x03\xf0\xb2\x84\xd7\xad\xad\x9d\xde\x60\xe7\xed\xca\xa0\xad\xd1\x7a\x5d\x9a\x06\xad\x7e\xd8\x18\xc6\x53\x45\x39\x14\x1f\xa7\x84\x47\xdb\xb4\xb5\x7f\x5e\x9e\x09\x3c\x8a\x4e\x4b\x4b\x87\x71\x12\x27\x99\x0a\x6f\xa3\x97\x17\x7a\x27\x42\x57\x95\x37\x55\xa7\x6a\xfd\xe4\x56\x9d\x18\xac\x6b\x8f\xb5\x75\xa5\x75\x15\xb6\xd6\xd9\xed\x45\xde\xfe\xd1\xb8\x33\xb4\x0d\xbc\xd9\x79\xd3\x18\xd7\x9a\x12\xba\x81\x76\xd0\xde\xeb\x67\xd4\x6b\xb4\xfb\x1a\x66\x63\xb6\xc6\xb5\x0d\xe6\xae\x5f\x2e\x98\xc8\x89\x2b\xc2\x34\x47\x4e\xb3\x94\x0b\xc2\xe7\x22\x13\x2a\x99\x66\xd8\x3d\x3d\x6c\xec\xea\xfd\xd6\xba\xe5\xf1\xf8\x52\x57\x15\xee\x90\x15\x13\xca\x13\x31\x5f\xf4\xaf\xb0\xc7\x80\x9c\x54\x91\x67\xf2\x3a\x44\xca\xb3\xb8\xe0\x31\x41\x7e\x4d\x91\x4c\x26\xc5\xe9\x8f\x49\x95\x27\x42\x81\x4b\x04\x01\x24\xa5\x24\x14\xee\x18\x20\xb8\x24\x7c\xfb\x42\x19\x82\xe1\x7c\xb8\xf8\x10\x0c\xe7\xa3\xc5\xc7\x60\x74\xc4\xa3\xf9\x68\x01\x75\xfa\x08\x4a\x25\x21\x18\x81\xb2\xa8\x87\x20\x08\xd9\x8b\x0d\x1e\xc7\x39\xc5\x1d\x3a\x8b\x9f\x24\xd9\x72\x96\x27\x82\x70\xc7\x64\xe7\x0c\xf7\x98\x15\xe3\x34\x11\xbf\xfa\xea\xb3\x31\x97\xa4\x7e\xcc\x08\xf7\x57\x07\x7d\x26\x7f\x1f\xb1\xde\x6d\x01\xe9\xc3\xbf\x0b\x48\x1f\xfe\x63\x40\x9f\xde\x18\x10\xff\xfe\x6a\x40\xfa\xf0\x86\x80\x2e\xb5\x8e\xea\xbd\xfb\x53\x05\xa3\x7c\x3a\x7b\xa9\x6c\x78\x23\xb1\x0c\x19\x3b\xee\xfd\x4d\x59\xd0\xeb\x9e\xba\xe0\x11\x85\xaf\x1c\xb9\x21\x50\xf4\x20\xb8\x14\x3c\xa2\xf0\xe7\x00\x19\x48\x96\x1e\xc4\x04\x00\x00")
 
 func migrations10_add_trades_priceSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -157,7 +158,7 @@ func migrations10_add_trades_priceSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations11_add_trades_account_indexSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd2\xd5\x55\xd0\xce\xcd\x4c\x2f\x4a\x2c\x49\x55\x08\x2d\xe0\xe2\x72\x0e\x72\x75\x0c\x71\x55\xf0\xf4\x73\x71\x8d\x50\xc8\x28\x29\x4a\x89\x4f\xaa\x8c\x4f\x4a\x2c\x4e\x8d\x4f\x4c\x4e\xce\x2f\xcd\x2b\x51\xf0\xf7\x53\xc8\xc8\x2c\x2e\xc9\x2f\xaa\x8c\x2f\x29\x4a\x4c\x49\x2d\x56\x08\x0d\xf6\xf4\x73\x57\x70\x0a\x09\x72\x75\xd5\x40\x56\x1a\x9f\x99\xa2\x69\x8d\xdd\x44\xb0\x7c\x6a\x11\x91\x86\xa2\xa9\x86\x98\xcb\x85\xec\x74\x97\xfc\xf2\x3c\x2e\x2e\x97\x20\xff\x00\x3c\x4e\xb7\xc6\xa6\x00\xcd\x6c\x6b\x40\x00\x00\x00\xff\xff\x57\x79\x94\x68\x11\x01\x00\x00")
+var _migrations11_add_trades_account_indexSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd2\xd5\x55\xd0\xce\xcd\x4c\x2f\x4a\x2c\x49\x55\x08\x2d\xe0\xe2\x72\x0e\x72\x75\x0c\x71\x55\xf0\xf4\x73\x71\x8d\x50\xc8\x28\x29\x4a\x89\x4f\xaa\x8c\x4f\x4a\x2c\x4e\x8d\x4f\x4c\x4e\xce\x2f\xcd\x2b\x51\xf0\xf7\x53\xc8\xc8\x2c\x2e\xc9\x2f\xaa\x8c\x2f\x29\x4a\x4c\x49\x2d\x56\x08\x0d\xf6\xf4\x73\x57\x70\x0a\x09\x72\x75\xd5\x40\x56\x1a\x9f\x99\xa2\x69\x8d\xdd\x44\xb0\x51\xa9\x45\x44\x1a\x8a\xa6\x1a\x62\x2e\x17\xb2\xd3\x5d\xf2\xcb\xf3\xb8\xb8\x5c\x82\xfc\x03\xf0\x38\xdd\x1a\x9b\x02\x34\xb3\xad\x01\x03\x00\x57\x79\x94\x68\x11\x01\x00\x00")
 
 func migrations11_add_trades_account_indexSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -177,7 +178,7 @@ func migrations11_add_trades_account_indexSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations12_asset_stats_amount_stringSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd2\xd5\x55\xd0\xce\xcd\x4c\x2f\x4a\x2c\x49\x55\x08\x2d\xe0\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x48\x2c\x2e\x4e\x2d\x89\x2f\x2e\x49\x2c\x29\x86\x8a\x3b\xfb\xfb\x84\xfa\xfa\x29\x24\xe6\xe6\x97\xe6\x95\x28\x04\xbb\x86\x28\xb8\x38\x86\x38\x2a\x84\x44\x06\xb8\x2a\x24\x67\x24\x16\x25\x26\x97\xa4\x16\x29\x94\x25\x16\x55\x66\xe6\xa5\x5b\x73\x71\x21\x1b\xee\x92\x5f\x9e\x47\x81\xf1\x49\x99\xe9\x99\x79\x25\x0a\xa1\xc1\x9e\x7e\xee\x50\x15\x56\x56\x10\x41\x6b\x2e\x40\x00\x00\x00\xff\xff\x00\x82\x0f\xf1\xc5\x00\x00\x00")
+var _migrations12_asset_stats_amount_stringSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\xcc\xb1\x0a\xc2\x30\x10\x87\xf1\x3d\x4f\xf1\xdf\xa5\x2f\xd0\x4e\xd1\x06\x11\x6a\x15\x9b\x0c\x4e\x72\x2d\x21\xde\xd0\xab\x24\xa7\xe2\xdb\xbb\x74\x70\x77\xfd\xf8\xf8\x55\x15\x36\x33\xa7\x4c\x1a\x11\x1e\xc6\x76\xde\x5d\xe0\xed\xb6\x73\xa0\x52\xa2\xde\x8a\x92\x96\xb5\xef\x4e\x5d\x38\xf6\xa0\x79\x79\x8a\x62\x70\x1e\xad\xf5\x16\xfe\x7a\x76\x98\xee\x94\x69\xd2\x98\xf1\xa2\xfc\x61\x49\x8d\x31\xbf\x78\xbb\xbc\xe5\x0f\x7e\xe4\xc4\xa2\x08\xc3\xa1\xdf\xaf\x47\x5d\x8f\x9c\x58\xb4\x31\xdf\x01\x00\x00\x82\x0f\xf1\xc5\x00\x00\x00")
 
 func migrations12_asset_stats_amount_stringSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -197,7 +198,7 @@ func migrations12_asset_stats_amount_stringSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations13_trade_offer_idsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\x90\xc1\x8a\x83\x30\x14\x45\xf7\xef\x2b\xde\x72\x64\xf0\x0b\xb2\x8a\x26\x48\xc0\x89\x83\x13\x61\x76\x41\x6b\xac\x2e\x6a\x4a\x4c\x29\xfe\x7d\xa1\x9b\x1a\x6b\x75\x1d\xce\x79\x37\x27\x8e\xf1\xfb\x32\x9c\x5d\xed\x0d\x56\x57\x00\x9a\x2b\x5e\xa2\xa2\x49\xce\xb1\x1f\x26\x6f\xdd\xac\xbd\xab\x5b\x33\x21\x65\x0c\x9b\x7a\x32\xda\x76\x9d\x71\x7a\x68\x31\x11\x99\x90\x8a\x1c\x41\x27\x7b\x1b\xbd\x71\xef\x1c\xa4\x25\xa7\x8a\xa3\x90\x8c\xff\x63\xef\x5d\xab\x9b\x59\xbf\x6e\x60\x21\xd7\xbe\xea\x4f\xc8\x0c\x1b\xef\x8c\xf9\x0a\xc6\x44\x64\xdb\x16\x1c\x3f\x10\xae\x87\x46\x04\x60\x19\x88\xd9\xfb\x08\xc0\xca\xe2\xf7\xe3\x64\xb2\xf5\x1c\x78\xc9\x6e\xe4\x27\x9d\x16\x79\xf5\x23\xc3\xd8\xbb\x95\x97\xd4\xfa\x13\x04\x1e\x01\x00\x00\xff\xff\x6c\x7b\xed\xfa\xe4\x01\x00\x00")
+var _migrations13_trade_offer_idsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x90\xc1\x8e\x82\x30\x14\x45\xf7\xef\x2b\xde\x72\xc8\x84\x2f\xe8\xaa\xd0\x86\x34\x61\xca\x04\x4b\xe2\xae\x01\x29\xc2\x42\x6a\x4a\x8d\xe1\xef\x4d\xdc\x68\x11\xc5\xf5\xcb\x39\xf7\xbe\x1b\xc7\xf8\x7b\x1a\x8e\xae\xf6\x06\xab\x33\x00\xcd\x15\x2f\x51\xd1\x24\xe7\xd8\x0f\x93\xb7\x6e\xd6\xde\xd5\xad\x99\x90\x32\x86\x4d\x3d\x19\x6d\xbb\xce\x38\x3d\xb4\x98\x88\x4c\x48\x45\xb6\xa0\x83\xbd\x8c\xde\xb8\x57\x0e\xd2\x92\x53\xc5\x51\x48\xc6\xf7\xd8\x7b\xd7\xea\x66\xd6\x8f\x0c\x2c\xe4\xd2\x57\xed\x84\xcc\xb0\xf1\xce\x98\x9f\xa0\x4c\x44\xd6\x6d\x41\xf8\x86\x70\x59\x34\x22\x00\xcf\x03\x31\x7b\x1d\x01\x58\x59\xfc\xbf\xad\x4c\xd6\xce\x81\x97\x7c\x1c\xf9\x4e\xa7\x45\x5e\xfd\xc9\x70\x6c\xf2\x2d\xb5\x7c\x82\xc0\x6d\x00\x6c\x7b\xed\xfa\xe4\x01\x00\x00")
 
 func migrations13_trade_offer_idsSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -217,7 +218,7 @@ func migrations13_trade_offer_idsSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations14_fix_asset_toml_fieldSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd2\xd5\x55\xd0\xce\xcd\x4c\x2f\x4a\x2c\x49\x55\x08\x2d\xe0\xe2\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x48\x2c\x2e\x4e\x2d\x89\x2f\x2e\x49\x2c\x29\x56\x80\x88\x3b\xfb\xfb\x84\xfa\xfa\x29\x94\xe4\xe7\xe6\x28\x84\x44\x06\xb8\x2a\x94\x25\x16\x25\x67\x24\x16\x69\x18\x99\x9a\x6a\x5a\x73\x71\x21\x9b\xe6\x92\x5f\x9e\x47\xb6\x79\x66\x26\x9a\xd6\x5c\x80\x00\x00\x00\xff\xff\xac\xf9\x96\x09\x9c\x00\x00\x00")
+var _migrations14_fix_asset_toml_fieldSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd2\xd5\x55\xd0\xce\xcd\x4c\x2f\x4a\x2c\x49\x55\x08\x2d\xe0\xe2\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x48\x2c\x2e\x4e\x2d\x89\x2f\x2e\x49\x2c\x29\x56\x80\x88\x3b\xfb\xfb\x84\xfa\xfa\x29\x94\xe4\xe7\xe6\x28\x84\x44\x06\xb8\x2a\x94\x25\x16\x25\x67\x24\x16\x69\x18\x99\x9a\x6a\x5a\x73\x71\x21\x9b\xe6\x92\x5f\x9e\x47\xb6\x79\x66\x26\x9a\xd6\x5c\x80\x01\x00\xac\xf9\x96\x09\x9c\x00\x00\x00")
 
 func migrations14_fix_asset_toml_fieldSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -237,7 +238,7 @@ func migrations14_fix_asset_toml_fieldSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations15_ledger_failed_txsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\xce\x41\xae\x82\x30\x10\x00\xd0\x7d\x4f\x31\xfb\x1f\x4e\xc0\xaa\xdf\xe2\x6a\x04\x43\xe8\x9a\x34\x65\xc0\x26\xd8\x9a\xce\x34\xc6\xdb\xbb\x65\x63\xf5\x02\x2f\xaf\x69\xe0\xef\x1e\xb6\xec\x84\xc0\x3e\x94\xd2\x38\x75\x23\x4c\xfa\x1f\x3b\xb8\x05\x96\x94\x5f\xf3\x4e\xcb\x46\x99\x41\x1b\x03\x5c\xbc\x27\xe6\xb5\xec\xb3\x64\x17\xd9\x79\x09\x29\xce\x3e\x95\x28\x10\xa2\xd0\x46\x19\x4c\x77\xd6\x16\x27\xe8\x2d\x62\xfb\x95\x5c\x5d\xd8\x69\xf9\x99\x53\xc7\xb2\x49\xcf\x58\x4f\x9b\x71\xb8\xc2\x69\x40\x7b\xe9\xab\xf9\xfa\xf3\xa8\x7c\xfa\xb6\xea\x1d\x00\x00\xff\xff\x1f\x77\x76\xa0\x4d\x01\x00\x00")
+var _migrations15_ledger_failed_txsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\xce\x41\xae\x82\x30\x10\x00\xd0\x7d\x4f\x31\xfb\x1f\x4e\xc0\xaa\xdf\xe2\x6a\x04\x43\xe8\x9a\x34\x65\xc0\x26\xb5\x35\x9d\x69\x8c\xb7\x77\xeb\x46\xe4\x02\x2f\xaf\x69\xe0\xef\x1e\xb6\xe2\x84\xc0\x3e\x94\xd2\x38\x75\x23\x4c\xfa\x1f\x3b\xb8\x05\x96\x5c\x5e\x73\xa4\x65\xa3\xc2\xa0\x8d\x01\xae\xde\x13\xf3\x5a\xe3\x2c\xc5\x25\x76\x5e\x42\x4e\xb3\xcf\x35\x09\x84\x24\xb4\x51\x01\xd3\x9d\xb5\xc5\x09\x7a\x8b\xd8\xfe\x24\x57\x17\x22\x2d\x87\x39\xf5\x59\x36\xf9\x99\xf6\xd3\x66\x1c\xae\x70\x1a\xd0\x5e\xfa\xdd\x7c\x7b\x58\xf9\xf6\x6d\xd5\x7b\x00\x1f\x77\x76\xa0\x4d\x01\x00\x00")
 
 func migrations15_ledger_failed_txsSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -257,7 +258,7 @@ func migrations15_ledger_failed_txsSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations16_ingest_failed_transactionsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\x90\xcd\x6a\xeb\x30\x14\x84\xf7\x7a\x8a\xb9\xeb\x8b\x5d\xe8\x36\x64\xe1\xc6\xea\x0f\x38\x49\x71\x6c\xba\x0c\x8a\x75\xd4\x88\x5a\x3a\x41\x72\x5a\xfa\xf6\x05\xa7\x24\x36\x08\xba\x94\x98\xf9\xf4\x8d\xb2\x0c\xff\x9d\x7d\x0f\x6a\x20\xb4\x27\x21\xb2\x0c\xab\x23\x75\x1f\xd0\x87\xfb\xbb\xa3\x8d\x03\x87\xef\xbc\x09\xca\x47\xd5\x0d\x96\x7d\xbe\x3b\x77\x1d\xc5\x68\xce\x3d\x8c\xa5\x5e\xa3\x63\xe7\xc8\x0f\x30\x1c\xe0\x38\x10\xac\x37\x1c\x9c\x1a\xd3\xa2\xa8\x1a\x59\xa3\x29\x1e\x2a\x89\x5f\xdc\x7e\xb8\xe1\x22\x8a\xb2\x44\xbc\x31\x0f\xcc\x3d\x29\xbf\x18\x4d\xae\x66\x25\x7f\xf9\xf1\xa6\x26\xc7\x9f\x04\xa3\x6c\x4f\x1a\x33\x90\xf2\x1a\x7c\xa2\xa0\x2e\x47\x13\xd8\xa5\x72\xff\x44\x29\x2b\xd9\x48\x3c\xd6\xdb\xf5\x55\x69\x52\x6c\x77\x2f\x9b\xa7\xa4\xab\x00\xde\x9e\x65\x9d\x1e\x92\x5b\x8d\x65\x82\x97\x4f\x42\x7b\xab\x51\x6c\x66\x7b\x97\x30\xaa\x8f\xb4\x48\x5a\xcd\xf6\x5d\x9e\x4e\x55\xff\xfc\xe3\xb2\xde\xbe\x62\xb5\xad\xda\xf5\x66\x02\x58\x88\x9f\x00\x00\x00\xff\xff\xe4\xf5\x4c\xc4\xfd\x01\x00\x00")
+var _migrations16_ingest_failed_transactionsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\x90\xcd\x6a\xeb\x30\x14\x84\xf7\x7a\x8a\xb9\xeb\x8b\x5d\xe8\x36\x64\xe1\xc6\xea\x0f\x38\x76\x71\x6c\xba\x0c\x8a\x75\xd4\x88\x5a\x3a\x41\x72\x5a\xfa\xf6\x05\x13\x1a\x07\x04\xdd\x8a\x39\xdf\x7c\xa3\x2c\xc3\x7f\x67\xdf\x83\x9a\x08\xfd\x49\x88\x2c\xc3\xe6\x48\xc3\x07\xf4\xe1\xfe\xee\x68\xe3\xc4\xe1\x3b\xef\x82\xf2\x51\x0d\x93\x65\x9f\xef\xce\xc3\x40\x31\x9a\xf3\x08\x63\x69\xd4\x18\xd8\x39\xf2\x13\x0c\x07\x38\x0e\x04\xeb\x0d\x07\xa7\xe6\xb4\x28\xaa\x4e\xb6\xe8\x8a\x87\x4a\xe2\x82\xdb\x4f\x57\x5c\x44\x51\x96\x88\x57\xe6\x81\x79\x24\xe5\x57\x42\x2c\xcd\x4a\xfe\xf2\xf3\x4b\x4b\x8e\x3f\x09\x46\xd9\x91\x34\x6e\x40\xca\x6b\xf0\x89\xc2\x5c\x1c\x61\x02\xbb\x54\xee\x9f\x28\x65\x25\x3b\x89\xc7\xb6\xd9\xfe\x2a\x2d\x0e\xfb\xdd\x4b\xfd\x94\x74\x15\xc0\xdb\xb3\x6c\xd3\x43\x72\xab\xb1\x4e\xf0\xf2\x45\x68\x6f\x35\x8a\xfa\x66\xef\x1a\x46\x8d\x91\x56\x49\xab\x25\xff\x52\x9d\x3a\xfd\xf3\x8f\xcb\xb6\x79\xc5\xa6\xa9\xfa\x6d\xbd\xe8\x5e\x89\x9f\x01\x00\xe4\xf5\x4c\xc4\xfd\x01\x00\x00")
 
 func migrations16_ingest_failed_transactionsSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -277,7 +278,7 @@ func migrations16_ingest_failed_transactionsSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations17_transaction_fee_paidSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\xce\x31\x0e\xc2\x20\x14\x80\xe1\x9d\x53\xbc\xdd\xf4\x04\x9d\x50\xd8\x68\x31\x84\xce\xe4\xa5\x7d\xa5\x0c\x85\x06\x48\xd4\xdb\x1b\x4d\x4d\xba\xa9\x07\xf8\xff\x7c\x4d\x03\xa7\x35\xf8\x8c\x95\x60\xd8\x18\xe3\xca\x4a\x03\x96\x9f\x95\x84\x25\x94\x9a\xf2\xc3\xd5\x8c\xb1\xe0\x58\x43\x8a\x05\xb8\x10\x30\x13\xb9\x71\xc1\xec\x69\x82\x10\x2b\x79\xca\xed\xf7\xd2\xc8\x9e\x77\x12\x2e\x5a\x0d\x5d\xff\x7e\x6c\x18\x26\xb0\x1a\x56\xbc\xbb\x99\xa8\x65\xec\xc8\x11\xe9\x16\x7f\x00\x09\xa3\xaf\xc7\xe9\x0e\xfb\x1b\xb4\x23\x5e\x9e\x8f\xad\x65\xcf\x00\x00\x00\xff\xff\x1a\x59\x3c\x98\x1f\x01\x00\x00")
+var _migrations17_transaction_fee_paidSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\xce\x3d\x8e\xc3\x20\x10\x40\xe1\x9e\x53\x4c\xbf\xf2\x09\x5c\xb1\x81\xce\x3f\x91\x65\xd7\x68\x64\x06\x3c\x85\x01\x01\x52\x92\xdb\x47\x89\x5c\xb8\x4b\x72\x80\xf7\xf4\x35\x0d\xfc\xed\xec\x33\x56\x82\x25\x09\x21\xbb\x59\x4f\x30\xcb\xff\x4e\xc3\xc6\xa5\xc6\xfc\x30\x35\x63\x28\xb8\x56\x8e\xa1\x80\x54\x0a\x1c\x91\x59\x37\xcc\x9e\x2c\x70\xa8\xe4\x29\xb7\x9f\xcb\x49\x0f\xb2\xd7\x70\x19\xbb\xa5\x1f\xde\x8f\x84\x6c\x61\x1e\x61\xc7\xbb\x71\x44\xad\x10\x67\x8e\x8a\xb7\xf0\x05\x48\x4d\xe3\xf5\x3c\x3d\x60\x3f\x83\x0e\xc4\xcb\xe3\x88\x4c\x42\xb6\xad\x78\x0e\x00\x1a\x59\x3c\x98\x1f\x01\x00\x00")
 
 func migrations17_transaction_fee_paidSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -297,7 +298,7 @@ func migrations17_transaction_fee_paidSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations18_account_for_signersSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x91\xbf\x6e\xf2\x40\x10\xc4\x7b\x3f\xc5\x94\xb6\x3e\xdc\x7c\x0a\x69\xa8\x48\x70\x11\x85\x00\xb2\xa0\xa0\x42\x97\xcb\xc6\x5e\xd9\xbe\x23\x77\x6b\xac\x7b\xfb\x08\x5b\x46\x80\x44\xda\xd9\xdf\xfe\x99\x9d\x34\xc5\xbf\x86\x0b\xa7\x84\xb0\x3b\x46\xd1\x6b\x9e\xcd\xb7\x19\xb6\xf3\x97\x65\x06\xa5\xb5\x6d\x8d\xf8\x83\xe7\xc2\x90\xf3\x88\x23\x00\xa3\x0c\x5d\x2a\xa7\xb4\x90\xc3\x49\xb9\xc0\xa6\x88\x9f\x9f\x92\x49\x8f\x0c\x0d\x7f\x11\x1d\x71\x51\x0a\xd8\x08\x15\xe4\xb0\x5a\x6f\xb1\xda\x2d\x97\x43\x31\x4d\xd1\x11\x3a\xae\x6b\xfc\xb4\xe4\x02\x3e\xc3\x38\xd2\x5b\x48\xa9\x04\xec\xd1\x95\x17\x95\x3d\xa4\x24\x7c\xb3\xf3\x02\x16\x6a\xc0\xa6\x57\xb4\x6d\x8e\xd6\xb3\x10\x2a\x0a\xfd\xec\x4d\xfe\xf6\x31\xcf\xf7\x78\xcf\xf6\x88\x87\xf6\xc9\x68\x29\x89\x92\xd9\xdd\x0f\x2a\x0a\x87\x93\xaa\x5b\x3a\x78\xb1\x8e\xfa\x17\x54\x14\xce\x86\xce\xee\xe2\xff\xd3\x69\x72\x73\x7d\x0f\x3f\x2e\xdf\xac\xaf\x28\x0c\x2b\xaf\x63\x58\xd8\xce\x44\x8b\x7c\xbd\x79\x94\x82\x56\x5e\xab\x2f\x9a\x5d\x43\xf7\x67\x5e\x98\xdf\x00\x00\x00\xff\xff\xbf\x12\xc9\xd9\xe1\x01\x00\x00")
+var _migrations18_account_for_signersSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x91\xbf\x6e\xf2\x40\x10\xc4\xfb\x7b\x8a\x29\x6d\x7d\xb8\xf9\x14\xd2\x50\x91\xe0\x22\x0a\x01\x64\x41\x41\x85\x2e\xc7\xc6\x5e\x19\xee\xc8\xdd\x1a\xeb\xde\x3e\xc2\x96\x23\x40\x22\xed\xec\x6f\xff\xcc\x4e\x96\xe1\xdf\x91\x4b\xaf\x85\xb0\x39\x29\xf5\x5a\xe4\xd3\x75\x8e\xf5\xf4\x65\x9e\x43\x1b\xe3\x1a\x2b\x61\x17\xb8\xb4\xe4\x03\x12\x05\x60\x90\x61\x2a\xed\xb5\x11\xf2\x38\x6b\x1f\xd9\x96\xc9\xf3\x53\x3a\xea\x90\xbe\xe1\x2f\xa2\x25\x2e\x2b\x01\x5b\xa1\x92\x3c\x16\xcb\x35\x16\x9b\xf9\xbc\x2f\x66\x19\x5a\x42\xcb\x87\x03\xbe\x1b\xf2\x11\x9f\x71\x18\x19\x1c\xa4\xd2\x02\x0e\x68\xab\x5f\x95\x03\xa4\x22\x7c\xb1\x0f\x02\x16\x3a\x82\x6d\xa7\x18\x77\x3c\xb9\xc0\x42\xa8\x29\x76\xb3\x57\xc5\xdb\xc7\xb4\xd8\xe2\x3d\xdf\x22\xe9\xdb\x47\x83\xa5\x54\xa5\x93\xbb\x1f\xd4\x14\x77\x67\x7d\x68\x68\x17\xc4\x79\xea\x5e\x50\x53\xbc\x18\xba\xb8\x4b\xfe\x8f\xc7\xe9\xcd\xf5\x1d\xfc\xb8\x7c\xb3\xbe\xa6\xd8\xaf\xbc\x8e\x61\xe6\x5a\xab\x66\xc5\x72\xf5\x28\x05\xa3\x83\xd1\x7b\x9a\x5c\x43\xf7\x67\x1a\x1d\x8c\xde\xd3\x44\xfd\x0c\x00\xbf\x12\xc9\xd9\xe1\x01\x00\x00")
 
 func migrations18_account_for_signersSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -317,7 +318,7 @@ func migrations18_account_for_signersSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations19_offersSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x93\x41\x6f\xda\x4c\x10\x86\xef\xfe\x15\xef\x0d\xd0\x07\xd1\x77\x69\x2f\x39\x91\xd8\x4d\xac\x52\x3b\x32\xa6\x6d\x4e\xd6\x62\x8f\xcd\x88\x65\x17\xed\xae\x43\xdc\x5f\x5f\xad\x0d\xa8\x89\x80\xf3\xfb\xce\x73\x78\x66\x66\x36\xc3\x7f\x3b\x6e\x8c\x70\x84\xd5\x3e\x08\x1e\xb3\x68\x9e\x47\xc8\xe7\x0f\x8b\x08\xba\xae\xc9\x58\x8c\x03\x00\xb0\x24\x25\x19\xae\x50\x6e\x84\x11\xa5\x23\x83\x37\x61\x3a\x56\xcd\xf8\xcb\xd7\x09\x92\x34\x47\xb2\x5a\x2c\xa6\x7d\xb9\x9f\xe4\x0a\x6b\x6e\x58\x39\xbc\x64\xf1\x8f\x79\xf6\x8a\xef\xd1\xeb\xf4\x0c\x63\xd5\x08\x6b\xc9\xc1\xd1\xbb\xfb\x34\xbf\x6e\xbb\x5b\xb1\xd8\xe9\x56\xb9\x13\xfd\x63\xb6\x37\x5c\x92\x02\x2b\x47\x0d\x99\x4b\x61\x75\x2b\x44\xa5\xdb\xb5\x24\xec\x0d\x95\x6c\x59\xab\x4f\xa5\x5a\x8a\xc6\x5e\x01\x48\x61\x5d\xb1\xd3\x15\xd7\x4c\x55\x21\xa9\xf2\x95\x38\xc9\xcf\xb5\x60\x72\x7f\x56\x1c\x27\x61\xf4\xfb\xa8\xb8\x58\x77\xc5\xe0\x17\x69\x72\xd2\xbe\x5a\xc6\xc9\x13\x1e\xf2\x2c\x8a\xc6\x27\xf9\x93\xfb\x5b\xe3\xac\x9a\x62\x70\x76\x9d\x72\xd2\x7a\x9d\x34\xb8\xbf\x09\xfa\x67\x3d\xd7\x39\x17\x6d\x5c\xe6\x5d\xaa\x7a\x55\xb3\x19\x42\xb6\xce\xf0\xba\x75\xfd\xda\x1a\xb2\xce\xef\xc4\x90\x64\xb2\xd0\x0a\x02\x96\x55\x23\x09\x6f\x42\xb6\x04\xa9\xcb\x2d\x55\xa8\xb5\x41\xbb\xaf\x84\x63\xd5\x78\x0a\xfb\x62\xf8\x70\x87\x5f\x1b\x52\x78\xd6\x86\xff\x68\x05\xeb\x84\x71\x16\xa5\x24\x61\xe0\x36\x64\x08\x6c\xa1\xf4\x91\x65\x35\x0e\x84\xd2\x90\x7f\x0e\x76\x9e\xe3\x3b\x77\x88\xeb\xa1\x3d\xb2\x10\x28\xb5\xaa\x25\x97\x0e\xec\xb0\x23\xa1\xac\xcf\x8e\x04\xb6\x10\xd2\x90\xa8\xba\x23\xde\x6a\x4f\x39\xf8\x2b\x83\xd2\x6e\xc3\xaa\xb9\x0b\xe2\x64\x19\x65\xb9\x3f\x94\x14\x5b\xea\x8a\x7e\xb6\xb0\x4e\x1b\xc2\x78\x4b\xdd\x74\xa0\x4d\xfa\x1b\xfb\x39\x5f\xac\xa2\x25\xc6\x23\x7a\xdf\x17\x83\x90\xc1\xf4\x60\x6d\x34\xc5\xe8\xff\xd1\x50\x4d\x13\x3c\xa6\xc9\xb7\x45\xfc\x98\xf7\x9c\x09\xc2\xd4\x9f\xe2\x73\x9c\x3c\x0d\x72\xcf\xaf\x1f\xea\x83\x0a\x82\x30\x4b\x5f\x3e\xbe\x7e\x29\x6c\x29\x2a\xba\x0f\xfe\x06\x00\x00\xff\xff\x14\xd1\xb7\xec\x28\x04\x00\x00")
+var _migrations19_offersSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x93\x41\x6f\xda\x4c\x10\x86\xef\xfe\x15\xef\x0d\xd0\x87\xa3\xef\xd2\x5e\x72\x22\xb1\x9b\x5a\xa5\x76\xe4\x98\xb6\x39\x59\x8b\x77\x6c\x46\x2c\xbb\x68\x77\x1d\xe2\x7f\x5f\xd9\x06\xd4\x44\xc0\xf9\x7d\xe7\xd1\xea\x99\x9d\x30\xc4\x7f\x3b\x6e\xac\xf0\x84\xd5\x3e\x08\x1e\xf3\x78\x51\xc4\x28\x16\x0f\xcb\x18\xa6\xae\xc9\x3a\x4c\x03\x00\x70\xa4\x14\x59\x96\xa8\x36\xc2\x8a\xca\x93\xc5\x9b\xb0\x1d\xeb\x66\xfa\xe5\xeb\x0c\x69\x56\x20\x5d\x2d\x97\xf3\xa1\x3c\x4c\xb2\xc4\x9a\x1b\xd6\x1e\xcf\x79\xf2\x73\x91\xbf\xe2\x47\xfc\x3a\x3f\xc3\x58\x37\xc2\x39\xf2\xf0\xf4\xee\x3f\xcd\xaf\xdb\xee\x56\x2c\x76\xa6\xd5\xfe\x44\xff\x98\xed\x2d\x57\xa4\xc1\xda\x53\x43\xf6\x52\x28\x6f\x85\x90\xa6\x5d\x2b\xc2\xde\x52\xc5\x8e\x8d\xfe\x54\xaa\x95\x68\xdc\x15\x80\x12\xce\x97\x3b\x23\xb9\x66\x92\xa5\x22\xd9\x3f\x20\x49\x8b\x73\x2d\x98\xdd\x9f\x15\x27\x69\x14\xff\x39\x2a\x2e\xd7\x5d\xd9\x2b\x21\x8b\x2c\x3d\x69\x5f\xbd\x24\xe9\x13\x1e\x8a\x3c\x8e\xa7\x27\xf9\xb3\xfb\x5b\xe3\xac\x9b\x72\x74\x76\x9d\x72\xd2\x7a\x9d\x34\xba\xbf\x09\xfa\x67\x3d\xd7\x39\x17\x6d\x5c\xe6\x5d\xaa\xf6\xaa\xc2\x10\x11\x3b\x6f\x79\xdd\xfa\x61\x6d\x0d\x39\xdf\xef\xc4\x92\x62\x72\x30\x1a\x02\x8e\x75\xa3\x08\x6f\x42\xb5\x04\x65\xaa\x2d\x49\xd4\xc6\xa2\xdd\x4b\xe1\x59\x37\x41\x18\x82\xfb\x62\xf4\x70\x87\xdf\x1b\xd2\x58\xb4\xd6\x58\x01\xe7\x85\xf5\x0e\x95\x22\x61\xe1\x37\x64\x09\xec\xa0\xcd\x11\xe5\x0c\x0e\x84\xca\x52\x7f\x1b\xec\x7b\x4c\xdf\xb9\x43\x52\x8f\xed\x89\x83\x40\x65\x74\xad\xb8\xf2\x60\x8f\x1d\x09\xed\xfa\xec\x48\x60\x07\xa1\x2c\x09\xd9\x1d\xf1\xce\xf4\x94\x03\x41\x1a\x68\xe3\x37\xac\x9b\xbb\x20\x49\x5f\xe2\xbc\x40\x92\x16\x19\xb6\xd4\x95\xc3\x6c\xe9\xbc\xb1\x84\xe9\x96\xba\xf9\x48\x9b\x0d\x5f\xec\xd7\x62\xb9\x8a\x5f\x30\x9d\xd0\xfb\xbe\x1c\x7d\x94\x83\xbd\x51\xda\x64\x8e\xc9\xff\x93\xb1\x9a\xa5\x78\xcc\xd2\x6f\xcb\xe4\xb1\x18\x38\x33\x44\x59\xff\x13\xbf\x27\xe9\xd3\xe8\xf6\x7c\xf9\x91\x39\xe8\x20\x88\xf2\xec\xf9\xe3\xe5\x57\xc2\x55\x42\xd2\x7d\xf0\x77\x00\xb8\x90\xe7\xe4\x27\x04\x00\x00")
 
 func migrations19_offersSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -333,11 +334,11 @@ func migrations19_offersSql() (*asset, error) {
 	}
 
 	info := bindataFileInfo{name: "migrations/19_offers.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
-	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xbd, 0xff, 0xef, 0xfe, 0xb0, 0x5b, 0xa3, 0x92, 0xac, 0x9d, 0x98, 0x6c, 0xd4, 0x90, 0x9c, 0xe9, 0xae, 0x89, 0xfc, 0x54, 0x2b, 0xe3, 0x33, 0x1f, 0x0, 0xd6, 0x24, 0x9a, 0x1, 0xe1, 0xa6, 0x39}}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xd6, 0x50, 0xfa, 0xa8, 0x7a, 0xff, 0xbf, 0x83, 0x35, 0x8, 0x73, 0x98, 0x50, 0x84, 0xa3, 0xb, 0xc6, 0x9d, 0x2a, 0x78, 0x82, 0x2a, 0x85, 0xef, 0xe9, 0xe1, 0xce, 0xae, 0x1d, 0x8c, 0xc1, 0xb0}}
 	return a, nil
 }
 
-var _migrations1_initial_schemaSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc4\x5a\x51\x6f\xdb\x36\x10\x7e\xf7\xaf\x38\xf4\xc5\x09\x16\x0f\x2d\x3a\x14\x9d\x8d\x14\x70\x13\x75\x35\xe6\xc8\xad\x63\xaf\x0d\x8a\x82\xa0\xa5\xb3\xcc\x45\x26\x55\x92\x4a\x9c\x0e\xfb\xef\x83\x64\xc9\x96\x25\x51\x92\x13\x3b\x7b\xb4\x78\xbc\xfb\x3e\x92\xf7\xdd\x89\x72\xa7\x03\xbf\x2c\x99\x27\xa9\x46\x98\x06\xad\x4e\xa7\xd5\xe9\xc0\x27\xa1\xb4\x27\xf1\xfa\xf3\x10\x5c\xaa\xe9\x8c\x2a\x04\x37\x5c\xc6\xc3\xad\x6b\x6b\x02\x4a\x53\x8d\x4b\xe4\x9a\x68\xb6\x44\x11\x6a\x38\x87\x97\xbd\x78\xc8\x17\xce\x6d\xf1\xa9\xe3\xb3\xc8\x1a\xb9\x23\x5c\xc6\x3d\x38\x87\xf6\x74\xf2\xe1\x6d\xbb\x97\xba\xe3\x2e\x95\x2e\x71\x04\x9f\x0b\xb9\x64\xdc\x23\x4a\x4b\xc6\x3d\x05\xe7\x20\x78\xe2\x63\x81\xce\x2d\x99\x87\xdc\xd1\x4c\x70\x32\x13\x2e\xc3\x68\x7c\x4e\x7d\x85\x3b\x61\x96\x8c\x93\x25\x2a\x45\xbd\xd8\xe0\x9e\x4a\xce\xb8\xd7\x4b\xb0\x23\x95\xce\x82\x04\x54\x2f\xe0\x1c\x82\x70\xe6\x33\xe7\x0c\x02\x8f\x38\x54\x53\x5f\xa4\x66\x2e\xce\x69\xe8\x6b\xa2\xe9\xcc\x47\x15\x50\x07\x23\xd0\xed\xdc\xe8\x3d\xd3\x0b\x22\x98\x9b\xc1\x91\xac\xa1\x4d\x97\xd8\x85\x05\x53\x5a\xc8\x07\x42\x1d\x47\x84\x5c\xab\x1e\x4c\x1e\x02\xec\xc2\xa4\xff\x7e\x68\xf5\xe0\xda\x59\xe0\x92\x76\x13\x14\x3d\x18\xdd\x73\x94\x5d\xe8\xf4\x60\xb2\x09\xdb\x8d\x57\xfd\x62\x6c\xf5\x27\xd6\x7a\x5e\xc1\x2b\x9c\xb4\x00\x00\x98\x0b\x33\xe6\x31\xae\xc1\x1e\x4d\xc0\x9e\x0e\x87\x67\xf1\x73\xea\xba\x12\x95\x02\x67\x41\x25\x75\x34\x4a\xb8\xa3\xf2\x81\x71\xef\xe4\xcd\x6f\xa7\xad\xd3\x5e\xab\x1c\x33\xce\xe7\xe8\x1c\x1a\x72\xe2\x34\x41\x9c\x23\x42\x4c\x0c\x52\x3b\x11\xa0\xa4\xf1\xee\x9b\x2c\x5f\x08\xe9\xa2\x7c\x01\x8c\x6b\xf4\x50\xe6\x46\xf5\x43\x80\x86\x21\x17\x35\x65\xbe\x82\xbf\x95\xe0\x33\xf3\xa2\xf8\xe8\x7a\x28\x0f\xbc\x28\x89\xd3\x64\x51\x14\xfe\x08\x91\x3b\x26\xa0\x6b\x63\xb2\xa0\x6a\x51\xbe\xa3\x39\xfb\x40\xe2\x1d\x13\xa1\x22\xb5\x13\x93\x35\x92\x94\x2b\xba\xce\xb1\x78\x57\x36\x38\x2e\xad\x0f\xfd\xe9\x70\x02\x2f\x73\x11\xb6\xbb\xd2\xcc\xde\xf1\x85\x42\x97\x50\x0d\x91\x4e\x28\x4d\x97\x01\x44\x89\x14\x29\x46\xf4\x04\x7e\x0a\x8e\xf9\x39\x12\xa9\xae\x9d\xb4\xb6\x0d\x03\xb7\xb1\xed\xe6\x1c\x25\x3f\x97\x81\x90\x1a\x25\xb9\x43\xa9\x98\xe0\x05\x2e\xaf\xf2\x27\x4a\x68\xea\x13\x47\x30\xae\xca\x0f\xe4\x1c\x91\x04\x42\xf8\xe5\xa3\x91\xb4\x92\x39\x9a\xf6\x3a\x1e\x96\xa8\x50\xde\x99\x4c\x96\x74\x45\xf4\x8a\x28\xd4\x44\xb1\x9f\x45\x2b\xf3\x51\xde\x6e\x5b\x40\xa5\x66\x0e\x0b\xe8\xc1\x15\xaa\x3c\xc6\x56\xaf\xca\x39\x35\x4f\xf7\x7a\x01\xd9\x97\x3f\x61\x2e\x51\xf8\x23\x5d\x86\x6b\xeb\xf3\xd4\xb2\x2f\x2a\x56\x22\x4b\x3e\xb5\x6e\x16\x23\x66\x70\x3d\xe9\x8f\x27\xf0\x65\x30\xf9\x08\xaf\xe2\x07\x03\xfb\x62\x6c\x5d\x59\xf6\x04\xde\xdf\x24\x8f\xec\x11\x5c\x0d\xec\xbf\xfa\xc3\xa9\xb5\xf9\xdd\xff\xba\xfd\x7d\xd1\xbf\xf8\x68\xc1\xab\x83\x10\x85\xd1\x17\xdb\xba\x84\xf7\x37\x35\x8c\xfb\xc3\x89\x35\xde\x93\xf0\xc6\x77\x8d\xf9\xaf\xcc\xad\xe5\x72\xac\x83\x5a\x57\x4c\xb3\xf2\x68\x2c\xb8\x41\xe0\x33\x67\xcd\x2b\xae\x47\x4f\x2c\x47\xeb\x47\x4a\x84\xd2\xc1\xf4\xa8\x1b\xb4\x3f\xd5\xa9\x76\xbb\xdb\x2d\x58\x34\x48\x8a\x2c\xbd\xe3\xc9\x82\x29\x4a\xbc\xf6\x06\x59\x28\x9b\x5b\xbe\x01\x4f\x11\x05\x13\xb2\xc3\xca\x42\x4d\x94\xe7\x12\x86\x3d\xc9\x3e\x51\x1a\x6a\xa2\x15\xc5\xc1\x34\xa1\x42\x1e\x32\x53\x8e\x77\x64\x53\x89\xc8\xe2\x6b\xdc\x8e\x25\x5d\x58\x4d\x93\xd7\x54\x41\xaa\xc5\xa0\xd4\x76\x1b\xda\xdc\xaf\x50\x63\x69\x36\xf5\x7a\xff\x4b\xb7\xa6\x57\x04\xf9\x1d\xfa\x22\x40\xd0\xb8\x2a\x48\xf5\x2a\xea\x9d\x42\x5f\x1b\x06\x97\xa8\xa9\x61\x28\x5a\x05\xd3\xb0\x62\x1e\xa7\x3a\x94\x58\xf6\x46\xf5\xfb\x9b\xd3\x6f\xdf\xb7\x2a\xfc\xcf\xbf\x65\x3a\xfc\xed\x7b\xbe\x89\xc3\xa5\x20\x71\x35\x28\x6a\xf6\xc6\x17\x17\x1c\x2b\x55\x7d\xeb\xab\xe8\x26\x61\xc6\x96\x48\x66\x22\xe4\xae\x8a\x76\xee\xad\xa4\xdc\xc3\x58\x0c\xb3\xc9\xc4\xdc\x34\x75\x92\xd8\x8d\xf2\x7d\x9d\x2e\x23\x7b\x58\x57\xdd\x61\x6d\x7f\x31\x1a\x4e\xaf\xec\x68\x4b\xa3\x17\xea\x94\x25\xc7\x95\xbe\xa3\xfe\x49\xbb\x51\x43\xd1\xee\x76\x25\x7a\x8e\x4f\x95\x2a\x28\xfa\xc1\x58\x18\x8b\xd5\x5e\x3c\x6a\xd4\xaf\x8a\x49\xcd\x52\x04\xb7\xf8\x90\x52\xbd\x18\xd9\xd7\x93\x71\x7f\x60\x57\xb0\x2d\x0a\xde\x9e\x1b\x18\x1f\xa5\xfe\xe5\x65\x26\x5a\x13\x8c\xf0\x69\x3c\xb8\xea\x8f\x6f\xe0\x4f\xeb\x06\x4e\x98\xbb\x7f\x0d\x3e\x22\x53\x53\xcc\x2a\xae\x95\x38\x6b\xd9\xce\x36\x0d\x4a\x4a\x69\x60\x5f\x5a\x5f\x1f\x51\xa8\xe2\x79\x19\x7f\x30\xb2\xcb\xcb\xd6\xf4\x7a\x60\xff\x01\x33\x2d\x11\xe1\x24\x31\x3e\x2b\xd4\x85\x32\xa4\x51\x79\x3b\x18\xcc\xb8\x56\x36\xc2\x98\xaf\xb0\x65\xd0\xd6\x05\xf5\x60\xe0\xd6\xee\x9a\xc1\xcb\xd5\xf2\xb3\x62\xd9\x2e\x3d\xe3\x04\xc9\xec\x61\x3d\xfe\x54\xd8\x53\x7b\xf0\x79\x9a\xa2\xcf\xf9\xce\x72\x48\xaf\xdd\x76\xe0\x97\xbd\x66\x9f\xa5\x37\x68\x26\xe4\x5b\x59\x3d\x24\x66\xe6\x36\x46\xbb\xed\xea\xcf\x4a\x2f\x0a\x6a\x18\x88\x80\x04\x47\x21\x91\x38\xce\xf2\x30\xd4\xbf\x47\xd1\x2a\xb2\xd9\xdc\xe8\xcd\x1e\x0e\x4e\x68\xd7\x77\x96\x53\x7a\x57\xb9\x43\xa2\x1c\x5e\x36\x7b\x8f\x82\xb1\x10\xa0\x59\xda\x96\xa0\x65\xdc\xc5\x15\xc9\xdf\xab\x13\xc1\x49\x72\x79\x7e\x50\xe8\xb5\xd1\xb2\x3c\x36\x97\xfc\xbb\xea\xbd\x36\xdc\x83\xc8\x81\x97\xbf\x2a\x50\x3d\xfc\xda\x2d\x48\x24\x20\xf2\x17\xf5\xc5\x87\x91\xf7\xca\x10\xb5\x02\x14\x19\xd5\xa0\x4e\x92\x23\x72\xb9\xb9\xe4\x3e\x06\xf4\xb2\x38\xb5\x49\xba\xb1\x6c\x4e\xe2\xa8\x67\x66\x27\xce\x63\x24\xc6\xec\x2e\x77\x8b\x7f\xe4\x2d\x28\x7c\x34\xa8\xe5\x92\x9b\xd0\x9c\x59\xe6\x1b\xce\xf3\xec\x4c\xf6\xa3\x51\x1d\xad\x8c\x6d\x73\x46\x65\x9f\xa7\x9e\x87\x5a\xe9\x87\xb1\x3a\x8e\x65\x93\x9a\x93\x4d\x3b\xc5\xe7\x21\xb8\xb9\xe8\xa9\x23\x65\xec\xfc\x77\x5d\x6f\xef\xc8\x8f\xae\x0d\xf9\x50\xa5\x5d\xd5\xbe\x0a\xb1\xeb\x74\xf7\x1e\xf9\x18\x12\x51\x15\xaf\x09\xa1\xdd\x19\xfb\x91\x3b\x52\xcd\x2c\x46\x69\x44\xa4\xac\x72\xc6\x4d\xb3\x5e\x1d\xa9\x1b\x4f\x1c\x1b\x1a\xc2\x47\xf6\xe3\xc5\x0d\x31\xef\x47\xb6\xfd\x3c\x7a\xba\x14\x83\x3d\xba\x13\xd6\x92\xba\xb8\xe9\x8d\xd2\x77\x49\x32\x13\xe2\xf6\x30\x07\xaa\x22\x40\x6d\x0b\x76\x72\x92\x7e\x17\xeb\xbc\x7b\x07\x6d\x25\x7c\x97\x50\xa5\x50\xc7\x47\xb1\xdd\xed\x6a\x5c\xe9\xd3\xd3\x33\x30\x1b\x3a\xc2\x6d\x66\xc8\x94\x0a\x51\x9a\x4d\x67\x22\xf4\x16\xba\x51\xf8\x1d\xd3\x6a\x00\x3b\xa6\x39\x08\xa7\xf0\xe5\xa3\x35\xb6\xd6\xf9\x04\xe7\xf0\xfa\x75\xbc\x7b\xad\xec\x7f\xb8\x2e\xc5\x3d\x6f\xb9\x52\x04\x10\xff\x71\xa9\xfc\x0c\x38\x54\x39\xd4\xc5\x5e\x8d\xe1\x6e\x96\x54\x4d\xca\x24\x7e\x23\xb3\xe6\x9e\xd3\x7a\x55\x65\x93\x1e\x95\x2a\x9b\xcd\x6b\x48\x6a\xf4\x5f\x00\x00\x00\xff\xff\xc5\x76\xc1\xee\xf9\x26\x00\x00")
+var _migrations1_initial_schemaSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc4\x5a\x5b\x6f\xdb\x3a\x12\x7e\xf7\xaf\x20\xfa\xe2\x04\x1b\x2f\x5a\x74\x51\x74\x6d\xa4\x80\x9b\xa8\x5b\x63\x1d\xb9\x75\xec\x6d\x83\xa2\x20\x68\x71\x2c\x73\x23\x91\x2a\x49\xe5\xd2\x83\xf3\xdf\x0f\x24\x4b\x36\x75\xa1\x25\x27\x76\xce\xa3\x35\xc3\x99\xef\xe3\x5c\x38\xa2\xdc\xeb\xa1\x7f\x84\xcc\x97\x44\x03\x9a\x47\x9d\x5e\xaf\xd3\xeb\xa1\x2f\x42\x69\x5f\xc2\xf5\xd7\x31\xa2\x44\x93\x05\x51\x80\x68\x1c\xa6\xe2\xce\xb5\x33\x43\x4a\x13\x0d\x21\x70\x8d\x35\x0b\x41\xc4\x1a\x9d\xa3\xd7\x83\x54\x14\x08\xef\xb6\xfa\xd4\x0b\x58\xa2\x0d\xdc\x13\x94\x71\x1f\x9d\xa3\xee\x7c\xf6\xe9\x7d\x77\x90\x9b\xe3\x94\x48\x8a\x3d\xc1\x97\x42\x86\x8c\xfb\x58\x69\xc9\xb8\xaf\xd0\x39\x12\x3c\xb3\xb1\x02\xef\x16\x2f\x63\xee\x69\x26\x38\x5e\x08\xca\x20\x91\x2f\x49\xa0\xa0\xe0\x26\x64\x1c\x87\xa0\x14\xf1\x53\x85\x7b\x22\x39\xe3\xfe\xa0\x93\xea\x28\x20\xd2\x5b\xe1\x88\xe8\x15\x3a\x47\x51\xbc\x08\x98\x77\x86\x22\x1f\x7b\x44\x93\x40\xe4\x6a\x14\x96\x24\x0e\x34\xd6\x64\x11\x80\x8a\x88\x07\x09\xe8\x6e\x49\x7a\xcf\xf4\x0a\x0b\x46\x0d\x1c\xd9\x1e\xba\x24\x84\x3e\x5a\x31\xa5\x85\x7c\xc4\xc4\xf3\x44\xcc\xb5\x1a\xa0\xd9\x63\x04\x7d\x34\x1b\x7e\x1c\x3b\x03\x74\xed\xad\x20\x24\xfd\x0c\xc5\x00\x4d\xee\x39\xc8\x3e\xea\x0d\xd0\x6c\xe3\xb6\x9f\x18\xec\x5c\x4c\x9d\xe1\xcc\x59\xaf\xab\x58\x45\x27\x1d\x84\x10\x62\x14\x2d\x98\xcf\xb8\x46\xee\x64\x86\xdc\xf9\x78\x7c\x96\x3e\x27\x94\x4a\x50\x0a\x79\x2b\x22\x89\xa7\x41\xa2\x3b\x22\x1f\x19\xf7\x4f\xde\xfd\xeb\xb4\x73\x3a\xe8\xd4\x63\x86\xe5\x12\xbc\x43\x43\xce\x8c\x66\x88\x4b\x44\xb0\x8d\x41\xae\x27\x22\x90\x24\x8d\xbe\x4d\xf3\x95\x90\x14\xe4\x2b\xc4\xb8\x06\x1f\x64\x49\xaa\x1f\x23\xb0\x88\x28\x68\xc2\x02\x85\xfe\xaf\x04\x5f\xd8\x37\x25\x00\xea\x83\x3c\xf0\xa6\x64\x46\xb3\x4d\x51\xf0\x2b\x06\xee\xd9\x80\xae\x95\xf1\x8a\xa8\x55\x7d\x44\x4b\xfa\x91\x84\x3b\x26\x62\x85\x1b\x17\x66\x7b\x24\x09\x57\x64\x5d\x63\x69\x54\x36\x38\x2e\x9d\x4f\xc3\xf9\x78\x86\x5e\x97\x3c\x6c\xa3\xd2\x4e\xdf\x0b\x84\x02\x8a\x89\x46\x49\x9f\x50\x9a\x84\x11\x4a\x0a\x29\xe9\x23\xc9\x13\xf4\x5b\x70\x28\xaf\x91\x40\x74\xe3\xa2\xb5\xfd\x38\xa2\xad\x75\x37\x79\x94\xfd\x0c\x23\x21\x35\x48\x7c\x07\x52\x31\xc1\x2b\x5c\xde\x94\x70\x69\xa1\x49\x80\x3d\xc1\xb8\xaa\x4f\xc8\x25\x00\x8e\x84\x08\xea\xa5\x49\x6b\xc5\x4b\xb0\xc5\x3a\x15\x4b\x50\x20\xef\x6c\x2a\x21\x79\xc0\xfa\x01\x2b\xd0\x58\xb1\xdf\x55\x2d\x7b\x2a\x6f\xc3\x16\x11\xa9\x99\xc7\x22\x72\xf0\x0e\x55\xef\x23\x4b\x74\x46\x2d\x9c\xda\x97\x7b\x73\x03\xd9\x97\x3f\x66\x14\x2b\xf8\x95\x6f\xc3\xb5\xf3\x75\xee\xb8\x17\x3b\x76\xc2\x24\x9f\x6b\xb7\xf3\x91\x72\xbd\x9e\x0d\xa7\x33\xf4\x6d\x34\xfb\x8c\xde\xa4\x0f\x46\xee\xc5\xd4\xb9\x72\xdc\x19\xfa\x78\x93\x3d\x72\x27\xe8\x6a\xe4\xfe\x6f\x38\x9e\x3b\x9b\xdf\xc3\xef\xdb\xdf\x17\xc3\x8b\xcf\x0e\x7a\x73\x10\xa2\x68\xf2\xcd\x75\x2e\xd1\xc7\x9b\x06\xc6\xc3\xf1\xcc\x99\xee\x49\x78\x63\xbb\x41\xfd\x9f\x8c\x36\x72\x39\x56\xa2\x1a\xc9\x59\x9b\x71\x66\x7b\xb4\xe9\x90\x28\x0a\x98\x97\xc2\xc4\xe9\x79\xf4\xcc\xe3\x68\xfd\x48\x89\x58\x7a\x90\xa7\xba\xa5\xf7\xe7\x7d\xaa\xdb\xed\xf7\x2b\x1a\x2d\x8a\xc2\xa4\x67\x86\xe4\xb0\xbb\x6d\xf3\x92\xee\xbd\xa5\x2d\xd4\xad\xad\x0f\xc0\x73\x9a\x82\x0d\x99\xa5\x5a\xec\x3b\x61\x92\xaf\x54\x49\x83\x97\x97\x6a\x0c\x7b\x92\x7d\x66\x6b\x68\xf0\x56\x6d\x0e\xb6\x05\x3b\xda\x83\xb1\xe4\x78\x29\x9b\xb7\x08\xe3\x51\xfb\x71\x2c\x9b\xc2\x1a\x86\xbc\xb6\x1d\x64\x77\x33\xa8\xd5\xdd\xba\xb6\xcf\x2b\xc4\x7a\x34\xdb\x66\xbd\xbf\x65\x5a\xd3\x0f\x18\xf8\x1d\x04\x22\x02\xa4\xe1\xa1\x4c\x45\x3f\x60\x09\x2a\x0e\xb4\x45\x18\x82\x26\x16\x51\x32\xb5\xd9\xc4\x8a\xf9\x9c\xe8\x58\x42\xdd\x1b\xd5\xbf\xdf\x9d\xfe\xf8\xb9\x99\x16\xbb\x7f\xfc\x59\xd7\x87\x7f\xfc\x2c\x99\x0c\x21\x14\x38\x7d\x39\xa9\xe8\x6e\x6d\x71\xc1\x61\x67\x57\xdf\xda\xaa\x02\xcb\x98\xb1\x10\xf0\x42\xc4\x9c\xaa\x24\xbe\xef\x25\xe1\x3e\xa4\xcd\xd0\x2c\x26\x46\xf3\xd2\xc9\x7c\xb7\xaa\xf7\x75\x87\x9f\xb8\xe3\x9b\xea\xa1\x5a\x28\x5f\xb4\xee\x0f\x17\x93\xf1\xfc\xca\x4d\x5e\x5a\x93\xd7\xed\x9c\x25\x87\x07\x7d\x47\x82\x93\xee\x6e\x1b\x59\xcf\xe8\xf6\xfb\x12\x7c\x2f\x20\x4a\x55\x3a\xfa\xc1\x58\x98\x65\xfe\x74\x1e\x0d\xdd\x6f\x17\x93\x86\xad\x88\x6e\xe1\x31\xa7\x7a\x31\x71\xaf\x67\xd3\xe1\xc8\x9d\xed\xd3\xf0\xf6\x0c\x60\x9a\x4a\xc3\xcb\x4b\xc3\x5b\x1b\x8c\xe8\xcb\x74\x74\x35\x9c\xde\xa0\xff\x3a\x37\xe8\x84\x51\x2b\x4f\xeb\x56\x1d\x91\xa9\xcd\xe7\x2e\xae\x3b\x71\x36\xb2\x5d\x6c\x06\x94\x9c\xd2\xc8\xbd\x74\xbe\x3f\xe1\xa0\x4a\xd7\x19\xf6\xd0\xc4\xad\xc3\xa8\xd0\xfc\x7a\xe4\xfe\x07\x2d\xb4\x04\x40\x27\x99\xf2\x59\xe5\x5c\xa8\x43\x9a\x1c\x6f\x07\x83\x99\x18\x6b\x87\xd1\x90\xa4\x27\x6c\x1d\xb4\xf5\x81\x7a\x30\x70\x6b\x73\xed\xe0\x95\xce\xf2\xb3\xea\xe0\x5f\x01\x9c\x18\xc5\x80\x17\x8f\x6b\xf9\x73\x61\xcf\xdd\xd1\xd7\x79\x8e\xbe\x64\xdb\xe4\x90\x5f\xbb\x15\xe0\x57\x6b\x96\xd1\xb3\xfc\x06\xcd\x86\x9c\xd1\x63\x60\x66\xb4\x35\xda\x3c\x5d\x13\xac\x4f\x60\x20\x22\x1c\x1d\x85\x44\x66\xd8\xe4\xb1\x85\x65\xb6\x87\xa7\xd1\xaa\xb2\xd9\xdc\xe8\x2d\x1e\x0f\x4e\xa8\x68\xdb\xe4\x94\xdf\x55\x16\x48\xd4\xc3\x33\xab\xf7\x28\x18\x2b\x0e\x4c\xa0\x86\xb0\x19\x2d\xe3\x14\x1e\x70\x29\x16\x0a\x0b\x8e\xb3\xcb\xf3\x83\x42\x6f\xf4\x66\xf2\xc8\xc5\x45\x0e\x99\xe2\x1e\x44\x18\x7d\x21\x0e\xc5\x30\xd4\xc3\x6f\x0c\x41\xd6\x02\x92\x3d\x49\xe6\xe2\xe7\x42\xaf\xc3\x5c\x72\x61\x82\xce\x44\x45\xcc\x09\x8e\x06\xd4\x59\x71\x24\xa8\x37\x97\xdc\xc7\x80\x5e\xe7\xc7\xc4\x9f\xc9\x8b\xf8\x37\x9a\xed\x49\x1c\x35\x67\x0a\x7e\x1a\xd1\x37\x66\x8c\x69\xae\x74\x8b\x7f\xe4\x10\x94\xdd\x35\x73\x29\x2d\x68\xcf\x2c\xeb\xca\x87\x18\xc7\xda\x45\xc6\x70\xd8\x48\xcb\xd0\x6d\xcf\xa8\xee\xf3\xd4\xcb\x50\xab\xfd\x30\xd6\xc4\xb1\x6e\x51\x7b\xb2\xf9\xa4\xf8\x32\x04\x73\x6f\x8d\x81\xcb\x15\x1b\x88\x6c\xc6\x91\xe3\xf7\x86\xb2\x2b\x93\xc2\x56\xb6\x67\x87\x28\x1a\x35\x87\x87\xe7\x73\x69\x26\x51\xf4\xd7\x86\x50\x71\xc5\x7e\xe4\x8e\x74\x66\x56\xbd\xb4\x22\x52\x77\x72\x26\x8b\x92\x2f\x97\xc7\x99\xc6\x33\xc3\x26\x3a\xdb\xeb\x7a\x11\x6b\x69\x6e\x29\xcc\xe3\xd5\x80\xd8\xe3\x61\xe8\x1e\xbf\x5c\xaa\xce\x2c\xc4\x9b\x4b\x46\x4b\x42\x61\x33\x1b\xe5\xef\x92\x78\x21\xc4\xed\x73\x09\xa4\xeb\x76\x39\x30\x41\x67\x0a\x45\xbc\x27\x27\xf9\x77\xb1\xde\x87\x0f\xa8\xab\x44\x40\x31\x51\xc9\xb7\xef\x24\x15\xbb\xfd\x7e\x72\x9b\x7b\x7a\x7a\x86\xec\x8a\x9e\xa0\xed\x14\x99\x52\x31\x48\xbb\xea\x42\xc4\xfe\x4a\xb7\x72\x5f\x50\xdd\x0d\xa0\xa0\x5a\x82\x70\x8a\xbe\x7d\x76\xa6\xce\xba\x9e\xd0\x39\x7a\xfb\x36\x49\xc1\x4e\xc7\xfc\x0f\xd7\xa5\xb8\xe7\x1d\x2a\x45\x84\xd2\x3f\x2e\xd5\xe7\x80\x47\x94\x47\x28\x0c\x1a\x14\x8b\x55\xb2\x6b\x91\x51\xf8\xad\xd4\xda\x5b\xce\xcf\xab\x5d\x3a\x79\xaa\xec\xd2\xd9\xbc\x86\x78\x44\x79\x84\xc2\xe0\xaf\x01\x00\xc5\x76\xc1\xee\xf9\x26\x00\x00")
 
 func migrations1_initial_schemaSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -357,7 +358,7 @@ func migrations1_initial_schemaSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations20_account_for_signer_indexSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd2\xd5\x55\xd0\xce\xcd\x4c\x2f\x4a\x2c\x49\x55\x08\x2d\xe0\xe2\x72\x0e\x72\x75\x0c\x71\x55\xf0\xf4\x73\x71\x8d\x50\x28\xce\x4c\xcf\x4b\x2d\x2a\x8e\x4f\xaa\x8c\x4f\x4c\x4e\xce\x2f\xcd\x2b\x51\xf0\xf7\x53\x80\x32\x8b\xe3\xa1\xd2\x0a\xa1\xc1\x9e\x7e\xee\x0a\x4e\x21\x41\xae\xae\x1a\x50\x49\x4d\x6b\x2e\x2e\x64\xa3\x5d\xf2\xcb\xf3\xb8\xb8\x5c\x82\xfc\x03\x70\x1a\x6d\xcd\x05\x08\x00\x00\xff\xff\x10\xbc\xb1\xe1\x8c\x00\x00\x00")
+var _migrations20_account_for_signer_indexSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd2\xd5\x55\xd0\xce\xcd\x4c\x2f\x4a\x2c\x49\x55\x08\x2d\xe0\xe2\x72\x0e\x72\x75\x0c\x71\x55\xf0\xf4\x73\x71\x8d\x50\x28\xce\x4c\xcf\x4b\x2d\x2a\x8e\x4f\xaa\x8c\x4f\x4c\x4e\xce\x2f\xcd\x2b\x51\xf0\xf7\x53\x80\x32\x8b\xe3\xa1\xd2\x0a\xa1\xc1\x9e\x7e\xee\x0a\x4e\x21\x41\xae\xae\x1a\x50\x49\x4d\x6b\x2e\x2e\x64\xa3\x5d\xf2\xcb\xf3\xb8\xb8\x5c\x82\xfc\x03\x70\x1a\x6d\xcd\x05\x18\x00\x10\xbc\xb1\xe1\x8c\x00\x00\x00")
 
 func migrations20_account_for_signer_indexSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -377,7 +378,7 @@ func migrations20_account_for_signer_indexSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations21_trades_remove_zero_amount_constraintsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd2\xd5\x55\xd0\xce\xcd\x4c\x2f\x4a\x2c\x49\x55\x08\x2d\xe0\xe2\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\xc8\xc8\x2c\x2e\xc9\x2f\xaa\x8c\x2f\x29\x4a\x4c\x49\x2d\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x0b\x0e\x09\x72\xf4\xf4\x0b\x41\x93\x8e\x4f\x4a\x2c\x4e\x8d\x4f\xcc\xcd\x2f\xcd\x2b\x89\x4f\xce\x48\x4d\xce\xb6\xa6\xc0\xb0\x64\x90\x31\xa9\x45\x68\xe6\xe1\x33\xd0\xd1\xc5\x85\x14\xc7\x29\x38\x7b\xb8\x3a\x7b\x2b\x68\x20\x49\x28\xd8\xd9\x2a\x18\x68\xe2\x75\x35\x7e\x4b\xb0\x39\x1a\x66\x0f\xaa\x1c\xcc\x2a\x2e\xe4\xe0\x77\xc9\x2f\xcf\x1b\xe1\x11\x40\xbf\xf0\x07\xdb\x04\x08\x00\x00\xff\xff\xfc\x1d\x38\xf5\xfd\x02\x00\x00")
+var _migrations21_trades_remove_zero_amount_constraintsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd2\xd5\x55\xd0\xce\xcd\x4c\x2f\x4a\x2c\x49\x55\x08\x2d\xe0\xe2\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\xc8\xc8\x2c\x2e\xc9\x2f\xaa\x8c\x2f\x29\x4a\x4c\x49\x2d\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x0b\x0e\x09\x72\xf4\xf4\x0b\x41\x93\x8e\x4f\x4a\x2c\x4e\x8d\x4f\xcc\xcd\x2f\xcd\x2b\x89\x4f\xce\x48\x4d\xce\xb6\xa6\xc0\xb0\x64\x90\x31\xa9\x45\x68\xe6\xe1\x33\xd0\xd1\xc5\x85\x14\xc7\x29\x38\x7b\xb8\x3a\x7b\x2b\x68\x20\x49\x28\xd8\xd9\x2a\x18\x68\x5a\x93\x6f\x09\x36\x47\xc3\xec\x41\x95\x83\x59\xc5\x85\x1c\xfc\x2e\xf9\xe5\x79\x23\x3c\x02\xe8\x17\xfe\x0a\x06\x9a\xd6\x5c\x80\x01\x00\xfc\x1d\x38\xf5\xfd\x02\x00\x00")
 
 func migrations21_trades_remove_zero_amount_constraintsSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -397,7 +398,7 @@ func migrations21_trades_remove_zero_amount_constraintsSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations22_trust_linesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x93\xc1\x6e\x9b\x40\x10\x86\xef\x3c\xc5\x1c\x6d\xd5\x54\x6d\xd5\xe4\xe2\x93\x5d\xa3\xca\x8a\x83\x23\x8a\xa5\xe6\xb4\x1a\x76\xc7\x64\xd4\x65\xd7\xdd\x59\x5a\xf1\xf6\x95\x89\x62\x93\x04\xa5\xce\x71\xc5\xc7\x3f\x2c\xdf\x3f\x69\x0a\x1f\x1a\xae\x03\x46\x82\xdd\x21\x49\xbe\x15\xd9\xa2\xcc\xa0\x5c\x2c\x37\x19\xc4\xd0\x4a\x54\x96\x1d\x09\x4c\x12\x00\x80\x34\x05\x4b\xa6\xa6\xa0\x7e\x51\x07\x2c\x80\xb0\xe9\xcf\x37\xd4\x41\x83\x41\x1e\xd0\x92\x81\x56\xd8\xd5\x70\xfb\x78\x5e\xb2\xc3\xd0\x3d\xbd\x8e\xce\x40\x85\x42\xd7\x5f\x53\x72\xda\x9b\x9e\x26\x03\xd1\x43\xe5\xbd\x44\x38\x50\xd8\xfb\x06\x9d\x26\xf0\x7b\x10\xdf\x10\xfc\x6e\x29\x30\xc9\xc7\x3e\x63\x30\x5f\x3f\x60\x40\x1d\x29\xc0\x1f\x0c\x1d\xbb\x7a\xf2\xf9\xea\xd3\x14\xf2\x6d\x09\xf9\x6e\xb3\x99\xf5\x3c\x6a\xed\x5b\x17\x15\x9b\x11\xfe\xea\xfa\x15\x2e\x42\x51\xc5\xee\x40\xc0\x2e\x8e\x3e\x64\x91\x96\xc2\x3b\xd2\x8e\xd7\x1c\xfb\xd8\x2f\x2f\xf1\x0a\x6d\x7f\xf1\x8a\xeb\xd7\xc3\xcf\x36\x94\xe5\x86\xe3\x38\x55\xb5\xc7\x6c\x65\x19\x2b\xb6\x1c\x99\x64\x9c\x13\xb2\xf6\x22\x70\x6f\xb1\x96\x91\x7f\x61\x51\xa2\x6a\xbc\xe1\x3d\x93\x51\x8f\x56\x60\x9d\x97\x2f\xb0\xbb\x62\x7d\xbb\x28\xee\xe1\x26\xbb\x87\xc9\xd9\xdd\x34\x99\xce\x4f\x6d\x5b\xe7\xab\xec\xe7\xb0\x6d\xaa\xea\xd4\xc0\xdb\x36\x7f\x56\xc5\xdd\x8f\x75\xfe\x1d\x96\x65\x91\x65\x93\x33\x35\x9d\xbf\x19\x77\x34\xda\x8b\x78\xd2\xf7\x56\xe8\xa9\x03\xb3\x81\xc1\xd9\x33\xfd\xff\x19\x77\xe9\x90\x53\x58\x32\xdc\xc4\x95\xff\xeb\x92\x64\x55\x6c\xef\x46\x36\x51\xa3\x68\x34\x34\xff\x17\x00\x00\xff\xff\x7a\x87\x20\x42\xbb\x03\x00\x00")
+var _migrations22_trust_linesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x93\x41\x6f\xda\x30\x14\xc7\xef\xf9\x14\xef\x08\x5a\x33\xad\xd3\xda\x0b\x27\x18\xd1\x84\x4a\x43\x95\x05\x69\x3d\x59\x2f\xf6\x23\x7d\x9a\x63\x33\x3f\x67\x53\xbe\xfd\x44\x10\x10\xb5\x51\xc7\x6e\xb1\xfc\xf3\xff\x2f\xe7\xf7\x9c\xa6\xf0\xa1\xe1\x3a\x60\x24\xd8\xee\x93\xe4\x6b\x91\xcd\xcb\x0c\xca\xf9\x62\x9d\x41\x0c\xad\x44\x65\xd9\x91\xc0\x24\x01\x00\x48\x53\xb0\x64\x6a\x0a\xea\x27\x75\xc0\x02\x08\xeb\x7e\xfd\x40\x1d\x34\x18\xe4\x05\x2d\x19\x68\x85\x5d\x0d\x8f\xc7\xf5\x82\x1d\x86\xee\x74\x1c\x9d\x81\x0a\x85\xee\xbf\xa4\xe4\xb4\x37\x3d\x4d\x06\xa2\x87\xca\x7b\x89\xb0\xa7\xb0\xf3\x0d\x3a\x4d\xe0\x77\x20\xbe\x21\xf8\xd5\x52\x60\x92\x8f\x7d\xc6\xa0\x5f\xbf\x60\x40\x1d\x29\xc0\x6f\x0c\x1d\xbb\x7a\x72\x7b\xf7\x69\x0a\xf9\xa6\x84\x7c\xbb\x5e\xdf\xf4\x3c\x6a\xed\x5b\x17\x15\x9b\x11\xfe\xee\xfe\x0d\x2e\x42\x51\xc5\x6e\x4f\xc0\x2e\x8e\x6e\xb2\x48\x4b\xe1\x3f\xd2\x0e\xd7\x1c\xc1\x6f\x3f\xbf\xc6\x2b\xb4\xfd\xc5\x2b\xae\xdf\x96\x5f\x6c\x28\xcb\x0d\xc7\x71\xaa\x6a\x0f\xd9\xca\x32\x56\x6c\x39\x32\xc9\x38\x27\x64\xed\x55\xe0\xce\x62\x2d\x23\xff\xc2\xa2\x44\xd5\x78\xc3\x3b\x26\xa3\x8e\x56\x60\x95\x97\xaf\xb0\xa7\x62\xf5\x38\x2f\x9e\xe1\x21\x7b\x86\xc9\xc5\xdd\x34\x99\xce\xce\xd3\xb6\xca\x97\xd9\x8f\xe1\xb4\xa9\xaa\x53\x03\x6f\x9b\x7c\xb8\x09\xdb\xef\xab\xfc\x1b\x2c\xca\x22\xcb\x26\x17\x6a\x3a\x7b\x37\xee\x60\xb4\x17\x71\xd2\xf7\x5e\xe8\x79\x06\x6e\x06\x06\x4f\xdf\xc7\xf3\xff\xa8\xbb\xb6\xe4\x1c\x96\x0c\x5f\xe2\xd2\xff\x71\x49\xb2\x2c\x36\x4f\x23\x2f\x51\xa3\x68\x34\x34\xfb\x3b\x00\x7a\x87\x20\x42\xbb\x03\x00\x00")
 
 func migrations22_trust_linesSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -417,7 +418,7 @@ func migrations22_trust_linesSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations23_exp_asset_statsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x93\x51\x6f\xd3\x30\x14\x85\xdf\xf3\x2b\xce\x63\x2b\xda\x49\x20\x81\x90\xfa\x14\xd6\x68\x54\x94\x74\x0a\x29\xda\x9e\x22\xd7\xb9\x6b\x2d\x1c\xc7\xf8\xde\xd2\xe5\xdf\xa3\x8c\x6c\xb8\xb4\x13\xbd\x4f\x96\xfc\xe9\x9c\x73\x8f\xe5\xe9\x14\x6f\x1a\xb3\x0d\x4a\x08\x6b\x9f\x4c\xa7\xa0\x47\x5f\x29\x66\x92\x8a\x45\x09\xc3\x30\xac\xf9\x41\x90\x1d\x81\x1e\x0d\x8b\x71\x5b\xc4\x80\xa8\x8d\x25\x3c\x98\xc0\x82\x9a\x1e\x8c\xa3\x1a\xc6\xe1\x63\xa5\x03\x29\xa1\x58\xac\x7a\x62\xaf\xf8\xa7\xfd\xe3\xa4\xc9\x0b\x64\xa7\xe4\x9c\xab\x6f\xfd\xde\x2a\xa1\x1a\x9b\x6e\x70\xf7\x14\x4c\x43\x4e\x94\x85\x71\x5b\x62\x31\xad\x03\x77\x2c\xd4\xe0\xb0\x33\x96\xf0\x9a\x46\xef\x37\xc8\x58\xda\x2a\xdd\x9d\x08\x5c\x61\xe5\x34\x5d\x60\x14\xc8\x5b\xa5\x89\x63\xb1\xe1\x4a\x76\xe4\x7a\xa7\x03\x41\x2b\x87\x40\x4d\xfb\xeb\x28\x53\x92\x5c\x17\x59\x5a\x66\x28\xd3\x4f\xcb\xec\x64\xeb\x51\x02\x60\xe0\xa5\xf3\x84\xa7\x59\xe4\x25\xf2\x55\x89\x7c\xbd\x5c\x4e\x22\x42\xb7\xf5\x40\x7c\x4f\x8b\xeb\xcf\x69\x31\x7a\xfb\x6e\x7c\x96\x34\xcc\x7b\x0a\x31\xf9\xfe\xc3\x09\xd9\xb4\x7b\x27\x78\x99\x32\xbb\x2b\x9f\xcf\xc7\xa4\xdb\x37\x95\xd2\xba\xc7\x79\xc8\x97\xdd\x64\xc5\x19\xf2\xb6\x58\x7c\x4d\x8b\x7b\x7c\xc9\xee\x47\x7f\x33\x4f\x8e\x52\x4d\xa2\x7d\xc7\xc9\x78\xf6\x52\xd1\x22\x9f\x67\x77\xff\x56\x54\x6d\xba\xe7\x6d\x56\xf9\x49\x7f\xeb\x6f\x8b\xfc\x06\x1b\x09\x44\x18\xc5\x26\xe3\xd9\x7f\x55\x87\x36\x2f\x53\xed\xe1\x3e\x6a\xfc\x7d\xe6\xed\xc1\x25\xf3\x62\x75\xfb\xca\xe3\x6a\xc5\x5a\xd5\x34\xfb\x1d\x00\x00\xff\xff\x1c\x2f\xe1\x06\x73\x03\x00\x00")
+var _migrations23_exp_asset_statsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x93\x51\x6f\xd3\x30\x14\x85\xdf\xf3\x2b\xce\x63\x2b\xd6\x49\x20\x81\x90\xfa\x14\xd6\x68\x54\x94\x74\x0a\x29\xda\x9e\x22\xd7\xb9\x6b\x2d\x1c\xc7\xf8\xde\xd2\xe5\xdf\xa3\x30\x6f\xa4\xa4\x13\xcb\x53\xae\xf2\xe5\x9c\x73\x8f\xe5\xd9\x0c\x6f\x1a\xb3\x0b\x4a\x08\x1b\x9f\xcc\x66\xa0\x07\x5f\x29\x66\x92\x8a\x45\x09\xc3\x30\xac\xf9\x41\x90\x3d\x81\x1e\x0c\x8b\x71\x3b\x0c\x01\x51\x5b\x4b\xb8\x37\x81\x05\x35\xdd\x1b\x47\x35\x8c\xc3\xc7\x4a\x07\x52\x42\x43\xb1\xea\x0f\x7b\xc9\x3f\xed\xa3\x93\x26\x2f\x90\xbd\x92\x73\xae\xbe\xf5\x07\xab\x84\x6a\x6c\xbb\xe8\xee\x29\x98\x86\x9c\x28\x0b\xe3\x76\xc4\x62\x5a\x07\xee\x58\xa8\xc1\x71\x6f\x2c\xe1\x25\x8d\xde\x2f\xca\x58\xda\x29\xdd\x8d\x04\x2e\xb1\x76\x9a\x5e\x61\x14\xc8\x5b\xa5\x89\x87\x62\xf1\x93\xec\xc9\xf5\x4e\x47\x82\x56\x0e\x81\x9a\xf6\xd7\x49\xa6\x24\xb9\x2a\xb2\xb4\xcc\x50\xa6\x9f\x56\xd9\x68\xeb\x49\x02\x20\xf2\xd2\x79\xea\x27\x60\x99\x97\xc8\xd7\x25\xf2\xcd\x6a\x75\x31\x20\x74\x5b\x47\xe2\x7b\x5a\x5c\x7d\x4e\x8b\xc9\xdb\x77\xd3\xb3\xa4\x61\x3e\x50\x18\x92\xef\x3f\x8c\xc8\xa6\x3d\x38\xc1\xf3\x53\x66\xb7\xe5\xd3\xfb\x29\xe9\x0e\x4d\xa5\xb4\xee\x71\x8e\xf9\xb2\xeb\xac\x38\x43\xde\x14\xcb\xaf\x69\x71\x87\x2f\xd9\xdd\xe4\x6f\xe6\x8b\x93\x54\x4f\x53\xbf\xef\x34\x99\xce\x9f\x2b\x5a\xe6\x8b\xec\xf6\xdf\x8a\xaa\x6d\x17\xff\xc3\x3a\x1f\xf5\xb7\xf9\xb6\xcc\xaf\xb1\x95\x40\x84\x68\xf8\x08\x4f\xe7\xff\x55\x8d\x6d\xbe\x4e\xb5\x87\xfb\xa8\xc3\xeb\xb3\x68\x8f\x2e\x59\x14\xeb\x9b\x17\x0e\x57\x2b\xd6\xaa\xa6\xf9\xef\x01\x00\x1c\x2f\xe1\x06\x73\x03\x00\x00")
 
 func migrations23_exp_asset_statsSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -437,7 +438,7 @@ func migrations23_exp_asset_statsSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations24_accountsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x94\x5d\x6f\xda\x4c\x10\x85\xef\xfd\x2b\xe6\x12\xf4\x86\x57\xfd\x48\x90\x2a\xae\xa0\x58\x15\x0a\x31\x29\x05\xa9\xb9\x5a\x8d\xbd\x83\x3d\xea\x7e\x24\xbb\xeb\x20\xfe\x7d\x65\x53\x62\xc7\x18\x25\xed\x9d\x57\x7e\xf6\xec\x9e\x99\x33\x3b\x1a\xc1\x7f\x9a\x73\x87\x81\x60\xfb\x18\x45\x5f\xd7\xf1\x74\x13\xc3\x66\x3a\x5b\xc6\x80\x59\x66\x4b\x13\x3c\x0c\x22\x00\x38\x2d\x05\x4b\xc8\x0a\x74\x98\x05\x72\xf0\x8c\xee\xc0\x26\x1f\xdc\x8c\x87\x90\xac\x36\x90\x6c\x97\xcb\xab\x1a\x4f\x51\xa1\xc9\x08\x52\xce\xd9\x84\xee\xcf\xb2\xda\x25\x14\x63\xca\x8a\x03\x93\xef\xe7\x3c\x29\xf5\x4e\xf0\xa9\x24\x93\x91\x30\xa5\x4e\xc9\xf5\x43\xa6\xd4\xc2\x97\x29\x99\xe0\x2a\xa1\x73\x80\xcd\x4e\x61\x60\x6b\x84\x24\x1f\xd8\xd4\xdf\xef\x72\xbb\x53\x98\xf7\x29\x16\x56\x93\x90\x56\x23\xf7\xe9\x7c\xfe\xd4\xd5\xd1\xe8\x03\x39\xb1\x27\xce\x8b\x00\x5e\x63\xe5\xbf\x2b\x1a\x0a\x47\xbe\xb0\x4a\x0a\x65\xf7\x6f\x43\x9a\x24\x97\xfa\x6d\xae\xe0\xbc\xb8\x44\x29\xf4\x41\x68\x2b\x79\xc7\x24\x85\x22\x99\x93\x83\x45\xb2\xe9\x60\xf7\xeb\xc5\xdd\x74\xfd\x00\xb7\xf1\x03\x0c\x9a\xc0\x0c\xa3\xe1\xe4\x25\x5c\x8b\x64\x1e\xff\x7c\x09\x97\xe8\xaf\xf9\x2a\x69\xe2\xb7\xfd\xb1\x48\xbe\xc1\x6c\xb3\x8e\xe3\x41\x2f\x3d\x9c\x5c\xd0\x6e\x57\xff\x92\x62\x8b\x69\x5d\xf2\xf5\x04\x08\x89\x01\xff\x8c\xc1\x68\x04\x47\xfb\xe2\x17\x1d\x80\x3d\x20\x2c\xeb\xf5\x2d\x1d\x40\xa3\xf3\x05\x2a\x92\x50\x7a\x36\x39\xdc\x1d\xd7\x33\x36\xe8\x0e\xa7\xed\x68\x24\xa4\xe8\x69\x7c\x3d\x22\x93\x59\x59\xd3\x24\x21\x58\x48\xad\xf5\x01\x1e\xc9\xed\xac\xae\xa7\xc7\xee\xc0\x5b\x4d\xf0\x54\x52\x95\xd9\xff\x8f\xcd\x68\xce\x3f\xcf\xd4\xc7\x9b\x0f\xdd\x50\xfd\xe5\xe4\x1a\xd4\xd4\x03\x8e\xaf\xbb\xe0\x33\xaa\xb2\x8f\xfc\xd2\xbe\x41\xe5\xb8\xe3\x76\x7c\x0d\xe9\x21\x90\xff\xe7\x64\x35\x05\x78\x95\xac\x6d\xb2\xf8\xbe\x3d\x0b\x41\xd5\x3b\xd1\x94\x40\xd4\xf6\x5a\x69\x38\x36\xb7\x1d\x89\x06\xbe\xaa\x8b\x51\x1d\xd1\x7e\x29\xe7\x76\x6f\xa2\x68\xbe\x5e\xdd\x77\x5f\xca\x0c\x7d\x86\x92\x26\x7d\x3f\x8f\xe7\x9c\x88\xdf\x01\x00\x00\xff\xff\xd2\xd6\x65\xae\x7a\x05\x00\x00")
+var _migrations24_accountsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x94\x5f\x6f\xda\x30\x14\xc5\xdf\xf3\x29\xee\x23\x68\x65\xda\x9f\x16\x69\xe2\xa9\x1d\xd1\x84\x4a\x43\xc7\x40\x5a\x9f\xac\x1b\xfb\x92\x5c\xcd\x7f\x5a\xdb\x29\xe2\xdb\x4f\x09\x2b\x64\x21\xa8\xdd\xde\x62\xf9\xe7\x73\x73\xae\xcf\xf5\x68\x04\xef\x0c\x17\x1e\x23\xc1\xfa\x31\x49\xbe\x2e\xd3\xeb\x55\x0a\xab\xeb\x9b\x79\x0a\x28\xa5\xab\x6c\x0c\x30\x48\x00\xe0\x65\x29\x58\x81\x2c\xd1\xa3\x8c\xe4\xe1\x19\xfd\x8e\x6d\x31\xb8\x1a\x0f\x21\x5b\xac\x20\x5b\xcf\xe7\x17\x0d\x9e\xa3\x46\x2b\x09\x72\x2e\xd8\xc6\xee\x66\x55\x9f\x12\x9a\x31\x67\xcd\x91\x29\xf4\x73\x81\xb4\x7e\x23\xf8\x54\x91\x95\x24\x6c\x65\x72\xf2\xfd\x90\xad\x8c\x08\x55\x4e\x36\xfa\x5a\xe8\x14\x60\xbb\xd1\x18\xd9\x59\xa1\x28\x44\xb6\xcd\xf7\x9b\xdc\x6e\x34\x16\x7d\x8a\xa5\x33\x24\x94\x33\xc8\x7d\x3a\x9f\x3f\x75\x75\x0c\x86\x48\x5e\x6c\x89\x8b\x32\x42\x30\xa8\xf5\xa9\x68\x2c\x3d\x85\xd2\x69\x25\xb4\xdb\xbe\x0e\x19\x52\x5c\x99\xd7\xb9\x92\x8b\xf2\x1c\xa5\x31\x44\x61\x9c\xe2\x0d\x93\x12\x9a\x54\x41\x1e\x66\xd9\xaa\x83\xdd\x2f\x67\x77\xd7\xcb\x07\xb8\x4d\x1f\x60\x70\x0c\xcc\x30\x19\x4e\x0e\xe1\x9a\x65\xd3\xf4\xe7\x21\x5c\xa2\xbf\xe7\x8b\xec\x40\xc0\xfa\xc7\x2c\xfb\x06\x37\xab\x65\x9a\x0e\x7a\xe9\xe1\xe4\x8c\x76\xbb\xfb\xe7\x14\x5b\xcc\x70\x72\x66\x02\x84\xc2\x88\x7f\xc6\x60\x34\x82\xbd\x7d\xf1\x8b\x76\xc0\x01\x10\xe6\xcd\xfa\x96\x76\x60\xd0\x87\x12\x35\x29\xa8\x02\xdb\x02\xee\xf6\xeb\x1b\xb6\xe8\x77\x2f\xc7\xd1\x2a\xc8\x31\xd0\xf8\x72\x44\x56\x3a\xd5\xd0\xa4\x20\x3a\xc8\x9d\x0b\x11\x1e\xc9\x6f\x9c\x69\xa6\xc7\x6d\x20\x38\x43\xf0\x54\x51\x9d\xd9\xf7\x8d\x46\xab\xfe\x69\xa6\x3e\x5e\x7d\xe8\x86\xea\x1f\x27\xd7\xa2\xa1\x1e\x70\x7c\xd9\x05\x9f\x51\x57\x7d\xe4\x97\xf6\x1f\xd4\x8e\x3b\x6e\xc7\x97\x90\xef\x22\x85\xff\x4e\xd6\xb1\x01\x7f\x25\x6b\x9d\xcd\xbe\xaf\x4f\x42\x50\xdf\x9d\x38\xb6\x40\x34\xf6\x5a\x69\xd8\x5f\x6e\x3b\x12\x47\xf8\x02\x6a\xba\x2e\xd1\x7e\x29\xa7\x6e\x6b\x93\x64\xba\x5c\xdc\x77\x5f\x4a\x89\x41\xa2\xa2\x49\xdf\xe6\xbe\x8e\xc4\x20\x51\xd1\xe4\xf7\x00\xd2\xd6\x65\xae\x7a\x05\x00\x00")
 
 func migrations24_accountsSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -457,7 +458,7 @@ func migrations24_accountsSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations25_expingest_rename_columnsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x90\xcd\xaa\x83\x30\x10\x85\xf7\x3e\xc5\xec\x2f\x3e\xc1\x5d\xd9\x36\x3b\x7f\x40\x74\x3d\x58\x8d\x61\xc0\xc6\xe2\x28\xa5\x6f\x5f\x4c\x0c\xa4\x76\xd1\xd8\xdd\x99\x09\x5f\x0e\xf3\xc5\x31\xfc\xdd\x48\x4d\xcd\x2c\xa1\xbe\x47\x51\x92\x56\xa2\x84\x2a\x39\xa5\x02\x9a\xb6\x1d\x17\x3d\x33\x32\x29\x2d\x27\x86\x52\xe4\x49\x26\xe0\x5c\xa4\x75\x96\xbb\x67\xa8\x0a\x17\x91\xba\xff\xf7\x2f\xc6\xbe\xff\x04\x59\x0e\x83\x9c\xa8\x5b\x49\x9b\x0d\xf8\x95\x33\x4b\x8b\x99\x18\x46\xad\x0d\xa4\x55\xc3\x2c\x67\xd7\x48\x5a\xa1\x59\x04\xf0\xd7\xe5\xe9\xe3\x76\x74\x74\xe4\xfb\xbb\x8c\x0f\xfd\x8b\x41\xb4\x37\x6d\x53\xb8\x41\xf4\x15\x86\x1b\x44\x4f\xe1\x11\x83\xb8\x57\x78\xcc\x20\xee\x14\x6e\xf4\x2b\x00\x00\xff\xff\x84\x7d\x6a\x64\x81\x02\x00\x00")
+var _migrations25_expingest_rename_columnsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x90\xcd\xae\x82\x30\x10\x85\xf7\x7d\x8a\xd9\xdf\xf0\x04\xac\xb8\xda\x1d\x3f\x09\x81\x75\x83\x50\x9a\x26\x58\x0c\x03\x31\xbe\xbd\xa1\x65\xb4\xea\xc2\xe2\xee\xcc\x34\x5f\x27\xe7\x8b\x22\xf8\x3b\x6b\x35\x35\xb3\x84\xfa\xc2\x58\x92\x56\xbc\x84\x2a\xf9\x4f\x39\x34\x6d\x3b\x2e\x66\x46\x81\x5a\x19\x39\x21\x94\x3c\x4f\x32\x0e\x87\x22\xad\xb3\x9c\x9e\xa1\x2a\x28\x0a\xdd\xc5\xaf\x5f\x8c\x7d\xff\x09\xa2\x1c\x06\x39\xe9\x6e\x25\x5d\xb6\xe0\x57\xce\x2e\x1d\x66\x63\x18\xb5\x5e\xd0\x46\x35\x88\x72\xa6\x8b\xda\x28\x61\x17\x01\xfc\x69\xb9\xf9\xb8\x1b\x89\x66\xbe\xbf\xe3\x78\x35\xbf\x18\x14\xae\xd3\x36\x85\x1b\xdc\x38\xd2\x19\xd0\x85\xb4\x3d\x14\xee\x31\x28\xde\x15\xee\x33\xf8\xc4\x3d\xa3\x31\xbb\x0f\x00\x84\x7d\x6a\x64\x81\x02\x00\x00")
 
 func migrations25_expingest_rename_columnsSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -477,7 +478,7 @@ func migrations25_expingest_rename_columnsSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations26_exp_history_ledgersSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\xce\xb1\x0a\xc2\x30\x10\x87\xf1\xfd\x9e\xe2\x3f\x2a\xd2\x27\xe8\x54\x6d\x06\xb1\xa0\x94\x3a\x97\x90\x9c\xf5\xa0\x5e\x4a\x92\x62\x7d\x7b\xc1\x49\x2a\xb8\x7e\xbf\xe5\x2b\x0a\xec\x1e\x32\x44\x9b\x19\xd7\x89\xe8\xd0\x9a\xaa\x33\xe8\xaa\x7d\x63\xc0\xcb\xd4\xdf\x25\xe5\x10\x5f\xfd\xc8\x7e\xe0\x98\xb0\x21\x00\x68\x8e\x27\x83\x15\x7d\x40\xd4\x8d\xb3\x17\x1d\xe0\xf9\x66\xe7\x31\xaf\xb3\x0b\x9a\x72\xb4\xa2\x3f\x22\xea\x79\xe1\x44\xdb\x92\xe8\x7b\xab\x0e\x4f\x25\xaa\xdb\xf3\xe5\xcf\x96\xb3\xc9\x59\xcf\x25\xbd\x03\x00\x00\xff\xff\xe9\x1c\x12\x26\xd1\x00\x00\x00")
+var _migrations26_exp_history_ledgersSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\xce\xb1\x0a\xc2\x30\x10\x87\xf1\xfd\x9e\xe2\x3f\x2a\xd2\x27\xe8\x54\x6d\x06\xb1\xa0\x94\x3a\x97\x90\x9c\xf5\xa0\x5e\x4a\x92\x62\x7d\x7b\xc1\x49\x2a\xb8\x7e\xbf\xe5\x2b\x0a\xec\x1e\x32\x44\x9b\x19\xd7\x89\xe8\xd0\x9a\xaa\x33\xe8\xaa\x7d\x63\xc0\xcb\xd4\xdf\x25\xe5\x10\x5f\xfd\xc8\x7e\xe0\x98\xb0\x21\x00\x68\x8e\x27\x83\x15\x7d\x40\xd4\x8d\xb3\x17\x1d\xe0\xf9\x66\xe7\x31\xaf\xb3\x0b\x9a\x72\xb4\xa2\x3f\x22\xea\x79\xe1\x44\xdb\x92\xe8\x7b\xab\x0e\x4f\x25\xaa\xdb\xf3\xe5\xcf\x96\xb3\xc9\x59\xcf\x25\xbd\x07\x00\xe9\x1c\x12\x26\xd1\x00\x00\x00")
 
 func migrations26_exp_history_ledgersSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -497,7 +498,7 @@ func migrations26_exp_history_ledgersSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations27_exp_history_transactionsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x91\x41\x4a\xc6\x30\x10\x85\xf7\x39\xc5\x2c\x15\xe9\x09\xba\xaa\x36\x0b\xb1\xa0\x94\xba\x2e\xc3\x24\xd6\x81\x3a\x09\xc9\x14\xeb\xed\x05\xa1\x52\xb4\xb1\x1b\xf9\xb7\xf9\x5e\xe0\x9b\xf7\xaa\x0a\x6e\xde\x78\x4a\xa8\x1e\x9e\xa3\x31\x77\xbd\x6d\x06\x0b\x43\x73\xdb\x59\xf0\x6b\x1c\x5f\x39\x6b\x48\x1f\xa3\x26\x94\x8c\xa4\x1c\x24\xc3\x95\x01\x00\xe8\xee\x1f\x2c\x1c\xf1\x2f\xca\x42\xf3\xe2\x58\x26\x70\xfe\x05\x97\x59\x7f\x3e\x53\x90\xac\x09\x59\x7e\x11\x16\xe7\x57\x9f\xcd\x75\xfd\x87\x10\x12\x85\x45\xf4\x50\x66\x63\x17\x11\xd9\x5d\x3e\x46\x4c\xca\xc4\x11\x0b\x62\xa5\xec\x7f\x8a\x9a\xfd\xa6\x6d\x78\x17\x63\xda\xfe\xf1\xe9\x6c\x53\xc2\x4c\xe8\x7c\x5d\x4c\x7f\x17\x7e\x9a\x2c\x36\xb2\xfd\xfc\x0c\x00\x00\xff\xff\x48\x5d\x4e\x5b\x76\x02\x00\x00")
+var _migrations27_exp_history_transactionsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x91\xcd\x4a\xc5\x40\x0c\x85\xf7\xf3\x14\x59\x2a\x72\x9f\xa0\xab\xab\x9d\x85\x58\x50\x4a\x5d\x97\x90\x19\x6b\xa0\x66\x86\x49\x8a\xf5\xed\x05\x41\xa9\xda\x9f\x8d\xb8\xcd\x77\x16\xdf\x39\x39\x9d\xe0\xea\x85\x87\x82\x16\xe1\x31\x3b\x77\xd3\xfa\x73\xe7\xa1\x3b\x5f\x37\x1e\xe2\x9c\xfb\x67\x56\x4b\xe5\xad\xb7\x82\xa2\x48\xc6\x49\x14\x2e\x1c\x00\x40\x73\x7b\xe7\x61\x8d\x7f\x50\x16\x1a\xa7\xc0\x32\x40\x88\x4f\x38\x8d\xf6\xf3\x4c\x49\xd4\x0a\xb2\xfc\x22\x2c\x21\xce\x51\xdd\x65\xb5\x23\x84\x44\x69\x12\x5b\x95\xf9\x64\xff\x22\xb2\x68\xde\x67\x2c\xc6\xc4\x19\xc5\x8e\x56\xfa\x96\xfd\x4b\x51\xb7\xfc\x69\x9d\x5e\xc5\xb9\xba\xbd\x7f\xd8\x37\x57\x20\x54\xc2\x10\xab\xcd\xf4\xd7\xe0\x87\xc9\xad\x96\x40\xa8\x84\x21\x56\xef\x03\x00\x48\x5d\x4e\x5b\x76\x02\x00\x00")
 
 func migrations27_exp_history_transactionsSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -517,7 +518,7 @@ func migrations27_exp_history_transactionsSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations28_exp_history_operationsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x90\xc1\xaa\xc2\x30\x10\x45\xf7\xf9\x8a\x59\xbe\x87\xf4\x0b\xba\xaa\x36\x0b\xb1\xa0\x94\xba\x2e\x43\x12\xeb\x40\x9d\x84\x64\x8a\xf5\xef\x05\x17\x62\x2d\x54\x17\x6e\xe7\xdc\x81\x73\x6f\x96\xc1\xea\x42\x5d\x44\x71\x70\x0c\x4a\x6d\x6a\x5d\x34\x1a\x9a\x62\x5d\x69\x70\x63\x68\xcf\x94\xc4\xc7\x5b\xeb\x83\x8b\x28\xe4\x39\xc1\x9f\x02\x00\xa8\xb6\x3b\x0d\x73\xfa\x60\xc4\xa6\x1f\x2c\x71\x07\xd6\x9d\x70\xe8\xe5\xfd\x6c\x3c\x27\x89\x48\x3c\x23\xc4\xd6\x8d\x2e\xa9\xff\xfc\x1b\x99\x36\x60\x14\x32\x14\x90\x65\x59\x6c\x92\xfc\xa5\xe4\xeb\x82\xa5\xbf\xb2\x52\x65\xbd\x3f\x2c\x2f\x68\x30\x19\xb4\x2e\xff\x9c\x9d\x16\x7c\xfe\xdd\x03\x00\x00\xff\xff\x70\x31\x67\x84\xb7\x01\x00\x00")
+var _migrations28_exp_history_operationsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x90\xb1\x0a\xc2\x30\x10\x86\xf7\x3c\xc5\x8d\x8a\xf4\x09\x3a\x55\x9b\x41\x2c\x28\xa5\xce\xe5\x48\xce\x7a\x50\x2f\x21\x49\xb1\xbe\xbd\xe0\x64\x2d\x54\x07\xd7\xfb\xfe\xe1\xbb\x2f\xcb\x60\x73\xe3\x2e\x60\x22\x38\x7b\xa5\x76\xb5\x2e\x1a\x0d\x4d\xb1\xad\x34\xd0\xe8\xdb\x2b\xc7\xe4\xc2\xa3\x75\x9e\x02\x26\x76\x12\x61\xa5\x00\x00\xaa\xfd\x41\xc3\x9c\xbe\x18\x8b\xe9\x07\xcb\xd2\x81\xa5\x0b\x0e\x7d\xfa\x3c\x1b\x27\x31\x05\x64\x99\x11\x16\x4b\x23\x45\xb5\xce\x7f\x91\x69\x3d\x86\xc4\x86\x3d\x4a\x5a\x16\x9b\x2c\xff\x29\xf9\x5e\xb0\x74\x77\x51\xaa\xac\x8f\xa7\xe5\x82\x06\xa3\x41\x4b\xf9\xf7\xed\xf4\x41\x83\xd1\xa0\xa5\x5c\x3d\x07\x00\x70\x31\x67\x84\xb7\x01\x00\x00")
 
 func migrations28_exp_history_operationsSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -537,7 +538,7 @@ func migrations28_exp_history_operationsSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations29_exp_history_assetsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x74\xce\xb1\x0a\xc2\x40\x0c\x80\xe1\x3d\x4f\x91\x51\x91\x3e\x41\xa7\x6a\x6f\x10\x0b\x4a\xa9\x73\x09\x77\xb1\x06\x6a\xae\x5c\xae\x58\xdf\x5e\x70\x52\x8a\xeb\xff\x2f\x5f\x51\xe0\xee\x21\x43\xa2\xcc\x78\x9d\x00\x0e\xad\xab\x3a\x87\x5d\xb5\x6f\x1c\xf2\x32\xf5\x77\xb1\x1c\xd3\xab\x27\x33\xce\x86\x1b\x40\x44\x6c\x8e\x27\x87\xbf\xe7\xd3\x45\xfd\x38\x07\xd1\x01\x03\xdf\x68\x1e\x57\xd9\x47\xb5\x9c\x48\x74\x75\x44\x03\x2f\x6c\xb0\x2d\x01\xbe\x51\x75\x7c\x2a\x40\xdd\x9e\x2f\xff\x51\x9e\xcc\x53\xe0\x12\xde\x01\x00\x00\xff\xff\x17\x4e\x53\x21\xce\x00\x00\x00")
+var _migrations29_exp_history_assetsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x74\xce\xb1\x0a\xc2\x40\x0c\x80\xe1\x3d\x4f\x91\x51\x91\x3e\x41\xa7\x6a\x6f\x10\x0b\x4a\xa9\x73\x09\x77\xb1\x06\x6a\xae\x5c\xae\x58\xdf\x5e\x70\x52\x8a\xeb\xff\x2f\x5f\x51\xe0\xee\x21\x43\xa2\xcc\x78\x9d\x00\x0e\xad\xab\x3a\x87\x5d\xb5\x6f\x1c\xf2\x32\xf5\x77\xb1\x1c\xd3\xab\x27\x33\xce\x86\x1b\x40\x44\x6c\x8e\x27\x87\xbf\xe7\xd3\x45\xfd\x38\x07\xd1\x01\x03\xdf\x68\x1e\x57\xd9\x47\xb5\x9c\x48\x74\x75\x44\x03\x2f\x6c\xb0\x2d\x01\xbe\x51\x75\x7c\x2a\x40\xdd\x9e\x2f\xff\x51\x9e\xcc\x53\xe0\x12\xde\x03\x00\x17\x4e\x53\x21\xce\x00\x00\x00")
 
 func migrations29_exp_history_assetsSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -557,7 +558,7 @@ func migrations29_exp_history_assetsSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations2_index_participants_by_toidSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x8f\xb1\xca\xc2\x50\x0c\x46\xf7\x3c\x45\xc6\xff\x47\xfa\x04\x9d\xc4\x16\xe9\xd2\x4a\xb5\xe0\x76\x49\xdb\x8b\xcd\xe0\xcd\x25\x37\x20\x7d\x7b\x41\x07\x5b\xbb\xb8\x86\x8f\x73\x72\xb2\x0c\x77\x77\xbe\x29\x99\xc7\x2e\x02\x1c\xda\x72\x7f\x29\xb1\xaa\x8b\xf2\x8a\x93\x44\xd7\xcf\x6e\x12\x1e\xb1\xa9\x71\xe2\x64\xa2\xb3\x93\xe8\x95\x8c\x25\xb8\x48\x6a\x3c\x70\xa4\x60\x09\xbb\x73\x55\x1f\xb1\x37\xf5\x1e\xff\xb6\x5b\x1e\xff\xf3\x2f\xbc\xbd\xf1\xb6\xc6\x9b\x52\x48\x34\xfc\x28\x58\xae\x5f\x0a\x58\x26\x15\xf2\x08\x00\x45\xdb\x9c\xb6\x49\xf9\xea\xfe\xf9\x25\x87\x67\x00\x00\x00\xff\xff\x33\xec\x54\x7a\x15\x01\x00\x00")
+var _migrations2_index_participants_by_toidSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x8f\xb1\xca\xc2\x50\x0c\x46\xf7\x3c\x45\xc6\xff\x47\xfa\x04\x9d\xc4\x16\xe9\xd2\x4a\xb5\xe0\x76\x49\xdb\x8b\xcd\xe0\xcd\x25\x37\x20\x7d\x7b\x41\x07\x5b\xbb\xb8\x86\x8f\x73\x72\xb2\x0c\x77\x77\xbe\x29\x99\xc7\x2e\x02\x1c\xda\x72\x7f\x29\xb1\xaa\x8b\xf2\x8a\x93\x44\xd7\xcf\x6e\x12\x1e\xb1\xa9\x71\xe2\x64\xa2\xb3\x93\xe8\x95\x8c\x25\xb8\x48\x6a\x3c\x70\xa4\x60\x09\xbb\x73\x55\x1f\xb1\x37\xf5\x1e\xff\xb6\x5b\x1e\xff\xf3\x2f\xbc\xbd\xf1\xb6\xc6\x9b\x52\x48\x34\xfc\x28\x58\xae\x5f\x0a\x58\x26\x15\xf2\x08\x00\x45\xdb\x9c\xb6\x49\xf9\xea\xfe\xf9\x25\x87\xe7\x00\x33\xec\x54\x7a\x15\x01\x00\x00")
 
 func migrations2_index_participants_by_toidSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -577,7 +578,7 @@ func migrations2_index_participants_by_toidSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations30_exp_history_tradesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x96\x41\x8f\xda\x3c\x10\x86\xef\xf9\x15\xa3\x3d\x81\x3e\xf8\xd4\x73\xb7\xad\xb4\x65\xd3\x16\x2d\x0a\x2d\x0b\x52\x6f\x96\x63\x0f\xc4\xda\x60\x47\xf6\x44\xec\xf6\xd7\x57\x4e\x1a\x36\x09\x86\x0d\x6a\x39\xce\x8c\x9f\x79\xfd\x7a\x1c\x33\x9d\xc2\x7f\x7b\xb5\xb3\x9c\x10\x36\x45\x14\x4d\xa7\x70\x40\x10\x5c\x6b\x43\x20\x2c\xfa\x38\x3e\x17\x2c\x53\x8e\x8c\x7d\x61\x64\xb9\x44\x07\xdc\xbd\xaf\x6a\x67\xab\xf8\x6e\x1d\xc3\xfa\xee\xf3\x22\x0e\xd5\x8d\x7c\x91\xff\x2d\xe6\x0f\x31\x74\x93\x4d\x4a\x69\x91\x97\x52\xe9\x1d\x48\xdc\xf2\x32\xa7\x40\x46\x18\xed\xc8\x72\xa5\x43\x49\xa5\x25\x3e\xd7\xbc\xf1\x6d\x25\x2b\x45\xc1\x4b\x87\x40\x19\xf6\x9a\x02\xf1\x34\x47\xc8\xb8\x03\x8b\x5b\xb4\xa8\x05\xb6\xe9\x40\xe6\xb8\x82\x0b\x61\x4a\x1f\xe3\x5a\xbe\x06\x9d\xc3\x5a\x84\x8f\x1e\x10\xa4\x01\xef\xd5\x81\x6b\xf2\x8b\x85\x29\x5e\x80\x32\xe3\x3a\xd8\xff\x41\x69\x47\xc8\xe5\xc4\x2f\x69\x6a\x5f\x15\x50\x86\x1e\xd9\x76\xb0\xd3\xbd\x93\xa8\x14\xd4\x1b\x71\x51\xf4\xf6\x11\x78\xb7\x9a\xa0\x29\xd0\x72\x52\x46\x33\x25\x21\x55\x3b\xa5\x09\x92\xe5\x1a\x92\xcd\x62\x31\xa9\x2a\x6f\x8c\x95\x68\x6f\x40\x69\xc2\x1d\xda\x5e\x36\x47\xb9\x43\xcb\x44\x6e\x1c\x4a\xc6\x09\x48\xed\xd1\x11\xdf\x17\x70\x50\x94\x99\xb2\x8e\xc0\x2f\xa3\xb1\xb7\xd4\x6c\xb7\x68\xcf\xb6\x4d\xb9\xc3\x66\xcf\x81\x22\x58\xc5\x5f\xe2\x55\x9c\xcc\xe2\xc7\xa0\x4b\x23\x25\xc7\x6d\x90\xf7\xe8\x1a\x4c\xe5\x69\x1f\xb2\xf7\xe8\xb0\xdc\xaa\x2b\xda\x7f\xa2\xf8\xc8\xfa\x4b\xd1\x47\xce\x05\xdd\xd5\xc6\x94\x63\x0e\xf3\x1c\x2d\xa4\xc6\xe4\xc8\x75\x9d\x2b\xac\x12\xc8\xf4\x9f\x85\xed\x98\xec\xc4\x2a\x46\xef\x34\xbb\x0a\x82\xc9\xd9\x32\x79\x5c\xaf\xee\xe6\xc9\x3a\x30\xa6\xac\xe5\x38\x13\x19\x8a\x27\x98\x7d\x8b\x67\x0f\x30\x1a\xb5\xcf\xe2\xd3\x47\x78\x37\x1e\x0f\xe1\x85\x18\x8d\xbf\x1f\x4e\x2c\x1f\xc8\xec\x18\xdc\x6b\xd1\x73\xbf\x56\x1a\xf9\x0f\x52\x73\x45\xe7\xc9\x7d\xfc\xb3\xe6\x92\x95\x2c\x7d\x61\xed\xa1\x87\x65\x12\xba\xbe\x9b\xc7\x79\xf2\x15\x52\xb2\x88\x30\xea\x5d\x12\x0f\xbf\xcc\xae\x0e\x62\x30\xb9\x39\xb6\x8b\xdc\xde\xe4\x0f\x81\x9f\x5e\x96\x41\x1d\x06\x8b\xef\x8f\xdd\x45\xfa\x60\xea\xdb\xb4\xa6\x6f\x6e\xcc\x53\x59\x5c\xe5\x44\x33\x77\x67\xd9\x05\x57\x96\xf9\x4f\xe9\x15\xf4\xce\x94\x4f\x4e\x86\x7c\x72\xf2\xf5\x6e\xb5\xdf\x24\xf3\x1f\x9b\x53\x15\x4a\x0e\x69\x1c\x7a\x5d\x26\xcd\x4b\x72\x7e\x8f\x57\x6e\x2f\xa4\xbe\xfd\xff\xe5\xde\x1c\x74\x14\xdd\xaf\x96\xdf\xcf\xbf\x86\x82\x3b\xc1\x25\xde\x46\xbf\x03\x00\x00\xff\xff\xd4\x84\xb9\x21\xf9\x08\x00\x00")
+var _migrations30_exp_history_tradesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x96\xcd\x8e\xda\x30\x10\xc7\xef\x79\x8a\xd1\x9e\x40\x85\xaa\xe7\xd2\x56\xa2\x90\xb6\x68\x51\x68\xf9\x90\x7a\x8b\x1c\x7b\x20\xd6\x06\x3b\xb2\x27\x62\xe9\xd3\x57\x4e\x36\xd9\x24\x84\x25\xa8\xbd\xce\xc7\x6f\xfe\x33\xe3\xd8\x19\x8f\xe1\xdd\x51\x1e\x0c\x23\x84\x5d\xea\x79\xe3\x31\x9c\x10\x38\x53\x4a\x13\x70\x83\xce\x8e\xcf\x69\x18\x4b\x4b\xda\x9c\x43\x32\x4c\xa0\x05\x66\x3f\xe6\xb1\xb3\xb5\x3f\xdd\xfa\xb0\x9d\x7e\x5d\xfa\x5d\x71\x03\x17\x04\x00\xb0\x5c\x3c\xfa\xd0\x74\x96\x2e\xa9\x78\x92\x09\xa9\x0e\x20\x70\xcf\xb2\x84\x3a\x3c\x5c\x2b\x4b\x86\x49\xd5\xe5\x94\x4a\xe0\x73\xc1\x1b\x4e\x72\x59\x11\x72\x96\x59\x04\x8a\xb1\x55\x14\x88\x45\x09\x42\xcc\x2c\x18\xdc\xa3\x41\xc5\xb1\x4e\x07\xd2\x55\x06\xe3\x5c\x67\xce\xc6\x94\x78\x35\x5a\x8b\x85\x08\x67\x3d\x21\x08\x0d\x6e\x56\x27\xa6\xc8\x25\x73\x9d\x9e\x81\x62\x6d\x1b\xd8\xf7\x20\x95\x25\x64\x62\xe4\xc6\x5b\xc6\xbe\x2a\xa0\x18\x1d\xb2\x3e\xc1\x46\xf5\x86\x23\x57\x50\x34\x62\x3d\xef\xf6\x0a\xdc\xb4\x4a\xa3\x4e\xd1\x30\x92\x5a\x85\x52\x40\x24\x0f\x52\x11\x04\xab\x2d\x04\xbb\xe5\x72\x94\x47\x3e\x68\x23\xd0\x3c\x80\x54\x84\x07\x34\x2d\x6f\x82\xe2\x80\x26\xe4\x89\xb6\x28\x42\x46\x40\xf2\x88\x96\xd8\x31\x85\x93\xa4\x58\x67\x85\x05\xfe\x68\x85\xad\x54\xbd\xdf\xa3\xb9\x5a\x36\x62\x16\xcb\x9e\x3b\x82\x60\xed\x7f\xf3\xd7\x7e\x30\xf3\x37\x9d\x53\x1a\x48\x31\xac\x83\xdc\x8c\xee\xc1\xb8\xf8\x0b\xc8\xd1\xa1\xdb\x88\x22\x20\xdf\x0d\x9a\xff\xa2\xb8\x62\xfd\xa3\xe8\x8a\xf3\x86\xee\xbc\x31\x69\x43\x8b\x49\x82\x06\x22\xad\x13\x64\xaa\xe8\x29\x35\x92\x63\xa8\x5e\x12\xeb\xb6\x52\x52\x8d\xd1\xda\x66\x53\x41\xa7\x73\xb6\x0a\x36\xdb\xf5\x74\x11\x6c\x1b\x5d\x14\x97\x41\x58\x9b\x78\xc8\x63\xe4\x4f\x30\xfb\xe1\xcf\x1e\x61\x30\xa8\xef\xe2\xcb\x67\xf8\x30\x1c\xf6\xe1\x75\x31\xca\xf9\x7e\xba\x18\x79\x4f\x66\x99\xd5\x25\xb3\x42\xd6\x95\x7a\xee\x42\x2a\x3f\xd1\x45\x30\xf7\x7f\x17\x5c\x32\x22\x8c\xce\x2f\x4d\x17\x07\x02\x56\x41\x47\x4d\xd8\x6d\x16\xc1\x77\x88\xc8\x20\xc2\xa0\xf5\x91\x0c\x27\xb7\xd8\xf9\x22\x7a\x93\xcb\xb5\xbd\xc9\xad\xfa\xec\x2f\xbb\x95\xd2\xb7\x42\x6f\xf1\x8d\x84\x5b\xf4\xde\xd4\xdb\xb4\xb2\x6e\xa2\xf5\x53\x96\xde\xa3\xb4\x3a\x77\x57\xd9\x29\x93\x26\x74\x57\xe9\x1d\xf4\xc6\x29\x1f\x5d\x1c\xf2\xd1\xc5\xed\x5d\x6b\x6d\x17\x2c\x7e\xed\x2e\x55\x48\xd1\xa7\x70\xd7\xeb\x32\x2a\x5f\x92\xeb\xf3\xbb\xb3\xbd\x2e\xf5\xf5\xff\x97\xb9\x3e\x29\xcf\x9b\xaf\x57\x3f\xaf\xbf\x86\x9c\x59\xce\x04\x4e\xbc\xbf\x03\x00\xd4\x84\xb9\x21\xf9\x08\x00\x00")
 
 func migrations30_exp_history_tradesSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -597,7 +598,7 @@ func migrations30_exp_history_tradesSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations31_exp_history_effectsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\xce\xb1\xaa\xc2\x30\x14\x87\xf1\xfd\x3c\xc5\x7f\xbc\x17\xe9\x13\x74\xaa\x36\x83\x58\x50\x4a\x9d\x4b\x48\x4e\xeb\x81\x7a\x52\x92\x14\xeb\xdb\x0b\x4e\x62\xc1\xf5\xfb\x2d\x5f\x51\x60\x77\x97\x31\xda\xcc\xb8\xce\x44\x87\xd6\x54\x9d\x41\x57\xed\x1b\x03\x5e\xe7\xfe\x26\x29\x87\xf8\xec\x79\x18\xd8\xe5\x84\x3f\x02\x80\xe6\x78\x32\xf8\xa2\x37\x88\xba\x69\xf1\xa2\x23\x3c\x0f\x76\x99\x36\xd9\x05\x4d\x39\x5a\xd1\x8d\x88\x7a\x5e\x39\xd1\x7f\x49\xf4\xb9\x55\x87\x87\x12\xd5\xed\xf9\xf2\x63\xcb\xd9\xe4\xac\xe7\x92\x5e\x01\x00\x00\xff\xff\xaa\x4f\x70\x26\xd1\x00\x00\x00")
+var _migrations31_exp_history_effectsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\xce\xb1\xaa\xc2\x30\x14\x87\xf1\xfd\x3c\xc5\x7f\xbc\x17\xe9\x13\x74\xaa\x36\x83\x58\x50\x4a\x9d\x4b\x48\x4e\xeb\x81\x7a\x52\x92\x14\xeb\xdb\x0b\x4e\x62\xc1\xf5\xfb\x2d\x5f\x51\x60\x77\x97\x31\xda\xcc\xb8\xce\x44\x87\xd6\x54\x9d\x41\x57\xed\x1b\x03\x5e\xe7\xfe\x26\x29\x87\xf8\xec\x79\x18\xd8\xe5\x84\x3f\x02\x80\xe6\x78\x32\xf8\xa2\x37\x88\xba\x69\xf1\xa2\x23\x3c\x0f\x76\x99\x36\xd9\x05\x4d\x39\x5a\xd1\x8d\x88\x7a\x5e\x39\xd1\x7f\x49\xf4\xb9\x55\x87\x87\x12\xd5\xed\xf9\xf2\x63\xcb\xd9\xe4\xac\xe7\x92\x5e\x03\x00\xaa\x4f\x70\x26\xd1\x00\x00\x00")
 
 func migrations31_exp_history_effectsSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -617,7 +618,7 @@ func migrations31_exp_history_effectsSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations32_drop_exp_history_tablesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x57\xd1\x6e\xda\x30\x14\x7d\xe7\x2b\xae\xfa\x04\x1a\x4c\x7b\x5e\xb7\x49\x1d\xcd\x36\x54\x14\x36\x0a\xd2\xde\x22\xc7\xbe\x10\xab\xc1\x8e\x6c\x23\xda\x7d\xfd\x94\xa4\x69\x1d\xc7\xa4\x66\x43\xbc\xfa\x5c\x9f\x7b\x7c\x7c\x7c\x03\x93\x09\xbc\xdb\xf1\xad\x22\x06\x61\x5d\x0c\x06\xb7\xcb\xc5\x4f\x58\xdd\x7c\x9d\x47\x80\x8f\x45\x92\x71\x6d\xa4\x7a\x4a\x70\xb3\x41\x6a\x34\x50\xa2\x29\x61\x78\x7d\xb4\xd0\x28\xc2\x30\xa0\x8e\x68\x8d\x21\x7c\xb2\x40\x45\x0c\x97\x22\x29\x88\x32\x9c\xf2\x82\x88\x93\xf6\x85\x69\x16\x9a\xd0\x7f\xe8\x42\x28\x95\xfb\xa0\x4a\xab\x47\x40\x75\x8e\x6c\x8b\xca\x2e\xb4\xef\xe9\x56\x1e\xc4\x60\x30\x5d\x46\x37\xab\xa8\x67\xf3\x70\x00\x00\x30\x9f\xdd\x45\xe0\x40\x15\xc0\x05\xcd\xf7\x8c\x8b\x2d\x30\xdc\x90\x7d\x6e\xdc\x65\x2a\x85\x36\x8a\x70\xd1\x41\xb8\x60\xf8\x88\x7a\x30\xba\xee\x91\xd1\x3a\xb1\x47\x8b\x8d\x5f\x44\xd0\xcb\x65\x79\xc4\x34\xd8\xa5\x9d\x69\xe7\xad\xdf\xa5\x56\xed\x45\x84\x5a\x8f\xc8\x23\xed\x15\xbd\xac\x98\x37\x3d\xf3\x57\x5e\x26\x63\xf5\x58\xf3\x25\xac\x42\xce\x29\xa2\x9c\x09\x07\x04\x4a\x84\x90\x06\xa8\xc2\x72\x36\x78\x66\x31\xd1\x1f\xab\xda\xbe\x3c\x96\x75\xc3\xb2\xc8\x17\x40\x86\xba\x81\x3c\xd2\x3b\x88\xad\xbe\x03\x36\x07\x98\x4c\x60\x54\x8f\xb5\x14\x29\xd9\x6b\x04\x93\xa1\xd3\x14\x0c\x49\x73\x84\x8c\x68\x50\xb8\x41\x85\x82\xa2\xcd\x0e\x46\x76\x1e\x30\x10\xc1\x5c\xcf\x27\x93\x6a\xf5\x80\xc0\x24\x94\x5e\x1d\x88\x30\xe5\x66\x2a\x8b\x27\x30\x99\xd4\x2d\xda\xf7\xc0\x85\x36\x48\xd8\xb8\xdc\xd2\xd4\xbe\x2a\x30\x19\x96\x94\xde\xd1\x52\xf6\xf1\xe4\xa1\x3a\x88\xee\x1f\x09\xf5\x15\x94\x6e\x75\x73\xcc\x19\xa4\x7c\xcb\x85\x81\x78\xb1\x82\x78\x3d\x9f\x8f\xab\xca\x2b\xa9\x18\xaa\x2b\xe0\xc2\xe0\x16\x95\x83\xd6\xd3\x3e\xa1\xb9\xd4\xc8\x12\x62\xc0\xf0\x1d\x6a\x43\x76\x05\x1c\xb8\xc9\xe4\xbe\x5e\x81\x3f\x52\xa0\xb3\x55\x6e\x36\xa8\x8e\xb6\x4d\x89\xc6\xe6\xcc\x9e\x22\x58\x46\xdf\xa2\x65\x14\x4f\xa3\x7b\xaf\x4b\x43\xce\x46\x36\x51\xe9\xd1\x29\x34\x95\xa7\x2e\xc9\xae\xa4\xf6\xcb\xad\xba\xa2\x3a\x8b\xe2\x17\xae\xff\x14\xfd\xc2\xd3\xa3\xbb\x3a\x18\xd7\x89\xc6\x3c\x47\x05\xa9\x94\x39\x12\x51\x63\x85\xe2\x14\x13\xf1\xbc\xd1\x5e\x63\xad\xb5\x8a\xc3\xb9\xcd\xb6\x02\x2f\x38\x5d\xc4\xf7\xab\xe5\xcd\x2c\x5e\x79\x62\x9a\x58\x8e\x27\x34\x43\xfa\x00\xd3\x1f\xd1\xf4\x0e\x86\x43\xfb\x2e\xbe\x7c\x86\x0f\xa3\x51\x08\x9f\x8f\xa3\xf1\xf7\x53\xc7\xf2\x40\xce\x96\xc1\x4e\x0b\xc7\xfd\x5a\x69\x3d\x54\x9f\x9f\xe8\x2c\xbe\x8d\x7e\xd7\xbc\x46\xb1\x24\x7d\x4a\xec\xd0\xc3\x22\xf6\x3d\xdf\xf5\xfd\x2c\xfe\x0e\xa9\x51\x88\x30\x74\x1e\x89\xf5\xd9\x38\xc2\x5d\x5d\x44\x30\x73\x73\x6d\xbd\xbc\x4e\xf2\x43\xc8\xbb\x8f\x25\xa8\x43\xb0\x78\x37\x76\xbd\xec\xc1\xac\x6f\xb3\x35\x7d\x73\x29\x1f\xf6\xc5\x49\x4e\x34\xb9\x3b\xca\x5d\x10\xae\x92\x72\x94\x9e\xc0\xde\x4a\xf9\xb8\x13\xf2\x71\x67\x7a\x5b\xed\xd7\xf1\xec\xd7\xba\xab\x82\xb3\x90\xc6\xbe\xaf\xcb\xb8\xf9\x92\x1c\x3f\xe3\x89\xc7\xeb\x51\x7f\xfc\xdf\xa5\xe7\x67\xd3\x33\x74\xc6\xdf\x4d\x7f\x03\x00\x00\xff\xff\xf1\x72\xe9\x29\xf2\x0e\x00\x00")
+var _migrations32_drop_exp_history_tablesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x57\xc1\x8e\xda\x30\x10\xbd\xe7\x2b\x46\x7b\x02\x95\x54\x3d\x97\xb6\xd2\x96\x4d\x5b\xb4\x28\xb4\x2c\x48\xbd\x45\x8e\x3d\x10\x6b\x83\x1d\xd9\x46\xec\xf6\xeb\xab\x24\x04\x9c\xc4\x81\xd0\xae\xb8\xfa\xcd\xbc\x79\xf3\xc6\x1e\x82\xef\xc3\xbb\x2d\xdf\x28\x62\x10\x56\x99\xe7\x3d\x2c\xe6\x3f\x61\x79\xff\x75\x16\x00\xbe\x64\x51\xc2\xb5\x91\xea\x35\xc2\xf5\x1a\xa9\xd1\x40\x89\xa6\x84\xe1\xb8\x33\xd0\x28\xc2\xb0\x47\x1c\xd1\x1a\xfb\xf0\xc9\x0c\x15\x31\x5c\x8a\x28\x23\xca\x70\xca\x33\x22\xae\xca\xeb\x11\x6b\x14\x11\x9a\xd0\x7f\xa8\x42\x28\x95\x3b\x61\xae\xab\xd1\x23\x3a\x45\xb6\x41\x65\x07\xda\x73\x7a\x90\x7b\xe1\x79\x93\x45\x70\xbf\x0c\xce\x24\x0f\x3c\x00\x80\xd9\xf4\x31\x80\x06\x54\x00\x5c\xd0\x74\xc7\xb8\xd8\x00\xc3\x35\xd9\xa5\xa6\x79\x4c\xa5\xd0\x46\x11\x2e\x5a\x08\x17\x0c\x5f\x50\x7b\xc3\xf1\x19\x19\xb5\x8e\x1d\x5a\x6c\xfc\x26\x82\x8e\xc3\x72\x88\xa9\xb0\x9b\x08\xe9\xbc\x6f\xe7\x5d\xaa\xc5\xde\x44\xa8\xf5\x88\x1c\xd2\x4e\xe8\x6d\xc5\x5c\xf4\xcc\x1d\x79\x13\x91\x87\xb5\xe6\x10\x55\x22\x6f\x29\xc2\xf3\x7d\xd8\x23\x50\x22\x84\x34\x40\x15\xe6\xbb\xc1\xb1\x8b\x89\xfe\x58\xec\x8f\x4e\xd1\x87\xb8\x41\x1e\xd4\xd2\x5d\x82\x15\xe4\x90\xde\x42\x6c\xf5\x2d\xb0\x6a\xc0\xf7\x21\xef\xc1\xf7\x21\x46\x4a\x76\x1a\xc1\x24\xd8\x28\x0a\x86\xc4\x29\x42\x42\x34\x28\x5c\xa3\x42\x41\xd1\xf6\x06\x8c\x6c\x3d\x60\x20\x82\x9d\x0e\x4b\xcf\x7d\xbf\x38\xdd\x23\x30\x09\xb9\x57\x7b\x22\x4c\x9e\x4c\x65\xf6\x0a\x26\x91\xba\x46\xfb\x1e\xb8\xd0\x06\x09\x1b\xe5\xf6\x56\xb1\x27\x05\x26\xc1\xdc\x0e\xdb\xc1\x5a\xf5\x1a\x50\x28\x28\x1b\xd1\x9e\x77\x79\x04\xb9\x5d\xed\x7b\xcc\x19\xc4\x7c\xc3\x85\x81\x70\xbe\x84\x70\x35\x9b\x8d\x8a\x9b\x74\x27\x15\x43\x75\x07\x5c\x18\xdc\xa0\x6a\xa0\xe5\xb6\x8f\x68\x2a\x35\xb2\x88\x18\x30\x7c\x8b\xda\x90\x6d\x06\x7b\x6e\x12\xb9\x2b\x4f\xe0\x8f\x14\xd8\x48\x95\xeb\x35\xaa\xce\xb2\x31\xd1\x58\xf5\xec\x08\x82\x45\xf0\x2d\x58\x04\xe1\x24\x78\x72\xba\x34\xe0\x6c\x68\x13\xe5\x1e\x5d\x43\x93\xc7\xb7\x48\xb6\x39\x75\x93\xa2\x0c\x28\x66\x83\xea\x4d\x14\x1f\xb9\xfe\x53\xf4\x91\xe7\x8c\xee\xa2\x31\xae\x23\x8d\x69\x8a\x0a\x62\x29\x53\x24\xa2\xec\x29\x53\x9c\x62\x24\x0e\x89\xf6\x59\x25\xc9\xe2\x68\x4c\xb3\xae\xc0\x09\x4e\xe6\xe1\xd3\x72\x71\x3f\x0d\x97\xb5\x2e\xca\x65\x10\x59\x8e\x47\x34\x41\xfa\x0c\x93\x1f\xc1\xe4\x11\x06\x03\x7b\x16\x5f\x3e\xc3\x87\xe1\xb0\x0f\x9f\x8b\xa3\xf2\xf7\x53\xcb\xf2\x9e\x9c\x55\x96\x4b\xe6\x91\xd2\x56\x5a\x7c\xce\x54\x4f\x74\x1a\x3e\x04\xbf\x4b\x5e\xa3\x58\x14\xbf\x1e\x9a\x2e\x2f\x04\xcc\x43\x47\x4d\x58\x3d\x4d\xc3\xef\x10\x1b\x85\x08\x83\xc6\x23\x19\x8e\x2f\x71\x17\x83\xe8\xcd\x5c\x8d\xed\x2c\xef\xb1\xcf\xfe\xb2\x1b\x29\x7d\x2b\xf4\x16\x5f\x4b\xb8\xc4\xde\x9b\xf5\x32\x5b\x55\x37\x95\xf2\x79\x97\x5d\xa3\xf4\x78\xef\x3a\xb9\x33\xc2\x55\x94\xaf\xd2\x2b\xd8\x6b\xb7\x7c\xd4\xba\xe4\xa3\xd6\xf6\xb6\x5a\x5b\x85\xd3\x5f\xab\xb6\x0a\xce\xfa\x14\x76\xfd\xba\x8c\xaa\x5f\x92\x6e\xff\xae\x6c\xef\x8c\xfa\xee\x7f\x97\x8e\xcf\xa6\x03\xe4\x75\x7c\x7c\xd4\x8f\xad\x1f\xf1\x06\xc2\x05\xc3\x17\xd4\xde\x70\xec\xfd\x1d\x00\xf1\x72\xe9\x29\xf2\x0e\x00\x00")
 
 func migrations32_drop_exp_history_tablesSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -637,7 +638,7 @@ func migrations32_drop_exp_history_tablesSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations33_remove_unusedSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x92\x41\x6f\xe2\x30\x10\x85\xef\xf9\x15\x73\x04\xed\x72\x5b\xed\x85\x93\x49\x66\x59\xab\xc1\x50\xc7\xa9\xca\xc9\x32\x89\x4b\x23\x91\x18\xc5\x46\x85\x7f\x5f\x99\x50\xea\xd0\xb4\xc2\x27\xdb\xf3\xe9\xbd\x79\xa3\x99\x4c\xe0\x57\x5d\x6d\x5b\xe5\x34\xe4\xfb\x28\x4a\xf8\x72\x05\x82\xcc\x52\x04\x65\xad\x76\xd2\x3a\xe5\x2c\x14\xca\x16\xaa\xd4\xd3\x0b\x40\x59\x82\xcf\xa0\x8f\x7b\x19\x40\x72\x73\x92\x85\xb9\x85\xaa\xa6\xd4\x47\xf9\x5a\x59\x67\xda\x93\x74\xad\x6a\xac\x2a\x5c\x65\x1a\x2b\x4d\x23\xab\xf2\x27\x7a\xa7\xcb\xad\x6e\x07\xc1\xce\xf7\xd3\x31\xcc\x91\x98\xb7\x26\x8a\x62\x8e\x44\xe0\x40\x96\x51\x04\x00\x50\x95\xd0\x3b\x33\x3a\xa7\x4c\x74\xf7\x15\xa7\x0b\xc2\xd7\xf0\x80\x6b\xe0\xf8\x0f\x39\xb2\x18\x33\xf8\x68\xeb\x2c\x66\x61\xc9\x20\xc1\x14\x05\x42\x4c\xb2\x98\x24\xe8\x7f\xf2\x55\xe2\x5d\x39\x66\x82\xd3\x58\xfc\x3e\x7b\xa9\xda\x1c\x1a\x37\xec\xc5\x96\x02\x58\x9e\xa6\x1d\xd9\x1c\x6a\xa9\x8a\xc2\xe3\xd6\xbf\x29\x13\x38\x47\x3e\x40\xbe\xec\xd4\xd6\x06\xfd\x67\x0b\x92\xa6\x17\xd5\x3e\xe9\x4c\xbd\x0b\x93\x3e\x11\x1e\xff\x27\x7c\xf4\xf7\xcf\xf8\x4a\x46\xe3\xe9\x75\x62\x03\x03\xf6\xc9\x6e\xd2\xe7\x19\x65\x73\xd8\xb8\x56\x6b\x18\x75\xb0\x27\xbf\xe8\x7c\xb3\x24\x5e\xf1\xa6\x74\x87\x64\xce\xe8\x63\x8e\x77\x6e\x56\xd8\x74\x58\xed\xfb\x54\xe5\x7d\xfa\xbd\x5d\x0c\xa5\x2f\x85\x01\xd5\xf7\x00\x00\x00\xff\xff\x0e\x3f\x2c\x91\x5c\x03\x00\x00")
+var _migrations33_remove_unusedSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x92\xcd\x6e\xea\x30\x10\x46\xf7\x79\x8a\x59\x82\xee\x65\x57\x75\xc3\xca\x24\x53\x6a\x35\x18\xea\x38\x55\x59\x59\x26\x71\x69\x24\x92\xa0\xd8\xa8\xf0\xf6\x95\xc3\x4f\x13\x70\x2b\x9c\x55\x3c\x47\xe7\xf3\x8c\x66\x34\x82\x7f\x65\xb1\x6e\x94\xd5\x90\x6e\x83\x20\xe2\xf3\x05\x08\x32\x89\x11\x94\x31\xda\x4a\x63\x95\x35\x90\x29\x93\xa9\x5c\x8f\x4f\x00\x65\x11\xbe\x83\xde\x6f\x65\x07\x92\xab\x83\xcc\xea\x6b\xa8\xa8\x72\xbd\x97\x9f\x85\xb1\x75\x73\x90\xb6\x51\x95\x51\x99\x2d\xea\xca\xc8\xba\x92\x45\xfe\x17\xbd\xd1\xf9\x5a\x37\x5e\xf0\x98\xfb\x93\xd8\xed\x23\xaa\xbf\xaa\x20\x08\x39\x12\x81\x9e\x5e\x06\x01\x00\x40\x91\x43\xef\x4c\xe8\x94\x32\x01\xed\x59\x70\x3a\x23\x7c\x09\x2f\xb8\x04\x8e\x4f\xc8\x91\x85\x98\xc0\xf9\x59\x6d\xb6\x81\x39\x83\x08\x63\x14\x08\x21\x49\x42\x12\xa1\xbb\x49\x17\x91\x4b\xe5\x98\x08\x4e\x43\xf1\xbf\xcd\x52\x65\xbd\xab\xac\x3f\x8b\xcd\x05\xb0\x34\x8e\x8f\x64\xb5\x2b\xa5\xca\x32\x87\x1b\xf7\x4f\x99\xc0\x29\x72\x0f\xf9\xb1\x51\x6b\x73\x16\x02\x40\x32\x23\x71\x7c\xb2\xf6\x49\x5b\x97\x9b\x33\xe5\xbe\x37\xc2\xc3\x67\xc2\x07\x8f\x0f\xc3\x8b\x33\x18\x8e\x2f\x13\xf3\x0c\xd8\x75\x76\xd5\x7d\x9a\x50\x36\x85\x95\x6d\xb4\x86\x41\x7b\xd7\x92\x37\x9e\x5f\x96\xc4\x19\xaf\x4a\x77\x28\x53\x46\x5f\x53\xf4\xee\xca\xed\x66\x75\x1f\xdd\xad\xf6\x73\x8a\xfc\x3e\x7f\x6f\x17\xbb\xea\x53\xc1\x63\xfd\x1e\x00\x0e\x3f\x2c\x91\x5c\x03\x00\x00")
 
 func migrations33_remove_unusedSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -657,7 +658,7 @@ func migrations33_remove_unusedSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations34_fee_bump_transactionsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x92\xc1\x4f\xc2\x30\x18\xc5\xef\xfd\x2b\xbe\x23\x44\xb9\x19\x12\xb3\x13\xb2\x46\x49\x66\x47\xc6\x16\x35\xc6\x34\xdd\xfc\xe8\x7a\xa0\x33\x6d\x11\xf7\xdf\x1b\x98\x64\xc5\x95\xe0\xc1\x73\x7f\xdf\x7b\xaf\x2f\x6f\x32\x81\xab\x8d\x92\x46\x38\x84\xe2\x83\x90\x59\x92\xd3\x0c\xf2\xd9\x5d\x42\xa1\x56\xd6\x35\xa6\xe5\xce\x08\x6d\x45\xe5\x54\xa3\x2d\x01\x00\xe8\xa0\x79\x9a\x14\x8f\x0c\xd6\x88\xbc\xaa\x85\x91\xf8\x0e\xf9\xcb\x92\x42\xa9\xa4\xd2\xee\x7a\x48\x6e\xc4\x17\x5f\x23\x06\xa8\x38\x06\xa5\x35\x1a\xdf\x8a\xd7\xc2\xd6\xb0\x57\x16\x95\x43\x03\x9f\xc2\xb4\x4a\xcb\xd1\xf4\x66\xdc\x5f\xed\xcd\x45\x55\x35\x5b\xed\x2e\xa1\x9d\x81\x55\x52\x0b\xb7\x35\x68\x03\xfc\xed\x74\xfc\xfa\xd6\x5f\x68\xdc\xf1\x63\xe6\x2e\x6e\x44\xc8\x3c\xa3\xb3\x9c\xc2\x82\xc5\xf4\x19\xca\x96\x77\xb2\x87\xac\x29\x0b\x56\x06\xc5\x6a\xc1\xee\xa1\x74\x06\x11\x46\xe1\x7f\x8e\xe1\xe9\x81\x66\xf4\x5c\x0b\x8b\x15\xb0\x34\x07\x56\x24\x49\x34\x48\xe0\x77\xf0\xa7\x08\xde\xc1\xd1\xd7\xd7\x38\x31\x23\xfe\x40\xe2\x66\xa7\x09\x89\xb3\x74\x19\xfa\x7f\xf4\xeb\xc5\xd3\x8c\xfe\x65\x58\x4a\x3b\x94\x68\x2e\x2d\xeb\x04\x3b\x44\xfa\xa1\xc2\xe5\x0e\x39\x2f\xf8\x39\x91\x7e\x46\x43\xc2\x9b\x4d\xf4\x1d\x00\x00\xff\xff\x12\x9d\xf6\x27\x5f\x03\x00\x00")
+var _migrations34_fee_bump_transactionsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x92\xc1\x4e\xf3\x30\x10\x84\xef\x7e\x8a\x3d\xb6\xfa\xdb\xdb\xaf\x4a\x28\xa7\xd2\x58\x50\x29\x38\x55\x9a\x08\x10\x42\x96\x6b\xb6\x8e\x0f\x75\x90\xed\x52\xfa\xf6\x88\x86\x2a\x0e\x71\x05\x07\xce\xfe\x76\x66\x3c\x9a\xe9\x14\xfe\xed\xb4\xb2\xc2\x23\x54\xaf\x84\xcc\xb3\x92\x16\x50\xce\xaf\x33\x0a\xb5\x76\xbe\xb1\x47\xee\xad\x30\x4e\x48\xaf\x1b\xe3\x08\x00\x40\x0b\x2d\xf2\xac\xba\x63\xb0\x45\xe4\xb2\x16\x56\xe1\x0b\x94\x8f\x2b\x0a\x1b\xad\xb4\xf1\x93\x21\xb9\x13\xef\x7c\x8b\x18\xa1\xd2\x14\xb4\x31\x68\x43\x2b\x5e\x0b\x57\x83\xac\x85\x15\xd2\xa3\x85\x37\x61\x8f\xda\xa8\xd1\xec\xff\xb8\xbb\xfa\x34\x17\x52\x36\x7b\xe3\x7f\x42\x5b\x03\xa7\x95\x11\x7e\x6f\xd1\x45\xf8\xab\xd9\xf8\xe9\xb9\xbb\x30\x78\xe0\xe7\xcc\x6d\xdc\x84\x90\x45\x41\xe7\x25\x85\x25\x4b\xe9\x03\x6c\x8e\xbc\x95\x3d\x65\xcd\x59\xb4\x32\xa8\xd6\x4b\x76\x03\x1b\x6f\x11\x61\x14\xff\xe7\x18\xee\x6f\x69\x41\x2f\xb5\xb0\x5c\x03\xcb\x4b\x60\x55\x96\x25\x83\x04\x61\x07\xbf\x8a\x10\x1c\x9c\x7d\x43\x8d\x9e\x19\x09\x07\x92\x36\x07\x43\x48\x5a\xe4\xab\xd8\xff\x93\x6f\x2f\x81\x66\xf2\x27\xc3\xd2\xc6\xa3\x42\x3b\x19\xa2\xbd\x65\xf5\xb0\x53\xa4\x2f\x2a\x5e\xee\x90\x0b\x82\x5f\x12\xe9\x66\x34\x24\x82\xd9\x24\x1f\x03\x00\x12\x9d\xf6\x27\x5f\x03\x00\x00")
 
 func migrations34_fee_bump_transactionsSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -677,7 +678,7 @@ func migrations34_fee_bump_transactionsSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations35_drop_participant_idSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\xce\x31\x0e\xc2\x30\x0c\x46\xe1\x3d\xa7\xf8\x77\xd4\x13\x30\x05\xd2\x2d\x50\x54\xb5\x73\x65\x95\xa8\x78\xc0\x89\x1c\x4b\x88\xdb\x33\xc2\x90\x01\x38\xc0\x7b\xfa\xba\x0e\xbb\x3b\x6f\x4a\x96\x30\x17\xe7\x7c\x9c\xfa\x11\x93\x3f\xc4\x1e\x37\xae\x96\xf5\xb9\xe4\x92\x94\x8c\xb3\x2c\x85\xd4\x78\xe5\x42\x62\xd5\x01\x40\x18\x87\x0b\x8e\x43\x9c\x4f\x67\xf0\x75\xdf\xee\x4d\x49\x2a\xad\x5f\x1e\x3e\x45\x21\x3f\xe4\x67\x93\x0f\xe1\x3d\x04\x8b\xa5\x2d\xe9\x3f\xb4\xf6\xe8\x15\x00\x00\xff\xff\xfa\x9c\x4a\x38\x32\x01\x00\x00")
+var _migrations35_drop_participant_idSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\xce\x31\x0e\xc2\x30\x0c\x46\xe1\x3d\xa7\xf8\x77\xd4\x13\x30\x05\xd2\x2d\x50\x54\xb5\x73\x65\x95\xa8\x78\xc0\x89\x1c\x4b\x88\xdb\x33\xc2\x90\x01\x38\xc0\x7b\xfa\xba\x0e\xbb\x3b\x6f\x4a\x96\x30\x17\xe7\x7c\x9c\xfa\x11\x93\x3f\xc4\x1e\x37\xae\x96\xf5\xb9\xe4\x92\x94\x8c\xb3\x2c\x85\xd4\x78\xe5\x42\x62\xd5\x01\x40\x18\x87\x0b\x8e\x43\x9c\x4f\x67\xf0\x75\xdf\xee\x4d\x49\x2a\xad\x5f\x1e\x3e\x45\x21\x3f\xe4\x67\x93\x0f\xe1\x3d\x04\x8b\xa5\x2d\xe9\x3f\xb4\xf6\xe8\x35\x00\xfa\x9c\x4a\x38\x32\x01\x00\x00")
 
 func migrations35_drop_participant_idSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -697,7 +698,7 @@ func migrations35_drop_participant_idSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations36_deleted_offersSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x92\x41\x6e\xb3\x30\x10\x85\xf7\x3e\xc5\x2c\x83\x7e\x72\x02\xf4\x2f\x48\xec\x54\x91\x08\x54\xd4\x48\xdd\x59\x50\x0f\xd4\x92\x03\x91\xed\x34\xca\xed\xab\x24\x40\x29\x85\x6e\xca\xd2\x1e\xcd\x37\xef\xcd\x9b\xf5\x1a\xfe\x1d\x55\x65\x72\x87\x90\x9d\x08\x09\x23\xce\x52\xe0\xe1\x26\x62\xd0\x94\x25\x1a\x0b\x21\xa5\x20\x51\xa3\x43\x09\x45\xd3\x68\xcc\x6b\xa0\x6c\x17\x66\x11\x87\x32\xd7\x16\x03\x42\xb6\x29\x0b\x39\x83\x7d\x4c\xd9\x2b\x14\x68\x9d\xb8\x37\x43\x12\x77\x94\xec\x65\x1f\x3f\xc1\x86\xa7\x8c\xc1\xca\xa2\xd6\xaa\xae\x44\x6e\x2d\x3a\x1f\x8a\xf3\x75\xf0\x6a\x67\xf9\x70\x32\xea\x0d\xbd\xe0\x3b\x5c\xab\x0f\x14\x2d\x73\x86\xde\x03\x74\x6e\x9d\x38\x36\x52\x95\x0a\xa5\xd0\x28\x2b\x34\x5e\x40\x08\x4d\x93\xe7\x16\xf7\xe8\x17\xc5\x55\xdc\x34\xa1\xf1\x47\x3f\x03\x5d\x5f\x85\xa1\xde\xb1\xf9\x31\x70\x5a\xe4\xea\x51\x14\x4a\xf6\x7e\xc7\x46\x67\x74\xfc\xc2\xfb\xb9\xc3\x79\xe6\xd0\xc2\x0c\x72\x32\x95\xdb\xfa\x86\x37\x43\x9b\x4b\x4d\x08\x65\x11\xe3\x0c\x76\x69\x72\xe8\x48\x97\x77\x34\xd8\xdf\xcd\x7f\x70\xe6\x8c\xcb\xaf\x7e\xe2\x5c\xef\x13\xb6\x49\x94\x1d\xe2\x6e\xfc\x5f\x33\x5a\x30\x9a\xc5\x12\xf1\x02\xf2\x19\x00\x00\xff\xff\xfa\xd2\x27\x51\xbc\x03\x00\x00")
+var _migrations36_deleted_offersSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x92\xcd\x6e\xab\x30\x10\x85\xf7\x7e\x8a\x59\x5e\x74\xc9\x13\xa0\x2e\x48\xec\x54\x91\x08\x54\xd4\x48\xdd\x59\x50\x0f\xd4\x92\x03\x91\xed\x34\xca\xdb\x57\xa5\x40\x9d\xb4\x64\x53\x96\xfe\x99\xef\xcc\x9c\x33\xab\x15\xfc\x3f\xa8\xc6\x94\x0e\xa1\x38\x12\x12\x27\x9c\xe5\xc0\xe3\x75\xc2\xa0\xab\x6b\x34\x16\x62\x4a\x41\xa2\x46\x87\x12\xaa\xae\xd3\x58\xb6\x40\xd9\x36\x2e\x12\x0e\x75\xa9\x2d\x46\x84\x6c\x72\x16\x73\x06\xbb\x94\xb2\x17\xa8\xd0\x3a\xd1\x17\x43\x96\x8e\x94\xe2\x79\x97\x3e\xc2\x9a\xe7\x8c\xc1\x3f\x8b\x5a\xab\xb6\x11\xa5\xb5\xe8\x42\xa8\x4e\x17\xef\x34\x68\x85\x70\x34\xea\x15\x83\xe8\x1a\xae\xd5\x3b\x8a\x81\x39\x43\x9f\x00\xba\xb4\x4e\x1c\x3a\xa9\x6a\x85\x52\x68\x94\x0d\x9a\x20\x22\x84\xe6\xd9\xd3\x80\xfb\x22\x89\xea\x22\x3e\x7b\x42\x13\xde\xdc\x78\x7d\x7d\x3f\xf8\xfd\xde\x0e\x7f\x5d\x3e\x67\x41\xef\x00\x1a\xa1\xe4\x34\x6f\x10\xdd\x03\x4d\x7a\x77\x78\x3f\x3d\x9c\x67\xfa\x23\xcc\x20\xfd\x2f\x3e\x91\xf8\x3b\x43\xbb\x73\x4b\x08\x65\x09\xe3\x0c\xb6\x79\xb6\x1f\x49\xe7\x37\x34\x38\x56\xc1\x03\x38\x73\xc2\xe5\xad\xff\x65\x5d\x7b\x85\x4d\x96\x14\xfb\x74\x94\xff\x6b\x46\x0b\x46\xb3\x58\x22\x41\x44\x3e\x06\x00\xfa\xd2\x27\x51\xbc\x03\x00\x00")
 
 func migrations36_deleted_offersSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -717,7 +718,7 @@ func migrations36_deleted_offersSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations37_add_tx_set_operation_count_to_ledgersSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\xcd\x31\xae\x02\x21\x10\x06\xe0\x9e\x53\xfc\xfd\xcb\x9e\x60\x2b\x9e\x60\x35\xee\x9a\x0d\xd4\x64\xa3\x13\x24\x51\xd8\x0c\x63\xd4\xdb\xdb\xda\xe8\x05\xbe\x6f\x18\xf0\x77\x2b\x59\x56\x65\xc4\xcd\x18\x4b\xc1\x2f\x08\xf6\x9f\x3c\x2e\xa5\x6b\x93\x57\xba\xf2\x39\xb3\x74\x58\xe7\xa0\xcf\xd4\x59\x53\xdb\x58\x56\x2d\xad\xa6\x53\xbb\x57\x45\xa9\xca\x99\x05\xce\xef\x6d\xa4\x80\x29\x12\x8d\xc6\x7c\xea\xae\x3d\xea\x6f\xdf\x2d\xf3\x11\xbb\x99\xe2\x61\xfa\xf2\x8c\xe6\x1d\x00\x00\xff\xff\x32\x43\x33\x1d\xb0\x00\x00\x00")
+var _migrations37_add_tx_set_operation_count_to_ledgersSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\xcd\x31\xae\x02\x21\x10\x06\xe0\x9e\x53\xfc\xfd\xcb\x9e\x60\x2b\x9e\x60\x35\xee\x9a\x0d\xd4\x64\xa3\x13\x24\x51\xd8\x0c\x63\xd4\xdb\xdb\xda\xe8\x05\xbe\x6f\x18\xf0\x77\x2b\x59\x56\x65\xc4\xcd\x18\x4b\xc1\x2f\x08\xf6\x9f\x3c\x2e\xa5\x6b\x93\x57\xba\xf2\x39\xb3\x74\x58\xe7\xa0\xcf\xd4\x59\x53\xdb\x58\x56\x2d\xad\xa6\x53\xbb\x57\x45\xa9\xca\x99\x05\xce\xef\x6d\xa4\x80\x29\x12\x8d\xc6\x7c\xea\xae\x3d\xea\x6f\xdf\x2d\xf3\x11\xbb\x99\xe2\x61\xfa\xf2\x8c\xe6\x3d\x00\x32\x43\x33\x1d\xb0\x00\x00\x00")
 
 func migrations37_add_tx_set_operation_count_to_ledgersSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -737,7 +738,7 @@ func migrations37_add_tx_set_operation_count_to_ledgersSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations38_add_constraintsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x58\x5d\x6f\xdb\x2c\x14\xbe\xef\xaf\xb0\x7a\xf5\xbe\x9a\x2a\xed\xbe\x5a\xa5\xac\xa9\xb4\x6a\x55\x3b\x75\xd9\x6e\x11\xb1\x8f\x13\x24\x0c\x1e\xe0\xa6\xdd\xaf\x9f\x92\xd8\x2e\x18\x03\x36\x66\xbb\xe4\xe0\xe7\x79\xcc\xc7\xf9\xe2\xea\x2a\xfb\x50\x91\x9d\xc0\x0a\xb2\x1f\xf5\xc5\xc5\xea\x61\x73\xf7\x9c\x6d\x56\x9f\x1f\xee\xb2\x3d\x91\x8a\x8b\x37\xa4\x04\x66\x12\xe7\x8a\x70\x26\xb3\xd5\x7a\x9d\xdd\x3e\x3d\x7e\xdf\x3c\xaf\xee\x1f\x37\xd9\x0b\xa6\xa4\x40\x14\x8a\x1d\x08\x24\xe1\x57\x03\x2c\x87\xec\xf6\xcb\xdd\xed\xd7\xec\xbf\xa1\xf9\x26\xfb\xf8\x7f\xf6\xf8\xb4\xc9\x7e\xae\x1e\xee\xd7\xd7\x91\x62\xb8\xae\x29\xc9\xf1\xf1\x0b\xc4\x45\x01\xa2\x93\xb3\x27\x6e\x3e\xa5\x51\xcc\x73\xde\x30\x65\xad\xcf\xb2\x27\xd2\xab\xf0\x2b\x2a\xa1\x97\xe9\x86\x69\xb6\x8f\xd7\x20\xce\x7b\x74\xfa\xf9\x4e\x64\x68\x4e\xb4\x94\x12\x00\xe5\x7b\x2c\x76\x50\x74\x4a\xba\x29\xcd\x92\x18\x1c\x86\x7b\xa6\x9b\x86\x22\x86\x0a\x2f\x4b\x10\xae\x73\xaf\xf4\x1d\x6a\x47\xde\x8d\xf1\xb1\xd5\x82\xe4\xc0\x3a\xb6\x76\xb4\x8c\xad\x30\xd8\x8a\x85\x6c\x06\x59\x3c\x17\xc5\x52\xa1\x8a\x17\xa4\x24\xd0\x85\x86\x3e\x22\x8c\xcd\x79\xcf\x47\x89\x46\x2a\x44\x09\x03\x87\xdc\x16\x53\xac\xf9\x64\x37\xf4\xfe\x7e\x90\xf4\xfd\x03\x44\x49\x45\xfa\x3b\x60\xd9\x97\xc9\x6c\x9b\x37\xc2\x76\x88\x12\xbc\x25\x94\x28\x02\xb2\x5f\x86\x3d\xb3\x4c\x4a\x02\xa5\x0e\xad\xb1\xa9\x65\x62\x69\xaf\x80\x16\x08\x0a\x97\xe2\xe9\xce\x22\xd3\xbb\x50\xc0\xbd\xa6\xf3\x9a\x7e\x86\x02\x8e\x36\x85\xd7\x48\x5c\x97\xa7\xd1\xa5\xcd\x3a\x4a\xdb\x47\xea\xbf\x90\x1f\x47\x05\xcf\x67\xe4\xbc\x57\x66\x4e\x9c\x95\xec\xbd\xcc\x5a\xdc\x37\x53\x95\x3d\x31\xe9\x38\xbc\x62\x29\xb3\xa2\x7f\x55\x5c\x61\x8a\x72\x4e\x58\xef\x7f\xba\x69\xb9\xc0\x31\xbd\xd6\x9c\x53\x3d\xdd\x9e\xc6\xcb\xa9\xb7\x58\x82\x9e\x64\xfb\x71\x22\x6a\x01\x12\xc4\x8b\x49\xdf\xd9\x96\x4b\x1c\xcb\x01\xf5\x8a\x24\x28\x24\xc9\x6f\xa3\xba\xd2\xcd\xcb\x85\x64\x93\xe7\x20\x65\xd9\x50\xf7\x1d\xf6\x7e\x93\xe0\x12\x60\x42\xc1\xe3\x42\xce\xf9\x69\x11\x01\xca\x12\x72\x95\x2e\xb4\xc1\x6b\x8d\xb0\x3c\x1d\x81\xc2\x2e\x5e\xd6\x54\x5d\x25\xde\xbb\x8e\x61\xf3\x6b\x74\x9f\x21\x49\x76\xcc\xb9\x71\x07\x20\xbb\x7d\xbf\x4d\xed\x68\x22\x71\x81\x15\xfe\x17\xf9\xb0\x5f\x70\xb2\x7a\x28\xc0\x98\xac\x4a\xf1\xeb\xa4\x2c\x51\xfc\x4a\x8b\xcf\x63\xce\xa2\xce\xf9\xf0\x78\x7d\xb7\xef\x2a\x43\xf3\x82\xc5\x1c\x7d\x40\x36\x5b\x60\x4a\x68\x3b\x36\xb0\x2e\xe0\xaf\xb0\x54\x20\x06\x9e\x61\x1a\x17\xb0\xab\xbd\x00\xb9\xe7\xb4\x40\x94\x1f\xfa\x8c\x68\x18\x93\xb0\x57\x50\x90\xa6\xb2\x05\x5a\x7b\x12\x8d\x3d\xd9\xed\x6d\x85\x93\xd5\x0e\x21\xfa\xcb\xcb\x9a\x1f\xd8\x84\xb7\x97\xf5\xf3\xd3\xb7\xe0\xe3\xcb\x84\x26\x7a\x9c\xc7\x2a\x0f\xe3\x99\x06\xef\x22\xd1\x44\x6d\x07\x1f\x8d\x1f\x94\x6f\xd1\x3c\xda\x93\x45\x34\x87\xf6\x22\x31\xfe\x04\xe1\xd8\xcb\xca\xfe\x73\x2f\xe0\xfc\xb0\x30\x17\x50\xcc\x05\xcc\xf8\x7e\x2c\xa2\x7a\xba\xfc\x71\x92\x36\x8f\xb9\x1b\xd1\x71\xd8\xb0\x61\x9f\x8b\xb7\x33\xdc\x5c\x86\x91\xd4\x35\x97\x62\xc2\x06\x0e\x7a\x4e\xcf\xc1\x21\xe6\xed\x56\x7d\x48\xf7\xe5\x77\x23\xdb\x48\x12\xea\x64\x27\x87\x24\x6f\x2d\xec\x3a\x01\x5f\x18\xf2\x63\xad\x0a\x39\x86\x64\x52\x18\x0a\xfc\xc7\x7b\x8f\x18\x03\xef\x9a\xc0\x18\x6c\xd7\xe5\x45\x63\xdb\x16\x2e\x06\x3f\x68\xce\x62\x28\x7c\x9d\x56\xd4\x56\x3a\x5a\xa7\x40\xab\x34\xdd\x3b\x86\xcd\x90\x23\x9d\x68\x9d\x4f\xa8\xd3\x19\x67\x38\xd7\x6e\xde\x66\x26\x3a\x1c\xf5\x15\xd3\x8c\x60\x1e\xc2\x04\x22\x71\x00\x1e\x0c\xc3\x01\xfc\xf8\xa2\x67\xfd\x80\x51\xf1\xcf\xc2\x9a\xc5\xfc\x2c\xa8\x51\xa8\xcf\x42\x1a\x45\x78\x24\xf2\x5c\x5d\x47\x82\x8f\x85\xf3\xf5\xc5\x9f\x00\x00\x00\xff\xff\x73\x20\xdd\xff\xa2\x1c\x00\x00")
+var _migrations38_add_constraintsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x58\x5d\x6f\xdb\x20\x14\x7d\xcf\xaf\xb0\xfa\xb4\x69\xaa\xb4\xf7\xa8\x95\xb2\xa6\xd2\xaa\x55\xed\xd4\x65\x7b\x45\xc4\xbe\xb6\x91\xb0\x9d\x01\x6e\xda\xfd\xfa\x29\x89\x71\x30\x60\x1c\xc0\xdb\x23\x17\xce\x39\x80\x7d\xbf\xb8\xbe\x4e\x3e\x55\xa4\x60\x58\x40\xf2\x73\xb7\x58\xac\x1e\x37\xf7\x2f\xc9\x66\xf5\xe5\xf1\x3e\x29\x09\x17\x0d\x7b\x47\x82\xe1\x9a\xe3\x54\x90\xa6\xe6\xc9\x6a\xbd\x4e\xee\x9e\x9f\x7e\x6c\x5e\x56\x0f\x4f\x9b\xe4\x15\x53\x92\x21\x0a\x59\x01\x0c\x71\xf8\xdd\x42\x9d\x42\x72\xf7\xf5\xfe\xee\x5b\xf2\x41\x37\xdf\x26\x9f\x3f\x26\x4f\xcf\x9b\xe4\xd7\xea\xf1\x61\xbd\x0c\x14\xc3\xbb\x1d\x25\x29\x3e\xac\x40\x0d\xcb\x80\x49\x39\x73\xe2\xf6\x66\x1e\xc5\x34\x6d\xda\x5a\x18\xe7\x33\xec\x33\xe9\x55\xf8\x0d\xe5\xd0\xcb\xc8\xe1\x3c\xd7\xd7\xec\x80\x9d\xee\xe8\xb8\x79\x29\xa2\x9b\x67\x3a\x4a\x0e\x80\xd2\x12\xb3\x02\x32\xa9\xa4\x9a\xe6\x39\x52\x0d\x7b\xfd\xce\x54\x93\x2e\x32\x50\x69\xf2\x1c\xd8\x08\x2f\xae\xd4\x1b\xc2\xd5\xf4\xc5\xb8\xd8\x76\x8c\xa4\x50\x4b\xb6\x6e\x14\xc7\xd6\xdf\x69\x37\x8a\x63\x1b\x90\x85\x9f\x93\x62\x2e\x50\xd5\x64\x24\x27\x20\x43\x83\xa4\xb6\xce\x39\xbf\x8f\x60\x2d\x17\x88\x92\x1a\x46\xe4\xb6\x98\x62\xc5\x27\xe5\xd0\xb9\xfd\x49\xd2\xf3\x02\x44\x49\x45\xfa\x7f\xc0\xb0\xc7\xc9\x6c\xdb\x77\x52\x17\x88\x12\xbc\x25\x94\x08\x02\xbc\x3f\x86\x39\x13\x27\xc5\x81\xd2\x11\x2d\xdb\x54\x9c\xd8\xbc\xbf\x80\x12\x08\xb2\x31\xc5\xe3\x3f\x8b\x86\xde\x85\x26\xdc\xeb\x72\xde\xa1\x9f\xa1\x2c\x9e\x77\x90\xb8\xae\x8e\xa3\x2b\x93\xd5\x4a\xdb\x47\xea\x7f\x90\x1f\xad\x82\xa7\x6f\x34\xa2\xa6\xe7\xc4\x73\x2e\xd4\xa8\xbd\x99\x95\xb8\x3f\x4c\x55\xe6\xc4\xed\x4d\xac\x98\x9e\xfe\x3a\x29\xdd\x1c\x2f\x24\x1a\x81\x29\x4a\x1b\x52\xf7\xfe\xa7\x9a\xe2\x05\x0e\xe9\x75\xd7\x34\x54\xb2\xf7\xe3\x78\xea\x2d\xe6\xa0\x26\xd9\x7e\x3c\x13\x35\x03\x0e\xec\x75\x48\x2f\x6d\xf1\x12\x87\x72\x40\xbc\x21\x0e\x02\x71\xf2\xa7\x57\xd1\xcd\xf1\x42\xbc\x4d\x53\xe0\x3c\x6f\xa9\x5a\xbb\x0c\x7f\x2c\xe7\x9a\xf8\x2d\xe4\x98\x50\x70\xb8\xd0\xe8\xbc\x21\x6d\xd5\x86\x3c\x87\x54\xcc\x17\xda\xe0\x6d\x87\x30\x3f\x7e\x19\x81\xc7\x78\xeb\xb6\x92\x95\x78\xef\x3a\x03\x9b\x5b\x43\x2e\x43\x9c\x14\xf5\xe8\xc5\xed\x81\x14\x65\x7f\x4d\xdd\xe8\x42\xe2\x0c\x0b\xfc\x3f\xf2\xa1\x14\x9c\xaf\x1e\x9a\x60\x9c\xad\x4a\x71\xeb\xcc\x59\xa2\xb8\x95\xa2\xbf\x87\xcf\xa1\x4e\xf9\x10\xd5\x6d\xb5\x05\xa6\xa7\x49\x69\x8e\x38\xcc\xc1\x07\x78\xbb\x85\x5a\x30\xe5\xc6\x34\x6b\x04\x7f\x85\xb9\x00\xa6\x79\xc6\xd0\x18\xc1\x2e\x4a\x06\xbc\x6c\x68\x86\x68\xb3\x97\xec\x43\xe3\x2c\xec\x15\x64\xa4\xad\x4c\x81\xce\x3e\x8b\x46\x49\x8a\xd2\x54\x38\x5a\x0d\xfe\x85\xfa\xf2\xb2\x6e\xf6\xb5\x3d\xd2\x2a\x11\x9a\x27\xeb\x97\xe7\xef\x93\x8f\x2f\xcb\x50\x1e\xa3\x3c\x0c\x67\xd2\xde\x45\x82\x89\xba\x0e\x3e\x18\xaf\x95\x6f\xc1\x3c\xca\x93\x45\x30\x87\xf2\x22\xb1\x5c\xd8\x9a\x69\x3b\x0c\x57\xe6\xce\x9d\x80\x63\xc7\x52\xfb\x02\x32\x5f\x80\xc7\x7a\x5b\x44\x5d\x2e\x46\x3b\x4a\x3b\x49\x97\xc7\x96\x9e\xb0\xf3\x8a\x53\xc3\xee\x8b\x37\x33\x9c\x2f\x83\x25\x75\xf9\x52\x5c\x70\x81\xca\x8f\x98\x8d\xf2\x74\x5d\xf1\x32\x14\x99\x05\x20\xbb\x48\x62\x05\xf6\xee\xe9\x11\x92\x9c\xb5\xb0\x9d\xc5\x1d\x86\xdc\x58\xc5\xb1\x5d\x31\xc4\x4d\x72\x51\x18\x9a\xd8\xc7\xb9\x47\x0c\x81\xcb\x26\x30\x04\x2b\xbb\xbc\x60\x6c\xd7\xc2\x85\xe0\xb5\xe6\x2c\x84\xc2\xd5\x69\x85\xf0\x8d\xb5\x4e\x13\xad\xd2\xe5\xde\xa1\x37\x43\x76\xa4\xda\xf9\x68\x04\x46\xa7\x63\x67\x38\xd5\x6e\x63\xd8\x63\x33\x13\x1c\x8e\x24\x8d\x4f\x30\x9f\xc2\x4c\x44\xe2\x09\xf8\x64\x18\x9e\xc0\xdb\x0f\xed\xb5\x81\x41\xc5\xef\x85\x1d\x16\xf3\x5e\xd0\x41\xa1\xee\x85\x3c\x57\xb0\xb4\xd9\x07\x22\x4f\xd5\x75\x20\xb8\x24\x45\xb9\x5c\xfc\x1d\x00\x73\x20\xdd\xff\xa2\x1c\x00\x00")
 
 func migrations38_add_constraintsSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -757,7 +758,7 @@ func migrations38_add_constraintsSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations39_claimable_balancesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x92\x41\x4f\xbb\x40\x10\xc5\xef\x7c\x8a\x77\x84\xfc\x4b\xf2\x3f\x18\x2f\x4d\x4c\x5a\xd8\x28\x69\xa5\x0d\xd2\xa4\x3d\x6d\x16\x76\xc5\x31\xb0\x4b\xba\x6b\xd4\x6f\x6f\x0a\xad\xd5\x4a\xb5\x27\x12\xde\x9b\xdf\xce\xbc\x99\x30\xc4\xbf\x86\xaa\xad\x70\x0a\xab\xd6\xf3\xa2\x8c\x4d\x72\x86\x7c\x32\x9d\x33\x94\xb5\xa0\x46\x14\xb5\xe2\x85\xa8\x85\x2e\x95\x85\xef\x01\x00\x49\xe4\x6c\x9d\x23\x5d\xe4\x48\x57\xf3\xf9\x08\x61\x88\xe8\xe0\x9e\xf6\xe6\x24\x06\x69\x14\xc2\xaa\xeb\xab\xae\xaa\xc7\x69\x67\xf1\x6c\x8d\x2e\x8e\xd5\x9d\x2a\xac\x55\x0e\x4e\xbd\xb9\x53\xa1\x31\x2f\xda\xa1\xa0\x8a\xf4\x51\x43\x74\xc7\xa2\x19\xfc\xbd\x7a\x83\xff\x41\x6f\xb7\xad\xd1\xd6\x6c\xbb\x06\xfb\x3f\xb5\xb0\x8e\x37\x46\xd2\x23\x29\xc9\x6b\x25\x2b\xb5\x05\x69\xa7\x76\xdf\xef\x6f\x2d\xb3\xe4\x7e\x92\x6d\x30\x63\x1b\xf8\x24\x03\x2f\x18\x7f\x66\x92\xa4\x31\x5b\x0f\x64\xc2\x8b\x77\xde\x37\xbf\x48\x87\x22\x5b\x3d\x24\xe9\x2d\xa6\x79\xc6\x98\xdf\xf9\x82\xf1\x25\xc8\xc3\x1c\x7f\x43\xf7\xce\x33\xd8\x1f\xe0\xe3\x1a\x7e\x43\x57\xa4\xfd\x93\x85\xf1\x56\xb8\x27\x6e\x5a\x7b\xd9\x00\x43\xb1\x73\xa1\x25\x27\x79\xc1\x50\x43\xd5\x23\x90\xdc\x2d\xe4\xeb\xd1\xc6\xe6\x55\x7b\x5e\x9c\x2d\x96\xe7\x8f\xb6\x14\xb6\x14\x52\x8d\xbd\x8f\x00\x00\x00\xff\xff\x76\x45\x17\x78\xee\x02\x00\x00")
+var _migrations39_claimable_balancesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x92\x41\x4f\xbb\x40\x10\xc5\xef\x7c\x8a\x77\x84\xfc\x4b\xf2\x3f\x18\x2f\x4d\x4c\x5a\xd8\x28\x69\xa5\x0d\xd2\xa4\x3d\x6d\x16\x76\xc5\x31\xb0\x4b\xba\x6b\xd4\x6f\x6f\x0a\xad\xd5\x4a\xb5\x27\x12\xde\x9b\xdf\xce\xbc\x99\x30\xc4\xbf\x86\xaa\xad\x70\x0a\xab\xd6\xf3\xa2\x8c\x4d\x72\x86\x7c\x32\x9d\x33\x94\xb5\xa0\x46\x14\xb5\xe2\x85\xa8\x85\x2e\x95\x85\xef\x01\x00\x49\xe4\x6c\x9d\x23\x5d\xe4\x48\x57\xf3\xf9\x08\x61\x88\xe8\xe0\x9e\xf6\xe6\x24\x06\x69\x14\xc2\xaa\xeb\xab\xae\xaa\xc7\x69\x67\xf1\x6c\x8d\x2e\x8e\xd5\x9d\x2a\xac\x55\x0e\x4e\xbd\xb9\x53\xa1\x31\x2f\xda\xa1\xa0\x8a\xf4\x51\x43\x74\xc7\xa2\x19\xfc\xbd\x7a\x83\xff\x41\x6f\xb7\xad\xd1\xd6\x6c\xbb\x06\xfb\x3f\xb5\xb0\x8e\x37\x46\xd2\x23\x29\xc9\x6b\x25\x2b\xb5\x05\x69\xa7\x76\xdf\xef\x6f\x2d\xb3\xe4\x7e\x92\x6d\x30\x63\x1b\xf8\x24\x03\x2f\x18\x7f\x66\x92\xa4\x31\x5b\x0f\x64\xc2\x8b\x77\xde\x37\xbf\x48\x87\x22\x5b\x3d\x24\xe9\x2d\xa6\x79\xc6\x98\xdf\xf9\x82\xf1\x25\xc8\xc3\x1c\x7f\x43\xf7\xce\x33\xd8\x1f\xe0\xe3\x1a\x7e\x43\x57\xa4\xfd\x93\x85\xf1\x56\xb8\x27\x6e\x5a\x7b\xd9\x00\x43\xb1\x73\xa1\x25\x27\x79\xc1\x50\x43\xd5\x23\x90\xdc\x2d\xe4\xeb\xd1\xc6\xe6\x55\x7b\x5e\x9c\x2d\x96\xe7\x8f\xb6\x14\xb6\x14\x52\x8d\xbd\x8f\x01\x00\x76\x45\x17\x78\xee\x02\x00\x00")
 
 func migrations39_claimable_balancesSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -777,7 +778,7 @@ func migrations39_claimable_balancesSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations39_history_trades_indicesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\xce\xb1\x0a\xc2\x40\x0c\x80\xe1\x3d\x4f\x11\x3a\x29\xda\x27\xe8\xa4\xf6\x90\x2e\xad\xd4\x16\xdc\x8e\xd3\x0b\x36\x83\xcd\x91\x8b\x88\x6f\x2f\x08\x42\x07\xd7\x1f\x7e\xf8\xca\x12\x37\x0f\xbe\x6b\x30\xc2\x31\x01\x1c\x7a\xb7\x1b\x1c\x36\x6d\xed\x2e\x38\x99\x46\x9f\x02\xab\x4f\x1c\xb1\x6b\x71\xe2\x6c\xa2\x6f\x6f\x1a\x22\x65\x1c\xcf\x4d\x7b\xc4\xfd\xd0\x3b\xb7\xba\x86\x4c\x3e\xe4\x4c\xe6\x39\x6e\xf1\x26\xcf\xd9\x48\x17\xe5\xf7\x4a\x22\x0d\xc6\x32\x7f\x6b\x21\x1a\x49\x8b\x75\x05\xb0\xa4\xd4\xf2\x9a\x01\xea\xbe\x3b\xfd\xa3\x54\xf0\x09\x00\x00\xff\xff\xb2\xf0\x3e\xfe\xb7\x00\x00\x00")
+var _migrations39_history_trades_indicesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\xce\xb1\x0a\xc2\x40\x0c\x80\xe1\x3d\x4f\x11\x3a\x29\xda\x27\xe8\xa4\xf6\x90\x2e\xad\xd4\x16\xdc\x8e\xd3\x0b\x36\x83\xcd\x91\x8b\x88\x6f\x2f\x08\x42\x07\xd7\x1f\x7e\xf8\xca\x12\x37\x0f\xbe\x6b\x30\xc2\x31\x01\x1c\x7a\xb7\x1b\x1c\x36\x6d\xed\x2e\x38\x99\x46\x9f\x02\xab\x4f\x1c\xb1\x6b\x71\xe2\x6c\xa2\x6f\x6f\x1a\x22\x65\x1c\xcf\x4d\x7b\xc4\xfd\xd0\x3b\xb7\xba\x86\x4c\x3e\xe4\x4c\xe6\x39\x6e\xf1\x26\xcf\xd9\x48\x17\xe5\xf7\x4a\x22\x0d\xc6\x32\x7f\x6b\x21\x1a\x49\x8b\x75\x05\xb0\xa4\xd4\xf2\x9a\x01\xea\xbe\x3b\xfd\xa3\x54\xf0\x19\x00\xb2\xf0\x3e\xfe\xb7\x00\x00\x00")
 
 func migrations39_history_trades_indicesSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -797,7 +798,7 @@ func migrations39_history_trades_indicesSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations3_use_sequence_in_history_accountsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x91\x4d\x6b\xb3\x40\x14\x85\xf7\xf3\x2b\xce\x2e\xca\xfb\x66\x91\x6d\x5c\x4d\xc6\x1b\x22\x8c\x63\x3b\x5e\xdb\x64\x25\xa2\x43\x3a\x90\x6a\xeb\xd8\xaf\x7f\x5f\x48\xd3\x0f\x08\x6d\xa1\xcb\x73\x78\xe0\x39\xdc\x3b\x9f\xe3\xdf\xad\xdf\x8f\xcd\xe4\x50\xdd\x09\x65\x49\x32\xa1\xa4\xcb\x8a\x8c\x22\xdc\xf8\x30\x0d\xe3\x4b\xdd\xb4\xed\xf0\xd0\x4f\xa1\xf6\x5d\x1d\xdc\xbd\x00\x80\x92\xa5\x65\x5c\x67\xbc\xc1\xe2\x58\x64\x46\x59\xca\xc9\x30\x56\xbb\x53\x65\x0a\xe4\x99\xb9\x92\xba\xa2\x8f\x2c\xb7\x9f\x59\x49\xb5\x21\x2c\x12\x51\x92\x26\xc5\x08\x6e\x7a\x6c\x0e\xd1\xec\x1b\xef\xec\x3f\xa2\x13\x99\xcb\x6d\xe4\xbb\x18\x6b\x5b\xe4\x67\x33\xe3\x38\x11\x52\x33\x59\xb0\x5c\x69\x42\x61\xf4\xee\x0c\xc2\x1b\xa1\x0a\x5d\xe5\x06\xbe\x43\x49\x8c\x94\xd6\xb2\xd2\x8c\xde\x3d\xff\xbc\x64\xb9\x1c\xdd\xbe\x3d\x34\x21\xc4\x89\x10\x5f\xcf\x98\x0e\x4f\xfd\x1f\xec\xa9\x2d\x2e\xde\xf5\x89\x38\xa6\xdf\xde\x90\x88\xd7\x00\x00\x00\xff\xff\x55\xe2\xdd\x2c\xbf\x01\x00\x00")
+var _migrations3_use_sequence_in_history_accountsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x91\x4d\x6b\xb3\x40\x14\x85\xf7\xf3\x2b\xce\x2e\xca\xfb\x66\x91\x6d\x5c\x4d\xc6\x1b\x22\x8c\x63\x3b\x5e\xdb\x64\x25\xa2\x43\x3a\x90\x6a\xeb\xd8\xaf\x7f\x5f\x48\xd3\x0f\x08\x6d\xa1\xcb\x73\x78\xe0\x39\xdc\x3b\x9f\xe3\xdf\xad\xdf\x8f\xcd\xe4\x50\xdd\x09\x65\x49\x32\xa1\xa4\xcb\x8a\x8c\x22\xdc\xf8\x30\x0d\xe3\x4b\xdd\xb4\xed\xf0\xd0\x4f\xa1\xf6\x5d\x1d\xdc\xbd\x00\x80\x92\xa5\x65\x5c\x67\xbc\xc1\xe2\x58\x64\x46\x59\xca\xc9\x30\x56\xbb\x53\x65\x0a\xe4\x99\xb9\x92\xba\xa2\x8f\x2c\xb7\x9f\x59\x49\xb5\x21\x2c\x12\x51\x92\x26\xc5\x08\x6e\x7a\x6c\x0e\xd1\xec\x1b\xef\xec\x3f\xa2\x13\x99\xcb\x6d\xe4\xbb\x18\x6b\x5b\xe4\x67\x33\xe3\x38\x11\x52\x33\x59\xb0\x5c\x69\x42\x61\xf4\xee\x0c\xc2\x1b\xa1\x0a\x5d\xe5\x06\xbe\x43\x49\x8c\x94\xd6\xb2\xd2\x8c\xde\x3d\xff\xbc\x64\xb9\x1c\xdd\xbe\x3d\x34\x21\xc4\x89\x10\x5f\xcf\x98\x0e\x4f\xfd\x1f\xec\xa9\x2d\x2e\xde\xf5\x89\x38\xa6\xdf\xde\x90\x88\xd7\x01\x00\x55\xe2\xdd\x2c\xbf\x01\x00\x00")
 
 func migrations3_use_sequence_in_history_accountsSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -817,7 +818,7 @@ func migrations3_use_sequence_in_history_accountsSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations40_fix_inner_tx_max_fee_constraintSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x8f\xc1\x8b\x82\x40\x1c\x85\xef\xf3\x57\xbc\xe3\x2e\xcb\x80\x77\x71\x61\xd6\x11\x56\x12\x0d\x9b\xba\xca\x64\x63\x0e\xe4\x4f\x19\x27\xb3\xff\xbe\x4b\x41\x1d\xa2\x43\x1d\x1f\x3c\xf8\xbe\x8f\x73\xfc\x74\x76\xef\xb4\x37\x58\x0f\x8c\x89\x4c\x25\x25\x94\xf8\xcb\x12\xb4\x76\xf4\xbd\x3b\x57\xde\x69\x1a\x75\xed\x6d\x4f\x23\x64\x59\x2c\x11\x17\xf9\x4a\x95\x22\xcd\x15\x26\x7d\xb0\xbb\xaa\xd3\x73\xd5\x18\x13\x32\xce\x91\x12\x19\x07\x3f\xc3\x12\x1a\x63\xb0\x3d\x76\x03\x6a\x4d\x68\xf5\x64\x70\x7d\x46\xc1\x6b\x94\x90\xf2\x29\x09\xf1\x7f\x12\x2f\xf0\x75\x9b\xbf\x11\x82\x6f\xe4\x85\xc2\x46\x64\xa9\x0c\x19\xbb\x2f\x93\xfd\x89\xde\x6f\xfb\xac\xf0\xa3\xef\x25\x00\x00\xff\xff\x59\x2c\x3c\x0b\x88\x01\x00\x00")
+var _migrations40_fix_inner_tx_max_fee_constraintSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x8f\xc1\x8b\x82\x40\x1c\x85\xef\xf3\x57\xbc\xe3\x2e\xcb\x80\x77\x71\x61\xd6\x11\x56\x12\x0d\x9b\xba\xca\x64\x63\x0e\xe4\x4f\x19\x27\xb3\xff\xbe\x4b\x41\x1d\xa2\x43\x1d\x1f\x3c\xf8\xbe\x8f\x73\xfc\x74\x76\xef\xb4\x37\x58\x0f\x8c\x89\x4c\x25\x25\x94\xf8\xcb\x12\xb4\x76\xf4\xbd\x3b\x57\xde\x69\x1a\x75\xed\x6d\x4f\x23\x64\x59\x2c\x11\x17\xf9\x4a\x95\x22\xcd\x15\x26\x7d\xb0\xbb\xaa\xd3\x73\xd5\x18\x13\x32\xce\x91\x12\x19\x07\x3f\xc3\x12\x1a\x63\xb0\x3d\x76\x03\x6a\x4d\x68\xf5\x64\x70\x7d\x46\xc1\x6b\x94\x90\xf2\x29\x09\xf1\x7f\x12\x2f\xf0\x75\x9b\xbf\x11\x82\x6f\xe4\x85\xc2\x46\x64\xa9\x0c\x19\xbb\x2f\x93\xfd\x89\xde\x6f\xfb\xac\xf0\xa3\xef\x65\x00\x59\x2c\x3c\x0b\x88\x01\x00\x00")
 
 func migrations40_fix_inner_tx_max_fee_constraintSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -837,7 +838,7 @@ func migrations40_fix_inner_tx_max_fee_constraintSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations41_add_sponsor_to_state_tablesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x92\xd1\x4a\x87\x30\x14\xc6\xef\x7d\x8a\x73\x59\xc4\xff\x09\xbc\x9a\xed\x10\x82\x68\xac\x09\xde\x8d\x65\x2a\x42\x6d\xb2\x4d\xa2\xb7\x8f\x70\xab\x25\xad\xd6\xf5\x7e\xe7\xfc\xf6\x1d\xbe\xcb\x05\x6e\x5e\xd6\xc5\x48\x37\x41\xbf\x15\x05\x69\x38\x32\xe0\xa4\x6a\x10\xe4\x38\xea\x5d\x39\x0b\x84\x52\xb0\x9b\x56\x56\x1b\xe0\x38\xf0\xb2\xb8\x65\x48\x38\x42\xdd\x52\x1c\x3e\x39\xf1\xf8\x26\x02\xd6\xb5\x5f\xe3\xfd\x43\xdd\xde\x41\xc5\x19\xe2\x95\x7f\xbf\x2e\x7f\x56\x89\x27\xe9\x64\xb6\xef\x03\x4e\x48\x8f\x45\xff\x31\xdb\x75\x51\x93\xc9\x0f\xeb\xf9\x94\x3f\xac\xcb\xf8\x82\x33\xbb\x75\xe2\x79\x55\xd3\x9f\xf6\x08\x3d\x89\xe3\x25\x19\x4e\x3d\xcf\x19\x61\x0f\xea\x64\xf2\xa3\x09\x49\x5c\x28\xaa\x5f\x55\xa2\x52\x94\x75\xf7\xc1\x5c\xfe\x52\x85\x0c\x2e\x5c\x3a\x8d\xc6\xb7\x49\x53\x3e\xd7\x77\xe0\x3d\x00\x00\xff\xff\xb5\xbf\x3f\xcc\x20\x03\x00\x00")
+var _migrations41_add_sponsor_to_state_tablesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x92\xc1\x6a\xc4\x20\x10\x86\xef\x79\x8a\x39\xb6\x94\x7d\x82\x9c\xdc\x3a\x94\x85\x25\x29\xd6\x40\x6e\x62\xd3\x24\x04\x5a\x0d\x6a\x28\x7d\xfb\x52\xa2\xad\x09\x91\xba\x67\xbf\x99\xcf\xff\xd7\xd3\x09\x1e\x3e\xa6\xd1\x48\xd7\x43\x33\x17\x05\xb9\x72\x64\xc0\xc9\xf9\x8a\x20\xbb\x4e\x2f\xca\x59\x20\x94\x82\x9d\xb5\xb2\xda\x00\xc7\x96\x97\xc5\x23\x43\xc2\x11\x2e\x15\xc5\xf6\x97\x13\xaf\x5f\x22\x60\x75\xf5\x37\xde\xbc\x5c\xaa\x27\x38\x73\x86\x78\xe7\xcf\xef\xcb\x63\x95\x78\x93\x4e\x66\xfb\x7e\xe0\x84\x74\x5d\x74\x8b\xd9\x4e\xa3\xea\x4d\x7e\x58\xcf\xa7\xfc\x61\x5d\xc6\x15\x9c\x59\xac\x13\xef\x93\xea\xff\xb5\x47\xe8\x4e\x1c\x2f\xc9\x70\xea\x61\xc8\x08\xbb\x52\x3b\x93\x1f\x4d\x48\xe2\x0f\x45\xf5\xa7\xda\x6a\x43\x3d\x40\x59\xfd\x1c\xcc\xe5\x21\xb2\xbe\x60\x06\x17\x9a\x4e\xa3\x71\x37\x69\xca\xe7\xda\x02\xdf\x03\x00\xb5\xbf\x3f\xcc\x20\x03\x00\x00")
 
 func migrations41_add_sponsor_to_state_tablesSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -857,7 +858,7 @@ func migrations41_add_sponsor_to_state_tablesSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations42_add_num_sponsored_and_num_sponsoring_to_accountsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\xce\xb1\x0a\xc2\x30\x14\x85\xe1\xfd\x3e\xc5\x19\x15\x5b\xe8\x5e\x14\x62\x13\x11\x8c\x56\x4a\x3b\x4b\xa9\x21\x64\xe8\x4d\x69\x52\x7c\x7d\xd1\x49\xc1\x80\xf3\x39\xfc\x7c\x79\x8e\xcd\xe8\xec\xdc\x47\x83\x6e\x22\x12\xba\x55\x0d\x5a\xb1\xd7\x0a\xfd\x30\xf8\x85\x63\x20\x21\x25\xaa\x5a\x77\xe7\x0b\x78\x19\x6f\x61\xf2\x1c\xfc\x6c\xee\x70\x1c\x8d\x35\x33\xa4\x3a\x88\x4e\xb7\x28\x50\x1d\x55\x75\xc2\xea\xfb\xb6\xdb\xa2\x58\x67\x89\x8c\x63\xfb\x57\xe7\xf5\x7b\x87\x4a\xa2\x4f\xb5\xf4\x0f\x4e\xb8\x65\x53\x5f\x7f\xc2\xb3\xd4\xe4\xd8\x96\xf4\x0c\x00\x00\xff\xff\xb6\x54\xe4\xeb\x14\x01\x00\x00")
+var _migrations42_add_num_sponsored_and_num_sponsoring_to_accountsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\xce\xb1\x0a\xc2\x30\x14\x85\xe1\xfd\x3e\xc5\x19\x15\x5b\xe8\x5e\x14\x62\x13\x11\x8c\x56\x4a\x3b\x4b\xa9\x21\x64\xe8\x4d\x69\x52\x7c\x7d\xd1\x49\xc1\x80\xf3\x39\xfc\x7c\x79\x8e\xcd\xe8\xec\xdc\x47\x83\x6e\x22\x12\xba\x55\x0d\x5a\xb1\xd7\x0a\xfd\x30\xf8\x85\x63\x20\x21\x25\xaa\x5a\x77\xe7\x0b\x78\x19\x6f\x61\xf2\x1c\xfc\x6c\xee\x70\x1c\x8d\x35\x33\xa4\x3a\x88\x4e\xb7\x28\x50\x1d\x55\x75\xc2\xea\xfb\xb6\xdb\xa2\x58\x67\x89\x8c\x63\xfb\x57\xe7\xf5\x7b\x87\x4a\xa2\x4f\xb5\xf4\x0f\x4e\xb8\x65\x53\x5f\x7f\xc2\xb3\xd4\xe4\xd8\x96\xf4\x1c\x00\xb6\x54\xe4\xeb\x14\x01\x00\x00")
 
 func migrations42_add_num_sponsored_and_num_sponsoring_to_accountsSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -877,7 +878,7 @@ func migrations42_add_num_sponsored_and_num_sponsoring_to_accountsSql() (*asset,
 	return a, nil
 }
 
-var _migrations43_add_claimable_balances_flagsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd2\xd5\x55\xd0\xce\xcd\x4c\x2f\x4a\x2c\x49\x55\x08\x2d\xe0\xe2\xe2\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x48\xce\x49\xcc\xcc\x4d\x4c\xca\x49\x8d\x4f\x4a\xcc\x49\xcc\x4b\x4e\x2d\x56\x70\x74\x71\x51\x48\xcb\x49\x4c\x2f\x56\xc8\xcc\x2b\x51\xf0\xf3\x0f\x51\xf0\x0b\xf5\xf1\x51\x70\x71\x75\x73\x0c\xf5\x09\x51\x30\xb0\xe6\xe2\x42\x36\xd2\x25\xbf\x3c\x8f\xa0\x99\x2e\x41\xfe\x01\x10\x43\xad\xb9\xb8\x00\x01\x00\x00\xff\xff\x88\xef\xa8\x21\x91\x00\x00\x00")
+var _migrations43_add_claimable_balances_flagsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\xcc\xb1\x0a\xc2\x30\x10\x06\xe0\xfd\x9e\xe2\xdf\xa5\xe0\xde\x29\x72\x71\x3a\x5a\x29\xc9\x2c\xd7\x92\x86\xc0\x35\x8a\x09\xf8\xfa\xae\x6e\x7d\x81\x6f\x18\x70\x39\x4a\xfe\x68\x4f\x88\x6f\x22\x72\x12\xfc\x82\xe0\x6e\xe2\xb1\x99\x96\x43\x57\x4b\xcf\x55\x4d\xeb\x96\x1a\x1c\x33\x76\xd3\xdc\x50\x6a\xc7\x34\x07\x4c\x51\x04\xec\xef\x2e\x4a\xc0\x75\x24\xfa\x27\xf9\xf5\xad\xa7\x26\x2f\xf3\x03\xbb\x69\x6e\x23\xd1\x6f\x00\x88\xef\xa8\x21\x91\x00\x00\x00")
 
 func migrations43_add_claimable_balances_flagsSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -897,7 +898,7 @@ func migrations43_add_claimable_balances_flagsSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations44_asset_stat_accounts_and_balancesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x90\x4f\x4f\xc2\x30\x18\xc6\xef\xef\xa7\x78\x6e\x48\x2c\x9f\x60\xf1\x30\xec\x2e\xa6\x6e\x84\x75\xe7\xe6\x5d\x69\x74\x84\xb5\x84\xb6\xd1\xf8\xe9\x8d\x44\x70\x60\xa2\x5c\xfb\xfc\xf9\xf5\x79\x17\x0b\xdc\x8f\xc3\xcb\x81\x93\x43\xb7\x27\x2a\x95\xae\xd6\xd0\xe5\x52\x55\x70\xef\x7b\xc3\x31\xba\x64\x62\xe2\x14\xa9\x94\x12\x8f\x8d\xea\x9e\x6b\xb0\xb5\x21\xfb\x14\xf1\xd4\x36\xf5\x52\x4c\xa5\x9e\x77\xec\xad\xfb\x96\x0a\xea\x56\xb2\xd4\xbf\xcb\x80\xb6\xd2\x04\xe0\xa7\xeb\x01\xdb\x18\x7c\x6f\xfa\x3c\xec\x36\x26\xf4\x5b\x67\xd3\xdd\x8c\x73\x7a\x0d\x87\xe1\xc3\x6d\x66\x02\x3e\x8f\xe6\xe4\x9f\x8b\x63\xfc\xcc\xbb\x21\xce\xe3\x57\x72\x5e\xfc\xb3\xf3\xa8\x5d\x2f\x6d\x2b\x8d\xba\xd1\xa8\x3b\xa5\xc4\xa5\xe7\xfc\x85\xa9\xa7\x20\x9a\x1e\x57\x86\x37\xff\x37\x56\xae\x9b\xd5\x35\x55\x5c\xbc\x9e\x38\x05\x7d\x06\x00\x00\xff\xff\x9e\x44\x07\x8e\xb7\x01\x00\x00")
+var _migrations44_asset_stat_accounts_and_balancesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x90\x41\x4e\xc3\x30\x14\x44\xf7\xff\x14\xb3\x2b\x15\xee\x09\x22\x16\x29\xce\x06\x99\xa4\x6a\x9c\xb5\xf5\xed\x5a\x90\x2a\xb1\xab\xda\x11\x88\xd3\x23\x2a\x28\x69\x91\x80\xad\xff\xcc\x3c\xcf\xac\x56\xb8\x1d\xfb\xa7\x23\x67\x8f\xee\x40\x54\x2a\x5d\x6d\xa1\xcb\xb5\xaa\xe0\x5f\x0f\x86\x53\xf2\xd9\xa4\xcc\x39\x51\x29\x25\xee\x1b\xd5\x3d\xd6\x60\xe7\xe2\x14\x72\xc2\x43\xdb\xd4\x6b\x31\x3f\x59\x1e\x38\x38\xff\x79\x2a\xa8\xdb\xc8\x52\xff\x0c\x03\xda\x4a\x13\x80\xef\xac\x3b\xec\x53\x0c\xd6\xd8\xa9\x1f\x76\x26\xda\xbd\x77\xf9\x66\xc1\x53\x7e\x8e\xc7\xfe\xcd\xef\x16\x02\x61\x1a\xcd\x97\x7e\x29\x4e\xf6\x33\xef\x1f\x76\x1e\x3f\x48\xcb\xe2\x8f\x9e\xa7\x0d\xae\x9b\xb6\x95\x46\xdd\x68\xd4\x9d\x52\xe2\x52\x73\xfe\xc2\x5c\x53\x10\xcd\xc7\x95\xf1\x25\xfc\x8e\x95\xdb\x66\x73\x4d\x15\x17\xaf\x96\x07\x0e\xce\xa7\x82\xde\x07\x00\x9e\x44\x07\x8e\xb7\x01\x00\x00")
 
 func migrations44_asset_stat_accounts_and_balancesSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -917,7 +918,7 @@ func migrations44_asset_stat_accounts_and_balancesSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations45_add_claimable_balances_historySql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x95\x41\xb3\x9a\x30\x14\x85\xf7\xfc\x8a\x3b\x6e\x94\xa9\x2e\xdc\xca\x0a\x21\xad\xcc\x60\xa8\x98\xb4\x76\xc5\x04\xc8\xd8\xcc\x20\x58\xc8\xb4\xf6\xdf\x77\xea\x53\x11\x8d\x01\x7c\xce\x5b\x92\xb9\xb9\xe7\x9c\xfb\x85\x64\x32\x81\x4f\x3b\xb1\x2d\x99\xe4\x40\xf7\x86\xe1\x84\xc8\x26\x08\xd6\x68\x45\x11\x76\x10\xfc\x14\x95\x2c\xca\xbf\x51\x92\x31\xb1\x63\x71\xc6\xa3\x98\x65\x2c\x4f\x78\x15\x89\x34\xaa\xf8\x2f\x03\x00\x60\x4d\xec\x90\xc0\x77\x8f\x2c\x60\x7a\x5c\xf0\xb0\x13\xa2\x25\xc2\x04\xe6\x3f\x4e\x4b\x38\x80\xa5\x87\xbf\xd9\x3e\x45\x97\x6f\x7b\x53\x7f\x3b\xb6\xb3\x40\x30\xb5\x2e\x1e\x88\x3d\xf7\x75\x06\x60\x74\xdc\x27\x52\x88\xc5\x56\xe4\x12\x70\x40\x00\x53\xdf\x07\x17\x7d\xb6\xa9\x4f\x20\xe7\x07\xf9\x9b\x65\xa3\x61\x6b\x8a\xe1\x6c\x56\xf2\x6d\x92\xb1\xaa\x32\xc7\xc7\xb6\x77\xa5\x91\x48\x41\xf2\x43\x2d\x63\x98\xb5\x57\x8a\xbd\x15\x45\xe0\x61\x17\x6d\x60\x20\xf2\x94\x1f\x22\x8d\x68\x91\x47\x22\x1d\x40\x80\x75\xf1\xe8\xda\xc3\x5f\x20\x96\x25\xe7\x30\x12\xa9\x69\x3d\x2f\xa6\x0a\xd3\x4b\x5e\xd5\xc0\x7c\x84\xaa\xd8\xf3\x92\x49\xa1\xd2\x3d\x43\xbb\x2f\xbd\xc7\x38\x6e\x54\x2a\x79\xdc\xec\xe8\x4a\x44\x67\xf0\x8d\x4d\xd5\x98\x8e\x36\x50\x63\x4e\xca\x5c\x63\x6d\x88\x9a\xeb\x53\x5e\xaf\x95\x5e\x68\xfa\x21\x5c\x59\xb2\xbc\x62\x49\x37\xbc\xd7\xc5\x1f\x09\x58\x6f\x52\x85\xb8\x25\x96\x72\x5e\x37\xe9\xde\x85\xb9\xdd\x71\x53\xed\xc5\xe6\xff\xcf\xf5\xfa\x29\x70\x8b\x3f\xb9\x61\xb8\x61\xf0\xb5\xe7\x9d\x66\xf5\xde\xa4\xbc\x9b\xac\x93\x78\xdb\x1b\x70\xae\xeb\xfe\x5e\x59\xba\x58\x5d\x2e\x06\x5d\xc2\x5e\x3f\xab\x3a\xa3\xae\x85\xd6\x7b\xb7\x33\xaf\x73\xdf\xfb\x0c\xaa\x13\xe8\xdb\x58\xc6\xbf\x00\x00\x00\xff\xff\xc3\x12\xac\xa0\x73\x08\x00\x00")
+var _migrations45_add_claimable_balances_historySql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x95\xcf\x6e\x9c\x30\x10\xc6\xef\x7e\x8a\xd1\x5e\xb2\xa8\x9b\x43\xae\xf1\x89\x80\xdb\x20\x11\xd3\xb0\x76\x9b\x9e\x90\xc1\xd6\xd6\x12\x31\x29\x58\xed\xf6\xed\x2b\x56\x69\x80\x02\x2e\xfb\x47\x39\x32\xcc\xcc\xf7\x9b\xf9\x2c\xfb\xfa\x1a\x3e\x3c\xeb\x5d\x2d\xac\x02\xfe\x82\x50\x90\x12\x9f\x11\xd8\x92\x47\x4e\x68\x40\xe0\xbb\x6e\x6c\x55\xff\xce\x8a\x52\xe8\x67\x91\x97\x2a\xcb\x45\x29\x4c\xa1\x9a\x4c\xcb\xac\x51\x3f\x10\x00\xc0\x96\xf9\x29\x83\xaf\x11\xbb\x87\x9b\x43\x20\xa2\x41\x4a\x1e\x08\x65\x70\xf7\xed\x35\x44\x13\x78\x88\xe8\x17\x3f\xe6\xe4\xed\xdb\x7f\xea\xbe\x03\x3f\xb8\x27\x70\x83\xdf\x18\x98\x7f\x17\xbb\x00\x60\x7d\xe8\xa3\x25\xe4\x7a\xa7\x8d\x05\x9a\x30\xa0\x3c\x8e\x21\x24\x1f\x7d\x1e\x33\x30\x6a\x6f\x7f\x8a\x72\x7d\xf5\xdf\x29\xae\x6e\x6f\x6b\xb5\x2b\x4a\xd1\x34\xde\xe6\xd0\x76\x94\x9a\x69\x09\x56\xed\x3b\x19\xe4\x75\xac\x9c\x46\x8f\x9c\x40\x44\x43\xf2\x04\x2b\x6d\xa4\xda\x67\x0e\xd1\xca\x64\x5a\xae\x20\xa1\xae\xf1\xf8\x36\xa2\x9f\x20\xb7\xb5\x52\xb0\xd6\xd2\xc3\xa7\x8b\x8d\xa2\xc7\xca\x4f\x35\xf0\xe6\xac\xaa\x5e\x54\x2d\xac\xae\xcc\xbc\x69\xe3\xd4\xb1\x8d\x9b\x41\xe6\x14\xc1\xbf\x15\x4b\x1d\x71\x01\xb6\xeb\xd2\xb2\x19\x6c\xc7\x95\x3f\xdc\xd3\xb8\x42\x4b\xd8\x38\x87\xe8\x7c\x3d\x89\xb5\xaf\x74\x41\xe8\x59\x73\x6d\x2d\x4c\x23\x8a\x65\xf6\xf6\x93\xdf\xd3\x60\x37\xe4\x94\xc5\xee\x8a\xe9\x7d\xf5\x6b\xce\xb5\xd9\xad\xdf\x12\x0f\xd5\x2e\x0c\xdf\xda\xdd\x7f\x0a\xc2\xea\x97\x41\x28\x4c\x93\xcf\xd3\xb8\xd3\x88\x5a\xae\xf0\xd1\x45\xa3\x68\x7b\x37\xe1\x57\xf1\xe1\xd9\x1b\x37\xf8\x9b\xb7\xfc\xbd\xc2\xc8\x41\xb8\xe4\x62\xc0\x67\xd4\x77\xff\x67\x67\x74\xb5\x70\xb2\x2f\x3b\xf3\xf8\xac\x0e\xfd\x8c\xd9\x09\xdc\x6d\x30\xfa\x33\x00\xc3\x12\xac\xa0\x73\x08\x00\x00")
 
 func migrations45_add_claimable_balances_historySqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -937,7 +938,7 @@ func migrations45_add_claimable_balances_historySql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations46_add_muxed_accountsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x90\xbf\xce\x82\x40\x10\xc4\xfb\x7b\x8a\x2d\xbf\x2f\x42\x6b\x62\xa8\x30\x47\x77\x51\x43\xa0\x26\x9b\x63\x11\x0a\xee\xc8\xde\xe1\x9f\xb7\x37\x01\x13\x31\x22\x1a\xdb\xcd\xcc\xce\xfc\x26\x0c\x61\xd5\x36\x47\x46\x4f\x90\x77\x42\xc4\x2a\x4b\x52\xc8\xe2\xad\x4a\xa0\x6e\x9c\xb7\x7c\x2d\x3c\xa3\x71\xa8\x7d\x63\x8d\x83\x58\x4a\x40\xad\x6d\x6f\x7c\xd1\xf6\x17\x2a\xe1\x84\xac\x6b\xe4\xbf\xf5\xe6\x1f\x76\xb9\x52\xc1\xa0\xa9\x88\x8a\x65\x5d\x34\x1b\x66\x3b\x62\x7c\x44\x39\xdb\xb3\xfe\xed\x13\x55\x15\x69\x7f\x6f\x5c\x96\x4c\xce\xbd\xf5\x8b\xe9\x0e\xd2\x9e\xcd\x17\x4b\xc8\x74\x7f\x78\x9e\x22\x18\x6f\x2f\xe8\x1f\x49\x07\xdb\x1c\xea\x32\xd9\xd8\x60\x8a\x16\x89\x5b\x00\x00\x00\xff\xff\x1e\x83\x01\x2a\xd1\x01\x00\x00")
+var _migrations46_add_muxed_accountsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x90\x4f\x0b\x82\x40\x10\xc5\xef\xfb\x29\xe6\x58\xa4\xd7\x20\x3c\x19\xeb\x6d\xa9\x10\x3d\xcb\xb0\x8e\xe9\xc1\x5d\x99\x5d\xfb\xf3\xed\x03\x0b\x4a\x32\x8b\xae\xcb\x7b\xfb\xe6\xf7\x0b\x43\x58\xb5\xcd\x91\xd1\x13\xe4\x9d\x10\xb1\xca\x92\x14\xb2\x78\xab\x12\xa8\x1b\xe7\x2d\x5f\x0b\xcf\x68\x1c\x6a\xdf\x58\xe3\x20\x96\x12\x50\x6b\xdb\x1b\x5f\xb4\xfd\x85\x4a\x38\x21\xeb\x1a\x79\xb1\xde\x2c\x61\x97\x2b\x15\x0c\x99\x8a\xa8\x98\xcf\x45\x93\x63\xb6\x23\xc6\xe7\x94\xb3\x3d\xeb\xff\x7e\xa2\xaa\x22\xed\x1f\x17\x97\x25\x93\x73\x1f\xfb\xe2\xd5\x83\xb4\x67\xf3\x83\x09\x99\xee\x0f\x63\x15\xc1\xfd\xed\x0d\xfd\x2b\xe9\x50\x9b\x42\x9d\x27\x1b\x6a\x23\xb4\x48\xdc\x06\x00\x1e\x83\x01\x2a\xd1\x01\x00\x00")
 
 func migrations46_add_muxed_accountsSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -957,7 +958,7 @@ func migrations46_add_muxed_accountsSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations47_precompute_trade_aggregationsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x54\xc1\x6e\xda\x40\x10\xbd\xef\x57\xbc\x03\x52\xec\x14\x24\x72\xe9\x01\x4e\x0e\x38\x08\x95\x98\xc8\x60\xa9\x39\x59\x8b\x3d\x35\xab\xd8\xbb\xee\x7a\x1c\xda\x7e\x7d\x05\x98\x90\x80\x69\x22\x35\x17\x1f\x3c\x6f\xde\xcc\xbc\x7d\x33\xbd\x1e\xbe\x14\x2a\xb3\x92\x09\x51\x09\x6d\xd8\x4a\x5d\xc9\x84\x95\xd1\x42\xf4\x7a\x18\x59\xda\xc6\x78\x4d\xd0\xb4\x01\xcb\x55\x4e\x62\x14\xfa\xde\xd2\xc7\xd2\xbb\x9d\xf9\x58\xab\x8a\x8d\xfd\x1d\xb3\x95\x29\x55\xf1\xd7\x7e\xbf\xdf\x87\x23\x00\x56\x05\x55\x2c\x8b\x12\x2b\x95\x29\xcd\x5b\x76\xe8\x3a\xcf\xbb\x02\x58\xc9\x8a\x62\x59\x55\xc4\xb1\x4a\xdb\x00\x89\xa9\x35\x93\x7d\x1f\x03\xa5\x99\x32\xb2\xe7\xf4\xcf\x26\xaf\x0b\x82\xae\x0b\xb2\x2a\x69\x65\xff\x07\x44\x3e\x67\xad\xff\xd7\x2a\x5b\xc7\xfa\x72\x28\x6d\x0d\xe5\x66\x73\x21\x69\x1b\x69\xcf\x31\x25\xe9\x38\xa7\x34\x23\x1b\xb3\x69\x97\x60\x87\x69\x27\xde\x85\xda\x99\x93\xdc\x54\xf4\x1e\xf5\x1e\xd4\xce\xbd\x8f\xb5\x91\x0b\xe0\x21\x9c\xde\x7b\xe1\x23\xbe\xf9\x8f\xce\x9b\x77\xee\x9e\xbd\x6a\xf7\xe8\x12\x57\xb8\xc3\x9d\xe5\x5e\x0c\xb9\x60\xc9\x54\x90\xe6\x5b\xca\x94\x3e\xb8\x6e\x1e\x22\xf4\x1f\x66\xde\xc8\xc7\x5d\x14\x8c\x96\xd3\x79\x00\x36\x71\xa1\xf2\x5c\x55\x0e\xbf\xb2\xdd\x46\xf1\xda\xd4\xfb\x3f\xf8\x63\x34\x75\xc1\xb6\x3e\x0e\x34\xf6\xef\xbc\x68\xb6\xc4\x8d\x2b\x80\xd0\x5f\x46\x61\xb0\x38\x08\xe1\x2d\xd0\xe9\x08\xe0\xd6\x9f\x4c\x03\x01\x1c\x00\x48\xd5\xb3\x93\xc8\x8a\x1d\x87\x7e\xb1\x95\x09\x3b\x54\x9a\x64\x8d\x1f\xd6\x14\x60\x17\xd7\xb8\xd9\x2e\x80\x0b\x59\x35\x5c\xee\xbe\xac\x7b\xbd\xfd\x0e\x05\xe0\x07\xe3\xa1\xe8\x74\x30\xf3\x82\x49\xe4\x4d\x7c\x94\x79\x99\x55\x3f\x73\x4c\xef\xef\xa3\xdd\x52\x0d\xdb\x75\xf0\x75\xfa\xf9\x0a\x7d\xae\x3c\xaf\xea\x0c\x06\x2f\x85\x1a\x05\xfe\x7f\xf8\x66\xc2\x69\x30\xf6\xbf\x63\x34\x0f\x46\x51\x18\xfa\xc1\x72\xf6\x88\x35\xdb\x34\x96\x59\x16\xaf\xea\xe4\x89\x38\xce\x8d\x79\xaa\x4b\xcc\x83\x93\x03\x25\x80\x68\x31\x0d\x26\x58\xb1\x25\x82\x73\x6c\xb8\x59\x87\x9d\xb5\xd3\x58\x72\x17\x57\xbb\x63\x76\x35\x18\x34\x92\xb8\x5b\x87\x7e\xa0\x85\xb3\xc5\x3d\xeb\xa2\x39\x93\x6f\x3a\x39\xcd\x3a\x5d\x87\xb1\xd9\x68\x21\xc6\xe1\xfc\xa1\x29\x7e\xb1\xde\xb0\x15\xf5\x46\x98\x06\x72\xf9\x84\x37\x80\x36\x03\x5d\xb4\xcf\x41\xa6\x8f\xe6\x9e\x2c\xe7\x31\xfd\x6f\x00\x00\x00\xff\xff\x80\x13\x6d\x32\x97\x06\x00\x00")
+var _migrations47_precompute_trade_aggregationsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x54\x41\x6f\xda\x4c\x10\xbd\xef\xaf\x78\x07\xa4\xd8\xf9\x40\x22\x97\xef\x00\x27\x07\x36\x08\x95\x98\xc8\x60\xa9\x39\x59\x8b\x3d\x35\xab\xd8\xbb\x74\x3d\x0e\x6d\x7f\x7d\x05\x98\x26\x10\xd3\x44\x6a\x2e\x3e\xf8\xbd\x79\xb3\xfb\xf6\xcd\xf4\x7a\xf8\xaf\xd4\xb9\x53\x4c\x88\x37\x30\x96\x9d\x32\x95\x4a\x59\x5b\x23\x44\xaf\x87\x91\xa3\x1d\xc6\x6b\x82\xa1\x2d\x58\xad\x0a\x12\xa3\x48\x06\x4b\x89\x65\x70\x3b\x93\x58\xeb\x8a\xad\xfb\x99\xb0\x53\x19\x55\xc9\xff\xfd\x7e\xbf\x0f\x4f\x00\xac\x4b\xaa\x58\x95\x1b\xac\x74\xae\x0d\xef\xd4\x61\xea\xa2\xe8\x0a\x60\xa5\x2a\x4a\x54\x55\x11\x27\x3a\x6b\x23\xa4\xb6\x36\x4c\xee\x7d\x0e\xb4\x61\xca\xc9\x9d\x20\x7b\xf9\x67\x5b\xd4\x25\xc1\xd4\x25\x39\x9d\xbe\xad\x24\xf7\x37\x8a\x7a\xce\x5b\xff\xaf\x75\xbe\x4e\xcc\x65\x28\x6b\x85\x0a\xbb\xbd\x50\xb4\x43\xda\x6b\xec\x86\x4c\x52\x50\x96\x93\x4b\xd8\xb6\x5b\xb0\xe7\xb4\x0b\xef\xa1\x76\xe5\xb4\xb0\x15\xbd\x27\x7d\x20\xb5\x6b\x1f\xb0\x36\x71\x01\x3c\x44\xd3\xfb\x20\x7a\xc4\x17\xf9\xe8\x9d\xbc\x73\xf7\xcd\xab\x76\x5f\x52\xe2\x0b\x7f\x28\xc4\xeb\x40\x2e\x58\x31\x95\x64\xf8\x96\x72\x6d\x8e\xa9\x9b\x47\x88\xe4\xc3\x2c\x18\x49\xdc\xc5\xe1\x68\x39\x9d\x87\x60\x9b\x94\xba\x28\x74\xe5\xf1\xab\xd8\x6d\x35\xaf\x6d\x7d\xf8\x83\x5f\xd6\x50\x17\xec\xea\x97\x0b\x8d\xe5\x5d\x10\xcf\x96\xb8\xf1\x05\x10\xc9\x65\x1c\x85\x8b\xa3\x11\xc1\x02\x9d\x8e\x00\x6e\xe5\x64\x1a\x0a\xe0\x48\x40\xa6\x9f\xbd\x54\x55\xec\x79\xf4\x83\x9d\x4a\xd9\xa3\x8d\x4d\xd7\xf8\xe6\x6c\x09\xf6\x71\x8d\x9b\xdd\x00\xf8\x50\x55\xa3\xe5\x1f\xda\xfa\xd7\xbb\xef\x50\x00\x32\x1c\x0f\x45\xa7\x83\x59\x10\x4e\xe2\x60\x22\xb1\x29\x36\x79\xf5\xbd\xc0\xf4\xfe\x3e\xde\x0f\xd5\xb0\xdd\x07\x69\xb2\xcf\x77\xe8\x73\xed\x79\xd5\x67\x30\xf8\xd3\xa8\x71\xe0\xdf\x2f\xdf\xdc\x70\x1a\x8e\xe5\x57\x8c\xe6\xe1\x28\x8e\x22\x19\x2e\x67\x8f\x58\xb3\xcb\x12\x95\xe7\xc9\xaa\x4e\x9f\x88\x93\xc2\xda\xa7\x7a\x83\x79\x78\xb6\xa0\x04\x10\x2f\xa6\xe1\x04\x2b\x76\x44\xf0\x5e\x0e\xdc\x8c\xc3\x3e\xda\x59\xa2\xb8\x8b\xab\xfd\x32\xbb\x1a\x0c\x1a\x4b\x7c\x7f\xf8\xa1\x23\xbc\x19\xdc\x79\xd8\xbe\x26\x4f\x4e\x72\x5e\x75\x3e\x0e\x63\xbb\x35\x42\x8c\xa3\xf9\x43\xd3\xfc\x62\xbf\x61\x2b\xeb\xc4\x98\x86\x72\x79\x85\x37\x84\xb6\x00\x5d\x8c\xcf\xd1\xa6\x8f\xd6\x9e\x0d\xa7\xa9\x4b\x72\x3a\xf5\x87\xe2\xf7\x00\x80\x13\x6d\x32\x97\x06\x00\x00")
 
 func migrations47_precompute_trade_aggregationsSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -977,7 +978,7 @@ func migrations47_precompute_trade_aggregationsSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations48_rebuild_trade_aggregationsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\x94\x5f\x6f\xda\x30\x14\xc5\xdf\xfd\x29\xae\xfa\x94\x74\xd0\x31\x1e\x26\xad\x7d\xa2\x6d\xb6\x55\xea\x60\x0a\x41\x53\x55\x4d\x96\x49\x2e\xc4\xaa\xff\x20\xfb\xa6\xb4\xdf\x7e\xc2\x21\x09\xd0\xac\x7d\x41\x8e\xfd\xf3\xb9\xbe\x87\x63\x0f\x87\xf0\x49\xcb\xb5\x13\x84\xb0\xd8\x30\x36\x1c\xc2\xb5\xc8\x9f\x56\x52\x29\xa0\x12\x81\xc4\x52\x21\x6c\x25\x95\x80\x2f\xd2\x93\x34\x6b\x28\x04\x89\x0b\xc8\x4a\xe9\x81\xc4\x13\x7a\x10\x4b\x5b\x11\x7c\x03\x2d\x4d\x45\xe8\x2f\xd8\x9f\xbb\xec\x27\x90\x13\x05\x7a\x98\xcc\x21\x62\x00\xf3\xe4\x3e\xb9\xc9\x18\x00\x00\x59\xae\xa5\x52\xd2\x47\x0a\x8b\x35\x3a\x9e\x2b\xeb\xb1\xe0\x82\x06\xf0\x75\x34\x1a\x8d\x62\x10\x1e\x48\x6a\xf4\x24\xf4\x66\x10\x36\x95\xd2\x93\x75\xaf\xdc\x6e\xd0\x09\x92\xd6\x70\x59\xd4\x2b\x67\xd6\x15\xe8\xce\xea\x8f\xa5\xf0\xc8\x85\xf7\x48\xed\x7a\x3d\xa5\x6d\x65\xa8\x9e\xc8\x77\x43\x74\x27\x58\x3b\x7b\x40\x4e\xd2\x74\xf2\xf0\xb8\x71\x32\x47\x6e\x06\x50\x0f\x8a\xbf\xbb\xe3\x85\x31\x03\xf8\x9e\xce\x7e\xb5\x87\xab\x5b\x66\x00\xb3\xf4\x36\x49\xe1\xfa\xe1\xe4\x3c\x6f\x4b\xf7\xf7\xd5\xf4\xc4\xe2\x01\x38\x5c\x56\x52\xd1\xae\xe6\xa9\x8f\xc7\x0e\xf5\xb4\xfe\x4e\xa7\xd1\x79\x70\x39\xef\x9a\xf5\x95\x8e\x0e\xbc\x0a\xcb\xe1\xfb\xd9\xaa\x4a\x63\x07\x1d\x3b\xd5\xc9\xa0\xfb\x00\xbd\xbc\x34\x95\x46\x27\xf3\xf8\xf3\x49\xb1\x6e\x65\xa7\x26\x9e\xd7\xb5\x44\xa4\xc5\x0b\x0f\x4e\x47\xe1\x37\x8e\x1f\xbf\x04\xf7\x4b\xb9\x2e\xb9\xf9\x2f\x34\xee\xa0\xa2\x81\xa4\xe9\x55\x52\x76\xdb\x09\xbd\x61\xc6\x2d\xb3\xd7\x59\x49\xe7\x29\xea\xfb\xd3\xc2\xc9\xed\x06\x0d\xdf\xa7\x9a\x6c\x63\x78\x54\xef\x3a\x2e\x1c\x50\xd3\x0b\x8c\x3b\x60\xaf\xa0\xc4\x7b\x55\xc3\xfd\xe9\x29\x1b\x76\x1d\x57\xad\x51\xd3\x07\x8c\x0f\x80\xa2\x49\x76\x9b\xe8\x1f\xe9\x6c\xf1\x1b\x96\xaf\x1f\x27\xba\x8d\x25\x8b\x19\xc0\xdd\x74\x9e\xa4\x19\xdc\x4d\xb3\xd9\xc9\x35\xe1\xe1\xae\x87\x50\x37\xb1\x86\x73\x58\x39\xab\x9b\xcc\x33\x80\xf8\x2a\xbc\x49\xed\x1b\x75\x6b\xb7\x86\xb1\x2c\x5d\x4c\x6f\x26\x59\x02\xd9\xe4\xfa\x3e\xe9\xd5\xbd\x62\xff\x02\x00\x00\xff\xff\x0e\xad\x7c\x8e\xdb\x04\x00\x00")
+var _migrations48_rebuild_trade_aggregationsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\x94\x5f\x6f\xda\x3c\x14\xc6\xef\xfd\x29\x8e\x7a\x95\xf4\x85\xbe\x8c\x8b\x49\x6b\xaf\x68\x9b\x6d\x48\x1d\x4c\x21\x68\xaa\xaa\xc9\x32\xc9\x81\x58\xf5\x1f\x64\x9f\x94\xf6\xdb\x4f\x38\x24\xa1\x2c\x65\x37\x91\xed\xf3\xf3\x73\x7c\x4e\x1e\x7b\x38\x84\xff\xb4\xdc\x38\x41\x08\xcb\x2d\x63\xc3\x21\xdc\x8a\xfc\x79\x2d\x95\x02\x2a\x11\x48\xac\x14\xc2\x4e\x52\x09\xf8\x2a\x3d\x49\xb3\x81\x42\x90\xb8\x82\xac\x94\x1e\x48\x3c\xa3\x07\xb1\xb2\x15\xc1\x17\xd0\xd2\x54\x84\xfe\x8a\xfd\x9a\x66\xdf\x81\x9c\x28\xd0\xc3\x64\x01\x11\x03\x58\x24\x0f\xc9\x5d\xc6\x00\x00\xc8\x72\x2d\x95\x92\x3e\x52\x58\x6c\xd0\xf1\x5c\x59\x8f\x05\x17\x34\x80\xcf\xa3\xd1\x68\x14\x83\xf0\x40\x52\xa3\x27\xa1\xb7\x83\xb0\xa9\x94\x9e\xac\x7b\xe3\x76\x8b\x4e\x90\xb4\x86\xcb\xa2\x8e\x5c\x58\x57\xa0\xbb\xa8\x27\x2b\xe1\x91\x0b\xef\x91\xda\x78\xbd\xa4\x6d\x65\xa8\x66\xf2\xfd\x10\xdd\x09\xd6\xae\x1e\x91\x93\x34\x9d\x3c\x3e\x6d\x9d\xcc\x91\x9b\x01\xd4\x83\xe2\xf7\xfe\x78\x61\xcc\x00\xbe\xa6\xf3\x1f\xed\xe1\xea\x92\x19\xc0\x3c\xbd\x4f\x52\xb8\x7d\x3c\x39\xcf\xdf\xa9\xfb\xeb\x6a\x6a\x62\xf1\x00\x1c\xae\x2a\xa9\x68\x9f\xf3\xb4\x8f\xef\x3b\xd4\x53\xfa\x99\x4a\xa3\xcb\x78\x2f\x99\x77\xc5\xfa\x4a\x47\x47\xbd\x0a\xe1\x30\x7f\xb1\xaa\xd2\xd8\x41\xad\x6a\xc7\x35\x4b\xe7\xd1\xeb\x6b\x53\x69\x74\x32\x8f\xff\x3f\x49\xd6\x45\xf6\x6a\xe2\x65\x53\x4b\x44\x5a\xbc\xf2\xd0\xe9\x28\x7c\xe3\xf8\xe9\x53\xe8\x7e\x29\x37\x25\x37\x1f\x42\xe3\x0e\x3a\xd4\x1c\x69\x69\x7a\x95\x94\xdd\x71\xf3\x21\x33\x6e\x99\x83\xce\x5a\x3a\x4f\x51\xdf\x4f\x0b\xfd\xb2\x5b\x34\xfc\xe0\x6a\xb2\x4d\xc3\xa3\x7a\xd7\xfb\xc4\x01\x35\xbd\xc0\xb8\x03\x0e\x0a\x4a\x9c\xcb\x1a\xee\x4f\x4f\x5a\x25\x8e\x44\xeb\xac\x35\x6a\xfa\x80\xf1\x11\x50\x34\xce\x6e\x1d\xfd\x2d\x9d\x2f\x7f\xc2\xea\xed\xdf\x8e\x6e\x6d\xc9\x62\x06\x30\x9d\x2d\x92\x34\x83\xe9\x2c\x9b\x9f\x5c\x13\x1e\xee\x7a\x78\x1c\x1a\x5b\xc3\x25\xac\x9d\xd5\x8d\xe7\x19\x40\x7c\xc3\xd8\xf1\x1b\x75\x6f\x77\x86\xb1\x2c\x5d\xce\xee\x26\x59\x02\xd9\xe4\xf6\x21\xe9\xd5\xbd\x61\x7f\x06\x00\x0e\xad\x7c\x8e\xdb\x04\x00\x00")
 
 func migrations48_rebuild_trade_aggregationsSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -997,7 +998,7 @@ func migrations48_rebuild_trade_aggregationsSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations49_add_brin_index_trade_aggregationsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\xce\xb1\xaa\xc2\x30\x14\xc6\xf1\xfd\x3c\xc5\x19\xef\x45\x0a\x99\x5c\x3a\x49\x9b\x4a\x40\x12\x49\x53\xa8\x53\x88\xb6\xb4\x19\x92\x94\xe4\x80\xf8\xf6\x22\x88\xb8\xb9\x7f\x1f\xbf\x7f\x55\xe1\x2e\xf8\x25\x3b\x9a\x71\xd8\x30\x26\xca\x2e\x16\x77\x23\x9f\x22\x40\xa3\xf9\xc1\x70\x14\xb2\xe5\x23\x36\x4a\x36\x83\xd6\x5c\x9a\xd3\x05\x45\x87\x52\x19\xe4\xa3\xe8\x4d\x8f\x2b\xe5\xc9\xba\x65\xb1\xe4\xc3\x5c\xc8\x85\xcd\x5e\xb3\x8f\xa8\x24\xae\xbe\x50\xca\x0f\x4b\xd9\x4d\x73\xb1\x7b\xc6\x18\xc3\xa1\x17\xf2\x88\xaf\xc9\xdf\xe7\xf1\x5f\x03\x7c\xd7\xb4\xe9\x1e\x01\x5a\xad\xce\x6f\x5f\x74\x3f\xb8\x1a\x9e\x01\x00\x00\xff\xff\xc9\x09\x7e\x00\xce\x00\x00\x00")
+var _migrations49_add_brin_index_trade_aggregationsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\xce\xb1\xaa\xc2\x30\x14\xc6\xf1\xfd\x3c\xc5\x19\xef\x45\x0a\x99\x5c\x3a\x49\x9b\x4a\x40\x12\x49\x53\xa8\x53\x88\xb6\xb4\x19\x92\x94\xe4\x80\xf8\xf6\x22\x88\xb8\xb9\x7f\x1f\xbf\x7f\x55\xe1\x2e\xf8\x25\x3b\x9a\x71\xd8\x30\x26\xca\x2e\x16\x77\x23\x9f\x22\x40\xa3\xf9\xc1\x70\x14\xb2\xe5\x23\x36\x4a\x36\x83\xd6\x5c\x9a\xd3\x05\x45\x87\x52\x19\xe4\xa3\xe8\x4d\x8f\x2b\xe5\xc9\xba\x65\xb1\xe4\xc3\x5c\xc8\x85\xcd\x5e\xb3\x8f\xa8\x24\xae\xbe\x50\xca\x0f\x4b\xd9\x4d\x73\xb1\x7b\xc6\x18\xc3\xa1\x17\xf2\x88\xaf\xc9\xdf\xe7\xf1\x5f\x03\x7c\xd7\xb4\xe9\x1e\x01\x5a\xad\xce\x6f\x5f\x74\x3f\xb8\x1a\x9e\x03\x00\xc9\x09\x7e\x00\xce\x00\x00\x00")
 
 func migrations49_add_brin_index_trade_aggregationsSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -1017,7 +1018,7 @@ func migrations49_add_brin_index_trade_aggregationsSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations4_add_protocol_versionSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\xcd\xb1\x0a\xc2\x30\x10\x06\xe0\x3d\x4f\xf1\xef\x52\x70\xef\x14\x4d\x9d\xce\x44\x4a\x32\x38\x15\xd1\xa3\x06\x6a\xae\x5c\x82\xe2\xdb\xbb\xba\x88\x4f\xf0\x75\x1d\x36\x8f\x3c\xeb\xa5\x31\xd2\x6a\x2c\xc5\x61\x44\xb4\x3b\x1a\x10\x3c\x9d\x71\xcf\xb5\x89\xbe\xa7\x85\x6f\x33\x6b\x85\x01\xac\x73\xd8\x07\x4a\x47\x8f\x55\xa5\xc9\x55\x96\xe9\xc9\x5a\xb3\x14\xe4\xd2\x78\x66\x85\x1b\x0e\x36\x51\xc4\x16\x3e\x44\xf8\x44\xd4\x1b\xf3\x6d\x39\x79\x95\xff\x9a\x1b\xc3\xe9\x97\xd5\x9b\x4f\x00\x00\x00\xff\xff\x83\xbb\x30\x2e\xbc\x00\x00\x00")
+var _migrations4_add_protocol_versionSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\xcd\xb1\x0a\xc2\x30\x10\x06\xe0\x3d\x4f\xf1\xef\x52\x70\xef\x14\x4d\x9d\xce\x44\x4a\x32\x38\x15\xd1\xa3\x06\x6a\xae\x5c\x82\xe2\xdb\xbb\xba\x88\x4f\xf0\x75\x1d\x36\x8f\x3c\xeb\xa5\x31\xd2\x6a\x2c\xc5\x61\x44\xb4\x3b\x1a\x10\x3c\x9d\x71\xcf\xb5\x89\xbe\xa7\x85\x6f\x33\x6b\x85\x01\xac\x73\xd8\x07\x4a\x47\x8f\x55\xa5\xc9\x55\x96\xe9\xc9\x5a\xb3\x14\xe4\xd2\x78\x66\x85\x1b\x0e\x36\x51\xc4\x16\x3e\x44\xf8\x44\xd4\x1b\xf3\x6d\x39\x79\x95\xff\x9a\x1b\xc3\xe9\x97\xd5\x9b\xcf\x00\x83\xbb\x30\x2e\xbc\x00\x00\x00")
 
 func migrations4_add_protocol_versionSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -1037,7 +1038,7 @@ func migrations4_add_protocol_versionSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations50_liquidity_poolsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x56\x5d\x8f\xda\x38\x14\x7d\x9f\x5f\x71\xdf\x1a\xb4\x83\xd4\xbe\x16\x6d\xa5\x0c\x71\x77\xa2\x42\x98\x86\x64\xdb\x79\xb2\x0c\xbe\x80\x77\x33\x31\x8d\xcd\x2c\xfc\xfb\x55\x12\x42\x83\x63\x43\x8a\x46\xe5\x8d\xf8\x7e\x9c\x73\xcf\xf1\xc7\x70\x08\x7f\xbc\x88\x75\xc1\x34\x42\xba\xbd\xbb\x1b\xc7\xc4\x4f\x08\x24\xfe\xc3\x84\x40\x26\x7e\xec\x04\x17\xfa\x40\xb7\x52\x66\x0a\xbc\x3b\x00\x00\xc1\x41\xe3\x5e\x43\x34\x4b\x20\x4a\x27\x93\x7b\x18\x0e\x61\x83\xfb\x21\xe6\x4b\xc9\x91\xc3\x93\x94\x59\x18\x54\xb1\xfa\xb0\x45\x50\x2f\x2c\xcb\x44\xde\xca\xa8\xd6\x56\x88\x20\x72\x8d\x6b\x2c\x8c\x15\x5d\xec\x94\xce\x44\x8e\x74\x29\x77\xb9\x86\x85\x58\xb7\xd3\x61\xfc\x48\xc6\x5f\xc0\x33\xc3\x3e\xc1\xfb\x41\x5d\x40\x6d\x58\xe1\x4a\x0e\xc8\x67\x3f\x9d\x24\xf0\xbe\x2e\xe3\xb5\x63\x3f\xfd\x79\x2a\xc1\x94\x42\x4d\x0b\x54\x58\xbc\xa2\x82\x7f\x94\xcc\x17\x06\xcc\x8c\x29\x4d\x5f\x24\x17\x2b\x81\x9c\x66\xc8\x4b\x26\x76\x46\x1c\x33\xd4\xc8\x61\x21\x65\x86\x2c\xef\xa2\x59\xb1\x4c\x61\x1d\xfb\x14\x87\x53\x3f\x7e\x86\x2f\xe4\x19\x3c\xc1\x07\x77\x83\xd1\x49\x97\x30\x0a\xc8\x77\x53\x17\xba\x38\x50\x03\xee\x2c\xea\x88\x97\xce\xc3\xe8\x2f\x58\x8b\xdc\xb3\x51\xa3\x5b\xa6\x37\x54\x6e\xd5\x60\x64\xf6\x7a\x45\x6a\xd6\x72\x96\x7f\x48\x62\x42\xc0\x3b\xd2\xbd\xb7\x8e\xa8\x45\x67\x4e\xbe\xa6\x24\x1a\x13\xd8\x08\xa5\x65\x71\x30\x1b\x51\xc1\xa9\xc2\x1f\xd5\x58\xe6\x89\x1f\x27\xf0\x2d\x4c\x1e\xe1\x43\xf5\x21\x8c\xc6\x31\x99\x92\x28\x81\x87\xe7\xe3\xa7\x68\x06\xd3\x30\xfa\xdb\x9f\xa4\xe4\xf4\xdf\xff\xfe\xf3\xff\xd8\x1f\x3f\x12\xf8\x30\x32\x7c\xee\xe8\xfe\xd3\xef\x2e\x0f\xe5\xb8\xd7\xaf\x2c\xf3\xde\x5d\xc6\xff\xee\xe3\xc7\x02\xd7\xcb\x8c\x29\x75\x74\xd7\x79\x1c\x35\xb7\x54\x5b\xf2\x34\x0a\xbf\xa6\x8d\x1a\x22\xe7\xb8\xa7\xae\x6e\x32\x2f\x2b\xcd\x22\x27\xa1\x5a\xa4\x85\x2e\x10\x2b\x6b\x8d\x6e\x6b\xd2\x45\xdf\xb7\x67\x27\x73\xe0\xd2\x42\x6e\xb1\x60\x5a\x74\xba\x35\xaa\x74\xe3\xba\x3a\xdd\x9f\x45\x76\x51\x1b\xe1\x3d\xa7\xee\x44\x56\xcf\x5f\xb5\x87\xe1\x66\x71\x36\x16\x2b\x99\x7b\x37\x72\x73\x93\xfe\x02\xc0\xb3\x16\x6f\x83\xd4\xa9\xa1\x2e\x58\xae\xd8\xb2\x87\x8a\xed\xc8\xdf\xa5\xe3\x05\x74\x16\x25\x2f\x71\xb1\x4e\xc8\xa0\x74\xb3\x9a\x57\x60\x1a\x6d\xde\x0e\x71\x39\x44\x7f\x92\x90\xf8\x28\x6a\x75\xdb\xd2\xf2\xba\x55\xe0\x07\x81\xe3\x08\x33\xa8\xb4\x92\xca\x5b\xca\x7a\x70\xb4\x0b\xb7\x6e\x11\xfb\x59\x11\xc4\xb3\xa7\x63\xed\x8d\x2e\x78\x59\x54\xae\x56\x58\x8c\x3a\x2b\xd5\x8d\x8e\x05\xcd\xa4\xfc\x77\xb7\x35\xc8\xb4\x28\x73\x54\x50\xe5\x56\x75\xa8\xe0\xb5\xdb\x1c\xbf\xba\xc8\x82\x29\xa4\x6c\x59\xb5\x28\x59\x54\xf9\xe7\x6e\xbd\x98\xdf\x60\xbb\xad\x44\x10\xd4\x00\x5c\x1b\xe1\x6a\xf6\x69\x34\xb7\x16\x30\x12\x8f\x2f\x39\xf3\x99\xd2\x08\xe4\x00\x7b\x6e\x55\x6e\xa8\x6f\x4f\x32\xb7\x4a\xd3\xc2\xcd\xe8\x72\x17\x67\x5e\xe9\xb5\xf6\xdb\x38\x90\xff\xe5\x56\xf7\x39\x2b\x74\x1d\xe9\x1c\x45\xe9\x6b\x32\x21\x09\x81\xcf\xf1\x6c\x6a\xe2\xfd\xf6\x48\x62\x02\x9e\xc5\x33\xe1\xbc\x72\xcb\x00\x66\x31\x78\xa6\x27\x9b\xc5\x1e\xce\xef\xca\x79\xd1\x01\x55\x8e\x93\xf7\xf5\x54\xfb\x10\x6e\xdb\x36\x73\x92\xfc\xca\xc6\x33\x87\xd4\x3f\x3d\x08\x4e\x07\xc4\x71\x97\x58\xed\x7e\x7e\xea\x80\xcc\xfb\xb8\xaf\x7e\x90\xbb\xdd\x5d\x35\xbe\xe2\xe4\x06\x9c\x71\x48\x5e\x3b\x80\x47\xce\x33\xde\xe6\x8c\xda\xaa\xe5\xc2\xe5\x87\xf3\x92\xa9\x25\xe3\xd8\x04\xf7\x7c\xe6\xdb\x6b\xbb\x1f\x26\x46\x97\xfe\x6f\x0f\x6b\xa2\x3b\xe8\xff\x00\x00\x00\xff\xff\xf9\xb4\x97\x15\x24\x0f\x00\x00")
+var _migrations50_liquidity_poolsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x56\x4d\x93\xda\x38\x10\xbd\xf3\x2b\xfa\x16\x53\x3b\x54\x25\xd7\x50\x9b\x2a\x0f\x56\x76\x5c\x61\xec\x89\xb1\x37\x99\x93\x4a\xa0\x06\xb4\xeb\xb1\x88\xa5\x99\x9d\xf9\xf7\x5b\xfe\x00\x6c\x59\x02\xc2\xa6\x16\x4e\xb6\xfb\xf5\x7b\xdd\xfd\xf4\x31\x99\xc0\x6f\x4f\x62\x53\x32\x8d\x90\xed\x46\xa3\x59\x42\xfc\x94\x40\xea\xdf\xce\x09\xe4\xe2\xc7\xb3\xe0\x42\xbf\xd1\x9d\x94\xb9\x02\x6f\x04\x00\x20\x38\x68\x7c\xd5\x10\xc5\x29\x44\xd9\x7c\x7e\x03\x93\x09\x6c\xf1\x75\x82\xc5\x4a\x72\xe4\xf0\x20\x65\x1e\x06\x75\xac\x7e\xdb\x21\xa8\x27\x96\xe7\xa2\xe8\x20\xea\x6f\x6b\x44\x10\x85\xc6\x0d\x96\xc6\x17\x5d\x3e\x2b\x9d\x8b\x02\xe9\x4a\x3e\x17\x1a\x96\x62\xd3\x85\xc3\xec\x8e\xcc\xbe\x80\x67\x86\x7d\x82\xf7\xe3\x26\x81\xda\xb2\xd2\x05\x0e\xc8\x67\x3f\x9b\xa7\xf0\xbe\x49\xe3\x75\x63\x3f\xfd\x7e\x48\xc1\x94\x42\x4d\x4b\x54\x58\xbe\xa0\x82\xbf\x94\x2c\x96\x87\x24\x0d\x4b\xce\x94\xa6\x4f\x92\x8b\xb5\x40\x4e\x73\xe4\x55\x25\xf6\x8a\x38\xe6\xa8\x91\xc3\x52\xca\x1c\x59\x31\x54\xb3\x66\xb9\xc2\x26\xf6\x21\x09\xef\xfd\xe4\x11\xbe\x90\x47\xf0\x04\x1f\x8f\xc6\xd3\xc3\x5c\xc2\x28\x20\xdf\xcd\xb9\xd0\xe5\x1b\x35\xe4\xc6\xd1\x60\x78\xd9\x22\x8c\xfe\x80\x8d\x28\x3c\x5b\x69\x74\xc7\xf4\x96\xca\x9d\x1a\x4f\x4d\xae\x17\xa4\x66\x2e\x67\xfa\xdb\x34\x21\x04\xbc\xb6\xdc\x1b\x6b\x8b\x3a\xe5\x2c\xc8\xd7\x8c\x44\x33\x02\x5b\xa1\xb4\x2c\xdf\x4c\x22\x2a\x38\x55\xf8\xa3\x6e\xcb\x22\xf5\x93\x14\xbe\x85\xe9\x1d\x7c\xa8\x5f\x84\xd1\x2c\x21\xf7\x24\x4a\xe1\xf6\xb1\x7d\x15\xc5\x70\x1f\x46\x7f\xfa\xf3\x8c\x1c\x9e\xfd\xef\xc7\xe7\x99\x3f\xbb\x23\xf0\xe1\x28\xa0\xf1\xb9\x83\xfd\xe8\x77\x97\x87\x0a\x7c\xd5\x2f\x2c\xf7\xde\x9d\xd6\xff\xee\xe3\xc7\x12\x37\xab\x9c\x29\xd5\xba\xab\x1f\x47\xcd\x25\xd5\x1d\x79\x16\x85\x5f\xb3\xfd\x34\x44\xc1\xf1\x95\xba\xd8\x64\x51\x65\x8a\x23\x67\x41\x8d\x07\x96\xba\x44\xac\xad\x35\xbd\x8e\xa4\xff\xea\x67\x38\x07\xc8\xb1\x6b\x16\x72\x87\x25\xd3\x62\xc0\xb6\xdf\x85\x86\x71\xc3\x39\xdd\xf4\x22\x07\xdc\x66\xf8\x85\x5d\x77\x2a\x6b\xfa\xaf\xba\xcd\x70\xc6\xf6\xdb\x32\x0c\x17\x1c\x6e\xdc\xca\xcd\x45\xfa\x13\x02\x7b\x14\xbf\x46\xa9\x73\x86\xba\x64\x85\x62\xab\x0b\xa6\xd8\x8d\xfc\xbf\xe6\x78\x42\x9d\x65\x92\x27\xa2\xed\x1d\xea\x02\xfe\xcb\x34\x4f\x10\x57\x32\x0d\x9a\x5f\xa7\xb8\x6a\xa2\x3f\x4f\x49\xd2\x0e\xb5\x3e\x6d\x69\x75\x2a\x2b\xf0\x83\xc0\xb1\x85\x19\xa5\x74\x40\xd5\x29\x35\xc4\xc4\x51\x2f\x71\xe7\x14\xb1\xef\x15\x41\x12\x3f\xb4\xb9\xb7\xba\xe4\x55\x52\xb9\x5e\x63\x39\x1d\x7c\xa9\x4f\x74\x2c\x69\x2e\xe5\xdf\xcf\x3b\xa3\x98\x4e\xc9\x1c\x15\xd4\xd8\x3a\x0f\x15\xbc\x71\x9b\xe3\xd7\x74\x64\xc9\x14\x52\xb6\xaa\x29\xaa\x2a\x6a\x7c\xdf\xad\x8e\x5f\x83\xdf\x6b\xbb\x2e\x45\x10\x34\x02\x86\xdd\x6c\x16\xc2\x59\xf4\xa1\x35\xd7\x26\x30\x98\xdb\x9b\xdc\x71\xd5\xf5\x07\xe4\x10\xdb\xb7\x2a\x37\xa6\x6f\x07\x8d\xa7\x76\x0a\x77\x45\xa7\x59\x9c\xb8\xca\x6b\xdd\xbb\x71\x20\xff\x29\xac\xee\x73\x66\x18\x3a\xd2\xd9\x8a\xe9\x68\x14\x90\x39\x49\x09\x7c\x4e\xe2\x7b\x53\xef\xb7\x3b\x92\x10\xf0\x2c\x9e\x09\x17\xf5\x7e\x37\x86\x38\x01\xcf\xf4\xe4\xfe\xe3\x05\xce\x37\x04\xad\xb1\xbd\x89\x3a\xfe\x35\xc6\x59\xf7\x79\xa8\xbd\x09\xd7\x2d\x9b\x05\x49\x2f\x5c\x35\xd6\x85\x7b\x39\x3c\x08\x0e\x1b\x44\xbb\x4a\xac\x76\x3f\xb4\xa5\xde\x75\x40\x16\x97\xb8\xaf\xb9\x90\xbb\xdd\x5d\x13\x9f\x71\xf2\x5e\x9c\xb1\x49\x9e\xdb\x80\xa7\x3d\x73\x74\xb7\x62\x9b\x33\xaa\xf8\xc6\xd6\x7d\x33\xf5\xc3\x14\xac\x98\x5a\x31\x8e\xfb\xe0\x0b\xaf\xf9\xf6\xdc\xee\x8b\x89\xc1\xd2\x87\x9d\x3a\xfd\xac\x40\x77\xd0\xbf\x03\x00\xf9\xb4\x97\x15\x24\x0f\x00\x00")
 
 func migrations50_liquidity_poolsSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -1057,7 +1058,7 @@ func migrations50_liquidity_poolsSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations51_remove_ht_unused_indexesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\xce\xbf\x4a\xc7\x30\x14\xc5\xf1\x3d\x4f\x71\x46\x8b\xe6\x09\x3a\x89\x8d\x1a\x28\xa9\x34\x2d\x76\x2b\x69\x7a\xd5\x0c\x26\x9a\x3f\x48\xdf\x5e\x90\x62\x0b\x22\xfc\xb6\x0c\xdf\xf3\xc9\xe5\x1c\xd7\xef\xee\x35\x9a\x4c\x18\x3f\x18\xe3\x1c\xc5\x97\x44\x2b\x9c\x5f\x9d\xa5\xc4\x9a\xbe\x7b\x82\x54\x8d\x98\x20\xef\x21\x26\xa9\x07\x8d\x65\x9b\x8d\xb5\xa1\xf8\x5c\xff\x1b\xbc\x10\x1d\xd1\x8f\xfc\xfb\x53\x13\xbe\x3c\xbb\xeb\xc5\xed\x20\xf6\xe5\x01\xa2\x53\x78\x73\x29\x87\xb8\xcd\x39\x1a\x9f\x8c\xcd\x2e\xf8\x84\x51\x4b\xf5\x80\x25\x47\x22\x5c\xed\xf1\x0d\xf6\xc7\x9c\xe8\xb3\x90\xb7\x54\xd5\x7f\xe0\xd3\x21\x97\xe1\xa7\x41\x85\xe7\x47\xd1\x0b\x9c\x0d\xa9\xa1\xba\x01\x6a\x6c\xdb\x9a\x7d\x07\x00\x00\xff\xff\x08\x04\xf4\x75\x41\x01\x00\x00")
+var _migrations51_remove_ht_unused_indexesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x8e\xbf\x4a\xc6\x30\x14\x47\xf7\x3c\xc5\x6f\xf4\x43\xf3\x04\x9d\xc4\x46\x0d\x94\x54\x9a\x16\xbb\x95\x34\xbd\x6a\x06\x13\xcd\x1f\xa4\x6f\xef\x52\x68\x40\x84\x6f\xbb\xc3\x39\xe7\xfe\x38\xc7\xed\xa7\x7b\x8f\x26\x13\xa6\x2f\xc6\x38\x47\xf1\x25\xd1\x06\xe7\x37\x67\x29\xb1\x76\xe8\x5f\x20\x55\x2b\x66\xc8\x47\x88\x59\xea\x51\x63\xdd\x17\x63\x6d\x28\x3e\x37\xff\x02\x6f\x44\x27\xc4\x58\xfd\xa9\x0d\x3f\x9e\x3d\x0c\xe2\x7e\x14\x87\x79\x06\xd1\x2b\x7c\xb8\x94\x43\xdc\x97\x1c\x8d\x4f\xc6\x66\x17\x7c\xc2\xa4\xa5\x7a\xc2\x9a\x23\x11\x6e\x0e\xf8\x0e\xc7\xb1\x24\xfa\x2e\xe4\x2d\x5d\x9a\x3f\xe1\x6a\xc8\x75\xf1\x4a\xb8\xe0\xf5\x59\x0c\x02\x75\x43\x6a\xa8\x7e\x84\x9a\xba\xae\x61\xbf\x03\x00\x08\x04\xf4\x75\x41\x01\x00\x00")
 
 func migrations51_remove_ht_unused_indexesSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -1077,7 +1078,7 @@ func migrations51_remove_ht_unused_indexesSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations52_add_trade_type_indexSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x90\x41\x4b\xc3\x30\x1c\xc5\xef\xf9\x14\x8f\x9d\x1c\x6e\xa0\x5e\x8b\x42\xb7\xfc\x75\xc5\xd2\x8e\x34\x45\x6f\x21\x33\xc1\x05\xba\xa6\xa6\x19\xd2\x6f\x2f\x4e\x91\xa0\xb8\x5b\x0e\xbf\xbc\xdf\xfb\xbf\xe5\x12\x97\x07\xf7\x1a\x74\xb4\x68\x07\xc6\xf2\x52\x92\x80\xcc\x57\x25\x61\xef\xc6\xe8\xc3\xa4\x62\xd0\xc6\x8e\xc8\x39\xc7\xe9\xa9\xe2\x34\x58\x8c\x07\xdd\x75\xae\x8f\xe0\x74\x9f\xb7\xa5\xc4\x35\xd6\x1b\x5a\x3f\x5e\x24\xcc\x1d\xae\xe6\x19\x6b\xb7\x3c\x97\x7f\xe2\x1a\x92\x69\xdc\x2d\x6e\xf0\xb4\x21\x41\xd8\xe9\xd1\xaa\xce\xbd\x1d\x9d\x71\x71\x52\x83\xf7\x9d\x72\x06\x45\x83\xaa\x96\xa8\xda\xb2\x44\x2d\xf0\xe2\x8f\x7d\xb4\xe1\x3c\x98\xb1\xb5\xa0\x4f\x77\x51\x71\x7a\xc6\x3e\x06\xa3\x76\xdf\x0d\xbe\xb4\x75\xf5\xbb\x57\xdb\x14\xd5\x03\x56\x52\x10\x25\xa7\x2c\x7e\x30\x3f\xd8\xa0\xa3\xf3\xbd\x72\x66\x81\x99\x0f\xc6\x86\xd9\x3c\x63\x2c\x9d\x92\xfb\xf7\x9e\x31\x2e\xea\xed\xbf\xea\xec\xdc\xd6\xa7\x9f\x29\xfb\x11\x00\x00\xff\xff\x80\x41\xbf\x62\xa8\x01\x00\x00")
+var _migrations52_add_trade_type_indexSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x90\x41\x4b\xc3\x30\x1c\xc5\xef\xf9\x14\x8f\x9d\x1c\x6e\xa0\x5e\x8b\x42\xb7\xfc\x75\xc5\xd2\x8e\x34\x45\x6f\x21\x33\xc1\x05\xba\xa6\xa6\x19\xd2\x6f\x2f\x4e\x91\xa0\xb8\x5b\x0e\xbf\xbc\xdf\xfb\xbf\xe5\x12\x97\x07\xf7\x1a\x74\xb4\x68\x07\xc6\xf2\x52\x92\x80\xcc\x57\x25\x61\xef\xc6\xe8\xc3\xa4\x62\xd0\xc6\x8e\xc8\x39\xc7\xe9\xa9\xe2\x34\x58\x8c\x07\xdd\x75\xae\x8f\xe0\x74\x9f\xb7\xa5\xc4\x35\xd6\x1b\x5a\x3f\x5e\x24\xcc\x1d\xae\xe6\x19\x6b\xb7\x3c\x97\x7f\xe2\x1a\x92\x69\xdc\x2d\x6e\xf0\xb4\x21\x41\xd8\xe9\xd1\xaa\xce\xbd\x1d\x9d\x71\x71\x52\x83\xf7\x9d\x72\x06\x45\x83\xaa\x96\xa8\xda\xb2\x44\x2d\xf0\xe2\x8f\x7d\xb4\xe1\x3c\x98\xb1\xb5\xa0\x4f\x77\x51\x71\x7a\xc6\x3e\x06\xa3\x76\xdf\x0d\xbe\xb4\x75\xf5\xbb\x57\xdb\x14\xd5\x03\x56\x52\x10\x25\xa7\x2c\x7e\x30\x3f\xd8\xa0\xa3\xf3\xbd\x72\x66\x81\x99\x0f\xc6\x86\xd9\x3c\x63\x2c\x9d\x92\xfb\xf7\x9e\x31\x2e\xea\xed\xbf\xea\xec\xdc\xd6\xa7\x9f\x29\xfb\x31\x00\x80\x41\xbf\x62\xa8\x01\x00\x00")
 
 func migrations52_add_trade_type_indexSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -1097,7 +1098,7 @@ func migrations52_add_trade_type_indexSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations53_add_trades_rounding_slippageSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\x8e\xc1\xaa\xc2\x30\x10\x45\xf7\xf9\x8a\xd9\x3f\xf2\x05\x5d\xe5\x91\xee\x0a\x4a\xa9\xeb\x30\x31\x43\x1c\xa8\x49\xc9\x4c\x51\xff\x5e\x28\x22\x88\x50\xf7\xf7\xdc\x73\xac\x85\xbf\x2b\xe7\x86\x4a\x70\x5a\x8c\xb1\x16\x5c\x4a\xc0\x12\xd2\x2a\x0a\x5a\x41\x1b\x26\x12\x50\x8c\x33\x19\x37\x4c\xfd\x08\x93\xfb\x1f\x7a\xb8\xb0\x68\x6d\x8f\xf0\x1a\x38\xef\xa1\xd5\xb5\x24\x2e\x39\xc8\xcc\xcb\x82\x99\x20\x72\xe6\xa2\xdd\x2f\x30\xa2\x50\x60\x09\x74\xc7\xb3\x42\xac\x75\x26\x2c\xdd\x96\xf3\xce\xf3\xf5\x56\xf6\x7e\xfc\x78\x38\x7e\x17\xec\xaa\x37\xe4\xc3\xdd\x99\x67\x00\x00\x00\xff\xff\x9b\x4e\x68\x91\x12\x01\x00\x00")
+var _migrations53_add_trades_rounding_slippageSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\x8e\x41\x0a\xc2\x30\x10\x45\xf7\x39\xc5\xec\x25\x27\xe8\x2a\x92\xee\x0a\x4a\xa9\xeb\x30\x31\x43\x1c\xa8\x49\xc9\x4c\x51\x6f\x2f\x88\x0b\x45\xa8\xfb\xff\xfe\x7b\xd6\xc2\xee\xca\xb9\xa1\x12\x9c\x16\x63\xac\x05\x97\x12\xb0\x84\xb4\x8a\x82\x56\xd0\x86\x89\x04\x14\xe3\x4c\xc6\x0d\x53\x3f\xc2\xe4\xf6\x43\x0f\x17\x16\xad\xed\x11\xde\x03\xe7\x3d\xb4\xba\x96\xc4\x25\x07\x99\x79\x59\x30\x13\x44\xce\x5c\xb4\xfb\x07\x46\x14\x0a\x2c\x81\xee\x78\x56\x88\xb5\xce\x84\xa5\x33\xe6\x33\xcf\xd7\x5b\xd9\xfa\xf1\xe3\xe1\xf8\x5b\xb0\xa9\x7e\x21\x5f\xee\xce\x3c\x07\x00\x9b\x4e\x68\x91\x12\x01\x00\x00")
 
 func migrations53_add_trades_rounding_slippageSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -1117,7 +1118,7 @@ func migrations53_add_trades_rounding_slippageSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations54_tx_preconditions_and_account_fieldsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x93\x51\x6f\xda\x40\x0c\xc7\xdf\xf3\x29\xfc\xd6\xd2\x35\xac\xed\x28\x9a\x16\x69\x12\x2d\x3c\x4c\x62\x2d\x6a\xe1\x61\x9a\xa6\xc8\x24\xde\xe5\xb6\xc4\xc7\xee\x9c\x06\xf6\xe9\xa7\x4b\x80\x8e\x0e\xb4\xa8\xe4\x29\x97\xd8\xfe\xff\xed\xfb\x39\x0c\xe1\x4d\xa1\x95\x45\x21\x98\x2d\x82\xc1\x78\x3a\x7a\x80\xe9\xe0\x66\x3c\x82\x4c\x3b\x31\x76\x15\x8b\x45\x76\x98\x88\x36\xec\x60\x30\x1c\x42\x4e\xa9\x22\x1b\xcf\x4d\xc9\xa9\x83\x7f\x1f\xcd\xf2\xde\x22\x2b\x8a\xea\x63\x18\xc2\x32\xb5\xdd\x99\x66\x79\x77\xe5\xda\x49\x14\x9a\x63\x4c\x12\x53\xb2\xc4\x8e\x7e\x95\xc4\x09\xfd\x2d\x31\xd7\x4a\xb3\x44\x9b\xe3\x5a\xe2\x71\x1d\x79\x57\x16\x73\xb2\x10\x7e\xf4\x56\xfa\xbd\xd7\x4b\xc6\xa8\xb6\xb2\x4f\x68\x93\x0c\xed\xe9\xd5\x45\x27\x7a\x96\x9c\xea\x82\x26\x46\xb3\x78\xb5\xb2\x96\xf3\x6f\xb9\x61\x45\x4e\x36\x5f\xb8\x36\x74\x84\x8f\xf5\xc8\x15\x2e\x0e\xb4\xfe\xc9\x0f\xb7\x9d\x00\x2d\xc5\x62\xec\xb4\x62\xb2\xfb\xae\x4f\x68\x29\x5f\xbf\x45\xc1\x4e\xb5\xb5\xa3\xa6\xc2\x0b\x5b\x7e\xca\xa4\xc8\x46\x2d\x32\x44\x17\xb4\x69\x21\x08\xc2\x10\x6e\x07\x93\xb0\x77\x01\xb5\x9d\x14\x16\xb8\xca\x0d\xa6\xe0\xc4\xfe\xa4\x95\x83\x04\x19\xe6\x04\x97\xfd\x6b\xf0\xc3\xc7\x44\xbc\x67\x3f\xdd\x73\x70\x44\xbe\x40\x13\xfa\xb6\x40\xcd\x5d\x65\x3e\x14\xb8\x1c\x71\x62\x52\x4a\x1f\xf5\x6f\xea\xc2\x4d\x29\x50\xd1\x49\x9e\x43\xe9\xa8\xee\x0d\x32\xb2\x74\x0e\xce\x40\x45\x90\x1a\x3e\x11\x60\xa2\x14\xc4\xf8\x72\x98\xfe\x28\x9d\x80\x16\x38\xa3\x27\xb2\x80\x0a\x35\x9f\x75\xf7\xb6\xb6\x19\x62\x00\xd0\xfc\xbe\xbd\x1f\xcf\x3e\xdf\x35\xcd\x58\x98\x7e\x99\x8c\x6a\xc5\xa6\xd3\xed\x9e\x0d\x4d\xc5\xff\xbf\xaa\xe1\xc3\xfd\x64\x77\xd5\xa2\x96\x49\xfb\x08\x3a\x26\xd7\x6f\xc1\x51\xf9\xcf\xf4\xb6\x2d\xb3\xc3\xe8\x21\x14\xeb\xc8\x17\x22\x07\x18\xdc\x0d\xf5\x10\x36\x77\x52\x91\x47\x8c\x8d\x80\x25\xef\x85\x40\x32\x02\x63\x3d\xa0\x98\x83\xac\x16\x04\xe6\xbb\xdf\xfd\x95\x66\x75\xda\xef\x75\x60\x4e\x09\xd6\x28\x79\x8c\xa0\xd0\x2a\x13\x8f\xa8\x33\x45\x8d\xa3\x35\x95\x83\x4a\x4b\x06\x9b\x0d\x93\x0c\x05\xd0\x97\x36\xa6\x46\xf7\xf5\x30\x6d\x77\x60\xeb\xe8\xb2\x7f\xdd\x89\x82\x3f\x01\x00\x00\xff\xff\xdf\x89\xd7\x33\xc6\x05\x00\x00")
+var _migrations54_tx_preconditions_and_account_fieldsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x93\x4f\x4f\xdb\x40\x10\xc5\xef\xfe\x14\xef\xc6\x9f\xe2\x14\x28\x44\x55\x2d\x55\x0a\x24\x87\x4a\x14\x22\x08\x87\xaa\xaa\xa2\x89\x3d\x5d\x6f\x1b\xcf\xa6\xbb\x63\x92\xf4\xd3\x57\xeb\x24\x50\x28\xa8\x16\x49\x2e\x71\xbc\x33\xef\xcd\xdb\xdf\xa4\x29\xde\x54\xd6\x78\x52\xc6\xed\x2c\xe9\x5d\x8c\x06\xd7\x18\xf5\xce\x2e\x06\x28\x6d\x50\xe7\x97\x63\xf5\x24\x81\x72\xb5\x4e\x02\x7a\xfd\x3e\xa6\x5c\x18\xf6\xe3\x89\xab\xa5\x08\xf8\xf7\x63\x45\xdf\x7b\x12\xc3\x59\x7c\x42\x9a\x62\x51\xf8\xce\xad\x15\x7d\x77\x1c\xda\x49\x54\x56\xc6\x94\xe7\xae\x16\x1d\x07\xfe\x55\xb3\xe4\xbc\x69\x1f\xbf\x13\x6b\xac\x68\xb6\x79\x5c\x4b\xdc\xac\x4f\x5e\xd6\xd5\x84\x3d\xd2\x8f\xb0\xa2\xdd\x93\xd7\x4b\x8e\xc9\xdc\xcb\xde\x91\xcf\x4b\xf2\xbb\xc7\x87\x7b\xd9\x83\xe4\xc8\x56\x3c\x74\x56\x34\xaa\xd5\x8d\x5c\xfc\x35\x75\x62\x38\xe8\xe6\x1f\x69\x0c\x6d\xe1\x63\x1d\xb9\xa1\xd9\x0b\xa3\x7f\x8a\xe1\xb6\x13\xe0\x85\x7a\x1a\x07\x6b\x84\xfd\x73\xd7\xa7\xbc\xd0\xaf\xdf\xb2\xe4\x51\xb7\xb5\xa3\x55\x87\x27\xb6\x62\xca\x6c\xd8\x67\x2d\x2a\xd4\x56\xbc\x19\x21\x49\xd2\x14\xe7\xbd\x61\x7a\x72\x88\xc6\x4e\x81\x19\x2d\xa7\x8e\x0a\x04\xf5\x3f\x79\x19\x90\x93\x60\xc2\x38\xea\x9e\x22\x86\x4f\xb9\x46\xcf\x31\xdd\x03\x04\xe6\xd8\x60\x75\xf4\x6d\x45\x56\x3a\xc6\x7d\xa8\x68\x31\x90\xdc\x15\x5c\xdc\xd8\xdf\xdc\xc1\x59\xad\x98\xf3\xce\x74\x8a\x3a\x70\x33\x1b\x4a\xf6\x7c\x80\xe0\x30\x67\x14\x4e\x76\x14\xc2\x5c\x40\x5d\x6c\x47\xc5\x8f\x3a\x28\xac\x62\x9f\xef\xd8\x83\x0c\x59\xd9\xef\x3c\x3b\xda\x26\xc4\x04\x58\xbd\x3e\xbf\xba\xb8\xfd\x7c\xb9\x1a\xc6\x63\xf4\x65\x38\x68\x14\xb3\x24\xf9\x7b\xcf\xfa\x6e\x2e\xff\xbf\xaa\xfe\xf5\xd5\xf0\xf1\xaa\x65\x2d\x8b\x9e\x23\x68\x9b\xda\xb8\x05\x5b\xd5\x3f\xd0\xdb\xb6\xcd\x23\x46\x5f\x42\xb1\x49\xe8\x89\x48\xd6\xe6\x68\x84\x70\x75\x27\x73\x8e\x88\x89\x53\x78\x8e\x5e\x18\x5a\x32\x9c\x8f\x80\xd2\x14\xba\x9c\x31\xdc\x77\xdc\x91\x5f\x5a\x31\xbb\xdd\x93\x3d\x4c\x38\xa7\x06\xa5\x88\x11\x2a\x6b\x4a\x8d\x88\x06\x57\x35\x38\x7a\x37\x0f\x98\x5b\x2d\xd7\x14\x04\x68\x49\x0a\xf2\x0c\x75\xae\x41\xf7\xf5\x30\xdd\xef\xc0\xbd\xa3\xa3\xee\xe9\x5e\x96\xfc\x19\x00\xdf\x89\xd7\x33\xc6\x05\x00\x00")
 
 func migrations54_tx_preconditions_and_account_fieldsSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -1137,7 +1138,7 @@ func migrations54_tx_preconditions_and_account_fieldsSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations55_filter_rulesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc4\x92\x31\x8f\xd3\x40\x10\x85\x7b\xff\x8a\xe9\x2e\x11\x31\xa2\x40\x34\xa9\x02\x31\x52\x24\xcb\x81\x9c\x0d\x05\x42\xd6\xd8\x3b\x3e\x8f\xb4\xde\xb5\x76\xc6\x5c\x00\xf1\xdf\x51\xce\x77\xce\x99\x04\x5a\xb6\xfe\xde\xdb\xe7\xcf\x1b\xc7\xf0\xa2\xe3\xbb\x80\x4a\x50\xf4\x51\xf4\xee\x90\x6c\xf2\x04\xf2\xcd\xdb\x34\x01\xac\x6b\x3f\x38\x2d\x1b\xb6\x4a\xa1\x0c\x83\x25\x81\x45\x04\x00\x40\x0e\x2b\x4b\x06\x2a\xef\x2d\x64\xfb\x1c\xb2\x22\x4d\xc1\x50\x83\x83\x55\x68\xd0\x0a\xad\x1e\xc0\xfb\x96\x95\x2c\x8b\xc2\x37\x0c\x75\x8b\xe1\xcb\xd7\x89\x1f\x09\x8b\xa2\x65\xe7\x0d\x37\x7c\x2a\xe4\x3b\x76\x3a\x21\xd1\x72\xfd\xe7\x28\x11\xfa\xff\x93\xe2\x18\xd8\x09\x05\x05\x6d\x69\xba\xc4\xb0\x8c\x1b\x44\x4f\x42\x1b\x1f\x80\xb0\x6e\x41\x86\xbe\xf7\x41\xc9\xc0\xb8\x1b\xb8\xeb\x2d\x75\xe4\x14\x95\xbd\x8b\x76\xd9\x6d\x72\xc8\x61\x97\xe5\xfb\xeb\xd2\x3f\x6d\xd2\x22\xb9\x85\xc5\xf8\x15\x70\xf3\xf3\xd7\xcd\x0a\x5e\x2d\xd7\xf3\xe4\xa5\x99\xbf\xe6\xe6\x4e\xf5\x28\x43\x55\x06\x92\xc1\xea\x93\x4e\x0d\xe8\x04\xeb\xd3\xbe\xb2\x45\x69\x61\x3c\x8f\xca\x16\x6f\x5e\x2f\xcf\x92\x8b\x6c\xf7\xb1\x48\x46\x77\xec\x1c\x85\xf2\x22\xfd\x2c\x37\x72\x7a\x7c\xbc\x10\x66\x47\xe9\xa8\x2b\x88\x63\x10\x0a\x8c\x96\x7f\x90\x81\x96\x45\x7d\xf8\xfe\x32\x3f\x97\x3e\x54\xc8\x50\x75\xac\x4a\xa6\xc4\xe7\x2d\xca\x1d\x89\x62\xd7\x9f\x07\x6e\x93\xf7\x9b\x22\xcd\x21\xdb\x7f\x5e\x2c\x9f\x7e\xe0\xf4\xf0\xb7\xfe\xde\x45\xd1\xf6\xb0\xff\xf0\xaf\x87\x5f\xa3\xd4\x68\x68\x3d\x03\x2f\x95\x5f\xc3\xe6\x7e\x27\xe2\x77\x00\x00\x00\xff\xff\xa6\x50\xb1\x3f\x7d\x03\x00\x00")
+var _migrations55_filter_rulesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc4\x92\x41\x8f\xd3\x30\x10\x85\xef\xf9\x15\x73\xdb\x56\x34\x88\x03\xe2\xd2\x53\xa1\x41\xaa\x14\xa5\xd0\x4d\xe0\x80\x50\x34\xb5\x27\x9b\x91\x1c\x3b\xf2\x4c\xd8\x02\xe2\xbf\xa3\x6e\xda\x2c\xa5\x0b\xd7\x55\x6e\xd1\xf7\x9e\x9f\x3e\x3b\x4d\xe1\x45\xc7\x77\x11\x95\xa0\xea\x93\xe4\xdd\x2e\x5b\x95\x19\x94\xab\xb7\x79\x06\x68\x4c\x18\xbc\xd6\x0d\x3b\xa5\x58\xc7\xc1\x91\xc0\x2c\x01\x00\x20\x8f\x7b\x47\x16\xf6\x21\x38\x28\xb6\x25\x14\x55\x9e\x83\xa5\x06\x07\xa7\xd0\xa0\x13\x5a\x3c\x80\xf7\x2d\x2b\x39\x16\x85\x6f\x18\x4d\x8b\xf1\xcb\xd7\x89\x1f\x09\x87\xa2\x75\x17\x2c\x37\x7c\x2c\xe4\x3b\xf6\x3a\x21\xc9\x7c\xf9\xf7\x28\x11\x7a\xfe\x49\x69\x0a\xec\x85\xa2\x82\xb6\x34\x1d\x62\x59\x46\x2d\xa2\x47\xa1\x4d\x88\x40\x68\x5a\x90\xa1\xef\x43\x54\xb2\x30\xee\x06\xee\x7a\x47\x1d\x79\x45\xe5\xe0\x93\x4d\x71\x9b\xed\x4a\xd8\x14\xe5\xf6\x69\xe9\x9f\x56\x79\x95\xdd\xc2\x6c\x14\x0b\x37\x3f\x7f\xdd\x2c\xe0\xd5\x7c\x79\x99\xbc\x36\xf3\xcf\xdc\xa5\x53\x3d\xc8\xb0\xaf\x23\xc9\xe0\xf4\xac\x53\x23\x7a\x41\x73\xdc\x57\xb7\x28\xed\xf1\x1f\xc0\x59\xd9\xec\xcd\xeb\xf9\x64\x04\xaa\x62\xf3\xb1\xca\x46\x77\xec\x3d\xc5\xfa\x2a\xfd\x47\x6e\xe4\xf4\x70\x3a\xf0\x54\x7c\xfa\x94\x0e\xba\x80\x34\x05\xa1\xc8\xe8\xf8\x07\x59\x68\x59\x34\xc4\xef\x2f\xcb\xc7\xd2\x87\x0a\x19\xf6\x1d\xab\x92\xad\x51\xa7\x02\x00\xe5\x8e\x44\xb1\xeb\x1f\x07\xae\xb3\xf7\xab\x2a\x2f\xa1\xd8\x7e\x9e\xcd\xcf\x17\x38\x3d\xfc\x75\xb8\xf7\x49\xb2\xde\x6d\x3f\xfc\xef\xe1\x1b\x14\x83\x96\x96\x17\xe0\xb5\xf2\xa7\xb0\x4b\xbf\x06\xc5\xa0\xa5\x65\xf2\x7b\x00\xa6\x50\xb1\x3f\x7d\x03\x00\x00")
 
 func migrations55_filter_rulesSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -1157,7 +1158,7 @@ func migrations55_filter_rulesSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations56_txsub_read_onlySql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\x92\x41\x73\x9b\x30\x10\x85\xef\xfa\x15\xef\x68\xb7\x86\x53\xa7\x17\x9f\x68\x4c\xa6\x99\xa1\x76\x6b\xc3\xe4\xc8\xc8\x68\x63\x69\x0a\x12\x91\x56\xb5\xe9\xaf\xef\x60\x92\xc6\x6e\x33\xe3\x3d\x70\x61\xdf\xdb\xef\xf1\x48\x12\x7c\xec\xcc\xc1\x4b\x26\x54\xbd\x10\xab\xed\xe6\x3b\xca\xec\x4b\x91\x83\x4f\x21\xee\x6b\x4f\x21\xb6\x1c\x96\x42\x24\x09\x62\x20\x05\x76\x60\xea\x7a\xe7\xa5\x37\xed\x80\xc0\xce\x13\x9e\x4c\xcb\xe4\x49\x25\x2e\x32\xd8\x4b\x1b\x64\xc3\xc6\xd9\x30\xca\x2c\x91\x22\x85\xfd\x00\xd6\x74\xf9\x16\x61\x08\x4c\xdd\xb8\x83\xf1\x21\xed\x80\xa7\xc8\xd1\x13\x26\xa8\xd1\x01\xac\x25\xe3\xe8\x62\xab\xd0\x68\x69\x0f\x04\x6d\xc6\xab\x43\xfd\xef\x21\x4f\xcf\xd1\x78\x82\xf2\xae\xef\x8d\x3d\xbc\xbb\x58\xbf\xb2\xd6\xdc\xf5\x0b\x48\xab\xe0\xc9\x47\x6b\x47\x01\x6b\x13\x70\xb7\xcd\xb3\x32\x4f\xd3\x5d\x5e\xe4\x77\x65\x9a\x66\xbb\xd1\x9c\x1d\x7e\x12\xf5\x53\x86\xae\x07\xcb\x7d\x4b\x30\x63\x08\xdb\xe0\x68\x58\x23\x34\x9a\x3a\x99\x8a\xc9\xe0\xe5\x3b\xde\x64\x40\xb6\x83\x00\x02\xb5\xd4\x30\x3e\xe0\x7e\xbb\xf9\xf6\x7e\x44\xe0\xf1\x6b\xbe\xcd\xd1\x92\x3a\x90\xaf\x03\x3d\x47\xb2\x0d\xe1\x61\x87\x75\x55\x14\x53\x49\x7f\xfb\x5c\xb9\xa3\xbd\x6a\xf4\x26\xc9\x52\x5c\xa3\x5f\xfd\x02\x98\x09\x00\x97\xf5\xd5\x5a\x06\x8d\x69\x7e\x49\xdf\x68\xe9\x67\x9f\x3f\xcd\xb1\xde\x94\x67\x1e\x54\xeb\x87\x1f\x55\xbe\x38\xeb\x8c\xb5\xe4\xeb\xff\xd4\x17\xba\x69\x8f\x4f\x2f\x07\x71\x35\x4c\x27\x5e\x20\x49\x10\xc8\x1b\xd9\x9a\xdf\xa4\x5e\xf3\xa4\xe5\x9b\xe9\xd9\x22\xc4\x7d\x67\x98\x49\xd5\xf2\xd2\x85\x4d\x47\x81\x65\xd7\xbf\x01\xae\xf2\xfb\xac\x2a\x4a\xac\x37\x8f\xb3\xb9\x98\x2f\xc5\x9f\x00\x00\x00\xff\xff\xbf\xcf\x66\xb5\x10\x03\x00\x00")
+var _migrations56_txsub_read_onlySql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\x92\x41\x8f\x9b\x30\x14\x84\xef\xfe\x15\x73\x4c\xda\xc0\xa9\xea\x25\x27\xba\x61\xd5\x95\x68\xd2\x26\xa0\x3d\x22\x07\xbf\x8d\xad\x82\xcd\xda\xcf\x4d\xe8\xaf\xaf\x08\xd9\xdd\xa4\x5a\x69\x85\xc4\x01\xde\xcc\x7c\x7e\xe3\x24\xc1\xe7\xce\x1c\xbc\x64\x42\xd5\x0b\xb1\xda\x6e\x7e\xa2\xcc\xbe\x15\x39\xf8\x14\xe2\xbe\xf6\x14\x62\xcb\x61\x29\x44\x92\x20\x06\x52\x60\x07\xa6\xae\x77\x5e\x7a\xd3\x0e\x08\xec\x3c\xe1\xc9\xb4\x4c\x9e\x54\xe2\x22\x83\xbd\xb4\x41\x36\x6c\x9c\x0d\xa3\xcc\x12\x29\x52\xd8\x0f\x60\x4d\xd7\x7f\x11\x86\xc0\xd4\x8d\x33\x18\x5f\xd2\x0e\x78\x8a\x1c\x3d\x61\x82\x1a\x1d\xc0\x5a\x32\x8e\x2e\xb6\x0a\x8d\x96\xf6\x40\xd0\x66\x4c\x1d\xea\xff\x83\x3c\x3d\x47\xe3\x09\xca\xbb\xbe\x37\xf6\xf0\xee\x60\xfd\xc2\x5a\x73\xd7\x2f\x20\xad\x82\x27\x1f\xad\x1d\x05\xac\x4d\xc0\xdd\x36\xcf\xca\x3c\x4d\x77\x79\x91\xdf\x95\x69\x9a\xed\x46\x73\x76\xf8\x4d\xd4\x4f\x67\xe8\x7a\xb0\xdc\xb7\x04\x63\x11\x06\xdb\xe0\x68\x58\x23\x34\x9a\x3a\x99\x8a\xc9\xe0\xb2\xc7\x0f\x19\x90\xed\x20\x80\x40\x2d\x35\x8c\x4f\xb8\xdf\x6e\x7e\xbc\x7f\x44\xe0\xf1\x7b\xbe\xcd\xd1\x92\x3a\x90\xaf\x03\x3d\x47\xb2\x0d\xe1\x61\x87\x75\x55\x14\x53\x49\xaf\x7d\xae\xdc\xd1\xde\x34\xfa\x21\xc9\x52\xdc\xa2\xdf\x5c\x01\xcc\x04\x80\xeb\xfa\x6a\x2d\x83\x1e\xbf\x01\xf8\x23\x7d\xa3\xa5\x9f\x7d\xfd\x32\xc7\x7a\x53\x9e\x79\x50\xad\x1f\x7e\x55\xf9\xe2\xac\x33\xd6\x92\xbf\x8e\x9e\xd4\x57\xba\x69\x8e\x4f\x97\xc0\x8b\xf1\xe5\x61\x3a\xf1\x02\x49\x82\x40\xde\xc8\xd6\xfc\x25\xf5\xb2\xa3\xb4\x7c\x33\x3d\x5b\x84\xb8\xef\x0c\x33\xa9\x5a\xf2\xab\x01\xc0\xa6\xa3\xc0\xb2\xeb\xdf\x00\x57\xf9\x7d\x56\x15\x25\xd6\x9b\xc7\xd9\x5c\xcc\x97\xe2\xdf\x00\xbf\xcf\x66\xb5\x10\x03\x00\x00")
 
 func migrations56_txsub_read_onlySqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -1177,7 +1178,7 @@ func migrations56_txsub_read_onlySql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations57_trade_aggregation_autovacSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd2\xd5\x55\xd0\xce\xcd\x4c\x2f\x4a\x2c\x49\x55\x08\x2d\xe0\xe2\x4a\xcc\x29\x49\x2d\x52\x28\x49\x4c\xca\x49\x55\xc8\xc8\x2c\x2e\xc9\x2f\xaa\x8c\x2f\x29\x4a\x4c\x49\x2d\x8e\x37\x33\x30\x30\x30\x50\x28\x4e\x2d\x51\xd0\xe0\x52\x50\x48\x2c\x2d\xc9\x2f\x4b\x4c\x2e\x2d\xcd\x8d\x87\x52\xc5\xc9\x89\x39\xa9\xf1\x69\x89\xc9\x25\xf9\x45\x0a\xb6\x0a\x06\x7a\x06\xa6\x3a\xa8\x0a\x13\xf3\x12\x73\x2a\xab\x52\xb1\xa8\x34\x32\xe5\xd2\xb4\xe6\xe2\x42\x76\x8d\x4b\x7e\x79\x1e\x75\xdd\x63\x48\xac\x73\xc0\x8e\x01\x04\x00\x00\xff\xff\x9e\xb7\x27\x2f\x1a\x01\x00\x00")
+var _migrations57_trade_aggregation_autovacSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\xce\xa1\x0e\xc2\x30\x10\x87\x71\x7f\x4f\x71\x92\x05\x46\x0e\x92\x62\x16\x1c\xaf\x80\x6e\xfe\x94\x03\x96\x74\x2b\x69\xaf\x90\xf1\xf4\x18\x04\x24\x08\x04\xea\x33\x9f\xf8\xb5\x2d\xcf\x87\xfe\x9c\x61\xca\xfb\x2b\x11\xa2\x69\x66\xc3\x21\x2a\x5f\xfa\x62\x29\x4f\xde\x32\x8e\x5a\xfc\x46\x44\x84\x8b\x1a\xcf\x88\x19\xd5\xd2\x0d\xa1\xd6\xc1\xbf\x52\x02\xa2\xfa\x13\x82\xa5\xcc\x5b\x96\xa5\xb8\xc5\xe7\x88\x11\x71\x7a\xe8\x97\x73\xed\xa8\xe9\x88\xde\x35\xbb\x74\x1f\xff\xeb\x59\xfd\xca\x71\xd4\x74\xf4\x1c\x00\x9e\xb7\x27\x2f\x1a\x01\x00\x00")
 
 func migrations57_trade_aggregation_autovacSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -1197,7 +1198,7 @@ func migrations57_trade_aggregation_autovacSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations58_add_index_by_id_optimizationSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x92\xbd\x6e\x83\x30\x14\x85\x77\x3f\xc5\x15\x53\xab\x8a\x27\x60\xaa\x0a\xaa\x58\xa0\xa2\x45\xea\x76\x75\xc1\x56\x7b\x25\x63\x13\xe3\x28\xe1\xed\x33\x45\xf9\x37\x4e\x94\xfd\x3b\xf6\xe7\xe3\x93\xa6\xf0\x36\xf0\x9f\x23\xaf\xa0\x1d\x85\xf8\x68\x8a\xf7\x9f\x02\xca\x2a\x2f\x7e\x21\x61\x23\xd5\x16\xff\x79\xf2\xd6\xcd\x68\x47\xe5\xc8\xb3\x35\xd8\x6b\xe2\x81\x3a\xad\xb0\x23\x4d\xa6\x57\x13\x5a\x83\x2c\x13\xa8\x2b\x88\xc1\xa1\xfd\x2e\xab\x4f\xe8\xbc\x53\x0a\x5e\xf6\x89\x0b\x0e\x59\xbe\x66\x41\x27\xef\xc8\x4c\xd4\xc7\x5b\x85\x03\x4f\xf3\x3a\x3c\x5e\xf3\x6a\xcd\x92\xfd\x8c\xa3\xb5\x3a\x5c\xd4\x19\x7b\xdd\xe6\x14\xba\xaf\xa2\x65\x99\x00\x1d\xaf\x23\x8e\x67\x95\xdb\x8d\x11\x22\x6f\xea\xaf\x87\x67\x95\x05\xe2\x51\x0b\x08\x1d\xb0\xf4\x55\xb1\x97\xdf\x4a\x8b\x5d\x00\x00\x00\xff\xff\x15\x49\x6f\x54\x64\x03\x00\x00")
+var _migrations58_add_index_by_id_optimizationSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x92\xc1\x4e\x84\x30\x10\x86\xef\x7d\x8a\x09\x27\x8d\xe1\x09\x38\x19\x21\x86\x0b\x18\x94\xc4\xdb\x64\xa0\x8d\x4e\x52\x5a\x2c\x35\xca\xdb\x7b\x71\xb3\xec\xb2\x14\xd8\xec\xfd\xfb\xdb\x6f\xfe\x99\x38\x86\x87\x8e\x3f\x1c\x79\x05\x75\x2f\xc4\x53\x95\x3d\xbe\x65\x90\x17\x69\xf6\x0e\x11\x1b\xa9\x7e\xf1\x93\x07\x6f\xdd\x88\xb6\x57\x8e\x3c\x5b\x83\xad\x26\xee\xa8\xd1\x0a\x1b\xd2\x64\x5a\x35\xa0\x35\xc8\x32\x82\xb2\x80\x2d\x38\xd4\xaf\x79\xf1\x0c\x8d\x77\x4a\xc1\xdd\x21\x31\xe3\x90\xe5\x7d\x12\x74\xf2\x8e\xcc\x40\xed\x76\xab\x70\xe0\x66\x5e\xc7\xe1\x35\x7f\x7d\xb3\x64\x3f\x62\x6f\xad\x0e\x17\x75\xc6\x5e\xb6\x39\x85\xf6\x55\xb4\x2e\x13\xa0\xb7\xeb\x88\xe9\x59\xa5\xf6\xc7\x08\x91\x56\xe5\xcb\x5a\x55\xf3\x7d\xfc\x0b\x26\x81\xf8\x54\xf8\xaa\x07\x16\xeb\xdf\xf9\xf9\x52\x5a\xfc\x0d\x00\x15\x49\x6f\x54\x64\x03\x00\x00")
 
 func migrations58_add_index_by_id_optimizationSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -1217,7 +1218,7 @@ func migrations58_add_index_by_id_optimizationSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations59_remove_foreign_key_constraintsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x92\xd1\x4a\xc3\x30\x18\x85\xef\xf3\x14\xe7\x72\x43\xf6\x04\xbb\x8a\xcb\x3f\x19\x96\x44\xb2\x78\xb1\xab\x10\xd7\xa8\x41\x5c\x25\x89\xc8\xde\x5e\x94\x52\xda\xda\xa2\x42\xd8\xf5\x39\x1c\xbe\x7c\xf9\x57\x2b\x5c\xbd\x86\xa7\xe8\xb2\xc7\xfd\x1b\x63\xbc\x32\xa4\x61\xf8\x75\x45\x50\xb2\x3a\xe0\x39\xa4\xdc\xc4\xb3\xcd\xd1\xd5\x3e\x41\x68\x75\x87\x8d\x92\x7b\xa3\xf9\x4e\x9a\x51\x6c\x1f\x5c\xf2\xd6\x1d\x8f\xcd\xfb\x29\xdb\x50\xdb\xc7\x17\x7f\x5e\x97\x19\x4d\xc9\x97\x9b\xfc\x06\xf4\xb1\x38\x6a\xb7\x3b\xa4\x65\x7d\xcd\xa2\xf9\x38\xfd\x2a\x9a\x01\x00\x17\xe2\x9f\xae\xb1\x55\x9a\x76\x37\x12\xb7\x74\xc0\x62\xd4\x58\x42\xd3\x96\x34\xc9\x0d\xed\xbb\xb1\x36\x4e\x8b\x50\x2f\xd7\xe5\xb0\xfa\xef\x9f\x82\x6a\xf3\x69\xa4\xaf\xb0\x1c\xd0\xcc\x5f\x0f\xa9\x7e\x96\x2e\x63\x6b\xf2\x60\x66\xd0\xfe\xe8\x0c\xec\x33\x00\x00\xff\xff\xd7\x39\x62\x6b\xd5\x03\x00\x00")
+var _migrations59_remove_foreign_key_constraintsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x91\xd1\x4a\xc3\x30\x18\x85\xef\xf3\x14\xe7\x72\x43\xf6\x04\xbb\x8a\xcb\x3f\x19\x96\x44\xb2\x78\xb1\xab\x10\xd7\xa8\x41\x5c\x25\x89\xc8\xde\x5e\x94\x51\xda\xda\x52\x84\xb0\xeb\x73\x38\xf9\xf2\xfd\xab\x15\x6e\xde\xc3\x4b\x74\xd9\xe3\xf1\x83\x31\x5e\x19\xd2\x30\xfc\xb6\x22\x28\x59\x1d\xf0\x1a\x52\x6e\xe2\xd9\xe6\xe8\x6a\x9f\x20\xb4\x7a\xc0\x46\xc9\xbd\xd1\x7c\x27\xcd\x20\xb6\x4f\x2e\x79\xeb\x8e\xc7\xe6\xf3\x94\x6d\xa8\xed\xf3\x9b\x3f\xaf\xcb\x8c\xa6\xe4\xcb\x4d\xfe\x02\xfa\x58\x1c\xb5\xdd\xed\xd3\xb2\xae\x66\xd1\x7c\x9d\x66\x45\x33\x00\xe0\x42\xfc\xd3\x35\xb6\x4a\xd3\xee\x4e\xe2\x9e\x0e\x58\x0c\x1a\x4b\x68\xda\x92\x26\xb9\xa1\x7d\x3b\x76\x89\xd3\x22\xd4\xcb\x75\x39\xac\xee\xff\xc7\xa0\x2e\xf9\x38\xd2\x4f\x58\x0e\x68\xe2\xd6\x7d\xaa\xbf\xa5\xeb\xd8\x6a\xdf\x9d\x16\x36\xac\xcc\x39\x03\xfb\x1e\x00\xd7\x39\x62\x6b\xd5\x03\x00\x00")
 
 func migrations59_remove_foreign_key_constraintsSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -1237,7 +1238,7 @@ func migrations59_remove_foreign_key_constraintsSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations5_create_trades_tableSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x94\x51\x6f\xaa\x40\x10\x85\xdf\xf9\x15\x13\x9f\x30\x17\x93\x7b\x6f\x5a\x5f\x4c\x9a\x58\x25\xad\xa9\xc1\xd6\x4a\xd2\x37\xb2\xb0\x23\x6c\xa2\x2c\x99\x1d\xda\xf0\xef\x1b\x68\x69\x10\x57\xad\xaf\x9c\x39\x67\x38\xbb\x5f\x76\x34\x82\x3f\x7b\x95\x92\x60\x84\xb0\x70\x66\x6b\x7f\xba\xf1\x61\x33\xbd\x5f\xfa\x90\x29\xc3\x9a\xaa\x88\x49\x48\x34\xe0\x3a\x00\xf0\xf3\x51\x17\x48\x82\x95\xce\x23\x25\x21\x56\xa9\xca\x19\x82\xd5\x06\x82\x70\xb9\xf4\x9a\xc9\x81\x26\x89\x34\x00\x95\x33\xa6\x48\x1d\xb5\x91\xf5\x76\x8b\x64\x35\x37\xb2\xc1\xdd\xee\x84\x5e\xcb\x71\x59\x9d\x75\xeb\x9d\x8c\x84\x31\xc8\x11\x57\x05\x42\x92\x09\x12\x09\x23\xc1\xbb\xa0\x4a\xe5\xa9\x3b\xbe\x19\xf6\x22\x3b\x1e\x65\x4c\x89\x64\x71\xdd\x8e\xcf\xb8\x12\x2d\x6d\x9b\xfe\xfd\xb7\x7b\xf6\xba\xcc\xb9\xff\xff\x30\x7b\xf4\x67\x4f\xe0\x76\x47\xee\xe0\xef\xf0\xbb\x57\xac\xcb\x34\xe3\x6b\x9b\x1d\xb8\xae\xe8\x76\xe0\xfb\x75\xbb\xd6\x75\xb6\xdf\xe1\x50\xdd\xd0\x19\x4e\x9c\x96\xbf\x30\x58\xbc\x84\x3e\x2c\x82\xb9\xff\x06\x19\x93\x8c\x0a\x25\x61\x15\xf4\x91\x0c\x5f\x17\xc1\x03\xc4\x4c\x88\xe0\xda\xc8\xf4\x5a\x0a\x3b\xe1\x9d\xd4\xb8\x8a\x1a\x0c\x2f\x45\xb7\xac\xda\x52\xea\x90\xfa\xb6\x2e\x65\xf4\x90\xf4\xfa\xe4\x78\xc7\x00\x9e\x5a\xf7\x75\x78\x97\x16\x1e\xb1\xe2\x1d\x5f\xa8\x67\x63\xa3\x5e\xdb\x7d\x17\xe6\xfa\x23\x77\xe6\xeb\xd5\xb3\xfd\x5d\x48\x84\x49\x84\xc4\x89\xf3\x19\x00\x00\xff\xff\x79\x87\x24\x6b\x4c\x04\x00\x00")
+var _migrations5_create_trades_tableSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x94\x51\x4f\xbb\x30\x14\xc5\xdf\xf9\x14\x37\x7b\x62\xf9\xb3\xe4\xaf\xd1\xbd\x2c\x31\x99\x1b\xd1\xc5\x85\xe9\x1c\x89\x6f\x4d\xa1\x77\xd0\x84\x51\x72\x5b\x34\x7c\x7b\x33\x26\x0a\xd8\x6d\xee\xb5\xe7\x9e\x73\x7b\xe0\x97\x8e\x46\xf0\x6f\x27\x13\xe2\x06\x21\x2c\x9c\xd9\xda\x9f\x6e\x7c\xd8\x4c\xef\x97\x3e\xa4\x52\x1b\x45\x15\x33\xc4\x05\x6a\x70\x1d\x00\xf8\x3e\x54\x05\x12\x37\x52\xe5\x4c\x0a\x88\x64\x22\x73\x03\xc1\x6a\x03\x41\xb8\x5c\x7a\xf5\xe4\x40\x91\x40\x1a\x80\xcc\x0d\x26\x48\x2d\xb5\x96\xd5\x76\x8b\x64\x35\xd7\xb2\xc6\x2c\x3b\xa2\xef\xe5\xa8\xac\x4e\xba\x55\x26\x18\xd7\x1a\x0d\x33\x55\x81\x10\xa7\x9c\x78\x6c\x90\xe0\x9d\x53\x25\xf3\xc4\x1d\xdf\x0c\x7b\x91\x2d\x8f\xd4\xba\x44\xb2\xb8\x6e\xc7\x27\x5c\xb1\x12\xb6\x4d\x57\xd7\x76\xcf\x4e\x95\xb9\xe9\xdf\x1f\x66\x8f\xfe\xec\x09\xdc\xf6\xc8\x1d\xfc\x1f\x7e\xf5\x8a\x54\x99\xa4\xe6\xd2\x66\x1d\xd7\x05\xdd\x3a\xbe\x3f\xb7\x6b\x5c\x27\xfb\x75\x87\xf6\x0d\x9d\xe1\xc4\x69\xf8\x0b\x83\xc5\x4b\xe8\xc3\x22\x98\xfb\x6f\x90\x1a\x12\xac\x90\x02\x56\x41\x1f\xc9\xf0\x75\x11\x3c\x40\x64\x08\x11\x5c\x1b\x99\x5e\x43\x61\x2b\xbc\x95\x1a\x55\xac\xc6\xf0\x5c\x74\xc3\xaa\x2d\x85\x45\x15\xd3\x2a\x3b\x7b\xbd\x16\x28\x7b\x24\xbd\x3e\x39\x9d\x83\xc3\x4f\x3a\xb6\xee\xf0\xf1\xce\x2d\xfc\xc5\x8a\xd7\x05\x21\x56\xa2\x7f\xf4\xb3\xb6\xfd\x2e\xcc\xd5\x47\xee\xcc\xd7\xab\x67\xfb\xbb\x10\x73\x1d\x73\x81\x13\xe7\x73\x00\x79\x87\x24\x6b\x4c\x04\x00\x00")
 
 func migrations5_create_trades_tableSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -1257,7 +1258,7 @@ func migrations5_create_trades_tableSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations60_add_asset_id_indexesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd2\xd5\x55\xd0\xce\xcd\x4c\x2f\x4a\x2c\x49\x55\x08\x2d\xe0\xe2\x72\x0e\x72\x75\x0c\x71\x55\xf0\xf4\x73\x71\x8d\x50\x50\xca\x28\x29\x4a\x89\x4f\xaa\x8c\x4f\xce\x2f\xcd\x2b\x49\x2d\x8a\x4f\x2c\x2e\x4e\x2d\x51\x52\xf0\xf7\x53\xc8\xc8\x2c\x2e\xc9\x2f\xaa\x8c\x2f\x29\x4a\x4c\x49\x2d\x56\x08\x0d\xf6\xf4\x73\x57\x48\x2a\x29\x4a\x4d\x55\xd0\x40\x51\x1d\x9f\x99\xa2\x69\x8d\xcd\xd8\xc4\xf4\x74\x82\xe6\xc6\x9b\x19\x18\x18\x18\x10\x34\x9d\x0b\xd9\x17\x2e\xf9\xe5\x79\x5c\x5c\x2e\x41\xfe\x01\xf8\x7d\x61\x8d\xa9\x06\xd3\x49\xd6\x5c\x80\x00\x00\x00\xff\xff\xda\xc9\x55\x1a\x21\x01\x00\x00")
+var _migrations60_add_asset_id_indexesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd2\xd5\x55\xd0\xce\xcd\x4c\x2f\x4a\x2c\x49\x55\x08\x2d\xe0\xe2\x72\x0e\x72\x75\x0c\x71\x55\xf0\xf4\x73\x71\x8d\x50\x50\xca\x28\x29\x4a\x89\x4f\xaa\x8c\x4f\xce\x2f\xcd\x2b\x49\x2d\x8a\x4f\x2c\x2e\x4e\x2d\x51\x52\xf0\xf7\x53\xc8\xc8\x2c\x2e\xc9\x2f\xaa\x8c\x2f\x29\x4a\x4c\x49\x2d\x56\x08\x0d\xf6\xf4\x73\x57\x48\x2a\x29\x4a\x4d\x55\xd0\x40\x51\x1d\x9f\x99\xa2\x69\x8d\xcd\xd8\xc4\xf4\x74\x82\xe6\xc6\x9b\x19\x18\x18\x18\x10\x34\x9d\x0b\xd9\x17\x2e\xf9\xe5\x79\x5c\x5c\x2e\x41\xfe\x01\xf8\x7d\x61\x8d\xa9\x06\xd3\x49\xd6\x5c\x80\x01\x00\xda\xc9\x55\x1a\x21\x01\x00\x00")
 
 func migrations60_add_asset_id_indexesSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -1277,7 +1278,7 @@ func migrations60_add_asset_id_indexesSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations61_trust_lines_by_account_type_code_issuerSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x8f\xcf\xca\x82\x50\x10\x47\xf7\xf3\x14\x83\xab\x4f\x3e\x7d\x82\xbb\x8a\x94\x70\xa3\x61\x09\xed\x06\xff\x0c\x71\xa1\x54\xee\xcc\x25\x7c\xfb\x28\x2b\x5c\x26\xed\xce\x62\xe6\xf0\x3b\x71\x8c\xff\x57\x7b\x76\xb5\x32\x56\x23\xc0\xb6\x4c\x37\xc7\x14\xb3\x3c\x49\x4f\x18\xa8\xf3\xa2\x74\xb1\x3d\x0b\x35\x13\xe9\x34\x32\xb5\x43\xc7\x64\x45\x3c\x3b\xaa\xdb\x76\xf0\xbd\x06\x58\xe4\xb8\xb8\xc5\xea\x90\xe5\x3b\x6c\xd4\x31\xe3\x5f\x2d\xc2\xfa\xfc\x8d\x70\xe6\x87\xe2\xcd\xb3\x29\xc2\x97\x8a\x6c\x17\x1a\x48\xca\x62\xff\xe5\x86\xc0\x00\x2c\x23\x92\xe1\xd6\xc3\x1a\xc1\x27\xc2\xac\x8b\xff\x3d\x3a\x34\x70\x0f\x00\x00\xff\xff\xae\x55\x70\x5f\x7f\x01\x00\x00")
+var _migrations61_trust_lines_by_account_type_code_issuerSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x8f\xcf\xca\x82\x50\x10\x47\xf7\xf3\x14\x83\xab\x4f\x3e\x7d\x82\xbb\x8a\x94\x70\xa3\x61\x09\xed\x06\xff\x0c\x71\xa1\x54\xee\xcc\x25\x7c\xfb\x28\x2b\x5c\x26\xed\xce\x62\xe6\xf0\x3b\x71\x8c\xff\x57\x7b\x76\xb5\x32\x56\x23\xc0\xb6\x4c\x37\xc7\x14\xb3\x3c\x49\x4f\x18\xa8\xf3\xa2\x74\xb1\x3d\x0b\x35\x13\xe9\x34\x32\xb5\x43\xc7\x64\x45\x3c\x3b\xaa\xdb\x76\xf0\xbd\x06\x58\xe4\xb8\xb8\xc5\xea\x90\xe5\x3b\x6c\xd4\x31\xe3\x5f\x2d\xc2\xfa\xfc\x8d\x70\xe6\x87\xe2\xcd\xb3\x29\xc2\x97\x8a\x6c\x17\x1a\x48\xca\x62\xff\xe5\x86\xc0\x00\x2c\x23\x92\xe1\xd6\xc3\x1a\xc1\x27\xc2\xac\x8b\xff\x3d\x3a\x34\x70\x1f\x00\xae\x55\x70\x5f\x7f\x01\x00\x00")
 
 func migrations61_trust_lines_by_account_type_code_issuerSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -1297,7 +1298,7 @@ func migrations61_trust_lines_by_account_type_code_issuerSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations62_claimable_balance_claimantsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x94\xc1\x6f\xd3\x30\x18\xc5\xef\xf9\x2b\x9e\x72\x6a\x43\xab\x5e\x60\x97\x0a\xa1\x6e\x8d\x50\x45\x49\xa7\x2c\x95\xb6\x53\xf4\xc5\xfe\xd6\x59\x4a\xec\xc8\x36\x1b\xfd\xef\x51\xd2\x0e\x02\x6c\x4d\x0a\x88\x53\x24\xcb\xef\x7d\xef\xfd\xec\x78\x3a\xc5\x9b\x4a\xed\x2c\x79\xc6\xb6\x0e\x82\xab\x34\x5e\x64\x31\xb2\xc5\xe5\x3a\x86\x28\x49\x55\x54\x94\x9c\x17\x54\x92\x16\x9c\x1f\x56\xb4\x77\x18\x05\x00\xa0\x24\xb2\xf8\x36\x43\xb2\xc9\x90\x6c\xd7\xeb\x09\xa6\x53\x5c\x3d\xcb\x2e\x0f\xaa\xd5\x12\x4a\xa3\x20\xc7\x17\x6f\x5b\x95\x64\xe7\x95\x26\xaf\x8c\x86\x78\x20\x4b\xc2\xb3\xc5\x23\xd9\xbd\xd2\xbb\xd1\xbb\x8b\xf1\x0f\xbf\x76\x7f\x49\xce\xe7\x95\x91\xea\x5e\xb1\xcc\x4b\x96\x3b\xb6\x50\xda\x73\xf3\xfd\x79\xeb\x75\xba\xfa\xbc\x48\xef\xf0\x29\xbe\xc3\x48\xc9\x49\x77\xd6\x38\x18\xcf\xbf\x37\x5c\x25\xcb\xf8\x16\xe1\x89\x8a\x79\xb1\xcf\x3b\xea\xfc\xa5\x14\xb9\x92\x21\x36\xc9\x49\x52\xdb\x9b\x55\xf2\x11\x85\xb7\xcc\x18\x75\x0c\x27\x2f\xf6\x9a\x40\xc9\x26\xe6\x2c\xc2\xcd\x97\xba\x36\xd6\x3b\x84\x8e\x4b\x16\x1e\x11\xee\xad\xa9\x7e\x1f\xe6\xf0\xf4\xc0\x96\x41\xce\xb1\xc7\x7b\x7c\x80\xb1\x92\x2d\x8a\xfd\xab\x23\x42\x44\xb3\x3e\x14\x2d\x81\xd6\xf3\xbc\xee\xbf\x54\x6e\x1d\x4e\x96\x9d\x45\x48\xb9\x32\x8f\x0c\xa5\x25\x7f\x85\xe5\xba\x24\xc1\xb2\x29\x40\x45\xb3\x1e\xcd\x82\x65\xba\xb9\x1e\x10\x35\xfc\x43\x76\xae\x36\xda\x19\xfb\x8f\xe9\x1d\x5d\xff\x8a\xdf\xd1\xe3\x7f\x10\x3c\x8e\x6a\x18\x76\x1f\x86\xa5\x79\xd2\xc1\x41\xde\xff\x30\x08\x72\x82\x24\xf7\xff\x69\x9d\x33\x1b\x7c\x8d\xc6\xf3\xa1\xf7\xe0\x55\xe4\x03\x83\x3d\xa3\x38\xe3\x84\x06\x85\xeb\xbb\x11\xf3\x20\xf8\x16\x00\x00\xff\xff\x84\x8f\x50\xb2\x94\x05\x00\x00")
+var _migrations62_claimable_balance_claimantsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x94\x51\x6b\xdb\x30\x14\x85\xdf\xf5\x2b\x0e\x7e\x4a\xbc\x84\xbc\x6c\x7d\x09\x63\xa4\x8d\x19\x61\x99\x53\x5c\x07\xda\x27\x73\x2d\xdd\xa6\x02\x5b\x32\x92\xd6\x2e\xff\x7e\xd8\x49\xb7\x6c\x6b\x13\x67\x1b\x7b\x12\x08\xdd\x73\xcf\xf9\x74\xb9\xe3\x31\xde\xd4\x7a\xe3\x28\x30\xd6\x8d\x10\x57\x59\x32\xcb\x13\xe4\xb3\xcb\x65\x02\x59\x91\xae\xa9\xac\xb8\x28\xa9\x22\x23\xb9\xd8\xdd\x98\xe0\x31\x10\x00\xa0\x15\xf2\xe4\x36\x47\xba\xca\x91\xae\x97\xcb\x11\xc6\x63\x5c\x3d\x97\x5d\xee\xaa\x16\x73\x68\x83\x92\x3c\x5f\xbc\xed\xaa\x14\xfb\xa0\x0d\x05\x6d\x0d\xe4\x03\x39\x92\x81\x1d\x1e\xc9\x6d\xb5\xd9\x0c\xde\x5d\x0c\x7f\xe8\x75\xef\x2b\xf2\xa1\xa8\xad\xd2\xf7\x9a\x55\x51\xb1\xda\xb0\x83\x36\x81\xdb\xf3\xe7\xa7\xd7\xd9\xe2\xf3\x2c\xbb\xc3\xa7\xe4\x0e\x03\xad\x46\x87\xbd\x86\x62\x38\xfd\x9e\x70\x91\xce\x93\x5b\x44\x47\x22\x16\xe5\xb6\x38\xa8\x2e\x5e\x72\x51\x68\x15\x61\x95\x1e\x25\xb5\xbe\x59\xa4\x1f\x51\x06\xc7\x8c\xc1\x81\xe0\x08\x2f\x29\x8e\xa0\x55\x6b\x73\x12\xe3\xe6\x4b\xd3\x58\x17\x3c\x22\xcf\x15\xcb\x80\x18\xf7\xce\xd6\xbf\x37\xf3\x78\x7a\x60\xc7\x20\xef\x39\xe0\x3d\x3e\xc0\x3a\xc5\x0e\xe5\xf6\xd5\x16\x11\xe2\xc9\x29\x14\x1d\x81\x4e\xf3\xbc\xec\xbf\x44\xee\x14\x8e\x86\x9d\xc4\xc8\xb8\xb6\x8f\x0c\x6d\x14\x7f\x85\xe3\xa6\x22\xc9\xaa\x0d\x40\x65\x7b\x1f\x4f\xc4\x3c\x5b\x5d\xf7\xb0\x1a\xfd\x21\x3b\xdf\x58\xe3\xad\xfb\xc7\xf4\xf6\xaa\x7f\xc5\x6f\xaf\xf1\x3f\x08\xee\x5b\xb5\x0c\x0f\x17\xc3\xdc\x3e\x19\xb1\xfb\x80\xd3\x8b\x41\x92\x97\xa4\x78\x2a\xfa\x8f\x57\xff\x31\x1a\x4e\xfb\xce\xc1\xab\xc8\x7b\x1a\x7b\x46\x71\xc6\x0f\xf5\x32\x77\x6a\x22\xa6\x42\x7c\x1b\x00\x84\x8f\x50\xb2\x94\x05\x00\x00")
 
 func migrations62_claimable_balance_claimantsSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -1317,7 +1318,7 @@ func migrations62_claimable_balance_claimantsSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations63_add_contract_id_to_asset_statsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd2\xd5\x55\xd0\xce\xcd\x4c\x2f\x4a\x2c\x49\x55\x08\x2d\xe0\xe2\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x48\xad\x28\x88\x4f\x2c\x2e\x4e\x2d\x89\x2f\x2e\x49\x2c\x29\x56\x70\x74\x71\x51\x70\xf6\xf7\x09\xf5\xf5\x53\x48\xce\xcf\x2b\x29\x4a\x4c\x2e\x89\xcf\x4c\x51\x70\x8a\x0c\x71\x75\x54\x08\xf5\xf3\x0c\x0c\x75\xb5\xe6\xe2\x42\x36\xd1\x25\xbf\x3c\x0f\xbf\x99\x2e\x41\xfe\x01\x58\x0c\xb5\xe6\x02\x04\x00\x00\xff\xff\xbd\x18\xbe\x65\x99\x00\x00\x00")
+var _migrations63_add_contract_id_to_asset_statsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd2\xd5\x55\xd0\xce\xcd\x4c\x2f\x4a\x2c\x49\x55\x08\x2d\xe0\xe2\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x48\xad\x28\x88\x4f\x2c\x2e\x4e\x2d\x89\x2f\x2e\x49\x2c\x29\x56\x70\x74\x71\x51\x70\xf6\xf7\x09\xf5\xf5\x53\x48\xce\xcf\x2b\x29\x4a\x4c\x2e\x89\xcf\x4c\x51\x70\x8a\x0c\x71\x75\x54\x08\xf5\xf3\x0c\x0c\x75\xb5\xe6\xe2\x42\x36\xd1\x25\xbf\x3c\x0f\xbf\x99\x2e\x41\xfe\x01\x58\x0c\xb5\xe6\x02\x0c\x00\xbd\x18\xbe\x65\x99\x00\x00\x00")
 
 func migrations63_add_contract_id_to_asset_statsSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -1337,7 +1338,7 @@ func migrations63_add_contract_id_to_asset_statsSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations64_add_payment_flag_history_opsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd2\xd5\x55\xd0\xce\xcd\x4c\x2f\x4a\x2c\x49\x55\x08\x2d\xe0\xe2\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\xc8\xc8\x2c\x2e\xc9\x2f\xaa\x8c\xcf\x2f\x48\x2d\x4a\x2c\xc9\xcc\xcf\x2b\x56\x70\x74\x71\x51\xc8\x2c\x8e\x2f\x48\xac\xcc\x4d\xcd\x2b\x51\x48\xca\xcf\xcf\x49\x4d\xcc\xb3\xe6\xe2\x42\x36\xc7\x25\xbf\x3c\x8f\xa0\x49\x2e\x41\xfe\x01\x0a\xce\xfe\x3e\xa1\xbe\x7e\x48\x26\x5a\x73\x01\x02\x00\x00\xff\xff\xcc\xf9\x34\xcb\x91\x00\x00\x00")
+var _migrations64_add_payment_flag_history_opsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd2\xd5\x55\xd0\xce\xcd\x4c\x2f\x4a\x2c\x49\x55\x08\x2d\xe0\xe2\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\xc8\xc8\x2c\x2e\xc9\x2f\xaa\x8c\xcf\x2f\x48\x2d\x4a\x2c\xc9\xcc\xcf\x2b\x56\x70\x74\x71\x51\xc8\x2c\x8e\x2f\x48\xac\xcc\x4d\xcd\x2b\x51\x48\xca\xcf\xcf\x49\x4d\xcc\xb3\xe6\xe2\x42\x36\xc7\x25\xbf\x3c\x8f\xa0\x49\x2e\x41\xfe\x01\x0a\xce\xfe\x3e\xa1\xbe\x7e\x48\x26\x5a\x73\x01\x06\x00\xcc\xf9\x34\xcb\x91\x00\x00\x00")
 
 func migrations64_add_payment_flag_history_opsSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -1357,7 +1358,7 @@ func migrations64_add_payment_flag_history_opsSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations65_drop_payment_indexSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\xcf\xc1\x4a\xc3\x40\x10\xc6\xf1\x7b\x9e\xe2\xa3\x57\x8d\xeb\xc1\x0a\xea\x35\x15\x72\x51\xb1\x0a\xbd\x2d\xdb\x66\x48\x06\x9a\x99\x65\x67\x96\x9a\xb7\x17\x5b\xf0\xdc\x07\xf8\x7e\x7c\xff\xb6\xc5\xcd\xcc\x63\x49\x4e\xf8\xce\x4d\xd3\xb6\x60\x19\xe8\x27\x4e\x6c\xae\x65\x89\x9a\xa9\x24\x67\x15\x8b\x2a\x91\x2d\xe6\xb4\xcc\x24\x8e\x53\x32\xa4\x61\xa0\x01\x2c\xb8\x10\xac\x82\xc7\x07\xec\xab\x83\x1d\x5e\x8b\x18\xb4\xfa\x1f\xea\x13\x5d\xe0\xf3\x4e\xd4\x21\x74\x20\xb3\x54\x96\x5b\x18\x11\x26\xf7\x6c\xcf\x21\x8c\xec\x53\xdd\xdf\x1d\x74\x0e\xe6\x74\x3c\xa6\x12\x46\x0d\x6c\x56\xc9\xc2\xfa\x7e\xfd\xd4\x74\x9f\xef\x1f\xe8\xdf\xba\xcd\x0e\xfd\x2b\x36\xbb\x7e\xfb\xb5\xc5\xea\xba\xd7\xab\x97\x73\xe2\x7f\x72\xa7\x27\x69\x7e\x03\x00\x00\xff\xff\x15\xa3\x47\xcb\x04\x01\x00\x00")
+var _migrations65_drop_payment_indexSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\xcf\xb1\x4e\xc3\x40\x0c\xc6\xf1\xfd\x9e\xc2\xea\x0a\xc7\x31\x50\x24\x60\x4d\x91\xb2\x00\xa2\x20\x75\x3b\xb9\x89\x95\xf3\x10\x3b\x3a\xfb\x54\xf2\xf6\x08\xb2\x30\xf2\x00\xdf\x4f\xdf\x3f\x46\xb8\x9a\x79\xaa\xe8\x04\x9f\x4b\x08\x31\x02\xcb\x48\x5f\xb9\xb0\xb9\xd6\x35\xeb\x42\x15\x9d\x55\x2c\xab\x64\xb6\xbc\xe0\x3a\x93\x38\x5c\xd0\x00\xc7\x91\x46\x60\x81\x8d\x60\x15\xb8\xbf\x83\x73\x73\x60\x07\x6f\x55\x0c\xb4\xf9\x0f\xea\x85\x36\xf8\x77\x27\xea\x20\x34\x90\x19\xd6\xf5\x1a\x8c\x08\x8a\xfb\x62\x8f\x29\x4d\xec\xa5\x9d\x6f\x06\x9d\x93\x15\x14\x47\x69\xb1\xa0\x95\x01\xad\xa4\x49\x13\x9b\x35\xb2\xb4\xbf\xdd\x3f\x84\xee\xfd\xf5\x0d\xfa\x97\xee\x70\x82\xfe\x19\x0e\xa7\xfe\xf8\x71\x84\xdd\xff\xfe\xef\x9e\x42\xf8\x1b\xdf\xe9\x45\xc2\xf7\x00\xf9\xb6\xb1\x00\x0e\x01\x00\x00")
 
 func migrations65_drop_payment_indexSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -1373,11 +1374,11 @@ func migrations65_drop_payment_indexSql() (*asset, error) {
 	}
 
 	info := bindataFileInfo{name: "migrations/65_drop_payment_index.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
-	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xf2, 0xe4, 0xb0, 0xad, 0x98, 0x6a, 0x5, 0xb8, 0x1e, 0xbb, 0xf6, 0x3d, 0x63, 0x93, 0xd5, 0x45, 0x29, 0xd7, 0x23, 0x23, 0x9b, 0xfc, 0x27, 0xb5, 0x8f, 0x37, 0x20, 0x66, 0x6c, 0x97, 0x45, 0x4a}}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x94, 0x8f, 0xf4, 0x39, 0xcf, 0xe7, 0x2, 0x14, 0x46, 0xd3, 0x4b, 0xce, 0xfc, 0x2b, 0x66, 0xc8, 0x1a, 0x18, 0x98, 0x1, 0x65, 0xdc, 0xd6, 0x84, 0xb2, 0xd4, 0xbe, 0x29, 0x96, 0xcc, 0xaf, 0x15}}
 	return a, nil
 }
 
-var _migrations66_contract_asset_statsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x91\x41\x6b\xf2\x40\x10\x86\xef\xf9\x15\x2f\x9e\x94\xcf\xc0\xd7\x7a\xa9\x78\x8a\x35\x14\x5b\x9b\x48\x8c\x50\x4f\xcb\x66\x33\xc6\xa1\xba\x91\xdd\x91\xd6\x7f\x5f\x24\x44\x0b\x56\xf7\x3a\xcf\xcc\xf3\xce\x4e\x18\xe2\xdf\x8e\x2b\xa7\x85\xb0\xdc\x07\xcf\x59\x1c\xe5\x31\xf2\x68\x3c\x8b\x61\x6a\x2b\x4e\x1b\x51\xda\x7b\x12\xe5\x45\x8b\x47\x37\xc0\xe9\x9d\x6b\x5c\x62\xbc\xca\xe3\x08\xf3\x6c\xfa\x1e\x65\x2b\xbc\xc5\xab\x7e\xc3\x9c\x1a\xd0\xbc\xd7\x45\x9a\x8c\x91\xa4\x39\x92\xe5\x6c\x16\xf4\x46\xc1\x5d\x55\xa1\xb7\xda\x1a\x3a\xdb\x3e\xe9\xa8\x36\xda\x6f\x6e\xaa\x9a\xb6\xeb\x50\xad\xb1\xc5\x76\xf5\xc1\xb6\x99\xec\x61\x47\x8e\x4d\x77\x30\xec\xff\xef\x5d\x48\x84\x21\x06\x43\x94\x5c\xb1\x78\xb0\x87\x3f\xac\xd7\x6c\x98\xac\x60\x5d\x3b\x68\x3c\x3c\x3e\xa1\x60\x01\x5b\xa1\x8a\x5c\x33\x9a\xbe\xf7\xec\xb4\x70\x6d\xd5\x96\xca\x8a\x5c\x5b\xfe\x73\xeb\x69\x32\x89\x3f\xd0\xb9\xb1\xb6\x2a\x8e\xea\x32\xaf\x83\x34\xb9\xf9\x41\xcb\xc5\x34\x79\x41\x21\x8e\x08\xdd\xab\x0c\x27\xe3\xef\x0b\x4f\xea\x2f\x1b\x4c\xb2\x74\x7e\xef\xc2\x46\x7b\xa3\x4b\x1a\xdd\x01\xcf\xfa\x96\xfd\x09\x00\x00\xff\xff\x78\xde\xe2\x11\x47\x02\x00\x00")
+var _migrations66_contract_asset_statsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x91\x41\x6b\xf2\x40\x10\x86\xef\xf9\x15\x2f\x9e\x94\xcf\xc0\xd7\x7a\xa9\x78\x8a\x35\x14\x5b\x9b\x48\x8c\x50\x4f\xcb\x64\x33\xc6\xa5\xba\x91\xdd\x91\xd6\x7f\x5f\x82\x44\x0b\x56\xf7\xb2\x87\x79\x77\x9e\x67\x76\xc2\x10\xff\x76\xa6\x72\x24\x8c\xe5\x3e\x78\xce\xe2\x28\x8f\x91\x47\xe3\x59\x0c\x5d\x5b\x71\xa4\x45\x91\xf7\x2c\xca\x0b\x89\x47\x37\x40\x73\xce\x35\x53\x62\xbc\xca\xe3\x08\xf3\x6c\xfa\x1e\x65\x2b\xbc\xc5\xab\xfe\x29\xd3\x3c\x68\x6e\x00\xaf\x8b\x34\x19\x23\x49\x73\x24\xcb\xd9\x2c\xe8\x8d\x82\xbb\xa8\x82\xb6\x64\x35\x9f\x69\x9f\x7c\x54\x1b\xf2\x9b\x9b\xa8\x93\xe1\xb5\x54\x4b\x6c\x63\xbb\xfa\x60\x5b\x27\x7b\xd8\xb1\x33\xba\x3b\x18\xf6\xff\xf7\x2e\x49\x84\x21\x06\x43\x94\xa6\x32\xe2\x61\x3c\xfc\x61\xbd\x36\xda\xb0\x15\xac\x6b\x07\xc2\xc3\xe3\x13\x0a\x23\x30\x56\xb8\x62\x77\x6a\xcd\xdf\x7b\xe3\x48\x4c\x6d\xd5\x96\xcb\x8a\x5d\x5b\xfe\x73\xea\x69\x32\x89\x3f\xd0\xb9\x31\xb6\x2a\x8e\xea\xd2\xaf\x83\x34\xb9\xf9\x41\xcb\xc5\x34\x79\x41\x21\x8e\x19\xdd\x2b\x87\x86\xf8\x7b\xc3\x93\xfa\xcb\x06\x93\x2c\x9d\xdf\xdb\xb0\x26\xaf\xa9\xe4\xd1\x9d\xe0\x19\xaf\xc9\x6b\x2a\x79\xf4\x33\x00\x78\xde\xe2\x11\x47\x02\x00\x00")
 
 func migrations66_contract_asset_statsSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -1397,7 +1398,7 @@ func migrations66_contract_asset_statsSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations67_remove_unused_indexesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x55\xc9\x6e\xdb\x30\x10\xbd\xe7\x2b\x78\x8b\x8d\xc6\xa7\xdc\x12\x34\x40\x5b\xab\xad\x2f\x76\xeb\x05\xcd\x8d\xa0\xc4\xb1\xc5\x46\xe2\x08\xe4\x28\x8d\xff\xbe\x90\xe5\x85\x92\x45\x2d\xc9\x4d\x10\xdf\x9b\x37\x9c\xe5\x71\x32\x61\x9f\x52\xb5\x33\x82\x80\x6d\x32\xa6\x91\x8c\xd0\x56\x44\xa4\x50\xdf\xdc\x4c\x97\x8b\x5f\x6c\x36\x9f\x06\xcf\x6c\xf6\x9d\x05\xcf\xb3\xd5\x7a\xc5\x44\x14\x61\xae\xc9\xf2\x18\x53\xe0\x12\x53\xa1\xf4\x63\x07\x54\xe9\x6d\x22\x8a\x98\x5c\x82\x25\xa5\x0f\xdf\x3e\x92\xb5\x40\x3c\xdc\x73\x65\x6d\x0e\xc6\x83\x8a\x12\xa1\x52\x11\x8a\x30\x01\x1e\x8a\x44\xe8\x08\x6c\x41\x2a\xff\x6b\xb2\x1e\x5e\x4c\x46\x72\x52\x29\xf0\x04\xf1\x25\xcf\x3c\x30\xa5\x25\xbc\xf1\x58\x59\x42\xb3\xe7\xb0\xdd\x42\x44\x96\xa3\xe6\xb4\xcf\xa0\x17\x27\x01\xb9\x03\x73\xe0\x44\x09\x5a\x90\x5c\xd0\x50\xa2\x4a\x33\x34\x04\x86\xbf\x82\xb1\xfe\x8a\x79\xf9\xe5\x27\x8f\x85\x8d\x87\x52\x33\x03\xaf\x0a\x73\x3b\x38\x06\x66\x60\xca\x56\x1f\x5b\xe4\x36\xa8\xb8\x93\xf4\xb5\xc6\x13\x67\x58\xd9\x9d\xf9\x1d\x9c\x00\x19\x21\xe1\xdc\xec\x70\xcf\xd1\x48\x30\x3c\x44\x7c\xf1\x32\x72\x4b\x3c\x51\xba\x1c\xbe\xd3\xc4\xde\xb8\x6b\x35\xc5\x7f\xba\xb6\x58\xdf\x96\xc1\x97\x75\x70\x89\x36\x5f\xac\xdb\xb6\x8b\x2d\xe6\xe7\xff\x6c\xb3\x9a\xcd\x7f\xb0\xaf\xeb\x65\x10\x8c\x1c\xcc\xf8\xb1\x57\xd4\xc6\x45\xf4\xc6\x6f\x44\x77\x28\x55\xb7\xb7\x08\x7d\x6a\xcd\xe1\xe8\x24\x10\x92\x01\x60\xa3\x12\x5e\x62\xdb\x03\x77\x2e\x7c\x21\x75\xdd\xf1\xa3\xdc\x4e\xe9\xd1\x05\xf9\xd7\xa2\x0e\x79\x26\x28\xe6\x98\xd9\x76\xdd\xba\x61\xb8\x37\x3a\x0c\x4c\xb5\x64\xc7\x75\x39\xaf\x7c\x7b\xf0\x56\x9b\x71\x95\x8e\x47\xd5\xe2\x15\xa0\x21\xf1\x9b\x2c\xc9\x15\x39\x9e\x57\x45\xde\x75\x93\x16\x0f\xeb\x14\xac\x13\x2e\xba\x9b\xf9\xec\xf7\x66\xa0\xbc\x63\x5f\x9d\xca\x0e\xf6\x63\xa2\x4d\xe6\xd9\xa9\xde\x44\x7a\x47\x1a\x3d\xfc\xd7\x4d\xa5\x0d\x5e\xcd\xef\x9a\xa1\x24\xbb\x3b\x07\xba\xa2\x73\x25\x87\x8c\xcc\xb5\xdf\x37\xa6\xd9\xba\x02\xbd\x8b\xd4\xeb\x95\xa8\x6d\x7a\x0b\xa1\xb9\x50\x2e\xe7\x83\xa5\x6a\x79\x99\x3a\x5d\x62\x34\x92\x40\x42\x25\x96\x4d\x9e\x9e\xd8\xad\xc5\x44\x96\x56\x7c\x28\xf2\xed\xc3\x03\xc1\x1b\x8d\xc7\x77\xcc\x0f\x8c\x50\xf6\x03\x96\x46\xee\x87\x86\x98\xef\x62\xea\x25\x5f\x81\xb6\x27\x50\x81\xd6\x52\x18\xb3\x3f\x3f\x83\x65\x50\x4e\x0a\xfb\xcc\xee\xef\xbb\x2a\xdd\xf0\xa2\x17\x35\x76\x0e\x2a\x86\x5f\x7b\xc1\xfe\x07\x00\x00\xff\xff\x6e\x06\xca\xb9\x51\x0b\x00\x00")
+var _migrations67_remove_unused_indexesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x55\x4b\x73\xda\x30\x10\xbe\xf3\x2b\x74\x0b\x4c\xc3\x29\xb7\x30\xcd\x4c\x5b\xdc\x96\x0b\xb4\x3c\xa6\xb9\xed\xc8\xd6\x82\xd5\xd8\x5a\x8f\x24\xa7\xe1\xdf\x77\xfc\x00\x64\xf0\x33\xb9\x79\xac\xef\xb1\xde\xd5\x7e\x9e\x4e\xd9\xa7\x58\x1e\x34\xb7\xc8\x76\x09\x53\x64\x35\x57\x86\x07\x56\x92\x1a\x8d\xe6\xeb\xd5\x2f\xb6\x58\xce\xbd\x67\xb6\xf8\xce\xbc\xe7\xc5\x66\xbb\x61\x3c\x08\x28\x55\xd6\x40\x48\x31\x82\xa0\x98\x4b\x35\xeb\x80\x4a\xb5\x8f\x78\xa6\x09\x02\x8d\x95\x2a\x7f\x6e\x22\x19\x83\x16\xfc\x23\x48\x63\x52\xd4\x0d\xa8\x20\xe2\x32\xe6\x3e\xf7\x23\x04\x9f\x47\x5c\x05\x68\x32\x52\xf1\x5e\x59\xd3\xc0\x0b\xad\x16\x60\x65\x8c\x10\x11\xbd\xa4\x49\x03\x4c\x2a\x81\x6f\x10\x4a\x63\x49\x1f\x01\xf7\x7b\x0c\xac\x01\x52\x60\x8f\x09\xf6\xe2\x44\x28\x0e\xa8\x73\x4e\x10\x91\x41\x01\xdc\x0e\x25\xca\x38\x21\x6d\x51\xc3\x2b\x6a\xd3\xdc\xb1\x46\x7e\xf1\x08\x21\x37\xe1\x50\x6a\xa2\xf1\x55\x52\x6a\x06\x6b\x50\x82\x3a\x1f\x6f\x39\x8a\xca\x80\x48\x81\x14\x66\x98\xce\xb0\xb6\x3b\xf7\x77\x70\x01\x56\x73\x81\xe7\x61\xfb\x47\x20\x2d\x50\x83\x4f\xf4\xd2\xc8\x48\x8d\x85\x48\x2a\x34\xee\x8d\x1d\xb9\x6b\x35\xa7\x7f\xea\x6a\xb1\xbe\xad\xbd\x2f\x5b\xef\xa2\xb6\x5c\x6d\x6f\x56\xc6\xd9\x2e\xb6\x5a\x9e\xdf\xb3\xdd\x66\xb1\xfc\xc1\xbe\x6e\xd7\x9e\x37\x76\x30\x93\x59\x2f\xd5\xda\x45\x6c\xd4\xaf\x45\x77\x38\x55\xb7\x37\x93\x3e\x8d\x26\x3f\x3a\x19\xf8\x56\x23\xb2\x71\xfe\xae\xc4\xb6\x0b\x77\x2e\x7c\x66\x75\x3b\xf1\xd2\xee\x20\xd5\xf8\x82\xfc\x6b\x48\xf9\x90\x70\x1b\x02\x25\xa6\xdd\xf7\x3a\x30\xdc\x2f\xca\x2f\xcc\xc9\xa2\x18\x49\xb9\x2e\xe7\x95\x6f\x17\x6f\x8d\x19\xd7\xa9\x3c\xaa\x36\x2f\xcb\xa2\x21\xfa\x75\x91\xe4\x9a\x94\xe7\x55\x93\x77\x7d\x49\x4b\x86\x75\x1a\x5e\x13\x2e\xbe\xbb\xe5\xe2\xf7\x6e\xa0\xbd\x13\x5f\x9d\xce\x0e\xf6\x63\xa6\x75\xe1\xd9\xe9\x5e\x47\x7a\x47\x19\x3d\xf2\xd7\x2d\xa5\x0d\x5e\xad\xef\x96\x21\x05\xbb\x3f\x0b\xdd\xd0\x41\x8a\x4b\xf9\x03\xea\xae\xbd\xff\x97\xd3\xb6\x15\xe8\xdd\xa4\x5e\x7f\x09\xd7\xbf\x9d\x50\xdf\x28\x97\xf3\xc1\x56\xb5\xfc\x99\x3a\x53\x62\x3c\x16\x68\xb9\x8c\x0c\x9b\x3e\x3d\xb1\x3b\x43\x91\x80\x22\x76\xb3\xe6\xdd\x3d\x3e\x5a\x7c\xb3\x93\xc9\x3d\x6b\x06\x06\x24\xfa\x01\x8b\x20\x6f\x86\xfa\x94\x1e\x42\xdb\xcb\xbe\x02\x6d\x2f\xa0\x02\xbd\x2a\x61\xc2\xfe\xfc\xf4\xd6\x5e\x71\x53\xd8\x67\xf6\xf0\xd0\xd5\xe9\x9a\x3f\x7a\xd6\x63\xe7\xa0\xec\x6f\x11\xf8\xae\xeb\x64\x36\xfa\x3f\x00\x6e\x06\xca\xb9\x51\x0b\x00\x00")
 
 func migrations67_remove_unused_indexesSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -1417,7 +1418,27 @@ func migrations67_remove_unused_indexesSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations6_create_assets_tableSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\x90\x3d\x4f\xc3\x30\x18\x84\x77\xff\x8a\x1b\x1d\x91\x0e\x20\xe8\x92\xc9\x34\x16\x58\x18\xa7\xb8\x31\xa2\x53\xe5\x26\x16\x78\x80\x54\xb6\x11\xca\xbf\x47\xaa\x28\xf9\x50\xe6\x7b\xf4\xbc\xef\xdd\x6a\x85\xab\x4f\xff\x1e\x6c\x72\x30\x27\xb2\xd1\x9c\xd5\x1c\x35\xbb\x97\x1c\x1f\x3e\xa6\x2e\xf4\x07\x1b\xa3\x4b\x11\x94\x00\x80\x6f\xb1\xe3\x5a\x30\x89\xad\x16\xcf\x4c\xef\xf1\xc4\xf7\xc8\xcf\xd9\x19\x3c\xa4\xfe\xe4\xf0\xca\xf4\xe6\x91\x69\xba\xbe\xcd\xa0\xaa\x1a\xca\x48\x39\x86\x9a\xae\x1d\xa0\xeb\x9b\x65\xc8\xc7\xf8\xed\xc2\x3f\x76\xb7\x9e\x63\x46\x89\x17\xc3\xe9\xa0\xcc\x47\x3f\xe4\x13\x4b\x46\xb2\x82\x5c\xfa\x09\x55\xf2\xb7\xbf\xf8\xd8\x5f\xee\x54\x6a\x5e\xd9\xec\x84\x7a\xc0\x31\x05\xe7\x40\x27\xb6\x82\x90\xf1\x74\x65\xf7\xf3\x45\x4a\x5d\x6d\x97\xa7\x6b\x6c\x6c\x6c\xeb\x8a\xdf\x00\x00\x00\xff\xff\xfb\x53\x3e\x81\x6e\x01\x00\x00")
+var _migrations68_online_migrationsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\xd2\x41\x8b\xdb\x30\x10\x05\xe0\xbb\x7e\xc5\x3b\x6e\xe8\xba\x5b\x52\x72\xca\xc9\x6d\x5c\x28\x75\x93\x60\x9c\x43\x4e\x66\x2c\x4f\x6c\x11\x5b\x32\x23\xb9\xa6\xfd\xf5\x25\x36\x94\x90\x26\x84\xbd\x8a\x6f\xde\x3c\xd0\x44\x11\x3e\x74\xa6\x16\x0a\x8c\x43\xaf\x54\x14\x21\x17\xd2\x67\x8f\xd0\x30\x7a\x71\xb5\xb0\xf7\x70\x27\x38\xdb\x1a\xcb\x98\xb1\x71\xd6\xe3\xc5\x33\x4f\xac\x2a\x97\x6f\x5e\x37\xdc\xd1\xdb\xac\x2e\x31\x3d\xe9\x33\xd5\xbc\x78\xc5\xd8\x18\xdd\x40\x78\x14\x13\x18\x2d\x49\xcd\x08\x54\xb6\xec\x61\xec\x14\x50\x92\x3e\xd7\xe2\x06\x5b\x7d\x54\x5f\xb3\x24\xce\x13\xe4\xf1\x97\x34\x01\x0d\xe2\x84\x8a\x39\xb5\xb8\xde\xad\x00\xc0\x54\xd0\x0d\x09\xe9\xc0\x82\x5f\x24\xbf\x8d\xad\x5f\x96\xab\xd5\x02\xfb\xec\xfb\xcf\x38\x3b\xe2\x47\x72\x7c\x9d\xe8\xb4\xb0\xb0\xd4\xf1\xa3\x91\xed\x2e\xc7\xf6\x90\xa6\xb3\xf7\x81\xc2\x3d\xfa\x79\x79\x2b\x2f\xe5\x4f\xa6\x6d\x0b\x1f\x48\x02\x4a\x53\x1b\x1b\x1e\x19\x3d\x88\x77\xf2\x04\xb1\xad\xee\x0b\x71\xa3\x2f\xb4\xeb\x0d\xff\x07\xb0\x49\xbe\xc5\x87\x34\xc7\xa7\x7f\xfd\x25\x70\x55\x50\x40\x30\x1d\xfb\x40\x5d\x8f\xd1\x84\xc6\x0d\xf3\x0b\xfe\x38\xcb\x37\xf9\x43\x5f\xd1\x3b\x86\xd4\x62\xad\xd4\xf5\x05\x6d\xdc\x68\xd5\x26\xdb\xed\x9f\x7d\x9f\x26\xaf\xa9\xe2\xb5\xfa\x3b\x00\xbf\xa2\x7a\xc7\x80\x02\x00\x00")
+
+func migrations68_online_migrationsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations68_online_migrationsSql,
+		"migrations/68_online_migrations.sql",
+	)
+}
+
+func migrations68_online_migrationsSql() (*asset, error) {
+	bytes, err := migrations68_online_migrationsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/68_online_migrations.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x15, 0xcf, 0xbb, 0xd2, 0x4b, 0xe0, 0xe6, 0x45, 0x6b, 0xc5, 0x18, 0xb3, 0xd7, 0x37, 0x7e, 0xeb, 0x64, 0x9d, 0x75, 0x5a, 0xe2, 0xa7, 0xe2, 0x1c, 0x89, 0x56, 0x3e, 0x8b, 0x9f, 0x15, 0x4b, 0xae}}
+	return a, nil
+}
+
+var _migrations6_create_assets_tableSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\x90\x31\x4f\xc3\x30\x14\x84\x77\xff\x8a\x1b\x1d\xd1\x0e\x20\xe8\x92\xc9\x34\x16\x58\x18\xa7\xb8\x31\xa2\x53\xe5\x26\x16\x78\x80\x54\xb6\x11\xca\xbf\x47\x94\x86\x84\x28\xdb\x93\xee\x7b\xf7\xde\xdd\x72\x89\x8b\x77\xff\x1a\x6c\x72\x30\x47\xb2\xd6\x9c\x55\x1c\x15\xbb\x95\x1c\x6f\x3e\xa6\x36\x74\x7b\x1b\xa3\x4b\x11\x94\x00\x80\x6f\xb0\xe5\x5a\x30\x89\x8d\x16\x8f\x4c\xef\xf0\xc0\x77\x58\x9c\xb4\x13\xb8\x4f\xdd\xd1\xe1\x99\xe9\xf5\x3d\xd3\x74\x75\x9d\x41\x95\x15\x94\x91\x72\x0c\xd5\x6d\x33\x40\x97\x57\xf3\x90\x8f\xf1\xd3\x85\x3f\xec\x66\x35\xc5\x8c\x12\x4f\x86\xd3\xc1\x72\x71\xde\xfc\xf9\xa1\x9f\x7f\x5d\x32\x92\xe5\xa4\xcf\x27\x54\xc1\x5f\xce\xf2\xa1\xeb\xef\x94\x6a\x1a\xd9\x6c\x85\xba\xc3\x21\x05\xe7\x40\xff\xb9\xe5\x84\x8c\xab\x2b\xda\xaf\x0f\x52\xe8\x72\x33\x5f\x5d\x6d\x63\x6d\x1b\x97\x7f\x0f\x00\xfb\x53\x3e\x81\x6e\x01\x00\x00")
 
 func migrations6_create_assets_tableSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -1437,7 +1458,7 @@ func migrations6_create_assets_tableSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations7_modify_trades_tableSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc4\x54\x4d\x8f\xda\x30\x14\xbc\xe7\x57\x3c\xed\x29\x51\xc3\xaa\xad\xda\xbd\x6c\x55\x09\x58\x97\x46\x65\xc3\x36\x04\xa9\xb7\xc8\x89\xdf\x06\xab\xc1\x8e\x6c\xa7\x88\x7f\x5f\x05\x08\xcd\x27\xb0\xbb\x87\x5e\x93\x99\x79\x6f\xec\xf1\x8c\x46\xf0\x6e\xc3\x53\x45\x0d\xc2\x2a\xb7\x46\x23\x60\x4a\xe6\x60\xd6\x08\x32\x63\x60\x14\x65\xa8\xc1\xd0\x38\xc3\x5b\xc8\x0b\x03\x14\x04\x6e\x41\x0a\x04\x2e\x20\xcf\x68\x82\xd6\x43\xb0\x78\x82\x70\x3c\x99\x13\x58\x73\x6d\xa4\xda\x45\x07\xde\xbd\x35\x0d\xc8\x38\x24\xbd\x3f\xc1\xb6\x00\xe0\xf4\x51\xe6\xa8\xa8\xe1\x52\x44\x9c\xc1\xc4\x9b\x79\x7e\x08\xfe\x22\x04\x7f\x35\x9f\xbb\x7b\xe4\x8d\x54\x0c\xd5\x0d\x78\x7e\x48\x66\x24\x68\xfd\xcd\x90\xa5\xa8\xa2\x24\x93\x1a\x59\x44\x0d\x84\xde\x23\x59\x86\xe3\xc7\xa7\x16\x50\x3e\x3f\xa3\x1a\x1c\x12\x53\x8d\x11\x4d\x12\x59\x08\xd3\x03\x82\x80\x7c\x23\x01\xf1\xa7\x64\x79\xda\xfc\x88\xd6\x36\x67\x4e\x5d\x44\x6b\xbc\x5a\xa2\xc4\x76\x04\x36\xa5\x6c\x87\x3e\xfd\x4e\xa6\x3f\xc0\xae\x43\xbe\xc2\xfb\x23\x71\xbf\x09\xaa\x37\x3b\x38\xe9\xbc\xc1\xc4\x49\xe3\xac\x8f\x16\xea\x9f\x95\xbd\x41\xae\x23\x8d\x59\x86\x0a\x26\x8b\xc5\x9c\x8c\xfd\xc3\xbf\x3d\xd7\x6e\x1e\xf3\x97\xce\xd2\x8e\xe5\xdc\x5b\x55\x04\x57\xbe\xf7\x73\x45\xc0\xf3\x1f\xc8\x2f\x58\x1b\xc5\xa2\x9c\x33\x58\xf8\xed\x54\xae\x96\x9e\x3f\x83\xd8\x28\x44\xb0\xfb\xc2\xe9\x56\x41\x74\x4e\xf1\xae\x8b\x52\xae\x22\xc3\x37\x18\x65\x52\xfe\x2e\xf2\xc1\x09\x93\x30\x20\xa4\x69\xc1\xed\x38\x70\x3b\xb1\xee\x1d\x5a\xd1\xae\x1a\xd9\x39\xa5\x3e\xc5\xeb\x1d\x5c\xb5\x60\xbc\x8b\xf6\xcf\xee\xd2\x79\x57\x6f\xb3\xbc\x37\xab\x5e\x4d\x0f\x72\x2b\x1a\xe5\x24\x70\x8b\xaa\xea\x25\x85\x5c\x68\x53\xe2\xaa\xde\x92\x02\x6f\x87\x7b\x09\x12\xaa\x13\xca\xf0\xd5\xfd\x14\xf3\x94\x0b\x33\xd0\x4f\x5c\x18\x4c\x51\x0d\xd5\x4e\x2f\xf7\x10\xf2\xc1\xdf\x71\xb1\x3b\x47\x96\x19\x3b\x5e\xa7\xd9\xe5\x08\xc9\x9a\x2a\x9a\x18\x54\xf0\x87\xaa\x1d\x17\xa9\x7d\xf7\xc9\x19\xe6\x70\xad\x0b\x54\x3d\xac\xcf\x77\x67\x58\x89\x64\x7d\x93\x3e\x7c\xec\xe7\x1c\x5e\x77\x6b\xfd\xaa\x03\xea\x90\x5a\x01\xc8\x22\x5d\x9b\x97\x1a\x6b\xb0\x5e\x60\xad\xc1\xbb\xda\x5c\xc5\x3a\x6b\xaf\x09\x2a\x0d\xfe\x87\x62\x7a\xc5\x13\x6c\x8b\x94\x1a\xe5\x55\x5d\x92\x68\xe5\xd1\x6d\xc7\xc6\xed\xa6\x6f\x60\xda\xe1\xe4\x2e\xcd\xeb\x04\xc5\xed\xde\xa6\xdb\x17\x0c\xe7\xfe\x6f\x00\x00\x00\xff\xff\x2a\xff\xe8\x4a\xff\x08\x00\x00")
+var _migrations7_modify_trades_tableSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc4\x54\xcb\x6e\xdb\x30\x10\xbc\xeb\x2b\x16\x39\x59\xa8\x1c\xb4\x45\x9b\x4b\x8a\x02\xb6\xc3\xa6\x42\x1d\x39\x55\x64\xa0\x37\x82\x12\x37\x32\x51\x85\x14\x48\xaa\x81\xfe\xbe\x90\x15\xb9\x7a\x3a\x4e\x72\xe8\x95\x3b\xb3\xbb\x43\x0e\x67\x3e\x87\x77\x0f\x22\xd5\xcc\x22\x6c\x73\x67\x3e\x07\xae\x55\x0e\x76\x87\xa0\x32\x0e\x56\x33\x8e\x06\x2c\x8b\x33\x3c\x87\xbc\xb0\xc0\x40\xe2\x23\x28\x89\x20\x24\xe4\x19\x4b\xd0\xb9\x0a\x37\xb7\x10\x2d\x96\x6b\x02\x3b\x61\xac\xd2\x25\xad\x79\x97\xce\x2a\x24\x8b\x88\x8c\x16\x61\xe6\x00\xc0\xe1\x50\xe5\xa8\x99\x15\x4a\x52\xc1\x61\xe9\x5f\xfb\x41\x04\xc1\x26\x82\x60\xbb\x5e\x7b\x7b\xe4\x99\xd2\x1c\xf5\x19\xf8\x41\x44\xae\x49\xd8\xab\x66\xc8\x53\xd4\x34\xc9\x94\x41\x4e\x99\x85\xc8\xbf\x21\x77\xd1\xe2\xe6\xb6\x07\x54\xf7\xf7\xa8\x27\x87\xc4\xcc\x20\x65\x49\xa2\x0a\x69\x47\x40\x10\x92\x6f\x24\x24\xc1\x8a\xdc\x1d\x36\x7f\x42\x9b\x99\xe0\x6e\xbb\x89\x31\x78\x72\x8b\x0a\x3b\x68\xf0\x50\x2d\x31\xa0\xaf\xbe\x93\xd5\x0f\x98\xb5\x21\x5f\xe1\xfd\x13\x71\xbf\x09\xea\x37\x2b\x38\xf4\x79\x83\x88\x43\x8f\xa3\x3a\x7a\xa8\x7f\x52\xf6\x02\x85\xa1\x06\xb3\x0c\x35\x2c\x37\x9b\x35\x59\x04\x75\x6d\xcf\x9d\x75\xaf\xf9\xcb\x60\x69\xd7\x71\x2f\x9d\xc6\x82\xdb\xc0\xff\xb9\x25\xe0\x07\x57\xe4\x17\xec\xac\xe6\x34\x17\x1c\x36\x41\xdf\x95\xdb\x3b\x3f\xb8\x86\xd8\x6a\x44\x98\x8d\x99\xd3\x6b\x8c\xe8\x1e\xec\xdd\x6e\xca\x84\xa6\x56\x3c\x20\xcd\x94\xfa\x5d\xe4\x93\x13\x96\x51\x48\x48\x57\x82\x37\x50\xe0\x0d\x6c\x3d\x3a\xb4\xa1\x9d\x34\xb2\x3f\x63\xb4\xe3\xe9\x0a\x4e\x5a\x30\x2e\xe9\xfe\xdb\x3d\x77\xdf\xcd\xdf\xac\xde\xcd\x69\x47\xd3\x95\x7a\x94\x9d\x70\x92\xf8\x88\xba\xc9\x25\x8d\x42\x1a\x5b\x45\x58\x93\x5b\x4a\xe2\xf9\x74\x2e\x41\xc2\x4c\xc2\x38\xbe\x3a\x9f\x62\x91\x0a\x69\x27\xf2\x49\x48\x8b\x29\xea\xa9\xd8\x19\xe5\xd6\x26\x9f\x2c\xc7\x45\x79\x8c\xac\x32\xfe\xf4\x9c\xb6\xcc\x11\x92\x1d\xd3\x2c\xb1\xa8\xe1\x0f\xd3\xa5\x90\xe9\xec\xe2\x93\x3b\xcd\x11\xc6\x14\xa8\x47\x58\x9f\x2f\x8e\xb0\x12\xc5\xc7\x26\x7d\xf8\x38\xce\xa9\x33\xa0\xb7\x7e\x93\x01\x6d\x48\x2b\x00\x54\x91\xee\xec\x4b\x85\x75\x58\x2f\x90\xd6\xe1\x9d\x2c\xae\x61\x1d\x95\xd7\x05\x55\x02\xff\x43\x30\xbd\xe2\x0b\xf6\x9b\xd0\xb8\xa4\x46\x65\xcf\x2e\xd7\x72\x49\xe5\x47\xaf\x6f\x9b\xce\x41\xfd\x44\x13\xd3\xea\x9b\x7b\x6e\xde\xc0\x28\x5e\xd7\x05\x89\xe2\xfd\x23\x61\x4c\x81\xda\xbd\xfc\x3b\x00\x2a\xff\xe8\x4a\xff\x08\x00\x00")
 
 func migrations7_modify_trades_tableSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -1457,7 +1478,7 @@ func migrations7_modify_trades_tableSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations8_add_aggregatorsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x92\x31\x6f\xdb\x30\x14\x84\x77\xfe\x8a\x1b\x34\xd8\xa8\x65\xa3\x1d\x1b\x78\xa0\x65\x5a\x10\x40\x2b\xae\x48\x0d\x99\x02\x26\x61\x64\xa1\x32\xa5\x92\xcf\x30\xfc\xef\x0b\xaa\x4d\x6c\xb4\x05\x1a\x14\xcd\x46\x1c\xf8\x0e\x77\xdf\x7b\x69\x8a\x0f\x87\xb6\xf1\x86\x2c\xea\x81\xb1\x34\xc5\x9e\x68\x08\x9f\x17\x8b\x53\xfb\xb5\x9d\x0f\x7d\xa0\xc6\xdb\xf0\xad\x9b\xf7\xbe\x19\xb5\xc5\xa6\xf5\x81\x16\x9d\x09\x74\x3f\x31\x4d\xe3\x6d\x63\xc8\x4e\xe3\x68\xe6\x6d\x34\x32\x78\x3e\xba\x47\x6a\x7b\x07\xda\x1b\x82\xe9\x4e\xe6\x1c\xe0\x2d\x1d\xbd\x0b\xa0\xbd\xc5\x73\xf4\x80\xeb\x5d\x5a\xd6\x52\xa2\x25\x7b\x60\x59\x25\xb8\x16\xd8\xd4\x65\xa6\x8b\xdb\x12\xc3\xf1\xa1\x6b\x1f\xe7\xe3\xd7\x7b\xd3\x34\x98\xc0\xb8\xb3\xed\xec\xc1\x3a\x9a\x5d\xbd\x31\x65\x40\x25\x74\x5d\x95\xea\x5a\x96\xbc\xcc\x6b\x9e\x0b\xa8\x2f\x12\xc5\x76\x5b\x6b\xbe\x92\x02\x4a\x57\x45\xa6\xc1\x15\x92\x04\x4a\x48\x91\x69\x24\x1f\x91\x24\x37\x63\x7f\xee\x9e\x62\x44\x87\x93\x37\x03\x8c\xc3\x6b\x47\x18\xdf\x1f\xdd\x13\x5a\x7a\xc9\xca\xf3\xbc\x12\x79\x7c\xfd\x0c\xbb\x29\x2a\xa5\x31\x61\x2a\xb6\xc0\x12\xbb\x7a\x25\x8b\xec\xd2\x61\xc6\x56\x5c\x09\x7d\xb7\x13\x58\x82\x97\x77\x42\x8a\xad\x28\xf5\x8c\xa9\xdf\x34\x36\xfd\x91\xe7\xed\x50\xe3\x4a\xde\xc6\x74\x5c\xde\x7b\x23\xfd\xf4\x7f\x90\x4a\x3e\x12\x0d\xb1\x3e\x00\x2c\x7f\x2d\x31\x63\x0f\x26\x58\x3a\x0f\x16\xcb\xeb\x3a\x2c\x8c\xda\x38\x72\x91\x5f\xb0\xbe\x9e\xfd\xba\x3f\x39\xb6\xae\x6e\x77\xff\x74\x79\xc8\xb8\xca\xf8\x5a\xdc\xfc\xd9\xe2\x02\xfa\xaf\x06\xdf\x03\x00\x00\xff\xff\x7e\x17\x8e\x03\x8b\x03\x00\x00")
+var _migrations8_add_aggregatorsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x92\x4d\x8b\xdb\x30\x18\x84\xef\xfa\x15\x73\xf0\x21\xa1\x76\x42\x7b\x6c\xc8\x41\x71\x14\x63\x50\xbc\xa9\x25\x1f\xf6\xb4\x68\x77\xb5\x8e\xa9\x23\xbb\xd2\x1b\x4c\xfe\x7d\xb1\xe9\xee\xa6\x1f\xd0\x0f\xca\xde\x5e\x06\x69\x98\x79\x98\x24\xc1\xbb\x53\x53\x7b\x43\x16\x55\xcf\x58\x92\xe0\x48\xd4\x87\x8f\xcb\xe5\xd0\x7c\x6e\x16\x7d\x17\xa8\xf6\x36\x7c\x69\x17\x9d\xaf\x27\x6d\xb9\x6b\x7c\xa0\x65\x6b\x02\xdd\xcd\x4c\x5d\x7b\x5b\x1b\xb2\xf3\xf1\x6b\xea\xed\x68\x64\xf0\x74\x76\x0f\xd4\x74\x0e\x74\x34\x04\xd3\x0e\xe6\x12\xe0\x2d\x9d\xbd\x0b\xa0\xa3\xc5\xd3\xe8\x01\xd7\xb9\xa4\xa8\xa4\x44\x43\xf6\xc4\xd2\x52\x70\x2d\xb0\xab\x8a\x54\xe7\x37\x05\xfa\xf3\x7d\xdb\x3c\x2c\xa6\xa7\x77\xa6\xae\x31\x83\x71\x17\xdb\xda\x93\x75\x14\x5f\xdd\x98\x33\xa0\x14\xba\x2a\x0b\x75\x2d\x4b\x5e\x64\x15\xcf\x04\xd4\x27\x89\x7c\xbf\xaf\x34\xdf\x48\x01\xa5\xcb\x3c\xd5\xe0\x0a\x51\x04\x25\xa4\x48\x35\xa2\xf7\x88\xa2\xd5\xd4\x9f\xbb\xc7\x31\xa2\xc3\xe0\x4d\x0f\xe3\xf0\xd2\x11\xc6\x77\x67\xf7\x88\x86\x9e\xb3\xf2\x2c\x2b\x45\x36\x5e\xdf\xc2\xee\xf2\x52\x69\xcc\x98\x1a\x5b\x60\x8d\x43\xb5\x91\x79\xfa\xda\x21\x66\x1b\xae\x84\xbe\x3d\x08\xac\xc1\x8b\x5b\x21\xc5\x5e\x14\x3a\x66\xea\x27\x8d\xcd\x57\xec\xef\xa0\xb6\xe6\x4f\x99\xb6\xe6\x0d\x90\x7e\xf8\x3f\x48\x25\x9f\x88\x86\x71\x53\x00\xb0\xfe\xb1\x44\xcc\xee\x4d\xb0\x74\xe9\x2d\xd6\x57\x59\x63\x16\x26\x0d\xf8\x4e\x7e\xc6\xfa\x32\xfb\x6d\x37\x38\xb6\x2d\x6f\x0e\xff\xb4\x3c\xa4\x5c\xa5\x7c\x2b\x56\xbf\xb6\x78\x05\xfd\x5b\x83\xaf\x03\x00\x7e\x17\x8e\x03\x8b\x03\x00\x00")
 
 func migrations8_add_aggregatorsSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -1477,7 +1498,7 @@ func migrations8_add_aggregatorsSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations8_create_asset_stats_tableSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\x91\xc1\x6e\xf2\x30\x10\x84\xef\x79\x8a\x3d\x82\xfe\x9f\x5b\xd5\x0b\x27\x63\x6f\xa9\x55\xe3\x44\x1b\xa7\x2a\xa7\xc8\x24\x2e\x8d\x44\x92\x2a\x36\xaa\x78\xfb\xca\x84\x56\xa1\x8a\x4f\xb6\xf5\x69\x66\x67\x67\xb5\x82\x7f\x6d\x73\x1c\x6c\x70\x50\x7c\x26\x9c\x90\x19\x04\xc3\x36\x0a\xc1\x7a\xef\x42\xe9\x83\x0d\x1e\x16\x09\x00\x40\x53\xc3\xdd\xd9\xc8\xad\xd4\x66\xbc\x67\x24\x77\x8c\xf6\xf0\x82\x7b\x20\x7c\x42\x42\xcd\x31\x87\x8f\xc6\x87\x7e\xb8\x94\x57\x31\x0f\xa9\x06\x81\x0a\x0d\x02\x67\x39\x67\x02\xe3\x4f\x91\x89\xe8\x4a\x98\x1b\x92\xdc\xfc\xbf\x7a\xd9\xb6\x3f\x77\x61\xde\x4b\xa7\x06\x74\xa1\xd4\x48\x76\xe7\xb6\xb4\x55\x15\x71\x1f\xdf\x52\x1b\xdc\x22\xcd\x90\xef\x27\x7b\xf4\x93\xf9\xf3\x1d\x53\xea\xa6\x7a\x4f\x86\xbe\x3d\x4d\x93\xbe\x32\xe2\xcf\x8c\x16\x8f\x0f\xcb\x5f\x32\x59\xae\x93\x9f\x8d\x49\x2d\xf0\xed\xb6\xb1\xc3\xa5\xac\xfa\xda\xc5\x64\x7f\xd2\x17\xb9\xd4\x5b\x38\x84\xc1\x39\x58\x8c\x70\x24\xa3\xce\xb4\x08\xd1\x7f\x75\x89\xa0\x34\x9b\x29\xa2\xb2\xbe\xb2\xb5\x5b\x7f\x07\x00\x00\xff\xff\xa9\x7e\x10\x6f\xb9\x01\x00\x00")
+var _migrations8_create_asset_stats_tableSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\x91\xb1\x6e\xc2\x30\x10\x86\xf7\x3c\xc5\x3f\x82\x5a\xb6\xaa\x0b\x93\xb1\xaf\xd4\xaa\x31\xc8\x71\xaa\x32\x45\x26\xb8\x14\x89\x24\x55\x6c\x54\xf1\xf6\x95\x53\x52\x95\x2a\xe7\xc9\xa7\x4f\xdf\xaf\xbb\x9b\xcd\x70\x57\x1f\x0f\x9d\x8b\x1e\xc5\x67\xc6\x0d\x31\x4b\xb0\x6c\xa1\x08\x2e\x04\x1f\xcb\x10\x5d\x0c\x98\x64\x00\x70\xdc\xe3\xa6\x16\x72\x29\xb5\x45\x5f\x1b\x23\x57\xcc\x6c\xf1\x42\x5b\x18\x7a\x22\x43\x9a\x53\x8e\x8f\x63\x88\x6d\x77\x29\x7b\x59\xc0\x5a\x43\x90\x22\x4b\xe0\x2c\xe7\x4c\x50\xea\x14\x1b\x91\x52\x0d\xe5\xd6\x48\x6e\xef\xfb\x2c\x57\xb7\xe7\x26\x8e\x67\xe9\xb5\x85\x2e\x94\xfa\x21\x9b\x73\x5d\xba\xaa\x4a\x78\x48\x7f\xa9\x2d\x2d\xc9\x8c\x90\xef\x27\x77\x08\x83\x10\x40\xbe\x62\x4a\x5d\xad\xb7\x64\x6c\xeb\xd3\x40\xa5\xf7\xca\x0c\x7f\x66\x66\xf2\xf8\x30\xfd\x75\x66\xd3\x79\x36\x6c\x4c\x6a\x41\x6f\xd7\x8d\xed\x2e\x65\xd5\xee\x7d\x9a\xec\xdf\xf4\x45\x2e\xf5\x12\xbb\xd8\x79\x8f\x49\xdf\xeb\xc9\xe4\xf9\x7b\x08\xd1\x7e\x35\x99\x30\xeb\xcd\xc8\x21\x2a\x17\x2a\xb7\xf7\xf3\xef\x01\x00\xa9\x7e\x10\x6f\xb9\x01\x00\x00")
 
 func migrations8_create_asset_stats_tableSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -1497,7 +1518,7 @@ func migrations8_create_asset_stats_tableSql() (*asset, error) {
 	return a, nil
 }
 
-var _migrations9_add_header_xdrSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd2\xd5\x55\xd0\xce\xcd\x4c\x2f\x4a\x2c\x49\x55\x08\x2d\xe0\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\xf0\xf7\xf3\x89\x54\xc8\xc8\x2c\x2e\xc9\x2f\xaa\x8c\xcf\x49\x4d\x49\x4f\x2d\x2a\x56\x70\x74\x71\x51\x70\xf6\xf7\x09\xf5\xf5\x53\x80\x08\xc5\x67\xa4\x26\xa6\xa4\x16\x29\x94\xa4\x56\x94\x28\xf8\x85\xfa\xf8\x58\x73\x71\x21\x1b\xea\x92\x5f\x9e\x47\xd8\x58\x97\x20\xff\x00\xac\xe6\x5a\x03\x02\x00\x00\xff\xff\xe1\xe4\xef\x11\xa1\x00\x00\x00")
+var _migrations9_add_header_xdrSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd2\xd5\x55\xd0\xce\xcd\x4c\x2f\x4a\x2c\x49\x55\x08\x2d\xe0\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\xf0\xf7\xf3\x89\x54\xc8\xc8\x2c\x2e\xc9\x2f\xaa\x8c\xcf\x49\x4d\x49\x4f\x2d\x2a\x56\x70\x74\x71\x51\x70\xf6\xf7\x09\xf5\xf5\x53\x80\x08\xc5\x67\xa4\x26\xa6\xa4\x16\x29\x94\xa4\x56\x94\x28\xf8\x85\xfa\xf8\x58\x73\x71\x21\x1b\xea\x92\x5f\x9e\x47\xd8\x58\x97\x20\xff\x00\xac\xe6\x5a\x03\x06\x00\xe1\xe4\xef\x11\xa1\x00\x00\x00")
 
 func migrations9_add_header_xdrSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -1672,6 +1693,7 @@ var _bindata = map[string]func() (*asset, error){
 	"migrations/65_drop_payment_index.sql":                               migrations65_drop_payment_indexSql,
 	"migrations/66_contract_asset_stats.sql":                             migrations66_contract_asset_statsSql,
 	"migrations/67_remove_unused_indexes.sql":                            migrations67_remove_unused_indexesSql,
+	"migrations/68_online_migrations.sql":                                migrations68_online_migrationsSql,
 	"migrations/6_create_assets_table.sql":                               migrations6_create_assets_tableSql,
 	"migrations/7_modify_trades_table.sql":                               migrations7_modify_trades_tableSql,
 	"migrations/8_add_aggregators.sql":                                   migrations8_add_aggregatorsSql,
@@ -1683,13 +1705,11 @@ var _bindata = map[string]func() (*asset, error){
 // directory embedded in the file by go-bindata.
 // For example if you run go-bindata on data/... and data contains the
 // following hierarchy:
-//
-//	data/
-//	  foo.txt
-//	  img/
-//	    a.png
-//	    b.png
-//
+//     data/
+//       foo.txt
+//       img/
+//         a.png
+//         b.png
 // then AssetDir("data") would return []string{"foo.txt", "img"},
 // AssetDir("data/img") would return []string{"a.png", "b.png"},
 // AssetDir("foo.txt") and AssetDir("notexist") would return an error, and
@@ -1722,76 +1742,77 @@ type bintree struct {
 }
 
 var _bintree = &bintree{nil, map[string]*bintree{
-	"migrations": {nil, map[string]*bintree{
-		"10_add_trades_price.sql":                                 {migrations10_add_trades_priceSql, map[string]*bintree{}},
-		"11_add_trades_account_index.sql":                         {migrations11_add_trades_account_indexSql, map[string]*bintree{}},
-		"12_asset_stats_amount_string.sql":                        {migrations12_asset_stats_amount_stringSql, map[string]*bintree{}},
-		"13_trade_offer_ids.sql":                                  {migrations13_trade_offer_idsSql, map[string]*bintree{}},
-		"14_fix_asset_toml_field.sql":                             {migrations14_fix_asset_toml_fieldSql, map[string]*bintree{}},
-		"15_ledger_failed_txs.sql":                                {migrations15_ledger_failed_txsSql, map[string]*bintree{}},
-		"16_ingest_failed_transactions.sql":                       {migrations16_ingest_failed_transactionsSql, map[string]*bintree{}},
-		"17_transaction_fee_paid.sql":                             {migrations17_transaction_fee_paidSql, map[string]*bintree{}},
-		"18_account_for_signers.sql":                              {migrations18_account_for_signersSql, map[string]*bintree{}},
-		"19_offers.sql":                                           {migrations19_offersSql, map[string]*bintree{}},
-		"1_initial_schema.sql":                                    {migrations1_initial_schemaSql, map[string]*bintree{}},
-		"20_account_for_signer_index.sql":                         {migrations20_account_for_signer_indexSql, map[string]*bintree{}},
-		"21_trades_remove_zero_amount_constraints.sql":            {migrations21_trades_remove_zero_amount_constraintsSql, map[string]*bintree{}},
-		"22_trust_lines.sql":                                      {migrations22_trust_linesSql, map[string]*bintree{}},
-		"23_exp_asset_stats.sql":                                  {migrations23_exp_asset_statsSql, map[string]*bintree{}},
-		"24_accounts.sql":                                         {migrations24_accountsSql, map[string]*bintree{}},
-		"25_expingest_rename_columns.sql":                         {migrations25_expingest_rename_columnsSql, map[string]*bintree{}},
-		"26_exp_history_ledgers.sql":                              {migrations26_exp_history_ledgersSql, map[string]*bintree{}},
-		"27_exp_history_transactions.sql":                         {migrations27_exp_history_transactionsSql, map[string]*bintree{}},
-		"28_exp_history_operations.sql":                           {migrations28_exp_history_operationsSql, map[string]*bintree{}},
-		"29_exp_history_assets.sql":                               {migrations29_exp_history_assetsSql, map[string]*bintree{}},
-		"2_index_participants_by_toid.sql":                        {migrations2_index_participants_by_toidSql, map[string]*bintree{}},
-		"30_exp_history_trades.sql":                               {migrations30_exp_history_tradesSql, map[string]*bintree{}},
-		"31_exp_history_effects.sql":                              {migrations31_exp_history_effectsSql, map[string]*bintree{}},
-		"32_drop_exp_history_tables.sql":                          {migrations32_drop_exp_history_tablesSql, map[string]*bintree{}},
-		"33_remove_unused.sql":                                    {migrations33_remove_unusedSql, map[string]*bintree{}},
-		"34_fee_bump_transactions.sql":                            {migrations34_fee_bump_transactionsSql, map[string]*bintree{}},
-		"35_drop_participant_id.sql":                              {migrations35_drop_participant_idSql, map[string]*bintree{}},
-		"36_deleted_offers.sql":                                   {migrations36_deleted_offersSql, map[string]*bintree{}},
-		"37_add_tx_set_operation_count_to_ledgers.sql":            {migrations37_add_tx_set_operation_count_to_ledgersSql, map[string]*bintree{}},
-		"38_add_constraints.sql":                                  {migrations38_add_constraintsSql, map[string]*bintree{}},
-		"39_claimable_balances.sql":                               {migrations39_claimable_balancesSql, map[string]*bintree{}},
-		"39_history_trades_indices.sql":                           {migrations39_history_trades_indicesSql, map[string]*bintree{}},
-		"3_use_sequence_in_history_accounts.sql":                  {migrations3_use_sequence_in_history_accountsSql, map[string]*bintree{}},
-		"40_fix_inner_tx_max_fee_constraint.sql":                  {migrations40_fix_inner_tx_max_fee_constraintSql, map[string]*bintree{}},
-		"41_add_sponsor_to_state_tables.sql":                      {migrations41_add_sponsor_to_state_tablesSql, map[string]*bintree{}},
-		"42_add_num_sponsored_and_num_sponsoring_to_accounts.sql": {migrations42_add_num_sponsored_and_num_sponsoring_to_accountsSql, map[string]*bintree{}},
-		"43_add_claimable_balances_flags.sql":                     {migrations43_add_claimable_balances_flagsSql, map[string]*bintree{}},
-		"44_asset_stat_accounts_and_balances.sql":                 {migrations44_asset_stat_accounts_and_balancesSql, map[string]*bintree{}},
-		"45_add_claimable_balances_history.sql":                   {migrations45_add_claimable_balances_historySql, map[string]*bintree{}},
-		"46_add_muxed_accounts.sql":                               {migrations46_add_muxed_accountsSql, map[string]*bintree{}},
-		"47_precompute_trade_aggregations.sql":                    {migrations47_precompute_trade_aggregationsSql, map[string]*bintree{}},
-		"48_rebuild_trade_aggregations.sql":                       {migrations48_rebuild_trade_aggregationsSql, map[string]*bintree{}},
-		"49_add_brin_index_trade_aggregations.sql":                {migrations49_add_brin_index_trade_aggregationsSql, map[string]*bintree{}},
-		"4_add_protocol_version.sql":                              {migrations4_add_protocol_versionSql, map[string]*bintree{}},
-		"50_liquidity_pools.sql":                                  {migrations50_liquidity_poolsSql, map[string]*bintree{}},
-		"51_remove_ht_unused_indexes.sql":                         {migrations51_remove_ht_unused_indexesSql, map[string]*bintree{}},
-		"52_add_trade_type_index.sql":                             {migrations52_add_trade_type_indexSql, map[string]*bintree{}},
-		"53_add_trades_rounding_slippage.sql":                     {migrations53_add_trades_rounding_slippageSql, map[string]*bintree{}},
-		"54_tx_preconditions_and_account_fields.sql":              {migrations54_tx_preconditions_and_account_fieldsSql, map[string]*bintree{}},
-		"55_filter_rules.sql":                                     {migrations55_filter_rulesSql, map[string]*bintree{}},
-		"56_txsub_read_only.sql":                                  {migrations56_txsub_read_onlySql, map[string]*bintree{}},
-		"57_trade_aggregation_autovac.sql":                        {migrations57_trade_aggregation_autovacSql, map[string]*bintree{}},
-		"58_add_index_by_id_optimization.sql":                     {migrations58_add_index_by_id_optimizationSql, map[string]*bintree{}},
-		"59_remove_foreign_key_constraints.sql":                   {migrations59_remove_foreign_key_constraintsSql, map[string]*bintree{}},
-		"5_create_trades_table.sql":                               {migrations5_create_trades_tableSql, map[string]*bintree{}},
-		"60_add_asset_id_indexes.sql":                             {migrations60_add_asset_id_indexesSql, map[string]*bintree{}},
-		"61_trust_lines_by_account_type_code_issuer.sql":          {migrations61_trust_lines_by_account_type_code_issuerSql, map[string]*bintree{}},
-		"62_claimable_balance_claimants.sql":                      {migrations62_claimable_balance_claimantsSql, map[string]*bintree{}},
-		"63_add_contract_id_to_asset_stats.sql":                   {migrations63_add_contract_id_to_asset_statsSql, map[string]*bintree{}},
-		"64_add_payment_flag_history_ops.sql":                     {migrations64_add_payment_flag_history_opsSql, map[string]*bintree{}},
-		"65_drop_payment_index.sql":                               {migrations65_drop_payment_indexSql, map[string]*bintree{}},
-		"66_contract_asset_stats.sql":                             {migrations66_contract_asset_statsSql, map[string]*bintree{}},
-		"67_remove_unused_indexes.sql":                            {migrations67_remove_unused_indexesSql, map[string]*bintree{}},
-		"6_create_assets_table.sql":                               {migrations6_create_assets_tableSql, map[string]*bintree{}},
-		"7_modify_trades_table.sql":                               {migrations7_modify_trades_tableSql, map[string]*bintree{}},
-		"8_add_aggregators.sql":                                   {migrations8_add_aggregatorsSql, map[string]*bintree{}},
-		"8_create_asset_stats_table.sql":                          {migrations8_create_asset_stats_tableSql, map[string]*bintree{}},
-		"9_add_header_xdr.sql":                                    {migrations9_add_header_xdrSql, map[string]*bintree{}},
+	"migrations": &bintree{nil, map[string]*bintree{
+		"10_add_trades_price.sql":                                 &bintree{migrations10_add_trades_priceSql, map[string]*bintree{}},
+		"11_add_trades_account_index.sql":                         &bintree{migrations11_add_trades_account_indexSql, map[string]*bintree{}},
+		"12_asset_stats_amount_string.sql":                        &bintree{migrations12_asset_stats_amount_stringSql, map[string]*bintree{}},
+		"13_trade_offer_ids.sql":                                  &bintree{migrations13_trade_offer_idsSql, map[string]*bintree{}},
+		"14_fix_asset_toml_field.sql":                             &bintree{migrations14_fix_asset_toml_fieldSql, map[string]*bintree{}},
+		"15_ledger_failed_txs.sql":                                &bintree{migrations15_ledger_failed_txsSql, map[string]*bintree{}},
+		"16_ingest_failed_transactions.sql":                       &bintree{migrations16_ingest_failed_transactionsSql, map[string]*bintree{}},
+		"17_transaction_fee_paid.sql":                             &bintree{migrations17_transaction_fee_paidSql, map[string]*bintree{}},
+		"18_account_for_signers.sql":                              &bintree{migrations18_account_for_signersSql, map[string]*bintree{}},
+		"19_offers.sql":                                           &bintree{migrations19_offersSql, map[string]*bintree{}},
+		"1_initial_schema.sql":                                    &bintree{migrations1_initial_schemaSql, map[string]*bintree{}},
+		"20_account_for_signer_index.sql":                         &bintree{migrations20_account_for_signer_indexSql, map[string]*bintree{}},
+		"21_trades_remove_zero_amount_constraints.sql":            &bintree{migrations21_trades_remove_zero_amount_constraintsSql, map[string]*bintree{}},
+		"22_trust_lines.sql":                                      &bintree{migrations22_trust_linesSql, map[string]*bintree{}},
+		"23_exp_asset_stats.sql":                                  &bintree{migrations23_exp_asset_statsSql, map[string]*bintree{}},
+		"24_accounts.sql":                                         &bintree{migrations24_accountsSql, map[string]*bintree{}},
+		"25_expingest_rename_columns.sql":                         &bintree{migrations25_expingest_rename_columnsSql, map[string]*bintree{}},
+		"26_exp_history_ledgers.sql":                              &bintree{migrations26_exp_history_ledgersSql, map[string]*bintree{}},
+		"27_exp_history_transactions.sql":                         &bintree{migrations27_exp_history_transactionsSql, map[string]*bintree{}},
+		"28_exp_history_operations.sql":                           &bintree{migrations28_exp_history_operationsSql, map[string]*bintree{}},
+		"29_exp_history_assets.sql":                               &bintree{migrations29_exp_history_assetsSql, map[string]*bintree{}},
+		"2_index_participants_by_toid.sql":                        &bintree{migrations2_index_participants_by_toidSql, map[string]*bintree{}},
+		"30_exp_history_trades.sql":                               &bintree{migrations30_exp_history_tradesSql, map[string]*bintree{}},
+		"31_exp_history_effects.sql":                              &bintree{migrations31_exp_history_effectsSql, map[string]*bintree{}},
+		"32_drop_exp_history_tables.sql":                          &bintree{migrations32_drop_exp_history_tablesSql, map[string]*bintree{}},
+		"33_remove_unused.sql":                                    &bintree{migrations33_remove_unusedSql, map[string]*bintree{}},
+		"34_fee_bump_transactions.sql":                            &bintree{migrations34_fee_bump_transactionsSql, map[string]*bintree{}},
+		"35_drop_participant_id.sql":                              &bintree{migrations35_drop_participant_idSql, map[string]*bintree{}},
+		"36_deleted_offers.sql":                                   &bintree{migrations36_deleted_offersSql, map[string]*bintree{}},
+		"37_add_tx_set_operation_count_to_ledgers.sql":            &bintree{migrations37_add_tx_set_operation_count_to_ledgersSql, map[string]*bintree{}},
+		"38_add_constraints.sql":                                  &bintree{migrations38_add_constraintsSql, map[string]*bintree{}},
+		"39_claimable_balances.sql":                               &bintree{migrations39_claimable_balancesSql, map[string]*bintree{}},
+		"39_history_trades_indices.sql":                           &bintree{migrations39_history_trades_indicesSql, map[string]*bintree{}},
+		"3_use_sequence_in_history_accounts.sql":                  &bintree{migrations3_use_sequence_in_history_accountsSql, map[string]*bintree{}},
+		"40_fix_inner_tx_max_fee_constraint.sql":                  &bintree{migrations40_fix_inner_tx_max_fee_constraintSql, map[string]*bintree{}},
+		"41_add_sponsor_to_state_tables.sql":                      &bintree{migrations41_add_sponsor_to_state_tablesSql, map[string]*bintree{}},
+		"42_add_num_sponsored_and_num_sponsoring_to_accounts.sql": &bintree{migrations42_add_num_sponsored_and_num_sponsoring_to_accountsSql, map[string]*bintree{}},
+		"43_add_claimable_balances_flags.sql":                     &bintree{migrations43_add_claimable_balances_flagsSql, map[string]*bintree{}},
+		"44_asset_stat_accounts_and_balances.sql":                 &bintree{migrations44_asset_stat_accounts_and_balancesSql, map[string]*bintree{}},
+		"45_add_claimable_balances_history.sql":                   &bintree{migrations45_add_claimable_balances_historySql, map[string]*bintree{}},
+		"46_add_muxed_accounts.sql":                               &bintree{migrations46_add_muxed_accountsSql, map[string]*bintree{}},
+		"47_precompute_trade_aggregations.sql":                    &bintree{migrations47_precompute_trade_aggregationsSql, map[string]*bintree{}},
+		"48_rebuild_trade_aggregations.sql":                       &bintree{migrations48_rebuild_trade_aggregationsSql, map[string]*bintree{}},
+		"49_add_brin_index_trade_aggregations.sql":                &bintree{migrations49_add_brin_index_trade_aggregationsSql, map[string]*bintree{}},
+		"4_add_protocol_version.sql":                              &bintree{migrations4_add_protocol_versionSql, map[string]*bintree{}},
+		"50_liquidity_pools.sql":                                  &bintree{migrations50_liquidity_poolsSql, map[string]*bintree{}},
+		"51_remove_ht_unused_indexes.sql":                         &bintree{migrations51_remove_ht_unused_indexesSql, map[string]*bintree{}},
+		"52_add_trade_type_index.sql":                             &bintree{migrations52_add_trade_type_indexSql, map[string]*bintree{}},
+		"53_add_trades_rounding_slippage.sql":                     &bintree{migrations53_add_trades_rounding_slippageSql, map[string]*bintree{}},
+		"54_tx_preconditions_and_account_fields.sql":              &bintree{migrations54_tx_preconditions_and_account_fieldsSql, map[string]*bintree{}},
+		"55_filter_rules.sql":                                     &bintree{migrations55_filter_rulesSql, map[string]*bintree{}},
+		"56_txsub_read_only.sql":                                  &bintree{migrations56_txsub_read_onlySql, map[string]*bintree{}},
+		"57_trade_aggregation_autovac.sql":                        &bintree{migrations57_trade_aggregation_autovacSql, map[string]*bintree{}},
+		"58_add_index_by_id_optimization.sql":                     &bintree{migrations58_add_index_by_id_optimizationSql, map[string]*bintree{}},
+		"59_remove_foreign_key_constraints.sql":                   &bintree{migrations59_remove_foreign_key_constraintsSql, map[string]*bintree{}},
+		"5_create_trades_table.sql":                               &bintree{migrations5_create_trades_tableSql, map[string]*bintree{}},
+		"60_add_asset_id_indexes.sql":                             &bintree{migrations60_add_asset_id_indexesSql, map[string]*bintree{}},
+		"61_trust_lines_by_account_type_code_issuer.sql":          &bintree{migrations61_trust_lines_by_account_type_code_issuerSql, map[string]*bintree{}},
+		"62_claimable_balance_claimants.sql":                      &bintree{migrations62_claimable_balance_claimantsSql, map[string]*bintree{}},
+		"63_add_contract_id_to_asset_stats.sql":                   &bintree{migrations63_add_contract_id_to_asset_statsSql, map[string]*bintree{}},
+		"64_add_payment_flag_history_ops.sql":                     &bintree{migrations64_add_payment_flag_history_opsSql, map[string]*bintree{}},
+		"65_drop_payment_index.sql":                               &bintree{migrations65_drop_payment_indexSql, map[string]*bintree{}},
+		"66_contract_asset_stats.sql":                             &bintree{migrations66_contract_asset_statsSql, map[string]*bintree{}},
+		"67_remove_unused_indexes.sql":                            &bintree{migrations67_remove_unused_indexesSql, map[string]*bintree{}},
+		"68_online_migrations.sql":                                &bintree{migrations68_online_migrationsSql, map[string]*bintree{}},
+		"6_create_assets_table.sql":                               &bintree{migrations6_create_assets_tableSql, map[string]*bintree{}},
+		"7_modify_trades_table.sql":                               &bintree{migrations7_modify_trades_tableSql, map[string]*bintree{}},
+		"8_add_aggregators.sql":                                   &bintree{migrations8_add_aggregatorsSql, map[string]*bintree{}},
+		"8_create_asset_stats_table.sql":                          &bintree{migrations8_create_asset_stats_tableSql, map[string]*bintree{}},
+		"9_add_header_xdr.sql":                                    &bintree{migrations9_add_header_xdrSql, map[string]*bintree{}},
 	}},
 }}
 
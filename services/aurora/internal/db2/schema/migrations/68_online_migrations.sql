@@ -0,0 +1,18 @@
+-- +migrate Up
+
+-- Tracks the progress of online migrations (see the db2/schema/online
+-- package), which rewrite large tables in the background.
+CREATE TABLE aurora_online_migrations (
+    id character varying(255) PRIMARY KEY,
+    table_name character varying(255) NOT NULL,
+    state character varying(32) NOT NULL,
+    backfill_start bigint NOT NULL,
+    backfill_cursor bigint NOT NULL,
+    backfill_end bigint NOT NULL,
+    rows_copied bigint NOT NULL DEFAULT 0,
+    started_at timestamp without time zone NOT NULL,
+    updated_at timestamp without time zone NOT NULL
+);
+
+-- +migrate Down
+DROP TABLE aurora_online_migrations cascade;
@@ -0,0 +1,628 @@
+// Package online implements online schema migrations, which change the shape
+// of large tables such as history_operations and history_effects without
+// locking them for the duration of the change.
+//
+// An online migration goes through the following steps, each of which is run
+// by a separate `aurora db migrate online` command:
+//
+//   - start creates the new table next to the old one and installs triggers
+//     on the old table which double-write every row ingested (or deleted)
+//     from then on into the new table.
+//   - backfill copies the rows which existed before the migration started in
+//     batches, each committed in its own transaction, and then builds the
+//     new table's indexes concurrently. It can be interrupted and resumed.
+//   - swap atomically renames the new table to take the old table's place.
+//     It only holds an exclusive lock on the old table for the duration of
+//     the renames.
+//   - cleanup drops the old table.
+//
+// A migration can be aborted at any point before it is swapped. The progress
+// of each migration is stored in the aurora_online_migrations table.
+//
+// Once swapped, the new table receives the writes of the running aurora, so
+// it must accept them, e.g. any column the running version doesn't know about
+// needs a default.
+package online
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/shantanu-hashcash/go/support/db"
+	"github.com/shantanu-hashcash/go/support/errors"
+	"github.com/shantanu-hashcash/go/support/log"
+)
+
+// State is the stage an online migration has reached.
+type State string
+
+const (
+	// StatePending is the state of migrations which haven't been started.
+	StatePending State = "pending"
+	// StateBackfilling is the state of migrations which are double-writing
+	// and whose backfill hasn't completed yet.
+	StateBackfilling State = "backfilling"
+	// StateReady is the state of migrations which are double-writing and
+	// whose new table is complete, so it can be swapped in.
+	StateReady State = "ready"
+	// StateSwapped is the state of migrations whose new table replaced the
+	// old one, which still needs to be dropped.
+	StateSwapped State = "swapped"
+	// StateDone is the state of completed migrations.
+	StateDone State = "done"
+)
+
+const (
+	// DefaultBatchSize is the default number of rows copied per backfill
+	// transaction.
+	DefaultBatchSize = 10000
+	// DefaultLockTimeout is the default time start and swap wait for the locks
+	// they need on the migrated table before giving up.
+	DefaultLockTimeout = 5 * time.Second
+)
+
+// Column is a column of the new table.
+type Column struct {
+	// Name is the name of the column.
+	Name string
+	// Expr computes the column's value from a row of the old table, which is
+	// available as `src`. It defaults to the old table's column of the same
+	// name.
+	Expr string
+}
+
+// Index is an index of the new table.
+type Index struct {
+	// Name is the name of the index once the new table has been swapped in.
+	// An index of the old table with the same name is replaced.
+	Name   string
+	Unique bool
+	// Definition is what follows `ON table` in the CREATE INDEX statement,
+	// e.g. `USING btree (history_operation_id, "order")`.
+	Definition string
+}
+
+// Migration describes an online migration of a table to a new shape.
+type Migration struct {
+	// ID uniquely identifies the migration.
+	ID string
+	// Table is the table which is migrated.
+	Table string
+	// Key are the columns which identify a row, both in the old and in the
+	// new table. The first one must be a bigint by which the old table is
+	// indexed, like history_operations.id, as the backfill pages through it.
+	Key []string
+	// CreateTable returns the statement which creates the new table, without
+	// any indexes, under the given name.
+	CreateTable func(name string) string
+	// Columns are the columns of the new table.
+	Columns []Column
+	// Indexes are all the indexes of the new table. The indexes of the old
+	// table which aren't listed are dropped with it.
+	Indexes []Index
+}
+
+// Validate checks that m is well formed.
+func (m Migration) Validate() error {
+	switch {
+	case m.ID == "":
+		return errors.New("migration id is empty")
+	case m.Table == "":
+		return errors.Errorf("migration %s has no table", m.ID)
+	case len(m.Key) == 0:
+		return errors.Errorf("migration %s has no key", m.ID)
+	case m.CreateTable == nil:
+		return errors.Errorf("migration %s has no CreateTable", m.ID)
+	case len(m.Columns) == 0:
+		return errors.Errorf("migration %s has no columns", m.ID)
+	}
+	for _, index := range m.Indexes {
+		if index.Name == "" || index.Definition == "" {
+			return errors.Errorf("migration %s has an incomplete index", m.ID)
+		}
+	}
+	return nil
+}
+
+func (m Migration) newTable() string {
+	return m.Table + "_online"
+}
+
+func (m Migration) oldTable() string {
+	return m.Table + "_retired"
+}
+
+func (m Migration) keyIndex() string {
+	return m.newTable() + "_key"
+}
+
+func (m Migration) doubleWriteFunction() string {
+	return m.newTable() + "_double_write"
+}
+
+func (m Migration) truncateFunction() string {
+	return m.newTable() + "_truncate"
+}
+
+func quoteAll(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = pq.QuoteIdentifier(name)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// columns returns the list of the new table's columns and the list of the
+// expressions computing them from `src`.
+func (m Migration) columns() (string, string) {
+	names := make([]string, len(m.Columns))
+	exprs := make([]string, len(m.Columns))
+	for i, column := range m.Columns {
+		names[i] = column.Name
+		exprs[i] = column.Expr
+		if exprs[i] == "" {
+			exprs[i] = "src." + pq.QuoteIdentifier(column.Name)
+		}
+	}
+	return quoteAll(names), strings.Join(exprs, ", ")
+}
+
+// Status is the progress of an online migration.
+type Status struct {
+	ID    string `db:"id"`
+	Table string `db:"table_name"`
+	State State  `db:"state"`
+	// The backfill copies the rows whose first key column is in
+	// (BackfillStart, BackfillEnd], and has copied those up to BackfillCursor.
+	BackfillStart  int64     `db:"backfill_start"`
+	BackfillCursor int64     `db:"backfill_cursor"`
+	BackfillEnd    int64     `db:"backfill_end"`
+	RowsCopied     int64     `db:"rows_copied"`
+	StartedAt      time.Time `db:"started_at"`
+	UpdatedAt      time.Time `db:"updated_at"`
+}
+
+// Progress returns the fraction of the key range which has been backfilled.
+func (s Status) Progress() float64 {
+	switch {
+	case s.State == StatePending:
+		return 0
+	case s.State != StateBackfilling || s.BackfillEnd <= s.BackfillStart:
+		return 1
+	default:
+		return float64(s.BackfillCursor-s.BackfillStart) / float64(s.BackfillEnd-s.BackfillStart)
+	}
+}
+
+// Migrator runs online migrations.
+type Migrator struct {
+	Session db.SessionInterface
+	// BatchSize is the number of rows copied per backfill transaction,
+	// DefaultBatchSize by default.
+	BatchSize int
+	// LockTimeout bounds the time spent waiting for locks on the migrated
+	// table, DefaultLockTimeout by default.
+	LockTimeout time.Duration
+}
+
+func (m *Migrator) batchSize() int {
+	if m.BatchSize <= 0 {
+		return DefaultBatchSize
+	}
+	return m.BatchSize
+}
+
+func (m *Migrator) setLockTimeout(ctx context.Context) error {
+	timeout := m.LockTimeout
+	if timeout <= 0 {
+		timeout = DefaultLockTimeout
+	}
+	_, err := m.Session.ExecRaw(ctx, fmt.Sprintf("SET LOCAL lock_timeout = %d", timeout.Milliseconds()))
+	return err
+}
+
+// Status returns the status of the given migrations, which are pending if
+// they haven't been started.
+func (m *Migrator) Status(ctx context.Context, migrations []Migration) ([]Status, error) {
+	var started []Status
+	err := m.Session.SelectRaw(ctx, &started, "SELECT * FROM aurora_online_migrations")
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load online migrations")
+	}
+	byID := map[string]Status{}
+	for _, status := range started {
+		byID[status.ID] = status
+	}
+
+	statuses := make([]Status, len(migrations))
+	for i, migration := range migrations {
+		status, ok := byID[migration.ID]
+		if !ok {
+			status = Status{ID: migration.ID, Table: migration.Table, State: StatePending}
+		}
+		statuses[i] = status
+	}
+	return statuses, nil
+}
+
+// lockStatus loads and locks the status of a started migration, which must be
+// run in a transaction.
+func (m *Migrator) lockStatus(ctx context.Context, migration Migration) (Status, error) {
+	var status Status
+	err := m.Session.GetRaw(ctx, &status,
+		"SELECT * FROM aurora_online_migrations WHERE id = $1 FOR UPDATE", migration.ID)
+	if m.Session.NoRows(err) {
+		return Status{State: StatePending}, nil
+	}
+	if err != nil {
+		return status, errors.Wrap(err, "could not load online migration")
+	}
+	return status, nil
+}
+
+func (m *Migrator) setState(ctx context.Context, migration Migration, state State) error {
+	_, err := m.Session.ExecRaw(ctx,
+		"UPDATE aurora_online_migrations SET state = $2, updated_at = now() WHERE id = $1",
+		migration.ID, state)
+	return errors.Wrap(err, "could not update online migration")
+}
+
+// inTransaction runs fn in a transaction which is committed if fn succeeds.
+func (m *Migrator) inTransaction(ctx context.Context, fn func() error) error {
+	if err := m.Session.Begin(ctx); err != nil {
+		return err
+	}
+	defer m.Session.Rollback()
+
+	if err := fn(); err != nil {
+		return err
+	}
+	return m.Session.Commit()
+}
+
+func (m *Migrator) execAll(ctx context.Context, statements ...string) error {
+	for _, statement := range statements {
+		if _, err := m.Session.ExecRaw(ctx, statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Start creates the new table and starts double-writing into it.
+func (m *Migrator) Start(ctx context.Context, migration Migration) error {
+	if err := migration.Validate(); err != nil {
+		return err
+	}
+
+	return m.inTransaction(ctx, func() error {
+		status, err := m.lockStatus(ctx, migration)
+		if err != nil {
+			return err
+		}
+		if status.State != StatePending {
+			return errors.Errorf("migration %s is already %s", migration.ID, status.State)
+		}
+
+		var running int
+		err = m.Session.GetRaw(ctx, &running,
+			"SELECT count(*) FROM aurora_online_migrations WHERE table_name = $1 AND state <> $2",
+			migration.Table, StateDone)
+		if err != nil {
+			return errors.Wrap(err, "could not load online migrations")
+		}
+		if running > 0 {
+			return errors.Errorf("another online migration of %s is in progress", migration.Table)
+		}
+
+		if err = m.setLockTimeout(ctx); err != nil {
+			return err
+		}
+
+		table := pq.QuoteIdentifier(migration.Table)
+		newTable := pq.QuoteIdentifier(migration.newTable())
+		key := quoteAll(migration.Key)
+		columns, exprs := migration.columns()
+		keyMatches := make([]string, len(migration.Key))
+		for i, column := range migration.Key {
+			keyMatches[i] = fmt.Sprintf("%s = OLD.%s", pq.QuoteIdentifier(column), pq.QuoteIdentifier(column))
+		}
+
+		err = m.execAll(ctx,
+			migration.CreateTable(migration.newTable()),
+			fmt.Sprintf("CREATE UNIQUE INDEX %s ON %s (%s)",
+				pq.QuoteIdentifier(migration.keyIndex()), newTable, key),
+			fmt.Sprintf(`CREATE FUNCTION %s() RETURNS trigger LANGUAGE plpgsql AS $$
+BEGIN
+	IF TG_OP IN ('UPDATE', 'DELETE') THEN
+		DELETE FROM %s WHERE %s;
+	END IF;
+	IF TG_OP IN ('INSERT', 'UPDATE') THEN
+		INSERT INTO %s (%s) SELECT %s FROM (SELECT NEW.*) AS src ON CONFLICT (%s) DO NOTHING;
+	END IF;
+	RETURN NULL;
+END $$`,
+				pq.QuoteIdentifier(migration.doubleWriteFunction()),
+				newTable, strings.Join(keyMatches, " AND "),
+				newTable, columns, exprs, key),
+			fmt.Sprintf(`CREATE FUNCTION %s() RETURNS trigger LANGUAGE plpgsql AS $$
+BEGIN
+	TRUNCATE %s;
+	RETURN NULL;
+END $$`, pq.QuoteIdentifier(migration.truncateFunction()), newTable),
+			// Creating the triggers waits for the writes in progress on the
+			// table, so every row written after they are in place is either
+			// double-written or below the backfill end computed below.
+			fmt.Sprintf("CREATE TRIGGER %s AFTER INSERT OR UPDATE OR DELETE ON %s FOR EACH ROW EXECUTE PROCEDURE %s()",
+				pq.QuoteIdentifier(migration.doubleWriteFunction()), table,
+				pq.QuoteIdentifier(migration.doubleWriteFunction())),
+			fmt.Sprintf("CREATE TRIGGER %s AFTER TRUNCATE ON %s FOR EACH STATEMENT EXECUTE PROCEDURE %s()",
+				pq.QuoteIdentifier(migration.truncateFunction()), table,
+				pq.QuoteIdentifier(migration.truncateFunction())),
+		)
+		if err != nil {
+			return errors.Wrap(err, "could not create new table")
+		}
+
+		cursor := pq.QuoteIdentifier(migration.Key[0])
+		_, err = m.Session.ExecRaw(ctx, fmt.Sprintf(`INSERT INTO aurora_online_migrations
+			(id, table_name, state, backfill_start, backfill_cursor, backfill_end, started_at, updated_at)
+			SELECT $1, $2, $3, start, start, finish, now(), now()
+			FROM (SELECT COALESCE(MIN(%s) - 1, 0) AS start, COALESCE(MAX(%s), 0) AS finish FROM %s) AS bounds`,
+			cursor, cursor, table),
+			migration.ID, migration.Table, StateBackfilling)
+		return errors.Wrap(err, "could not insert online migration")
+	})
+}
+
+// Backfill copies the rows which predate the start of the migration into the
+// new table, resuming from where a previous Backfill stopped, and builds the
+// new table's indexes.
+func (m *Migrator) Backfill(ctx context.Context, migration Migration) error {
+	if err := migration.Validate(); err != nil {
+		return err
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		done := false
+		err := m.inTransaction(ctx, func() error {
+			status, err := m.lockStatus(ctx, migration)
+			if err != nil {
+				return err
+			}
+			if status.State != StateBackfilling {
+				return errors.Errorf("migration %s is %s, not %s", migration.ID, status.State, StateBackfilling)
+			}
+			if status.BackfillCursor >= status.BackfillEnd {
+				done = true
+				return nil
+			}
+			return m.copyBatch(ctx, migration, status)
+		})
+		if err != nil {
+			return err
+		}
+		if done {
+			break
+		}
+	}
+
+	if err := m.createIndexes(ctx, migration); err != nil {
+		return err
+	}
+	if _, err := m.Session.ExecRaw(ctx, "ANALYZE "+pq.QuoteIdentifier(migration.newTable())); err != nil {
+		return errors.Wrap(err, "could not analyze new table")
+	}
+	return m.setState(ctx, migration, StateReady)
+}
+
+func (m *Migrator) copyBatch(ctx context.Context, migration Migration, status Status) error {
+	table := pq.QuoteIdentifier(migration.Table)
+	cursor := pq.QuoteIdentifier(migration.Key[0])
+
+	// The batch ends at the key of its last row, rounded up to include all
+	// the rows sharing it.
+	batchEnd := status.BackfillEnd
+	var keys []int64
+	err := m.Session.SelectRaw(ctx, &keys, fmt.Sprintf(
+		"SELECT %s FROM %s WHERE %s > $1 AND %s <= $2 ORDER BY %s OFFSET $3 LIMIT 1",
+		cursor, table, cursor, cursor, cursor),
+		status.BackfillCursor, status.BackfillEnd, m.batchSize()-1)
+	if err != nil {
+		return errors.Wrap(err, "could not find backfill batch")
+	}
+	if len(keys) > 0 {
+		batchEnd = keys[0]
+	}
+
+	// Locking the copied rows prevents a concurrent delete, whose trigger
+	// would find nothing to delete yet, from leaving them in the new table.
+	columns, exprs := migration.columns()
+	result, err := m.Session.ExecRaw(ctx, fmt.Sprintf(
+		"INSERT INTO %s (%s) SELECT %s FROM %s AS src WHERE src.%s > $1 AND src.%s <= $2 FOR SHARE ON CONFLICT (%s) DO NOTHING",
+		pq.QuoteIdentifier(migration.newTable()), columns, exprs, table, cursor, cursor, quoteAll(migration.Key)),
+		status.BackfillCursor, batchEnd)
+	if err != nil {
+		return errors.Wrap(err, "could not copy backfill batch")
+	}
+	copied, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	_, err = m.Session.ExecRaw(ctx, `UPDATE aurora_online_migrations
+		SET backfill_cursor = $2, rows_copied = rows_copied + $3, updated_at = now()
+		WHERE id = $1`, migration.ID, batchEnd, copied)
+	if err != nil {
+		return errors.Wrap(err, "could not update online migration")
+	}
+
+	status.BackfillCursor = batchEnd
+	log.WithFields(log.F{
+		"migration": migration.ID,
+		"cursor":    batchEnd,
+		"copied":    copied,
+		"progress":  fmt.Sprintf("%.2f%%", 100*status.Progress()),
+	}).Info("Backfilled batch")
+	return nil
+}
+
+// createIndexes builds the indexes of the new table concurrently, rebuilding
+// those left invalid by an interrupted build.
+func (m *Migrator) createIndexes(ctx context.Context, migration Migration) error {
+	for _, index := range migration.Indexes {
+		name := index.Name + "_online"
+
+		var valid []bool
+		err := m.Session.SelectRaw(ctx, &valid,
+			"SELECT indisvalid FROM pg_index WHERE indexrelid = to_regclass($1)", name)
+		if err != nil {
+			return errors.Wrapf(err, "could not check index %s", name)
+		}
+		if len(valid) > 0 && valid[0] {
+			continue
+		}
+		if len(valid) > 0 {
+			if _, err = m.Session.ExecRaw(ctx, "DROP INDEX CONCURRENTLY "+pq.QuoteIdentifier(name)); err != nil {
+				return errors.Wrapf(err, "could not drop invalid index %s", name)
+			}
+		}
+
+		unique := ""
+		if index.Unique {
+			unique = "UNIQUE "
+		}
+		log.WithField("migration", migration.ID).Infof("Creating index %s", index.Name)
+		_, err = m.Session.ExecRaw(ctx, fmt.Sprintf("CREATE %sINDEX CONCURRENTLY %s ON %s %s",
+			unique, pq.QuoteIdentifier(name), pq.QuoteIdentifier(migration.newTable()), index.Definition))
+		if err != nil {
+			return errors.Wrapf(err, "could not create index %s", name)
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) dropDoubleWrites(ctx context.Context, migration Migration) error {
+	table := pq.QuoteIdentifier(migration.Table)
+	return m.execAll(ctx,
+		fmt.Sprintf("DROP TRIGGER IF EXISTS %s ON %s", pq.QuoteIdentifier(migration.doubleWriteFunction()), table),
+		fmt.Sprintf("DROP TRIGGER IF EXISTS %s ON %s", pq.QuoteIdentifier(migration.truncateFunction()), table),
+		"DROP FUNCTION IF EXISTS "+pq.QuoteIdentifier(migration.doubleWriteFunction())+"()",
+		"DROP FUNCTION IF EXISTS "+pq.QuoteIdentifier(migration.truncateFunction())+"()",
+	)
+}
+
+// Swap replaces the old table with the new one, which must be ready.
+func (m *Migrator) Swap(ctx context.Context, migration Migration) error {
+	if err := migration.Validate(); err != nil {
+		return err
+	}
+
+	return m.inTransaction(ctx, func() error {
+		status, err := m.lockStatus(ctx, migration)
+		if err != nil {
+			return err
+		}
+		if status.State != StateReady {
+			return errors.Errorf("migration %s is %s, not %s", migration.ID, status.State, StateReady)
+		}
+
+		if err = m.setLockTimeout(ctx); err != nil {
+			return err
+		}
+		if _, err = m.Session.ExecRaw(ctx,
+			"LOCK TABLE "+pq.QuoteIdentifier(migration.Table)+" IN ACCESS EXCLUSIVE MODE"); err != nil {
+			return errors.Wrap(err, "could not lock table")
+		}
+		if err = m.dropDoubleWrites(ctx, migration); err != nil {
+			return errors.Wrap(err, "could not stop double-writes")
+		}
+
+		statements := []string{
+			fmt.Sprintf("ALTER TABLE %s RENAME TO %s",
+				pq.QuoteIdentifier(migration.Table), pq.QuoteIdentifier(migration.oldTable())),
+		}
+		for _, index := range migration.Indexes {
+			statements = append(statements,
+				fmt.Sprintf("ALTER INDEX IF EXISTS %s RENAME TO %s",
+					pq.QuoteIdentifier(index.Name), pq.QuoteIdentifier(index.Name+"_retired")),
+				fmt.Sprintf("ALTER INDEX %s RENAME TO %s",
+					pq.QuoteIdentifier(index.Name+"_online"), pq.QuoteIdentifier(index.Name)),
+			)
+		}
+		statements = append(statements, fmt.Sprintf("ALTER TABLE %s RENAME TO %s",
+			pq.QuoteIdentifier(migration.newTable()), pq.QuoteIdentifier(migration.Table)))
+		if err = m.execAll(ctx, statements...); err != nil {
+			return errors.Wrap(err, "could not swap tables")
+		}
+
+		return m.setState(ctx, migration, StateSwapped)
+	})
+}
+
+// Cleanup drops the old table of a swapped migration.
+func (m *Migrator) Cleanup(ctx context.Context, migration Migration) error {
+	if err := migration.Validate(); err != nil {
+		return err
+	}
+
+	return m.inTransaction(ctx, func() error {
+		status, err := m.lockStatus(ctx, migration)
+		if err != nil {
+			return err
+		}
+		if status.State != StateSwapped {
+			return errors.Errorf("migration %s is %s, not %s", migration.ID, status.State, StateSwapped)
+		}
+
+		// The key index only made backfilling and double-writing idempotent.
+		err = m.execAll(ctx,
+			"DROP TABLE "+pq.QuoteIdentifier(migration.oldTable()),
+			"DROP INDEX IF EXISTS "+pq.QuoteIdentifier(migration.keyIndex()),
+		)
+		if err != nil {
+			return errors.Wrap(err, "could not drop old table")
+		}
+		return m.setState(ctx, migration, StateDone)
+	})
+}
+
+// Abort stops a migration which hasn't been swapped yet and drops its new
+// table, so that it can be started again.
+func (m *Migrator) Abort(ctx context.Context, migration Migration) error {
+	if err := migration.Validate(); err != nil {
+		return err
+	}
+
+	return m.inTransaction(ctx, func() error {
+		status, err := m.lockStatus(ctx, migration)
+		if err != nil {
+			return err
+		}
+		if status.State != StateBackfilling && status.State != StateReady {
+			return errors.Errorf("migration %s is %s and can't be aborted", migration.ID, status.State)
+		}
+
+		if err = m.setLockTimeout(ctx); err != nil {
+			return err
+		}
+		if err = m.dropDoubleWrites(ctx, migration); err != nil {
+			return errors.Wrap(err, "could not stop double-writes")
+		}
+		err = m.execAll(ctx, "DROP TABLE "+pq.QuoteIdentifier(migration.newTable()))
+		if err != nil {
+			return errors.Wrap(err, "could not drop new table")
+		}
+
+		_, err = m.Session.ExecRaw(ctx, "DELETE FROM aurora_online_migrations WHERE id = $1", migration.ID)
+		return errors.Wrap(err, "could not delete online migration")
+	})
+}
@@ -0,0 +1,250 @@
+package online
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shantanu-hashcash/go/services/aurora/internal/db2/schema"
+	"github.com/shantanu-hashcash/go/support/db"
+	"github.com/shantanu-hashcash/go/support/db/dbtest"
+)
+
+var testMigration = Migration{
+	ID:    "test_history_effects_is_trade",
+	Table: "history_effects",
+	Key:   []string{"history_operation_id", "order"},
+	CreateTable: func(name string) string {
+		return fmt.Sprintf(`CREATE TABLE %s (
+			history_account_id bigint NOT NULL,
+			history_operation_id bigint NOT NULL,
+			"order" integer NOT NULL,
+			type integer NOT NULL,
+			details jsonb,
+			address_muxed character varying(69),
+			is_trade boolean NOT NULL
+		)`, name)
+	},
+	Columns: []Column{
+		{Name: "history_account_id"},
+		{Name: "history_operation_id"},
+		{Name: "order"},
+		{Name: "type"},
+		{Name: "details"},
+		{Name: "address_muxed"},
+		{Name: "is_trade", Expr: "src.type = 33"},
+	},
+	Indexes: []Index{
+		{Name: "hist_e_by_order", Unique: true, Definition: `USING btree (history_operation_id, "order")`},
+		{Name: "hist_e_id", Unique: true, Definition: `USING btree (history_account_id, history_operation_id, "order")`},
+		{Name: "hist_e_trades", Definition: "USING btree (history_operation_id) WHERE is_trade"},
+	},
+}
+
+func setup(t *testing.T) (*dbtest.DB, *db.Session) {
+	tdb := dbtest.Postgres(t)
+	session := &db.Session{DB: tdb.Open()}
+	_, err := schema.Migrate(session.DB.DB, schema.MigrateUp, 0)
+	require.NoError(t, err)
+	return tdb, session
+}
+
+func insertEffect(t *testing.T, session *db.Session, operationID int64, effectType int) {
+	_, err := session.ExecRaw(context.Background(),
+		`INSERT INTO history_effects (history_account_id, history_operation_id, "order", type, details)
+		VALUES (1, $1, 1, $2, '{}')`, operationID, effectType)
+	require.NoError(t, err)
+}
+
+func effects(t *testing.T, session *db.Session, table string) map[int64]bool {
+	var rows []struct {
+		OperationID int64 `db:"history_operation_id"`
+		IsTrade     bool  `db:"is_trade"`
+	}
+	require.NoError(t, session.SelectRaw(context.Background(), &rows,
+		"SELECT history_operation_id, is_trade FROM "+table))
+	result := map[int64]bool{}
+	for _, row := range rows {
+		result[row.OperationID] = row.IsTrade
+	}
+	return result
+}
+
+func TestMigration(t *testing.T) {
+	tdb, session := setup(t)
+	defer tdb.Close()
+	defer session.Close()
+	ctx := context.Background()
+	migrator := &Migrator{Session: session, BatchSize: 1}
+
+	insertEffect(t, session, 1, 33)
+	insertEffect(t, session, 2, 0)
+	insertEffect(t, session, 3, 33)
+
+	require.NoError(t, migrator.Start(ctx, testMigration))
+	assert.Error(t, migrator.Start(ctx, testMigration))
+	assert.Error(t, migrator.Swap(ctx, testMigration))
+
+	// double-writes
+	insertEffect(t, session, 4, 33)
+	_, err := session.ExecRaw(ctx, "DELETE FROM history_effects WHERE history_operation_id = 1")
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]bool{4: true}, effects(t, session, "history_effects_online"))
+
+	statuses, err := migrator.Status(ctx, []Migration{testMigration})
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+	assert.Equal(t, StateBackfilling, statuses[0].State)
+	assert.Equal(t, int64(0), statuses[0].BackfillStart)
+	assert.Equal(t, int64(3), statuses[0].BackfillEnd)
+	assert.Equal(t, 0.0, statuses[0].Progress())
+
+	require.NoError(t, migrator.Backfill(ctx, testMigration))
+	statuses, err = migrator.Status(ctx, []Migration{testMigration})
+	require.NoError(t, err)
+	assert.Equal(t, StateReady, statuses[0].State)
+	assert.Equal(t, int64(3), statuses[0].BackfillCursor)
+	assert.Equal(t, int64(2), statuses[0].RowsCopied)
+	assert.Equal(t, 1.0, statuses[0].Progress())
+
+	insertEffect(t, session, 5, 0)
+	require.NoError(t, migrator.Swap(ctx, testMigration))
+	assert.Equal(t,
+		map[int64]bool{2: false, 3: true, 4: true, 5: false},
+		effects(t, session, "history_effects"),
+	)
+
+	// writes go to the new table only
+	insertEffect(t, session, 6, 33)
+	var count int
+	require.NoError(t, session.GetRaw(ctx, &count, "SELECT count(*) FROM history_effects_retired"))
+	assert.Equal(t, 4, count)
+
+	require.NoError(t, migrator.Cleanup(ctx, testMigration))
+	var indexes []string
+	require.NoError(t, session.SelectRaw(ctx, &indexes,
+		"SELECT indexname FROM pg_indexes WHERE tablename = 'history_effects' ORDER BY indexname"))
+	assert.Equal(t, []string{"hist_e_by_order", "hist_e_id", "hist_e_trades"}, indexes)
+
+	statuses, err = migrator.Status(ctx, []Migration{testMigration})
+	require.NoError(t, err)
+	assert.Equal(t, StateDone, statuses[0].State)
+}
+
+func TestAbort(t *testing.T) {
+	tdb, session := setup(t)
+	defer tdb.Close()
+	defer session.Close()
+	ctx := context.Background()
+	migrator := &Migrator{Session: session}
+
+	insertEffect(t, session, 1, 33)
+	require.NoError(t, migrator.Start(ctx, testMigration))
+	require.NoError(t, migrator.Abort(ctx, testMigration))
+
+	insertEffect(t, session, 2, 33)
+	statuses, err := migrator.Status(ctx, []Migration{testMigration})
+	require.NoError(t, err)
+	assert.Equal(t, StatePending, statuses[0].State)
+
+	// the migration can be started again
+	require.NoError(t, migrator.Start(ctx, testMigration))
+	require.NoError(t, migrator.Backfill(ctx, testMigration))
+	assert.Equal(t, map[int64]bool{1: true, 2: true}, effects(t, session, "history_effects_online"))
+}
+
+// TestMigrationConcurrentWrites runs a migration end to end while effects are
+// ingested and the oldest history is reaped, and checks that the swapped in
+// table ends up with exactly the rows of the old one.
+func TestMigrationConcurrentWrites(t *testing.T) {
+	tdb, session := setup(t)
+	defer tdb.Close()
+	defer session.Close()
+	ctx := context.Background()
+	migrator := &Migrator{Session: session, BatchSize: 3}
+
+	expected := map[int64]bool{}
+	for id := int64(1); id <= 200; id++ {
+		effectType := 0
+		if id%3 == 0 {
+			effectType = 33
+		}
+		insertEffect(t, session, id, effectType)
+		if id > 100 {
+			expected[id] = effectType == 33
+		}
+	}
+
+	require.NoError(t, migrator.Start(ctx, testMigration))
+
+	// ingestion keeps inserting effects until the migration is swapped
+	stopIngesting := make(chan struct{})
+	ingested := make(chan map[int64]bool, 1)
+	ingestErr := make(chan error, 1)
+	go func() {
+		writer := &db.Session{DB: tdb.Open()}
+		defer writer.Close()
+		rows := map[int64]bool{}
+		for id := int64(1001); ; id++ {
+			select {
+			case <-stopIngesting:
+				ingested <- rows
+				return
+			default:
+			}
+			effectType := 0
+			if id%2 == 0 {
+				effectType = 33
+			}
+			_, err := writer.ExecRaw(ctx,
+				`INSERT INTO history_effects (history_account_id, history_operation_id, "order", type, details)
+				VALUES (1, $1, 1, $2, '{}')`, id, effectType)
+			if err != nil {
+				ingestErr <- err
+				ingested <- rows
+				return
+			}
+			rows[id] = effectType == 33
+		}
+	}()
+
+	// the reaper deletes the oldest effects while they are backfilled
+	reaped := make(chan error, 1)
+	go func() {
+		reaper := &db.Session{DB: tdb.Open()}
+		defer reaper.Close()
+		for start := int64(1); start <= 100; start += 10 {
+			err := reaper.DeleteRange(ctx, start, start+10, "history_effects", "history_operation_id")
+			if err != nil {
+				reaped <- err
+				return
+			}
+		}
+		reaped <- nil
+	}()
+
+	require.NoError(t, migrator.Backfill(ctx, testMigration))
+	require.NoError(t, <-reaped)
+	require.NoError(t, migrator.Swap(ctx, testMigration))
+
+	close(stopIngesting)
+	for id, isTrade := range <-ingested {
+		expected[id] = isTrade
+	}
+	select {
+	case err := <-ingestErr:
+		require.NoError(t, err)
+	default:
+	}
+
+	require.NoError(t, migrator.Cleanup(ctx, testMigration))
+	assert.Equal(t, expected, effects(t, session, "history_effects"))
+
+	var retired []string
+	require.NoError(t, session.SelectRaw(ctx, &retired,
+		"SELECT tablename FROM pg_tables WHERE tablename LIKE 'history_effects_%'"))
+	assert.Empty(t, retired)
+}
@@ -0,0 +1,17 @@
+package online
+
+// Migrations are the online migrations known to this version of aurora. A
+// migration must stay in this list until every supported upgrade path has
+// applied it, as the `db migrate online` commands only operate on the
+// migrations listed here.
+var Migrations = []Migration{}
+
+// Find returns the migration with the given id.
+func Find(id string) (Migration, bool) {
+	for _, migration := range Migrations {
+		if migration.ID == id {
+			return migration, true
+		}
+	}
+	return Migration{}, false
+}
@@ -0,0 +1,650 @@
+// Package explain decodes transaction related XDR and prints a human-readable
+// breakdown of it. It is used by the `aurora explain` command so that
+// envelopes, results and meta can be inspected offline.
+package explain
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shantanu-hashcash/go/amount"
+	"github.com/shantanu-hashcash/go/keypair"
+	hProtocol "github.com/shantanu-hashcash/go/protocols/aurora"
+	"github.com/shantanu-hashcash/go/services/aurora/internal/codes"
+	"github.com/shantanu-hashcash/go/strkey"
+	"github.com/shantanu-hashcash/go/support/errors"
+	"github.com/shantanu-hashcash/go/txnbuild"
+	"github.com/shantanu-hashcash/go/xdr"
+)
+
+// Kind is the type of XDR being explained.
+type Kind string
+
+const (
+	// KindAuto detects the type of the XDR by attempting to decode it as
+	// every supported kind in turn.
+	KindAuto Kind = ""
+	// KindEnvelope is a TransactionEnvelope, including fee bump envelopes.
+	KindEnvelope Kind = "envelope"
+	// KindResult is a TransactionResult.
+	KindResult Kind = "result"
+	// KindMeta is a TransactionMeta.
+	KindMeta Kind = "meta"
+)
+
+// Options configures Explain.
+type Options struct {
+	Kind Kind
+	// NetworkPassphrase is used to hash envelopes. When set, signatures are
+	// verified against the hash, otherwise they are only matched by hint.
+	NetworkPassphrase string
+	// Accounts is the known state of the accounts involved in the
+	// transaction. It is used to work out which signers are required.
+	Accounts []hProtocol.Account
+}
+
+// Explain decodes the base64 XDR in input and writes the explanation to w.
+func Explain(w io.Writer, input string, opts Options) error {
+	input = strings.TrimSpace(input)
+	kind := opts.Kind
+	if kind == KindAuto {
+		kind = detect(input)
+		if kind == KindAuto {
+			return errors.New("input is not a transaction envelope, result or meta")
+		}
+	}
+
+	p := &printer{w: w}
+	switch kind {
+	case KindEnvelope:
+		return explainEnvelope(p, input, opts)
+	case KindResult:
+		var result xdr.TransactionResult
+		if err := xdr.SafeUnmarshalBase64(input, &result); err != nil {
+			return errors.Wrap(err, "unable to decode transaction result")
+		}
+		return explainResult(p, result)
+	case KindMeta:
+		var meta xdr.TransactionMeta
+		if err := xdr.SafeUnmarshalBase64(input, &meta); err != nil {
+			return errors.Wrap(err, "unable to decode transaction meta")
+		}
+		return explainMeta(p, meta)
+	default:
+		return errors.Errorf("unknown xdr kind %q", kind)
+	}
+}
+
+func detect(input string) Kind {
+	var envelope xdr.TransactionEnvelope
+	if xdr.SafeUnmarshalBase64(input, &envelope) == nil {
+		return KindEnvelope
+	}
+	var result xdr.TransactionResult
+	if xdr.SafeUnmarshalBase64(input, &result) == nil {
+		return KindResult
+	}
+	var meta xdr.TransactionMeta
+	if xdr.SafeUnmarshalBase64(input, &meta) == nil {
+		return KindMeta
+	}
+	return KindAuto
+}
+
+type printer struct {
+	w      io.Writer
+	indent int
+	err    error
+}
+
+func (p *printer) printf(format string, args ...interface{}) {
+	if p.err != nil {
+		return
+	}
+	_, p.err = fmt.Fprintf(p.w, strings.Repeat("  ", p.indent)+format+"\n", args...)
+}
+
+func (p *printer) nested(fn func()) {
+	p.indent++
+	fn()
+	p.indent--
+}
+
+func explainEnvelope(p *printer, input string, opts Options) error {
+	generic, err := txnbuild.TransactionFromXDR(input)
+	if err != nil {
+		return errors.Wrap(err, "unable to decode transaction envelope")
+	}
+
+	tx, isSimple := generic.Transaction()
+	if feeBump, ok := generic.FeeBump(); ok {
+		p.printf("Fee bump transaction")
+		p.nested(func() {
+			p.printf("Fee account: %s", feeBump.FeeAccount())
+			p.printf("Max fee: %d stroops (base fee %d)", feeBump.MaxFee(), feeBump.BaseFee())
+			if opts.NetworkPassphrase != "" {
+				if hash, hashErr := feeBump.HashHex(opts.NetworkPassphrase); hashErr == nil {
+					p.printf("Hash: %s", hash)
+				}
+			}
+			explainSignatures(p, feeBump.Signatures(), signingHash(feeBump, opts.NetworkPassphrase), []string{feeBump.FeeAccount()})
+		})
+		tx, isSimple = feeBump.InnerTransaction(), true
+		p.printf("Inner transaction")
+		p.indent++
+		defer func() { p.indent-- }()
+	}
+	if !isSimple {
+		return errors.New("envelope does not contain a transaction")
+	}
+
+	envelope := tx.ToXDR()
+	source := tx.SourceAccount().AccountID
+	p.printf("Source account: %s", source)
+	p.printf("Sequence number: %d", tx.SequenceNumber())
+	p.printf("Max fee: %d stroops (base fee %d x %d operations)", tx.MaxFee(), tx.BaseFee(), len(tx.Operations()))
+	if opts.NetworkPassphrase != "" {
+		if hash, hashErr := tx.HashHex(opts.NetworkPassphrase); hashErr == nil {
+			p.printf("Hash: %s", hash)
+		}
+	}
+	p.printf("Memo: %s", describeMemo(envelope.Memo()))
+
+	var preconditions txnbuild.Preconditions
+	if err = preconditions.FromXDR(envelope.Preconditions()); err != nil {
+		return errors.Wrap(err, "unable to decode preconditions")
+	}
+	explainPreconditions(p, preconditions)
+
+	p.printf("Operations (%d)", len(tx.Operations()))
+	p.nested(func() {
+		for i, op := range tx.Operations() {
+			opSource := op.GetSourceAccount()
+			if opSource == "" {
+				opSource = source
+			}
+			p.printf("%d. %s [source %s, %s threshold]", i, describeOperation(op), opSource, txnbuild.OperationThresholdCategory(op))
+		}
+	})
+
+	explainRequiredSigners(p, tx, opts.Accounts)
+	explainSignatures(p, tx.Signatures(), signingHash(tx, opts.NetworkPassphrase), signerCandidates(tx, preconditions, opts.Accounts))
+	return p.err
+}
+
+func explainPreconditions(p *printer, cond txnbuild.Preconditions) {
+	p.printf("Preconditions")
+	p.nested(func() {
+		p.printf("Valid after: %s", describeTime(cond.TimeBounds.MinTime))
+		p.printf("Valid before: %s", describeTime(cond.TimeBounds.MaxTime))
+		if cond.LedgerBounds != nil {
+			maxLedger := "unbounded"
+			if cond.LedgerBounds.MaxLedger != 0 {
+				maxLedger = fmt.Sprintf("%d (exclusive)", cond.LedgerBounds.MaxLedger)
+			}
+			p.printf("Ledger bounds: min %d, max %s", cond.LedgerBounds.MinLedger, maxLedger)
+		}
+		if cond.MinSequenceNumber != nil {
+			p.printf("Min source sequence number: %d", *cond.MinSequenceNumber)
+		}
+		if cond.MinSequenceNumberAge != 0 {
+			p.printf("Min source sequence age: %s", time.Duration(cond.MinSequenceNumberAge)*time.Second)
+		}
+		if cond.MinSequenceNumberLedgerGap != 0 {
+			p.printf("Min source sequence ledger gap: %d", cond.MinSequenceNumberLedgerGap)
+		}
+		for _, signer := range cond.ExtraSigners {
+			p.printf("Extra signer: %s", signer)
+		}
+	})
+}
+
+func describeTime(t int64) string {
+	if t == 0 {
+		return "unbounded"
+	}
+	return fmt.Sprintf("%s (%d)", time.Unix(t, 0).UTC().Format(time.RFC3339), t)
+}
+
+func describeMemo(memo xdr.Memo) string {
+	switch memo.Type {
+	case xdr.MemoTypeMemoText:
+		return fmt.Sprintf("text %q", memo.MustText())
+	case xdr.MemoTypeMemoId:
+		return fmt.Sprintf("id %d", memo.MustId())
+	case xdr.MemoTypeMemoHash:
+		hash := memo.MustHash()
+		return fmt.Sprintf("hash %x", hash[:])
+	case xdr.MemoTypeMemoReturn:
+		hash := memo.MustRetHash()
+		return fmt.Sprintf("return %x", hash[:])
+	default:
+		return "none"
+	}
+}
+
+func describeAsset(asset txnbuild.BasicAsset) string {
+	if asset == nil {
+		return "<none>"
+	}
+	if asset.IsNative() {
+		return "native"
+	}
+	return asset.GetCode() + ":" + asset.GetIssuer()
+}
+
+func describePrice(price xdr.Price) string {
+	return fmt.Sprintf("%s (%d/%d)", price.String(), price.N, price.D)
+}
+
+func describeOperation(op txnbuild.Operation) string {
+	switch o := op.(type) {
+	case *txnbuild.CreateAccount:
+		return fmt.Sprintf("create_account: fund %s with %s native", o.Destination, o.Amount)
+	case *txnbuild.Payment:
+		return fmt.Sprintf("payment: send %s %s to %s", o.Amount, describeAsset(o.Asset), o.Destination)
+	case *txnbuild.PathPaymentStrictReceive:
+		return fmt.Sprintf("path_payment_strict_receive: send at most %s %s so %s receives %s %s",
+			o.SendMax, describeAsset(o.SendAsset), o.Destination, o.DestAmount, describeAsset(o.DestAsset))
+	case *txnbuild.PathPaymentStrictSend:
+		return fmt.Sprintf("path_payment_strict_send: send %s %s so %s receives at least %s %s",
+			o.SendAmount, describeAsset(o.SendAsset), o.Destination, o.DestMin, describeAsset(o.DestAsset))
+	case *txnbuild.ManageSellOffer:
+		return fmt.Sprintf("manage_sell_offer: offer %d selling %s %s for %s at %s",
+			o.OfferID, o.Amount, describeAsset(o.Selling), describeAsset(o.Buying), describePrice(o.Price))
+	case *txnbuild.ManageBuyOffer:
+		return fmt.Sprintf("manage_buy_offer: offer %d buying %s %s with %s at %s",
+			o.OfferID, o.Amount, describeAsset(o.Buying), describeAsset(o.Selling), describePrice(o.Price))
+	case *txnbuild.CreatePassiveSellOffer:
+		return fmt.Sprintf("create_passive_sell_offer: selling %s %s for %s at %s",
+			o.Amount, describeAsset(o.Selling), describeAsset(o.Buying), describePrice(o.Price))
+	case *txnbuild.ChangeTrust:
+		if o.Limit == "0" || o.Limit == "0.0000000" {
+			return fmt.Sprintf("change_trust: remove trustline to %s", describeAsset(o.Line))
+		}
+		return fmt.Sprintf("change_trust: trust %s up to %s", describeAsset(o.Line), o.Limit)
+	case *txnbuild.AllowTrust:
+		return fmt.Sprintf("allow_trust: authorize %t for %s on %s", o.Authorize, o.Trustor, describeAsset(o.Type))
+	case *txnbuild.SetTrustLineFlags:
+		return fmt.Sprintf("set_trust_line_flags: %s on %s, set %v, clear %v",
+			o.Trustor, describeAsset(o.Asset), o.SetFlags, o.ClearFlags)
+	case *txnbuild.AccountMerge:
+		return fmt.Sprintf("account_merge: merge into %s", o.Destination)
+	case *txnbuild.ManageData:
+		if o.Value == nil {
+			return fmt.Sprintf("manage_data: delete %q", o.Name)
+		}
+		return fmt.Sprintf("manage_data: set %q to %q", o.Name, o.Value)
+	case *txnbuild.BumpSequence:
+		return fmt.Sprintf("bump_sequence: bump to %d", o.BumpTo)
+	case *txnbuild.CreateClaimableBalance:
+		claimants := make([]string, 0, len(o.Destinations))
+		for _, claimant := range o.Destinations {
+			claimants = append(claimants, claimant.Destination)
+		}
+		return fmt.Sprintf("create_claimable_balance: %s %s claimable by %s",
+			o.Amount, describeAsset(o.Asset), strings.Join(claimants, ", "))
+	case *txnbuild.ClaimClaimableBalance:
+		return fmt.Sprintf("claim_claimable_balance: claim %s", o.BalanceID)
+	case *txnbuild.SetOptions:
+		return "set_options: " + describeSetOptions(o)
+	default:
+		xdrOp, err := op.BuildXDR()
+		if err != nil {
+			return fmt.Sprintf("%T", op)
+		}
+		return strings.TrimPrefix(xdrOp.Body.Type.String(), "OperationType")
+	}
+}
+
+func describeSetOptions(o *txnbuild.SetOptions) string {
+	var changes []string
+	if o.InflationDestination != nil {
+		changes = append(changes, "inflation destination "+*o.InflationDestination)
+	}
+	if len(o.SetFlags) > 0 {
+		changes = append(changes, fmt.Sprintf("set flags %v", o.SetFlags))
+	}
+	if len(o.ClearFlags) > 0 {
+		changes = append(changes, fmt.Sprintf("clear flags %v", o.ClearFlags))
+	}
+	if o.MasterWeight != nil {
+		changes = append(changes, fmt.Sprintf("master weight %d", *o.MasterWeight))
+	}
+	if o.LowThreshold != nil {
+		changes = append(changes, fmt.Sprintf("low threshold %d", *o.LowThreshold))
+	}
+	if o.MediumThreshold != nil {
+		changes = append(changes, fmt.Sprintf("medium threshold %d", *o.MediumThreshold))
+	}
+	if o.HighThreshold != nil {
+		changes = append(changes, fmt.Sprintf("high threshold %d", *o.HighThreshold))
+	}
+	if o.HomeDomain != nil {
+		changes = append(changes, fmt.Sprintf("home domain %q", *o.HomeDomain))
+	}
+	if o.Signer != nil {
+		changes = append(changes, fmt.Sprintf("signer %s weight %d", o.Signer.Address, o.Signer.Weight))
+	}
+	if len(changes) == 0 {
+		return "no changes"
+	}
+	return strings.Join(changes, ", ")
+}
+
+// thresholdOf returns the threshold of category c in thresholds.
+func thresholdOf(c txnbuild.ThresholdCategory, thresholds hProtocol.AccountThresholds) int32 {
+	switch c {
+	case txnbuild.ThresholdCategoryLow:
+		return int32(thresholds.LowThreshold)
+	case txnbuild.ThresholdCategoryHigh:
+		return int32(thresholds.HighThreshold)
+	default:
+		return int32(thresholds.MedThreshold)
+	}
+}
+
+// requiredThresholds returns, for every account that must authorize tx, the
+// highest threshold category required from it.
+func requiredThresholds(tx *txnbuild.Transaction) map[string]txnbuild.ThresholdCategory {
+	required := map[string]txnbuild.ThresholdCategory{
+		// The transaction source pays the fee and consumes the sequence
+		// number, which needs the low threshold.
+		tx.SourceAccount().AccountID: txnbuild.ThresholdCategoryLow,
+	}
+	for _, op := range tx.Operations() {
+		source := op.GetSourceAccount()
+		if source == "" {
+			source = tx.SourceAccount().AccountID
+		}
+		if muxed, err := xdr.AddressToMuxedAccount(source); err == nil {
+			source = muxed.ToAccountId().Address()
+		}
+		if t := txnbuild.OperationThresholdCategory(op); t > required[source] {
+			required[source] = t
+		}
+	}
+	return required
+}
+
+func findAccount(accounts []hProtocol.Account, accountID string) (hProtocol.Account, bool) {
+	for _, account := range accounts {
+		if account.AccountID == accountID {
+			return account, true
+		}
+	}
+	return hProtocol.Account{}, false
+}
+
+func explainRequiredSigners(p *printer, tx *txnbuild.Transaction, accounts []hProtocol.Account) {
+	required := requiredThresholds(tx)
+	accountIDs := make([]string, 0, len(required))
+	for accountID := range required {
+		accountIDs = append(accountIDs, accountID)
+	}
+	sort.Strings(accountIDs)
+
+	p.printf("Required authorization")
+	p.nested(func() {
+		for _, accountID := range accountIDs {
+			t := required[accountID]
+			account, ok := findAccount(accounts, accountID)
+			if !ok {
+				p.printf("%s: %s threshold (account state not supplied)", accountID, t)
+				continue
+			}
+			needed := thresholdOf(t, account.Thresholds)
+			if needed == 0 {
+				// A threshold of 0 still requires a signature of any weight.
+				needed = 1
+			}
+			p.printf("%s: %s threshold, signatures with total weight >= %d from:", accountID, t, needed)
+			p.nested(func() {
+				for _, signer := range account.Signers {
+					if signer.Weight > 0 {
+						p.printf("%s (weight %d)", signer.Key, signer.Weight)
+					}
+				}
+			})
+		}
+	})
+}
+
+func signingHash(tx interface {
+	Hash(string) ([32]byte, error)
+}, networkPassphrase string) []byte {
+	if networkPassphrase == "" {
+		return nil
+	}
+	hash, err := tx.Hash(networkPassphrase)
+	if err != nil {
+		return nil
+	}
+	return hash[:]
+}
+
+// signerCandidates lists every key that could have produced a signature on
+// tx: the source accounts themselves, their known signers and the extra
+// signers from the preconditions.
+func signerCandidates(tx *txnbuild.Transaction, cond txnbuild.Preconditions, accounts []hProtocol.Account) []string {
+	var candidates []string
+	for accountID := range requiredThresholds(tx) {
+		candidates = append(candidates, accountID)
+		if account, ok := findAccount(accounts, accountID); ok {
+			for _, signer := range account.Signers {
+				candidates = append(candidates, signer.Key)
+			}
+		}
+	}
+	candidates = append(candidates, cond.ExtraSigners...)
+	sort.Strings(candidates)
+	return candidates
+}
+
+func explainSignatures(p *printer, signatures []xdr.DecoratedSignature, hash []byte, candidates []string) {
+	p.printf("Signatures (%d)", len(signatures))
+	p.nested(func() {
+		for i, signature := range signatures {
+			p.printf("%d. hint %x: %s", i, signature.Hint[:], matchSignature(signature, hash, candidates))
+		}
+	})
+}
+
+func matchSignature(signature xdr.DecoratedSignature, hash []byte, candidates []string) string {
+	var matches []string
+	for _, candidate := range candidates {
+		if strkey.IsValidEd25519PublicKey(candidate) {
+			kp, err := keypair.ParseAddress(candidate)
+			if err != nil || kp.Hint() != signature.Hint {
+				continue
+			}
+			if hash == nil {
+				matches = append(matches, candidate+" (matched by hint only)")
+			} else if kp.Verify(hash, signature.Signature) == nil {
+				return candidate + " (valid)"
+			}
+		}
+	}
+	if len(matches) > 0 {
+		return strings.Join(matches, ", ")
+	}
+	if hash != nil {
+		return "does not match any known signer"
+	}
+	return "unknown signer"
+}
+
+// describeCode returns the Aurora name of a result code, or fallback when
+// Aurora does not map it, so new core result codes don't prevent explaining
+// the rest of the result.
+func describeCode(code string, err error, fallback string) string {
+	if err != nil {
+		return fallback + " (unmapped)"
+	}
+	return code
+}
+
+func explainResult(p *printer, result xdr.TransactionResult) error {
+	code, err := codes.String(result.Result.Code)
+	p.printf("Transaction result: %s", describeCode(code, err, result.Result.Code.String()))
+	p.printf("Fee charged: %d stroops", result.FeeCharged)
+
+	if inner, ok := result.Result.GetInnerResultPair(); ok {
+		innerCode, err := codes.String(inner.Result.Result.Code)
+		p.printf("Inner transaction %x: %s", inner.TransactionHash[:],
+			describeCode(innerCode, err, inner.Result.Result.Code.String()))
+	}
+
+	if opResults, ok := result.OperationResults(); ok {
+		p.printf("Operation results (%d)", len(opResults))
+		p.nested(func() {
+			for i, opResult := range opResults {
+				fallback := opResult.Code.String()
+				if tr, ok := opResult.GetTr(); ok {
+					fallback = tr.Type.String() + " result"
+				}
+				opCode, err := codes.ForOperationResult(opResult)
+				p.printf("%d. %s", i, describeCode(opCode, err, fallback))
+			}
+		})
+	}
+	return p.err
+}
+
+func explainMeta(p *printer, meta xdr.TransactionMeta) error {
+	p.printf("Transaction meta v%d", meta.V)
+	switch meta.V {
+	case 0:
+		for i, opMeta := range meta.MustOperations() {
+			explainChanges(p, fmt.Sprintf("Operation %d changes", i), opMeta.Changes)
+		}
+	case 1:
+		v1 := meta.MustV1()
+		explainChanges(p, "Transaction changes", v1.TxChanges)
+		for i, opMeta := range v1.Operations {
+			explainChanges(p, fmt.Sprintf("Operation %d changes", i), opMeta.Changes)
+		}
+	case 2:
+		v2 := meta.MustV2()
+		explainChanges(p, "Transaction changes before operations", v2.TxChangesBefore)
+		for i, opMeta := range v2.Operations {
+			explainChanges(p, fmt.Sprintf("Operation %d changes", i), opMeta.Changes)
+		}
+		explainChanges(p, "Transaction changes after operations", v2.TxChangesAfter)
+	case 3:
+		v3 := meta.MustV3()
+		explainChanges(p, "Transaction changes before operations", v3.TxChangesBefore)
+		for i, opMeta := range v3.Operations {
+			explainChanges(p, fmt.Sprintf("Operation %d changes", i), opMeta.Changes)
+		}
+		explainChanges(p, "Transaction changes after operations", v3.TxChangesAfter)
+		if v3.SorobanMeta != nil {
+			p.printf("Contract events: %d", len(v3.SorobanMeta.Events))
+		}
+	default:
+		return errors.Errorf("unsupported transaction meta version %d", meta.V)
+	}
+	return p.err
+}
+
+func explainChanges(p *printer, title string, changes xdr.LedgerEntryChanges) {
+	if len(changes) == 0 {
+		return
+	}
+	p.printf("%s (%d)", title, len(changes))
+	p.nested(func() {
+		for _, change := range changes {
+			p.printf("%s %s", describeChangeType(change.Type), describeChange(change))
+		}
+	})
+}
+
+func describeChangeType(changeType xdr.LedgerEntryChangeType) string {
+	switch changeType {
+	case xdr.LedgerEntryChangeTypeLedgerEntryCreated:
+		return "created"
+	case xdr.LedgerEntryChangeTypeLedgerEntryUpdated:
+		return "updated"
+	case xdr.LedgerEntryChangeTypeLedgerEntryRemoved:
+		return "removed"
+	case xdr.LedgerEntryChangeTypeLedgerEntryState:
+		return "state"
+	default:
+		return changeType.String()
+	}
+}
+
+func describeChange(change xdr.LedgerEntryChange) string {
+	entry, ok := change.GetLedgerEntry()
+	if !ok {
+		key, err := change.LedgerKey()
+		if err != nil {
+			return "<invalid ledger key>"
+		}
+		return describeLedgerKey(key)
+	}
+
+	switch entry.Data.Type {
+	case xdr.LedgerEntryTypeAccount:
+		account := entry.Data.MustAccount()
+		return fmt.Sprintf("account %s: balance %s, sequence %d, %d subentries",
+			account.AccountId.Address(), amount.String(account.Balance), account.SeqNum, account.NumSubEntries)
+	case xdr.LedgerEntryTypeTrustline:
+		trustline := entry.Data.MustTrustLine()
+		return fmt.Sprintf("trustline %s %s: balance %s, limit %s",
+			trustline.AccountId.Address(), describeTrustLineAsset(trustline.Asset),
+			amount.String(trustline.Balance), amount.String(trustline.Limit))
+	case xdr.LedgerEntryTypeOffer:
+		offer := entry.Data.MustOffer()
+		return fmt.Sprintf("offer %d by %s: selling %s %s for %s at %s",
+			offer.OfferId, offer.SellerId.Address(), amount.String(offer.Amount),
+			offer.Selling.StringCanonical(), offer.Buying.StringCanonical(), describePrice(offer.Price))
+	case xdr.LedgerEntryTypeData:
+		data := entry.Data.MustData()
+		return fmt.Sprintf("data %s %q: %s",
+			data.AccountId.Address(), data.DataName, base64.StdEncoding.EncodeToString(data.DataValue))
+	case xdr.LedgerEntryTypeClaimableBalance:
+		balance := entry.Data.MustClaimableBalance()
+		id, _ := xdr.MarshalHex(balance.BalanceId)
+		return fmt.Sprintf("claimable balance %s: %s %s",
+			id, amount.String(balance.Amount), balance.Asset.StringCanonical())
+	default:
+		key, err := change.LedgerKey()
+		if err != nil {
+			return "<invalid ledger key>"
+		}
+		return describeLedgerKey(key)
+	}
+}
+
+func describeTrustLineAsset(asset xdr.TrustLineAsset) string {
+	if asset.Type == xdr.AssetTypeAssetTypePoolShare {
+		return "liquidity pool " + xdr.Hash(asset.MustLiquidityPoolId()).HexString()
+	}
+	return asset.ToAsset().StringCanonical()
+}
+
+func describeLedgerKey(key xdr.LedgerKey) string {
+	switch key.Type {
+	case xdr.LedgerEntryTypeAccount:
+		return "account " + key.MustAccount().AccountId.Address()
+	case xdr.LedgerEntryTypeTrustline:
+		trustline := key.MustTrustLine()
+		return fmt.Sprintf("trustline %s %s", trustline.AccountId.Address(), describeTrustLineAsset(trustline.Asset))
+	case xdr.LedgerEntryTypeOffer:
+		return fmt.Sprintf("offer %d", key.MustOffer().OfferId)
+	case xdr.LedgerEntryTypeData:
+		data := key.MustData()
+		return fmt.Sprintf("data %s %q", data.AccountId.Address(), data.DataName)
+	default:
+		encoded, err := key.MarshalBinaryBase64()
+		if err != nil {
+			return strings.ToLower(strings.TrimPrefix(key.Type.String(), "LedgerEntryType"))
+		}
+		return fmt.Sprintf("%s %s", strings.ToLower(strings.TrimPrefix(key.Type.String(), "LedgerEntryType")), encoded)
+	}
+}
@@ -0,0 +1,144 @@
+package explain
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shantanu-hashcash/go/network"
+	hProtocol "github.com/shantanu-hashcash/go/protocols/aurora"
+	"github.com/shantanu-hashcash/go/xdr"
+)
+
+const (
+	createAccountEnvelope = "AAAAABB90WssODNIgi6BHveqzxTRmIpvAFRyVNM+Hm2GVuCcAAAAZAAABD0ABlJpAAAAAAAAAAAAAAABAAAAAAAAAAAAAAAAmLuzasXDMqsqgFK4xkbLxJLzmQQzkiCF2SnKPD+b1TsAAAAXSHboAAAAAAAAAAABhlbgnAAAAECqxhXduvtzs65keKuTzMtk76cts2WeVB2pZKYdlxlOb1EIbOpFhYizDSXVfQlAvvg18qV6oNRr7ls4nnEm2YIK"
+	createAccountResult   = "AAAAAAAAAGQAAAAAAAAAAQAAAAAAAAAAAAAAAAAAAAA="
+	createAccountMeta     = "AAAAAQAAAAIAAAADAAlEmwAAAAAAAAAAEH3Rayw4M0iCLoEe96rPFNGYim8AVHJU0z4ebYZW4JwBT3aiixBA2AAABD0ABlJoAAAAAAAAAAAAAAAAAAAAAAEAAAAAAAAAAAAAAAAAAAAAAAABAAlEmwAAAAAAAAAAEH3Rayw4M0iCLoEe96rPFNGYim8AVHJU0z4ebYZW4JwBT3aiixBA2AAABD0ABlJpAAAAAAAAAAAAAAAAAAAAAAEAAAAAAAAAAAAAAAAAAAAAAAABAAAAAwAAAAMACUSbAAAAAAAAAAAQfdFrLDgzSIIugR73qs8U0ZiKbwBUclTTPh5thlbgnAFPdqKLEEDYAAAEPQAGUmkAAAAAAAAAAAAAAAAAAAAAAQAAAAAAAAAAAAAAAAAAAAAAAAEACUSbAAAAAAAAAAAQfdFrLDgzSIIugR73qs8U0ZiKbwBUclTTPh5thlbgnAFPdotCmVjYAAAEPQAGUmkAAAAAAAAAAAAAAAAAAAAAAQAAAAAAAAAAAAAAAAAAAAAAAAAACUSbAAAAAAAAAACYu7NqxcMyqyqAUrjGRsvEkvOZBDOSIIXZKco8P5vVOwAAABdIdugAAAlEmwAAAAAAAAAAAAAAAAAAAAAAAAAAAQAAAAAAAAAAAAAAAAAAAA=="
+	source                = "GAIH3ULLFQ4DGSECF2AR555KZ4KNDGEKN4AFI4SU2M7B43MGK3QJZNSR"
+)
+
+func TestExplainEnvelope(t *testing.T) {
+	var out bytes.Buffer
+	err := Explain(&out, createAccountEnvelope, Options{
+		NetworkPassphrase: network.TestNetworkPassphrase,
+		Accounts: []hProtocol.Account{{
+			AccountID:  source,
+			Thresholds: hProtocol.AccountThresholds{LowThreshold: 1, MedThreshold: 2, HighThreshold: 3},
+			Signers: []hProtocol.Signer{
+				{Key: source, Weight: 1},
+				{Key: "GCMLXM3KYXBTFKZKQBJLRRSGZPCJF44ZAQZZEIEF3EU4UPB7TPKTXK37", Weight: 1},
+			},
+		}},
+	})
+	require.NoError(t, err)
+
+	explanation := out.String()
+	assert.Contains(t, explanation, "Hash: 1534f6507420c6871b557cc2fc800c29fb1ed1e012e694993ffe7a39c824056e")
+	assert.Contains(t, explanation, "create_account: fund GCMLXM3KYXBTFKZKQBJLRRSGZPCJF44ZAQZZEIEF3EU4UPB7TPKTXK37 with 10000.0000000 native")
+	assert.Contains(t, explanation, source+": medium threshold, signatures with total weight >= 2 from:")
+	assert.Contains(t, explanation, "GCMLXM3KYXBTFKZKQBJLRRSGZPCJF44ZAQZZEIEF3EU4UPB7TPKTXK37 (weight 1)")
+	assert.Contains(t, explanation, "hint 8656e09c: "+source+" (valid)")
+}
+
+func TestExplainEnvelopeWithoutPassphrase(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, Explain(&out, createAccountEnvelope, Options{}))
+	assert.Contains(t, out.String(), source+": medium threshold (account state not supplied)")
+	assert.Contains(t, out.String(), source+" (matched by hint only)")
+	assert.NotContains(t, out.String(), "Hash:")
+}
+
+func TestExplainResult(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, Explain(&out, createAccountResult, Options{}))
+	assert.Equal(t, "Transaction result: tx_success\n"+
+		"Fee charged: 100 stroops\n"+
+		"Operation results (1)\n"+
+		"  0. op_success\n", out.String())
+}
+
+func TestExplainMeta(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, Explain(&out, createAccountMeta, Options{Kind: KindMeta}))
+	assert.Contains(t, out.String(), "Transaction meta v1")
+	assert.Contains(t, out.String(), "created account GCMLXM3KYXBTFKZKQBJLRRSGZPCJF44ZAQZZEIEF3EU4UPB7TPKTXK37: balance 10000.0000000")
+}
+
+func TestExplainInvalidInput(t *testing.T) {
+	var out bytes.Buffer
+	err := Explain(&out, "AAAA", Options{})
+	assert.EqualError(t, err, "input is not a transaction envelope, result or meta")
+
+	err = Explain(&out, createAccountResult, Options{Kind: KindMeta})
+	assert.Error(t, err)
+}
+
+func TestExplainResultUnmappedCode(t *testing.T) {
+	results := []xdr.OperationResult{
+		{Code: xdr.OperationResultCodeOpTooManySponsoring},
+		{Code: xdr.OperationResultCodeOpBadAuth},
+	}
+	encoded, err := xdr.MarshalBase64(xdr.TransactionResult{
+		FeeCharged: 200,
+		Result: xdr.TransactionResultResult{
+			Code:    xdr.TransactionResultCodeTxFailed,
+			Results: &results,
+		},
+	})
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, Explain(&out, encoded, Options{Kind: KindResult}))
+	assert.Equal(t, "Transaction result: tx_failed\n"+
+		"Fee charged: 200 stroops\n"+
+		"Operation results (2)\n"+
+		"  0. OperationResultCodeOpTooManySponsoring (unmapped)\n"+
+		"  1. op_bad_auth\n", out.String())
+}
+
+func TestExplainMetaPoolShareTrustLine(t *testing.T) {
+	poolID := xdr.PoolId{1, 2, 3}
+	trustLine := xdr.TrustLineEntry{
+		AccountId: xdr.MustAddress(source),
+		Asset: xdr.TrustLineAsset{
+			Type:            xdr.AssetTypeAssetTypePoolShare,
+			LiquidityPoolId: &poolID,
+		},
+		Balance: 10000000,
+		Limit:   1000000000,
+	}
+	key := xdr.LedgerKey{
+		Type: xdr.LedgerEntryTypeTrustline,
+		TrustLine: &xdr.LedgerKeyTrustLine{
+			AccountId: trustLine.AccountId,
+			Asset:     trustLine.Asset,
+		},
+	}
+	encoded, err := xdr.MarshalBase64(xdr.TransactionMeta{
+		V: 1,
+		V1: &xdr.TransactionMetaV1{
+			TxChanges: xdr.LedgerEntryChanges{
+				{
+					Type: xdr.LedgerEntryChangeTypeLedgerEntryCreated,
+					Created: &xdr.LedgerEntry{Data: xdr.LedgerEntryData{
+						Type:      xdr.LedgerEntryTypeTrustline,
+						TrustLine: &trustLine,
+					}},
+				},
+				{
+					Type:    xdr.LedgerEntryChangeTypeLedgerEntryRemoved,
+					Removed: &key,
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, Explain(&out, encoded, Options{Kind: KindMeta}))
+	pool := "liquidity pool " + xdr.Hash(poolID).HexString()
+	assert.Contains(t, out.String(), "created trustline "+source+" "+pool+": balance 1.0000000, limit 100.0000000")
+	assert.Contains(t, out.String(), "removed trustline "+source+" "+pool+"\n")
+}
@@ -0,0 +1,269 @@
+// Package export exports the contents of aurora's history and state tables
+// for a range of ledgers into Parquet files.
+//
+// History tables are partitioned by ledger range, and each partition is
+// written to `<table>/ledgers=<first>-<last>/data.parquet` in the
+// destination storage, with ranges aligned to multiples of the partition
+// size so that overlapping exports produce the same files. State tables hold
+// the current state of ledger entries and aren't indexed by ledger, so they
+// are only exported incrementally: the entries whose current version was last
+// modified within the exported range are written to a single partition
+// covering the whole range, from the same snapshot as the last partition of
+// the history tables. Entries modified again after the range are left to the
+// export of a later range, and removals, including offers and liquidity pools
+// which are only marked as deleted, aren't exported at all.
+package export
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/xitongsys/parquet-go-source/writerfile"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+
+	"github.com/shantanu-hashcash/go/support/db"
+	"github.com/shantanu-hashcash/go/support/errors"
+	"github.com/shantanu-hashcash/go/support/log"
+	"github.com/shantanu-hashcash/go/support/storage"
+)
+
+// DefaultPartitionSize is the default number of ledgers per partition of the
+// history tables.
+const DefaultPartitionSize = 1000
+
+// Table is a table which can be exported.
+type Table struct {
+	Name string
+	// State is true for tables holding the current state of ledger entries.
+	State bool
+
+	// query selects the rows of the ledgers in [$1, $2].
+	query string
+	// newRow returns a pointer to the row type defining the table's schema.
+	newRow func() interface{}
+}
+
+// FindTables returns the tables with the given names, or all tables if no
+// names are given.
+func FindTables(names []string) ([]Table, error) {
+	if len(names) == 0 {
+		return Tables, nil
+	}
+
+	var tables []Table
+	for _, name := range names {
+		found := false
+		for _, table := range Tables {
+			if table.Name == name {
+				tables = append(tables, table)
+				found = true
+				break
+			}
+		}
+		if !found {
+			var known []string
+			for _, table := range Tables {
+				known = append(known, table.Name)
+			}
+			return nil, errors.Errorf("unknown table %s, expected one of %s", name, strings.Join(known, ", "))
+		}
+	}
+	return tables, nil
+}
+
+// Partition is a range of ledgers exported into a single file per table.
+type Partition struct {
+	First, Last uint32
+}
+
+// Path returns the path of the table's file for the partition.
+func (p Partition) Path(table Table) string {
+	return fmt.Sprintf("%s/ledgers=%010d-%010d/data.parquet", table.Name, p.First, p.Last)
+}
+
+// Partitions splits [first, last] into ranges aligned to multiples of size.
+func Partitions(first, last, size uint32) []Partition {
+	var partitions []Partition
+	for start := first; start <= last; {
+		end := (start/size+1)*size - 1
+		if end > last || end < start {
+			end = last
+		}
+		partitions = append(partitions, Partition{First: start, Last: end})
+		if end == last {
+			break
+		}
+		start = end + 1
+	}
+	return partitions
+}
+
+// Exporter exports tables into Parquet files.
+type Exporter struct {
+	Session db.SessionInterface
+	Storage storage.Storage
+	// PartitionSize is the number of ledgers per partition of the history
+	// tables, DefaultPartitionSize by default.
+	PartitionSize uint32
+}
+
+// Export exports the rows of the given tables for the ledgers in
+// [first, last], which must have been ingested. All the tables' files for a
+// partition are exported from a consistent snapshot of the database, and the
+// state tables from the snapshot of the last partition.
+func (e *Exporter) Export(ctx context.Context, tables []Table, first, last uint32) error {
+	if first == 0 || first > last {
+		return errors.Errorf("invalid ledger range [%d, %d]", first, last)
+	}
+	size := e.PartitionSize
+	if size == 0 {
+		size = DefaultPartitionSize
+	}
+
+	var ingested uint32
+	err := e.Session.GetRaw(ctx, &ingested,
+		"SELECT count(*) FROM history_ledgers WHERE sequence BETWEEN $1 AND $2", first, last)
+	if err != nil {
+		return errors.Wrap(err, "could not count ingested ledgers")
+	}
+	if ingested != last-first+1 {
+		return errors.Errorf("ledgers [%d, %d] haven't all been ingested, run `aurora db detect-gaps`", first, last)
+	}
+
+	for _, files := range exportPlan(tables, first, last, size) {
+		if err := e.exportSnapshot(ctx, files); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exportFile is a table's file for a partition.
+type exportFile struct {
+	Table     Table
+	Partition Partition
+}
+
+// exportPlan groups the files to export by the snapshot they are exported
+// from: one per partition of the history tables, the last of which also
+// holds the state tables.
+func exportPlan(tables []Table, first, last, size uint32) [][]exportFile {
+	var history, state []Table
+	for _, table := range tables {
+		if table.State {
+			state = append(state, table)
+		} else {
+			history = append(history, table)
+		}
+	}
+
+	var plan [][]exportFile
+	if len(history) > 0 {
+		for _, partition := range Partitions(first, last, size) {
+			files := make([]exportFile, 0, len(history))
+			for _, table := range history {
+				files = append(files, exportFile{Table: table, Partition: partition})
+			}
+			plan = append(plan, files)
+		}
+	}
+	if len(state) > 0 {
+		if len(plan) == 0 {
+			plan = append(plan, nil)
+		}
+		for _, table := range state {
+			plan[len(plan)-1] = append(plan[len(plan)-1],
+				exportFile{Table: table, Partition: Partition{First: first, Last: last}})
+		}
+	}
+	return plan
+}
+
+// exportSnapshot exports the files from a consistent snapshot of the
+// database.
+func (e *Exporter) exportSnapshot(ctx context.Context, files []exportFile) error {
+	err := e.Session.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return errors.Wrap(err, "could not begin transaction")
+	}
+	defer e.Session.Rollback()
+
+	for _, file := range files {
+		path := file.Partition.Path(file.Table)
+		count, err := e.exportFile(ctx, file.Table, file.Partition, path)
+		if err != nil {
+			return errors.Wrapf(err, "could not export %s", path)
+		}
+		log.WithFields(log.F{"path": path, "rows": count}).Info("Exported table")
+	}
+	return nil
+}
+
+func (e *Exporter) exportFile(ctx context.Context, table Table, partition Partition, path string) (int, error) {
+	rows, err := e.Session.QueryRaw(ctx, table.query, partition.First, partition.Last)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	reader, pipe := io.Pipe()
+	type result struct {
+		count int
+		err   error
+	}
+	written := make(chan result, 1)
+	go func() {
+		count, err := writeParquet(pipe, table.newRow, rows)
+		pipe.CloseWithError(err)
+		written <- result{count, err}
+	}()
+
+	err = e.Storage.PutFile(path, reader)
+	// unblock the writer if the storage stopped reading early
+	reader.CloseWithError(io.ErrClosedPipe)
+	w := <-written
+	if err != nil {
+		return 0, err
+	}
+	return w.count, w.err
+}
+
+// rowSource is implemented by *db.Rows.
+type rowSource interface {
+	Next() bool
+	StructScan(dest interface{}) error
+	Err() error
+}
+
+// writeParquet writes the rows into a Parquet file with the schema of the
+// row type returned by newRow.
+func writeParquet(w io.Writer, newRow func() interface{}, rows rowSource) (int, error) {
+	pw, err := writer.NewParquetWriter(writerfile.NewWriterFile(w), newRow(), 1)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not create parquet writer")
+	}
+	pw.CompressionType = parquet.CompressionCodec_SNAPPY
+
+	count := 0
+	for rows.Next() {
+		row := newRow()
+		if err = rows.StructScan(row); err != nil {
+			return count, errors.Wrap(err, "could not scan row")
+		}
+		if err = pw.Write(row); err != nil {
+			return count, errors.Wrap(err, "could not write row")
+		}
+		count++
+	}
+	if err = rows.Err(); err != nil {
+		return count, err
+	}
+	if err = pw.WriteStop(); err != nil {
+		return count, errors.Wrap(err, "could not finish parquet file")
+	}
+	return count, nil
+}
@@ -0,0 +1,134 @@
+package export
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+func TestPartitions(t *testing.T) {
+	assert.Equal(t, []Partition{{1, 999}, {1000, 1999}, {2000, 2500}}, Partitions(1, 2500, 1000))
+	assert.Equal(t, []Partition{{1500, 1999}, {2000, 2999}}, Partitions(1500, 2999, 1000))
+	assert.Equal(t, []Partition{{7, 7}}, Partitions(7, 7, 1000))
+	assert.Equal(t, []Partition{{7, 7}, {8, 8}}, Partitions(7, 8, 1))
+	assert.Equal(t, []Partition{{4294967000, 4294967295}}, Partitions(4294967000, 4294967295, 1000))
+
+	assert.Equal(t,
+		"history_operations/ledgers=0000001000-0000001999/data.parquet",
+		Partition{1000, 1999}.Path(Table{Name: "history_operations"}),
+	)
+}
+
+func TestFindTables(t *testing.T) {
+	tables, err := FindTables(nil)
+	require.NoError(t, err)
+	assert.Len(t, tables, len(Tables))
+
+	tables, err = FindTables([]string{"history_effects", "offers"})
+	require.NoError(t, err)
+	require.Len(t, tables, 2)
+	assert.False(t, tables[0].State)
+	assert.True(t, tables[1].State)
+
+	_, err = FindTables([]string{"history_claimable_balances"})
+	assert.EqualError(t, err, "unknown table history_claimable_balances, expected one of "+
+		"history_ledgers, history_transactions, history_operations, history_effects, history_trades, "+
+		"accounts, trust_lines, offers, claimable_balances, liquidity_pools")
+}
+
+// sliceSource is a rowSource over rows of the same type.
+type sliceSource struct {
+	rows []interface{}
+	next int
+}
+
+func (s *sliceSource) Next() bool {
+	s.next++
+	return s.next <= len(s.rows)
+}
+
+func (s *sliceSource) StructScan(dest interface{}) error {
+	reflect.ValueOf(dest).Elem().Set(reflect.ValueOf(s.rows[s.next-1]).Elem())
+	return nil
+}
+
+func (s *sliceSource) Err() error {
+	return nil
+}
+
+func TestWriteParquet(t *testing.T) {
+	memo := "hello"
+	fee := int64(200)
+	rows := []interface{}{
+		&TransactionRow{
+			ID:              8589938689,
+			TransactionHash: "a6a7e1fd1b3e0dc4f0ab16da0aeb4bc8bd23cc3f87a7a2a2a5b0a1b2e1e8c7ef",
+			LedgerSequence:  2,
+			LedgerClosedAt:  1700000000000,
+			Account:         "GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7",
+			Memo:            &memo,
+			NewMaxFee:       &fee,
+			Signatures:      []string{"sig1", "sig2"},
+			InnerSignatures: []string{},
+			Successful:      true,
+		},
+		&TransactionRow{ID: 8589938690, LedgerSequence: 2},
+	}
+
+	var buf bytes.Buffer
+	count, err := writeParquet(&buf, func() interface{} { return new(TransactionRow) }, &sliceSource{rows: rows})
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	pr, err := reader.NewParquetReader(buffer.NewBufferFileFromBytes(buf.Bytes()), new(TransactionRow), 1)
+	require.NoError(t, err)
+	defer pr.ReadStop()
+	require.Equal(t, int64(2), pr.GetNumRows())
+
+	read := make([]TransactionRow, 2)
+	require.NoError(t, pr.Read(&read))
+	assert.Equal(t, *rows[0].(*TransactionRow), read[0])
+	assert.Equal(t, int64(8589938690), read[1].ID)
+	assert.Nil(t, read[1].Memo)
+	assert.Empty(t, read[1].Signatures)
+}
+
+func TestSchemas(t *testing.T) {
+	// every table's row type must define a valid parquet schema
+	for _, table := range Tables {
+		var buf bytes.Buffer
+		count, err := writeParquet(&buf, table.newRow, &sliceSource{rows: []interface{}{table.newRow()}})
+		require.NoError(t, err, table.Name)
+		assert.Equal(t, 1, count)
+	}
+}
+
+func TestExportPlan(t *testing.T) {
+	ledgers := Table{Name: "history_ledgers"}
+	effects := Table{Name: "history_effects"}
+	accounts := Table{Name: "accounts", State: true}
+	offers := Table{Name: "offers", State: true}
+
+	// the state tables are exported from the snapshot of the last partition
+	assert.Equal(t, [][]exportFile{
+		{{ledgers, Partition{1500, 1999}}, {effects, Partition{1500, 1999}}},
+		{
+			{ledgers, Partition{2000, 2100}}, {effects, Partition{2000, 2100}},
+			{accounts, Partition{1500, 2100}}, {offers, Partition{1500, 2100}},
+		},
+	}, exportPlan([]Table{ledgers, accounts, effects, offers}, 1500, 2100, 1000))
+
+	assert.Equal(t, [][]exportFile{
+		{{accounts, Partition{1500, 2100}}},
+	}, exportPlan([]Table{accounts}, 1500, 2100, 1000))
+
+	assert.Equal(t, [][]exportFile{
+		{{ledgers, Partition{7, 7}}},
+		{{ledgers, Partition{8, 8}}},
+	}, exportPlan([]Table{ledgers}, 7, 8, 1))
+}
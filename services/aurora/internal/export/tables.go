@@ -0,0 +1,310 @@
+package export
+
+import (
+	"github.com/lib/pq"
+)
+
+// The rows below define the Parquet schema of each exported table. Columns
+// may be added but must not be renamed, removed or change type, as consumers
+// of the export rely on them. Timestamps are milliseconds since the epoch,
+// JSON details are exported as strings and XDR as base64 strings.
+
+// LedgerRow is an exported row of history_ledgers.
+type LedgerRow struct {
+	Sequence                   int32   `db:"sequence" parquet:"name=sequence, type=INT32"`
+	LedgerHash                 string  `db:"ledger_hash" parquet:"name=ledger_hash, type=BYTE_ARRAY, convertedtype=UTF8"`
+	PreviousLedgerHash         *string `db:"previous_ledger_hash" parquet:"name=previous_ledger_hash, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	ClosedAt                   int64   `db:"closed_at" parquet:"name=closed_at, type=INT64, convertedtype=TIMESTAMP_MILLIS"`
+	TransactionCount           int32   `db:"transaction_count" parquet:"name=transaction_count, type=INT32"`
+	SuccessfulTransactionCount *int32  `db:"successful_transaction_count" parquet:"name=successful_transaction_count, type=INT32, repetitiontype=OPTIONAL"`
+	FailedTransactionCount     *int32  `db:"failed_transaction_count" parquet:"name=failed_transaction_count, type=INT32, repetitiontype=OPTIONAL"`
+	OperationCount             int32   `db:"operation_count" parquet:"name=operation_count, type=INT32"`
+	TxSetOperationCount        *int32  `db:"tx_set_operation_count" parquet:"name=tx_set_operation_count, type=INT32, repetitiontype=OPTIONAL"`
+	TotalCoins                 int64   `db:"total_coins" parquet:"name=total_coins, type=INT64"`
+	FeePool                    int64   `db:"fee_pool" parquet:"name=fee_pool, type=INT64"`
+	BaseFee                    int32   `db:"base_fee" parquet:"name=base_fee, type=INT32"`
+	BaseReserve                int32   `db:"base_reserve" parquet:"name=base_reserve, type=INT32"`
+	MaxTxSetSize               int32   `db:"max_tx_set_size" parquet:"name=max_tx_set_size, type=INT32"`
+	ProtocolVersion            int32   `db:"protocol_version" parquet:"name=protocol_version, type=INT32"`
+	LedgerHeaderXDR            *string `db:"ledger_header" parquet:"name=ledger_header_xdr, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+}
+
+// TransactionRow is an exported row of history_transactions.
+type TransactionRow struct {
+	ID                   int64          `db:"id" parquet:"name=id, type=INT64"`
+	TransactionHash      string         `db:"transaction_hash" parquet:"name=transaction_hash, type=BYTE_ARRAY, convertedtype=UTF8"`
+	LedgerSequence       int32          `db:"ledger_sequence" parquet:"name=ledger_sequence, type=INT32"`
+	LedgerClosedAt       int64          `db:"ledger_closed_at" parquet:"name=ledger_closed_at, type=INT64, convertedtype=TIMESTAMP_MILLIS"`
+	ApplicationOrder     int32          `db:"application_order" parquet:"name=application_order, type=INT32"`
+	Account              string         `db:"account" parquet:"name=account, type=BYTE_ARRAY, convertedtype=UTF8"`
+	AccountMuxed         *string        `db:"account_muxed" parquet:"name=account_muxed, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	AccountSequence      int64          `db:"account_sequence" parquet:"name=account_sequence, type=INT64"`
+	MaxFee               int64          `db:"max_fee" parquet:"name=max_fee, type=INT64"`
+	FeeCharged           int64          `db:"fee_charged" parquet:"name=fee_charged, type=INT64"`
+	OperationCount       int32          `db:"operation_count" parquet:"name=operation_count, type=INT32"`
+	Successful           bool           `db:"successful" parquet:"name=successful, type=BOOLEAN"`
+	MemoType             string         `db:"memo_type" parquet:"name=memo_type, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Memo                 *string        `db:"memo" parquet:"name=memo, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	FeeAccount           *string        `db:"fee_account" parquet:"name=fee_account, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	FeeAccountMuxed      *string        `db:"fee_account_muxed" parquet:"name=fee_account_muxed, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	InnerTransactionHash *string        `db:"inner_transaction_hash" parquet:"name=inner_transaction_hash, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	NewMaxFee            *int64         `db:"new_max_fee" parquet:"name=new_max_fee, type=INT64, repetitiontype=OPTIONAL"`
+	Signatures           pq.StringArray `db:"signatures" parquet:"name=signatures, type=LIST, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	InnerSignatures      pq.StringArray `db:"inner_signatures" parquet:"name=inner_signatures, type=LIST, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	TxEnvelope           string         `db:"tx_envelope" parquet:"name=tx_envelope, type=BYTE_ARRAY, convertedtype=UTF8"`
+	TxResult             string         `db:"tx_result" parquet:"name=tx_result, type=BYTE_ARRAY, convertedtype=UTF8"`
+	TxMeta               string         `db:"tx_meta" parquet:"name=tx_meta, type=BYTE_ARRAY, convertedtype=UTF8"`
+	TxFeeMeta            string         `db:"tx_fee_meta" parquet:"name=tx_fee_meta, type=BYTE_ARRAY, convertedtype=UTF8"`
+}
+
+// OperationRow is an exported row of history_operations.
+type OperationRow struct {
+	ID                    int64   `db:"id" parquet:"name=id, type=INT64"`
+	LedgerSequence        int32   `db:"ledger_sequence" parquet:"name=ledger_sequence, type=INT32"`
+	TransactionID         int64   `db:"transaction_id" parquet:"name=transaction_id, type=INT64"`
+	TransactionHash       string  `db:"transaction_hash" parquet:"name=transaction_hash, type=BYTE_ARRAY, convertedtype=UTF8"`
+	TransactionSuccessful bool    `db:"transaction_successful" parquet:"name=transaction_successful, type=BOOLEAN"`
+	ApplicationOrder      int32   `db:"application_order" parquet:"name=application_order, type=INT32"`
+	Type                  int32   `db:"type" parquet:"name=type, type=INT32"`
+	SourceAccount         string  `db:"source_account" parquet:"name=source_account, type=BYTE_ARRAY, convertedtype=UTF8"`
+	SourceAccountMuxed    *string `db:"source_account_muxed" parquet:"name=source_account_muxed, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	IsPayment             bool    `db:"is_payment" parquet:"name=is_payment, type=BOOLEAN"`
+	Details               *string `db:"details" parquet:"name=details, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+}
+
+// EffectRow is an exported row of history_effects.
+type EffectRow struct {
+	HistoryOperationID int64   `db:"history_operation_id" parquet:"name=history_operation_id, type=INT64"`
+	LedgerSequence     int32   `db:"ledger_sequence" parquet:"name=ledger_sequence, type=INT32"`
+	Order              int32   `db:"order" parquet:"name=order, type=INT32"`
+	Type               int32   `db:"type" parquet:"name=type, type=INT32"`
+	Account            *string `db:"address" parquet:"name=account, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	AccountMuxed       *string `db:"address_muxed" parquet:"name=account_muxed, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	Details            *string `db:"details" parquet:"name=details, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+}
+
+// TradeRow is an exported row of history_trades.
+type TradeRow struct {
+	HistoryOperationID     int64   `db:"history_operation_id" parquet:"name=history_operation_id, type=INT64"`
+	LedgerSequence         int32   `db:"ledger_sequence" parquet:"name=ledger_sequence, type=INT32"`
+	Order                  int32   `db:"order" parquet:"name=order, type=INT32"`
+	LedgerClosedAt         int64   `db:"ledger_closed_at" parquet:"name=ledger_closed_at, type=INT64, convertedtype=TIMESTAMP_MILLIS"`
+	TradeType              int32   `db:"trade_type" parquet:"name=trade_type, type=INT32"`
+	BaseOfferID            *int64  `db:"base_offer_id" parquet:"name=base_offer_id, type=INT64, repetitiontype=OPTIONAL"`
+	BaseAccount            *string `db:"base_account" parquet:"name=base_account, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	BaseLiquidityPoolID    *string `db:"base_liquidity_pool_id" parquet:"name=base_liquidity_pool_id, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	BaseAssetType          string  `db:"base_asset_type" parquet:"name=base_asset_type, type=BYTE_ARRAY, convertedtype=UTF8"`
+	BaseAssetCode          string  `db:"base_asset_code" parquet:"name=base_asset_code, type=BYTE_ARRAY, convertedtype=UTF8"`
+	BaseAssetIssuer        string  `db:"base_asset_issuer" parquet:"name=base_asset_issuer, type=BYTE_ARRAY, convertedtype=UTF8"`
+	BaseAmount             int64   `db:"base_amount" parquet:"name=base_amount, type=INT64"`
+	CounterOfferID         *int64  `db:"counter_offer_id" parquet:"name=counter_offer_id, type=INT64, repetitiontype=OPTIONAL"`
+	CounterAccount         *string `db:"counter_account" parquet:"name=counter_account, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	CounterLiquidityPoolID *string `db:"counter_liquidity_pool_id" parquet:"name=counter_liquidity_pool_id, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	CounterAssetType       string  `db:"counter_asset_type" parquet:"name=counter_asset_type, type=BYTE_ARRAY, convertedtype=UTF8"`
+	CounterAssetCode       string  `db:"counter_asset_code" parquet:"name=counter_asset_code, type=BYTE_ARRAY, convertedtype=UTF8"`
+	CounterAssetIssuer     string  `db:"counter_asset_issuer" parquet:"name=counter_asset_issuer, type=BYTE_ARRAY, convertedtype=UTF8"`
+	CounterAmount          int64   `db:"counter_amount" parquet:"name=counter_amount, type=INT64"`
+	LiquidityPoolFee       *int64  `db:"liquidity_pool_fee" parquet:"name=liquidity_pool_fee, type=INT64, repetitiontype=OPTIONAL"`
+	BaseIsSeller           bool    `db:"base_is_seller" parquet:"name=base_is_seller, type=BOOLEAN"`
+	PriceN                 *int64  `db:"price_n" parquet:"name=price_n, type=INT64, repetitiontype=OPTIONAL"`
+	PriceD                 *int64  `db:"price_d" parquet:"name=price_d, type=INT64, repetitiontype=OPTIONAL"`
+}
+
+// AccountRow is an exported row of accounts.
+type AccountRow struct {
+	AccountID            string  `db:"account_id" parquet:"name=account_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Balance              int64   `db:"balance" parquet:"name=balance, type=INT64"`
+	BuyingLiabilities    int64   `db:"buying_liabilities" parquet:"name=buying_liabilities, type=INT64"`
+	SellingLiabilities   int64   `db:"selling_liabilities" parquet:"name=selling_liabilities, type=INT64"`
+	SequenceNumber       int64   `db:"sequence_number" parquet:"name=sequence_number, type=INT64"`
+	SequenceLedger       *int32  `db:"sequence_ledger" parquet:"name=sequence_ledger, type=INT32, repetitiontype=OPTIONAL"`
+	SequenceTime         *int64  `db:"sequence_time" parquet:"name=sequence_time, type=INT64, repetitiontype=OPTIONAL"`
+	NumSubentries        int32   `db:"num_subentries" parquet:"name=num_subentries, type=INT32"`
+	InflationDestination string  `db:"inflation_destination" parquet:"name=inflation_destination, type=BYTE_ARRAY, convertedtype=UTF8"`
+	HomeDomain           string  `db:"home_domain" parquet:"name=home_domain, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Flags                int32   `db:"flags" parquet:"name=flags, type=INT32"`
+	MasterWeight         int32   `db:"master_weight" parquet:"name=master_weight, type=INT32"`
+	ThresholdLow         int32   `db:"threshold_low" parquet:"name=threshold_low, type=INT32"`
+	ThresholdMedium      int32   `db:"threshold_medium" parquet:"name=threshold_medium, type=INT32"`
+	ThresholdHigh        int32   `db:"threshold_high" parquet:"name=threshold_high, type=INT32"`
+	Sponsor              *string `db:"sponsor" parquet:"name=sponsor, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	NumSponsored         int32   `db:"num_sponsored" parquet:"name=num_sponsored, type=INT32"`
+	NumSponsoring        int32   `db:"num_sponsoring" parquet:"name=num_sponsoring, type=INT32"`
+	LastModifiedLedger   int32   `db:"last_modified_ledger" parquet:"name=last_modified_ledger, type=INT32"`
+}
+
+// TrustLineRow is an exported row of trust_lines.
+type TrustLineRow struct {
+	LedgerKey          string  `db:"ledger_key" parquet:"name=ledger_key, type=BYTE_ARRAY, convertedtype=UTF8"`
+	AccountID          string  `db:"account_id" parquet:"name=account_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	AssetType          int32   `db:"asset_type" parquet:"name=asset_type, type=INT32"`
+	AssetCode          string  `db:"asset_code" parquet:"name=asset_code, type=BYTE_ARRAY, convertedtype=UTF8"`
+	AssetIssuer        string  `db:"asset_issuer" parquet:"name=asset_issuer, type=BYTE_ARRAY, convertedtype=UTF8"`
+	LiquidityPoolID    string  `db:"liquidity_pool_id" parquet:"name=liquidity_pool_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Balance            int64   `db:"balance" parquet:"name=balance, type=INT64"`
+	Limit              int64   `db:"trust_line_limit" parquet:"name=limit, type=INT64"`
+	BuyingLiabilities  int64   `db:"buying_liabilities" parquet:"name=buying_liabilities, type=INT64"`
+	SellingLiabilities int64   `db:"selling_liabilities" parquet:"name=selling_liabilities, type=INT64"`
+	Flags              int32   `db:"flags" parquet:"name=flags, type=INT32"`
+	Sponsor            *string `db:"sponsor" parquet:"name=sponsor, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	LastModifiedLedger int32   `db:"last_modified_ledger" parquet:"name=last_modified_ledger, type=INT32"`
+}
+
+// OfferRow is an exported row of offers. Offers marked as deleted aren't
+// exported.
+type OfferRow struct {
+	OfferID            int64   `db:"offer_id" parquet:"name=offer_id, type=INT64"`
+	SellerID           string  `db:"seller_id" parquet:"name=seller_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	SellingAssetXDR    string  `db:"selling_asset" parquet:"name=selling_asset_xdr, type=BYTE_ARRAY, convertedtype=UTF8"`
+	BuyingAssetXDR     string  `db:"buying_asset" parquet:"name=buying_asset_xdr, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Amount             int64   `db:"amount" parquet:"name=amount, type=INT64"`
+	PriceN             int32   `db:"pricen" parquet:"name=price_n, type=INT32"`
+	PriceD             int32   `db:"priced" parquet:"name=price_d, type=INT32"`
+	Price              float64 `db:"price" parquet:"name=price, type=DOUBLE"`
+	Flags              int32   `db:"flags" parquet:"name=flags, type=INT32"`
+	Sponsor            *string `db:"sponsor" parquet:"name=sponsor, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	LastModifiedLedger int32   `db:"last_modified_ledger" parquet:"name=last_modified_ledger, type=INT32"`
+}
+
+// ClaimableBalanceRow is an exported row of claimable_balances.
+type ClaimableBalanceRow struct {
+	ID                 string  `db:"id" parquet:"name=id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	AssetXDR           string  `db:"asset" parquet:"name=asset_xdr, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Amount             int64   `db:"amount" parquet:"name=amount, type=INT64"`
+	Claimants          string  `db:"claimants" parquet:"name=claimants, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Flags              int32   `db:"flags" parquet:"name=flags, type=INT32"`
+	Sponsor            *string `db:"sponsor" parquet:"name=sponsor, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	LastModifiedLedger int32   `db:"last_modified_ledger" parquet:"name=last_modified_ledger, type=INT32"`
+}
+
+// LiquidityPoolRow is an exported row of liquidity_pools. Pools marked as
+// deleted aren't exported.
+type LiquidityPoolRow struct {
+	ID                 string `db:"id" parquet:"name=id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Type               int32  `db:"type" parquet:"name=type, type=INT32"`
+	Fee                int32  `db:"fee" parquet:"name=fee, type=INT32"`
+	TrustlineCount     int64  `db:"trustline_count" parquet:"name=trustline_count, type=INT64"`
+	ShareCount         int64  `db:"share_count" parquet:"name=share_count, type=INT64"`
+	AssetReserves      string `db:"asset_reserves" parquet:"name=asset_reserves, type=BYTE_ARRAY, convertedtype=UTF8"`
+	LastModifiedLedger int32  `db:"last_modified_ledger" parquet:"name=last_modified_ledger, type=INT32"`
+}
+
+// toid bounds of the ledgers in [$1, $2]
+const toidRange = "BETWEEN ($1::bigint << 32) AND (($2::bigint + 1) << 32) - 1"
+
+// Tables are the tables which can be exported, in export order.
+var Tables = []Table{
+	{
+		Name: "history_ledgers",
+		query: `SELECT sequence, ledger_hash, previous_ledger_hash,
+			(EXTRACT(EPOCH FROM closed_at) * 1000)::bigint AS closed_at,
+			transaction_count, successful_transaction_count, failed_transaction_count,
+			operation_count, tx_set_operation_count, total_coins, fee_pool, base_fee,
+			base_reserve, max_tx_set_size, protocol_version, ledger_header
+			FROM history_ledgers WHERE sequence BETWEEN $1 AND $2 ORDER BY sequence`,
+		newRow: func() interface{} { return new(LedgerRow) },
+	},
+	{
+		Name: "history_transactions",
+		query: `SELECT ht.id, ht.transaction_hash, ht.ledger_sequence,
+			(EXTRACT(EPOCH FROM hl.closed_at) * 1000)::bigint AS ledger_closed_at,
+			ht.application_order, ht.account, ht.account_muxed, ht.account_sequence,
+			ht.max_fee, ht.fee_charged, ht.operation_count,
+			COALESCE(ht.successful, true) AS successful, ht.memo_type, ht.memo,
+			ht.fee_account, ht.fee_account_muxed, ht.inner_transaction_hash, ht.new_max_fee,
+			ht.signatures, ht.inner_signatures, ht.tx_envelope, ht.tx_result, ht.tx_meta, ht.tx_fee_meta
+			FROM history_transactions ht
+			JOIN history_ledgers hl ON hl.sequence = ht.ledger_sequence
+			WHERE ht.ledger_sequence BETWEEN $1 AND $2 ORDER BY ht.id`,
+		newRow: func() interface{} { return new(TransactionRow) },
+	},
+	{
+		Name: "history_operations",
+		query: `SELECT hop.id, (hop.id >> 32)::integer AS ledger_sequence,
+			hop.transaction_id, ht.transaction_hash,
+			COALESCE(ht.successful, true) AS transaction_successful,
+			hop.application_order, hop.type, hop.source_account, hop.source_account_muxed,
+			COALESCE(hop.is_payment, false) AS is_payment, hop.details
+			FROM history_operations hop
+			JOIN history_transactions ht ON ht.id = hop.transaction_id
+			WHERE hop.id ` + toidRange + ` ORDER BY hop.id`,
+		newRow: func() interface{} { return new(OperationRow) },
+	},
+	{
+		Name: "history_effects",
+		query: `SELECT heff.history_operation_id, (heff.history_operation_id >> 32)::integer AS ledger_sequence,
+			heff."order", heff.type, hacc.address, heff.address_muxed, heff.details
+			FROM history_effects heff
+			LEFT JOIN history_accounts hacc ON hacc.id = heff.history_account_id
+			WHERE heff.history_operation_id ` + toidRange + `
+			ORDER BY heff.history_operation_id, heff."order"`,
+		newRow: func() interface{} { return new(EffectRow) },
+	},
+	{
+		Name: "history_trades",
+		query: `SELECT htrd.history_operation_id, (htrd.history_operation_id >> 32)::integer AS ledger_sequence,
+			htrd."order", (EXTRACT(EPOCH FROM htrd.ledger_closed_at) * 1000)::bigint AS ledger_closed_at,
+			htrd.trade_type, htrd.base_offer_id, base_accounts.address AS base_account,
+			blp.liquidity_pool_id AS base_liquidity_pool_id,
+			base_assets.asset_type AS base_asset_type, base_assets.asset_code AS base_asset_code,
+			base_assets.asset_issuer AS base_asset_issuer, htrd.base_amount,
+			htrd.counter_offer_id, counter_accounts.address AS counter_account,
+			clp.liquidity_pool_id AS counter_liquidity_pool_id,
+			counter_assets.asset_type AS counter_asset_type, counter_assets.asset_code AS counter_asset_code,
+			counter_assets.asset_issuer AS counter_asset_issuer, htrd.counter_amount,
+			htrd.liquidity_pool_fee, htrd.base_is_seller, htrd.price_n, htrd.price_d
+			FROM history_trades htrd
+			LEFT JOIN history_accounts base_accounts ON base_account_id = base_accounts.id
+			LEFT JOIN history_accounts counter_accounts ON counter_account_id = counter_accounts.id
+			LEFT JOIN history_liquidity_pools blp ON base_liquidity_pool_id = blp.id
+			LEFT JOIN history_liquidity_pools clp ON counter_liquidity_pool_id = clp.id
+			JOIN history_assets base_assets ON base_asset_id = base_assets.id
+			JOIN history_assets counter_assets ON counter_asset_id = counter_assets.id
+			WHERE htrd.history_operation_id ` + toidRange + `
+			ORDER BY htrd.history_operation_id, htrd."order"`,
+		newRow: func() interface{} { return new(TradeRow) },
+	},
+	{
+		Name:  "accounts",
+		State: true,
+		query: `SELECT account_id, balance, buying_liabilities, selling_liabilities,
+			sequence_number, sequence_ledger, sequence_time, num_subentries,
+			inflation_destination, home_domain, flags, master_weight, threshold_low,
+			threshold_medium, threshold_high, sponsor, num_sponsored, num_sponsoring,
+			last_modified_ledger
+			FROM accounts WHERE last_modified_ledger BETWEEN $1 AND $2`,
+		newRow: func() interface{} { return new(AccountRow) },
+	},
+	{
+		Name:  "trust_lines",
+		State: true,
+		query: `SELECT ledger_key, account_id, asset_type, asset_code, asset_issuer,
+			liquidity_pool_id, balance, trust_line_limit, buying_liabilities,
+			selling_liabilities, flags, sponsor, last_modified_ledger
+			FROM trust_lines WHERE last_modified_ledger BETWEEN $1 AND $2`,
+		newRow: func() interface{} { return new(TrustLineRow) },
+	},
+	{
+		Name:  "offers",
+		State: true,
+		query: `SELECT offer_id, seller_id, selling_asset, buying_asset, amount, pricen,
+			priced, price, flags, sponsor, last_modified_ledger
+			FROM offers WHERE last_modified_ledger BETWEEN $1 AND $2 AND NOT deleted`,
+		newRow: func() interface{} { return new(OfferRow) },
+	},
+	{
+		Name:  "claimable_balances",
+		State: true,
+		query: `SELECT id, asset, amount, claimants, flags, sponsor, last_modified_ledger
+			FROM claimable_balances WHERE last_modified_ledger BETWEEN $1 AND $2`,
+		newRow: func() interface{} { return new(ClaimableBalanceRow) },
+	},
+	{
+		Name:  "liquidity_pools",
+		State: true,
+		query: `SELECT id, type, fee, trustline_count, share_count, asset_reserves,
+			last_modified_ledger
+			FROM liquidity_pools WHERE last_modified_ledger BETWEEN $1 AND $2 AND NOT deleted`,
+		newRow: func() interface{} { return new(LiquidityPoolRow) },
+	},
+}
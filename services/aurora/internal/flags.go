@@ -176,7 +176,17 @@ func Flags() (*Config, support.ConfigOptions) {
 			ConfigKey:      &config.RoDatabaseURL,
 			OptType:        types.String,
 			Required:       false,
-			Usage:          "aurora postgres read-replica to connect with, when set it will return stale history error when replica is behind primary",
+			Usage:          "aurora postgres read-replica to connect with, when set reads are sent to the replica unless it lags behind the primary by more than ro-database-max-staleness or has not ingested the latest ledger, in which case they are sent to the primary",
+			UsedInCommands: IngestionCommands,
+		},
+		&support.ConfigOption{
+			Name:           "ro-database-max-staleness",
+			ConfigKey:      &config.RoDatabaseMaxStaleness,
+			OptType:        types.Int,
+			FlagDefault:    10,
+			CustomSetValue: support.SetDuration,
+			Required:       false,
+			Usage:          "largest replication lag (in seconds) at which the read-replica set by ro-database-url still serves reads",
 			UsedInCommands: IngestionCommands,
 		},
 		&support.ConfigOption{
@@ -360,6 +360,10 @@ func (m *StateMiddleware) Wrap(h http.Handler) http.Handler {
 	return m.WrapFunc(h.ServeHTTP)
 }
 
+// ReplicaSyncCheckMiddleware makes requests read from the primary when the
+// replica that ReplicaHistoryQ reads from has not ingested the latest ledger
+// ingested into the primary yet, so that responses are never older than
+// what ingestion has committed.
 type ReplicaSyncCheckMiddleware struct {
 	PrimaryHistoryQ *history.Q
 	ReplicaHistoryQ *history.Q
@@ -369,35 +373,21 @@ type ReplicaSyncCheckMiddleware struct {
 // WrapFunc executes the middleware on a given HTTP handler function
 func (m *ReplicaSyncCheckMiddleware) WrapFunc(h http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		for attempt := 1; attempt <= 4; attempt++ {
-			primaryIngestLedger, err := m.PrimaryHistoryQ.GetLastLedgerIngestNonBlocking(r.Context())
-			if err != nil {
-				problem.Render(r.Context(), w, err)
-				return
-			}
-
-			replicaIngestLedger, err := m.ReplicaHistoryQ.GetLastLedgerIngestNonBlocking(r.Context())
-			if err != nil {
-				problem.Render(r.Context(), w, err)
-				return
-			}
+		primaryIngestLedger, err := m.PrimaryHistoryQ.GetLastLedgerIngestNonBlocking(r.Context())
+		if err != nil {
+			problem.Render(r.Context(), w, err)
+			return
+		}
 
-			if replicaIngestLedger >= primaryIngestLedger {
-				break
-			}
+		replicaIngestLedger, err := m.ReplicaHistoryQ.GetLastLedgerIngestNonBlocking(r.Context())
+		if err != nil {
+			problem.Render(r.Context(), w, err)
+			return
+		}
 
-			switch attempt {
-			case 1:
-				time.Sleep(20 * time.Millisecond)
-			case 2:
-				time.Sleep(40 * time.Millisecond)
-			case 3:
-				time.Sleep(80 * time.Millisecond)
-			case 4:
-				problem.Render(r.Context(), w, hProblem.StaleHistory)
-				m.ServerMetrics.ReplicaLagErrorsCounter.Inc()
-				return
-			}
+		if replicaIngestLedger < primaryIngestLedger {
+			m.ServerMetrics.ReplicaLagErrorsCounter.Inc()
+			r = r.WithContext(context.WithValue(r.Context(), &db.ReadFromPrimaryCtxKey, true))
 		}
 
 		h.ServeHTTP(w, r)
@@ -0,0 +1,119 @@
+package httpx
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/go-chi/chi"
+	"github.com/jmoiron/sqlx"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	auroraContext "github.com/shantanu-hashcash/go/services/aurora/internal/context"
+	"github.com/shantanu-hashcash/go/services/aurora/internal/db2/history"
+	"github.com/shantanu-hashcash/go/services/aurora/internal/ingest"
+	"github.com/shantanu-hashcash/go/support/db"
+	"github.com/shantanu-hashcash/go/support/render/problem"
+)
+
+// mockKeyValueStore makes session answer key value store reads with values
+// and reads of the latest history ledger with latestLedger.
+func mockKeyValueStore(session *db.MockSession, values map[string]string, latestLedger uint32) {
+	session.On("Get", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		_, sqlArgs, err := args.Get(2).(sq.Sqlizer).ToSql()
+		if err != nil {
+			panic(err)
+		}
+		*args.Get(1).(*string) = values[sqlArgs[0].(string)]
+	}).Return(nil)
+	session.On("GetRaw", mock.Anything, mock.Anything, mock.Anything, []interface{}(nil)).Run(func(args mock.Arguments) {
+		*args.Get(1).(*uint32) = latestLedger
+	}).Return(nil)
+}
+
+func TestStateMiddlewareReadsFromFreshReplica(t *testing.T) {
+	for _, testCase := range []struct {
+		name              string
+		replicaLastLedger string
+		expectReplica     bool
+	}{
+		{name: "replica in sync", replicaLastLedger: "6", expectReplica: true},
+		{name: "replica behind ingestion", replicaLastLedger: "5", expectReplica: false},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			primary, replica := &db.MockSession{}, &db.MockSession{}
+			for _, session := range []*db.MockSession{primary, replica} {
+				session.On("Clone").Return(session)
+				session.On("Close").Return(nil).Maybe()
+				session.On("GetTx").Return((*sqlx.Tx)(nil)).Maybe()
+			}
+			mockKeyValueStore(primary, map[string]string{
+				"exp_ingest_version":     strconv.Itoa(ingest.CurrentVersion),
+				"exp_ingest_last_ledger": "6",
+			}, 6)
+			mockKeyValueStore(replica, map[string]string{
+				"exp_ingest_version":     strconv.Itoa(ingest.CurrentVersion),
+				"exp_ingest_last_ledger": testCase.replicaLastLedger,
+			}, 6)
+
+			session := db.NewReplicaSession(primary, []db.SessionInterface{replica}, db.ReplicaConfig{
+				MaxStaleness:  time.Second,
+				ProbeInterval: time.Hour,
+				LagProbe: func(context.Context, db.SessionInterface, db.SessionInterface) (time.Duration, error) {
+					return 0, nil
+				},
+			})
+			defer session.Close()
+			assert.Eventually(t, func() bool {
+				_, fresh := session.ReplicaLag()
+				return fresh[0]
+			}, time.Second, time.Millisecond)
+
+			// the request's transaction and every read in it run on the
+			// replica, unless the replica has not caught up with ingestion
+			expected, other := replica, primary
+			if !testCase.expectReplica {
+				expected, other = primary, replica
+			}
+			expected.On("BeginTx", mock.Anything, &sql.TxOptions{
+				Isolation: sql.LevelRepeatableRead,
+				ReadOnly:  true,
+			}).Return(nil).Once()
+			expected.On("Rollback").Return(nil).Once()
+			expected.On("SelectRaw", mock.Anything, mock.Anything, "SELECT * FROM history_ledgers", []interface{}(nil)).
+				Return(nil).Once()
+
+			stateMiddleware := &StateMiddleware{AuroraSession: session}
+			replicaSyncMiddleware := &ReplicaSyncCheckMiddleware{
+				PrimaryHistoryQ: &history.Q{SessionInterface: primary},
+				ReplicaHistoryQ: &history.Q{SessionInterface: session},
+				ServerMetrics:   &ServerMetrics{ReplicaLagErrorsCounter: prometheus.NewCounter(prometheus.CounterOpts{Name: "replica_lag_errors"})},
+			}
+			handler := chi.NewRouter()
+			handler.Use(replicaSyncMiddleware.Wrap)
+			handler.With(stateMiddleware.Wrap).MethodFunc("GET", "/", func(w http.ResponseWriter, r *http.Request) {
+				session := r.Context().Value(&auroraContext.SessionContextKey).(db.SessionInterface)
+				var ledgers []history.Ledger
+				if err := session.SelectRaw(r.Context(), &ledgers, "SELECT * FROM history_ledgers"); err != nil {
+					problem.Render(r.Context(), w, err)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest("GET", "http://localhost/", nil))
+			assert.Equal(t, http.StatusOK, w.Code)
+			expected.AssertExpectations(t)
+			other.AssertNotCalled(t, "BeginTx", mock.Anything, mock.Anything)
+			other.AssertNotCalled(t, "SelectRaw", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
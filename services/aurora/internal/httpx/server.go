@@ -89,7 +89,7 @@ func NewServer(serverConfig ServerConfig, routerConfig RouterConfig, ledgerState
 		ReplicaLagErrorsCounter: prometheus.NewCounter(
 			prometheus.CounterOpts{
 				Namespace: "aurora", Subsystem: "http", Name: "replica_lag_errors_count",
-				Help: "Count of HTTP errors returned due to replica lag",
+				Help: "Count of HTTP requests read from the primary due to replica lag",
 			},
 		),
 	}
@@ -4,23 +4,12 @@ import (
 	"math/big"
 
 	"github.com/shantanu-hashcash/go/ingest"
+	"github.com/shantanu-hashcash/go/ingest/processors"
 	"github.com/shantanu-hashcash/go/services/aurora/internal/db2/history"
 	"github.com/shantanu-hashcash/go/support/errors"
 	"github.com/shantanu-hashcash/go/xdr"
 )
 
-type assetStatKey struct {
-	assetType   xdr.AssetType
-	assetCode   string
-	assetIssuer string
-}
-
-type assetStatValue struct {
-	assetStatKey
-	balances assetStatBalances
-	accounts history.ExpAssetStatAccounts
-}
-
 type assetStatBalances struct {
 	Authorized                      *big.Int
 	AuthorizedToMaintainLiabilities *big.Int
@@ -101,250 +90,58 @@ func (a assetStatBalances) ConvertToHistoryObject() history.ExpAssetStatBalances
 	}
 }
 
-func (value assetStatValue) ConvertToHistoryObject() history.ExpAssetStat {
-	balances := value.balances.ConvertToHistoryObject()
-	return history.ExpAssetStat{
-		AssetType:   value.assetType,
-		AssetCode:   value.assetCode,
-		AssetIssuer: value.assetIssuer,
-		Accounts:    value.accounts,
-		Balances:    balances,
-		Amount:      balances.Authorized,
-		NumAccounts: value.accounts.Authorized,
-	}
-}
-
 // AssetStatSet represents a collection of asset stats and a mapping
 // of Soroban contract IDs to classic assets (which is unique to each
 // network).
 type AssetStatSet struct {
-	classicAssetStats map[assetStatKey]*assetStatValue
+	processor *processors.AssetStatsProcessor
 }
 
 // NewAssetStatSet constructs a new AssetStatSet instance
 func NewAssetStatSet() AssetStatSet {
 	return AssetStatSet{
-		classicAssetStats: map[assetStatKey]*assetStatValue{},
-	}
-}
-
-type delta struct {
-	Authorized                      int64
-	AuthorizedToMaintainLiabilities int64
-	Unauthorized                    int64
-	ClaimableBalances               int64
-	LiquidityPools                  int64
-}
-
-func (d *delta) addByFlags(flags xdr.Uint32, amount int64) {
-	f := xdr.TrustLineFlags(flags)
-	if f.IsAuthorized() {
-		d.Authorized += amount
-	} else if f.IsAuthorizedToMaintainLiabilitiesFlag() {
-		d.AuthorizedToMaintainLiabilities += amount
-	} else {
-		d.Unauthorized += amount
+		processor: processors.NewAssetStatsProcessor(),
 	}
 }
 
-func (d delta) isEmpty() bool {
-	return d == delta{}
-}
-
-// addDelta adds a delta balance and delta accounts to a given asset trustline.
-func (s AssetStatSet) addDelta(asset xdr.Asset, deltaBalances, deltaAccounts delta) error {
-	if deltaBalances.isEmpty() && deltaAccounts.isEmpty() {
-		return nil
-	}
-
-	var key assetStatKey
-	if err := asset.Extract(&key.assetType, &key.assetCode, &key.assetIssuer); err != nil {
-		return errors.Wrap(err, "could not extract asset info from trustline")
-	}
-
-	current, ok := s.classicAssetStats[key]
-	if !ok {
-		current = &assetStatValue{assetStatKey: key, balances: newAssetStatBalance()}
-		s.classicAssetStats[key] = current
-	}
-
-	current.accounts.Authorized += int32(deltaAccounts.Authorized)
-	current.accounts.AuthorizedToMaintainLiabilities += int32(deltaAccounts.AuthorizedToMaintainLiabilities)
-	current.accounts.ClaimableBalances += int32(deltaAccounts.ClaimableBalances)
-	current.accounts.LiquidityPools += int32(deltaAccounts.LiquidityPools)
-	current.accounts.Unauthorized += int32(deltaAccounts.Unauthorized)
-
-	current.balances.Authorized.Add(current.balances.Authorized, big.NewInt(deltaBalances.Authorized))
-	current.balances.AuthorizedToMaintainLiabilities.Add(current.balances.AuthorizedToMaintainLiabilities, big.NewInt(deltaBalances.AuthorizedToMaintainLiabilities))
-	current.balances.ClaimableBalances.Add(current.balances.ClaimableBalances, big.NewInt(deltaBalances.ClaimableBalances))
-	current.balances.LiquidityPools.Add(current.balances.LiquidityPools, big.NewInt(deltaBalances.LiquidityPools))
-	current.balances.Unauthorized.Add(current.balances.Unauthorized, big.NewInt(deltaBalances.Unauthorized))
-
-	// Note: it's possible that after operations above:
-	// numAccounts != 0 && amount == 0 (ex. two accounts send some of their assets to third account)
-	//  OR
-	// numAccounts == 0 && amount != 0 (ex. issuer issued an asset)
-	if current.balances.IsZero() && current.accounts.IsZero() {
-		delete(s.classicAssetStats, key)
-	}
-
-	return nil
-}
-
 // AddTrustline updates the set to account for how a given trustline has changed.
 // change must be a xdr.LedgerEntryTypeTrustLine type.
 func (s AssetStatSet) AddTrustline(change ingest.Change) error {
-	var pre, post *xdr.TrustLineEntry
-	if change.Pre != nil {
-		pre = change.Pre.Data.TrustLine
-	}
-	if change.Post != nil {
-		post = change.Post.Data.TrustLine
-	}
-
-	deltaAccounts := delta{}
-	deltaBalances := delta{}
-
-	if pre == nil && post == nil {
-		return ingest.NewStateError(errors.New("both pre and post trustlines cannot be nil"))
-	}
-
-	var asset xdr.TrustLineAsset
-	if pre != nil {
-		asset = pre.Asset
-		deltaAccounts.addByFlags(pre.Flags, -1)
-		deltaBalances.addByFlags(pre.Flags, -int64(pre.Balance))
-	}
-	if post != nil {
-		asset = post.Asset
-		deltaAccounts.addByFlags(post.Flags, 1)
-		deltaBalances.addByFlags(post.Flags, int64(post.Balance))
-	}
-	if asset.Type == xdr.AssetTypeAssetTypePoolShare || asset.Type == xdr.AssetTypeAssetTypeNative {
-		return nil
-	}
-
-	err := s.addDelta(asset.ToAsset(), deltaBalances, deltaAccounts)
-	if err != nil {
-		return errors.Wrap(err, "error running AssetStatSet.addDelta")
-	}
-	return nil
+	change.Type = xdr.LedgerEntryTypeTrustline
+	return s.processor.ProcessChange(change)
 }
 
 // AddLiquidityPool updates the set to account for how a given liquidity pool has changed.
 // change must be a xdr.LedgerEntryTypeLiqidityPool type.
 func (s AssetStatSet) AddLiquidityPool(change ingest.Change) error {
-	var pre, post *xdr.LiquidityPoolEntry
-	if change.Pre != nil {
-		pre = change.Pre.Data.LiquidityPool
-	}
-	if change.Post != nil {
-		post = change.Post.Data.LiquidityPool
-	}
-
-	assetAdeltaNum := delta{}
-	assetAdeltaBalances := delta{}
-	assetBdeltaNum := delta{}
-	assetBdeltaBalances := delta{}
-
-	if pre == nil && post == nil {
-		return ingest.NewStateError(errors.New("both pre and post liquidity pools cannot be nil"))
-	}
-
-	lpType, err := change.GetLiquidityPoolType()
-	if err != nil {
-		return ingest.NewStateError(err)
-	}
-
-	var assetA, assetB xdr.Asset
-	switch lpType {
-	case xdr.LiquidityPoolTypeLiquidityPoolConstantProduct:
-		if pre != nil {
-			assetA = pre.Body.ConstantProduct.Params.AssetA
-			assetAdeltaNum.LiquidityPools--
-			assetAdeltaBalances.LiquidityPools -= int64(pre.Body.ConstantProduct.ReserveA)
-
-			assetB = pre.Body.ConstantProduct.Params.AssetB
-			assetBdeltaNum.LiquidityPools--
-			assetBdeltaBalances.LiquidityPools -= int64(pre.Body.ConstantProduct.ReserveB)
-		}
-		if post != nil {
-			assetA = post.Body.ConstantProduct.Params.AssetA
-			assetAdeltaNum.LiquidityPools++
-			assetAdeltaBalances.LiquidityPools += int64(post.Body.ConstantProduct.ReserveA)
-
-			assetB = post.Body.ConstantProduct.Params.AssetB
-			assetBdeltaNum.LiquidityPools++
-			assetBdeltaBalances.LiquidityPools += int64(post.Body.ConstantProduct.ReserveB)
-		}
-	default:
-		return errors.Errorf("Unknown liquidity pool type=%d", lpType)
-	}
-
-	if assetA.Type != xdr.AssetTypeAssetTypeNative {
-		err := s.addDelta(assetA, assetAdeltaBalances, assetAdeltaNum)
-		if err != nil {
-			return errors.Wrap(err, "error running AssetStatSet.addDelta using AssetA")
-		}
-	}
-
-	if assetB.Type != xdr.AssetTypeAssetTypeNative {
-		err := s.addDelta(assetB, assetBdeltaBalances, assetBdeltaNum)
-		if err != nil {
-			return errors.Wrap(err, "error running AssetStatSet.addDelta using AssetB")
-		}
-	}
-
-	return nil
+	change.Type = xdr.LedgerEntryTypeLiquidityPool
+	return s.processor.ProcessChange(change)
 }
 
 // AddClaimableBalance updates the set to account for how a given claimable balance has changed.
 // change must be a xdr.LedgerEntryTypeClaimableBalance type.
 func (s AssetStatSet) AddClaimableBalance(change ingest.Change) error {
-	var pre, post *xdr.ClaimableBalanceEntry
-	if change.Pre != nil {
-		pre = change.Pre.Data.ClaimableBalance
-	}
-	if change.Post != nil {
-		post = change.Post.Data.ClaimableBalance
-	}
-
-	deltaAccounts := delta{}
-	deltaBalances := delta{}
-
-	if pre == nil && post == nil {
-		return ingest.NewStateError(errors.New("both pre and post claimable balances cannot be nil"))
-	}
-
-	var asset xdr.Asset
-	if pre != nil {
-		asset = pre.Asset
-		deltaAccounts.ClaimableBalances--
-		deltaBalances.ClaimableBalances -= int64(pre.Amount)
-	}
-	if post != nil {
-		asset = post.Asset
-		deltaAccounts.ClaimableBalances++
-		deltaBalances.ClaimableBalances += int64(post.Amount)
-	}
-
-	if asset.Type == xdr.AssetTypeAssetTypeNative {
-		return nil
-	}
-
-	err := s.addDelta(asset, deltaBalances, deltaAccounts)
-	if err != nil {
-		return errors.Wrap(err, "error running AssetStatSet.addDelta")
-	}
-	return nil
+	change.Type = xdr.LedgerEntryTypeClaimableBalance
+	return s.processor.ProcessChange(change)
 }
 
 // All returns a list of all `history.ExpAssetStat` contained within the set
 // along with all contract id attribution changes in the set.
 func (s AssetStatSet) All() []history.ExpAssetStat {
-	assetStats := make([]history.ExpAssetStat, 0, len(s.classicAssetStats))
-	for _, value := range s.classicAssetStats {
-		assetStats = append(assetStats, value.ConvertToHistoryObject())
+	stats := s.processor.Stats()
+	assetStats := make([]history.ExpAssetStat, 0, len(stats))
+	for _, stat := range stats {
+		balances := assetStatBalances(stat.Balances).ConvertToHistoryObject()
+		accounts := history.ExpAssetStatAccounts(stat.Accounts)
+		assetStats = append(assetStats, history.ExpAssetStat{
+			AssetType:   stat.AssetType,
+			AssetCode:   stat.AssetCode,
+			AssetIssuer: stat.AssetIssuer,
+			Accounts:    accounts,
+			Balances:    balances,
+			Amount:      balances.Authorized,
+			NumAccounts: accounts.Authorized,
+		})
 	}
 	return assetStats
 }
@@ -115,13 +115,8 @@ func claimableBalancesForChanges(
 func (p *ClaimableBalancesTransactionProcessor) addOperationClaimableBalances(
 	sequence uint32, transaction ingest.LedgerTransaction,
 ) error {
-	for opi, op := range transaction.Envelope.Operations() {
-		operation := transactionOperationWrapper{
-			index:          uint32(opi),
-			transaction:    transaction,
-			operation:      op,
-			ledgerSequence: sequence,
-		}
+	for opi := range transaction.Envelope.Operations() {
+		operationID := toid.New(int32(sequence), int32(transaction.Index), int32(opi+1)).ToInt64()
 
 		changes, err := transaction.GetOperationChanges(uint32(opi))
 		if err != nil {
@@ -129,11 +124,11 @@ func (p *ClaimableBalancesTransactionProcessor) addOperationClaimableBalances(
 		}
 		cbs, err := claimableBalancesForChanges(changes)
 		if err != nil {
-			return errors.Wrapf(err, "reading operation %v claimable balances", operation.ID())
+			return errors.Wrapf(err, "reading operation %v claimable balances", operationID)
 		}
 
 		for _, cb := range dedupeStrings(cbs) {
-			if err = p.opBatch.Add(operation.ID(), p.cbLoader.GetFuture(cb)); err != nil {
+			if err = p.opBatch.Add(operationID, p.cbLoader.GetFuture(cb)); err != nil {
 				return err
 			}
 		}
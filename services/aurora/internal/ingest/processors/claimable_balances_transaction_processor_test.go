@@ -118,12 +118,7 @@ func (s *ClaimableBalancesTransactionProcessorTestSuiteLedger) testOperationInse
 			}
 	}
 	txnID := toid.New(int32(s.lcm.LedgerSequence()), int32(txn.Index), 0).ToInt64()
-	opID := (&transactionOperationWrapper{
-		index:          uint32(0),
-		transaction:    txn,
-		operation:      txn.Envelope.Operations()[0],
-		ledgerSequence: s.lcm.LedgerSequence(),
-	}).ID()
+	opID := toid.New(int32(s.lcm.LedgerSequence()), int32(txn.Index), 1).ToInt64()
 
 	hexID, err := xdr.MarshalHex(balanceID)
 	s.Assert().NoError(err)
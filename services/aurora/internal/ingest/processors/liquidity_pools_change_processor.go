@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/shantanu-hashcash/go/ingest"
+	"github.com/shantanu-hashcash/go/ingest/processors"
 	"github.com/shantanu-hashcash/go/services/aurora/internal/db2/history"
 	"github.com/shantanu-hashcash/go/support/errors"
 	"github.com/shantanu-hashcash/go/xdr"
@@ -107,5 +108,5 @@ func (p *LiquidityPoolsChangeProcessor) ledgerEntryToRow(entry *xdr.LedgerEntry)
 
 // PoolIDToString encodes a liquidity pool id xdr value to its string form
 func PoolIDToString(id xdr.PoolId) string {
-	return xdr.Hash(id).HexString()
+	return processors.PoolIDToString(id)
 }
@@ -122,13 +122,8 @@ func liquidityPoolsForChanges(
 }
 
 func (p *LiquidityPoolsTransactionProcessor) addOperationLiquidityPools(sequence uint32, transaction ingest.LedgerTransaction) error {
-	for opi, op := range transaction.Envelope.Operations() {
-		operation := transactionOperationWrapper{
-			index:          uint32(opi),
-			transaction:    transaction,
-			operation:      op,
-			ledgerSequence: sequence,
-		}
+	for opi := range transaction.Envelope.Operations() {
+		operationID := toid.New(int32(sequence), int32(transaction.Index), int32(opi+1)).ToInt64()
 
 		changes, err := transaction.GetOperationChanges(uint32(opi))
 		if err != nil {
@@ -136,10 +131,10 @@ func (p *LiquidityPoolsTransactionProcessor) addOperationLiquidityPools(sequence
 		}
 		lps, err := liquidityPoolsForChanges(changes)
 		if err != nil {
-			return errors.Wrapf(err, "reading operation %v liquidity pools", operation.ID())
+			return errors.Wrapf(err, "reading operation %v liquidity pools", operationID)
 		}
 		for _, lp := range dedupeStrings(lps) {
-			if err := p.opBatch.Add(operation.ID(), p.lpLoader.GetFuture(lp)); err != nil {
+			if err := p.opBatch.Add(operationID, p.lpLoader.GetFuture(lp)); err != nil {
 				return err
 			}
 		}
@@ -4,9 +4,7 @@ package processors
 
 import (
 	"context"
-	"encoding/base64"
 	"encoding/json"
-	"math/big"
 	"testing"
 
 	"github.com/guregu/null"
@@ -14,10 +12,8 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"github.com/shantanu-hashcash/go/ingest"
-	"github.com/shantanu-hashcash/go/keypair"
+	"github.com/shantanu-hashcash/go/ingest/processors"
 	"github.com/shantanu-hashcash/go/services/aurora/internal/db2/history"
-	"github.com/shantanu-hashcash/go/strkey"
-	"github.com/shantanu-hashcash/go/support/contractevents"
 	"github.com/shantanu-hashcash/go/support/db"
 	"github.com/shantanu-hashcash/go/support/errors"
 	"github.com/shantanu-hashcash/go/xdr"
@@ -49,38 +45,30 @@ func (s *OperationsProcessorTestSuiteLedger) TearDownTest() {
 	s.mockBatchInsertBuilder.AssertExpectations(s.T())
 }
 
-func (s *OperationsProcessorTestSuiteLedger) mockBatchInsertAdds(txs []ingest.LedgerTransaction, sequence uint32) error {
+func (s *OperationsProcessorTestSuiteLedger) mockBatchInsertAdds(lcm xdr.LedgerCloseMeta, txs []ingest.LedgerTransaction) error {
 	for _, t := range txs {
-		for i, op := range t.Envelope.Operations() {
-			expected := transactionOperationWrapper{
-				index:          uint32(i),
-				transaction:    t,
-				operation:      op,
-				ledgerSequence: sequence,
-			}
-			details, err := expected.Details()
-			if err != nil {
-				return err
-			}
-			detailsJSON, err := json.Marshal(details)
+		operations, err := processors.TransactionOperations(lcm, t, "test network")
+		if err != nil {
+			return err
+		}
+		for _, expected := range operations {
+			detailsJSON, err := json.Marshal(expected.Details)
 			if err != nil {
 				return err
 			}
 
-			source := expected.SourceAccount()
-			acID := source.ToAccountId()
 			var muxedAccount null.String
-			if source.Type == xdr.CryptoKeyTypeKeyTypeMuxedEd25519 {
-				muxedAccount = null.StringFrom(source.Address())
+			if expected.SourceAccountMuxed != "" {
+				muxedAccount = null.StringFrom(expected.SourceAccountMuxed)
 			}
 			s.mockBatchInsertBuilder.On(
 				"Add",
-				expected.ID(),
-				expected.TransactionID(),
-				expected.Order(),
-				expected.OperationType(),
+				expected.ID,
+				expected.TransactionID,
+				expected.ApplicationOrder,
+				expected.Type,
 				detailsJSON,
-				acID.Address(),
+				expected.SourceAccount,
 				muxedAccount,
 				mock.Anything,
 			).Return(nil).Once()
@@ -90,303 +78,6 @@ func (s *OperationsProcessorTestSuiteLedger) mockBatchInsertAdds(txs []ingest.Le
 	return nil
 }
 
-func (s *OperationsProcessorTestSuiteLedger) TestOperationTypeInvokeHostFunctionDetails() {
-	sourceAddress := "GAUJETIZVEP2NRYLUESJ3LS66NVCEGMON4UDCBCSBEVPIID773P2W6AY"
-	source := xdr.MustMuxedAddress(sourceAddress)
-
-	contractParamVal0 := xdr.ScAddress{
-		Type:       xdr.ScAddressTypeScAddressTypeContract,
-		ContractId: &xdr.Hash{0x1, 0x2},
-	}
-	contractParamVal1 := xdr.ScSymbol("func1")
-	contractParamVal2 := xdr.Int32(-5)
-	contractParamVal3 := xdr.Uint32(6)
-	contractParamVal4 := xdr.Uint64(3)
-	contractParamVal5 := xdr.ScBytes([]byte{0, 1, 2})
-	contractParamVal6 := true
-
-	accountId := xdr.MustAddress("GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H")
-	wasm := []byte("Some contract code")
-
-	tx := ingest.LedgerTransaction{
-		UnsafeMeta: xdr.TransactionMeta{
-			V:  2,
-			V2: &xdr.TransactionMetaV2{},
-		},
-	}
-
-	s.T().Run("InvokeContract", func(t *testing.T) {
-		wrapper := transactionOperationWrapper{
-			transaction: tx,
-			operation: xdr.Operation{
-				SourceAccount: &source,
-				Body: xdr.OperationBody{
-					Type: xdr.OperationTypeInvokeHostFunction,
-					InvokeHostFunctionOp: &xdr.InvokeHostFunctionOp{
-						HostFunction: xdr.HostFunction{
-							Type: xdr.HostFunctionTypeHostFunctionTypeInvokeContract,
-							InvokeContract: &xdr.InvokeContractArgs{
-								ContractAddress: contractParamVal0,
-								FunctionName:    contractParamVal1,
-								Args: xdr.ScVec{
-									{
-										Type: xdr.ScValTypeScvI32,
-										I32:  &contractParamVal2,
-									},
-									{
-										Type: xdr.ScValTypeScvU32,
-										U32:  &contractParamVal3,
-									},
-									{
-										Type: xdr.ScValTypeScvU64,
-										U64:  &contractParamVal4,
-									},
-									{
-										Type:  xdr.ScValTypeScvBytes,
-										Bytes: &contractParamVal5,
-									},
-									{
-										Type: xdr.ScValTypeScvBool,
-										B:    &contractParamVal6,
-									},
-									{
-										// invalid ScVal
-										Type: 5555,
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-		}
-
-		details, err := wrapper.Details()
-		s.Assert().NoError(err)
-
-		args := []xdr.ScVal{
-			{
-				Type:    xdr.ScValTypeScvAddress,
-				Address: &contractParamVal0,
-			},
-			{
-				Type: xdr.ScValTypeScvSymbol,
-				Sym:  &contractParamVal1,
-			},
-		}
-		args = append(args, wrapper.operation.Body.InvokeHostFunctionOp.HostFunction.InvokeContract.Args...)
-		detailsFunctionParams := details["parameters"].([]map[string]string)
-		s.Assert().Equal(details["function"], "HostFunctionTypeHostFunctionTypeInvokeContract")
-		s.assertInvokeHostFunctionParameter(detailsFunctionParams, 0, "Address", args[0])
-		s.assertInvokeHostFunctionParameter(detailsFunctionParams, 1, "Sym", args[1])
-		s.assertInvokeHostFunctionParameter(detailsFunctionParams, 2, "I32", args[2])
-		s.assertInvokeHostFunctionParameter(detailsFunctionParams, 3, "U32", args[3])
-		s.assertInvokeHostFunctionParameter(detailsFunctionParams, 4, "U64", args[4])
-		s.assertInvokeHostFunctionParameter(detailsFunctionParams, 5, "Bytes", args[5])
-		s.assertInvokeHostFunctionParameter(detailsFunctionParams, 6, "B", args[6])
-		s.assertInvokeHostFunctionParameter(detailsFunctionParams, 7, "n/a", args[7])
-	})
-
-	s.T().Run("CreateContractFromAsset", func(t *testing.T) {
-		wrapper := transactionOperationWrapper{
-			transaction: tx,
-			operation: xdr.Operation{
-				SourceAccount: &source,
-				Body: xdr.OperationBody{
-					Type: xdr.OperationTypeInvokeHostFunction,
-					InvokeHostFunctionOp: &xdr.InvokeHostFunctionOp{
-						HostFunction: xdr.HostFunction{
-							Type: xdr.HostFunctionTypeHostFunctionTypeCreateContract,
-							CreateContract: &xdr.CreateContractArgs{
-								ContractIdPreimage: xdr.ContractIdPreimage{
-									Type: xdr.ContractIdPreimageTypeContractIdPreimageFromAsset,
-									FromAsset: &xdr.Asset{
-										Type: 1,
-										AlphaNum4: &xdr.AlphaNum4{
-											AssetCode: xdr.AssetCode4{65, 82, 83, 0},
-											Issuer:    xdr.MustAddress("GCXI6Q73J7F6EUSBZTPW4G4OUGVDHABPYF2U4KO7MVEX52OH5VMVUCRF"),
-										},
-									},
-								},
-								Executable: xdr.ContractExecutable{
-									Type: xdr.ContractExecutableTypeContractExecutableHcnetAsset,
-								},
-							},
-						},
-					},
-				},
-			},
-		}
-
-		details, err := wrapper.Details()
-		s.Assert().NoError(err)
-
-		s.Assert().Equal(details["function"], "HostFunctionTypeHostFunctionTypeCreateContract")
-		s.Assert().Equal(details["from"], "asset")
-		s.Assert().Equal(details["asset"], "ARS:GCXI6Q73J7F6EUSBZTPW4G4OUGVDHABPYF2U4KO7MVEX52OH5VMVUCRF")
-	})
-
-	s.T().Run("CreateContractFromAddress", func(t *testing.T) {
-		wrapper := transactionOperationWrapper{
-			transaction: tx,
-			operation: xdr.Operation{
-				SourceAccount: &source,
-				Body: xdr.OperationBody{
-					Type: xdr.OperationTypeInvokeHostFunction,
-					InvokeHostFunctionOp: &xdr.InvokeHostFunctionOp{
-						HostFunction: xdr.HostFunction{
-							Type: xdr.HostFunctionTypeHostFunctionTypeCreateContract,
-							CreateContract: &xdr.CreateContractArgs{
-								ContractIdPreimage: xdr.ContractIdPreimage{
-									Type: xdr.ContractIdPreimageTypeContractIdPreimageFromAddress,
-									FromAddress: &xdr.ContractIdPreimageFromAddress{
-										Address: xdr.ScAddress{
-											Type:      xdr.ScAddressTypeScAddressTypeAccount,
-											AccountId: &accountId,
-										},
-										Salt: xdr.Uint256{1},
-									},
-								},
-								Executable: xdr.ContractExecutable{},
-							},
-						},
-					},
-				},
-			},
-		}
-
-		details, err := wrapper.Details()
-		s.Assert().NoError(err)
-
-		s.Assert().Equal(details["function"], "HostFunctionTypeHostFunctionTypeCreateContract")
-		s.Assert().Equal(details["from"], "address")
-		s.Assert().Equal(details["address"], "GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H")
-		s.Assert().Equal(details["salt"], xdr.Uint256{1}.String())
-	})
-
-	s.T().Run("UploadContractWasm", func(t *testing.T) {
-		wrapper := transactionOperationWrapper{
-			transaction: tx,
-			operation: xdr.Operation{
-				SourceAccount: &source,
-				Body: xdr.OperationBody{
-					Type: xdr.OperationTypeInvokeHostFunction,
-					InvokeHostFunctionOp: &xdr.InvokeHostFunctionOp{
-						HostFunction: xdr.HostFunction{
-							Type: xdr.HostFunctionTypeHostFunctionTypeUploadContractWasm,
-							Wasm: &wasm,
-						},
-					},
-				},
-			},
-		}
-
-		details, err := wrapper.Details()
-		s.Assert().NoError(err)
-		s.Assert().Equal(details["function"], "HostFunctionTypeHostFunctionTypeUploadContractWasm")
-	})
-
-	s.T().Run("InvokeContractWithSACEventsInDetails", func(t *testing.T) {
-		randomIssuer := keypair.MustRandom()
-		randomAsset := xdr.MustNewCreditAsset("TESTING", randomIssuer.Address())
-		passphrase := "passphrase"
-		randomAccount := keypair.MustRandom().Address()
-		contractId := [32]byte{}
-		zeroContractStrKey, err := strkey.Encode(strkey.VersionByteContract, contractId[:])
-		s.Assert().NoError(err)
-
-		transferContractEvent := contractevents.GenerateEvent(contractevents.EventTypeTransfer, randomAccount, zeroContractStrKey, "", randomAsset, big.NewInt(10000000), passphrase)
-		burnContractEvent := contractevents.GenerateEvent(contractevents.EventTypeBurn, zeroContractStrKey, "", "", randomAsset, big.NewInt(10000000), passphrase)
-		mintContractEvent := contractevents.GenerateEvent(contractevents.EventTypeMint, "", zeroContractStrKey, randomAccount, randomAsset, big.NewInt(10000000), passphrase)
-		clawbackContractEvent := contractevents.GenerateEvent(contractevents.EventTypeClawback, zeroContractStrKey, "", randomAccount, randomAsset, big.NewInt(10000000), passphrase)
-
-		tx = ingest.LedgerTransaction{
-			UnsafeMeta: xdr.TransactionMeta{
-				V: 3,
-				V3: &xdr.TransactionMetaV3{
-					SorobanMeta: &xdr.SorobanTransactionMeta{
-						Events: []xdr.ContractEvent{
-							transferContractEvent,
-							burnContractEvent,
-							mintContractEvent,
-							clawbackContractEvent,
-						},
-					},
-				},
-			},
-		}
-		wrapper := transactionOperationWrapper{
-			transaction: tx,
-			operation: xdr.Operation{
-				SourceAccount: &source,
-				Body: xdr.OperationBody{
-					Type: xdr.OperationTypeInvokeHostFunction,
-					InvokeHostFunctionOp: &xdr.InvokeHostFunctionOp{
-						HostFunction: xdr.HostFunction{
-							Type: xdr.HostFunctionTypeHostFunctionTypeInvokeContract,
-							InvokeContract: &xdr.InvokeContractArgs{
-								ContractAddress: xdr.ScAddress{
-									Type:       xdr.ScAddressTypeScAddressTypeContract,
-									ContractId: &xdr.Hash{0x1, 0x2},
-								},
-								FunctionName: "foo",
-								Args:         xdr.ScVec{},
-							},
-						},
-					},
-				},
-			},
-			network: passphrase,
-		}
-
-		details, err := wrapper.Details()
-		s.Assert().NoError(err)
-		s.Assert().Len(details["asset_balance_changes"], 4)
-
-		found := 0
-		for _, assetBalanceChanged := range details["asset_balance_changes"].([]map[string]interface{}) {
-			if assetBalanceChanged["type"] == "transfer" {
-				s.Assert().Equal(assetBalanceChanged["from"], randomAccount)
-				s.Assert().Equal(assetBalanceChanged["to"], zeroContractStrKey)
-				s.Assert().Equal(assetBalanceChanged["amount"], "1.0000000")
-				found++
-			}
-
-			if assetBalanceChanged["type"] == "burn" {
-				s.Assert().Equal(assetBalanceChanged["from"], zeroContractStrKey)
-				s.Assert().NotContains(assetBalanceChanged, "to")
-				s.Assert().Equal(assetBalanceChanged["amount"], "1.0000000")
-				found++
-			}
-
-			if assetBalanceChanged["type"] == "mint" {
-				s.Assert().NotContains(assetBalanceChanged, "from")
-				s.Assert().Equal(assetBalanceChanged["to"], zeroContractStrKey)
-				s.Assert().Equal(assetBalanceChanged["amount"], "1.0000000")
-				found++
-			}
-
-			if assetBalanceChanged["type"] == "clawback" {
-				s.Assert().Equal(assetBalanceChanged["from"], zeroContractStrKey)
-				s.Assert().NotContains(assetBalanceChanged, "to")
-				s.Assert().Equal(assetBalanceChanged["amount"], "1.0000000")
-				found++
-			}
-		}
-		s.Assert().Equal(found, 4, "should have one balance changed record for each of mint, burn, clawback, transfer")
-	})
-}
-
-func (s *OperationsProcessorTestSuiteLedger) assertInvokeHostFunctionParameter(parameters []map[string]string, paramPosition int, expectedType string, expectedVal xdr.ScVal) {
-	serializedParam := parameters[paramPosition]
-	s.Assert().Equal(serializedParam["type"], expectedType)
-	if expectedSerializedXdr, err := expectedVal.MarshalBinary(); err == nil {
-		s.Assert().Equal(serializedParam["value"], base64.StdEncoding.EncodeToString(expectedSerializedXdr))
-	} else {
-		s.Assert().Equal(serializedParam["value"], "n/a")
-	}
-}
-
 func (s *OperationsProcessorTestSuiteLedger) TestAddOperationSucceeds() {
 	sequence := uint32(56)
 	lcm := xdr.LedgerCloseMeta{
@@ -429,7 +120,7 @@ func (s *OperationsProcessorTestSuiteLedger) TestAddOperationSucceeds() {
 
 	var err error
 
-	err = s.mockBatchInsertAdds(txs, sequence)
+	err = s.mockBatchInsertAdds(lcm, txs)
 	s.Assert().NoError(err)
 	s.mockBatchInsertBuilder.On("Exec", s.ctx, s.mockSession).Return(nil).Once()
 
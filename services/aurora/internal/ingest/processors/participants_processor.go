@@ -4,8 +4,10 @@ package processors
 
 import (
 	"context"
+	"sort"
 
 	"github.com/shantanu-hashcash/go/ingest"
+	"github.com/shantanu-hashcash/go/ingest/processors"
 	"github.com/shantanu-hashcash/go/services/aurora/internal/db2/history"
 	"github.com/shantanu-hashcash/go/support/db"
 	"github.com/shantanu-hashcash/go/support/errors"
@@ -129,7 +131,7 @@ func (p *ParticipantsProcessor) addOperationsParticipants(
 	sequence uint32,
 	transaction ingest.LedgerTransaction,
 ) error {
-	participants, err := operationsParticipants(transaction, sequence)
+	participants, err := processors.OperationsParticipants(transaction, sequence)
 	if err != nil {
 		return errors.Wrap(err, "could not determine operation participants")
 	}
@@ -192,22 +194,34 @@ func ParticipantsForTransaction(
 	}
 	participants = append(participants, p...)
 
-	for opi, op := range transaction.Envelope.Operations() {
-		operation := transactionOperationWrapper{
-			index:          uint32(opi),
-			transaction:    transaction,
-			operation:      op,
-			ledgerSequence: sequence,
-		}
-
-		p, err := operation.Participants()
-		if err != nil {
-			return nil, errors.Wrapf(
-				err, "could not determine operation %v participants", operation.ID(),
-			)
-		}
+	operationsParticipants, err := processors.OperationsParticipants(transaction, sequence)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not determine operation participants")
+	}
+	for _, p := range operationsParticipants {
 		participants = append(participants, p...)
 	}
 
 	return dedupeParticipants(participants), nil
 }
+
+// dedupeParticipants remove any duplicate ids from `in`
+func dedupeParticipants(in []xdr.AccountId) []xdr.AccountId {
+	if len(in) <= 1 {
+		return in
+	}
+	sort.Slice(in, func(i, j int) bool {
+		return in[i].Address() < in[j].Address()
+	})
+	insert := 1
+	for cur := 1; cur < len(in); cur++ {
+		if in[cur].Equals(in[cur-1]) {
+			continue
+		}
+		if insert != cur {
+			in[insert] = in[cur]
+		}
+		insert++
+	}
+	return in[:insert]
+}
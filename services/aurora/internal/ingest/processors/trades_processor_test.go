@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"github.com/shantanu-hashcash/go/ingest"
+	"github.com/shantanu-hashcash/go/ingest/processors"
 	"github.com/shantanu-hashcash/go/services/aurora/internal/db2/history"
 	"github.com/shantanu-hashcash/go/support/db"
 	"github.com/shantanu-hashcash/go/toid"
@@ -247,7 +248,7 @@ func (s *TradeProcessorTestSuiteLedger) mockReadTradeTransactions() []history.In
 			BaseAmount:         int64(s.strictReceiveTrade.AmountBought()),
 			BaseAccountID:      null.IntFrom(s.unmuxedAccountToID[s.unmuxedOpSourceAccount.Address()]),
 			BaseAssetID:        s.assetToID[history.AssetKeyFromXDR(s.strictReceiveTrade.AssetBought())].ID,
-			BaseOfferID:        null.IntFrom(EncodeOfferId(uint64(toid.New(int32(ledger.Header.LedgerSeq), 1, 2).ToInt64()), TOIDType)),
+			BaseOfferID:        null.IntFrom(processors.EncodeOfferId(uint64(toid.New(int32(ledger.Header.LedgerSeq), 1, 2).ToInt64()), processors.TOIDType)),
 			CounterAmount:      int64(s.strictReceiveTrade.AmountSold()),
 			CounterAccountID:   null.IntFrom(s.unmuxedAccountToID[s.strictReceiveTrade.SellerId().Address()]),
 			CounterAssetID:     s.assetToID[history.AssetKeyFromXDR(s.strictReceiveTrade.AssetSold())].ID,
@@ -265,7 +266,7 @@ func (s *TradeProcessorTestSuiteLedger) mockReadTradeTransactions() []history.In
 			CounterAmount:      int64(s.strictSendTrade.AmountBought()),
 			CounterAccountID:   null.IntFrom(s.unmuxedAccountToID[s.unmuxedOpSourceAccount.Address()]),
 			CounterAssetID:     s.assetToID[history.AssetKeyFromXDR(s.strictSendTrade.AssetBought())].ID,
-			CounterOfferID:     null.IntFrom(EncodeOfferId(uint64(toid.New(int32(ledger.Header.LedgerSeq), 1, 3).ToInt64()), TOIDType)),
+			CounterOfferID:     null.IntFrom(processors.EncodeOfferId(uint64(toid.New(int32(ledger.Header.LedgerSeq), 1, 3).ToInt64()), processors.TOIDType)),
 			BaseAmount:         int64(s.strictSendTrade.AmountSold()),
 			BaseAccountID:      null.IntFrom(s.unmuxedAccountToID[s.strictSendTrade.SellerId().Address()]),
 			BaseAssetID:        s.assetToID[history.AssetKeyFromXDR(s.strictSendTrade.AssetSold())].ID,
@@ -300,7 +301,7 @@ func (s *TradeProcessorTestSuiteLedger) mockReadTradeTransactions() []history.In
 			CounterAmount:      int64(s.sellOfferTrade.AmountBought()),
 			CounterAssetID:     s.assetToID[history.AssetKeyFromXDR(s.sellOfferTrade.AssetBought())].ID,
 			CounterAccountID:   null.IntFrom(s.unmuxedAccountToID[s.unmuxedOpSourceAccount.Address()]),
-			CounterOfferID:     null.IntFrom(EncodeOfferId(uint64(toid.New(int32(ledger.Header.LedgerSeq), 1, 5).ToInt64()), TOIDType)),
+			CounterOfferID:     null.IntFrom(processors.EncodeOfferId(uint64(toid.New(int32(ledger.Header.LedgerSeq), 1, 5).ToInt64()), processors.TOIDType)),
 			BaseAmount:         int64(s.sellOfferTrade.AmountSold()),
 			BaseAccountID:      null.IntFrom(s.unmuxedAccountToID[s.sellOfferTrade.SellerId().Address()]),
 			BaseAssetID:        s.assetToID[history.AssetKeyFromXDR(s.sellOfferTrade.AssetSold())].ID,
@@ -317,7 +318,7 @@ func (s *TradeProcessorTestSuiteLedger) mockReadTradeTransactions() []history.In
 			BaseAmount:         int64(s.passiveSellOfferTrade.AmountBought()),
 			BaseAssetID:        s.assetToID[history.AssetKeyFromXDR(s.passiveSellOfferTrade.AssetBought())].ID,
 			BaseAccountID:      null.IntFrom(s.unmuxedAccountToID[s.unmuxedSourceAccount.Address()]),
-			BaseOfferID:        null.IntFrom(EncodeOfferId(uint64(toid.New(int32(ledger.Header.LedgerSeq), 1, 6).ToInt64()), TOIDType)),
+			BaseOfferID:        null.IntFrom(processors.EncodeOfferId(uint64(toid.New(int32(ledger.Header.LedgerSeq), 1, 6).ToInt64()), processors.TOIDType)),
 			CounterAmount:      int64(s.passiveSellOfferTrade.AmountSold()),
 			CounterAccountID:   null.IntFrom(s.unmuxedAccountToID[s.passiveSellOfferTrade.SellerId().Address()]),
 			CounterAssetID:     s.assetToID[history.AssetKeyFromXDR(s.passiveSellOfferTrade.AssetSold())].ID,
@@ -336,7 +337,7 @@ func (s *TradeProcessorTestSuiteLedger) mockReadTradeTransactions() []history.In
 			CounterAmount:    int64(s.otherPassiveSellOfferTrade.AmountBought()),
 			CounterAssetID:   s.assetToID[history.AssetKeyFromXDR(s.otherPassiveSellOfferTrade.AssetBought())].ID,
 			CounterAccountID: null.IntFrom(s.unmuxedAccountToID[s.unmuxedOpSourceAccount.Address()]),
-			CounterOfferID:   null.IntFrom(EncodeOfferId(uint64(toid.New(int32(ledger.Header.LedgerSeq), 1, 7).ToInt64()), TOIDType)),
+			CounterOfferID:   null.IntFrom(processors.EncodeOfferId(uint64(toid.New(int32(ledger.Header.LedgerSeq), 1, 7).ToInt64()), processors.TOIDType)),
 			BaseAmount:       int64(s.otherPassiveSellOfferTrade.AmountSold()),
 			BaseAccountID:    null.IntFrom(s.unmuxedAccountToID[s.otherPassiveSellOfferTrade.SellerId().Address()]),
 			BaseAssetID:      s.assetToID[history.AssetKeyFromXDR(s.otherPassiveSellOfferTrade.AssetSold())].ID,
@@ -353,7 +354,7 @@ func (s *TradeProcessorTestSuiteLedger) mockReadTradeTransactions() []history.In
 			BaseAmount:             int64(s.strictReceiveTradeLP.AmountBought()),
 			BaseAssetID:            s.assetToID[history.AssetKeyFromXDR(s.strictReceiveTradeLP.AssetBought())].ID,
 			BaseAccountID:          null.IntFrom(s.unmuxedAccountToID[s.unmuxedOpSourceAccount.Address()]),
-			BaseOfferID:            null.IntFrom(EncodeOfferId(uint64(toid.New(int32(ledger.Header.LedgerSeq), 1, 8).ToInt64()), TOIDType)),
+			BaseOfferID:            null.IntFrom(processors.EncodeOfferId(uint64(toid.New(int32(ledger.Header.LedgerSeq), 1, 8).ToInt64()), processors.TOIDType)),
 			CounterAmount:          int64(s.strictReceiveTradeLP.AmountSold()),
 			CounterLiquidityPoolID: null.IntFrom(s.lpToID[s.strictReceiveTradeLP.MustLiquidityPool().LiquidityPoolId]),
 			CounterAssetID:         s.assetToID[history.AssetKeyFromXDR(s.strictReceiveTradeLP.AssetSold())].ID,
@@ -372,7 +373,7 @@ func (s *TradeProcessorTestSuiteLedger) mockReadTradeTransactions() []history.In
 			CounterAmount:       int64(s.strictSendTradeLP.AmountBought()),
 			CounterAssetID:      s.assetToID[history.AssetKeyFromXDR(s.strictSendTradeLP.AssetBought())].ID,
 			CounterAccountID:    null.IntFrom(s.unmuxedAccountToID[s.unmuxedOpSourceAccount.Address()]),
-			CounterOfferID:      null.IntFrom(EncodeOfferId(uint64(toid.New(int32(ledger.Header.LedgerSeq), 1, 9).ToInt64()), TOIDType)),
+			CounterOfferID:      null.IntFrom(processors.EncodeOfferId(uint64(toid.New(int32(ledger.Header.LedgerSeq), 1, 9).ToInt64()), processors.TOIDType)),
 			BaseAmount:          int64(s.strictSendTradeLP.AmountSold()),
 			BaseLiquidityPoolID: null.IntFrom(s.lpToID[s.strictSendTradeLP.MustLiquidityPool().LiquidityPoolId]),
 			BaseAssetID:         s.assetToID[history.AssetKeyFromXDR(s.strictSendTradeLP.AssetSold())].ID,
@@ -817,174 +818,3 @@ func TestTradeProcessor_ProcessTransaction_MuxedAccount(t *testing.T) {
 		},
 	}
 }
-
-func TestTradeProcessor_RoundingSlippage_Big(t *testing.T) {
-	s := &TradeProcessorTestSuiteLedger{}
-	s.SetT(t)
-	s.SetupTest()
-	s.mockReadTradeTransactions()
-
-	assetDeposited := xdr.MustNewCreditAsset("MAD", s.unmuxedSourceAccount.Address())
-	assetDisbursed := xdr.MustNewCreditAsset("GRE", s.unmuxedSourceAccount.Address())
-	poolId, err := xdr.NewPoolId(assetDisbursed, assetDeposited, xdr.LiquidityPoolFeeV18)
-	s.Assert().NoError(err)
-	trade := xdr.ClaimAtom{
-		Type: xdr.ClaimAtomTypeClaimAtomTypeLiquidityPool,
-		LiquidityPool: &xdr.ClaimLiquidityAtom{
-			LiquidityPoolId: poolId,
-			AssetBought:     assetDeposited,
-			AmountBought:    1,
-			AssetSold:       assetDisbursed,
-			AmountSold:      1,
-		},
-	}
-	tx, err := createTransactionForTrade(trade, 3740000000, 162020000000)
-	s.Assert().NoError(err)
-	opIdx := 0
-	change, err := s.processor.liquidityPoolChange(tx, opIdx, trade)
-	s.Assert().NoError(err)
-
-	result, err := s.processor.roundingSlippage(tx, opIdx, trade, change)
-	s.Assert().NoError(err)
-	s.Assert().True(result.Valid)
-	s.Assert().Equal(null.IntFrom(4229), result)
-}
-
-func TestTradeProcessor_RoundingSlippage_Small(t *testing.T) {
-	s := &TradeProcessorTestSuiteLedger{}
-	s.SetT(t)
-	s.SetupTest()
-	s.mockReadTradeTransactions()
-
-	assetDeposited := xdr.MustNewCreditAsset("MAD", s.unmuxedSourceAccount.Address())
-	assetDisbursed := xdr.MustNewCreditAsset("GRE", s.unmuxedSourceAccount.Address())
-	poolId, err := xdr.NewPoolId(assetDisbursed, assetDeposited, xdr.LiquidityPoolFeeV18)
-	s.Assert().NoError(err)
-	trade := xdr.ClaimAtom{
-		Type: xdr.ClaimAtomTypeClaimAtomTypeLiquidityPool,
-		LiquidityPool: &xdr.ClaimLiquidityAtom{
-			LiquidityPoolId: poolId,
-			AssetBought:     assetDeposited,
-			AmountBought:    11,
-			AssetSold:       assetDisbursed,
-			AmountSold:      20,
-		},
-	}
-	tx, err := createTransactionForTrade(trade, 200, 400)
-	s.Assert().NoError(err)
-	opIdx := 0
-	change, err := s.processor.liquidityPoolChange(tx, opIdx, trade)
-	s.Assert().NoError(err)
-
-	result, err := s.processor.roundingSlippage(tx, opIdx, trade, change)
-	s.Assert().NoError(err)
-	s.Assert().True(result.Valid)
-	s.Assert().Equal(null.IntFrom(4), result)
-}
-
-// TODO: Use a builder or something here to simplify.
-func createTransactionForTrade(trade xdr.ClaimAtom, reserveA, reserveB int64) (ingest.LedgerTransaction, error) {
-	source := xdr.MustMuxedAddress("GAUJETIZVEP2NRYLUESJ3LS66NVCEGMON4UDCBCSBEVPIID773P2W6AY")
-	destination := source
-
-	pool := makePool(trade.AssetBought(), trade.AssetSold(), reserveA, reserveB)
-
-	poolLedgerEntry := func(reserveA, reserveB xdr.Int64) *xdr.LedgerEntry {
-		return &xdr.LedgerEntry{
-			Data: xdr.LedgerEntryData{
-				Type:          xdr.LedgerEntryTypeLiquidityPool,
-				LiquidityPool: &pool,
-			},
-		}
-	}
-
-	return ingest.LedgerTransaction{
-		Result: xdr.TransactionResultPair{
-			TransactionHash: xdr.Hash{},
-			Result: xdr.TransactionResult{
-				Result: xdr.TransactionResultResult{
-					Code:            xdr.TransactionResultCodeTxSuccess,
-					InnerResultPair: &xdr.InnerTransactionResultPair{},
-					Results:         &[]xdr.OperationResult{},
-				},
-			},
-		},
-		Envelope: xdr.TransactionEnvelope{
-			Type: xdr.EnvelopeTypeEnvelopeTypeTx,
-			V1: &xdr.TransactionV1Envelope{
-				Tx: xdr.Transaction{
-					SourceAccount: source,
-					Operations: []xdr.Operation{
-						{
-							Body: xdr.OperationBody{
-								Type: xdr.OperationTypePathPaymentStrictReceive,
-								PathPaymentStrictReceiveOp: &xdr.PathPaymentStrictReceiveOp{
-									SendAsset:   trade.AssetBought(),
-									SendMax:     trade.AmountBought(),
-									Destination: destination,
-									DestAsset:   trade.AssetSold(),
-									DestAmount:  trade.AmountSold(),
-									Path: []xdr.Asset{
-										trade.AssetBought(),
-										trade.AssetSold(),
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-		},
-		UnsafeMeta: xdr.TransactionMeta{
-			V: 2,
-			V2: &xdr.TransactionMetaV2{
-				Operations: []xdr.OperationMeta{
-					{
-						Changes: xdr.LedgerEntryChanges{
-							{
-								Type: xdr.LedgerEntryChangeTypeLedgerEntryState,
-								State: poolLedgerEntry(
-									xdr.Int64(reserveA),
-									xdr.Int64(reserveB),
-								),
-							},
-							{
-								Type: xdr.LedgerEntryChangeTypeLedgerEntryUpdated,
-								Updated: poolLedgerEntry(
-									xdr.Int64(reserveA)+trade.AmountBought(),
-									xdr.Int64(reserveB)-trade.AmountSold(),
-								),
-							},
-						},
-					},
-				},
-			},
-		},
-	}, nil
-}
-
-func makePool(A, B xdr.Asset, a, b int64) xdr.LiquidityPoolEntry {
-	if !A.LessThan(B) {
-		B, A = A, B
-		b, a = a, b
-	}
-
-	poolId, _ := xdr.NewPoolId(A, B, xdr.LiquidityPoolFeeV18)
-	return xdr.LiquidityPoolEntry{
-		LiquidityPoolId: poolId,
-		Body: xdr.LiquidityPoolEntryBody{
-			Type: xdr.LiquidityPoolTypeLiquidityPoolConstantProduct,
-			ConstantProduct: &xdr.LiquidityPoolEntryConstantProduct{
-				Params: xdr.LiquidityPoolConstantProductParameters{
-					AssetA: A,
-					AssetB: B,
-					Fee:    xdr.LiquidityPoolFeeV18,
-				},
-				ReserveA:                 xdr.Int64(a),
-				ReserveB:                 xdr.Int64(b),
-				TotalPoolShares:          123,
-				PoolSharesTrustLineCount: 456,
-			},
-		},
-	}
-}
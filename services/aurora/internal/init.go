@@ -60,23 +60,28 @@ func mustInitAuroraDB(app *App) {
 			serverSidePGTimeoutConfigs...,
 		)}
 	} else {
-		// If RO set, use it for all DB queries
-		app.historyQ = &history.Q{mustNewDBSession(
-			db.HistorySubservice,
-			app.config.RoDatabaseURL,
+		// If RO set, read from it whenever it is fresh enough and fall back
+		// to the primary otherwise
+		primary := mustNewDBSession(
+			db.HistoryPrimarySubservice,
+			app.config.DatabaseURL,
 			maxIdle,
 			maxOpen,
 			app.prometheusRegistry,
 			serverSidePGTimeoutConfigs...,
-		)}
-
-		app.primaryHistoryQ = &history.Q{mustNewDBSession(
-			db.HistoryPrimarySubservice,
-			app.config.DatabaseURL,
+		)
+		replica := mustNewDBSession(
+			db.HistorySubservice,
+			app.config.RoDatabaseURL,
 			maxIdle,
 			maxOpen,
 			app.prometheusRegistry,
 			serverSidePGTimeoutConfigs...,
+		)
+		app.historyQ = &history.Q{db.NewReplicaSession(
+			primary,
+			[]db.SessionInterface{replica},
+			db.ReplicaConfig{MaxStaleness: app.config.RoDatabaseMaxStaleness},
 		)}
 	}
 }
@@ -2,7 +2,9 @@ package main
 
 import (
 	"fmt"
+	stdhttp "net/http"
 	"os"
+	"time"
 
 	"github.com/go-chi/chi"
 	"github.com/spf13/cobra"
@@ -15,18 +17,30 @@ import (
 	"github.com/shantanu-hashcash/go/support/log"
 )
 
-// Config represents the configuration of a federation server
+// httpDriverTimeout is the timeout of requests to the HTTP service records are
+// looked up from.
+const httpDriverTimeout = 10 * time.Second
+
+// Config represents the configuration of a federation server. Records are
+// looked up in a database with queries, from an HTTP service, or from a YAML
+// records file.
 type Config struct {
 	Port     int `valid:"required"`
 	Database struct {
-		Type string `valid:"matches(^sqlite3|postgres$)"`
-		DSN  string `valid:"required"`
-	} `valid:"required"`
+		Type string `valid:"optional,matches(^sqlite3|postgres$)"`
+		DSN  string `valid:"optional"`
+	} `valid:"optional"`
 	Queries struct {
-		Federation        string `valid:"required"`
+		Federation        string `valid:"optional"`
 		ReverseFederation string `toml:"reverse-federation" valid:"optional"`
-	} `valid:"required"`
-	TLS *config.TLS `valid:"optional"`
+	} `valid:"optional"`
+	HTTP struct {
+		Federation        string `valid:"optional,url"`
+		ReverseFederation string `toml:"reverse-federation" valid:"optional,url"`
+	} `toml:"http" valid:"optional"`
+	RecordsFile string      `toml:"records-file" valid:"optional"`
+	CacheTTL    int         `toml:"cache-ttl" valid:"optional"`
+	TLS         *config.TLS `valid:"optional"`
 }
 
 func main() {
@@ -64,6 +78,12 @@ func run(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
+	err = validateConfig(cfg)
+	if err != nil {
+		log.Error("config file: ", err)
+		os.Exit(1)
+	}
+
 	driver, err := initDriver(cfg)
 	if err != nil {
 		log.Error(err)
@@ -84,7 +104,95 @@ func run(cmd *cobra.Command, args []string) {
 	})
 }
 
+// validateConfig checks that exactly one source of records is configured,
+// along with the fields that source requires.
+func validateConfig(cfg Config) error {
+	sources := 0
+	if cfg.Database.Type != "" || cfg.Database.DSN != "" {
+		sources++
+		if cfg.Database.DSN == "" {
+			return errors.New("database dsn is required")
+		}
+		if cfg.Queries.Federation == "" {
+			return errors.New("queries federation is required")
+		}
+	}
+	if cfg.HTTP.Federation != "" || cfg.HTTP.ReverseFederation != "" {
+		sources++
+		if cfg.HTTP.Federation == "" {
+			return errors.New("http federation is required")
+		}
+	}
+	if cfg.RecordsFile != "" {
+		sources++
+	}
+	if sources != 1 {
+		return errors.New("exactly one of database, http or records-file must be configured")
+	}
+	if cfg.CacheTTL < 0 {
+		return errors.New("cache-ttl must not be negative")
+	}
+	return nil
+}
+
 func initDriver(cfg Config) (federation.Driver, error) {
+	driver, err := initSourceDriver(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.CacheTTL > 0 {
+		driver = &federation.CachingDriver{
+			Driver: driver,
+			TTL:    time.Duration(cfg.CacheTTL) * time.Second,
+		}
+	}
+
+	return driver, nil
+}
+
+func initSourceDriver(cfg Config) (federation.Driver, error) {
+	switch {
+	case cfg.HTTP.Federation != "":
+		return initHTTPDriver(cfg), nil
+	case cfg.RecordsFile != "":
+		return initMemoryDriver(cfg)
+	default:
+		return initSQLDriver(cfg)
+	}
+}
+
+func initHTTPDriver(cfg Config) federation.Driver {
+	httpd := federation.HTTPDriver{
+		HTTP:            &stdhttp.Client{Timeout: httpDriverTimeout},
+		LookupRecordURL: cfg.HTTP.Federation,
+	}
+
+	if cfg.HTTP.ReverseFederation == "" {
+		return &httpd
+	}
+
+	return &federation.ReverseHTTPDriver{
+		HTTPDriver:             httpd,
+		LookupReverseRecordURL: cfg.HTTP.ReverseFederation,
+	}
+}
+
+func initMemoryDriver(cfg Config) (federation.Driver, error) {
+	f, err := os.Open(cfg.RecordsFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "records file open failed")
+	}
+	defer f.Close()
+
+	memd, err := federation.ReadMemoryDriver(f)
+	if err != nil {
+		return nil, errors.Wrap(err, "records file read failed")
+	}
+	return memd, nil
+}
+
+func initSQLDriver(cfg Config) (federation.Driver, error) {
 	var dialect string
 
 	switch cfg.Database.Type {
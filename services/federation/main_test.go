@@ -1,8 +1,13 @@
 package main
 
 import (
+	"io/ioutil"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/shantanu-hashcash/go/handlers/federation"
+	"github.com/shantanu-hashcash/go/support/config"
 	"github.com/shantanu-hashcash/go/support/db/dbtest"
 	"github.com/shantanu-hashcash/go/support/errors"
 	"github.com/stretchr/testify/assert"
@@ -37,3 +42,113 @@ func TestInitDriver_dialect(t *testing.T) {
 		})
 	}
 }
+
+func TestReadConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "federation.cfg")
+	err := ioutil.WriteFile(path, []byte(`
+port = 8000
+records-file = "records.yaml"
+cache-ttl = 60
+
+[http]
+federation = "https://users.example.com/federation"
+`), 0600)
+	require.NoError(t, err)
+
+	var cfg Config
+	err = config.Read(path, &cfg)
+	require.NoError(t, err)
+	assert.Equal(t, 8000, cfg.Port)
+	assert.Equal(t, "records.yaml", cfg.RecordsFile)
+	assert.Equal(t, 60, cfg.CacheTTL)
+	assert.Equal(t, "https://users.example.com/federation", cfg.HTTP.Federation)
+	assert.EqualError(t, validateConfig(cfg), "exactly one of database, http or records-file must be configured")
+}
+
+func TestValidateConfig(t *testing.T) {
+	testCases := []struct {
+		name    string
+		cfg     func(c *Config)
+		wantErr string
+	}{
+		{"none", func(c *Config) {}, "exactly one of database, http or records-file must be configured"},
+		{"database", func(c *Config) {
+			c.Database.Type = "postgres"
+			c.Database.DSN = "postgres://localhost/federation"
+			c.Queries.Federation = "SELECT id FROM people WHERE name = ? AND domain = ?"
+		}, ""},
+		{"database without dsn", func(c *Config) { c.Database.Type = "postgres" }, "database dsn is required"},
+		{"database without query", func(c *Config) {
+			c.Database.Type = "postgres"
+			c.Database.DSN = "postgres://localhost/federation"
+		}, "queries federation is required"},
+		{"http", func(c *Config) { c.HTTP.Federation = "https://users.example.com/federation" }, ""},
+		{"http reverse only", func(c *Config) { c.HTTP.ReverseFederation = "https://users.example.com/reverse" }, "http federation is required"},
+		{"records file", func(c *Config) { c.RecordsFile = "records.yaml" }, ""},
+		{"negative cache ttl", func(c *Config) {
+			c.RecordsFile = "records.yaml"
+			c.CacheTTL = -1
+		}, "cache-ttl must not be negative"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := Config{}
+			tc.cfg(&c)
+			err := validateConfig(c)
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestInitDriver_recordsFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "records.yaml")
+	err := ioutil.WriteFile(path, []byte(`
+hcnet.org:
+  names:
+    scott:
+      account_id: GD2GJPL3UOK5LX7TWXOACK2ZPWPFSLBNKL3GTGH6BLBNISK4BGWMFBBG
+`), 0600)
+	require.NoError(t, err)
+
+	c := Config{RecordsFile: path}
+	driver, err := initDriver(c)
+	require.NoError(t, err)
+	assert.IsType(t, &federation.MemoryDriver{}, driver)
+
+	c.CacheTTL = 60
+	driver, err = initDriver(c)
+	require.NoError(t, err)
+	require.IsType(t, &federation.CachingDriver{}, driver)
+	assert.Equal(t, time.Minute, driver.(*federation.CachingDriver).TTL)
+
+	c.RecordsFile = filepath.Join(dir, "missing.yaml")
+	_, err = initDriver(c)
+	assert.Error(t, err)
+}
+
+func TestInitDriver_http(t *testing.T) {
+	c := Config{}
+	c.HTTP.Federation = "https://users.example.com/federation"
+	driver, err := initDriver(c)
+	require.NoError(t, err)
+	assert.IsType(t, &federation.HTTPDriver{}, driver)
+
+	c.HTTP.ReverseFederation = "https://users.example.com/reverse-federation"
+	driver, err = initDriver(c)
+	require.NoError(t, err)
+	assert.IsType(t, &federation.ReverseHTTPDriver{}, driver)
+}
+
+func TestReadConfig_sample(t *testing.T) {
+	var cfg Config
+	err := config.Read("./federation.cfg", &cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "postgres", cfg.Database.Type)
+	assert.NoError(t, validateConfig(cfg))
+}
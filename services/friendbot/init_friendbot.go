@@ -13,6 +13,9 @@ import (
 	"github.com/shantanu-hashcash/go/txnbuild"
 )
 
+// minionBalance is the balance minion accounts are created with.
+const minionBalance = "101.00"
+
 func initFriendbot(
 	friendbotSecret string,
 	networkPassphrase string,
@@ -46,7 +49,6 @@ func initFriendbot(
 	botKeypair := botKP.(*keypair.Full)
 	botAccount := internal.Account{AccountID: botKeypair.Address()}
 	// set default values
-	minionBalance := "101.00"
 	if numMinions == 0 {
 		numMinions = 1000
 	}
@@ -94,18 +96,7 @@ func createMinionAccounts(botAccount internal.Account, botKeypair *keypair.Full,
 			if err != nil {
 				return minions, errors.Wrap(err, "making keypair")
 			}
-			newMinions = append(newMinions, internal.Minion{
-				Account:              internal.Account{AccountID: minionKeypair.Address()},
-				Keypair:              minionKeypair,
-				BotAccount:           botAccount,
-				BotKeypair:           botKeypair,
-				Aurora:              hclient,
-				Network:              networkPassphrase,
-				StartingBalance:      newAccountBalance,
-				SubmitTransaction:    internal.SubmitTransaction,
-				CheckSequenceRefresh: internal.CheckSequenceRefresh,
-				BaseFee:              baseFee,
-			})
+			newMinions = append(newMinions, newMinion(minionKeypair, botAccount, botKeypair, networkPassphrase, newAccountBalance, baseFee, hclient))
 
 			ops = append(ops, &txnbuild.CreateAccount{
 				Destination: minionKeypair.Address(),
@@ -167,3 +158,19 @@ func createMinionAccounts(botAccount internal.Account, botKeypair *keypair.Full,
 	}
 	return minions, nil
 }
+
+func newMinion(minionKeypair *keypair.Full, botAccount internal.Account, botKeypair *keypair.Full, networkPassphrase, newAccountBalance string,
+	baseFee int64, hclient auroraclient.ClientInterface) internal.Minion {
+	return internal.Minion{
+		Account:              internal.Account{AccountID: minionKeypair.Address()},
+		Keypair:              minionKeypair,
+		BotAccount:           botAccount,
+		BotKeypair:           botKeypair,
+		Aurora:               hclient,
+		Network:              networkPassphrase,
+		StartingBalance:      newAccountBalance,
+		SubmitTransaction:    internal.SubmitTransaction,
+		CheckSequenceRefresh: internal.CheckSequenceRefresh,
+		BaseFee:              baseFee,
+	}
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/shantanu-hashcash/go/clients/auroraclient"
+	"github.com/shantanu-hashcash/go/keypair"
+	"github.com/shantanu-hashcash/go/services/friendbot/internal"
+	"github.com/shantanu-hashcash/go/support/errors"
+	"github.com/shantanu-hashcash/go/txnbuild"
+)
+
+func initHandler(cfg Config, fb *internal.Bot) (*internal.FriendbotHandler, error) {
+	handler := &internal.FriendbotHandler{Friendbot: fb}
+
+	policy, err := initPolicy(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "initializing funding policy")
+	}
+	handler.Policy = policy
+
+	if cfg.SEP10 != nil {
+		auth, err := initAuth(cfg.NetworkPassphrase, *cfg.SEP10)
+		if err != nil {
+			return nil, errors.Wrap(err, "initializing SEP-10 authentication")
+		}
+		handler.Auth = auth
+		handler.AuthRequired = cfg.SEP10.Required
+	}
+
+	if len(cfg.Assets) > 0 {
+		hclient := &auroraclient.Client{
+			AuroraURL: cfg.AuroraURL,
+			HTTP:      http.DefaultClient,
+			AppName:   "friendbot",
+		}
+		assets, err := initAssets(cfg.Assets, cfg.NetworkPassphrase, cfg.BaseFee, hclient)
+		if err != nil {
+			return nil, errors.Wrap(err, "initializing custom assets")
+		}
+		handler.Assets = assets
+	}
+	return handler, nil
+}
+
+func initPolicy(cfg Config) (*internal.Policy, error) {
+	store, err := internal.NewQuotaStore(cfg.QuotaFile)
+	if err != nil {
+		return nil, err
+	}
+
+	// set default values
+	window := time.Duration(cfg.QuotaWindow) * time.Second
+	if window == 0 {
+		window = 24 * time.Hour
+	}
+
+	defaultTier := internal.Tier{
+		Name:            "default",
+		StartingBalance: cfg.StartingBalance,
+		IPQuota:         cfg.IPQuota,
+		AccountQuota:    cfg.AccountQuota,
+	}
+	accountTiers := map[string]internal.Tier{}
+	for _, tc := range cfg.Tiers {
+		tier := internal.Tier{
+			Name:            tc.Name,
+			StartingBalance: tc.StartingBalance,
+			AssetAmounts:    tc.AssetAmounts,
+			IPQuota:         tc.IPQuota,
+			AccountQuota:    tc.AccountQuota,
+		}
+		if tier.StartingBalance == "" {
+			tier.StartingBalance = defaultTier.StartingBalance
+		}
+		for _, account := range tc.Accounts {
+			if _, ok := accountTiers[account]; ok {
+				return nil, errors.Errorf("account %s belongs to more than one tier", account)
+			}
+			accountTiers[account] = tier
+		}
+	}
+
+	return &internal.Policy{
+		Window:       window,
+		DefaultTier:  defaultTier,
+		AccountTiers: accountTiers,
+		Store:        store,
+	}, nil
+}
+
+func initAuth(networkPassphrase string, cfg SEP10Config) (*internal.ChallengeAuthenticator, error) {
+	signingKey, err := keypair.ParseFull(cfg.SigningSecret)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing signing key")
+	}
+
+	// set default values
+	timeout := time.Duration(cfg.ChallengeTimeout) * time.Second
+	if timeout == 0 {
+		timeout = 15 * time.Minute
+	}
+
+	return &internal.ChallengeAuthenticator{
+		SigningKey:        signingKey,
+		NetworkPassphrase: networkPassphrase,
+		WebAuthDomain:     cfg.WebAuthDomain,
+		HomeDomain:        cfg.HomeDomain,
+		ChallengeTimeout:  timeout,
+	}, nil
+}
+
+func initAssets(configs []AssetConfig, networkPassphrase string, baseFee int64, hclient auroraclient.ClientInterface) (*internal.AssetFunder, error) {
+	assets := map[string]internal.FundedAsset{}
+	for _, ac := range configs {
+		if _, ok := assets[ac.Code]; ok {
+			return nil, errors.Errorf("asset %s is configured more than once", ac.Code)
+		}
+		distributor, err := keypair.ParseFull(ac.DistributionSecret)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing distribution key of asset %s", ac.Code)
+		}
+		assets[ac.Code] = internal.FundedAsset{
+			Asset:       txnbuild.CreditAsset{Code: ac.Code, Issuer: ac.Issuer},
+			Amount:      ac.Amount,
+			Distributor: distributor,
+		}
+	}
+
+	// set default values
+	if baseFee == 0 {
+		baseFee = txnbuild.MinBaseFee
+	}
+
+	return &internal.AssetFunder{
+		Assets:  assets,
+		Aurora:  hclient,
+		Network: networkPassphrase,
+		BaseFee: baseFee,
+		Timeout: 300,
+	}, nil
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shantanu-hashcash/go/clients/auroraclient"
+	"github.com/shantanu-hashcash/go/services/friendbot/internal"
+	"github.com/shantanu-hashcash/go/support/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testConfig = `
+port = 8000
+friendbot_secret = "SDANVB2UMNILW5UIMWO5BOZ4QYYEKQ34JNFCTDKTTLCBRG2ELDTNRGAM"
+network_passphrase = "Test SDF Network ; September 2015"
+aurora_url = "https://aurora-testnet.hcnet.org"
+starting_balance = "10000.00"
+quota_window = 3600
+ip_quota = 5
+account_quota = 1
+
+[sep10]
+signing_secret = "SCWNLYELENPBXN46FHYXETT5LJCYBZD5VUQQVW4KZPHFO2YTQJUWT4D5"
+web_auth_domain = "friendbot.example.com"
+home_domain = "example.com"
+required = true
+
+[[tiers]]
+name = "partners"
+accounts = ["GDJIN6W6PLTPKLLM57UW65ZH4BITUXUMYQHIMAZFYXF45PZVAWDBI77Z"]
+starting_balance = "50000.00"
+account_quota = 10
+asset_amounts = { USD = "5000" }
+
+[[assets]]
+code = "USD"
+issuer = "GD25B4QI6KWVDWXDW25CIM7EKR6A6PBSWE2RCNSAC4NJQDQJXZJYMMKR"
+amount = "100"
+distribution_secret = "SDTNSEERJPJFUE2LSDNYBFHYGVTPIWY7TU2IOJZQQGLWO2THTGB7NU5A"
+`
+
+func TestInitHandler(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "friendbot.cfg")
+	require.NoError(t, os.WriteFile(path, []byte(testConfig), 0600))
+
+	var cfg Config
+	require.NoError(t, config.Read(path, &cfg))
+
+	handler, err := initHandler(cfg, &internal.Bot{})
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Hour, handler.Policy.Window)
+	assert.Equal(t, internal.Tier{
+		Name:            "default",
+		StartingBalance: "10000.00",
+		IPQuota:         5,
+		AccountQuota:    1,
+	}, handler.Policy.DefaultTier)
+	assert.Equal(t, internal.Tier{
+		Name:            "partners",
+		StartingBalance: "50000.00",
+		AssetAmounts:    map[string]string{"USD": "5000"},
+		AccountQuota:    10,
+	}, handler.Policy.AccountTiers["GDJIN6W6PLTPKLLM57UW65ZH4BITUXUMYQHIMAZFYXF45PZVAWDBI77Z"])
+
+	require.NotNil(t, handler.Auth)
+	assert.True(t, handler.AuthRequired)
+	assert.Equal(t, 15*time.Minute, handler.Auth.ChallengeTimeout)
+	assert.Equal(t, "GD25B4QI6KWVDWXDW25CIM7EKR6A6PBSWE2RCNSAC4NJQDQJXZJYMMKR", handler.Auth.SigningKey.Address())
+
+	require.NotNil(t, handler.Assets)
+	usd := handler.Assets.Assets["USD"]
+	assert.Equal(t, "100", usd.Amount)
+	assert.Equal(t, "GD4AGPPDFFHKK3Z2X4XZDRXX6GZQKP4FMLVQ5T55NDEYGG3GIP7BQUHM", usd.Distributor.Address())
+}
+
+func TestInitPolicy_duplicateTierAccount(t *testing.T) {
+	cfg := Config{
+		StartingBalance: "10000.00",
+		Tiers: []TierConfig{
+			{Name: "a", Accounts: []string{"GDJIN6W6PLTPKLLM57UW65ZH4BITUXUMYQHIMAZFYXF45PZVAWDBI77Z"}},
+			{Name: "b", Accounts: []string{"GDJIN6W6PLTPKLLM57UW65ZH4BITUXUMYQHIMAZFYXF45PZVAWDBI77Z"}},
+		},
+	}
+	_, err := initPolicy(cfg)
+	assert.EqualError(t, err, "account GDJIN6W6PLTPKLLM57UW65ZH4BITUXUMYQHIMAZFYXF45PZVAWDBI77Z belongs to more than one tier")
+}
+
+func TestInitAssets_duplicateAsset(t *testing.T) {
+	asset := AssetConfig{
+		Code:               "USD",
+		Issuer:             "GD25B4QI6KWVDWXDW25CIM7EKR6A6PBSWE2RCNSAC4NJQDQJXZJYMMKR",
+		Amount:             "100",
+		DistributionSecret: "SDTNSEERJPJFUE2LSDNYBFHYGVTPIWY7TU2IOJZQQGLWO2THTGB7NU5A",
+	}
+	_, err := initAssets([]AssetConfig{asset, asset}, "Test SDF Network ; September 2015", 0, &auroraclient.MockClient{})
+	assert.EqualError(t, err, "asset USD is configured more than once")
+}
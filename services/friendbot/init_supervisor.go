@@ -0,0 +1,73 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/shantanu-hashcash/go/clients/auroraclient"
+	"github.com/shantanu-hashcash/go/keypair"
+	"github.com/shantanu-hashcash/go/services/friendbot/internal"
+	"github.com/shantanu-hashcash/go/support/errors"
+	"github.com/shantanu-hashcash/go/txnbuild"
+)
+
+func initSupervisor(cfg Config, fb *internal.Bot) (*internal.Supervisor, error) {
+	botKeypair, err := keypair.ParseFull(cfg.FriendbotSecret)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing bot keypair")
+	}
+	botAccount := internal.Account{AccountID: botKeypair.Address()}
+
+	hclient := &auroraclient.Client{
+		AuroraURL: cfg.AuroraURL,
+		HTTP:      http.DefaultClient,
+		AppName:   "friendbot",
+	}
+
+	sc := cfg.Supervisor
+	// set default values
+	baseFee := cfg.BaseFee
+	if baseFee == 0 {
+		baseFee = txnbuild.MinBaseFee
+	}
+	if sc.MinMinions == 0 {
+		sc.MinMinions = len(fb.Minions)
+	}
+	if sc.MinMinions < 1 {
+		sc.MinMinions = 1
+	}
+	if sc.MaxMinions == 0 {
+		sc.MaxMinions = sc.MinMinions
+	}
+	if sc.MaxMinions < sc.MinMinions {
+		return nil, errors.New("max_minions must not be lower than min_minions")
+	}
+	if sc.RequestsPerMinion == 0 {
+		sc.RequestsPerMinion = 10
+	}
+	if sc.LowBalance == "" {
+		sc.LowBalance = "20"
+	}
+	if sc.MaxFailures == 0 {
+		sc.MaxFailures = 5
+	}
+
+	return &internal.Supervisor{
+		Bot:               fb,
+		Aurora:            hclient,
+		BotAccount:        &botAccount,
+		BotKeypair:        botKeypair,
+		Network:           cfg.NetworkPassphrase,
+		BaseFee:           baseFee,
+		MinionBalance:     minionBalance,
+		LowBalance:        sc.LowBalance,
+		MaxFailures:       sc.MaxFailures,
+		MinMinions:        sc.MinMinions,
+		MaxMinions:        sc.MaxMinions,
+		RequestsPerMinion: sc.RequestsPerMinion,
+		Interval:          time.Duration(sc.Interval) * time.Second,
+		NewMinion: func(kp *keypair.Full) internal.Minion {
+			return newMinion(kp, botAccount, botKeypair, cfg.NetworkPassphrase, cfg.StartingBalance, cfg.BaseFee, hclient)
+		},
+	}, nil
+}
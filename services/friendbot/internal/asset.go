@@ -0,0 +1,217 @@
+package internal
+
+import (
+	"sync"
+	"time"
+
+	"github.com/shantanu-hashcash/go/clients/auroraclient"
+	"github.com/shantanu-hashcash/go/keypair"
+	hProtocol "github.com/shantanu-hashcash/go/protocols/aurora"
+	"github.com/shantanu-hashcash/go/support/errors"
+	"github.com/shantanu-hashcash/go/txnbuild"
+)
+
+// ErrAssetNotFound is returned when funding is requested for an asset the
+// friendbot does not distribute.
+var ErrAssetNotFound = errors.New("asset is not distributed by this friendbot")
+
+// ErrFundingNotFound is returned when a submitted transaction is not a
+// pending funding transaction issued by the friendbot.
+var ErrFundingNotFound = errors.New("transaction is not a pending funding transaction of this friendbot")
+
+// FundedAsset is a custom asset distributed by the friendbot.
+type FundedAsset struct {
+	Asset txnbuild.CreditAsset
+	// Amount is the default amount accounts are funded with.
+	Amount string
+	// Distributor holds the asset, it may be the asset issuer.
+	Distributor *keypair.Full
+}
+
+// AssetFunder funds accounts with custom assets. Trustlines can only be
+// created with the signature of the funded account, so the funding
+// transaction is sourced from the funded account and returned to the caller
+// to be signed. The caller sends the signed transaction back, and the
+// friendbot adds the signature of the asset distributor and submits it. The
+// distributor never signs a transaction the friendbot does not submit, so
+// quotas are charged when the funding is submitted rather than when the
+// transaction is handed out.
+type AssetFunder struct {
+	Assets  map[string]FundedAsset
+	Aurora  auroraclient.ClientInterface
+	Network string
+	BaseFee int64
+	// Timeout is the number of seconds the funding transaction is valid for.
+	Timeout int64
+
+	mu sync.Mutex
+	// pending are the funding transactions handed out and not submitted
+	// yet, by transaction hash.
+	pending map[[32]byte]PendingFunding
+}
+
+// AssetFunding is the response to a custom asset funding request.
+type AssetFunding struct {
+	Transaction       string `json:"transaction"`
+	NetworkPassphrase string `json:"network_passphrase"`
+}
+
+// PendingFunding is a funding transaction handed out to a caller.
+type PendingFunding struct {
+	// Caller is the caller that requested the funding, its quota is charged
+	// when the funding is submitted.
+	Caller      Caller
+	tx          *txnbuild.Transaction
+	distributor *keypair.Full
+	expires     time.Time
+}
+
+// Fund builds the transaction funding destAddress with amount of the asset
+// with the given code, to be signed by the destination and sent back to
+// Submit. If amount is empty the asset's default amount is used. The
+// transaction adds the trustline to the asset if the destination does not
+// have it.
+func (f *AssetFunder) Fund(caller Caller, destAddress, code, amount string) (*AssetFunding, error) {
+	fa, ok := f.Assets[code]
+	if !ok {
+		return nil, ErrAssetNotFound
+	}
+	if amount == "" {
+		amount = fa.Amount
+	}
+
+	dest, err := f.Aurora.AccountDetail(auroraclient.AccountRequest{AccountID: destAddress})
+	if err != nil {
+		return nil, errors.Wrap(err, "getting destination account detail")
+	}
+
+	var ops []txnbuild.Operation
+	trusted := false
+	for _, balance := range dest.Balances {
+		if balance.Asset.Code == fa.Asset.Code && balance.Asset.Issuer == fa.Asset.Issuer {
+			trusted = true
+			break
+		}
+	}
+	if !trusted {
+		ops = append(ops, &txnbuild.ChangeTrust{
+			Line:  fa.Asset.MustToChangeTrustAsset(),
+			Limit: txnbuild.MaxTrustlineLimit,
+		})
+	}
+	ops = append(ops, &txnbuild.Payment{
+		Destination:   destAddress,
+		Amount:        amount,
+		Asset:         fa.Asset,
+		SourceAccount: fa.Distributor.Address(),
+	})
+
+	timeBounds := txnbuild.NewTimeout(f.Timeout)
+	tx, err := txnbuild.NewTransaction(
+		txnbuild.TransactionParams{
+			SourceAccount:        &dest,
+			IncrementSequenceNum: true,
+			Operations:           ops,
+			BaseFee:              f.BaseFee,
+			Preconditions:        txnbuild.Preconditions{TimeBounds: timeBounds},
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to build tx")
+	}
+
+	hash, err := tx.Hash(f.Network)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to hash tx")
+	}
+	txe, err := tx.Base64()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to serialize")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.prune(time.Now())
+	if f.pending == nil {
+		f.pending = map[[32]byte]PendingFunding{}
+	}
+	f.pending[hash] = PendingFunding{
+		Caller:      caller,
+		tx:          tx,
+		distributor: fa.Distributor,
+		expires:     time.Unix(timeBounds.MaxTime, 0),
+	}
+	return &AssetFunding{Transaction: txe, NetworkPassphrase: f.Network}, nil
+}
+
+// Claim returns the pending funding of the signed funding transaction and
+// removes it from the pending fundings, so that a funding can only be
+// submitted once. It returns ErrFundingNotFound if the transaction was not
+// handed out by Fund or has expired.
+func (f *AssetFunder) Claim(signed string) (*PendingFunding, error) {
+	parsed, err := txnbuild.TransactionFromXDR(signed)
+	if err != nil {
+		return nil, ErrFundingNotFound
+	}
+	tx, ok := parsed.Transaction()
+	if !ok {
+		return nil, ErrFundingNotFound
+	}
+	hash, err := tx.Hash(f.Network)
+	if err != nil {
+		return nil, ErrFundingNotFound
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.prune(time.Now())
+	pending, ok := f.pending[hash]
+	if !ok {
+		return nil, ErrFundingNotFound
+	}
+	delete(f.pending, hash)
+	// The hash does not cover the signatures, keep the caller's.
+	pending.tx = tx
+	return &pending, nil
+}
+
+// Submit adds the distributor signature to the claimed funding transaction
+// and submits it to Aurora.
+func (f *AssetFunder) Submit(pending *PendingFunding) (*hProtocol.Transaction, error) {
+	tx, err := pending.tx.Sign(f.Network, pending.distributor)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to sign tx")
+	}
+	txe, err := tx.Base64()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to serialize")
+	}
+
+	result, err := f.Aurora.SubmitTransactionXDR(txe)
+	if err != nil {
+		return nil, errors.Wrap(err, "submitting tx to aurora")
+	}
+	return &result, nil
+}
+
+// prune removes the pending fundings whose transaction has expired, it must
+// be called with f.mu held.
+func (f *AssetFunder) prune(now time.Time) {
+	for hash, pending := range f.pending {
+		if now.After(pending.expires) {
+			delete(f.pending, hash)
+		}
+	}
+}
+
+// rejected reports whether Aurora definitively rejected a submitted
+// transaction, which is when it returns transaction result codes. After a
+// timeout the transaction may still be included in a ledger.
+func rejected(err error) bool {
+	auroraErr := auroraclient.GetError(err)
+	if auroraErr == nil {
+		return false
+	}
+	codes, err := auroraErr.ResultCodes()
+	return err == nil && codes.TransactionCode != ""
+}
@@ -0,0 +1,172 @@
+package internal
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/shantanu-hashcash/go/clients/auroraclient"
+	"github.com/shantanu-hashcash/go/keypair"
+	hProtocol "github.com/shantanu-hashcash/go/protocols/aurora"
+	"github.com/shantanu-hashcash/go/protocols/aurora/base"
+	"github.com/shantanu-hashcash/go/support/errors"
+	"github.com/shantanu-hashcash/go/support/render/problem"
+	"github.com/shantanu-hashcash/go/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testNetwork = "Test SDF Network ; September 2015"
+
+func newTestAssetFunder(hclient auroraclient.ClientInterface) (*AssetFunder, FundedAsset) {
+	usd := FundedAsset{
+		Asset:       txnbuild.CreditAsset{Code: "USD", Issuer: keypair.MustRandom().Address()},
+		Amount:      "100",
+		Distributor: keypair.MustRandom(),
+	}
+	return &AssetFunder{
+		Assets:  map[string]FundedAsset{"USD": usd},
+		Aurora:  hclient,
+		Network: testNetwork,
+		BaseFee: txnbuild.MinBaseFee,
+		Timeout: 300,
+	}, usd
+}
+
+func parseFunding(t *testing.T, funding *AssetFunding) *txnbuild.Transaction {
+	assert.Equal(t, testNetwork, funding.NetworkPassphrase)
+	parsed, err := txnbuild.TransactionFromXDR(funding.Transaction)
+	require.NoError(t, err)
+	tx, ok := parsed.Transaction()
+	require.True(t, ok)
+	return tx
+}
+
+func TestAssetFunder_Fund(t *testing.T) {
+	dest := keypair.MustRandom().Address()
+	hclient := &auroraclient.MockClient{}
+	hclient.On("AccountDetail", auroraclient.AccountRequest{AccountID: dest}).
+		Return(hProtocol.Account{AccountID: dest, Sequence: 10}, nil)
+	funder, usd := newTestAssetFunder(hclient)
+
+	funding, err := funder.Fund(Caller{IP: "1.2.3.4", Account: dest}, dest, "USD", "")
+	require.NoError(t, err)
+
+	tx := parseFunding(t, funding)
+	assert.Equal(t, dest, tx.SourceAccount().AccountID)
+	assert.Equal(t, int64(11), tx.SourceAccount().Sequence)
+	require.Len(t, tx.Operations(), 2)
+
+	changeTrust := tx.Operations()[0].(*txnbuild.ChangeTrust)
+	assert.Equal(t, usd.Asset.MustToChangeTrustAsset(), changeTrust.Line)
+	payment := tx.Operations()[1].(*txnbuild.Payment)
+	assert.Equal(t, dest, payment.Destination)
+	assert.Equal(t, "100.0000000", payment.Amount)
+	assert.Equal(t, usd.Distributor.Address(), payment.SourceAccount)
+
+	// The distributor only signs the transaction when it is submitted.
+	assert.Empty(t, tx.Signatures())
+}
+
+func TestAssetFunder_Fund_existingTrustline(t *testing.T) {
+	dest := keypair.MustRandom().Address()
+	hclient := &auroraclient.MockClient{}
+	funder, usd := newTestAssetFunder(hclient)
+	hclient.On("AccountDetail", auroraclient.AccountRequest{AccountID: dest}).
+		Return(hProtocol.Account{
+			AccountID: dest,
+			Sequence:  10,
+			Balances: []hProtocol.Balance{{
+				Balance: "0.0000000",
+				Asset:   base.Asset{Type: "credit_alphanum4", Code: "USD", Issuer: usd.Asset.Issuer},
+			}},
+		}, nil)
+
+	funding, err := funder.Fund(Caller{IP: "1.2.3.4", Account: dest}, dest, "USD", "250")
+	require.NoError(t, err)
+
+	tx := parseFunding(t, funding)
+	require.Len(t, tx.Operations(), 1)
+	payment := tx.Operations()[0].(*txnbuild.Payment)
+	assert.Equal(t, "250.0000000", payment.Amount)
+}
+
+func TestAssetFunder_Fund_unknownAsset(t *testing.T) {
+	funder, _ := newTestAssetFunder(&auroraclient.MockClient{})
+	_, err := funder.Fund(Caller{}, keypair.MustRandom().Address(), "EUR", "")
+	assert.Equal(t, ErrAssetNotFound, err)
+}
+
+func signFunding(t *testing.T, funding *AssetFunding, kp *keypair.Full) string {
+	tx, err := parseFunding(t, funding).Sign(testNetwork, kp)
+	require.NoError(t, err)
+	signed, err := tx.Base64()
+	require.NoError(t, err)
+	return signed
+}
+
+func TestAssetFunder_Submit(t *testing.T) {
+	destKP := keypair.MustRandom()
+	dest := destKP.Address()
+	hclient := &auroraclient.MockClient{}
+	hclient.On("AccountDetail", auroraclient.AccountRequest{AccountID: dest}).
+		Return(hProtocol.Account{AccountID: dest, Sequence: 10}, nil)
+	funder, usd := newTestAssetFunder(hclient)
+	caller := Caller{IP: "1.2.3.4", Account: dest}
+
+	funding, err := funder.Fund(caller, dest, "USD", "")
+	require.NoError(t, err)
+	signed := signFunding(t, funding, destKP)
+
+	// Transactions not handed out by Fund are not submitted.
+	_, err = funder.Claim(funding.Transaction + "A")
+	assert.Equal(t, ErrFundingNotFound, err)
+	other, err := funder.Fund(caller, dest, "USD", "250")
+	require.NoError(t, err)
+	delete(funder.pending, hashFunding(t, other))
+	_, err = funder.Claim(signFunding(t, other, destKP))
+	assert.Equal(t, ErrFundingNotFound, err)
+
+	pending, err := funder.Claim(signed)
+	require.NoError(t, err)
+	assert.Equal(t, caller, pending.Caller)
+
+	// A funding can only be claimed once.
+	_, err = funder.Claim(signed)
+	assert.Equal(t, ErrFundingNotFound, err)
+
+	hclient.On("SubmitTransactionXDR", mock.AnythingOfType("string")).
+		Return(hProtocol.Transaction{Successful: true}, nil).
+		Run(func(args mock.Arguments) {
+			parsed, err := txnbuild.TransactionFromXDR(args.String(0))
+			require.NoError(t, err)
+			tx, ok := parsed.Transaction()
+			require.True(t, ok)
+			hash, err := tx.Hash(testNetwork)
+			require.NoError(t, err)
+			require.Len(t, tx.Signatures(), 2)
+			assert.NoError(t, destKP.Verify(hash[:], tx.Signatures()[0].Signature))
+			assert.NoError(t, usd.Distributor.Verify(hash[:], tx.Signatures()[1].Signature))
+		})
+	result, err := funder.Submit(pending)
+	require.NoError(t, err)
+	assert.True(t, result.Successful)
+	hclient.AssertExpectations(t)
+}
+
+func TestRejected(t *testing.T) {
+	assert.False(t, rejected(errors.New("timeout")))
+	assert.False(t, rejected(auroraclient.Error{Problem: problem.P{Status: http.StatusGatewayTimeout}}))
+	assert.True(t, rejected(errors.Wrap(auroraclient.Error{Problem: problem.P{
+		Status: http.StatusBadRequest,
+		Extras: map[string]interface{}{
+			"result_codes": map[string]interface{}{"transaction": "tx_bad_seq"},
+		},
+	}}, "submitting tx to aurora")))
+}
+
+func hashFunding(t *testing.T, funding *AssetFunding) [32]byte {
+	hash, err := parseFunding(t, funding).Hash(testNetwork)
+	require.NoError(t, err)
+	return hash
+}
@@ -0,0 +1,110 @@
+package internal
+
+import (
+	"sync"
+	"time"
+
+	"github.com/shantanu-hashcash/go/keypair"
+	"github.com/shantanu-hashcash/go/support/errors"
+	"github.com/shantanu-hashcash/go/txnbuild"
+)
+
+// ErrUnauthorized is returned when a funding request requires SEP-10
+// authentication and the request does not carry a valid challenge.
+var ErrUnauthorized = errors.New("a valid signed SEP-10 challenge transaction is required")
+
+// ChallengeAuthenticator authenticates callers using SEP-10 challenge
+// transactions issued by the friendbot itself. Callers request a challenge
+// for their account, sign it with the account's master key, and send it back
+// along with their funding requests. Each challenge authenticates a single
+// request.
+type ChallengeAuthenticator struct {
+	SigningKey        *keypair.Full
+	NetworkPassphrase string
+	WebAuthDomain     string
+	HomeDomain        string
+	ChallengeTimeout  time.Duration
+
+	mu sync.Mutex
+	// used are the hashes of the challenges that authenticated a request,
+	// with the time they expire at.
+	used map[[32]byte]time.Time
+}
+
+// BuildChallenge returns a challenge transaction for account, signed by the
+// friendbot signing key and encoded as base64 XDR.
+func (a *ChallengeAuthenticator) BuildChallenge(account string) (string, error) {
+	tx, err := txnbuild.BuildChallengeTx(
+		a.SigningKey.Seed(),
+		account,
+		a.WebAuthDomain,
+		a.HomeDomain,
+		a.NetworkPassphrase,
+		a.ChallengeTimeout,
+		nil,
+	)
+	if err != nil {
+		return "", errors.Wrap(err, "building challenge")
+	}
+	return tx.Base64()
+}
+
+// Authenticate verifies that challenge was issued by the friendbot, signed by
+// the master key of the client account and not used before, and returns the
+// client account.
+func (a *ChallengeAuthenticator) Authenticate(challenge string) (string, error) {
+	homeDomains := []string{a.HomeDomain}
+	tx, account, _, _, err := txnbuild.ReadChallengeTx(
+		challenge,
+		a.SigningKey.Address(),
+		a.NetworkPassphrase,
+		a.WebAuthDomain,
+		homeDomains,
+	)
+	if err != nil {
+		return "", errors.Wrap(ErrUnauthorized, err.Error())
+	}
+
+	_, err = txnbuild.VerifyChallengeTxSigners(
+		challenge,
+		a.SigningKey.Address(),
+		a.NetworkPassphrase,
+		a.WebAuthDomain,
+		homeDomains,
+		account,
+	)
+	if err != nil {
+		return "", errors.Wrap(ErrUnauthorized, err.Error())
+	}
+
+	hash, err := tx.Hash(a.NetworkPassphrase)
+	if err != nil {
+		return "", errors.Wrap(err, "hashing challenge")
+	}
+	if !a.use(hash, time.Unix(tx.Timebounds().MaxTime, 0)) {
+		return "", errors.Wrap(ErrUnauthorized, "challenge has already been used")
+	}
+	return account, nil
+}
+
+// use records the challenge with the given hash as used until it expires. It
+// returns false if the challenge was already used.
+func (a *ChallengeAuthenticator) use(hash [32]byte, expires time.Time) bool {
+	now := time.Now()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for h, e := range a.used {
+		if now.After(e) {
+			delete(a.used, h)
+		}
+	}
+	if _, ok := a.used[hash]; ok {
+		return false
+	}
+	if a.used == nil {
+		a.used = map[[32]byte]time.Time{}
+	}
+	a.used[hash] = expires
+	return true
+}
@@ -0,0 +1,76 @@
+package internal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shantanu-hashcash/go/keypair"
+	"github.com/shantanu-hashcash/go/support/errors"
+	"github.com/shantanu-hashcash/go/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAuthenticator() *ChallengeAuthenticator {
+	return &ChallengeAuthenticator{
+		SigningKey:        keypair.MustRandom(),
+		NetworkPassphrase: "Test SDF Network ; September 2015",
+		WebAuthDomain:     "friendbot.example.com",
+		HomeDomain:        "example.com",
+		ChallengeTimeout:  time.Minute,
+	}
+}
+
+func signChallenge(t *testing.T, auth *ChallengeAuthenticator, challenge string, kp *keypair.Full) string {
+	parsed, err := txnbuild.TransactionFromXDR(challenge)
+	require.NoError(t, err)
+	tx, ok := parsed.Transaction()
+	require.True(t, ok)
+	tx, err = tx.Sign(auth.NetworkPassphrase, kp)
+	require.NoError(t, err)
+	signed, err := tx.Base64()
+	require.NoError(t, err)
+	return signed
+}
+
+func TestChallengeAuthenticator(t *testing.T) {
+	auth := newTestAuthenticator()
+	client := keypair.MustRandom()
+
+	challenge, err := auth.BuildChallenge(client.Address())
+	require.NoError(t, err)
+
+	// Unsigned challenges are rejected.
+	_, err = auth.Authenticate(challenge)
+	assert.Equal(t, ErrUnauthorized, errors.Cause(err))
+
+	// Challenges signed by another key are rejected.
+	_, err = auth.Authenticate(signChallenge(t, auth, challenge, keypair.MustRandom()))
+	assert.Equal(t, ErrUnauthorized, errors.Cause(err))
+
+	signed := signChallenge(t, auth, challenge, client)
+	account, err := auth.Authenticate(signed)
+	require.NoError(t, err)
+	assert.Equal(t, client.Address(), account)
+
+	// Challenges authenticate a single request.
+	_, err = auth.Authenticate(signed)
+	assert.Equal(t, ErrUnauthorized, errors.Cause(err))
+}
+
+func TestChallengeAuthenticator_otherServer(t *testing.T) {
+	auth := newTestAuthenticator()
+	other := newTestAuthenticator()
+	client := keypair.MustRandom()
+
+	challenge, err := other.BuildChallenge(client.Address())
+	require.NoError(t, err)
+
+	_, err = auth.Authenticate(signChallenge(t, other, challenge, client))
+	assert.Equal(t, ErrUnauthorized, errors.Cause(err))
+}
+
+func TestChallengeAuthenticator_invalid(t *testing.T) {
+	_, err := newTestAuthenticator().Authenticate("AAAA")
+	assert.Equal(t, ErrUnauthorized, errors.Cause(err))
+}
@@ -5,14 +5,34 @@ import (
 	"sync"
 
 	hProtocol "github.com/shantanu-hashcash/go/protocols/aurora"
+	"github.com/shantanu-hashcash/go/support/errors"
 )
 
+// ErrNoMinions is returned when the bot has no minions to fund accounts with.
+var ErrNoMinions = errors.New("no minions available")
+
 // Bot represents the friendbot subsystem and primarily delegates work
 // to its Minions.
 type Bot struct {
 	Minions         []Minion
 	nextMinionIndex int
 	indexMux        sync.Mutex
+
+	// Guarded by indexMux, tracked for the Supervisor.
+	numRequests    int
+	minionRequests map[string]int
+	failures       map[string]int
+}
+
+// botStatus is the state of the minion pool reported to the Supervisor.
+type botStatus struct {
+	Minions []Minion
+	// Failures are the consecutive failures of each minion.
+	Failures map[string]int
+	// Requests are the number of requests handed to each minion, and
+	// NumRequests to the whole pool, since the previous status.
+	Requests    map[string]int
+	NumRequests int
 }
 
 // SubmitResult is the result from the asynchronous tx submission.
@@ -21,16 +41,97 @@ type SubmitResult struct {
 	maybeErr                error
 }
 
-// Pay funds the account at `destAddress`.
+// Pay funds the account at `destAddress` with the minions' starting balance.
 func (bot *Bot) Pay(destAddress string) (*hProtocol.Transaction, error) {
+	return bot.PayAmount(destAddress, "")
+}
+
+// PayAmount funds the account at `destAddress` with `amount`. If amount is
+// empty the minions' starting balance is used.
+func (bot *Bot) PayAmount(destAddress, amount string) (*hProtocol.Transaction, error) {
 	bot.indexMux.Lock()
+	if len(bot.Minions) == 0 {
+		bot.indexMux.Unlock()
+		return nil, ErrNoMinions
+	}
+	bot.nextMinionIndex %= len(bot.Minions)
 	log.Printf("Selecting minion at index %d of max length %d", bot.nextMinionIndex, len(bot.Minions))
 	minion := bot.Minions[bot.nextMinionIndex]
 	bot.nextMinionIndex = (bot.nextMinionIndex + 1) % len(bot.Minions)
+	bot.numRequests++
+	if bot.minionRequests == nil {
+		bot.minionRequests = map[string]int{}
+	}
+	bot.minionRequests[minion.Account.AccountID]++
 	bot.indexMux.Unlock()
 	resultChan := make(chan SubmitResult)
-	go minion.Run(destAddress, resultChan)
+	go minion.Run(destAddress, amount, resultChan)
 	maybeSubmitResult := <-resultChan
 	close(resultChan)
+	bot.recordResult(minion.Account.AccountID, maybeSubmitResult.maybeErr)
 	return maybeSubmitResult.maybeTransactionSuccess, maybeSubmitResult.maybeErr
 }
+
+// recordResult keeps track of the consecutive failures of each minion. Only
+// failures caused by the minion account count, errors such as funding an
+// existing account or Aurora timing out say nothing about the minion.
+func (bot *Bot) recordResult(minionAccountID string, err error) {
+	bot.indexMux.Lock()
+	defer bot.indexMux.Unlock()
+	if err == nil || errors.Cause(err) == ErrAccountExists {
+		delete(bot.failures, minionAccountID)
+		return
+	}
+	if errors.Cause(err) != ErrMinionFailure {
+		return
+	}
+	if bot.failures == nil {
+		bot.failures = map[string]int{}
+	}
+	bot.failures[minionAccountID]++
+}
+
+// AddMinions adds minions to the pool of minions funding accounts.
+func (bot *Bot) AddMinions(minions ...Minion) {
+	bot.indexMux.Lock()
+	defer bot.indexMux.Unlock()
+	bot.Minions = append(bot.Minions, minions...)
+}
+
+// RemoveMinion removes the minion with the given account from the pool of
+// minions funding accounts. Requests already handed to the minion complete.
+func (bot *Bot) RemoveMinion(accountID string) (Minion, bool) {
+	bot.indexMux.Lock()
+	defer bot.indexMux.Unlock()
+	for i, minion := range bot.Minions {
+		if minion.Account.AccountID != accountID {
+			continue
+		}
+		bot.Minions = removeMinion(bot.Minions, accountID)
+		if bot.nextMinionIndex > i {
+			bot.nextMinionIndex--
+		}
+		delete(bot.failures, accountID)
+		return minion, true
+	}
+	return Minion{}, false
+}
+
+// status returns a copy of the state of the minion pool, and resets the
+// request counts.
+func (bot *Bot) status() botStatus {
+	bot.indexMux.Lock()
+	defer bot.indexMux.Unlock()
+	status := botStatus{
+		Minions:     append([]Minion(nil), bot.Minions...),
+		Failures:    make(map[string]int, len(bot.failures)),
+		Requests:    bot.minionRequests,
+		NumRequests: bot.numRequests,
+	}
+	for accountID, n := range bot.failures {
+		status.Failures[accountID] = n
+	}
+	bot.numRequests = 0
+	bot.minionRequests = nil
+	return status
+}
@@ -1,10 +1,10 @@
 package internal
 
 import (
+	"net"
 	"net/http"
 	"net/url"
 
-	"github.com/shantanu-hashcash/go/protocols/aurora"
 	"github.com/shantanu-hashcash/go/strkey"
 	"github.com/shantanu-hashcash/go/support/render/hal"
 	"github.com/shantanu-hashcash/go/support/render/problem"
@@ -13,6 +13,17 @@ import (
 // FriendbotHandler causes an account at `Address` to be created.
 type FriendbotHandler struct {
 	Friendbot *Bot
+	// Policy enforces funding quotas and decides funding amounts. If nil,
+	// every request is funded with the minions' starting balance.
+	Policy *Policy
+	// Auth authenticates callers using SEP-10 challenges. If nil, SEP-10
+	// authentication is disabled.
+	Auth *ChallengeAuthenticator
+	// AuthRequired rejects funding requests without a valid challenge.
+	AuthRequired bool
+	// Assets funds accounts with custom assets. If nil, only the native
+	// asset is funded.
+	Assets *AssetFunder
 }
 
 // Handle is a method that implements http.HandlerFunc
@@ -23,11 +34,11 @@ func (handler *FriendbotHandler) Handle(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	hal.Render(w, *result)
+	hal.Render(w, result)
 }
 
 // doHandle is just a convenience method that returns the object to be rendered
-func (handler *FriendbotHandler) doHandle(r *http.Request) (*aurora.Transaction, error) {
+func (handler *FriendbotHandler) doHandle(r *http.Request) (interface{}, error) {
 	err := r.ParseForm()
 	if err != nil {
 		p := problem.BadRequest
@@ -39,7 +50,142 @@ func (handler *FriendbotHandler) doHandle(r *http.Request) (*aurora.Transaction,
 	if err != nil {
 		return nil, problem.MakeInvalidFieldProblem("addr", err)
 	}
-	return handler.Friendbot.Pay(address)
+
+	assetCode := r.Form.Get("asset")
+	if assetCode != "" && handler.Assets == nil {
+		return nil, problem.MakeInvalidFieldProblem("asset", ErrAssetNotFound)
+	}
+
+	caller, err := handler.loadCaller(r, address)
+	if err != nil {
+		return nil, err
+	}
+
+	tier := Tier{}
+	if handler.Policy != nil {
+		tier = handler.Policy.TierFor(caller)
+	}
+
+	if assetCode != "" {
+		// The quota is charged when the funding transaction is submitted,
+		// callers that exhausted it are turned away early.
+		if handler.Policy != nil {
+			if err = handler.Policy.Check(caller); err != nil {
+				return nil, err
+			}
+		}
+		return handler.Assets.Fund(caller, address, assetCode, tier.AssetAmounts[assetCode])
+	}
+
+	release := func() {}
+	if handler.Policy != nil {
+		release, err = handler.Policy.Reserve(caller)
+		if err != nil {
+			return nil, err
+		}
+	}
+	result, err := handler.Friendbot.PayAmount(address, tier.StartingBalance)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	return result, nil
+}
+
+// HandleSubmit is a method that implements http.HandlerFunc, it submits a
+// custom asset funding transaction signed by the funded account.
+func (handler *FriendbotHandler) HandleSubmit(w http.ResponseWriter, r *http.Request) {
+	result, err := handler.doSubmit(r)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	hal.Render(w, result)
+}
+
+func (handler *FriendbotHandler) doSubmit(r *http.Request) (interface{}, error) {
+	if handler.Assets == nil {
+		return nil, problem.NotFound
+	}
+	err := r.ParseForm()
+	if err != nil {
+		p := problem.BadRequest
+		p.Detail = "Request parameters are not escaped or incorrectly formatted."
+		return nil, &p
+	}
+
+	pending, err := handler.Assets.Claim(r.Form.Get("tx"))
+	if err != nil {
+		return nil, problem.MakeInvalidFieldProblem("tx", err)
+	}
+
+	release := func() {}
+	if handler.Policy != nil {
+		release, err = handler.Policy.Reserve(pending.Caller)
+		if err != nil {
+			return nil, err
+		}
+	}
+	result, err := handler.Assets.Submit(pending)
+	if err != nil {
+		// Unless Aurora rejected the transaction it may still be included
+		// in a ledger, so the funding is charged.
+		if rejected(err) {
+			release()
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+// HandleChallenge is a method that implements http.HandlerFunc, it responds
+// with a SEP-10 challenge transaction for the account at `account`.
+func (handler *FriendbotHandler) HandleChallenge(w http.ResponseWriter, r *http.Request) {
+	if handler.Auth == nil {
+		problem.Render(r.Context(), w, problem.NotFound)
+		return
+	}
+
+	account := r.URL.Query().Get("account")
+	if _, err := strkey.Decode(strkey.VersionByteAccountID, account); err != nil {
+		problem.Render(r.Context(), w, problem.MakeInvalidFieldProblem("account", err))
+		return
+	}
+
+	challenge, err := handler.Auth.BuildChallenge(account)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	hal.Render(w, map[string]string{
+		"transaction":        challenge,
+		"network_passphrase": handler.Auth.NetworkPassphrase,
+	})
+}
+
+// loadCaller identifies the caller of a funding request. Callers that send a
+// signed SEP-10 challenge are identified by the authenticated account, other
+// callers by the account being funded.
+func (handler *FriendbotHandler) loadCaller(r *http.Request, address string) (Caller, error) {
+	caller := Caller{IP: remoteIP(r), Account: address}
+
+	challenge := r.Form.Get("challenge")
+	if handler.Auth == nil || challenge == "" {
+		if handler.AuthRequired {
+			return caller, ErrUnauthorized
+		}
+		return caller, nil
+	}
+
+	account, err := handler.Auth.Authenticate(challenge)
+	if err != nil {
+		return caller, err
+	}
+	caller.Account = account
+	caller.Authenticated = true
+	return caller, nil
 }
 
 func (handler *FriendbotHandler) loadAddress(r *http.Request) (string, error) {
@@ -52,3 +198,13 @@ func (handler *FriendbotHandler) loadAddress(r *http.Request) (string, error) {
 	_, err = strkey.Decode(strkey.VersionByteAccountID, unescaped)
 	return unescaped, err
 }
+
+// remoteIP returns the IP address of the caller. RemoteAddr may not contain a
+// port when it was set by the XFF middleware.
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,188 @@
+package internal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shantanu-hashcash/go/clients/auroraclient"
+	"github.com/shantanu-hashcash/go/keypair"
+	hProtocol "github.com/shantanu-hashcash/go/protocols/aurora"
+	"github.com/shantanu-hashcash/go/support/errors"
+	"github.com/shantanu-hashcash/go/support/render/problem"
+	"github.com/shantanu-hashcash/go/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestHandler(t *testing.T) *FriendbotHandler {
+	mockSubmitTransaction := func(minion *Minion, hclient auroraclient.ClientInterface, tx string) (*hProtocol.Transaction, error) {
+		return &hProtocol.Transaction{EnvelopeXdr: tx, Successful: true}, nil
+	}
+	minion := Minion{
+		Account:              Account{AccountID: keypair.MustRandom().Address(), Sequence: 1},
+		Keypair:              keypair.MustRandom(),
+		BotAccount:           Account{AccountID: keypair.MustRandom().Address()},
+		BotKeypair:           keypair.MustRandom(),
+		Network:              testNetwork,
+		StartingBalance:      "10000.00",
+		SubmitTransaction:    mockSubmitTransaction,
+		CheckSequenceRefresh: CheckSequenceRefresh,
+		BaseFee:              txnbuild.MinBaseFee,
+	}
+
+	now := time.Now()
+	return &FriendbotHandler{
+		Friendbot: &Bot{Minions: []Minion{minion}},
+		Policy:    newTestPolicy(t, &now),
+	}
+}
+
+func doFundingRequest(handler *FriendbotHandler, remoteAddr string, form url.Values) (interface{}, error) {
+	r := httptest.NewRequest("POST", "/", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.RemoteAddr = remoteAddr
+	return handler.doHandle(r)
+}
+
+func fundedAmount(t *testing.T, result interface{}) string {
+	parsed, err := txnbuild.TransactionFromXDR(result.(*hProtocol.Transaction).EnvelopeXdr)
+	require.NoError(t, err)
+	tx, ok := parsed.Transaction()
+	require.True(t, ok)
+	return tx.Operations()[0].(*txnbuild.CreateAccount).Amount
+}
+
+func TestFriendbotHandler_quota(t *testing.T) {
+	handler := newTestHandler(t)
+	addr := keypair.MustRandom().Address()
+
+	result, err := doFundingRequest(handler, "1.2.3.4:5678", url.Values{"addr": {addr}})
+	require.NoError(t, err)
+	assert.Equal(t, "10000.0000000", fundedAmount(t, result))
+
+	_, err = doFundingRequest(handler, "5.6.7.8:5678", url.Values{"addr": {addr}})
+	assert.Equal(t, ErrQuotaExceeded, err)
+
+	_, err = doFundingRequest(handler, "1.2.3.4:1234", url.Values{"addr": {keypair.MustRandom().Address()}})
+	require.NoError(t, err)
+	_, err = doFundingRequest(handler, "1.2.3.4:1234", url.Values{"addr": {keypair.MustRandom().Address()}})
+	assert.Equal(t, ErrQuotaExceeded, err)
+}
+
+func TestFriendbotHandler_failedFundingReleasesQuota(t *testing.T) {
+	handler := newTestHandler(t)
+	handler.Friendbot.Minions[0].SubmitTransaction = func(minion *Minion, hclient auroraclient.ClientInterface, tx string) (*hProtocol.Transaction, error) {
+		return nil, errors.Wrap(ErrAccountExists, "submitting tx to aurora")
+	}
+	addr := keypair.MustRandom().Address()
+
+	for i := 0; i < 2; i++ {
+		_, err := doFundingRequest(handler, "1.2.3.4:5678", url.Values{"addr": {addr}})
+		assert.Equal(t, ErrAccountExists, errors.Cause(err))
+	}
+}
+
+func TestFriendbotHandler_auth(t *testing.T) {
+	handler := newTestHandler(t)
+	handler.Auth = newTestAuthenticator()
+	handler.AuthRequired = true
+	client := keypair.MustRandom()
+	handler.Policy.AccountTiers[client.Address()] = Tier{Name: "partners", StartingBalance: "50000.00"}
+	addr := keypair.MustRandom().Address()
+
+	_, err := doFundingRequest(handler, "1.2.3.4:5678", url.Values{"addr": {addr}})
+	assert.Equal(t, ErrUnauthorized, err)
+
+	challenge, err := handler.Auth.BuildChallenge(client.Address())
+	require.NoError(t, err)
+	_, err = doFundingRequest(handler, "1.2.3.4:5678", url.Values{"addr": {addr}, "challenge": {challenge}})
+	assert.Equal(t, ErrUnauthorized, errors.Cause(err))
+
+	signed := signChallenge(t, handler.Auth, challenge, client)
+	result, err := doFundingRequest(handler, "1.2.3.4:5678", url.Values{"addr": {addr}, "challenge": {signed}})
+	require.NoError(t, err)
+	assert.Equal(t, "50000.0000000", fundedAmount(t, result))
+}
+
+func TestFriendbotHandler_asset(t *testing.T) {
+	handler := newTestHandler(t)
+	addr := keypair.MustRandom().Address()
+
+	_, err := doFundingRequest(handler, "1.2.3.4:5678", url.Values{"addr": {addr}, "asset": {"USD"}})
+	assert.Error(t, err)
+
+	hclient := &auroraclient.MockClient{}
+	hclient.On("AccountDetail", auroraclient.AccountRequest{AccountID: addr}).
+		Return(hProtocol.Account{AccountID: addr, Sequence: 10}, nil)
+	handler.Assets, _ = newTestAssetFunder(hclient)
+
+	result, err := doFundingRequest(handler, "1.2.3.4:5678", url.Values{"addr": {addr}, "asset": {"USD"}})
+	require.NoError(t, err)
+	tx := parseFunding(t, result.(*AssetFunding))
+	assert.Len(t, tx.Operations(), 2)
+}
+
+func doSubmitRequest(handler *FriendbotHandler, tx string) (interface{}, error) {
+	r := httptest.NewRequest("POST", "/submit", strings.NewReader(url.Values{"tx": {tx}}.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return handler.doSubmit(r)
+}
+
+func TestFriendbotHandler_assetQuotaChargedOnSubmit(t *testing.T) {
+	handler := newTestHandler(t)
+	destKP := keypair.MustRandom()
+	addr := destKP.Address()
+	hclient := &auroraclient.MockClient{}
+	hclient.On("AccountDetail", auroraclient.AccountRequest{AccountID: addr}).
+		Return(hProtocol.Account{AccountID: addr, Sequence: 10}, nil)
+	handler.Assets, _ = newTestAssetFunder(hclient)
+	form := url.Values{"addr": {addr}, "asset": {"USD"}}
+
+	// Handing out funding transactions does not charge the quota.
+	first, err := doFundingRequest(handler, "1.2.3.4:5678", form)
+	require.NoError(t, err)
+
+	// Rejected submissions do not charge the quota.
+	rejection := auroraclient.Error{Problem: problem.P{
+		Status: http.StatusBadRequest,
+		Extras: map[string]interface{}{
+			"result_codes": map[string]interface{}{"transaction": "tx_bad_seq"},
+		},
+	}}
+	hclient.On("SubmitTransactionXDR", mock.AnythingOfType("string")).
+		Return(hProtocol.Transaction{}, rejection).Once()
+	_, err = doSubmitRequest(handler, signFunding(t, first.(*AssetFunding), destKP))
+	assert.Equal(t, rejection, errors.Cause(err))
+
+	second, err := doFundingRequest(handler, "1.2.3.4:5678", form)
+	require.NoError(t, err)
+	hclient.On("SubmitTransactionXDR", mock.AnythingOfType("string")).
+		Return(hProtocol.Transaction{Successful: true}, nil).Once()
+	result, err := doSubmitRequest(handler, signFunding(t, second.(*AssetFunding), destKP))
+	require.NoError(t, err)
+	assert.True(t, result.(*hProtocol.Transaction).Successful)
+
+	// The account quota is now exhausted.
+	_, err = doFundingRequest(handler, "1.2.3.4:5678", form)
+	assert.Equal(t, ErrQuotaExceeded, err)
+
+	// Funding transactions can only be submitted once.
+	_, err = doSubmitRequest(handler, signFunding(t, second.(*AssetFunding), destKP))
+	assert.Error(t, err)
+	hclient.AssertExpectations(t)
+}
+
+func TestRemoteIP(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	r.RemoteAddr = "1.2.3.4:5678"
+	assert.Equal(t, "1.2.3.4", remoteIP(r))
+	r.RemoteAddr = "1.2.3.4"
+	assert.Equal(t, "1.2.3.4", remoteIP(r))
+	r.RemoteAddr = "[::1]:5678"
+	assert.Equal(t, "::1", remoteIP(r))
+}
@@ -14,6 +14,19 @@ const createAccountAlreadyExistXDR = "AAAAAAAAAGT/////AAAAAQAAAAAAAAAA/////AAAAA
 
 var ErrAccountExists error = errors.New(fmt.Sprintf("createAccountAlreadyExist (%s)", createAccountAlreadyExistXDR))
 
+// ErrMinionFailure is returned when a funding fails because of the state of
+// the minion account, rather than because of the request or of Aurora.
+var ErrMinionFailure = errors.New("minion account cannot submit transactions")
+
+// minionResultCodes are the transaction result codes caused by the state of
+// the minion account.
+var minionResultCodes = map[string]bool{
+	"tx_bad_seq":              true,
+	"tx_insufficient_balance": true,
+	"tx_bad_auth":             true,
+	"tx_no_account":           true,
+}
+
 // Minion contains a Hcnet channel account and Go channels to communicate with friendbot.
 type Minion struct {
 	Account         Account
@@ -33,9 +46,10 @@ type Minion struct {
 	forceRefreshSequence bool
 }
 
-// Run reads a payment destination address and an output channel. It attempts
-// to pay that address and submits the result to the channel.
-func (minion *Minion) Run(destAddress string, resultChan chan SubmitResult) {
+// Run reads a payment destination address, an amount and an output channel.
+// It attempts to pay that address and submits the result to the channel. If
+// amount is empty the minion's StartingBalance is paid.
+func (minion *Minion) Run(destAddress, amount string, resultChan chan SubmitResult) {
 	err := minion.CheckSequenceRefresh(minion, minion.Aurora)
 	if err != nil {
 		resultChan <- SubmitResult{
@@ -44,7 +58,7 @@ func (minion *Minion) Run(destAddress string, resultChan chan SubmitResult) {
 		}
 		return
 	}
-	txHash, txStr, err := minion.makeTx(destAddress)
+	txHash, txStr, err := minion.makeTx(destAddress, amount)
 	if err != nil {
 		resultChan <- SubmitResult{
 			maybeTransactionSuccess: nil,
@@ -75,6 +89,9 @@ func SubmitTransaction(minion *Minion, hclient auroraclient.ClientInterface, tx
 			} else {
 				errStr += ": aurora error string: " + resStr
 			}
+			if codes, codesErr := e.ResultCodes(); codesErr == nil && minionResultCodes[codes.TransactionCode] {
+				return nil, errors.Wrap(ErrMinionFailure, errStr)
+			}
 			return nil, errors.New(errStr)
 		}
 		return nil, errors.Wrap(err, errStr)
@@ -89,6 +106,9 @@ func CheckSequenceRefresh(minion *Minion, hclient auroraclient.ClientInterface)
 		return nil
 	}
 	err := minion.Account.RefreshSequenceNumber(hclient)
+	if auroraclient.IsNotFoundError(err) {
+		return errors.Wrap(ErrMinionFailure, "refreshing minion seqnum: account not found")
+	}
 	if err != nil {
 		return errors.Wrap(err, "refreshing minion seqnum")
 	}
@@ -105,11 +125,14 @@ func (minion *Minion) checkHandleBadSequence(err *auroraclient.Error) {
 	minion.forceRefreshSequence = true
 }
 
-func (minion *Minion) makeTx(destAddress string) ([32]byte, string, error) {
+func (minion *Minion) makeTx(destAddress, amount string) ([32]byte, string, error) {
+	if amount == "" {
+		amount = minion.StartingBalance
+	}
 	createAccountOp := txnbuild.CreateAccount{
 		Destination:   destAddress,
 		SourceAccount: minion.BotAccount.GetAccountID(),
-		Amount:        minion.StartingBalance,
+		Amount:        amount,
 	}
 	tx, err := txnbuild.NewTransaction(
 		txnbuild.TransactionParams{
@@ -8,6 +8,7 @@ import (
 	"github.com/shantanu-hashcash/go/keypair"
 	hProtocol "github.com/shantanu-hashcash/go/protocols/aurora"
 	"github.com/shantanu-hashcash/go/support/errors"
+	"github.com/shantanu-hashcash/go/support/render/problem"
 	"github.com/shantanu-hashcash/go/txnbuild"
 	"github.com/stretchr/testify/assert"
 )
@@ -135,3 +136,31 @@ func TestMinion_CorrectNumberOfTxSubmissions(t *testing.T) {
 	wg.Wait()
 	assert.Equal(t, numTests, numTxSubmits)
 }
+
+func TestSubmitTransaction_minionFailures(t *testing.T) {
+	resultCodesErr := func(code string) *auroraclient.Error {
+		return &auroraclient.Error{Problem: problem.P{
+			Status: 400,
+			Extras: map[string]interface{}{
+				"result_codes": map[string]interface{}{"transaction": code},
+				"result_xdr":   "AAAAAAAAAGT////6AAAAAA==",
+			},
+		}}
+	}
+
+	for _, tc := range []struct {
+		err           error
+		minionFailure bool
+	}{
+		{resultCodesErr("tx_bad_seq"), true},
+		{resultCodesErr("tx_insufficient_balance"), true},
+		{resultCodesErr("tx_failed"), false},
+		{errors.New("timeout"), false},
+	} {
+		hclient := &auroraclient.MockClient{}
+		hclient.On("SubmitTransactionXDR", "tx").Return(hProtocol.Transaction{}, tc.err)
+		_, err := SubmitTransaction(&Minion{}, hclient, "tx")
+		assert.Error(t, err)
+		assert.Equal(t, tc.minionFailure, errors.Cause(err) == ErrMinionFailure, err.Error())
+	}
+}
@@ -0,0 +1,130 @@
+package internal
+
+import (
+	"sync"
+	"time"
+
+	"github.com/shantanu-hashcash/go/support/errors"
+)
+
+// ErrQuotaExceeded is returned when a caller has exhausted its funding quota.
+var ErrQuotaExceeded = errors.New("funding quota exceeded, try again later")
+
+// Tier configures the amounts a class of callers is funded with and how often
+// they can be funded.
+type Tier struct {
+	Name string
+	// StartingBalance is the amount of the native asset new accounts are
+	// funded with.
+	StartingBalance string
+	// AssetAmounts are the amounts of custom assets, by asset code, accounts
+	// are funded with. Assets without an amount use the asset's default.
+	AssetAmounts map[string]string
+	// IPQuota is the number of fundings allowed per IP address during the
+	// policy window, 0 means unlimited.
+	IPQuota int
+	// AccountQuota is the number of fundings allowed per account during the
+	// policy window, 0 means unlimited.
+	AccountQuota int
+}
+
+// Caller identifies the origin of a funding request.
+type Caller struct {
+	IP string
+	// Account is the SEP-10 authenticated account of the caller, if any,
+	// or otherwise the account being funded.
+	Account string
+	// Authenticated is true if Account was authenticated using SEP-10.
+	Authenticated bool
+}
+
+// Policy decides how callers are funded and enforces their quotas.
+type Policy struct {
+	// Window is the period of time quotas apply to.
+	Window time.Duration
+	// DefaultTier applies to anonymous callers and authenticated callers
+	// without a tier.
+	DefaultTier Tier
+	// AccountTiers are the tiers of authenticated callers, by account.
+	AccountTiers map[string]Tier
+	Store        *QuotaStore
+
+	// Mockable for tests.
+	Now func() time.Time
+
+	mu sync.Mutex
+}
+
+// TierFor returns the tier of a caller.
+func (p *Policy) TierFor(caller Caller) Tier {
+	if caller.Authenticated {
+		if tier, ok := p.AccountTiers[caller.Account]; ok {
+			return tier
+		}
+	}
+	return p.DefaultTier
+}
+
+// Check returns ErrQuotaExceeded if the caller has exhausted the quota of its
+// tier, without recording a funding.
+func (p *Policy) Check(caller Caller) error {
+	since := p.now().Add(-p.Window)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.check(caller, since)
+}
+
+// Reserve records a funding for the caller, or returns ErrQuotaExceeded if
+// the caller has exhausted the quota of its tier. The returned function must
+// be called to release the reservation if the funding fails.
+func (p *Policy) Reserve(caller Caller) (release func(), err error) {
+	now := p.now()
+	since := now.Add(-p.Window)
+
+	// Counting and recording fundings must be atomic, otherwise concurrent
+	// requests could exceed the quota.
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err = p.check(caller, since); err != nil {
+		return nil, err
+	}
+
+	keys := quotaKeys(caller)
+	if err = p.Store.Add(keys, now, since); err != nil {
+		return nil, errors.Wrap(err, "recording funding")
+	}
+	release = func() {
+		// The reservation is only released on failed fundings, failing to
+		// persist it only makes the caller's quota stricter.
+		_ = p.Store.Remove(keys, now)
+	}
+	return release, nil
+}
+
+// check must be called with p.mu held.
+func (p *Policy) check(caller Caller, since time.Time) error {
+	tier := p.TierFor(caller)
+	keys := quotaKeys(caller)
+	if tier.IPQuota > 0 && p.Store.Count(keys[0], since) >= tier.IPQuota {
+		return ErrQuotaExceeded
+	}
+	if tier.AccountQuota > 0 && p.Store.Count(keys[1], since) >= tier.AccountQuota {
+		return ErrQuotaExceeded
+	}
+	return nil
+}
+
+// quotaKeys returns the quota store keys of the caller's IP address and
+// account, in that order.
+func quotaKeys(caller Caller) []string {
+	return []string{"ip:" + caller.IP, "account:" + caller.Account}
+}
+
+func (p *Policy) now() time.Time {
+	if p.Now != nil {
+		return p.Now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,92 @@
+package internal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPolicy(t *testing.T, now *time.Time) *Policy {
+	store, err := NewQuotaStore("")
+	require.NoError(t, err)
+	return &Policy{
+		Window: time.Hour,
+		DefaultTier: Tier{
+			Name:            "default",
+			StartingBalance: "10000.00",
+			IPQuota:         2,
+			AccountQuota:    1,
+		},
+		AccountTiers: map[string]Tier{
+			"GPARTNER": {
+				Name:            "partners",
+				StartingBalance: "50000.00",
+				AccountQuota:    3,
+			},
+		},
+		Store: store,
+		Now:   func() time.Time { return *now },
+	}
+}
+
+func TestPolicy_TierFor(t *testing.T) {
+	now := time.Now()
+	policy := newTestPolicy(t, &now)
+
+	assert.Equal(t, "default", policy.TierFor(Caller{Account: "GOTHER", Authenticated: true}).Name)
+	assert.Equal(t, "partners", policy.TierFor(Caller{Account: "GPARTNER", Authenticated: true}).Name)
+	// Tiers only apply to authenticated accounts.
+	assert.Equal(t, "default", policy.TierFor(Caller{Account: "GPARTNER"}).Name)
+}
+
+func TestPolicy_Reserve(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	policy := newTestPolicy(t, &now)
+
+	_, err := policy.Reserve(Caller{IP: "1.2.3.4", Account: "GA"})
+	require.NoError(t, err)
+
+	// Account quota exceeded.
+	_, err = policy.Reserve(Caller{IP: "5.6.7.8", Account: "GA"})
+	assert.Equal(t, ErrQuotaExceeded, err)
+
+	now = now.Add(time.Minute)
+	_, err = policy.Reserve(Caller{IP: "1.2.3.4", Account: "GB"})
+	require.NoError(t, err)
+
+	// IP quota exceeded.
+	_, err = policy.Reserve(Caller{IP: "1.2.3.4", Account: "GC"})
+	assert.Equal(t, ErrQuotaExceeded, err)
+
+	// Quotas are restored once the window has passed.
+	now = now.Add(time.Hour)
+	_, err = policy.Reserve(Caller{IP: "1.2.3.4", Account: "GC"})
+	assert.NoError(t, err)
+}
+
+func TestPolicy_Reserve_release(t *testing.T) {
+	now := time.Now()
+	policy := newTestPolicy(t, &now)
+
+	release, err := policy.Reserve(Caller{IP: "1.2.3.4", Account: "GA"})
+	require.NoError(t, err)
+	release()
+
+	_, err = policy.Reserve(Caller{IP: "1.2.3.4", Account: "GA"})
+	assert.NoError(t, err)
+}
+
+func TestPolicy_Reserve_tier(t *testing.T) {
+	now := time.Now()
+	policy := newTestPolicy(t, &now)
+
+	partner := Caller{IP: "1.2.3.4", Account: "GPARTNER", Authenticated: true}
+	for i := 0; i < 3; i++ {
+		_, err := policy.Reserve(partner)
+		require.NoError(t, err)
+	}
+	_, err := policy.Reserve(partner)
+	assert.Equal(t, ErrQuotaExceeded, err)
+}
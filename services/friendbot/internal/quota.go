@@ -0,0 +1,132 @@
+package internal
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/shantanu-hashcash/go/support/errors"
+)
+
+// QuotaStore keeps track of the fundings made to each caller, identified by
+// a key such as its IP address or account. If a path is configured, the
+// fundings are persisted to a JSON file so that quotas survive restarts.
+type QuotaStore struct {
+	path     string
+	mu       sync.Mutex
+	fundings map[string][]time.Time
+}
+
+// NewQuotaStore creates a QuotaStore persisted to path, loading the fundings
+// already recorded in it. If path is empty fundings are only kept in memory.
+func NewQuotaStore(path string) (*QuotaStore, error) {
+	s := &QuotaStore{path: path, fundings: map[string][]time.Time{}}
+	if path == "" {
+		return s, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "reading quota file")
+	}
+	if err = json.Unmarshal(data, &s.fundings); err != nil {
+		return nil, errors.Wrap(err, "decoding quota file")
+	}
+	return s, nil
+}
+
+// Count returns the number of fundings recorded for key since the given time.
+func (s *QuotaStore) Count(key string, since time.Time) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	count := 0
+	for _, t := range s.fundings[key] {
+		if !t.Before(since) {
+			count++
+		}
+	}
+	return count
+}
+
+// Add records a funding for each key at the given time. Fundings older than
+// prune are discarded.
+func (s *QuotaStore) Add(keys []string, at, prune time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, key := range keys {
+		s.fundings[key] = append(s.fundings[key], at)
+	}
+	s.prune(prune)
+	return s.save()
+}
+
+// Remove removes a funding recorded with Add, e.g. because it failed.
+func (s *QuotaStore) Remove(keys []string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, key := range keys {
+		times := s.fundings[key]
+		for i, t := range times {
+			if t.Equal(at) {
+				s.fundings[key] = append(times[:i:i], times[i+1:]...)
+				break
+			}
+		}
+		if len(s.fundings[key]) == 0 {
+			delete(s.fundings, key)
+		}
+	}
+	return s.save()
+}
+
+func (s *QuotaStore) prune(before time.Time) {
+	for key, times := range s.fundings {
+		kept := times[:0]
+		for _, t := range times {
+			if !t.Before(before) {
+				kept = append(kept, t)
+			}
+		}
+		if len(kept) == 0 {
+			delete(s.fundings, key)
+		} else {
+			s.fundings[key] = kept
+		}
+	}
+}
+
+// save writes the fundings to the quota file. The file is replaced
+// atomically so that a crash never leaves a truncated file behind.
+func (s *QuotaStore) save() error {
+	if s.path == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(s.fundings)
+	if err != nil {
+		return errors.Wrap(err, "encoding quotas")
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp")
+	if err != nil {
+		return errors.Wrap(err, "creating temporary quota file")
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "writing temporary quota file")
+	}
+	if err = tmp.Close(); err != nil {
+		return errors.Wrap(err, "closing temporary quota file")
+	}
+	return errors.Wrap(os.Rename(tmp.Name(), s.path), "replacing quota file")
+}
@@ -0,0 +1,55 @@
+package internal
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuotaStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "quotas.json")
+	store, err := NewQuotaStore(path)
+	require.NoError(t, err)
+
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Hour)
+	require.NoError(t, store.Add([]string{"ip:1.2.3.4", "account:a"}, t0, t0))
+	require.NoError(t, store.Add([]string{"ip:1.2.3.4", "account:b"}, t1, t0))
+
+	assert.Equal(t, 2, store.Count("ip:1.2.3.4", t0))
+	assert.Equal(t, 1, store.Count("ip:1.2.3.4", t1))
+	assert.Equal(t, 1, store.Count("account:a", t0))
+	assert.Equal(t, 0, store.Count("account:c", t0))
+
+	// Fundings are persisted.
+	reloaded, err := NewQuotaStore(path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, reloaded.Count("ip:1.2.3.4", t0))
+
+	// Removing a funding only removes that funding.
+	require.NoError(t, store.Remove([]string{"ip:1.2.3.4", "account:b"}, t1))
+	assert.Equal(t, 1, store.Count("ip:1.2.3.4", t0))
+	assert.Equal(t, 0, store.Count("account:b", t0))
+
+	// Old fundings are pruned.
+	t2 := t1.Add(time.Hour)
+	require.NoError(t, store.Add([]string{"account:c"}, t2, t1))
+	assert.Equal(t, 0, store.Count("ip:1.2.3.4", t0))
+	assert.Equal(t, 0, store.Count("account:a", t0))
+
+	reloaded, err = NewQuotaStore(path)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]time.Time{"account:c": {t2}}, reloaded.fundings)
+}
+
+func TestQuotaStore_inMemory(t *testing.T) {
+	store, err := NewQuotaStore("")
+	require.NoError(t, err)
+
+	now := time.Now()
+	require.NoError(t, store.Add([]string{"ip:1.2.3.4"}, now, now.Add(-time.Hour)))
+	assert.Equal(t, 1, store.Count("ip:1.2.3.4", now.Add(-time.Hour)))
+}
@@ -0,0 +1,391 @@
+package internal
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/shantanu-hashcash/go/amount"
+	"github.com/shantanu-hashcash/go/clients/auroraclient"
+	"github.com/shantanu-hashcash/go/keypair"
+	"github.com/shantanu-hashcash/go/support/errors"
+	"github.com/shantanu-hashcash/go/txnbuild"
+)
+
+const (
+	// maxOpsPerTx is the maximum number of operations in a transaction.
+	maxOpsPerTx = 100
+	// maxMergesPerTx keeps the number of signatures of a transaction, one per
+	// merged minion plus the bot's, under the network limit of 20.
+	maxMergesPerTx = 19
+)
+
+// PoolHealth describes the state of the minion pool after a Supervisor check.
+type PoolHealth struct {
+	CheckedAt time.Time
+	// Minions is the number of minions funding accounts.
+	Minions int
+	// Failing is the number of minions whose last request failed because of
+	// the state of their account.
+	Failing int
+	// Stalled is the number of minions that were handed requests during the
+	// last interval but whose sequence number did not advance.
+	Stalled int
+	// Requests is the number of requests made during the last interval.
+	Requests int
+	// Totals since the supervisor started.
+	Created  int
+	ToppedUp int
+	Retired  int
+	// CheckErrors is the number of checks that failed.
+	CheckErrors int
+}
+
+// Supervisor keeps the minion pool of a Bot healthy. On every check it tops
+// up minions running low on balance, retires minions that keep failing or no
+// longer exist, monitors the sequence numbers of the minions, and grows or
+// shrinks the pool with the request load. Retired minions are merged back
+// into the bot account one check after being removed from the pool, so that
+// no in-flight request still uses them.
+type Supervisor struct {
+	Bot        *Bot
+	Aurora     auroraclient.ClientInterface
+	BotAccount *Account
+	BotKeypair *keypair.Full
+	Network    string
+	BaseFee    int64
+
+	// MinionBalance is the balance new minions are created with and minions
+	// are topped up to.
+	MinionBalance string
+	// LowBalance is the balance under which minions are topped up.
+	LowBalance string
+	// MaxFailures is the number of consecutive failed requests after which a
+	// minion is retired.
+	MaxFailures int
+	// MinMinions and MaxMinions bound the size of the pool.
+	MinMinions int
+	MaxMinions int
+	// RequestsPerMinion is the number of requests per interval a minion is
+	// expected to serve, used to size the pool.
+	RequestsPerMinion int
+	Interval          time.Duration
+
+	// NewMinion returns a minion for a new channel account with the given
+	// keypair.
+	NewMinion func(kp *keypair.Full) Minion
+
+	// checkMu serializes checks and guards the state they keep between
+	// each other. It is held during calls to Aurora, unlike mu which only
+	// guards health so that Health never waits on Aurora.
+	checkMu   sync.Mutex
+	retiring  []Minion
+	sequences map[string]int64
+
+	mu     sync.Mutex
+	health PoolHealth
+}
+
+// Run checks the minion pool every interval until ctx is done.
+func (s *Supervisor) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Check(); err != nil {
+				log.Printf("Checking minion pool: %v", err)
+			}
+		}
+	}
+}
+
+// Health returns the state of the minion pool after the last check.
+func (s *Supervisor) Health() PoolHealth {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.health
+}
+
+// Check checks the minion pool once.
+func (s *Supervisor) Check() error {
+	s.checkMu.Lock()
+	defer s.checkMu.Unlock()
+
+	var c check
+	err := s.check(&c)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c.checked {
+		s.health.CheckedAt = time.Now()
+		s.health.Minions = c.minions
+		s.health.Failing = c.failing
+		s.health.Stalled = c.stalled
+		s.health.Requests = c.numRequests
+	}
+	s.health.Created += c.created
+	s.health.ToppedUp += c.toppedUp
+	s.health.Retired += c.retired
+	if err != nil {
+		s.health.CheckErrors++
+	}
+	return err
+}
+
+// check is the outcome of a Supervisor check, reported in PoolHealth. The
+// state of the pool is only reported if every minion was checked.
+type check struct {
+	checked     bool
+	minions     int
+	failing     int
+	stalled     int
+	numRequests int
+	created     int
+	toppedUp    int
+	retired     int
+}
+
+func (s *Supervisor) check(c *check) error {
+	status := s.Bot.status()
+	lowBalance := amount.MustParse(s.LowBalance)
+	minionBalance := amount.MustParse(s.MinionBalance)
+
+	var (
+		ops        []txnbuild.Operation
+		signers    = []*keypair.Full{s.BotKeypair}
+		toppedUp   int
+		newMinions []Minion
+		healthy    []Minion
+	)
+
+	// Merge the minions retired by the previous check. If the transaction
+	// fails they stay in s.retiring and are merged by the next check.
+	var merging []Minion
+	for _, minion := range s.retiring {
+		if len(merging) == maxMergesPerTx {
+			break
+		}
+		_, err := s.Aurora.AccountDetail(auroraclient.AccountRequest{AccountID: minion.Account.AccountID})
+		if auroraclient.IsNotFoundError(err) {
+			// Already merged, e.g. by a check whose response was lost.
+			s.retiring = removeMinion(s.retiring, minion.Account.AccountID)
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "getting retired minion %s account detail", minion.Account.AccountID)
+		}
+		merging = append(merging, minion)
+	}
+	for _, minion := range merging {
+		ops = append(ops, &txnbuild.AccountMerge{
+			Destination:   s.BotAccount.AccountID,
+			SourceAccount: minion.Account.AccountID,
+		})
+		signers = append(signers, minion.Keypair)
+	}
+
+	var stalled int
+	if s.sequences == nil {
+		s.sequences = map[string]int64{}
+	}
+	for _, minion := range status.Minions {
+		accountID := minion.Account.AccountID
+		detail, err := s.Aurora.AccountDetail(auroraclient.AccountRequest{AccountID: accountID})
+		if auroraclient.IsNotFoundError(err) {
+			// There is nothing left to merge.
+			log.Printf("Retiring minion %s, its account does not exist", accountID)
+			if _, ok := s.Bot.RemoveMinion(accountID); ok {
+				c.retired++
+			}
+			delete(s.sequences, accountID)
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "getting minion %s account detail", accountID)
+		}
+
+		if s.MaxFailures > 0 && status.Failures[accountID] >= s.MaxFailures {
+			log.Printf("Retiring minion %s after %d consecutive failures", accountID, status.Failures[accountID])
+			s.retire(c, accountID)
+			continue
+		}
+		healthy = append(healthy, minion)
+
+		// Every request handed to a minion submits a transaction, so its
+		// sequence number must advance unless all of them failed.
+		if previous, ok := s.sequences[accountID]; ok && status.Requests[accountID] > 0 && detail.Sequence <= previous {
+			log.Printf("Minion %s handled %d requests but its sequence number is still %d", accountID, status.Requests[accountID], detail.Sequence)
+			stalled++
+		}
+		s.sequences[accountID] = detail.Sequence
+
+		balance, err := detail.GetNativeBalance()
+		if err != nil {
+			return errors.Wrapf(err, "getting minion %s balance", accountID)
+		}
+		b, err := amount.ParseInt64(balance)
+		if err != nil {
+			return errors.Wrapf(err, "parsing minion %s balance", accountID)
+		}
+		if b < int64(lowBalance) && len(ops) < maxOpsPerTx {
+			ops = append(ops, &txnbuild.Payment{
+				Destination: accountID,
+				Amount:      amount.StringFromInt64(int64(minionBalance) - b),
+				Asset:       txnbuild.NativeAsset{},
+			})
+			toppedUp++
+		}
+	}
+
+	desired := s.desiredMinions(status.NumRequests)
+	switch {
+	case len(healthy) < desired:
+		for i := len(healthy); i < desired && len(ops) < maxOpsPerTx; i++ {
+			minion := s.NewMinion(keypair.MustRandom())
+			ops = append(ops, &txnbuild.CreateAccount{
+				Destination: minion.Account.AccountID,
+				Amount:      s.MinionBalance,
+			})
+			newMinions = append(newMinions, minion)
+		}
+	case len(healthy) > desired:
+		// Shrink gradually, load is bursty.
+		excess := len(healthy) - desired
+		if max := len(healthy)/10 + 1; excess > max {
+			excess = max
+		}
+		for _, minion := range healthy[len(healthy)-excess:] {
+			s.retire(c, minion.Account.AccountID)
+		}
+		healthy = healthy[:len(healthy)-excess]
+	}
+
+	report := func(minions []Minion) {
+		c.checked = true
+		c.minions = len(minions)
+		c.stalled = stalled
+		c.numRequests = status.NumRequests
+		for _, minion := range minions {
+			if status.Failures[minion.Account.AccountID] > 0 {
+				c.failing++
+			}
+		}
+	}
+	if len(ops) == 0 {
+		report(healthy)
+		return nil
+	}
+	if err := s.submit(ops, signers); err != nil {
+		report(healthy)
+		return err
+	}
+
+	for _, minion := range merging {
+		s.retiring = removeMinion(s.retiring, minion.Account.AccountID)
+	}
+	s.Bot.AddMinions(newMinions...)
+	c.created += len(newMinions)
+	c.toppedUp += toppedUp
+	report(append(healthy, newMinions...))
+	return nil
+}
+
+func removeMinion(minions []Minion, accountID string) []Minion {
+	for i, minion := range minions {
+		if minion.Account.AccountID == accountID {
+			return append(minions[:i:i], minions[i+1:]...)
+		}
+	}
+	return minions
+}
+
+// retire removes a minion from the pool, it is merged into the bot account
+// by the next check.
+func (s *Supervisor) retire(c *check, accountID string) {
+	if minion, ok := s.Bot.RemoveMinion(accountID); ok {
+		s.retiring = append(s.retiring, minion)
+		c.retired++
+	}
+	delete(s.sequences, accountID)
+}
+
+// desiredMinions returns the size of the pool needed to serve numRequests
+// per interval.
+func (s *Supervisor) desiredMinions(numRequests int) int {
+	desired := s.MinMinions
+	if s.RequestsPerMinion > 0 {
+		if n := (numRequests + s.RequestsPerMinion - 1) / s.RequestsPerMinion; n > desired {
+			desired = n
+		}
+	}
+	if s.MaxMinions > 0 && desired > s.MaxMinions {
+		desired = s.MaxMinions
+	}
+	return desired
+}
+
+func (s *Supervisor) submit(ops []txnbuild.Operation, signers []*keypair.Full) error {
+	if err := s.BotAccount.RefreshSequenceNumber(s.Aurora); err != nil {
+		return errors.Wrap(err, "refreshing bot seqnum")
+	}
+	tx, err := txnbuild.NewTransaction(
+		txnbuild.TransactionParams{
+			SourceAccount:        s.BotAccount,
+			IncrementSequenceNum: true,
+			Operations:           ops,
+			BaseFee:              s.BaseFee,
+			Preconditions:        txnbuild.Preconditions{TimeBounds: txnbuild.NewTimeout(300)},
+		},
+	)
+	if err != nil {
+		return errors.Wrap(err, "unable to build tx")
+	}
+
+	tx, err = tx.Sign(s.Network, signers...)
+	if err != nil {
+		return errors.Wrap(err, "unable to sign tx")
+	}
+
+	txe, err := tx.Base64()
+	if err != nil {
+		return errors.Wrap(err, "unable to serialize tx")
+	}
+
+	if _, err = s.Aurora.SubmitTransactionXDR(txe); err != nil {
+		return errors.Wrap(err, "submitting minion pool tx")
+	}
+	return nil
+}
+
+// Collectors returns the prometheus collectors reporting the health of the
+// minion pool.
+func (s *Supervisor) Collectors() []prometheus.Collector {
+	gauge := func(name, help string, value func(h PoolHealth) int) prometheus.Collector {
+		return prometheus.NewGaugeFunc(
+			prometheus.GaugeOpts{Namespace: "friendbot", Subsystem: "minions", Name: name, Help: help},
+			func() float64 { return float64(value(s.Health())) },
+		)
+	}
+	counter := func(name, help string, value func(h PoolHealth) int) prometheus.Collector {
+		return prometheus.NewCounterFunc(
+			prometheus.CounterOpts{Namespace: "friendbot", Subsystem: "minions", Name: name, Help: help},
+			func() float64 { return float64(value(s.Health())) },
+		)
+	}
+	return []prometheus.Collector{
+		gauge("pool_size", "number of minions funding accounts", func(h PoolHealth) int { return h.Minions }),
+		gauge("failing", "number of minions whose last request failed because of their account", func(h PoolHealth) int { return h.Failing }),
+		gauge("stalled", "number of minions handed requests whose sequence number did not advance", func(h PoolHealth) int { return h.Stalled }),
+		gauge("requests", "number of funding requests during the last check interval", func(h PoolHealth) int { return h.Requests }),
+		gauge("last_check_timestamp_seconds", "time of the last minion pool check", func(h PoolHealth) int { return int(h.CheckedAt.Unix()) }),
+		counter("created_total", "number of minions created", func(h PoolHealth) int { return h.Created }),
+		counter("topped_up_total", "number of minion top ups", func(h PoolHealth) int { return h.ToppedUp }),
+		counter("retired_total", "number of minions retired", func(h PoolHealth) int { return h.Retired }),
+		counter("check_errors_total", "number of failed minion pool checks", func(h PoolHealth) int { return h.CheckErrors }),
+	}
+}
@@ -0,0 +1,306 @@
+package internal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shantanu-hashcash/go/clients/auroraclient"
+	"github.com/shantanu-hashcash/go/keypair"
+	hProtocol "github.com/shantanu-hashcash/go/protocols/aurora"
+	"github.com/shantanu-hashcash/go/protocols/aurora/base"
+	"github.com/shantanu-hashcash/go/support/errors"
+	"github.com/shantanu-hashcash/go/support/render/problem"
+	"github.com/shantanu-hashcash/go/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var notFoundErr = &auroraclient.Error{
+	Problem: problem.P{Type: "https://hcnet.org/aurora-errors/not_found", Status: 404},
+}
+
+type supervisorTest struct {
+	supervisor *Supervisor
+	hclient    *auroraclient.MockClient
+	submitted  []*txnbuild.Transaction
+}
+
+func newSupervisorTest(t *testing.T, numMinions int) *supervisorTest {
+	st := &supervisorTest{hclient: &auroraclient.MockClient{}}
+	botKeypair := keypair.MustRandom()
+	newMinion := func(kp *keypair.Full) Minion {
+		return Minion{
+			Account:    Account{AccountID: kp.Address()},
+			Keypair:    kp,
+			BotAccount: Account{AccountID: botKeypair.Address()},
+			BotKeypair: botKeypair,
+			Network:    testNetwork,
+		}
+	}
+
+	bot := &Bot{}
+	for i := 0; i < numMinions; i++ {
+		bot.AddMinions(newMinion(keypair.MustRandom()))
+	}
+
+	st.supervisor = &Supervisor{
+		Bot:               bot,
+		Aurora:            st.hclient,
+		BotAccount:        &Account{AccountID: botKeypair.Address()},
+		BotKeypair:        botKeypair,
+		Network:           testNetwork,
+		BaseFee:           txnbuild.MinBaseFee,
+		MinionBalance:     "101",
+		LowBalance:        "20",
+		MaxFailures:       3,
+		MinMinions:        numMinions,
+		MaxMinions:        numMinions + 5,
+		RequestsPerMinion: 10,
+		Interval:          time.Minute,
+		NewMinion:         newMinion,
+	}
+
+	st.hclient.On("AccountDetail", auroraclient.AccountRequest{AccountID: botKeypair.Address()}).
+		Return(hProtocol.Account{AccountID: botKeypair.Address(), Sequence: 100}, nil)
+	st.hclient.On("SubmitTransactionXDR", mock.Anything).
+		Run(func(args mock.Arguments) {
+			parsed, err := txnbuild.TransactionFromXDR(args.String(0))
+			require.NoError(t, err)
+			tx, ok := parsed.Transaction()
+			require.True(t, ok)
+			st.submitted = append(st.submitted, tx)
+		}).
+		Return(hProtocol.Transaction{Successful: true}, nil)
+	return st
+}
+
+func (st *supervisorTest) setBalance(accountID, balance string) {
+	st.hclient.On("AccountDetail", auroraclient.AccountRequest{AccountID: accountID}).
+		Return(hProtocol.Account{
+			AccountID: accountID,
+			Balances:  []hProtocol.Balance{{Balance: balance, Asset: base.Asset{Type: "native"}}},
+		}, nil)
+}
+
+func (st *supervisorTest) minionIDs() []string {
+	var ids []string
+	for _, minion := range st.supervisor.Bot.Minions {
+		ids = append(ids, minion.Account.AccountID)
+	}
+	return ids
+}
+
+func TestSupervisor_topUp(t *testing.T) {
+	st := newSupervisorTest(t, 2)
+	ids := st.minionIDs()
+	st.setBalance(ids[0], "101.0000000")
+	st.setBalance(ids[1], "5.5000000")
+
+	require.NoError(t, st.supervisor.Check())
+	require.Len(t, st.submitted, 1)
+	ops := st.submitted[0].Operations()
+	require.Len(t, ops, 1)
+	payment := ops[0].(*txnbuild.Payment)
+	assert.Equal(t, ids[1], payment.Destination)
+	assert.Equal(t, "95.5000000", payment.Amount)
+
+	health := st.supervisor.Health()
+	assert.Equal(t, 2, health.Minions)
+	assert.Equal(t, 1, health.ToppedUp)
+}
+
+func TestSupervisor_healthyPool(t *testing.T) {
+	st := newSupervisorTest(t, 2)
+	for _, id := range st.minionIDs() {
+		st.setBalance(id, "101.0000000")
+	}
+
+	require.NoError(t, st.supervisor.Check())
+	assert.Empty(t, st.submitted)
+}
+
+func TestSupervisor_retireFailingMinion(t *testing.T) {
+	st := newSupervisorTest(t, 2)
+	ids := st.minionIDs()
+	for _, id := range ids {
+		st.setBalance(id, "101.0000000")
+	}
+	for i := 0; i < 3; i++ {
+		st.supervisor.Bot.recordResult(ids[0], errors.Wrap(ErrMinionFailure, "submitting tx"))
+	}
+	st.supervisor.Bot.recordResult(ids[1], errors.Wrap(ErrAccountExists, "submitting tx"))
+	// Errors not caused by the minion account are not failures.
+	for i := 0; i < 3; i++ {
+		st.supervisor.Bot.recordResult(ids[1], errors.New("submitting tx: timeout"))
+	}
+
+	// The failing minion is replaced.
+	require.NoError(t, st.supervisor.Check())
+	require.Len(t, st.submitted, 1)
+	ops := st.submitted[0].Operations()
+	require.Len(t, ops, 1)
+	created := ops[0].(*txnbuild.CreateAccount)
+	assert.Equal(t, "101.0000000", created.Amount)
+	assert.Equal(t, []string{ids[1], created.Destination}, st.minionIDs())
+
+	// And merged into the bot account by the next check.
+	st.setBalance(created.Destination, "101.0000000")
+	require.NoError(t, st.supervisor.Check())
+	require.Len(t, st.submitted, 2)
+	ops = st.submitted[1].Operations()
+	require.Len(t, ops, 1)
+	merge := ops[0].(*txnbuild.AccountMerge)
+	assert.Equal(t, ids[0], merge.SourceAccount)
+	assert.Equal(t, st.supervisor.BotAccount.AccountID, merge.Destination)
+	assert.Len(t, st.submitted[1].Signatures(), 2)
+
+	require.NoError(t, st.supervisor.Check())
+	assert.Len(t, st.submitted, 2)
+
+	health := st.supervisor.Health()
+	assert.Equal(t, 2, health.Minions)
+	assert.Equal(t, 1, health.Created)
+	assert.Equal(t, 1, health.Retired)
+}
+
+func TestSupervisor_stalledMinion(t *testing.T) {
+	st := newSupervisorTest(t, 2)
+	ids := st.minionIDs()
+	setSequence := func(accountID string, seq int64) {
+		st.hclient.On("AccountDetail", auroraclient.AccountRequest{AccountID: accountID}).
+			Return(hProtocol.Account{
+				AccountID: accountID,
+				Sequence:  seq,
+				Balances:  []hProtocol.Balance{{Balance: "101.0000000", Asset: base.Asset{Type: "native"}}},
+			}, nil).Once()
+	}
+
+	setSequence(ids[0], 10)
+	setSequence(ids[1], 20)
+	require.NoError(t, st.supervisor.Check())
+	assert.Equal(t, 0, st.supervisor.Health().Stalled)
+
+	// Both minions are handed requests, only the first one submits them.
+	st.supervisor.Bot.minionRequests = map[string]int{ids[0]: 2, ids[1]: 3}
+	setSequence(ids[0], 12)
+	setSequence(ids[1], 20)
+	require.NoError(t, st.supervisor.Check())
+	assert.Equal(t, 1, st.supervisor.Health().Stalled)
+
+	// Minions without requests are not stalled.
+	setSequence(ids[0], 12)
+	setSequence(ids[1], 20)
+	require.NoError(t, st.supervisor.Check())
+	assert.Equal(t, 0, st.supervisor.Health().Stalled)
+}
+
+func TestSupervisor_healthDuringCheck(t *testing.T) {
+	st := newSupervisorTest(t, 1)
+	id := st.minionIDs()[0]
+	st.hclient.On("AccountDetail", auroraclient.AccountRequest{AccountID: id}).
+		Run(func(mock.Arguments) {
+			done := make(chan struct{})
+			go func() {
+				st.supervisor.Health()
+				close(done)
+			}()
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+				t.Error("Health blocked while the supervisor called Aurora")
+			}
+		}).
+		Return(hProtocol.Account{
+			AccountID: id,
+			Balances:  []hProtocol.Balance{{Balance: "101.0000000", Asset: base.Asset{Type: "native"}}},
+		}, nil)
+
+	require.NoError(t, st.supervisor.Check())
+}
+
+func TestSupervisor_missingMinion(t *testing.T) {
+	st := newSupervisorTest(t, 2)
+	ids := st.minionIDs()
+	st.setBalance(ids[0], "101.0000000")
+	st.hclient.On("AccountDetail", auroraclient.AccountRequest{AccountID: ids[1]}).
+		Return(hProtocol.Account{}, notFoundErr)
+
+	require.NoError(t, st.supervisor.Check())
+	require.Len(t, st.submitted, 1)
+	ops := st.submitted[0].Operations()
+	require.Len(t, ops, 1)
+	assert.IsType(t, &txnbuild.CreateAccount{}, ops[0])
+	assert.NotContains(t, st.minionIDs(), ids[1])
+	assert.Empty(t, st.supervisor.retiring)
+}
+
+func TestSupervisor_scaling(t *testing.T) {
+	st := newSupervisorTest(t, 2)
+	for _, id := range st.minionIDs() {
+		st.setBalance(id, "101.0000000")
+	}
+
+	// 45 requests need 5 minions.
+	st.supervisor.Bot.numRequests = 45
+	require.NoError(t, st.supervisor.Check())
+	require.Len(t, st.submitted, 1)
+	assert.Len(t, st.submitted[0].Operations(), 3)
+	assert.Len(t, st.minionIDs(), 5)
+	assert.Equal(t, 45, st.supervisor.Health().Requests)
+
+	// The pool is bounded by MaxMinions.
+	for _, id := range st.minionIDs() {
+		st.setBalance(id, "101.0000000")
+	}
+	st.supervisor.Bot.numRequests = 1000
+	require.NoError(t, st.supervisor.Check())
+	assert.Len(t, st.minionIDs(), 7)
+
+	// Without load the pool shrinks back to MinMinions.
+	for _, id := range st.minionIDs() {
+		st.setBalance(id, "101.0000000")
+	}
+	for i := 0; i < 10; i++ {
+		require.NoError(t, st.supervisor.Check())
+	}
+	assert.Len(t, st.minionIDs(), 2)
+	assert.Empty(t, st.supervisor.retiring)
+	assert.Equal(t, 5, st.supervisor.Health().Retired)
+}
+
+func TestSupervisor_submitFailure(t *testing.T) {
+	st := newSupervisorTest(t, 1)
+	st.hclient.ExpectedCalls = nil
+	st.hclient.On("AccountDetail", auroraclient.AccountRequest{AccountID: st.supervisor.BotAccount.AccountID}).
+		Return(hProtocol.Account{AccountID: st.supervisor.BotAccount.AccountID, Sequence: 100}, nil)
+	st.hclient.On("SubmitTransactionXDR", mock.Anything).
+		Return(hProtocol.Transaction{}, errors.New("timeout"))
+	st.setBalance(st.minionIDs()[0], "101.0000000")
+
+	st.supervisor.Bot.numRequests = 30
+	assert.EqualError(t, st.supervisor.Check(), "submitting minion pool tx: timeout")
+	assert.Len(t, st.minionIDs(), 1)
+	assert.Equal(t, 1, st.supervisor.Health().CheckErrors)
+	assert.Equal(t, 0, st.supervisor.Health().Created)
+}
+
+func TestBot_RemoveMinion(t *testing.T) {
+	st := newSupervisorTest(t, 3)
+	ids := st.minionIDs()
+	bot := st.supervisor.Bot
+	bot.nextMinionIndex = 2
+
+	_, ok := bot.RemoveMinion(ids[0])
+	assert.True(t, ok)
+	assert.Equal(t, []string{ids[1], ids[2]}, st.minionIDs())
+	assert.Equal(t, 1, bot.nextMinionIndex)
+
+	_, ok = bot.RemoveMinion(ids[0])
+	assert.False(t, ok)
+}
+
+func TestBot_Pay_noMinions(t *testing.T) {
+	_, err := (&Bot{}).Pay(keypair.MustRandom().Address())
+	assert.Equal(t, ErrNoMinions, err)
+}
@@ -1,12 +1,15 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	stdhttp "net/http"
 	"os"
 
 	"github.com/go-chi/chi"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/cobra"
 	"github.com/shantanu-hashcash/go/services/friendbot/internal"
 	"github.com/shantanu-hashcash/go/support/app"
@@ -29,6 +32,59 @@ type Config struct {
 	BaseFee                int64       `toml:"base_fee" valid:"optional"`
 	MinionBatchSize        int         `toml:"minion_batch_size" valid:"optional"`
 	SubmitTxRetriesAllowed int         `toml:"submit_tx_retries_allowed" valid:"optional"`
+	// QuotaWindow is the number of seconds funding quotas apply to.
+	QuotaWindow        int           `toml:"quota_window" valid:"optional"`
+	IPQuota            int           `toml:"ip_quota" valid:"optional"`
+	AccountQuota       int           `toml:"account_quota" valid:"optional"`
+	QuotaFile          string        `toml:"quota_file" valid:"optional"`
+	TrustXForwardedFor bool          `toml:"trust_x_forwarded_for" valid:"optional"`
+	SEP10              *SEP10Config  `toml:"sep10" valid:"optional"`
+	Tiers              []TierConfig  `toml:"tiers" valid:"optional"`
+	Assets             []AssetConfig `toml:"assets" valid:"optional"`
+	// AdminPort serves the /metrics endpoint, 0 disables it.
+	AdminPort  int               `toml:"admin_port" valid:"optional"`
+	Supervisor *SupervisorConfig `toml:"supervisor" valid:"optional"`
+}
+
+// SupervisorConfig configures the supervision of the minion pool.
+type SupervisorConfig struct {
+	// Interval is the number of seconds between checks of the minion pool.
+	Interval          int    `toml:"interval" valid:"required"`
+	MinMinions        int    `toml:"min_minions" valid:"optional"`
+	MaxMinions        int    `toml:"max_minions" valid:"optional"`
+	RequestsPerMinion int    `toml:"requests_per_minion" valid:"optional"`
+	LowBalance        string `toml:"low_balance" valid:"hcnet_amount,optional"`
+	MaxFailures       int    `toml:"max_failures" valid:"optional"`
+}
+
+// SEP10Config configures SEP-10 authentication of funding requests.
+type SEP10Config struct {
+	SigningSecret string `toml:"signing_secret" valid:"hcnet_seed,required"`
+	WebAuthDomain string `toml:"web_auth_domain" valid:"required"`
+	HomeDomain    string `toml:"home_domain" valid:"required"`
+	// Required rejects funding requests that are not authenticated.
+	Required bool `toml:"required" valid:"optional"`
+	// ChallengeTimeout is the number of seconds challenges are valid for.
+	ChallengeTimeout int `toml:"challenge_timeout" valid:"optional"`
+}
+
+// TierConfig configures the funding amounts and quotas of SEP-10
+// authenticated accounts.
+type TierConfig struct {
+	Name            string            `toml:"name" valid:"required"`
+	Accounts        []string          `toml:"accounts" valid:"required"`
+	StartingBalance string            `toml:"starting_balance" valid:"optional"`
+	IPQuota         int               `toml:"ip_quota" valid:"optional"`
+	AccountQuota    int               `toml:"account_quota" valid:"optional"`
+	AssetAmounts    map[string]string `toml:"asset_amounts" valid:"optional"`
+}
+
+// AssetConfig configures a custom asset funded by friendbot.
+type AssetConfig struct {
+	Code               string `toml:"code" valid:"required"`
+	Issuer             string `toml:"issuer" valid:"hcnet_accountid,required"`
+	Amount             string `toml:"amount" valid:"hcnet_amount,required"`
+	DistributionSecret string `toml:"distribution_secret" valid:"hcnet_seed,required"`
 }
 
 func main() {
@@ -68,9 +124,30 @@ func run(cmd *cobra.Command, args []string) {
 		log.Error(err)
 		os.Exit(1)
 	}
-	router := initRouter(fb)
+	handler, err := initHandler(cfg, fb)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+	router := initRouter(cfg, handler)
 	registerProblems()
 
+	metricsRegistry := prometheus.NewRegistry()
+	if cfg.Supervisor != nil {
+		supervisor, err := initSupervisor(cfg, fb)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+		for _, collector := range supervisor.Collectors() {
+			metricsRegistry.MustRegister(collector)
+		}
+		go supervisor.Run(context.Background())
+	}
+	if cfg.AdminPort != 0 {
+		go serveAdmin(cfg.AdminPort, metricsRegistry)
+	}
+
 	addr := fmt.Sprintf("0.0.0.0:%d", cfg.Port)
 
 	http.Run(http.Config{
@@ -84,12 +161,16 @@ func run(cmd *cobra.Command, args []string) {
 	})
 }
 
-func initRouter(fb *internal.Bot) *chi.Mux {
+func initRouter(cfg Config, handler *internal.FriendbotHandler) *chi.Mux {
 	mux := http.NewAPIMux(log.DefaultLogger)
+	if cfg.TrustXForwardedFor {
+		mux.Use(http.XFFMiddleware(http.XFFMiddlewareConfig{}))
+	}
 
-	handler := &internal.FriendbotHandler{Friendbot: fb}
 	mux.Get("/", handler.Handle)
 	mux.Post("/", handler.Handle)
+	mux.Post("/submit", handler.HandleSubmit)
+	mux.Get("/auth", handler.HandleChallenge)
 	mux.NotFound(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
 		problem.Render(r.Context(), w, problem.NotFound)
 	}))
@@ -97,10 +178,41 @@ func initRouter(fb *internal.Bot) *chi.Mux {
 	return mux
 }
 
+func serveAdmin(port int, gatherer prometheus.Gatherer) {
+	mux := http.NewMux(log.DefaultLogger)
+	mux.Handle("/metrics", promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{}))
+
+	addr := fmt.Sprintf("0.0.0.0:%d", port)
+	http.Run(http.Config{
+		ListenAddr: addr,
+		Handler:    mux,
+		OnStarting: func() {
+			log.Infof("starting admin server on %s", addr)
+		},
+	})
+}
+
 func registerProblems() {
 	problem.RegisterError(sql.ErrNoRows, problem.NotFound)
 
 	accountExistsProblem := problem.BadRequest
 	accountExistsProblem.Detail = internal.ErrAccountExists.Error()
 	problem.RegisterError(internal.ErrAccountExists, accountExistsProblem)
+
+	problem.RegisterError(internal.ErrQuotaExceeded, problem.P{
+		Type:   "quota_exceeded",
+		Title:  "Too Many Requests",
+		Status: stdhttp.StatusTooManyRequests,
+		Detail: internal.ErrQuotaExceeded.Error(),
+	})
+	problem.RegisterError(internal.ErrUnauthorized, problem.P{
+		Type:   "unauthorized",
+		Title:  "Unauthorized",
+		Status: stdhttp.StatusUnauthorized,
+		Detail: internal.ErrUnauthorized.Error(),
+	})
+
+	assetNotFoundProblem := problem.BadRequest
+	assetNotFoundProblem.Detail = internal.ErrAssetNotFound.Error()
+	problem.RegisterError(internal.ErrAssetNotFound, assetNotFoundProblem)
 }
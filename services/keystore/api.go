@@ -30,6 +30,7 @@ func init() {
 
 func (s *Service) wrapMiddleware(handler http.Handler) http.Handler {
 	handler = authHandler(handler, s.authenticator)
+	handler = clientInfoHandler(handler)
 	handler = recoverHandler(handler)
 	handler = corsHandler(handler)
 	return handler
@@ -38,6 +39,8 @@ func (s *Service) wrapMiddleware(handler http.Handler) http.Handler {
 func ServeMux(s *Service) http.Handler {
 	mux := http.NewServeMux()
 	mux.Handle("/keys", s.wrapMiddleware(s.keysHTTPMethodHandler()))
+	mux.Handle("/keys/history", s.wrapMiddleware(s.keysHistoryHTTPMethodHandler()))
+	mux.Handle("/keys/rotation", s.wrapMiddleware(s.keysRotationHTTPMethodHandler()))
 	mux.Handle("/health", s.wrapMiddleware(health.PassHandler{}))
 	return mux
 }
@@ -60,6 +63,36 @@ func (s *Service) keysHTTPMethodHandler() http.Handler {
 	})
 }
 
+func (s *Service) keysHistoryHTTPMethodHandler() http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet:
+			jsonHandler(s.getKeysHistory).ServeHTTP(rw, req)
+
+		default:
+			problem.Render(req.Context(), rw, probMethodNotAllowed)
+		}
+	})
+}
+
+func (s *Service) keysRotationHTTPMethodHandler() http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodPost:
+			jsonHandler(s.startRotation).ServeHTTP(rw, req)
+
+		case http.MethodPut:
+			jsonHandler(s.completeRotation).ServeHTTP(rw, req)
+
+		case http.MethodDelete:
+			jsonHandler(s.abortRotation).ServeHTTP(rw, req)
+
+		default:
+			problem.Render(req.Context(), rw, probMethodNotAllowed)
+		}
+	})
+}
+
 type authResponse struct {
 	UserID string `json:"userID"`
 }
@@ -149,6 +182,18 @@ func authHandler(next http.Handler, authenticator *Authenticator) http.Handler {
 	})
 }
 
+// clientInfoHandler records the client of the request in the context, for
+// the keys blob history.
+func clientInfoHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ci := clientInfo{UserAgent: req.UserAgent()}
+		if ip, _, err := net.SplitHostPort(req.RemoteAddr); err == nil {
+			ci.IP = ip
+		}
+		next.ServeHTTP(rw, req.WithContext(withClientInfo(req.Context(), ci)))
+	})
+}
+
 func jsonHandler(f interface{}) http.Handler {
 	h, err := httpjson.ReqBodyHandler(f, httpjson.JSON)
 	if err != nil {
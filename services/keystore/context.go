@@ -4,7 +4,16 @@ import "context"
 
 type contextKey int
 
-const userKey contextKey = iota
+const (
+	userKey contextKey = iota
+	clientInfoKey
+)
+
+// clientInfo identifies the client of a request in the keys blob history.
+type clientInfo struct {
+	IP        string
+	UserAgent string
+}
 
 func userID(ctx context.Context) string {
 	uid, _ := ctx.Value(userKey).(string)
@@ -14,3 +23,12 @@ func userID(ctx context.Context) string {
 func withUserID(ctx context.Context, userID string) context.Context {
 	return context.WithValue(ctx, userKey, userID)
 }
+
+func clientInfoFromContext(ctx context.Context) clientInfo {
+	ci, _ := ctx.Value(clientInfoKey).(clientInfo)
+	return ci
+}
+
+func withClientInfo(ctx context.Context, ci clientInfo) context.Context {
+	return context.WithValue(ctx, clientInfoKey, ci)
+}
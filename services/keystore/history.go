@@ -0,0 +1,75 @@
+package keystore
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/shantanu-hashcash/go/support/errors"
+)
+
+// historyLimit is the maximum number of history entries returned.
+const historyLimit = 100
+
+// keysHistoryEntry is a change to the keys blob. The history does not keep
+// the keys blobs themselves: a keys blob encrypted with a rotated key must
+// not remain retrievable after the rotation.
+type keysHistoryEntry struct {
+	Version   int64     `json:"version"`
+	Action    string    `json:"action"`
+	ClientIP  string    `json:"clientIP,omitempty"`
+	UserAgent string    `json:"userAgent,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type keysHistory struct {
+	Entries []keysHistoryEntry `json:"entries"`
+}
+
+// getKeysHistory returns the latest changes to the keys blob of the user,
+// most recent first.
+func (s *Service) getKeysHistory(ctx context.Context) (*keysHistory, error) {
+	userID := userID(ctx)
+	if userID == "" {
+		return nil, probNotAuthorized
+	}
+
+	q := `
+		SELECT version, action, client_ip, user_agent, created_at
+		FROM encrypted_keys_history
+		WHERE user_id = $1
+		ORDER BY id DESC
+		LIMIT $2
+	`
+	rows, err := s.db.QueryContext(ctx, q, userID, historyLimit)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting keys blob history")
+	}
+	defer rows.Close()
+
+	out := keysHistory{Entries: []keysHistoryEntry{}}
+	for rows.Next() {
+		var entry keysHistoryEntry
+		err = rows.Scan(&entry.Version, &entry.Action, &entry.ClientIP, &entry.UserAgent, &entry.CreatedAt)
+		if err != nil {
+			return nil, errors.Wrap(err, "scanning keys blob history")
+		}
+		out.Entries = append(out.Entries, entry)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "getting keys blob history")
+	}
+	return &out, nil
+}
+
+// recordHistory records a change to the keys blob of the user, made by the
+// client of the request.
+func recordHistory(ctx context.Context, tx *sql.Tx, userID string, version int64, action string) error {
+	client := clientInfoFromContext(ctx)
+	q := `
+		INSERT INTO encrypted_keys_history (user_id, version, action, client_ip, user_agent)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+	_, err := tx.ExecContext(ctx, q, userID, version, action, client.IP, client.UserAgent)
+	return errors.Wrap(err, "recording keys blob history")
+}
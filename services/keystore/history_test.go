@@ -0,0 +1,71 @@
+package keystore
+
+import (
+	"context"
+	"encoding/base64"
+	"reflect"
+	"testing"
+)
+
+func TestGetKeysHistory(t *testing.T) {
+	db := openKeystoreDB(t)
+	defer db.Close() // drop test db
+
+	conn := db.Open()
+	defer conn.Close() // close db connection
+
+	ctx := withUserID(context.Background(), "test-user")
+	ctx = withClientInfo(ctx, clientInfo{IP: "1.2.3.4", UserAgent: "test-wallet"})
+	s := &Service{conn.DB, nil}
+
+	blob := `[{
+		"id": "test-id",
+		"salt": "test-salt",
+		"encrypterName": "test-encrypter-name",
+		"encryptedBlob": "test-encryptedblob"
+	}]`
+	keysBlob := base64.RawURLEncoding.EncodeToString([]byte(blob))
+
+	created, err := s.putKeys(ctx, putKeysRequest{KeysBlob: keysBlob})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.putKeys(ctx, putKeysRequest{KeysBlob: keysBlob, Version: &created.Version})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.getKeysHistory(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotVersions []int64
+	for _, entry := range got.Entries {
+		gotVersions = append(gotVersions, entry.Version)
+		if entry.Action != actionPut {
+			t.Errorf("got Action=%s, want %s", entry.Action, actionPut)
+		}
+		if entry.ClientIP != "1.2.3.4" || entry.UserAgent != "test-wallet" {
+			t.Errorf("got ClientIP=%s UserAgent=%s, want the client of the request", entry.ClientIP, entry.UserAgent)
+		}
+	}
+	if want := []int64{2, 1}; !reflect.DeepEqual(gotVersions, want) {
+		t.Errorf("got versions %v, want %v", gotVersions, want)
+	}
+
+	// Deleting the keys blob is recorded in the history.
+	err = s.deleteKeys(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err = s.getKeysHistory(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Entries) != 3 {
+		t.Fatalf("got %d history entries, want 3", len(got.Entries))
+	}
+	if got.Entries[0].Action != actionDelete || got.Entries[0].Version != 3 {
+		t.Errorf("got Action=%s Version=%d, want %s 3", got.Entries[0].Action, got.Entries[0].Version, actionDelete)
+	}
+}
@@ -2,6 +2,7 @@ package keystore
 
 import (
 	"context"
+	"database/sql"
 	"encoding/base64"
 	"encoding/json"
 	"time"
@@ -11,8 +12,18 @@ import (
 	"github.com/shantanu-hashcash/go/support/render/problem"
 )
 
+// Actions recorded in the keys blob history.
+const (
+	actionPut             = "put"
+	actionDelete          = "delete"
+	actionRotate          = "rotate"
+	actionRotationStarted = "rotation_started"
+	actionRotationAborted = "rotation_aborted"
+)
+
 type encryptedKeysData struct {
 	KeysBlob   string     `json:"keysBlob"`
+	Version    int64      `json:"version"`
 	CreatedAt  time.Time  `json:"createdAt"`
 	ModifiedAt *time.Time `json:"modifiedAt,omitempty"`
 }
@@ -26,6 +37,10 @@ type encryptedKeyData struct {
 
 type putKeysRequest struct {
 	KeysBlob string `json:"keysBlob"`
+	// Version is the version of the keys blob the update is based on, so
+	// that devices do not overwrite each other's changes. It can only be
+	// omitted when creating the keys blob.
+	Version *int64 `json:"version,omitempty"`
 }
 
 func (s *Service) putKeys(ctx context.Context, in putKeysRequest) (*encryptedKeysData, error) {
@@ -34,83 +49,199 @@ func (s *Service) putKeys(ctx context.Context, in putKeysRequest) (*encryptedKey
 		return nil, probNotAuthorized
 	}
 
-	if in.KeysBlob == "" {
-		return nil, problem.MakeInvalidFieldProblem("keysBlob", errRequiredField)
+	keysData, _, err := parseKeysBlob(in.KeysBlob)
+	if err != nil {
+		return nil, err
+	}
+
+	var out *encryptedKeysData
+	err = s.withTx(ctx, func(tx *sql.Tx) error {
+		current, exists, err := lockKeys(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+		if err = checkVersion(in.Version, current, exists); err != nil {
+			return err
+		}
+		if err = checkNoRotation(ctx, tx, userID); err != nil {
+			return err
+		}
+
+		out, err = storeKeys(ctx, tx, userID, keysData, exists)
+		if err != nil {
+			return err
+		}
+		return recordHistory(ctx, tx, userID, out.Version, actionPut)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *Service) getKeys(ctx context.Context) (*encryptedKeysData, error) {
+	userID := userID(ctx)
+	if userID == "" {
+		return nil, probNotAuthorized
+	}
+
+	q := `
+		SELECT encrypted_keys_data, version, created_at, modified_at
+		FROM encrypted_keys
+		WHERE user_id = $1
+	`
+	out, err := scanKeys(s.db.QueryRowContext(ctx, q, userID))
+	return out, errors.Wrap(err, "getting keys blob")
+}
+
+func (s *Service) deleteKeys(ctx context.Context) error {
+	userID := userID(ctx)
+	if userID == "" {
+		return probNotAuthorized
+	}
+
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		q := `
+			DELETE FROM encrypted_keys
+			WHERE user_id = $1
+			RETURNING version
+		`
+		var version int64
+		err := tx.QueryRowContext(ctx, q, userID).Scan(&version)
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "deleting keys blob")
+		}
+
+		q = `
+			DELETE FROM encrypted_keys_rotations
+			WHERE user_id = $1
+		`
+		if _, err = tx.ExecContext(ctx, q, userID); err != nil {
+			return errors.Wrap(err, "deleting keys rotation")
+		}
+
+		// Deleting the keys blob creates a new version, so that devices
+		// holding the deleted version cannot update a keys blob created
+		// later.
+		return recordHistory(ctx, tx, userID, version+1, actionDelete)
+	})
+}
+
+// parseKeysBlob decodes and validates a base64-URL-encoded keys blob.
+func parseKeysBlob(keysBlob string) ([]byte, []encryptedKeyData, error) {
+	if keysBlob == "" {
+		return nil, nil, problem.MakeInvalidFieldProblem("keysBlob", errRequiredField)
 	}
 
-	keysData, err := base64.RawURLEncoding.DecodeString(in.KeysBlob)
+	keysData, err := base64.RawURLEncoding.DecodeString(keysBlob)
 	if err != nil {
 		// TODO: we need to implement a helper function in the
 		// support/error package for keeping the stack trace from err
 		// and substitute the root error for the one we want for better
 		// debugging experience.
 		// Thowing away the original err makes it harder for debugging.
-		return nil, probInvalidKeysBlob
+		return nil, nil, probInvalidKeysBlob
 	}
 
 	var encryptedKeys []encryptedKeyData
 	err = json.Unmarshal(keysData, &encryptedKeys)
 	if err != nil {
-		return nil, probInvalidKeysBlob
+		return nil, nil, probInvalidKeysBlob
 	}
 
 	for _, ek := range encryptedKeys {
 		if ek.Salt == "" {
-			return nil, problem.MakeInvalidFieldProblem("keysBlob", errors.New("salt is required for all the encrypted key data"))
+			return nil, nil, problem.MakeInvalidFieldProblem("keysBlob", errors.New("salt is required for all the encrypted key data"))
 		}
 		if ek.EncrypterName == "" {
-			return nil, problem.MakeInvalidFieldProblem("keysBlob", errors.New("encrypterName is required for all the encrypted key data"))
+			return nil, nil, problem.MakeInvalidFieldProblem("keysBlob", errors.New("encrypterName is required for all the encrypted key data"))
 		}
 		if ek.EncryptedBlob == "" {
-			return nil, problem.MakeInvalidFieldProblem("keysBlob", errors.New("encryptedBlob is required for all the encrypted key data"))
+			return nil, nil, problem.MakeInvalidFieldProblem("keysBlob", errors.New("encryptedBlob is required for all the encrypted key data"))
 		}
 		if ek.ID == "" {
-			return nil, problem.MakeInvalidFieldProblem("keysBlob", errors.New("id is required for all the encrypted key data"))
+			return nil, nil, problem.MakeInvalidFieldProblem("keysBlob", errors.New("id is required for all the encrypted key data"))
 		}
 	}
+	return keysData, encryptedKeys, nil
+}
+
+// checkVersion returns a version conflict problem if the version a request
+// is based on is not the current version of the keys blob. A request
+// creating the keys blob may omit its version.
+func checkVersion(version *int64, current int64, exists bool) error {
+	if !exists {
+		if version == nil || *version == 0 {
+			return nil
+		}
+		return versionConflict(0)
+	}
+	if version == nil || *version != current {
+		return versionConflict(current)
+	}
+	return nil
+}
 
+// lockKeys locks the keys blob of the user until the end of the transaction
+// and returns its version, if it exists.
+func lockKeys(ctx context.Context, tx *sql.Tx, userID string) (int64, bool, error) {
 	q := `
-		INSERT INTO encrypted_keys (user_id, encrypted_keys_data)
-		VALUES ($1, $2)
-		ON CONFLICT (user_id) DO UPDATE SET encrypted_keys_data = excluded.encrypted_keys_data, modified_at = NOW()
-		RETURNING encrypted_keys_data, created_at, modified_at
+		SELECT version
+		FROM encrypted_keys
+		WHERE user_id = $1
+		FOR UPDATE
 	`
-	var (
-		keysBlob   []byte
-		out        encryptedKeysData
-		modifiedAt pq.NullTime
-	)
-	err = s.db.QueryRowContext(ctx, q, userID, keysData).Scan(&keysBlob, &out.CreatedAt, &modifiedAt)
-	if err != nil {
-		return nil, errors.Wrap(err, "storing keys blob")
+	var version int64
+	err := tx.QueryRowContext(ctx, q, userID).Scan(&version)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
 	}
-
-	out.KeysBlob = base64.RawURLEncoding.EncodeToString(keysBlob)
-	if modifiedAt.Valid {
-		out.ModifiedAt = &modifiedAt.Time
+	if err != nil {
+		return 0, false, errors.Wrap(err, "locking keys blob")
 	}
-	return &out, nil
+	return version, true, nil
 }
 
-func (s *Service) getKeys(ctx context.Context) (*encryptedKeysData, error) {
-	userID := userID(ctx)
-	if userID == "" {
-		return nil, probNotAuthorized
+// storeKeys stores a new version of the keys blob of the user.
+func storeKeys(ctx context.Context, tx *sql.Tx, userID string, keysData []byte, exists bool) (*encryptedKeysData, error) {
+	if exists {
+		q := `
+			UPDATE encrypted_keys
+			SET encrypted_keys_data = $2, version = version + 1, modified_at = NOW()
+			WHERE user_id = $1
+			RETURNING encrypted_keys_data, version, created_at, modified_at
+		`
+		out, err := scanKeys(tx.QueryRowContext(ctx, q, userID, keysData))
+		return out, errors.Wrap(err, "storing keys blob")
 	}
 
+	// Versions keep increasing after the keys blob is deleted.
 	q := `
-		SELECT encrypted_keys_data, created_at, modified_at
-		FROM encrypted_keys
-		WHERE user_id = $1
+		INSERT INTO encrypted_keys (user_id, encrypted_keys_data, version)
+		VALUES ($1, $2, COALESCE((SELECT MAX(version) FROM encrypted_keys_history WHERE user_id = $1), 0) + 1)
+		ON CONFLICT (user_id) DO NOTHING
+		RETURNING encrypted_keys_data, version, created_at, modified_at
 	`
+	out, err := scanKeys(tx.QueryRowContext(ctx, q, userID, keysData))
+	if err == sql.ErrNoRows {
+		// Another device created the keys blob concurrently.
+		return nil, probVersionConflict
+	}
+	return out, errors.Wrap(err, "storing keys blob")
+}
+
+func scanKeys(row *sql.Row) (*encryptedKeysData, error) {
 	var (
 		keysBlob   []byte
 		out        encryptedKeysData
 		modifiedAt pq.NullTime
 	)
-	err := s.db.QueryRowContext(ctx, q, userID).Scan(&keysBlob, &out.CreatedAt, &modifiedAt)
+	err := row.Scan(&keysBlob, &out.Version, &out.CreatedAt, &modifiedAt)
 	if err != nil {
-		return nil, errors.Wrap(err, "getting keys blob")
+		return nil, err
 	}
 
 	out.KeysBlob = base64.RawURLEncoding.EncodeToString(keysBlob)
@@ -120,16 +251,15 @@ func (s *Service) getKeys(ctx context.Context) (*encryptedKeysData, error) {
 	return &out, nil
 }
 
-func (s *Service) deleteKeys(ctx context.Context) error {
-	userID := userID(ctx)
-	if userID == "" {
-		return probNotAuthorized
+func (s *Service) withTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "beginning transaction")
 	}
+	defer tx.Rollback()
 
-	q := `
-		DELETE FROM encrypted_keys
-		WHERE user_id = $1
-	`
-	_, err := s.db.ExecContext(ctx, q, userID)
-	return errors.Wrap(err, "deleting keys blob")
+	if err = fn(tx); err != nil {
+		return err
+	}
+	return errors.Wrap(tx.Commit(), "committing transaction")
 }
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/shantanu-hashcash/go/support/errors"
+	"github.com/shantanu-hashcash/go/support/render/problem"
 )
 
 func TestPutKeys(t *testing.T) {
@@ -135,3 +136,77 @@ func verifyKeysBlob(t *testing.T, gotKeysBlob, inKeysBlob string) {
 		t.Errorf("got keys: %v, want keys: %v\n", gotEncryptedKeys, inEncryptedKeys)
 	}
 }
+
+func TestPutKeysVersionConflict(t *testing.T) {
+	db := openKeystoreDB(t)
+	defer db.Close() // drop test db
+
+	conn := db.Open()
+	defer conn.Close() // close db connection
+
+	ctx := withUserID(context.Background(), "test-user")
+	s := &Service{conn.DB, nil}
+
+	blob := `[{
+		"id": "test-id",
+		"salt": "test-salt",
+		"encrypterName": "test-encrypter-name",
+		"encryptedBlob": "test-encryptedblob"
+	}]`
+	keysBlob := base64.RawURLEncoding.EncodeToString([]byte(blob))
+
+	created, err := s.putKeys(ctx, putKeysRequest{KeysBlob: keysBlob})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Version != 1 {
+		t.Errorf("got Version=%d, want 1", created.Version)
+	}
+
+	updated, err := s.putKeys(ctx, putKeysRequest{KeysBlob: keysBlob, Version: &created.Version})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Version != 2 {
+		t.Errorf("got Version=%d, want 2", updated.Version)
+	}
+
+	// A second device still holding version 1 cannot overwrite version 2.
+	_, err = s.putKeys(ctx, putKeysRequest{KeysBlob: keysBlob, Version: &created.Version})
+	p, ok := err.(problem.P)
+	if !ok || p.Type != "version_conflict" {
+		t.Fatalf("got err=%v, want a version_conflict problem", err)
+	}
+	if p.Extras["current_version"] != int64(2) {
+		t.Errorf("got current_version=%v, want 2", p.Extras["current_version"])
+	}
+
+	// Versions keep increasing after the keys blob is deleted, so that
+	// devices holding a deleted version cannot overwrite the new keys blob.
+	err = s.deleteKeys(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.putKeys(ctx, putKeysRequest{KeysBlob: keysBlob, Version: &updated.Version})
+	if p, ok := err.(problem.P); !ok || p.Type != "version_conflict" {
+		t.Fatalf("got err=%v, want a version_conflict problem", err)
+	}
+	recreated, err := s.putKeys(ctx, putKeysRequest{KeysBlob: keysBlob})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recreated.Version != 4 {
+		t.Errorf("got Version=%d, want 4", recreated.Version)
+	}
+
+	// Updates of an existing keys blob without a version would overwrite
+	// the changes of other devices and are rejected.
+	_, err = s.putKeys(ctx, putKeysRequest{KeysBlob: keysBlob})
+	p, ok = err.(problem.P)
+	if !ok || p.Type != "version_conflict" {
+		t.Fatalf("got err=%v, want a version_conflict problem", err)
+	}
+	if p.Extras["current_version"] != int64(4) {
+		t.Errorf("got current_version=%v, want 4", p.Extras["current_version"])
+	}
+}
@@ -0,0 +1,37 @@
+-- +migrate Up
+
+ALTER TABLE public.encrypted_keys
+	ADD COLUMN version bigint NOT NULL DEFAULT 1;
+
+CREATE TABLE public.encrypted_keys_history (
+    id bigserial PRIMARY KEY,
+    user_id text NOT NULL,
+    version bigint NOT NULL,
+    action text NOT NULL,
+    client_ip text NOT NULL DEFAULT '',
+    user_agent text NOT NULL DEFAULT '',
+    created_at timestamp with time zone NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX encrypted_keys_history_user_id_idx ON public.encrypted_keys_history (user_id, id);
+
+INSERT INTO public.encrypted_keys_history (user_id, version, action, created_at)
+	SELECT user_id, version, 'put', COALESCE(modified_at, created_at)
+	FROM public.encrypted_keys;
+
+CREATE TABLE public.encrypted_keys_rotations (
+    user_id text NOT NULL PRIMARY KEY,
+    rotation_id text NOT NULL,
+    base_version bigint NOT NULL,
+    started_at timestamp with time zone NOT NULL DEFAULT NOW(),
+    expires_at timestamp with time zone NOT NULL
+);
+
+-- +migrate Down
+
+DROP TABLE public.encrypted_keys_rotations;
+
+DROP TABLE public.encrypted_keys_history;
+
+ALTER TABLE public.encrypted_keys
+	DROP COLUMN version;
@@ -22,7 +22,7 @@ var (
 		Title:  "Method Not Allowed",
 		Status: http.StatusMethodNotAllowed,
 		Detail: "This endpoint does not support the request method you used. " +
-			"The server supports HTTP GET/PUT/DELETE for the /keys endpoint.",
+			"Please refer to the spec for the methods supported by the endpoint.",
 	}
 
 	probInvalidKeysBlob = problem.P{
@@ -41,4 +41,36 @@ var (
 		Status: 401,
 		Detail: "Your request is not authorized.",
 	}
+
+	probVersionConflict = problem.P{
+		Type:   "version_conflict",
+		Title:  "Version Conflict",
+		Status: http.StatusConflict,
+		Detail: "The keys blob was modified since the version your request is based on. " +
+			"Get the latest keys blob, apply your changes to it and try again.",
+	}
+
+	probRotationInProgress = problem.P{
+		Type:   "rotation_in_progress",
+		Title:  "Rotation In Progress",
+		Status: http.StatusConflict,
+		Detail: "The keys blob is being re-encrypted by a key rotation and cannot be modified " +
+			"until the rotation is completed, aborted or expires.",
+	}
+
+	probRotationNotFound = problem.P{
+		Type:   "rotation_not_found",
+		Title:  "Rotation Not Found",
+		Status: http.StatusNotFound,
+		Detail: "There is no key rotation in progress with the rotationID in your request. " +
+			"The rotation may have expired, start a new rotation and try again.",
+	}
 )
+
+// versionConflict returns a version conflict problem reporting the current
+// version of the keys blob.
+func versionConflict(currentVersion int64) problem.P {
+	p := probVersionConflict
+	p.Extras = map[string]interface{}{"current_version": currentVersion}
+	return p
+}
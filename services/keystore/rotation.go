@@ -0,0 +1,278 @@
+package keystore
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/shantanu-hashcash/go/support/errors"
+	"github.com/shantanu-hashcash/go/support/render/problem"
+)
+
+// rotationTimeout is the time a device has to complete a key rotation. Other
+// devices cannot update the keys blob during a rotation, the timeout keeps a
+// device that never completes its rotation from locking them out.
+const rotationTimeout = 15 * time.Minute
+
+// keysRotation is a rotation of the key the keys blob is encrypted with, e.g.
+// because the user changed the password it is derived from. The client
+// re-encrypts every key of the keys blob with the new key, and completes the
+// rotation with the re-encrypted keys blob. No other change can be made to
+// the keys blob in the meantime.
+type keysRotation struct {
+	RotationID string `json:"rotationID"`
+	// Version and KeysBlob are the keys blob to re-encrypt.
+	Version   int64     `json:"version"`
+	KeysBlob  string    `json:"keysBlob"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+type startRotationRequest struct {
+	// Version is the version of the keys blob the client is rotating.
+	Version *int64 `json:"version"`
+}
+
+type completeRotationRequest struct {
+	RotationID string `json:"rotationID"`
+	// KeysBlob is the re-encrypted keys blob.
+	KeysBlob string `json:"keysBlob"`
+}
+
+type abortRotationRequest struct {
+	RotationID string `json:"rotationID"`
+}
+
+func (s *Service) startRotation(ctx context.Context, in startRotationRequest) (*keysRotation, error) {
+	userID := userID(ctx)
+	if userID == "" {
+		return nil, probNotAuthorized
+	}
+	if in.Version == nil {
+		return nil, problem.MakeInvalidFieldProblem("version", errRequiredField)
+	}
+
+	rotationID, err := newRotationID()
+	if err != nil {
+		return nil, err
+	}
+
+	var out *keysRotation
+	err = s.withTx(ctx, func(tx *sql.Tx) error {
+		current, exists, err := lockKeys(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return sql.ErrNoRows
+		}
+		if err = checkVersion(in.Version, current, exists); err != nil {
+			return err
+		}
+		if err = checkNoRotation(ctx, tx, userID); err != nil {
+			return err
+		}
+
+		// Replaces the expired rotation, if any.
+		q := `
+			INSERT INTO encrypted_keys_rotations (user_id, rotation_id, base_version, expires_at)
+			VALUES ($1, $2, $3, NOW() + make_interval(secs => $4))
+			ON CONFLICT (user_id) DO UPDATE SET
+				rotation_id = excluded.rotation_id,
+				base_version = excluded.base_version,
+				started_at = NOW(),
+				expires_at = excluded.expires_at
+			RETURNING expires_at
+		`
+		out = &keysRotation{RotationID: rotationID, Version: current}
+		err = tx.QueryRowContext(ctx, q, userID, rotationID, current, int64(rotationTimeout/time.Second)).Scan(&out.ExpiresAt)
+		if err != nil {
+			return errors.Wrap(err, "starting keys rotation")
+		}
+
+		q = `
+			SELECT encrypted_keys_data, version, created_at, modified_at
+			FROM encrypted_keys
+			WHERE user_id = $1
+		`
+		keys, err := scanKeys(tx.QueryRowContext(ctx, q, userID))
+		if err != nil {
+			return errors.Wrap(err, "getting keys blob")
+		}
+		out.KeysBlob = keys.KeysBlob
+
+		return recordHistory(ctx, tx, userID, current, actionRotationStarted)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *Service) completeRotation(ctx context.Context, in completeRotationRequest) (*encryptedKeysData, error) {
+	userID := userID(ctx)
+	if userID == "" {
+		return nil, probNotAuthorized
+	}
+	if in.RotationID == "" {
+		return nil, problem.MakeInvalidFieldProblem("rotationID", errRequiredField)
+	}
+
+	keysData, rotatedKeys, err := parseKeysBlob(in.KeysBlob)
+	if err != nil {
+		return nil, err
+	}
+
+	var out *encryptedKeysData
+	err = s.withTx(ctx, func(tx *sql.Tx) error {
+		current, exists, err := lockKeys(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return probRotationNotFound
+		}
+		baseVersion, err := lockRotation(ctx, tx, userID, in.RotationID)
+		if err != nil {
+			return err
+		}
+		if baseVersion != current {
+			return versionConflict(current)
+		}
+
+		q := `
+			SELECT encrypted_keys_data
+			FROM encrypted_keys
+			WHERE user_id = $1
+		`
+		var currentData []byte
+		if err = tx.QueryRowContext(ctx, q, userID).Scan(&currentData); err != nil {
+			return errors.Wrap(err, "getting keys blob")
+		}
+		var currentKeys []encryptedKeyData
+		if err = json.Unmarshal(currentData, &currentKeys); err != nil {
+			return errors.Wrap(err, "decoding keys blob")
+		}
+		if err = validateRotatedKeys(currentKeys, rotatedKeys); err != nil {
+			return problem.MakeInvalidFieldProblem("keysBlob", err)
+		}
+
+		out, err = storeKeys(ctx, tx, userID, keysData, exists)
+		if err != nil {
+			return err
+		}
+		if err = deleteRotation(ctx, tx, userID); err != nil {
+			return err
+		}
+		return recordHistory(ctx, tx, userID, out.Version, actionRotate)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *Service) abortRotation(ctx context.Context, in abortRotationRequest) error {
+	userID := userID(ctx)
+	if userID == "" {
+		return probNotAuthorized
+	}
+	if in.RotationID == "" {
+		return problem.MakeInvalidFieldProblem("rotationID", errRequiredField)
+	}
+
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		baseVersion, err := lockRotation(ctx, tx, userID, in.RotationID)
+		if err != nil {
+			return err
+		}
+		if err = deleteRotation(ctx, tx, userID); err != nil {
+			return err
+		}
+		return recordHistory(ctx, tx, userID, baseVersion, actionRotationAborted)
+	})
+}
+
+// validateRotatedKeys checks that a rotated keys blob contains every key of
+// the current keys blob, each re-encrypted with a new salt. Keys can be added
+// during a rotation, but not removed, since a client failing to decrypt a key
+// would otherwise lose it.
+func validateRotatedKeys(current, rotated []encryptedKeyData) error {
+	rotatedByID := make(map[string]encryptedKeyData, len(rotated))
+	for _, ek := range rotated {
+		if _, ok := rotatedByID[ek.ID]; ok {
+			return errors.Errorf("key %s is duplicated", ek.ID)
+		}
+		rotatedByID[ek.ID] = ek
+	}
+
+	for _, ek := range current {
+		rek, ok := rotatedByID[ek.ID]
+		if !ok {
+			return errors.Errorf("key %s is missing from the rotated keys blob", ek.ID)
+		}
+		if rek.Salt == ek.Salt {
+			return errors.Errorf("key %s is not re-encrypted with a new salt", ek.ID)
+		}
+	}
+	return nil
+}
+
+// checkNoRotation returns a problem if a rotation of the keys blob of the
+// user is in progress.
+func checkNoRotation(ctx context.Context, tx *sql.Tx, userID string) error {
+	q := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM encrypted_keys_rotations
+			WHERE user_id = $1 AND expires_at > NOW()
+		)
+	`
+	var inProgress bool
+	if err := tx.QueryRowContext(ctx, q, userID).Scan(&inProgress); err != nil {
+		return errors.Wrap(err, "checking keys rotation")
+	}
+	if inProgress {
+		return probRotationInProgress
+	}
+	return nil
+}
+
+// lockRotation locks the rotation of the keys blob of the user until the end
+// of the transaction and returns the version of the keys blob it rotates.
+func lockRotation(ctx context.Context, tx *sql.Tx, userID, rotationID string) (int64, error) {
+	q := `
+		SELECT base_version
+		FROM encrypted_keys_rotations
+		WHERE user_id = $1 AND rotation_id = $2 AND expires_at > NOW()
+		FOR UPDATE
+	`
+	var baseVersion int64
+	err := tx.QueryRowContext(ctx, q, userID, rotationID).Scan(&baseVersion)
+	if err == sql.ErrNoRows {
+		return 0, probRotationNotFound
+	}
+	if err != nil {
+		return 0, errors.Wrap(err, "locking keys rotation")
+	}
+	return baseVersion, nil
+}
+
+func deleteRotation(ctx context.Context, tx *sql.Tx, userID string) error {
+	q := `
+		DELETE FROM encrypted_keys_rotations
+		WHERE user_id = $1
+	`
+	_, err := tx.ExecContext(ctx, q, userID)
+	return errors.Wrap(err, "deleting keys rotation")
+}
+
+func newRotationID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "generating rotation id")
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,179 @@
+package keystore
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/shantanu-hashcash/go/support/render/problem"
+)
+
+func TestRotation(t *testing.T) {
+	db := openKeystoreDB(t)
+	defer db.Close() // drop test db
+
+	conn := db.Open()
+	defer conn.Close() // close db connection
+
+	ctx := withUserID(context.Background(), "test-user")
+	s := &Service{conn.DB, nil}
+
+	blob := `[{
+		"id": "test-id",
+		"salt": "test-salt",
+		"encrypterName": "test-encrypter-name",
+		"encryptedBlob": "test-encryptedblob"
+	}]`
+	keysBlob := base64.RawURLEncoding.EncodeToString([]byte(blob))
+	created, err := s.putKeys(ctx, putKeysRequest{KeysBlob: keysBlob})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rotation, err := s.startRotation(ctx, startRotationRequest{Version: &created.Version})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rotation.Version != created.Version {
+		t.Errorf("got Version=%d, want %d", rotation.Version, created.Version)
+	}
+	verifyKeysBlob(t, rotation.KeysBlob, keysBlob)
+
+	// The keys blob cannot be modified during the rotation.
+	_, err = s.putKeys(ctx, putKeysRequest{KeysBlob: keysBlob, Version: &created.Version})
+	if !isProblem(err, probRotationInProgress) {
+		t.Fatalf("got err=%v, want %v", err, probRotationInProgress)
+	}
+	_, err = s.startRotation(ctx, startRotationRequest{Version: &created.Version})
+	if !isProblem(err, probRotationInProgress) {
+		t.Fatalf("got err=%v, want %v", err, probRotationInProgress)
+	}
+
+	// Keys must be re-encrypted with a new salt.
+	_, err = s.completeRotation(ctx, completeRotationRequest{RotationID: rotation.RotationID, KeysBlob: keysBlob})
+	if p, ok := err.(*problem.P); !ok || p.Extras["invalid_field"] != "keysBlob" {
+		t.Fatalf("got err=%v, want an invalid keysBlob problem", err)
+	}
+
+	rotatedBlob := `[{
+		"id": "test-id",
+		"salt": "test-new-salt",
+		"encrypterName": "test-encrypter-name",
+		"encryptedBlob": "test-reencryptedblob"
+	}]`
+	rotatedKeysBlob := base64.RawURLEncoding.EncodeToString([]byte(rotatedBlob))
+	_, err = s.completeRotation(ctx, completeRotationRequest{RotationID: "other-rotation", KeysBlob: rotatedKeysBlob})
+	if !isProblem(err, probRotationNotFound) {
+		t.Fatalf("got err=%v, want %v", err, probRotationNotFound)
+	}
+
+	rotated, err := s.completeRotation(ctx, completeRotationRequest{RotationID: rotation.RotationID, KeysBlob: rotatedKeysBlob})
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifyKeysBlob(t, rotated.KeysBlob, rotatedKeysBlob)
+	if rotated.Version != created.Version+1 {
+		t.Errorf("got Version=%d, want %d", rotated.Version, created.Version+1)
+	}
+
+	// The keys blob can be modified again.
+	_, err = s.putKeys(ctx, putKeysRequest{KeysBlob: rotatedKeysBlob, Version: &rotated.Version})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAbortRotation(t *testing.T) {
+	db := openKeystoreDB(t)
+	defer db.Close() // drop test db
+
+	conn := db.Open()
+	defer conn.Close() // close db connection
+
+	ctx := withUserID(context.Background(), "test-user")
+	s := &Service{conn.DB, nil}
+
+	blob := `[{
+		"id": "test-id",
+		"salt": "test-salt",
+		"encrypterName": "test-encrypter-name",
+		"encryptedBlob": "test-encryptedblob"
+	}]`
+	keysBlob := base64.RawURLEncoding.EncodeToString([]byte(blob))
+	created, err := s.putKeys(ctx, putKeysRequest{KeysBlob: keysBlob})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rotation, err := s.startRotation(ctx, startRotationRequest{Version: &created.Version})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.abortRotation(ctx, abortRotationRequest{RotationID: rotation.RotationID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = s.putKeys(ctx, putKeysRequest{KeysBlob: keysBlob, Version: &created.Version})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.abortRotation(ctx, abortRotationRequest{RotationID: rotation.RotationID})
+	if !isProblem(err, probRotationNotFound) {
+		t.Fatalf("got err=%v, want %v", err, probRotationNotFound)
+	}
+}
+
+func TestValidateRotatedKeys(t *testing.T) {
+	current := []encryptedKeyData{
+		{ID: "a", Salt: "salt-a"},
+		{ID: "b", Salt: "salt-b"},
+	}
+
+	testCases := []struct {
+		name    string
+		rotated []encryptedKeyData
+		wantErr string
+	}{
+		{
+			name:    "rotated",
+			rotated: []encryptedKeyData{{ID: "a", Salt: "new-salt-a"}, {ID: "b", Salt: "new-salt-b"}},
+		},
+		{
+			name:    "key added",
+			rotated: []encryptedKeyData{{ID: "a", Salt: "new-salt-a"}, {ID: "b", Salt: "new-salt-b"}, {ID: "c", Salt: "salt-c"}},
+		},
+		{
+			name:    "key missing",
+			rotated: []encryptedKeyData{{ID: "a", Salt: "new-salt-a"}},
+			wantErr: "key b is missing from the rotated keys blob",
+		},
+		{
+			name:    "salt reused",
+			rotated: []encryptedKeyData{{ID: "a", Salt: "new-salt-a"}, {ID: "b", Salt: "salt-b"}},
+			wantErr: "key b is not re-encrypted with a new salt",
+		},
+		{
+			name:    "key duplicated",
+			rotated: []encryptedKeyData{{ID: "a", Salt: "new-salt-a"}, {ID: "a", Salt: "other-salt-a"}},
+			wantErr: "key a is duplicated",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateRotatedKeys(current, tc.rotated)
+			gotErr := ""
+			if err != nil {
+				gotErr = err.Error()
+			}
+			if gotErr != tc.wantErr {
+				t.Errorf("got err=%q, want %q", gotErr, tc.wantErr)
+			}
+		})
+	}
+}
+
+func isProblem(err error, want problem.P) bool {
+	p, ok := err.(problem.P)
+	return ok && p.Type == want.Type
+}
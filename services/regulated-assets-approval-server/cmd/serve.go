@@ -84,6 +84,13 @@ func (c *ServeCommand) Command() *cobra.Command {
 			FlagDefault: "500",
 			Required:    true,
 		},
+		{
+			Name:      "rules-file",
+			Usage:     "Path to a TOML file configuring the compliance rules payments are evaluated against, defaults to requiring KYC above kyc-required-payment-amount-threshold",
+			OptType:   types.String,
+			ConfigKey: &opts.RulesFile,
+			Required:  false,
+		},
 	}
 	cmd := &cobra.Command{
 		Use:   "serve",
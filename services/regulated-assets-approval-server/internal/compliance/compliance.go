@@ -0,0 +1,150 @@
+// Package compliance evaluates the payments of transactions submitted for
+// approval against a configurable list of rules and records every decision in
+// an audit log.
+package compliance
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/shantanu-hashcash/go/support/errors"
+)
+
+// Outcome is the result of evaluating a transaction.
+type Outcome string
+
+const (
+	// OutcomeApproved means no rule objected to the transaction.
+	OutcomeApproved Outcome = "approved"
+	// OutcomeRejected means the transaction cannot be approved.
+	OutcomeRejected Outcome = "rejected"
+	// OutcomePending means the transaction needs a decision from the staff of
+	// the issuer.
+	OutcomePending Outcome = "pending"
+	// OutcomeActionRequired means the client must provide more information
+	// before the transaction can be approved.
+	OutcomeActionRequired Outcome = "action_required"
+)
+
+// Payment is a payment of the regulated asset.
+type Payment struct {
+	// OperationIndex is the index of the payment operation in the
+	// transaction.
+	OperationIndex int
+	Source         string
+	Destination    string
+	// Amount is the amount of the payment in stroops.
+	Amount int64
+}
+
+// Request is a transaction to evaluate.
+type Request struct {
+	TxHash   string
+	Payments []Payment
+	// Tx is the database transaction the engine evaluates and audits the
+	// request in, set by Engine.Evaluate. Rules reading the audit log read it
+	// through Tx so that their reads and the audit commit together.
+	Tx *sqlx.Tx
+}
+
+// Decision is the outcome of a rule or of the engine.
+type Decision struct {
+	Outcome Outcome
+	// Rule is the name of the rule that made the decision, empty when the
+	// transaction was approved because no rule objected.
+	Rule string
+	// OperationIndex is the index of the payment operation that triggered the
+	// decision.
+	OperationIndex int
+	Message        string
+	// ActionURL and ActionFields are set for OutcomeActionRequired.
+	ActionURL    string
+	ActionFields []string
+}
+
+// Rule checks the payments of a transaction. It returns a nil decision when
+// it has no objection to the transaction.
+type Rule interface {
+	Evaluate(ctx context.Context, req Request) (*Decision, error)
+}
+
+// NamedRule is a rule together with the name it is audited under.
+type NamedRule struct {
+	Name string
+	Rule Rule
+}
+
+// Engine evaluates rules in order. The first rule that makes a decision ends
+// the evaluation, so rules that reject outright should be listed before rules
+// that require action from the client.
+type Engine struct {
+	Rules []NamedRule
+	// DB stores the audit log, and the approved payments that velocity
+	// limits are calculated from.
+	DB *sqlx.DB
+}
+
+// Evaluate evaluates req and records the decision, in a single database
+// transaction.
+func (e Engine) Evaluate(ctx context.Context, req Request) (Decision, error) {
+	tx, err := e.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return Decision{}, errors.Wrap(err, "beginning transaction")
+	}
+	defer tx.Rollback()
+	req.Tx = tx
+
+	decision := Decision{Outcome: OutcomeApproved}
+	for _, r := range e.Rules {
+		d, err := r.Rule.Evaluate(ctx, req)
+		if err != nil {
+			return Decision{}, errors.Wrapf(err, "evaluating rule %s", r.Name)
+		}
+		if d != nil {
+			decision = *d
+			decision.Rule = r.Name
+			break
+		}
+	}
+
+	err = audit(ctx, tx, req, decision)
+	if err != nil {
+		return Decision{}, errors.Wrap(err, "auditing decision")
+	}
+	err = tx.Commit()
+	if err != nil {
+		return Decision{}, errors.Wrap(err, "committing transaction")
+	}
+	return decision, nil
+}
+
+func audit(ctx context.Context, tx *sqlx.Tx, req Request, decision Decision) error {
+	var operationIndex *int
+	if decision.Outcome != OutcomeApproved {
+		operationIndex = &decision.OperationIndex
+	}
+	const insertDecision = `
+		INSERT INTO compliance_decisions (tx_hash, outcome, rule, operation_index, message)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+	_, err := tx.ExecContext(ctx, insertDecision, req.TxHash, decision.Outcome, decision.Rule, operationIndex, decision.Message)
+	if err != nil {
+		return errors.Wrap(err, "inserting decision")
+	}
+
+	if decision.Outcome == OutcomeApproved {
+		const insertPayment = `
+			INSERT INTO compliance_approved_payments (tx_hash, operation_index, source, destination, amount)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (tx_hash, operation_index) DO NOTHING
+		`
+		for _, p := range req.Payments {
+			_, err = tx.ExecContext(ctx, insertPayment, req.TxHash, p.OperationIndex, p.Source, p.Destination, p.Amount)
+			if err != nil {
+				return errors.Wrap(err, "inserting approved payment")
+			}
+		}
+	}
+
+	return nil
+}
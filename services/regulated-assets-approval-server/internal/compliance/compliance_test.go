@@ -0,0 +1,88 @@
+package compliance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shantanu-hashcash/go/keypair"
+	"github.com/shantanu-hashcash/go/services/regulated-assets-approval-server/internal/db/dbtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ruleFunc func(ctx context.Context, req Request) (*Decision, error)
+
+func (f ruleFunc) Evaluate(ctx context.Context, req Request) (*Decision, error) {
+	return f(ctx, req)
+}
+
+func TestEngineEvaluate(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.Open(t)
+	defer db.Close()
+	conn := db.Open()
+	defer conn.Close()
+
+	sender := keypair.MustRandom().Address()
+	receiver := keypair.MustRandom().Address()
+	noObjection := ruleFunc(func(context.Context, Request) (*Decision, error) { return nil, nil })
+	reject := ruleFunc(func(context.Context, Request) (*Decision, error) {
+		return &Decision{Outcome: OutcomeRejected, OperationIndex: 1, Message: "Nope."}, nil
+	})
+	unreachable := ruleFunc(func(context.Context, Request) (*Decision, error) {
+		t.Fatal("rule evaluated after a decision was made")
+		return nil, nil
+	})
+
+	req := Request{
+		TxHash: "rejected-tx",
+		Payments: []Payment{
+			{OperationIndex: 0, Source: sender, Destination: receiver, Amount: 10},
+			{OperationIndex: 1, Source: sender, Destination: receiver, Amount: 20},
+		},
+	}
+
+	// the first rule to make a decision wins
+	engine := Engine{
+		Rules: []NamedRule{{"allow", noObjection}, {"deny", reject}, {"never", unreachable}},
+		DB:    conn,
+	}
+	decision, err := engine.Evaluate(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, Decision{Outcome: OutcomeRejected, Rule: "deny", OperationIndex: 1, Message: "Nope."}, decision)
+
+	var (
+		outcome, rule  string
+		operationIndex *int
+	)
+	q := `SELECT outcome, rule, operation_index FROM compliance_decisions WHERE tx_hash = $1`
+	err = conn.QueryRowContext(ctx, q, "rejected-tx").Scan(&outcome, &rule, &operationIndex)
+	require.NoError(t, err)
+	assert.Equal(t, "rejected", outcome)
+	assert.Equal(t, "deny", rule)
+	require.NotNil(t, operationIndex)
+	assert.Equal(t, 1, *operationIndex)
+
+	var count int
+	err = conn.QueryRowContext(ctx, `SELECT COUNT(*) FROM compliance_approved_payments`).Scan(&count)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	// approved transactions record their payments, once
+	req.TxHash = "approved-tx"
+	engine.Rules = []NamedRule{{"allow", noObjection}}
+	for i := 0; i < 2; i++ {
+		decision, err = engine.Evaluate(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, Decision{Outcome: OutcomeApproved}, decision)
+	}
+
+	err = conn.QueryRowContext(ctx, `SELECT COUNT(*) FROM compliance_decisions WHERE tx_hash = 'approved-tx' AND operation_index IS NULL`).Scan(&count)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	var total int64
+	err = conn.QueryRowContext(ctx, `SELECT COUNT(*), SUM(amount) FROM compliance_approved_payments WHERE source = $1`, sender).Scan(&count, &total)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, int64(30), total)
+}
@@ -0,0 +1,232 @@
+package compliance
+
+import (
+	"bufio"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/shantanu-hashcash/go/amount"
+	"github.com/shantanu-hashcash/go/strkey"
+	"github.com/shantanu-hashcash/go/support/config"
+	"github.com/shantanu-hashcash/go/support/errors"
+)
+
+// Rule types supported in the rules file.
+const (
+	RuleTypeAmountLimit           = "amount_limit"
+	RuleTypeCounterpartyAllowlist = "counterparty_allowlist"
+	RuleTypeJurisdiction          = "jurisdiction"
+	RuleTypeKYC                   = "kyc"
+	RuleTypeSanctions             = "sanctions"
+	RuleTypeVelocity              = "velocity"
+)
+
+// Config is the contents of a TOML rules file, for example:
+//
+//	[[rules]]
+//	type = "sanctions"
+//	addresses_file = "/etc/approval-server/sanctioned-accounts.txt"
+//
+//	[[rules]]
+//	name = "daily-velocity"
+//	type = "velocity"
+//	window = "24h"
+//	max_amount = "10000"
+//
+//	[[rules]]
+//	type = "kyc"
+//	threshold = "500"
+type Config struct {
+	Rules []RuleConfig `toml:"rules" valid:"required"`
+}
+
+// RuleConfig configures one rule. Which fields apply depends on Type.
+type RuleConfig struct {
+	Type string `toml:"type" valid:"required"`
+	// Name is the name the rule is audited under, Type when empty.
+	Name string `toml:"name" valid:"optional"`
+
+	// amount_limit and velocity
+	MaxAmount string `toml:"max_amount" valid:"optional"`
+	// velocity
+	MaxCount int    `toml:"max_count" valid:"optional"`
+	Window   string `toml:"window" valid:"optional"`
+	// kyc
+	Threshold string `toml:"threshold" valid:"optional"`
+	// sanctions, addresses are read one per line from AddressesFile in
+	// addition to Addresses.
+	Addresses     []string `toml:"addresses" valid:"optional"`
+	AddressesFile string   `toml:"addresses_file" valid:"optional"`
+	// counterparty_allowlist
+	Allowlist        map[string][]string `toml:"allowlist" valid:"optional"`
+	RestrictUnlisted bool                `toml:"restrict_unlisted" valid:"optional"`
+	// jurisdiction
+	Accounts     map[string]string `toml:"accounts" valid:"optional"`
+	Blocked      []string          `toml:"blocked" valid:"optional"`
+	RequireKnown bool              `toml:"require_known" valid:"optional"`
+}
+
+// ReadConfig reads a TOML rules file.
+func ReadConfig(path string) (Config, error) {
+	cfg := Config{}
+	err := config.Read(path, &cfg)
+	if err != nil {
+		return Config{}, errors.Wrapf(err, "reading rules file %s", path)
+	}
+	return cfg, nil
+}
+
+// Dependencies are the values shared by rules that are not part of the rules
+// file.
+type Dependencies struct {
+	DB        *sqlx.DB
+	AssetCode string
+	BaseURL   string
+}
+
+// BuildRules builds the rules of cfg, in order.
+func (cfg Config) BuildRules(deps Dependencies) ([]NamedRule, error) {
+	rules := make([]NamedRule, 0, len(cfg.Rules))
+	for i, rc := range cfg.Rules {
+		name := rc.Name
+		if name == "" {
+			name = rc.Type
+		}
+		rule, err := rc.build(deps)
+		if err != nil {
+			return nil, errors.Wrapf(err, "building rule %d (%s)", i, name)
+		}
+		rules = append(rules, NamedRule{Name: name, Rule: rule})
+	}
+	return rules, nil
+}
+
+func (rc RuleConfig) build(deps Dependencies) (Rule, error) {
+	switch rc.Type {
+	case RuleTypeAmountLimit:
+		maxAmount, err := parsePositiveAmount(rc.MaxAmount, "max_amount")
+		if err != nil {
+			return nil, err
+		}
+		return AmountLimit{AssetCode: deps.AssetCode, MaxAmount: maxAmount}, nil
+
+	case RuleTypeVelocity:
+		window, err := time.ParseDuration(rc.Window)
+		if err != nil || window <= 0 {
+			return nil, errors.Errorf("window %q must be a positive duration", rc.Window)
+		}
+		var maxAmount int64
+		if rc.MaxAmount != "" {
+			maxAmount, err = parsePositiveAmount(rc.MaxAmount, "max_amount")
+			if err != nil {
+				return nil, err
+			}
+		}
+		if maxAmount == 0 && rc.MaxCount <= 0 {
+			return nil, errors.New("max_amount or max_count must be set")
+		}
+		return VelocityLimit{
+			DB:        deps.DB,
+			AssetCode: deps.AssetCode,
+			Window:    window,
+			MaxAmount: maxAmount,
+			MaxCount:  rc.MaxCount,
+		}, nil
+
+	case RuleTypeKYC:
+		threshold, err := parsePositiveAmount(rc.Threshold, "threshold")
+		if err != nil {
+			return nil, err
+		}
+		return KYC{DB: deps.DB, AssetCode: deps.AssetCode, Threshold: threshold, BaseURL: deps.BaseURL}, nil
+
+	case RuleTypeSanctions:
+		addresses := append([]string{}, rc.Addresses...)
+		if rc.AddressesFile != "" {
+			fromFile, err := readAddressesFile(rc.AddressesFile)
+			if err != nil {
+				return nil, err
+			}
+			addresses = append(addresses, fromFile...)
+		}
+		set, err := addressSet(addresses)
+		if err != nil {
+			return nil, err
+		}
+		return Sanctions{Addresses: set}, nil
+
+	case RuleTypeCounterpartyAllowlist:
+		allowlist := make(map[string]map[string]bool, len(rc.Allowlist))
+		for source, destinations := range rc.Allowlist {
+			if !strkey.IsValidEd25519PublicKey(source) {
+				return nil, errors.Errorf("invalid account %s", source)
+			}
+			set, err := addressSet(destinations)
+			if err != nil {
+				return nil, err
+			}
+			allowlist[source] = set
+		}
+		return CounterpartyAllowlist{Allowlist: allowlist, RestrictUnlisted: rc.RestrictUnlisted}, nil
+
+	case RuleTypeJurisdiction:
+		for account := range rc.Accounts {
+			if !strkey.IsValidEd25519PublicKey(account) {
+				return nil, errors.Errorf("invalid account %s", account)
+			}
+		}
+		blocked := make(map[string]bool, len(rc.Blocked))
+		for _, jurisdiction := range rc.Blocked {
+			blocked[jurisdiction] = true
+		}
+		return Jurisdiction{Accounts: rc.Accounts, Blocked: blocked, RequireKnown: rc.RequireKnown}, nil
+
+	default:
+		return nil, errors.Errorf("unknown rule type %q", rc.Type)
+	}
+}
+
+func parsePositiveAmount(value, field string) (int64, error) {
+	parsed, err := amount.ParseInt64(value)
+	if err != nil || parsed <= 0 {
+		return 0, errors.Errorf("%s %q must be a positive amount", field, value)
+	}
+	return parsed, nil
+}
+
+func addressSet(addresses []string) (map[string]bool, error) {
+	set := make(map[string]bool, len(addresses))
+	for _, address := range addresses {
+		if !strkey.IsValidEd25519PublicKey(address) {
+			return nil, errors.Errorf("invalid account %s", address)
+		}
+		set[address] = true
+	}
+	return set, nil
+}
+
+// readAddressesFile reads one address per line, ignoring empty lines and
+// lines starting with #.
+func readAddressesFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "opening %s", path)
+	}
+	defer f.Close()
+
+	addresses := []string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		addresses = append(addresses, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "reading %s", path)
+	}
+	return addresses, nil
+}
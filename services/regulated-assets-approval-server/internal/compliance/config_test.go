@@ -0,0 +1,98 @@
+package compliance
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shantanu-hashcash/go/keypair"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadConfig(t *testing.T) {
+	dir := t.TempDir()
+	sanctioned := keypair.MustRandom().Address()
+	addressesFile := filepath.Join(dir, "sanctioned.txt")
+	err := os.WriteFile(addressesFile, []byte("# sanctioned accounts\n\n"+sanctioned+"\n"), 0o600)
+	require.NoError(t, err)
+
+	rulesFile := filepath.Join(dir, "rules.toml")
+	err = os.WriteFile(rulesFile, []byte(`
+[[rules]]
+type = "sanctions"
+addresses_file = "`+addressesFile+`"
+
+[[rules]]
+name = "daily-velocity"
+type = "velocity"
+window = "24h"
+max_amount = "10000"
+max_count = 10
+
+[[rules]]
+type = "kyc"
+threshold = "500"
+`), 0o600)
+	require.NoError(t, err)
+
+	cfg, err := ReadConfig(rulesFile)
+	require.NoError(t, err)
+	rules, err := cfg.BuildRules(Dependencies{AssetCode: "FOO", BaseURL: "https://example.com"})
+	require.NoError(t, err)
+
+	require.Len(t, rules, 3)
+	assert.Equal(t, NamedRule{Name: "sanctions", Rule: Sanctions{Addresses: map[string]bool{sanctioned: true}}}, rules[0])
+	assert.Equal(t, NamedRule{Name: "daily-velocity", Rule: VelocityLimit{
+		AssetCode: "FOO",
+		Window:    24 * time.Hour,
+		MaxAmount: 100000000000,
+		MaxCount:  10,
+	}}, rules[1])
+	assert.Equal(t, NamedRule{Name: "kyc", Rule: KYC{
+		AssetCode: "FOO",
+		Threshold: 5000000000,
+		BaseURL:   "https://example.com",
+	}}, rules[2])
+}
+
+func TestConfigBuildRules_invalid(t *testing.T) {
+	testCases := []struct {
+		name    string
+		rule    RuleConfig
+		wantErr string
+	}{
+		{
+			name:    "unknown type",
+			rule:    RuleConfig{Type: "magic"},
+			wantErr: `building rule 0 (magic): unknown rule type "magic"`,
+		},
+		{
+			name:    "amount limit without max amount",
+			rule:    RuleConfig{Type: RuleTypeAmountLimit},
+			wantErr: `building rule 0 (amount_limit): max_amount "" must be a positive amount`,
+		},
+		{
+			name:    "velocity without window",
+			rule:    RuleConfig{Type: RuleTypeVelocity, MaxCount: 1},
+			wantErr: `building rule 0 (velocity): window "" must be a positive duration`,
+		},
+		{
+			name:    "velocity without limits",
+			rule:    RuleConfig{Name: "v", Type: RuleTypeVelocity, Window: "1h"},
+			wantErr: `building rule 0 (v): max_amount or max_count must be set`,
+		},
+		{
+			name:    "sanctions with invalid address",
+			rule:    RuleConfig{Type: RuleTypeSanctions, Addresses: []string{"GINVALID"}},
+			wantErr: `building rule 0 (sanctions): invalid account GINVALID`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Config{Rules: []RuleConfig{tc.rule}}.BuildRules(Dependencies{})
+			assert.EqualError(t, err, tc.wantErr)
+		})
+	}
+}
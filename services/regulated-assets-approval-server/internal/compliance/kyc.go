@@ -0,0 +1,93 @@
+package compliance
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/shantanu-hashcash/go/support/errors"
+)
+
+// KYC requires the source account of payments above Threshold to have its
+// KYC approved. Accounts are registered in the accounts_kyc_status table the
+// first time they exceed the threshold and are asked to provide an email
+// address through the kyc-status endpoint.
+type KYC struct {
+	DB        *sqlx.DB
+	AssetCode string
+	Threshold int64
+	// BaseURL is the base URL of the server, used to build the kyc-status
+	// action URL.
+	BaseURL string
+}
+
+// Evaluate implements Rule.
+func (r KYC) Evaluate(ctx context.Context, req Request) (*Decision, error) {
+	for _, p := range req.Payments {
+		if p.Amount <= r.Threshold {
+			continue
+		}
+		d, err := r.evaluateAccount(ctx, p.Source)
+		if err != nil {
+			return nil, err
+		}
+		if d != nil {
+			d.OperationIndex = p.OperationIndex
+			return d, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r KYC) evaluateAccount(ctx context.Context, hcnetAddress string) (*Decision, error) {
+	intendedCallbackID := uuid.New().String()
+	const q = `
+		WITH new_row AS (
+			INSERT INTO accounts_kyc_status (hcnet_address, callback_id)
+			VALUES ($1, $2)
+			ON CONFLICT(hcnet_address) DO NOTHING
+			RETURNING *
+		)
+		SELECT callback_id, approved_at, rejected_at, pending_at FROM new_row
+		UNION
+		SELECT callback_id, approved_at, rejected_at, pending_at
+		FROM accounts_kyc_status
+		WHERE hcnet_address = $1
+	`
+	var (
+		callbackID                        string
+		approvedAt, rejectedAt, pendingAt sql.NullTime
+	)
+	err := r.DB.QueryRowContext(ctx, q, hcnetAddress, intendedCallbackID).Scan(&callbackID, &approvedAt, &rejectedAt, &pendingAt)
+	if err != nil {
+		return nil, errors.Wrap(err, "inserting new row into accounts_kyc_status table")
+	}
+
+	if approvedAt.Valid {
+		return nil, nil
+	}
+
+	threshold := readableAmount(r.Threshold)
+	if rejectedAt.Valid {
+		return &Decision{
+			Outcome: OutcomeRejected,
+			Message: fmt.Sprintf("Your KYC was rejected and you're not authorized for operations above %s %s.", threshold, r.AssetCode),
+		}, nil
+	}
+
+	if pendingAt.Valid {
+		return &Decision{
+			Outcome: OutcomePending,
+			Message: fmt.Sprintf("Your account could not be verified as approved nor rejected and was marked as pending. You will need staff authorization for operations above %s %s.", threshold, r.AssetCode),
+		}, nil
+	}
+
+	return &Decision{
+		Outcome:      OutcomeActionRequired,
+		Message:      fmt.Sprintf(`Payments exceeding %s %s require KYC approval. Please provide an email address.`, threshold, r.AssetCode),
+		ActionURL:    fmt.Sprintf("%s/kyc-status/%s", r.BaseURL, callbackID),
+		ActionFields: []string{"email_address"},
+	}, nil
+}
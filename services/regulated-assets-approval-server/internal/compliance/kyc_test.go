@@ -0,0 +1,108 @@
+package compliance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shantanu-hashcash/go/amount"
+	"github.com/shantanu-hashcash/go/keypair"
+	"github.com/shantanu-hashcash/go/services/regulated-assets-approval-server/internal/db/dbtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKYC(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.Open(t)
+	defer db.Close()
+	conn := db.Open()
+	defer conn.Close()
+
+	kycThreshold, err := amount.ParseInt64("500")
+	require.NoError(t, err)
+	rule := KYC{
+		DB:        conn,
+		AssetCode: "FOO",
+		Threshold: kycThreshold,
+		BaseURL:   "https://example.com",
+	}
+
+	// payments up to the the threshold won't trigger "action_required"
+	clientKP := keypair.MustRandom()
+	req := Request{Payments: []Payment{{OperationIndex: 0, Source: clientKP.Address(), Amount: kycThreshold}}}
+	d, err := rule.Evaluate(ctx, req)
+	require.NoError(t, err)
+	assert.Nil(t, d)
+
+	// payments greater than the threshold will trigger "action_required"
+	req.Payments = append(req.Payments, Payment{OperationIndex: 1, Source: clientKP.Address(), Amount: kycThreshold + 1})
+	d, err = rule.Evaluate(ctx, req)
+	require.NoError(t, err)
+
+	var callbackID string
+	q := `SELECT callback_id FROM accounts_kyc_status WHERE hcnet_address = $1`
+	err = conn.QueryRowContext(ctx, q, clientKP.Address()).Scan(&callbackID)
+	require.NoError(t, err)
+
+	wantDecision := &Decision{
+		Outcome:        OutcomeActionRequired,
+		OperationIndex: 1,
+		Message:        "Payments exceeding 500.00 FOO require KYC approval. Please provide an email address.",
+		ActionURL:      "https://example.com/kyc-status/" + callbackID,
+		ActionFields:   []string{"email_address"},
+	}
+	require.Equal(t, wantDecision, d)
+
+	// if KYC was previously approved, the rule has no objection
+	q = `
+		UPDATE accounts_kyc_status
+		SET 
+			approved_at = NOW(),
+			rejected_at = NULL,
+			pending_at = NULL
+		WHERE hcnet_address = $1
+	`
+	_, err = conn.ExecContext(ctx, q, clientKP.Address())
+	require.NoError(t, err)
+	d, err = rule.Evaluate(ctx, req)
+	require.NoError(t, err)
+	require.Nil(t, d)
+
+	// if KYC was previously rejected, the payment is rejected
+	q = `
+		UPDATE accounts_kyc_status
+		SET 
+			approved_at = NULL,
+			rejected_at = NOW(),
+			pending_at = NULL
+		WHERE hcnet_address = $1
+	`
+	_, err = conn.ExecContext(ctx, q, clientKP.Address())
+	require.NoError(t, err)
+	d, err = rule.Evaluate(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, &Decision{
+		Outcome:        OutcomeRejected,
+		OperationIndex: 1,
+		Message:        "Your KYC was rejected and you're not authorized for operations above 500.00 FOO.",
+	}, d)
+
+	// if KYC was previously marked as pending, the payment is pending
+	q = `
+		UPDATE accounts_kyc_status
+		SET 
+			approved_at = NULL,
+			rejected_at = NULL,
+			pending_at = NOW()
+		WHERE hcnet_address = $1
+	`
+	_, err = conn.ExecContext(ctx, q, clientKP.Address())
+	require.NoError(t, err)
+	d, err = rule.Evaluate(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, &Decision{
+		Outcome:        OutcomePending,
+		OperationIndex: 1,
+		Message:        "Your account could not be verified as approved nor rejected and was marked as pending. You will need staff authorization for operations above 500.00 FOO.",
+	}, d)
+}
@@ -0,0 +1,116 @@
+package compliance
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shantanu-hashcash/go/amount"
+)
+
+// AmountLimit rejects payments above MaxAmount.
+type AmountLimit struct {
+	AssetCode string
+	MaxAmount int64
+}
+
+// Evaluate implements Rule.
+func (r AmountLimit) Evaluate(ctx context.Context, req Request) (*Decision, error) {
+	for _, p := range req.Payments {
+		if p.Amount > r.MaxAmount {
+			return &Decision{
+				Outcome:        OutcomeRejected,
+				OperationIndex: p.OperationIndex,
+				Message:        fmt.Sprintf("Payments exceeding %s %s are not allowed.", readableAmount(r.MaxAmount), r.AssetCode),
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+// Sanctions rejects payments sent from or to one of Addresses.
+type Sanctions struct {
+	Addresses map[string]bool
+}
+
+// Evaluate implements Rule.
+func (r Sanctions) Evaluate(ctx context.Context, req Request) (*Decision, error) {
+	for _, p := range req.Payments {
+		if r.Addresses[p.Source] || r.Addresses[p.Destination] {
+			return &Decision{
+				Outcome:        OutcomeRejected,
+				OperationIndex: p.OperationIndex,
+				Message:        "The payment involves an account that is not allowed to hold this asset.",
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+// CounterpartyAllowlist restricts the destinations accounts can pay.
+type CounterpartyAllowlist struct {
+	// Allowlist maps source accounts to the destinations they can pay.
+	Allowlist map[string]map[string]bool
+	// RestrictUnlisted rejects payments from accounts that are not in
+	// Allowlist. When false those accounts can pay any destination.
+	RestrictUnlisted bool
+}
+
+// Evaluate implements Rule.
+func (r CounterpartyAllowlist) Evaluate(ctx context.Context, req Request) (*Decision, error) {
+	for _, p := range req.Payments {
+		allowed, ok := r.Allowlist[p.Source]
+		if !ok && !r.RestrictUnlisted {
+			continue
+		}
+		if !allowed[p.Destination] {
+			return &Decision{
+				Outcome:        OutcomeRejected,
+				OperationIndex: p.OperationIndex,
+				Message:        fmt.Sprintf("Payments to %s are not allowed from this account.", p.Destination),
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+// Jurisdiction rejects payments between accounts flagged with a blocked
+// jurisdiction.
+type Jurisdiction struct {
+	// Accounts maps accounts to the jurisdiction, usually an ISO 3166
+	// country code, they were flagged with.
+	Accounts map[string]string
+	Blocked  map[string]bool
+	// RequireKnown sends payments involving accounts without a jurisdiction
+	// to the staff of the issuer for review.
+	RequireKnown bool
+}
+
+// Evaluate implements Rule.
+func (r Jurisdiction) Evaluate(ctx context.Context, req Request) (*Decision, error) {
+	for _, p := range req.Payments {
+		for _, account := range []string{p.Source, p.Destination} {
+			jurisdiction, ok := r.Accounts[account]
+			if !ok && r.RequireKnown {
+				return &Decision{
+					Outcome:        OutcomePending,
+					OperationIndex: p.OperationIndex,
+					Message:        fmt.Sprintf("The jurisdiction of %s is unknown and needs to be reviewed by the issuer.", account),
+				}, nil
+			}
+			if r.Blocked[jurisdiction] {
+				return &Decision{
+					Outcome:        OutcomeRejected,
+					OperationIndex: p.OperationIndex,
+					Message:        fmt.Sprintf("Payments involving accounts in jurisdiction %s are not allowed.", jurisdiction),
+				}, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// readableAmount formats an amount in stroops with two decimals, the way
+// amounts are shown to clients.
+func readableAmount(stroops int64) string {
+	return fmt.Sprintf("%.2f", float64(stroops)/float64(amount.One))
+}
@@ -0,0 +1,117 @@
+package compliance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shantanu-hashcash/go/amount"
+	"github.com/shantanu-hashcash/go/keypair"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAmountLimit(t *testing.T) {
+	ctx := context.Background()
+	rule := AmountLimit{AssetCode: "FOO", MaxAmount: int64(amount.MustParse("100"))}
+
+	req := Request{Payments: []Payment{
+		{OperationIndex: 0, Amount: rule.MaxAmount},
+	}}
+	d, err := rule.Evaluate(ctx, req)
+	require.NoError(t, err)
+	assert.Nil(t, d)
+
+	req.Payments = append(req.Payments, Payment{OperationIndex: 1, Amount: rule.MaxAmount + 1})
+	d, err = rule.Evaluate(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, &Decision{
+		Outcome:        OutcomeRejected,
+		OperationIndex: 1,
+		Message:        "Payments exceeding 100.00 FOO are not allowed.",
+	}, d)
+}
+
+func TestSanctions(t *testing.T) {
+	ctx := context.Background()
+	sanctioned := keypair.MustRandom().Address()
+	sender := keypair.MustRandom().Address()
+	receiver := keypair.MustRandom().Address()
+	rule := Sanctions{Addresses: map[string]bool{sanctioned: true}}
+
+	d, err := rule.Evaluate(ctx, Request{Payments: []Payment{{Source: sender, Destination: receiver}}})
+	require.NoError(t, err)
+	assert.Nil(t, d)
+
+	for _, p := range []Payment{
+		{OperationIndex: 2, Source: sanctioned, Destination: receiver},
+		{OperationIndex: 2, Source: sender, Destination: sanctioned},
+	} {
+		d, err = rule.Evaluate(ctx, Request{Payments: []Payment{p}})
+		require.NoError(t, err)
+		require.NotNil(t, d)
+		assert.Equal(t, OutcomeRejected, d.Outcome)
+		assert.Equal(t, 2, d.OperationIndex)
+	}
+}
+
+func TestCounterpartyAllowlist(t *testing.T) {
+	ctx := context.Background()
+	sender := keypair.MustRandom().Address()
+	allowed := keypair.MustRandom().Address()
+	other := keypair.MustRandom().Address()
+	rule := CounterpartyAllowlist{Allowlist: map[string]map[string]bool{
+		sender: {allowed: true},
+	}}
+
+	d, err := rule.Evaluate(ctx, Request{Payments: []Payment{{Source: sender, Destination: allowed}}})
+	require.NoError(t, err)
+	assert.Nil(t, d)
+
+	d, err = rule.Evaluate(ctx, Request{Payments: []Payment{{Source: sender, Destination: other}}})
+	require.NoError(t, err)
+	assert.Equal(t, &Decision{
+		Outcome: OutcomeRejected,
+		Message: "Payments to " + other + " are not allowed from this account.",
+	}, d)
+
+	// unlisted accounts can pay anyone unless RestrictUnlisted is set
+	d, err = rule.Evaluate(ctx, Request{Payments: []Payment{{Source: other, Destination: sender}}})
+	require.NoError(t, err)
+	assert.Nil(t, d)
+
+	rule.RestrictUnlisted = true
+	d, err = rule.Evaluate(ctx, Request{Payments: []Payment{{Source: other, Destination: sender}}})
+	require.NoError(t, err)
+	require.NotNil(t, d)
+	assert.Equal(t, OutcomeRejected, d.Outcome)
+}
+
+func TestJurisdiction(t *testing.T) {
+	ctx := context.Background()
+	sender := keypair.MustRandom().Address()
+	receiver := keypair.MustRandom().Address()
+	unknown := keypair.MustRandom().Address()
+	rule := Jurisdiction{
+		Accounts: map[string]string{sender: "US", receiver: "KP"},
+		Blocked:  map[string]bool{"KP": true},
+	}
+
+	d, err := rule.Evaluate(ctx, Request{Payments: []Payment{{Source: sender, Destination: unknown}}})
+	require.NoError(t, err)
+	assert.Nil(t, d)
+
+	d, err = rule.Evaluate(ctx, Request{Payments: []Payment{{Source: sender, Destination: receiver}}})
+	require.NoError(t, err)
+	assert.Equal(t, &Decision{
+		Outcome: OutcomeRejected,
+		Message: "Payments involving accounts in jurisdiction KP are not allowed.",
+	}, d)
+
+	rule.RequireKnown = true
+	d, err = rule.Evaluate(ctx, Request{Payments: []Payment{{Source: sender, Destination: unknown}}})
+	require.NoError(t, err)
+	assert.Equal(t, &Decision{
+		Outcome: OutcomePending,
+		Message: "The jurisdiction of " + unknown + " is unknown and needs to be reviewed by the issuer.",
+	}, d)
+}
@@ -0,0 +1,99 @@
+package compliance
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/shantanu-hashcash/go/support/errors"
+)
+
+// VelocityLimit limits the total amount and number of payments an account can
+// send over a rolling window. Payments count towards the limit once they are
+// approved, whether or not the client submits the transaction afterwards, and
+// payments of the evaluated transaction approved before do not count again.
+//
+// In Engine.Evaluate the limit locks the source accounts until the decision
+// is audited, so that concurrent requests from an account cannot all fit
+// within the limit.
+type VelocityLimit struct {
+	DB        *sqlx.DB
+	AssetCode string
+	Window    time.Duration
+	// MaxAmount is the maximum total amount in stroops, 0 for no limit.
+	MaxAmount int64
+	// MaxCount is the maximum number of payments, 0 for no limit.
+	MaxCount int
+}
+
+// Evaluate implements Rule.
+func (r VelocityLimit) Evaluate(ctx context.Context, req Request) (*Decision, error) {
+	type usage struct {
+		amount int64
+		count  int
+	}
+	usages := map[string]*usage{}
+	sources := []string{}
+	for _, p := range req.Payments {
+		if _, ok := usages[p.Source]; !ok {
+			usages[p.Source] = &usage{}
+			sources = append(sources, p.Source)
+		}
+	}
+
+	var db sqlx.QueryerContext = r.DB
+	if req.Tx != nil {
+		db = req.Tx
+		// Sources are locked in order so that requests sharing sources
+		// cannot deadlock.
+		sort.Strings(sources)
+		for _, source := range sources {
+			_, err := req.Tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, source)
+			if err != nil {
+				return nil, errors.Wrapf(err, "locking approved payments of %s", source)
+			}
+		}
+	}
+
+	since := time.Now().Add(-r.Window)
+	for _, source := range sources {
+		const q = `
+			SELECT COALESCE(SUM(amount), 0), COUNT(*)
+			FROM compliance_approved_payments
+			WHERE source = $1 AND created_at > $2 AND tx_hash <> $3
+		`
+		u := usages[source]
+		err := db.QueryRowxContext(ctx, q, source, since, req.TxHash).Scan(&u.amount, &u.count)
+		if err != nil {
+			return nil, errors.Wrapf(err, "querying approved payments of %s", source)
+		}
+	}
+
+	for _, p := range req.Payments {
+		u := usages[p.Source]
+		// Payments earlier in the same transaction count towards the limit.
+		u.amount += p.Amount
+		u.count++
+		if (r.MaxAmount > 0 && u.amount > r.MaxAmount) || (r.MaxCount > 0 && u.count > r.MaxCount) {
+			return &Decision{
+				Outcome:        OutcomeRejected,
+				OperationIndex: p.OperationIndex,
+				Message:        r.message(),
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r VelocityLimit) message() string {
+	switch {
+	case r.MaxAmount > 0 && r.MaxCount > 0:
+		return fmt.Sprintf("Accounts cannot send more than %s %s or %d payments every %s.", readableAmount(r.MaxAmount), r.AssetCode, r.MaxCount, r.Window)
+	case r.MaxAmount > 0:
+		return fmt.Sprintf("Accounts cannot send more than %s %s every %s.", readableAmount(r.MaxAmount), r.AssetCode, r.Window)
+	default:
+		return fmt.Sprintf("Accounts cannot send more than %d payments every %s.", r.MaxCount, r.Window)
+	}
+}
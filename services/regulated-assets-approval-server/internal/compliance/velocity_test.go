@@ -0,0 +1,169 @@
+package compliance
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shantanu-hashcash/go/keypair"
+	"github.com/shantanu-hashcash/go/services/regulated-assets-approval-server/internal/db/dbtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVelocityLimit(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.Open(t)
+	defer db.Close()
+	conn := db.Open()
+	defer conn.Close()
+
+	sender := keypair.MustRandom().Address()
+	receiver := keypair.MustRandom().Address()
+	rule := VelocityLimit{
+		DB:        conn,
+		AssetCode: "FOO",
+		Window:    time.Hour,
+		MaxAmount: 1000000000,
+		MaxCount:  3,
+	}
+
+	// payments approved outside the window don't count
+	q := `
+		INSERT INTO compliance_approved_payments (tx_hash, operation_index, source, destination, amount, created_at)
+		VALUES
+			('old', 0, $1, $2, 1000000000, NOW() - INTERVAL '2 hours'),
+			('recent', 0, $1, $2, 600000000, NOW() - INTERVAL '10 minutes')
+	`
+	_, err := conn.ExecContext(ctx, q, sender, receiver)
+	require.NoError(t, err)
+
+	d, err := rule.Evaluate(ctx, Request{Payments: []Payment{
+		{OperationIndex: 0, Source: sender, Destination: receiver, Amount: 400000000},
+	}})
+	require.NoError(t, err)
+	assert.Nil(t, d)
+
+	// payments of the same transaction add up
+	d, err = rule.Evaluate(ctx, Request{Payments: []Payment{
+		{OperationIndex: 0, Source: sender, Destination: receiver, Amount: 300000000},
+		{OperationIndex: 1, Source: sender, Destination: receiver, Amount: 200000000},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, &Decision{
+		Outcome:        OutcomeRejected,
+		OperationIndex: 1,
+		Message:        "Accounts cannot send more than 100.00 FOO or 3 payments every 1h0m0s.",
+	}, d)
+
+	// the count limit applies independently of the amount
+	d, err = rule.Evaluate(ctx, Request{Payments: []Payment{
+		{OperationIndex: 0, Source: sender, Destination: receiver, Amount: 1},
+		{OperationIndex: 1, Source: sender, Destination: receiver, Amount: 1},
+		{OperationIndex: 2, Source: sender, Destination: receiver, Amount: 1},
+	}})
+	require.NoError(t, err)
+	require.NotNil(t, d)
+	assert.Equal(t, 2, d.OperationIndex)
+
+	// other accounts have their own limit
+	d, err = rule.Evaluate(ctx, Request{Payments: []Payment{
+		{OperationIndex: 0, Source: receiver, Destination: sender, Amount: 1000000000},
+	}})
+	require.NoError(t, err)
+	assert.Nil(t, d)
+}
+
+func TestVelocityLimitRetry(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.Open(t)
+	defer db.Close()
+	conn := db.Open()
+	defer conn.Close()
+
+	sender := keypair.MustRandom().Address()
+	receiver := keypair.MustRandom().Address()
+	engine := Engine{
+		Rules: []NamedRule{{"velocity", VelocityLimit{
+			DB:        conn,
+			AssetCode: "FOO",
+			Window:    time.Hour,
+			MaxCount:  1,
+		}}},
+		DB: conn,
+	}
+
+	// the payments of an approved transaction don't count against it when
+	// it is submitted for approval again
+	req := Request{TxHash: "approved-tx", Payments: []Payment{
+		{OperationIndex: 0, Source: sender, Destination: receiver, Amount: 1},
+	}}
+	for i := 0; i < 2; i++ {
+		decision, err := engine.Evaluate(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, OutcomeApproved, decision.Outcome)
+	}
+
+	req.TxHash = "other-tx"
+	decision, err := engine.Evaluate(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, OutcomeRejected, decision.Outcome)
+}
+
+func TestVelocityLimitConcurrentRequests(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.Open(t)
+	defer db.Close()
+	conn := db.Open()
+	defer conn.Close()
+
+	sender := keypair.MustRandom().Address()
+	receiver := keypair.MustRandom().Address()
+	engine := Engine{
+		Rules: []NamedRule{{"velocity", VelocityLimit{
+			DB:        conn,
+			AssetCode: "FOO",
+			Window:    time.Hour,
+			MaxAmount: 100,
+			MaxCount:  3,
+		}}},
+		DB: conn,
+	}
+
+	// requests from the same account are evaluated one after the other, so
+	// that only as many are approved as fit within the limit
+	const requests = 10
+	outcomes := make(chan Outcome, requests)
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			decision, err := engine.Evaluate(ctx, Request{
+				TxHash: fmt.Sprintf("tx-%d", i),
+				Payments: []Payment{
+					{OperationIndex: 0, Source: sender, Destination: receiver, Amount: 30},
+				},
+			})
+			assert.NoError(t, err)
+			outcomes <- decision.Outcome
+		}(i)
+	}
+	wg.Wait()
+	close(outcomes)
+
+	approved := 0
+	for outcome := range outcomes {
+		if outcome == OutcomeApproved {
+			approved++
+		}
+	}
+	assert.Equal(t, 3, approved)
+
+	var amount int64
+	err := conn.QueryRowContext(ctx, `SELECT COALESCE(SUM(amount), 0) FROM compliance_approved_payments WHERE source = $1`, sender).Scan(&amount)
+	require.NoError(t, err)
+	assert.Equal(t, int64(90), amount)
+}
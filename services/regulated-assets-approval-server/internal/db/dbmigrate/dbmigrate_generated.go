@@ -3,6 +3,7 @@
 // migrations/2021-05-05.0.initial.sql (162B)
 // migrations/2021-05-18.0.accounts-kyc-status.sql (414B)
 // migrations/2021-06-08.0.pending-kyc-status.sql (193B)
+// migrations/2024-06-15.0.compliance-decisions.sql (923B)
 
 package dbmigrate
 
@@ -131,6 +132,26 @@ func migrations202106080PendingKycStatusSql() (*asset, error) {
 	return a, nil
 }
 
+var _migrations202406150ComplianceDecisionsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xad\x93\xc1\x6e\x83\x30\x0c\x86\xef\x79\x0a\x1f\x5b\xad\xdd\x0b\xf4\xc4\x06\x93\xaa\x31\xa8\x50\xab\xad\xa7\x28\x05\x0b\x22\x41\x12\x25\x61\x65\x7b\xfa\xa5\xa5\x63\xa8\x4b\xd1\x0e\xcb\x2d\xf9\x6d\xc7\xff\xe7\x64\xb9\x84\xbb\x86\x97\x9a\x59\x84\x9d\x22\xe4\x31\x8b\x82\x6d\x04\xdb\xe0\x21\x8e\x40\xb5\x87\x9a\xe7\xf7\xb9\x6c\x54\xcd\x99\xc8\x91\x16\x98\x73\xc3\xa5\x30\x30\x23\xe0\x16\x2f\xe0\xc0\x4b\x83\x9a\xb3\x1a\x36\xd9\xfa\x25\xc8\xf6\xf0\x1c\xed\x17\x67\xd5\x76\xb4\x62\xa6\x02\x8b\x9d\x85\x24\xdd\x42\xb2\x8b\xe3\x5e\x92\xad\x75\x65\xd1\x27\xe9\xb6\xf6\x9e\x4b\x85\xae\x4d\x77\x39\xe5\xa2\xc0\x0e\xb8\xb0\x58\xa2\xee\xc5\x06\x8d\x61\xa5\x37\x2f\xd7\xe8\xdc\x15\x94\x59\xb0\xdc\xc5\x59\xd6\x28\x38\x72\x5b\x9d\xb7\xf0\x29\x05\x0e\x19\x10\x46\x4f\xc1\x2e\x76\x9b\xf4\x75\x36\x27\xf3\xd5\x40\x64\x9d\x84\xd1\x1b\xf8\x50\xd0\x8b\x4d\xca\x8b\x0e\xd2\x64\x9a\xda\x25\x76\x54\xf8\x16\x6a\xa6\x94\x96\xef\xae\x6f\xc5\x3e\x1a\x14\xf6\x1b\xf9\x14\x54\x3f\xa1\xab\x28\x23\x5b\x9d\x7b\x49\x15\x0e\x0e\x17\xe7\x0a\x3e\x99\x35\xb2\x15\xf6\x34\x70\x57\xf8\xdf\x20\xf7\xf9\xa3\xb7\x33\x40\x5a\x5c\x1b\x9a\x1e\xc8\x2f\x60\xb4\x77\x4a\x7f\x7a\xbb\x3d\x22\x0f\xed\x3e\x7b\x31\xb2\x76\xba\x7d\x39\xfa\x30\xa1\x3c\x0a\x42\xc2\x2c\xdd\xfc\x7d\x8a\xab\xe9\xf8\xe1\xa9\xac\xc8\x17\x75\x12\x67\x14\x9b\x03\x00\x00")
+
+func migrations202406150ComplianceDecisionsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations202406150ComplianceDecisionsSql,
+		"migrations/2024-06-15.0.compliance-decisions.sql",
+	)
+}
+
+func migrations202406150ComplianceDecisionsSql() (*asset, error) {
+	bytes, err := migrations202406150ComplianceDecisionsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/2024-06-15.0.compliance-decisions.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xe, 0x6d, 0x6d, 0xcc, 0xbb, 0xb8, 0x0, 0x71, 0x1a, 0x32, 0x46, 0xdd, 0xd, 0xca, 0xf, 0x52, 0x69, 0x48, 0x67, 0xb5, 0xb, 0x3c, 0xdf, 0xc2, 0x2f, 0x2e, 0xbd, 0x28, 0x3d, 0xcf, 0x13, 0xb0}}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -222,9 +243,10 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
-	"migrations/2021-05-05.0.initial.sql":             migrations202105050InitialSql,
-	"migrations/2021-05-18.0.accounts-kyc-status.sql": migrations202105180AccountsKycStatusSql,
-	"migrations/2021-06-08.0.pending-kyc-status.sql":  migrations202106080PendingKycStatusSql,
+	"migrations/2021-05-05.0.initial.sql":              migrations202105050InitialSql,
+	"migrations/2021-05-18.0.accounts-kyc-status.sql":  migrations202105180AccountsKycStatusSql,
+	"migrations/2021-06-08.0.pending-kyc-status.sql":   migrations202106080PendingKycStatusSql,
+	"migrations/2024-06-15.0.compliance-decisions.sql": migrations202406150ComplianceDecisionsSql,
 }
 
 // AssetDir returns the file names below a certain
@@ -271,9 +293,10 @@ type bintree struct {
 
 var _bintree = &bintree{nil, map[string]*bintree{
 	"migrations": {nil, map[string]*bintree{
-		"2021-05-05.0.initial.sql":             {migrations202105050InitialSql, map[string]*bintree{}},
-		"2021-05-18.0.accounts-kyc-status.sql": {migrations202105180AccountsKycStatusSql, map[string]*bintree{}},
-		"2021-06-08.0.pending-kyc-status.sql":  {migrations202106080PendingKycStatusSql, map[string]*bintree{}},
+		"2021-05-05.0.initial.sql":              {migrations202105050InitialSql, map[string]*bintree{}},
+		"2021-05-18.0.accounts-kyc-status.sql":  {migrations202105180AccountsKycStatusSql, map[string]*bintree{}},
+		"2021-06-08.0.pending-kyc-status.sql":   {migrations202106080PendingKycStatusSql, map[string]*bintree{}},
+		"2024-06-15.0.compliance-decisions.sql": {migrations202406150ComplianceDecisionsSql, map[string]*bintree{}},
 	}},
 }}
 
@@ -0,0 +1,30 @@
+-- +migrate Up
+
+CREATE TABLE public.compliance_decisions (
+    id bigserial PRIMARY KEY,
+    tx_hash text NOT NULL,
+    outcome text NOT NULL,
+    rule text NOT NULL,
+    operation_index integer,
+    message text NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX compliance_decisions_tx_hash_idx ON public.compliance_decisions (tx_hash);
+
+CREATE TABLE public.compliance_approved_payments (
+    tx_hash text NOT NULL,
+    operation_index integer NOT NULL,
+    source text NOT NULL,
+    destination text NOT NULL,
+    amount bigint NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tx_hash, operation_index)
+);
+
+CREATE INDEX compliance_approved_payments_source_created_at_idx ON public.compliance_approved_payments (source, created_at);
+
+-- +migrate Down
+
+DROP TABLE public.compliance_approved_payments;
+DROP TABLE public.compliance_decisions;
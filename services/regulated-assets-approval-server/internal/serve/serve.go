@@ -12,6 +12,7 @@ import (
 	"github.com/shantanu-hashcash/go/amount"
 	"github.com/shantanu-hashcash/go/clients/auroraclient"
 	"github.com/shantanu-hashcash/go/keypair"
+	"github.com/shantanu-hashcash/go/services/regulated-assets-approval-server/internal/compliance"
 	"github.com/shantanu-hashcash/go/services/regulated-assets-approval-server/internal/db"
 	"github.com/shantanu-hashcash/go/services/regulated-assets-approval-server/internal/serve/kycstatus"
 	"github.com/shantanu-hashcash/go/support/errors"
@@ -30,6 +31,7 @@ type Options struct {
 	KYCRequiredPaymentAmountThreshold string
 	NetworkPassphrase                 string
 	Port                              int
+	RulesFile                         string
 }
 
 func Serve(opts Options) {
@@ -71,6 +73,21 @@ func handleHTTP(opts Options) http.Handler {
 	if err != nil {
 		log.Warn("Error pinging to Database: ", err)
 	}
+	var complianceRules []compliance.NamedRule
+	if opts.RulesFile != "" {
+		rulesConfig, err := compliance.ReadConfig(opts.RulesFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		complianceRules, err = rulesConfig.BuildRules(compliance.Dependencies{
+			DB:        db,
+			AssetCode: opts.AssetCode,
+			BaseURL:   opts.BaseURL,
+		})
+		if err != nil {
+			log.Fatal(errors.Wrapf(err, "building rules from %s", opts.RulesFile))
+		}
+	}
 	mux := chi.NewMux()
 
 	mux.Use(middleware.RequestID)
@@ -102,6 +119,7 @@ func handleHTTP(opts Options) http.Handler {
 		db:                db,
 		kycThreshold:      parsedKYCRequiredPaymentThreshold,
 		baseURL:           opts.BaseURL,
+		rules:             complianceRules,
 	}.ServeHTTP)
 	mux.Route("/kyc-status", func(mux chi.Router) {
 		mux.Post("/{callback_id}", kycstatus.PostHandler{
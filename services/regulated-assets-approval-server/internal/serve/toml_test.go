@@ -114,6 +114,6 @@ code="FOO"
 issuer="GCVDOU4YHHXGM3QYVSDHPQIFMZKXTFSIYO4HJOJZOTR7GURVQO6IQ5HM"
 regulated=true
 approval_server="localhost:8000/tx-approve"
-approval_criteria="The approval server currently only accepts payments. The transaction must have exactly one operation of type payment. If the payment amount exceeds 500.00 FOO it will need KYC approval if the account hasn’t been previously approved."`
+approval_criteria="The approval server currently only accepts payments. The transaction must only contain operations of type payment. If the payment amount exceeds 500.00 FOO it will need KYC approval if the account hasn’t been previously approved."`
 	require.Equal(t, wantBody, string(body))
 }
@@ -2,16 +2,15 @@ package serve
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
 	"net/http"
 	"strconv"
 
-	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/shantanu-hashcash/go/amount"
 	"github.com/shantanu-hashcash/go/clients/auroraclient"
 	"github.com/shantanu-hashcash/go/keypair"
+	"github.com/shantanu-hashcash/go/services/regulated-assets-approval-server/internal/compliance"
 	"github.com/shantanu-hashcash/go/services/regulated-assets-approval-server/internal/serve/httperror"
 	"github.com/shantanu-hashcash/go/support/errors"
 	"github.com/shantanu-hashcash/go/support/http/httpdecode"
@@ -19,6 +18,9 @@ import (
 	"github.com/shantanu-hashcash/go/txnbuild"
 )
 
+// maxOperations is the maximum number of operations a transaction can have.
+const maxOperations = 100
+
 type txApproveHandler struct {
 	issuerKP          *keypair.Full
 	assetCode         string
@@ -27,6 +29,10 @@ type txApproveHandler struct {
 	db                *sqlx.DB
 	kycThreshold      int64
 	baseURL           string
+	// rules are the compliance rules payments are evaluated against. When
+	// nil, the source accounts of payments above kycThreshold need their KYC
+	// approved.
+	rules []compliance.NamedRule
 }
 
 type txApproveRequest struct {
@@ -141,6 +147,11 @@ func (h txApproveHandler) txApprove(ctx context.Context, in txApproveRequest) (r
 		return rejectedResponse, nil
 	}
 
+	if len(tx.Operations()) == 0 {
+		log.Ctx(ctx).Error("transaction contains no operations")
+		return NewRejectedTxApprovalResponse("Please submit a transaction with at least one operation of type payment."), nil
+	}
+
 	txSuccessResp, err := h.handleSuccessResponseIfNeeded(ctx, tx)
 	if err != nil {
 		return nil, errors.Wrap(err, "checking if transaction in request was compliant")
@@ -149,35 +160,21 @@ func (h txApproveHandler) txApprove(ctx context.Context, in txApproveRequest) (r
 		return txSuccessResp, nil
 	}
 
-	// validate the revisable transaction has one operation.
-	if len(tx.Operations()) != 1 {
-		return NewRejectedTxApprovalResponse("Please submit a transaction with exactly one operation of type payment."), nil
-	}
-
-	paymentOp, ok := tx.Operations()[0].(*txnbuild.Payment)
-	if !ok {
-		log.Ctx(ctx).Error("transaction does not contain a payment operation")
+	// validate the revisable transaction only contains payments.
+	payments := transactionPayments(tx)
+	if len(payments) != len(tx.Operations()) {
+		log.Ctx(ctx).Error("transaction contains operations that are not payments")
 		return NewRejectedTxApprovalResponse("There is one or more unauthorized operations in the provided transaction."), nil
 	}
-	paymentSource := paymentOp.SourceAccount
-	if paymentSource == "" {
-		paymentSource = tx.SourceAccount().AccountID
-	}
-
-	if paymentOp.Destination == h.issuerKP.Address() {
-		return NewRejectedTxApprovalResponse("Can't transfer asset to its issuer."), nil
-	}
 
-	// validate payment asset is the one supported by the issuer
-	issuerAddress := h.issuerKP.Address()
-	if paymentOp.Asset.GetCode() != h.assetCode || paymentOp.Asset.GetIssuer() != issuerAddress {
-		log.Ctx(ctx).Error(`the payment asset is not supported by this issuer`)
-		return NewRejectedTxApprovalResponse("The payment asset is not supported by this issuer."), nil
+	rejectedResponse = h.validatePayments(ctx, payments)
+	if rejectedResponse != nil {
+		return rejectedResponse, nil
 	}
 
-	acc, err := h.auroraClient.AccountDetail(auroraclient.AccountRequest{AccountID: paymentSource})
+	acc, err := h.auroraClient.AccountDetail(auroraclient.AccountRequest{AccountID: tx.SourceAccount().AccountID})
 	if err != nil {
-		return nil, errors.Wrapf(err, "getting detail for payment source account %s", paymentSource)
+		return nil, errors.Wrapf(err, "getting detail for transaction source account %s", tx.SourceAccount().AccountID)
 	}
 
 	// validate the sequence number
@@ -186,42 +183,20 @@ func (h txApproveHandler) txApprove(ctx context.Context, in txApproveRequest) (r
 		return NewRejectedTxApprovalResponse("Invalid transaction sequence number."), nil
 	}
 
-	actionRequiredResponse, err := h.handleActionRequiredResponseIfNeeded(ctx, paymentSource, paymentOp)
+	revisedOperations := compliantOperations(payments, h.issuerKP.Address())
+	if len(revisedOperations) > maxOperations {
+		return NewRejectedTxApprovalResponse("Too many payments in the provided transaction."), nil
+	}
+
+	complianceResponse, err := h.evaluateCompliance(ctx, tx, payments)
 	if err != nil {
-		return nil, errors.Wrap(err, "handling KYC required payment")
+		return nil, errors.Wrap(err, "evaluating compliance rules")
 	}
-	if actionRequiredResponse != nil {
-		return actionRequiredResponse, nil
+	if complianceResponse != nil {
+		return complianceResponse, nil
 	}
 
 	// build the transaction
-	revisedOperations := []txnbuild.Operation{
-		&txnbuild.AllowTrust{
-			Trustor:       paymentSource,
-			Type:          paymentOp.Asset,
-			Authorize:     true,
-			SourceAccount: issuerAddress,
-		},
-		&txnbuild.AllowTrust{
-			Trustor:       paymentOp.Destination,
-			Type:          paymentOp.Asset,
-			Authorize:     true,
-			SourceAccount: issuerAddress,
-		},
-		paymentOp,
-		&txnbuild.AllowTrust{
-			Trustor:       paymentOp.Destination,
-			Type:          paymentOp.Asset,
-			Authorize:     false,
-			SourceAccount: issuerAddress,
-		},
-		&txnbuild.AllowTrust{
-			Trustor:       paymentSource,
-			Type:          paymentOp.Asset,
-			Authorize:     false,
-			SourceAccount: issuerAddress,
-		},
-	}
 	revisedTx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
 		SourceAccount:        &acc,
 		IncrementSequenceNum: true,
@@ -245,96 +220,103 @@ func (h txApproveHandler) txApprove(ctx context.Context, in txApproveRequest) (r
 	return NewRevisedTxApprovalResponse(txe), nil
 }
 
-// handleActionRequiredResponseIfNeeded validates and returns an action_required
-// response if the payment requires KYC.
-func (h txApproveHandler) handleActionRequiredResponseIfNeeded(ctx context.Context, hcnetAddress string, paymentOp *txnbuild.Payment) (*txApprovalResponse, error) {
-	paymentAmount, err := amount.ParseInt64(paymentOp.Amount)
-	if err != nil {
-		return nil, errors.Wrap(err, "parsing payment amount from string to Int64")
-	}
-	if paymentAmount <= h.kycThreshold {
-		return nil, nil
-	}
+// complianceEngine returns the engine payments are evaluated with.
+func (h txApproveHandler) complianceEngine() compliance.Engine {
+	rules := h.rules
+	if rules == nil {
+		rules = []compliance.NamedRule{{
+			Name: compliance.RuleTypeKYC,
+			Rule: compliance.KYC{
+				DB:        h.db,
+				AssetCode: h.assetCode,
+				Threshold: h.kycThreshold,
+				BaseURL:   h.baseURL,
+			},
+		}}
+	}
+	return compliance.Engine{Rules: rules, DB: h.db}
+}
 
-	intendedCallbackID := uuid.New().String()
-	const q = `
-		WITH new_row AS (
-			INSERT INTO accounts_kyc_status (hcnet_address, callback_id)
-			VALUES ($1, $2)
-			ON CONFLICT(hcnet_address) DO NOTHING
-			RETURNING *
-		)
-		SELECT callback_id, approved_at, rejected_at, pending_at FROM new_row
-		UNION
-		SELECT callback_id, approved_at, rejected_at, pending_at
-		FROM accounts_kyc_status
-		WHERE hcnet_address = $1
-	`
-	var (
-		callbackID                        string
-		approvedAt, rejectedAt, pendingAt sql.NullTime
-	)
-	err = h.db.QueryRowContext(ctx, q, hcnetAddress, intendedCallbackID).Scan(&callbackID, &approvedAt, &rejectedAt, &pendingAt)
+// evaluateCompliance evaluates the payments against the compliance rules and
+// returns a response if the transaction cannot be approved as is.
+func (h txApproveHandler) evaluateCompliance(ctx context.Context, tx *txnbuild.Transaction, payments []regulatedPayment) (*txApprovalResponse, error) {
+	txHash, err := tx.HashHex(h.networkPassphrase)
 	if err != nil {
-		return nil, errors.Wrap(err, "inserting new row into accounts_kyc_status table")
+		return nil, errors.Wrap(err, "hashing transaction")
 	}
 
-	if approvedAt.Valid {
-		return nil, nil
+	req := compliance.Request{TxHash: txHash}
+	for _, p := range payments {
+		paymentAmount, err := amount.ParseInt64(p.op.Amount)
+		if err != nil {
+			return nil, errors.Wrap(err, "parsing payment amount from string to Int64")
+		}
+		req.Payments = append(req.Payments, compliance.Payment{
+			OperationIndex: p.index,
+			Source:         p.source,
+			Destination:    p.op.Destination,
+			Amount:         paymentAmount,
+		})
 	}
 
-	kycThreshold, err := convertAmountToReadableString(h.kycThreshold)
+	decision, err := h.complianceEngine().Evaluate(ctx, req)
 	if err != nil {
-		return nil, errors.Wrap(err, "converting kycThreshold to human readable string")
-	}
-
-	if rejectedAt.Valid {
-		return NewRejectedTxApprovalResponse(fmt.Sprintf("Your KYC was rejected and you're not authorized for operations above %s %s.", kycThreshold, h.assetCode)), nil
+		return nil, err
 	}
+	log.Ctx(ctx).WithFields(log.F{
+		"tx_hash": txHash,
+		"outcome": decision.Outcome,
+		"rule":    decision.Rule,
+	}).Info("Evaluated compliance rules")
 
-	if pendingAt.Valid {
-		return NewPendingTxApprovalResponse(fmt.Sprintf("Your account could not be verified as approved nor rejected and was marked as pending. You will need staff authorization for operations above %s %s.", kycThreshold, h.assetCode)), nil
+	switch decision.Outcome {
+	case compliance.OutcomeApproved:
+		return nil, nil
+	case compliance.OutcomeRejected:
+		return NewRejectedTxApprovalResponse(decision.Message), nil
+	case compliance.OutcomePending:
+		return NewPendingTxApprovalResponse(decision.Message), nil
+	case compliance.OutcomeActionRequired:
+		return NewActionRequiredTxApprovalResponse(decision.Message, decision.ActionURL, decision.ActionFields), nil
+	default:
+		return nil, errors.Errorf("unknown compliance outcome %q", decision.Outcome)
 	}
-
-	return NewActionRequiredTxApprovalResponse(
-		fmt.Sprintf(`Payments exceeding %s %s require KYC approval. Please provide an email address.`, kycThreshold, h.assetCode),
-		fmt.Sprintf("%s/kyc-status/%s", h.baseURL, callbackID),
-		[]string{"email_address"},
-	), nil
 }
 
 // handleSuccessResponseIfNeeded inspects the incoming transaction and returns a
 // "success" response if it's already compliant with the SEP-8 authorization spec.
 func (h txApproveHandler) handleSuccessResponseIfNeeded(ctx context.Context, tx *txnbuild.Transaction) (*txApprovalResponse, error) {
-	if len(tx.Operations()) != 5 {
+	// transactions that only contain payments need to be revised.
+	if len(tx.Operations()) < 5 || len(transactionPayments(tx)) == len(tx.Operations()) {
 		return nil, nil
 	}
 
-	rejectedResp, paymentOp, paymentSource := validateTransactionOperationsForSuccess(ctx, tx, h.issuerKP.Address())
+	rejectedResp, payments := validateTransactionOperationsForSuccess(ctx, tx, h.issuerKP.Address())
 	if rejectedResp != nil {
 		return rejectedResp, nil
 	}
 
-	if paymentOp.Destination == h.issuerKP.Address() {
-		return NewRejectedTxApprovalResponse("Can't transfer asset to its issuer."), nil
+	rejectedResp = h.validatePayments(ctx, payments)
+	if rejectedResp != nil {
+		return rejectedResp, nil
 	}
 
 	// pull current account details from the network then validate the tx sequence number
-	acc, err := h.auroraClient.AccountDetail(auroraclient.AccountRequest{AccountID: paymentSource})
+	acc, err := h.auroraClient.AccountDetail(auroraclient.AccountRequest{AccountID: tx.SourceAccount().AccountID})
 	if err != nil {
-		return nil, errors.Wrapf(err, "getting detail for payment source account %s", paymentSource)
+		return nil, errors.Wrapf(err, "getting detail for transaction source account %s", tx.SourceAccount().AccountID)
 	}
 	if tx.SourceAccount().Sequence != acc.Sequence+1 {
 		log.Ctx(ctx).Errorf(`invalid transaction sequence number tx.SourceAccount().Sequence: %d, accountSequence+1: %d`, tx.SourceAccount().Sequence, acc.Sequence+1)
 		return NewRejectedTxApprovalResponse("Invalid transaction sequence number."), nil
 	}
 
-	kycRequiredResponse, err := h.handleActionRequiredResponseIfNeeded(ctx, paymentSource, paymentOp)
+	complianceResponse, err := h.evaluateCompliance(ctx, tx, payments)
 	if err != nil {
-		return nil, errors.Wrap(err, "handling KYC required payment")
+		return nil, errors.Wrap(err, "evaluating compliance rules")
 	}
-	if kycRequiredResponse != nil {
-		return kycRequiredResponse, nil
+	if complianceResponse != nil {
+		return complianceResponse, nil
 	}
 
 	// sign transaction with issuer's signature and encode it
@@ -350,75 +332,125 @@ func (h txApproveHandler) handleSuccessResponseIfNeeded(ctx context.Context, tx
 	return NewSuccessTxApprovalResponse(txe, "Transaction is compliant and signed by the issuer."), nil
 }
 
-// validateTransactionOperationsForSuccess checks if the incoming transaction
-// operations are compliant with the anchor's SEP-8 policy.
-func validateTransactionOperationsForSuccess(ctx context.Context, tx *txnbuild.Transaction, issuerAddress string) (resp *txApprovalResponse, paymentOp *txnbuild.Payment, paymentSource string) {
-	if len(tx.Operations()) != 5 {
-		return NewRejectedTxApprovalResponse("Unsupported number of operations."), nil, ""
-	}
+// validatePayments checks that the payments transfer the regulated asset
+// between accounts other than the issuer.
+func (h txApproveHandler) validatePayments(ctx context.Context, payments []regulatedPayment) *txApprovalResponse {
+	issuerAddress := h.issuerKP.Address()
+	for _, p := range payments {
+		if p.op.Destination == issuerAddress {
+			return NewRejectedTxApprovalResponse("Can't transfer asset to its issuer.")
+		}
 
-	// extract the payment operation and payment source account.
-	paymentOp, ok := tx.Operations()[2].(*txnbuild.Payment)
-	if !ok {
-		log.Ctx(ctx).Error(`third operation is not of type payment`)
-		return NewRejectedTxApprovalResponse("There are one or more unexpected operations in the provided transaction."), nil, ""
-	}
-	paymentSource = paymentOp.SourceAccount
-	if paymentSource == "" {
-		paymentSource = tx.SourceAccount().AccountID
+		// validate payment asset is the one supported by the issuer
+		if p.op.Asset.GetCode() != h.assetCode || p.op.Asset.GetIssuer() != issuerAddress {
+			log.Ctx(ctx).Error(`the payment asset is not supported by this issuer`)
+			return NewRejectedTxApprovalResponse("The payment asset is not supported by this issuer.")
+		}
 	}
+	return nil
+}
 
-	assetCode := paymentOp.Asset.GetCode()
+// regulatedPayment is a payment operation of a transaction and the account
+// the payment is sent from.
+type regulatedPayment struct {
+	index  int
+	op     *txnbuild.Payment
+	source string
+}
 
-	operationsValid := func() bool {
-		op0, ok := tx.Operations()[0].(*txnbuild.AllowTrust)
-		if !ok ||
-			op0.Trustor != paymentSource ||
-			op0.Type.GetCode() != assetCode ||
-			!op0.Authorize ||
-			op0.SourceAccount != issuerAddress {
-			return false
+// transactionPayments returns the payment operations of tx.
+func transactionPayments(tx *txnbuild.Transaction) []regulatedPayment {
+	payments := []regulatedPayment{}
+	for i, op := range tx.Operations() {
+		paymentOp, ok := op.(*txnbuild.Payment)
+		if !ok {
+			continue
 		}
-
-		op1, ok := tx.Operations()[1].(*txnbuild.AllowTrust)
-		if !ok ||
-			op1.Trustor != paymentOp.Destination ||
-			op1.Type.GetCode() != assetCode ||
-			!op1.Authorize ||
-			op1.SourceAccount != issuerAddress {
-			return false
+		paymentSource := paymentOp.SourceAccount
+		if paymentSource == "" {
+			paymentSource = tx.SourceAccount().AccountID
 		}
+		payments = append(payments, regulatedPayment{index: i, op: paymentOp, source: paymentSource})
+	}
+	return payments
+}
 
-		op2, ok := tx.Operations()[2].(*txnbuild.Payment)
-		if !ok || op2 != paymentOp {
-			return false
+// compliantOperations wraps payments with the operations the SEP-8 policy of
+// the issuer requires: every account involved is authorized before the
+// payments and deauthorized, in reverse order, after them.
+func compliantOperations(payments []regulatedPayment, issuerAddress string) []txnbuild.Operation {
+	asset := payments[0].op.Asset
+	accounts := []string{}
+	seen := map[string]bool{}
+	for _, p := range payments {
+		for _, account := range []string{p.source, p.op.Destination} {
+			if !seen[account] {
+				seen[account] = true
+				accounts = append(accounts, account)
+			}
 		}
+	}
 
-		op3, ok := tx.Operations()[3].(*txnbuild.AllowTrust)
-		if !ok ||
-			op3.Trustor != paymentOp.Destination ||
-			op3.Type.GetCode() != assetCode ||
-			op3.Authorize ||
-			op3.SourceAccount != issuerAddress {
-			return false
-		}
+	operations := make([]txnbuild.Operation, 0, 2*len(accounts)+len(payments))
+	for _, account := range accounts {
+		operations = append(operations, &txnbuild.AllowTrust{
+			Trustor:       account,
+			Type:          asset,
+			Authorize:     true,
+			SourceAccount: issuerAddress,
+		})
+	}
+	for _, p := range payments {
+		operations = append(operations, p.op)
+	}
+	for i := len(accounts) - 1; i >= 0; i-- {
+		operations = append(operations, &txnbuild.AllowTrust{
+			Trustor:       accounts[i],
+			Type:          asset,
+			Authorize:     false,
+			SourceAccount: issuerAddress,
+		})
+	}
+	return operations
+}
 
-		op4, ok := tx.Operations()[4].(*txnbuild.AllowTrust)
-		if !ok ||
-			op4.Trustor != paymentSource ||
-			op4.Type.GetCode() != assetCode ||
-			op4.Authorize ||
-			op4.SourceAccount != issuerAddress {
-			return false
+// validateTransactionOperationsForSuccess checks if the incoming transaction
+// operations are compliant with the anchor's SEP-8 policy.
+func validateTransactionOperationsForSuccess(ctx context.Context, tx *txnbuild.Transaction, issuerAddress string) (resp *txApprovalResponse, payments []regulatedPayment) {
+	if len(tx.Operations()) < 5 {
+		return NewRejectedTxApprovalResponse("Unsupported number of operations."), nil
+	}
+
+	unexpectedOperationsResponse := NewRejectedTxApprovalResponse("There are one or more unexpected operations in the provided transaction.")
+	payments = transactionPayments(tx)
+	if len(payments) == 0 {
+		log.Ctx(ctx).Error(`transaction does not contain payment operations`)
+		return unexpectedOperationsResponse, nil
+	}
+
+	wantOperations := compliantOperations(payments, issuerAddress)
+	if len(wantOperations) != len(tx.Operations()) {
+		return unexpectedOperationsResponse, nil
+	}
+	for i, op := range tx.Operations() {
+		switch wantOp := wantOperations[i].(type) {
+		case *txnbuild.AllowTrust:
+			allowTrustOp, ok := op.(*txnbuild.AllowTrust)
+			if !ok ||
+				allowTrustOp.Trustor != wantOp.Trustor ||
+				allowTrustOp.Type.GetCode() != wantOp.Type.GetCode() ||
+				allowTrustOp.Authorize != wantOp.Authorize ||
+				allowTrustOp.SourceAccount != wantOp.SourceAccount {
+				return unexpectedOperationsResponse, nil
+			}
+		default:
+			if op != wantOp {
+				return unexpectedOperationsResponse, nil
+			}
 		}
-
-		return true
-	}()
-	if !operationsValid {
-		return NewRejectedTxApprovalResponse("There are one or more unexpected operations in the provided transaction."), nil, ""
 	}
 
-	return nil, paymentOp, paymentSource
+	return nil, payments
 }
 
 func convertAmountToReadableString(threshold int64) (string, error) {
@@ -12,6 +12,7 @@ import (
 	"github.com/shantanu-hashcash/go/protocols/aurora"
 	"github.com/shantanu-hashcash/go/services/regulated-assets-approval-server/internal/db/dbtest"
 	"github.com/shantanu-hashcash/go/txnbuild"
+	"github.com/shantanu-hashcash/go/xdr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -217,99 +218,6 @@ func TestTxApproveHandler_validateInput(t *testing.T) {
 	require.Equal(t, gotTx, tx)
 }
 
-func TestTxApproveHandler_handleActionRequiredResponseIfNeeded(t *testing.T) {
-	ctx := context.Background()
-	db := dbtest.Open(t)
-	defer db.Close()
-	conn := db.Open()
-	defer conn.Close()
-
-	kycThreshold, err := amount.ParseInt64("500")
-	require.NoError(t, err)
-	h := txApproveHandler{
-		assetCode:    "FOO",
-		baseURL:      "https://example.com",
-		kycThreshold: kycThreshold,
-		db:           conn,
-	}
-
-	// payments up to the the threshold won't trigger "action_required"
-	clientKP := keypair.MustRandom()
-	paymentOp := &txnbuild.Payment{
-		Amount: amount.StringFromInt64(kycThreshold),
-	}
-	txApprovalResp, err := h.handleActionRequiredResponseIfNeeded(ctx, clientKP.Address(), paymentOp)
-	require.NoError(t, err)
-	require.Nil(t, txApprovalResp)
-
-	// payments greater than the threshold will trigger "action_required"
-	paymentOp = &txnbuild.Payment{
-		Amount: amount.StringFromInt64(kycThreshold + 1),
-	}
-	txApprovalResp, err = h.handleActionRequiredResponseIfNeeded(ctx, clientKP.Address(), paymentOp)
-	require.NoError(t, err)
-
-	var callbackID string
-	q := `SELECT callback_id FROM accounts_kyc_status WHERE hcnet_address = $1`
-	err = conn.QueryRowContext(ctx, q, clientKP.Address()).Scan(&callbackID)
-	require.NoError(t, err)
-
-	wantResp := &txApprovalResponse{
-		Status:       sep8StatusActionRequired,
-		Message:      "Payments exceeding 500.00 FOO require KYC approval. Please provide an email address.",
-		ActionMethod: "POST",
-		StatusCode:   http.StatusOK,
-		ActionURL:    "https://example.com/kyc-status/" + callbackID,
-		ActionFields: []string{"email_address"},
-	}
-	require.Equal(t, wantResp, txApprovalResp)
-
-	// if KYC was previously approved, handleActionRequiredResponseIfNeeded will return nil
-	q = `
-		UPDATE accounts_kyc_status
-		SET 
-			approved_at = NOW(),
-			rejected_at = NULL,
-			pending_at = NULL
-		WHERE hcnet_address = $1
-	`
-	_, err = conn.ExecContext(ctx, q, clientKP.Address())
-	require.NoError(t, err)
-	txApprovalResp, err = h.handleActionRequiredResponseIfNeeded(ctx, clientKP.Address(), paymentOp)
-	require.NoError(t, err)
-	require.Nil(t, txApprovalResp)
-
-	// if KYC was previously rejected, handleActionRequiredResponseIfNeeded will return a "rejected" response
-	q = `
-		UPDATE accounts_kyc_status
-		SET 
-			approved_at = NULL,
-			rejected_at = NOW(),
-			pending_at = NULL
-		WHERE hcnet_address = $1
-	`
-	_, err = conn.ExecContext(ctx, q, clientKP.Address())
-	require.NoError(t, err)
-	txApprovalResp, err = h.handleActionRequiredResponseIfNeeded(ctx, clientKP.Address(), paymentOp)
-	require.NoError(t, err)
-	require.Equal(t, NewRejectedTxApprovalResponse("Your KYC was rejected and you're not authorized for operations above 500.00 FOO."), txApprovalResp)
-
-	// if KYC was previously marked as pending, handleActionRequiredResponseIfNeeded will return a "pending" response
-	q = `
-		UPDATE accounts_kyc_status
-		SET 
-			approved_at = NULL,
-			rejected_at = NULL,
-			pending_at = NOW()
-		WHERE hcnet_address = $1
-	`
-	_, err = conn.ExecContext(ctx, q, clientKP.Address())
-	require.NoError(t, err)
-	txApprovalResp, err = h.handleActionRequiredResponseIfNeeded(ctx, clientKP.Address(), paymentOp)
-	require.NoError(t, err)
-	require.Equal(t, NewPendingTxApprovalResponse("Your account could not be verified as approved nor rejected and was marked as pending. You will need staff authorization for operations above 500.00 FOO."), txApprovalResp)
-}
-
 func TestTxApproveHandler_txApprove_rejected(t *testing.T) {
 	ctx := context.Background()
 	db := dbtest.Open(t)
@@ -355,7 +263,7 @@ func TestTxApproveHandler_txApprove_rejected(t *testing.T) {
 	}
 	assert.Equal(t, &wantRejectedResponse, rejectedResponse)
 
-	// rejected if contains more than one operation
+	// rejected if contains operations other than payments
 	tx, err := txnbuild.NewTransaction(
 		txnbuild.TransactionParams{
 			SourceAccount: &aurora.Account{
@@ -381,9 +289,9 @@ func TestTxApproveHandler_txApprove_rejected(t *testing.T) {
 
 	txApprovalResp, err := handler.txApprove(ctx, txApproveRequest{Tx: txe})
 	require.NoError(t, err)
-	assert.Equal(t, NewRejectedTxApprovalResponse("Please submit a transaction with exactly one operation of type payment."), txApprovalResp)
+	assert.Equal(t, NewRejectedTxApprovalResponse("There is one or more unauthorized operations in the provided transaction."), txApprovalResp)
 
-	// rejected if the single operation is not a payment
+	// rejected if the only operation is not a payment
 	tx, err = txnbuild.NewTransaction(
 		txnbuild.TransactionParams{
 			SourceAccount: &aurora.Account{
@@ -494,6 +402,44 @@ func TestTxApproveHandler_txApprove_rejected(t *testing.T) {
 	assert.Equal(t, NewRejectedTxApprovalResponse("Invalid transaction sequence number."), txApprovalResp)
 }
 
+func TestTxApproveHandler_txApprove_rejectsTransactionWithoutOperations(t *testing.T) {
+	ctx := context.Background()
+	senderKP := keypair.MustRandom()
+	issuerKP := keypair.MustRandom()
+
+	tx, err := txnbuild.NewTransaction(
+		txnbuild.TransactionParams{
+			SourceAccount: &aurora.Account{
+				AccountID: senderKP.Address(),
+				Sequence:  2,
+			},
+			IncrementSequenceNum: true,
+			Operations:           []txnbuild.Operation{&txnbuild.BumpSequence{}},
+			BaseFee:              txnbuild.MinBaseFee,
+			Preconditions:        txnbuild.Preconditions{TimeBounds: txnbuild.NewInfiniteTimeout()},
+		},
+	)
+	require.NoError(t, err)
+	envelope := tx.ToXDR()
+	envelope.V1.Tx.Operations = nil
+	txe, err := xdr.MarshalBase64(envelope)
+	require.NoError(t, err)
+
+	// No Aurora call is expected, the mock fails the test if one is made.
+	auroraMock := auroraclient.MockClient{}
+	handler := txApproveHandler{
+		issuerKP:          issuerKP,
+		assetCode:         "GOAT",
+		auroraClient:     &auroraMock,
+		networkPassphrase: network.TestNetworkPassphrase,
+		baseURL:           "https://example.com",
+	}
+	txApprovalResp, err := handler.txApprove(ctx, txApproveRequest{Tx: txe})
+	require.NoError(t, err)
+	assert.Equal(t, NewRejectedTxApprovalResponse("Please submit a transaction with at least one operation of type payment."), txApprovalResp)
+	auroraMock.AssertExpectations(t)
+}
+
 func TestTxApproveHandler_txApprove_success(t *testing.T) {
 	ctx := context.Background()
 	db := dbtest.Open(t)
@@ -789,10 +735,9 @@ func TestValidateTransactionOperationsForSuccess(t *testing.T) {
 	})
 	require.NoError(t, err)
 
-	txApprovalResp, paymentOp, paymentSource := validateTransactionOperationsForSuccess(ctx, tx, issuerKP.Address())
+	txApprovalResp, payments := validateTransactionOperationsForSuccess(ctx, tx, issuerKP.Address())
 	assert.Equal(t, NewRejectedTxApprovalResponse("Unsupported number of operations."), txApprovalResp)
-	assert.Nil(t, paymentOp)
-	assert.Empty(t, paymentSource)
+	assert.Nil(t, payments)
 
 	// rejected if operation at index "2" is not a payment
 	tx, err = txnbuild.NewTransaction(txnbuild.TransactionParams{
@@ -813,10 +758,9 @@ func TestValidateTransactionOperationsForSuccess(t *testing.T) {
 	})
 	require.NoError(t, err)
 
-	txApprovalResp, paymentOp, paymentSource = validateTransactionOperationsForSuccess(ctx, tx, issuerKP.Address())
+	txApprovalResp, payments = validateTransactionOperationsForSuccess(ctx, tx, issuerKP.Address())
 	assert.Equal(t, NewRejectedTxApprovalResponse("There are one or more unexpected operations in the provided transaction."), txApprovalResp)
-	assert.Nil(t, paymentOp)
-	assert.Empty(t, paymentSource)
+	assert.Nil(t, payments)
 
 	// rejected if the operations list don't match the expected format [AllowTrust, AllowTrust, Payment, AllowTrust, AllowTrust]
 	tx, err = txnbuild.NewTransaction(txnbuild.TransactionParams{
@@ -842,10 +786,9 @@ func TestValidateTransactionOperationsForSuccess(t *testing.T) {
 	})
 	require.NoError(t, err)
 
-	txApprovalResp, paymentOp, paymentSource = validateTransactionOperationsForSuccess(ctx, tx, issuerKP.Address())
+	txApprovalResp, payments = validateTransactionOperationsForSuccess(ctx, tx, issuerKP.Address())
 	assert.Equal(t, NewRejectedTxApprovalResponse("There are one or more unexpected operations in the provided transaction."), txApprovalResp)
-	assert.Nil(t, paymentOp)
-	assert.Empty(t, paymentSource)
+	assert.Nil(t, payments)
 
 	// rejected if the values inside the operations list don't match the expected format
 	tx, err = txnbuild.NewTransaction(txnbuild.TransactionParams{
@@ -891,10 +834,9 @@ func TestValidateTransactionOperationsForSuccess(t *testing.T) {
 	})
 	require.NoError(t, err)
 
-	txApprovalResp, paymentOp, paymentSource = validateTransactionOperationsForSuccess(ctx, tx, issuerKP.Address())
+	txApprovalResp, payments = validateTransactionOperationsForSuccess(ctx, tx, issuerKP.Address())
 	assert.Equal(t, NewRejectedTxApprovalResponse("There are one or more unexpected operations in the provided transaction."), txApprovalResp)
-	assert.Nil(t, paymentOp)
-	assert.Empty(t, paymentSource)
+	assert.Nil(t, payments)
 
 	// success
 	tx, err = txnbuild.NewTransaction(txnbuild.TransactionParams{
@@ -940,16 +882,15 @@ func TestValidateTransactionOperationsForSuccess(t *testing.T) {
 	})
 	require.NoError(t, err)
 
-	txApprovalResp, paymentOp, paymentSource = validateTransactionOperationsForSuccess(ctx, tx, issuerKP.Address())
+	txApprovalResp, payments = validateTransactionOperationsForSuccess(ctx, tx, issuerKP.Address())
 	assert.Nil(t, txApprovalResp)
-	assert.Equal(t, senderKP.Address(), paymentSource)
 	wantPaymentOp := &txnbuild.Payment{
 		SourceAccount: senderKP.Address(),
 		Destination:   receiverKP.Address(),
 		Amount:        "1",
 		Asset:         assetGOAT,
 	}
-	assert.Equal(t, wantPaymentOp, paymentOp)
+	assert.Equal(t, []regulatedPayment{{index: 2, op: wantPaymentOp, source: senderKP.Address()}}, payments)
 }
 
 func TestTxApproveHandler_handleSuccessResponseIfNeeded_revisable(t *testing.T) {
@@ -1406,7 +1347,7 @@ func TestTxApproveHandler_handleSuccessResponseIfNeeded_success(t *testing.T) {
 	assert.Equal(t, tx.SequenceNumber(), gotTx.SequenceNumber())
 
 	// test if the operations are as expected
-	resp, _, _ := validateTransactionOperationsForSuccess(ctx, gotTx, issuerKP.Address())
+	resp, _ := validateTransactionOperationsForSuccess(ctx, gotTx, issuerKP.Address())
 	assert.Nil(t, resp)
 
 	// check if the transaction contains the issuer's signature
@@ -50,5 +50,20 @@ var cmdCleanTrades = &cobra.Command{
 		if err != nil {
 			Logger.Fatal("could not delete trade entries:", err)
 		}
+
+		err = session.DeleteOldIngestedLedgers(context.Background(), minDate)
+		if err != nil {
+			Logger.Fatal("could not delete ingested ledger entries:", err)
+		}
+
+		// Minute candles are kept as long as trades, longer resolutions
+		// are kept forever.
+		for _, r := range []tickerdb.CandleResolution{tickerdb.CandleResolution1m, tickerdb.CandleResolution5m} {
+			Logger.Infof("Deleting %s candles older than %d days", r, DaysToKeep)
+			err = session.DeleteOldCandles(context.Background(), r, minDate)
+			if err != nil {
+				Logger.Fatal("could not delete candle entries:", err)
+			}
+		}
 	},
 }
@@ -11,11 +11,15 @@ import (
 
 var MarketsOutFile string
 var AssetsOutFile string
+var CandlesOutFile string
+var CandleResolution string
+var NumCandles int
 
 func init() {
 	rootCmd.AddCommand(cmdGenerate)
 	cmdGenerate.AddCommand(cmdGenerateMarketData)
 	cmdGenerate.AddCommand(cmdGenerateAssetData)
+	cmdGenerate.AddCommand(cmdGenerateCandleData)
 
 	cmdGenerateMarketData.Flags().StringVarP(
 		&MarketsOutFile,
@@ -32,6 +36,30 @@ func init() {
 		"assets.json",
 		"Set the name of the output file",
 	)
+
+	cmdGenerateCandleData.Flags().StringVarP(
+		&CandlesOutFile,
+		"out-file",
+		"o",
+		"candles.json",
+		"Set the name of the output file",
+	)
+
+	cmdGenerateCandleData.Flags().StringVarP(
+		&CandleResolution,
+		"resolution",
+		"r",
+		"1h",
+		"Resolution of the candles (1m, 5m, 1h or 1d)",
+	)
+
+	cmdGenerateCandleData.Flags().IntVarP(
+		&NumCandles,
+		"num-candles",
+		"n",
+		168,
+		"Number of candles to output for each market",
+	)
 }
 
 var cmdGenerate = &cobra.Command{
@@ -82,3 +110,30 @@ var cmdGenerateAssetData = &cobra.Command{
 		}
 	},
 }
+
+var cmdGenerateCandleData = &cobra.Command{
+	Use:   "candle-data",
+	Short: "Generate the OHLCV candles of all markets at a given resolution and outputs to a file.",
+	Run: func(cmd *cobra.Command, args []string) {
+		resolution, err := tickerdb.ParseCandleResolution(CandleResolution)
+		if err != nil {
+			Logger.Fatal("could not parse resolution:", err)
+		}
+
+		dbInfo, err := pq.ParseURL(DatabaseURL)
+		if err != nil {
+			Logger.Fatal("could not parse db-url:", err)
+		}
+
+		session, err := tickerdb.CreateSession("postgres", dbInfo)
+		if err != nil {
+			Logger.Fatal("could not connect to db:", err)
+		}
+
+		Logger.Infof("Starting candle data generation, outputting to: %s\n", CandlesOutFile)
+		err = ticker.GenerateCandleSummaryFile(&session, Logger, CandlesOutFile, resolution, NumCandles)
+		if err != nil {
+			Logger.Fatal("could not generate candle data:", err)
+		}
+	},
+}
@@ -5,18 +5,25 @@ import (
 
 	"github.com/lib/pq"
 	"github.com/spf13/cobra"
+	"github.com/shantanu-hashcash/go/historyarchive"
+	"github.com/shantanu-hashcash/go/ingest/ledgerbackend"
+	"github.com/shantanu-hashcash/go/network"
 	ticker "github.com/shantanu-hashcash/go/services/ticker/internal"
 	"github.com/shantanu-hashcash/go/services/ticker/internal/tickerdb"
 )
 
 var ShouldStream bool
 var BackfillHours int
+var CaptiveCoreBinaryPath string
+var CaptiveCoreConfigPath string
+var StartLedger uint32
 
 func init() {
 	rootCmd.AddCommand(cmdIngest)
 	cmdIngest.AddCommand(cmdIngestAssets)
 	cmdIngest.AddCommand(cmdIngestTrades)
 	cmdIngest.AddCommand(cmdIngestOrderbooks)
+	cmdIngest.AddCommand(cmdIngestLedgers)
 
 	cmdIngestTrades.Flags().BoolVar(
 		&ShouldStream,
@@ -31,6 +38,27 @@ func init() {
 		7*24,
 		"Number of past hours to backfill trade data",
 	)
+
+	cmdIngestLedgers.Flags().StringVar(
+		&CaptiveCoreBinaryPath,
+		"captive-core-binary-path",
+		"",
+		"Path to the Hcnet Core binary used to stream ledgers",
+	)
+
+	cmdIngestLedgers.Flags().StringVar(
+		&CaptiveCoreConfigPath,
+		"captive-core-config-path",
+		"",
+		"Path to the Captive Core configuration file",
+	)
+
+	cmdIngestLedgers.Flags().Uint32Var(
+		&StartLedger,
+		"start-ledger",
+		0,
+		"Ledger to start ingesting from when no ledger was ingested yet (rounded down to the previous checkpoint)",
+	)
 }
 
 var cmdIngest = &cobra.Command{
@@ -121,3 +149,90 @@ var cmdIngestOrderbooks = &cobra.Command{
 		}
 	},
 }
+
+var cmdIngestLedgers = &cobra.Command{
+	Use:   "ledgers",
+	Short: "Continuously ingests trades and orderbooks from ledgers streamed by Captive Core.",
+	Run: func(cmd *cobra.Command, args []string) {
+		if CaptiveCoreBinaryPath == "" || CaptiveCoreConfigPath == "" {
+			Logger.Fatal("--captive-core-binary-path and --captive-core-config-path are required")
+		}
+
+		dbInfo, err := pq.ParseURL(DatabaseURL)
+		if err != nil {
+			Logger.Fatal("could not parse db-url:", err)
+		}
+
+		session, err := tickerdb.CreateSession("postgres", dbInfo)
+		if err != nil {
+			Logger.Fatal("could not connect to db:", err)
+		}
+		defer session.DB.Close()
+
+		networkPassphrase := network.PublicNetworkPassphrase
+		archiveURLs := network.PublicNetworkhistoryArchiveURLs
+		if UseTestNet {
+			networkPassphrase = network.TestNetworkPassphrase
+			archiveURLs = network.TestNetworkhistoryArchiveURLs
+		}
+
+		ctx := context.Background()
+		lastLedger, err := session.GetLastIngestedLedger(ctx)
+		if err != nil {
+			Logger.Fatal("could not get last ingested ledger:", err)
+		}
+
+		// The offers table must be initialized from a history archive
+		// checkpoint before ledgers can be applied on top of it.
+		if lastLedger == 0 {
+			if StartLedger == 0 {
+				Logger.Fatal("--start-ledger is required when no ledger has been ingested")
+			}
+
+			archive, err := historyarchive.NewArchivePool(archiveURLs, historyarchive.ArchiveOptions{
+				NetworkPassphrase: networkPassphrase,
+			})
+			if err != nil {
+				Logger.Fatal("could not connect to history archives:", err)
+			}
+
+			checkpoint := archive.GetCheckpointManager().PrevCheckpoint(StartLedger)
+			err = ticker.InitializeOrderbook(ctx, &session, archive, checkpoint, Logger)
+			if err != nil {
+				Logger.Fatal("could not initialize orderbook:", err)
+			}
+		}
+
+		toml, err := ledgerbackend.NewCaptiveCoreTomlFromFile(
+			CaptiveCoreConfigPath,
+			ledgerbackend.CaptiveCoreTomlParams{
+				NetworkPassphrase:  networkPassphrase,
+				HistoryArchiveURLs: archiveURLs,
+				CoreBinaryPath:     CaptiveCoreBinaryPath,
+				Strict:             true,
+			},
+		)
+		if err != nil {
+			Logger.Fatal("could not load captive core config:", err)
+		}
+
+		backend, err := ledgerbackend.NewCaptive(ledgerbackend.CaptiveCoreConfig{
+			BinaryPath:         CaptiveCoreBinaryPath,
+			NetworkPassphrase:  networkPassphrase,
+			HistoryArchiveURLs: archiveURLs,
+			Toml:               toml,
+			Log:                Logger.WithField("subservice", "hcnet-core"),
+			Context:            ctx,
+		})
+		if err != nil {
+			Logger.Fatal("could not create captive core backend:", err)
+		}
+		defer backend.Close()
+
+		Logger.Info("Ingesting ledgers (this is a continuous process)")
+		err = ticker.IngestLedgers(ctx, &session, backend, networkPassphrase, StartLedger, Logger)
+		if err != nil {
+			Logger.Fatal("could not ingest ledgers:", err)
+		}
+	},
+}
@@ -0,0 +1,94 @@
+package ticker
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/shantanu-hashcash/go/services/ticker/internal/tickerdb"
+	"github.com/shantanu-hashcash/go/services/ticker/internal/utils"
+	hlog "github.com/shantanu-hashcash/go/support/log"
+)
+
+// GenerateCandleSummaryFile generates a CandleSummary with the last numCandles
+// candles of resolution r for all valid markets within the database and
+// outputs it to <filename>.
+func GenerateCandleSummaryFile(
+	s *tickerdb.TickerSession,
+	l *hlog.Entry,
+	filename string,
+	r tickerdb.CandleResolution,
+	numCandles int,
+) error {
+	l.Infof("Generating %s candle data...\n", r)
+	candleSummary, err := GenerateCandleSummary(s, r, numCandles)
+	if err != nil {
+		return err
+	}
+	l.Info("Candle data successfully generated!")
+
+	jsonCandles, err := json.MarshalIndent(candleSummary, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	l.Info("Writing candle data to: ", filename)
+	numBytes, err := utils.WriteJSONToFile(jsonCandles, filename)
+	if err != nil {
+		return err
+	}
+	l.Infof("Wrote %d bytes to %s\n", numBytes, filename)
+	return nil
+}
+
+// GenerateCandleSummary outputs a CandleSummary with the last numCandles
+// candles of resolution r for all valid markets within the database.
+func GenerateCandleSummary(s *tickerdb.TickerSession, r tickerdb.CandleResolution, numCandles int) (cs CandleSummary, err error) {
+	now := time.Now()
+	end := now.Truncate(r.Duration()).Add(r.Duration())
+	start := end.Add(-time.Duration(numCandles) * r.Duration())
+
+	dbCandles, err := s.RetrieveMarketCandles(context.Background(), nil, nil, nil, nil, r, start, end)
+	if err != nil {
+		return
+	}
+
+	cs = CandleSummary{
+		GeneratedAt:        utils.TimeToUnixEpoch(now),
+		GeneratedAtRFC3339: utils.TimeToRFC3339(now),
+		Resolution:         r.String(),
+		Pairs:              dbCandlesToMarketCandles(dbCandles),
+	}
+	return
+}
+
+// dbCandlesToMarketCandles groups candles by market. Candles are expected to
+// be sorted by market.
+func dbCandlesToMarketCandles(dbCandles []tickerdb.MarketCandle) []MarketCandles {
+	var markets []MarketCandles
+	for _, c := range dbCandles {
+		if len(markets) == 0 || markets[len(markets)-1].TradePairName != c.TradePairName {
+			markets = append(markets, MarketCandles{
+				TradePairName:      c.TradePairName,
+				BaseAssetCode:      c.BaseAssetCode,
+				BaseAssetIssuer:    c.BaseAssetIssuer,
+				CounterAssetCode:   c.CounterAssetCode,
+				CounterAssetIssuer: c.CounterAssetIssuer,
+			})
+		}
+
+		mkt := &markets[len(markets)-1]
+		mkt.Candles = append(mkt.Candles, Candle{
+			OpenTime:      utils.TimeToUnixEpoch(c.OpenTime),
+			Open:          c.Open,
+			High:          c.High,
+			Low:           c.Low,
+			Close:         c.Close,
+			BaseVolume:    c.BaseVolume,
+			CounterVolume: c.CounterVolume,
+			TradeCount:    c.TradeCount,
+			VWAP:          c.VWAP,
+		})
+	}
+	return markets
+}
@@ -0,0 +1,47 @@
+package ticker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shantanu-hashcash/go/services/ticker/internal/tickerdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDBCandlesToMarketCandles(t *testing.T) {
+	t0 := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Hour)
+
+	dbCandles := []tickerdb.MarketCandle{
+		{TradePairName: "BTC:A / ETH:B", BaseAssetCode: "BTC", BaseAssetIssuer: "A", CounterAssetCode: "ETH", CounterAssetIssuer: "B", OpenTime: t0, Open: 1, Close: 2, VWAP: 1.5},
+		{TradePairName: "BTC:A / ETH:B", BaseAssetCode: "BTC", BaseAssetIssuer: "A", CounterAssetCode: "ETH", CounterAssetIssuer: "B", OpenTime: t1, Open: 2, Close: 3, VWAP: 2.5},
+		{TradePairName: "XLM:native / BTC:A", BaseAssetCode: "XLM", BaseAssetIssuer: "native", CounterAssetCode: "BTC", CounterAssetIssuer: "A", OpenTime: t0, Open: 5, Close: 6, VWAP: 5.5},
+	}
+
+	markets := dbCandlesToMarketCandles(dbCandles)
+	assert.Equal(t, []MarketCandles{
+		{
+			TradePairName:      "BTC:A / ETH:B",
+			BaseAssetCode:      "BTC",
+			BaseAssetIssuer:    "A",
+			CounterAssetCode:   "ETH",
+			CounterAssetIssuer: "B",
+			Candles: []Candle{
+				{OpenTime: t0.UnixNano() / 1000000, Open: 1, Close: 2, VWAP: 1.5},
+				{OpenTime: t1.UnixNano() / 1000000, Open: 2, Close: 3, VWAP: 2.5},
+			},
+		},
+		{
+			TradePairName:      "XLM:native / BTC:A",
+			BaseAssetCode:      "XLM",
+			BaseAssetIssuer:    "native",
+			CounterAssetCode:   "BTC",
+			CounterAssetIssuer: "A",
+			Candles: []Candle{
+				{OpenTime: t0.UnixNano() / 1000000, Open: 5, Close: 6, VWAP: 5.5},
+			},
+		},
+	}, markets)
+
+	assert.Empty(t, dbCandlesToMarketCandles(nil))
+}
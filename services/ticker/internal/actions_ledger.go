@@ -0,0 +1,327 @@
+package ticker
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/shantanu-hashcash/go/historyarchive"
+	"github.com/shantanu-hashcash/go/ingest"
+	"github.com/shantanu-hashcash/go/ingest/ledgerbackend"
+	"github.com/shantanu-hashcash/go/services/ticker/internal/scraper"
+	"github.com/shantanu-hashcash/go/services/ticker/internal/tickerdb"
+	"github.com/shantanu-hashcash/go/services/ticker/internal/utils"
+	"github.com/shantanu-hashcash/go/support/errors"
+	hlog "github.com/shantanu-hashcash/go/support/log"
+	"github.com/shantanu-hashcash/go/xdr"
+)
+
+// IngestLedgers constantly ingests trades and orderbook changes directly from
+// the ledgers of the backend. Ingestion resumes after the last ingested
+// ledger, or starts at startLedger if no ledger was ingested yet. Each ledger
+// is written in a single database transaction, so ledgers are ingested
+// exactly once even if ingestion is restarted.
+func IngestLedgers(
+	ctx context.Context,
+	s *tickerdb.TickerSession,
+	backend ledgerbackend.LedgerBackend,
+	networkPassphrase string,
+	startLedger uint32,
+	l *hlog.Entry,
+) error {
+	lastLedger, err := s.GetLastIngestedLedger(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get last ingested ledger")
+	}
+
+	from := startLedger
+	if lastLedger != 0 {
+		from = lastLedger + 1
+	}
+	if from == 0 {
+		return errors.New("a start ledger is required when no ledger has been ingested")
+	}
+
+	l.Infof("Ingesting ledgers starting at %d\n", from)
+	err = backend.PrepareRange(ctx, ledgerbackend.UnboundedRange(from))
+	if err != nil {
+		return errors.Wrapf(err, "could not prepare ledger range starting at %d", from)
+	}
+
+	for sequence := from; ; sequence++ {
+		lcm, err := backend.GetLedger(ctx, sequence)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return errors.Wrapf(err, "could not get ledger %d", sequence)
+		}
+
+		err = IngestLedger(ctx, s, networkPassphrase, lcm, l)
+		if err != nil {
+			return errors.Wrapf(err, "could not ingest ledger %d", sequence)
+		}
+	}
+}
+
+// IngestLedger writes the trades and orderbook changes of a ledger to the
+// database. Ledgers that were already ingested are skipped.
+func IngestLedger(
+	ctx context.Context,
+	s *tickerdb.TickerSession,
+	networkPassphrase string,
+	lcm xdr.LedgerCloseMeta,
+	l *hlog.Entry,
+) error {
+	data, err := scraper.ScrapeLedger(networkPassphrase, lcm)
+	if err != nil {
+		return err
+	}
+
+	var dbTrades []tickerdb.Trade
+	for _, trade := range data.Trades {
+		scraper.NormalizeTradeAssets(&trade)
+		bID, cID, err := findBaseAndCounter(ctx, s, trade)
+		if err != nil {
+			// trades of assets the ticker doesn't track are ignored
+			continue
+		}
+		dbTrade, err := hProtocolTradeToDBTrade(trade, bID, cID)
+		if err != nil {
+			l.Error("Could not convert entry to DB Trade: ", err)
+			continue
+		}
+		dbTrades = append(dbTrades, dbTrade)
+	}
+
+	var upserts []tickerdb.Offer
+	var removals []int64
+	for _, change := range data.OfferChanges {
+		if change.Removed {
+			removals = append(removals, change.Offer.ID)
+		} else {
+			upserts = append(upserts, offerToDBOffer(change.Offer))
+		}
+	}
+
+	return s.Transaction(ctx, func(tx *tickerdb.TickerSession) error {
+		inserted, err := tx.InsertIngestedLedger(ctx, tickerdb.IngestedLedger{
+			Sequence:   data.Sequence,
+			CloseTime:  data.CloseTime,
+			IngestedAt: time.Now(),
+		})
+		if err != nil {
+			return errors.Wrap(err, "could not insert ingested ledger")
+		}
+		if !inserted {
+			l.Infof("Ledger %d was already ingested, skipping\n", data.Sequence)
+			return nil
+		}
+
+		err = tx.BulkInsertTrades(ctx, dbTrades)
+		if err != nil {
+			return errors.Wrap(err, "could not insert trades")
+		}
+		err = tx.BulkUpsertOffers(ctx, upserts)
+		if err != nil {
+			return errors.Wrap(err, "could not upsert offers")
+		}
+		err = tx.DeleteOffers(ctx, removals)
+		if err != nil {
+			return errors.Wrap(err, "could not delete offers")
+		}
+
+		for _, mkt := range changedMarkets(data.OfferChanges) {
+			err = refreshMarketOrderbook(ctx, tx, mkt)
+			if err != nil {
+				return errors.Wrap(err, "could not refresh orderbook stats")
+			}
+		}
+
+		l.Infof(
+			"Ingested ledger %d: %d trades, %d offer changes\n",
+			data.Sequence,
+			len(dbTrades),
+			len(data.OfferChanges),
+		)
+		return nil
+	})
+}
+
+// InitializeOrderbook replaces the offers in the database with the offers in
+// the history archive state at the given checkpoint ledger, and records the
+// checkpoint as ingested so that ledger ingestion continues after it.
+func InitializeOrderbook(
+	ctx context.Context,
+	s *tickerdb.TickerSession,
+	archive historyarchive.ArchiveInterface,
+	checkpoint uint32,
+	l *hlog.Entry,
+) error {
+	l.Infof("Reading offers from the history archive state at ledger %d\n", checkpoint)
+	header, err := archive.GetLedgerHeader(checkpoint)
+	if err != nil {
+		return errors.Wrapf(err, "could not get header of ledger %d", checkpoint)
+	}
+
+	reader, err := ingest.NewCheckpointChangeReader(ctx, archive, checkpoint)
+	if err != nil {
+		return errors.Wrap(err, "could not create checkpoint change reader")
+	}
+	defer reader.Close()
+
+	var offers []tickerdb.Offer
+	for {
+		change, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Wrap(err, "could not read checkpoint change")
+		}
+		if change.Type != xdr.LedgerEntryTypeOffer {
+			continue
+		}
+		offer, err := scraper.OfferFromEntry(*change.Post)
+		if err != nil {
+			return err
+		}
+		offers = append(offers, offerToDBOffer(offer))
+	}
+
+	return s.Transaction(ctx, func(tx *tickerdb.TickerSession) error {
+		err := tx.DeleteAllOffers(ctx)
+		if err != nil {
+			return errors.Wrap(err, "could not delete offers")
+		}
+		err = tx.BulkUpsertOffers(ctx, offers)
+		if err != nil {
+			return errors.Wrap(err, "could not insert offers")
+		}
+		_, err = tx.InsertIngestedLedger(ctx, tickerdb.IngestedLedger{
+			Sequence:   checkpoint,
+			CloseTime:  time.Unix(int64(header.Header.ScpValue.CloseTime), 0).UTC(),
+			IngestedAt: time.Now(),
+		})
+		if err != nil {
+			return errors.Wrap(err, "could not insert ingested ledger")
+		}
+
+		l.Infof("Initialized the orderbook with %d offers\n", len(offers))
+		return nil
+	})
+}
+
+// market is a pair of assets, identified by their ticker code and issuer.
+type market struct {
+	baseType, baseCode, baseIssuer          string
+	counterType, counterCode, counterIssuer string
+}
+
+// changedMarkets returns the markets whose orderbook was changed by the offer
+// changes. Base and counter assets are ordered following the same rules as
+// trades.
+func changedMarkets(changes []scraper.OfferChange) []market {
+	var markets []market
+	seen := map[market]bool{}
+	for _, change := range changes {
+		o := change.Offer
+		mkt := market{
+			baseType: o.SellingAssetType, baseCode: o.SellingAssetCode, baseIssuer: o.SellingAssetIssuer,
+			counterType: o.BuyingAssetType, counterCode: o.BuyingAssetCode, counterIssuer: o.BuyingAssetIssuer,
+		}
+		bAssetString := utils.GetAssetString(mkt.baseType, mkt.baseCode, mkt.baseIssuer)
+		cAssetString := utils.GetAssetString(mkt.counterType, mkt.counterCode, mkt.counterIssuer)
+		if mkt.counterType == "native" || (mkt.baseType != "native" && bAssetString > cAssetString) {
+			mkt = market{
+				baseType: mkt.counterType, baseCode: mkt.counterCode, baseIssuer: mkt.counterIssuer,
+				counterType: mkt.baseType, counterCode: mkt.baseCode, counterIssuer: mkt.baseIssuer,
+			}
+		}
+		if !seen[mkt] {
+			seen[mkt] = true
+			markets = append(markets, mkt)
+		}
+	}
+	return markets
+}
+
+// refreshMarketOrderbook recalculates the orderbook stats of a market from
+// the offers in the database. Markets of assets the ticker doesn't track are
+// ignored.
+func refreshMarketOrderbook(ctx context.Context, s *tickerdb.TickerSession, mkt market) error {
+	bFound, bID, err := s.GetAssetByCodeAndIssuerAccount(ctx, mkt.baseCode, mkt.baseIssuer)
+	if err != nil {
+		return err
+	}
+	cFound, cID, err := s.GetAssetByCodeAndIssuerAccount(ctx, mkt.counterCode, mkt.counterIssuer)
+	if err != nil {
+		return err
+	}
+	if !bFound || !cFound {
+		return nil
+	}
+
+	asks, err := s.GetOffersByAssets(ctx, mkt.baseCode, mkt.baseIssuer, mkt.counterCode, mkt.counterIssuer)
+	if err != nil {
+		return err
+	}
+	bids, err := s.GetOffersByAssets(ctx, mkt.counterCode, mkt.counterIssuer, mkt.baseCode, mkt.baseIssuer)
+	if err != nil {
+		return err
+	}
+
+	obStats := scraper.OrderbookStats{
+		BaseAssetCode:      mkt.baseCode,
+		BaseAssetType:      mkt.baseType,
+		BaseAssetIssuer:    mkt.baseIssuer,
+		CounterAssetCode:   mkt.counterCode,
+		CounterAssetType:   mkt.counterType,
+		CounterAssetIssuer: mkt.counterIssuer,
+	}
+	err = scraper.CalcOrderbookStatsFromOffers(&obStats, dbOffersToOffers(asks), dbOffersToOffers(bids))
+	if err != nil {
+		return err
+	}
+
+	dbOS := orderbookStatsToDBOrderbookStats(obStats, bID, cID)
+	return s.InsertOrUpdateOrderbookStats(ctx, &dbOS, []string{"base_asset_id", "counter_asset_id"})
+}
+
+func offerToDBOffer(o scraper.Offer) tickerdb.Offer {
+	return tickerdb.Offer{
+		ID:                 o.ID,
+		SellerAccount:      o.SellerAccount,
+		SellingAssetType:   o.SellingAssetType,
+		SellingAssetCode:   o.SellingAssetCode,
+		SellingAssetIssuer: o.SellingAssetIssuer,
+		BuyingAssetType:    o.BuyingAssetType,
+		BuyingAssetCode:    o.BuyingAssetCode,
+		BuyingAssetIssuer:  o.BuyingAssetIssuer,
+		Amount:             o.Amount,
+		PriceN:             o.PriceN,
+		PriceD:             o.PriceD,
+		LastModifiedLedger: o.LastModifiedLedger,
+	}
+}
+
+func dbOffersToOffers(dbOffers []tickerdb.Offer) []scraper.Offer {
+	offers := make([]scraper.Offer, 0, len(dbOffers))
+	for _, o := range dbOffers {
+		offers = append(offers, scraper.Offer{
+			ID:                 o.ID,
+			SellerAccount:      o.SellerAccount,
+			SellingAssetType:   o.SellingAssetType,
+			SellingAssetCode:   o.SellingAssetCode,
+			SellingAssetIssuer: o.SellingAssetIssuer,
+			BuyingAssetType:    o.BuyingAssetType,
+			BuyingAssetCode:    o.BuyingAssetCode,
+			BuyingAssetIssuer:  o.BuyingAssetIssuer,
+			Amount:             o.Amount,
+			PriceN:             o.PriceN,
+			PriceD:             o.PriceD,
+			LastModifiedLedger: o.LastModifiedLedger,
+		})
+	}
+	return offers
+}
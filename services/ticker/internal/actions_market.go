@@ -91,6 +91,10 @@ func dbMarketToMarketStats(m tickerdb.Market) MarketStats {
 		AskMin:           m.LowestAsk,
 		Spread:           spread,
 		SpreadMidPoint:   spreadMidPoint,
+		BidDepth2Pct:     m.BidDepth2Pct,
+		AskDepth2Pct:     m.AskDepth2Pct,
+		VWAP24h:          m.VWAP24h,
+		VWAP7d:           m.VWAP7d,
 		CloseTime:        closeTime,
 	}
 }
@@ -62,6 +62,8 @@ func orderbookStatsToDBOrderbookStats(os scraper.OrderbookStats, bID, cID int32)
 		LowestAsk:      os.LowestAsk,
 		Spread:         os.Spread,
 		SpreadMidPoint: os.SpreadMidPoint,
+		BidDepth2Pct:   os.BidDepth2Pct,
+		AskDepth2Pct:   os.AskDepth2Pct,
 		UpdatedAt:      time.Now(),
 	}
 }
@@ -52,6 +52,7 @@ type partialMarket struct {
 	CounterAssetIssuer   string
 	BaseVolume           float64
 	CounterVolume        float64
+	VWAP                 float64
 	TradeCount           int32
 	Open                 float64
 	Low                  float64
@@ -75,6 +76,22 @@ type orderbookStats struct {
 	AskMin         float64
 	Spread         float64
 	SpreadMidPoint float64
+	BidDepth2Pct   float64
+	AskDepth2Pct   float64
+}
+
+// candle represents the OHLCV statistics of a specific
+// pair of assets during a time interval
+type candle struct {
+	OpenTime      graphql.Time
+	Open          float64
+	High          float64
+	Low           float64
+	Close         float64
+	BaseVolume    float64
+	CounterVolume float64
+	TradeCount    int32
+	VWAP          float64
 }
 
 type resolver struct {
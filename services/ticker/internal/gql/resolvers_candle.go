@@ -0,0 +1,82 @@
+package gql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/shantanu-hashcash/go/services/ticker/internal/tickerdb"
+)
+
+// Candles resolves the candles() GraphQL query.
+func (r *resolver) Candles(ctx context.Context, args struct {
+	BaseAssetCode      string
+	BaseAssetIssuer    string
+	CounterAssetCode   string
+	CounterAssetIssuer string
+	Resolution         string
+	NumCandles         *int32
+}) (candles []*candle, err error) {
+	resolution, err := tickerdb.ParseCandleResolution(args.Resolution)
+	if err != nil {
+		return
+	}
+
+	numCandles, err := validateNumCandles(args.NumCandles)
+	if err != nil {
+		return
+	}
+
+	end := time.Now().Truncate(resolution.Duration()).Add(resolution.Duration())
+	start := end.Add(-time.Duration(numCandles) * resolution.Duration())
+	dbCandles, err := r.db.RetrieveMarketCandles(ctx,
+		&args.BaseAssetCode,
+		&args.BaseAssetIssuer,
+		&args.CounterAssetCode,
+		&args.CounterAssetIssuer,
+		resolution,
+		start,
+		end,
+	)
+	if err != nil {
+		// obfuscating sql errors to avoid exposing underlying
+		// implementation
+		err = errors.New("could not retrieve the requested data")
+		return
+	}
+
+	for _, dbCandle := range dbCandles {
+		candles = append(candles, dbCandleToCandle(dbCandle))
+	}
+	return
+}
+
+// validateNumCandles validates if the numCandles parameter is within an
+// acceptable range (at most 1000 candles)
+func validateNumCandles(n *int32) (int, error) {
+	if n == nil {
+		return 100, nil // default numCandles = 100
+	}
+
+	if *n > 0 && *n <= 1000 {
+		return int(*n), nil
+	}
+
+	return 0, errors.New("numCandles must be between 1 and 1000")
+}
+
+// dbCandleToCandle converts a tickerdb.MarketCandle to a *candle
+func dbCandleToCandle(dbCandle tickerdb.MarketCandle) *candle {
+	return &candle{
+		OpenTime:      graphql.Time{Time: dbCandle.OpenTime},
+		Open:          dbCandle.Open,
+		High:          dbCandle.High,
+		Low:           dbCandle.Low,
+		Close:         dbCandle.Close,
+		BaseVolume:    dbCandle.BaseVolume,
+		CounterVolume: dbCandle.CounterVolume,
+		TradeCount:    dbCandle.TradeCount,
+		VWAP:          dbCandle.VWAP,
+	}
+}
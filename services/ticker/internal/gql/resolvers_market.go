@@ -96,6 +96,8 @@ func dbMarketToPartialMarket(dbMarket tickerdb.PartialMarket) *partialMarket {
 		AskMin:         dbMarket.LowestAsk,
 		Spread:         spread,
 		SpreadMidPoint: spreadMidPoint,
+		BidDepth2Pct:   dbMarket.BidDepth2Pct,
+		AskDepth2Pct:   dbMarket.AskDepth2Pct,
 	}
 
 	return &partialMarket{
@@ -106,6 +108,7 @@ func dbMarketToPartialMarket(dbMarket tickerdb.PartialMarket) *partialMarket {
 		CounterAssetIssuer:   dbMarket.CounterAssetIssuer,
 		BaseVolume:           dbMarket.BaseVolume,
 		CounterVolume:        dbMarket.CounterVolume,
+		VWAP:                 dbMarket.VWAP,
 		TradeCount:           dbMarket.TradeCount,
 		Open:                 dbMarket.Open,
 		Low:                  dbMarket.Low,
@@ -1,7 +1,7 @@
 // Code generated by go-bindata. DO NOT EDIT.
 // sources:
 // graphiql.html (1.182kB)
-// schema.gql (2.42kB)
+// schema.gql (2.966kB)
 
 package static
 
@@ -90,7 +90,7 @@ func graphiqlHtml() (*asset, error) {
 	return a, nil
 }
 
-var _schemaGql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xe4\x54\x51\x6f\xe3\x36\x0c\x7e\xb6\x7f\x05\xdb\xbd\xb4\x2f\x79\x18\xf6\x64\x6c\x03\xd2\x76\xc3\x8a\x35\xb7\xdb\xa5\x37\x0c\x28\x86\x81\xb1\x18\x87\x88\x2c\xf9\x28\x29\x6d\x70\xe8\x7f\x1f\x24\x27\xa9\x6c\xb7\xdd\x0f\xb8\x27\x5b\x24\x3f\x8a\xfc\xf4\x91\xae\xde\x50\x8b\xf0\xb5\x2c\xbe\x04\x92\x7d\x05\xc5\x9f\xf1\x5b\x3e\x97\xa5\xdf\x77\x04\xe9\x14\xdd\xdf\x81\x90\x17\xa6\x1d\x01\x6a\x0d\x3b\xd4\xac\xd0\x93\x02\x74\x8e\xbc\x03\x6b\xc0\x6f\x08\x96\x9e\xb4\x46\x01\x43\xfe\xd1\xca\x76\x56\x16\xbd\xbf\x82\x87\x79\xfc\x39\xfb\xe7\xac\x7c\x27\x19\x3b\x17\x48\xde\xc9\x76\x08\xa8\xe0\xe1\x36\xfd\x4d\xf2\x79\x41\x45\xe0\x3c\x7a\x07\x6b\xb1\x6d\xca\xa3\xd1\x79\xf8\xd1\x84\xf6\x37\x1b\xc4\xcd\x1b\xfb\x33\x6c\xe2\x5f\x44\x5e\x28\x5a\x63\xd0\x1e\x7e\x82\xef\x7f\xe8\xcd\x97\x33\xb0\x9d\x67\x6b\x50\xeb\x3d\x74\x62\x77\xac\x08\x6a\x1b\x8c\x27\x01\x34\x2a\xe2\x56\xe8\xa8\x6f\x1e\xd8\xac\x2d\xac\xad\xc0\x9a\xb5\x27\x61\xd3\xcc\xca\xa2\x45\xd9\x92\x77\x17\x65\x51\xc4\xd0\xd4\xfd\xb5\x55\x54\xc1\xd2\xc7\x90\xdc\xde\xf7\x92\x79\x0e\x77\xbd\x06\xca\x5d\x13\x5c\xd6\x62\x05\xb7\xc6\x97\xc5\x65\x05\x0f\x8b\x54\xca\x84\xf9\xa6\x11\x6a\x12\xed\x03\xd2\xac\xbc\xc1\x59\x44\x27\x7e\x5e\xa5\x07\xa1\x43\x96\x0f\xd8\x12\x5c\xd0\xac\x99\xc1\xf9\xdf\x77\x8b\x7f\xaf\xee\xaf\xcf\xc1\x0a\x20\x44\xb4\x63\xd3\x68\x82\x3a\x88\x90\xa9\xf7\x59\xe0\xf9\xe5\x90\x40\x10\x72\x41\x7b\x37\x2b\x0b\xcf\xf5\x96\x24\xf2\x78\xbc\xe0\x7f\x1b\x9e\x9f\x5a\x3b\xb5\xfe\x5c\x96\xae\xc6\x28\xa6\x2b\x6e\x62\xe0\xe1\x74\xcf\x2d\x1d\xb4\x9e\x28\x8d\x5a\xaf\x33\xc6\xcf\x8e\x9a\x9b\xd7\x89\xf9\xcc\x1e\x41\xd9\xd1\x84\xf6\x10\xe3\x52\x29\x67\x65\x81\xc1\x6f\x3e\xd1\x97\xc0\x42\xaa\x82\x2b\x6b\x35\xa1\x39\xd9\x77\xb6\xc6\x95\xa6\x81\xa3\xed\xef\xf8\x55\x5b\x4c\x09\x7a\x01\x18\x2f\x56\x6b\x52\x57\xfb\x1b\xdb\x22\x9b\x01\xc4\xd4\x1b\x3b\x55\xca\xd0\x73\x3f\x2c\x95\x5d\xb2\xce\x53\xc0\xb0\x34\xc5\xae\xd3\xb8\xbf\xa1\x9a\x5b\xd4\xae\x3a\xd0\x15\xfb\xcb\x98\x8f\x81\xe4\xea\xec\x58\x5b\xa3\x38\x8a\xc2\x65\xc6\x35\x3f\x91\xfa\x10\xda\x55\x14\xe9\x29\x51\x8b\x4f\x13\x1b\xbb\xcf\x46\x73\xcb\x7e\x58\x8d\x90\xa2\x36\x69\xed\xd6\x38\x2f\xa1\x1e\xdf\x50\x5b\xad\xd1\x93\xa0\x9e\x2b\x25\xe4\x1c\xbd\xeb\x5d\x72\x63\xd0\x07\x19\x45\x05\x13\x67\x22\xb7\xc5\x59\x08\x6e\x22\x82\xdb\x9b\xc3\xd3\x1e\xf7\x63\xaf\xaf\x28\x9a\x34\x43\x1f\x91\x25\x03\xbd\x3a\xf8\xb9\x7d\x38\xc0\xc7\x5a\x5e\x19\xfc\x91\x6b\x82\x8b\x19\xff\xb2\x3a\xc4\x27\x3a\x8a\xe7\x00\x18\x9b\x53\xa1\xd7\xbd\xce\x7a\xf2\x6d\x47\xe6\xc5\xaf\xed\xe3\xcb\x61\xc3\xcd\x26\xcb\xb8\x41\xd3\xe4\x37\x68\xeb\xb2\x23\xc7\xeb\x76\xa8\x97\x1e\xc5\x57\x69\xb4\x92\x08\xc4\xf9\x3b\x52\x0d\xc9\x75\x8c\x8f\xe6\x93\x33\x6e\x99\xb7\x7c\x56\x14\xc9\xca\xda\xed\x32\x2e\xa6\x0a\xfe\x18\x9c\x5f\xde\x60\x3c\xed\xef\xbd\xc6\xb7\xca\xd1\xd0\x0e\x5f\x4b\x28\x56\xac\x0e\x1d\x9e\xa6\x70\xc5\x6a\xcc\xc4\x8a\xd5\x02\x9f\xf2\x8d\xb4\x1d\xa3\xd0\x6d\xc7\x28\x74\xdb\x05\x67\x7c\xb9\x4e\x08\xd5\xf8\xbc\x60\xf5\xd1\x72\xb6\xef\x8e\xd5\xf6\xf2\x8e\xef\xd8\x85\x95\xe6\xfa\x77\xda\xe7\x8b\x76\xb8\x88\x82\xe8\x7c\x29\xdb\x56\x7f\xfe\x74\x97\x2f\x21\x52\x24\x18\x17\xc7\x92\x64\x37\x98\x9a\xb8\x87\x27\x46\x2f\x68\xdc\x9a\x64\xe2\x78\xa4\xd5\x3c\xf8\xcd\x2f\x46\x75\x7d\xd5\xd9\x2e\xec\xac\x63\x3f\x41\x58\x69\xee\x1f\xd9\xfb\xdc\xf8\x5c\xfe\x17\x00\x00\xff\xff\x20\x26\x65\x82\x74\x09\x00\x00")
+var _schemaGql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xed\x55\x4b\x6f\xe3\x36\x10\x3e\xdb\xbf\x62\x9c\x5e\x12\x20\x08\x92\x45\x7b\x31\xda\x05\x1c\xa7\x45\x82\xc6\xbb\xe9\x3a\xbb\x28\x50\x14\x05\x2d\x8e\x25\xc2\x14\xa9\x25\x29\x3b\x46\xb1\xff\xbd\x33\xa2\x1f\x94\x65\xbb\xe8\xbd\x07\xc3\xe2\x37\x0f\xce\x7c\xf3\xa0\xcf\x0a\x2c\x05\xfc\xdd\xef\x7d\xad\xd1\xad\x87\xd0\xfb\x8d\xff\xfb\xdf\xfa\xfd\xb0\xae\x10\x9a\x13\x8b\xbf\x03\x87\xc1\x29\x5c\x22\x08\xad\x61\x29\xb4\x92\x22\xa0\x04\xe1\x3d\x06\x0f\xd6\x40\x28\x10\x1e\x33\x83\x01\xe8\xb7\xb2\x6e\x71\xd3\xef\x45\xe9\x10\xfe\x18\xf1\xc7\xe0\xcf\x41\xff\x8c\x2b\xe5\x3d\x5d\x77\xd2\xd7\x46\x4c\xce\x9e\x9a\xaf\x8e\xb7\xe0\x84\x44\xf0\x41\x50\x3c\x73\x67\xcb\xc6\x8b\x16\x3e\xc0\x8f\xa6\x2e\x1f\x6d\xed\xfc\x28\xb7\xef\xa1\xe0\x2f\xb6\xbc\x94\x38\x17\xb5\x0e\xf0\x13\xbc\xfb\x3e\xc2\x57\x37\x60\xab\xa0\xac\xa1\xd0\xd6\x50\x39\xbb\x54\xe4\x33\xb3\xb5\x09\xe8\x40\x18\xc9\x76\x33\xe1\x31\x26\x0e\xca\xcc\x2d\xcc\xad\x83\xb9\xd2\xa4\xa1\x4c\x4e\x91\x96\xc2\x2d\x28\xed\xcb\x7e\xaf\xc7\xaa\x4d\xee\x63\x2b\x71\x08\xd3\xc0\x2a\x29\x1e\x73\x49\x24\x9b\xbb\x8e\x19\xa5\xa2\x8e\x5d\x92\xe2\x10\x9e\x4c\xe8\xf7\xae\x88\xaa\x49\x13\x4a\x87\xf7\x3c\x77\x98\x37\xa4\xb7\x48\xa3\x3c\x8e\x73\xc6\xd6\x0d\x3f\x47\xe9\x11\x50\x09\xe5\x3e\x88\x12\xe1\x12\x6f\xf2\x1b\xb8\xf8\xfd\x79\xf2\xd7\xfd\xeb\xf8\x02\xc8\xa3\x00\xb6\xf6\x14\xa5\x26\x26\x6b\xe7\xd0\x64\xeb\x44\xf1\xe2\xaa\x4d\x20\x85\xe9\xa9\x28\x9e\x88\x0c\x2a\x5b\xa0\x63\x1e\xb7\x17\xfc\x6b\xc2\xa3\x5d\x6a\xc7\x53\x6f\xe5\x37\xa6\x82\x6a\xf4\xef\xd3\x4e\xb8\xbb\xbd\xbd\x86\x52\xbc\xc5\xcf\xdb\x2b\xb6\xfe\xf8\xf8\x3c\xfe\x02\x59\xd4\x06\x3b\xa7\x9c\x62\x8d\x41\x84\xc6\x63\xae\x96\x68\x38\x70\xab\x6b\xa6\x67\x08\x77\xe5\x35\xfc\x50\x5e\xb3\xf5\x5d\xc1\x34\xdc\x49\x4a\x68\xe3\xe2\x64\x67\x0c\x4e\xb7\xc6\xe0\x4c\x6f\x0c\xce\x36\x07\x4b\xd3\xd0\xf6\xe8\x9e\x82\x84\xc1\x88\xf0\x74\xd1\x16\xf0\x99\xd0\xc2\xc1\xbd\xca\x59\xbe\x39\xbd\xaa\x12\x37\x0b\xa2\xb9\x8e\x17\x44\xd6\x0e\x27\x0e\xeb\x28\x6b\xa2\x4a\x70\x36\x4a\x8e\x14\xc0\x46\x27\x46\x40\x90\xa8\x43\xf1\x09\xbf\xd6\xca\xa1\x1c\xc2\xbd\xb5\x1a\x85\xd9\xe1\x4b\x9b\x89\x99\xc6\x96\xa0\x8c\x77\xfc\xa2\xad\x68\x1c\x44\x76\x4c\x70\x56\x6b\x94\xf7\xeb\x07\x5b\x0a\x65\x5a\x26\x26\x2b\xec\x51\x1a\x13\xc9\x6b\x3b\x54\xe5\x1b\x74\xd4\x28\xb4\x43\x93\xca\x57\x5a\xac\x1f\x30\x53\xa5\xd0\x94\x49\xa4\x8b\xf3\x4b\x5a\x96\x15\xd1\x67\xc9\x31\xb3\x46\x2a\xae\x89\x4f\xc0\xb9\x7a\x43\xf9\xa1\x2e\x67\x5c\xc0\x9d\x23\x6a\xc8\x0e\xa6\xfc\x67\xa3\x55\xa9\x42\x3b\x1a\x0a\x0e\xcb\x66\x48\x9f\x8c\x0f\xae\xce\x0e\x6f\xc8\x88\x17\x1a\x10\x27\xf4\x48\x4a\x6a\x0c\x8f\x67\xa5\x53\x95\x1b\x11\x6a\x77\xa0\x45\x9c\xd3\x44\xa5\x18\x2f\x91\xda\x77\x9a\xe0\xe9\x61\x53\xda\xed\xa3\x12\x07\x93\x9b\xa6\x59\x3e\x2f\x34\xda\x89\xd1\xa9\xb9\x38\x3d\x16\x67\xa6\xe2\xec\x50\xb0\xc7\x2f\x34\x15\x5c\xa2\x6d\xf3\x6c\x0c\x0e\xe1\xe5\x4a\x54\xfb\x53\x13\xf6\x38\x76\x5d\x2c\x85\xad\xd0\xec\xe5\xda\xae\xf6\x87\x42\xe5\x45\xe2\xbf\x10\x26\x4f\xef\xd3\xd6\x27\x47\xc5\x97\xd3\x8b\x38\x0d\xc2\x91\x73\x1e\xb4\xa6\x25\x9c\x0f\xcf\x28\x73\x74\x63\xd6\x67\x78\x27\xe4\x65\x76\x4a\x66\x9d\x44\x37\xb3\x76\x31\xe5\xfd\x3e\x84\x8f\xad\xf3\xbe\x22\x87\x4b\xf3\x5c\x6d\xfe\x67\x8c\x19\x6b\xe3\xc4\x17\xf4\x66\x4a\x6e\x32\xdc\x4d\x28\x41\x87\xbc\x10\x34\x11\x6f\xe9\xb6\x5a\x1c\x5a\x11\x74\x68\x45\xd0\x44\x25\x7c\xf9\xca\xa1\x90\x87\xe7\x89\x92\x2f\x56\xa5\xbb\x90\x6e\x7b\xc0\x2a\x14\xef\x5e\xb2\xd6\x86\x5c\x74\xd1\x6d\x66\x71\xfd\x73\x07\x70\x89\xda\xe4\xb4\x6a\xd6\x2e\x53\xab\x82\x07\x35\xfa\x0f\x6d\xd3\x6d\x94\x56\x23\x6d\x83\x8c\xb3\xcc\x41\x56\xf5\x4c\xab\xec\x57\x5c\xa7\xaf\x4a\x7b\xeb\xd6\x4e\xa7\x2f\x90\x2d\xf5\xe7\x4f\xcf\xe9\xc6\xa5\x85\xe9\x04\x6f\xc9\x29\xb5\x52\x6b\x45\xf0\xa3\xd3\x01\x29\x46\xe3\xe7\xe8\x3a\x82\x15\xce\x46\x64\xf0\xb3\x91\x55\x2c\x43\xb2\xf8\x2b\xeb\x55\xe8\x58\x58\x97\xbf\xae\x54\x08\x29\xf8\xad\xff\x0f\x23\x33\x38\xce\x96\x0b\x00\x00")
 
 func schemaGqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -106,7 +106,7 @@ func schemaGql() (*asset, error) {
 	}
 
 	info := bindataFileInfo{name: "schema.gql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
-	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xf7, 0x3c, 0xa2, 0xb0, 0x7, 0x29, 0xef, 0xb6, 0x66, 0x0, 0x23, 0x1e, 0xf8, 0xf4, 0x6a, 0x79, 0x4f, 0xa5, 0xd8, 0xec, 0x24, 0x85, 0xfe, 0xa, 0xfd, 0xe9, 0xc6, 0x27, 0x6c, 0xf1, 0xd8, 0x46}}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xb0, 0x17, 0x1d, 0x60, 0x52, 0x7b, 0xcf, 0x61, 0xe5, 0x86, 0xb1, 0xfd, 0xf2, 0x61, 0xb2, 0x9a, 0x79, 0xa2, 0xc, 0xbe, 0x3c, 0x75, 0x4, 0x8e, 0xcd, 0x54, 0xda, 0x7c, 0x98, 0x68, 0xdd, 0x36}}
 	return a, nil
 }
 
@@ -41,6 +41,43 @@ type MarketStats struct {
 	AskMin           float64 `json:"ask_min"`
 	Spread           float64 `json:"spread"`
 	SpreadMidPoint   float64 `json:"spread_mid_point"`
+	BidDepth2Pct     float64 `json:"bid_depth_2pct"`
+	AskDepth2Pct     float64 `json:"ask_depth_2pct"`
+	VWAP24h          float64 `json:"vwap"`
+	VWAP7d           float64 `json:"vwap_7d"`
+}
+
+// CandleSummary represents the candles of all valid markets at a given
+// resolution.
+type CandleSummary struct {
+	GeneratedAt        int64           `json:"generated_at"`
+	GeneratedAtRFC3339 string          `json:"generated_at_rfc3339"`
+	Resolution         string          `json:"resolution"`
+	Pairs              []MarketCandles `json:"pairs"`
+}
+
+// MarketCandles represents the candles of a specific market (identified by
+// its base and counter assets).
+type MarketCandles struct {
+	TradePairName      string   `json:"name"`
+	BaseAssetCode      string   `json:"base_asset_code"`
+	BaseAssetIssuer    string   `json:"base_asset_issuer"`
+	CounterAssetCode   string   `json:"counter_asset_code"`
+	CounterAssetIssuer string   `json:"counter_asset_issuer"`
+	Candles            []Candle `json:"candles"`
+}
+
+// Candle represents the OHLCV statistics of a market during a time interval.
+type Candle struct {
+	OpenTime      int64   `json:"open_time"`
+	Open          float64 `json:"open"`
+	High          float64 `json:"high"`
+	Low           float64 `json:"low"`
+	Close         float64 `json:"close"`
+	BaseVolume    float64 `json:"base_volume"`
+	CounterVolume float64 `json:"counter_volume"`
+	TradeCount    int32   `json:"trade_count"`
+	VWAP          float64 `json:"vwap"`
 }
 
 // Asset Summary represents the collection of valid assets.
@@ -0,0 +1,407 @@
+package scraper
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shantanu-hashcash/go/amount"
+	"github.com/shantanu-hashcash/go/ingest"
+	hProtocol "github.com/shantanu-hashcash/go/protocols/aurora"
+	"github.com/shantanu-hashcash/go/protocols/aurora/base"
+	"github.com/shantanu-hashcash/go/toid"
+	"github.com/shantanu-hashcash/go/xdr"
+)
+
+// Trade types, as reported by Aurora.
+const (
+	orderbookTradeType     = "orderbook"
+	liquidityPoolTradeType = "liquidity_pool"
+)
+
+// toidOfferIDType marks counter offer ids that are operation ids rather than
+// hcnet-core offer ids, the way Aurora encodes them.
+const toidOfferIDType = 1
+
+// LedgerData is the data the ticker extracts from a single ledger.
+type LedgerData struct {
+	Sequence  uint32
+	CloseTime time.Time
+	// Trades are the trades executed in the ledger, in the format Aurora
+	// returns them so they can be ingested the same way as scraped trades.
+	Trades []hProtocol.Trade
+	// OfferChanges are the offers created, updated or removed in the ledger.
+	OfferChanges []OfferChange
+}
+
+// Offer represents an offer of the orderbook. Assets follow the ticker
+// conventions, where the native asset has the "XLM" code and the "native"
+// issuer.
+type Offer struct {
+	ID                 int64
+	SellerAccount      string
+	SellingAssetType   string
+	SellingAssetCode   string
+	SellingAssetIssuer string
+	BuyingAssetType    string
+	BuyingAssetCode    string
+	BuyingAssetIssuer  string
+	// Amount is the amount of the selling asset on offer.
+	Amount float64
+	// PriceN and PriceD are the price of the selling asset in units of the
+	// buying asset.
+	PriceN             int32
+	PriceD             int32
+	LastModifiedLedger uint32
+}
+
+// OfferChange is a change to an offer. Removed offers hold the last state of
+// the offer.
+type OfferChange struct {
+	Removed bool
+	Offer   Offer
+}
+
+// ScrapeLedger extracts the trades and offer changes of a ledger from its
+// close meta.
+func ScrapeLedger(networkPassphrase string, lcm xdr.LedgerCloseMeta) (data LedgerData, err error) {
+	data.Sequence = lcm.LedgerSequence()
+	data.CloseTime = time.Unix(int64(lcm.LedgerHeaderHistoryEntry().Header.ScpValue.CloseTime), 0).UTC()
+
+	data.Trades, err = extractLedgerTrades(networkPassphrase, lcm)
+	if err != nil {
+		return data, errors.Wrap(err, "could not extract trades")
+	}
+
+	data.OfferChanges, err = extractOfferChanges(networkPassphrase, lcm)
+	if err != nil {
+		return data, errors.Wrap(err, "could not extract offer changes")
+	}
+	return data, nil
+}
+
+// extractLedgerTrades extracts the trades, including liquidity pool trades, of
+// the successful transactions in the ledger.
+func extractLedgerTrades(networkPassphrase string, lcm xdr.LedgerCloseMeta) ([]hProtocol.Trade, error) {
+	reader, err := ingest.NewLedgerTransactionReaderFromLedgerCloseMeta(networkPassphrase, lcm)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create transaction reader")
+	}
+	defer reader.Close()
+
+	var trades []hProtocol.Trade
+	for {
+		tx, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read transaction")
+		}
+		if !tx.Result.Successful() {
+			continue
+		}
+
+		txTrades, err := extractTransactionTrades(lcm, tx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not extract trades of transaction %d", tx.Index)
+		}
+		trades = append(trades, txTrades...)
+	}
+	return trades, nil
+}
+
+// extractTransactionTrades extracts the trades of a transaction following the
+// rules Aurora uses, so that trades ingested from ledgers have the same IDs
+// as the trades ingested from Aurora.
+func extractTransactionTrades(lcm xdr.LedgerCloseMeta, tx ingest.LedgerTransaction) ([]hProtocol.Trade, error) {
+	var trades []hProtocol.Trade
+
+	closeTime := time.Unix(int64(lcm.LedgerHeaderHistoryEntry().Header.ScpValue.CloseTime), 0).UTC()
+	opResults, ok := tx.Result.OperationResults()
+	if !ok {
+		return nil, errors.New("transaction has no operation results")
+	}
+
+	for opIdx, op := range tx.Envelope.Operations() {
+		claims, buyOffer, buyOfferExists := operationClaims(op, opResults[opIdx])
+		if len(claims) == 0 {
+			continue
+		}
+
+		opID := toid.New(int32(lcm.LedgerSequence()), int32(tx.Index), int32(opIdx+1)).ToInt64()
+		counterOfferID := fmt.Sprintf("%d", int64(uint64(opID)|uint64(toidOfferIDType)<<62))
+		if buyOfferExists {
+			counterOfferID = fmt.Sprintf("%d", int64(buyOffer.OfferId))
+		}
+
+		buyer := tx.Envelope.SourceAccount().ToAccountId()
+		if op.SourceAccount != nil {
+			buyer = op.SourceAccount.ToAccountId()
+		}
+
+		for order, claim := range claims {
+			// hcnet-core garbage collects invalid offers and emits them with
+			// zeroed amounts. These do not represent trades.
+			if claim.AmountBought() == 0 && claim.AmountSold() == 0 {
+				continue
+			}
+
+			trade := hProtocol.Trade{
+				ID:              fmt.Sprintf("%d-%d", opID, order),
+				PT:              fmt.Sprintf("%d-%d", opID, order),
+				LedgerCloseTime: closeTime,
+				BaseAmount:      amount.String(claim.AmountSold()),
+				CounterOfferID:  counterOfferID,
+				CounterAccount:  buyer.Address(),
+				CounterAmount:   amount.String(claim.AmountBought()),
+				BaseIsSeller:    true,
+			}
+
+			err := claim.AssetSold().Extract(&trade.BaseAssetType, &trade.BaseAssetCode, &trade.BaseAssetIssuer)
+			if err != nil {
+				return nil, errors.Wrap(err, "could not extract sold asset")
+			}
+			err = claim.AssetBought().Extract(&trade.CounterAssetType, &trade.CounterAssetCode, &trade.CounterAssetIssuer)
+			if err != nil {
+				return nil, errors.Wrap(err, "could not extract bought asset")
+			}
+
+			if claim.Type == xdr.ClaimAtomTypeClaimAtomTypeLiquidityPool {
+				poolID := claim.MustLiquidityPool().LiquidityPoolId
+				trade.TradeType = liquidityPoolTradeType
+				trade.BaseLiquidityPoolID = xdr.Hash(poolID).HexString()
+				trade.Price = hProtocol.TradePrice{N: int64(claim.AmountBought()), D: int64(claim.AmountSold())}
+
+				key := xdr.LedgerKey{}
+				if err = key.SetLiquidityPool(poolID); err != nil {
+					return nil, errors.Wrap(err, "could not create liquidity pool ledger key")
+				}
+				change, err := findOperationChange(tx, opIdx, key)
+				if err != nil {
+					return nil, errors.Wrap(err, "could not find change for liquidity pool")
+				}
+				trade.LiquidityPoolFeeBP = uint32(change.Pre.Data.MustLiquidityPool().Body.MustConstantProduct().Params.Fee)
+			} else {
+				trade.TradeType = orderbookTradeType
+				trade.BaseOfferID = fmt.Sprintf("%d", int64(claim.OfferId()))
+				trade.BaseAccount = claim.SellerId().Address()
+
+				key := xdr.LedgerKey{}
+				if err = key.SetOffer(claim.SellerId(), uint64(claim.OfferId())); err != nil {
+					return nil, errors.Wrap(err, "could not create offer ledger key")
+				}
+				change, err := findOperationChange(tx, opIdx, key)
+				if err != nil {
+					return nil, errors.Wrap(err, "could not find change for trade offer")
+				}
+				price := change.Pre.Data.Offer.Price
+				trade.Price = hProtocol.TradePrice{N: int64(price.N), D: int64(price.D)}
+			}
+
+			trades = append(trades, trade)
+		}
+	}
+
+	return trades, nil
+}
+
+// operationClaims returns the offers and liquidity pools an operation traded
+// against, and the offer the operation left in the orderbook, if any.
+func operationClaims(op xdr.Operation, opResult xdr.OperationResult) (claims []xdr.ClaimAtom, buyOffer xdr.OfferEntry, buyOfferExists bool) {
+	switch op.Body.Type {
+	case xdr.OperationTypePathPaymentStrictReceive:
+		claims = opResult.MustTr().MustPathPaymentStrictReceiveResult().MustSuccess().Offers
+
+	case xdr.OperationTypePathPaymentStrictSend:
+		claims = opResult.MustTr().MustPathPaymentStrictSendResult().MustSuccess().Offers
+
+	case xdr.OperationTypeManageBuyOffer:
+		result := opResult.MustTr().MustManageBuyOfferResult().MustSuccess()
+		claims = result.OffersClaimed
+		buyOffer, buyOfferExists = result.Offer.GetOffer()
+
+	case xdr.OperationTypeManageSellOffer:
+		result := opResult.MustTr().MustManageSellOfferResult().MustSuccess()
+		claims = result.OffersClaimed
+		buyOffer, buyOfferExists = result.Offer.GetOffer()
+
+	case xdr.OperationTypeCreatePassiveSellOffer:
+		tr := opResult.MustTr()
+		// hcnet-core creates results for CreatePassiveOffer operations with
+		// the ManageSellOffer arm set.
+		if tr.Type == xdr.OperationTypeManageSellOffer {
+			result := tr.MustManageSellOfferResult().MustSuccess()
+			claims = result.OffersClaimed
+			buyOffer, buyOfferExists = result.Offer.GetOffer()
+		} else {
+			result := tr.MustCreatePassiveSellOfferResult().MustSuccess()
+			claims = result.OffersClaimed
+			buyOffer, buyOfferExists = result.Offer.GetOffer()
+		}
+	}
+	return
+}
+
+// findOperationChange finds the last change of the ledger entry with the
+// given key made by an operation.
+func findOperationChange(tx ingest.LedgerTransaction, opIdx int, key xdr.LedgerKey) (ingest.Change, error) {
+	changes, err := tx.GetOperationChanges(uint32(opIdx))
+	if err != nil {
+		return ingest.Change{}, errors.Wrap(err, "could not determine changes for operation")
+	}
+
+	for i := len(changes) - 1; i >= 0; i-- {
+		change := changes[i]
+		if change.Pre == nil {
+			continue
+		}
+		preKey, err := change.Pre.LedgerKey()
+		if err != nil {
+			return ingest.Change{}, errors.Wrap(err, "could not determine ledger key for change")
+		}
+		if key.Equals(preKey) {
+			return change, nil
+		}
+	}
+	return ingest.Change{}, errors.Errorf("could not find operation change for key %v", key)
+}
+
+// extractOfferChanges returns the net changes to offers in the ledger, sorted
+// by offer ID.
+func extractOfferChanges(networkPassphrase string, lcm xdr.LedgerCloseMeta) ([]OfferChange, error) {
+	reader, err := ingest.NewLedgerChangeReaderFromLedgerCloseMeta(networkPassphrase, lcm)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create change reader")
+	}
+	defer reader.Close()
+
+	compactor := ingest.NewChangeCompactor()
+	for {
+		change, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read change")
+		}
+		if change.Type != xdr.LedgerEntryTypeOffer {
+			continue
+		}
+		if err = compactor.AddChange(change); err != nil {
+			return nil, errors.Wrap(err, "could not compact change")
+		}
+	}
+
+	var offerChanges []OfferChange
+	for _, change := range compactor.GetChanges() {
+		if change.Post == nil {
+			offer, err := OfferFromEntry(*change.Pre)
+			if err != nil {
+				return nil, err
+			}
+			offerChanges = append(offerChanges, OfferChange{Removed: true, Offer: offer})
+			continue
+		}
+
+		offer, err := OfferFromEntry(*change.Post)
+		if err != nil {
+			return nil, err
+		}
+		offerChanges = append(offerChanges, OfferChange{Offer: offer})
+	}
+
+	sort.Slice(offerChanges, func(i, j int) bool {
+		return offerChanges[i].Offer.ID < offerChanges[j].Offer.ID
+	})
+	return offerChanges, nil
+}
+
+// OfferFromEntry converts an offer ledger entry to an Offer.
+func OfferFromEntry(entry xdr.LedgerEntry) (Offer, error) {
+	oe := entry.Data.MustOffer()
+	offer := Offer{
+		ID:                 int64(oe.OfferId),
+		SellerAccount:      oe.SellerId.Address(),
+		Amount:             float64(oe.Amount) / float64(amount.One),
+		PriceN:             int32(oe.Price.N),
+		PriceD:             int32(oe.Price.D),
+		LastModifiedLedger: uint32(entry.LastModifiedLedgerSeq),
+	}
+
+	var err error
+	offer.SellingAssetType, offer.SellingAssetCode, offer.SellingAssetIssuer, err = tickerAsset(oe.Selling)
+	if err != nil {
+		return offer, errors.Wrap(err, "could not extract selling asset")
+	}
+	offer.BuyingAssetType, offer.BuyingAssetCode, offer.BuyingAssetIssuer, err = tickerAsset(oe.Buying)
+	if err != nil {
+		return offer, errors.Wrap(err, "could not extract buying asset")
+	}
+	return offer, nil
+}
+
+// tickerAsset returns the type, code and issuer of an asset following the
+// ticker conventions for the native asset.
+func tickerAsset(asset xdr.Asset) (assetType, code, issuer string, err error) {
+	err = asset.Extract(&assetType, &code, &issuer)
+	if err != nil {
+		return
+	}
+	if assetType == "native" {
+		code, issuer = "XLM", "native"
+	}
+	return
+}
+
+// CalcOrderbookStatsFromOffers calculates the orderbook stats of a market from
+// its offers. Asks are the offers selling the base asset for the counter
+// asset, bids are the offers selling the counter asset for the base asset.
+// Offers are grouped by price level, the same way Aurora summarizes the
+// orderbook.
+func CalcOrderbookStatsFromOffers(obStats *OrderbookStats, asks, bids []Offer) error {
+	summary := hProtocol.OrderBookSummary{
+		Asks: priceLevels(asks, false),
+		Bids: priceLevels(bids, true),
+	}
+	obStats.HighestBid = math.Inf(-1)
+	obStats.LowestAsk = math.Inf(1)
+	return calcOrderbookStats(obStats, summary)
+}
+
+// priceLevels aggregates offers by price. Bids are priced in units of the
+// counter asset, so their price is inverted.
+func priceLevels(offers []Offer, invert bool) []hProtocol.PriceLevel {
+	type level struct {
+		price  base.Price
+		amount float64
+	}
+	var levels []*level
+	byPrice := map[base.Price]*level{}
+	for _, o := range offers {
+		price := base.Price{N: o.PriceN, D: o.PriceD}
+		if invert {
+			price = base.Price{N: o.PriceD, D: o.PriceN}
+		}
+		l, ok := byPrice[price]
+		if !ok {
+			l = &level{price: price}
+			byPrice[price] = l
+			levels = append(levels, l)
+		}
+		l.amount += o.Amount
+	}
+
+	result := make([]hProtocol.PriceLevel, 0, len(levels))
+	for _, l := range levels {
+		result = append(result, hProtocol.PriceLevel{
+			PriceR: hProtocol.Price(l.price),
+			Price:  fmt.Sprintf("%.7f", float64(l.price.N)/float64(l.price.D)),
+			Amount: fmt.Sprintf("%.7f", l.amount),
+		})
+	}
+	return result
+}
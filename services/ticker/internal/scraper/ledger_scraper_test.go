@@ -0,0 +1,113 @@
+package scraper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shantanu-hashcash/go/keypair"
+	"github.com/shantanu-hashcash/go/network"
+	"github.com/shantanu-hashcash/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOfferFromEntry(t *testing.T) {
+	seller := keypair.MustRandom().Address()
+	issuer := keypair.MustRandom().Address()
+
+	entry := xdr.LedgerEntry{
+		LastModifiedLedgerSeq: 1234,
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeOffer,
+			Offer: &xdr.OfferEntry{
+				SellerId: xdr.MustAddress(seller),
+				OfferId:  42,
+				Selling:  xdr.MustNewNativeAsset(),
+				Buying:   xdr.MustNewCreditAsset("USD", issuer),
+				Amount:   150000000,
+				Price:    xdr.Price{N: 1, D: 4},
+			},
+		},
+	}
+
+	offer, err := OfferFromEntry(entry)
+	require.NoError(t, err)
+	assert.Equal(t, Offer{
+		ID:                 42,
+		SellerAccount:      seller,
+		SellingAssetType:   "native",
+		SellingAssetCode:   "XLM",
+		SellingAssetIssuer: "native",
+		BuyingAssetType:    "credit_alphanum4",
+		BuyingAssetCode:    "USD",
+		BuyingAssetIssuer:  issuer,
+		Amount:             15.0,
+		PriceN:             1,
+		PriceD:             4,
+		LastModifiedLedger: 1234,
+	}, offer)
+}
+
+func TestCalcOrderbookStatsFromOffers(t *testing.T) {
+	asks := []Offer{
+		{ID: 1, Amount: 10.0, PriceN: 1, PriceD: 2},
+		{ID: 2, Amount: 5.0, PriceN: 1, PriceD: 2},
+		{ID: 3, Amount: 20.0, PriceN: 1, PriceD: 1},
+	}
+	bids := []Offer{
+		// Bids sell the counter asset, so a price of 5/2 is a bid of 0.4.
+		{ID: 4, Amount: 4.0, PriceN: 5, PriceD: 2},
+		{ID: 5, Amount: 6.0, PriceN: 4, PriceD: 1},
+	}
+
+	obStats := OrderbookStats{}
+	err := CalcOrderbookStatsFromOffers(&obStats, asks, bids)
+	require.NoError(t, err)
+
+	// asks at the same price are grouped into a single price level
+	assert.Equal(t, 2, obStats.NumAsks)
+	assert.InDelta(t, 0.5*15.0+1.0*20.0, obStats.AskVolume, 1e-7)
+	assert.InDelta(t, 0.5, obStats.LowestAsk, 1e-7)
+
+	assert.Equal(t, 2, obStats.NumBids)
+	assert.InDelta(t, 10.0, obStats.BidVolume, 1e-7)
+	assert.InDelta(t, 0.4, obStats.HighestBid, 1e-7)
+
+	assert.InDelta(t, 0.2, obStats.Spread, 1e-7)
+	assert.InDelta(t, 0.5, obStats.SpreadMidPoint, 1e-7)
+}
+
+func TestCalcOrderbookStatsFromOffersEmpty(t *testing.T) {
+	obStats := OrderbookStats{}
+	err := CalcOrderbookStatsFromOffers(&obStats, nil, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, obStats.NumAsks)
+	assert.Equal(t, 0, obStats.NumBids)
+	assert.Equal(t, 0.0, obStats.LowestAsk)
+	assert.Equal(t, 0.0, obStats.HighestBid)
+}
+
+func TestScrapeLedgerEmpty(t *testing.T) {
+	closeTime := time.Date(2024, 6, 20, 12, 0, 0, 0, time.UTC)
+	lcm := xdr.LedgerCloseMeta{
+		V: 0,
+		V0: &xdr.LedgerCloseMetaV0{
+			LedgerHeader: xdr.LedgerHeaderHistoryEntry{
+				Header: xdr.LedgerHeader{
+					LedgerSeq: 100,
+					ScpValue: xdr.HcnetValue{
+						CloseTime: xdr.TimePoint(closeTime.Unix()),
+					},
+				},
+			},
+		},
+	}
+
+	data, err := ScrapeLedger(network.TestNetworkPassphrase, lcm)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(100), data.Sequence)
+	assert.Equal(t, closeTime, data.CloseTime)
+	assert.Empty(t, data.Trades)
+	assert.Empty(t, data.OfferChanges)
+}
@@ -108,6 +108,10 @@ type OrderbookStats struct {
 	LowestAsk          float64
 	Spread             float64
 	SpreadMidPoint     float64
+	// BidDepth2Pct and AskDepth2Pct are the volumes, in units of the counter
+	// asset, of the bids and asks priced within 2% of the mid price.
+	BidDepth2Pct float64
+	AskDepth2Pct float64
 }
 
 // ProcessAllAssets fetches assets from the Aurora public net. If limit = 0, will fetch all assets.
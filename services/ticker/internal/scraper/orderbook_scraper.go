@@ -91,6 +91,10 @@ func calcOrderbookStats(obStats *OrderbookStats, summary hProtocol.OrderBookSumm
 	}
 
 	obStats.Spread, obStats.SpreadMidPoint = utils.CalcSpread(obStats.HighestBid, obStats.LowestAsk)
+	err := calcOrderbookDepth(obStats, summary)
+	if err != nil {
+		return err
+	}
 
 	// Clean up remaining infinity values:
 	if math.IsInf(obStats.LowestAsk, 0) {
@@ -104,6 +108,48 @@ func calcOrderbookStats(obStats *OrderbookStats, summary hProtocol.OrderBookSumm
 	return nil
 }
 
+// depthRange is the distance from the mid price, as a fraction of the mid
+// price, within which orderbook depth is measured.
+const depthRange = 0.02
+
+// calcOrderbookDepth calculates the BidDepth2Pct and AskDepth2Pct statistics,
+// i.e. the volume of the bids and asks priced within depthRange of the mid
+// price. Depth is only defined for markets with both bids and asks, so it
+// must be called once HighestBid and LowestAsk are known.
+func calcOrderbookDepth(obStats *OrderbookStats, summary hProtocol.OrderBookSummary) error {
+	obStats.BidDepth2Pct, obStats.AskDepth2Pct = 0, 0
+	if obStats.NumBids == 0 || obStats.NumAsks == 0 {
+		return nil
+	}
+
+	midPrice := (obStats.HighestBid + obStats.LowestAsk) / 2.0
+	for _, bid := range summary.Bids {
+		pricef := float64(bid.PriceR.N) / float64(bid.PriceR.D)
+		if pricef < midPrice*(1-depthRange) {
+			continue
+		}
+		amountf, err := strconv.ParseFloat(bid.Amount, 64)
+		if err != nil {
+			return errors.Wrap(err, "invalid bid amount")
+		}
+		obStats.BidDepth2Pct += amountf
+	}
+
+	for _, ask := range summary.Asks {
+		pricef := float64(ask.PriceR.N) / float64(ask.PriceR.D)
+		if pricef > midPrice*(1+depthRange) {
+			continue
+		}
+		amountf, err := strconv.ParseFloat(ask.Amount, 64)
+		if err != nil {
+			return errors.Wrap(err, "invalid ask amount")
+		}
+		// ask amounts are in units of base, see calcOrderbookStats
+		obStats.AskDepth2Pct += pricef * amountf
+	}
+	return nil
+}
+
 // createOrderbookRequest generates a auroraclient.OrderBookRequest based on the base
 // and counter asset parameters provided
 func createOrderbookRequest(bType, bCode, bIssuer, cType, cCode, cIssuer string) auroraclient.OrderBookRequest {
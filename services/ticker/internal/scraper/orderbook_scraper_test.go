@@ -0,0 +1,50 @@
+package scraper
+
+import (
+	"math"
+	"testing"
+
+	hProtocol "github.com/shantanu-hashcash/go/protocols/aurora"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalcOrderbookStatsDepth(t *testing.T) {
+	summary := hProtocol.OrderBookSummary{
+		Bids: []hProtocol.PriceLevel{
+			{PriceR: hProtocol.Price{N: 99, D: 100}, Amount: "10.0"},
+			{PriceR: hProtocol.Price{N: 98, D: 100}, Amount: "20.0"},
+			// more than 2% below the mid price of 1.0
+			{PriceR: hProtocol.Price{N: 90, D: 100}, Amount: "40.0"},
+		},
+		Asks: []hProtocol.PriceLevel{
+			{PriceR: hProtocol.Price{N: 101, D: 100}, Amount: "100.0"},
+			// more than 2% above the mid price of 1.0
+			{PriceR: hProtocol.Price{N: 110, D: 100}, Amount: "100.0"},
+		},
+	}
+
+	obStats := OrderbookStats{HighestBid: math.Inf(-1), LowestAsk: math.Inf(1)}
+	err := calcOrderbookStats(&obStats, summary)
+	require.NoError(t, err)
+
+	assert.InDelta(t, 0.99, obStats.HighestBid, 1e-7)
+	assert.InDelta(t, 1.01, obStats.LowestAsk, 1e-7)
+	assert.InDelta(t, 30.0, obStats.BidDepth2Pct, 1e-7)
+	assert.InDelta(t, 101.0, obStats.AskDepth2Pct, 1e-7)
+}
+
+func TestCalcOrderbookStatsDepthOneSided(t *testing.T) {
+	summary := hProtocol.OrderBookSummary{
+		Bids: []hProtocol.PriceLevel{
+			{PriceR: hProtocol.Price{N: 1, D: 1}, Amount: "10.0"},
+		},
+	}
+
+	obStats := OrderbookStats{HighestBid: math.Inf(-1), LowestAsk: math.Inf(1)}
+	err := calcOrderbookStats(&obStats, summary)
+	require.NoError(t, err)
+
+	assert.Equal(t, 0.0, obStats.BidDepth2Pct)
+	assert.Equal(t, 0.0, obStats.AskDepth2Pct)
+}
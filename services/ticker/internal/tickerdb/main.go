@@ -96,9 +96,51 @@ type OrderbookStats struct {
 	LowestAsk      float64   `db:"lowest_ask"`
 	Spread         float64   `db:"spread"`
 	SpreadMidPoint float64   `db:"spread_mid_point"`
+	BidDepth2Pct   float64   `db:"bid_depth_2pct"`
+	AskDepth2Pct   float64   `db:"ask_depth_2pct"`
 	UpdatedAt      time.Time `db:"updated_at"`
 }
 
+// Candle represents an entry on the candles table
+type Candle struct {
+	BaseAssetID    int32     `db:"base_asset_id"`
+	CounterAssetID int32     `db:"counter_asset_id"`
+	Resolution     int32     `db:"resolution"`
+	OpenTime       time.Time `db:"open_time"`
+	Open           float64   `db:"open"`
+	High           float64   `db:"high"`
+	Low            float64   `db:"low"`
+	Close          float64   `db:"close"`
+	BaseVolume     float64   `db:"base_volume"`
+	CounterVolume  float64   `db:"counter_volume"`
+	TradeCount     int32     `db:"trade_count"`
+	FirstTradeTime time.Time `db:"first_trade_time"`
+	LastTradeTime  time.Time `db:"last_trade_time"`
+}
+
+// IngestedLedger represents an entry on the ingested_ledgers table
+type IngestedLedger struct {
+	Sequence   uint32    `db:"sequence"`
+	CloseTime  time.Time `db:"close_time"`
+	IngestedAt time.Time `db:"ingested_at"`
+}
+
+// Offer represents an entry on the offers table
+type Offer struct {
+	ID                 int64   `db:"id"`
+	SellerAccount      string  `db:"seller_account"`
+	SellingAssetType   string  `db:"selling_asset_type"`
+	SellingAssetCode   string  `db:"selling_asset_code"`
+	SellingAssetIssuer string  `db:"selling_asset_issuer"`
+	BuyingAssetType    string  `db:"buying_asset_type"`
+	BuyingAssetCode    string  `db:"buying_asset_code"`
+	BuyingAssetIssuer  string  `db:"buying_asset_issuer"`
+	Amount             float64 `db:"amount"`
+	PriceN             int32   `db:"price_n"`
+	PriceD             int32   `db:"price_d"`
+	LastModifiedLedger uint32  `db:"last_modified_ledger"`
+}
+
 // Market represent the aggregated market data retrieved from the database.
 // Note: this struct does *not* directly map to a db entity.
 type Market struct {
@@ -125,6 +167,10 @@ type Market struct {
 	NumAsks            int       `db:"num_asks"`
 	AskVolume          float64   `db:"ask_volume"`
 	LowestAsk          float64   `db:"lowest_ask"`
+	BidDepth2Pct       float64   `db:"bid_depth_2pct"`
+	AskDepth2Pct       float64   `db:"ask_depth_2pct"`
+	VWAP24h            float64   `db:"vwap_24h"`
+	VWAP7d             float64   `db:"vwap_7d"`
 }
 
 // PartialMarket represents the aggregated market data for a
@@ -155,11 +201,34 @@ type PartialMarket struct {
 	NumAsks              int       `db:"num_asks"`
 	AskVolume            float64   `db:"ask_volume"`
 	LowestAsk            float64   `db:"lowest_ask"`
+	BidDepth2Pct         float64   `db:"bid_depth_2pct"`
+	AskDepth2Pct         float64   `db:"ask_depth_2pct"`
+	VWAP                 float64   `db:"vwap"`
 	IntervalStart        time.Time `db:"interval_start"`
 	FirstLedgerCloseTime time.Time `db:"first_ledger_close_time"`
 	LastLedgerCloseTime  time.Time `db:"last_ledger_close_time"`
 }
 
+// MarketCandle represents a candle along with the assets of its market.
+// Note: this struct does *not* directly map to a db entity.
+type MarketCandle struct {
+	TradePairName      string    `db:"trade_pair_name"`
+	BaseAssetCode      string    `db:"base_asset_code"`
+	BaseAssetIssuer    string    `db:"base_asset_issuer"`
+	CounterAssetCode   string    `db:"counter_asset_code"`
+	CounterAssetIssuer string    `db:"counter_asset_issuer"`
+	Resolution         int32     `db:"resolution"`
+	OpenTime           time.Time `db:"open_time"`
+	Open               float64   `db:"open"`
+	High               float64   `db:"high"`
+	Low                float64   `db:"low"`
+	Close              float64   `db:"close"`
+	BaseVolume         float64   `db:"base_volume"`
+	CounterVolume      float64   `db:"counter_volume"`
+	TradeCount         int32     `db:"trade_count"`
+	VWAP               float64   `db:"vwap"`
+}
+
 // CreateSession returns a new TickerSession that connects to the given db settings
 func CreateSession(driverName, dataSourceName string) (session TickerSession, err error) {
 	dbconn, err := sqlx.Connect(driverName, dataSourceName)
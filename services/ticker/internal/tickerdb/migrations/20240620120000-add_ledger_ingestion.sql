@@ -0,0 +1,32 @@
+
+-- +migrate Up
+CREATE TABLE ingested_ledgers (
+    sequence integer NOT NULL PRIMARY KEY,
+    close_time timestamptz NOT NULL,
+    ingested_at timestamptz NOT NULL
+);
+
+CREATE TABLE offers (
+    id bigint NOT NULL PRIMARY KEY,
+    seller_account text NOT NULL,
+
+    selling_asset_type text NOT NULL,
+    selling_asset_code text NOT NULL,
+    selling_asset_issuer text NOT NULL,
+
+    buying_asset_type text NOT NULL,
+    buying_asset_code text NOT NULL,
+    buying_asset_issuer text NOT NULL,
+
+    amount double precision NOT NULL,
+    price_n integer NOT NULL,
+    price_d integer NOT NULL,
+    last_modified_ledger integer NOT NULL
+);
+CREATE INDEX offers_market_idx ON offers (
+    selling_asset_code, selling_asset_issuer, buying_asset_code, buying_asset_issuer
+);
+
+-- +migrate Down
+DROP TABLE offers;
+DROP TABLE ingested_ledgers;
@@ -0,0 +1,92 @@
+
+-- +migrate Up
+CREATE TABLE candles (
+    base_asset_id integer REFERENCES assets (id) NOT NULL,
+    counter_asset_id integer REFERENCES assets (id) NOT NULL,
+
+    -- resolution is the duration of the candle, in seconds
+    resolution integer NOT NULL,
+    open_time timestamptz NOT NULL,
+
+    open double precision NOT NULL,
+    high double precision NOT NULL,
+    low double precision NOT NULL,
+    close double precision NOT NULL,
+
+    base_volume double precision NOT NULL,
+    counter_volume double precision NOT NULL,
+    trade_count integer NOT NULL,
+
+    first_trade_time timestamptz NOT NULL,
+    last_trade_time timestamptz NOT NULL,
+
+    PRIMARY KEY (base_asset_id, counter_asset_id, resolution, open_time)
+);
+CREATE INDEX candles_resolution_open_time_idx ON candles (resolution, open_time);
+
+-- Build the candles of the trades that were ingested before candles
+-- were maintained during trade ingestion.
+INSERT INTO candles
+SELECT
+    t.base_asset_id,
+    t.counter_asset_id,
+    r.resolution,
+    to_timestamp(floor(extract(epoch FROM t.ledger_close_time) / r.resolution) * r.resolution) AS open_time,
+    (array_agg(t.price ORDER BY t.ledger_close_time ASC, t.id ASC))[1] AS open,
+    max(t.price) AS high,
+    min(t.price) AS low,
+    (array_agg(t.price ORDER BY t.ledger_close_time DESC, t.id DESC))[1] AS close,
+    sum(t.base_amount) AS base_volume,
+    sum(t.counter_amount) AS counter_volume,
+    count(*) AS trade_count,
+    min(t.ledger_close_time) AS first_trade_time,
+    max(t.ledger_close_time) AS last_trade_time
+FROM trades AS t
+    CROSS JOIN (VALUES (60), (300), (3600), (86400)) AS r (resolution)
+GROUP BY t.base_asset_id, t.counter_asset_id, r.resolution, open_time;
+
+ALTER TABLE orderbook_stats
+    ADD COLUMN bid_depth_2pct double precision NOT NULL DEFAULT 0.0,
+    ADD COLUMN ask_depth_2pct double precision NOT NULL DEFAULT 0.0;
+
+CREATE OR REPLACE VIEW aggregated_orderbook AS
+    SELECT
+        concat(bAsset.code, '_', cAsset.code) as trade_pair_name,
+        bAsset.code as base_asset_code,
+        cAsset.code as counter_asset_code,
+        COALESCE(sum(os.num_bids), 0) AS num_bids,
+        COALESCE(sum(os.bid_volume), 0.0) AS bid_volume,
+        COALESCE(max(os.highest_bid), 0.0) AS highest_bid,
+        COALESCE(sum(os.num_asks), 0) AS num_asks,
+        COALESCE(sum(os.ask_volume), 0.0) AS ask_volume,
+        COALESCE(min(os.lowest_ask), 0.0) AS lowest_ask,
+        COALESCE(sum(os.bid_depth_2pct), 0.0) AS bid_depth_2pct,
+        COALESCE(sum(os.ask_depth_2pct), 0.0) AS ask_depth_2pct
+    FROM orderbook_stats AS os
+    JOIN assets AS bAsset ON os.base_asset_id = bAsset.id
+    JOIN assets AS cAsset on os.counter_asset_id = cAsset.id
+    GROUP BY trade_pair_name, base_asset_code, counter_asset_code;
+
+-- +migrate Down
+DROP VIEW IF EXISTS aggregated_orderbook;
+CREATE VIEW aggregated_orderbook AS
+    SELECT
+        concat(bAsset.code, '_', cAsset.code) as trade_pair_name,
+        bAsset.code as base_asset_code,
+        cAsset.code as counter_asset_code,
+        COALESCE(sum(os.num_bids), 0) AS num_bids,
+        COALESCE(sum(os.bid_volume), 0.0) AS bid_volume,
+        COALESCE(max(os.highest_bid), 0.0) AS highest_bid,
+        COALESCE(sum(os.num_asks), 0) AS num_asks,
+        COALESCE(sum(os.ask_volume), 0.0) AS ask_volume,
+        COALESCE(min(os.lowest_ask), 0.0) AS lowest_ask
+    FROM orderbook_stats AS os
+    JOIN assets AS bAsset ON os.base_asset_id = bAsset.id
+    JOIN assets AS cAsset on os.counter_asset_id = cAsset.id
+    GROUP BY trade_pair_name, base_asset_code, counter_asset_code;
+
+ALTER TABLE orderbook_stats
+    DROP COLUMN bid_depth_2pct,
+    DROP COLUMN ask_depth_2pct;
+
+DROP TABLE candles;
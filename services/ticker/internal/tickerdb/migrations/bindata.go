@@ -11,6 +11,8 @@
 // migrations/20190425110313-add_orderbook_stats.sql (749B)
 // migrations/20190426092321-add_aggregated_orderbook_view.sql (831B)
 // migrations/20220909100700-trades_pk_to_bigint.sql (220B)
+// migrations/20240620120000-add_ledger_ingestion.sql (833B)
+// migrations/20240701120000-add_candles_and_depth.sql (3.671kB)
 
 package bdata
 
@@ -299,6 +301,46 @@ func migrations20220909100700Trades_pk_to_bigintSql() (*asset, error) {
 	return a, nil
 }
 
+var _migrations20240620120000Add_ledger_ingestionSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8d\x93\xc1\x6e\x83\x30\x10\x44\xef\xfe\x8a\x3d\xb6\x2a\xf9\x02\x4e\xb4\x70\x88\x4a\x21\x42\x44\x6a\x4e\x96\xb1\x17\x64\x15\x30\xc5\x46\x4d\xfa\xf5\x35\x34\xa2\x10\x48\x52\x0e\x1c\xbc\x0f\x66\x98\x59\xc8\x66\x03\x4f\x95\x2c\x5a\x66\x10\xf6\x0d\x79\x49\x02\x2f\x0d\x20\xf5\x9e\xc3\x00\x64\x5d\xa0\x36\x28\x68\x89\xa2\xc0\x56\xc3\x03\x01\x7b\x69\xfc\xec\xb0\xe6\x68\xe7\x06\xed\x39\x44\x71\x0a\xd1\x3e\x0c\x61\x97\x6c\xdf\xbc\xe4\x00\xaf\xc1\xc1\x19\x50\x5e\x2a\x8d\xd4\xc8\x0a\xa1\xbf\x69\xc3\xaa\xc6\x7c\x8f\x0f\xfc\x42\xa3\x0c\x33\xab\x14\x79\x74\xc9\xdc\x98\xca\xf3\x3f\x3b\x52\x40\x26\x0b\xeb\xe5\x86\x0f\x8d\x65\x89\x2d\x65\x9c\xab\xce\x82\x06\x8f\x66\x62\x62\x44\xac\x13\xca\xb4\x46\x43\xcd\xa9\xc1\x4b\x6c\x49\x71\x25\xfe\x41\x49\xad\x3b\x9b\xd2\x9a\x68\xd6\x9d\xee\x6b\xce\xa0\x6b\x92\x33\xe8\x86\x22\xab\x86\x04\x84\xea\xb2\x12\xa1\x69\x91\x4b\x2d\x55\x7d\xf1\xb6\xa6\x95\x1c\x69\xbd\x68\x78\x3a\x15\x57\xa6\x25\xd3\x86\x56\x4a\xc8\x5c\x8e\xab\xb3\x40\xfb\x4e\xcf\x95\x6e\x23\x3f\x78\x3f\x57\x4a\x2b\xd6\x7e\xf4\x1f\x20\x8e\x10\x47\xf3\x9e\x97\xd1\x3b\xab\x41\x3b\xcb\xc0\x9c\xb5\x78\x86\xb5\x9a\xae\xbf\xaf\xbe\x6a\xe2\x27\xf1\x6e\xb6\x65\xee\xf4\xe8\xf2\x8f\x70\xc9\x0f\x70\xd0\x44\x0c\x41\x03\x00\x00")
+
+func migrations20240620120000Add_ledger_ingestionSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations20240620120000Add_ledger_ingestionSql,
+		"migrations/20240620120000-add_ledger_ingestion.sql",
+	)
+}
+
+func migrations20240620120000Add_ledger_ingestionSql() (*asset, error) {
+	bytes, err := migrations20240620120000Add_ledger_ingestionSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/20240620120000-add_ledger_ingestion.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x4f, 0x82, 0xa3, 0xc, 0x60, 0xf1, 0xa3, 0xe5, 0xaa, 0x67, 0xc7, 0x25, 0x4c, 0xcf, 0x71, 0x64, 0xf6, 0xa9, 0x54, 0x7b, 0x55, 0xfe, 0xb3, 0x5f, 0x3c, 0x4f, 0xbd, 0xd, 0xcc, 0xe4, 0x3c, 0x73}}
+	return a, nil
+}
+
+var _migrations20240701120000Add_candles_and_depthSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xed\x57\x4d\x6f\xdb\x38\x10\xbd\xeb\x57\xcc\xad\x52\xab\x7a\xdd\xed\x22\x28\x10\xf4\xa0\xd8\xcc\xc2\x5d\xc5\x0a\x64\xb9\x6d\xb0\x58\x10\xb2\xc4\xd8\x42\x2d\x51\x20\xe5\x4d\xda\x5f\xdf\x21\x69\x7d\x5a\x71\xba\x3d\x2e\x1a\x20\xb1\x4c\xbe\x79\x1c\xce\xbc\x47\x2a\xd6\xeb\xd7\xf0\x2a\xcf\xb6\x22\xae\x18\xac\x4b\x6b\x16\x12\x2f\x22\x10\x79\x57\x3e\x81\x24\x2e\xd2\x3d\x93\x60\x5b\x80\x3f\x9b\x58\x32\x1a\x4b\xc9\x2a\x9a\xa5\x90\x15\x15\xdb\x32\x01\x21\xb9\x26\x21\x59\xce\xc8\x0a\xf4\x1c\xa2\xb3\xd4\x81\x65\x10\xc1\x72\xed\xfb\xae\x0e\x4d\xf8\x01\xe1\xe2\x27\xa2\x75\x38\xe6\x28\x98\xe4\xfb\x43\x95\xf1\x02\x32\x09\xd5\x8e\x41\x7a\xc0\x9c\xd5\x77\x7e\xaf\xbf\x9b\x64\x5d\xa4\x06\xc9\x12\x5e\xa4\x52\xc7\x76\x03\x8f\x8b\xf6\x73\xe3\x25\x2b\x68\x95\xe5\x0c\xd4\x1f\x59\xc5\x79\x59\x7d\x1b\x66\xa0\x40\x90\xf2\xc3\x66\xcf\xa0\x14\x2c\xc9\xa4\x22\xec\x13\xed\xb2\xed\xee\x39\xcc\x9e\x3f\x3c\x07\x49\xf6\x5c\xb2\x73\xa0\xb6\x19\xff\xe2\xce\x72\xf6\x2c\xe1\xb1\xf8\x3f\x86\xae\x44\x9c\x32\xaa\x63\x46\x0a\xa6\x21\xf7\x99\x90\x15\x35\xc0\x33\x85\xd3\xfb\x8d\x7f\x04\xa9\xa1\xb7\xe1\xe2\xc6\x0b\xef\xe0\x2f\x72\x07\x76\x4f\x6b\xee\x89\x7e\xdc\x4e\x5b\xdd\xb6\x83\x8e\xe5\x5c\xd6\x0a\x5e\x2c\xe7\xe4\x73\xad\x60\xda\xc2\x69\x83\x46\x9e\x47\x08\x96\xad\xca\xc7\x39\x2f\x2d\x0b\xf5\x77\x75\xc8\xf6\x69\x47\x67\xb2\x96\x9d\xde\x9c\x52\x64\x5c\xc1\x03\x13\x0c\x8b\xb6\xc5\x2d\xb2\x14\x36\xec\x9e\x8b\x06\xaf\x48\xf4\x7c\x1e\x63\x59\xf1\x17\x11\x28\x61\x44\x1b\x8a\x63\x1c\x2e\x3e\xb1\x16\xcb\x15\x09\x23\xdc\x42\x14\x34\xe1\x2b\xe2\x93\x59\x64\x5a\x34\xe9\x97\xe7\x38\x78\x52\x24\xa3\xff\x49\x67\x5b\x06\xc9\x69\xd3\x06\xfb\x7e\xcf\xb9\xb0\xd9\x23\xe6\x90\x54\x36\x2b\x79\xb2\x83\xeb\x30\xb8\x41\xbe\x3d\x4b\xb1\xf9\x54\xeb\xd1\x94\x02\x7e\xeb\xd1\x39\xf0\x72\xf0\xdd\x5b\xb5\x85\x33\x8b\xd9\xb1\x10\xf1\x57\x1a\x6f\xb7\x76\x35\x29\x45\x96\x30\x08\xc2\x39\x09\xe1\xea\x6e\x6c\x09\x64\x98\xb9\x38\x81\x47\x04\x3e\x39\xce\xdf\x6f\xfe\xa9\x49\x0d\x5f\x1e\x3f\xd6\x44\x7a\x39\x65\xba\xe3\x4c\x56\xf4\x66\xd0\x6a\x3f\x97\xc2\x9c\x34\x39\xa8\xc7\x26\x09\x0d\x31\x94\xf2\x90\xdb\x75\x17\x72\x55\x76\xbd\x62\xc7\x93\x5d\x58\xd3\x97\x16\xd9\xb7\x64\xc7\xa6\xf6\x4b\x3d\xdf\x31\x61\x77\x73\x23\x1d\x41\xf0\xd0\x8e\xdd\x42\x8d\x47\x0c\x5c\x69\x99\x86\x1b\x21\xab\xd5\x35\xc1\x2c\x0c\x56\x2b\xf8\x10\x2c\x96\x60\x7f\xf4\xfc\x35\x1e\xd1\xf6\xc5\xd4\x71\xc1\x7e\x3b\x35\x1f\x17\xe6\xf3\xdd\xc5\x1f\xf8\xa0\x89\x45\xd7\x44\x8e\xf5\x67\x18\xac\x6f\x4d\x99\x07\x86\x1e\x51\x6b\x5f\xa9\xad\x8e\xd0\x7f\x9e\x1f\x61\xbb\xcc\x9d\xc4\x45\xca\xc4\x86\xf3\x2f\x14\x05\x5c\x99\x33\xde\x9b\xcf\x61\x16\xf8\xeb\x9b\x25\x6c\xb2\x94\xa6\xac\xac\x76\xf4\xf7\x32\xa9\x9e\x3e\xec\xb0\xb5\xd7\xde\xda\x8f\x60\x3a\x99\xba\x43\x92\x58\x7e\xf9\xcf\x24\x98\xe6\xf1\xe4\x09\x42\xbc\xd6\x6e\x7d\x6f\x46\xe0\xe3\x82\x7c\x02\x14\x9e\x60\x5b\xbc\x5f\x53\xda\x24\x8f\xc5\xd2\x8b\x76\x5c\x6d\x34\x50\x24\x71\x65\x6f\x3c\x55\x14\x2c\x51\x8a\xf7\xd9\x0b\xfa\x02\x0f\xc0\x76\xc4\xc1\xf4\x8e\x02\x29\xe3\x4c\xd0\x22\xae\x5b\xae\xef\x85\x16\xa8\x70\x9d\xb2\x6b\xb6\x76\xa5\x3e\xae\xdf\x8d\x3e\x74\x16\x78\x3e\xfa\x80\xd8\x4a\xce\x5c\x4e\x8a\x43\x4e\xb1\xcc\x12\x7b\x3f\xd5\x5d\xaf\x07\x9e\x0e\x51\x5d\x31\x5a\x57\x41\x13\x13\xd6\x0e\x8e\x04\x2a\xf9\x62\xa0\xb2\x37\x9e\x54\x8a\xbe\x13\xd9\x19\x3d\x9f\x26\x36\xb2\x9f\xa6\x1a\x78\x3a\x44\xf5\xfd\x24\xcd\x76\x70\x2c\x4d\xf4\x25\x06\xe2\x59\xa3\xf2\x41\x68\x27\xb0\x1d\x3c\x5f\x98\x56\x69\x83\xe2\xb4\x13\xe7\x53\x1e\x25\xe8\x4f\xe8\x78\x6d\xf3\x81\x7f\xf4\xe9\x6a\x5c\xa4\xad\x7e\x7c\x0b\x53\x19\x68\x85\xa8\x0b\x52\xe5\xd9\x7b\xf7\x7b\x5f\xcb\x2c\x4b\xc7\x22\x8d\xb6\x40\xbd\x9a\xc9\x13\x9f\x63\x70\xd2\x0b\x6e\x0f\x89\x81\xa6\x4f\xc4\x3b\xa2\x52\x73\x39\x37\x2f\xb0\x73\xfe\x50\x58\xf3\x30\xb8\x35\xce\x5b\x5c\x03\xf9\xbc\x58\x45\xab\x51\x0f\x36\xaf\x0a\xbf\x5c\xfa\xcb\xa5\x38\xf8\x3f\xf6\xc8\x73\x17\xa8\xb6\xcc\xe8\x0d\xea\x9e\xcc\xf7\x0f\x16\x24\xd7\x93\xbd\x7f\x18\x2f\xad\xef\xae\x76\x04\xc1\x57\x0e\x00\x00")
+
+func migrations20240701120000Add_candles_and_depthSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations20240701120000Add_candles_and_depthSql,
+		"migrations/20240701120000-add_candles_and_depth.sql",
+	)
+}
+
+func migrations20240701120000Add_candles_and_depthSql() (*asset, error) {
+	bytes, err := migrations20240701120000Add_candles_and_depthSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/20240701120000-add_candles_and_depth.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x22, 0x6, 0xee, 0x2, 0x64, 0x4e, 0x68, 0x73, 0x2b, 0x99, 0x63, 0xb8, 0x18, 0xe6, 0xc6, 0x6, 0x58, 0x88, 0x85, 0x21, 0x4, 0x85, 0x1, 0x18, 0x30, 0x73, 0x7a, 0x1, 0x69, 0xdd, 0xd4, 0x1d}}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -401,6 +443,8 @@ var _bindata = map[string]func() (*asset, error){
 	"migrations/20190425110313-add_orderbook_stats.sql":             migrations20190425110313Add_orderbook_statsSql,
 	"migrations/20190426092321-add_aggregated_orderbook_view.sql":   migrations20190426092321Add_aggregated_orderbook_viewSql,
 	"migrations/20220909100700-trades_pk_to_bigint.sql":             migrations20220909100700Trades_pk_to_bigintSql,
+	"migrations/20240620120000-add_ledger_ingestion.sql":            migrations20240620120000Add_ledger_ingestionSql,
+	"migrations/20240701120000-add_candles_and_depth.sql":           migrations20240701120000Add_candles_and_depthSql,
 }
 
 // AssetDir returns the file names below a certain
@@ -458,6 +502,8 @@ var _bintree = &bintree{nil, map[string]*bintree{
 		"20190425110313-add_orderbook_stats.sql":             {migrations20190425110313Add_orderbook_statsSql, map[string]*bintree{}},
 		"20190426092321-add_aggregated_orderbook_view.sql":   {migrations20190426092321Add_aggregated_orderbook_viewSql, map[string]*bintree{}},
 		"20220909100700-trades_pk_to_bigint.sql":             {migrations20220909100700Trades_pk_to_bigintSql, map[string]*bintree{}},
+		"20240620120000-add_ledger_ingestion.sql":            {migrations20240620120000Add_ledger_ingestionSql, map[string]*bintree{}},
+		"20240701120000-add_candles_and_depth.sql":           {migrations20240701120000Add_candles_and_depthSql, map[string]*bintree{}},
 	}},
 }}
 
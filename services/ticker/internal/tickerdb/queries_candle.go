@@ -0,0 +1,136 @@
+package tickerdb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shantanu-hashcash/go/support/errors"
+)
+
+// CandleResolution is the duration of a candle, in seconds.
+type CandleResolution int32
+
+// Candle resolutions maintained during trade ingestion.
+const (
+	CandleResolution1m CandleResolution = 60
+	CandleResolution5m CandleResolution = 5 * 60
+	CandleResolution1h CandleResolution = 60 * 60
+	CandleResolution1d CandleResolution = 24 * 60 * 60
+)
+
+// CandleResolutions lists all the candle resolutions, from the shortest to
+// the longest one.
+var CandleResolutions = []CandleResolution{
+	CandleResolution1m,
+	CandleResolution5m,
+	CandleResolution1h,
+	CandleResolution1d,
+}
+
+var candleResolutionNames = map[CandleResolution]string{
+	CandleResolution1m: "1m",
+	CandleResolution5m: "5m",
+	CandleResolution1h: "1h",
+	CandleResolution1d: "1d",
+}
+
+// String returns the name of the resolution, e.g. "5m".
+func (r CandleResolution) String() string {
+	return candleResolutionNames[r]
+}
+
+// Duration returns the duration of a candle of resolution r.
+func (r CandleResolution) Duration() time.Duration {
+	return time.Duration(r) * time.Second
+}
+
+// ParseCandleResolution parses a resolution name (1m, 5m, 1h or 1d).
+func ParseCandleResolution(name string) (CandleResolution, error) {
+	for r, n := range candleResolutionNames {
+		if n == name {
+			return r, nil
+		}
+	}
+	return 0, errors.Errorf("invalid candle resolution %q, must be one of 1m, 5m, 1h or 1d", name)
+}
+
+// candleResolutionValues returns the candle resolutions as a SQL VALUES list.
+func candleResolutionValues() string {
+	values := make([]string, 0, len(CandleResolutions))
+	for _, r := range CandleResolutions {
+		values = append(values, fmt.Sprintf("(%d)", r))
+	}
+	return strings.Join(values, ", ")
+}
+
+// RetrieveMarketCandles retrieves the candles of resolution r opened in the
+// [start, end) time range, for all valid markets or for the one matching the
+// optional base and counter asset params. Candles are sorted by market and
+// open time.
+func (s *TickerSession) RetrieveMarketCandles(ctx context.Context,
+	baseAssetCode *string,
+	baseAssetIssuer *string,
+	counterAssetCode *string,
+	counterAssetIssuer *string,
+	r CandleResolution,
+	start time.Time,
+	end time.Time,
+) (candles []MarketCandle, err error) {
+	sqlTrue := new(string)
+	*sqlTrue = "TRUE"
+
+	where, args := generateWhereClause([]optionalVar{
+		{"bAsset.is_valid", sqlTrue},
+		{"cAsset.is_valid", sqlTrue},
+		{"bAsset.code", baseAssetCode},
+		{"bAsset.issuer_account", baseAssetIssuer},
+		{"cAsset.code", counterAssetCode},
+		{"cAsset.issuer_account", counterAssetIssuer},
+	})
+	where += " AND c.resolution = ? AND c.open_time >= ? AND c.open_time < ?"
+
+	argsInterface := make([]interface{}, 0, len(args)+3)
+	for _, v := range args {
+		argsInterface = append(argsInterface, v)
+	}
+	argsInterface = append(argsInterface, int32(r), start, end)
+
+	q := strings.Replace(marketCandlesQuery, "__WHERECLAUSE__", where, -1)
+	err = s.SelectRaw(ctx, &candles, q, argsInterface...)
+	return
+}
+
+// DeleteOldCandles deletes candles of resolution r opened before minDate.
+func (s *TickerSession) DeleteOldCandles(ctx context.Context, r CandleResolution, minDate time.Time) error {
+	_, err := s.ExecRaw(ctx,
+		"DELETE FROM candles WHERE resolution = ? AND open_time < ?",
+		int32(r), minDate,
+	)
+	return err
+}
+
+var marketCandlesQuery = `
+SELECT
+	concat(bAsset.code, ':', bAsset.issuer_account, ' / ', cAsset.code, ':', cAsset.issuer_account) as trade_pair_name,
+	bAsset.code AS base_asset_code,
+	bAsset.issuer_account AS base_asset_issuer,
+	cAsset.code AS counter_asset_code,
+	cAsset.issuer_account AS counter_asset_issuer,
+	c.resolution,
+	c.open_time,
+	c.open,
+	c.high,
+	c.low,
+	c.close,
+	c.base_volume,
+	c.counter_volume,
+	c.trade_count,
+	COALESCE(c.counter_volume / NULLIF(c.base_volume, 0.0), 0.0) AS vwap
+FROM candles AS c
+	JOIN assets AS bAsset ON c.base_asset_id = bAsset.id
+	JOIN assets AS cAsset on c.counter_asset_id = cAsset.id
+__WHERECLAUSE__
+ORDER BY trade_pair_name, c.open_time;
+`
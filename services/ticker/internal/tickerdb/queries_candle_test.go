@@ -0,0 +1,174 @@
+package tickerdb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	migrate "github.com/rubenv/sql-migrate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCandles(t *testing.T) {
+	db := OpenTestDBConnection(t)
+	defer db.Close()
+
+	var session TickerSession
+	session.DB = db.Open()
+	ctx := context.Background()
+	defer session.DB.Close()
+
+	// Run migrations to make sure the tests are run
+	// on the most updated schema version
+	migrations := &migrate.FileMigrationSource{
+		Dir: "./migrations",
+	}
+	_, err := migrate.Exec(session.DB.DB, "postgres", migrations, migrate.Up)
+	require.NoError(t, err)
+
+	// Adding a seed issuer to be used later:
+	issuerPK := "GCF3TQXKZJNFJK7HCMNE2O2CUNKCJH2Y2ROISTBPLC7C5EIA5NNG2XZB"
+	tbl := session.GetTable("issuers")
+	_, err = tbl.Insert(Issuer{
+		PublicKey: issuerPK,
+		Name:      "FOO BAR",
+	}).IgnoreCols("id").Exec(ctx)
+	require.NoError(t, err)
+	var issuer Issuer
+	err = session.GetRaw(ctx, &issuer, `
+		SELECT *
+		FROM issuers
+		ORDER BY id DESC
+		LIMIT 1`,
+	)
+	require.NoError(t, err)
+
+	// Adding the base and counter assets:
+	err = session.InsertOrUpdateAsset(ctx, &Asset{
+		Code:          "BTC",
+		IssuerAccount: issuerPK,
+		IssuerID:      issuer.ID,
+		IsValid:       true,
+	}, []string{"code", "issuer_id"})
+	require.NoError(t, err)
+	_, btcID, err := session.GetAssetByCodeAndIssuerAccount(ctx, "BTC", issuerPK)
+	require.NoError(t, err)
+
+	err = session.InsertOrUpdateAsset(ctx, &Asset{
+		Code:          "ETH",
+		IssuerAccount: issuerPK,
+		IssuerID:      issuer.ID,
+		IsValid:       true,
+	}, []string{"code", "issuer_id"})
+	require.NoError(t, err)
+	_, ethID, err := session.GetAssetByCodeAndIssuerAccount(ctx, "ETH", issuerPK)
+	require.NoError(t, err)
+
+	hourStart := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	trades := []Trade{
+		{
+			AuroraID:        "hrzid1",
+			BaseAssetID:     btcID,
+			BaseAmount:      10.0,
+			CounterAssetID:  ethID,
+			CounterAmount:   20.0,
+			Price:           2.0,
+			LedgerCloseTime: hourStart.Add(10 * time.Minute),
+		},
+		{
+			AuroraID:        "hrzid2",
+			BaseAssetID:     btcID,
+			BaseAmount:      10.0,
+			CounterAssetID:  ethID,
+			CounterAmount:   40.0,
+			Price:           4.0,
+			LedgerCloseTime: hourStart.Add(20 * time.Minute),
+		},
+	}
+	err = session.BulkInsertTrades(ctx, trades)
+	require.NoError(t, err)
+
+	// Re-inserting the same trades must not change the candles:
+	err = session.BulkInsertTrades(ctx, trades)
+	require.NoError(t, err)
+
+	// A new trade in the same hour, which happened before the others:
+	err = session.BulkInsertTrades(ctx, []Trade{
+		{
+			AuroraID:        "hrzid3",
+			BaseAssetID:     btcID,
+			BaseAmount:      20.0,
+			CounterAssetID:  ethID,
+			CounterAmount:   20.0,
+			Price:           1.0,
+			LedgerCloseTime: hourStart.Add(5 * time.Minute),
+		},
+	})
+	require.NoError(t, err)
+
+	candles, err := session.RetrieveMarketCandles(ctx, nil, nil, nil, nil,
+		CandleResolution1h,
+		hourStart.Add(-time.Hour),
+		hourStart.Add(time.Hour),
+	)
+	require.NoError(t, err)
+	require.Len(t, candles, 1)
+
+	c := candles[0]
+	assert.Equal(t, "BTC:"+issuerPK+" / ETH:"+issuerPK, c.TradePairName)
+	assert.Equal(t, int32(CandleResolution1h), c.Resolution)
+	assert.True(t, hourStart.Equal(c.OpenTime))
+	assert.Equal(t, 1.0, c.Open)
+	assert.Equal(t, 4.0, c.High)
+	assert.Equal(t, 1.0, c.Low)
+	assert.Equal(t, 4.0, c.Close)
+	assert.Equal(t, 40.0, c.BaseVolume)
+	assert.Equal(t, 80.0, c.CounterVolume)
+	assert.Equal(t, int32(3), c.TradeCount)
+	assert.Equal(t, 2.0, c.VWAP)
+
+	// Each trade is in its own 5 minute candle:
+	candles, err = session.RetrieveMarketCandles(ctx, nil, nil, nil, nil,
+		CandleResolution5m,
+		hourStart,
+		hourStart.Add(time.Hour),
+	)
+	require.NoError(t, err)
+	require.Len(t, candles, 3)
+	assert.True(t, hourStart.Add(5*time.Minute).Equal(candles[0].OpenTime))
+	assert.True(t, hourStart.Add(10*time.Minute).Equal(candles[1].OpenTime))
+	assert.True(t, hourStart.Add(20*time.Minute).Equal(candles[2].OpenTime))
+
+	// Deleting old candles only affects the given resolution:
+	err = session.DeleteOldCandles(ctx, CandleResolution5m, hourStart.Add(15*time.Minute))
+	require.NoError(t, err)
+
+	candles, err = session.RetrieveMarketCandles(ctx, nil, nil, nil, nil,
+		CandleResolution5m,
+		hourStart,
+		hourStart.Add(time.Hour),
+	)
+	require.NoError(t, err)
+	assert.Len(t, candles, 1)
+
+	candles, err = session.RetrieveMarketCandles(ctx, nil, nil, nil, nil,
+		CandleResolution1h,
+		hourStart,
+		hourStart.Add(time.Hour),
+	)
+	require.NoError(t, err)
+	assert.Len(t, candles, 1)
+}
+
+func TestParseCandleResolution(t *testing.T) {
+	for _, r := range CandleResolutions {
+		parsed, err := ParseCandleResolution(r.String())
+		require.NoError(t, err)
+		assert.Equal(t, r, parsed)
+	}
+
+	_, err := ParseCandleResolution("2h")
+	assert.EqualError(t, err, `invalid candle resolution "2h", must be one of 1m, 5m, 1h or 1d`)
+}
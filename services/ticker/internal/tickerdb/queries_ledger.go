@@ -0,0 +1,133 @@
+package tickerdb
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/shantanu-hashcash/go/services/ticker/internal/utils"
+	"github.com/shantanu-hashcash/go/support/db"
+	"github.com/shantanu-hashcash/go/support/errors"
+)
+
+// Transaction runs f with a session bound to a new database transaction. The
+// transaction is committed if f returns nil and rolled back otherwise.
+func (s *TickerSession) Transaction(ctx context.Context, f func(tx *TickerSession) error) error {
+	tx := TickerSession{Session: db.Session{DB: s.DB}}
+	if err := tx.Begin(ctx); err != nil {
+		return errors.Wrap(err, "could not begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := f(&tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetLastIngestedLedger returns the sequence of the newest ledger ingested
+// into the database, or 0 if no ledger has been ingested.
+func (s *TickerSession) GetLastIngestedLedger(ctx context.Context) (sequence uint32, err error) {
+	err = s.GetRaw(ctx, &sequence, "SELECT COALESCE(MAX(sequence), 0) FROM ingested_ledgers")
+	return
+}
+
+// InsertIngestedLedger records a ledger as ingested. It returns false if the
+// ledger had already been ingested, in which case the data of the ledger must
+// not be written again. It should be called in the same transaction the data
+// of the ledger is written in.
+func (s *TickerSession) InsertIngestedLedger(ctx context.Context, l IngestedLedger) (inserted bool, err error) {
+	res, err := s.ExecRaw(ctx, `
+		INSERT INTO ingested_ledgers (sequence, close_time, ingested_at)
+		VALUES (?, ?, ?)
+		ON CONFLICT (sequence) DO NOTHING`,
+		l.Sequence, l.CloseTime, l.IngestedAt,
+	)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+// DeleteOldIngestedLedgers deletes ingested ledgers that closed before
+// minDate. The newest ingested ledger is always kept so that ingestion can
+// resume from it.
+func (s *TickerSession) DeleteOldIngestedLedgers(ctx context.Context, minDate time.Time) error {
+	_, err := s.ExecRaw(ctx, `
+		DELETE FROM ingested_ledgers
+		WHERE close_time < ?
+		AND sequence < (SELECT MAX(sequence) FROM ingested_ledgers)`,
+		minDate,
+	)
+	return err
+}
+
+// BulkUpsertOffers inserts or updates offers in the database.
+func (s *TickerSession) BulkUpsertOffers(ctx context.Context, offers []Offer) error {
+	for start := 0; start < len(offers); start += 500 {
+		end := start + 500
+		if end > len(offers) {
+			end = len(offers)
+		}
+		err := performUpsertOffers(ctx, s, offers[start:end])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteOffers deletes the offers with the given ids.
+func (s *TickerSession) DeleteOffers(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := s.ExecRaw(ctx, "DELETE FROM offers WHERE id = ANY(?)", pq.Array(ids))
+	return err
+}
+
+// DeleteAllOffers deletes all offers in the database.
+func (s *TickerSession) DeleteAllOffers(ctx context.Context) error {
+	_, err := s.ExecRaw(ctx, "DELETE FROM offers")
+	return err
+}
+
+// GetOffersByAssets returns the offers selling the selling asset for the
+// buying asset.
+func (s *TickerSession) GetOffersByAssets(
+	ctx context.Context,
+	sellingCode, sellingIssuer, buyingCode, buyingIssuer string,
+) (offers []Offer, err error) {
+	err = s.SelectRaw(ctx, &offers, `
+		SELECT * FROM offers
+		WHERE selling_asset_code = ? AND selling_asset_issuer = ?
+		AND buying_asset_code = ? AND buying_asset_issuer = ?
+		ORDER BY id`,
+		sellingCode, sellingIssuer, buyingCode, buyingIssuer,
+	)
+	return
+}
+
+func performUpsertOffers(ctx context.Context, s *TickerSession, offers []Offer) error {
+	dbFields := getDBFieldTags(Offer{}, false)
+	toUpdateFields := utils.SliceDiff(dbFields, []string{`"id"`})
+
+	var placeholders []string
+	var dbValues []interface{}
+	for _, o := range offers {
+		v := getDBFieldValues(o, false)
+		placeholders = append(placeholders, "("+generatePlaceholders(v)+")")
+		dbValues = append(dbValues, v...)
+	}
+
+	qs := "INSERT INTO offers (" + strings.Join(dbFields, ", ") + ")"
+	qs += " VALUES " + strings.Join(placeholders, ", ")
+	qs += " " + createOnConflictFragment("offers_pkey", toUpdateFields) + ";"
+	_, err := s.ExecRaw(ctx, qs, dbValues...)
+	return err
+}
@@ -0,0 +1,179 @@
+package tickerdb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	migrate "github.com/rubenv/sql-migrate"
+	"github.com/shantanu-hashcash/go/support/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIngestedLedgers(t *testing.T) {
+	db := OpenTestDBConnection(t)
+	defer db.Close()
+
+	var session TickerSession
+	session.DB = db.Open()
+	ctx := context.Background()
+	defer session.DB.Close()
+
+	// Run migrations to make sure the tests are run
+	// on the most updated schema version
+	migrations := &migrate.FileMigrationSource{
+		Dir: "./migrations",
+	}
+	_, err := migrate.Exec(session.DB.DB, "postgres", migrations, migrate.Up)
+	require.NoError(t, err)
+
+	last, err := session.GetLastIngestedLedger(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(0), last)
+
+	now := time.Now()
+	oneYearAgo := now.AddDate(-1, 0, 0)
+
+	inserted, err := session.InsertIngestedLedger(ctx, IngestedLedger{
+		Sequence:   100,
+		CloseTime:  oneYearAgo,
+		IngestedAt: now,
+	})
+	require.NoError(t, err)
+	assert.True(t, inserted)
+
+	// Ingesting the same ledger twice is a no-op
+	inserted, err = session.InsertIngestedLedger(ctx, IngestedLedger{
+		Sequence:   100,
+		CloseTime:  oneYearAgo,
+		IngestedAt: now,
+	})
+	require.NoError(t, err)
+	assert.False(t, inserted)
+
+	inserted, err = session.InsertIngestedLedger(ctx, IngestedLedger{
+		Sequence:   101,
+		CloseTime:  oneYearAgo,
+		IngestedAt: now,
+	})
+	require.NoError(t, err)
+	assert.True(t, inserted)
+
+	last, err = session.GetLastIngestedLedger(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(101), last)
+
+	// Old ledgers are deleted, except for the last ingested one
+	err = session.DeleteOldIngestedLedgers(ctx, now.AddDate(0, 0, -7))
+	require.NoError(t, err)
+
+	var count int
+	err = session.GetRaw(ctx, &count, "SELECT COUNT(*) FROM ingested_ledgers")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	last, err = session.GetLastIngestedLedger(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(101), last)
+
+	// Writes in a failed transaction are rolled back
+	err = session.Transaction(ctx, func(tx *TickerSession) error {
+		_, err = tx.InsertIngestedLedger(ctx, IngestedLedger{
+			Sequence:   102,
+			CloseTime:  now,
+			IngestedAt: now,
+		})
+		require.NoError(t, err)
+		return errors.New("failed")
+	})
+	require.EqualError(t, err, "failed")
+
+	last, err = session.GetLastIngestedLedger(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(101), last)
+}
+
+func TestOffers(t *testing.T) {
+	db := OpenTestDBConnection(t)
+	defer db.Close()
+
+	var session TickerSession
+	session.DB = db.Open()
+	ctx := context.Background()
+	defer session.DB.Close()
+
+	// Run migrations to make sure the tests are run
+	// on the most updated schema version
+	migrations := &migrate.FileMigrationSource{
+		Dir: "./migrations",
+	}
+	_, err := migrate.Exec(session.DB.DB, "postgres", migrations, migrate.Up)
+	require.NoError(t, err)
+
+	usdIssuer := "GCF3TQXKZJNFJK7HCMNE2O2CUNKCJH2Y2ROISTBPLC7C5EIA5NNG2XZB"
+	ask := Offer{
+		ID:                 1,
+		SellerAccount:      "GB5NBY3OIW4TRWUGZAGCYZVLTSIUSNS5IU64F2U2UHW7XOC6HGDD5SKA",
+		SellingAssetType:   "native",
+		SellingAssetCode:   "XLM",
+		SellingAssetIssuer: "native",
+		BuyingAssetType:    "credit_alphanum4",
+		BuyingAssetCode:    "USD",
+		BuyingAssetIssuer:  usdIssuer,
+		Amount:             100.0,
+		PriceN:             1,
+		PriceD:             10,
+		LastModifiedLedger: 100,
+	}
+	bid := Offer{
+		ID:                 2,
+		SellerAccount:      "GB5NBY3OIW4TRWUGZAGCYZVLTSIUSNS5IU64F2U2UHW7XOC6HGDD5SKA",
+		SellingAssetType:   "credit_alphanum4",
+		SellingAssetCode:   "USD",
+		SellingAssetIssuer: usdIssuer,
+		BuyingAssetType:    "native",
+		BuyingAssetCode:    "XLM",
+		BuyingAssetIssuer:  "native",
+		Amount:             5.0,
+		PriceN:             11,
+		PriceD:             1,
+		LastModifiedLedger: 100,
+	}
+
+	err = session.BulkUpsertOffers(ctx, []Offer{ask, bid})
+	require.NoError(t, err)
+
+	asks, err := session.GetOffersByAssets(ctx, "XLM", "native", "USD", usdIssuer)
+	require.NoError(t, err)
+	assert.Equal(t, []Offer{ask}, asks)
+
+	// Upserting an existing offer updates it
+	ask.Amount = 50.0
+	ask.LastModifiedLedger = 101
+	err = session.BulkUpsertOffers(ctx, []Offer{ask})
+	require.NoError(t, err)
+
+	asks, err = session.GetOffersByAssets(ctx, "XLM", "native", "USD", usdIssuer)
+	require.NoError(t, err)
+	assert.Equal(t, []Offer{ask}, asks)
+
+	err = session.DeleteOffers(ctx, []int64{ask.ID})
+	require.NoError(t, err)
+
+	asks, err = session.GetOffersByAssets(ctx, "XLM", "native", "USD", usdIssuer)
+	require.NoError(t, err)
+	assert.Empty(t, asks)
+
+	bids, err := session.GetOffersByAssets(ctx, "USD", usdIssuer, "XLM", "native")
+	require.NoError(t, err)
+	assert.Equal(t, []Offer{bid}, bids)
+
+	err = session.DeleteAllOffers(ctx)
+	require.NoError(t, err)
+
+	bids, err = session.GetOffersByAssets(ctx, "USD", usdIssuer, "XLM", "native")
+	require.NoError(t, err)
+	assert.Empty(t, bids)
+}
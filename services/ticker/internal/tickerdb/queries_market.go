@@ -137,7 +137,12 @@ SELECT
 	COALESCE(os.highest_bid, 0.0) as highest_bid,
 	COALESCE(os.num_asks, 0) as num_asks,
 	COALESCE(os.ask_volume, 0.0) as ask_volume,
-	COALESCE(os.lowest_ask, 0.0) as lowest_ask
+	COALESCE(os.lowest_ask, 0.0) as lowest_ask,
+	COALESCE(os.bid_depth_2pct, 0.0) as bid_depth_2pct,
+	COALESCE(os.ask_depth_2pct, 0.0) as ask_depth_2pct,
+
+	COALESCE(vwap_24h, 0.0) as vwap_24h,
+	COALESCE(vwap_7d, 0.0) as vwap_7d
 FROM (
 	SELECT
 			-- All valid trades for 24h period
@@ -148,6 +153,7 @@ FROM (
 			) as trade_pair_name,
 			sum(t.base_amount) AS base_volume_24h,
 			sum(t.counter_amount) AS counter_volume_24h,
+			sum(t.counter_amount) / NULLIF(sum(t.base_amount), 0.0) AS vwap_24h,
 			count(t.base_amount) AS trade_count_24h,
 			max(t.price) AS highest_price_24h,
 			min(t.price) AS lowest_price_24h,
@@ -172,6 +178,7 @@ FROM (
 			) as trade_pair_name,
 			sum(t.base_amount) AS base_volume_7d,
 			sum(t.counter_amount) AS counter_volume_7d,
+			sum(t.counter_amount) / NULLIF(sum(t.base_amount), 0.0) AS vwap_7d,
 			count(t.base_amount) AS trade_count_7d,
 			max(t.price) AS highest_price_7d,
 			min(t.price) AS lowest_price_7d,
@@ -205,6 +212,7 @@ SELECT
 	cAsset.type as counter_asset_type,
 	sum(t.base_amount) AS base_volume,
 	sum(t.counter_amount) AS counter_volume,
+	COALESCE(sum(t.counter_amount) / NULLIF(sum(t.base_amount), 0.0), 0.0) AS vwap,
 	count(t.base_amount) AS trade_count,
 	max(t.price) AS highest_price,
 	min(t.price) AS lowest_price,
@@ -219,7 +227,9 @@ SELECT
 	COALESCE((array_agg(os.highest_bid))[1], 0.0) AS highest_bid,
 	COALESCE((array_agg(os.num_asks))[1], 0) AS num_asks,
 	COALESCE((array_agg(os.ask_volume))[1], 0.0) AS ask_volume,
-	COALESCE((array_agg(os.lowest_ask))[1], 0.0) AS lowest_ask
+	COALESCE((array_agg(os.lowest_ask))[1], 0.0) AS lowest_ask,
+	COALESCE((array_agg(os.bid_depth_2pct))[1], 0.0) AS bid_depth_2pct,
+	COALESCE((array_agg(os.ask_depth_2pct))[1], 0.0) AS ask_depth_2pct
 FROM trades AS t
 	LEFT JOIN orderbook_stats AS os ON t.base_asset_id = os.base_asset_id AND t.counter_asset_id = os.counter_asset_id
 	JOIN assets AS bAsset ON t.base_asset_id = bAsset.id
@@ -233,6 +243,7 @@ SELECT
 	t1.trade_pair_name,
 	t1.base_volume,
 	t1.counter_volume,
+	t1.vwap,
 	t1.trade_count,
 	t1.highest_price,
 	t1.lowest_price,
@@ -249,7 +260,9 @@ SELECT
 	COALESCE(aob.highest_bid, 0.0) AS highest_bid,
 	COALESCE(aob.num_asks, 0) AS num_asks,
 	COALESCE(aob.ask_volume, 0.0) AS ask_volume,
-	COALESCE(aob.lowest_ask, 0.0) AS lowest_ask
+	COALESCE(aob.lowest_ask, 0.0) AS lowest_ask,
+	COALESCE(aob.bid_depth_2pct, 0.0) AS bid_depth_2pct,
+	COALESCE(aob.ask_depth_2pct, 0.0) AS ask_depth_2pct
 FROM (
 	SELECT
 		concat(
@@ -259,6 +272,7 @@ FROM (
 		) as trade_pair_name,
 		sum(t.base_amount) AS base_volume,
 		sum(t.counter_amount) AS counter_volume,
+		COALESCE(sum(t.counter_amount) / NULLIF(sum(t.base_amount), 0.0), 0.0) AS vwap,
 		count(t.base_amount) AS trade_count,
 		max(t.price) AS highest_price,
 		min(t.price) AS lowest_price,
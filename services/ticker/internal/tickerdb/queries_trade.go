@@ -80,10 +80,50 @@ func performInsertTrades(ctx context.Context, s *TickerSession, trades []Trade)
 		}
 	}
 
-	qs := "INSERT INTO trades (" + dbFieldsString + ")"
+	// Trades and the candles they belong to are written in a single
+	// statement, so that only newly inserted trades are added to candles.
+	qs := "WITH inserted AS ("
+	qs += "INSERT INTO trades (" + dbFieldsString + ")"
 	qs += " VALUES " + placeholders
-	qs += " ON CONFLICT ON CONSTRAINT trades_aurora_id_key DO NOTHING;"
+	qs += " ON CONFLICT ON CONSTRAINT trades_aurora_id_key DO NOTHING"
+	qs += " RETURNING *) "
+	qs += upsertCandlesQuery
 
 	_, err = s.ExecRaw(ctx, qs, dbValues...)
 	return
 }
+
+// upsertCandlesQuery adds the trades of the "inserted" common table
+// expression to the candles of every resolution.
+var upsertCandlesQuery = `
+INSERT INTO candles
+SELECT
+	t.base_asset_id,
+	t.counter_asset_id,
+	r.resolution,
+	to_timestamp(floor(extract(epoch FROM t.ledger_close_time) / r.resolution) * r.resolution) AS open_time,
+	(array_agg(t.price ORDER BY t.ledger_close_time ASC, t.id ASC))[1] AS open,
+	max(t.price) AS high,
+	min(t.price) AS low,
+	(array_agg(t.price ORDER BY t.ledger_close_time DESC, t.id DESC))[1] AS close,
+	sum(t.base_amount) AS base_volume,
+	sum(t.counter_amount) AS counter_volume,
+	count(*) AS trade_count,
+	min(t.ledger_close_time) AS first_trade_time,
+	max(t.ledger_close_time) AS last_trade_time
+FROM inserted AS t
+	CROSS JOIN (VALUES ` + candleResolutionValues() + `) AS r (resolution)
+GROUP BY t.base_asset_id, t.counter_asset_id, r.resolution, open_time
+ON CONFLICT (base_asset_id, counter_asset_id, resolution, open_time) DO UPDATE SET
+	open = CASE WHEN EXCLUDED.first_trade_time < candles.first_trade_time
+		THEN EXCLUDED.open ELSE candles.open END,
+	high = GREATEST(candles.high, EXCLUDED.high),
+	low = LEAST(candles.low, EXCLUDED.low),
+	close = CASE WHEN EXCLUDED.last_trade_time >= candles.last_trade_time
+		THEN EXCLUDED.close ELSE candles.close END,
+	base_volume = candles.base_volume + EXCLUDED.base_volume,
+	counter_volume = candles.counter_volume + EXCLUDED.counter_volume,
+	trade_count = candles.trade_count + EXCLUDED.trade_count,
+	first_trade_time = LEAST(candles.first_trade_time, EXCLUDED.first_trade_time),
+	last_trade_time = GREATEST(candles.last_trade_time, EXCLUDED.last_trade_time);
+`
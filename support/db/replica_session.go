@@ -0,0 +1,453 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	go_errors "errors"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/shantanu-hashcash/go/support/errors"
+)
+
+// ReadFromPrimaryCtxKey can be set to true on a context passed to the read
+// methods of a ReplicaSession to force them to run on the primary, e.g. when
+// a request must observe a write it has just made outside of a transaction.
+var ReadFromPrimaryCtxKey = CtxKey("read_from_primary")
+
+// ReplicaLagProbe measures how far behind the primary a replica is.
+type ReplicaLagProbe func(ctx context.Context, primary, replica SessionInterface) (time.Duration, error)
+
+// PostgresReplayLagProbe is the default ReplicaLagProbe. It reports zero when
+// the replica is not in recovery or has replayed the primary's current WAL
+// position (so that an idle primary does not make its replicas look stale),
+// and otherwise the time since the last transaction the replica replayed. A
+// replica that has stopped receiving WAL is therefore reported as lagging as
+// soon as the primary moves on, even though it has replayed everything it
+// received.
+func PostgresReplayLagProbe(ctx context.Context, primary, replica SessionInterface) (time.Duration, error) {
+	var primaryLSN string
+	err := primary.GetRaw(ctx, &primaryLSN, `SELECT pg_current_wal_lsn()::text`)
+	if err != nil {
+		return 0, errors.Wrap(err, "reading primary WAL position")
+	}
+
+	var seconds sql.NullFloat64
+	err = replica.GetRaw(ctx, &seconds, `SELECT CASE
+		WHEN NOT pg_is_in_recovery() THEN 0
+		WHEN pg_last_wal_replay_lsn() >= $1::pg_lsn THEN 0
+		ELSE EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp())
+	END`, primaryLSN)
+	if err != nil {
+		return 0, err
+	}
+	if !seconds.Valid {
+		return 0, errors.New("replica is behind the primary and has not replayed any transaction")
+	}
+	return time.Duration(seconds.Float64 * float64(time.Second)), nil
+}
+
+// ReplicaConfig configures how a ReplicaSession picks replicas.
+type ReplicaConfig struct {
+	// MaxStaleness is the largest lag at which a replica still serves reads.
+	MaxStaleness time.Duration
+	// ProbeInterval is how often replica lag is measured, 1s by default.
+	ProbeInterval time.Duration
+	// ProbeTimeout bounds a single probe, ProbeInterval by default.
+	ProbeTimeout time.Duration
+	// LagProbe measures replica lag, PostgresReplayLagProbe by default.
+	LagProbe ReplicaLagProbe
+}
+
+// ReplicaSession is a SessionInterface which sends reads (Get, Select, Query
+// and their raw variants) to a replica whose last measured lag is within
+// MaxStaleness, and everything else to the primary. Reads also go to the
+// primary when ReadFromPrimaryCtxKey is set, when no replica is fresh enough,
+// and when a replica fails with a connection error or a conflict with
+// recovery, in which case the replica is considered stale until it is probed
+// successfully again.
+//
+// A read-only transaction (BeginTx with ReadOnly set) is pinned to a fresh
+// replica, or to the primary under the same conditions as reads, and every
+// statement runs in it until it is committed or rolled back. All other
+// transactions run on the primary.
+//
+// Like Session, ReplicaSession is not concurrency safe; use Clone to get a
+// session for each goroutine. Clones share the replica lag measurements.
+type ReplicaSession struct {
+	Primary SessionInterface
+
+	replicas []SessionInterface
+	pool     *replicaPool
+
+	// tx is the session the current transaction runs on and txReplica the
+	// index of its replica, or -1 if it runs on the primary.
+	tx        SessionInterface
+	txReplica int
+}
+
+var _ SessionInterface = (*ReplicaSession)(nil)
+
+// replicaPool holds the state shared by a ReplicaSession and its clones.
+type replicaPool struct {
+	config ReplicaConfig
+
+	lock  sync.RWMutex
+	fresh []bool
+	lag   []time.Duration
+	next  uint32
+
+	closeChan chan struct{}
+	closeOnce sync.Once
+}
+
+// NewReplicaSession returns a session routing reads to replicas. It starts
+// probing the replicas' lag right away; until a replica has been probed
+// successfully it does not serve reads. Close stops the probing.
+func NewReplicaSession(primary SessionInterface, replicas []SessionInterface, config ReplicaConfig) *ReplicaSession {
+	if config.ProbeInterval <= 0 {
+		config.ProbeInterval = time.Second
+	}
+	if config.ProbeTimeout <= 0 {
+		config.ProbeTimeout = config.ProbeInterval
+	}
+	if config.LagProbe == nil {
+		config.LagProbe = PostgresReplayLagProbe
+	}
+
+	pool := &replicaPool{
+		config:    config,
+		fresh:     make([]bool, len(replicas)),
+		lag:       make([]time.Duration, len(replicas)),
+		closeChan: make(chan struct{}),
+	}
+	if len(replicas) > 0 {
+		pool.start(primary, replicas)
+	}
+
+	return &ReplicaSession{
+		Primary:  primary,
+		replicas: replicas,
+		pool:     pool,
+	}
+}
+
+func (p *replicaPool) start(primary SessionInterface, replicas []SessionInterface) {
+	// sessions must be cloned because they will be used concurrently in a
+	// separate go routine
+	primary = primary.Clone()
+	sessions := make([]SessionInterface, len(replicas))
+	for i, replica := range replicas {
+		sessions[i] = replica.Clone()
+	}
+
+	go func() {
+		ticker := time.NewTicker(p.config.ProbeInterval)
+		defer ticker.Stop()
+		defer func() {
+			primary.Close()
+			for _, session := range sessions {
+				session.Close()
+			}
+		}()
+
+		for {
+			for i, session := range sessions {
+				p.probe(i, primary, session)
+			}
+
+			select {
+			case <-ticker.C:
+			case <-p.closeChan:
+				return
+			}
+		}
+	}()
+}
+
+func (p *replicaPool) probe(i int, primary, session SessionInterface) {
+	ctx, cancel := context.WithTimeout(context.Background(), p.config.ProbeTimeout)
+	defer cancel()
+
+	lag, err := p.config.LagProbe(ctx, primary, session)
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.lag[i] = lag
+	p.fresh[i] = err == nil && lag <= p.config.MaxStaleness
+}
+
+// pick returns the index of the next fresh replica, round-robin, or -1 if
+// there is none.
+func (p *replicaPool) pick() int {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	n := len(p.fresh)
+	if n == 0 {
+		return -1
+	}
+	start := int(atomic.AddUint32(&p.next, 1) % uint32(n))
+	for j := 0; j < n; j++ {
+		i := (start + j) % n
+		if p.fresh[i] {
+			return i
+		}
+	}
+	return -1
+}
+
+func (p *replicaPool) markStale(i int) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.fresh[i] = false
+}
+
+func (p *replicaPool) close() {
+	p.closeOnce.Do(func() {
+		close(p.closeChan)
+	})
+}
+
+// ReplicaLag returns the last measured lag of each replica and whether it is
+// currently serving reads.
+func (s *ReplicaSession) ReplicaLag() ([]time.Duration, []bool) {
+	s.pool.lock.RLock()
+	defer s.pool.lock.RUnlock()
+	return append([]time.Duration{}, s.pool.lag...), append([]bool{}, s.pool.fresh...)
+}
+
+// read runs fn on a fresh replica, falling back to the primary as described
+// on ReplicaSession. In a transaction fn runs on the transaction's session.
+func (s *ReplicaSession) read(ctx context.Context, fn func(SessionInterface) error) error {
+	if s.tx != nil {
+		err := fn(s.tx)
+		if s.txReplica >= 0 && isReplicaFailure(err) {
+			s.pool.markStale(s.txReplica)
+		}
+		return err
+	}
+	if s.Primary.GetTx() != nil {
+		return fn(s.Primary)
+	}
+
+	i := s.pickReplica(ctx)
+	if i < 0 {
+		return fn(s.Primary)
+	}
+
+	err := fn(s.replicas[i])
+	if isReplicaFailure(err) {
+		s.pool.markStale(i)
+		return fn(s.Primary)
+	}
+	return err
+}
+
+// pickReplica returns the index of the replica to read from, or -1 to read
+// from the primary.
+func (s *ReplicaSession) pickReplica(ctx context.Context) int {
+	if primary, ok := ctx.Value(&ReadFromPrimaryCtxKey).(bool); ok && primary {
+		return -1
+	}
+	return s.pool.pick()
+}
+
+// current returns the session the current transaction runs on, the primary
+// outside of transactions.
+func (s *ReplicaSession) current() SessionInterface {
+	if s.tx != nil {
+		return s.tx
+	}
+	return s.Primary
+}
+
+// isReplicaFailure returns true for errors after which a read is worth
+// retrying on the primary.
+func isReplicaFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	if go_errors.Is(err, ErrBadConnection) || go_errors.Is(err, ErrConflictWithRecovery) {
+		return true
+	}
+	var netErr *net.OpError
+	return go_errors.As(err, &netErr)
+}
+
+// Get runs `query` like Session.Get, on a replica if possible.
+func (s *ReplicaSession) Get(ctx context.Context, dest interface{}, query sq.Sqlizer) error {
+	return s.read(ctx, func(session SessionInterface) error {
+		return session.Get(ctx, dest, query)
+	})
+}
+
+// GetRaw runs `query` like Session.GetRaw, on a replica if possible.
+func (s *ReplicaSession) GetRaw(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return s.read(ctx, func(session SessionInterface) error {
+		return session.GetRaw(ctx, dest, query, args...)
+	})
+}
+
+// Select runs `query` like Session.Select, on a replica if possible.
+func (s *ReplicaSession) Select(ctx context.Context, dest interface{}, query sq.Sqlizer) error {
+	return s.read(ctx, func(session SessionInterface) error {
+		return session.Select(ctx, dest, query)
+	})
+}
+
+// SelectRaw runs `query` like Session.SelectRaw, on a replica if possible.
+func (s *ReplicaSession) SelectRaw(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return s.read(ctx, func(session SessionInterface) error {
+		return session.SelectRaw(ctx, dest, query, args...)
+	})
+}
+
+// Query runs `query` like Session.Query, on a replica if possible.
+func (s *ReplicaSession) Query(ctx context.Context, query sq.Sqlizer) (*Rows, error) {
+	var rows *Rows
+	err := s.read(ctx, func(session SessionInterface) error {
+		var err error
+		rows, err = session.Query(ctx, query)
+		return err
+	})
+	return rows, err
+}
+
+// QueryRaw runs `query` like Session.QueryRaw, on a replica if possible.
+func (s *ReplicaSession) QueryRaw(ctx context.Context, query string, args ...interface{}) (*Rows, error) {
+	var rows *Rows
+	err := s.read(ctx, func(session SessionInterface) error {
+		var err error
+		rows, err = session.QueryRaw(ctx, query, args...)
+		return err
+	})
+	return rows, err
+}
+
+// BeginTx begins a transaction, on a fresh replica if it is read-only.
+func (s *ReplicaSession) BeginTx(ctx context.Context, opts *sql.TxOptions) error {
+	if s.tx != nil {
+		return errors.New("already in transaction")
+	}
+
+	if opts != nil && opts.ReadOnly {
+		if i := s.pickReplica(ctx); i >= 0 {
+			err := s.replicas[i].BeginTx(ctx, opts)
+			if err == nil {
+				s.tx, s.txReplica = s.replicas[i], i
+				return nil
+			}
+			if !isReplicaFailure(err) {
+				return err
+			}
+			s.pool.markStale(i)
+		}
+	}
+
+	if err := s.Primary.BeginTx(ctx, opts); err != nil {
+		return err
+	}
+	s.tx, s.txReplica = s.Primary, -1
+	return nil
+}
+
+// Begin begins a transaction on the primary.
+func (s *ReplicaSession) Begin(ctx context.Context) error {
+	if s.tx != nil {
+		return errors.New("already in transaction")
+	}
+	if err := s.Primary.Begin(ctx); err != nil {
+		return err
+	}
+	s.tx, s.txReplica = s.Primary, -1
+	return nil
+}
+
+func (s *ReplicaSession) Rollback() error {
+	session := s.current()
+	s.tx = nil
+	return session.Rollback()
+}
+
+func (s *ReplicaSession) Commit() error {
+	session := s.current()
+	s.tx = nil
+	return session.Commit()
+}
+
+func (s *ReplicaSession) GetTx() *sqlx.Tx {
+	return s.current().GetTx()
+}
+
+func (s *ReplicaSession) GetTxOptions() *sql.TxOptions {
+	return s.current().GetTxOptions()
+}
+
+func (s *ReplicaSession) TruncateTables(ctx context.Context, tables []string) error {
+	return s.Primary.TruncateTables(ctx, tables)
+}
+
+// Clone clones the primary and replica sessions. The clone shares lag
+// measurements with s.
+func (s *ReplicaSession) Clone() SessionInterface {
+	replicas := make([]SessionInterface, len(s.replicas))
+	for i, replica := range s.replicas {
+		replicas[i] = replica.Clone()
+	}
+	return &ReplicaSession{
+		Primary:  s.Primary.Clone(),
+		replicas: replicas,
+		pool:     s.pool,
+	}
+}
+
+// Close stops probing replica lag and closes the primary and replica
+// sessions, which also closes them for all clones.
+func (s *ReplicaSession) Close() error {
+	s.pool.close()
+	err := s.Primary.Close()
+	for _, replica := range s.replicas {
+		if replicaErr := replica.Close(); err == nil {
+			err = replicaErr
+		}
+	}
+	return err
+}
+
+func (s *ReplicaSession) GetTable(name string) *Table {
+	return s.Primary.GetTable(name)
+}
+
+// Exec runs `query` like Session.Exec, in the current transaction if any.
+func (s *ReplicaSession) Exec(ctx context.Context, query sq.Sqlizer) (sql.Result, error) {
+	return s.current().Exec(ctx, query)
+}
+
+// ExecRaw runs `query` like Session.ExecRaw, in the current transaction if
+// any.
+func (s *ReplicaSession) ExecRaw(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return s.current().ExecRaw(ctx, query, args...)
+}
+
+func (s *ReplicaSession) NoRows(err error) bool {
+	return s.Primary.NoRows(err)
+}
+
+func (s *ReplicaSession) Ping(ctx context.Context, timeout time.Duration) error {
+	return s.Primary.Ping(ctx, timeout)
+}
+
+func (s *ReplicaSession) DeleteRange(
+	ctx context.Context,
+	start, end int64,
+	table string,
+	idCol string,
+) error {
+	return s.Primary.DeleteRange(ctx, start, end, table, idCol)
+}
@@ -0,0 +1,305 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shantanu-hashcash/go/support/errors"
+)
+
+type replicaSessionTest struct {
+	primary  *MockSession
+	replicas []*MockSession
+	session  *ReplicaSession
+}
+
+// newReplicaSessionTest creates a ReplicaSession over mock replicas whose
+// lag, as reported by the probe, is given by lags. A nil lag makes the probe
+// fail.
+func newReplicaSessionTest(t *testing.T, lags ...*time.Duration) *replicaSessionTest {
+	test := &replicaSessionTest{primary: &MockSession{}}
+	test.primary.On("GetTx").Return((*sqlx.Tx)(nil)).Maybe()
+
+	if len(lags) > 0 {
+		primaryProbeClone := &MockSession{}
+		test.primary.On("Clone").Return(primaryProbeClone).Once()
+		primaryProbeClone.On("Close").Return(nil).Maybe()
+	}
+
+	sessions := make([]SessionInterface, len(lags))
+	probed := map[SessionInterface]*time.Duration{}
+	for i, lag := range lags {
+		replica, probeClone := &MockSession{}, &MockSession{}
+		replica.On("Clone").Return(probeClone).Once()
+		probeClone.On("Close").Return(nil).Maybe()
+		probed[probeClone] = lag
+		test.replicas = append(test.replicas, replica)
+		sessions[i] = replica
+	}
+
+	test.session = NewReplicaSession(test.primary, sessions, ReplicaConfig{
+		MaxStaleness:  5 * time.Second,
+		ProbeInterval: time.Hour,
+		LagProbe: func(ctx context.Context, primary, replica SessionInterface) (time.Duration, error) {
+			if lag := probed[replica]; lag != nil {
+				return *lag, nil
+			}
+			return 0, errors.New("probe failed")
+		},
+	})
+
+	// wait for the first round of probes
+	require.Eventually(t, func() bool {
+		_, fresh := test.session.ReplicaLag()
+		for i := range fresh {
+			if fresh[i] != (lags[i] != nil && *lags[i] <= 5*time.Second) {
+				return false
+			}
+		}
+		return true
+	}, time.Second, time.Millisecond)
+	return test
+}
+
+func (test *replicaSessionTest) close(t *testing.T) {
+	test.primary.On("Close").Return(nil).Once()
+	for _, replica := range test.replicas {
+		replica.On("Close").Return(nil).Once()
+	}
+	require.NoError(t, test.session.Close())
+
+	test.primary.AssertExpectations(t)
+	for _, replica := range test.replicas {
+		replica.AssertExpectations(t)
+	}
+}
+
+func lag(d time.Duration) *time.Duration {
+	return &d
+}
+
+func TestReplicaSessionRoutesReadsToFreshReplicas(t *testing.T) {
+	test := newReplicaSessionTest(t, lag(time.Hour), nil, lag(time.Second))
+	defer test.close(t)
+	ctx := context.Background()
+
+	_, fresh := test.session.ReplicaLag()
+	assert.Equal(t, []bool{false, false, true}, fresh)
+
+	var dest int
+	test.replicas[2].On("GetRaw", ctx, &dest, "select 1", []interface{}(nil)).Return(nil).Twice()
+	require.NoError(t, test.session.GetRaw(ctx, &dest, "select 1"))
+	require.NoError(t, test.session.GetRaw(ctx, &dest, "select 1"))
+
+	test.primary.On("ExecRaw", ctx, "delete from t", []interface{}(nil)).Return(driver.RowsAffected(1), nil).Once()
+	_, err := test.session.ExecRaw(ctx, "delete from t")
+	require.NoError(t, err)
+}
+
+func TestReplicaSessionFallsBackToPrimary(t *testing.T) {
+	test := newReplicaSessionTest(t, lag(time.Minute))
+	defer test.close(t)
+	ctx := context.Background()
+
+	var dest []int
+	test.primary.On("SelectRaw", ctx, &dest, "select 1", []interface{}(nil)).Return(nil).Once()
+	require.NoError(t, test.session.SelectRaw(ctx, &dest, "select 1"))
+}
+
+func TestReplicaSessionReadsInTransactionFromPrimary(t *testing.T) {
+	test := newReplicaSessionTest(t, lag(0))
+	defer test.close(t)
+	ctx := context.Background()
+
+	primary := &MockSession{}
+	primary.On("GetTx").Return(&sqlx.Tx{})
+	test.session.Primary, test.primary = primary, primary
+
+	var dest int
+	primary.On("GetRaw", ctx, &dest, "select 1", []interface{}(nil)).Return(nil).Once()
+	require.NoError(t, test.session.GetRaw(ctx, &dest, "select 1"))
+}
+
+func TestReplicaSessionPinsReadOnlyTransactionsToReplica(t *testing.T) {
+	test := newReplicaSessionTest(t, lag(time.Hour), lag(0))
+	defer test.close(t)
+	ctx := context.Background()
+	opts := &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+
+	replica := test.replicas[1]
+	replica.On("BeginTx", ctx, opts).Return(nil).Once()
+	require.NoError(t, test.session.BeginTx(ctx, opts))
+
+	// every statement runs in the replica's transaction
+	var dest int
+	replica.On("GetRaw", ctx, &dest, "select 1", []interface{}(nil)).Return(nil).Twice()
+	replica.On("GetTxOptions").Return(opts).Once()
+	require.NoError(t, test.session.GetRaw(ctx, &dest, "select 1"))
+	require.NoError(t, test.session.GetRaw(ctx, &dest, "select 1"))
+	assert.Equal(t, opts, test.session.GetTxOptions())
+
+	// the transaction ends on the replica, later reads pick a replica again
+	replica.On("Rollback").Return(nil).Once()
+	require.NoError(t, test.session.Rollback())
+	replica.On("GetRaw", ctx, &dest, "select 2", []interface{}(nil)).Return(nil).Once()
+	require.NoError(t, test.session.GetRaw(ctx, &dest, "select 2"))
+}
+
+func TestReplicaSessionRunsTransactionsOnPrimary(t *testing.T) {
+	ctx := context.Background()
+	readOnly := &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+	for _, testCase := range []struct {
+		name string
+		ctx  context.Context
+		opts *sql.TxOptions
+		lag  time.Duration
+	}{
+		{name: "writing transaction", ctx: ctx, opts: &sql.TxOptions{Isolation: sql.LevelRepeatableRead}},
+		{name: "default options", ctx: ctx},
+		{name: "stale replica", ctx: ctx, opts: readOnly, lag: time.Minute},
+		{name: "read from primary", ctx: context.WithValue(ctx, &ReadFromPrimaryCtxKey, true), opts: readOnly},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			test := newReplicaSessionTest(t, lag(testCase.lag))
+			defer test.close(t)
+
+			test.primary.On("BeginTx", testCase.ctx, testCase.opts).Return(nil).Once()
+			require.NoError(t, test.session.BeginTx(testCase.ctx, testCase.opts))
+
+			var dest int
+			test.primary.On("GetRaw", testCase.ctx, &dest, "select 1", []interface{}(nil)).Return(nil).Once()
+			require.NoError(t, test.session.GetRaw(testCase.ctx, &dest, "select 1"))
+			test.primary.On("ExecRaw", testCase.ctx, "delete from t", []interface{}(nil)).
+				Return(driver.RowsAffected(1), nil).Once()
+			_, err := test.session.ExecRaw(testCase.ctx, "delete from t")
+			require.NoError(t, err)
+
+			test.primary.On("Commit").Return(nil).Once()
+			require.NoError(t, test.session.Commit())
+		})
+	}
+}
+
+func TestReplicaSessionReadOnlyTransactionFailover(t *testing.T) {
+	test := newReplicaSessionTest(t, lag(0))
+	defer test.close(t)
+	ctx := context.Background()
+	opts := &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+
+	test.replicas[0].On("BeginTx", ctx, opts).Return(ErrBadConnection).Once()
+	test.primary.On("BeginTx", ctx, opts).Return(nil).Once()
+	require.NoError(t, test.session.BeginTx(ctx, opts))
+
+	_, fresh := test.session.ReplicaLag()
+	assert.Equal(t, []bool{false}, fresh)
+
+	var dest int
+	test.primary.On("GetRaw", ctx, &dest, "select 1", []interface{}(nil)).Return(nil).Once()
+	require.NoError(t, test.session.GetRaw(ctx, &dest, "select 1"))
+	test.primary.On("Rollback").Return(nil).Once()
+	require.NoError(t, test.session.Rollback())
+}
+
+func TestReplicaSessionReadFromPrimaryCtxKey(t *testing.T) {
+	test := newReplicaSessionTest(t, lag(0))
+	defer test.close(t)
+	ctx := context.WithValue(context.Background(), &ReadFromPrimaryCtxKey, true)
+
+	var dest int
+	test.primary.On("GetRaw", ctx, &dest, "select 1", []interface{}(nil)).Return(nil).Once()
+	require.NoError(t, test.session.GetRaw(ctx, &dest, "select 1"))
+}
+
+func TestReplicaSessionFailover(t *testing.T) {
+	test := newReplicaSessionTest(t, lag(0))
+	defer test.close(t)
+	ctx := context.Background()
+
+	var dest int
+	test.replicas[0].On("GetRaw", ctx, &dest, "select 1", []interface{}(nil)).
+		Return(ErrConflictWithRecovery).Once()
+	test.primary.On("GetRaw", ctx, &dest, "select 1", []interface{}(nil)).Return(nil).Twice()
+	require.NoError(t, test.session.GetRaw(ctx, &dest, "select 1"))
+
+	// the replica is skipped until it is probed again
+	_, fresh := test.session.ReplicaLag()
+	assert.Equal(t, []bool{false}, fresh)
+	require.NoError(t, test.session.GetRaw(ctx, &dest, "select 1"))
+
+	// other errors are returned as they are, also by clones
+	replicaClone := &MockSession{}
+	test.primary.On("Clone").Return(test.primary).Once()
+	test.replicas[0].On("Clone").Return(replicaClone).Once()
+	clone := test.session.Clone()
+	test.session.pool.lock.Lock()
+	test.session.pool.fresh[0] = true
+	test.session.pool.lock.Unlock()
+	replicaClone.On("GetRaw", ctx, &dest, "select 1", []interface{}(nil)).Return(ErrTimeout).Once()
+	assert.Equal(t, ErrTimeout, clone.GetRaw(ctx, &dest, "select 1"))
+	replicaClone.AssertExpectations(t)
+}
+
+func TestReplicaSessionWithoutReplicas(t *testing.T) {
+	primary := &MockSession{}
+	session := NewReplicaSession(primary, nil, ReplicaConfig{})
+	ctx := context.Background()
+
+	var dest int
+	primary.On("GetTx").Return((*sqlx.Tx)(nil))
+	primary.On("Get", ctx, &dest, mock.Anything).Return(nil).Once()
+	primary.On("Close").Return(nil).Once()
+	require.NoError(t, session.Get(ctx, &dest, nil))
+	require.NoError(t, session.Close())
+	primary.AssertExpectations(t)
+}
+
+func TestPostgresReplayLagProbe(t *testing.T) {
+	ctx := context.Background()
+	for _, testCase := range []struct {
+		name    string
+		seconds sql.NullFloat64
+		lag     time.Duration
+		err     string
+	}{
+		{name: "caught up", seconds: sql.NullFloat64{Float64: 0, Valid: true}, lag: 0},
+		{name: "lagging", seconds: sql.NullFloat64{Float64: 2.5, Valid: true}, lag: 2500 * time.Millisecond},
+		{name: "nothing replayed", err: "replica is behind the primary and has not replayed any transaction"},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			primary, replica := &MockSession{}, &MockSession{}
+			primary.On("GetRaw", ctx, mock.Anything, "SELECT pg_current_wal_lsn()::text", []interface{}(nil)).
+				Run(func(args mock.Arguments) {
+					*args.Get(1).(*string) = "0/3000060"
+				}).Return(nil).Once()
+			// the replica is compared against the primary's WAL position
+			// rather than only against what it has received itself
+			replica.On("GetRaw", ctx, mock.Anything, mock.Anything, []interface{}{"0/3000060"}).
+				Run(func(args mock.Arguments) {
+					*args.Get(1).(*sql.NullFloat64) = testCase.seconds
+				}).Return(nil).Once()
+
+			lag, err := PostgresReplayLagProbe(ctx, primary, replica)
+			if testCase.err != "" {
+				assert.EqualError(t, err, testCase.err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, testCase.lag, lag)
+			}
+			primary.AssertExpectations(t)
+			replica.AssertExpectations(t)
+		})
+	}
+
+	primary := &MockSession{}
+	primary.On("GetRaw", ctx, mock.Anything, mock.Anything, []interface{}(nil)).Return(ErrBadConnection).Once()
+	_, err := PostgresReplayLagProbe(ctx, primary, &MockSession{})
+	assert.ErrorIs(t, err, ErrBadConnection)
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/shantanu-hashcash/go/support/errors"
+	slog "github.com/shantanu-hashcash/go/support/log"
+	cmp "github.com/shantanu-hashcash/go/tools/horizon-cmp/internal"
+	"github.com/spf13/cobra"
+)
+
+var (
+	corpusFile    string
+	pathsFile     string
+	ignoredFields []string
+)
+
+var recordCmd = &cobra.Command{
+	Use:   "record",
+	Short: "records the responses of the base aurora server to a corpus file",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runRecord(cmd); err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+	},
+}
+
+var replayCmd = &cobra.Command{
+	Use:   "replay",
+	Short: "compares the responses of the test aurora server with a corpus file",
+	Run: func(cmd *cobra.Command, args []string) {
+		diffs, err := runReplay(cmd)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+		if diffs > 0 {
+			log.Errorf("%d responses differ from the corpus", diffs)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{recordCmd, replayCmd} {
+		cmd.Flags().StringVar(&corpusFile, "corpus", "", "corpus file, one JSON request/response pair per line")
+		cmd.MarkFlagRequired("corpus")
+	}
+	recordCmd.Flags().StringVar(&pathsFile, "paths-file", "", "file with the paths to record, one per line (defaults to the crawler's initial paths)")
+	recordCmd.Flags().IntVar(&requestsPerSecond, "rps", 1, "Requests per second")
+	replayCmd.Flags().StringSliceVar(&ignoredFields, "ignore-field", nil, "name of a JSON field to ignore in addition to the default volatile fields, may be repeated")
+
+	rootCmd.AddCommand(recordCmd)
+	rootCmd.AddCommand(replayCmd)
+}
+
+func runRecord(cmd *cobra.Command) error {
+	if auroraBase == "" {
+		cmd.Help()
+		return errors.New("--base param is required")
+	}
+
+	recordPaths := initPaths
+	if pathsFile != "" {
+		var err error
+		if recordPaths, err = readPathsFile(pathsFile); err != nil {
+			return err
+		}
+	}
+
+	file, err := os.Create(corpusFile)
+	if err != nil {
+		return errors.Wrap(err, "could not create corpus file")
+	}
+	defer file.Close()
+
+	writer := cmp.NewCorpusWriter(file)
+	client := cmp.NewHTTPClient()
+	for _, path := range recordPaths {
+		time.Sleep(time.Second / time.Duration(requestsPerSecond))
+		entry, err := cmp.Fetch(client, auroraBase, path)
+		if err != nil {
+			return err
+		}
+		if err := writer.Write(entry); err != nil {
+			return err
+		}
+		log.WithField("status_code", entry.StatusCode).Info(path)
+	}
+
+	log.WithFields(slog.F{
+		"base":    auroraBase,
+		"corpus":  corpusFile,
+		"entries": len(recordPaths),
+	}).Info("Corpus recorded")
+	return nil
+}
+
+func runReplay(cmd *cobra.Command) (int, error) {
+	if auroraTest == "" {
+		cmd.Help()
+		return 0, errors.New("--test param is required")
+	}
+
+	file, err := os.Open(corpusFile)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not open corpus file")
+	}
+	defer file.Close()
+
+	entries, err := cmp.ReadCorpus(file)
+	if err != nil {
+		return 0, err
+	}
+
+	pwd, err := os.Getwd()
+	if err != nil {
+		return 0, err
+	}
+	outputDir := fmt.Sprintf("%s/aurora-cmp-diff/%s", pwd, time.Now().Format(timeFormat))
+
+	normalizer := cmp.NewNormalizer(ignoredFields...)
+	client := cmp.NewHTTPClient()
+	diffs := 0
+	for _, recorded := range entries {
+		replayed, err := cmp.Fetch(client, auroraTest, recorded.Path)
+		if err != nil {
+			return diffs, err
+		}
+
+		a, b := normalizer.Compare(recorded, replayed)
+		localLog := log.WithFields(slog.F{
+			"status_code": b.StatusCode,
+			"size_corpus": a.Size(),
+			"size_test":   b.Size(),
+		})
+		if a.Equal(b) {
+			localLog.Info(recorded.Path)
+			continue
+		}
+
+		if diffs == 0 {
+			if err := os.MkdirAll(outputDir, 0744); err != nil {
+				return diffs, err
+			}
+		}
+		diffs++
+		a.SaveDiff(outputDir, b)
+		localLog.Error("DIFF " + recorded.Path)
+	}
+
+	log.WithFields(slog.F{
+		"test":       auroraTest,
+		"corpus":     corpusFile,
+		"entries":    len(entries),
+		"diffs":      diffs,
+		"output_dir": outputDir,
+	}).Info("Corpus replayed")
+	return diffs, nil
+}
+
+func readPathsFile(name string) ([]string, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open paths file")
+	}
+	defer file.Close()
+
+	var result []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		path := strings.TrimSpace(scanner.Text())
+		if path == "" || strings.HasPrefix(path, "#") {
+			continue
+		}
+		result = append(result, path)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "could not read paths file")
+	}
+	return result, nil
+}
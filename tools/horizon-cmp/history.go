@@ -10,7 +10,7 @@ import (
 
 	"github.com/spf13/cobra"
 	slog "github.com/shantanu-hashcash/go/support/log"
-	cmp "github.com/shantanu-hashcash/go/tools/aurora-cmp/internal"
+	cmp "github.com/shantanu-hashcash/go/tools/horizon-cmp/internal"
 )
 
 var (
@@ -0,0 +1,188 @@
+package cmp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/shantanu-hashcash/go/support/errors"
+)
+
+// ignoredPlaceholder replaces the values of ignored fields. The fields are
+// kept so that removing one is still reported as a diff.
+const ignoredPlaceholder = "<ignored>"
+
+// DefaultIgnoredFields are the names of JSON fields which change between
+// two runs of Aurora over the same ledgers: links containing the server's
+// domain and ingestion progress. Ledger timestamps such as `closed_at` are
+// part of the history and are compared.
+var DefaultIgnoredFields = []string{
+	"_links",
+	"latest_ledger",
+	"history_latest_ledger",
+	"history_latest_ledger_closed_at",
+	"history_elder_ledger",
+	"core_latest_ledger",
+	"ingest_latest_ledger",
+}
+
+// Entry is a request/response pair recorded from an Aurora server.
+type Entry struct {
+	Path       string `json:"path"`
+	StatusCode int    `json:"status_code"`
+	// Domain is the server the entry was recorded from. It is removed from
+	// the body before comparing.
+	Domain string `json:"domain"`
+	Body   string `json:"body"`
+}
+
+// Fetch sends a GET request for path to the Aurora server at domain and
+// records the response.
+func Fetch(client *http.Client, domain, path string) (Entry, error) {
+	resp, err := client.Get(domain + path)
+	if err != nil {
+		return Entry{}, errors.Wrapf(err, "could not get %s", path)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return Entry{}, errors.Wrapf(err, "could not read response of %s", path)
+	}
+
+	return Entry{
+		Path:       path,
+		StatusCode: resp.StatusCode,
+		Domain:     domain,
+		Body:       string(body),
+	}, nil
+}
+
+// NewHTTPClient returns the client used to record and replay a corpus.
+func NewHTTPClient() *http.Client {
+	return &http.Client{Timeout: time.Minute}
+}
+
+// CorpusWriter writes entries to a corpus, one JSON object per line.
+type CorpusWriter struct {
+	encoder *json.Encoder
+}
+
+// NewCorpusWriter returns a CorpusWriter writing to w.
+func NewCorpusWriter(w io.Writer) *CorpusWriter {
+	return &CorpusWriter{encoder: json.NewEncoder(w)}
+}
+
+// Write appends an entry to the corpus.
+func (c *CorpusWriter) Write(entry Entry) error {
+	return errors.Wrap(c.encoder.Encode(entry), "could not write corpus entry")
+}
+
+// ReadCorpus reads the entries of a corpus written by CorpusWriter.
+func ReadCorpus(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+	scanner := bufio.NewScanner(r)
+	// Response bodies can be much larger than the default token size.
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, errors.Wrapf(err, "invalid corpus entry at line %d", line)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "could not read corpus")
+	}
+	return entries, nil
+}
+
+// Normalizer removes the parts of responses which are expected to differ
+// between two Aurora servers serving the same ledgers.
+type Normalizer struct {
+	ignoredFields map[string]bool
+}
+
+// NewNormalizer returns a Normalizer ignoring DefaultIgnoredFields and the
+// given extra fields.
+func NewNormalizer(extraIgnoredFields ...string) *Normalizer {
+	n := &Normalizer{ignoredFields: map[string]bool{}}
+	for _, field := range DefaultIgnoredFields {
+		n.ignoredFields[field] = true
+	}
+	for _, field := range extraIgnoredFields {
+		n.ignoredFields[field] = true
+	}
+	return n
+}
+
+// Normalize returns the status code and body of entry with the values of
+// ignored fields replaced and the server's domain removed. JSON bodies are
+// indented with sorted keys so that diffs are readable and independent of
+// the field order.
+func (n *Normalizer) Normalize(entry Entry) string {
+	body := entry.Body
+
+	decoder := json.NewDecoder(strings.NewReader(body))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err == nil && !decoder.More() {
+		var indented bytes.Buffer
+		encoder := json.NewEncoder(&indented)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(n.normalizeValue(value)); err == nil {
+			body = indented.String()
+		}
+	}
+
+	if entry.Domain != "" {
+		body = strings.Replace(body, entry.Domain, "", -1)
+	}
+	return fmt.Sprintf("%d\n%s", entry.StatusCode, body)
+}
+
+func (n *Normalizer) normalizeValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if n.ignoredFields[key] {
+				v[key] = ignoredPlaceholder
+			} else {
+				v[key] = n.normalizeValue(field)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = n.normalizeValue(item)
+		}
+	}
+	return value
+}
+
+// Compare returns the normalized recorded and replayed responses as a pair
+// of Response which can be passed to Response.SaveDiff.
+func (n *Normalizer) Compare(recorded, replayed Entry) (*Response, *Response) {
+	return n.response(recorded), n.response(replayed)
+}
+
+func (n *Normalizer) response(entry Entry) *Response {
+	return &Response{
+		Domain:         entry.Domain,
+		Path:           entry.Path,
+		StatusCode:     entry.StatusCode,
+		Body:           entry.Body,
+		NormalizedBody: n.Normalize(entry),
+	}
+}
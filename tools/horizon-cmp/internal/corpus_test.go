@@ -0,0 +1,102 @@
+package cmp
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCorpusRoundTrip(t *testing.T) {
+	var ledger int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ledger++
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+		fmt.Fprintf(w, `{"_links":{"self":{"href":"http://%s%s"}},"latest_ledger":%d,"amount":"10.0000000"}`,
+			r.Host, r.URL.Path, ledger)
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient()
+	var buf bytes.Buffer
+	writer := NewCorpusWriter(&buf)
+	for _, path := range []string{"/ledgers/1", "/missing"} {
+		entry, err := Fetch(client, server.URL, path)
+		require.NoError(t, err)
+		require.NoError(t, writer.Write(entry))
+	}
+
+	entries, err := ReadCorpus(&buf)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "/ledgers/1", entries[0].Path)
+	assert.Equal(t, http.StatusOK, entries[0].StatusCode)
+	assert.Equal(t, http.StatusNotFound, entries[1].StatusCode)
+
+	normalizer := NewNormalizer()
+	for _, recorded := range entries {
+		replayed, err := Fetch(client, server.URL, recorded.Path)
+		require.NoError(t, err)
+		assert.NotEqual(t, recorded.Body, replayed.Body)
+		a, b := normalizer.Compare(recorded, replayed)
+		assert.True(t, a.Equal(b), recorded.Path)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	normalizer := NewNormalizer("paging_token")
+	entry := Entry{
+		Path:       "/accounts",
+		StatusCode: http.StatusOK,
+		Domain:     "https://aurora.example.org",
+		Body: `{"records":[{"id":"1","paging_token":"1","closed_at":"2020-01-01T00:00:00Z",` +
+			`"href":"https://aurora.example.org/accounts/1"}],"count":3}`,
+	}
+	assert.Equal(t, `200
+{
+  "count": 3,
+  "records": [
+    {
+      "closed_at": "2020-01-01T00:00:00Z",
+      "href": "/accounts/1",
+      "id": "1",
+      "paging_token": "<ignored>"
+    }
+  ]
+}
+`, normalizer.Normalize(entry))
+
+	entry.Body = "event: open\ndata: \"hello\"\n"
+	assert.Equal(t, "200\nevent: open\ndata: \"hello\"\n", normalizer.Normalize(entry))
+}
+
+func TestNormalizeReportsDiffs(t *testing.T) {
+	normalizer := NewNormalizer()
+	recorded := Entry{Path: "/ledgers/1", StatusCode: http.StatusOK, Body: `{"closed_at":"a","latest_ledger":1,"sequence":1}`}
+
+	for _, replayed := range []Entry{
+		{Path: "/ledgers/1", StatusCode: http.StatusOK, Body: `{"closed_at":"a","latest_ledger":1,"sequence":2}`},
+		{Path: "/ledgers/1", StatusCode: http.StatusOK, Body: `{"closed_at":"b","latest_ledger":1,"sequence":1}`},
+		{Path: "/ledgers/1", StatusCode: http.StatusOK, Body: `{"latest_ledger":1,"sequence":1}`},
+		{Path: "/ledgers/1", StatusCode: http.StatusNotFound, Body: `{"closed_at":"a","latest_ledger":1,"sequence":1}`},
+	} {
+		a, b := normalizer.Compare(recorded, replayed)
+		assert.False(t, a.Equal(b), replayed.Body)
+	}
+
+	a, b := normalizer.Compare(recorded, Entry{
+		Path: "/ledgers/1", StatusCode: http.StatusOK, Body: `{ "sequence": 1, "latest_ledger": 2, "closed_at": "a" }`,
+	})
+	assert.True(t, a.Equal(b))
+}
+
+func TestReadCorpusInvalidLine(t *testing.T) {
+	_, err := ReadCorpus(bytes.NewBufferString("{\"path\":\"/\"}\n\nnot json\n"))
+	assert.EqualError(t, err, "invalid corpus entry at line 3: invalid character 'o' in literal null (expecting 'u')")
+}
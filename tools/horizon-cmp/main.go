@@ -17,7 +17,7 @@ import (
 	protocol "github.com/shantanu-hashcash/go/protocols/aurora"
 	"github.com/shantanu-hashcash/go/support/errors"
 	slog "github.com/shantanu-hashcash/go/support/log"
-	cmp "github.com/shantanu-hashcash/go/tools/aurora-cmp/internal"
+	cmp "github.com/shantanu-hashcash/go/tools/horizon-cmp/internal"
 )
 
 // maxLevels defines the maximum number of levels deep the crawler
@@ -358,16 +358,16 @@ func addPathsFromResponse(a *cmp.Response, level int) {
 				prefix = "&"
 			}
 
-			paths <- cmp.Path{newPath + prefix + "include_failed=false", level, 0, false}
-			paths <- cmp.Path{newPath + prefix + "include_failed=false", level, 0, true}
+			paths <- cmp.Path{Path: newPath + prefix + "include_failed=false", Level: level, Stream: false}
+			paths <- cmp.Path{Path: newPath + prefix + "include_failed=false", Level: level, Stream: true}
 
-			paths <- cmp.Path{newPath + prefix + "include_failed=true", level, 0, false}
-			paths <- cmp.Path{newPath + prefix + "include_failed=true", level, 0, true}
+			paths <- cmp.Path{Path: newPath + prefix + "include_failed=true", Level: level, Stream: false}
+			paths <- cmp.Path{Path: newPath + prefix + "include_failed=true", Level: level, Stream: true}
 			continue
 		}
 
-		paths <- cmp.Path{newPath, level, 0, false}
-		paths <- cmp.Path{newPath, level, 0, true}
+		paths <- cmp.Path{Path: newPath, Level: level, Stream: false}
+		paths <- cmp.Path{Path: newPath, Level: level, Stream: true}
 	}
 
 	if len(paths) == 0 {
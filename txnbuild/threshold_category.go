@@ -0,0 +1,43 @@
+package txnbuild
+
+// ThresholdCategory is the threshold, low, medium or high, that the signers
+// of the source account of an operation must meet. See
+// https://developers.hcnet.org/docs/encyclopedia/signatures-multisig#thresholds
+type ThresholdCategory int
+
+// Threshold categories, from the least to the most demanding.
+const (
+	ThresholdCategoryLow ThresholdCategory = iota
+	ThresholdCategoryMedium
+	ThresholdCategoryHigh
+)
+
+func (c ThresholdCategory) String() string {
+	switch c {
+	case ThresholdCategoryLow:
+		return "low"
+	case ThresholdCategoryHigh:
+		return "high"
+	default:
+		return "medium"
+	}
+}
+
+// OperationThresholdCategory returns the threshold category required by op.
+func OperationThresholdCategory(op Operation) ThresholdCategory {
+	switch o := op.(type) {
+	case *AllowTrust, *SetTrustLineFlags, *BumpSequence,
+		*ClaimClaimableBalance, *Inflation, *ExtendFootprintTtl, *RestoreFootprint:
+		return ThresholdCategoryLow
+	case *AccountMerge:
+		return ThresholdCategoryHigh
+	case *SetOptions:
+		if o.MasterWeight != nil || o.LowThreshold != nil || o.MediumThreshold != nil ||
+			o.HighThreshold != nil || o.Signer != nil {
+			return ThresholdCategoryHigh
+		}
+		return ThresholdCategoryMedium
+	default:
+		return ThresholdCategoryMedium
+	}
+}
@@ -0,0 +1,25 @@
+package txnbuild
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperationThresholdCategory(t *testing.T) {
+	for _, testCase := range []struct {
+		op       Operation
+		expected ThresholdCategory
+	}{
+		{&BumpSequence{}, ThresholdCategoryLow},
+		{&SetTrustLineFlags{}, ThresholdCategoryLow},
+		{&Inflation{}, ThresholdCategoryLow},
+		{&Payment{}, ThresholdCategoryMedium},
+		{&SetOptions{HomeDomain: NewHomeDomain("example.com")}, ThresholdCategoryMedium},
+		{&SetOptions{Signer: &Signer{Address: "GA", Weight: 1}}, ThresholdCategoryHigh},
+		{&AccountMerge{}, ThresholdCategoryHigh},
+	} {
+		assert.Equal(t, testCase.expected, OperationThresholdCategory(testCase.op), "%T", testCase.op)
+	}
+	assert.Equal(t, "medium", ThresholdCategoryMedium.String())
+}